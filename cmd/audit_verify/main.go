@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/polygonid/sh-id-platform/internal/auditlog"
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+func main() {
+	identifier := flag.String("did", "", "verify only the credential log of this identity")
+	flag.Parse()
+
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Error(context.Background(), "cannot load config", "err", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(log.NewContext(context.Background(), cfg.Log.Level, cfg.Log.Mode, os.Stderr), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	storage, err := db.NewStorage(cfg.Database.URL)
+	if err != nil {
+		log.Error(ctx, "cannot connect to database", "err", err)
+		os.Exit(1)
+	}
+	defer func(storage *db.Storage) {
+		if err := storage.Close(); err != nil {
+			log.Error(ctx, "error closing database connection", "err", err)
+		}
+	}(storage)
+
+	report, err := auditlog.Verify(ctx, storage, *identifier)
+	if err != nil {
+		log.Error(ctx, "verifying the credential logs", "err", err)
+		os.Exit(1)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Error(ctx, "writing the report", "err", err)
+		os.Exit(1)
+	}
+	if !report.Ok() {
+		log.Error(ctx, "the credential logs were modified")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/polygonid/sh-id-platform/internal/backup"
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/providers/blockchain"
+	"github.com/polygonid/sh-id-platform/pkg/blockchain/eth"
+	client "github.com/polygonid/sh-id-platform/pkg/http"
+)
+
+const usage = `usage: backup <command> [flags]
+
+commands:
+  export  -out <file>                 write a consistent bundle of the issuer tables, key references and config manifest
+  restore -in <file> [-skip-verify]   load a bundle into an empty, migrated database and verify it
+  verify  -in <file>                  check keys and on chain states of an already restored bundle
+`
+
+var errVerificationFailed = errors.New("verification failed")
+
+func main() {
+	if len(os.Args) < 2 {
+		_, _ = fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Error(context.Background(), "cannot load config", "err", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(log.NewContext(context.Background(), cfg.Log.Level, cfg.Log.Mode, os.Stderr), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	outbound, err := client.NewTransport(cfg.Outbound.ProxyURL, cfg.Outbound.NoProxy, cfg.Outbound.CABundle)
+	if err != nil {
+		log.Error(ctx, "cannot configure the outbound http clients", "err", err)
+		os.Exit(1)
+	}
+
+	storage, err := db.NewStorage(cfg.Database.URL)
+	if err != nil {
+		log.Error(ctx, "cannot connect to database", "err", err)
+		os.Exit(1)
+	}
+	defer func(storage *db.Storage) {
+		if err := storage.Close(); err != nil {
+			log.Error(ctx, "error closing database connection", "err", err)
+		}
+	}(storage)
+
+	switch os.Args[1] {
+	case "export":
+		err = runExport(ctx, cfg, storage, os.Args[2:])
+	case "restore":
+		err = runRestore(ctx, cfg, storage, outbound, os.Args[2:])
+	case "verify":
+		err = runVerify(ctx, cfg, storage, outbound, os.Args[2:])
+	default:
+		_, _ = fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Error(ctx, "backup command failed", "command", os.Args[1], "err", err)
+		os.Exit(1)
+	}
+}
+
+func runExport(ctx context.Context, cfg *config.Configuration, storage *db.Storage, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	out := fs.String("out", "issuer-backup.json", "bundle file to write")
+	_ = fs.Parse(args)
+
+	keyStore, err := kms.NewFromConfig(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := backup.Export(ctx, storage, keyStore, backup.NewManifest(cfg))
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, raw, 0o600); err != nil {
+		return err
+	}
+	log.Info(ctx, "backup exported", "file", *out, "keys", len(bundle.Keys))
+	return nil
+}
+
+func runRestore(ctx context.Context, cfg *config.Configuration, storage *db.Storage, outbound *http.Transport, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	in := fs.String("in", "issuer-backup.json", "bundle file to read")
+	skipVerify := fs.Bool("skip-verify", false, "do not verify keys and on chain states after restoring")
+	_ = fs.Parse(args)
+
+	bundle, err := readBundle(*in)
+	if err != nil {
+		return err
+	}
+
+	if bundle.Manifest.ContractAddress != cfg.Ethereum.ContractAddress {
+		log.Warn(ctx, "bundle was taken against a different state contract", "bundle", bundle.Manifest.ContractAddress, "config", cfg.Ethereum.ContractAddress)
+	}
+
+	if err := backup.Restore(ctx, storage, bundle); err != nil {
+		return err
+	}
+	log.Info(ctx, "backup restored", "file", *in)
+
+	if *skipVerify {
+		return nil
+	}
+	return verify(ctx, cfg, storage, outbound, bundle)
+}
+
+func runVerify(ctx context.Context, cfg *config.Configuration, storage *db.Storage, outbound *http.Transport, args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	in := fs.String("in", "issuer-backup.json", "bundle file to read")
+	_ = fs.Parse(args)
+
+	bundle, err := readBundle(*in)
+	if err != nil {
+		return err
+	}
+	return verify(ctx, cfg, storage, outbound, bundle)
+}
+
+func verify(ctx context.Context, cfg *config.Configuration, storage *db.Storage, outbound *http.Transport, bundle *backup.Bundle) error {
+	keyStore, err := kms.NewFromConfig(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	commonClient, err := blockchain.Dial(ctx, cfg.Ethereum.URL, outbound)
+	if err != nil {
+		return fmt.Errorf("dialing ethereum node: %w", err)
+	}
+	defer commonClient.Close()
+
+	cl := eth.NewClient(commonClient, &eth.ClientConfig{
+		DefaultGasLimit:        cfg.Ethereum.DefaultGasLimit,
+		ConfirmationTimeout:    cfg.Ethereum.ConfirmationTimeout,
+		ConfirmationBlockCount: cfg.Ethereum.ConfirmationBlockCount,
+		ReceiptTimeout:         cfg.Ethereum.ReceiptTimeout,
+		MinGasPrice:            big.NewInt(int64(cfg.Ethereum.MinGasPrice)),
+		MaxGasPrice:            big.NewInt(int64(cfg.Ethereum.MaxGasPrice)),
+		RPCResponseTimeout:     cfg.Ethereum.RPCResponseTimeout,
+		WaitReceiptCycleTime:   cfg.Ethereum.WaitReceiptCycleTime,
+		WaitBlockCycleTime:     cfg.Ethereum.WaitBlockCycleTime,
+	})
+
+	report, err := backup.Verify(ctx, storage, keyStore, cl, common.HexToAddress(cfg.Ethereum.ContractAddress), bundle)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	if !report.Ok() {
+		return errVerificationFailed
+	}
+	return nil
+}
+
+func readBundle(path string) (*backup.Bundle, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &backup.Bundle{}
+	if err := json.Unmarshal(raw, bundle); err != nil {
+		return nil, fmt.Errorf("decoding bundle %s: %w", path, err)
+	}
+	return bundle, nil
+}
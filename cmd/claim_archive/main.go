@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/claimarchive"
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+func main() {
+	age := flag.Duration("age", 180*24*time.Hour, "how long the revoked and expired claims are kept before archiving them")
+	dryRun := flag.Bool("dry-run", false, "only report the claims to archive and their size, without moving them")
+	identifier := flag.String("did", "", "archive or restore only the claims of this identity")
+	batchSize := flag.Int("batch", claimarchive.DefaultBatchSize, "claims moved in each transaction")
+	restore := flag.Bool("restore", false, "move the archived claims back to the claims table")
+	claimID := flag.String("id", "", "with -restore, restore only this claim")
+	flag.Parse()
+
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Error(context.Background(), "cannot load config", "err", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(log.NewContext(context.Background(), cfg.Log.Level, cfg.Log.Mode, os.Stderr), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if *age < 0 {
+		log.Error(ctx, "the age cannot be negative", "age", *age)
+		os.Exit(2)
+	}
+	var id *uuid.UUID
+	if *claimID != "" {
+		parsed, err := uuid.Parse(*claimID)
+		if err != nil || !*restore {
+			log.Error(ctx, "-id must be a claim id and is only used with -restore", "id", *claimID)
+			os.Exit(2)
+		}
+		id = &parsed
+	}
+
+	storage, err := db.NewStorage(cfg.Database.URL)
+	if err != nil {
+		log.Error(ctx, "cannot connect to database", "err", err)
+		os.Exit(1)
+	}
+	defer func(storage *db.Storage) {
+		if err := storage.Close(); err != nil {
+			log.Error(ctx, "error closing database connection", "err", err)
+		}
+	}(storage)
+
+	var report *claimarchive.Report
+	if *restore {
+		report, err = claimarchive.Restore(ctx, storage, claimarchive.RestoreOptions{Identifier: *identifier, ID: id, BatchSize: *batchSize})
+	} else {
+		report, err = claimarchive.Archive(ctx, storage, claimarchive.Options{Age: *age, Identifier: *identifier, BatchSize: *batchSize, DryRun: *dryRun})
+	}
+	if err != nil {
+		log.Error(ctx, "archiving claims", "err", err, "restore", *restore)
+		os.Exit(1)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Error(ctx, "writing the report", "err", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/doctor"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	client "github.com/polygonid/sh-id-platform/pkg/http"
+
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	timeout := flag.Duration("timeout", 10*time.Second, "how long each check can take")
+	asJSON := flag.Bool("json", false, "print the report as json")
+	flag.Parse()
+
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Error(context.Background(), "cannot load config", "err", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(log.NewContext(context.Background(), cfg.Log.Level, cfg.Log.Mode, os.Stderr), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	outbound, err := client.NewTransport(cfg.Outbound.ProxyURL, cfg.Outbound.NoProxy, cfg.Outbound.CABundle)
+	if err != nil {
+		log.Error(ctx, "cannot configure the outbound http clients", "err", err)
+		os.Exit(1)
+	}
+
+	report := doctor.New(cfg, *timeout, outbound).Run(ctx)
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	} else {
+		err = report.Write(os.Stdout)
+	}
+	if err != nil {
+		log.Error(ctx, "writing the report", "err", err)
+		os.Exit(1)
+	}
+	if report.Failed() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultRequestTimeout = 30 * time.Second
+
+// apiError is returned when the issuer node answers with a non 2xx status code.
+type apiError struct {
+	Status int
+	Body   string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("issuer node responded with status %d: %s", e.Status, strings.TrimSpace(e.Body))
+}
+
+// apiClient is a thin http client for the issuer node admin API.
+type apiClient struct {
+	baseURL  string
+	user     string
+	password string
+	// token is sent as a bearer token instead of the basic auth credentials
+	token string
+	// issuerDID selects the issuer of the UI API requests, the default one of the node when empty
+	issuerDID string
+	http      *http.Client
+}
+
+func newAPIClient(baseURL, user, password string) *apiClient {
+	return &apiClient{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		user:     user,
+		password: password,
+		http:     &http.Client{Timeout: defaultRequestTimeout},
+	}
+}
+
+// withToken returns a client that authorizes its requests with the bearer token
+func (c *apiClient) withToken(token string) *apiClient {
+	authorized := *c
+	authorized.token = token
+	return &authorized
+}
+
+// forIssuer returns a client whose UI API requests are for the issuer did
+func (c *apiClient) forIssuer(did string) *apiClient {
+	issuer := *c
+	issuer.issuerDID = did
+	return &issuer
+}
+
+// do sends a request to the issuer node. If in is not nil it is sent as a json body. If out is not nil,
+// the response body is decoded into it.
+func (c *apiClient) do(ctx context.Context, method, path string, in any, out any) error {
+	var body io.Reader = http.NoBody
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encoding request body: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else if c.user != "" || c.password != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+	if c.issuerDID != "" {
+		req.Header.Set("X-Issuer-DID", c.issuerDID)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return &apiError{Status: resp.StatusCode, Body: string(raw)}
+	}
+
+	if out == nil || len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, out)
+}
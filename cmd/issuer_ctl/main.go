@@ -0,0 +1,742 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/event"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/redis"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+)
+
+const usage = `issuer-ctl is a command line tool to operate an issuer node.
+
+Usage:
+  issuer-ctl <command> <action> [flags]
+
+Commands:
+  identity create     Creates a new identity
+  identity list       Lists the identities managed by the node
+  schema import       Imports a schema into the issuer (UI API)
+  schema build        Generates a schema from its attributes, and registers or publishes it in IPFS (UI API)
+  schema list         Lists the imported schemas (UI API)
+  credential issue    Issues a new credential
+  credential get      Returns a credential
+  credential list     Lists the credentials of an identity
+  credential revoke   Revokes a credential by its revocation nonce
+  credential resign   Replaces the signed credentials broken by a change of their JSON-LD context
+  credential timing   Prints the time spent in each phase of the issuance of a credential
+  state publish       Publishes the identity state on chain
+  backup export       Exports all the credentials of an identity to a file
+  events tail         Prints the events published by the node as they arrive
+  metering export     Exports the billable operations per month to a file or a webhook
+  maintenance on      Rejects the requests that change the node until it is switched off
+  maintenance off     Switches the maintenance mode off
+  maintenance status  Prints the maintenance mode of the node
+  region status       Prints the role of the region of the node and the lease of the active region
+  region promote      Makes the region of the node the active one, after the active region failed
+  db explain          Prints the plans of the frequent queries and the indexes and vacuums they need
+  quota show          Prints the quotas of an identity and its usage
+  quota set           Replaces the quotas of an identity that override the ones of the node
+  keystore import     Imports a private key, like the publishing key, in the key store file (development only)
+  manifest export     Exports the identities, schemas, links, webhooks and policies of the node as a manifest
+  manifest apply      Changes the node to match a manifest
+
+Run 'issuer-ctl <command> <action> -h' to see the flags of each action.
+`
+
+type command func(ctx context.Context, cfg *config.Configuration, args []string) error
+
+var commands = map[string]command{
+	"identity create":    identityCreate,
+	"identity list":      identityList,
+	"schema import":      schemaImport,
+	"schema build":       schemaBuild,
+	"schema list":        schemaList,
+	"credential issue":   credentialIssue,
+	"credential get":     credentialGet,
+	"credential list":    credentialList,
+	"credential revoke":  credentialRevoke,
+	"credential resign":  credentialResign,
+	"credential timing":  credentialTiming,
+	"state publish":      statePublish,
+	"backup export":      backupExport,
+	"events tail":        eventsTail,
+	"metering export":    meteringExport,
+	"maintenance on":     maintenanceOn,
+	"maintenance off":    maintenanceOff,
+	"maintenance status": maintenanceStatus,
+	"region status":      regionStatus,
+	"region promote":     regionPromote,
+	"db explain":         dbExplain,
+	"quota show":         quotaShow,
+	"quota set":          quotaSet,
+	"keystore import":    keystoreImport,
+	"manifest export":    manifestExport,
+	"manifest apply":     manifestApply,
+}
+
+func main() {
+	if len(os.Args) < 3 {
+		_, _ = fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]+" "+os.Args[2]]
+	if !ok {
+		_, _ = fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1]+" "+os.Args[2], usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "cannot load config: %s\n", err)
+		os.Exit(1)
+	}
+
+	// Logs go to stderr, so the output of the commands can be piped to other tools.
+	ctx, cancel := signal.NotifyContext(log.NewContext(context.Background(), cfg.Log.Level, cfg.Log.Mode, os.Stderr), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := cmd(ctx, cfg, os.Args[3:]); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// connFlags are the connection flags shared by all the commands. Default values are taken from the node configuration.
+type connFlags struct {
+	apiURL     string
+	user       string
+	password   string
+	token      string
+	uiURL      string
+	uiUser     string
+	uiPassword string
+}
+
+func newFlagSet(name string, cfg *config.Configuration) (*flag.FlagSet, *connFlags) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	c := &connFlags{}
+	fs.StringVar(&c.apiURL, "api", cfg.ServerUrl, "issuer node API url")
+	fs.StringVar(&c.user, "user", cfg.HTTPBasicAuth.User, "issuer node API basic auth user")
+	fs.StringVar(&c.password, "password", cfg.HTTPBasicAuth.Password, "issuer node API basic auth password")
+	fs.StringVar(&c.token, "token", os.Getenv("ISSUER_API_TOKEN"), "bearer token of the identity provider for the issuer node API, replaces the basic auth credentials")
+	fs.StringVar(&c.uiURL, "ui-api", cfg.APIUI.ServerURL, "issuer node UI API url")
+	fs.StringVar(&c.uiUser, "ui-user", cfg.APIUI.APIUIAuth.User, "issuer node UI API basic auth user")
+	fs.StringVar(&c.uiPassword, "ui-password", cfg.APIUI.APIUIAuth.Password, "issuer node UI API basic auth password")
+	return fs, c
+}
+
+func (c *connFlags) api() *apiClient {
+	if c.token != "" {
+		return newAPIClient(c.apiURL, "", "").withToken(c.token)
+	}
+	return newAPIClient(c.apiURL, c.user, c.password)
+}
+
+func (c *connFlags) ui() *apiClient {
+	return newAPIClient(c.uiURL, c.uiUser, c.uiPassword)
+}
+
+func identityCreate(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("identity create", cfg)
+	method := fs.String("method", cfg.APIUI.IdentityMethod, "DID method")
+	blockchain := fs.String("blockchain", cfg.APIUI.IdentityBlockchain, "DID blockchain")
+	network := fs.String("network", cfg.APIUI.IdentityNetwork, "DID network")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	req := map[string]any{
+		"didMetadata": map[string]string{
+			"method":     *method,
+			"blockchain": *blockchain,
+			"network":    *network,
+		},
+	}
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodPost, "/v1/identities", req, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func identityList(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("identity list", cfg)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodGet, "/v1/identities", nil, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func schemaImport(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("schema import", cfg)
+	schemaURL := fs.String("url", "", "schema url (required)")
+	schemaType := fs.String("type", "", "schema type (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *schemaURL == "" || *schemaType == "" {
+		return fmt.Errorf("url and type flags are required")
+	}
+
+	req := map[string]string{"url": *schemaURL, "schemaType": *schemaType}
+	var resp json.RawMessage
+	if err := conn.ui().do(ctx, http.MethodPost, "/v1/schemas", req, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func schemaBuild(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("schema build", cfg)
+	schemaType := fs.String("type", "", "schema type (required)")
+	version := fs.String("version", "1.0.0", "schema version")
+	title := fs.String("title", "", "schema title")
+	description := fs.String("description", "", "schema description")
+	attributes := fs.String("attributes", "", `attributes as a json array like [{"name":"birthday","type":"date","required":true}]. Use @file to read them from a file (required)`)
+	register := fs.Bool("register", false, "create the schema in the registry of the node")
+	ipfs := fs.Bool("ipfs", false, "publish the schema in IPFS")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *schemaType == "" || *attributes == "" {
+		return fmt.Errorf("type and attributes flags are required")
+	}
+
+	var attrs []map[string]any
+	if err := readJSON(*attributes, &attrs); err != nil {
+		return fmt.Errorf("invalid attributes: %w", err)
+	}
+	req := map[string]any{
+		"type":       *schemaType,
+		"version":    *version,
+		"attributes": attrs,
+		"register":   *register,
+		"ipfs":       *ipfs,
+	}
+	if *title != "" {
+		req["title"] = *title
+	}
+	if *description != "" {
+		req["description"] = *description
+	}
+	var resp json.RawMessage
+	if err := conn.ui().do(ctx, http.MethodPost, "/v1/schemas/builder", req, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func schemaList(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("schema list", cfg)
+	query := fs.String("query", "", "filter schemas by this text")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path := "/v1/schemas"
+	if *query != "" {
+		path += "?query=" + url.QueryEscape(*query)
+	}
+	var resp json.RawMessage
+	if err := conn.ui().do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func credentialIssue(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("credential issue", cfg)
+	did := fs.String("did", cfg.APIUI.Issuer, "issuer DID")
+	schema := fs.String("schema", "", "credential schema url (required)")
+	typ := fs.String("type", "", "credential type (required)")
+	subject := fs.String("subject", "", "credential subject as a json object. Use @file to read it from a file (required)")
+	expiration := fs.Int64("expiration", 0, "credential expiration as a unix timestamp")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *did == "" || *schema == "" || *typ == "" || *subject == "" {
+		return fmt.Errorf("did, schema, type and subject flags are required")
+	}
+
+	credentialSubject, err := readSubject(*subject)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{
+		"credentialSchema":  *schema,
+		"type":              *typ,
+		"credentialSubject": credentialSubject,
+	}
+	if *expiration != 0 {
+		req["expiration"] = *expiration
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodPost, fmt.Sprintf("/v1/%s/claims", *did), req, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func credentialGet(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("credential get", cfg)
+	did := fs.String("did", cfg.APIUI.Issuer, "issuer DID")
+	id := fs.String("id", "", "credential id (required)")
+	dataModel := fs.String("data-model", "", "W3C data model of the credential (1.1|2.0)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *did == "" || *id == "" {
+		return fmt.Errorf("did and id flags are required")
+	}
+
+	path := fmt.Sprintf("/v1/%s/claims/%s", *did, *id)
+	if *dataModel != "" {
+		path += "?" + url.Values{"dataModel": {*dataModel}}.Encode()
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func credentialList(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("credential list", cfg)
+	did := fs.String("did", cfg.APIUI.Issuer, "issuer DID")
+	subject := fs.String("subject", "", "filter by subject DID")
+	schemaType := fs.String("schema-type", "", "filter by schema type")
+	revoked := fs.String("revoked", "", "filter by revocation status (true|false)")
+	dataModel := fs.String("data-model", "", "W3C data model of the credentials (1.1|2.0)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *did == "" {
+		return fmt.Errorf("did flag is required")
+	}
+
+	params := url.Values{}
+	if *subject != "" {
+		params.Set("subject", *subject)
+	}
+	if *schemaType != "" {
+		params.Set("schemaType", *schemaType)
+	}
+	if *revoked != "" {
+		params.Set("revoked", *revoked)
+	}
+	if *dataModel != "" {
+		params.Set("dataModel", *dataModel)
+	}
+	path := fmt.Sprintf("/v1/%s/claims", *did)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func credentialRevoke(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("credential revoke", cfg)
+	did := fs.String("did", cfg.APIUI.Issuer, "issuer DID")
+	nonce := fs.Uint64("nonce", 0, "revocation nonce of the credential (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *did == "" || *nonce == 0 {
+		return fmt.Errorf("did and nonce flags are required")
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodPost, fmt.Sprintf("/v1/%s/claims/revoke/%d", *did, *nonce), nil, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func credentialResign(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("credential resign", cfg)
+	did := fs.String("did", cfg.APIUI.Issuer, "issuer DID")
+	typ := fs.String("type", "", "only checks the credentials of this type")
+	dryRun := fs.Bool("dry-run", false, "only reports the drifted credentials, without replacing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *did == "" {
+		return fmt.Errorf("did flag is required")
+	}
+
+	req := map[string]any{"dryRun": *dryRun}
+	if *typ != "" {
+		req["type"] = *typ
+	}
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodPost, fmt.Sprintf("/v1/%s/claims/resign", *did), req, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func credentialTiming(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("credential timing", cfg)
+	did := fs.String("did", cfg.APIUI.Issuer, "issuer DID")
+	id := fs.String("id", "", "credential id (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *did == "" || *id == "" {
+		return fmt.Errorf("did and id flags are required")
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodGet, fmt.Sprintf("/v1/%s/claims/%s/timing", *did, *id), nil, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func statePublish(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("state publish", cfg)
+	did := fs.String("did", cfg.APIUI.Issuer, "issuer DID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *did == "" {
+		return fmt.Errorf("did flag is required")
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodPost, fmt.Sprintf("/v1/%s/state/publish", *did), nil, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func backupExport(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("backup export", cfg)
+	did := fs.String("did", cfg.APIUI.Issuer, "issuer DID")
+	out := fs.String("out", "", "output file. Defaults to stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *did == "" {
+		return fmt.Errorf("did flag is required")
+	}
+
+	var credentials json.RawMessage
+	if err := conn.api().do(ctx, http.MethodGet, fmt.Sprintf("/v1/%s/claims", *did), nil, &credentials); err != nil {
+		return err
+	}
+	backup := map[string]any{
+		"issuer":      *did,
+		"credentials": credentials,
+	}
+
+	if *out == "" {
+		return printJSON(os.Stdout, backup)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	return printJSON(f, backup)
+}
+
+func eventsTail(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs := flag.NewFlagSet("events tail", flag.ContinueOnError)
+	backend := fs.String("backend", cfg.Cache.Backend, "where the events are sent: redis or postgres")
+	redisURL := fs.String("redis", cfg.Cache.RedisUrl, "redis url")
+	databaseURL := fs.String("database", cfg.Database.URL, "database url of the postgres backend")
+	topics := fs.String("topics", strings.Join([]string{event.CreateCredentialEvent, event.CreateConnectionEvent}, ","), "comma separated list of topics")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var ps pubsub.Subscriber
+	if *backend == config.CachePostgres {
+		storage, err := db.NewStorage(*databaseURL)
+		if err != nil {
+			return fmt.Errorf("cannot connect to the database: %w", err)
+		}
+		defer func() { _ = storage.Close() }()
+		postgresPubSub := pubsub.NewPostgres(storage.Pgx)
+		postgresPubSub.WithLogger(log.Error)
+		ps = postgresPubSub
+	} else {
+		rdb, err := redis.Open(*redisURL)
+		if err != nil {
+			return fmt.Errorf("cannot connect to redis: %w", err)
+		}
+		defer func() { _ = rdb.Close() }()
+		redisPubSub := pubsub.NewRedis(rdb)
+		redisPubSub.WithLogger(log.Error)
+		ps = redisPubSub
+	}
+	for _, topic := range strings.Split(*topics, ",") {
+		topic := strings.TrimSpace(topic)
+		ps.Subscribe(ctx, topic, func(_ context.Context, msg pubsub.Message) error {
+			return printJSON(os.Stdout, map[string]any{"topic": topic, "event": json.RawMessage(msg)})
+		})
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+func meteringExport(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("metering export", cfg)
+	from := fs.String("from", "", "first month of the period, like 2023-04. Defaults to the to month")
+	to := fs.String("to", "", "last month of the period, like 2023-04. Defaults to the current month")
+	out := fs.String("out", "", "output file. Defaults to stdout")
+	webhook := fs.String("webhook", "", "url to POST the report to instead of writing it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	if *from != "" {
+		query.Set("from", *from)
+	}
+	if *to != "" {
+		query.Set("to", *to)
+	}
+	var report json.RawMessage
+	if err := conn.api().do(ctx, http.MethodGet, "/v1/metering?"+query.Encode(), nil, &report); err != nil {
+		return err
+	}
+
+	if *webhook != "" {
+		return newAPIClient(*webhook, "", "").do(ctx, http.MethodPost, "", report, nil)
+	}
+	if *out == "" {
+		return printJSON(os.Stdout, report)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	return printJSON(f, report)
+}
+
+// readSubject parses a credential subject from a json string or from a file when prefixed with @
+func readSubject(s string) (map[string]any, error) {
+	subject := make(map[string]any)
+	if err := readJSON(s, &subject); err != nil {
+		return nil, fmt.Errorf("invalid credential subject: %w", err)
+	}
+	return subject, nil
+}
+
+// readJSON parses v from a json string or from a file when prefixed with @
+func readJSON(s string, v any) error {
+	raw := []byte(s)
+	if strings.HasPrefix(s, "@") {
+		var err error
+		if raw, err = os.ReadFile(strings.TrimPrefix(s, "@")); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func maintenanceOn(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("maintenance on", cfg)
+	message := fs.String("message", "", "reason shown to the clients of the rejected requests")
+	retryAfter := fs.Duration("retry-after", 0, "how long the clients should wait to retry. Defaults to ISSUER_MAINTENANCE_RETRY_AFTER")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	req := map[string]any{"enabled": true}
+	if *message != "" {
+		req["message"] = *message
+	}
+	if *retryAfter > 0 {
+		req["retryAfter"] = int(retryAfter.Seconds())
+	}
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodPut, "/v1/maintenance", req, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func maintenanceOff(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("maintenance off", cfg)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodPut, "/v1/maintenance", map[string]any{"enabled": false}, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func maintenanceStatus(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("maintenance status", cfg)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodGet, "/v1/maintenance", nil, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func regionStatus(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("region status", cfg)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodGet, "/v1/region", nil, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func regionPromote(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("region promote", cfg)
+	force := fs.Bool("force", false, "take the lease before it expires, when the active region is known to be down")
+	promoteDatabase := fs.Bool("promote-db", false, "promote the replica of the region to primary first")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	req := map[string]any{"force": *force, "promoteDatabase": *promoteDatabase}
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodPost, "/v1/region/promote", req, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func dbExplain(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("db explain", cfg)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodGet, "/v1/diagnostics/query-plans", nil, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func quotaShow(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("quota show", cfg)
+	did := fs.String("did", cfg.APIUI.Issuer, "issuer DID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *did == "" {
+		return fmt.Errorf("did flag is required")
+	}
+
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodGet, fmt.Sprintf("/v1/%s/stats", *did), nil, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func quotaSet(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("quota set", cfg)
+	did := fs.String("did", cfg.APIUI.Issuer, "issuer DID")
+	maxActiveLinks := fs.Int("max-active-links", -1, "maximum links that can issue credentials, 0 is unlimited. -1 keeps the quota of the node")
+	maxCredentialsPerDay := fs.Int("max-credentials-per-day", -1, "maximum credentials issued per UTC day, 0 is unlimited. -1 keeps the quota of the node")
+	maxSchemas := fs.Int("max-schemas", -1, "maximum schemas, 0 is unlimited. -1 keeps the quota of the node")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *did == "" {
+		return fmt.Errorf("did flag is required")
+	}
+
+	override := func(value int) *int {
+		if value < 0 {
+			return nil
+		}
+		return &value
+	}
+	req := map[string]*int{
+		"maxActiveLinks":       override(*maxActiveLinks),
+		"maxCredentialsPerDay": override(*maxCredentialsPerDay),
+		"maxSchemas":           override(*maxSchemas),
+	}
+	var resp json.RawMessage
+	if err := conn.api().do(ctx, http.MethodPut, fmt.Sprintf("/v1/%s/quotas", *did), req, &resp); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, resp)
+}
+
+func keystoreImport(_ context.Context, cfg *config.Configuration, args []string) error {
+	fs := flag.NewFlagSet("keystore import", flag.ContinueOnError)
+	path := fs.String("path", cfg.KeyStore.File.Path, "key store file")
+	passphrase := fs.String("passphrase", cfg.KeyStore.File.Passphrase, "passphrase of the key store file")
+	id := fs.String("id", cfg.PublishingKeyPath, "key id, the publishing key path by default")
+	keyType := fs.String("type", string(kms.KeyTypeEthereum), "key type (ETH|BJJ)")
+	privateKey := fs.String("private-key", "", "hex encoded private key (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" || *privateKey == "" {
+		return fmt.Errorf("id and private-key flags are required")
+	}
+	if *keyType != string(kms.KeyTypeEthereum) && *keyType != string(kms.KeyTypeBabyJubJub) {
+		return fmt.Errorf("unknown key type %q", *keyType)
+	}
+	key, err := hex.DecodeString(strings.TrimPrefix(*privateKey, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+
+	keyID := kms.KeyID{Type: kms.KeyType(*keyType), ID: *id}
+	if err := kms.ImportFileKey(kms.FileConfig{Path: *path, Passphrase: *passphrase}, keyID, key); err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, map[string]string{"type": *keyType, "id": *id})
+}
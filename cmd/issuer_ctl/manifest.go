@@ -0,0 +1,777 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+)
+
+// manifest declares the configuration of the identities of a node. The collections that are present replace the ones
+// of the node, and the settings or collections that are missing are left as they are.
+type manifest struct {
+	Identities []manifestIdentity `json:"identities"`
+}
+
+type manifestIdentity struct {
+	DID               string            `json:"did"`
+	DefaultProofTypes []string          `json:"defaultProofTypes,omitempty"`
+	Quotas            *manifestQuotas   `json:"quotas,omitempty"`
+	Branding          *manifestBranding `json:"branding,omitempty"`
+	Webhooks          []manifestWebhook `json:"webhooks"`
+	Schemas           []manifestSchema  `json:"schemas"`
+	Links             []manifestLink    `json:"links"`
+}
+
+// manifestQuotas are the quotas of an identity. A missing quota is the one of the node, and 0 is unlimited.
+type manifestQuotas struct {
+	MaxActiveLinks       *int `json:"maxActiveLinks"`
+	MaxCredentialsPerDay *int `json:"maxCredentialsPerDay"`
+	MaxSchemas           *int `json:"maxSchemas"`
+}
+
+// manifestBranding is the branding of an identity. A missing field is the one of the node.
+type manifestBranding struct {
+	DisplayName  *string `json:"displayName"`
+	Logo         *string `json:"logo"`
+	PrimaryColor *string `json:"primaryColor"`
+	TextColor    *string `json:"textColor"`
+}
+
+// manifestWebhook is a schema webhook. The authorization is never returned by the node, so it is not exported, and
+// it is only sent when the webhook is created.
+type manifestWebhook struct {
+	SchemaType    string   `json:"schemaType"`
+	URL           string   `json:"url"`
+	Events        []string `json:"events,omitempty"`
+	Authorization string   `json:"authorization,omitempty"`
+}
+
+// manifestSchema is an imported schema with its settings: the policies of its credentials, its post-issuance hooks
+// and the display strings of its credential offers. A missing setting is removed from the schema.
+type manifestSchema struct {
+	URL                 string           `json:"url"`
+	Type                string           `json:"type"`
+	Title               string           `json:"title,omitempty"`
+	Description         string           `json:"description,omitempty"`
+	DefaultProofTypes   []string         `json:"defaultProofTypes,omitempty"`
+	DefaultExpiration   string           `json:"defaultExpiration,omitempty"`
+	PIIAttributes       []string         `json:"piiAttributes,omitempty"`
+	MaxActivePerSubject int              `json:"maxActivePerSubject,omitempty"`
+	RevokeSuperseded    bool             `json:"revokeSuperseded,omitempty"`
+	PostIssuanceHooks   []map[string]any `json:"postIssuanceHooks,omitempty"`
+	DisplayStrings      map[string]any   `json:"displayStrings,omitempty"`
+	Prerequisites       []map[string]any `json:"prerequisites,omitempty"`
+	Deprecated          bool             `json:"deprecated,omitempty"`
+}
+
+// manifestLink is an active link. The links can't be changed, so they are matched by all their fields, and the
+// validation mode is only sent when the link is created.
+type manifestLink struct {
+	SchemaURL                  string           `json:"schemaUrl"`
+	SchemaType                 string           `json:"schemaType"`
+	CredentialSubject          map[string]any   `json:"credentialSubject"`
+	ProofTypes                 []string         `json:"proofTypes"`
+	Expiration                 string           `json:"expiration,omitempty"`
+	CredentialExpiration       string           `json:"credentialExpiration,omitempty"`
+	CredentialExpirationPolicy string           `json:"credentialExpirationPolicy,omitempty"`
+	MaxIssuance                *int             `json:"maxIssuance,omitempty"`
+	Locale                     string           `json:"locale,omitempty"`
+	DisplayStrings             map[string]any   `json:"displayStrings,omitempty"`
+	Prerequisites              []map[string]any `json:"prerequisites,omitempty"`
+	ValidationMode             string           `json:"validationMode,omitempty"`
+}
+
+// remoteSchema is a schema as the UI API returns it
+type remoteSchema struct {
+	manifestSchema
+	ID           string     `json:"id"`
+	DeprecatedAt *time.Time `json:"deprecatedAt"`
+}
+
+// remoteLink is a link as the UI API returns it
+type remoteLink struct {
+	manifestLink
+	ID     string `json:"id"`
+	Active bool   `json:"active"`
+	Status string `json:"status"`
+}
+
+// remoteWebhook is a schema webhook as the issuer API returns it
+type remoteWebhook struct {
+	manifestWebhook
+	ID string `json:"id"`
+}
+
+func manifestExport(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("manifest export", cfg)
+	did := fs.String("did", "", "only exports this identity")
+	out := fs.String("out", "", "output file, stdout when empty")
+	format := fs.String("format", "yaml", "format of the manifest (yaml|json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "yaml" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	dids := []string{*did}
+	if *did == "" {
+		if err := conn.api().do(ctx, http.MethodGet, "/v1/identities", nil, &dids); err != nil {
+			return err
+		}
+	}
+	var m manifest
+	for _, did := range dids {
+		identity, err := exportIdentity(ctx, conn, did)
+		if err != nil {
+			return fmt.Errorf("exporting %s: %w", did, err)
+		}
+		m.Identities = append(m.Identities, identity)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+	if *format == "json" {
+		return printJSON(w, m)
+	}
+	return printYAML(w, m)
+}
+
+// printYAML writes v as yaml with the field names and the numbers of its json encoding
+func printYAML(w io.Writer, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(raw, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// blockStyle drops the json flow style of the node, so it is written as plain yaml. The strings that would be read
+// as other types are still quoted.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// exportIdentity reads the effective settings of the identity, so the ones inherited from the node are exported too
+func exportIdentity(ctx context.Context, conn *connFlags, did string) (manifestIdentity, error) {
+	api, ui := conn.api(), conn.ui().forIssuer(did)
+	identity := manifestIdentity{DID: did}
+
+	var proofTypes struct {
+		ProofTypes []string `json:"proofTypes"`
+	}
+	if err := api.do(ctx, http.MethodGet, fmt.Sprintf("/v1/%s/default-proof-types", did), nil, &proofTypes); err != nil {
+		return identity, err
+	}
+	identity.DefaultProofTypes = proofTypes.ProofTypes
+
+	var stats struct {
+		Quotas manifestQuotas `json:"quotas"`
+	}
+	if err := api.do(ctx, http.MethodGet, fmt.Sprintf("/v1/%s/stats", did), nil, &stats); err != nil {
+		return identity, err
+	}
+	identity.Quotas = &stats.Quotas
+
+	identity.Branding = &manifestBranding{}
+	if err := api.do(ctx, http.MethodGet, fmt.Sprintf("/v1/%s/branding", did), nil, identity.Branding); err != nil {
+		return identity, err
+	}
+
+	webhooks, err := remoteWebhooks(ctx, api, did)
+	if err != nil {
+		return identity, err
+	}
+	identity.Webhooks = make([]manifestWebhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		identity.Webhooks = append(identity.Webhooks, webhook.manifestWebhook)
+	}
+
+	schemas, err := remoteSchemas(ctx, ui)
+	if err != nil {
+		return identity, err
+	}
+	identity.Schemas = make([]manifestSchema, 0, len(schemas))
+	for _, schema := range schemas {
+		identity.Schemas = append(identity.Schemas, schema.manifestSchema)
+	}
+
+	links, err := remoteLinks(ctx, ui)
+	if err != nil {
+		return identity, err
+	}
+	identity.Links = make([]manifestLink, 0, len(links))
+	for _, link := range links {
+		if link.Active {
+			identity.Links = append(identity.Links, link.manifestLink)
+		}
+	}
+	return identity, nil
+}
+
+func manifestApply(ctx context.Context, cfg *config.Configuration, args []string) error {
+	fs, conn := newFlagSet("manifest apply", cfg)
+	file := fs.String("f", "", "manifest file in yaml or json, - reads it from stdin (required)")
+	dryRun := fs.Bool("dry-run", false, "only prints the changes, without applying them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("f flag is required")
+	}
+
+	m, err := readManifest(*file)
+	if err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	var dids []string
+	if err := conn.api().do(ctx, http.MethodGet, "/v1/identities", nil, &dids); err != nil {
+		return err
+	}
+	for _, identity := range m.Identities {
+		if !contains(dids, identity.DID) {
+			return fmt.Errorf("the identity %s is not managed by the node, create it with issuer-ctl identity create and put its DID in the manifest", identity.DID)
+		}
+	}
+
+	r := &reconciler{api: conn.api(), out: os.Stdout, dryRun: *dryRun}
+	for _, identity := range m.Identities {
+		r.ui = conn.ui().forIssuer(identity.DID)
+		if err := r.identity(ctx, identity); err != nil {
+			return fmt.Errorf("applying %s: %w", identity.DID, err)
+		}
+	}
+	if r.changes == 0 {
+		_, _ = fmt.Fprintln(r.out, "the node already matches the manifest")
+	}
+	return nil
+}
+
+// readManifest parses a yaml or json manifest. The authorizations of the webhooks can read environment variables
+// like ${CRM_TOKEN}, so the secrets are not kept in the manifest.
+func readManifest(file string) (*manifest, error) {
+	var raw []byte
+	var err error
+	if file == "-" {
+		raw, err = io.ReadAll(os.Stdin)
+	} else {
+		raw, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the yaml is decoded as json, so the manifest values have the types of the API responses
+	var decoded any
+	if err := yaml.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	raw, err = json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+	var m manifest
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for i, identity := range m.Identities {
+		if identity.DID == "" {
+			return nil, errors.New("an identity doesn't have a did")
+		}
+		if seen[identity.DID] {
+			return nil, fmt.Errorf("the identity %s is declared twice", identity.DID)
+		}
+		seen[identity.DID] = true
+		for _, schema := range identity.Schemas {
+			if schema.URL == "" || schema.Type == "" {
+				return nil, fmt.Errorf("a schema of %s doesn't have a url or a type", identity.DID)
+			}
+		}
+		for j, webhook := range identity.Webhooks {
+			if webhook.SchemaType == "" || webhook.URL == "" {
+				return nil, fmt.Errorf("a webhook of %s doesn't have a schema type or a url", identity.DID)
+			}
+			m.Identities[i].Webhooks[j].Authorization = os.ExpandEnv(webhook.Authorization)
+		}
+		for _, link := range identity.Links {
+			if link.SchemaURL == "" || link.SchemaType == "" {
+				return nil, fmt.Errorf("a link of %s doesn't have a schema url or a schema type", identity.DID)
+			}
+		}
+	}
+	return &m, nil
+}
+
+// reconciler changes the settings of an identity to the ones of the manifest, and prints each change
+type reconciler struct {
+	api     *apiClient
+	ui      *apiClient
+	out     io.Writer
+	dryRun  bool
+	changes int
+}
+
+// change prints the change and applies it unless it is a dry run
+func (r *reconciler) change(ctx context.Context, did string, description string, apply func(ctx context.Context) error) error {
+	r.changes++
+	_, _ = fmt.Fprintf(r.out, "%s: %s\n", did, description)
+	if r.dryRun {
+		return nil
+	}
+	return apply(ctx)
+}
+
+func (r *reconciler) identity(ctx context.Context, identity manifestIdentity) error {
+	steps := []func(ctx context.Context, identity manifestIdentity) error{
+		r.defaultProofTypes,
+		r.quotas,
+		r.branding,
+		r.webhooks,
+		r.schemas,
+		r.links,
+	}
+	for _, step := range steps {
+		if err := step(ctx, identity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reconciler) defaultProofTypes(ctx context.Context, identity manifestIdentity) error {
+	if identity.DefaultProofTypes == nil {
+		return nil
+	}
+	path := fmt.Sprintf("/v1/%s/default-proof-types", identity.DID)
+	var current struct {
+		ProofTypes []string `json:"proofTypes"`
+	}
+	if err := r.api.do(ctx, http.MethodGet, path, nil, &current); err != nil {
+		return err
+	}
+	if sameValue(sorted(current.ProofTypes), sorted(identity.DefaultProofTypes)) {
+		return nil
+	}
+	return r.change(ctx, identity.DID, fmt.Sprintf("set the default proof types to %v", identity.DefaultProofTypes), func(ctx context.Context) error {
+		return r.api.do(ctx, http.MethodPut, path, map[string]any{"proofTypes": identity.DefaultProofTypes}, nil)
+	})
+}
+
+func (r *reconciler) quotas(ctx context.Context, identity manifestIdentity) error {
+	if identity.Quotas == nil {
+		return nil
+	}
+	var stats struct {
+		Quotas manifestQuotas `json:"quotas"`
+	}
+	if err := r.api.do(ctx, http.MethodGet, fmt.Sprintf("/v1/%s/stats", identity.DID), nil, &stats); err != nil {
+		return err
+	}
+	desired, current := identity.Quotas, stats.Quotas
+	if sameOverride(desired.MaxActiveLinks, current.MaxActiveLinks) && sameOverride(desired.MaxCredentialsPerDay, current.MaxCredentialsPerDay) && sameOverride(desired.MaxSchemas, current.MaxSchemas) {
+		return nil
+	}
+	return r.change(ctx, identity.DID, "update the quotas", func(ctx context.Context) error {
+		return r.api.do(ctx, http.MethodPut, fmt.Sprintf("/v1/%s/quotas", identity.DID), desired, nil)
+	})
+}
+
+func (r *reconciler) branding(ctx context.Context, identity manifestIdentity) error {
+	if identity.Branding == nil {
+		return nil
+	}
+	path := fmt.Sprintf("/v1/%s/branding", identity.DID)
+	var current manifestBranding
+	if err := r.api.do(ctx, http.MethodGet, path, nil, &current); err != nil {
+		return err
+	}
+	desired := identity.Branding
+	if sameOverride(desired.DisplayName, current.DisplayName) && sameOverride(desired.Logo, current.Logo) && sameOverride(desired.PrimaryColor, current.PrimaryColor) && sameOverride(desired.TextColor, current.TextColor) {
+		return nil
+	}
+	return r.change(ctx, identity.DID, "update the branding", func(ctx context.Context) error {
+		return r.api.do(ctx, http.MethodPut, path, desired, nil)
+	})
+}
+
+// webhooks deletes the webhooks that are not in the manifest and creates the missing ones. The ones notified of
+// other events are replaced.
+func (r *reconciler) webhooks(ctx context.Context, identity manifestIdentity) error {
+	if identity.Webhooks == nil {
+		return nil
+	}
+	current, err := remoteWebhooks(ctx, r.api, identity.DID)
+	if err != nil {
+		return err
+	}
+	kept := make(map[int]bool)
+	for _, webhook := range current {
+		i := indexOf(identity.Webhooks, func(desired manifestWebhook) bool { return webhookKey(desired) == webhookKey(webhook.manifestWebhook) })
+		if i >= 0 && !kept[i] {
+			kept[i] = true
+			continue
+		}
+		id := webhook.ID
+		if err := r.change(ctx, identity.DID, fmt.Sprintf("delete the webhook %s of %s", webhook.URL, webhook.SchemaType), func(ctx context.Context) error {
+			return r.api.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/%s/webhooks/%s", identity.DID, id), nil, nil)
+		}); err != nil {
+			return err
+		}
+	}
+	for i, webhook := range identity.Webhooks {
+		if kept[i] {
+			continue
+		}
+		webhook := webhook
+		if err := r.change(ctx, identity.DID, fmt.Sprintf("create the webhook %s of %s", webhook.URL, webhook.SchemaType), func(ctx context.Context) error {
+			return r.api.do(ctx, http.MethodPost, fmt.Sprintf("/v1/%s/webhooks", identity.DID), webhook, nil)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemas imports the missing schemas, updates the settings that changed and deprecates the schemas that are not in
+// the manifest, since they can't be deleted
+func (r *reconciler) schemas(ctx context.Context, identity manifestIdentity) error {
+	if identity.Schemas == nil {
+		return nil
+	}
+	current, err := remoteSchemas(ctx, r.ui)
+	if err != nil {
+		return err
+	}
+	for _, desired := range identity.Schemas {
+		i := indexOf(current, func(schema remoteSchema) bool { return schema.URL == desired.URL && schema.Type == desired.Type })
+		if i < 0 {
+			desired := desired
+			if err := r.change(ctx, identity.DID, fmt.Sprintf("import the schema %s", desired.Type), func(ctx context.Context) error {
+				var imported struct {
+					ID string `json:"id"`
+				}
+				if err := r.ui.do(ctx, http.MethodPost, "/v1/schemas", map[string]string{"url": desired.URL, "schemaType": desired.Type}, &imported); err != nil {
+					return err
+				}
+				if patch := schemaPatch(desired, manifestSchema{}); len(patch) > 0 {
+					return r.ui.do(ctx, http.MethodPatch, "/v1/schemas/"+imported.ID, patch, nil)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		if patch := schemaPatch(desired, current[i].manifestSchema); len(patch) > 0 {
+			id := current[i].ID
+			if err := r.change(ctx, identity.DID, fmt.Sprintf("update %s of the schema %s", strings.Join(sortedKeys(patch), ", "), desired.Type), func(ctx context.Context) error {
+				return r.ui.do(ctx, http.MethodPatch, "/v1/schemas/"+id, patch, nil)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, schema := range current {
+		declared := indexOf(identity.Schemas, func(desired manifestSchema) bool { return schema.URL == desired.URL && schema.Type == desired.Type }) >= 0
+		if declared || schema.Deprecated {
+			continue
+		}
+		id := schema.ID
+		if err := r.change(ctx, identity.DID, fmt.Sprintf("deprecate the schema %s", schema.Type), func(ctx context.Context) error {
+			return r.ui.do(ctx, http.MethodPatch, "/v1/schemas/"+id, map[string]any{"deprecated": true}, nil)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// links creates the missing links, activates the deactivated ones of the manifest and deactivates the active ones
+// that are not in it
+func (r *reconciler) links(ctx context.Context, identity manifestIdentity) error {
+	if identity.Links == nil {
+		return nil
+	}
+	current, err := remoteLinks(ctx, r.ui)
+	if err != nil {
+		return err
+	}
+	matched := make(map[int]bool)
+	for _, desired := range identity.Links {
+		// the same link can be declared twice, and each one matches a different link of the node
+		i := -1
+		for j, link := range current {
+			if !matched[j] && link.Status != "exceeded" && linkKey(link.manifestLink) == linkKey(desired) {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			desired := desired
+			if err := r.change(ctx, identity.DID, fmt.Sprintf("create a link of %s", desired.SchemaType), func(ctx context.Context) error {
+				return r.createLink(ctx, desired)
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		matched[i] = true
+		if !current[i].Active {
+			id := current[i].ID
+			if err := r.change(ctx, identity.DID, fmt.Sprintf("activate the link %s of %s", id, desired.SchemaType), func(ctx context.Context) error {
+				return r.ui.do(ctx, http.MethodPatch, "/v1/credentials/links/"+id, map[string]bool{"active": true}, nil)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	for i, link := range current {
+		if matched[i] || !link.Active {
+			continue
+		}
+		id := link.ID
+		if err := r.change(ctx, identity.DID, fmt.Sprintf("deactivate the link %s of %s", id, link.SchemaType), func(ctx context.Context) error {
+			return r.ui.do(ctx, http.MethodPatch, "/v1/credentials/links/"+id, map[string]bool{"active": false}, nil)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createLink creates the link with the schema of the identity it is for
+func (r *reconciler) createLink(ctx context.Context, link manifestLink) error {
+	schemas, err := remoteSchemas(ctx, r.ui)
+	if err != nil {
+		return err
+	}
+	i := indexOf(schemas, func(schema remoteSchema) bool { return schema.URL == link.SchemaURL && schema.Type == link.SchemaType })
+	if i < 0 {
+		return fmt.Errorf("the schema %s of a link is not imported, add it to the schemas of the manifest", link.SchemaURL)
+	}
+	req := map[string]any{
+		"schemaID":          schemas[i].ID,
+		"credentialSubject": link.CredentialSubject,
+		"signatureProof":    contains(link.ProofTypes, "BJJSignature2021"),
+		"mtProof":           contains(link.ProofTypes, "Iden3SparseMerkleTreeProof"),
+		"limitedClaims":     link.MaxIssuance,
+	}
+	optional := map[string]any{
+		"expiration":                 link.Expiration,
+		"credentialExpiration":       link.CredentialExpiration,
+		"credentialExpirationPolicy": link.CredentialExpirationPolicy,
+		"locale":                     link.Locale,
+		"displayStrings":             link.DisplayStrings,
+		"prerequisites":              link.Prerequisites,
+		"validationMode":             link.ValidationMode,
+	}
+	for name, value := range optional {
+		if !isEmpty(value) {
+			req[name] = value
+		}
+	}
+	return r.ui.do(ctx, http.MethodPost, "/v1/credentials/links", req, nil)
+}
+
+// schemaPatch returns the settings of desired that are different in current, with the empty values that remove them
+func schemaPatch(desired, current manifestSchema) map[string]any {
+	settings := func(s manifestSchema) map[string]any {
+		return map[string]any{
+			"title":               s.Title,
+			"description":         s.Description,
+			"defaultProofTypes":   orEmpty(s.DefaultProofTypes),
+			"defaultExpiration":   s.DefaultExpiration,
+			"piiAttributes":       orEmpty(sorted(s.PIIAttributes)),
+			"maxActivePerSubject": s.MaxActivePerSubject,
+			"revokeSuperseded":    s.RevokeSuperseded,
+			"postIssuanceHooks":   orEmpty(s.PostIssuanceHooks),
+			"displayStrings":      orEmptyMap(s.DisplayStrings),
+			"prerequisites":       orEmpty(s.Prerequisites),
+			"deprecated":          s.Deprecated,
+		}
+	}
+	want, have := settings(desired), settings(current)
+	patch := make(map[string]any)
+	for name, value := range want {
+		if !sameValue(value, have[name]) {
+			patch[name] = value
+		}
+	}
+	return patch
+}
+
+func remoteWebhooks(ctx context.Context, api *apiClient, did string) ([]remoteWebhook, error) {
+	var webhooks []remoteWebhook
+	if err := api.do(ctx, http.MethodGet, fmt.Sprintf("/v1/%s/webhooks", did), nil, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// remoteSchemas returns the schemas of the identity with all their settings
+func remoteSchemas(ctx context.Context, ui *apiClient) ([]remoteSchema, error) {
+	var list []remoteSchema
+	if err := ui.do(ctx, http.MethodGet, "/v1/schemas", nil, &list); err != nil {
+		return nil, err
+	}
+	schemas := make([]remoteSchema, 0, len(list))
+	for _, item := range list {
+		var schema remoteSchema
+		if err := ui.do(ctx, http.MethodGet, "/v1/schemas/"+item.ID, nil, &schema); err != nil {
+			return nil, err
+		}
+		schema.Deprecated = schema.DeprecatedAt != nil
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+func remoteLinks(ctx context.Context, ui *apiClient) ([]remoteLink, error) {
+	var links []remoteLink
+	if err := ui.do(ctx, http.MethodGet, "/v1/credentials/links?"+url.Values{"status": {"all"}}.Encode(), nil, &links); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// webhookKey identifies a webhook by its schema type, url and events, where no events are all of them
+func webhookKey(webhook manifestWebhook) string {
+	events := sorted(webhook.Events)
+	if len(events) == 0 {
+		events = []string{"issued", "revoked"}
+	}
+	return fmt.Sprintf("%s %s %v", webhook.SchemaType, webhook.URL, events)
+}
+
+// linkKey identifies a link by all the fields the UI API returns, with the times in UTC
+func linkKey(link manifestLink) string {
+	if t, err := time.Parse(time.RFC3339, link.Expiration); err == nil {
+		link.Expiration = t.UTC().Format(time.RFC3339)
+	}
+	link.ProofTypes = sorted(link.ProofTypes)
+	link.ValidationMode = ""
+	return canonical(link)
+}
+
+// sameOverride tells if a setting of an identity is the desired one, where a missing setting is the one of the node
+func sameOverride[T comparable](desired, current *T) bool {
+	return desired == nil || (current != nil && *desired == *current)
+}
+
+// sameValue tells if two values are the same once encoded as JSON, where an empty value is the same as a missing one
+func sameValue(a, b any) bool {
+	return canonical(a) == canonical(b)
+}
+
+// canonical encodes v as JSON with the keys of the objects sorted, and as an empty string when it is empty
+func canonical(v any) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil || isEmpty(decoded) {
+		return ""
+	}
+	raw, _ = json.Marshal(decoded)
+	return string(raw)
+}
+
+func isEmpty(v any) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case bool:
+		return !value
+	case float64:
+		return value == 0
+	case []any:
+		return len(value) == 0
+	case map[string]any:
+		return len(value) == 0
+	case []map[string]any:
+		return len(value) == 0
+	}
+	return false
+}
+
+func sorted(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	res := append([]string(nil), values...)
+	sort.Strings(res)
+	return res
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func orEmpty[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
+
+func orEmptyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
+
+func contains(values []string, value string) bool {
+	return indexOf(values, func(v string) bool { return v == value }) >= 0
+}
+
+func indexOf[T any](values []T, match func(T) bool) int {
+	for i, value := range values {
+		if match(value) {
+			return i
+		}
+	}
+	return -1
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/kms"
 	"github.com/polygonid/sh-id-platform/internal/log"
-	"github.com/polygonid/sh-id-platform/internal/providers"
+	"github.com/polygonid/sh-id-platform/internal/providers/blockchain"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
 	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 )
@@ -24,19 +24,23 @@ func main() {
 
 	ctx := log.NewContext(context.Background(), cfg.Log.Level, cfg.Log.Mode, os.Stdout)
 
-	storage, err := db.NewStorage(cfg.Database.URL)
+	networks, err := cfg.Networks()
 	if err != nil {
-		log.Error(ctx, "cannot connect to database", "err", err)
+		log.Error(ctx, "invalid networks configuration", "err", err)
+		return
+	}
+	if err := blockchain.RegisterNetworks(networks); err != nil {
+		log.Error(ctx, "cannot register the networks", "err", err)
 		return
 	}
 
-	vaultCli, err := providers.NewVaultClient(cfg.KeyStore.Address, cfg.KeyStore.Token)
+	storage, err := db.NewStorage(cfg.Database.URL)
 	if err != nil {
-		log.Error(ctx, "cannot init vault client: ", "err", err)
+		log.Error(ctx, "cannot connect to database", "err", err)
 		return
 	}
 
-	keyStore, err := kms.Open(cfg.KeyStore.PluginIden3MountPath, vaultCli)
+	keyStore, err := kms.NewFromConfig(ctx, cfg)
 	if err != nil {
 		log.Error(ctx, "cannot initialize kms", "err", err)
 		return
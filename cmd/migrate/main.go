@@ -2,7 +2,14 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
 	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/pressly/goose/v3"
 
 	"github.com/polygonid/sh-id-platform/internal/config"
 	"github.com/polygonid/sh-id-platform/internal/db/schema"
@@ -11,7 +18,32 @@ import (
 	_ "github.com/lib/pq"
 )
 
+const usage = `usage: migrate [-dry-run] [-json] [command]
+
+  up              apply every pending migration, the default
+  down            roll back the last applied migration
+  to <version>    apply or roll back the migrations until version is the last applied, 0 rolls back all of them
+  version         print the version of the last applied migration
+  status          print the applied and the pending migrations
+
+  -dry-run        print the SQL up, down or to would run, without running it
+  -json           print status and the -dry-run steps as json
+`
+
 func main() {
+	dryRun := flag.Bool("dry-run", false, "print the SQL that would run, without running it")
+	asJSON := flag.Bool("json", false, "print status and the dry run steps as json")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	args := flag.Args()
+	command := "up"
+	if len(args) > 0 {
+		// the flags can also follow the command
+		command = args[0]
+		_ = flag.CommandLine.Parse(args[1:])
+		args = append([]string{command}, flag.Args()...)
+	}
+
 	cfg, err := config.Load("")
 	if err != nil {
 		log.Error(context.Background(), "cannot load config", "err", err)
@@ -20,10 +52,116 @@ func main() {
 	ctx := log.NewContext(context.Background(), cfg.Log.Level, cfg.Log.Mode, os.Stdout)
 	log.Debug(ctx, "database", "url", cfg.Database.URL)
 
-	if err := schema.Migrate(cfg.Database.URL); err != nil {
-		log.Error(ctx, "error migrating database", "err", err)
+	switch {
+	case command == "status" && len(args) == 1:
+		statuses, err := schema.Status(cfg.Database.URL)
+		if err != nil {
+			log.Error(ctx, "error reading the migrations status", "err", err)
+			os.Exit(1)
+		}
+		printStatus(statuses, *asJSON)
 		return
+	case command == "version" && len(args) <= 1:
+		version, err := schema.Version(cfg.Database.URL)
+		if err != nil {
+			log.Error(ctx, "error reading the database version", "err", err)
+			os.Exit(1)
+		}
+		fmt.Println(version)
+		return
+	case command == "up" && len(args) <= 1:
+		if *dryRun {
+			steps, err := schema.Plan(cfg.Database.URL, goose.MaxVersion)
+			exitOnPlanError(ctx, err)
+			printSteps(steps, *asJSON)
+			return
+		}
+		err = schema.Migrate(cfg.Database.URL)
+	case command == "down" && len(args) == 1:
+		if *dryRun {
+			steps, err := schema.PlanDown(cfg.Database.URL)
+			exitOnPlanError(ctx, err)
+			printSteps(steps, *asJSON)
+			return
+		}
+		err = schema.MigrateDown(cfg.Database.URL)
+	case command == "to" && len(args) == 2:
+		version, parseErr := strconv.ParseInt(args[1], 10, 64)
+		if parseErr != nil || version < 0 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		if *dryRun {
+			steps, err := schema.Plan(cfg.Database.URL, version)
+			exitOnPlanError(ctx, err)
+			printSteps(steps, *asJSON)
+			return
+		}
+		err = schema.MigrateTo(cfg.Database.URL, version)
+	default:
+		flag.Usage()
+		os.Exit(2)
 	}
+	if err != nil {
+		log.Error(ctx, "error migrating database", "err", err, "command", command)
+		os.Exit(1)
+	}
+
+	version, err := schema.Version(cfg.Database.URL)
+	if err != nil {
+		log.Error(ctx, "error reading the database version", "err", err)
+		os.Exit(1)
+	}
+	log.Info(ctx, "migration done!", "version", version)
+}
+
+func exitOnPlanError(ctx context.Context, err error) {
+	if err != nil {
+		log.Error(ctx, "error planning the migrations", "err", err)
+		os.Exit(1)
+	}
+}
+
+func printStatus(statuses []schema.MigrationStatus, asJSON bool) {
+	if asJSON {
+		printJSON(statuses)
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tAPPLIED AT\tMIGRATION")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.Applied {
+			appliedAt = "applied"
+			if status.AppliedAt != nil && !status.AppliedAt.IsZero() {
+				appliedAt = status.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, appliedAt, status.Source)
+	}
+	_ = w.Flush()
+}
+
+func printSteps(steps []schema.Step, asJSON bool) {
+	if asJSON {
+		printJSON(steps)
+		return
+	}
+	if len(steps) == 0 {
+		fmt.Println("-- nothing to run")
+		return
+	}
+	for _, step := range steps {
+		direction := "up"
+		if step.Down {
+			direction = "down"
+		}
+		fmt.Printf("-- %s %s\n%s\n\n", step.Source, direction, step.SQL)
+	}
+}
 
-	log.Info(ctx, "migration done!")
+func printJSON(v any) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
 }
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/mtprune"
+)
+
+func main() {
+	retention := flag.Duration("retention", 30*24*time.Hour, "how long the merkle trees of the old states are kept")
+	dryRun := flag.Bool("dry-run", false, "only report the orphaned nodes, without deleting them")
+	identifier := flag.String("did", "", "prune only the merkle trees of this identity")
+	flag.Parse()
+
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Error(context.Background(), "cannot load config", "err", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(log.NewContext(context.Background(), cfg.Log.Level, cfg.Log.Mode, os.Stderr), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if *retention < 0 {
+		log.Error(ctx, "the retention cannot be negative", "retention", *retention)
+		os.Exit(2)
+	}
+
+	storage, err := db.NewStorage(cfg.Database.URL)
+	if err != nil {
+		log.Error(ctx, "cannot connect to database", "err", err)
+		os.Exit(1)
+	}
+	defer func(storage *db.Storage) {
+		if err := storage.Close(); err != nil {
+			log.Error(ctx, "error closing database connection", "err", err)
+		}
+	}(storage)
+
+	report, err := mtprune.Prune(ctx, storage, mtprune.Options{Retention: *retention, DryRun: *dryRun, Identifier: *identifier})
+	if err != nil {
+		log.Error(ctx, "pruning merkle trees", "err", err)
+		os.Exit(1)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Error(ctx, "writing the report", "err", err)
+		os.Exit(1)
+	}
+}
@@ -3,10 +3,13 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 
+	redis2 "github.com/go-redis/redis/v8"
+
 	"github.com/polygonid/sh-id-platform/internal/config"
 	"github.com/polygonid/sh-id-platform/internal/core/event"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
@@ -16,11 +19,13 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/kms"
 	"github.com/polygonid/sh-id-platform/internal/loader"
 	"github.com/polygonid/sh-id-platform/internal/log"
-	"github.com/polygonid/sh-id-platform/internal/providers"
+	"github.com/polygonid/sh-id-platform/internal/providers/blockchain"
 	"github.com/polygonid/sh-id-platform/internal/redis"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/blobstore"
 	"github.com/polygonid/sh-id-platform/pkg/cache"
-	"github.com/polygonid/sh-id-platform/pkg/http"
+	client "github.com/polygonid/sh-id-platform/pkg/http"
+	"github.com/polygonid/sh-id-platform/pkg/notifications"
 	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
 )
@@ -40,37 +45,64 @@ func main() {
 		return
 	}
 
-	rdb, err := redis.Open(cfg.Cache.RedisUrl)
+	outbound, err := client.NewTransport(cfg.Outbound.ProxyURL, cfg.Outbound.NoProxy, cfg.Outbound.CABundle)
 	if err != nil {
-		log.Error(ctx, "cannot connect to redis", "err", err, "host", cfg.Cache.RedisUrl)
+		log.Error(ctx, "cannot configure the outbound http clients", "err", err)
 		return
 	}
 
-	storage, err := db.NewStorage(cfg.Database.URL)
+	storage, err := db.NewStorage(cfg.Database.URL, db.WithPool(db.Pool(cfg.Database.Pool)))
 	if err != nil {
 		log.Error(ctx, "cannot connect to database", "err", err)
 		return
 	}
 
-	ps := pubsub.NewRedis(rdb)
-	ps.WithLogger(log.Error)
-	cachex := cache.NewRedisCache(rdb)
+	var rdb *redis2.Client
+	var ps pubsub.Client
+	var cachex cache.Cache
+	if cfg.Cache.Backend == config.CachePostgres {
+		postgresPubSub := pubsub.NewPostgres(storage.Pgx)
+		postgresPubSub.WithLogger(log.Error)
+		postgresCache := cache.NewPostgresCache(storage.Pgx)
+		postgresCache.Run(ctx, cfg.Cache.CleanupPeriod)
+		ps, cachex = postgresPubSub, postgresCache
+	} else {
+		rdb, err = redis.Open(cfg.Cache.RedisUrl)
+		if err != nil {
+			log.Error(ctx, "cannot connect to redis", "err", err, "host", cfg.Cache.RedisUrl)
+			return
+		}
+		redisPubSub := pubsub.NewRedis(rdb)
+		redisPubSub.WithLogger(log.Error)
+		ps, cachex = redisPubSub, cache.NewRedisCache(rdb)
+	}
 
 	connectionsRepository := repositories.NewConnections()
 
 	connectionsService := services.NewConnection(connectionsRepository, storage)
-	credentialsService, err := newCredentialsService(cfg, storage, cachex, ps)
+	credentialsService, err := newCredentialsService(ctx, cfg, storage, cachex, ps, outbound)
 	if err != nil {
 		log.Error(ctx, "cannot initialize the credential service", "err", err)
 		return
 	}
 
-	notificationGateway := gateways.NewPushNotificationClient(http.DefaultHTTPClientWithRetry)
+	pushGateways, err := notifications.ParseGateways(cfg.PushGateways.URLs)
+	if err != nil {
+		log.Error(ctx, "invalid push gateways", "err", err)
+		return
+	}
+	if pushGateways != nil {
+		pushGateways.Run(ctx, cfg.PushGateways.HealthCheckPeriod, notifications.HTTPHealthCheck(cfg.PushGateways.HealthCheckPeriod, outbound))
+	}
+	notificationGateway := gateways.NewPushNotificationClientWithGateways(client.NewClientWithRetry(outbound), pushGateways)
 	notificationService := services.NewNotification(notificationGateway, connectionsService, credentialsService)
 	ctxCancel, cancel := context.WithCancel(ctx)
 	defer func() {
 		log.Info(ctx, "Shutting down...")
 		cancel()
+		if rdb == nil {
+			return
+		}
 		if err := rdb.Close(); err != nil {
 			log.Error(ctx, "closing redis connection", "err", err)
 		}
@@ -78,6 +110,41 @@ func main() {
 
 	ps.Subscribe(ctxCancel, event.CreateCredentialEvent, notificationService.SendCreateCredentialNotification)
 	ps.Subscribe(ctxCancel, event.CreateConnectionEvent, notificationService.SendCreateConnectionNotification)
+	ps.Subscribe(ctxCancel, event.PostIssuanceHookEvent, credentialsService.RunQueuedPostIssuanceHook)
+
+	schemaWebhooks := services.NewSchemaWebhookDispatcher(credentialsService, cfg.SchemaWebhooks.Timeout, outbound)
+	ps.Subscribe(ctxCancel, event.CreateCredentialEvent, schemaWebhooks.DeliverIssued)
+	ps.Subscribe(ctxCancel, event.RevokeCredentialEvent, schemaWebhooks.DeliverRevoked)
+
+	if cfg.VerificationWebhook.URL != "" {
+		verificationWebhook, err := services.NewVerificationWebhook(cfg.VerificationWebhook.URL, cfg.VerificationWebhook.Authorization, cfg.VerificationWebhook.Timeout, outbound, storage)
+		if err != nil {
+			log.Error(ctx, "cannot initialize the verification webhook", "err", err)
+			return
+		}
+		ps.Subscribe(ctxCancel, event.VerificationEvent, verificationWebhook.Deliver)
+		log.Info(ctx, "delivering the verification results", "url", cfg.VerificationWebhook.URL)
+	}
+
+	sink, err := pubsub.NewSink(pubsub.SinkConfig{
+		Backend:         cfg.EventSink.Backend,
+		Destination:     cfg.EventSink.Destination,
+		Region:          cfg.EventSink.Region,
+		KeyID:           cfg.EventSink.KeyID,
+		Key:             cfg.EventSink.Key,
+		CredentialsFile: cfg.EventSink.CredentialsFile,
+		Transport:       outbound,
+	})
+	if err != nil {
+		log.Error(ctx, "cannot initialize the event sink", "err", err)
+		return
+	}
+	if sink != nil {
+		for _, topic := range []string{event.CreateCredentialEvent, event.CreateConnectionEvent} {
+			ps.Subscribe(ctxCancel, topic, pubsub.Forward(sink, topic))
+		}
+		log.Info(ctx, "forwarding the issuer events", "backend", cfg.EventSink.Backend, "destination", cfg.EventSink.Destination)
+	}
 
 	gracefulShutdown := make(chan os.Signal, 1)
 	signal.Notify(gracefulShutdown, syscall.SIGINT, syscall.SIGTERM)
@@ -85,29 +152,53 @@ func main() {
 	<-gracefulShutdown
 }
 
-func newCredentialsService(cfg *config.Configuration, storage *db.Storage, cachex cache.Cache, ps pubsub.Client) (ports.ClaimsService, error) {
-	vaultCli, err := providers.NewVaultClient(cfg.KeyStore.Address, cfg.KeyStore.Token)
+func newCredentialsService(ctx context.Context, cfg *config.Configuration, storage *db.Storage, cachex cache.Cache, ps pubsub.Client, outbound *http.Transport) (ports.ClaimsService, error) {
+	outboundClient := &http.Client{Transport: outbound}
+	payloadStore, err := blobstore.Open(cfg.PayloadStore.Backend, cfg.PayloadStore.URL, cfg.PayloadStore.Authorization, outbound)
 	if err != nil {
-		return nil, fmt.Errorf("cannot init vault client: err %s", err.Error())
+		return nil, fmt.Errorf("cannot initialize the payload store: err %s", err.Error())
 	}
 
 	identityRepository := repositories.NewIdentity()
-	claimsRepository := repositories.NewClaims()
+	claimsRepository := repositories.NewClaimsWithPayloadStore(payloadStore, cfg.PayloadStore.Threshold)
 	mtRepository := repositories.NewIdentityMerkleTreeRepository()
 	identityStateRepository := repositories.NewIdentityState()
 	revocationRepository := repositories.NewRevocation()
-	keyStore, err := kms.Open(cfg.KeyStore.PluginIden3MountPath, vaultCli)
+	keyStore, err := kms.NewFromConfig(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("cannot initialize kms: err %s", err.Error())
 	}
 
 	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	networks, err := cfg.Networks()
+	if err != nil {
+		return nil, fmt.Errorf("invalid networks configuration: err %s", err.Error())
+	}
+	if err := blockchain.RegisterNetworks(networks); err != nil {
+		return nil, fmt.Errorf("cannot register the networks: err %s", err.Error())
+	}
+	baseLoader, err := loader.LocalDirFactory(loader.IPFSFactory(loader.HTTPClientFactory(outboundClient), gateways.NewIPFSFetcher(cfg.IPFS.URL, cfg.IPFS.GatewayURL, outboundClient)), cfg.SchemaBundle.Dir, cfg.SchemaBundle.Order)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the local schema bundle: err %s", err.Error())
+	}
+	mirrors, err := loader.ParseMirrors(cfg.SchemaLoader.Mirrors)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema mirrors: err %s", err.Error())
+	}
+	baseLoader = loader.ChainFactory([]loader.Source{
+		{Name: "origin", Factory: baseLoader, Timeout: cfg.Timeouts.SchemaLoader},
+		{Name: "mirror", Factory: loader.MirrorFactory(loader.HTTPClientFactory(outboundClient), mirrors), Timeout: cfg.Timeouts.SchemaLoader},
+	},
+		loader.Retry{Attempts: cfg.SchemaLoader.Attempts, Backoff: cfg.SchemaLoader.Backoff, MaxBackoff: cfg.SchemaLoader.MaxBackoff},
+		loader.Breaker{Failures: cfg.SchemaLoader.BreakerFailures, Cooldown: cfg.SchemaLoader.BreakerCooldown},
+	)
 	var schemaLoader loader.Factory
 	if cfg.SchemaCache == nil || !*cfg.SchemaCache {
-		schemaLoader = loader.HTTPFactory
+		schemaLoader = baseLoader
 	} else {
-		schemaLoader = loader.CachedFactory(loader.HTTPFactory, cachex)
+		schemaLoader = loader.CachedFactoryWithTTL(baseLoader, cachex, cfg.SchemaCacheTTL)
 	}
+	schemaLoader = loader.StoredFactory(schemaLoader, repositories.NewSchema(*storage))
 
 	mtService := services.NewIdentityMerkleTrees(mtRepository)
 	identityService := services.NewIdentity(keyStore, identityRepository, mtRepository, identityStateRepository, mtService, claimsRepository, revocationRepository, nil, storage, rhsp, nil, nil, ps)
@@ -119,9 +210,12 @@ func newCredentialsService(cfg *config.Configuration, storage *db.Storage, cache
 		schemaLoader,
 		storage,
 		services.ClaimCfg{
-			RHSEnabled: cfg.ReverseHashService.Enabled,
-			RHSUrl:     cfg.ReverseHashService.URL,
-			Host:       cfg.ServerUrl,
+			RHSEnabled:      cfg.ReverseHashService.Enabled,
+			RHSUrl:          cfg.ReverseHashService.URL,
+			NetworkRHSUrls:  networks.RHSUrls(),
+			Host:            cfg.ServerUrl,
+			HookCaptureSize: cfg.HookCaptureSize,
+			HookTransport:   outbound,
 		},
 		ps,
 	)
@@ -2,32 +2,38 @@ package main
 
 import (
 	"context"
-	"math/big"
+	"errors"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
+	redis2 "github.com/go-redis/redis/v8"
 
 	"github.com/polygonid/sh-id-platform/internal/config"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/core/services"
 	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/gateways"
+	"github.com/polygonid/sh-id-platform/internal/health"
 	"github.com/polygonid/sh-id-platform/internal/kms"
 	"github.com/polygonid/sh-id-platform/internal/loader"
 	"github.com/polygonid/sh-id-platform/internal/log"
-	"github.com/polygonid/sh-id-platform/internal/providers"
+	"github.com/polygonid/sh-id-platform/internal/providers/blockchain"
 	"github.com/polygonid/sh-id-platform/internal/redis"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
-	"github.com/polygonid/sh-id-platform/pkg/blockchain/eth"
+	"github.com/polygonid/sh-id-platform/pkg/blobstore"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+	client "github.com/polygonid/sh-id-platform/pkg/http"
 	"github.com/polygonid/sh-id-platform/pkg/loaders"
 	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
 )
 
+// vaultTokenMinTTL is how long before the expiration of the vault token the operators are alerted
+const vaultTokenMinTTL = 24 * time.Hour
+
 func main() {
 	cfg, err := config.Load("")
 	if err != nil {
@@ -38,16 +44,22 @@ func main() {
 	// Context with log
 	ctx, cancel := context.WithCancel(log.NewContext(context.Background(), cfg.Log.Level, cfg.Log.Mode, os.Stdout))
 
-	rdb, err := redis.Open(cfg.Cache.RedisUrl)
+	outbound, err := client.NewTransport(cfg.Outbound.ProxyURL, cfg.Outbound.NoProxy, cfg.Outbound.CABundle)
 	if err != nil {
-		log.Error(ctx, "cannot connect to redis", "err", err, "host", cfg.Cache.RedisUrl)
+		log.Error(ctx, "cannot configure the outbound http clients", "err", err)
 		return
 	}
-	ps := pubsub.NewRedis(rdb)
-	ps.WithLogger(log.Error)
+	outboundClient := &http.Client{Transport: outbound}
 
-	storage, err := db.NewStorage(cfg.Database.URL)
-	if err != nil {
+	startCtx, cancelStart := context.WithTimeout(ctx, cfg.StartupTimeout)
+	defer cancelStart()
+	startup := health.NewReadiness()
+
+	var storage *db.Storage
+	if err := startup.Connect(startCtx, "postgres", func(context.Context) (err error) {
+		storage, err = db.NewStorage(cfg.Database.URL, db.WithStatementTimeout(cfg.Timeouts.Database), db.WithPool(db.Pool(cfg.Database.Pool)))
+		return err
+	}); err != nil {
 		log.Error(ctx, "cannot connect to database", "err", err)
 		panic(err)
 	}
@@ -59,45 +71,76 @@ func main() {
 		}
 	}(storage)
 
-	vaultCli, err := providers.NewVaultClient(cfg.KeyStore.Address, cfg.KeyStore.Token)
-	if err != nil {
-		log.Error(ctx, "cannot init vault client: ", "err", err)
-		panic(err)
+	var ps pubsub.Client
+	if cfg.Cache.Backend == config.CachePostgres {
+		postgresPubSub := pubsub.NewPostgres(storage.Pgx)
+		postgresPubSub.WithLogger(log.Error)
+		ps = postgresPubSub
+	} else {
+		var rdb *redis2.Client
+		if err := startup.Connect(startCtx, "redis", func(context.Context) (err error) {
+			rdb, err = redis.Open(cfg.Cache.RedisUrl)
+			return err
+		}); err != nil {
+			log.Error(ctx, "cannot connect to redis", "err", err, "host", cfg.Cache.RedisUrl)
+			return
+		}
+		redisPubSub := pubsub.NewRedis(rdb)
+		redisPubSub.WithLogger(log.Error)
+		ps = redisPubSub
 	}
 
-	bjjKeyProvider, err := kms.NewVaultPluginIden3KeyProvider(vaultCli, cfg.KeyStore.PluginIden3MountPath, kms.KeyTypeBabyJubJub)
+	var keyStore *kms.KMS
+	if err := startup.Connect(startCtx, "key store", func(ctx context.Context) (err error) {
+		keyStore, err = kms.NewFromConfig(ctx, cfg)
+		return err
+	}); err != nil {
+		log.Error(ctx, "cannot initialize kms", "err", err)
+		panic(err)
+	}
+	keyStoreCheck, err := kms.VaultAuthCheck(cfg, vaultTokenMinTTL)
 	if err != nil {
-		log.Error(ctx, "cannot create BabyJubJub key provider", "err", err)
+		log.Error(ctx, "cannot check the vault token", "err", err)
 		panic(err)
 	}
 
-	ethKeyProvider, err := kms.NewVaultPluginIden3KeyProvider(vaultCli, cfg.KeyStore.PluginIden3MountPath, kms.KeyTypeEthereum)
+	payloadStore, err := blobstore.Open(cfg.PayloadStore.Backend, cfg.PayloadStore.URL, cfg.PayloadStore.Authorization, outbound)
 	if err != nil {
-		log.Error(ctx, "cannot create Ethereum key provider", "err", err)
+		log.Error(ctx, "cannot initialize the payload store", "err", err)
 		panic(err)
 	}
 
-	keyStore := kms.NewKMS()
-	err = keyStore.RegisterKeyProvider(kms.KeyTypeBabyJubJub, bjjKeyProvider)
+	schemaLoader, err := loader.LocalDirFactory(loader.IPFSFactory(loader.HTTPClientFactory(outboundClient), gateways.NewIPFSFetcher(cfg.IPFS.URL, cfg.IPFS.GatewayURL, outboundClient)), cfg.SchemaBundle.Dir, cfg.SchemaBundle.Order)
 	if err != nil {
-		log.Error(ctx, "cannot register BabyJubJub key provider", "err", err)
+		log.Error(ctx, "cannot load the local schema bundle", "err", err, "dir", cfg.SchemaBundle.Dir)
 		panic(err)
 	}
-
-	err = keyStore.RegisterKeyProvider(kms.KeyTypeEthereum, ethKeyProvider)
+	mirrors, err := loader.ParseMirrors(cfg.SchemaLoader.Mirrors)
 	if err != nil {
-		log.Error(ctx, "cannot register Ethereum key provider", "err", err)
+		log.Error(ctx, "invalid schema mirrors", "err", err)
 		panic(err)
 	}
+	schemaLoader = loader.ChainFactory([]loader.Source{
+		{Name: "origin", Factory: schemaLoader, Timeout: cfg.Timeouts.SchemaLoader},
+		{Name: "mirror", Factory: loader.MirrorFactory(loader.HTTPClientFactory(outboundClient), mirrors), Timeout: cfg.Timeouts.SchemaLoader},
+	},
+		loader.Retry{Attempts: cfg.SchemaLoader.Attempts, Backoff: cfg.SchemaLoader.Backoff, MaxBackoff: cfg.SchemaLoader.MaxBackoff},
+		loader.Breaker{Failures: cfg.SchemaLoader.BreakerFailures, Cooldown: cfg.SchemaLoader.BreakerCooldown},
+	)
 
 	identityRepo := repositories.NewIdentity()
-	claimsRepo := repositories.NewClaims()
+	claimsRepo := repositories.NewClaimsWithPayloadStore(payloadStore, cfg.PayloadStore.Threshold)
 	mtRepo := repositories.NewIdentityMerkleTreeRepository()
 	identityStateRepo := repositories.NewIdentityState()
 	revocationRepository := repositories.NewRevocation()
 	mtService := services.NewIdentityMerkleTrees(mtRepo)
 
 	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	networks, err := cfg.Networks()
+	if err != nil {
+		log.Error(ctx, "invalid networks configuration", "err", err)
+		panic(err)
+	}
 	connectionsRepository := repositories.NewConnections()
 	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
 	claimsService := services.NewClaim(
@@ -105,47 +148,51 @@ func main() {
 		identityService,
 		mtService,
 		identityStateRepo,
-		loader.HTTPFactory,
+		schemaLoader,
 		storage,
 		services.ClaimCfg{
-			RHSEnabled: cfg.ReverseHashService.Enabled,
-			RHSUrl:     cfg.ReverseHashService.URL,
-			Host:       cfg.ServerUrl,
+			RHSEnabled:     cfg.ReverseHashService.Enabled,
+			RHSUrl:         cfg.ReverseHashService.URL,
+			NetworkRHSUrls: networks.RHSUrls(),
+			Host:           cfg.ServerUrl,
+			HookTransport:  outbound,
 		},
 		ps,
 	)
 
-	commonClient, err := ethclient.Dial(cfg.Ethereum.URL)
-	if err != nil {
-		panic("Error dialing with ethclient: " + err.Error())
+	var chain blockchain.Networks
+	if err := startup.Connect(startCtx, "blockchain", func(ctx context.Context) (err error) {
+		chain, err = blockchain.OpenNetworks(ctx, cfg, storage, keyStore, outbound)
+		return err
+	}); err != nil {
+		log.Error(ctx, "failed init blockchain backend", "err", err, "sandbox", cfg.Sandbox)
+		panic("failed init blockchain backend")
 	}
-
-	cl := eth.NewClient(commonClient, &eth.ClientConfig{
-		DefaultGasLimit:        cfg.Ethereum.DefaultGasLimit,
-		ConfirmationTimeout:    cfg.Ethereum.ConfirmationTimeout,
-		ConfirmationBlockCount: cfg.Ethereum.ConfirmationBlockCount,
-		ReceiptTimeout:         cfg.Ethereum.ReceiptTimeout,
-		MinGasPrice:            big.NewInt(int64(cfg.Ethereum.MinGasPrice)),
-		MaxGasPrice:            big.NewInt(int64(cfg.Ethereum.MaxGasPrice)),
-		RPCResponseTimeout:     cfg.Ethereum.RPCResponseTimeout,
-		WaitReceiptCycleTime:   cfg.Ethereum.WaitReceiptCycleTime,
-		WaitBlockCycleTime:     cfg.Ethereum.WaitBlockCycleTime,
-	})
+	cancelStart()
 
 	circuitsLoaderService := loaders.NewCircuits(cfg.Circuit.Path)
-	proofService := initProofService(ctx, cfg, circuitsLoaderService)
+	proofService := initProofService(ctx, cfg, circuitsLoaderService, outbound)
 
-	transactionService, err := gateways.NewTransaction(cl, cfg.Ethereum.ConfirmationBlockCount)
+	publisher := gateways.NewPublisher(storage, identityService, claimsService, mtService, keyStore, chain, proofService, chain, cfg.Ethereum.ConfirmationTimeout, ps)
+	keyRotationService := services.NewKeyRotation(keyStore, claimsService, claimsRepo, identityStateRepo, revocationRepository, publisher, storage, cfg.ServerUrl)
+	regionService := services.NewRegion(storage, cfg.Region.Name, cfg.Region.LeaseTTL, cfg.Region.Refresh, clock.System)
+	go regionService.Run(ctx)
+
+	alertChannels, err := alertChannels(cfg.Alerts, outbound)
 	if err != nil {
-		log.Error(ctx, "error creating transaction service", "err", err)
-		panic("error creating transaction service")
+		log.Error(ctx, "invalid alerts configuration", "err", err)
+		panic(err)
 	}
-	publisherGateway, err := gateways.NewPublisherEthGateway(cl, common.HexToAddress(cfg.Ethereum.ContractAddress), keyStore, cfg.PublishingKeyPath)
-	if err != nil {
-		log.Error(ctx, "error creating publish gateway", "err", err)
-		panic("error creating publish gateway")
+	alertsCfg := services.OperatorAlertsConfig{
+		Repeat:        cfg.Alerts.Repeat,
+		BacklogSize:   cfg.Alerts.BacklogSize,
+		BacklogAge:    cfg.Alerts.BacklogAge,
+		KeyStoreCheck: keyStoreCheck,
+	}
+	if cfg.ReverseHashService.Enabled {
+		alertsCfg.RHSURLs = networks.RHSUrls()
 	}
-	publisher := gateways.NewPublisher(storage, identityService, claimsService, mtService, keyStore, transactionService, proofService, publisherGateway, cfg.Ethereum.ConfirmationTimeout, ps)
+	operatorAlerts := services.NewOperatorAlerts(storage, alertsCfg, alertChannels...)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -155,20 +202,74 @@ func main() {
 		for {
 			select {
 			case <-ticker.C:
+				// only the active region updates the states
+				if err := regionService.Fence(ctx); err != nil {
+					if !errors.Is(err, services.ErrRegionPassive) {
+						log.Error(ctx, "reading the region", "err", err)
+					}
+					continue
+				}
 				publisher.CheckTransactionStatus(ctx)
+				keyRotationService.Advance(ctx)
 			case <-ctx.Done():
 				log.Info(ctx, "finishing check transaction status job")
 			}
 		}
 	}(ctx)
 
+	if cfg.Alerts.Period > 0 {
+		go func(ctx context.Context) {
+			ticker := time.NewTicker(cfg.Alerts.Period)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					// only the active region alerts, the passive ones don't publish
+					if err := regionService.Fence(ctx); err != nil {
+						continue
+					}
+					operatorAlerts.Check(ctx)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ctx)
+	}
+
 	<-quit
 	log.Info(ctx, "finishing app")
 	cancel()
 	log.Info(ctx, "Finished")
 }
 
-func initProofService(ctx context.Context, config *config.Configuration, circuitLoaderService *loaders.Circuits) ports.ZKGenerator {
+// alertChannels returns the webhook and the email channels of the operator alerts that are configured. The webhook
+// is called with transport.
+func alertChannels(cfg config.Alerts, transport http.RoundTripper) ([]ports.AlertChannel, error) {
+	var channels []ports.AlertChannel
+	if cfg.WebhookURL != "" {
+		webhook, err := gateways.NewAlertWebhook(cfg.WebhookURL, cfg.WebhookAuthorization, cfg.Timeout, transport)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, webhook)
+	}
+	if cfg.SMTPAddress != "" {
+		email, err := gateways.NewAlertEmail(gateways.AlertEmailConfig{
+			Address:  cfg.SMTPAddress,
+			User:     cfg.SMTPUser,
+			Password: cfg.SMTPPassword,
+			From:     cfg.EmailFrom,
+			To:       cfg.Recipients(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, email)
+	}
+	return channels, nil
+}
+
+func initProofService(ctx context.Context, config *config.Configuration, circuitLoaderService *loaders.Circuits, transport http.RoundTripper) ports.ZKGenerator {
 	log.Info(ctx, "native prover enabled", "enabled", config.NativeProofGenerationEnabled)
 	if config.NativeProofGenerationEnabled {
 		proverConfig := &services.NativeProverConfig{
@@ -180,6 +281,7 @@ func initProofService(ctx context.Context, config *config.Configuration, circuit
 	proverConfig := &gateways.ProverConfig{
 		ServerURL:       config.Prover.ServerURL,
 		ResponseTimeout: config.Prover.ResponseTimeout,
+		Transport:       transport,
 	}
 	return gateways.NewProverService(proverConfig)
 }
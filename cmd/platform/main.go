@@ -2,39 +2,53 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"expvar"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	redis2 "github.com/go-redis/redis/v8"
 
 	"github.com/polygonid/sh-id-platform/internal/api"
 	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/event"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/core/services"
 	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/errors"
 	"github.com/polygonid/sh-id-platform/internal/gateways"
 	"github.com/polygonid/sh-id-platform/internal/health"
+	"github.com/polygonid/sh-id-platform/internal/jsonschema"
 	"github.com/polygonid/sh-id-platform/internal/kms"
 	"github.com/polygonid/sh-id-platform/internal/loader"
 	"github.com/polygonid/sh-id-platform/internal/log"
-	"github.com/polygonid/sh-id-platform/internal/providers"
 	"github.com/polygonid/sh-id-platform/internal/providers/blockchain"
 	"github.com/polygonid/sh-id-platform/internal/redis"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/antiabuse"
+	"github.com/polygonid/sh-id-platform/pkg/blobstore"
+	"github.com/polygonid/sh-id-platform/pkg/blockchain/statecache"
 	"github.com/polygonid/sh-id-platform/pkg/cache"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+	client "github.com/polygonid/sh-id-platform/pkg/http"
 	"github.com/polygonid/sh-id-platform/pkg/loaders"
+	"github.com/polygonid/sh-id-platform/pkg/oidc"
+	"github.com/polygonid/sh-id-platform/pkg/policy"
 	"github.com/polygonid/sh-id-platform/pkg/protocol"
 	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+	"github.com/polygonid/sh-id-platform/pkg/trustregistry"
 )
 
+// oidcTimeout bounds the requests for the keys of the OIDC identity provider
+const oidcTimeout = 10 * time.Second
+
 func main() {
 	cfg, err := config.Load("")
 	if err != nil {
@@ -50,72 +64,186 @@ func main() {
 		return
 	}
 
-	storage, err := db.NewStorage(cfg.Database.URL)
+	outbound, err := client.NewTransport(cfg.Outbound.ProxyURL, cfg.Outbound.NoProxy, cfg.Outbound.CABundle)
+	if err != nil {
+		log.Error(ctx, "cannot configure the outbound http clients", "err", err)
+		return
+	}
+	outboundClient := &http.Client{Transport: outbound}
+
+	// The server is started before connecting to the dependencies so the readiness endpoint can report the startup
+	readiness := health.NewReadiness()
+	addresses, err := config.ListenAddresses(cfg.ServerAddress, cfg.ServerPort)
 	if err != nil {
+		log.Error(ctx, "invalid server address", "err", err)
+		return
+	}
+	listeners, err := client.Listen(addresses)
+	if err != nil {
+		log.Error(ctx, "starting http server", "err", err)
+		return
+	}
+	server := &http.Server{
+		Handler: readiness.Handler(),
+	}
+	for _, listener := range listeners {
+		go func(listener net.Listener) {
+			log.Info(ctx, "server started", "address", listener.Addr().String())
+			if err := server.Serve(listener); err != nil {
+				log.Error(ctx, "starting http server", "err", err)
+			}
+		}(listener)
+	}
+
+	startCtx, cancelStart := context.WithTimeout(ctx, cfg.StartupTimeout)
+	defer cancelStart()
+
+	var storage *db.Storage
+	if err := readiness.Connect(startCtx, "postgres", func(context.Context) (err error) {
+		storage, err = db.NewStorage(cfg.Database.URL, db.WithStatementTimeout(cfg.Timeouts.Database), db.WithPool(db.Pool(cfg.Database.Pool)))
+		return err
+	}); err != nil {
 		log.Error(ctx, "cannot connect to database", "err", err)
 		return
 	}
+	if cfg.Database.ReplicaURL != "" {
+		if err := readiness.Connect(startCtx, "postgres replica", func(context.Context) error {
+			return storage.ConnectReplica(cfg.Database.ReplicaURL, db.WithStatementTimeout(cfg.Timeouts.Database), db.WithPool(db.Pool(cfg.Database.Pool)))
+		}); err != nil {
+			log.Error(ctx, "cannot connect to the database replica", "err", err)
+			return
+		}
+	}
 
-	// Redis cache
-	rdb, err := redis.Open(cfg.Cache.RedisUrl)
+	// Cache and pubsub, in redis or in the database when the node runs without redis
+	var rdb *redis2.Client
+	var ps pubsub.Client
+	var cachex cache.Cache
+	if cfg.Cache.Backend == config.CachePostgres {
+		postgresPubSub := pubsub.NewPostgres(storage.Pgx)
+		postgresPubSub.WithLogger(log.Error)
+		postgresCache := cache.NewPostgresCache(storage.Pgx)
+		postgresCache.Run(ctx, cfg.Cache.CleanupPeriod)
+		ps, cachex = postgresPubSub, postgresCache
+	} else {
+		if err := readiness.Connect(startCtx, "redis", func(context.Context) (err error) {
+			rdb, err = redis.Open(cfg.Cache.RedisUrl)
+			return err
+		}); err != nil {
+			log.Error(ctx, "cannot connect to redis", "err", err, "host", cfg.Cache.RedisUrl)
+			return
+		}
+		redisPubSub := pubsub.NewRedis(rdb)
+		redisPubSub.WithLogger(log.Error)
+		ps, cachex = redisPubSub, cache.NewRedisCache(rdb)
+	}
+	baseLoader, err := loader.LocalDirFactory(loader.IPFSFactory(loader.HTTPClientFactory(outboundClient), gateways.NewIPFSFetcher(cfg.IPFS.URL, cfg.IPFS.GatewayURL, outboundClient)), cfg.SchemaBundle.Dir, cfg.SchemaBundle.Order)
 	if err != nil {
-		log.Error(ctx, "cannot connect to redis", "err", err, "host", cfg.Cache.RedisUrl)
+		log.Error(ctx, "cannot load the local schema bundle", "err", err, "dir", cfg.SchemaBundle.Dir)
 		return
 	}
-	ps := pubsub.NewRedis(rdb)
-	ps.WithLogger(log.Error)
-	cachex := cache.NewRedisCache(rdb)
+	mirrors, err := loader.ParseMirrors(cfg.SchemaLoader.Mirrors)
+	if err != nil {
+		log.Error(ctx, "invalid schema mirrors", "err", err)
+		return
+	}
+	baseLoader = loader.ChainFactory([]loader.Source{
+		{Name: "origin", Factory: baseLoader, Timeout: cfg.Timeouts.SchemaLoader},
+		{Name: "mirror", Factory: loader.MirrorFactory(loader.HTTPClientFactory(outboundClient), mirrors), Timeout: cfg.Timeouts.SchemaLoader},
+	},
+		loader.Retry{Attempts: cfg.SchemaLoader.Attempts, Backoff: cfg.SchemaLoader.Backoff, MaxBackoff: cfg.SchemaLoader.MaxBackoff},
+		loader.Breaker{Failures: cfg.SchemaLoader.BreakerFailures, Cooldown: cfg.SchemaLoader.BreakerCooldown},
+	)
 	var schemaLoader loader.Factory
 	if cfg.SchemaCache == nil || !*cfg.SchemaCache {
-		schemaLoader = loader.HTTPFactory
+		schemaLoader = baseLoader
 	} else {
-		schemaLoader = loader.CachedFactory(loader.HTTPFactory, cachex)
+		schemaLoader = loader.CachedFactoryWithTTL(baseLoader, cachex, cfg.SchemaCacheTTL)
 	}
-
-	vaultCli, err := providers.NewVaultClient(cfg.KeyStore.Address, cfg.KeyStore.Token)
+	schemaLoader = loader.StoredFactory(schemaLoader, repositories.NewSchema(*storage))
+	contextsLoader, err := loader.ContextsFactory(schemaLoader, cfg.JSONLD.Contexts)
 	if err != nil {
-		log.Error(ctx, "cannot init vault client: ", "err", err)
+		log.Error(ctx, "invalid JSON-LD contexts", "err", err, "contexts", cfg.JSONLD.Contexts)
 		return
 	}
+	jsonschema.SetDocumentLoader(loader.NewDocumentLoader(contextsLoader, cfg.SchemaCacheTTL), *cfg.JSONLD.SafeMode)
 
-	keyStore, err := kms.Open(cfg.KeyStore.PluginIden3MountPath, vaultCli)
-	if err != nil {
+	var keyStore *kms.KMS
+	if err := readiness.Connect(startCtx, "key store", func(ctx context.Context) (err error) {
+		keyStore, err = kms.NewFromConfig(ctx, cfg)
+		return err
+	}); err != nil {
 		log.Error(ctx, "cannot initialize kms", "err", err)
 		return
 	}
 
-	ethereumClient, err := blockchain.Open(cfg)
-	if err != nil {
-		log.Error(ctx, "error dialing with ethereum client", "err", err)
+	var chain blockchain.Networks
+	if err := readiness.Connect(startCtx, "blockchain", func(ctx context.Context) (err error) {
+		chain, err = blockchain.OpenNetworks(ctx, cfg, storage, keyStore, outbound)
+		return err
+	}); err != nil {
+		log.Error(ctx, "failed init blockchain backend", "err", err, "sandbox", cfg.Sandbox)
 		return
 	}
+	cancelStart()
+
+	if cfg.StateCache.TTL > 0 {
+		chain.CacheResolvers(statecache.Config{TTL: cfg.StateCache.TTL, StaleTTL: cfg.StateCache.StaleTTL, Clock: clock.System})
+		ps.Subscribe(ctx, event.StateTransitionEvent, chain.InvalidateStates)
+	}
+
+	circuitsLoaderService := loaders.NewCircuits(cfg.Circuit.Path)
 
-	stateContract, err := blockchain.InitEthClient(cfg.Ethereum.URL, cfg.Ethereum.ContractAddress)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	networks, err := cfg.Networks()
 	if err != nil {
-		log.Error(ctx, "failed init ethereum client", "err", err)
+		log.Error(ctx, "invalid networks configuration", "err", err)
 		return
 	}
 
-	ethConn, err := blockchain.InitEthConnect(cfg.Ethereum)
+	payloadStore, err := blobstore.Open(cfg.PayloadStore.Backend, cfg.PayloadStore.URL, cfg.PayloadStore.Authorization, outbound)
 	if err != nil {
-		log.Error(ctx, "failed init ethereum connect", "err", err)
+		log.Error(ctx, "cannot initialize the payload store", "err", err)
 		return
 	}
 
-	circuitsLoaderService := loaders.NewCircuits(cfg.Circuit.Path)
+	var policyHook policy.Hook
+	if cfg.PolicyHook.URL != "" {
+		policyHook, err = policy.NewHTTPHook(cfg.PolicyHook.URL, cfg.PolicyHook.Authorization, cfg.PolicyHook.Timeout, outbound)
+		if err != nil {
+			log.Error(ctx, "cannot initialize the policy hook", "err", err)
+			return
+		}
+	}
 
-	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	credentialIDs, err := cfg.CredentialIDs.Scheme()
+	if err != nil {
+		log.Error(ctx, "invalid credential ids", "err", err)
+		return
+	}
+
+	trustRegistry, err := trustregistry.Open(cfg.TrustRegistry.Backend, cfg.TrustRegistry.URL, cfg.TrustRegistry.Authorization, outbound)
+	if err != nil {
+		log.Error(ctx, "cannot initialize the trust registry", "err", err)
+		return
+	}
+	if trustRegistry != nil {
+		trustRegistry = trustregistry.NewCached(trustRegistry, cachex, cfg.TrustRegistry.CacheTTL)
+	}
 
 	// repositories initialization
 	identityRepository := repositories.NewIdentity()
-	claimsRepository := repositories.NewClaims()
+	claimsRepository := repositories.NewClaimsWithPayloadStore(payloadStore, cfg.PayloadStore.Threshold)
 	mtRepository := repositories.NewIdentityMerkleTreeRepository()
 	identityStateRepository := repositories.NewIdentityState()
 	revocationRepository := repositories.NewRevocation()
+	connectionsRepository := repositories.NewConnections()
 
 	// services initialization
 	mtService := services.NewIdentityMerkleTrees(mtRepository)
-	identityService := services.NewIdentity(keyStore, identityRepository, mtRepository, identityStateRepository, mtService, claimsRepository, revocationRepository, nil, storage, rhsp, nil, nil, ps)
+	identityService := services.NewIdentity(keyStore, identityRepository, mtRepository, identityStateRepository, mtService, claimsRepository, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, ps)
+	quotaService := services.NewQuota(storage, domain.Quotas(cfg.Quotas), clock.System)
+	brandingService := services.NewBranding(storage, domain.Branding(cfg.Branding))
 	claimsService := services.NewClaim(
 		claimsRepository,
 		identityService,
@@ -124,41 +252,78 @@ func main() {
 		schemaLoader,
 		storage,
 		services.ClaimCfg{
-			RHSEnabled: cfg.ReverseHashService.Enabled,
-			RHSUrl:     cfg.ReverseHashService.URL,
-			Host:       cfg.ServerUrl,
+			RHSEnabled:           cfg.ReverseHashService.Enabled,
+			RHSUrl:               cfg.ReverseHashService.URL,
+			NetworkRHSUrls:       networks.RHSUrls(),
+			Host:                 cfg.ServerUrl,
+			AgentReplayWindow:    cfg.AgentReplayWindow,
+			ClockSkew:            cfg.ClockSkew,
+			PolicyHook:           policyHook,
+			PolicyFailOpen:       cfg.PolicyHook.FailOpen,
+			HookCaptureSize:      cfg.HookCaptureSize,
+			HookTransport:        outbound,
+			Quotas:               quotaService,
+			CredentialIDs:        credentialIDs,
+			SigningKeyRoundRobin: cfg.SigningKeySelection == config.SigningKeySelectionRoundRobin,
 		},
 		ps,
 	)
-	proofService := gateways.NewProver(ctx, cfg, circuitsLoaderService)
-	revocationService := services.NewRevocationService(ethConn, common.HexToAddress(cfg.Ethereum.ContractAddress))
-	zkProofService := services.NewProofService(claimsService, revocationService, identityService, mtService, claimsRepository, keyStore, storage, stateContract, schemaLoader)
-	transactionService, err := gateways.NewTransaction(ethereumClient, cfg.Ethereum.ConfirmationBlockCount)
-	if err != nil {
-		log.Error(ctx, "error creating transaction service", "err", err)
-		return
+	if cfg.AgentReplayWindow > 0 {
+		repositories.NewAgentMessagePruner(storage.Pgx).Run(ctx, cfg.Cache.CleanupPeriod)
 	}
+	connectionsService := services.NewConnection(connectionsRepository, storage)
+	proofService := gateways.NewProver(ctx, cfg, circuitsLoaderService, outbound)
+	revocationService := services.NewRevocationService(chain, outbound)
+	zkProofService := services.NewProofService(claimsService, revocationService, identityService, mtService, claimsRepository, keyStore, storage, chain, schemaLoader)
+	issuerMetadataService := services.NewIssuerMetadata(
+		services.IssuerMetadataCfg{
+			Host:       cfg.ServerUrl,
+			RHSEnabled: cfg.ReverseHashService.Enabled,
+			RHSUrl:     cfg.ReverseHashService.URL,
+		},
+		identityService,
+		claimsService,
+		repositories.NewSchema(*storage),
+		brandingService,
+	)
+	meteringService := services.NewMetering(storage)
+	maintenanceService := services.NewMaintenance(storage, cfg.Maintenance.RetryAfter, cfg.Maintenance.Refresh, clock.System)
+	regionService := services.NewRegion(storage, cfg.Region.Name, cfg.Region.LeaseTTL, cfg.Region.Refresh, clock.System)
+	go regionService.Run(ctx)
+	publisher := gateways.NewPublisher(storage, identityService, claimsService, mtService, keyStore, chain, proofService, chain, cfg.Ethereum.ConfirmationTimeout, ps)
+	keyRotationService := services.NewKeyRotation(keyStore, claimsService, claimsRepository, identityStateRepository, revocationRepository, publisher, storage, cfg.ServerUrl)
+	signingKeyService := services.NewSigningKeys(keyStore, identityService, claimsService, claimsRepository, storage, cfg.ServerUrl)
+	claimsIngestService := services.NewClaimsIngest(claimsService, cfg.Ingest.Workers, cfg.Ingest.QueueDepth)
+	go claimsIngestService.Run(ctx)
 
-	publisherGateway, err := gateways.NewPublisherEthGateway(ethereumClient, common.HexToAddress(cfg.Ethereum.ContractAddress), keyStore, cfg.PublishingKeyPath)
+	protocolCfg, err := protocol.NewConfig(cfg.Protocol.Circuits, cfg.Protocol.Packers)
 	if err != nil {
-		log.Error(ctx, "error creating publish gateway", "err", err)
+		log.Error(ctx, "invalid protocol configuration", "err", err)
 		return
 	}
+	protocolCfg.Clock = clock.System
+	protocolCfg.ClockSkew = cfg.ClockSkew
+	protocolCfg.Resolvers = chain.Resolvers()
 
-	publisher := gateways.NewPublisher(storage, identityService, claimsService, mtService, keyStore, transactionService, proofService, publisherGateway, cfg.Ethereum.ConfirmationTimeout, ps)
-
-	packageManager, err := protocol.InitPackageManager(ctx, stateContract, zkProofService, cfg.Circuit.Path)
+	packageManager, err := protocol.InitPackageManager(ctx, chain, zkProofService, cfg.Circuit.Path, protocolCfg)
 	if err != nil {
 		log.Error(ctx, "failed init package protocol", "err", err)
 		return
 	}
 
-	serverHealth := health.New(health.Monitors{
+	expvar.Publish("postgres_pool", expvar.Func(func() any { return storage.Stats() }))
+	expvar.Publish("claims_ingest_queue", expvar.Func(func() any { return claimsIngestService.Depth() }))
+
+	monitors := health.Monitors{
 		"postgres": storage.Ping,
-		"redis": func(rdb *redis2.Client) health.Pinger {
-			return func(ctx context.Context) error { return rdb.Ping(ctx).Err() }
-		}(rdb),
-	})
+	}
+	if rdb != nil {
+		monitors["redis"] = func(ctx context.Context) error { return rdb.Ping(ctx).Err() }
+	}
+	if storage.Replica != nil {
+		monitors["postgres replica"] = storage.PingReplica
+	}
+	serverHealth := health.New(monitors)
 	serverHealth.Run(ctx, health.DefaultPingPeriod)
 
 	mux := chi.NewRouter()
@@ -166,41 +331,124 @@ func main() {
 		chiMiddleware.RequestID,
 		log.ChiMiddleware(ctx),
 		chiMiddleware.Recoverer,
-		cors.Handler(cors.Options{AllowedOrigins: []string{"*"}}),
+		client.CORS(client.CORSConfig(cfg.CORS)),
+		client.SecurityHeaders(client.SecurityHeadersConfig(cfg.SecurityHeaders)),
 		chiMiddleware.NoCache,
 	)
+	if cfg.Timeouts.Request > 0 {
+		mux.Use(client.Timeout(cfg.Timeouts.Request, api.IsClaimsStream))
+	}
+	serverDeps := api.Dependencies{
+		Identity:           identityService,
+		Claims:             claimsService,
+		Connections:        connectionsService,
+		Display:            services.NewDisplay(claimsService, brandingService, schemaLoader),
+		CredentialValidity: services.NewCredentialValidity(identityService, claimsService, revocationService, trustRegistry, meteringService, clock.System),
+		IssuerMetadata:     issuerMetadataService,
+		Metering:           meteringService,
+		Maintenance:        maintenanceService,
+		Quota:              quotaService,
+		Branding:           brandingService,
+		Diagnostics:        services.NewDiagnostics(storage),
+		Region:             regionService,
+		KeyRotation:        keyRotationService,
+		SigningKeys:        signingKeyService,
+		ClaimsIngest:       claimsIngestService,
+		Publisher:          publisher,
+		PackageManager:     packageManager,
+		Health:             serverHealth,
+	}
+	if protocolCfg.Accepts(protocol.MediaTypeSignedMessage) {
+		serverDeps.SignedMessages = protocol.NewSignedMessages(signingKeyService.Secp256k1Signers)
+	}
 	api.HandlerFromMux(
 		api.NewStrictHandlerWithOptions(
-			api.NewServer(cfg, identityService, claimsService, publisher, packageManager, serverHealth),
-			middlewares(ctx, cfg.HTTPBasicAuth),
+			api.NewServer(cfg, serverDeps),
+			middlewares(ctx, authMiddleware(ctx, cfg.HTTPBasicAuth, cfg.OIDC, outbound), rateLimitMiddleware(ctx, cfg, rdb, storage), maintenanceService, regionService, cfg.Region.LeaseTTL),
 			api.StrictHTTPServerOptions{
 				RequestErrorHandlerFunc:  errors.RequestErrorHandlerFunc,
 				ResponseErrorHandlerFunc: errors.ResponseErrorHandlerFunc,
 			}),
 		mux)
 	api.RegisterStatic(mux)
-
-	server := &http.Server{
-		Addr:    fmt.Sprintf(":%d", cfg.ServerPort),
-		Handler: mux,
+	// the metrics are only served with basic auth credentials, that an empty user or password would not protect
+	if cfg.HTTPBasicAuth.User != "" && cfg.HTTPBasicAuth.Password != "" {
+		mux.With(chiMiddleware.BasicAuth("metrics", map[string]string{cfg.HTTPBasicAuth.User: cfg.HTTPBasicAuth.Password})).
+			Handle("/debug/vars", expvar.Handler())
+	} else {
+		log.Warn(ctx, "the basic auth credentials are not set, /debug/vars is not served")
 	}
+	readiness.Ready(mux, serverHealth)
+	log.Info(ctx, "server ready", "port", cfg.ServerPort)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
-	go func() {
-		log.Info(ctx, "server started", "port", cfg.ServerPort)
-		if err := server.ListenAndServe(); err != nil {
-			log.Error(ctx, "starting http server", "err", err)
-		}
-	}()
-
 	<-quit
 	log.Info(ctx, "Shutting down")
 }
 
-func middlewares(ctx context.Context, auth config.HTTPBasicAuth) []api.StrictMiddlewareFunc {
+func middlewares(ctx context.Context, auth api.StrictMiddlewareFunc, rateLimit api.StrictMiddlewareFunc, maintenanceService ports.MaintenanceService, regionService ports.RegionService, regionRetryAfter time.Duration) []api.StrictMiddlewareFunc {
 	return []api.StrictMiddlewareFunc{
+		api.MaintenanceMiddleware(maintenanceService),
+		api.RegionMiddleware(regionService, regionRetryAfter),
 		api.LogMiddleware(ctx),
-		api.BasicAuthMiddleware(ctx, auth.User, auth.Password),
+		auth,
+		rateLimit,
+	}
+}
+
+// rateLimitMiddleware throttles the route groups of the issuer API that have a rate limit, in redis or in the database
+// when the node runs without redis
+func rateLimitMiddleware(ctx context.Context, cfg *config.Configuration, rdb *redis2.Client, storage *db.Storage) api.StrictMiddlewareFunc {
+	throttles := make(map[string]antiabuse.Throttle)
+	for group, perMinute := range map[string]int{
+		api.RateLimitAgent:   cfg.RateLimit.Agent,
+		api.RateLimitQRCode:  cfg.RateLimit.QRCode,
+		api.RateLimitStatus:  cfg.RateLimit.Status,
+		api.RateLimitDefault: cfg.RateLimit.Default,
+	} {
+		if perMinute <= 0 {
+			continue
+		}
+		if rdb != nil {
+			throttles[group] = antiabuse.NewRedisIPThrottle(rdb, "api_"+group, perMinute)
+			continue
+		}
+		throttle := antiabuse.NewPostgresIPThrottle(storage.Pgx, "api_"+group, perMinute)
+		throttle.Run(ctx, cfg.Cache.CleanupPeriod)
+		throttles[group] = throttle
+	}
+	return api.RateLimitMiddleware(throttles, cfg.RateLimit.TrustProxy)
+}
+
+// authMiddleware authorizes the requests with the basic auth credentials, or with the bearer tokens of the OIDC
+// identity provider when it is configured
+func authMiddleware(ctx context.Context, auth config.HTTPBasicAuth, oidcCfg config.OIDC, transport http.RoundTripper) api.StrictMiddlewareFunc {
+	basicAuth := api.BasicAuthWithPIIMiddleware(ctx, auth.User, auth.Password, auth.PIIUser, auth.PIIPassword)
+	if !oidcCfg.Enabled() {
+		return basicAuth
+	}
+	// the claims were validated with the configuration
+	requiredClaims, _ := oidc.ParseClaims(oidcCfg.RequiredClaims)
+	piiClaims, _ := oidc.ParseClaims(oidcCfg.PIIClaims)
+	roleClaims, _ := oidcCfg.RoleClaims()
+	var roles []api.RoleClaims
+	for _, role := range []domain.Role{domain.RoleAdmin, domain.RoleOperator, domain.RoleReadOnly} {
+		if claims, ok := roleClaims[role]; ok {
+			roles = append(roles, api.RoleClaims{Role: role, Claims: claims})
+		}
+	}
+	verifier := oidc.NewVerifier(oidc.Config{
+		Issuer:         oidcCfg.Issuer,
+		JWKSURL:        oidcCfg.JWKSURL,
+		Audience:       oidcCfg.Audience,
+		RequiredClaims: requiredClaims,
+		Client:         &http.Client{Timeout: oidcTimeout, Transport: transport},
+	})
+	log.Info(ctx, "the API accepts the bearer tokens of the identity provider", "issuer", oidcCfg.Issuer, "basicAuth", oidcCfg.AllowBasicAuth)
+	if !oidcCfg.AllowBasicAuth {
+		basicAuth = nil
 	}
+	return api.OIDCAuthMiddleware(verifier, piiClaims, roles, basicAuth)
 }
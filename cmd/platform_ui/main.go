@@ -3,40 +3,46 @@ package main
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"expvar"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	redis2 "github.com/go-redis/redis/v8"
 	auth "github.com/iden3/go-iden3-auth"
 	authLoaders "github.com/iden3/go-iden3-auth/loaders"
-	"github.com/iden3/go-iden3-auth/pubsignals"
-	"github.com/iden3/go-iden3-auth/state"
 	core "github.com/iden3/go-iden3-core"
 
 	"github.com/polygonid/sh-id-platform/internal/api_ui"
 	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/event"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/core/services"
 	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/errors"
 	"github.com/polygonid/sh-id-platform/internal/gateways"
 	"github.com/polygonid/sh-id-platform/internal/health"
+	"github.com/polygonid/sh-id-platform/internal/jsonschema"
 	"github.com/polygonid/sh-id-platform/internal/kms"
 	"github.com/polygonid/sh-id-platform/internal/loader"
 	"github.com/polygonid/sh-id-platform/internal/log"
-	"github.com/polygonid/sh-id-platform/internal/providers"
 	"github.com/polygonid/sh-id-platform/internal/providers/blockchain"
 	"github.com/polygonid/sh-id-platform/internal/redis"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/antiabuse"
+	"github.com/polygonid/sh-id-platform/pkg/blobstore"
+	"github.com/polygonid/sh-id-platform/pkg/blockchain/statecache"
 	"github.com/polygonid/sh-id-platform/pkg/cache"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+	client "github.com/polygonid/sh-id-platform/pkg/http"
 	"github.com/polygonid/sh-id-platform/pkg/loaders"
+	"github.com/polygonid/sh-id-platform/pkg/notifications"
+	"github.com/polygonid/sh-id-platform/pkg/policy"
 	"github.com/polygonid/sh-id-platform/pkg/protocol"
 	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
@@ -57,88 +63,200 @@ func main() {
 		return
 	}
 
-	storage, err := db.NewStorage(cfg.Database.URL)
+	outbound, err := client.NewTransport(cfg.Outbound.ProxyURL, cfg.Outbound.NoProxy, cfg.Outbound.CABundle)
 	if err != nil {
-		log.Error(ctx, "cannot connect to database", "err", err)
+		log.Error(ctx, "cannot configure the outbound http clients", "err", err)
 		return
 	}
+	outboundClient := &http.Client{Transport: outbound}
 
-	// Redis cache
-	rdb, err := redis.Open(cfg.Cache.RedisUrl)
+	// The server is started before connecting to the dependencies so the readiness endpoint can report the startup
+	readiness := health.NewReadiness()
+	addresses, err := config.ListenAddresses(cfg.APIUI.ServerAddress, cfg.APIUI.ServerPort)
 	if err != nil {
-		log.Error(ctx, "cannot connect to redis", "err", err, "host", cfg.Cache.RedisUrl)
+		log.Error(ctx, "invalid server address", "err", err)
 		return
 	}
-	ps := pubsub.NewRedis(rdb)
-	ps.WithLogger(log.Error)
-	cachex := cache.NewRedisCache(rdb)
+	listeners, err := client.Listen(addresses)
+	if err != nil {
+		log.Error(ctx, "starting HTTP UI API server", "err", err)
+		return
+	}
+	server := &http.Server{
+		Handler: readiness.Handler(),
+	}
+	for _, listener := range listeners {
+		go func(listener net.Listener) {
+			log.Info(ctx, "UI API server started", "address", listener.Addr().String())
+			if err := server.Serve(listener); err != nil {
+				log.Error(ctx, "starting HTTP UI API server", "err", err)
+			}
+		}(listener)
+	}
 
-	var schemaLoader loader.Factory
-	if cfg.APIUI.SchemaCache == nil || !*cfg.APIUI.SchemaCache {
-		schemaLoader = loader.HTTPFactory
+	startCtx, cancelStart := context.WithTimeout(ctx, cfg.StartupTimeout)
+	defer cancelStart()
+
+	var storage *db.Storage
+	if err := readiness.Connect(startCtx, "postgres", func(context.Context) (err error) {
+		storage, err = db.NewStorage(cfg.Database.URL, db.WithStatementTimeout(cfg.Timeouts.Database), db.WithPool(db.Pool(cfg.Database.Pool)))
+		return err
+	}); err != nil {
+		log.Error(ctx, "cannot connect to database", "err", err)
+		return
+	}
+	if cfg.Database.ReplicaURL != "" {
+		if err := readiness.Connect(startCtx, "postgres replica", func(context.Context) error {
+			return storage.ConnectReplica(cfg.Database.ReplicaURL, db.WithStatementTimeout(cfg.Timeouts.Database), db.WithPool(db.Pool(cfg.Database.Pool)))
+		}); err != nil {
+			log.Error(ctx, "cannot connect to the database replica", "err", err)
+			return
+		}
+	}
+
+	// Cache and pubsub, in redis or in the database when the node runs without redis
+	var rdb *redis2.Client
+	var ps pubsub.Client
+	var cachex cache.Cache
+	if cfg.Cache.Backend == config.CachePostgres {
+		postgresPubSub := pubsub.NewPostgres(storage.Pgx)
+		postgresPubSub.WithLogger(log.Error)
+		postgresCache := cache.NewPostgresCache(storage.Pgx)
+		postgresCache.Run(ctx, cfg.Cache.CleanupPeriod)
+		ps, cachex = postgresPubSub, postgresCache
 	} else {
-		schemaLoader = loader.CachedFactory(loader.HTTPFactory, cachex)
+		if err := readiness.Connect(startCtx, "redis", func(context.Context) (err error) {
+			rdb, err = redis.Open(cfg.Cache.RedisUrl)
+			return err
+		}); err != nil {
+			log.Error(ctx, "cannot connect to redis", "err", err, "host", cfg.Cache.RedisUrl)
+			return
+		}
+		redisPubSub := pubsub.NewRedis(rdb)
+		redisPubSub.WithLogger(log.Error)
+		ps, cachex = redisPubSub, cache.NewRedisCache(rdb)
 	}
 
-	vaultCli, err := providers.NewVaultClient(cfg.KeyStore.Address, cfg.KeyStore.Token)
+	baseLoader, err := loader.LocalDirFactory(loader.IPFSFactory(loader.HTTPClientFactory(outboundClient), gateways.NewIPFSFetcher(cfg.IPFS.URL, cfg.IPFS.GatewayURL, outboundClient)), cfg.SchemaBundle.Dir, cfg.SchemaBundle.Order)
 	if err != nil {
-		log.Error(ctx, "cannot init vault client: ", "err", err)
+		log.Error(ctx, "cannot load the local schema bundle", "err", err, "dir", cfg.SchemaBundle.Dir)
 		return
 	}
-
-	keyStore, err := kms.Open(cfg.KeyStore.PluginIden3MountPath, vaultCli)
+	mirrors, err := loader.ParseMirrors(cfg.SchemaLoader.Mirrors)
 	if err != nil {
-		log.Error(ctx, "cannot initialize kms", "err", err)
+		log.Error(ctx, "invalid schema mirrors", "err", err)
 		return
 	}
-
-	ethereumClient, err := blockchain.Open(cfg)
+	baseLoader = loader.ChainFactory([]loader.Source{
+		{Name: "origin", Factory: baseLoader, Timeout: cfg.Timeouts.SchemaLoader},
+		{Name: "mirror", Factory: loader.MirrorFactory(loader.HTTPClientFactory(outboundClient), mirrors), Timeout: cfg.Timeouts.SchemaLoader},
+	},
+		loader.Retry{Attempts: cfg.SchemaLoader.Attempts, Backoff: cfg.SchemaLoader.Backoff, MaxBackoff: cfg.SchemaLoader.MaxBackoff},
+		loader.Breaker{Failures: cfg.SchemaLoader.BreakerFailures, Cooldown: cfg.SchemaLoader.BreakerCooldown},
+	)
+	var schemaLoader loader.Factory
+	if cfg.APIUI.SchemaCache == nil || !*cfg.APIUI.SchemaCache {
+		schemaLoader = baseLoader
+	} else {
+		schemaLoader = loader.CachedFactoryWithTTL(baseLoader, cachex, cfg.SchemaCacheTTL)
+	}
+	schemaLoader = loader.StoredFactory(schemaLoader, repositories.NewSchema(*storage))
+	contextsLoader, err := loader.ContextsFactory(schemaLoader, cfg.JSONLD.Contexts)
 	if err != nil {
-		log.Error(ctx, "error dialing with ethereum client", "err", err)
+		log.Error(ctx, "invalid JSON-LD contexts", "err", err, "contexts", cfg.JSONLD.Contexts)
 		return
 	}
+	jsonschema.SetDocumentLoader(loader.NewDocumentLoader(contextsLoader, cfg.SchemaCacheTTL), *cfg.JSONLD.SafeMode)
 
-	stateContract, err := blockchain.InitEthClient(cfg.Ethereum.URL, cfg.Ethereum.ContractAddress)
-	if err != nil {
-		log.Error(ctx, "failed init ethereum client", "err", err)
+	var keyStore *kms.KMS
+	if err := readiness.Connect(startCtx, "key store", func(ctx context.Context) (err error) {
+		keyStore, err = kms.NewFromConfig(ctx, cfg)
+		return err
+	}); err != nil {
+		log.Error(ctx, "cannot initialize kms", "err", err)
 		return
 	}
 
-	ethConn, err := blockchain.InitEthConnect(cfg.Ethereum)
-	if err != nil {
-		log.Error(ctx, "failed init ethereum connect", "err", err)
+	var chain blockchain.Networks
+	if err := readiness.Connect(startCtx, "blockchain", func(ctx context.Context) (err error) {
+		chain, err = blockchain.OpenNetworks(ctx, cfg, storage, keyStore, outbound)
+		return err
+	}); err != nil {
+		log.Error(ctx, "failed init blockchain backend", "err", err, "sandbox", cfg.Sandbox)
 		return
 	}
+	cancelStart()
 
-	verificationKeyLoader := &authLoaders.FSKeyLoader{Dir: cfg.Circuit.Path + "/authV2"}
-	resolvers := map[string]pubsignals.StateResolver{
-		cfg.Ethereum.ResolverPrefix: state.ETHResolver{
-			RPCUrl:          cfg.Ethereum.URL,
-			ContractAddress: common.HexToAddress(cfg.Ethereum.ContractAddress),
-		},
+	if cfg.StateCache.TTL > 0 {
+		chain.CacheResolvers(statecache.Config{TTL: cfg.StateCache.TTL, StaleTTL: cfg.StateCache.StaleTTL, Clock: clock.System})
+		ps.Subscribe(ctx, event.StateTransitionEvent, chain.InvalidateStates)
 	}
 
+	verificationKeyLoader := loaders.NewVerificationKeys(cfg.Circuit.Path)
+	resolvers := chain.Resolvers()
+
 	verifier := auth.NewVerifier(verificationKeyLoader, authLoaders.DefaultSchemaLoader{IpfsURL: "ipfs.io"}, resolvers)
 
 	circuitsLoaderService := loaders.NewCircuits(cfg.Circuit.Path)
 
 	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	networks, err := cfg.Networks()
+	if err != nil {
+		log.Error(ctx, "invalid networks configuration", "err", err)
+		return
+	}
+
+	payloadStore, err := blobstore.Open(cfg.PayloadStore.Backend, cfg.PayloadStore.URL, cfg.PayloadStore.Authorization, outbound)
+	if err != nil {
+		log.Error(ctx, "cannot initialize the payload store", "err", err)
+		return
+	}
+	if payloadStore != nil {
+		repositories.NewPayloadCollector(storage.Pgx, payloadStore).Run(ctx, cfg.PayloadStore.CleanupPeriod)
+	}
+
+	var policyHook policy.Hook
+	if cfg.PolicyHook.URL != "" {
+		policyHook, err = policy.NewHTTPHook(cfg.PolicyHook.URL, cfg.PolicyHook.Authorization, cfg.PolicyHook.Timeout, outbound)
+		if err != nil {
+			log.Error(ctx, "cannot initialize the policy hook", "err", err)
+			return
+		}
+	}
+
+	credentialIDs, err := cfg.CredentialIDs.Scheme()
+	if err != nil {
+		log.Error(ctx, "invalid credential ids", "err", err)
+		return
+	}
 
 	// repositories initialization
 	identityRepository := repositories.NewIdentity()
-	claimsRepository := repositories.NewClaims()
+	claimsRepository := repositories.NewClaimsWithPayloadStore(payloadStore, cfg.PayloadStore.Threshold)
 	mtRepository := repositories.NewIdentityMerkleTreeRepository()
 	identityStateRepository := repositories.NewIdentityState()
 	revocationRepository := repositories.NewRevocation()
 	connectionsRepository := repositories.NewConnections()
-	sessionRepository := repositories.NewSessionCached(cachex)
+	sessionTTLs := repositories.SessionTTLs{AuthRequest: cfg.SessionStore.AuthRequestTTL, LinkState: cfg.SessionStore.LinkStateTTL, Clock: clock.System}
+	var sessionRepository ports.SessionRepository
+	if cfg.SessionStore.Backend == config.SessionStorePostgres {
+		sessionRepository = repositories.NewSessionPostgres(*storage, sessionTTLs)
+	} else {
+		sessionRepository = repositories.NewSessionCachedWithTTLs(cachex, sessionTTLs)
+	}
 	linkRepository := repositories.NewLink(*storage)
 	schemaRepository := repositories.NewSchema(*storage)
 
 	// services initialization
 	mtService := services.NewIdentityMerkleTrees(mtRepository)
 	identityService := services.NewIdentity(keyStore, identityRepository, mtRepository, identityStateRepository, mtService, claimsRepository, revocationRepository, connectionsRepository, storage, rhsp, verifier, sessionRepository, ps)
-	schemaService := services.NewSchema(schemaRepository, schemaLoader)
+	quotaService := services.NewQuota(storage, domain.Quotas(cfg.Quotas), clock.System)
+	brandingService := services.NewBranding(storage, domain.Branding(cfg.Branding))
+	var ipfsGateway ports.IPFSGateway
+	if cfg.IPFS.URL != "" {
+		ipfsGateway = gateways.NewIPFSClient(cfg.IPFS.URL, outboundClient)
+	}
+	schemaService := services.NewSchema(schemaRepository, schemaLoader, quotaService, ipfsGateway, cfg.IPFS.PinImported)
 	claimsService := services.NewClaim(
 		claimsRepository,
 		identityService,
@@ -147,62 +265,133 @@ func main() {
 		schemaLoader,
 		storage,
 		services.ClaimCfg{
-			RHSEnabled: cfg.ReverseHashService.Enabled,
-			RHSUrl:     cfg.ReverseHashService.URL,
-			Host:       cfg.APIUI.ServerURL,
+			RHSEnabled:           cfg.ReverseHashService.Enabled,
+			RHSUrl:               cfg.ReverseHashService.URL,
+			NetworkRHSUrls:       networks.RHSUrls(),
+			Host:                 cfg.APIUI.ServerURL,
+			AgentReplayWindow:    cfg.AgentReplayWindow,
+			ClockSkew:            cfg.ClockSkew,
+			PolicyHook:           policyHook,
+			PolicyFailOpen:       cfg.PolicyHook.FailOpen,
+			HookCaptureSize:      cfg.HookCaptureSize,
+			HookTransport:        outbound,
+			Quotas:               quotaService,
+			CredentialIDs:        credentialIDs,
+			SigningKeyRoundRobin: cfg.SigningKeySelection == config.SigningKeySelectionRoundRobin,
 		},
 		ps,
 	)
+	if cfg.AgentReplayWindow > 0 {
+		repositories.NewAgentMessagePruner(storage.Pgx).Run(ctx, cfg.Cache.CleanupPeriod)
+	}
 	connectionsService := services.NewConnection(connectionsRepository, storage)
-	linkService := services.NewLinkService(storage, claimsService, claimsRepository, linkRepository, schemaRepository, schemaLoader, sessionRepository, ps)
-	proofService := gateways.NewProver(ctx, cfg, circuitsLoaderService)
-	revocationService := services.NewRevocationService(ethConn, common.HexToAddress(cfg.Ethereum.ContractAddress))
-	zkProofService := services.NewProofService(claimsService, revocationService, identityService, mtService, claimsRepository, keyStore, storage, stateContract, schemaLoader)
-	transactionService, err := gateways.NewTransaction(ethereumClient, cfg.Ethereum.ConfirmationBlockCount)
+	subjectService := services.NewSubject(claimsRepository, connectionsRepository, repositories.NewSubject(), storage)
+	pushGateways, err := notifications.ParseGateways(cfg.PushGateways.URLs)
 	if err != nil {
-		log.Error(ctx, "error creating transaction service", "err", err)
+		log.Error(ctx, "invalid push gateways", "err", err)
 		return
 	}
-
-	publisherGateway, err := gateways.NewPublisherEthGateway(ethereumClient, common.HexToAddress(cfg.Ethereum.ContractAddress), keyStore, cfg.PublishingKeyPath)
+	if pushGateways != nil {
+		pushGateways.Run(ctx, cfg.PushGateways.HealthCheckPeriod, notifications.HTTPHealthCheck(cfg.PushGateways.HealthCheckPeriod, outbound))
+	}
+	notificationService := services.NewNotification(gateways.NewPushNotificationClientWithGateways(client.NewClientWithRetry(outbound), pushGateways), connectionsService, claimsService)
+	linkService := services.NewLinkService(storage, claimsService, claimsRepository, linkRepository, schemaRepository, schemaLoader, sessionRepository, ps, quotaService, clock.System)
+	proofService := gateways.NewProver(ctx, cfg, circuitsLoaderService, outbound)
+	revocationService := services.NewRevocationService(chain, outbound)
+	zkProofService := services.NewProofService(claimsService, revocationService, identityService, mtService, claimsRepository, keyStore, storage, chain, schemaLoader)
+	publisher := gateways.NewPublisher(storage, identityService, claimsService, mtService, keyStore, chain, proofService, chain, cfg.Ethereum.ConfirmationTimeout, ps)
+
+	protocolCfg, err := protocol.NewConfig(cfg.Protocol.Circuits, cfg.Protocol.Packers)
 	if err != nil {
-		log.Error(ctx, "error creating publish gateway", "err", err)
+		log.Error(ctx, "invalid protocol configuration", "err", err)
 		return
 	}
+	protocolCfg.Clock = clock.System
+	protocolCfg.ClockSkew = cfg.ClockSkew
+	protocolCfg.Resolvers = resolvers
 
-	publisher := gateways.NewPublisher(storage, identityService, claimsService, mtService, keyStore, transactionService, proofService, publisherGateway, cfg.Ethereum.ConfirmationTimeout, ps)
-
-	packageManager, err := protocol.InitPackageManager(ctx, stateContract, zkProofService, cfg.Circuit.Path)
+	packageManager, err := protocol.InitPackageManager(ctx, chain, zkProofService, cfg.Circuit.Path, protocolCfg)
 	if err != nil {
 		log.Error(ctx, "failed init package protocol", "err", err)
 		return
 	}
 
-	serverHealth := health.New(health.Monitors{
+	expvar.Publish("postgres_pool", expvar.Func(func() any { return storage.Stats() }))
+
+	monitors := health.Monitors{
 		"postgres": storage.Ping,
-		"redis": func(rdb *redis2.Client) health.Pinger {
-			return func(ctx context.Context) error { return rdb.Ping(ctx).Err() }
-		}(rdb),
-	})
+	}
+	if rdb != nil {
+		monitors["redis"] = func(ctx context.Context) error { return rdb.Ping(ctx).Err() }
+	}
+	if storage.Replica != nil {
+		monitors["postgres replica"] = storage.PingReplica
+	}
+	serverHealth := health.New(monitors)
 	serverHealth.Run(ctx, health.DefaultPingPeriod)
 
 	if !identifierExists(ctx, &cfg.APIUI.IssuerDID, identityService) {
 		log.Error(ctx, "issuer DID must exist")
 		return
 	}
+	for _, tenant := range cfg.APIUI.Tenants {
+		if !identifierExists(ctx, &tenant.DID, identityService) {
+			log.Error(ctx, "the DID of the tenant must exist", "did", tenant.DID.String(), "user", tenant.User)
+			return
+		}
+	}
+
+	antiAbuse := cfg.APIUI.AntiAbuse
+	challenger, err := antiabuse.NewChallenger(antiabuse.ChallengeConfig{
+		Type:             antiAbuse.Challenge,
+		CaptchaVerifyURL: antiAbuse.CaptchaVerifyURL,
+		CaptchaSecret:    antiAbuse.CaptchaSecret,
+		CaptchaSiteKey:   antiAbuse.CaptchaSiteKey,
+		CaptchaTransport: outbound,
+		PoWDifficulty:    antiAbuse.PoWDifficulty,
+		PoWSecret:        antiAbuse.PoWSecret,
+	})
+	if err != nil {
+		log.Error(ctx, "invalid anti-abuse challenge configuration", "err", err)
+		return
+	}
+	newThrottle := func(scope string, perMinute int) antiabuse.Throttle {
+		if antiAbuse.ThrottleBackend != config.ThrottlePostgres {
+			return antiabuse.NewIPThrottle(perMinute)
+		}
+		throttle := antiabuse.NewPostgresIPThrottle(storage.Pgx, scope, perMinute)
+		throttle.Run(ctx, cfg.Cache.CleanupPeriod)
+		return throttle
+	}
+	var throttle antiabuse.Throttle
+	if antiAbuse.RateLimit > 0 {
+		throttle = newThrottle("links", antiAbuse.RateLimit)
+	}
+	var schemaProxyThrottle antiabuse.Throttle
+	if cfg.APIUI.SchemaProxyRateLimit > 0 {
+		schemaProxyThrottle = newThrottle("schema_proxy", cfg.APIUI.SchemaProxyRateLimit)
+	}
+
+	maintenanceService := services.NewMaintenance(storage, cfg.Maintenance.RetryAfter, cfg.Maintenance.Refresh, clock.System)
+	regionService := services.NewRegion(storage, cfg.Region.Name, cfg.Region.LeaseTTL, cfg.Region.Refresh, clock.System)
+	go regionService.Run(ctx)
 
 	mux := chi.NewRouter()
 	mux.Use(
 		chiMiddleware.RequestID,
 		log.ChiMiddleware(ctx),
 		chiMiddleware.Recoverer,
-		cors.AllowAll().Handler,
+		client.CORS(client.CORSConfig(cfg.APIUI.CORS)),
+		client.SecurityHeaders(client.SecurityHeadersConfig(cfg.APIUI.SecurityHeaders)),
 		chiMiddleware.NoCache,
 	)
+	if cfg.Timeouts.Request > 0 {
+		mux.Use(chiMiddleware.Timeout(cfg.Timeouts.Request))
+	}
 	api_ui.HandlerWithOptions(
 		api_ui.NewStrictHandlerWithOptions(
-			api_ui.NewServer(cfg, identityService, claimsService, schemaService, connectionsService, linkService, publisher, packageManager, serverHealth),
-			middlewares(ctx, cfg.APIUI.APIUIAuth),
+			api_ui.NewServer(cfg, identityService, claimsService, schemaService, connectionsService, linkService, subjectService, notificationService, brandingService, publisher, packageManager, serverHealth),
+			middlewares(ctx, cfg.APIUI, claimsService, identityService, api_ui.AntiAbuseMiddleware(challenger, throttle, cfg.APIUI.AntiAbuse.TrustProxy), api_ui.SchemaProxyMiddleware(schemaProxyThrottle, cfg.APIUI.AntiAbuse.TrustProxy), api_ui.MaintenanceMiddleware(maintenanceService), api_ui.RegionMiddleware(regionService, cfg.Region.LeaseTTL)),
 			api_ui.StrictHTTPServerOptions{
 				RequestErrorHandlerFunc:  errors.RequestErrorHandlerFunc,
 				ResponseErrorHandlerFunc: errors.ResponseErrorHandlerFunc,
@@ -213,21 +402,19 @@ func main() {
 		},
 	)
 	api_ui.RegisterStatic(mux)
-
-	server := &http.Server{
-		Addr:    fmt.Sprintf(":%d", cfg.APIUI.ServerPort),
-		Handler: mux,
+	// the metrics are only served with basic auth credentials, that an empty user or password would not protect
+	if cfg.APIUI.APIUIAuth.User != "" && cfg.APIUI.APIUIAuth.Password != "" {
+		mux.With(chiMiddleware.BasicAuth("metrics", map[string]string{cfg.APIUI.APIUIAuth.User: cfg.APIUI.APIUIAuth.Password})).
+			Handle("/debug/vars", expvar.Handler())
+	} else {
+		log.Warn(ctx, "the basic auth credentials are not set, /debug/vars is not served")
 	}
+	readiness.Ready(mux, serverHealth)
+	log.Info(ctx, "UI API server ready", "port", cfg.APIUI.ServerPort)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
-	go func() {
-		log.Info(ctx, "UI API server started", "port", cfg.APIUI.ServerPort)
-		if err := server.ListenAndServe(); err != nil {
-			log.Error(ctx, "starting HTTP UI API server", "err", err)
-		}
-	}()
-
 	<-quit
 	log.Info(ctx, "Shutting down")
 }
@@ -237,10 +424,16 @@ func identifierExists(ctx context.Context, did *core.DID, service ports.Identity
 	return err == nil
 }
 
-func middlewares(ctx context.Context, auth config.APIUIAuth) []api_ui.StrictMiddlewareFunc {
+func middlewares(ctx context.Context, apiUI config.APIUI, claimsService ports.ClaimsService, identityService ports.IdentityService, antiAbuse api_ui.StrictMiddlewareFunc, schemaProxy api_ui.StrictMiddlewareFunc, maintenance api_ui.StrictMiddlewareFunc, region api_ui.StrictMiddlewareFunc) []api_ui.StrictMiddlewareFunc {
+	auth := apiUI.APIUIAuth
 	return []api_ui.StrictMiddlewareFunc{
+		maintenance,
+		region,
+		antiAbuse,
+		schemaProxy,
 		api_ui.LogMiddleware(ctx),
-		api_ui.BasicAuthMiddleware(ctx, auth.User, auth.Password),
+		api_ui.BasicAuthWithTenantsMiddleware(ctx, auth.User, auth.Password, auth.PIIUser, auth.PIIPassword, apiUI.Tenants, apiUI.IssuerDID, claimsService),
+		api_ui.TenantMiddleware(identityService),
 	}
 }
 
@@ -13,12 +13,71 @@ import (
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/go-chi/chi/v5"
+	uuid "github.com/google/uuid"
+	"github.com/iden3/go-schema-processor/verifiable"
 )
 
 const (
 	BasicAuthScopes = "basicAuth.Scopes"
 )
 
+// Defines values for CredentialValidityResponseVerdict.
+const (
+	Expired CredentialValidityResponseVerdict = "expired"
+	Revoked CredentialValidityResponseVerdict = "revoked"
+	Unknown CredentialValidityResponseVerdict = "unknown"
+	Valid   CredentialValidityResponseVerdict = "valid"
+)
+
+// Defines values for DataModel.
+const (
+	N11 DataModel = "1.1"
+	N20 DataModel = "2.0"
+)
+
+// Defines values for GetClaimDisplayParamsFormat.
+const (
+	GetClaimDisplayParamsFormatJson GetClaimDisplayParamsFormat = "json"
+	GetClaimDisplayParamsFormatSvg  GetClaimDisplayParamsFormat = "svg"
+)
+
+// Defines values for GetMeteringParamsFormat.
+const (
+	GetMeteringParamsFormatCsv  GetMeteringParamsFormat = "csv"
+	GetMeteringParamsFormatJson GetMeteringParamsFormat = "json"
+)
+
+// Defines values for KeyRotationStatus.
+const (
+	Adding    KeyRotationStatus = "adding"
+	Completed KeyRotationStatus = "completed"
+	Revoking  KeyRotationStatus = "revoking"
+)
+
+// Defines values for ProofType.
+const (
+	BJJSignature2021           ProofType = "BJJSignature2021"
+	Iden3SparseMerkleTreeProof ProofType = "Iden3SparseMerkleTreeProof"
+)
+
+// Defines values for SigningKeyStatus.
+const (
+	Active  SigningKeyStatus = "active"
+	Pending SigningKeyStatus = "pending"
+)
+
+// Defines values for SigningKeyType.
+const (
+	BJJ       SigningKeyType = "BJJ"
+	Secp256k1 SigningKeyType = "secp256k1"
+)
+
+// Defines values for ValidationMode.
+const (
+	Lenient ValidationMode = "lenient"
+	Strict  ValidationMode = "strict"
+)
+
 // AgentResponse defines model for AgentResponse.
 type AgentResponse struct {
 	Body     interface{} `json:"body"`
@@ -30,29 +89,57 @@ type AgentResponse struct {
 	Type     string      `json:"type"`
 }
 
+// Branding defines model for Branding.
+type Branding struct {
+	DisplayName  string `json:"displayName"`
+	Logo         string `json:"logo"`
+	PrimaryColor string `json:"primaryColor"`
+	TextColor    string `json:"textColor"`
+}
+
 // CreateClaimRequest defines model for CreateClaimRequest.
 type CreateClaimRequest struct {
 	CredentialSchema      string                 `json:"credentialSchema"`
 	CredentialSubject     map[string]interface{} `json:"credentialSubject"`
+	DisplayMethod         *DisplayMethod         `json:"displayMethod,omitempty"`
 	Expiration            *int64                 `json:"expiration,omitempty"`
 	MerklizedRootPosition *string                `json:"merklizedRootPosition,omitempty"`
-	RevNonce              *uint64                `json:"revNonce,omitempty"`
-	SubjectPosition       *string                `json:"subjectPosition,omitempty"`
-	Type                  string                 `json:"type"`
-	Version               *uint32                `json:"version,omitempty"`
+
+	// MtProof Attach an Iden3SparseMerkleTreeProof once the identity state is published.
+	MtProof  *bool   `json:"mtProof,omitempty"`
+	RevNonce *uint64 `json:"revNonce,omitempty"`
+
+	// SignatureProof Attach a BJJSignature2021 proof. If neither this nor mtProof are true, the schema or identity defaults are used.
+	SignatureProof *bool `json:"signatureProof,omitempty"`
+
+	// SigningKeyID The key of the key store that signs the signature proof, one of the active signing keys of the identity. A secp256k1 key signs an EcdsaSecp256k1Signature2019 proof instead of a BJJSignature2021 one. By default the node picks a BabyJubJub key.
+	SigningKeyID    *string `json:"signingKeyID,omitempty"`
+	SubjectPosition *string `json:"subjectPosition,omitempty"`
+	Type            string  `json:"type"`
+
+	// ValidationMode How the credential subject is checked against the schema. strict rejects the attributes that are not in
+	// the schema and the values that don't have its types. lenient converts the values given as strings and drops the
+	// attributes that are not in the schema, with a warning for each one. The default is strict.
+	ValidationMode *ValidationMode `json:"validationMode,omitempty"`
+	Version        *uint32         `json:"version,omitempty"`
 }
 
 // CreateClaimResponse defines model for CreateClaimResponse.
 type CreateClaimResponse struct {
 	Id string `json:"id"`
+
+	// Warnings Warnings of the lenient validation of the credential subject, one for each converted or dropped attribute.
+	Warnings *[]string `json:"warnings,omitempty"`
 }
 
 // CreateIdentityRequest defines model for CreateIdentityRequest.
 type CreateIdentityRequest struct {
 	DidMetadata struct {
 		Blockchain string `json:"blockchain"`
-		Method     string `json:"method"`
-		Network    string `json:"network"`
+
+		// Method DID method of the identity, iden3 or polygonid unless other drivers are registered
+		Method  string `json:"method"`
+		Network string `json:"network"`
 	} `json:"didMetadata"`
 }
 
@@ -62,12 +149,92 @@ type CreateIdentityResponse struct {
 	State      *IdentityState `json:"state,omitempty"`
 }
 
+// CreateSchemaWebhookRequest defines model for CreateSchemaWebhookRequest.
+type CreateSchemaWebhookRequest struct {
+	// Authorization Authorization header of the webhook requests
+	Authorization *string `json:"authorization,omitempty"`
+
+	// Events The events the webhook is notified of, issued and revoked. Both by default.
+	Events     *[]string `json:"events,omitempty"`
+	SchemaType string    `json:"schemaType"`
+
+	// Url http or https url the events are POSTed to
+	Url string `json:"url"`
+}
+
+// CredentialDisplayResponse defines model for CredentialDisplayResponse.
+type CredentialDisplayResponse struct {
+	BackgroundColor      string        `json:"backgroundColor"`
+	BackgroundImageUrl   string        `json:"backgroundImageUrl"`
+	CredentialID         string        `json:"credentialID"`
+	Description          string        `json:"description"`
+	DescriptionTextColor string        `json:"descriptionTextColor"`
+	DisplayMethod        DisplayMethod `json:"displayMethod"`
+	IssuerName           string        `json:"issuerName"`
+	IssuerTextColor      string        `json:"issuerTextColor"`
+	Logo                 struct {
+		Alt string `json:"alt"`
+		Uri string `json:"uri"`
+	} `json:"logo"`
+	Title          string `json:"title"`
+	TitleTextColor string `json:"titleTextColor"`
+}
+
 // CredentialSchema defines model for CredentialSchema.
 type CredentialSchema struct {
 	Id   string `json:"id"`
 	Type string `json:"type"`
 }
 
+// CredentialValidityRequest A W3C credential as issued, including its credentialStatus
+type CredentialValidityRequest = verifiable.W3CCredential
+
+// CredentialValidityResponse defines model for CredentialValidityResponse.
+type CredentialValidityResponse struct {
+	// Accreditation Answer of the trust registry, only when the node has one configured
+	Accreditation        *IssuerAccreditation `json:"accreditation,omitempty"`
+	CheckedAt            time.Time            `json:"checkedAt"`
+	CredentialStatusType string               `json:"credentialStatusType"`
+	ExpirationDate       *time.Time           `json:"expirationDate,omitempty"`
+	Expired              bool                 `json:"expired"`
+	Issuer               string               `json:"issuer"`
+
+	// LocalIssuer The issuer is an identity of this node
+	LocalIssuer     bool    `json:"localIssuer"`
+	Reason          *string `json:"reason,omitempty"`
+	RevocationNonce uint64  `json:"revocationNonce"`
+
+	// Revoked Null when the revocation status cannot be resolved
+	Revoked *bool                             `json:"revoked"`
+	Verdict CredentialValidityResponseVerdict `json:"verdict"`
+}
+
+// CredentialValidityResponseVerdict defines model for CredentialValidityResponse.Verdict.
+type CredentialValidityResponseVerdict string
+
+// DisplayMethod Template used by the wallets to render the credential card. Only Iden3BasicDisplayMethodV1 is supported.
+type DisplayMethod struct {
+	// Id Url of the JSON card template
+	Id   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// DefaultProofTypes defines model for DefaultProofTypes.
+type DefaultProofTypes struct {
+	ProofTypes []ProofType `json:"proofTypes"`
+}
+
+// DriftedClaim defines model for DriftedClaim.
+type DriftedClaim struct {
+	// Error Why the claim couldn't be replaced
+	Error *string   `json:"error,omitempty"`
+	Id    uuid.UUID `json:"id"`
+
+	// ReplacedBy The re-signed replacement. Missing on a dry run or when the claim couldn't be replaced.
+	ReplacedBy *uuid.UUID `json:"replacedBy,omitempty"`
+	SchemaUrl  string     `json:"schemaUrl"`
+}
+
 // GenericErrorMessage defines model for GenericErrorMessage.
 type GenericErrorMessage struct {
 	Message string `json:"message"`
@@ -96,12 +263,19 @@ type GetClaimResponse struct {
 	CredentialSchema  CredentialSchema       `json:"credentialSchema"`
 	CredentialStatus  interface{}            `json:"credentialStatus"`
 	CredentialSubject map[string]interface{} `json:"credentialSubject"`
+	DisplayMethod     *DisplayMethod         `json:"displayMethod,omitempty"`
 	Expiration        *time.Time             `json:"expiration,omitempty"`
 	Id                string                 `json:"id"`
 	IssuanceDate      *time.Time             `json:"issuanceDate,omitempty"`
 	Issuer            string                 `json:"issuer"`
 	Proof             interface{}            `json:"proof"`
 	Type              []string               `json:"type"`
+
+	// ValidFrom Start of the validity of a credential of the data model 2.0, the issuanceDate of the data model 1.1
+	ValidFrom *time.Time `json:"validFrom,omitempty"`
+
+	// ValidUntil End of the validity of a credential of the data model 2.0, the expiration of the data model 1.1
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
 }
 
 // GetClaimsResponse defines model for GetClaimsResponse.
@@ -110,6 +284,12 @@ type GetClaimsResponse = []GetClaimResponse
 // Health defines model for Health.
 type Health map[string]bool
 
+// IdentityStats defines model for IdentityStats.
+type IdentityStats struct {
+	Quotas Quotas     `json:"quotas"`
+	Usage  QuotaUsage `json:"usage"`
+}
+
 // IdentityState defines model for IdentityState.
 type IdentityState struct {
 	BlockNumber        *int      `json:"blockNumber,omitempty"`
@@ -127,6 +307,144 @@ type IdentityState struct {
 	TxID               *string   `json:"txID,omitempty"`
 }
 
+// ImportClaimRequest A W3C credential issued by the identity, with its proofs
+type ImportClaimRequest = verifiable.W3CCredential
+
+// IssuanceTiming defines model for IssuanceTiming.
+type IssuanceTiming struct {
+	CreatedAt    time.Time `json:"createdAt"`
+	CredentialID string    `json:"credentialID"`
+
+	// Phases Milliseconds spent in each phase of the issuance that ran: schema_load, validation, merklization, signing, db_commit and notification.
+	Phases map[string]float64 `json:"phases"`
+
+	// SlowestPhase The phase that took longest
+	SlowestPhase *string `json:"slowestPhase,omitempty"`
+
+	// TotalMs Milliseconds of the whole issuance, including the steps between the phases
+	TotalMs float64 `json:"totalMs"`
+}
+
+// IssuerAccreditation Answer of the trust registry, only when the node has one configured
+type IssuerAccreditation struct {
+	// Accredited The issuer is accredited for the credential type. Null when the trust registry cannot be consulted
+	Accredited *bool `json:"accredited"`
+
+	// CheckedAt When the trust registry answered, the answers are cached
+	CheckedAt      time.Time `json:"checkedAt"`
+	CredentialType string    `json:"credentialType"`
+	Reason         *string   `json:"reason,omitempty"`
+}
+
+// IssuerMetadata defines model for IssuerMetadata.
+type IssuerMetadata struct {
+	CredentialStatusTypes []string               `json:"credentialStatusTypes"`
+	GeneratedAt           time.Time              `json:"generatedAt"`
+	Issuers               []IssuerMetadataIssuer `json:"issuers"`
+	ServiceEndpoints      IssuerServiceEndpoints `json:"serviceEndpoints"`
+	Url                   string                 `json:"url"`
+}
+
+// IssuerMetadataIssuer defines model for IssuerMetadataIssuer.
+type IssuerMetadataIssuer struct {
+	Branding Branding `json:"branding"`
+	Did      string   `json:"did"`
+
+	// ProofTypes Proof types that the identity can issue
+	ProofTypes []ProofType       `json:"proofTypes"`
+	PublicKeys []IssuerPublicKey `json:"publicKeys"`
+
+	// Schemas Schemas imported by the identity
+	Schemas []IssuerSchema `json:"schemas"`
+}
+
+// IssuerPublicKey Key of the identity auth claim. X and Y are the decimal coordinates of the key.
+type IssuerPublicKey struct {
+	Type string `json:"type"`
+	X    string `json:"x"`
+	Y    string `json:"y"`
+}
+
+// IssuerSchema defines model for IssuerSchema.
+type IssuerSchema struct {
+	Type string `json:"type"`
+	Url  string `json:"url"`
+}
+
+// IssuerServiceEndpoints defines model for IssuerServiceEndpoints.
+type IssuerServiceEndpoints struct {
+	Agent string `json:"agent"`
+
+	// CredentialStatus Template of the credential status url of the SparseMerkleTreeProof credentials
+	CredentialStatus   string `json:"credentialStatus"`
+	CredentialValidity string `json:"credentialValidity"`
+
+	// ReverseHashService Url of the reverse hash service of the Iden3ReverseSparseMerkleTreeProof credentials
+	ReverseHashService *string `json:"reverseHashService,omitempty"`
+}
+
+// KeyRotation defines model for KeyRotation.
+type KeyRotation struct {
+	// AddState The state that adds the new auth claim
+	AddState  *string   `json:"addState"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Error The last error that stopped the rotation from advancing
+	Error          *string   `json:"error"`
+	Id             uuid.UUID `json:"id"`
+	ModifiedAt     time.Time `json:"modifiedAt"`
+	NewAuthClaimID uuid.UUID `json:"newAuthClaimID"`
+
+	// NewKeyID The key of the key store that signs the identity state transitions once the rotation is completed
+	NewKeyID       string    `json:"newKeyID"`
+	OldAuthClaimID uuid.UUID `json:"oldAuthClaimID"`
+
+	// RevokeState The state that revokes the old auth claim
+	RevokeState *string `json:"revokeState"`
+
+	// Status adding while the state with the new auth claim is published, revoking while the state that revokes the old
+	// auth claim is published, and completed once both are confirmed.
+	Status KeyRotationStatus `json:"status"`
+}
+
+// KeyRotationStatus adding while the state with the new auth claim is published, revoking while the state that revokes the old
+// auth claim is published, and completed once both are confirmed.
+type KeyRotationStatus string
+
+// Maintenance defines model for Maintenance.
+type Maintenance struct {
+	Enabled bool    `json:"enabled"`
+	Message *string `json:"message,omitempty"`
+
+	// RetryAfter Seconds the clients should wait to retry the rejected requests
+	RetryAfter int `json:"retryAfter"`
+
+	// UpdatedAt Last time the mode was switched. Missing when it never was.
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// MeteringMonth defines model for MeteringMonth.
+type MeteringMonth struct {
+	// ApiKeyID Delegated API key of the operations. Missing for the ones of the issuer credentials.
+	ApiKeyID *uuid.UUID `json:"apiKeyID,omitempty"`
+	Count    int64      `json:"count"`
+	Issuer   string     `json:"issuer"`
+	Month    string     `json:"month"`
+
+	// Operation One of issuance, verification or publish
+	Operation string `json:"operation"`
+}
+
+// MeteringResponse defines model for MeteringResponse.
+type MeteringResponse struct {
+	From  string          `json:"from"`
+	To    string          `json:"to"`
+	Usage []MeteringMonth `json:"usage"`
+}
+
+// ProofType defines model for ProofType.
+type ProofType string
+
 // PublishIdentityStateResponse defines model for PublishIdentityStateResponse.
 type PublishIdentityStateResponse struct {
 	ClaimsTreeRoot     *string `json:"claimsTreeRoot,omitempty"`
@@ -136,6 +454,97 @@ type PublishIdentityStateResponse struct {
 	TxID               *string `json:"txID,omitempty"`
 }
 
+// PromoteRegionRequest defines model for PromoteRegionRequest.
+type PromoteRegionRequest struct {
+	// Force Takes the lease before it expires, when the active region is known to be down
+	Force *bool `json:"force,omitempty"`
+
+	// PromoteDatabase Promotes the replica of the region to primary first
+	PromoteDatabase *bool `json:"promoteDatabase,omitempty"`
+}
+
+// QueryPlan defines model for QueryPlan.
+type QueryPlan struct {
+	// Index Index that serves the query
+	Index string `json:"index"`
+
+	// Indexes Indexes the query reads
+	Indexes []string `json:"indexes"`
+	Name    string   `json:"name"`
+
+	// Rows Estimated number of rows of the query
+	Rows float64 `json:"rows"`
+
+	// SeqScans Tables the query reads sequentially
+	SeqScans []string `json:"seqScans"`
+
+	// TotalCost Estimated cost of the query, in the units of the postgres planner
+	TotalCost float64 `json:"totalCost"`
+}
+
+// QueryPlansResponse defines model for QueryPlansResponse.
+type QueryPlansResponse struct {
+	Advice []string     `json:"advice"`
+	Plans  []QueryPlan  `json:"plans"`
+	Tables []TableStats `json:"tables"`
+}
+
+// QuotaUsage defines model for QuotaUsage.
+type QuotaUsage struct {
+	ActiveLinks      int `json:"activeLinks"`
+	CredentialsToday int `json:"credentialsToday"`
+	Schemas          int `json:"schemas"`
+}
+
+// Quotas defines model for Quotas.
+type Quotas struct {
+	MaxActiveLinks       int `json:"maxActiveLinks"`
+	MaxCredentialsPerDay int `json:"maxCredentialsPerDay"`
+	MaxSchemas           int `json:"maxSchemas"`
+}
+
+// Region defines model for Region.
+type Region struct {
+	// Active Whether the region changes the node
+	Active bool `json:"active"`
+
+	// InRecovery Whether the database of the region is a replica that follows the one of the active region
+	InRecovery bool         `json:"inRecovery"`
+	Lease      *RegionLease `json:"lease,omitempty"`
+
+	// Name Region of the server. Empty when the node runs in a single region.
+	Name string `json:"name"`
+}
+
+// RegionLease defines model for RegionLease.
+type RegionLease struct {
+	// Epoch Grows with each promotion
+	Epoch int64 `json:"epoch"`
+
+	// ExpiresAt When the lease expires unless the active region renews it
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Region Active region
+	Region    string    `json:"region"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ResignClaimsRequest defines model for ResignClaimsRequest.
+type ResignClaimsRequest struct {
+	// DryRun Only reports the drifted claims, without replacing them
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// Type Only checks the claims of this type. All of them when missing.
+	Type *string `json:"type,omitempty"`
+}
+
+// ResignClaimsResponse defines model for ResignClaimsResponse.
+type ResignClaimsResponse struct {
+	// Checked Number of signed claims checked
+	Checked int            `json:"checked"`
+	Drifted []DriftedClaim `json:"drifted"`
+}
+
 // RevocationStatusResponse defines model for RevocationStatusResponse.
 type RevocationStatusResponse struct {
 	Issuer struct {
@@ -159,24 +568,135 @@ type RevokeClaimResponse struct {
 	Message string `json:"message"`
 }
 
+// SchemaWebhook defines model for SchemaWebhook.
+type SchemaWebhook struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Events The events the webhook is notified of, issued and revoked
+	Events     []string  `json:"events"`
+	Id         uuid.UUID `json:"id"`
+	SchemaType string    `json:"schemaType"`
+	Url        string    `json:"url"`
+}
+
+// SigningKey defines model for SigningKey.
+type SigningKey struct {
+	// Id The auth claim of the key
+	Id uuid.UUID `json:"id"`
+
+	// KeyID The key of the key store, that selects it in signingKeyID
+	KeyID string `json:"keyID"`
+
+	// PublicKey The compressed public key, in hex
+	PublicKey string `json:"publicKey"`
+
+	// Status pending until the state with the auth claim of the key is published, and active once it signs.
+	Status SigningKeyStatus `json:"status"`
+
+	// Type The curve of a signing key. The secp256k1 keys only sign the credentials that select them in signingKeyID, with
+	// an EcdsaSecp256k1Signature2019 proof that the zero knowledge circuits can't verify.
+	Type SigningKeyType `json:"type"`
+}
+
+// SigningKeyStatus pending until the state with the auth claim of the key is published, and active once it signs.
+type SigningKeyStatus string
+
+// SigningKeyType The curve of a signing key. The secp256k1 keys only sign the credentials that select them in signingKeyID, with
+// an EcdsaSecp256k1Signature2019 proof that the zero knowledge circuits can't verify.
+type SigningKeyType string
+
+// StreamClaimsAck defines model for StreamClaimsAck.
+type StreamClaimsAck struct {
+	Error *string `json:"error,omitempty"`
+	Id    *string `json:"id,omitempty"`
+
+	// Line Line of the stream, starting at 1
+	Line int `json:"line"`
+
+	// Status Status code the line would have had with CreateClaim, 201 when the credential was issued
+	Status   int       `json:"status"`
+	Warnings *[]string `json:"warnings,omitempty"`
+}
+
+// TableStats defines model for TableStats.
+type TableStats struct {
+	DeadRows   int64 `json:"deadRows"`
+	IndexScans int64 `json:"indexScans"`
+
+	// LastVacuum Last manual or automatic vacuum. Missing when it never was.
+	LastVacuum *time.Time `json:"lastVacuum,omitempty"`
+	LiveRows   int64      `json:"liveRows"`
+	SeqScans   int64      `json:"seqScans"`
+
+	// SizeBytes Size of the table with its indexes
+	SizeBytes int64  `json:"sizeBytes"`
+	Table     string `json:"table"`
+}
+
+// UpdateBrandingRequest defines model for UpdateBrandingRequest.
+type UpdateBrandingRequest struct {
+	DisplayName  *string `json:"displayName"`
+	Logo         *string `json:"logo"`
+	PrimaryColor *string `json:"primaryColor"`
+	TextColor    *string `json:"textColor"`
+}
+
+// UpdateDefaultProofTypesRequest defines model for UpdateDefaultProofTypesRequest.
+type UpdateDefaultProofTypesRequest struct {
+	ProofTypes *[]ProofType `json:"proofTypes"`
+}
+
+// UpdateQuotasRequest defines model for UpdateQuotasRequest.
+type UpdateQuotasRequest struct {
+	MaxActiveLinks       *int `json:"maxActiveLinks"`
+	MaxCredentialsPerDay *int `json:"maxCredentialsPerDay"`
+	MaxSchemas           *int `json:"maxSchemas"`
+}
+
+// ValidationMode How the credential subject is checked against the schema. strict rejects the attributes that are not in
+// the schema and the values that don't have its types. lenient converts the values given as strings and drops the
+// attributes that are not in the schema, with a warning for each one. The default is strict.
+type ValidationMode string
+
+// Accept defines model for accept.
+type Accept = string
+
+// DataModel defines model for dataModel.
+type DataModel string
+
 // PathClaim defines model for pathClaim.
 type PathClaim = string
 
 // PathIdentifier defines model for pathIdentifier.
 type PathIdentifier = string
 
+// PathKeyRotation defines model for pathKeyRotation.
+type PathKeyRotation = string
+
 // PathNonce defines model for pathNonce.
 type PathNonce = int64
 
+// PathSchemaWebhook defines model for pathSchemaWebhook.
+type PathSchemaWebhook = string
+
+// PathSigningKey defines model for pathSigningKey.
+type PathSigningKey = string
+
 // N400 defines model for 400.
 type N400 = GenericErrorMessage
 
 // N401 defines model for 401.
 type N401 = GenericErrorMessage
 
+// N403 defines model for 403.
+type N403 = GenericErrorMessage
+
 // N404 defines model for 404.
 type N404 = GenericErrorMessage
 
+// N409 defines model for 409.
+type N409 = GenericErrorMessage
+
 // N422 defines model for 422.
 type N422 = GenericErrorMessage
 
@@ -193,6 +713,19 @@ type N500CreateIdentity struct {
 // AgentTextBody defines parameters for Agent.
 type AgentTextBody = string
 
+// GetMeteringParams defines parameters for GetMetering.
+type GetMeteringParams struct {
+	// From First month of the period, included
+	From *string `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last month of the period, included. The current month when not set.
+	To     *string                  `form:"to,omitempty" json:"to,omitempty"`
+	Format *GetMeteringParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetMeteringParamsFormat defines parameters for GetMetering.
+type GetMeteringParamsFormat string
+
 // GetClaimsParams defines parameters for GetClaims.
 type GetClaimsParams struct {
 	// SchemaType Filter per schema type. Example - KYCAgeCredential
@@ -215,22 +748,91 @@ type GetClaimsParams struct {
 
 	// QueryValue Filter this value inside the data of the claim for the specified field in query_field
 	QueryValue *string `form:"query_value,omitempty" json:"query_value,omitempty"`
+
+	// Limit Maximum number of claims returned, the newest issued first, 100 without it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// PageToken Token of the page to return, from the X-Next-Page-Token header of the previous page.
+	PageToken *string `form:"pageToken,omitempty" json:"pageToken,omitempty"`
+
+	// DataModel Version of the W3C Verifiable Credentials Data Model of the returned credentials. It takes precedence over the
+	// Accept header. Without both, the credentials are returned in 1.1.
+	DataModel *DataModel `form:"dataModel,omitempty" json:"dataModel,omitempty"`
+
+	// Accept The credentials are returned in the data model 2.0 when it has application/vc or a profile with the
+	// https://www.w3.org/ns/credentials/v2 context, e.g: application/ld+json; profile="https://www.w3.org/ns/credentials/v2"
+	Accept *Accept `json:"Accept,omitempty"`
+}
+
+// GetClaimParams defines parameters for GetClaim.
+type GetClaimParams struct {
+	// DataModel Version of the W3C Verifiable Credentials Data Model of the returned credentials. It takes precedence over the
+	// Accept header. Without both, the credentials are returned in 1.1.
+	DataModel *DataModel `form:"dataModel,omitempty" json:"dataModel,omitempty"`
+
+	// Accept The credentials are returned in the data model 2.0 when it has application/vc or a profile with the
+	// https://www.w3.org/ns/credentials/v2 context, e.g: application/ld+json; profile="https://www.w3.org/ns/credentials/v2"
+	Accept *Accept `json:"Accept,omitempty"`
+}
+
+// GetClaimDisplayParams defines parameters for GetClaimDisplay.
+type GetClaimDisplayParams struct {
+	Format *GetClaimDisplayParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetClaimDisplayParamsFormat defines parameters for GetClaimDisplay.
+type GetClaimDisplayParamsFormat string
+
+// AddSigningKeyParams defines parameters for AddSigningKey.
+type AddSigningKeyParams struct {
+	// Type The curve of the key, BJJ when not set
+	Type *SigningKeyType `form:"type,omitempty" json:"type,omitempty"`
 }
 
 // AgentTextRequestBody defines body for Agent for text/plain ContentType.
 type AgentTextRequestBody = AgentTextBody
 
+// CheckCredentialValidityJSONRequestBody defines body for CheckCredentialValidity for application/json ContentType.
+type CheckCredentialValidityJSONRequestBody = CredentialValidityRequest
+
 // CreateIdentityJSONRequestBody defines body for CreateIdentity for application/json ContentType.
 type CreateIdentityJSONRequestBody = CreateIdentityRequest
 
+// UpdateIdentityBrandingJSONRequestBody defines body for UpdateIdentityBranding for application/json ContentType.
+type UpdateIdentityBrandingJSONRequestBody = UpdateBrandingRequest
+
 // CreateClaimJSONRequestBody defines body for CreateClaim for application/json ContentType.
 type CreateClaimJSONRequestBody = CreateClaimRequest
 
+// ImportClaimJSONRequestBody defines body for ImportClaim for application/json ContentType.
+type ImportClaimJSONRequestBody = ImportClaimRequest
+
+// ResignClaimsJSONRequestBody defines body for ResignClaims for application/json ContentType.
+type ResignClaimsJSONRequestBody = ResignClaimsRequest
+
+// UpdateMaintenanceJSONRequestBody defines body for UpdateMaintenance for application/json ContentType.
+type UpdateMaintenanceJSONRequestBody = UpdateMaintenanceRequest
+
+// PromoteRegionJSONRequestBody defines body for PromoteRegion for application/json ContentType.
+type PromoteRegionJSONRequestBody = PromoteRegionRequest
+
+// UpdateIdentityDefaultProofTypesJSONRequestBody defines body for UpdateIdentityDefaultProofTypes for application/json ContentType.
+type UpdateIdentityDefaultProofTypesJSONRequestBody = UpdateDefaultProofTypesRequest
+
+// UpdateIdentityQuotasJSONRequestBody defines body for UpdateIdentityQuotas for application/json ContentType.
+type UpdateIdentityQuotasJSONRequestBody = UpdateQuotasRequest
+
+// CreateSchemaWebhookJSONRequestBody defines body for CreateSchemaWebhook for application/json ContentType.
+type CreateSchemaWebhookJSONRequestBody = CreateSchemaWebhookRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Get the documentation
 	// (GET /)
 	GetDocumentation(w http.ResponseWriter, r *http.Request)
+	// Get Issuer Metadata
+	// (GET /.well-known/issuer-metadata)
+	GetIssuerMetadata(w http.ResponseWriter, r *http.Request)
 	// Gets the favicon
 	// (GET /favicon.ico)
 	GetFavicon(w http.ResponseWriter, r *http.Request)
@@ -243,33 +845,111 @@ type ServerInterface interface {
 	// Agent
 	// (POST /v1/agent)
 	Agent(w http.ResponseWriter, r *http.Request)
+	// Check Credential Validity
+	// (POST /v1/credentials/validity)
+	CheckCredentialValidity(w http.ResponseWriter, r *http.Request)
+	// Get Query Plans
+	// (GET /v1/diagnostics/query-plans)
+	GetQueryPlans(w http.ResponseWriter, r *http.Request)
 	// Get Identities
 	// (GET /v1/identities)
 	GetIdentities(w http.ResponseWriter, r *http.Request)
 	// Create Identity
 	// (POST /v1/identities)
 	CreateIdentity(w http.ResponseWriter, r *http.Request)
+	// Get Maintenance
+	// (GET /v1/maintenance)
+	GetMaintenance(w http.ResponseWriter, r *http.Request)
+	// Update Maintenance
+	// (PUT /v1/maintenance)
+	UpdateMaintenance(w http.ResponseWriter, r *http.Request)
+	// Get Metering
+	// (GET /v1/metering)
+	GetMetering(w http.ResponseWriter, r *http.Request, params GetMeteringParams)
+	// Get Region
+	// (GET /v1/region)
+	GetRegion(w http.ResponseWriter, r *http.Request)
+	// Promote Region
+	// (POST /v1/region/promote)
+	PromoteRegion(w http.ResponseWriter, r *http.Request)
+	// Get Identity Branding
+	// (GET /v1/{identifier}/branding)
+	GetIdentityBranding(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Update Identity Branding
+	// (PUT /v1/{identifier}/branding)
+	UpdateIdentityBranding(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Get Claims
 	// (GET /v1/{identifier}/claims)
 	GetClaims(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetClaimsParams)
 	// Create Claim
 	// (POST /v1/{identifier}/claims)
 	CreateClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Import Claim
+	// (POST /v1/{identifier}/claims/import)
+	ImportClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Re-sign Claims After Context Changes
+	// (POST /v1/{identifier}/claims/resign)
+	ResignClaims(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Get Revocation Status
 	// (GET /v1/{identifier}/claims/revocation/status/{nonce})
 	GetRevocationStatus(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce)
 	// Revoke Claim
 	// (POST /v1/{identifier}/claims/revoke/{nonce})
 	RevokeClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce)
+	// Stream Claims
+	// (POST /v1/{identifier}/claims/stream)
+	StreamClaims(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Get Claim
 	// (GET /v1/{identifier}/claims/{id})
-	GetClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim)
+	GetClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim, params GetClaimParams)
 	// Get Claim QR code
 	// (GET /v1/{identifier}/claims/{id}/qrcode)
 	GetClaimQrCode(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim)
+	// Get Claim Display
+	// (GET /v1/{identifier}/claims/{id}/display)
+	GetClaimDisplay(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim, params GetClaimDisplayParams)
+	// Get Claim Issuance Timing
+	// (GET /v1/{identifier}/claims/{id}/timing)
+	GetClaimIssuanceTiming(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim)
+	// Get Identity Default Proof Types
+	// (GET /v1/{identifier}/default-proof-types)
+	GetIdentityDefaultProofTypes(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Update Identity Default Proof Types
+	// (PUT /v1/{identifier}/default-proof-types)
+	UpdateIdentityDefaultProofTypes(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Get Signing Keys
+	// (GET /v1/{identifier}/keys)
+	GetSigningKeys(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Add Signing Key
+	// (POST /v1/{identifier}/keys)
+	AddSigningKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params AddSigningKeyParams)
+	// Rotate Identity Key
+	// (POST /v1/{identifier}/keys/rotations)
+	RotateIdentityKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Get Key Rotation
+	// (GET /v1/{identifier}/keys/rotations/{id})
+	GetKeyRotation(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathKeyRotation)
+	// Remove Signing Key
+	// (DELETE /v1/{identifier}/keys/{id})
+	RemoveSigningKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathSigningKey)
+	// Update Identity Quotas
+	// (PUT /v1/{identifier}/quotas)
+	UpdateIdentityQuotas(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Get Identity Stats
+	// (GET /v1/{identifier}/stats)
+	GetIdentityStats(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Publish Identity State
 	// (POST /v1/{identifier}/state/publish)
 	PublishIdentityState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Get Schema Webhooks
+	// (GET /v1/{identifier}/webhooks)
+	GetSchemaWebhooks(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Create Schema Webhook
+	// (POST /v1/{identifier}/webhooks)
+	CreateSchemaWebhook(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Delete Schema Webhook
+	// (DELETE /v1/{identifier}/webhooks/{id})
+	DeleteSchemaWebhook(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathSchemaWebhook)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -296,6 +976,21 @@ func (siw *ServerInterfaceWrapper) GetDocumentation(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetIssuerMetadata operation middleware
+func (siw *ServerInterfaceWrapper) GetIssuerMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIssuerMetadata(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetFavicon operation middleware
 func (siw *ServerInterfaceWrapper) GetFavicon(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -356,6 +1051,40 @@ func (siw *ServerInterfaceWrapper) Agent(w http.ResponseWriter, r *http.Request)
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// CheckCredentialValidity operation middleware
+func (siw *ServerInterfaceWrapper) CheckCredentialValidity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheckCredentialValidity(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetQueryPlans operation middleware
+func (siw *ServerInterfaceWrapper) GetQueryPlans(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetQueryPlans(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetIdentities operation middleware
 func (siw *ServerInterfaceWrapper) GetIdentities(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -390,29 +1119,199 @@ func (siw *ServerInterfaceWrapper) CreateIdentity(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetClaims operation middleware
-func (siw *ServerInterfaceWrapper) GetClaims(w http.ResponseWriter, r *http.Request) {
+// GetMaintenance operation middleware
+func (siw *ServerInterfaceWrapper) GetMaintenance(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-	// ------------- Path parameter "identifier" -------------
-	var identifier PathIdentifier
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMaintenance(w, r)
+	})
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateMaintenance operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMaintenance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetClaimsParams
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateMaintenance(w, r)
+	})
 
-	// ------------- Optional query parameter "schemaType" -------------
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	err = runtime.BindQueryParameter("form", true, false, "schemaType", r.URL.Query(), &params.SchemaType)
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetMetering operation middleware
+func (siw *ServerInterfaceWrapper) GetMetering(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMeteringParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMetering(w, r, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRegion operation middleware
+func (siw *ServerInterfaceWrapper) GetRegion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRegion(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PromoteRegion operation middleware
+func (siw *ServerInterfaceWrapper) PromoteRegion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PromoteRegion(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetIdentityBranding operation middleware
+func (siw *ServerInterfaceWrapper) GetIdentityBranding(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIdentityBranding(w, r, identifier)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateIdentityBranding operation middleware
+func (siw *ServerInterfaceWrapper) UpdateIdentityBranding(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateIdentityBranding(w, r, identifier)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetClaims operation middleware
+func (siw *ServerInterfaceWrapper) GetClaims(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClaimsParams
+
+	// ------------- Optional query parameter "schemaType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "schemaType", r.URL.Query(), &params.SchemaType)
 	if err != nil {
 		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schemaType", Err: err})
 		return
@@ -466,6 +1365,51 @@ func (siw *ServerInterfaceWrapper) GetClaims(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "pageToken" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageToken", r.URL.Query(), &params.PageToken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageToken", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "dataModel" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dataModel", r.URL.Query(), &params.DataModel)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dataModel", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Accept" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept")]; found {
+		var Accept Accept
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Accept", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept", runtime.ParamLocationHeader, valueList[0], &Accept)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Accept", Err: err})
+			return
+		}
+
+		params.Accept = &Accept
+
+	}
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetClaims(w, r, identifier, params)
 	})
@@ -505,6 +1449,62 @@ func (siw *ServerInterfaceWrapper) CreateClaim(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// ImportClaim operation middleware
+func (siw *ServerInterfaceWrapper) ImportClaim(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportClaim(w, r, identifier)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ResignClaims operation middleware
+func (siw *ServerInterfaceWrapper) ResignClaims(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResignClaims(w, r, identifier)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetRevocationStatus operation middleware
 func (siw *ServerInterfaceWrapper) GetRevocationStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -577,6 +1577,34 @@ func (siw *ServerInterfaceWrapper) RevokeClaim(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// StreamClaims operation middleware
+func (siw *ServerInterfaceWrapper) StreamClaims(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamClaims(w, r, identifier)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetClaim operation middleware
 func (siw *ServerInterfaceWrapper) GetClaim(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -603,8 +1631,40 @@ func (siw *ServerInterfaceWrapper) GetClaim(w http.ResponseWriter, r *http.Reque
 
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClaimParams
+
+	// ------------- Optional query parameter "dataModel" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dataModel", r.URL.Query(), &params.DataModel)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dataModel", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Accept" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept")]; found {
+		var Accept Accept
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Accept", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept", runtime.ParamLocationHeader, valueList[0], &Accept)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Accept", Err: err})
+			return
+		}
+
+		params.Accept = &Accept
+
+	}
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetClaim(w, r, identifier, id)
+		siw.Handler.GetClaim(w, r, identifier, id, params)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -651,8 +1711,8 @@ func (siw *ServerInterfaceWrapper) GetClaimQrCode(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PublishIdentityState operation middleware
-func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r *http.Request) {
+// GetClaimDisplay operation middleware
+func (siw *ServerInterfaceWrapper) GetClaimDisplay(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -666,10 +1726,28 @@ func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r
 		return
 	}
 
-	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+	// ------------- Path parameter "id" -------------
+	var id PathClaim
 
-	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PublishIdentityState(w, r, identifier)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClaimDisplayParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClaimDisplay(w, r, identifier, id, params)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -679,713 +1757,2632 @@ func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetClaimIssuanceTiming operation middleware
+func (siw *ServerInterfaceWrapper) GetClaimIssuanceTiming(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type UnmarshallingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (e *UnmarshallingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshalling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Path parameter "id" -------------
+	var id PathClaim
 
-func (e *UnmarshallingParamError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-type RequiredParamError struct {
-	ParamName string
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClaimIssuanceTiming(w, r, identifier, id)
+	})
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+// GetIdentityDefaultProofTypes operation middleware
+func (siw *ServerInterfaceWrapper) GetIdentityDefaultProofTypes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	var err error
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIdentityDefaultProofTypes(w, r, identifier)
+	})
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+// UpdateIdentityDefaultProofTypes operation middleware
+func (siw *ServerInterfaceWrapper) UpdateIdentityDefaultProofTypes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
-}
+	var err error
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/", wrapper.GetDocumentation)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/favicon.ico", wrapper.GetFavicon)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/static/docs/api/api.yaml", wrapper.GetYaml)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/status", wrapper.Health)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/agent", wrapper.Agent)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/identities", wrapper.GetIdentities)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/identities", wrapper.CreateIdentity)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/{identifier}/claims", wrapper.GetClaims)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/{identifier}/claims", wrapper.CreateClaim)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/{identifier}/claims/revocation/status/{nonce}", wrapper.GetRevocationStatus)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/{identifier}/claims/revoke/{nonce}", wrapper.RevokeClaim)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/{identifier}/claims/{id}", wrapper.GetClaim)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/{identifier}/claims/{id}/qrcode", wrapper.GetClaimQrCode)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/{identifier}/state/publish", wrapper.PublishIdentityState)
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateIdentityDefaultProofTypes(w, r, identifier)
 	})
 
-	return r
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type N400JSONResponse GenericErrorMessage
+// GetSigningKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetSigningKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type N401JSONResponse GenericErrorMessage
+	var err error
 
-type N404JSONResponse GenericErrorMessage
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type N422JSONResponse GenericErrorMessage
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-type N500JSONResponse GenericErrorMessage
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type N500CreateIdentityJSONResponse struct {
-	Code      *int    `json:"code,omitempty"`
-	Error     *string `json:"error,omitempty"`
-	RequestID *string `json:"requestID,omitempty"`
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSigningKeys(w, r, identifier)
+	})
 
-type GetDocumentationRequestObject struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetDocumentationResponseObject interface {
-	VisitGetDocumentationResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetDocumentation200Response struct {
-}
+// AddSigningKey operation middleware
+func (siw *ServerInterfaceWrapper) AddSigningKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response GetDocumentation200Response) VisitGetDocumentationResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
+	var err error
 
-type GetFaviconRequestObject struct {
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type GetFaviconResponseObject interface {
-	VisitGetFaviconResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-type GetFavicon200Response struct {
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-func (response GetFavicon200Response) VisitGetFaviconResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
-
-type GetYamlRequestObject struct {
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AddSigningKeyParams
 
-type GetYamlResponseObject interface {
-	VisitGetYamlResponse(w http.ResponseWriter) error
-}
+	// ------------- Optional query parameter "type" -------------
 
-type GetYaml200Response struct {
-}
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
 
-func (response GetYaml200Response) VisitGetYamlResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddSigningKey(w, r, identifier, params)
+	})
 
-type HealthRequestObject struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type HealthResponseObject interface {
-	VisitHealthResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type Health200JSONResponse Health
+// RotateIdentityKey operation middleware
+func (siw *ServerInterfaceWrapper) RotateIdentityKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type Health500JSONResponse struct{ N500JSONResponse }
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateIdentityKey(w, r, identifier)
+	})
 
-type AgentRequestObject struct {
-	Body *AgentTextRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type AgentResponseObject interface {
-	VisitAgentResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type Agent200JSONResponse AgentResponse
+// GetKeyRotation operation middleware
+func (siw *ServerInterfaceWrapper) GetKeyRotation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response Agent200JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type Agent400JSONResponse struct{ N400JSONResponse }
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (response Agent400JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Path parameter "id" -------------
+	var id PathKeyRotation
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-type Agent500JSONResponse struct{ N500JSONResponse }
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-func (response Agent500JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetKeyRotation(w, r, identifier, id)
+	})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetIdentitiesRequestObject struct {
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetIdentitiesResponseObject interface {
-	VisitGetIdentitiesResponse(w http.ResponseWriter) error
-}
+// RemoveSigningKey operation middleware
+func (siw *ServerInterfaceWrapper) RemoveSigningKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type GetIdentities200JSONResponse []string
+	var err error
 
-func (response GetIdentities200JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-type GetIdentities401JSONResponse struct{ N401JSONResponse }
+	// ------------- Path parameter "id" -------------
+	var id PathSigningKey
 
-func (response GetIdentities401JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type GetIdentities500JSONResponse struct{ N500JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveSigningKey(w, r, identifier, id)
+	})
 
-func (response GetIdentities500JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type CreateIdentityRequestObject struct {
-	Body *CreateIdentityJSONRequestBody
-}
+// UpdateIdentityQuotas operation middleware
+func (siw *ServerInterfaceWrapper) UpdateIdentityQuotas(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type CreateIdentityResponseObject interface {
-	VisitCreateIdentityResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type CreateIdentity201JSONResponse CreateIdentityResponse
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-func (response CreateIdentity201JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type CreateIdentity400JSONResponse struct{ N400JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateIdentityQuotas(w, r, identifier)
+	})
 
-func (response CreateIdentity400JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type CreateIdentity401JSONResponse struct{ N401JSONResponse }
+// GetIdentityStats operation middleware
+func (siw *ServerInterfaceWrapper) GetIdentityStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response CreateIdentity401JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type CreateIdentity500JSONResponse struct{ N500CreateIdentityJSONResponse }
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (response CreateIdentity500JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIdentityStats(w, r, identifier)
+	})
 
-type GetClaimsRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Params     GetClaimsParams
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetClaimsResponseObject interface {
-	VisitGetClaimsResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetClaims200JSONResponse GetClaimsResponse
-
-func (response GetClaims200JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// PublishIdentityState operation middleware
+func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type GetClaims400JSONResponse struct{ N400JSONResponse }
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-func (response GetClaims400JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type GetClaims401JSONResponse struct{ N401JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PublishIdentityState(w, r, identifier)
+	})
 
-func (response GetClaims401JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetClaims500JSONResponse struct{ N500JSONResponse }
+// GetSchemaWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetSchemaWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response GetClaims500JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type CreateClaimRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Body       *CreateClaimJSONRequestBody
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-type CreateClaimResponseObject interface {
-	VisitCreateClaimResponse(w http.ResponseWriter) error
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type CreateClaim201JSONResponse CreateClaimResponse
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSchemaWebhooks(w, r, identifier)
+	})
 
-func (response CreateClaim201JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type CreateClaim400JSONResponse struct{ N400JSONResponse }
-
-func (response CreateClaim400JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// CreateSchemaWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateSchemaWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type CreateClaim401JSONResponse struct{ N401JSONResponse }
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-func (response CreateClaim401JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type CreateClaim422JSONResponse struct{ N422JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSchemaWebhook(w, r, identifier)
+	})
 
-func (response CreateClaim422JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type CreateClaim500JSONResponse struct{ N500JSONResponse }
-
-func (response CreateClaim500JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// DeleteSchemaWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSchemaWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type GetRevocationStatusRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Nonce      PathNonce      `json:"nonce"`
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type GetRevocationStatusResponseObject interface {
-	VisitGetRevocationStatusResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-type GetRevocationStatus200JSONResponse RevocationStatusResponse
+	// ------------- Path parameter "id" -------------
+	var id PathSchemaWebhook
 
-func (response GetRevocationStatus200JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type GetRevocationStatus400JSONResponse struct{ N400JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSchemaWebhook(w, r, identifier, id)
+	})
 
-func (response GetRevocationStatus400JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetRevocationStatus500JSONResponse struct{ N500JSONResponse }
-
-func (response GetRevocationStatus500JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
-
-	return json.NewEncoder(w).Encode(response)
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
 }
 
-type RevokeClaimRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Nonce      PathNonce      `json:"nonce"`
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
 }
 
-type RevokeClaimResponseObject interface {
-	VisitRevokeClaimResponse(w http.ResponseWriter) error
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
 }
 
-type RevokeClaim202JSONResponse RevokeClaimResponse
-
-func (response RevokeClaim202JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
-
-	return json.NewEncoder(w).Encode(response)
+type UnmarshallingParamError struct {
+	ParamName string
+	Err       error
 }
 
-type RevokeClaim400JSONResponse struct{ N400JSONResponse }
-
-func (response RevokeClaim400JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *UnmarshallingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshalling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
 }
 
-type RevokeClaim401JSONResponse struct{ N401JSONResponse }
-
-func (response RevokeClaim401JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *UnmarshallingParamError) Unwrap() error {
+	return e.Err
 }
 
-type RevokeClaim404JSONResponse struct{ N404JSONResponse }
-
-func (response RevokeClaim404JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
+type RequiredParamError struct {
+	ParamName string
 }
 
-type RevokeClaim500JSONResponse struct{ N500JSONResponse }
-
-func (response RevokeClaim500JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
 }
 
-type GetClaimRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Id         PathClaim      `json:"id"`
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
 }
 
-type GetClaimResponseObject interface {
-	VisitGetClaimResponse(w http.ResponseWriter) error
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
 }
 
-type GetClaim200JSONResponse GetClaimResponse
-
-func (response GetClaim200JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
 }
 
-type GetClaim400JSONResponse struct{ N400JSONResponse }
-
-func (response GetClaim400JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
 }
 
-type GetClaim401JSONResponse struct{ N401JSONResponse }
-
-func (response GetClaim401JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
 }
 
-type GetClaim404JSONResponse struct{ N404JSONResponse }
-
-func (response GetClaim404JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
 }
 
-type GetClaim500JSONResponse struct{ N500JSONResponse }
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
 
-func (response GetClaim500JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
 
-	return json.NewEncoder(w).Encode(response)
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
 }
 
-type GetClaimQrCodeRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Id         PathClaim      `json:"id"`
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
 }
 
-type GetClaimQrCodeResponseObject interface {
-	VisitGetClaimQrCodeResponse(w http.ResponseWriter) error
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
 }
 
-type GetClaimQrCode200JSONResponse GetClaimQrCodeResponse
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
 
-func (response GetClaimQrCode200JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/", wrapper.GetDocumentation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/.well-known/issuer-metadata", wrapper.GetIssuerMetadata)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/favicon.ico", wrapper.GetFavicon)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/static/docs/api/api.yaml", wrapper.GetYaml)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/status", wrapper.Health)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/agent", wrapper.Agent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/credentials/validity", wrapper.CheckCredentialValidity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/diagnostics/query-plans", wrapper.GetQueryPlans)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/identities", wrapper.GetIdentities)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/identities", wrapper.CreateIdentity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/maintenance", wrapper.GetMaintenance)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/maintenance", wrapper.UpdateMaintenance)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/metering", wrapper.GetMetering)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/region", wrapper.GetRegion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/region/promote", wrapper.PromoteRegion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/branding", wrapper.GetIdentityBranding)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/{identifier}/branding", wrapper.UpdateIdentityBranding)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/claims", wrapper.GetClaims)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/claims", wrapper.CreateClaim)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/claims/import", wrapper.ImportClaim)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/claims/resign", wrapper.ResignClaims)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/claims/revocation/status/{nonce}", wrapper.GetRevocationStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/claims/revoke/{nonce}", wrapper.RevokeClaim)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/claims/stream", wrapper.StreamClaims)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/claims/{id}", wrapper.GetClaim)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/claims/{id}/qrcode", wrapper.GetClaimQrCode)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/claims/{id}/display", wrapper.GetClaimDisplay)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/claims/{id}/timing", wrapper.GetClaimIssuanceTiming)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/default-proof-types", wrapper.GetIdentityDefaultProofTypes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/{identifier}/default-proof-types", wrapper.UpdateIdentityDefaultProofTypes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/keys", wrapper.GetSigningKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/keys", wrapper.AddSigningKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/keys/rotations", wrapper.RotateIdentityKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/keys/rotations/{id}", wrapper.GetKeyRotation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/{identifier}/keys/{id}", wrapper.RemoveSigningKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/{identifier}/quotas", wrapper.UpdateIdentityQuotas)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/stats", wrapper.GetIdentityStats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/state/publish", wrapper.PublishIdentityState)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/webhooks", wrapper.GetSchemaWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/webhooks", wrapper.CreateSchemaWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/{identifier}/webhooks/{id}", wrapper.DeleteSchemaWebhook)
+	})
+
+	return r
+}
+
+type N400JSONResponse GenericErrorMessage
+
+type N401JSONResponse GenericErrorMessage
+
+type N403JSONResponse GenericErrorMessage
+
+type N404JSONResponse GenericErrorMessage
+
+type N409JSONResponse GenericErrorMessage
+
+type N422JSONResponse GenericErrorMessage
+
+type N500JSONResponse GenericErrorMessage
+
+type N500CreateIdentityJSONResponse struct {
+	Code      *int    `json:"code,omitempty"`
+	Error     *string `json:"error,omitempty"`
+	RequestID *string `json:"requestID,omitempty"`
+}
+
+type GetDocumentationRequestObject struct {
+}
+
+type GetDocumentationResponseObject interface {
+	VisitGetDocumentationResponse(w http.ResponseWriter) error
+}
+
+type GetDocumentation200Response struct {
+}
+
+func (response GetDocumentation200Response) VisitGetDocumentationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type GetIssuerMetadataRequestObject struct {
+}
+
+type GetIssuerMetadataResponseObject interface {
+	VisitGetIssuerMetadataResponse(w http.ResponseWriter) error
+}
+
+type GetIssuerMetadata200JSONResponse IssuerMetadata
+
+func (response GetIssuerMetadata200JSONResponse) VisitGetIssuerMetadataResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClaimQrCode400JSONResponse struct{ N400JSONResponse }
+type GetIssuerMetadata500JSONResponse struct{ N500JSONResponse }
 
-func (response GetClaimQrCode400JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+func (response GetIssuerMetadata500JSONResponse) VisitGetIssuerMetadataResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClaimQrCode404JSONResponse struct{ N404JSONResponse }
+type GetFaviconRequestObject struct {
+}
 
-func (response GetClaimQrCode404JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+type GetFaviconResponseObject interface {
+	VisitGetFaviconResponse(w http.ResponseWriter) error
+}
+
+type GetFavicon200Response struct {
+}
+
+func (response GetFavicon200Response) VisitGetFaviconResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type GetYamlRequestObject struct {
+}
+
+type GetYamlResponseObject interface {
+	VisitGetYamlResponse(w http.ResponseWriter) error
+}
+
+type GetYaml200Response struct {
+}
+
+func (response GetYaml200Response) VisitGetYamlResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type HealthRequestObject struct {
+}
+
+type HealthResponseObject interface {
+	VisitHealthResponse(w http.ResponseWriter) error
+}
+
+type Health200JSONResponse Health
+
+func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClaimQrCode500JSONResponse struct{ N500JSONResponse }
+type Health500JSONResponse struct{ N500JSONResponse }
 
-func (response GetClaimQrCode500JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishIdentityStateRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
+type AgentRequestObject struct {
+	Body *AgentTextRequestBody
 }
 
-type PublishIdentityStateResponseObject interface {
-	VisitPublishIdentityStateResponse(w http.ResponseWriter) error
+type AgentResponseObject interface {
+	VisitAgentResponse(w http.ResponseWriter) error
 }
 
-type PublishIdentityState200JSONResponse GenericErrorMessage
+type Agent200JSONResponse AgentResponse
 
-func (response PublishIdentityState200JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+func (response Agent200JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishIdentityState202JSONResponse PublishIdentityStateResponse
+type Agent400JSONResponse struct{ N400JSONResponse }
 
-func (response PublishIdentityState202JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+func (response Agent400JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishIdentityState400JSONResponse struct{ N400JSONResponse }
+type Agent500JSONResponse struct{ N500JSONResponse }
 
-func (response PublishIdentityState400JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+func (response Agent500JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckCredentialValidityRequestObject struct {
+	Body *CheckCredentialValidityJSONRequestBody
+}
+
+type CheckCredentialValidityResponseObject interface {
+	VisitCheckCredentialValidityResponse(w http.ResponseWriter) error
+}
+
+type CheckCredentialValidity200JSONResponse CredentialValidityResponse
+
+func (response CheckCredentialValidity200JSONResponse) VisitCheckCredentialValidityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckCredentialValidity400JSONResponse struct{ N400JSONResponse }
+
+func (response CheckCredentialValidity400JSONResponse) VisitCheckCredentialValidityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishIdentityState401JSONResponse struct{ N401JSONResponse }
+type CheckCredentialValidity401JSONResponse struct{ N401JSONResponse }
 
-func (response PublishIdentityState401JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+func (response CheckCredentialValidity401JSONResponse) VisitCheckCredentialValidityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishIdentityState500JSONResponse struct{ N500JSONResponse }
+type CheckCredentialValidity500JSONResponse struct{ N500JSONResponse }
 
-func (response PublishIdentityState500JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+func (response CheckCredentialValidity500JSONResponse) VisitCheckCredentialValidityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Get the documentation
-	// (GET /)
-	GetDocumentation(ctx context.Context, request GetDocumentationRequestObject) (GetDocumentationResponseObject, error)
-	// Gets the favicon
-	// (GET /favicon.ico)
-	GetFavicon(ctx context.Context, request GetFaviconRequestObject) (GetFaviconResponseObject, error)
+type GetQueryPlansRequestObject struct {
+}
+
+type GetQueryPlansResponseObject interface {
+	VisitGetQueryPlansResponse(w http.ResponseWriter) error
+}
+
+type GetQueryPlans200JSONResponse QueryPlansResponse
+
+func (response GetQueryPlans200JSONResponse) VisitGetQueryPlansResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQueryPlans401JSONResponse struct{ N401JSONResponse }
+
+func (response GetQueryPlans401JSONResponse) VisitGetQueryPlansResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQueryPlans500JSONResponse struct{ N500JSONResponse }
+
+func (response GetQueryPlans500JSONResponse) VisitGetQueryPlansResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentitiesRequestObject struct {
+}
+
+type GetIdentitiesResponseObject interface {
+	VisitGetIdentitiesResponse(w http.ResponseWriter) error
+}
+
+type GetIdentities200JSONResponse []string
+
+func (response GetIdentities200JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentities401JSONResponse struct{ N401JSONResponse }
+
+func (response GetIdentities401JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentities500JSONResponse struct{ N500JSONResponse }
+
+func (response GetIdentities500JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentityRequestObject struct {
+	Body *CreateIdentityJSONRequestBody
+}
+
+type CreateIdentityResponseObject interface {
+	VisitCreateIdentityResponse(w http.ResponseWriter) error
+}
+
+type CreateIdentity201JSONResponse CreateIdentityResponse
+
+func (response CreateIdentity201JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentity400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateIdentity400JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentity401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateIdentity401JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIdentity500JSONResponse struct{ N500CreateIdentityJSONResponse }
+
+func (response CreateIdentity500JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMaintenanceRequestObject struct {
+}
+
+type GetMaintenanceResponseObject interface {
+	VisitGetMaintenanceResponse(w http.ResponseWriter) error
+}
+
+type GetMaintenance200JSONResponse Maintenance
+
+func (response GetMaintenance200JSONResponse) VisitGetMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMaintenance401JSONResponse struct{ N401JSONResponse }
+
+func (response GetMaintenance401JSONResponse) VisitGetMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMaintenance500JSONResponse struct{ N500JSONResponse }
+
+func (response GetMaintenance500JSONResponse) VisitGetMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMaintenanceRequestObject struct {
+	Body *UpdateMaintenanceJSONRequestBody
+}
+
+type UpdateMaintenanceResponseObject interface {
+	VisitUpdateMaintenanceResponse(w http.ResponseWriter) error
+}
+
+type UpdateMaintenance200JSONResponse Maintenance
+
+func (response UpdateMaintenance200JSONResponse) VisitUpdateMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMaintenance400JSONResponse struct{ N400JSONResponse }
+
+func (response UpdateMaintenance400JSONResponse) VisitUpdateMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMaintenance401JSONResponse struct{ N401JSONResponse }
+
+func (response UpdateMaintenance401JSONResponse) VisitUpdateMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMaintenance500JSONResponse struct{ N500JSONResponse }
+
+func (response UpdateMaintenance500JSONResponse) VisitUpdateMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeteringRequestObject struct {
+	Params GetMeteringParams
+}
+
+type GetMeteringResponseObject interface {
+	VisitGetMeteringResponse(w http.ResponseWriter) error
+}
+
+type GetMetering200JSONResponse MeteringResponse
+
+func (response GetMetering200JSONResponse) VisitGetMeteringResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMetering200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetMetering200TextcsvResponse) VisitGetMeteringResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetMetering400JSONResponse struct{ N400JSONResponse }
+
+func (response GetMetering400JSONResponse) VisitGetMeteringResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMetering401JSONResponse struct{ N401JSONResponse }
+
+func (response GetMetering401JSONResponse) VisitGetMeteringResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMetering500JSONResponse struct{ N500JSONResponse }
+
+func (response GetMetering500JSONResponse) VisitGetMeteringResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRegionRequestObject struct {
+}
+
+type GetRegionResponseObject interface {
+	VisitGetRegionResponse(w http.ResponseWriter) error
+}
+
+type GetRegion200JSONResponse Region
+
+func (response GetRegion200JSONResponse) VisitGetRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRegion401JSONResponse struct{ N401JSONResponse }
+
+func (response GetRegion401JSONResponse) VisitGetRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRegion500JSONResponse struct{ N500JSONResponse }
+
+func (response GetRegion500JSONResponse) VisitGetRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PromoteRegionRequestObject struct {
+	Body *PromoteRegionJSONRequestBody
+}
+
+type PromoteRegionResponseObject interface {
+	VisitPromoteRegionResponse(w http.ResponseWriter) error
+}
+
+type PromoteRegion200JSONResponse Region
+
+func (response PromoteRegion200JSONResponse) VisitPromoteRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PromoteRegion400JSONResponse struct{ N400JSONResponse }
+
+func (response PromoteRegion400JSONResponse) VisitPromoteRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PromoteRegion401JSONResponse struct{ N401JSONResponse }
+
+func (response PromoteRegion401JSONResponse) VisitPromoteRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PromoteRegion409JSONResponse struct{ N409JSONResponse }
+
+func (response PromoteRegion409JSONResponse) VisitPromoteRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PromoteRegion500JSONResponse struct{ N500JSONResponse }
+
+func (response PromoteRegion500JSONResponse) VisitPromoteRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityBrandingRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type GetIdentityBrandingResponseObject interface {
+	VisitGetIdentityBrandingResponse(w http.ResponseWriter) error
+}
+
+type GetIdentityBranding200JSONResponse Branding
+
+func (response GetIdentityBranding200JSONResponse) VisitGetIdentityBrandingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityBranding400JSONResponse struct{ N400JSONResponse }
+
+func (response GetIdentityBranding400JSONResponse) VisitGetIdentityBrandingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityBranding401JSONResponse struct{ N401JSONResponse }
+
+func (response GetIdentityBranding401JSONResponse) VisitGetIdentityBrandingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityBranding404JSONResponse struct{ N404JSONResponse }
+
+func (response GetIdentityBranding404JSONResponse) VisitGetIdentityBrandingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityBranding500JSONResponse struct{ N500JSONResponse }
+
+func (response GetIdentityBranding500JSONResponse) VisitGetIdentityBrandingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityBrandingRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *UpdateIdentityBrandingJSONRequestBody
+}
+
+type UpdateIdentityBrandingResponseObject interface {
+	VisitUpdateIdentityBrandingResponse(w http.ResponseWriter) error
+}
+
+type UpdateIdentityBranding200JSONResponse Branding
+
+func (response UpdateIdentityBranding200JSONResponse) VisitUpdateIdentityBrandingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityBranding400JSONResponse struct{ N400JSONResponse }
+
+func (response UpdateIdentityBranding400JSONResponse) VisitUpdateIdentityBrandingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityBranding401JSONResponse struct{ N401JSONResponse }
+
+func (response UpdateIdentityBranding401JSONResponse) VisitUpdateIdentityBrandingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityBranding404JSONResponse struct{ N404JSONResponse }
+
+func (response UpdateIdentityBranding404JSONResponse) VisitUpdateIdentityBrandingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityBranding500JSONResponse struct{ N500JSONResponse }
+
+func (response UpdateIdentityBranding500JSONResponse) VisitUpdateIdentityBrandingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     GetClaimsParams
+}
+
+type GetClaimsResponseObject interface {
+	VisitGetClaimsResponse(w http.ResponseWriter) error
+}
+
+type GetClaims200ResponseHeaders struct {
+	XNextPageToken string
+	XTotalCount    int
+}
+
+type GetClaims200JSONResponse struct {
+	Body    GetClaimsResponse
+	Headers GetClaims200ResponseHeaders
+}
+
+func (response GetClaims200JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Page-Token", fmt.Sprint(response.Headers.XNextPageToken))
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetClaims400JSONResponse struct{ N400JSONResponse }
+
+func (response GetClaims400JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaims401JSONResponse struct{ N401JSONResponse }
+
+func (response GetClaims401JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaims500JSONResponse struct{ N500JSONResponse }
+
+func (response GetClaims500JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaimRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateClaimJSONRequestBody
+}
+
+type CreateClaimResponseObject interface {
+	VisitCreateClaimResponse(w http.ResponseWriter) error
+}
+
+type CreateClaim201JSONResponse CreateClaimResponse
+
+func (response CreateClaim201JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateClaim400JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateClaim401JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim403JSONResponse struct{ N403JSONResponse }
+
+func (response CreateClaim403JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim409JSONResponse struct{ N409JSONResponse }
+
+func (response CreateClaim409JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim422JSONResponse struct{ N422JSONResponse }
+
+func (response CreateClaim422JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateClaim500JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportClaimRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *ImportClaimJSONRequestBody
+}
+
+type ImportClaimResponseObject interface {
+	VisitImportClaimResponse(w http.ResponseWriter) error
+}
+
+type ImportClaim201JSONResponse CreateClaimResponse
+
+func (response ImportClaim201JSONResponse) VisitImportClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportClaim400JSONResponse struct{ N400JSONResponse }
+
+func (response ImportClaim400JSONResponse) VisitImportClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportClaim401JSONResponse struct{ N401JSONResponse }
+
+func (response ImportClaim401JSONResponse) VisitImportClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportClaim422JSONResponse struct{ N422JSONResponse }
+
+func (response ImportClaim422JSONResponse) VisitImportClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportClaim500JSONResponse struct{ N500JSONResponse }
+
+func (response ImportClaim500JSONResponse) VisitImportClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResignClaimsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *ResignClaimsJSONRequestBody
+}
+
+type ResignClaimsResponseObject interface {
+	VisitResignClaimsResponse(w http.ResponseWriter) error
+}
+
+type ResignClaims200JSONResponse ResignClaimsResponse
+
+func (response ResignClaims200JSONResponse) VisitResignClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResignClaims400JSONResponse struct{ N400JSONResponse }
+
+func (response ResignClaims400JSONResponse) VisitResignClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResignClaims401JSONResponse struct{ N401JSONResponse }
+
+func (response ResignClaims401JSONResponse) VisitResignClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResignClaims422JSONResponse struct{ N422JSONResponse }
+
+func (response ResignClaims422JSONResponse) VisitResignClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResignClaims500JSONResponse struct{ N500JSONResponse }
+
+func (response ResignClaims500JSONResponse) VisitResignClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatusRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Nonce      PathNonce      `json:"nonce"`
+}
+
+type GetRevocationStatusResponseObject interface {
+	VisitGetRevocationStatusResponse(w http.ResponseWriter) error
+}
+
+type GetRevocationStatus200JSONResponse RevocationStatusResponse
+
+func (response GetRevocationStatus200JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatus400JSONResponse struct{ N400JSONResponse }
+
+func (response GetRevocationStatus400JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatus500JSONResponse struct{ N500JSONResponse }
+
+func (response GetRevocationStatus500JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeClaimRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Nonce      PathNonce      `json:"nonce"`
+}
+
+type RevokeClaimResponseObject interface {
+	VisitRevokeClaimResponse(w http.ResponseWriter) error
+}
+
+type RevokeClaim202JSONResponse RevokeClaimResponse
+
+func (response RevokeClaim202JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeClaim400JSONResponse struct{ N400JSONResponse }
+
+func (response RevokeClaim400JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeClaim401JSONResponse struct{ N401JSONResponse }
+
+func (response RevokeClaim401JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeClaim404JSONResponse struct{ N404JSONResponse }
+
+func (response RevokeClaim404JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeClaim500JSONResponse struct{ N500JSONResponse }
+
+func (response RevokeClaim500JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamClaimsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       io.Reader
+}
+
+type StreamClaimsResponseObject interface {
+	VisitStreamClaimsResponse(w http.ResponseWriter) error
+}
+
+type StreamClaims200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response StreamClaims200ApplicationxNdjsonResponse) VisitStreamClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type StreamClaims400JSONResponse struct{ N400JSONResponse }
+
+func (response StreamClaims400JSONResponse) VisitStreamClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamClaims401JSONResponse struct{ N401JSONResponse }
+
+func (response StreamClaims401JSONResponse) VisitStreamClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamClaims500JSONResponse struct{ N500JSONResponse }
+
+func (response StreamClaims500JSONResponse) VisitStreamClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathClaim      `json:"id"`
+	Params     GetClaimParams
+}
+
+type GetClaimResponseObject interface {
+	VisitGetClaimResponse(w http.ResponseWriter) error
+}
+
+type GetClaim200JSONResponse GetClaimResponse
+
+func (response GetClaim200JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaim400JSONResponse struct{ N400JSONResponse }
+
+func (response GetClaim400JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaim401JSONResponse struct{ N401JSONResponse }
+
+func (response GetClaim401JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaim404JSONResponse struct{ N404JSONResponse }
+
+func (response GetClaim404JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaim500JSONResponse struct{ N500JSONResponse }
+
+func (response GetClaim500JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimQrCodeRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathClaim      `json:"id"`
+}
+
+type GetClaimQrCodeResponseObject interface {
+	VisitGetClaimQrCodeResponse(w http.ResponseWriter) error
+}
+
+type GetClaimQrCode200JSONResponse GetClaimQrCodeResponse
+
+func (response GetClaimQrCode200JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimQrCode400JSONResponse struct{ N400JSONResponse }
+
+func (response GetClaimQrCode400JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimQrCode404JSONResponse struct{ N404JSONResponse }
+
+func (response GetClaimQrCode404JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimQrCode500JSONResponse struct{ N500JSONResponse }
+
+func (response GetClaimQrCode500JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimDisplayRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathClaim      `json:"id"`
+	Params     GetClaimDisplayParams
+}
+
+type GetClaimDisplayResponseObject interface {
+	VisitGetClaimDisplayResponse(w http.ResponseWriter) error
+}
+
+type GetClaimDisplay200JSONResponse CredentialDisplayResponse
+
+func (response GetClaimDisplay200JSONResponse) VisitGetClaimDisplayResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimDisplay200ImagesvgXmlResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetClaimDisplay200ImagesvgXmlResponse) VisitGetClaimDisplayResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetClaimDisplay400JSONResponse struct{ N400JSONResponse }
+
+func (response GetClaimDisplay400JSONResponse) VisitGetClaimDisplayResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimDisplay404JSONResponse struct{ N404JSONResponse }
+
+func (response GetClaimDisplay404JSONResponse) VisitGetClaimDisplayResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimDisplay422JSONResponse struct{ N422JSONResponse }
+
+func (response GetClaimDisplay422JSONResponse) VisitGetClaimDisplayResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimDisplay500JSONResponse struct{ N500JSONResponse }
+
+func (response GetClaimDisplay500JSONResponse) VisitGetClaimDisplayResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimIssuanceTimingRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathClaim      `json:"id"`
+}
+
+type GetClaimIssuanceTimingResponseObject interface {
+	VisitGetClaimIssuanceTimingResponse(w http.ResponseWriter) error
+}
+
+type GetClaimIssuanceTiming200JSONResponse IssuanceTiming
+
+func (response GetClaimIssuanceTiming200JSONResponse) VisitGetClaimIssuanceTimingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimIssuanceTiming400JSONResponse struct{ N400JSONResponse }
+
+func (response GetClaimIssuanceTiming400JSONResponse) VisitGetClaimIssuanceTimingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimIssuanceTiming404JSONResponse struct{ N404JSONResponse }
+
+func (response GetClaimIssuanceTiming404JSONResponse) VisitGetClaimIssuanceTimingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimIssuanceTiming500JSONResponse struct{ N500JSONResponse }
+
+func (response GetClaimIssuanceTiming500JSONResponse) VisitGetClaimIssuanceTimingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityDefaultProofTypesRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type GetIdentityDefaultProofTypesResponseObject interface {
+	VisitGetIdentityDefaultProofTypesResponse(w http.ResponseWriter) error
+}
+
+type GetIdentityDefaultProofTypes200JSONResponse DefaultProofTypes
+
+func (response GetIdentityDefaultProofTypes200JSONResponse) VisitGetIdentityDefaultProofTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityDefaultProofTypes400JSONResponse struct{ N400JSONResponse }
+
+func (response GetIdentityDefaultProofTypes400JSONResponse) VisitGetIdentityDefaultProofTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityDefaultProofTypes401JSONResponse struct{ N401JSONResponse }
+
+func (response GetIdentityDefaultProofTypes401JSONResponse) VisitGetIdentityDefaultProofTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityDefaultProofTypes404JSONResponse struct{ N404JSONResponse }
+
+func (response GetIdentityDefaultProofTypes404JSONResponse) VisitGetIdentityDefaultProofTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityDefaultProofTypes500JSONResponse struct{ N500JSONResponse }
+
+func (response GetIdentityDefaultProofTypes500JSONResponse) VisitGetIdentityDefaultProofTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityDefaultProofTypesRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *UpdateIdentityDefaultProofTypesJSONRequestBody
+}
+
+type UpdateIdentityDefaultProofTypesResponseObject interface {
+	VisitUpdateIdentityDefaultProofTypesResponse(w http.ResponseWriter) error
+}
+
+type UpdateIdentityDefaultProofTypes200JSONResponse DefaultProofTypes
+
+func (response UpdateIdentityDefaultProofTypes200JSONResponse) VisitUpdateIdentityDefaultProofTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityDefaultProofTypes400JSONResponse struct{ N400JSONResponse }
+
+func (response UpdateIdentityDefaultProofTypes400JSONResponse) VisitUpdateIdentityDefaultProofTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityDefaultProofTypes401JSONResponse struct{ N401JSONResponse }
+
+func (response UpdateIdentityDefaultProofTypes401JSONResponse) VisitUpdateIdentityDefaultProofTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityDefaultProofTypes404JSONResponse struct{ N404JSONResponse }
+
+func (response UpdateIdentityDefaultProofTypes404JSONResponse) VisitUpdateIdentityDefaultProofTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityDefaultProofTypes500JSONResponse struct{ N500JSONResponse }
+
+func (response UpdateIdentityDefaultProofTypes500JSONResponse) VisitUpdateIdentityDefaultProofTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigningKeysRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type GetSigningKeysResponseObject interface {
+	VisitGetSigningKeysResponse(w http.ResponseWriter) error
+}
+
+type GetSigningKeys200JSONResponse []SigningKey
+
+func (response GetSigningKeys200JSONResponse) VisitGetSigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigningKeys400JSONResponse struct{ N400JSONResponse }
+
+func (response GetSigningKeys400JSONResponse) VisitGetSigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigningKeys401JSONResponse struct{ N401JSONResponse }
+
+func (response GetSigningKeys401JSONResponse) VisitGetSigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigningKeys404JSONResponse struct{ N404JSONResponse }
+
+func (response GetSigningKeys404JSONResponse) VisitGetSigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigningKeys500JSONResponse struct{ N500JSONResponse }
+
+func (response GetSigningKeys500JSONResponse) VisitGetSigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddSigningKeyRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     AddSigningKeyParams
+}
+
+type AddSigningKeyResponseObject interface {
+	VisitAddSigningKeyResponse(w http.ResponseWriter) error
+}
+
+type AddSigningKey201JSONResponse SigningKey
+
+func (response AddSigningKey201JSONResponse) VisitAddSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddSigningKey400JSONResponse struct{ N400JSONResponse }
+
+func (response AddSigningKey400JSONResponse) VisitAddSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddSigningKey401JSONResponse struct{ N401JSONResponse }
+
+func (response AddSigningKey401JSONResponse) VisitAddSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddSigningKey404JSONResponse struct{ N404JSONResponse }
+
+func (response AddSigningKey404JSONResponse) VisitAddSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddSigningKey500JSONResponse struct{ N500JSONResponse }
+
+func (response AddSigningKey500JSONResponse) VisitAddSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateIdentityKeyRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type RotateIdentityKeyResponseObject interface {
+	VisitRotateIdentityKeyResponse(w http.ResponseWriter) error
+}
+
+type RotateIdentityKey202JSONResponse KeyRotation
+
+func (response RotateIdentityKey202JSONResponse) VisitRotateIdentityKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateIdentityKey400JSONResponse struct{ N400JSONResponse }
+
+func (response RotateIdentityKey400JSONResponse) VisitRotateIdentityKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateIdentityKey401JSONResponse struct{ N401JSONResponse }
+
+func (response RotateIdentityKey401JSONResponse) VisitRotateIdentityKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateIdentityKey404JSONResponse struct{ N404JSONResponse }
+
+func (response RotateIdentityKey404JSONResponse) VisitRotateIdentityKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateIdentityKey409JSONResponse struct{ N409JSONResponse }
+
+func (response RotateIdentityKey409JSONResponse) VisitRotateIdentityKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateIdentityKey500JSONResponse struct{ N500JSONResponse }
+
+func (response RotateIdentityKey500JSONResponse) VisitRotateIdentityKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetKeyRotationRequestObject struct {
+	Identifier PathIdentifier  `json:"identifier"`
+	Id         PathKeyRotation `json:"id"`
+}
+
+type GetKeyRotationResponseObject interface {
+	VisitGetKeyRotationResponse(w http.ResponseWriter) error
+}
+
+type GetKeyRotation200JSONResponse KeyRotation
+
+func (response GetKeyRotation200JSONResponse) VisitGetKeyRotationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetKeyRotation400JSONResponse struct{ N400JSONResponse }
+
+func (response GetKeyRotation400JSONResponse) VisitGetKeyRotationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetKeyRotation401JSONResponse struct{ N401JSONResponse }
+
+func (response GetKeyRotation401JSONResponse) VisitGetKeyRotationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetKeyRotation404JSONResponse struct{ N404JSONResponse }
+
+func (response GetKeyRotation404JSONResponse) VisitGetKeyRotationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetKeyRotation500JSONResponse struct{ N500JSONResponse }
+
+func (response GetKeyRotation500JSONResponse) VisitGetKeyRotationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSigningKeyRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathSigningKey `json:"id"`
+}
+
+type RemoveSigningKeyResponseObject interface {
+	VisitRemoveSigningKeyResponse(w http.ResponseWriter) error
+}
+
+type RemoveSigningKey202JSONResponse RevokeClaimResponse
+
+func (response RemoveSigningKey202JSONResponse) VisitRemoveSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSigningKey400JSONResponse struct{ N400JSONResponse }
+
+func (response RemoveSigningKey400JSONResponse) VisitRemoveSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSigningKey401JSONResponse struct{ N401JSONResponse }
+
+func (response RemoveSigningKey401JSONResponse) VisitRemoveSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSigningKey404JSONResponse struct{ N404JSONResponse }
+
+func (response RemoveSigningKey404JSONResponse) VisitRemoveSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSigningKey409JSONResponse struct{ N409JSONResponse }
+
+func (response RemoveSigningKey409JSONResponse) VisitRemoveSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSigningKey500JSONResponse struct{ N500JSONResponse }
+
+func (response RemoveSigningKey500JSONResponse) VisitRemoveSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityQuotasRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *UpdateIdentityQuotasJSONRequestBody
+}
+
+type UpdateIdentityQuotasResponseObject interface {
+	VisitUpdateIdentityQuotasResponse(w http.ResponseWriter) error
+}
+
+type UpdateIdentityQuotas200JSONResponse Quotas
+
+func (response UpdateIdentityQuotas200JSONResponse) VisitUpdateIdentityQuotasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityQuotas400JSONResponse struct{ N400JSONResponse }
+
+func (response UpdateIdentityQuotas400JSONResponse) VisitUpdateIdentityQuotasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityQuotas401JSONResponse struct{ N401JSONResponse }
+
+func (response UpdateIdentityQuotas401JSONResponse) VisitUpdateIdentityQuotasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityQuotas404JSONResponse struct{ N404JSONResponse }
+
+func (response UpdateIdentityQuotas404JSONResponse) VisitUpdateIdentityQuotasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateIdentityQuotas500JSONResponse struct{ N500JSONResponse }
+
+func (response UpdateIdentityQuotas500JSONResponse) VisitUpdateIdentityQuotasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityStatsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type GetIdentityStatsResponseObject interface {
+	VisitGetIdentityStatsResponse(w http.ResponseWriter) error
+}
+
+type GetIdentityStats200JSONResponse IdentityStats
+
+func (response GetIdentityStats200JSONResponse) VisitGetIdentityStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityStats400JSONResponse struct{ N400JSONResponse }
+
+func (response GetIdentityStats400JSONResponse) VisitGetIdentityStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityStats401JSONResponse struct{ N401JSONResponse }
+
+func (response GetIdentityStats401JSONResponse) VisitGetIdentityStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityStats404JSONResponse struct{ N404JSONResponse }
+
+func (response GetIdentityStats404JSONResponse) VisitGetIdentityStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdentityStats500JSONResponse struct{ N500JSONResponse }
+
+func (response GetIdentityStats500JSONResponse) VisitGetIdentityStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishIdentityStateRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type PublishIdentityStateResponseObject interface {
+	VisitPublishIdentityStateResponse(w http.ResponseWriter) error
+}
+
+type PublishIdentityState200JSONResponse GenericErrorMessage
+
+func (response PublishIdentityState200JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishIdentityState202JSONResponse PublishIdentityStateResponse
+
+func (response PublishIdentityState202JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishIdentityState400JSONResponse struct{ N400JSONResponse }
+
+func (response PublishIdentityState400JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishIdentityState401JSONResponse struct{ N401JSONResponse }
+
+func (response PublishIdentityState401JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishIdentityState500JSONResponse struct{ N500JSONResponse }
+
+func (response PublishIdentityState500JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaWebhooksRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type GetSchemaWebhooksResponseObject interface {
+	VisitGetSchemaWebhooksResponse(w http.ResponseWriter) error
+}
+
+type GetSchemaWebhooks200JSONResponse []SchemaWebhook
+
+func (response GetSchemaWebhooks200JSONResponse) VisitGetSchemaWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaWebhooks400JSONResponse struct{ N400JSONResponse }
+
+func (response GetSchemaWebhooks400JSONResponse) VisitGetSchemaWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaWebhooks401JSONResponse struct{ N401JSONResponse }
+
+func (response GetSchemaWebhooks401JSONResponse) VisitGetSchemaWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaWebhooks500JSONResponse struct{ N500JSONResponse }
+
+func (response GetSchemaWebhooks500JSONResponse) VisitGetSchemaWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSchemaWebhookRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateSchemaWebhookJSONRequestBody
+}
+
+type CreateSchemaWebhookResponseObject interface {
+	VisitCreateSchemaWebhookResponse(w http.ResponseWriter) error
+}
+
+type CreateSchemaWebhook201JSONResponse SchemaWebhook
+
+func (response CreateSchemaWebhook201JSONResponse) VisitCreateSchemaWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSchemaWebhook400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateSchemaWebhook400JSONResponse) VisitCreateSchemaWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSchemaWebhook401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateSchemaWebhook401JSONResponse) VisitCreateSchemaWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSchemaWebhook500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateSchemaWebhook500JSONResponse) VisitCreateSchemaWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSchemaWebhookRequestObject struct {
+	Identifier PathIdentifier    `json:"identifier"`
+	Id         PathSchemaWebhook `json:"id"`
+}
+
+type DeleteSchemaWebhookResponseObject interface {
+	VisitDeleteSchemaWebhookResponse(w http.ResponseWriter) error
+}
+
+type DeleteSchemaWebhook200JSONResponse GenericErrorMessage
+
+func (response DeleteSchemaWebhook200JSONResponse) VisitDeleteSchemaWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSchemaWebhook400JSONResponse struct{ N400JSONResponse }
+
+func (response DeleteSchemaWebhook400JSONResponse) VisitDeleteSchemaWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSchemaWebhook401JSONResponse struct{ N401JSONResponse }
+
+func (response DeleteSchemaWebhook401JSONResponse) VisitDeleteSchemaWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSchemaWebhook404JSONResponse struct{ N404JSONResponse }
+
+func (response DeleteSchemaWebhook404JSONResponse) VisitDeleteSchemaWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSchemaWebhook500JSONResponse struct{ N500JSONResponse }
+
+func (response DeleteSchemaWebhook500JSONResponse) VisitDeleteSchemaWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get the documentation
+	// (GET /)
+	GetDocumentation(ctx context.Context, request GetDocumentationRequestObject) (GetDocumentationResponseObject, error)
+	// Get Issuer Metadata
+	// (GET /.well-known/issuer-metadata)
+	GetIssuerMetadata(ctx context.Context, request GetIssuerMetadataRequestObject) (GetIssuerMetadataResponseObject, error)
+	// Gets the favicon
+	// (GET /favicon.ico)
+	GetFavicon(ctx context.Context, request GetFaviconRequestObject) (GetFaviconResponseObject, error)
 	// Get the documentation yaml file
 	// (GET /static/docs/api/api.yaml)
 	GetYaml(ctx context.Context, request GetYamlRequestObject) (GetYamlResponseObject, error)
@@ -1395,82 +4392,829 @@ type StrictServerInterface interface {
 	// Agent
 	// (POST /v1/agent)
 	Agent(ctx context.Context, request AgentRequestObject) (AgentResponseObject, error)
+	// Check Credential Validity
+	// (POST /v1/credentials/validity)
+	CheckCredentialValidity(ctx context.Context, request CheckCredentialValidityRequestObject) (CheckCredentialValidityResponseObject, error)
+	// Get Query Plans
+	// (GET /v1/diagnostics/query-plans)
+	GetQueryPlans(ctx context.Context, request GetQueryPlansRequestObject) (GetQueryPlansResponseObject, error)
 	// Get Identities
 	// (GET /v1/identities)
 	GetIdentities(ctx context.Context, request GetIdentitiesRequestObject) (GetIdentitiesResponseObject, error)
 	// Create Identity
 	// (POST /v1/identities)
 	CreateIdentity(ctx context.Context, request CreateIdentityRequestObject) (CreateIdentityResponseObject, error)
+	// Get Maintenance
+	// (GET /v1/maintenance)
+	GetMaintenance(ctx context.Context, request GetMaintenanceRequestObject) (GetMaintenanceResponseObject, error)
+	// Update Maintenance
+	// (PUT /v1/maintenance)
+	UpdateMaintenance(ctx context.Context, request UpdateMaintenanceRequestObject) (UpdateMaintenanceResponseObject, error)
+	// Get Metering
+	// (GET /v1/metering)
+	GetMetering(ctx context.Context, request GetMeteringRequestObject) (GetMeteringResponseObject, error)
+	// Get Region
+	// (GET /v1/region)
+	GetRegion(ctx context.Context, request GetRegionRequestObject) (GetRegionResponseObject, error)
+	// Promote Region
+	// (POST /v1/region/promote)
+	PromoteRegion(ctx context.Context, request PromoteRegionRequestObject) (PromoteRegionResponseObject, error)
+	// Get Identity Branding
+	// (GET /v1/{identifier}/branding)
+	GetIdentityBranding(ctx context.Context, request GetIdentityBrandingRequestObject) (GetIdentityBrandingResponseObject, error)
+	// Update Identity Branding
+	// (PUT /v1/{identifier}/branding)
+	UpdateIdentityBranding(ctx context.Context, request UpdateIdentityBrandingRequestObject) (UpdateIdentityBrandingResponseObject, error)
 	// Get Claims
 	// (GET /v1/{identifier}/claims)
 	GetClaims(ctx context.Context, request GetClaimsRequestObject) (GetClaimsResponseObject, error)
 	// Create Claim
 	// (POST /v1/{identifier}/claims)
 	CreateClaim(ctx context.Context, request CreateClaimRequestObject) (CreateClaimResponseObject, error)
+	// Import Claim
+	// (POST /v1/{identifier}/claims/import)
+	ImportClaim(ctx context.Context, request ImportClaimRequestObject) (ImportClaimResponseObject, error)
+	// Re-sign Claims After Context Changes
+	// (POST /v1/{identifier}/claims/resign)
+	ResignClaims(ctx context.Context, request ResignClaimsRequestObject) (ResignClaimsResponseObject, error)
 	// Get Revocation Status
 	// (GET /v1/{identifier}/claims/revocation/status/{nonce})
 	GetRevocationStatus(ctx context.Context, request GetRevocationStatusRequestObject) (GetRevocationStatusResponseObject, error)
 	// Revoke Claim
 	// (POST /v1/{identifier}/claims/revoke/{nonce})
 	RevokeClaim(ctx context.Context, request RevokeClaimRequestObject) (RevokeClaimResponseObject, error)
+	// Stream Claims
+	// (POST /v1/{identifier}/claims/stream)
+	StreamClaims(ctx context.Context, request StreamClaimsRequestObject) (StreamClaimsResponseObject, error)
 	// Get Claim
 	// (GET /v1/{identifier}/claims/{id})
 	GetClaim(ctx context.Context, request GetClaimRequestObject) (GetClaimResponseObject, error)
 	// Get Claim QR code
 	// (GET /v1/{identifier}/claims/{id}/qrcode)
 	GetClaimQrCode(ctx context.Context, request GetClaimQrCodeRequestObject) (GetClaimQrCodeResponseObject, error)
+	// Get Claim Display
+	// (GET /v1/{identifier}/claims/{id}/display)
+	GetClaimDisplay(ctx context.Context, request GetClaimDisplayRequestObject) (GetClaimDisplayResponseObject, error)
+	// Get Claim Issuance Timing
+	// (GET /v1/{identifier}/claims/{id}/timing)
+	GetClaimIssuanceTiming(ctx context.Context, request GetClaimIssuanceTimingRequestObject) (GetClaimIssuanceTimingResponseObject, error)
+	// Get Identity Default Proof Types
+	// (GET /v1/{identifier}/default-proof-types)
+	GetIdentityDefaultProofTypes(ctx context.Context, request GetIdentityDefaultProofTypesRequestObject) (GetIdentityDefaultProofTypesResponseObject, error)
+	// Update Identity Default Proof Types
+	// (PUT /v1/{identifier}/default-proof-types)
+	UpdateIdentityDefaultProofTypes(ctx context.Context, request UpdateIdentityDefaultProofTypesRequestObject) (UpdateIdentityDefaultProofTypesResponseObject, error)
+	// Get Signing Keys
+	// (GET /v1/{identifier}/keys)
+	GetSigningKeys(ctx context.Context, request GetSigningKeysRequestObject) (GetSigningKeysResponseObject, error)
+	// Add Signing Key
+	// (POST /v1/{identifier}/keys)
+	AddSigningKey(ctx context.Context, request AddSigningKeyRequestObject) (AddSigningKeyResponseObject, error)
+	// Rotate Identity Key
+	// (POST /v1/{identifier}/keys/rotations)
+	RotateIdentityKey(ctx context.Context, request RotateIdentityKeyRequestObject) (RotateIdentityKeyResponseObject, error)
+	// Get Key Rotation
+	// (GET /v1/{identifier}/keys/rotations/{id})
+	GetKeyRotation(ctx context.Context, request GetKeyRotationRequestObject) (GetKeyRotationResponseObject, error)
+	// Remove Signing Key
+	// (DELETE /v1/{identifier}/keys/{id})
+	RemoveSigningKey(ctx context.Context, request RemoveSigningKeyRequestObject) (RemoveSigningKeyResponseObject, error)
+	// Update Identity Quotas
+	// (PUT /v1/{identifier}/quotas)
+	UpdateIdentityQuotas(ctx context.Context, request UpdateIdentityQuotasRequestObject) (UpdateIdentityQuotasResponseObject, error)
+	// Get Identity Stats
+	// (GET /v1/{identifier}/stats)
+	GetIdentityStats(ctx context.Context, request GetIdentityStatsRequestObject) (GetIdentityStatsResponseObject, error)
 	// Publish Identity State
 	// (POST /v1/{identifier}/state/publish)
 	PublishIdentityState(ctx context.Context, request PublishIdentityStateRequestObject) (PublishIdentityStateResponseObject, error)
+	// Get Schema Webhooks
+	// (GET /v1/{identifier}/webhooks)
+	GetSchemaWebhooks(ctx context.Context, request GetSchemaWebhooksRequestObject) (GetSchemaWebhooksResponseObject, error)
+	// Create Schema Webhook
+	// (POST /v1/{identifier}/webhooks)
+	CreateSchemaWebhook(ctx context.Context, request CreateSchemaWebhookRequestObject) (CreateSchemaWebhookResponseObject, error)
+	// Delete Schema Webhook
+	// (DELETE /v1/{identifier}/webhooks/{id})
+	DeleteSchemaWebhook(ctx context.Context, request DeleteSchemaWebhookRequestObject) (DeleteSchemaWebhookResponseObject, error)
+}
+
+type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
+
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetDocumentation operation middleware
+func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request) {
+	var request GetDocumentationRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDocumentation(ctx, request.(GetDocumentationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDocumentation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDocumentationResponseObject); ok {
+		if err := validResponse.VisitGetDocumentationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetIssuerMetadata operation middleware
+func (sh *strictHandler) GetIssuerMetadata(w http.ResponseWriter, r *http.Request) {
+	var request GetIssuerMetadataRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetIssuerMetadata(ctx, request.(GetIssuerMetadataRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetIssuerMetadata")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetIssuerMetadataResponseObject); ok {
+		if err := validResponse.VisitGetIssuerMetadataResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetFavicon operation middleware
+func (sh *strictHandler) GetFavicon(w http.ResponseWriter, r *http.Request) {
+	var request GetFaviconRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFavicon(ctx, request.(GetFaviconRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFavicon")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFaviconResponseObject); ok {
+		if err := validResponse.VisitGetFaviconResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetYaml operation middleware
+func (sh *strictHandler) GetYaml(w http.ResponseWriter, r *http.Request) {
+	var request GetYamlRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetYaml(ctx, request.(GetYamlRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetYaml")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetYamlResponseObject); ok {
+		if err := validResponse.VisitGetYamlResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// Health operation middleware
+func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
+	var request HealthRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Health(ctx, request.(HealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Health")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(HealthResponseObject); ok {
+		if err := validResponse.VisitHealthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// Agent operation middleware
+func (sh *strictHandler) Agent(w http.ResponseWriter, r *http.Request) {
+	var request AgentRequestObject
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+	body := AgentTextRequestBody(data)
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Agent(ctx, request.(AgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Agent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AgentResponseObject); ok {
+		if err := validResponse.VisitAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CheckCredentialValidity operation middleware
+func (sh *strictHandler) CheckCredentialValidity(w http.ResponseWriter, r *http.Request) {
+	var request CheckCredentialValidityRequestObject
+
+	var body CheckCredentialValidityJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CheckCredentialValidity(ctx, request.(CheckCredentialValidityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CheckCredentialValidity")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CheckCredentialValidityResponseObject); ok {
+		if err := validResponse.VisitCheckCredentialValidityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetQueryPlans operation middleware
+func (sh *strictHandler) GetQueryPlans(w http.ResponseWriter, r *http.Request) {
+	var request GetQueryPlansRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetQueryPlans(ctx, request.(GetQueryPlansRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetQueryPlans")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetQueryPlansResponseObject); ok {
+		if err := validResponse.VisitGetQueryPlansResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetIdentities operation middleware
+func (sh *strictHandler) GetIdentities(w http.ResponseWriter, r *http.Request) {
+	var request GetIdentitiesRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetIdentities(ctx, request.(GetIdentitiesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetIdentities")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetIdentitiesResponseObject); ok {
+		if err := validResponse.VisitGetIdentitiesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateIdentity operation middleware
+func (sh *strictHandler) CreateIdentity(w http.ResponseWriter, r *http.Request) {
+	var request CreateIdentityRequestObject
+
+	var body CreateIdentityJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateIdentity(ctx, request.(CreateIdentityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateIdentity")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateIdentityResponseObject); ok {
+		if err := validResponse.VisitCreateIdentityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetMaintenance operation middleware
+func (sh *strictHandler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	var request GetMaintenanceRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMaintenance(ctx, request.(GetMaintenanceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMaintenance")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMaintenanceResponseObject); ok {
+		if err := validResponse.VisitGetMaintenanceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// UpdateMaintenance operation middleware
+func (sh *strictHandler) UpdateMaintenance(w http.ResponseWriter, r *http.Request) {
+	var request UpdateMaintenanceRequestObject
+
+	var body UpdateMaintenanceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateMaintenance(ctx, request.(UpdateMaintenanceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateMaintenance")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateMaintenanceResponseObject); ok {
+		if err := validResponse.VisitUpdateMaintenanceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetMetering operation middleware
+func (sh *strictHandler) GetMetering(w http.ResponseWriter, r *http.Request, params GetMeteringParams) {
+	var request GetMeteringRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMetering(ctx, request.(GetMeteringRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMetering")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMeteringResponseObject); ok {
+		if err := validResponse.VisitGetMeteringResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetRegion operation middleware
+func (sh *strictHandler) GetRegion(w http.ResponseWriter, r *http.Request) {
+	var request GetRegionRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRegion(ctx, request.(GetRegionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRegion")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRegionResponseObject); ok {
+		if err := validResponse.VisitGetRegionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// PromoteRegion operation middleware
+func (sh *strictHandler) PromoteRegion(w http.ResponseWriter, r *http.Request) {
+	var request PromoteRegionRequestObject
+
+	var body PromoteRegionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PromoteRegion(ctx, request.(PromoteRegionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PromoteRegion")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PromoteRegionResponseObject); ok {
+		if err := validResponse.VisitPromoteRegionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetIdentityBranding operation middleware
+func (sh *strictHandler) GetIdentityBranding(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request GetIdentityBrandingRequestObject
+
+	request.Identifier = identifier
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetIdentityBranding(ctx, request.(GetIdentityBrandingRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetIdentityBranding")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetIdentityBrandingResponseObject); ok {
+		if err := validResponse.VisitGetIdentityBrandingResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// UpdateIdentityBranding operation middleware
+func (sh *strictHandler) UpdateIdentityBranding(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request UpdateIdentityBrandingRequestObject
+
+	request.Identifier = identifier
+
+	var body UpdateIdentityBrandingJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateIdentityBranding(ctx, request.(UpdateIdentityBrandingRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateIdentityBranding")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateIdentityBrandingResponseObject); ok {
+		if err := validResponse.VisitUpdateIdentityBrandingResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetClaims operation middleware
+func (sh *strictHandler) GetClaims(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetClaimsParams) {
+	var request GetClaimsRequestObject
+
+	request.Identifier = identifier
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetClaims(ctx, request.(GetClaimsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetClaims")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetClaimsResponseObject); ok {
+		if err := validResponse.VisitGetClaimsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateClaim operation middleware
+func (sh *strictHandler) CreateClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateClaimRequestObject
+
+	request.Identifier = identifier
+
+	var body CreateClaimJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateClaim(ctx, request.(CreateClaimRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateClaim")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateClaimResponseObject); ok {
+		if err := validResponse.VisitCreateClaimResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// ImportClaim operation middleware
+func (sh *strictHandler) ImportClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request ImportClaimRequestObject
+
+	request.Identifier = identifier
+
+	var body ImportClaimJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportClaim(ctx, request.(ImportClaimRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportClaim")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ImportClaimResponseObject); ok {
+		if err := validResponse.VisitImportClaimResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// ResignClaims operation middleware
+func (sh *strictHandler) ResignClaims(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request ResignClaimsRequestObject
+
+	request.Identifier = identifier
+
+	var body ResignClaimsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResignClaims(ctx, request.(ResignClaimsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResignClaims")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResignClaimsResponseObject); ok {
+		if err := validResponse.VisitResignClaimsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetRevocationStatus operation middleware
+func (sh *strictHandler) GetRevocationStatus(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce) {
+	var request GetRevocationStatusRequestObject
+
+	request.Identifier = identifier
+	request.Nonce = nonce
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRevocationStatus(ctx, request.(GetRevocationStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRevocationStatus")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRevocationStatusResponseObject); ok {
+		if err := validResponse.VisitGetRevocationStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// RevokeClaim operation middleware
+func (sh *strictHandler) RevokeClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce) {
+	var request RevokeClaimRequestObject
+
+	request.Identifier = identifier
+	request.Nonce = nonce
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeClaim(ctx, request.(RevokeClaimRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeClaim")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeClaimResponseObject); ok {
+		if err := validResponse.VisitRevokeClaimResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
+// StreamClaims operation middleware
+func (sh *strictHandler) StreamClaims(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request StreamClaimsRequestObject
 
-type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+	request.Identifier = identifier
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	request.Body = r.Body
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.StreamClaims(ctx, request.(StreamClaimsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StreamClaims")
+	}
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(StreamClaimsResponseObject); ok {
+		if err := validResponse.VisitStreamClaimsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-// GetDocumentation operation middleware
-func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request) {
-	var request GetDocumentationRequestObject
+// GetClaim operation middleware
+func (sh *strictHandler) GetClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim, params GetClaimParams) {
+	var request GetClaimRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetDocumentation(ctx, request.(GetDocumentationRequestObject))
+		return sh.ssi.GetClaim(ctx, request.(GetClaimRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetDocumentation")
+		handler = middleware(handler, "GetClaim")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetDocumentationResponseObject); ok {
-		if err := validResponse.VisitGetDocumentationResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetClaimResponseObject); ok {
+		if err := validResponse.VisitGetClaimResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1478,23 +5222,26 @@ func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// GetFavicon operation middleware
-func (sh *strictHandler) GetFavicon(w http.ResponseWriter, r *http.Request) {
-	var request GetFaviconRequestObject
+// GetClaimQrCode operation middleware
+func (sh *strictHandler) GetClaimQrCode(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
+	var request GetClaimQrCodeRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetFavicon(ctx, request.(GetFaviconRequestObject))
+		return sh.ssi.GetClaimQrCode(ctx, request.(GetClaimQrCodeRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetFavicon")
+		handler = middleware(handler, "GetClaimQrCode")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetFaviconResponseObject); ok {
-		if err := validResponse.VisitGetFaviconResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetClaimQrCodeResponseObject); ok {
+		if err := validResponse.VisitGetClaimQrCodeResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1502,23 +5249,27 @@ func (sh *strictHandler) GetFavicon(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetYaml operation middleware
-func (sh *strictHandler) GetYaml(w http.ResponseWriter, r *http.Request) {
-	var request GetYamlRequestObject
+// GetClaimDisplay operation middleware
+func (sh *strictHandler) GetClaimDisplay(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim, params GetClaimDisplayParams) {
+	var request GetClaimDisplayRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetYaml(ctx, request.(GetYamlRequestObject))
+		return sh.ssi.GetClaimDisplay(ctx, request.(GetClaimDisplayRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetYaml")
+		handler = middleware(handler, "GetClaimDisplay")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetYamlResponseObject); ok {
-		if err := validResponse.VisitGetYamlResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetClaimDisplayResponseObject); ok {
+		if err := validResponse.VisitGetClaimDisplayResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1526,23 +5277,26 @@ func (sh *strictHandler) GetYaml(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Health operation middleware
-func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
-	var request HealthRequestObject
+// GetClaimIssuanceTiming operation middleware
+func (sh *strictHandler) GetClaimIssuanceTiming(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
+	var request GetClaimIssuanceTimingRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Health(ctx, request.(HealthRequestObject))
+		return sh.ssi.GetClaimIssuanceTiming(ctx, request.(GetClaimIssuanceTimingRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Health")
+		handler = middleware(handler, "GetClaimIssuanceTiming")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(HealthResponseObject); ok {
-		if err := validResponse.VisitHealthResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetClaimIssuanceTimingResponseObject); ok {
+		if err := validResponse.VisitGetClaimIssuanceTimingResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1550,31 +5304,58 @@ func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Agent operation middleware
-func (sh *strictHandler) Agent(w http.ResponseWriter, r *http.Request) {
-	var request AgentRequestObject
+// GetIdentityDefaultProofTypes operation middleware
+func (sh *strictHandler) GetIdentityDefaultProofTypes(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request GetIdentityDefaultProofTypesRequestObject
+
+	request.Identifier = identifier
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetIdentityDefaultProofTypes(ctx, request.(GetIdentityDefaultProofTypesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetIdentityDefaultProofTypes")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
 
-	data, err := io.ReadAll(r.Body)
 	if err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetIdentityDefaultProofTypesResponseObject); ok {
+		if err := validResponse.VisitGetIdentityDefaultProofTypesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// UpdateIdentityDefaultProofTypes operation middleware
+func (sh *strictHandler) UpdateIdentityDefaultProofTypes(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request UpdateIdentityDefaultProofTypesRequestObject
+
+	request.Identifier = identifier
+
+	var body UpdateIdentityDefaultProofTypesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
 	}
-	body := AgentTextRequestBody(data)
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Agent(ctx, request.(AgentRequestObject))
+		return sh.ssi.UpdateIdentityDefaultProofTypes(ctx, request.(UpdateIdentityDefaultProofTypesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Agent")
+		handler = middleware(handler, "UpdateIdentityDefaultProofTypes")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AgentResponseObject); ok {
-		if err := validResponse.VisitAgentResponse(w); err != nil {
+	} else if validResponse, ok := response.(UpdateIdentityDefaultProofTypesResponseObject); ok {
+		if err := validResponse.VisitUpdateIdentityDefaultProofTypesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1582,23 +5363,25 @@ func (sh *strictHandler) Agent(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetIdentities operation middleware
-func (sh *strictHandler) GetIdentities(w http.ResponseWriter, r *http.Request) {
-	var request GetIdentitiesRequestObject
+// GetSigningKeys operation middleware
+func (sh *strictHandler) GetSigningKeys(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request GetSigningKeysRequestObject
+
+	request.Identifier = identifier
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetIdentities(ctx, request.(GetIdentitiesRequestObject))
+		return sh.ssi.GetSigningKeys(ctx, request.(GetSigningKeysRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetIdentities")
+		handler = middleware(handler, "GetSigningKeys")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetIdentitiesResponseObject); ok {
-		if err := validResponse.VisitGetIdentitiesResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetSigningKeysResponseObject); ok {
+		if err := validResponse.VisitGetSigningKeysResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1606,30 +5389,52 @@ func (sh *strictHandler) GetIdentities(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateIdentity operation middleware
-func (sh *strictHandler) CreateIdentity(w http.ResponseWriter, r *http.Request) {
-	var request CreateIdentityRequestObject
+// AddSigningKey operation middleware
+func (sh *strictHandler) AddSigningKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params AddSigningKeyParams) {
+	var request AddSigningKeyRequestObject
 
-	var body CreateIdentityJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+	request.Identifier = identifier
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddSigningKey(ctx, request.(AddSigningKeyRequestObject))
 	}
-	request.Body = &body
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddSigningKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddSigningKeyResponseObject); ok {
+		if err := validResponse.VisitAddSigningKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// RotateIdentityKey operation middleware
+func (sh *strictHandler) RotateIdentityKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request RotateIdentityKeyRequestObject
+
+	request.Identifier = identifier
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateIdentity(ctx, request.(CreateIdentityRequestObject))
+		return sh.ssi.RotateIdentityKey(ctx, request.(RotateIdentityKeyRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateIdentity")
+		handler = middleware(handler, "RotateIdentityKey")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateIdentityResponseObject); ok {
-		if err := validResponse.VisitCreateIdentityResponse(w); err != nil {
+	} else if validResponse, ok := response.(RotateIdentityKeyResponseObject); ok {
+		if err := validResponse.VisitRotateIdentityKeyResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1637,26 +5442,26 @@ func (sh *strictHandler) CreateIdentity(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-// GetClaims operation middleware
-func (sh *strictHandler) GetClaims(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetClaimsParams) {
-	var request GetClaimsRequestObject
+// GetKeyRotation operation middleware
+func (sh *strictHandler) GetKeyRotation(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathKeyRotation) {
+	var request GetKeyRotationRequestObject
 
 	request.Identifier = identifier
-	request.Params = params
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetClaims(ctx, request.(GetClaimsRequestObject))
+		return sh.ssi.GetKeyRotation(ctx, request.(GetKeyRotationRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetClaims")
+		handler = middleware(handler, "GetKeyRotation")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetClaimsResponseObject); ok {
-		if err := validResponse.VisitGetClaimsResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetKeyRotationResponseObject); ok {
+		if err := validResponse.VisitGetKeyRotationResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1664,13 +5469,40 @@ func (sh *strictHandler) GetClaims(w http.ResponseWriter, r *http.Request, ident
 	}
 }
 
-// CreateClaim operation middleware
-func (sh *strictHandler) CreateClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
-	var request CreateClaimRequestObject
+// RemoveSigningKey operation middleware
+func (sh *strictHandler) RemoveSigningKey(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathSigningKey) {
+	var request RemoveSigningKeyRequestObject
 
 	request.Identifier = identifier
+	request.Id = id
 
-	var body CreateClaimJSONRequestBody
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveSigningKey(ctx, request.(RemoveSigningKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveSigningKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemoveSigningKeyResponseObject); ok {
+		if err := validResponse.VisitRemoveSigningKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// UpdateIdentityQuotas operation middleware
+func (sh *strictHandler) UpdateIdentityQuotas(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request UpdateIdentityQuotasRequestObject
+
+	request.Identifier = identifier
+
+	var body UpdateIdentityQuotasJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -1678,18 +5510,18 @@ func (sh *strictHandler) CreateClaim(w http.ResponseWriter, r *http.Request, ide
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateClaim(ctx, request.(CreateClaimRequestObject))
+		return sh.ssi.UpdateIdentityQuotas(ctx, request.(UpdateIdentityQuotasRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateClaim")
+		handler = middleware(handler, "UpdateIdentityQuotas")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateClaimResponseObject); ok {
-		if err := validResponse.VisitCreateClaimResponse(w); err != nil {
+	} else if validResponse, ok := response.(UpdateIdentityQuotasResponseObject); ok {
+		if err := validResponse.VisitUpdateIdentityQuotasResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1697,26 +5529,25 @@ func (sh *strictHandler) CreateClaim(w http.ResponseWriter, r *http.Request, ide
 	}
 }
 
-// GetRevocationStatus operation middleware
-func (sh *strictHandler) GetRevocationStatus(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce) {
-	var request GetRevocationStatusRequestObject
+// GetIdentityStats operation middleware
+func (sh *strictHandler) GetIdentityStats(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request GetIdentityStatsRequestObject
 
 	request.Identifier = identifier
-	request.Nonce = nonce
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetRevocationStatus(ctx, request.(GetRevocationStatusRequestObject))
+		return sh.ssi.GetIdentityStats(ctx, request.(GetIdentityStatsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetRevocationStatus")
+		handler = middleware(handler, "GetIdentityStats")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetRevocationStatusResponseObject); ok {
-		if err := validResponse.VisitGetRevocationStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetIdentityStatsResponseObject); ok {
+		if err := validResponse.VisitGetIdentityStatsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1724,26 +5555,25 @@ func (sh *strictHandler) GetRevocationStatus(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// RevokeClaim operation middleware
-func (sh *strictHandler) RevokeClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce) {
-	var request RevokeClaimRequestObject
+// PublishIdentityState operation middleware
+func (sh *strictHandler) PublishIdentityState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request PublishIdentityStateRequestObject
 
 	request.Identifier = identifier
-	request.Nonce = nonce
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.RevokeClaim(ctx, request.(RevokeClaimRequestObject))
+		return sh.ssi.PublishIdentityState(ctx, request.(PublishIdentityStateRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RevokeClaim")
+		handler = middleware(handler, "PublishIdentityState")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RevokeClaimResponseObject); ok {
-		if err := validResponse.VisitRevokeClaimResponse(w); err != nil {
+	} else if validResponse, ok := response.(PublishIdentityStateResponseObject); ok {
+		if err := validResponse.VisitPublishIdentityStateResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1751,26 +5581,25 @@ func (sh *strictHandler) RevokeClaim(w http.ResponseWriter, r *http.Request, ide
 	}
 }
 
-// GetClaim operation middleware
-func (sh *strictHandler) GetClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
-	var request GetClaimRequestObject
+// GetSchemaWebhooks operation middleware
+func (sh *strictHandler) GetSchemaWebhooks(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request GetSchemaWebhooksRequestObject
 
 	request.Identifier = identifier
-	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetClaim(ctx, request.(GetClaimRequestObject))
+		return sh.ssi.GetSchemaWebhooks(ctx, request.(GetSchemaWebhooksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetClaim")
+		handler = middleware(handler, "GetSchemaWebhooks")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetClaimResponseObject); ok {
-		if err := validResponse.VisitGetClaimResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetSchemaWebhooksResponseObject); ok {
+		if err := validResponse.VisitGetSchemaWebhooksResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1778,26 +5607,32 @@ func (sh *strictHandler) GetClaim(w http.ResponseWriter, r *http.Request, identi
 	}
 }
 
-// GetClaimQrCode operation middleware
-func (sh *strictHandler) GetClaimQrCode(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
-	var request GetClaimQrCodeRequestObject
+// CreateSchemaWebhook operation middleware
+func (sh *strictHandler) CreateSchemaWebhook(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateSchemaWebhookRequestObject
 
 	request.Identifier = identifier
-	request.Id = id
+
+	var body CreateSchemaWebhookJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetClaimQrCode(ctx, request.(GetClaimQrCodeRequestObject))
+		return sh.ssi.CreateSchemaWebhook(ctx, request.(CreateSchemaWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetClaimQrCode")
+		handler = middleware(handler, "CreateSchemaWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetClaimQrCodeResponseObject); ok {
-		if err := validResponse.VisitGetClaimQrCodeResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateSchemaWebhookResponseObject); ok {
+		if err := validResponse.VisitCreateSchemaWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1805,25 +5640,26 @@ func (sh *strictHandler) GetClaimQrCode(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// PublishIdentityState operation middleware
-func (sh *strictHandler) PublishIdentityState(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
-	var request PublishIdentityStateRequestObject
+// DeleteSchemaWebhook operation middleware
+func (sh *strictHandler) DeleteSchemaWebhook(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathSchemaWebhook) {
+	var request DeleteSchemaWebhookRequestObject
 
 	request.Identifier = identifier
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PublishIdentityState(ctx, request.(PublishIdentityStateRequestObject))
+		return sh.ssi.DeleteSchemaWebhook(ctx, request.(DeleteSchemaWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PublishIdentityState")
+		handler = middleware(handler, "DeleteSchemaWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PublishIdentityStateResponseObject); ok {
-		if err := validResponse.VisitPublishIdentityStateResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteSchemaWebhookResponseObject); ok {
+		if err := validResponse.VisitDeleteSchemaWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
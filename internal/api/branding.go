@@ -0,0 +1,14 @@
+package api
+
+import (
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func toBrandingResponse(branding domain.Branding) Branding {
+	return Branding{
+		DisplayName:  branding.DisplayName,
+		Logo:         branding.LogoURL,
+		PrimaryColor: branding.PrimaryColor,
+		TextColor:    branding.TextColor,
+	}
+}
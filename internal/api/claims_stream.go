@@ -0,0 +1,165 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// maxStreamLineSize is the size of the longest line of a claims stream
+const maxStreamLineSize = 1 << 20
+
+// StreamClaims issues the credentials of a NDJSON stream of CreateClaimRequest, and acknowledges each line with a
+// StreamClaimsAck as its credential is issued
+func (s *Server) StreamClaims(ctx context.Context, request StreamClaimsRequestObject) (StreamClaimsResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return StreamClaims400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	return claimsStreamResponse{ctx: ctx, server: s, did: did, body: request.Body}, nil
+}
+
+// IsClaimsStream tells if the request is a claims stream, that lasts as long as its client sends it
+func IsClaimsStream(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/claims/stream")
+}
+
+// claimsStreamResponse reads the stream and writes the acks. The stream is read and issued while the acks are
+// written, so it needs the request context.
+type claimsStreamResponse struct {
+	ctx    context.Context
+	server *Server
+	did    *core.DID
+	body   io.Reader
+}
+
+// VisitStreamClaimsResponse writes the acks as the credentials are issued. The request body can only be read after the
+// response is written when the server supports full duplex, otherwise the acks are held until the whole stream is read.
+func (response claimsStreamResponse) VisitStreamClaimsResponse(w http.ResponseWriter) error {
+	fullDuplex := enableFullDuplex(w) == nil
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	acks := make(chan StreamClaimsAck)
+	var read atomic.Bool
+	go response.read(acks, &read)
+
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	var held []StreamClaimsAck
+	var err error
+	write := func(ack StreamClaimsAck) {
+		if err == nil {
+			err = encoder.Encode(ack)
+		}
+	}
+	for ack := range acks {
+		if !fullDuplex && !read.Load() {
+			held = append(held, ack)
+			continue
+		}
+		for _, heldAck := range held {
+			write(heldAck)
+		}
+		held = nil
+		write(ack)
+		if flusher != nil && err == nil {
+			flusher.Flush()
+		}
+	}
+	for _, heldAck := range held {
+		write(heldAck)
+	}
+	return err
+}
+
+// read submits each line of the stream to the ingest queue, sets read once the stream ends, and closes acks once every
+// line is acknowledged
+func (response claimsStreamResponse) read(acks chan<- StreamClaimsAck, read *atomic.Bool) {
+	var pending sync.WaitGroup
+	defer func() {
+		read.Store(true)
+		pending.Wait()
+		close(acks)
+	}()
+
+	scanner := bufio.NewScanner(response.body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var body CreateClaimRequest
+		if err := json.Unmarshal(scanner.Bytes(), &body); err != nil {
+			acks <- streamClaimsErrorAck(line, http.StatusBadRequest, fmt.Errorf("invalid credential request: %w", err))
+			continue
+		}
+		req, err := toCreateClaimRequest(response.did, body)
+		if err != nil {
+			acks <- streamClaimsErrorAck(line, http.StatusBadRequest, err)
+			continue
+		}
+
+		pending.Add(1)
+		ackLine := line
+		err = response.server.claimsIngestService.Submit(response.ctx, req, func(claim *domain.Claim, err error) {
+			defer pending.Done()
+			if err != nil {
+				acks <- streamClaimsErrorAck(ackLine, createClaimErrorStatus(err), err)
+				return
+			}
+			ack := StreamClaimsAck{Line: ackLine, Status: http.StatusCreated, Id: common.ToPointer(claim.ID.String())}
+			if len(req.Warnings) > 0 {
+				ack.Warnings = &req.Warnings
+			}
+			acks <- ack
+		})
+		if err != nil {
+			pending.Done()
+			log.Warn(response.ctx, "claims stream interrupted", "err", err, "did", response.did.String(), "line", line)
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		status := http.StatusBadRequest
+		if !errors.Is(err, bufio.ErrTooLong) {
+			status = http.StatusInternalServerError
+		}
+		acks <- streamClaimsErrorAck(line+1, status, fmt.Errorf("can't read the stream: %w", err))
+	}
+}
+
+func streamClaimsErrorAck(line int, status int, err error) StreamClaimsAck {
+	return StreamClaimsAck{Line: line, Status: status, Error: common.ToPointer(err.Error())}
+}
+
+// enableFullDuplex lets the request body be read after the response is written, like
+// http.ResponseController.EnableFullDuplex of go 1.21
+func enableFullDuplex(w http.ResponseWriter) error {
+	for {
+		switch t := w.(type) {
+		case interface{ EnableFullDuplex() error }:
+			return t.EnableFullDuplex()
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return errors.New("full duplex is not supported")
+		}
+	}
+}
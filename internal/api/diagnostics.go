@@ -0,0 +1,35 @@
+package api
+
+import (
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func toQueryPlansResponse(report *domain.QueryPlanReport) QueryPlansResponse {
+	resp := QueryPlansResponse{
+		Plans:  make([]QueryPlan, 0, len(report.Plans)),
+		Tables: make([]TableStats, 0, len(report.Tables)),
+		Advice: report.Advice,
+	}
+	for _, plan := range report.Plans {
+		resp.Plans = append(resp.Plans, QueryPlan{
+			Name:      plan.Name,
+			Index:     plan.Index,
+			TotalCost: plan.TotalCost,
+			Rows:      plan.Rows,
+			SeqScans:  plan.SeqScans,
+			Indexes:   plan.Indexes,
+		})
+	}
+	for _, table := range report.Tables {
+		resp.Tables = append(resp.Tables, TableStats{
+			Table:      table.Table,
+			LiveRows:   table.LiveRows,
+			DeadRows:   table.DeadRows,
+			SizeBytes:  table.SizeBytes,
+			SeqScans:   table.SeqScans,
+			IndexScans: table.IndexScans,
+			LastVacuum: table.LastVacuum,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// cardSVG draws a credential card with the layout wallets use for Iden3BasicDisplayMethodV1 templates.
+// html/template escapes the texts and drops unsafe urls. The colors the template leaves empty are the default ones of
+// the branding.
+var cardSVG = template.Must(template.New("card").Funcs(template.FuncMap{
+	"color": func(color string) string {
+		if color == "" {
+			return domain.DefaultBrandingTextColor
+		}
+		return color
+	},
+	"background": func(color string) string {
+		if color == "" {
+			return domain.DefaultBrandingPrimaryColor
+		}
+		return color
+	},
+}).Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="340" height="210" viewBox="0 0 340 210">
+<rect width="340" height="210" rx="16" fill="{{background .BackgroundColor}}"/>
+{{- if .BackgroundImageURL}}
+<image href="{{.BackgroundImageURL}}" width="340" height="210" preserveAspectRatio="xMidYMid slice"/>
+{{- end}}
+{{- if .Logo.URI}}
+<image href="{{.Logo.URI}}" x="20" y="20" width="40" height="40"><title>{{.Logo.Alt}}</title></image>
+{{- end}}
+<text x="20" y="104" font-family="sans-serif" font-size="20" font-weight="bold" fill="{{color .TitleTextColor}}">{{.Title}}</text>
+<text x="20" y="132" font-family="sans-serif" font-size="13" fill="{{color .DescriptionTextColor}}">{{.Description}}</text>
+<text x="20" y="188" font-family="sans-serif" font-size="11" fill="{{color .IssuerTextColor}}">{{.IssuerName}}</text>
+</svg>
+`))
+
+func renderCardSVG(card domain.DisplayTemplate) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := cardSVG.Execute(&buf, card); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func toIssuanceTimingResponse(timing *domain.IssuanceTiming) IssuanceTiming {
+	resp := IssuanceTiming{
+		CredentialID: timing.ClaimID.String(),
+		Phases:       make(map[string]float64, len(timing.Phases)),
+		TotalMs:      toMilliseconds(timing.Total),
+		CreatedAt:    timing.CreatedAt,
+	}
+	for phase, d := range timing.Phases {
+		resp.Phases[string(phase)] = toMilliseconds(d)
+	}
+	if slowest, _ := timing.Slowest(); slowest != "" {
+		resp.SlowestPhase = common.ToPointer(string(slowest))
+	}
+	return resp
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func toKeyRotationResponse(rotation *domain.KeyRotation) KeyRotation {
+	return KeyRotation{
+		Id:             rotation.ID,
+		Status:         KeyRotationStatus(rotation.Status),
+		OldAuthClaimID: rotation.OldAuthClaimID,
+		NewAuthClaimID: rotation.NewAuthClaimID,
+		NewKeyID:       rotation.NewKeyID,
+		AddState:       rotation.AddState,
+		RevokeState:    rotation.RevokeState,
+		Error:          rotation.Error,
+		CreatedAt:      rotation.CreatedAt,
+		ModifiedAt:     rotation.ModifiedAt,
+	}
+}
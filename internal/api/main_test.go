@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/hashicorp/vault/api"
@@ -12,7 +13,9 @@ import (
 	"github.com/iden3/iden3comm"
 
 	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
 	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/db/tests"
 	"github.com/polygonid/sh-id-platform/internal/errors"
@@ -121,12 +124,12 @@ func (kpm *KMSMock) RegisterKeyProvider(kt kms.KeyType, kp kms.KeyProvider) erro
 	return nil
 }
 
-func (kpm *KMSMock) CreateKey(kt kms.KeyType, identity *core.DID) (kms.KeyID, error) {
+func (kpm *KMSMock) CreateKey(ctx context.Context, kt kms.KeyType, identity *core.DID) (kms.KeyID, error) {
 	var key kms.KeyID
 	return key, nil
 }
 
-func (kpm *KMSMock) PublicKey(keyID kms.KeyID) ([]byte, error) {
+func (kpm *KMSMock) PublicKey(ctx context.Context, keyID kms.KeyID) ([]byte, error) {
 	var pubKey []byte
 	return pubKey, nil
 }
@@ -154,3 +157,51 @@ func NewPackageManagerMock() *iden3comm.PackageManager {
 func NewPublisherMock() ports.Publisher {
 	return nil
 }
+
+func NewDisplayMock() ports.DisplayService {
+	return nil
+}
+
+func NewCredentialValidityMock() ports.CredentialValidityService {
+	return nil
+}
+
+func NewIssuerMetadataMock() ports.IssuerMetadataService {
+	return nil
+}
+
+func NewKeyRotationMock() ports.KeyRotationService {
+	return nil
+}
+
+func NewSigningKeyMock() ports.SigningKeyService {
+	return nil
+}
+
+func NewClaimsIngestMock() ports.ClaimsIngestService {
+	return nil
+}
+
+// testDependencies returns the server dependencies of the tests: the services of the node on the test database and
+// mocks of the ones that call the outside
+func testDependencies(identityService ports.IdentityService, claimsService ports.ClaimsService, connectionsRepository ports.ConnectionsRepository) Dependencies {
+	return Dependencies{
+		Identity:           identityService,
+		Claims:             claimsService,
+		Connections:        services.NewConnection(connectionsRepository, storage),
+		Display:            NewDisplayMock(),
+		CredentialValidity: NewCredentialValidityMock(),
+		IssuerMetadata:     NewIssuerMetadataMock(),
+		Metering:           services.NewMetering(storage),
+		Maintenance:        services.NewMaintenance(storage, time.Minute, time.Second, nil),
+		Quota:              services.NewQuota(storage, domain.Quotas{}, nil),
+		Branding:           services.NewBranding(storage, domain.Branding{}),
+		Diagnostics:        services.NewDiagnostics(storage),
+		Region:             services.NewRegion(storage, "", time.Minute, time.Second, nil),
+		KeyRotation:        NewKeyRotationMock(),
+		SigningKeys:        NewSigningKeyMock(),
+		ClaimsIngest:       NewClaimsIngestMock(),
+		Publisher:          NewPublisherMock(),
+		PackageManager:     NewPackageManagerMock(),
+	}
+}
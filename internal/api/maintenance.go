@@ -0,0 +1,20 @@
+package api
+
+import (
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func toMaintenanceResponse(maintenance *domain.Maintenance) Maintenance {
+	resp := Maintenance{
+		Enabled:    maintenance.Enabled,
+		RetryAfter: int(maintenance.RetryAfter.Seconds()),
+	}
+	if maintenance.Message != "" {
+		resp.Message = common.ToPointer(maintenance.Message)
+	}
+	if !maintenance.UpdatedAt.IsZero() {
+		resp.UpdatedAt = common.ToPointer(maintenance.UpdatedAt)
+	}
+	return resp
+}
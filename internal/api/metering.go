@@ -0,0 +1,49 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// renderMeteringCSV writes the metering report with a header row and a row per month, issuer, api key and operation.
+// The api key is empty for the operations of the issuer credentials.
+func renderMeteringCSV(months []domain.MeteringMonth) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"month", "issuer", "api_key_id", "operation", "count"}); err != nil {
+		return nil, err
+	}
+	for i := range months {
+		apiKeyID := ""
+		if months[i].APIKeyID != nil {
+			apiKeyID = months[i].APIKeyID.String()
+		}
+		row := []string{months[i].MonthString(), months[i].IssuerDID.String(), apiKeyID, string(months[i].Operation), strconv.FormatInt(months[i].Count, 10)}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func toMeteringResponse(period domain.MeteringPeriod, months []domain.MeteringMonth) MeteringResponse {
+	resp := MeteringResponse{
+		From:  period.From.Format("2006-01"),
+		To:    period.To.Format("2006-01"),
+		Usage: make([]MeteringMonth, len(months)),
+	}
+	for i := range months {
+		resp.Usage[i] = MeteringMonth{
+			ApiKeyID:  months[i].APIKeyID,
+			Count:     months[i].Count,
+			Issuer:    months[i].IssuerDID.String(),
+			Month:     months[i].MonthString(),
+			Operation: string(months[i].Operation),
+		}
+	}
+	return resp
+}
@@ -4,12 +4,19 @@ import (
 	"context"
 	"crypto/subtle"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
 
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	apiErrors "github.com/polygonid/sh-id-platform/internal/errors"
 	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/pkg/antiabuse"
+	"github.com/polygonid/sh-id-platform/pkg/oidc"
 )
 
 // LogMiddleware returns a middleware that adds general log configuration to each context request
@@ -25,23 +32,210 @@ func LogMiddleware(ctx context.Context) StrictMiddlewareFunc {
 	}
 }
 
+// MaintenanceMiddleware rejects with a 503 and a Retry-After the requests that change the node while the maintenance
+// mode is enabled. The reads and the domain.MaintenanceExemptOperations are always served, and so is every request
+// when the mode can't be read.
+func MaintenanceMiddleware(maintenanceService ports.MaintenanceService) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+			if !domain.MaintenanceApplies(r.Method, operationID) {
+				return f(ctx, w, r, args)
+			}
+			maintenance, err := maintenanceService.Get(ctx)
+			if err != nil {
+				log.Error(ctx, "reading the maintenance mode", "err", err)
+				return f(ctx, w, r, args)
+			}
+			if !maintenance.Rejects(r.Method, operationID) {
+				return f(ctx, w, r, args)
+			}
+			message := "the node is under maintenance"
+			if maintenance.Message != "" {
+				message += ": " + maintenance.Message
+			}
+			return nil, apiErrors.UnavailableError{Err: errors.New(message), RetryAfter: maintenance.RetryAfter}
+		}
+	}
+}
+
+// RegionMiddleware rejects with a 503 and a Retry-After the requests that change the node while the region of the
+// server is passive. The reads and the domain.PassiveExemptOperations are always served, and so is every request when
+// the role of the region can't be read.
+func RegionMiddleware(regionService ports.RegionService, retryAfter time.Duration) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+			if !domain.PassiveApplies(r.Method, operationID) {
+				return f(ctx, w, r, args)
+			}
+			region, err := regionService.Get(ctx)
+			if err != nil {
+				log.Error(ctx, "reading the region", "err", err)
+				return f(ctx, w, r, args)
+			}
+			if !region.Rejects(r.Method, operationID) {
+				return f(ctx, w, r, args)
+			}
+			message := fmt.Sprintf("the region %s is passive", region.Name)
+			if region.Lease != nil && region.Lease.Region != region.Name {
+				message += ", the active one is " + region.Lease.Region
+			}
+			return nil, apiErrors.UnavailableError{Err: errors.New(message), RetryAfter: retryAfter}
+		}
+	}
+}
+
+// The route groups of the rate limits of the issuer API
+const (
+	// RateLimitAgent is the agent endpoint the wallets call to fetch their credentials
+	RateLimitAgent = "agent"
+	// RateLimitQRCode are the public qr codes and cards of the credentials that the wallets read
+	RateLimitQRCode = "qrcode"
+	// RateLimitStatus are the revocation status and the validity checks of the verifiers
+	RateLimitStatus = "status"
+	// RateLimitDefault is every other operation
+	RateLimitDefault = "default"
+)
+
+// rateLimitGroups are the route groups of the operations that are not in RateLimitDefault
+var rateLimitGroups = map[string]string{
+	"Agent":                   RateLimitAgent,
+	"GetClaimQrCode":          RateLimitQRCode,
+	"GetClaimDisplay":         RateLimitQRCode,
+	"GetRevocationStatus":     RateLimitStatus,
+	"CheckCredentialValidity": RateLimitStatus,
+}
+
+// RateLimitGroup returns the route group of the rate limits of an operation
+func RateLimitGroup(operationID string) string {
+	if group, ok := rateLimitGroups[operationID]; ok {
+		return group
+	}
+	return RateLimitDefault
+}
+
+// RateLimitMiddleware rejects with a 429 and a Retry-After the requests of the IPs that made too many requests to the
+// route group of the operation. The groups without a throttle are not limited. The client IP is the first one of the
+// X-Forwarded-For header with trustProxy.
+func RateLimitMiddleware(throttles map[string]antiabuse.Throttle, trustProxy bool) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		throttle := throttles[RateLimitGroup(operationID)]
+		if throttle == nil {
+			return f
+		}
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+			ip := antiabuse.ClientIP(r, trustProxy)
+			if wait, err := throttle.Allow(ctx, ip); err != nil {
+				log.Warn(ctx, "throttling request", "ip", ip, "operation", operationID)
+				return nil, apiErrors.TooManyRequestsError{Err: errors.New("too many requests"), RetryAfter: wait}
+			}
+			return f(ctx, w, r, args)
+		}
+	}
+}
+
+type piiScopeKey struct{}
+
 // BasicAuthMiddleware returns a middleware that performs an http basic authorization for endpoints configured with
 // basic auth in the api spec.
 // In uses the BasicAuthScopes value in context to figure if and endpoint needs authorization or not, because this
 // value is injected automatically by openapi when basic auth is selected
 func BasicAuthMiddleware(ctx context.Context, user, pass string) StrictMiddlewareFunc {
+	return BasicAuthWithPIIMiddleware(ctx, user, pass, "", "")
+}
+
+// BasicAuthWithPIIMiddleware works like BasicAuthMiddleware but it also accepts the piiUser and piiPass credentials.
+// Requests authorized with them have the PII scope and get the schema PII attributes unmasked.
+func BasicAuthWithPIIMiddleware(_ context.Context, user, pass, piiUser, piiPass string) StrictMiddlewareFunc {
 	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
 		return func(ctxReq context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
-			if ctxReq.Value(BasicAuthScopes) != nil && user != "" && pass != "" {
+			if ctxReq.Value(BasicAuthScopes) != nil {
 				userReq, passReq, ok := r.BasicAuth()
-				if !ok {
+				if ok && validCredentials(piiUser, piiPass, userReq, passReq) {
+					ctxReq = context.WithValue(ctxReq, piiScopeKey{}, true)
+				} else if user != "" && pass != "" && (!ok || !validCredentials(user, pass, userReq, passReq)) {
 					return nil, apiErrors.AuthError{Err: errors.New("unauthorized")}
 				}
-				if subtle.ConstantTimeCompare([]byte(user), []byte(userReq)) != 1 || subtle.ConstantTimeCompare([]byte(pass), []byte(passReq)) != 1 {
-					return nil, apiErrors.AuthError{Err: errors.New("unauthorized")}
+			}
+			return f(ctxReq, w, r, args)
+		}
+	}
+}
+
+// TokenVerifier verifies the bearer tokens of the requests and returns their claims
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (oidc.Claims, error)
+}
+
+// RoleClaims gives the role to the tokens with the claims
+type RoleClaims struct {
+	Role   domain.Role
+	Claims map[string]string
+}
+
+// OIDCAuthMiddleware authorizes the requests to the endpoints configured with basic auth in the api spec with the
+// JWT bearer tokens of an OpenID Connect identity provider. The tokens with the piiClaims have the PII scope. The role
+// of a token is the first of roles whose claims it has, and it can only call the operations of the role. Without roles
+// every token is an admin. The requests without a bearer token go through basicAuth, and are rejected when it is nil.
+func OIDCAuthMiddleware(verifier TokenVerifier, piiClaims map[string]string, roles []RoleClaims, basicAuth StrictMiddlewareFunc) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		var basic StrictHandlerFunc
+		if basicAuth != nil {
+			basic = basicAuth(f, operationID)
+		}
+		return func(ctxReq context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+			if ctxReq.Value(BasicAuthScopes) == nil {
+				return f(ctxReq, w, r, args)
+			}
+			scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !strings.EqualFold(scheme, "Bearer") {
+				if basic != nil {
+					return basic(ctxReq, w, r, args)
 				}
+				return nil, apiErrors.AuthError{Err: errors.New("unauthorized")}
 			}
-			return f(ctx, w, r, args)
+			claims, err := verifier.Verify(ctxReq, strings.TrimSpace(token))
+			if errors.Is(err, oidc.ErrInvalidToken) {
+				log.Info(ctxReq, "rejected bearer token", "err", err)
+				return nil, apiErrors.AuthError{Err: errors.New("unauthorized")}
+			}
+			if err != nil {
+				log.Error(ctxReq, "verifying the bearer token", "err", err)
+				return nil, err
+			}
+			role, found := tokenRole(claims, roles)
+			if !found || !role.Allows(r.Method, operationID) {
+				log.Warn(ctxReq, "bearer token calling a forbidden operation", "subject", claims.Subject(), "role", role, "operation", operationID)
+				return nil, apiErrors.ForbiddenError{Err: errors.New("forbidden")}
+			}
+			if len(piiClaims) > 0 && claims.Match(piiClaims) {
+				ctxReq = context.WithValue(ctxReq, piiScopeKey{}, true)
+			}
+			return f(log.With(ctxReq, "subject", claims.Subject(), "role", role), w, r, args)
+		}
+	}
+}
+
+// tokenRole returns the role of the token with the claims, admin when there are no roles
+func tokenRole(claims oidc.Claims, roles []RoleClaims) (domain.Role, bool) {
+	if len(roles) == 0 {
+		return domain.RoleAdmin, true
+	}
+	for _, role := range roles {
+		if claims.Match(role.Claims) {
+			return role.Role, true
 		}
 	}
+	return "", false
+}
+
+// hasPIIScope tells whether the request was authorized with the PII credentials
+func hasPIIScope(ctx context.Context) bool {
+	scope, _ := ctx.Value(piiScopeKey{}).(bool)
+	return scope
+}
+
+func validCredentials(user, pass, userReq, passReq string) bool {
+	return user != "" && pass != "" &&
+		subtle.ConstantTimeCompare([]byte(user), []byte(userReq)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(pass), []byte(passReq)) == 1
 }
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	apiErrors "github.com/polygonid/sh-id-platform/internal/errors"
+	"github.com/polygonid/sh-id-platform/pkg/antiabuse"
+	"github.com/polygonid/sh-id-platform/pkg/oidc"
+)
+
+// tokensMock accepts the tokens of its map, and fails with err when it is set
+type tokensMock struct {
+	tokens map[string]oidc.Claims
+	err    error
+}
+
+func (m tokensMock) Verify(_ context.Context, token string) (oidc.Claims, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	claims, ok := m.tokens[token]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key", oidc.ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+func TestOIDCAuthMiddleware(t *testing.T) {
+	ctx := context.WithValue(context.Background(), BasicAuthScopes, []string{""})
+	verifier := tokensMock{tokens: map[string]oidc.Claims{
+		"admin": {"sub": "alice", "groups": []any{"issuer-admins"}},
+		"pii":   {"sub": "bob", "groups": []any{"issuer-admins", "issuer-pii"}},
+		"staff": {"sub": "carol", "groups": []any{"support"}},
+		"guest": {"sub": "dave"},
+	}}
+	var pii bool
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+		pii = hasPIIScope(ctx)
+		return nil, nil
+	}
+	piiClaims := map[string]string{"groups": "issuer-pii"}
+	request := func(authorization string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/v1/identities", nil)
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		return r
+	}
+	basicAuth := BasicAuthMiddleware(ctx, "user", "password")
+
+	t.Run("should accept the valid tokens", func(t *testing.T) {
+		middleware := OIDCAuthMiddleware(verifier, piiClaims, nil, nil)(handler, "GetIdentities")
+		_, err := middleware(ctx, nil, request("Bearer admin"), nil)
+		require.NoError(t, err)
+		assert.False(t, pii)
+
+		_, err = middleware(ctx, nil, request("bearer pii"), nil)
+		require.NoError(t, err)
+		assert.True(t, pii, "the token has the PII claims")
+	})
+
+	t.Run("should reject the invalid tokens and the missing ones", func(t *testing.T) {
+		middleware := OIDCAuthMiddleware(verifier, piiClaims, nil, nil)(handler, "GetIdentities")
+		for _, authorization := range []string{"Bearer other", "", "Basic dXNlcjpwYXNzd29yZA=="} {
+			_, err := middleware(ctx, nil, request(authorization), nil)
+			var unauthorized apiErrors.AuthError
+			assert.ErrorAs(t, err, &unauthorized, authorization)
+		}
+	})
+
+	t.Run("should accept the basic auth credentials when they are allowed", func(t *testing.T) {
+		middleware := OIDCAuthMiddleware(verifier, piiClaims, nil, basicAuth)(handler, "GetIdentities")
+		r := request("")
+		r.SetBasicAuth("user", "password")
+		_, err := middleware(ctx, nil, r, nil)
+		require.NoError(t, err)
+
+		r.SetBasicAuth("user", "wrong")
+		_, err = middleware(ctx, nil, r, nil)
+		var unauthorized apiErrors.AuthError
+		assert.ErrorAs(t, err, &unauthorized)
+	})
+
+	t.Run("should not authorize the public endpoints", func(t *testing.T) {
+		middleware := OIDCAuthMiddleware(verifier, piiClaims, nil, nil)(handler, "GetIssuerMetadata")
+		_, err := middleware(context.Background(), nil, request(""), nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("should limit the tokens to the operations of their role", func(t *testing.T) {
+		roles := []RoleClaims{
+			{Role: domain.RoleAdmin, Claims: map[string]string{"groups": "issuer-admins"}},
+			{Role: domain.RoleReadOnly, Claims: map[string]string{"groups": "support"}},
+		}
+		list := OIDCAuthMiddleware(verifier, piiClaims, roles, nil)(handler, "GetClaims")
+		revoke := OIDCAuthMiddleware(verifier, piiClaims, roles, nil)(handler, "RevokeClaim")
+		post := httptest.NewRequest(http.MethodPost, "/v1/identities/claims/revoke/1", nil)
+		post.Header.Set("Authorization", "Bearer staff")
+
+		_, err := list(ctx, nil, request("Bearer staff"), nil)
+		require.NoError(t, err)
+		_, err = revoke(ctx, nil, post, nil)
+		var forbidden apiErrors.ForbiddenError
+		assert.ErrorAs(t, err, &forbidden)
+
+		post.Header.Set("Authorization", "Bearer admin")
+		_, err = revoke(ctx, nil, post, nil)
+		require.NoError(t, err)
+
+		_, err = list(ctx, nil, request("Bearer guest"), nil)
+		assert.ErrorAs(t, err, &forbidden, "a token without a role")
+	})
+
+	t.Run("should fail when the keys of the identity provider can't be read", func(t *testing.T) {
+		down := tokensMock{err: errors.New("connection refused")}
+		middleware := OIDCAuthMiddleware(down, piiClaims, nil, nil)(handler, "GetIdentities")
+		_, err := middleware(ctx, nil, request("Bearer admin"), nil)
+		require.Error(t, err)
+		var unauthorized apiErrors.AuthError
+		assert.False(t, errors.As(err, &unauthorized))
+	})
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	ctx := context.Background()
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	throttles := map[string]antiabuse.Throttle{RateLimitAgent: antiabuse.NewIPThrottle(2)}
+	request := func(remoteAddr, forwarded string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/v1/agent", nil)
+		r.RemoteAddr = remoteAddr
+		if forwarded != "" {
+			r.Header.Set("X-Forwarded-For", forwarded)
+		}
+		return r
+	}
+
+	agent := RateLimitMiddleware(throttles, true)(handler, "Agent")
+	for i := 0; i < 2; i++ {
+		_, err := agent(ctx, nil, request("10.0.0.1:4000", "203.0.113.7, 10.0.0.1"), nil)
+		require.NoError(t, err)
+	}
+	_, err := agent(ctx, nil, request("10.0.0.1:4000", "203.0.113.7"), nil)
+	var throttled apiErrors.TooManyRequestsError
+	require.ErrorAs(t, err, &throttled)
+	assert.Equal(t, 30*time.Second, throttled.RetryAfter)
+	_, err = agent(ctx, nil, request("10.0.0.1:4000", "198.51.100.1"), nil)
+	assert.NoError(t, err, "the other IPs are not throttled")
+
+	rec := httptest.NewRecorder()
+	apiErrors.ResponseErrorHandlerFunc(rec, nil, throttled)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+	for wait, header := range map[time.Duration]string{1500 * time.Millisecond: "2", 200 * time.Millisecond: "1", 0: "1"} {
+		rec := httptest.NewRecorder()
+		apiErrors.ResponseErrorHandlerFunc(rec, nil, apiErrors.TooManyRequestsError{Err: errors.New("too many requests"), RetryAfter: wait})
+		assert.Equal(t, header, rec.Header().Get("Retry-After"), "rounded up to at least a second")
+	}
+
+	claims := RateLimitMiddleware(throttles, true)(handler, "GetClaims")
+	for i := 0; i < 3; i++ {
+		_, err := claims(ctx, nil, request("10.0.0.1:4000", "203.0.113.7"), nil)
+		require.NoError(t, err, "the default group has no limit")
+	}
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func toQuotasResponse(quotas domain.Quotas) Quotas {
+	return Quotas{
+		MaxActiveLinks:       quotas.MaxActiveLinks,
+		MaxCredentialsPerDay: quotas.MaxCredentialsPerDay,
+		MaxSchemas:           quotas.MaxSchemas,
+	}
+}
+
+func toIdentityStatsResponse(usage *domain.QuotaUsage) IdentityStats {
+	return IdentityStats{
+		Quotas: toQuotasResponse(usage.Quotas),
+		Usage: QuotaUsage{
+			ActiveLinks:      usage.ActiveLinks,
+			CredentialsToday: usage.CredentialsToday,
+			Schemas:          usage.Schemas,
+		},
+	}
+}
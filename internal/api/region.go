@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func toRegionResponse(region *domain.Region) Region {
+	resp := Region{
+		Name:       region.Name,
+		Active:     region.Active,
+		InRecovery: region.InRecovery,
+	}
+	if region.Lease != nil {
+		resp.Lease = &RegionLease{
+			Region:    region.Lease.Region,
+			Epoch:     region.Lease.Epoch,
+			ExpiresAt: region.Lease.ExpiresAt,
+			UpdatedAt: region.Lease.UpdatedAt,
+		}
+	}
+	return resp
+}
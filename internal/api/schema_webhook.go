@@ -0,0 +1,23 @@
+package api
+
+import (
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func toSchemaWebhookResponse(webhook *domain.SchemaWebhook) SchemaWebhook {
+	return SchemaWebhook{
+		Id:         webhook.ID,
+		SchemaType: webhook.SchemaType,
+		Url:        webhook.URL,
+		Events:     webhook.Events,
+		CreatedAt:  webhook.CreatedAt,
+	}
+}
+
+func toSchemaWebhooksResponse(webhooks []domain.SchemaWebhook) []SchemaWebhook {
+	res := make([]SchemaWebhook, len(webhooks))
+	for i := range webhooks {
+		res[i] = toSchemaWebhookResponse(&webhooks[i])
+	}
+	return res
+}
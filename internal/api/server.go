@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -22,33 +23,88 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/core/services"
+	apiErrors "github.com/polygonid/sh-id-platform/internal/errors"
 	"github.com/polygonid/sh-id-platform/internal/gateways"
 	"github.com/polygonid/sh-id-platform/internal/health"
 	"github.com/polygonid/sh-id-platform/internal/log"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/credentials/credentialid"
+	agentprotocol "github.com/polygonid/sh-id-platform/pkg/protocol"
 	"github.com/polygonid/sh-id-platform/pkg/schema"
 )
 
 // Server implements StrictServerInterface and holds the implementation of all API controllers
 // This is the glue to the API autogenerated code
 type Server struct {
-	cfg              *config.Configuration
-	identityService  ports.IdentityService
-	claimService     ports.ClaimsService
-	publisherGateway ports.Publisher
-	packageManager   *iden3comm.PackageManager
-	health           *health.Status
+	cfg                       *config.Configuration
+	identityService           ports.IdentityService
+	claimService              ports.ClaimsService
+	connectionsService        ports.ConnectionsService
+	displayService            ports.DisplayService
+	credentialValidityService ports.CredentialValidityService
+	issuerMetadataService     ports.IssuerMetadataService
+	meteringService           ports.MeteringService
+	maintenanceService        ports.MaintenanceService
+	quotaService              ports.QuotaService
+	brandingService           ports.BrandingService
+	diagnosticsService        ports.DiagnosticsService
+	regionService             ports.RegionService
+	keyRotationService        ports.KeyRotationService
+	signingKeyService         ports.SigningKeyService
+	claimsIngestService       ports.ClaimsIngestService
+	publisherGateway          ports.Publisher
+	packageManager            *iden3comm.PackageManager
+	signedMessages            *agentprotocol.SignedMessages
+	health                    *health.Status
+}
+
+// Dependencies are the services and gateways the Server handlers call
+type Dependencies struct {
+	Identity           ports.IdentityService
+	Claims             ports.ClaimsService
+	Connections        ports.ConnectionsService
+	Display            ports.DisplayService
+	CredentialValidity ports.CredentialValidityService
+	IssuerMetadata     ports.IssuerMetadataService
+	Metering           ports.MeteringService
+	Maintenance        ports.MaintenanceService
+	Quota              ports.QuotaService
+	Branding           ports.BrandingService
+	Diagnostics        ports.DiagnosticsService
+	Region             ports.RegionService
+	KeyRotation        ports.KeyRotationService
+	SigningKeys        ports.SigningKeyService
+	ClaimsIngest       ports.ClaimsIngestService
+	Publisher          ports.Publisher
+	PackageManager     *iden3comm.PackageManager
+	// SignedMessages unpacks the agent messages signed with secp256k1 keys, nil when they are not accepted
+	SignedMessages *agentprotocol.SignedMessages
+	Health         *health.Status
 }
 
 // NewServer is a Server constructor
-func NewServer(cfg *config.Configuration, identityService ports.IdentityService, claimsService ports.ClaimsService, publisherGateway ports.Publisher, packageManager *iden3comm.PackageManager, health *health.Status) *Server {
+func NewServer(cfg *config.Configuration, deps Dependencies) *Server {
 	return &Server{
-		cfg:              cfg,
-		identityService:  identityService,
-		claimService:     claimsService,
-		publisherGateway: publisherGateway,
-		packageManager:   packageManager,
-		health:           health,
+		cfg:                       cfg,
+		identityService:           deps.Identity,
+		claimService:              deps.Claims,
+		connectionsService:        deps.Connections,
+		displayService:            deps.Display,
+		credentialValidityService: deps.CredentialValidity,
+		issuerMetadataService:     deps.IssuerMetadata,
+		meteringService:           deps.Metering,
+		maintenanceService:        deps.Maintenance,
+		quotaService:              deps.Quota,
+		brandingService:           deps.Branding,
+		diagnosticsService:        deps.Diagnostics,
+		regionService:             deps.Region,
+		keyRotationService:        deps.KeyRotation,
+		signingKeyService:         deps.SigningKeys,
+		claimsIngestService:       deps.ClaimsIngest,
+		publisherGateway:          deps.Publisher,
+		packageManager:            deps.PackageManager,
+		signedMessages:            deps.SignedMessages,
+		health:                    deps.Health,
 	}
 }
 
@@ -116,39 +172,130 @@ func (s *Server) CreateClaim(ctx context.Context, request CreateClaimRequestObje
 	if err != nil {
 		return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
-	var expiration *time.Time
-	if request.Body.Expiration != nil {
-		expiration = common.ToPointer(time.Unix(*request.Body.Expiration, 0))
+	req, err := toCreateClaimRequest(did, *request.Body)
+	if err != nil {
+		return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
 
-	req := ports.NewCreateClaimRequest(did, request.Body.CredentialSchema, request.Body.CredentialSubject, expiration, request.Body.Type, request.Body.Version, request.Body.SubjectPosition, request.Body.MerklizedRootPosition, common.ToPointer(true), common.ToPointer(true), nil, false)
-
 	resp, err := s.claimService.Save(ctx, req)
 	if err != nil {
-		if errors.Is(err, services.ErrJSONLdContext) {
+		switch createClaimErrorStatus(err) {
+		case http.StatusBadRequest:
 			return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
-		}
-		if errors.Is(err, services.ErrProcessSchema) {
-			return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
-		}
-		if errors.Is(err, services.ErrLoadingSchema) {
+		case http.StatusForbidden:
+			return CreateClaim403JSONResponse{N403JSONResponse{Message: err.Error()}}, nil
+		case http.StatusConflict:
+			return CreateClaim409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
+		case http.StatusUnprocessableEntity:
 			return CreateClaim422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
 		}
-		if errors.Is(err, services.ErrMalformedURL) {
-			return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	created := CreateClaim201JSONResponse{Id: resp.ID.String()}
+	if len(req.Warnings) > 0 {
+		created.Warnings = &req.Warnings
+	}
+	return created, nil
+}
+
+// toCreateClaimRequest builds the request of the service from the body of CreateClaim
+func toCreateClaimRequest(did *core.DID, body CreateClaimRequest) (*ports.CreateClaimRequest, error) {
+	var expiration *time.Time
+	if body.Expiration != nil {
+		expiration = common.ToPointer(time.Unix(*body.Expiration, 0))
+	}
+
+	req := ports.NewCreateClaimRequest(did, body.CredentialSchema, body.CredentialSubject, expiration, body.Type, body.Version, body.SubjectPosition, body.MerklizedRootPosition, body.SignatureProof, body.MtProof, nil, false)
+	if body.DisplayMethod != nil {
+		req.DisplayMethod = &domain.DisplayMethod{ID: body.DisplayMethod.Id, Type: body.DisplayMethod.Type}
+	}
+	if body.ValidationMode != nil {
+		var err error
+		if req.ValidationMode, err = domain.ParseValidationMode(string(*body.ValidationMode)); err != nil {
+			return nil, err
 		}
-		if errors.Is(err, services.ErrParseClaim) {
-			return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	req.SigningKeyID = body.SigningKeyID
+	return req, nil
+}
+
+// createClaimErrorStatus returns the status code of the errors of the claim creation
+func createClaimErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrJSONLdContext),
+		errors.Is(err, services.ErrProcessSchema),
+		errors.Is(err, services.ErrMalformedURL),
+		errors.Is(err, services.ErrSchemaDeprecated),
+		errors.Is(err, domain.ErrInvalidDisplayMethod),
+		errors.Is(err, domain.ErrIncompatibleProof),
+		errors.Is(err, domain.ErrUnsupportedProofType),
+		errors.Is(err, services.ErrParseClaim),
+		errors.Is(err, services.ErrInvalidCredentialSubject),
+		errors.Is(err, services.ErrSigningKeyNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrLoadingSchema):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrIssuanceLimitExceeded), errors.Is(err, services.ErrQuotaExceeded):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrIssuanceDenied):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// ImportClaim registers a credential issued by the identity in a previous deployment
+func (s *Server) ImportClaim(ctx context.Context, request ImportClaimRequestObject) (ImportClaimResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return ImportClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	claim, err := s.claimService.Import(ctx, *did, *request.Body)
+	if err != nil {
+		if errors.Is(err, services.ErrCredentialNotVerified) || errors.Is(err, services.ErrLoadingSchema) {
+			return ImportClaim422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
 		}
-		if errors.Is(err, services.ErrInvalidCredentialSubject) {
-			return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		if errors.Is(err, services.ErrInvalidCredential) || errors.Is(err, services.ErrClaimAlreadyExists) ||
+			errors.Is(err, services.ErrJSONLdContext) || errors.Is(err, services.ErrParseClaim) ||
+			errors.Is(err, services.ErrInvalidCredentialSubject) {
+			return ImportClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 		}
-		if errors.Is(err, services.ErrLoadingSchema) {
-			return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		return ImportClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return ImportClaim201JSONResponse{Id: claim.ID.String()}, nil
+}
+
+// ResignClaims replaces the signed claims of the identity whose signature broke because a JSON-LD context changed
+func (s *Server) ResignClaims(ctx context.Context, request ResignClaimsRequestObject) (ResignClaimsResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return ResignClaims400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	req := &ports.ResignDriftedRequest{DID: did}
+	if request.Body.Type != nil {
+		req.SchemaType = *request.Body.Type
+	}
+	if request.Body.DryRun != nil {
+		req.DryRun = *request.Body.DryRun
+	}
+	report, err := s.claimService.ResignDrifted(ctx, req)
+	if err != nil {
+		log.Error(ctx, "re-signing drifted claims", "err", err, "did", request.Identifier)
+		if errors.Is(err, services.ErrLoadingSchema) || errors.Is(err, services.ErrJSONLdContext) {
+			return ResignClaims422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
+		}
+		return ResignClaims500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp := ResignClaims200JSONResponse{Checked: report.Checked, Drifted: make([]DriftedClaim, len(report.Drifted))}
+	for i, drifted := range report.Drifted {
+		resp.Drifted[i] = DriftedClaim{Id: drifted.CredentialID, SchemaUrl: drifted.SchemaURL, ReplacedBy: drifted.ReplacedBy}
+		if drifted.Error != "" {
+			resp.Drifted[i].Error = common.ToPointer(drifted.Error)
 		}
-		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 	}
-	return CreateClaim201JSONResponse{Id: resp.ID.String()}, nil
+	return resp, nil
 }
 
 // RevokeClaim is the revocation claim controller
@@ -235,11 +382,16 @@ func (s *Server) GetClaim(ctx context.Context, request GetClaimRequestObject) (G
 		return GetClaim400JSONResponse{N400JSONResponse{"cannot proceed with an empty claim id"}}, nil
 	}
 
-	clID, err := uuid.Parse(request.Id)
+	clID, err := credentialid.Parse(request.Id)
 	if err != nil {
 		return GetClaim400JSONResponse{N400JSONResponse{"invalid claim id"}}, nil
 	}
 
+	dataModel, err := toCredentialDataModel(request.Params.DataModel, request.Params.Accept)
+	if err != nil {
+		return GetClaim400JSONResponse{N400JSONResponse{err.Error()}}, nil
+	}
+
 	claim, err := s.claimService.GetByID(ctx, did, clID)
 	if err != nil {
 		if errors.Is(err, services.ErrClaimNotFound) {
@@ -253,7 +405,13 @@ func (s *Server) GetClaim(ctx context.Context, request GetClaimRequestObject) (G
 		return GetClaim500JSONResponse{N500JSONResponse{"invalid claim format"}}, nil
 	}
 
-	return GetClaim200JSONResponse(toGetClaim200Response(w3c)), nil
+	resp := toGetClaim200Response(w3c, dataModel)
+	resp.DisplayMethod, err = toDisplayMethodResponse(claim)
+	if err != nil {
+		return GetClaim500JSONResponse{N500JSONResponse{"invalid claim format"}}, nil
+	}
+
+	return GetClaim200JSONResponse(resp), nil
 }
 
 // GetClaims is the controller to get multiple claims of a determined identity
@@ -278,18 +436,42 @@ func (s *Server) GetClaims(ctx context.Context, request GetClaimsRequestObject)
 	if err != nil {
 		return GetClaims400JSONResponse{N400JSONResponse{err.Error()}}, nil
 	}
+	filter.PII = hasPIIScope(ctx)
+	if err := filter.Paginate(request.Params.Limit, request.Params.PageToken); err != nil {
+		return GetClaims400JSONResponse{N400JSONResponse{err.Error()}}, nil
+	}
+
+	dataModel, err := toCredentialDataModel(request.Params.DataModel, request.Params.Accept)
+	if err != nil {
+		return GetClaims400JSONResponse{N400JSONResponse{err.Error()}}, nil
+	}
 
 	claims, err := s.claimService.GetAll(ctx, *did, filter)
 	if err != nil && !errors.Is(err, services.ErrClaimNotFound) {
 		return GetClaims500JSONResponse{N500JSONResponse{"there was an internal error trying to retrieve claims for the requested identifier"}}, nil
 	}
+	total, err := s.claimService.Count(ctx, *did, filter)
+	if err != nil {
+		return GetClaims500JSONResponse{N500JSONResponse{"there was an internal error trying to count the claims for the requested identifier"}}, nil
+	}
 
 	w3Claims, err := schema.FromClaimsModelToW3CCredential(claims)
 	if err != nil {
 		return GetClaims500JSONResponse{N500JSONResponse{"there was an internal error parsing the claims"}}, nil
 	}
 
-	return toGetClaims200Response(w3Claims), nil
+	resp := toGetClaims200Response(w3Claims, dataModel)
+	for i := range claims {
+		resp[i].DisplayMethod, err = toDisplayMethodResponse(claims[i])
+		if err != nil {
+			return GetClaims500JSONResponse{N500JSONResponse{"there was an internal error parsing the claims"}}, nil
+		}
+	}
+
+	return GetClaims200JSONResponse{
+		Body:    resp,
+		Headers: GetClaims200ResponseHeaders{XTotalCount: total, XNextPageToken: domain.NextPageToken(filter.Offset, len(claims), total)},
+	}, nil
 }
 
 // GetClaimQrCode returns a GetClaimQrCodeResponseObject that can be used with any QR generator to create a QR and
@@ -308,7 +490,7 @@ func (s *Server) GetClaimQrCode(ctx context.Context, request GetClaimQrCodeReque
 		return GetClaimQrCode400JSONResponse{N400JSONResponse{"cannot proceed with an empty claim id"}}, nil
 	}
 
-	claimID, err := uuid.Parse(request.Id)
+	claimID, err := credentialid.Parse(request.Id)
 	if err != nil {
 		return GetClaimQrCode400JSONResponse{N400JSONResponse{"invalid claim id"}}, nil
 	}
@@ -323,6 +505,96 @@ func (s *Server) GetClaimQrCode(ctx context.Context, request GetClaimQrCodeReque
 	return toGetClaimQrCode200JSONResponse(claim, s.cfg.ServerUrl), nil
 }
 
+// GetClaimDisplay renders the card of a claim with the template of its display method
+func (s *Server) GetClaimDisplay(ctx context.Context, request GetClaimDisplayRequestObject) (GetClaimDisplayResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetClaimDisplay400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	claimID, err := credentialid.Parse(request.Id)
+	if err != nil {
+		return GetClaimDisplay400JSONResponse{N400JSONResponse{"invalid claim id"}}, nil
+	}
+
+	format := GetClaimDisplayParamsFormatJson
+	if request.Params.Format != nil {
+		format = *request.Params.Format
+	}
+	if format != GetClaimDisplayParamsFormatJson && format != GetClaimDisplayParamsFormatSvg {
+		return GetClaimDisplay400JSONResponse{N400JSONResponse{"invalid format, must be json or svg"}}, nil
+	}
+
+	card, err := s.displayService.GetCredentialCard(ctx, *did, claimID)
+	if err != nil {
+		if errors.Is(err, services.ErrClaimNotFound) || errors.Is(err, services.ErrDisplayMethodNotFound) {
+			return GetClaimDisplay404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrLoadingDisplayTemplate) {
+			return GetClaimDisplay422JSONResponse{N422JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "rendering claim card", "err", err, "id", request.Id)
+		return GetClaimDisplay500JSONResponse{N500JSONResponse{"there was an error rendering the claim card"}}, nil
+	}
+
+	if format == GetClaimDisplayParamsFormatSvg {
+		svg, err := renderCardSVG(card.Template)
+		if err != nil {
+			log.Error(ctx, "rendering claim card svg", "err", err, "id", request.Id)
+			return GetClaimDisplay500JSONResponse{N500JSONResponse{"there was an error rendering the claim card"}}, nil
+		}
+		return GetClaimDisplay200ImagesvgXmlResponse{Body: bytes.NewReader(svg), ContentLength: int64(len(svg))}, nil
+	}
+
+	return GetClaimDisplay200JSONResponse(toCredentialDisplayResponse(card)), nil
+}
+
+// GetClaimIssuanceTiming returns the time spent in each phase of the issuance of a claim
+func (s *Server) GetClaimIssuanceTiming(ctx context.Context, request GetClaimIssuanceTimingRequestObject) (GetClaimIssuanceTimingResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetClaimIssuanceTiming400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	claimID, err := uuid.Parse(request.Id)
+	if err != nil {
+		return GetClaimIssuanceTiming400JSONResponse{N400JSONResponse{"invalid claim id"}}, nil
+	}
+
+	timing, err := s.claimService.GetIssuanceTiming(ctx, *did, claimID)
+	if err != nil {
+		if errors.Is(err, services.ErrIssuanceTimingNotFound) {
+			return GetClaimIssuanceTiming404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "getting the issuance timing", "err", err, "id", request.Id)
+		return GetClaimIssuanceTiming500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return GetClaimIssuanceTiming200JSONResponse(toIssuanceTimingResponse(timing)), nil
+}
+
+// CheckCredentialValidity resolves the status and expiration of a credential for relying parties
+func (s *Server) CheckCredentialValidity(ctx context.Context, request CheckCredentialValidityRequestObject) (CheckCredentialValidityResponseObject, error) {
+	validity, err := s.credentialValidityService.Check(ctx, *request.Body)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredential) {
+			return CheckCredentialValidity400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "checking credential validity", "err", err, "issuer", request.Body.Issuer)
+		return CheckCredentialValidity500JSONResponse{N500JSONResponse{"there was an error checking the credential"}}, nil
+	}
+	return CheckCredentialValidity200JSONResponse(toCredentialValidityResponse(validity)), nil
+}
+
+// GetIssuerMetadata returns the public description of the identities of the node and its service endpoints
+func (s *Server) GetIssuerMetadata(ctx context.Context, _ GetIssuerMetadataRequestObject) (GetIssuerMetadataResponseObject, error) {
+	metadata, err := s.issuerMetadataService.Get(ctx)
+	if err != nil {
+		log.Error(ctx, "getting issuer metadata", "err", err)
+		return GetIssuerMetadata500JSONResponse{N500JSONResponse{"there was an error getting the issuer metadata"}}, nil
+	}
+	return GetIssuerMetadata200JSONResponse(toIssuerMetadataResponse(metadata)), nil
+}
+
 // GetIdentities is the controller to get identities
 func (s *Server) GetIdentities(ctx context.Context, request GetIdentitiesRequestObject) (GetIdentitiesResponseObject, error) {
 	var response GetIdentities200JSONResponse
@@ -337,29 +609,149 @@ func (s *Server) GetIdentities(ctx context.Context, request GetIdentitiesRequest
 	return response, nil
 }
 
+// GetMetering returns the billable operations of the identities of the node per month, as JSON or CSV
+func (s *Server) GetMetering(ctx context.Context, request GetMeteringRequestObject) (GetMeteringResponseObject, error) {
+	var from, to string
+	if request.Params.From != nil {
+		from = *request.Params.From
+	}
+	if request.Params.To != nil {
+		to = *request.Params.To
+	}
+	period, err := domain.NewMeteringPeriod(from, to, time.Now())
+	if err != nil {
+		return GetMetering400JSONResponse{N400JSONResponse{"invalid period, the months must be like 2023-04 and from cannot be after to"}}, nil
+	}
+
+	format := GetMeteringParamsFormatJson
+	if request.Params.Format != nil {
+		format = *request.Params.Format
+	}
+	if format != GetMeteringParamsFormatJson && format != GetMeteringParamsFormatCsv {
+		return GetMetering400JSONResponse{N400JSONResponse{"invalid format, must be json or csv"}}, nil
+	}
+
+	months, err := s.meteringService.GetMonthly(ctx, period)
+	if err != nil {
+		log.Error(ctx, "getting metering", "err", err)
+		return GetMetering500JSONResponse{N500JSONResponse{"there was an error getting the metering"}}, nil
+	}
+
+	if format == GetMeteringParamsFormatCsv {
+		report, err := renderMeteringCSV(months)
+		if err != nil {
+			log.Error(ctx, "rendering metering csv", "err", err)
+			return GetMetering500JSONResponse{N500JSONResponse{"there was an error getting the metering"}}, nil
+		}
+		return GetMetering200TextcsvResponse{Body: bytes.NewReader(report), ContentLength: int64(len(report))}, nil
+	}
+	return GetMetering200JSONResponse(toMeteringResponse(period, months)), nil
+}
+
+// GetMaintenance returns the maintenance mode of the node
+func (s *Server) GetMaintenance(ctx context.Context, _ GetMaintenanceRequestObject) (GetMaintenanceResponseObject, error) {
+	maintenance, err := s.maintenanceService.Get(ctx)
+	if err != nil {
+		log.Error(ctx, "getting maintenance mode", "err", err)
+		return GetMaintenance500JSONResponse{N500JSONResponse{"there was an error getting the maintenance mode"}}, nil
+	}
+	return GetMaintenance200JSONResponse(toMaintenanceResponse(maintenance)), nil
+}
+
+// UpdateMaintenance switches the maintenance mode of the node on or off
+func (s *Server) UpdateMaintenance(ctx context.Context, request UpdateMaintenanceRequestObject) (UpdateMaintenanceResponseObject, error) {
+	var message string
+	if request.Body.Message != nil {
+		message = *request.Body.Message
+	}
+	var retryAfter time.Duration
+	if request.Body.RetryAfter != nil {
+		if *request.Body.RetryAfter < 0 {
+			return UpdateMaintenance400JSONResponse{N400JSONResponse{"retryAfter cannot be negative"}}, nil
+		}
+		retryAfter = time.Duration(*request.Body.RetryAfter) * time.Second
+	}
+	maintenance, err := s.maintenanceService.Update(ctx, request.Body.Enabled, message, retryAfter)
+	if err != nil {
+		log.Error(ctx, "updating maintenance mode", "err", err)
+		return UpdateMaintenance500JSONResponse{N500JSONResponse{"there was an error updating the maintenance mode"}}, nil
+	}
+	return UpdateMaintenance200JSONResponse(toMaintenanceResponse(maintenance)), nil
+}
+
+// GetQueryPlans explains the frequent queries of the node and advises how to speed them up
+func (s *Server) GetQueryPlans(ctx context.Context, _ GetQueryPlansRequestObject) (GetQueryPlansResponseObject, error) {
+	report, err := s.diagnosticsService.QueryPlans(ctx)
+	if err != nil {
+		log.Error(ctx, "explaining the queries", "err", err)
+		return GetQueryPlans500JSONResponse{N500JSONResponse{"there was an error explaining the queries"}}, nil
+	}
+	return GetQueryPlans200JSONResponse(toQueryPlansResponse(report)), nil
+}
+
+// GetRegion returns the role of the region of the server
+func (s *Server) GetRegion(ctx context.Context, _ GetRegionRequestObject) (GetRegionResponseObject, error) {
+	region, err := s.regionService.Get(ctx)
+	if err != nil {
+		log.Error(ctx, "getting the region", "err", err)
+		return GetRegion500JSONResponse{N500JSONResponse{"there was an error getting the region"}}, nil
+	}
+	return GetRegion200JSONResponse(toRegionResponse(region)), nil
+}
+
+// PromoteRegion makes the region of the server the active one
+func (s *Server) PromoteRegion(ctx context.Context, request PromoteRegionRequestObject) (PromoteRegionResponseObject, error) {
+	force := request.Body.Force != nil && *request.Body.Force
+	promoteDatabase := request.Body.PromoteDatabase != nil && *request.Body.PromoteDatabase
+	region, err := s.regionService.Promote(ctx, force, promoteDatabase)
+	if errors.Is(err, services.ErrRegionNotConfigured) {
+		return PromoteRegion400JSONResponse{N400JSONResponse{err.Error()}}, nil
+	}
+	if errors.Is(err, services.ErrRegionInRecovery) || errors.Is(err, services.ErrRegionLeaseHeld) {
+		return PromoteRegion409JSONResponse{N409JSONResponse{err.Error()}}, nil
+	}
+	if err != nil {
+		log.Error(ctx, "promoting the region", "err", err)
+		return PromoteRegion500JSONResponse{N500JSONResponse{"there was an error promoting the region"}}, nil
+	}
+	return PromoteRegion200JSONResponse(toRegionResponse(region)), nil
+}
+
 // Agent is the controller to fetch credentials from mobile
 func (s *Server) Agent(ctx context.Context, request AgentRequestObject) (AgentResponseObject, error) {
 	if request.Body == nil || *request.Body == "" {
 		log.Debug(ctx, "agent empty request")
 		return Agent400JSONResponse{N400JSONResponse{"cannot proceed with an empty request"}}, nil
 	}
-	basicMessage, err := s.packageManager.UnpackWithType(packers.MediaTypeZKPMessage, []byte(*request.Body))
+	basicMessage, err := s.unpackAgentMessage(ctx, []byte(*request.Body))
 	if err != nil {
 		log.Debug(ctx, "agent bad request", "err", err, "body", *request.Body)
 		return Agent400JSONResponse{N400JSONResponse{"cannot proceed with the given request"}}, nil
 	}
 
-	req, err := ports.NewAgentRequest(basicMessage)
+	req, err := ports.NewAgentRequest([]byte(*request.Body), basicMessage)
 	if err != nil {
 		log.Error(ctx, "agent parsing request", "err", err)
 		return Agent400JSONResponse{N400JSONResponse{err.Error()}}, nil
 	}
 
 	agent, err := s.claimService.Agent(ctx, req)
+	if errors.Is(err, services.ErrAgentMessageReplayed) || errors.Is(err, services.ErrAgentMessageOutsideWindow) || errors.Is(err, services.ErrAgentMessageNoCreatedTime) {
+		log.Warn(ctx, "agent rejected message", "err", err, "userDID", req.UserDID, "type", req.Type)
+		return Agent400JSONResponse{N400JSONResponse{err.Error()}}, nil
+	}
+	if errors.Is(err, services.ErrRegionPassive) {
+		log.Warn(ctx, "agent message rejected by the passive region", "err", err, "userDID", req.UserDID, "type", req.Type)
+		return nil, apiErrors.UnavailableError{Err: err, RetryAfter: s.cfg.Region.LeaseTTL}
+	}
 	if err != nil {
 		log.Error(ctx, "agent error", "err", err)
 		return Agent400JSONResponse{N400JSONResponse{err.Error()}}, nil
 	}
+
+	if err := s.connectionsService.Seen(ctx, *req.IssuerDID, *req.UserDID); err != nil {
+		log.Warn(ctx, "agent: recording connection last seen", "err", err, "userDID", req.UserDID)
+	}
 	return Agent200JSONResponse{
 		Body:     agent.Body,
 		From:     agent.From,
@@ -371,6 +763,14 @@ func (s *Server) Agent(ctx context.Context, request AgentRequestObject) (AgentRe
 	}, nil
 }
 
+// unpackAgentMessage verifies the zero knowledge packed message, or the signed one when they are accepted
+func (s *Server) unpackAgentMessage(ctx context.Context, envelope []byte) (*iden3comm.BasicMessage, error) {
+	if s.signedMessages != nil && agentprotocol.IsSignedMessage(envelope) {
+		return s.signedMessages.Unpack(ctx, envelope)
+	}
+	return s.packageManager.UnpackWithType(packers.MediaTypeZKPMessage, envelope)
+}
+
 // PublishIdentityState - publish identity state on chain
 func (s *Server) PublishIdentityState(ctx context.Context, request PublishIdentityStateRequestObject) (PublishIdentityStateResponseObject, error) {
 	did, err := core.ParseDID(request.Identifier)
@@ -395,6 +795,316 @@ func (s *Server) PublishIdentityState(ctx context.Context, request PublishIdenti
 	}, nil
 }
 
+// GetIdentityDefaultProofTypes returns the proof types of the identity credentials when the request does not set them
+func (s *Server) GetIdentityDefaultProofTypes(ctx context.Context, request GetIdentityDefaultProofTypesRequestObject) (GetIdentityDefaultProofTypesResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetIdentityDefaultProofTypes400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	proofTypes, err := s.identityService.GetDefaultProofTypes(ctx, *did)
+	if err != nil {
+		if errors.Is(err, services.ErrIdentityNotFound) {
+			return GetIdentityDefaultProofTypes404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "getting identity default proof types", "err", err, "did", did)
+		return GetIdentityDefaultProofTypes500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return GetIdentityDefaultProofTypes200JSONResponse(toDefaultProofTypesResponse(proofTypes)), nil
+}
+
+// UpdateIdentityDefaultProofTypes sets the proof types of the identity credentials when the request does not set them
+func (s *Server) UpdateIdentityDefaultProofTypes(ctx context.Context, request UpdateIdentityDefaultProofTypesRequestObject) (UpdateIdentityDefaultProofTypesResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return UpdateIdentityDefaultProofTypes400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	var proofTypes domain.ProofTypes
+	if request.Body.ProofTypes != nil {
+		proofTypes = make(domain.ProofTypes, len(*request.Body.ProofTypes))
+		for i, proofType := range *request.Body.ProofTypes {
+			proofTypes[i] = verifiable.ProofType(proofType)
+		}
+	}
+
+	if err := s.identityService.UpdateDefaultProofTypes(ctx, *did, proofTypes); err != nil {
+		if errors.Is(err, services.ErrIdentityNotFound) {
+			return UpdateIdentityDefaultProofTypes404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, domain.ErrNoProofTypes) || errors.Is(err, domain.ErrIncompatibleProof) || errors.Is(err, domain.ErrUnsupportedProofType) {
+			return UpdateIdentityDefaultProofTypes400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "updating identity default proof types", "err", err, "did", did)
+		return UpdateIdentityDefaultProofTypes500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	if proofTypes == nil {
+		proofTypes = domain.DefaultProofTypes(*did)
+	}
+	return UpdateIdentityDefaultProofTypes200JSONResponse(toDefaultProofTypesResponse(proofTypes)), nil
+}
+
+// GetIdentityStats returns the quotas of the identity and its usage of the resources they limit
+func (s *Server) GetIdentityStats(ctx context.Context, request GetIdentityStatsRequestObject) (GetIdentityStatsResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetIdentityStats400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	usage, err := s.quotaService.GetUsage(ctx, *did)
+	if err != nil {
+		if errors.Is(err, services.ErrIdentityNotFound) {
+			return GetIdentityStats404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "getting identity stats", "err", err, "did", did)
+		return GetIdentityStats500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return GetIdentityStats200JSONResponse(toIdentityStatsResponse(usage)), nil
+}
+
+// RotateIdentityKey starts the rotation of the BabyJubJub auth key of the identity
+func (s *Server) RotateIdentityKey(ctx context.Context, request RotateIdentityKeyRequestObject) (RotateIdentityKeyResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return RotateIdentityKey400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	rotation, err := s.keyRotationService.Rotate(ctx, *did)
+	if err != nil {
+		if errors.Is(err, services.ErrIdentityNotFound) {
+			return RotateIdentityKey404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrKeyRotationInProgress) {
+			return RotateIdentityKey409JSONResponse{N409JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "rotating identity key", "err", err, "did", did)
+		return RotateIdentityKey500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return RotateIdentityKey202JSONResponse(toKeyRotationResponse(rotation)), nil
+}
+
+// GetKeyRotation returns the progress of a rotation of the auth key of the identity
+func (s *Server) GetKeyRotation(ctx context.Context, request GetKeyRotationRequestObject) (GetKeyRotationResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetKeyRotation400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+	id, err := uuid.Parse(request.Id)
+	if err != nil {
+		return GetKeyRotation400JSONResponse{N400JSONResponse{"invalid key rotation id"}}, nil
+	}
+
+	rotation, err := s.keyRotationService.Get(ctx, *did, id)
+	if err != nil {
+		if errors.Is(err, services.ErrKeyRotationNotFound) {
+			return GetKeyRotation404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "getting key rotation", "err", err, "did", did, "id", id)
+		return GetKeyRotation500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return GetKeyRotation200JSONResponse(toKeyRotationResponse(rotation)), nil
+}
+
+// GetSigningKeys returns the keys that sign the credentials of the identity
+func (s *Server) GetSigningKeys(ctx context.Context, request GetSigningKeysRequestObject) (GetSigningKeysResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetSigningKeys400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	keys, err := s.signingKeyService.GetAll(ctx, *did)
+	if err != nil {
+		if errors.Is(err, services.ErrIdentityNotFound) {
+			return GetSigningKeys404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "getting signing keys", "err", err, "did", did)
+		return GetSigningKeys500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return GetSigningKeys200JSONResponse(toSigningKeysResponse(keys)), nil
+}
+
+// AddSigningKey creates a new key that signs the credentials of the identity once its auth claim is published
+func (s *Server) AddSigningKey(ctx context.Context, request AddSigningKeyRequestObject) (AddSigningKeyResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return AddSigningKey400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	keyType := domain.SigningKeyBJJ
+	if request.Params.Type != nil {
+		keyType = domain.SigningKeyType(*request.Params.Type)
+	}
+	key, err := s.signingKeyService.Add(ctx, *did, keyType)
+	if err != nil {
+		if errors.Is(err, services.ErrSigningKeyType) {
+			return AddSigningKey400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrIdentityNotFound) {
+			return AddSigningKey404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "adding signing key", "err", err, "did", did)
+		return AddSigningKey500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return AddSigningKey201JSONResponse(toSigningKeyResponse(key)), nil
+}
+
+// RemoveSigningKey revokes the auth claim of a signing key of the identity
+func (s *Server) RemoveSigningKey(ctx context.Context, request RemoveSigningKeyRequestObject) (RemoveSigningKeyResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return RemoveSigningKey400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+	id, err := uuid.Parse(request.Id)
+	if err != nil {
+		return RemoveSigningKey400JSONResponse{N400JSONResponse{"invalid signing key id"}}, nil
+	}
+
+	err = s.signingKeyService.Remove(ctx, *did, id)
+	if err != nil {
+		if errors.Is(err, services.ErrIdentityNotFound) || errors.Is(err, services.ErrSigningKeyNotFound) {
+			return RemoveSigningKey404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrLastSigningKey) {
+			return RemoveSigningKey409JSONResponse{N409JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "removing signing key", "err", err, "did", did, "id", id)
+		return RemoveSigningKey500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return RemoveSigningKey202JSONResponse{Message: "pending"}, nil
+}
+
+// GetSchemaWebhooks returns the webhooks of the identity registered for the credentials of a schema type
+func (s *Server) GetSchemaWebhooks(ctx context.Context, request GetSchemaWebhooksRequestObject) (GetSchemaWebhooksResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetSchemaWebhooks400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	webhooks, err := s.claimService.GetSchemaWebhooks(ctx, *did)
+	if err != nil {
+		log.Error(ctx, "getting schema webhooks", "err", err, "did", did)
+		return GetSchemaWebhooks500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return GetSchemaWebhooks200JSONResponse(toSchemaWebhooksResponse(webhooks)), nil
+}
+
+// CreateSchemaWebhook registers a webhook of the identity for the credentials of a schema type
+func (s *Server) CreateSchemaWebhook(ctx context.Context, request CreateSchemaWebhookRequestObject) (CreateSchemaWebhookResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return CreateSchemaWebhook400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	var events []string
+	if request.Body.Events != nil {
+		events = *request.Body.Events
+	}
+	var authorization string
+	if request.Body.Authorization != nil {
+		authorization = *request.Body.Authorization
+	}
+	webhook, err := s.claimService.CreateSchemaWebhook(ctx, *did, request.Body.SchemaType, request.Body.Url, events, authorization)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidSchemaWebhook) {
+			return CreateSchemaWebhook400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "creating schema webhook", "err", err, "did", did)
+		return CreateSchemaWebhook500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return CreateSchemaWebhook201JSONResponse(toSchemaWebhookResponse(webhook)), nil
+}
+
+// DeleteSchemaWebhook removes a schema webhook of the identity
+func (s *Server) DeleteSchemaWebhook(ctx context.Context, request DeleteSchemaWebhookRequestObject) (DeleteSchemaWebhookResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return DeleteSchemaWebhook400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+	id, err := uuid.Parse(request.Id)
+	if err != nil {
+		return DeleteSchemaWebhook400JSONResponse{N400JSONResponse{"invalid schema webhook id"}}, nil
+	}
+
+	if err := s.claimService.DeleteSchemaWebhook(ctx, *did, id); err != nil {
+		if errors.Is(err, services.ErrSchemaWebhookNotFound) {
+			return DeleteSchemaWebhook404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "deleting schema webhook", "err", err, "did", did, "id", id)
+		return DeleteSchemaWebhook500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return DeleteSchemaWebhook200JSONResponse{Message: "schema webhook deleted"}, nil
+}
+
+// UpdateIdentityQuotas replaces the quotas of the identity that override the ones of the node
+func (s *Server) UpdateIdentityQuotas(ctx context.Context, request UpdateIdentityQuotasRequestObject) (UpdateIdentityQuotasResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return UpdateIdentityQuotas400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	quotas, err := s.quotaService.Update(ctx, *did, domain.QuotaOverrides{
+		MaxActiveLinks:       request.Body.MaxActiveLinks,
+		MaxCredentialsPerDay: request.Body.MaxCredentialsPerDay,
+		MaxSchemas:           request.Body.MaxSchemas,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrIdentityNotFound) {
+			return UpdateIdentityQuotas404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrInvalidQuota) {
+			return UpdateIdentityQuotas400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "updating identity quotas", "err", err, "did", did)
+		return UpdateIdentityQuotas500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return UpdateIdentityQuotas200JSONResponse(toQuotasResponse(*quotas)), nil
+}
+
+// GetIdentityBranding returns how the identity is presented to the holders
+func (s *Server) GetIdentityBranding(ctx context.Context, request GetIdentityBrandingRequestObject) (GetIdentityBrandingResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetIdentityBranding400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	branding, err := s.brandingService.Get(ctx, *did)
+	if err != nil {
+		if errors.Is(err, services.ErrIdentityNotFound) {
+			return GetIdentityBranding404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "getting identity branding", "err", err, "did", did)
+		return GetIdentityBranding500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return GetIdentityBranding200JSONResponse(toBrandingResponse(*branding)), nil
+}
+
+// UpdateIdentityBranding replaces the branding of the identity that overrides the one of the node
+func (s *Server) UpdateIdentityBranding(ctx context.Context, request UpdateIdentityBrandingRequestObject) (UpdateIdentityBrandingResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return UpdateIdentityBranding400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	branding, err := s.brandingService.Update(ctx, *did, domain.BrandingOverrides{
+		DisplayName:  request.Body.DisplayName,
+		LogoURL:      request.Body.Logo,
+		PrimaryColor: request.Body.PrimaryColor,
+		TextColor:    request.Body.TextColor,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrIdentityNotFound) {
+			return UpdateIdentityBranding404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, domain.ErrInvalidBranding) {
+			return UpdateIdentityBranding400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "updating identity branding", "err", err, "did", did)
+		return UpdateIdentityBranding500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return UpdateIdentityBranding200JSONResponse(toBrandingResponse(*branding)), nil
+}
+
 // RegisterStatic add method to the mux that are not documented in the API.
 func RegisterStatic(mux *chi.Mux) {
 	mux.Get("/", documentation)
@@ -402,16 +1112,46 @@ func RegisterStatic(mux *chi.Mux) {
 	mux.Get("/favicon.ico", favicon)
 }
 
-func toGetClaims200Response(claims []*verifiable.W3CCredential) GetClaims200JSONResponse {
-	response := make(GetClaims200JSONResponse, len(claims))
+func toGetClaims200Response(claims []*verifiable.W3CCredential, dataModel domain.CredentialDataModel) GetClaimsResponse {
+	response := make(GetClaimsResponse, len(claims))
 	for i := range claims {
-		response[i] = toGetClaim200Response(claims[i])
+		response[i] = toGetClaim200Response(claims[i], dataModel)
 	}
 
 	return response
 }
 
-func toGetClaim200Response(claim *verifiable.W3CCredential) GetClaimResponse {
+// toCredentialDataModel returns the data model asked by the dataModel parameter or, without it, by the Accept header
+func toCredentialDataModel(dataModel *DataModel, accept *Accept) (domain.CredentialDataModel, error) {
+	if dataModel != nil {
+		return domain.ParseCredentialDataModel(string(*dataModel))
+	}
+	if accept != nil {
+		return domain.NegotiateCredentialDataModel(*accept), nil
+	}
+	return domain.CredentialDataModelV1, nil
+}
+
+// toGetClaim200Response returns the credential in dataModel. In 2.0 the base context is the v2 one, and issuanceDate
+// and expiration become validFrom and validUntil.
+func toGetClaim200Response(claim *verifiable.W3CCredential, dataModel domain.CredentialDataModel) GetClaimResponse {
+	if dataModel == domain.CredentialDataModelV2 {
+		return GetClaimResponse{
+			Context: domain.ContextsV2(claim.Context),
+			CredentialSchema: CredentialSchema{
+				claim.CredentialSchema.ID,
+				claim.CredentialSchema.Type,
+			},
+			CredentialStatus:  claim.CredentialStatus,
+			CredentialSubject: claim.CredentialSubject,
+			Id:                claim.ID,
+			Issuer:            claim.Issuer,
+			Proof:             claim.Proof,
+			Type:              claim.Type,
+			ValidFrom:         claim.IssuanceDate,
+			ValidUntil:        claim.Expiration,
+		}
+	}
 	return GetClaimResponse{
 		Context: claim.Context,
 		CredentialSchema: CredentialSchema{
@@ -429,6 +1169,91 @@ func toGetClaim200Response(claim *verifiable.W3CCredential) GetClaimResponse {
 	}
 }
 
+func toDisplayMethodResponse(claim *domain.Claim) (*DisplayMethod, error) {
+	displayMethod, err := claim.GetDisplayMethod()
+	if err != nil || displayMethod == nil {
+		return nil, err
+	}
+	return &DisplayMethod{Id: displayMethod.ID, Type: displayMethod.Type}, nil
+}
+
+func toCredentialValidityResponse(validity *domain.CredentialValidity) CredentialValidityResponse {
+	return CredentialValidityResponse{
+		Verdict:              CredentialValidityResponseVerdict(validity.Verdict()),
+		Issuer:               validity.Issuer,
+		CredentialStatusType: validity.CredentialStatusType,
+		RevocationNonce:      validity.RevocationNonce,
+		LocalIssuer:          validity.LocalIssuer,
+		Revoked:              validity.Revoked,
+		Expired:              validity.Expired,
+		ExpirationDate:       validity.ExpirationDate,
+		CheckedAt:            validity.CheckedAt,
+		Reason:               validity.Reason,
+		Accreditation:        toIssuerAccreditationResponse(validity.Accreditation),
+	}
+}
+
+func toIssuerAccreditationResponse(accreditation *domain.IssuerAccreditation) *IssuerAccreditation {
+	if accreditation == nil {
+		return nil
+	}
+	return &IssuerAccreditation{
+		CredentialType: accreditation.CredentialType,
+		Accredited:     accreditation.Accredited,
+		CheckedAt:      accreditation.CheckedAt,
+		Reason:         accreditation.Reason,
+	}
+}
+
+func toIssuerMetadataResponse(metadata *domain.IssuerMetadata) IssuerMetadata {
+	issuers := make([]IssuerMetadataIssuer, len(metadata.Issuers))
+	for i, issuer := range metadata.Issuers {
+		publicKeys := make([]IssuerPublicKey, len(issuer.PublicKeys))
+		for j, key := range issuer.PublicKeys {
+			publicKeys[j] = IssuerPublicKey{Type: key.Type, X: key.X, Y: key.Y}
+		}
+		proofTypes := make([]ProofType, len(issuer.ProofTypes))
+		for j, proofType := range issuer.ProofTypes {
+			proofTypes[j] = ProofType(proofType)
+		}
+		schemas := make([]IssuerSchema, len(issuer.Schemas))
+		for j, schema := range issuer.Schemas {
+			schemas[j] = IssuerSchema{Url: schema.URL, Type: schema.Type}
+		}
+		issuers[i] = IssuerMetadataIssuer{Did: issuer.DID, PublicKeys: publicKeys, ProofTypes: proofTypes, Schemas: schemas, Branding: toBrandingResponse(issuer.Branding)}
+	}
+	return IssuerMetadata{
+		Url:                   metadata.URL,
+		Issuers:               issuers,
+		CredentialStatusTypes: metadata.CredentialStatusTypes,
+		ServiceEndpoints: IssuerServiceEndpoints{
+			Agent:              metadata.AgentURL,
+			CredentialStatus:   metadata.CredentialStatusURL,
+			CredentialValidity: metadata.CredentialValidityURL,
+			ReverseHashService: metadata.ReverseHashServiceURL,
+		},
+		GeneratedAt: metadata.GeneratedAt,
+	}
+}
+
+func toCredentialDisplayResponse(card *domain.CredentialCard) CredentialDisplayResponse {
+	resp := CredentialDisplayResponse{
+		BackgroundColor:      card.Template.BackgroundColor,
+		BackgroundImageUrl:   card.Template.BackgroundImageURL,
+		CredentialID:         card.CredentialID.String(),
+		Description:          card.Template.Description,
+		DescriptionTextColor: card.Template.DescriptionTextColor,
+		DisplayMethod:        DisplayMethod{Id: card.DisplayMethod.ID, Type: card.DisplayMethod.Type},
+		IssuerName:           card.Template.IssuerName,
+		IssuerTextColor:      card.Template.IssuerTextColor,
+		Title:                card.Template.Title,
+		TitleTextColor:       card.Template.TitleTextColor,
+	}
+	resp.Logo.Uri = card.Template.Logo.URI
+	resp.Logo.Alt = card.Template.Logo.Alt
+	return resp
+}
+
 func toGetClaimQrCode200JSONResponse(claim *domain.Claim, hostURL string) *GetClaimQrCode200JSONResponse {
 	id := uuid.New()
 	return &GetClaimQrCode200JSONResponse{
@@ -481,3 +1306,11 @@ func writeFile(path string, mimeType string, w http.ResponseWriter) {
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(f)
 }
+
+func toDefaultProofTypesResponse(proofTypes domain.ProofTypes) DefaultProofTypes {
+	res := DefaultProofTypes{ProofTypes: make([]ProofType, len(proofTypes))}
+	for i, proofType := range proofTypes {
+		res.ProofTypes[i] = ProofType(proofType)
+	}
+	return res
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -32,6 +33,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/repositories"
 	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+	"github.com/polygonid/sh-id-platform/pkg/schema"
 )
 
 func TestServer_CreateIdentity(t *testing.T) {
@@ -56,7 +58,7 @@ func TestServer_CreateIdentity(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 
-	server := NewServer(&cfg, identityService, claimsService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, testDependencies(identityService, claimsService, connectionsRepository))
 	handler := getHandler(context.Background(), server)
 
 	type expected struct {
@@ -185,7 +187,7 @@ func TestServer_RevokeClaim(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 
-	server := NewServer(&cfg, identityService, claimsService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, testDependencies(identityService, claimsService, connectionsRepository))
 
 	idStr := "did:polygonid:polygon:mumbai:2qM77fA6NGGWL9QEeb1dv2VA6wz5svcohgv61LZ7wB"
 	identity := &domain.Identity{
@@ -337,7 +339,7 @@ func TestServer_CreateClaim(t *testing.T) {
 	pubSub := pubsub.NewMock()
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubSub)
 
-	server := NewServer(&cfg, identityService, claimsService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, testDependencies(identityService, claimsService, connectionsRepository))
 	handler := getHandler(ctx, server)
 
 	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
@@ -459,6 +461,107 @@ func TestServer_CreateClaim(t *testing.T) {
 	}
 }
 
+func TestServer_StreamClaims(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "mumbai"
+	)
+	ctx := log.NewContext(context.Background(), log.LevelDebug, log.OutputText, os.Stdout)
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsConf := services.ClaimCfg{
+		RHSEnabled: false,
+		Host:       "http://host",
+	}
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
+	claimsIngestService := services.NewClaimsIngest(claimsService, 2, 1)
+	ingestCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go claimsIngestService.Run(ingestCtx)
+
+	deps := testDependencies(identityService, claimsService, connectionsRepository)
+	deps.ClaimsIngest = claimsIngestService
+	server := NewServer(&cfg, deps)
+	handler := getHandler(ctx, server)
+
+	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
+	require.NoError(t, err)
+
+	credential := func(schemaURL string) string {
+		body, err := json.Marshal(CreateClaimRequest{
+			CredentialSchema: schemaURL,
+			Type:             "KYCAgeCredential",
+			CredentialSubject: map[string]any{
+				"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+				"birthday":     19960424,
+				"documentType": 2,
+			},
+			Expiration: common.ToPointer(time.Now().Add(time.Hour).Unix()),
+		})
+		require.NoError(t, err)
+		return string(body)
+	}
+	schemaURL := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	stream := strings.Join([]string{
+		credential(schemaURL),
+		"",
+		"{not json",
+		credential(schemaURL),
+		credential("wrong url"),
+		credential(schemaURL),
+	}, "\n")
+
+	t.Run("should not stream without auth", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/%s/claims/stream", iden.Identifier), strings.NewReader(stream))
+		require.NoError(t, err)
+		req.SetBasicAuth(authWrong())
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should acknowledge every line", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/%s/claims/stream", iden.Identifier), strings.NewReader(stream))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/x-ndjson", rr.Header().Get("Content-Type"))
+
+		acks := map[int]StreamClaimsAck{}
+		for _, line := range strings.Split(strings.TrimSpace(rr.Body.String()), "\n") {
+			var ack StreamClaimsAck
+			require.NoError(t, json.Unmarshal([]byte(line), &ack))
+			acks[ack.Line] = ack
+		}
+		require.Len(t, acks, 5)
+		for _, line := range []int{1, 4, 6} {
+			assert.Equal(t, http.StatusCreated, acks[line].Status)
+			require.NotNil(t, acks[line].Id)
+			claimID, err := uuid.Parse(*acks[line].Id)
+			require.NoError(t, err)
+			did, err := core.ParseDID(iden.Identifier)
+			require.NoError(t, err)
+			_, err = claimsService.GetByID(ctx, did, claimID)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, http.StatusBadRequest, acks[3].Status)
+		assert.NotNil(t, acks[3].Error)
+		assert.Equal(t, StreamClaimsAck{Line: 5, Status: http.StatusBadRequest, Error: common.ToPointer("malformed url")}, acks[5])
+	})
+}
+
 func TestServer_GetIdentities(t *testing.T) {
 	identityRepo := repositories.NewIdentity()
 	claimsRepo := repositories.NewClaims()
@@ -475,7 +578,7 @@ func TestServer_GetIdentities(t *testing.T) {
 		Host:       "host",
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
-	server := NewServer(&cfg, identityService, claimsService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, testDependencies(identityService, claimsService, connectionsRepository))
 	handler := getHandler(context.Background(), server)
 
 	idStr1 := "did:polygonid:polygon:mumbai:2qE1ZT16aqEWhh9mX9aqM2pe2ZwV995dTkReeKwCaQ"
@@ -533,6 +636,320 @@ func TestServer_GetIdentities(t *testing.T) {
 	}
 }
 
+func TestServer_IdentityDefaultProofTypes(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "mumbai"
+	)
+	ctx := log.NewContext(context.Background(), log.LevelDebug, log.OutputText, os.Stdout)
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "http://host"}, pubsub.NewMock())
+	server := NewServer(&cfg, testDependencies(identityService, claimsService, connectionsRepository))
+	handler := getHandler(ctx, server)
+
+	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
+	require.NoError(t, err)
+
+	type expected struct {
+		httpCode   int
+		message    string
+		proofTypes []ProofType
+	}
+	type testConfig struct {
+		name       string
+		auth       func() (string, string)
+		method     string
+		identifier string
+		body       *UpdateDefaultProofTypesRequest
+		expected   expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:       "No auth header",
+			auth:       authWrong,
+			method:     http.MethodGet,
+			identifier: iden.Identifier,
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name:       "Invalid did",
+			auth:       authOk,
+			method:     http.MethodGet,
+			identifier: "did:polygonid:wrong",
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  "invalid did",
+			},
+		},
+		{
+			name:       "Not existing identity",
+			auth:       authOk,
+			method:     http.MethodPut,
+			identifier: "did:polygonid:polygon:mumbai:2qKDJmySKNi4GD4vYdqfLb37MSTSijg77NoRZaKfDX",
+			body:       &UpdateDefaultProofTypesRequest{ProofTypes: &[]ProofType{BJJSignature2021}},
+			expected: expected{
+				httpCode: http.StatusNotFound,
+				message:  "identity not found",
+			},
+		},
+		{
+			name:       "Identity without defaults",
+			auth:       authOk,
+			method:     http.MethodGet,
+			identifier: iden.Identifier,
+			expected: expected{
+				httpCode:   http.StatusOK,
+				proofTypes: []ProofType{BJJSignature2021, Iden3SparseMerkleTreeProof},
+			},
+		},
+		{
+			name:       "Empty proof types",
+			auth:       authOk,
+			method:     http.MethodPut,
+			identifier: iden.Identifier,
+			body:       &UpdateDefaultProofTypesRequest{ProofTypes: &[]ProofType{}},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  "at least one proof type must be provided",
+			},
+		},
+		{
+			name:       "Update defaults",
+			auth:       authOk,
+			method:     http.MethodPut,
+			identifier: iden.Identifier,
+			body:       &UpdateDefaultProofTypesRequest{ProofTypes: &[]ProofType{BJJSignature2021}},
+			expected: expected{
+				httpCode:   http.StatusOK,
+				proofTypes: []ProofType{BJJSignature2021},
+			},
+		},
+		{
+			name:       "Identity with defaults",
+			auth:       authOk,
+			method:     http.MethodGet,
+			identifier: iden.Identifier,
+			expected: expected{
+				httpCode:   http.StatusOK,
+				proofTypes: []ProofType{BJJSignature2021},
+			},
+		},
+		{
+			name:       "Remove defaults",
+			auth:       authOk,
+			method:     http.MethodPut,
+			identifier: iden.Identifier,
+			body:       &UpdateDefaultProofTypesRequest{},
+			expected: expected{
+				httpCode:   http.StatusOK,
+				proofTypes: []ProofType{BJJSignature2021, Iden3SparseMerkleTreeProof},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v1/%s/default-proof-types", tc.identifier)
+			var body io.Reader
+			if tc.body != nil {
+				body = tests.JSONBody(t, tc.body)
+			}
+			req, err := http.NewRequest(tc.method, url, body)
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			switch tc.expected.httpCode {
+			case http.StatusOK:
+				var response DefaultProofTypes
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.proofTypes, response.ProofTypes)
+			case http.StatusBadRequest, http.StatusNotFound:
+				var response GenericErrorMessage
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			}
+		})
+	}
+}
+
+func TestServer_IdentityBranding(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "mumbai"
+	)
+	ctx := log.NewContext(context.Background(), log.LevelDebug, log.OutputText, os.Stdout)
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "http://host"}, pubsub.NewMock())
+	nodeBranding := domain.Branding{DisplayName: "Node", LogoURL: "https://node.example.com/logo.png", PrimaryColor: "#6c4ce0", TextColor: "#ffffff"}
+	deps := testDependencies(identityService, claimsService, connectionsRepository)
+	deps.Branding = services.NewBranding(storage, nodeBranding)
+	server := NewServer(&cfg, deps)
+	handler := getHandler(ctx, server)
+
+	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
+	require.NoError(t, err)
+
+	type expected struct {
+		httpCode int
+		message  string
+		branding Branding
+	}
+	type testConfig struct {
+		name       string
+		auth       func() (string, string)
+		method     string
+		identifier string
+		body       *UpdateBrandingRequest
+		expected   expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:       "No auth header",
+			auth:       authWrong,
+			method:     http.MethodGet,
+			identifier: iden.Identifier,
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name:       "Invalid did",
+			auth:       authOk,
+			method:     http.MethodGet,
+			identifier: "did:polygonid:wrong",
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  "invalid did",
+			},
+		},
+		{
+			name:       "Not existing identity",
+			auth:       authOk,
+			method:     http.MethodPut,
+			identifier: "did:polygonid:polygon:mumbai:2qKDJmySKNi4GD4vYdqfLb37MSTSijg77NoRZaKfDX",
+			body:       &UpdateBrandingRequest{DisplayName: common.ToPointer("Acme")},
+			expected: expected{
+				httpCode: http.StatusNotFound,
+				message:  "identity not found",
+			},
+		},
+		{
+			name:       "Identity without overrides",
+			auth:       authOk,
+			method:     http.MethodGet,
+			identifier: iden.Identifier,
+			expected: expected{
+				httpCode: http.StatusOK,
+				branding: Branding{DisplayName: "Node", Logo: "https://node.example.com/logo.png", PrimaryColor: "#6c4ce0", TextColor: "#ffffff"},
+			},
+		},
+		{
+			name:       "Invalid color",
+			auth:       authOk,
+			method:     http.MethodPut,
+			identifier: iden.Identifier,
+			body:       &UpdateBrandingRequest{PrimaryColor: common.ToPointer("red")},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  "invalid branding: the colors must be hex colors like #6c4ce0 <red>",
+			},
+		},
+		{
+			name:       "Invalid logo",
+			auth:       authOk,
+			method:     http.MethodPut,
+			identifier: iden.Identifier,
+			body:       &UpdateBrandingRequest{Logo: common.ToPointer("javascript:alert(1)")},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  "invalid branding: the logo must be an http or https url <javascript:alert(1)>",
+			},
+		},
+		{
+			name:       "Update overrides",
+			auth:       authOk,
+			method:     http.MethodPut,
+			identifier: iden.Identifier,
+			body:       &UpdateBrandingRequest{DisplayName: common.ToPointer("Acme"), Logo: common.ToPointer(""), PrimaryColor: common.ToPointer("#112233")},
+			expected: expected{
+				httpCode: http.StatusOK,
+				branding: Branding{DisplayName: "Acme", Logo: "", PrimaryColor: "#112233", TextColor: "#ffffff"},
+			},
+		},
+		{
+			name:       "Identity with overrides",
+			auth:       authOk,
+			method:     http.MethodGet,
+			identifier: iden.Identifier,
+			expected: expected{
+				httpCode: http.StatusOK,
+				branding: Branding{DisplayName: "Acme", Logo: "", PrimaryColor: "#112233", TextColor: "#ffffff"},
+			},
+		},
+		{
+			name:       "Remove overrides",
+			auth:       authOk,
+			method:     http.MethodPut,
+			identifier: iden.Identifier,
+			body:       &UpdateBrandingRequest{},
+			expected: expected{
+				httpCode: http.StatusOK,
+				branding: Branding{DisplayName: "Node", Logo: "https://node.example.com/logo.png", PrimaryColor: "#6c4ce0", TextColor: "#ffffff"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v1/%s/branding", tc.identifier)
+			var body io.Reader
+			if tc.body != nil {
+				body = tests.JSONBody(t, tc.body)
+			}
+			req, err := http.NewRequest(tc.method, url, body)
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			switch tc.expected.httpCode {
+			case http.StatusOK:
+				var response Branding
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.branding, response)
+			case http.StatusBadRequest, http.StatusNotFound:
+				var response GenericErrorMessage
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			}
+		})
+	}
+}
+
 func TestServer_GetClaimQrCode(t *testing.T) {
 	identityRepo := repositories.NewIdentity()
 	claimsRepo := repositories.NewClaims()
@@ -563,7 +980,7 @@ func TestServer_GetClaimQrCode(t *testing.T) {
 	claim := fixture.NewClaim(t, identity.Identifier)
 	fixture.CreateClaim(t, claim)
 
-	server := NewServer(&cfg, identityService, claimsService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, testDependencies(identityService, claimsService, connectionsRepository))
 	handler := getHandler(context.Background(), server)
 
 	type expected struct {
@@ -698,7 +1115,7 @@ func TestServer_GetClaim(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 
-	server := NewServer(&cfg, identityService, claimsService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, testDependencies(identityService, claimsService, connectionsRepository))
 
 	idStr := "did:polygonid:polygon:mumbai:2qLduMv2z7hnuhzkcTWesCUuJKpRVDEThztM4tsJUj"
 	idStrWithoutClaims := "did:polygonid:polygon:mumbai:2qGjTUuxZKqKS4Q8UmxHUPw55g15QgEVGnj6Wkq8Vk"
@@ -848,6 +1265,250 @@ func TestServer_GetClaim(t *testing.T) {
 	}
 }
 
+func TestServer_GetClaim_DataModel(t *testing.T) {
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	connectionsRepository := repositories.NewConnections()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	identityService := services.NewIdentity(&KMSMock{}, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	claimsConf := services.ClaimCfg{
+		RHSEnabled: false,
+		Host:       "host",
+	}
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, loader.CachedFactory(loader.HTTPFactory, cachex), storage, claimsConf, pubsub.NewMock())
+	server := NewServer(&cfg, testDependencies(identityService, claimsService, connectionsRepository))
+	handler := getHandler(context.Background(), server)
+
+	idStr := "did:polygonid:polygon:mumbai:2qMZrfBsXuGFTwSqkqYki78zF3pe1vtXoqH4yRLsfs"
+	fixture := tests.NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	claim := fixture.NewClaim(t, idStr)
+	fixture.CreateClaim(t, claim)
+
+	type expected struct {
+		httpCode  int
+		dataModel domain.CredentialDataModel
+	}
+	for _, tc := range []struct {
+		name     string
+		query    string
+		accept   string
+		expected expected
+	}{
+		{name: "default", expected: expected{httpCode: http.StatusOK, dataModel: domain.CredentialDataModelV1}},
+		{name: "parameter", query: "?dataModel=2.0", expected: expected{httpCode: http.StatusOK, dataModel: domain.CredentialDataModelV2}},
+		{name: "accept media type", accept: "application/vc", expected: expected{httpCode: http.StatusOK, dataModel: domain.CredentialDataModelV2}},
+		{name: "accept profile", accept: `application/ld+json; profile="https://www.w3.org/ns/credentials/v2"`, expected: expected{httpCode: http.StatusOK, dataModel: domain.CredentialDataModelV2}},
+		{name: "parameter over accept", query: "?dataModel=1.1", accept: "application/vc", expected: expected{httpCode: http.StatusOK, dataModel: domain.CredentialDataModelV1}},
+		{name: "invalid parameter", query: "?dataModel=3.0", expected: expected{httpCode: http.StatusBadRequest}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, url := range []string{fmt.Sprintf("/v1/%s/claims/%s", idStr, claim.ID), fmt.Sprintf("/v1/%s/claims", idStr)} {
+				rr := httptest.NewRecorder()
+				req, err := http.NewRequest("GET", url+tc.query, nil)
+				require.NoError(t, err)
+				req.SetBasicAuth(authOk())
+				if tc.accept != "" {
+					req.Header.Set("Accept", tc.accept)
+				}
+				handler.ServeHTTP(rr, req)
+				require.Equal(t, tc.expected.httpCode, rr.Code, url)
+				if tc.expected.httpCode != http.StatusOK {
+					continue
+				}
+
+				var response GetClaimResponse
+				if strings.HasSuffix(url, "/claims") {
+					var list GetClaimsResponse
+					require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+					require.Len(t, list, 1)
+					response = list[0]
+				} else {
+					require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				}
+				if tc.expected.dataModel == domain.CredentialDataModelV2 {
+					assert.Equal(t, domain.W3CCredentialContextV2, response.Context[0])
+					assert.NotContains(t, response.Context, verifiable.JSONLDSchemaW3CCredential2018)
+					assert.NotNil(t, response.ValidFrom)
+					assert.Nil(t, response.IssuanceDate)
+				} else {
+					assert.Equal(t, verifiable.JSONLDSchemaW3CCredential2018, response.Context[0])
+					assert.NotNil(t, response.IssuanceDate)
+					assert.Nil(t, response.ValidFrom)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_GetClaimDisplay(t *testing.T) {
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	connectionsRepository := repositories.NewConnections()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	identityService := services.NewIdentity(&KMSMock{}, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	claimsConf := services.ClaimCfg{
+		RHSEnabled: false,
+		Host:       "host",
+	}
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, loader.CachedFactory(loader.HTTPFactory, cachex), storage, claimsConf, pubsub.NewMock())
+
+	templates := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/card.json":
+			_, _ = w.Write([]byte(`{"title": "{{type}}", "description": "Born on {{credentialSubject.birthday}}", "issuerName": "Issuer <Inc>", "titleTextColor": "#000000", "logo": {"uri": "https://example.com/logo.png", "alt": "logo"}}`))
+		case "/broken.json":
+			_, _ = w.Write([]byte(`not a template`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer templates.Close()
+
+	deps := testDependencies(identityService, claimsService, connectionsRepository)
+	deps.Display = services.NewDisplay(claimsService, services.NewBranding(storage, domain.Branding{PrimaryColor: "#112233", TextColor: "#eeeeee"}), loader.HTTPFactory)
+	server := NewServer(&cfg, deps)
+	handler := getHandler(context.Background(), server)
+
+	idStr := "did:polygonid:polygon:mumbai:2qNytPv6dKKhfqopjBdXJU1vSVb3Lbgcidved32R64"
+	fixture := tests.NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+
+	claimWithDisplay := func(templateURL string) *domain.Claim {
+		claim := fixture.NewClaim(t, idStr)
+		vc, err := claim.GetVerifiableCredential()
+		require.NoError(t, err)
+		require.NoError(t, claim.Data.Set(schema.W3CCredential{
+			W3CCredential: vc,
+			DisplayMethod: &domain.DisplayMethod{ID: templateURL, Type: domain.Iden3BasicDisplayMethodV1},
+		}))
+		fixture.CreateClaim(t, claim)
+		return claim
+	}
+	withoutDisplay := fixture.NewClaim(t, idStr)
+	fixture.CreateClaim(t, withoutDisplay)
+	withDisplay := claimWithDisplay(templates.URL + "/card.json")
+	brokenDisplay := claimWithDisplay(templates.URL + "/broken.json")
+	missingDisplay := claimWithDisplay(templates.URL + "/missing.json")
+
+	type expected struct {
+		httpCode    int
+		message     string
+		contentType string
+		title       string
+	}
+
+	type testConfig struct {
+		name     string
+		did      string
+		claim    uuid.UUID
+		format   string
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:     "should get an error, invalid did",
+			did:      ":polygon:mumbai:2qPUUYXa98tQWZKSaRidf2QTDyZicFFxkTWNWjk2HJ",
+			claim:    withDisplay.ID,
+			expected: expected{httpCode: http.StatusBadRequest, message: "invalid did"},
+		},
+		{
+			name:     "should get an error, invalid format",
+			did:      idStr,
+			claim:    withDisplay.ID,
+			format:   "png",
+			expected: expected{httpCode: http.StatusBadRequest, message: "invalid format, must be json or svg"},
+		},
+		{
+			name:     "should get an error, non existing claim",
+			did:      idStr,
+			claim:    uuid.New(),
+			expected: expected{httpCode: http.StatusNotFound, message: "claim not found"},
+		},
+		{
+			name:     "should get an error, claim without display method",
+			did:      idStr,
+			claim:    withoutDisplay.ID,
+			expected: expected{httpCode: http.StatusNotFound, message: "the credential has no display method"},
+		},
+		{
+			name:     "should get an error, template is not valid",
+			did:      idStr,
+			claim:    brokenDisplay.ID,
+			expected: expected{httpCode: http.StatusUnprocessableEntity, message: "cannot load the display template"},
+		},
+		{
+			name:     "should get an error, template not found",
+			did:      idStr,
+			claim:    missingDisplay.ID,
+			expected: expected{httpCode: http.StatusUnprocessableEntity, message: "cannot load the display template"},
+		},
+		{
+			name:     "should get the card as json",
+			did:      idStr,
+			claim:    withDisplay.ID,
+			expected: expected{httpCode: http.StatusOK, contentType: "application/json", title: "KYCAgeCredential"},
+		},
+		{
+			name:     "should get the card as svg",
+			did:      idStr,
+			claim:    withDisplay.ID,
+			format:   "svg",
+			expected: expected{httpCode: http.StatusOK, contentType: "image/svg+xml", title: "KYCAgeCredential"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v1/%s/claims/%s/display", tc.did, tc.claim)
+			if tc.format != "" {
+				url += "?format=" + tc.format
+			}
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode != http.StatusOK {
+				var response GenericErrorMessage
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+				return
+			}
+
+			assert.Equal(t, tc.expected.contentType, rr.Header().Get("Content-Type"))
+			if tc.expected.contentType == "image/svg+xml" {
+				assert.True(t, strings.HasPrefix(rr.Body.String(), "<svg"))
+				assert.Contains(t, rr.Body.String(), ">"+tc.expected.title+"</text>")
+				assert.Contains(t, rr.Body.String(), "Born on 19960424")
+				assert.Contains(t, rr.Body.String(), "Issuer &lt;Inc&gt;")
+				assert.Contains(t, rr.Body.String(), `fill="#112233"`)
+				return
+			}
+
+			var response CredentialDisplayResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, withDisplay.ID.String(), response.CredentialID)
+			assert.Equal(t, DisplayMethod{Id: templates.URL + "/card.json", Type: domain.Iden3BasicDisplayMethodV1}, response.DisplayMethod)
+			assert.Equal(t, tc.expected.title, response.Title)
+			assert.Equal(t, "Born on 19960424", response.Description)
+			assert.Equal(t, "Issuer <Inc>", response.IssuerName)
+			assert.Equal(t, "#000000", response.TitleTextColor)
+			assert.Equal(t, "#eeeeee", response.DescriptionTextColor, "from the branding")
+			assert.Equal(t, "#112233", response.BackgroundColor, "from the branding")
+			assert.Equal(t, "https://example.com/logo.png", response.Logo.Uri)
+		})
+	}
+}
+
 func TestServer_GetClaims(t *testing.T) {
 	const (
 		method     = "polygonid"
@@ -871,7 +1532,7 @@ func TestServer_GetClaims(t *testing.T) {
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 
 	fixture := tests.NewFixture(storage)
-	server := NewServer(&cfg, identityService, claimsService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, testDependencies(identityService, claimsService, connectionsRepository))
 
 	ctx := context.Background()
 	identityMultipleClaims, err := server.identityService.Create(ctx, method, blockchain, network, "https://localhost.com")
@@ -885,7 +1546,7 @@ func TestServer_GetClaims(t *testing.T) {
 	handler := getHandler(context.Background(), server)
 
 	type expected struct {
-		response GetClaimsResponseObject
+		response any
 		len      int
 		httpCode int
 	}
@@ -947,7 +1608,7 @@ func TestServer_GetClaims(t *testing.T) {
 			expected: expected{
 				httpCode: http.StatusOK,
 				len:      0,
-				response: GetClaims200JSONResponse{},
+				response: GetClaimsResponse{},
 			},
 		},
 		{
@@ -957,7 +1618,7 @@ func TestServer_GetClaims(t *testing.T) {
 			expected: expected{
 				httpCode: http.StatusOK,
 				len:      1,
-				response: GetClaims200JSONResponse{
+				response: GetClaimsResponse{
 					GetClaimResponse{
 						Context: []string{"https://www.w3.org/2018/credentials/v1", "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/iden3credential-v2.json-ld", "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld"},
 						CredentialSchema: CredentialSchema{
@@ -993,7 +1654,7 @@ func TestServer_GetClaims(t *testing.T) {
 			expected: expected{
 				httpCode: http.StatusOK,
 				len:      1,
-				response: GetClaims200JSONResponse{
+				response: GetClaimsResponse{
 					GetClaimResponse{
 						Context: []string{"https://www.w3.org/2018/credentials/v1", "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/iden3credential-v2.json-ld", "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld"},
 						CredentialSchema: CredentialSchema{
@@ -1030,7 +1691,7 @@ func TestServer_GetClaims(t *testing.T) {
 			expected: expected{
 				httpCode: http.StatusOK,
 				len:      1,
-				response: GetClaims200JSONResponse{
+				response: GetClaimsResponse{
 					GetClaimResponse{
 						Context: []string{"https://www.w3.org/2018/credentials/v1", "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/iden3credential-v2.json-ld", "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld"},
 						CredentialSchema: CredentialSchema{
@@ -1066,7 +1727,7 @@ func TestServer_GetClaims(t *testing.T) {
 			expected: expected{
 				httpCode: http.StatusOK,
 				len:      0,
-				response: GetClaims200JSONResponse{},
+				response: GetClaimsResponse{},
 			},
 		},
 		{
@@ -1079,7 +1740,7 @@ func TestServer_GetClaims(t *testing.T) {
 			expected: expected{
 				httpCode: http.StatusOK,
 				len:      1,
-				response: GetClaims200JSONResponse{
+				response: GetClaimsResponse{
 					GetClaimResponse{
 						Context: []string{"https://www.w3.org/2018/credentials/v1", "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/iden3credential-v2.json-ld", "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld"},
 						CredentialSchema: CredentialSchema{
@@ -1115,7 +1776,7 @@ func TestServer_GetClaims(t *testing.T) {
 			expected: expected{
 				httpCode: http.StatusOK,
 				len:      1,
-				response: GetClaims200JSONResponse{
+				response: GetClaimsResponse{
 					GetClaimResponse{
 						Context: []string{"https://www.w3.org/2018/credentials/v1", "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/iden3credential-v2.json-ld", "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld"},
 						CredentialSchema: CredentialSchema{
@@ -1154,8 +1815,8 @@ func TestServer_GetClaims(t *testing.T) {
 			require.Equal(t, tc.expected.httpCode, rr.Code)
 
 			switch v := tc.expected.response.(type) {
-			case GetClaims200JSONResponse:
-				var response GetClaims200JSONResponse
+			case GetClaimsResponse:
+				var response GetClaimsResponse
 				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
 				assert.Equal(t, tc.expected.len, len(response))
 				for i := range response {
@@ -1172,6 +1833,40 @@ func TestServer_GetClaims(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("should get the claims in pages", func(t *testing.T) {
+		_ = fixture.CreateClaim(t, fixture.NewClaim(t, identityMultipleClaims.Identifier))
+		get := func(query string) (*httptest.ResponseRecorder, GetClaimsResponse) {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", createGetClaimsURL(identityMultipleClaims.Identifier, nil, nil, nil, nil, nil, nil)+"?"+query, nil)
+			require.NoError(t, err)
+			req.SetBasicAuth(authOk())
+			handler.ServeHTTP(rr, req)
+			var response GetClaimsResponse
+			if rr.Code == http.StatusOK {
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			}
+			return rr, response
+		}
+
+		rr, first := get("limit=1")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, first, 1)
+		assert.Equal(t, "2", rr.Header().Get("X-Total-Count"))
+		next := rr.Header().Get("X-Next-Page-Token")
+		require.NotEmpty(t, next)
+
+		rr, second := get("limit=1&pageToken=" + next)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Len(t, second, 1)
+		assert.NotEqual(t, first[0].Id, second[0].Id)
+		assert.Empty(t, rr.Header().Get("X-Next-Page-Token"), "the last page")
+
+		rr, _ = get("limit=0")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		rr, _ = get("pageToken=other")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
 }
 
 func TestServer_GetRevocationStatus(t *testing.T) {
@@ -1200,7 +1895,7 @@ func TestServer_GetRevocationStatus(t *testing.T) {
 	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
 	assert.NoError(t, err)
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
-	server := NewServer(&cfg, identityService, claimsService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, testDependencies(identityService, claimsService, connectionsRepository))
 	handler := getHandler(context.Background(), server)
 
 	schema := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
@@ -1268,6 +1963,182 @@ func TestServer_GetRevocationStatus(t *testing.T) {
 	}
 }
 
+func TestServer_CheckCredentialValidity(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "mumbai"
+		remoteDID  = "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+	)
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	claimsConf := services.ClaimCfg{
+		RHSEnabled: false,
+		Host:       "https://host.com",
+	}
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, loader.CachedFactory(loader.HTTPFactory, cachex), storage, claimsConf, pubsub.NewMock())
+	validityService := services.NewCredentialValidity(identityService, claimsService, services.NewRevocationService(nil, nil), nil, nil, nil)
+	deps := testDependencies(identityService, claimsService, connectionsRepository)
+	deps.CredentialValidity = validityService
+	server := NewServer(&cfg, deps)
+	handler := getHandler(context.Background(), server)
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+	credentialSubject := map[string]any{
+		"id":           remoteDID,
+		"birthday":     19960424,
+		"documentType": 2,
+	}
+	claim, err := claimsService.Save(ctx, ports.NewCreateClaimRequest(did, "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json", credentialSubject, common.ToPointer(time.Now().Add(time.Hour)), "KYCAgeCredential", nil, nil, nil, common.ToPointer(true), common.ToPointer(true), nil, false))
+	require.NoError(t, err)
+	localCredential, err := schema.FromClaimModelToW3CCredential(*claim)
+	require.NoError(t, err)
+
+	remoteIssuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status/revoked":
+			_, _ = w.Write([]byte(`{"issuer": {}, "mtp": {"existence": true, "siblings": []}}`))
+		case "/status/valid":
+			_, _ = w.Write([]byte(`{"issuer": {}, "mtp": {"existence": false, "siblings": []}}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer remoteIssuer.Close()
+
+	remoteCredential := func(status string, statusType verifiable.CredentialStatusType, expiration *time.Time) verifiable.W3CCredential {
+		return verifiable.W3CCredential{
+			ID:                "https://issuer.example.com/v1/credentials/" + uuid.NewString(),
+			Type:              []string{"VerifiableCredential", "KYCAgeCredential"},
+			Issuer:            remoteDID,
+			Expiration:        expiration,
+			CredentialSubject: credentialSubject,
+			CredentialStatus: verifiable.CredentialStatus{
+				ID:              remoteIssuer.URL + "/status/" + status,
+				Type:            statusType,
+				RevocationNonce: 1234,
+			},
+		}
+	}
+	past := time.Now().Add(-time.Hour)
+
+	type expected struct {
+		httpCode    int
+		message     string
+		verdict     CredentialValidityResponseVerdict
+		localIssuer bool
+		revoked     *bool
+		expired     bool
+	}
+	type testConfig struct {
+		name       string
+		auth       func() (string, string)
+		credential any
+		expected   expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:       "No auth header",
+			auth:       authWrong,
+			credential: remoteCredential("valid", verifiable.SparseMerkleTreeProof, nil),
+			expected:   expected{httpCode: http.StatusUnauthorized},
+		},
+		{
+			name:       "should get an error, the issuer is not a did",
+			auth:       authOk,
+			credential: map[string]any{"issuer": "issuer.example.com", "credentialStatus": remoteCredential("valid", verifiable.SparseMerkleTreeProof, nil).CredentialStatus},
+			expected:   expected{httpCode: http.StatusBadRequest, message: "invalid credential: the issuer must be a did"},
+		},
+		{
+			name:       "should get an error, no credential status",
+			auth:       authOk,
+			credential: map[string]any{"issuer": remoteDID},
+			expected:   expected{httpCode: http.StatusBadRequest, message: "invalid credential: the credential has no credentialStatus"},
+		},
+		{
+			name:       "should get an error, unsupported credential status",
+			auth:       authOk,
+			credential: remoteCredential("valid", "StatusList2021Entry", nil),
+			expected:   expected{httpCode: http.StatusBadRequest, message: "invalid credential: credentialStatus type StatusList2021Entry is not supported"},
+		},
+		{
+			name:       "should get valid, local issuer",
+			auth:       authOk,
+			credential: localCredential,
+			expected:   expected{httpCode: http.StatusOK, verdict: Valid, localIssuer: true, revoked: common.ToPointer(false)},
+		},
+		{
+			name:       "should get valid, remote issuer",
+			auth:       authOk,
+			credential: remoteCredential("valid", verifiable.SparseMerkleTreeProof, nil),
+			expected:   expected{httpCode: http.StatusOK, verdict: Valid, revoked: common.ToPointer(false)},
+		},
+		{
+			name:       "should get revoked, even if expired",
+			auth:       authOk,
+			credential: remoteCredential("revoked", verifiable.SparseMerkleTreeProof, &past),
+			expected:   expected{httpCode: http.StatusOK, verdict: Revoked, revoked: common.ToPointer(true), expired: true},
+		},
+		{
+			name:       "should get expired",
+			auth:       authOk,
+			credential: remoteCredential("valid", verifiable.SparseMerkleTreeProof, &past),
+			expected:   expected{httpCode: http.StatusOK, verdict: Expired, revoked: common.ToPointer(false), expired: true},
+		},
+		{
+			name:       "should get unknown, the issuer is not reachable",
+			auth:       authOk,
+			credential: remoteCredential("down", verifiable.SparseMerkleTreeProof, nil),
+			expected:   expected{httpCode: http.StatusOK, verdict: Unknown},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := json.Marshal(tc.credential)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, "/v1/credentials/validity", strings.NewReader(string(body)))
+			require.NoError(t, err)
+			req.SetBasicAuth(tc.auth())
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode == http.StatusUnauthorized {
+				return
+			}
+			if tc.expected.httpCode != http.StatusOK {
+				var response GenericErrorMessage
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+				return
+			}
+
+			var response CredentialValidityResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tc.expected.verdict, response.Verdict)
+			assert.Equal(t, tc.expected.localIssuer, response.LocalIssuer)
+			assert.Equal(t, tc.expected.revoked, response.Revoked)
+			assert.Equal(t, tc.expected.expired, response.Expired)
+			assert.Equal(t, tc.expected.revoked == nil, response.Reason != nil)
+			if response.Reason != nil {
+				assert.Equal(t, "cannot resolve the revocation status", *response.Reason, "the fetch error is not returned")
+			}
+			assert.Nil(t, response.Accreditation, "there is no trust registry")
+		})
+	}
+}
+
 func validateClaim(t *testing.T, resp, tc GetClaimResponse) {
 	t.Helper()
 	var responseCredentialStatus verifiable.CredentialStatus
@@ -1317,6 +2188,70 @@ func validateClaim(t *testing.T, resp, tc GetClaimResponse) {
 	assert.EqualValues(t, responseCredentialStatus, credentialStatusTC)
 }
 
+func TestServer_GetIssuerMetadata(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	connectionsRepository := repositories.NewConnections()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	identityService := services.NewIdentity(&KMSMock{}, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, loader.CachedFactory(loader.HTTPFactory, cachex), storage, services.ClaimCfg{Host: "host"}, pubsub.NewMock())
+	schemaRepo := repositories.NewSchema(*storage)
+	issuerMetadataService := services.NewIssuerMetadata(services.IssuerMetadataCfg{Host: "https://issuer.example.com/", RHSEnabled: true, RHSUrl: "https://rhs.example.com"}, identityService, claimsService, schemaRepo, services.NewBranding(storage, domain.Branding{DisplayName: "Issuer", PrimaryColor: "#6c4ce0", TextColor: "#ffffff"}))
+
+	identity, err := identityService.Create(ctx, "polygonid", "polygon", "mumbai", "https://issuer.example.com")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+	schemaHash, err := core.NewSchemaHashFromHex("ca938857241db9451ea329256b9c06e5")
+	require.NoError(t, err)
+	require.NoError(t, schemaRepo.Save(ctx, &domain.Schema{
+		ID:         uuid.New(),
+		IssuerDID:  *did,
+		URL:        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+		Type:       "KYCAgeCredential",
+		Hash:       schemaHash,
+		Attributes: domain.SchemaAttrs{"birthday", "documentType"},
+		CreatedAt:  time.Now(),
+	}))
+
+	deps := testDependencies(identityService, claimsService, connectionsRepository)
+	deps.IssuerMetadata = issuerMetadataService
+	server := NewServer(&cfg, deps)
+	handler := getHandler(ctx, server)
+
+	req, err := http.NewRequest(http.MethodGet, "/.well-known/issuer-metadata", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, "the metadata is public")
+
+	var response GetIssuerMetadata200JSONResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "https://issuer.example.com", response.Url)
+	assert.Equal(t, []string{"SparseMerkleTreeProof", "Iden3ReverseSparseMerkleTreeProof"}, response.CredentialStatusTypes)
+	assert.Equal(t, "https://issuer.example.com/v1/agent", response.ServiceEndpoints.Agent)
+	assert.Equal(t, common.ToPointer("https://rhs.example.com"), response.ServiceEndpoints.ReverseHashService)
+
+	var issuer *IssuerMetadataIssuer
+	for i := range response.Issuers {
+		if response.Issuers[i].Did == identity.Identifier {
+			issuer = &response.Issuers[i]
+		}
+	}
+	require.NotNil(t, issuer)
+	require.Len(t, issuer.PublicKeys, 1)
+	assert.Equal(t, domain.PublicKeyTypeBJJ, issuer.PublicKeys[0].Type)
+	assert.NotEmpty(t, issuer.PublicKeys[0].X)
+	assert.Equal(t, []ProofType{BJJSignature2021, Iden3SparseMerkleTreeProof}, issuer.ProofTypes)
+	assert.Equal(t, []IssuerSchema{{Url: "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json", Type: "KYCAgeCredential"}}, issuer.Schemas)
+	assert.Equal(t, Branding{DisplayName: "Issuer", PrimaryColor: "#6c4ce0", TextColor: "#ffffff"}, issuer.Branding)
+}
+
 func createGetClaimsURL(did string, schemaHash *string, schemaType *string, subject *string, revoked *string, self *string, queryField *string) string {
 	tURL := &url.URL{Path: fmt.Sprintf("/v1/%s/claims", did)}
 	q := tURL.Query()
@@ -1348,3 +2283,84 @@ func createGetClaimsURL(did string, schemaHash *string, schemaType *string, subj
 	tURL.RawQuery = q.Encode()
 	return tURL.String()
 }
+
+func TestServer_GetMetering(t *testing.T) {
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(&KMSMock{}, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "host"}, pubsub.NewMock())
+	server := NewServer(&cfg, testDependencies(identityService, claimsService, connectionsRepository))
+	handler := getHandler(context.Background(), server)
+
+	did, err := core.ParseDID("did:polygonid:polygon:mumbai:2qGZ2ZNAfsXv3nLyxgcRjvQaBKEgT4G4VmWWhRuYdX")
+	require.NoError(t, err)
+	apiKeyID := uuid.New()
+	meteringRepo := repositories.NewMetering()
+	at := time.Date(2021, 3, 15, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, meteringRepo.Add(context.Background(), storage.Pgx, *did, nil, domain.MeteredIssuance, at))
+	require.NoError(t, meteringRepo.Add(context.Background(), storage.Pgx, *did, nil, domain.MeteredIssuance, at.AddDate(0, 0, 10)))
+	require.NoError(t, meteringRepo.Add(context.Background(), storage.Pgx, *did, &apiKeyID, domain.MeteredIssuance, at))
+	require.NoError(t, meteringRepo.Add(context.Background(), storage.Pgx, *did, nil, domain.MeteredPublish, at.AddDate(0, 1, 0)))
+
+	t.Run("unauthorized", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/v1/metering", nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authWrong())
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/v1/metering?from=2021-05&to=2021-03", nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/v1/metering?from=2021-03&to=2021-04", nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response GetMetering200JSONResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "2021-03", response.From)
+		assert.Equal(t, "2021-04", response.To)
+		var usage []MeteringMonth
+		for _, month := range response.Usage {
+			if month.Issuer == did.String() {
+				usage = append(usage, month)
+			}
+		}
+		assert.Equal(t, []MeteringMonth{
+			{Month: "2021-03", Issuer: did.String(), Operation: "issuance", Count: 2},
+			{Month: "2021-03", Issuer: did.String(), ApiKeyID: &apiKeyID, Operation: "issuance", Count: 1},
+			{Month: "2021-04", Issuer: did.String(), Operation: "publish", Count: 1},
+		}, usage)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/v1/metering?from=2021-04&to=2021-04&format=csv", nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(rr.Body.String(), "month,issuer,api_key_id,operation,count\n"))
+		assert.Contains(t, rr.Body.String(), "2021-04,"+did.String()+",,publish,1\n")
+	})
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func toSigningKeyResponse(key *domain.SigningKey) SigningKey {
+	return SigningKey{
+		Id:        key.AuthClaimID,
+		KeyID:     key.KeyID,
+		Type:      SigningKeyType(key.Type),
+		PublicKey: key.PublicKey,
+		Status:    SigningKeyStatus(key.Status),
+	}
+}
+
+func toSigningKeysResponse(keys []*domain.SigningKey) []SigningKey {
+	res := make([]SigningKey, len(keys))
+	for i, key := range keys {
+		res[i] = toSigningKeyResponse(key)
+	}
+	return res
+}
@@ -21,6 +21,20 @@ const (
 	BasicAuthScopes = "basicAuth.Scopes"
 )
 
+// Defines values for ChallengeType.
+const (
+	Captcha ChallengeType = "captcha"
+	Pow     ChallengeType = "pow"
+)
+
+// Defines values for LinkSessionStatus.
+const (
+	LinkSessionStatusAuthenticated LinkSessionStatus = "authenticated"
+	LinkSessionStatusCreated       LinkSessionStatus = "created"
+	LinkSessionStatusFetched       LinkSessionStatus = "fetched"
+	LinkSessionStatusIssued        LinkSessionStatus = "issued"
+)
+
 // Defines values for LinkStatus.
 const (
 	LinkStatusActive   LinkStatus = "active"
@@ -28,12 +42,73 @@ const (
 	LinkStatusInactive LinkStatus = "inactive"
 )
 
+// Defines values for NotificationStatusStatus.
+const (
+	NotificationStatusStatusFailed  NotificationStatusStatus = "failed"
+	NotificationStatusStatusSent    NotificationStatusStatus = "sent"
+	NotificationStatusStatusSkipped NotificationStatusStatus = "skipped"
+)
+
+// Defines values for PostIssuanceHookMode.
+const (
+	Queued PostIssuanceHookMode = "queued"
+	Sync   PostIssuanceHookMode = "sync"
+)
+
+// Defines values for PrerequisiteProofType.
+const (
+	Mtp PrerequisiteProofType = "mtp"
+	Sig PrerequisiteProofType = "sig"
+)
+
+// Defines values for ProofType.
+const (
+	BJJSignature2021           ProofType = "BJJSignature2021"
+	Iden3SparseMerkleTreeProof ProofType = "Iden3SparseMerkleTreeProof"
+)
+
+// Defines values for Role.
+const (
+	Admin          Role = "admin"
+	IssuerOperator Role = "issuer-operator"
+	ReadOnly       Role = "read-only"
+)
+
+// Defines values for SchemaAttributeDefinitionType.
+const (
+	SchemaAttributeDefinitionTypeBoolean  SchemaAttributeDefinitionType = "boolean"
+	SchemaAttributeDefinitionTypeDate     SchemaAttributeDefinitionType = "date"
+	SchemaAttributeDefinitionTypeDateTime SchemaAttributeDefinitionType = "date-time"
+	SchemaAttributeDefinitionTypeInteger  SchemaAttributeDefinitionType = "integer"
+	SchemaAttributeDefinitionTypeNumber   SchemaAttributeDefinitionType = "number"
+	SchemaAttributeDefinitionTypeString   SchemaAttributeDefinitionType = "string"
+)
+
+// Defines values for SchemaFormFieldInput.
+const (
+	Checkbox SchemaFormFieldInput = "checkbox"
+	Date     SchemaFormFieldInput = "date"
+	Datetime SchemaFormFieldInput = "datetime"
+	Email    SchemaFormFieldInput = "email"
+	Number   SchemaFormFieldInput = "number"
+	Object   SchemaFormFieldInput = "object"
+	Select   SchemaFormFieldInput = "select"
+	Text     SchemaFormFieldInput = "text"
+	Url      SchemaFormFieldInput = "url"
+)
+
 // Defines values for StateTransactionStatus.
 const (
-	Created   StateTransactionStatus = "created"
-	Failed    StateTransactionStatus = "failed"
-	Pending   StateTransactionStatus = "pending"
-	Published StateTransactionStatus = "published"
+	StateTransactionStatusCreated   StateTransactionStatus = "created"
+	StateTransactionStatusFailed    StateTransactionStatus = "failed"
+	StateTransactionStatusPending   StateTransactionStatus = "pending"
+	StateTransactionStatusPublished StateTransactionStatus = "published"
+)
+
+// Defines values for ValidationMode.
+const (
+	Lenient ValidationMode = "lenient"
+	Strict  ValidationMode = "strict"
 )
 
 // Defines values for GetCredentialsParamsStatus.
@@ -51,6 +126,24 @@ const (
 	GetLinksParamsStatusInactive GetLinksParamsStatus = "inactive"
 )
 
+// APIKey Delegated key of the issuer for a third party.
+type APIKey struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Id        uuid.UUID `json:"id"`
+
+	// LinkIDs Links that the key issues the credentials through. Empty allows issuing without links.
+	LinkIDs []uuid.UUID `json:"linkIDs"`
+	Name    string      `json:"name"`
+
+	// Role What the key can call on its issuer: admin every operation, issuer-operator everything but the administration
+	// of the node, the identities, the api keys and the personal data of the subjects, and read-only the reads
+	// of issuer-operator. A key without a role can only issue credentials.
+	Role *Role `json:"role,omitempty"`
+
+	// SchemaTypes Types of the schemas of the credentials that the key can issue. Empty is any schema.
+	SchemaTypes []string `json:"schemaTypes"`
+}
+
 // AgentResponse defines model for AgentResponse.
 type AgentResponse struct {
 	Body     interface{} `json:"body"`
@@ -62,6 +155,13 @@ type AgentResponse struct {
 	Type     string      `json:"type"`
 }
 
+// AntiAbuseChallenge defines model for AntiAbuseChallenge.
+type AntiAbuseChallenge struct {
+	// Challenge Challenge that the client must solve to create a link session.
+	Challenge Challenge `json:"challenge"`
+	Message   string    `json:"message"`
+}
+
 // AuthenticationQrCodeResponse defines model for AuthenticationQrCodeResponse.
 type AuthenticationQrCodeResponse struct {
 	Body struct {
@@ -76,25 +176,155 @@ type AuthenticationQrCodeResponse struct {
 	Type string `json:"type"`
 }
 
+// Challenge Challenge that the client must solve to create a link session.
+type Challenge struct {
+	// Difficulty Number of leading zero bits of the sha256 of "<challenge>:<nonce>" that solves the proof of work.
+	Difficulty *int          `json:"difficulty,omitempty"`
+	Type       ChallengeType `json:"type"`
+
+	// Value Site key of the CAPTCHA or challenge of the proof of work.
+	Value string `json:"value"`
+}
+
+// Branding defines model for Branding.
+type Branding struct {
+	DisplayName  string `json:"displayName"`
+	Logo         string `json:"logo"`
+	PrimaryColor string `json:"primaryColor"`
+	TextColor    string `json:"textColor"`
+}
+
+// BuildSchemaRequest defines model for BuildSchemaRequest.
+type BuildSchemaRequest struct {
+	Attributes  []SchemaAttributeDefinition `json:"attributes"`
+	Description *string                     `json:"description,omitempty"`
+
+	// Ipfs Publishes the json schema and the JSON-LD context in IPFS.
+	Ipfs *bool `json:"ipfs,omitempty"`
+
+	// Register Creates the schema in the registry of the node.
+	Register *bool   `json:"register,omitempty"`
+	Title    *string `json:"title,omitempty"`
+	Type     string  `json:"type"`
+
+	// Version Semantic version, MAJOR.MINOR.PATCH with an optional pre-release.
+	Version string `json:"version"`
+}
+
+// BuildSchemaResponse defines model for BuildSchemaResponse.
+type BuildSchemaResponse struct {
+	// ContextUrl Url of the JSON-LD context, when it was published.
+	ContextUrl *string `json:"contextUrl,omitempty"`
+
+	// Id Id of the schema in the registry, when it was registered.
+	Id            *string                `json:"id,omitempty"`
+	JsonLdContext map[string]interface{} `json:"jsonLdContext"`
+	JsonSchema    map[string]interface{} `json:"jsonSchema"`
+
+	// SchemaUrl Url of the json schema, when it was published.
+	SchemaUrl *string `json:"schemaUrl,omitempty"`
+}
+
+// ChallengeType defines model for Challenge.Type.
+type ChallengeType string
+
+// CreateAPIKeyRequest defines model for CreateAPIKeyRequest.
+type CreateAPIKeyRequest struct {
+	// LinkIDs Links that the key issues the credentials through. When set, the key can only create the qr codes of these
+	// links and it cannot issue credentials without them.
+	LinkIDs *[]uuid.UUID `json:"linkIDs,omitempty"`
+	Name    string       `json:"name"`
+
+	// Role What the key can call on its issuer: admin every operation, issuer-operator everything but the administration
+	// of the node, the identities, the api keys and the personal data of the subjects, and read-only the reads
+	// of issuer-operator. A key without a role can only issue credentials.
+	Role *Role `json:"role,omitempty"`
+
+	// SchemaTypes Types of the schemas of the credentials that the key can issue, like KYCAgeCredential. Any schema
+	// when not set.
+	SchemaTypes *[]string `json:"schemaTypes,omitempty"`
+}
+
+// CreateAPIKeyResponse defines model for CreateAPIKeyResponse.
+type CreateAPIKeyResponse struct {
+	Id uuid.UUID `json:"id"`
+
+	// Secret Password of the key. It is only returned once.
+	Secret string `json:"secret"`
+}
+
+// CreateConnectionCredentialResponse defines model for CreateConnectionCredentialResponse.
+type CreateConnectionCredentialResponse struct {
+	Id           uuid.UUID          `json:"id"`
+	Notification NotificationStatus `json:"notification"`
+}
+
 // CreateCredentialRequest defines model for CreateCredentialRequest.
 type CreateCredentialRequest struct {
 	CredentialSchema  string                 `json:"credentialSchema"`
 	CredentialSubject map[string]interface{} `json:"credentialSubject"`
-	Expiration        *time.Time             `json:"expiration,omitempty"`
-	MtProof           *bool                  `json:"mtProof,omitempty"`
-	SignatureProof    *bool                  `json:"signatureProof,omitempty"`
-	Type              string                 `json:"type"`
+
+	// DisplayMethod Template used by the wallets to render the credential card. Only Iden3BasicDisplayMethodV1 is supported.
+	DisplayMethod *DisplayMethod `json:"displayMethod,omitempty"`
+	Expiration    *time.Time     `json:"expiration,omitempty"`
+	MtProof       *bool          `json:"mtProof,omitempty"`
+
+	// SignatureProof If neither signatureProof nor mtProof are true, the defaults of the schema or of the issuer are used.
+	SignatureProof *bool  `json:"signatureProof,omitempty"`
+	Type           string `json:"type"`
+
+	// ValidationMode How the credential subject is checked against the schema. strict rejects the attributes that are not in
+	// the schema and the values that don't have its types. lenient converts the values given as strings and drops the
+	// attributes that are not in the schema, with a warning for each one. The default is strict.
+	ValidationMode *ValidationMode `json:"validationMode,omitempty"`
 }
 
 // CreateLinkRequest defines model for CreateLinkRequest.
 type CreateLinkRequest struct {
 	CredentialExpiration *openapi_types.Date `json:"credentialExpiration,omitempty"`
-	CredentialSubject    CredentialSubject   `json:"credentialSubject"`
-	Expiration           *time.Time          `json:"expiration,omitempty"`
-	LimitedClaims        *int                `json:"limitedClaims"`
-	MtProof              bool                `json:"mtProof"`
-	SchemaID             uuid.UUID           `json:"schemaID"`
-	SignatureProof       bool                `json:"signatureProof"`
+
+	// CredentialExpirationPolicy Validity period of a credential relative to its issuance date. An ISO 8601 duration like P1Y or P1M15D, or a
+	// number of hours, days, weeks or years like 90d. Months are added in UTC and clamped to the end of shorter
+	// months. At most 100 years.
+	CredentialExpirationPolicy *ExpirationPolicy `json:"credentialExpirationPolicy,omitempty"`
+	CredentialSubject          CredentialSubject `json:"credentialSubject"`
+
+	// DisplayStrings Localized name and description of the credentials shown by the wallets in the credential offers, by locale
+	// (a language tag like en or pt-BR).
+	DisplayStrings *DisplayStrings `json:"displayStrings,omitempty"`
+	Expiration     *time.Time      `json:"expiration,omitempty"`
+	LimitedClaims  *int            `json:"limitedClaims"`
+
+	// Locale Locale of the credential offers when the holder doesn't ask for one with display strings
+	Locale  *string `json:"locale,omitempty"`
+	MtProof bool    `json:"mtProof"`
+
+	// Prerequisites Credentials that the holder must prove to hold before it gets the credential, on top of the schema ones.
+	Prerequisites  *[]Prerequisite `json:"prerequisites,omitempty"`
+	SchemaID       uuid.UUID       `json:"schemaID"`
+	SignatureProof bool            `json:"signatureProof"`
+
+	// ValidationMode How the credential subject is checked against the schema. strict rejects the attributes that are not in
+	// the schema and the values that don't have its types. lenient converts the values given as strings and drops the
+	// attributes that are not in the schema, with a warning for each one. The default is strict.
+	ValidationMode *ValidationMode `json:"validationMode,omitempty"`
+}
+
+// CreateSchemaRequest defines model for CreateSchemaRequest.
+type CreateSchemaRequest struct {
+	Description *string `json:"description,omitempty"`
+
+	// JsonLdContext JSON-LD context that defines the type and its attributes. Generated from the json schema when not set.
+	JsonLdContext *map[string]interface{} `json:"jsonLdContext,omitempty"`
+
+	// JsonSchema JSON schema of the credential, with the credential subject attributes in
+	// properties.credentialSubject.properties. Its $metadata is set by the node.
+	JsonSchema map[string]interface{} `json:"jsonSchema"`
+	Title      *string                `json:"title,omitempty"`
+	Type       string                 `json:"type"`
+
+	// Version Semantic version, MAJOR.MINOR.PATCH with an optional pre-release.
+	Version string `json:"version"`
 }
 
 // Credential defines model for Credential.
@@ -103,14 +333,18 @@ type Credential struct {
 	CredentialSubject map[string]interface{} `json:"credentialSubject"`
 	Expired           bool                   `json:"expired"`
 	ExpiresAt         *time.Time             `json:"expiresAt"`
-	Id                uuid.UUID              `json:"id"`
-	ProofTypes        []string               `json:"proofTypes"`
-	RevNonce          uint64                 `json:"revNonce"`
-	Revoked           bool                   `json:"revoked"`
-	SchemaHash        string                 `json:"schemaHash"`
-	SchemaType        string                 `json:"schemaType"`
-	SchemaUrl         string                 `json:"schemaUrl"`
-	UserID            string                 `json:"userID"`
+
+	// ExternalReferences References answered by the post-issuance hooks of the schema, by hook name. Only returned by the credential
+	// detail endpoint.
+	ExternalReferences *map[string]string `json:"externalReferences,omitempty"`
+	Id                 uuid.UUID          `json:"id"`
+	ProofTypes         []string           `json:"proofTypes"`
+	RevNonce           uint64             `json:"revNonce"`
+	Revoked            bool               `json:"revoked"`
+	SchemaHash         string             `json:"schemaHash"`
+	SchemaType         string             `json:"schemaType"`
+	SchemaUrl          string             `json:"schemaUrl"`
+	UserID             string             `json:"userID"`
 }
 
 // CredentialLinkQrCodeResponse defines model for CredentialLinkQrCodeResponse.
@@ -124,6 +358,34 @@ type CredentialLinkQrCodeResponse struct {
 // CredentialSubject defines model for CredentialSubject.
 type CredentialSubject = map[string]interface{}
 
+// DeviceNotificationStatus defines model for DeviceNotificationStatus.
+type DeviceNotificationStatus struct {
+	Reason string `json:"reason"`
+	Status string `json:"status"`
+}
+
+// DisplayMethod Template used by the wallets to render the credential card. Only Iden3BasicDisplayMethodV1 is supported.
+type DisplayMethod struct {
+	// Id Url of the JSON card template
+	Id   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// DisplayString defines model for DisplayString.
+type DisplayString struct {
+	Description *string `json:"description,omitempty"`
+	Name        string  `json:"name"`
+}
+
+// DisplayStrings Localized name and description of the credentials shown by the wallets in the credential offers, by locale
+// (a language tag like en or pt-BR).
+type DisplayStrings map[string]DisplayString
+
+// ExpirationPolicy Validity period of a credential relative to its issuance date. An ISO 8601 duration like P1Y or P1M15D, or a
+// number of hours, days, weeks or years like 90d. Months are added in UTC and clamped to the end of shorter
+// months. At most 100 years.
+type ExpirationPolicy = string
+
 // GenericErrorMessage defines model for GenericErrorMessage.
 type GenericErrorMessage struct {
 	Message string `json:"message"`
@@ -140,6 +402,7 @@ type GetConnectionResponse struct {
 	Credentials []Credential `json:"credentials"`
 	Id          string       `json:"id"`
 	IssuerID    string       `json:"issuerID"`
+	LastSeen    *time.Time   `json:"lastSeen"`
 	UserID      string       `json:"userID"`
 }
 
@@ -156,6 +419,30 @@ type GetLinkQrCodeResponse struct {
 // Health defines model for Health.
 type Health map[string]bool
 
+// HookDelivery Call to a post-issuance hook.
+type HookDelivery struct {
+	CreatedAt  time.Time `json:"createdAt"`
+	DurationMs int64     `json:"durationMs"`
+
+	// Error Why the call failed. Missing when the hook answered a 2xx status.
+	Error *string   `json:"error,omitempty"`
+	Hook  string    `json:"hook"`
+	Id    uuid.UUID `json:"id"`
+
+	// Request Body sent to the hook.
+	Request map[string]interface{} `json:"request"`
+
+	// Response Body answered by the hook, up to 64KiB.
+	Response *string `json:"response,omitempty"`
+
+	// Status Http status answered by the hook. Missing when the hook didn't answer.
+	Status *int `json:"status,omitempty"`
+
+	// Test True for the sample credentials sent to test the hook.
+	Test bool   `json:"test"`
+	Url  string `json:"url"`
+}
+
 // ImportSchemaRequest defines model for ImportSchemaRequest.
 type ImportSchemaRequest struct {
 	SchemaType string `json:"schemaType"`
@@ -164,8 +451,10 @@ type ImportSchemaRequest struct {
 
 // IssuerDescription defines model for IssuerDescription.
 type IssuerDescription struct {
-	DisplayName string `json:"displayName"`
-	Logo        string `json:"logo"`
+	DisplayName  string `json:"displayName"`
+	Logo         string `json:"logo"`
+	PrimaryColor string `json:"primaryColor"`
+	TextColor    string `json:"textColor"`
 }
 
 // Link defines model for Link.
@@ -173,21 +462,55 @@ type Link struct {
 	Active               bool                `json:"active"`
 	CreatedAt            time.Time           `json:"createdAt"`
 	CredentialExpiration *openapi_types.Date `json:"credentialExpiration"`
-	CredentialSubject    CredentialSubject   `json:"credentialSubject"`
-	Expiration           *time.Time          `json:"expiration"`
-	Id                   uuid.UUID           `json:"id"`
-	IssuedClaims         int                 `json:"issuedClaims"`
-	MaxIssuance          *int                `json:"maxIssuance"`
-	ProofTypes           []string            `json:"proofTypes"`
-	SchemaHash           string              `json:"schemaHash"`
-	SchemaType           string              `json:"schemaType"`
-	SchemaUrl            string              `json:"schemaUrl"`
-	Status               LinkStatus          `json:"status"`
+
+	// CredentialExpirationPolicy Validity period of the issued credentials when credentialExpiration is not set
+	CredentialExpirationPolicy *string           `json:"credentialExpirationPolicy"`
+	CredentialSubject          CredentialSubject `json:"credentialSubject"`
+
+	// DisplayStrings Localized name and description of the credentials shown by the wallets in the credential offers, by locale
+	// (a language tag like en or pt-BR).
+	DisplayStrings *DisplayStrings `json:"displayStrings,omitempty"`
+	Expiration     *time.Time      `json:"expiration"`
+	Id             uuid.UUID       `json:"id"`
+	IssuedClaims   int             `json:"issuedClaims"`
+
+	// Locale Locale of the credential offers when the holder doesn't ask for one with display strings
+	Locale      *string `json:"locale,omitempty"`
+	MaxIssuance *int    `json:"maxIssuance"`
+
+	// Prerequisites Credentials that the holder must prove to hold before it gets the credential, on top of the schema ones.
+	Prerequisites *[]Prerequisite `json:"prerequisites,omitempty"`
+	ProofTypes    []string        `json:"proofTypes"`
+	SchemaHash    string          `json:"schemaHash"`
+	SchemaType    string          `json:"schemaType"`
+	SchemaUrl     string          `json:"schemaUrl"`
+	Status        LinkStatus      `json:"status"`
 }
 
 // LinkStatus defines model for Link.Status.
 type LinkStatus string
 
+// LinkSession defines model for LinkSession.
+type LinkSession struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// CredentialID The credential issued to the wallet
+	CredentialID *uuid.UUID `json:"credentialID"`
+
+	// Error The last error that stopped the session, like a link that expired before the credential was issued
+	Error      *string           `json:"error"`
+	Id         uuid.UUID         `json:"id"`
+	LinkID     uuid.UUID         `json:"linkID"`
+	ModifiedAt time.Time         `json:"modifiedAt"`
+	Status     LinkSessionStatus `json:"status"`
+
+	// UserDID The wallet that authenticated with the qr code
+	UserDID *string `json:"userDID"`
+}
+
+// LinkSessionStatus defines model for LinkSessionStatus.
+type LinkSessionStatus string
+
 // LinkSimple defines model for LinkSimple.
 type LinkSimple struct {
 	Id         uuid.UUID `json:"id"`
@@ -197,6 +520,51 @@ type LinkSimple struct {
 	SchemaUrl  string    `json:"schemaUrl"`
 }
 
+// NotificationStatus defines model for NotificationStatus.
+type NotificationStatus struct {
+	Devices []DeviceNotificationStatus `json:"devices"`
+	Reason  *string                    `json:"reason,omitempty"`
+	Status  NotificationStatusStatus   `json:"status"`
+}
+
+// NotificationStatusStatus defines model for NotificationStatus.Status.
+type NotificationStatusStatus string
+
+// PostIssuanceHook Endpoint that receives the issued credentials as a POST with the credential in the body. The reference it
+// answers as {"reference": "..."} is kept in the credential external references under the hook name. A sync hook
+// is called before the issuance request returns, a queued hook is called by the notifications service. The
+// failures of the hooks don't affect the issuance.
+type PostIssuanceHook struct {
+	Mode PostIssuanceHookMode `json:"mode"`
+	Name string               `json:"name"`
+	Url  string               `json:"url"`
+}
+
+// PostIssuanceHookMode defines model for PostIssuanceHook.Mode.
+type PostIssuanceHookMode string
+
+// Prerequisite Credential that the holder must prove to hold, with a zero-knowledge proof in the authorization response of the
+// link, before it gets a credential. The proof is verified with the credentialAtomicQuerySigV2 circuit for the
+// sig proof type and the credentialAtomicQueryMTPV2 one for the mtp proof type.
+type Prerequisite struct {
+	// AllowedIssuers DIDs of the issuers of the credential, or * for any issuer
+	AllowedIssuers []string `json:"allowedIssuers"`
+
+	// Context JSON-LD context of the credential
+	Context string `json:"context"`
+
+	// CredentialSubject Optional query of one attribute of the credential with one of the $eq, $ne, $lt, $gt, $in or $nin operators
+	CredentialSubject *map[string]interface{} `json:"credentialSubject,omitempty"`
+	ProofType         PrerequisiteProofType   `json:"proofType"`
+	Type              string                  `json:"type"`
+}
+
+// PrerequisiteProofType defines model for Prerequisite.ProofType.
+type PrerequisiteProofType string
+
+// ProofType defines model for ProofType.
+type ProofType string
+
 // PublishIdentityStateResponse defines model for PublishIdentityStateResponse.
 type PublishIdentityStateResponse struct {
 	ClaimsTreeRoot     *string `json:"claimsTreeRoot,omitempty"`
@@ -206,6 +574,11 @@ type PublishIdentityStateResponse struct {
 	TxID               *string `json:"txID,omitempty"`
 }
 
+// PurgeSchemaCacheResponse defines model for PurgeSchemaCacheResponse.
+type PurgeSchemaCacheResponse struct {
+	Purged []string `json:"purged"`
+}
+
 // QrCodeBodyResponse defines model for QrCodeBodyResponse.
 type QrCodeBodyResponse struct {
 	Credentials []QrCodeCredentialResponse `json:"credentials"`
@@ -229,6 +602,23 @@ type QrCodeResponse struct {
 	Type string             `json:"type"`
 }
 
+// ReissueCredentialRequest defines model for ReissueCredentialRequest.
+type ReissueCredentialRequest struct {
+	CredentialSubject map[string]interface{} `json:"credentialSubject"`
+
+	// Expiration New expiration date. The replacement keeps the current one if not set.
+	Expiration *time.Time `json:"expiration,omitempty"`
+}
+
+// ReissueCredentialResponse defines model for ReissueCredentialResponse.
+type ReissueCredentialResponse struct {
+	Id           uuid.UUID          `json:"id"`
+	Notification NotificationStatus `json:"notification"`
+
+	// Replaces Id of the revoked credential
+	Replaces uuid.UUID `json:"replaces"`
+}
+
 // RevocationStatusResponse defines model for RevocationStatusResponse.
 type RevocationStatusResponse struct {
 	Issuer struct {
@@ -252,14 +642,156 @@ type RevokeCredentialResponse struct {
 	Message string `json:"message"`
 }
 
+// Role What the key can call on its issuer: admin every operation, issuer-operator everything but the administration
+// of the node, the identities, the api keys and the personal data of the subjects, and read-only the reads
+// of issuer-operator. A key without a role can only issue credentials.
+type Role string
+
 // Schema defines model for Schema.
 type Schema struct {
 	BigInt    string    `json:"bigInt"`
 	CreatedAt time.Time `json:"createdAt"`
-	Hash      string    `json:"hash"`
-	Id        string    `json:"id"`
-	Type      string    `json:"type"`
-	Url       string    `json:"url"`
+
+	// DeprecatedAt When the schema was deprecated. Not set when it is not deprecated.
+	DeprecatedAt *time.Time `json:"deprecatedAt,omitempty"`
+
+	// DefaultExpiration Validity period of a credential relative to its issuance date. An ISO 8601 duration like P1Y or P1M15D, or a
+	// number of hours, days, weeks or years like 90d. Months are added in UTC and clamped to the end of shorter
+	// months. At most 100 years.
+	DefaultExpiration *ExpirationPolicy `json:"defaultExpiration,omitempty"`
+
+	// DefaultProofTypes Proof types of the credentials of this schema when the creation request does not set them.
+	DefaultProofTypes *[]ProofType `json:"defaultProofTypes,omitempty"`
+	Description       *string      `json:"description,omitempty"`
+
+	// DisplayStrings Localized name and description of the credentials shown by the wallets in the credential offers, by locale
+	// (a language tag like en or pt-BR).
+	DisplayStrings *DisplayStrings `json:"displayStrings,omitempty"`
+
+	// Form Form to fill the credential subject, derived from the JSON schema. Only returned by the schema detail endpoint.
+	Form *SchemaForm `json:"form,omitempty"`
+	Hash string      `json:"hash"`
+	Id   string      `json:"id"`
+
+	// MaxActivePerSubject Maximum number of active credentials of this schema that a subject can hold. 1 makes the credential
+	// unique per subject. Not set when the schema has no limit.
+	MaxActivePerSubject *int `json:"maxActivePerSubject,omitempty"`
+
+	// PiiAttributes Credential subject attributes with personal data. Their values are masked in the credential listings
+	// unless they are requested with the PII credentials.
+	PiiAttributes *[]string `json:"piiAttributes,omitempty"`
+
+	// PostIssuanceHooks Endpoints that receive the credentials of this schema once they are issued.
+	PostIssuanceHooks *[]PostIssuanceHook `json:"postIssuanceHooks,omitempty"`
+
+	// Prerequisites Credentials that the holder must prove to hold before it gets a credential of this schema through a link.
+	Prerequisites *[]Prerequisite `json:"prerequisites,omitempty"`
+
+	// RevokeSuperseded Issuing a credential of this schema to a subject revokes the active credentials of this schema that the
+	// subject already holds.
+	RevokeSuperseded *bool   `json:"revokeSuperseded,omitempty"`
+	Title            *string `json:"title,omitempty"`
+	Type             string  `json:"type"`
+	Url              string  `json:"url"`
+
+	// Usage Credentials of the schema issued and revoked since it was first imported. Only returned by the schema detail
+	// endpoint.
+	Usage *SchemaUsage `json:"usage,omitempty"`
+
+	// Version Semantic version of the schemas of the registry. Not set for the imported schemas.
+	Version *string `json:"version,omitempty"`
+}
+
+// SchemaForm Form to fill the credential subject, derived from the JSON schema. Only returned by the schema detail endpoint.
+type SchemaForm struct {
+	Description string `json:"description"`
+
+	// Fields Credential subject attributes in the order of the JSON schema. The subject id is not included.
+	Fields []SchemaFormField `json:"fields"`
+	Title  string            `json:"title"`
+}
+
+// SchemaFormConstraints JSON schema validations of the field value
+type SchemaFormConstraints struct {
+	Enum      *[]interface{} `json:"enum,omitempty"`
+	Format    *string        `json:"format,omitempty"`
+	MaxLength *int           `json:"maxLength,omitempty"`
+	Maximum   *float64       `json:"maximum,omitempty"`
+	MinLength *int           `json:"minLength,omitempty"`
+	Minimum   *float64       `json:"minimum,omitempty"`
+	Pattern   *string        `json:"pattern,omitempty"`
+}
+
+// SchemaAttributeDefinition defines model for SchemaAttributeDefinition.
+type SchemaAttributeDefinition struct {
+	Description *string                       `json:"description,omitempty"`
+	Name        string                        `json:"name"`
+	Required    *bool                         `json:"required,omitempty"`
+	Title       *string                       `json:"title,omitempty"`
+	Type        SchemaAttributeDefinitionType `json:"type"`
+}
+
+// SchemaAttributeDefinitionType defines model for SchemaAttributeDefinition.Type.
+type SchemaAttributeDefinitionType string
+
+// SchemaFormField defines model for SchemaFormField.
+type SchemaFormField struct {
+	// Constraints JSON schema validations of the field value
+	Constraints SchemaFormConstraints `json:"constraints"`
+	Default     interface{}           `json:"default"`
+	Description string                `json:"description"`
+
+	// Fields Nested attributes of object fields
+	Fields []SchemaFormField    `json:"fields"`
+	Id     string               `json:"id"`
+	Input  SchemaFormFieldInput `json:"input"`
+
+	// Label The attribute title, or its id when the schema has no title
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+
+	// Type JSON schema type
+	Type string `json:"type"`
+}
+
+// SchemaFormFieldInput defines model for SchemaFormField.Input.
+type SchemaFormFieldInput string
+
+// SchemaStats defines model for SchemaStats.
+type SchemaStats struct {
+	// Days Days of the period with issuances or revocations, from the oldest.
+	Days   []SchemaUsageDay `json:"days"`
+	Issued int64            `json:"issued"`
+
+	// IssuedByLink Credentials of issued that were issued through a link.
+	IssuedByLink int64 `json:"issuedByLink"`
+
+	// RevocationRate Revoked credentials per issued credential in the period.
+	RevocationRate float64 `json:"revocationRate"`
+
+	// Revoked Credentials revoked in the period, that could have been issued before it.
+	Revoked int64 `json:"revoked"`
+}
+
+// SchemaUsage Credentials of the schema issued and revoked since it was first imported. Only returned by the schema detail
+// endpoint.
+type SchemaUsage struct {
+	Issued int64 `json:"issued"`
+
+	// IssuedByLink Credentials of issued that were issued through a link.
+	IssuedByLink int64 `json:"issuedByLink"`
+
+	// RevocationRate Revoked credentials per issued credential.
+	RevocationRate float64 `json:"revocationRate"`
+	Revoked        int64   `json:"revoked"`
+}
+
+// SchemaUsageDay defines model for SchemaUsageDay.
+type SchemaUsageDay struct {
+	Day          openapi_types.Date `json:"day"`
+	Issued       int64              `json:"issued"`
+	IssuedByLink int64              `json:"issuedByLink"`
+	Revoked      int64              `json:"revoked"`
 }
 
 // StateStatusResponse defines model for StateStatusResponse.
@@ -282,17 +814,122 @@ type StateTransactionStatus string
 // StateTransactionsResponse defines model for StateTransactionsResponse.
 type StateTransactionsResponse = []StateTransaction
 
+// SubjectData defines model for SubjectData.
+type SubjectData struct {
+	Connection  *GetConnectionResponse `json:"connection,omitempty"`
+	Credentials []Credential           `json:"credentials"`
+	Erasures    []SubjectErasure       `json:"erasures"`
+	Subject     string                 `json:"subject"`
+}
+
+// SubjectErasure defines model for SubjectErasure.
+type SubjectErasure struct {
+	// Connections number of connections deleted
+	Connections int64     `json:"connections"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	// Credentials number of credentials erased
+	Credentials int64     `json:"credentials"`
+	Id          uuid.UUID `json:"id"`
+
+	// SubjectHash sha256 of the subject DID
+	SubjectHash string `json:"subjectHash"`
+}
+
 // UUIDResponse defines model for UUIDResponse.
 type UUIDResponse struct {
 	Id string `json:"id"`
+
+	// Warnings Warnings of the lenient validation of the credential subject, one for each converted or dropped attribute.
+	Warnings *[]string `json:"warnings,omitempty"`
+}
+
+// UpdateBrandingRequest defines model for UpdateBrandingRequest.
+type UpdateBrandingRequest struct {
+	DisplayName  *string `json:"displayName"`
+	Logo         *string `json:"logo"`
+	PrimaryColor *string `json:"primaryColor"`
+	TextColor    *string `json:"textColor"`
+}
+
+// UpdateSchemaRequest defines model for UpdateSchemaRequest.
+type UpdateSchemaRequest struct {
+	// DefaultExpiration Validity period of a credential relative to its issuance date. An ISO 8601 duration like P1Y or P1M15D, or a
+	// number of hours, days, weeks or years like 90d. Months are added in UTC and clamped to the end of shorter
+	// months. At most 100 years.
+	DefaultExpiration *ExpirationPolicy `json:"defaultExpiration,omitempty"`
+	DefaultProofTypes *[]ProofType      `json:"defaultProofTypes,omitempty"`
+
+	// Deprecated Deprecate the schema, or undeprecate it with false.
+	Deprecated *bool `json:"deprecated,omitempty"`
+
+	// Description Description of the schema. An empty description removes it.
+	Description *string `json:"description,omitempty"`
+
+	// DisplayStrings Localized name and description of the credentials shown by the wallets in the credential offers, by locale
+	// (a language tag like en or pt-BR).
+	DisplayStrings *DisplayStrings `json:"displayStrings,omitempty"`
+
+	// MaxActivePerSubject Maximum number of active credentials of this schema per subject. 0 removes the limit.
+	MaxActivePerSubject *int `json:"maxActivePerSubject,omitempty"`
+
+	// PiiAttributes Credential subject attributes with personal data. An empty list removes the tags.
+	PiiAttributes *[]string `json:"piiAttributes,omitempty"`
+
+	// PostIssuanceHooks Endpoints that receive the credentials of this schema once they are issued. An empty list removes them.
+	PostIssuanceHooks *[]PostIssuanceHook `json:"postIssuanceHooks,omitempty"`
+
+	// Prerequisites Credentials that the holder must prove to hold before it gets a credential of this schema through a link.
+	// An empty list removes them.
+	Prerequisites *[]Prerequisite `json:"prerequisites,omitempty"`
+
+	// RevokeSuperseded Revoke the active credentials of this schema of a subject when a new one is issued to it.
+	RevokeSuperseded *bool `json:"revokeSuperseded,omitempty"`
+
+	// Title Title of the schema. An empty title removes it.
+	Title *string `json:"title,omitempty"`
+}
+
+// ValidationMode How the credential subject is checked against the schema. strict rejects the attributes that are not in
+// the schema and the values that don't have its types. lenient converts the values given as strings and drops the
+// attributes that are not in the schema, with a warning for each one. The default is strict.
+type ValidationMode string
+
+// Verification Result of the verification of the proofs sent by a wallet.
+type Verification struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Error Why the verification failed. Missing when the proofs are verified.
+	Error *string   `json:"error,omitempty"`
+	Id    uuid.UUID `json:"id"`
+
+	// ProofHashes Hex sha256 of the proofs answered to each query.
+	ProofHashes []string `json:"proofHashes"`
+
+	// Queries Proofs asked to the wallet, the scope of the authorization request.
+	Queries   []map[string]interface{} `json:"queries"`
+	SessionId uuid.UUID                `json:"sessionId"`
+
+	// UserDID Sender of the proofs. Missing when the message can't be verified.
+	UserDID  *string `json:"userDID,omitempty"`
+	Verified bool    `json:"verified"`
 }
 
+// AcceptLanguage defines model for acceptLanguage.
+type AcceptLanguage = string
+
+// AsOf defines model for asOf.
+type AsOf = time.Time
+
 // Id defines model for id.
 type Id = uuid.UUID
 
 // LinkID defines model for linkID.
 type LinkID = uuid.UUID
 
+// PathDid defines model for pathDid.
+type PathDid = string
+
 // PathNonce defines model for pathNonce.
 type PathNonce = int64
 
@@ -305,12 +942,21 @@ type N400 = GenericErrorMessage
 // N401 defines model for 401.
 type N401 = GenericErrorMessage
 
+// N403 defines model for 403.
+type N403 = GenericErrorMessage
+
 // N404 defines model for 404.
 type N404 = GenericErrorMessage
 
+// N409 defines model for 409.
+type N409 = GenericErrorMessage
+
 // N422 defines model for 422.
 type N422 = GenericErrorMessage
 
+// N429 defines model for 429.
+type N429 = GenericErrorMessage
+
 // N500 defines model for 500.
 type N500 = GenericErrorMessage
 
@@ -356,6 +1002,15 @@ type GetCredentialsParams struct {
 
 	// Query Query string to do full text search
 	Query *string `form:"query,omitempty" json:"query,omitempty"`
+
+	// AsOf Returns the data as it was at the given time, e.g: 2023-04-21T10:00:00Z
+	AsOf *AsOf `form:"asOf,omitempty" json:"asOf,omitempty"`
+
+	// Limit Maximum number of credentials returned, the newest issued first, 100 without it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// PageToken Token of the page to return, from the X-Next-Page-Token header of the previous page.
+	PageToken *string `form:"pageToken,omitempty" json:"pageToken,omitempty"`
 }
 
 // GetCredentialsParamsStatus defines parameters for GetCredentials.
@@ -398,6 +1053,33 @@ type AcivateLinkJSONBody struct {
 type GetLinkQRCodeParams struct {
 	// SessionID Session ID e.g: 89d298fa-15a6-4a1d-ab13-d1069467eedd
 	SessionID SessionID `form:"sessionID" json:"sessionID"`
+
+	// AcceptLanguage Locales of the holder, e.g: pt-BR, pt;q=0.9, en;q=0.8
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
+// GetLinkSessionsParams defines parameters for GetLinkSessions.
+type GetLinkSessionsParams struct {
+	// Status Only the sessions in the status
+	Status *LinkSessionStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// UserDID Only the sessions of the wallet
+	UserDID *string `form:"userDID,omitempty" json:"userDID,omitempty"`
+
+	// Limit Maximum number of sessions
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetCredentialParams defines parameters for GetCredential.
+type GetCredentialParams struct {
+	// AsOf Returns the data as it was at the given time, e.g: 2023-04-21T10:00:00Z
+	AsOf *AsOf `form:"asOf,omitempty" json:"asOf,omitempty"`
+}
+
+// GetCredentialQrCodeParams defines parameters for GetCredentialQrCode.
+type GetCredentialQrCodeParams struct {
+	// AcceptLanguage Locales of the holder, e.g: pt-BR, pt;q=0.9, en;q=0.8
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
 }
 
 // GetSchemasParams defines parameters for GetSchemas.
@@ -406,12 +1088,60 @@ type GetSchemasParams struct {
 	Query *string `form:"query,omitempty" json:"query,omitempty"`
 }
 
+// PurgeSchemaCacheParams defines parameters for PurgeSchemaCache.
+type PurgeSchemaCacheParams struct {
+	// Url Url of the json schema or JSON-LD context to purge.
+	Url *string `form:"url,omitempty" json:"url,omitempty"`
+}
+
+// GetSchemaStatsParams defines parameters for GetSchemaStats.
+type GetSchemaStatsParams struct {
+	// From First day of the period, included. The period starts with the first issuance when not set.
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day of the period, included. The period ends today when not set.
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetStateTransactionsParams defines parameters for GetStateTransactions.
+type GetStateTransactionsParams struct {
+	// AsOf Returns the data as it was at the given time, e.g: 2023-04-21T10:00:00Z
+	AsOf *AsOf `form:"asOf,omitempty" json:"asOf,omitempty"`
+}
+
+// GetVerificationsParams defines parameters for GetVerifications.
+type GetVerificationsParams struct {
+	// From Verifications done at or after this time.
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Verifications done before this time.
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Did Verifications of the proofs sent by this DID.
+	Did *string `form:"did,omitempty" json:"did,omitempty"`
+
+	// Verified Only the verified proofs with true, only the rejected ones with false.
+	Verified *bool `form:"verified,omitempty" json:"verified,omitempty"`
+
+	// Limit Maximum number of verifications returned.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // AgentTextRequestBody defines body for Agent for text/plain ContentType.
 type AgentTextRequestBody = AgentTextBody
 
+// CreateAPIKeyJSONRequestBody defines body for CreateAPIKey for application/json ContentType.
+type CreateAPIKeyJSONRequestBody = CreateAPIKeyRequest
+
 // AuthCallbackTextRequestBody defines body for AuthCallback for text/plain ContentType.
 type AuthCallbackTextRequestBody = AuthCallbackTextBody
 
+// UpdateBrandingJSONRequestBody defines body for UpdateBranding for application/json ContentType.
+type UpdateBrandingJSONRequestBody = UpdateBrandingRequest
+
+// CreateConnectionCredentialJSONRequestBody defines body for CreateConnectionCredential for application/json ContentType.
+type CreateConnectionCredentialJSONRequestBody = CreateCredentialRequest
+
 // CreateCredentialJSONRequestBody defines body for CreateCredential for application/json ContentType.
 type CreateCredentialJSONRequestBody = CreateCredentialRequest
 
@@ -424,9 +1154,21 @@ type CreateLinkQrCodeCallbackTextRequestBody = CreateLinkQrCodeCallbackTextBody
 // AcivateLinkJSONRequestBody defines body for AcivateLink for application/json ContentType.
 type AcivateLinkJSONRequestBody AcivateLinkJSONBody
 
+// ReissueCredentialJSONRequestBody defines body for ReissueCredential for application/json ContentType.
+type ReissueCredentialJSONRequestBody = ReissueCredentialRequest
+
 // ImportSchemaJSONRequestBody defines body for ImportSchema for application/json ContentType.
 type ImportSchemaJSONRequestBody = ImportSchemaRequest
 
+// BuildSchemaJSONRequestBody defines body for BuildSchema for application/json ContentType.
+type BuildSchemaJSONRequestBody = BuildSchemaRequest
+
+// CreateSchemaJSONRequestBody defines body for CreateSchema for application/json ContentType.
+type CreateSchemaJSONRequestBody = CreateSchemaRequest
+
+// UpdateSchemaJSONRequestBody defines body for UpdateSchema for application/json ContentType.
+type UpdateSchemaJSONRequestBody = UpdateSchemaRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Get the documentation
@@ -444,12 +1186,27 @@ type ServerInterface interface {
 	// Agent
 	// (POST /v1/agent)
 	Agent(w http.ResponseWriter, r *http.Request)
+	// Get API Keys
+	// (GET /v1/api-keys)
+	GetAPIKeys(w http.ResponseWriter, r *http.Request)
+	// Create API Key
+	// (POST /v1/api-keys)
+	CreateAPIKey(w http.ResponseWriter, r *http.Request)
+	// Delete API Key
+	// (DELETE /v1/api-keys/{id})
+	DeleteAPIKey(w http.ResponseWriter, r *http.Request, id Id)
 	// Authentication Callback
 	// (POST /v1/authentication/callback)
 	AuthCallback(w http.ResponseWriter, r *http.Request, params AuthCallbackParams)
 	// Get Connection QRCode
 	// (GET /v1/authentication/qrcode)
 	AuthQRCode(w http.ResponseWriter, r *http.Request)
+	// Get Branding
+	// (GET /v1/branding)
+	GetBranding(w http.ResponseWriter, r *http.Request)
+	// Update Branding
+	// (PUT /v1/branding)
+	UpdateBranding(w http.ResponseWriter, r *http.Request)
 	// Get Connections
 	// (GET /v1/connections)
 	GetConnections(w http.ResponseWriter, r *http.Request, params GetConnectionsParams)
@@ -462,6 +1219,12 @@ type ServerInterface interface {
 	// Delete Connection Credentials
 	// (DELETE /v1/connections/{id}/credentials)
 	DeleteConnectionCredentials(w http.ResponseWriter, r *http.Request, id Id)
+	// Get Connection Credentials
+	// (GET /v1/connections/{id}/credentials)
+	GetConnectionCredentials(w http.ResponseWriter, r *http.Request, id Id)
+	// Create Connection Credential
+	// (POST /v1/connections/{id}/credentials)
+	CreateConnectionCredential(w http.ResponseWriter, r *http.Request, id Id)
 	// Revoke Connection Credentials
 	// (POST /v1/connections/{id}/credentials/revoke)
 	RevokeConnectionCredentials(w http.ResponseWriter, r *http.Request, id Id)
@@ -495,6 +1258,12 @@ type ServerInterface interface {
 	// Create Authentication Link QRCode
 	// (POST /v1/credentials/links/{id}/qrcode)
 	CreateLinkQrCode(w http.ResponseWriter, r *http.Request, id Id)
+	// Get Link Sessions
+	// (GET /v1/credentials/links/{id}/sessions)
+	GetLinkSessions(w http.ResponseWriter, r *http.Request, id Id, params GetLinkSessionsParams)
+	// Get Link Session
+	// (GET /v1/credentials/links/{id}/sessions/{sessionID})
+	GetLinkSession(w http.ResponseWriter, r *http.Request, id Id, sessionID uuid.UUID)
 	// Get Revocation Status
 	// (GET /v1/credentials/revocation/status/{nonce})
 	GetRevocationStatus(w http.ResponseWriter, r *http.Request, nonce PathNonce)
@@ -506,19 +1275,52 @@ type ServerInterface interface {
 	DeleteCredential(w http.ResponseWriter, r *http.Request, id Id)
 	// Get Credential
 	// (GET /v1/credentials/{id})
-	GetCredential(w http.ResponseWriter, r *http.Request, id Id)
+	GetCredential(w http.ResponseWriter, r *http.Request, id Id, params GetCredentialParams)
 	// Get Credential QR code
 	// (GET /v1/credentials/{id}/qrcode)
-	GetCredentialQrCode(w http.ResponseWriter, r *http.Request, id Id)
+	GetCredentialQrCode(w http.ResponseWriter, r *http.Request, id Id, params GetCredentialQrCodeParams)
+	// Reissue Credential
+	// (POST /v1/credentials/{id}/reissue)
+	ReissueCredential(w http.ResponseWriter, r *http.Request, id Id)
+	// Get Hook Deliveries
+	// (GET /v1/hooks/deliveries)
+	GetHookDeliveries(w http.ResponseWriter, r *http.Request)
 	// Get Schemas
 	// (GET /v1/schemas)
 	GetSchemas(w http.ResponseWriter, r *http.Request, params GetSchemasParams)
 	// Import JSON schema
 	// (POST /v1/schemas)
 	ImportSchema(w http.ResponseWriter, r *http.Request)
+	// Purge Schema Cache
+	// (DELETE /v1/schemas/cache)
+	PurgeSchemaCache(w http.ResponseWriter, r *http.Request, params PurgeSchemaCacheParams)
+	// Build Schema
+	// (POST /v1/schemas/builder)
+	BuildSchema(w http.ResponseWriter, r *http.Request)
+	// Create Schema Version
+	// (POST /v1/schemas/registry)
+	CreateSchema(w http.ResponseWriter, r *http.Request)
 	// Get Schema
 	// (GET /v1/schemas/{id})
 	GetSchema(w http.ResponseWriter, r *http.Request, id Id)
+	// Update Schema
+	// (PATCH /v1/schemas/{id})
+	UpdateSchema(w http.ResponseWriter, r *http.Request, id Id)
+	// Get Schema JSON-LD Context
+	// (GET /v1/schemas/{id}/context)
+	GetSchemaContext(w http.ResponseWriter, r *http.Request, id Id)
+	// Test Post-Issuance Hook
+	// (POST /v1/schemas/{id}/hooks/{name}/test)
+	TestPostIssuanceHook(w http.ResponseWriter, r *http.Request, id Id, name string)
+	// Get Schema JSON Schema
+	// (GET /v1/schemas/{id}/jsonschema)
+	GetSchemaJSON(w http.ResponseWriter, r *http.Request, id Id)
+	// Get Schema Stats
+	// (GET /v1/schemas/{id}/stats)
+	GetSchemaStats(w http.ResponseWriter, r *http.Request, id Id, params GetSchemaStatsParams)
+	// Get Schema Versions
+	// (GET /v1/schemas/{id}/versions)
+	GetSchemaVersions(w http.ResponseWriter, r *http.Request, id Id)
 	// Publish Identity State
 	// (POST /v1/state/publish)
 	PublishState(w http.ResponseWriter, r *http.Request)
@@ -530,7 +1332,16 @@ type ServerInterface interface {
 	GetStateStatus(w http.ResponseWriter, r *http.Request)
 	// Get Identity State Transactions
 	// (GET /v1/state/transactions)
-	GetStateTransactions(w http.ResponseWriter, r *http.Request)
+	GetStateTransactions(w http.ResponseWriter, r *http.Request, params GetStateTransactionsParams)
+	// Erase Subject Data
+	// (DELETE /v1/subjects/{did})
+	EraseSubjectData(w http.ResponseWriter, r *http.Request, did PathDid)
+	// Get Subject Data
+	// (GET /v1/subjects/{did})
+	GetSubjectData(w http.ResponseWriter, r *http.Request, did PathDid)
+	// Get Verifications
+	// (GET /v1/verifications)
+	GetVerifications(w http.ResponseWriter, r *http.Request, params GetVerificationsParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -617,32 +1428,14 @@ func (siw *ServerInterfaceWrapper) Agent(w http.ResponseWriter, r *http.Request)
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// AuthCallback operation middleware
-func (siw *ServerInterfaceWrapper) AuthCallback(w http.ResponseWriter, r *http.Request) {
+// GetAPIKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params AuthCallbackParams
-
-	// ------------- Required query parameter "sessionID" -------------
-
-	if paramValue := r.URL.Query().Get("sessionID"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sessionID"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "sessionID", r.URL.Query(), &params.SessionID)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
-		return
-	}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AuthCallback(w, r, params)
+		siw.Handler.GetAPIKeys(w, r)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -652,7 +1445,87 @@ func (siw *ServerInterfaceWrapper) AuthCallback(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// AuthQRCode operation middleware
+// CreateAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAPIKey(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAPIKey(w, r, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AuthCallback operation middleware
+func (siw *ServerInterfaceWrapper) AuthCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AuthCallbackParams
+
+	// ------------- Required query parameter "sessionID" -------------
+
+	if paramValue := r.URL.Query().Get("sessionID"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sessionID"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "sessionID", r.URL.Query(), &params.SessionID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AuthCallback(w, r, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AuthQRCode operation middleware
 func (siw *ServerInterfaceWrapper) AuthQRCode(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -667,6 +1540,40 @@ func (siw *ServerInterfaceWrapper) AuthQRCode(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetBranding operation middleware
+func (siw *ServerInterfaceWrapper) GetBranding(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBranding(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateBranding operation middleware
+func (siw *ServerInterfaceWrapper) UpdateBranding(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateBranding(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetConnections operation middleware
 func (siw *ServerInterfaceWrapper) GetConnections(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -808,6 +1715,62 @@ func (siw *ServerInterfaceWrapper) DeleteConnectionCredentials(w http.ResponseWr
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetConnectionCredentials operation middleware
+func (siw *ServerInterfaceWrapper) GetConnectionCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConnectionCredentials(w, r, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateConnectionCredential operation middleware
+func (siw *ServerInterfaceWrapper) CreateConnectionCredential(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateConnectionCredential(w, r, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // RevokeConnectionCredentials operation middleware
 func (siw *ServerInterfaceWrapper) RevokeConnectionCredentials(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -871,6 +1834,30 @@ func (siw *ServerInterfaceWrapper) GetCredentials(w http.ResponseWriter, r *http
 		return
 	}
 
+	// ------------- Optional query parameter "asOf" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "asOf", r.URL.Query(), &params.AsOf)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "asOf", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "pageToken" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageToken", r.URL.Query(), &params.PageToken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageToken", Err: err})
+		return
+	}
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetCredentials(w, r, params)
 	})
@@ -1121,6 +2108,27 @@ func (siw *ServerInterfaceWrapper) GetLinkQRCode(w http.ResponseWriter, r *http.
 		return
 	}
 
+	headers := r.Header
+
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage AcceptLanguage
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Accept-Language", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Accept-Language", Err: err})
+			return
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+
+	}
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetLinkQRCode(w, r, id, params)
 	})
@@ -1158,6 +2166,98 @@ func (siw *ServerInterfaceWrapper) CreateLinkQrCode(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetLinkSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetLinkSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLinkSessionsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "userDID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "userDID", r.URL.Query(), &params.UserDID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userDID", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLinkSessions(w, r, id, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetLinkSession operation middleware
+func (siw *ServerInterfaceWrapper) GetLinkSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "sessionID" -------------
+	var sessionID uuid.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, chi.URLParam(r, "sessionID"), &sessionID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLinkSession(w, r, id, sessionID)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetRevocationStatus operation middleware
 func (siw *ServerInterfaceWrapper) GetRevocationStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -1257,11 +2357,22 @@ func (siw *ServerInterfaceWrapper) GetCredential(w http.ResponseWriter, r *http.
 
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCredential(w, r, id)
-	})
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCredentialParams
 
-	for _, middleware := range siw.HandlerMiddlewares {
+	// ------------- Optional query parameter "asOf" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "asOf", r.URL.Query(), &params.AsOf)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "asOf", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCredential(w, r, id, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
 		handler = middleware(handler)
 	}
 
@@ -1283,8 +2394,77 @@ func (siw *ServerInterfaceWrapper) GetCredentialQrCode(w http.ResponseWriter, r
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCredentialQrCodeParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage AcceptLanguage
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Accept-Language", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Accept-Language", Err: err})
+			return
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCredentialQrCode(w, r, id, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReissueCredential operation middleware
+func (siw *ServerInterfaceWrapper) ReissueCredential(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReissueCredential(w, r, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetHookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetHookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCredentialQrCode(w, r, id)
+		siw.Handler.GetHookDeliveries(w, r)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1341,25 +2521,27 @@ func (siw *ServerInterfaceWrapper) ImportSchema(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetSchema operation middleware
-func (siw *ServerInterfaceWrapper) GetSchema(w http.ResponseWriter, r *http.Request) {
+// PurgeSchemaCache operation middleware
+func (siw *ServerInterfaceWrapper) PurgeSchemaCache(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id Id
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PurgeSchemaCacheParams
+
+	// ------------- Optional query parameter "url" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "url", r.URL.Query(), &params.Url)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "url", Err: err})
 		return
 	}
 
-	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
-
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSchema(w, r, id)
+		siw.Handler.PurgeSchemaCache(w, r, params)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1369,14 +2551,14 @@ func (siw *ServerInterfaceWrapper) GetSchema(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PublishState operation middleware
-func (siw *ServerInterfaceWrapper) PublishState(w http.ResponseWriter, r *http.Request) {
+// BuildSchema operation middleware
+func (siw *ServerInterfaceWrapper) BuildSchema(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PublishState(w, r)
+		siw.Handler.BuildSchema(w, r)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1386,14 +2568,14 @@ func (siw *ServerInterfaceWrapper) PublishState(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// RetryPublishState operation middleware
-func (siw *ServerInterfaceWrapper) RetryPublishState(w http.ResponseWriter, r *http.Request) {
+// CreateSchema operation middleware
+func (siw *ServerInterfaceWrapper) CreateSchema(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RetryPublishState(w, r)
+		siw.Handler.CreateSchema(w, r)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1403,14 +2585,25 @@ func (siw *ServerInterfaceWrapper) RetryPublishState(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetStateStatus operation middleware
-func (siw *ServerInterfaceWrapper) GetStateStatus(w http.ResponseWriter, r *http.Request) {
+// GetSchema operation middleware
+func (siw *ServerInterfaceWrapper) GetSchema(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStateStatus(w, r)
+		siw.Handler.GetSchema(w, r, id)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1420,14 +2613,25 @@ func (siw *ServerInterfaceWrapper) GetStateStatus(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetStateTransactions operation middleware
-func (siw *ServerInterfaceWrapper) GetStateTransactions(w http.ResponseWriter, r *http.Request) {
+// UpdateSchema operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSchema(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStateTransactions(w, r)
+		siw.Handler.UpdateSchema(w, r, id)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1437,1412 +2641,2882 @@ func (siw *ServerInterfaceWrapper) GetStateTransactions(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetSchemaContext operation middleware
+func (siw *ServerInterfaceWrapper) GetSchemaContext(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "id" -------------
+	var id Id
 
-type UnmarshallingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-func (e *UnmarshallingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshalling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSchemaContext(w, r, id)
+	})
 
-func (e *UnmarshallingParamError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type RequiredParamError struct {
-	ParamName string
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+// TestPostIssuanceHook operation middleware
+func (siw *ServerInterfaceWrapper) TestPostIssuanceHook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	var err error
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	// ------------- Path parameter "id" -------------
+	var id Id
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Path parameter "name" -------------
+	var name string
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TestPostIssuanceHook(w, r, id, name)
+	})
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+// GetSchemaJSON operation middleware
+func (siw *ServerInterfaceWrapper) GetSchemaJSON(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+	var err error
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSchemaJSON(w, r, id)
 	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// GetSchemaStats operation middleware
+func (siw *ServerInterfaceWrapper) GetSchemaStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/", wrapper.GetDocumentation)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/favicon.ico", wrapper.GetFavicon)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/static/docs/api_ui/api.yaml", wrapper.GetYaml)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/status", wrapper.Health)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/agent", wrapper.Agent)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/authentication/callback", wrapper.AuthCallback)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/authentication/qrcode", wrapper.AuthQRCode)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/connections", wrapper.GetConnections)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/connections/{id}", wrapper.DeleteConnection)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/connections/{id}", wrapper.GetConnection)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/connections/{id}/credentials", wrapper.DeleteConnectionCredentials)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/connections/{id}/credentials/revoke", wrapper.RevokeConnectionCredentials)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/credentials", wrapper.GetCredentials)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/credentials", wrapper.CreateCredential)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/credentials/links", wrapper.GetLinks)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/credentials/links", wrapper.CreateLink)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/credentials/links/callback", wrapper.CreateLinkQrCodeCallback)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/credentials/links/{id}", wrapper.DeleteLink)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/credentials/links/{id}", wrapper.GetLink)
-	})
-	r.Group(func(r chi.Router) {
-		r.Patch(options.BaseURL+"/v1/credentials/links/{id}", wrapper.AcivateLink)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/credentials/links/{id}/qrcode", wrapper.GetLinkQRCode)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/credentials/links/{id}/qrcode", wrapper.CreateLinkQrCode)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/credentials/revocation/status/{nonce}", wrapper.GetRevocationStatus)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/credentials/revoke/{nonce}", wrapper.RevokeCredential)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/credentials/{id}", wrapper.DeleteCredential)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/credentials/{id}", wrapper.GetCredential)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/credentials/{id}/qrcode", wrapper.GetCredentialQrCode)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/schemas", wrapper.GetSchemas)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/schemas", wrapper.ImportSchema)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/schemas/{id}", wrapper.GetSchema)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/state/publish", wrapper.PublishState)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/state/retry", wrapper.RetryPublishState)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/state/status", wrapper.GetStateStatus)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/state/transactions", wrapper.GetStateTransactions)
-	})
-
-	return r
-}
-
-type N400JSONResponse GenericErrorMessage
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type N401JSONResponse GenericErrorMessage
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSchemaStatsParams
 
-type N404JSONResponse GenericErrorMessage
+	// ------------- Optional query parameter "from" -------------
 
-type N422JSONResponse GenericErrorMessage
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
 
-type N500JSONResponse GenericErrorMessage
+	// ------------- Optional query parameter "to" -------------
 
-type GetDocumentationRequestObject struct {
-}
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
 
-type GetDocumentationResponseObject interface {
-	VisitGetDocumentationResponse(w http.ResponseWriter) error
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSchemaStats(w, r, id, params)
+	})
 
-type GetDocumentation200Response struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (response GetDocumentation200Response) VisitGetDocumentationResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetFaviconRequestObject struct {
-}
+// GetSchemaVersions operation middleware
+func (siw *ServerInterfaceWrapper) GetSchemaVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type GetFaviconResponseObject interface {
-	VisitGetFaviconResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type GetFavicon200Response struct {
-}
+	// ------------- Path parameter "id" -------------
+	var id Id
 
-func (response GetFavicon200Response) VisitGetFaviconResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-type GetYamlRequestObject struct {
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type GetYamlResponseObject interface {
-	VisitGetYamlResponse(w http.ResponseWriter) error
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSchemaVersions(w, r, id)
+	})
 
-type GetYaml200Response struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (response GetYaml200Response) VisitGetYamlResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type HealthRequestObject struct {
-}
+// PublishState operation middleware
+func (siw *ServerInterfaceWrapper) PublishState(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type HealthResponseObject interface {
-	VisitHealthResponse(w http.ResponseWriter) error
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type Health200JSONResponse Health
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PublishState(w, r)
+	})
 
-func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type Health500JSONResponse struct{ N500JSONResponse }
+// RetryPublishState operation middleware
+func (siw *ServerInterfaceWrapper) RetryPublishState(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RetryPublishState(w, r)
+	})
 
-type AgentRequestObject struct {
-	Body *AgentTextRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type AgentResponseObject interface {
-	VisitAgentResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type Agent200JSONResponse AgentResponse
-
-func (response Agent200JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// GetStateStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetStateStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type Agent400JSONResponse struct{ N400JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStateStatus(w, r)
+	})
 
-func (response Agent400JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type Agent500JSONResponse struct{ N500JSONResponse }
+// GetStateTransactions operation middleware
+func (siw *ServerInterfaceWrapper) GetStateTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response Agent500JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type AuthCallbackRequestObject struct {
-	Params AuthCallbackParams
-	Body   *AuthCallbackTextRequestBody
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStateTransactionsParams
 
-type AuthCallbackResponseObject interface {
-	VisitAuthCallbackResponse(w http.ResponseWriter) error
-}
+	// ------------- Optional query parameter "asOf" -------------
 
-type AuthCallback200Response struct {
-}
-
-func (response AuthCallback200Response) VisitAuthCallbackResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
+	err = runtime.BindQueryParameter("form", true, false, "asOf", r.URL.Query(), &params.AsOf)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "asOf", Err: err})
+		return
+	}
 
-type AuthCallback400JSONResponse struct{ N400JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStateTransactions(w, r, params)
+	})
 
-func (response AuthCallback400JSONResponse) VisitAuthCallbackResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type AuthCallback500JSONResponse struct{ N500JSONResponse }
+// EraseSubjectData operation middleware
+func (siw *ServerInterfaceWrapper) EraseSubjectData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response AuthCallback500JSONResponse) VisitAuthCallbackResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "did" -------------
+	var did PathDid
 
-type AuthQRCodeRequestObject struct {
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, chi.URLParam(r, "did"), &did)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "did", Err: err})
+		return
+	}
 
-type AuthQRCodeResponseObject interface {
-	VisitAuthQRCodeResponse(w http.ResponseWriter) error
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type AuthQRCode200JSONResponse AuthenticationQrCodeResponse
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EraseSubjectData(w, r, did)
+	})
 
-func (response AuthQRCode200JSONResponse) VisitAuthQRCodeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type AuthQRCode500JSONResponse struct{ N500JSONResponse }
+// GetSubjectData operation middleware
+func (siw *ServerInterfaceWrapper) GetSubjectData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response AuthQRCode500JSONResponse) VisitAuthQRCodeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "did" -------------
+	var did PathDid
 
-type GetConnectionsRequestObject struct {
-	Params GetConnectionsParams
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, chi.URLParam(r, "did"), &did)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "did", Err: err})
+		return
+	}
 
-type GetConnectionsResponseObject interface {
-	VisitGetConnectionsResponse(w http.ResponseWriter) error
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type GetConnections200JSONResponse GetConnectionsResponse
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSubjectData(w, r, did)
+	})
 
-func (response GetConnections200JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetConnections400JSONResponse struct{ N400JSONResponse }
+// GetVerifications operation middleware
+func (siw *ServerInterfaceWrapper) GetVerifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response GetConnections400JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type GetConnections500JSONResponse struct{ N500JSONResponse }
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetVerificationsParams
 
-func (response GetConnections500JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	// ------------- Optional query parameter "from" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
 
-type DeleteConnectionRequestObject struct {
-	Id     Id `json:"id"`
-	Params DeleteConnectionParams
-}
+	// ------------- Optional query parameter "to" -------------
 
-type DeleteConnectionResponseObject interface {
-	VisitDeleteConnectionResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
 
-type DeleteConnection200JSONResponse GenericMessage
+	// ------------- Optional query parameter "did" -------------
 
-func (response DeleteConnection200JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindQueryParameter("form", true, false, "did", r.URL.Query(), &params.Did)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "did", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "verified" -------------
 
-type DeleteConnection400JSONResponse struct{ N400JSONResponse }
+	err = runtime.BindQueryParameter("form", true, false, "verified", r.URL.Query(), &params.Verified)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "verified", Err: err})
+		return
+	}
 
-func (response DeleteConnection400JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Optional query parameter "limit" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-type DeleteConnection500JSONResponse struct{ N500JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVerifications(w, r, params)
+	})
 
-func (response DeleteConnection500JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetConnectionRequestObject struct {
-	Id Id `json:"id"`
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
 }
 
-type GetConnectionResponseObject interface {
-	VisitGetConnectionResponse(w http.ResponseWriter) error
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
 }
 
-type GetConnection200JSONResponse GetConnectionResponse
-
-func (response GetConnection200JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
 }
 
-type GetConnection400JSONResponse struct{ N400JSONResponse }
-
-func (response GetConnection400JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
+type UnmarshallingParamError struct {
+	ParamName string
+	Err       error
 }
 
-type GetConnection500JSONResponse struct{ N500JSONResponse }
-
-func (response GetConnection500JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *UnmarshallingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshalling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
 }
 
-type DeleteConnectionCredentialsRequestObject struct {
-	Id Id `json:"id"`
+func (e *UnmarshallingParamError) Unwrap() error {
+	return e.Err
 }
 
-type DeleteConnectionCredentialsResponseObject interface {
-	VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error
+type RequiredParamError struct {
+	ParamName string
 }
 
-type DeleteConnectionCredentials200JSONResponse GenericMessage
-
-func (response DeleteConnectionCredentials200JSONResponse) VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
 }
 
-type DeleteConnectionCredentials500JSONResponse struct{ N500JSONResponse }
-
-func (response DeleteConnectionCredentials500JSONResponse) VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
-
-	return json.NewEncoder(w).Encode(response)
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
 }
 
-type RevokeConnectionCredentialsRequestObject struct {
-	Id Id `json:"id"`
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
 }
 
-type RevokeConnectionCredentialsResponseObject interface {
-	VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
 }
 
-type RevokeConnectionCredentials202JSONResponse GenericMessage
-
-func (response RevokeConnectionCredentials202JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
-
-	return json.NewEncoder(w).Encode(response)
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
 }
 
-type RevokeConnectionCredentials400JSONResponse struct{ N400JSONResponse }
-
-func (response RevokeConnectionCredentials400JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
 }
 
-type RevokeConnectionCredentials500JSONResponse struct{ N500JSONResponse }
-
-func (response RevokeConnectionCredentials500JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
 }
 
-type GetCredentialsRequestObject struct {
-	Params GetCredentialsParams
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
 }
 
-type GetCredentialsResponseObject interface {
-	VisitGetCredentialsResponse(w http.ResponseWriter) error
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
 }
 
-type GetCredentials200JSONResponse []Credential
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
 
-func (response GetCredentials200JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
 
-	return json.NewEncoder(w).Encode(response)
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
 }
 
-type GetCredentials400JSONResponse struct{ N400JSONResponse }
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
 
-func (response GetCredentials400JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/", wrapper.GetDocumentation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/favicon.ico", wrapper.GetFavicon)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/static/docs/api_ui/api.yaml", wrapper.GetYaml)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/status", wrapper.Health)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/agent", wrapper.Agent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/api-keys", wrapper.GetAPIKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/api-keys", wrapper.CreateAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/api-keys/{id}", wrapper.DeleteAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/authentication/callback", wrapper.AuthCallback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/authentication/qrcode", wrapper.AuthQRCode)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/branding", wrapper.GetBranding)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/branding", wrapper.UpdateBranding)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/connections", wrapper.GetConnections)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/connections/{id}", wrapper.DeleteConnection)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/connections/{id}", wrapper.GetConnection)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/connections/{id}/credentials", wrapper.DeleteConnectionCredentials)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/connections/{id}/credentials", wrapper.GetConnectionCredentials)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/connections/{id}/credentials", wrapper.CreateConnectionCredential)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/connections/{id}/credentials/revoke", wrapper.RevokeConnectionCredentials)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/credentials", wrapper.GetCredentials)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/credentials", wrapper.CreateCredential)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/credentials/links", wrapper.GetLinks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/credentials/links", wrapper.CreateLink)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/credentials/links/callback", wrapper.CreateLinkQrCodeCallback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/credentials/links/{id}", wrapper.DeleteLink)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/credentials/links/{id}", wrapper.GetLink)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/v1/credentials/links/{id}", wrapper.AcivateLink)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/credentials/links/{id}/qrcode", wrapper.GetLinkQRCode)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/credentials/links/{id}/qrcode", wrapper.CreateLinkQrCode)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/credentials/links/{id}/sessions", wrapper.GetLinkSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/credentials/links/{id}/sessions/{sessionID}", wrapper.GetLinkSession)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/credentials/revocation/status/{nonce}", wrapper.GetRevocationStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/credentials/revoke/{nonce}", wrapper.RevokeCredential)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/credentials/{id}", wrapper.DeleteCredential)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/credentials/{id}", wrapper.GetCredential)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/credentials/{id}/qrcode", wrapper.GetCredentialQrCode)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/credentials/{id}/reissue", wrapper.ReissueCredential)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/hooks/deliveries", wrapper.GetHookDeliveries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/schemas", wrapper.GetSchemas)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/schemas", wrapper.ImportSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/schemas/cache", wrapper.PurgeSchemaCache)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/schemas/builder", wrapper.BuildSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/schemas/registry", wrapper.CreateSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/schemas/{id}", wrapper.GetSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/v1/schemas/{id}", wrapper.UpdateSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/schemas/{id}/context", wrapper.GetSchemaContext)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/schemas/{id}/hooks/{name}/test", wrapper.TestPostIssuanceHook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/schemas/{id}/jsonschema", wrapper.GetSchemaJSON)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/schemas/{id}/stats", wrapper.GetSchemaStats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/schemas/{id}/versions", wrapper.GetSchemaVersions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/state/publish", wrapper.PublishState)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/state/retry", wrapper.RetryPublishState)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/state/status", wrapper.GetStateStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/state/transactions", wrapper.GetStateTransactions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/subjects/{did}", wrapper.EraseSubjectData)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/subjects/{did}", wrapper.GetSubjectData)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/verifications", wrapper.GetVerifications)
+	})
+
+	return r
 }
 
-type GetCredentials404JSONResponse struct{ N404JSONResponse }
+type N400JSONResponse GenericErrorMessage
 
-func (response GetCredentials404JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+type N401JSONResponse GenericErrorMessage
 
-	return json.NewEncoder(w).Encode(response)
-}
+type N403JSONResponse GenericErrorMessage
 
-type GetCredentials500JSONResponse struct{ N500JSONResponse }
+type N404JSONResponse GenericErrorMessage
 
-func (response GetCredentials500JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+type N409JSONResponse GenericErrorMessage
 
-	return json.NewEncoder(w).Encode(response)
-}
+type N422JSONResponse GenericErrorMessage
 
-type CreateCredentialRequestObject struct {
-	Body *CreateCredentialJSONRequestBody
+type N429ResponseHeaders struct {
+	RetryAfter int
 }
+type N429JSONResponse struct {
+	Body GenericErrorMessage
 
-type CreateCredentialResponseObject interface {
-	VisitCreateCredentialResponse(w http.ResponseWriter) error
+	Headers N429ResponseHeaders
 }
 
-type CreateCredential201JSONResponse UUIDResponse
-
-func (response CreateCredential201JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+type N500JSONResponse GenericErrorMessage
 
-	return json.NewEncoder(w).Encode(response)
+type GetDocumentationRequestObject struct {
 }
 
-type CreateCredential400JSONResponse struct{ N400JSONResponse }
-
-func (response CreateCredential400JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+type GetDocumentationResponseObject interface {
+	VisitGetDocumentationResponse(w http.ResponseWriter) error
+}
 
-	return json.NewEncoder(w).Encode(response)
+type GetDocumentation200Response struct {
 }
 
-type CreateCredential401JSONResponse struct{ N401JSONResponse }
+func (response GetDocumentation200Response) VisitGetDocumentationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
 
-func (response CreateCredential401JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+type GetFaviconRequestObject struct {
+}
 
-	return json.NewEncoder(w).Encode(response)
+type GetFaviconResponseObject interface {
+	VisitGetFaviconResponse(w http.ResponseWriter) error
 }
 
-type CreateCredential422JSONResponse struct{ N422JSONResponse }
+type GetFavicon200Response struct {
+}
 
-func (response CreateCredential422JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
+func (response GetFavicon200Response) VisitGetFaviconResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
 
-	return json.NewEncoder(w).Encode(response)
+type GetYamlRequestObject struct {
 }
 
-type CreateCredential500JSONResponse struct{ N500JSONResponse }
-
-func (response CreateCredential500JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type GetLinksRequestObject struct {
-	Params GetLinksParams
+type GetYamlResponseObject interface {
+	VisitGetYamlResponse(w http.ResponseWriter) error
 }
 
-type GetLinksResponseObject interface {
-	VisitGetLinksResponse(w http.ResponseWriter) error
+type GetYaml200Response struct {
 }
 
-type GetLinks200JSONResponse []Link
-
-func (response GetLinks200JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+func (response GetYaml200Response) VisitGetYamlResponse(w http.ResponseWriter) error {
 	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
+	return nil
 }
 
-type GetLinks400JSONResponse struct{ N400JSONResponse }
-
-func (response GetLinks400JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+type HealthRequestObject struct {
+}
 
-	return json.NewEncoder(w).Encode(response)
+type HealthResponseObject interface {
+	VisitHealthResponse(w http.ResponseWriter) error
 }
 
-type GetLinks404JSONResponse struct{ N404JSONResponse }
+type Health200JSONResponse Health
 
-func (response GetLinks404JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
+func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLinks500JSONResponse struct{ N500JSONResponse }
+type Health500JSONResponse struct{ N500JSONResponse }
 
-func (response GetLinks500JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
+func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkRequestObject struct {
-	Body *CreateLinkJSONRequestBody
+type AgentRequestObject struct {
+	Body *AgentTextRequestBody
 }
 
-type CreateLinkResponseObject interface {
-	VisitCreateLinkResponse(w http.ResponseWriter) error
+type AgentResponseObject interface {
+	VisitAgentResponse(w http.ResponseWriter) error
 }
 
-type CreateLink201JSONResponse UUIDResponse
+type Agent200JSONResponse AgentResponse
 
-func (response CreateLink201JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
+func (response Agent200JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLink400JSONResponse struct{ N400JSONResponse }
+type Agent400JSONResponse struct{ N400JSONResponse }
 
-func (response CreateLink400JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
+func (response Agent400JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLink500JSONResponse struct{ N500JSONResponse }
+type Agent500JSONResponse struct{ N500JSONResponse }
 
-func (response CreateLink500JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
+func (response Agent500JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkQrCodeCallbackRequestObject struct {
-	Params CreateLinkQrCodeCallbackParams
-	Body   *CreateLinkQrCodeCallbackTextRequestBody
-}
-
-type CreateLinkQrCodeCallbackResponseObject interface {
-	VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error
-}
-
-type CreateLinkQrCodeCallback200Response struct {
+type GetAPIKeysRequestObject struct {
 }
 
-func (response CreateLinkQrCodeCallback200Response) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
+type GetAPIKeysResponseObject interface {
+	VisitGetAPIKeysResponse(w http.ResponseWriter) error
 }
 
-type CreateLinkQrCodeCallback400JSONResponse struct{ N400JSONResponse }
+type GetAPIKeys200JSONResponse []APIKey
 
-func (response CreateLinkQrCodeCallback400JSONResponse) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
+func (response GetAPIKeys200JSONResponse) VisitGetAPIKeysResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkQrCodeCallback500JSONResponse struct{ N500JSONResponse }
+type GetAPIKeys500JSONResponse struct{ N500JSONResponse }
 
-func (response CreateLinkQrCodeCallback500JSONResponse) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
+func (response GetAPIKeys500JSONResponse) VisitGetAPIKeysResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteLinkRequestObject struct {
-	Id Id `json:"id"`
+type CreateAPIKeyRequestObject struct {
+	Body *CreateAPIKeyJSONRequestBody
 }
 
-type DeleteLinkResponseObject interface {
-	VisitDeleteLinkResponse(w http.ResponseWriter) error
+type CreateAPIKeyResponseObject interface {
+	VisitCreateAPIKeyResponse(w http.ResponseWriter) error
 }
 
-type DeleteLink200JSONResponse GenericMessage
+type CreateAPIKey201JSONResponse CreateAPIKeyResponse
 
-func (response DeleteLink200JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
+func (response CreateAPIKey201JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteLink400JSONResponse struct{ N400JSONResponse }
+type CreateAPIKey400JSONResponse struct{ N400JSONResponse }
 
-func (response DeleteLink400JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
+func (response CreateAPIKey400JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteLink500JSONResponse struct{ N500JSONResponse }
+type CreateAPIKey500JSONResponse struct{ N500JSONResponse }
 
-func (response DeleteLink500JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
+func (response CreateAPIKey500JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLinkRequestObject struct {
+type DeleteAPIKeyRequestObject struct {
 	Id Id `json:"id"`
 }
 
-type GetLinkResponseObject interface {
-	VisitGetLinkResponse(w http.ResponseWriter) error
+type DeleteAPIKeyResponseObject interface {
+	VisitDeleteAPIKeyResponse(w http.ResponseWriter) error
 }
 
-type GetLink200JSONResponse Link
+type DeleteAPIKey200JSONResponse GenericMessage
 
-func (response GetLink200JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
+func (response DeleteAPIKey200JSONResponse) VisitDeleteAPIKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLink404JSONResponse struct{ N404JSONResponse }
+type DeleteAPIKey404JSONResponse struct{ N404JSONResponse }
 
-func (response GetLink404JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
+func (response DeleteAPIKey404JSONResponse) VisitDeleteAPIKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLink500JSONResponse struct{ N500JSONResponse }
+type DeleteAPIKey500JSONResponse struct{ N500JSONResponse }
 
-func (response GetLink500JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
+func (response DeleteAPIKey500JSONResponse) VisitDeleteAPIKeyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AcivateLinkRequestObject struct {
-	Id   Id `json:"id"`
-	Body *AcivateLinkJSONRequestBody
+type AuthCallbackRequestObject struct {
+	Params AuthCallbackParams
+	Body   *AuthCallbackTextRequestBody
 }
 
-type AcivateLinkResponseObject interface {
-	VisitAcivateLinkResponse(w http.ResponseWriter) error
+type AuthCallbackResponseObject interface {
+	VisitAuthCallbackResponse(w http.ResponseWriter) error
 }
 
-type AcivateLink200JSONResponse GenericMessage
+type AuthCallback200Response struct {
+}
 
-func (response AcivateLink200JSONResponse) VisitAcivateLinkResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+func (response AuthCallback200Response) VisitAuthCallbackResponse(w http.ResponseWriter) error {
 	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
+	return nil
 }
 
-type AcivateLink400JSONResponse struct{ N400JSONResponse }
+type AuthCallback400JSONResponse struct{ N400JSONResponse }
 
-func (response AcivateLink400JSONResponse) VisitAcivateLinkResponse(w http.ResponseWriter) error {
+func (response AuthCallback400JSONResponse) VisitAuthCallbackResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AcivateLink500JSONResponse struct{ N500JSONResponse }
+type AuthCallback500JSONResponse struct{ N500JSONResponse }
 
-func (response AcivateLink500JSONResponse) VisitAcivateLinkResponse(w http.ResponseWriter) error {
+func (response AuthCallback500JSONResponse) VisitAuthCallbackResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLinkQRCodeRequestObject struct {
-	Id     Id `json:"id"`
-	Params GetLinkQRCodeParams
+type AuthQRCodeRequestObject struct {
 }
 
-type GetLinkQRCodeResponseObject interface {
-	VisitGetLinkQRCodeResponse(w http.ResponseWriter) error
+type AuthQRCodeResponseObject interface {
+	VisitAuthQRCodeResponse(w http.ResponseWriter) error
 }
 
-type GetLinkQRCode200JSONResponse GetLinkQrCodeResponse
+type AuthQRCode200JSONResponse AuthenticationQrCodeResponse
 
-func (response GetLinkQRCode200JSONResponse) VisitGetLinkQRCodeResponse(w http.ResponseWriter) error {
+func (response AuthQRCode200JSONResponse) VisitAuthQRCodeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLinkQRCode400JSONResponse struct{ N400JSONResponse }
+type AuthQRCode500JSONResponse struct{ N500JSONResponse }
 
-func (response GetLinkQRCode400JSONResponse) VisitGetLinkQRCodeResponse(w http.ResponseWriter) error {
+func (response AuthQRCode500JSONResponse) VisitAuthQRCodeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLinkQRCode404JSONResponse GenericErrorMessage
+type GetBrandingRequestObject struct {
+}
 
-func (response GetLinkQRCode404JSONResponse) VisitGetLinkQRCodeResponse(w http.ResponseWriter) error {
+type GetBrandingResponseObject interface {
+	VisitGetBrandingResponse(w http.ResponseWriter) error
+}
+
+type GetBranding200JSONResponse Branding
+
+func (response GetBranding200JSONResponse) VisitGetBrandingResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetLinkQRCode500JSONResponse struct{ N500JSONResponse }
+type GetBranding500JSONResponse struct{ N500JSONResponse }
 
-func (response GetLinkQRCode500JSONResponse) VisitGetLinkQRCodeResponse(w http.ResponseWriter) error {
+func (response GetBranding500JSONResponse) VisitGetBrandingResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkQrCodeRequestObject struct {
-	Id Id `json:"id"`
+type UpdateBrandingRequestObject struct {
+	Body *UpdateBrandingJSONRequestBody
 }
 
-type CreateLinkQrCodeResponseObject interface {
-	VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error
+type UpdateBrandingResponseObject interface {
+	VisitUpdateBrandingResponse(w http.ResponseWriter) error
 }
 
-type CreateLinkQrCode200JSONResponse CredentialLinkQrCodeResponse
+type UpdateBranding200JSONResponse Branding
 
-func (response CreateLinkQrCode200JSONResponse) VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error {
+func (response UpdateBranding200JSONResponse) VisitUpdateBrandingResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkQrCode400JSONResponse struct{ N400JSONResponse }
+type UpdateBranding400JSONResponse struct{ N400JSONResponse }
 
-func (response CreateLinkQrCode400JSONResponse) VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error {
+func (response UpdateBranding400JSONResponse) VisitUpdateBrandingResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateLinkQrCode404JSONResponse struct{ N404JSONResponse }
-
-func (response CreateLinkQrCode404JSONResponse) VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type CreateLinkQrCode500JSONResponse struct{ N500JSONResponse }
+type UpdateBranding500JSONResponse struct{ N500JSONResponse }
 
-func (response CreateLinkQrCode500JSONResponse) VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error {
+func (response UpdateBranding500JSONResponse) VisitUpdateBrandingResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRevocationStatusRequestObject struct {
-	Nonce PathNonce `json:"nonce"`
+type GetConnectionsRequestObject struct {
+	Params GetConnectionsParams
 }
 
-type GetRevocationStatusResponseObject interface {
-	VisitGetRevocationStatusResponse(w http.ResponseWriter) error
+type GetConnectionsResponseObject interface {
+	VisitGetConnectionsResponse(w http.ResponseWriter) error
 }
 
-type GetRevocationStatus200JSONResponse RevocationStatusResponse
+type GetConnections200JSONResponse GetConnectionsResponse
 
-func (response GetRevocationStatus200JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+func (response GetConnections200JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRevocationStatus400JSONResponse struct{ N400JSONResponse }
+type GetConnections400JSONResponse struct{ N400JSONResponse }
 
-func (response GetRevocationStatus400JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+func (response GetConnections400JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRevocationStatus500JSONResponse struct{ N500JSONResponse }
+type GetConnections500JSONResponse struct{ N500JSONResponse }
 
-func (response GetRevocationStatus500JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+func (response GetConnections500JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RevokeCredentialRequestObject struct {
-	Nonce PathNonce `json:"nonce"`
-}
-
-type RevokeCredentialResponseObject interface {
-	VisitRevokeCredentialResponse(w http.ResponseWriter) error
+type DeleteConnectionRequestObject struct {
+	Id     Id `json:"id"`
+	Params DeleteConnectionParams
 }
 
-type RevokeCredential202JSONResponse RevokeCredentialResponse
-
-func (response RevokeCredential202JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
-
-	return json.NewEncoder(w).Encode(response)
+type DeleteConnectionResponseObject interface {
+	VisitDeleteConnectionResponse(w http.ResponseWriter) error
 }
 
-type RevokeCredential401JSONResponse struct{ N401JSONResponse }
+type DeleteConnection200JSONResponse GenericMessage
 
-func (response RevokeCredential401JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
+func (response DeleteConnection200JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RevokeCredential404JSONResponse struct{ N404JSONResponse }
+type DeleteConnection400JSONResponse struct{ N400JSONResponse }
 
-func (response RevokeCredential404JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
+func (response DeleteConnection400JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RevokeCredential500JSONResponse struct{ N500JSONResponse }
+type DeleteConnection500JSONResponse struct{ N500JSONResponse }
 
-func (response RevokeCredential500JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
+func (response DeleteConnection500JSONResponse) VisitDeleteConnectionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCredentialRequestObject struct {
+type GetConnectionRequestObject struct {
 	Id Id `json:"id"`
 }
 
-type DeleteCredentialResponseObject interface {
-	VisitDeleteCredentialResponse(w http.ResponseWriter) error
+type GetConnectionResponseObject interface {
+	VisitGetConnectionResponse(w http.ResponseWriter) error
 }
 
-type DeleteCredential200JSONResponse GenericMessage
+type GetConnection200JSONResponse GetConnectionResponse
 
-func (response DeleteCredential200JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+func (response GetConnection200JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCredential400JSONResponse struct{ N400JSONResponse }
+type GetConnection400JSONResponse struct{ N400JSONResponse }
 
-func (response DeleteCredential400JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+func (response GetConnection400JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCredential401JSONResponse struct{ N401JSONResponse }
+type GetConnection500JSONResponse struct{ N500JSONResponse }
 
-func (response DeleteCredential401JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+func (response GetConnection500JSONResponse) VisitGetConnectionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCredential500JSONResponse struct{ N500JSONResponse }
-
-func (response DeleteCredential500JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+type DeleteConnectionCredentialsRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type DeleteConnectionCredentialsResponseObject interface {
+	VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error
+}
+
+type DeleteConnectionCredentials200JSONResponse GenericMessage
+
+func (response DeleteConnectionCredentials200JSONResponse) VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteConnectionCredentials500JSONResponse struct{ N500JSONResponse }
+
+func (response DeleteConnectionCredentials500JSONResponse) VisitDeleteConnectionCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialRequestObject struct {
+type GetConnectionCredentialsRequestObject struct {
 	Id Id `json:"id"`
 }
 
-type GetCredentialResponseObject interface {
-	VisitGetCredentialResponse(w http.ResponseWriter) error
+type GetConnectionCredentialsResponseObject interface {
+	VisitGetConnectionCredentialsResponse(w http.ResponseWriter) error
 }
 
-type GetCredential200JSONResponse Credential
+type GetConnectionCredentials200JSONResponse []Credential
 
-func (response GetCredential200JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+func (response GetConnectionCredentials200JSONResponse) VisitGetConnectionCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredential400JSONResponse struct{ N400JSONResponse }
+type GetConnectionCredentials400JSONResponse struct{ N400JSONResponse }
 
-func (response GetCredential400JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+func (response GetConnectionCredentials400JSONResponse) VisitGetConnectionCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredential500JSONResponse struct{ N500JSONResponse }
+type GetConnectionCredentials500JSONResponse struct{ N500JSONResponse }
 
-func (response GetCredential500JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+func (response GetConnectionCredentials500JSONResponse) VisitGetConnectionCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialQrCodeRequestObject struct {
-	Id Id `json:"id"`
+type CreateConnectionCredentialRequestObject struct {
+	Id   Id `json:"id"`
+	Body *CreateConnectionCredentialJSONRequestBody
 }
 
-type GetCredentialQrCodeResponseObject interface {
-	VisitGetCredentialQrCodeResponse(w http.ResponseWriter) error
+type CreateConnectionCredentialResponseObject interface {
+	VisitCreateConnectionCredentialResponse(w http.ResponseWriter) error
 }
 
-type GetCredentialQrCode200JSONResponse QrCodeResponse
+type CreateConnectionCredential201JSONResponse CreateConnectionCredentialResponse
 
-func (response GetCredentialQrCode200JSONResponse) VisitGetCredentialQrCodeResponse(w http.ResponseWriter) error {
+func (response CreateConnectionCredential201JSONResponse) VisitCreateConnectionCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialQrCode400JSONResponse struct{ N400JSONResponse }
+type CreateConnectionCredential400JSONResponse struct{ N400JSONResponse }
 
-func (response GetCredentialQrCode400JSONResponse) VisitGetCredentialQrCodeResponse(w http.ResponseWriter) error {
+func (response CreateConnectionCredential400JSONResponse) VisitCreateConnectionCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialQrCode404JSONResponse struct{ N404JSONResponse }
+type CreateConnectionCredential403JSONResponse struct{ N403JSONResponse }
 
-func (response GetCredentialQrCode404JSONResponse) VisitGetCredentialQrCodeResponse(w http.ResponseWriter) error {
+func (response CreateConnectionCredential403JSONResponse) VisitCreateConnectionCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCredentialQrCode500JSONResponse struct{ N500JSONResponse }
+type CreateConnectionCredential404JSONResponse struct{ N404JSONResponse }
 
-func (response GetCredentialQrCode500JSONResponse) VisitGetCredentialQrCodeResponse(w http.ResponseWriter) error {
+func (response CreateConnectionCredential404JSONResponse) VisitCreateConnectionCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSchemasRequestObject struct {
-	Params GetSchemasParams
-}
-
-type GetSchemasResponseObject interface {
-	VisitGetSchemasResponse(w http.ResponseWriter) error
-}
-
-type GetSchemas200JSONResponse []Schema
+type CreateConnectionCredential409JSONResponse struct{ N409JSONResponse }
 
-func (response GetSchemas200JSONResponse) VisitGetSchemasResponse(w http.ResponseWriter) error {
+func (response CreateConnectionCredential409JSONResponse) VisitCreateConnectionCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSchemas400JSONResponse struct{ N400JSONResponse }
+type CreateConnectionCredential422JSONResponse struct{ N422JSONResponse }
 
-func (response GetSchemas400JSONResponse) VisitGetSchemasResponse(w http.ResponseWriter) error {
+func (response CreateConnectionCredential422JSONResponse) VisitCreateConnectionCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSchemas500JSONResponse struct{ N500JSONResponse }
+type CreateConnectionCredential500JSONResponse struct{ N500JSONResponse }
 
-func (response GetSchemas500JSONResponse) VisitGetSchemasResponse(w http.ResponseWriter) error {
+func (response CreateConnectionCredential500JSONResponse) VisitCreateConnectionCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ImportSchemaRequestObject struct {
-	Body *ImportSchemaJSONRequestBody
+type RevokeConnectionCredentialsRequestObject struct {
+	Id Id `json:"id"`
 }
 
-type ImportSchemaResponseObject interface {
-	VisitImportSchemaResponse(w http.ResponseWriter) error
+type RevokeConnectionCredentialsResponseObject interface {
+	VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error
 }
 
-type ImportSchema201JSONResponse UUIDResponse
+type RevokeConnectionCredentials202JSONResponse GenericMessage
 
-func (response ImportSchema201JSONResponse) VisitImportSchemaResponse(w http.ResponseWriter) error {
+func (response RevokeConnectionCredentials202JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(202)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ImportSchema400JSONResponse struct{ N400JSONResponse }
+type RevokeConnectionCredentials400JSONResponse struct{ N400JSONResponse }
 
-func (response ImportSchema400JSONResponse) VisitImportSchemaResponse(w http.ResponseWriter) error {
+func (response RevokeConnectionCredentials400JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ImportSchema500JSONResponse struct{ N500JSONResponse }
+type RevokeConnectionCredentials500JSONResponse struct{ N500JSONResponse }
 
-func (response ImportSchema500JSONResponse) VisitImportSchemaResponse(w http.ResponseWriter) error {
+func (response RevokeConnectionCredentials500JSONResponse) VisitRevokeConnectionCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSchemaRequestObject struct {
-	Id Id `json:"id"`
+type GetCredentialsRequestObject struct {
+	Params GetCredentialsParams
 }
 
-type GetSchemaResponseObject interface {
-	VisitGetSchemaResponse(w http.ResponseWriter) error
+type GetCredentialsResponseObject interface {
+	VisitGetCredentialsResponse(w http.ResponseWriter) error
 }
 
-type GetSchema200JSONResponse Schema
+type GetCredentials200ResponseHeaders struct {
+	XNextPageToken string
+	XTotalCount    int
+}
 
-func (response GetSchema200JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+type GetCredentials200JSONResponse struct {
+	Body    []Credential
+	Headers GetCredentials200ResponseHeaders
+}
+
+func (response GetCredentials200JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Page-Token", fmt.Sprint(response.Headers.XNextPageToken))
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetSchema400JSONResponse struct{ N400JSONResponse }
+type GetCredentials400JSONResponse struct{ N400JSONResponse }
 
-func (response GetSchema400JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+func (response GetCredentials400JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSchema404JSONResponse struct{ N404JSONResponse }
+type GetCredentials404JSONResponse struct{ N404JSONResponse }
 
-func (response GetSchema404JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+func (response GetCredentials404JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSchema500JSONResponse struct{ N500JSONResponse }
+type GetCredentials500JSONResponse struct{ N500JSONResponse }
 
-func (response GetSchema500JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+func (response GetCredentials500JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishStateRequestObject struct {
+type CreateCredentialRequestObject struct {
+	Body *CreateCredentialJSONRequestBody
 }
 
-type PublishStateResponseObject interface {
-	VisitPublishStateResponse(w http.ResponseWriter) error
+type CreateCredentialResponseObject interface {
+	VisitCreateCredentialResponse(w http.ResponseWriter) error
 }
 
-type PublishState202JSONResponse PublishIdentityStateResponse
+type CreateCredential201JSONResponse UUIDResponse
 
-func (response PublishState202JSONResponse) VisitPublishStateResponse(w http.ResponseWriter) error {
+func (response CreateCredential201JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishState400JSONResponse struct{ N400JSONResponse }
+type CreateCredential400JSONResponse struct{ N400JSONResponse }
 
-func (response PublishState400JSONResponse) VisitPublishStateResponse(w http.ResponseWriter) error {
+func (response CreateCredential400JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishState500JSONResponse struct{ N500JSONResponse }
+type CreateCredential401JSONResponse struct{ N401JSONResponse }
 
-func (response PublishState500JSONResponse) VisitPublishStateResponse(w http.ResponseWriter) error {
+func (response CreateCredential401JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredential403JSONResponse struct{ N403JSONResponse }
+
+func (response CreateCredential403JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredential409JSONResponse struct{ N409JSONResponse }
+
+func (response CreateCredential409JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredential422JSONResponse struct{ N422JSONResponse }
+
+func (response CreateCredential422JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredential500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateCredential500JSONResponse) VisitCreateCredentialResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RetryPublishStateRequestObject struct {
+type GetLinksRequestObject struct {
+	Params GetLinksParams
 }
 
-type RetryPublishStateResponseObject interface {
-	VisitRetryPublishStateResponse(w http.ResponseWriter) error
+type GetLinksResponseObject interface {
+	VisitGetLinksResponse(w http.ResponseWriter) error
 }
 
-type RetryPublishState202JSONResponse PublishIdentityStateResponse
+type GetLinks200JSONResponse []Link
 
-func (response RetryPublishState202JSONResponse) VisitRetryPublishStateResponse(w http.ResponseWriter) error {
+func (response GetLinks200JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RetryPublishState400JSONResponse struct{ N400JSONResponse }
+type GetLinks400JSONResponse struct{ N400JSONResponse }
 
-func (response RetryPublishState400JSONResponse) VisitRetryPublishStateResponse(w http.ResponseWriter) error {
+func (response GetLinks400JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RetryPublishState500JSONResponse struct{ N500JSONResponse }
+type GetLinks404JSONResponse struct{ N404JSONResponse }
 
-func (response RetryPublishState500JSONResponse) VisitRetryPublishStateResponse(w http.ResponseWriter) error {
+func (response GetLinks404JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinks500JSONResponse struct{ N500JSONResponse }
+
+func (response GetLinks500JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetStateStatusRequestObject struct {
+type CreateLinkRequestObject struct {
+	Body *CreateLinkJSONRequestBody
 }
 
-type GetStateStatusResponseObject interface {
-	VisitGetStateStatusResponse(w http.ResponseWriter) error
+type CreateLinkResponseObject interface {
+	VisitCreateLinkResponse(w http.ResponseWriter) error
 }
 
-type GetStateStatus200JSONResponse StateStatusResponse
+type CreateLink201JSONResponse UUIDResponse
 
-func (response GetStateStatus200JSONResponse) VisitGetStateStatusResponse(w http.ResponseWriter) error {
+func (response CreateLink201JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetStateStatus500JSONResponse struct{ N500JSONResponse }
+type CreateLink400JSONResponse struct{ N400JSONResponse }
 
-func (response GetStateStatus500JSONResponse) VisitGetStateStatusResponse(w http.ResponseWriter) error {
+func (response CreateLink400JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLink500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateLink500JSONResponse) VisitCreateLinkResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetStateTransactionsRequestObject struct {
+type CreateLinkQrCodeCallbackRequestObject struct {
+	Params CreateLinkQrCodeCallbackParams
+	Body   *CreateLinkQrCodeCallbackTextRequestBody
 }
 
-type GetStateTransactionsResponseObject interface {
-	VisitGetStateTransactionsResponse(w http.ResponseWriter) error
+type CreateLinkQrCodeCallbackResponseObject interface {
+	VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error
 }
 
-type GetStateTransactions200JSONResponse StateTransactionsResponse
+type CreateLinkQrCodeCallback200Response struct {
+}
 
-func (response GetStateTransactions200JSONResponse) VisitGetStateTransactionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+func (response CreateLinkQrCodeCallback200Response) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
 	w.WriteHeader(200)
+	return nil
+}
+
+type CreateLinkQrCodeCallback400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateLinkQrCodeCallback400JSONResponse) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetStateTransactions500JSONResponse struct{ N500JSONResponse }
+type CreateLinkQrCodeCallback429JSONResponse struct{ N429JSONResponse }
 
-func (response GetStateTransactions500JSONResponse) VisitGetStateTransactionsResponse(w http.ResponseWriter) error {
+func (response CreateLinkQrCodeCallback429JSONResponse) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateLinkQrCodeCallback500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateLinkQrCodeCallback500JSONResponse) VisitCreateLinkQrCodeCallbackResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Get the documentation
-	// (GET /)
-	GetDocumentation(ctx context.Context, request GetDocumentationRequestObject) (GetDocumentationResponseObject, error)
+type DeleteLinkRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type DeleteLinkResponseObject interface {
+	VisitDeleteLinkResponse(w http.ResponseWriter) error
+}
+
+type DeleteLink200JSONResponse GenericMessage
+
+func (response DeleteLink200JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLink400JSONResponse struct{ N400JSONResponse }
+
+func (response DeleteLink400JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLink500JSONResponse struct{ N500JSONResponse }
+
+func (response DeleteLink500JSONResponse) VisitDeleteLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type GetLinkResponseObject interface {
+	VisitGetLinkResponse(w http.ResponseWriter) error
+}
+
+type GetLink200JSONResponse Link
+
+func (response GetLink200JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLink404JSONResponse struct{ N404JSONResponse }
+
+func (response GetLink404JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLink500JSONResponse struct{ N500JSONResponse }
+
+func (response GetLink500JSONResponse) VisitGetLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcivateLinkRequestObject struct {
+	Id   Id `json:"id"`
+	Body *AcivateLinkJSONRequestBody
+}
+
+type AcivateLinkResponseObject interface {
+	VisitAcivateLinkResponse(w http.ResponseWriter) error
+}
+
+type AcivateLink200JSONResponse GenericMessage
+
+func (response AcivateLink200JSONResponse) VisitAcivateLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcivateLink400JSONResponse struct{ N400JSONResponse }
+
+func (response AcivateLink400JSONResponse) VisitAcivateLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcivateLink500JSONResponse struct{ N500JSONResponse }
+
+func (response AcivateLink500JSONResponse) VisitAcivateLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkQRCodeRequestObject struct {
+	Id     Id `json:"id"`
+	Params GetLinkQRCodeParams
+}
+
+type GetLinkQRCodeResponseObject interface {
+	VisitGetLinkQRCodeResponse(w http.ResponseWriter) error
+}
+
+type GetLinkQRCode200JSONResponse GetLinkQrCodeResponse
+
+func (response GetLinkQRCode200JSONResponse) VisitGetLinkQRCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkQRCode400JSONResponse struct{ N400JSONResponse }
+
+func (response GetLinkQRCode400JSONResponse) VisitGetLinkQRCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkQRCode404JSONResponse GenericErrorMessage
+
+func (response GetLinkQRCode404JSONResponse) VisitGetLinkQRCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkQRCode429JSONResponse struct{ N429JSONResponse }
+
+func (response GetLinkQRCode429JSONResponse) VisitGetLinkQRCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetLinkQRCode500JSONResponse struct{ N500JSONResponse }
+
+func (response GetLinkQRCode500JSONResponse) VisitGetLinkQRCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLinkQrCodeRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type CreateLinkQrCodeResponseObject interface {
+	VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error
+}
+
+type CreateLinkQrCode200JSONResponse CredentialLinkQrCodeResponse
+
+func (response CreateLinkQrCode200JSONResponse) VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLinkQrCode400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateLinkQrCode400JSONResponse) VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLinkQrCode403JSONResponse AntiAbuseChallenge
+
+func (response CreateLinkQrCode403JSONResponse) VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLinkQrCode404JSONResponse struct{ N404JSONResponse }
+
+func (response CreateLinkQrCode404JSONResponse) VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLinkQrCode429JSONResponse struct{ N429JSONResponse }
+
+func (response CreateLinkQrCode429JSONResponse) VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateLinkQrCode500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateLinkQrCode500JSONResponse) VisitCreateLinkQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkSessionsRequestObject struct {
+	Id     Id `json:"id"`
+	Params GetLinkSessionsParams
+}
+
+type GetLinkSessionsResponseObject interface {
+	VisitGetLinkSessionsResponse(w http.ResponseWriter) error
+}
+
+type GetLinkSessions200JSONResponse []LinkSession
+
+func (response GetLinkSessions200JSONResponse) VisitGetLinkSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkSessions400JSONResponse struct{ N400JSONResponse }
+
+func (response GetLinkSessions400JSONResponse) VisitGetLinkSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkSessions404JSONResponse struct{ N404JSONResponse }
+
+func (response GetLinkSessions404JSONResponse) VisitGetLinkSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkSessions500JSONResponse struct{ N500JSONResponse }
+
+func (response GetLinkSessions500JSONResponse) VisitGetLinkSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkSessionRequestObject struct {
+	Id        Id        `json:"id"`
+	SessionID uuid.UUID `json:"sessionID"`
+}
+
+type GetLinkSessionResponseObject interface {
+	VisitGetLinkSessionResponse(w http.ResponseWriter) error
+}
+
+type GetLinkSession200JSONResponse LinkSession
+
+func (response GetLinkSession200JSONResponse) VisitGetLinkSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkSession400JSONResponse struct{ N400JSONResponse }
+
+func (response GetLinkSession400JSONResponse) VisitGetLinkSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkSession404JSONResponse struct{ N404JSONResponse }
+
+func (response GetLinkSession404JSONResponse) VisitGetLinkSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkSession500JSONResponse struct{ N500JSONResponse }
+
+func (response GetLinkSession500JSONResponse) VisitGetLinkSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatusRequestObject struct {
+	Nonce PathNonce `json:"nonce"`
+}
+
+type GetRevocationStatusResponseObject interface {
+	VisitGetRevocationStatusResponse(w http.ResponseWriter) error
+}
+
+type GetRevocationStatus200JSONResponse RevocationStatusResponse
+
+func (response GetRevocationStatus200JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatus400JSONResponse struct{ N400JSONResponse }
+
+func (response GetRevocationStatus400JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatus500JSONResponse struct{ N500JSONResponse }
+
+func (response GetRevocationStatus500JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeCredentialRequestObject struct {
+	Nonce PathNonce `json:"nonce"`
+}
+
+type RevokeCredentialResponseObject interface {
+	VisitRevokeCredentialResponse(w http.ResponseWriter) error
+}
+
+type RevokeCredential202JSONResponse RevokeCredentialResponse
+
+func (response RevokeCredential202JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeCredential401JSONResponse struct{ N401JSONResponse }
+
+func (response RevokeCredential401JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeCredential404JSONResponse struct{ N404JSONResponse }
+
+func (response RevokeCredential404JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeCredential500JSONResponse struct{ N500JSONResponse }
+
+func (response RevokeCredential500JSONResponse) VisitRevokeCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCredentialRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type DeleteCredentialResponseObject interface {
+	VisitDeleteCredentialResponse(w http.ResponseWriter) error
+}
+
+type DeleteCredential200JSONResponse GenericMessage
+
+func (response DeleteCredential200JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCredential400JSONResponse struct{ N400JSONResponse }
+
+func (response DeleteCredential400JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCredential401JSONResponse struct{ N401JSONResponse }
+
+func (response DeleteCredential401JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCredential404JSONResponse struct{ N404JSONResponse }
+
+func (response DeleteCredential404JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCredential500JSONResponse struct{ N500JSONResponse }
+
+func (response DeleteCredential500JSONResponse) VisitDeleteCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialRequestObject struct {
+	Id     Id `json:"id"`
+	Params GetCredentialParams
+}
+
+type GetCredentialResponseObject interface {
+	VisitGetCredentialResponse(w http.ResponseWriter) error
+}
+
+type GetCredential200JSONResponse Credential
+
+func (response GetCredential200JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredential400JSONResponse struct{ N400JSONResponse }
+
+func (response GetCredential400JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredential500JSONResponse struct{ N500JSONResponse }
+
+func (response GetCredential500JSONResponse) VisitGetCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialQrCodeRequestObject struct {
+	Id     Id `json:"id"`
+	Params GetCredentialQrCodeParams
+}
+
+type GetCredentialQrCodeResponseObject interface {
+	VisitGetCredentialQrCodeResponse(w http.ResponseWriter) error
+}
+
+type GetCredentialQrCode200JSONResponse QrCodeResponse
+
+func (response GetCredentialQrCode200JSONResponse) VisitGetCredentialQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialQrCode400JSONResponse struct{ N400JSONResponse }
+
+func (response GetCredentialQrCode400JSONResponse) VisitGetCredentialQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialQrCode404JSONResponse struct{ N404JSONResponse }
+
+func (response GetCredentialQrCode404JSONResponse) VisitGetCredentialQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialQrCode500JSONResponse struct{ N500JSONResponse }
+
+func (response GetCredentialQrCode500JSONResponse) VisitGetCredentialQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReissueCredentialRequestObject struct {
+	Id   Id `json:"id"`
+	Body *ReissueCredentialJSONRequestBody
+}
+
+type ReissueCredentialResponseObject interface {
+	VisitReissueCredentialResponse(w http.ResponseWriter) error
+}
+
+type ReissueCredential201JSONResponse ReissueCredentialResponse
+
+func (response ReissueCredential201JSONResponse) VisitReissueCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReissueCredential400JSONResponse struct{ N400JSONResponse }
+
+func (response ReissueCredential400JSONResponse) VisitReissueCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReissueCredential403JSONResponse struct{ N403JSONResponse }
+
+func (response ReissueCredential403JSONResponse) VisitReissueCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReissueCredential404JSONResponse struct{ N404JSONResponse }
+
+func (response ReissueCredential404JSONResponse) VisitReissueCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReissueCredential409JSONResponse struct{ N409JSONResponse }
+
+func (response ReissueCredential409JSONResponse) VisitReissueCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReissueCredential422JSONResponse struct{ N422JSONResponse }
+
+func (response ReissueCredential422JSONResponse) VisitReissueCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReissueCredential500JSONResponse struct{ N500JSONResponse }
+
+func (response ReissueCredential500JSONResponse) VisitReissueCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHookDeliveriesRequestObject struct {
+}
+
+type GetHookDeliveriesResponseObject interface {
+	VisitGetHookDeliveriesResponse(w http.ResponseWriter) error
+}
+
+type GetHookDeliveries200JSONResponse []HookDelivery
+
+func (response GetHookDeliveries200JSONResponse) VisitGetHookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHookDeliveries500JSONResponse struct{ N500JSONResponse }
+
+func (response GetHookDeliveries500JSONResponse) VisitGetHookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemasRequestObject struct {
+	Params GetSchemasParams
+}
+
+type GetSchemasResponseObject interface {
+	VisitGetSchemasResponse(w http.ResponseWriter) error
+}
+
+type GetSchemas200JSONResponse []Schema
+
+func (response GetSchemas200JSONResponse) VisitGetSchemasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemas400JSONResponse struct{ N400JSONResponse }
+
+func (response GetSchemas400JSONResponse) VisitGetSchemasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemas500JSONResponse struct{ N500JSONResponse }
+
+func (response GetSchemas500JSONResponse) VisitGetSchemasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportSchemaRequestObject struct {
+	Body *ImportSchemaJSONRequestBody
+}
+
+type ImportSchemaResponseObject interface {
+	VisitImportSchemaResponse(w http.ResponseWriter) error
+}
+
+type ImportSchema201JSONResponse UUIDResponse
+
+func (response ImportSchema201JSONResponse) VisitImportSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportSchema400JSONResponse struct{ N400JSONResponse }
+
+func (response ImportSchema400JSONResponse) VisitImportSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportSchema500JSONResponse struct{ N500JSONResponse }
+
+func (response ImportSchema500JSONResponse) VisitImportSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeSchemaCacheRequestObject struct {
+	Params PurgeSchemaCacheParams
+}
+
+type PurgeSchemaCacheResponseObject interface {
+	VisitPurgeSchemaCacheResponse(w http.ResponseWriter) error
+}
+
+type PurgeSchemaCache200JSONResponse PurgeSchemaCacheResponse
+
+func (response PurgeSchemaCache200JSONResponse) VisitPurgeSchemaCacheResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeSchemaCache400JSONResponse struct{ N400JSONResponse }
+
+func (response PurgeSchemaCache400JSONResponse) VisitPurgeSchemaCacheResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeSchemaCache500JSONResponse struct{ N500JSONResponse }
+
+func (response PurgeSchemaCache500JSONResponse) VisitPurgeSchemaCacheResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BuildSchemaRequestObject struct {
+	Body *BuildSchemaJSONRequestBody
+}
+
+type BuildSchemaResponseObject interface {
+	VisitBuildSchemaResponse(w http.ResponseWriter) error
+}
+
+type BuildSchema200JSONResponse BuildSchemaResponse
+
+func (response BuildSchema200JSONResponse) VisitBuildSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BuildSchema400JSONResponse struct{ N400JSONResponse }
+
+func (response BuildSchema400JSONResponse) VisitBuildSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BuildSchema500JSONResponse struct{ N500JSONResponse }
+
+func (response BuildSchema500JSONResponse) VisitBuildSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSchemaRequestObject struct {
+	Body *CreateSchemaJSONRequestBody
+}
+
+type CreateSchemaResponseObject interface {
+	VisitCreateSchemaResponse(w http.ResponseWriter) error
+}
+
+type CreateSchema201JSONResponse UUIDResponse
+
+func (response CreateSchema201JSONResponse) VisitCreateSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSchema400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateSchema400JSONResponse) VisitCreateSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSchema500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateSchema500JSONResponse) VisitCreateSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type GetSchemaResponseObject interface {
+	VisitGetSchemaResponse(w http.ResponseWriter) error
+}
+
+type GetSchema200JSONResponse Schema
+
+func (response GetSchema200JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchema400JSONResponse struct{ N400JSONResponse }
+
+func (response GetSchema400JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchema404JSONResponse struct{ N404JSONResponse }
+
+func (response GetSchema404JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchema500JSONResponse struct{ N500JSONResponse }
+
+func (response GetSchema500JSONResponse) VisitGetSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSchemaRequestObject struct {
+	Id   Id `json:"id"`
+	Body *UpdateSchemaJSONRequestBody
+}
+
+type UpdateSchemaResponseObject interface {
+	VisitUpdateSchemaResponse(w http.ResponseWriter) error
+}
+
+type UpdateSchema200JSONResponse Schema
+
+func (response UpdateSchema200JSONResponse) VisitUpdateSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSchema400JSONResponse struct{ N400JSONResponse }
+
+func (response UpdateSchema400JSONResponse) VisitUpdateSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSchema404JSONResponse struct{ N404JSONResponse }
+
+func (response UpdateSchema404JSONResponse) VisitUpdateSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSchema500JSONResponse struct{ N500JSONResponse }
+
+func (response UpdateSchema500JSONResponse) VisitUpdateSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaContextRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type GetSchemaContextResponseObject interface {
+	VisitGetSchemaContextResponse(w http.ResponseWriter) error
+}
+
+type GetSchemaContext200JSONResponse map[string]interface{}
+
+func (response GetSchemaContext200JSONResponse) VisitGetSchemaContextResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/ld+json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaContext400JSONResponse struct{ N400JSONResponse }
+
+func (response GetSchemaContext400JSONResponse) VisitGetSchemaContextResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaContext404JSONResponse struct{ N404JSONResponse }
+
+func (response GetSchemaContext404JSONResponse) VisitGetSchemaContextResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaContext429JSONResponse struct{ N429JSONResponse }
+
+func (response GetSchemaContext429JSONResponse) VisitGetSchemaContextResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetSchemaContext500JSONResponse struct{ N500JSONResponse }
+
+func (response GetSchemaContext500JSONResponse) VisitGetSchemaContextResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestPostIssuanceHookRequestObject struct {
+	Id   Id     `json:"id"`
+	Name string `json:"name"`
+}
+
+type TestPostIssuanceHookResponseObject interface {
+	VisitTestPostIssuanceHookResponse(w http.ResponseWriter) error
+}
+
+type TestPostIssuanceHook200JSONResponse HookDelivery
+
+func (response TestPostIssuanceHook200JSONResponse) VisitTestPostIssuanceHookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestPostIssuanceHook400JSONResponse struct{ N400JSONResponse }
+
+func (response TestPostIssuanceHook400JSONResponse) VisitTestPostIssuanceHookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestPostIssuanceHook404JSONResponse struct{ N404JSONResponse }
+
+func (response TestPostIssuanceHook404JSONResponse) VisitTestPostIssuanceHookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestPostIssuanceHook500JSONResponse struct{ N500JSONResponse }
+
+func (response TestPostIssuanceHook500JSONResponse) VisitTestPostIssuanceHookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaJSONRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type GetSchemaJSONResponseObject interface {
+	VisitGetSchemaJSONResponse(w http.ResponseWriter) error
+}
+
+type GetSchemaJSON200JSONResponse map[string]interface{}
+
+func (response GetSchemaJSON200JSONResponse) VisitGetSchemaJSONResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaJSON400JSONResponse struct{ N400JSONResponse }
+
+func (response GetSchemaJSON400JSONResponse) VisitGetSchemaJSONResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaJSON404JSONResponse struct{ N404JSONResponse }
+
+func (response GetSchemaJSON404JSONResponse) VisitGetSchemaJSONResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaJSON429JSONResponse struct{ N429JSONResponse }
+
+func (response GetSchemaJSON429JSONResponse) VisitGetSchemaJSONResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetSchemaJSON500JSONResponse struct{ N500JSONResponse }
+
+func (response GetSchemaJSON500JSONResponse) VisitGetSchemaJSONResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaStatsRequestObject struct {
+	Id     Id `json:"id"`
+	Params GetSchemaStatsParams
+}
+
+type GetSchemaStatsResponseObject interface {
+	VisitGetSchemaStatsResponse(w http.ResponseWriter) error
+}
+
+type GetSchemaStats200JSONResponse SchemaStats
+
+func (response GetSchemaStats200JSONResponse) VisitGetSchemaStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaStats400JSONResponse struct{ N400JSONResponse }
+
+func (response GetSchemaStats400JSONResponse) VisitGetSchemaStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaStats404JSONResponse struct{ N404JSONResponse }
+
+func (response GetSchemaStats404JSONResponse) VisitGetSchemaStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaStats500JSONResponse struct{ N500JSONResponse }
+
+func (response GetSchemaStats500JSONResponse) VisitGetSchemaStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaVersionsRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type GetSchemaVersionsResponseObject interface {
+	VisitGetSchemaVersionsResponse(w http.ResponseWriter) error
+}
+
+type GetSchemaVersions200JSONResponse []Schema
+
+func (response GetSchemaVersions200JSONResponse) VisitGetSchemaVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaVersions400JSONResponse struct{ N400JSONResponse }
+
+func (response GetSchemaVersions400JSONResponse) VisitGetSchemaVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaVersions404JSONResponse struct{ N404JSONResponse }
+
+func (response GetSchemaVersions404JSONResponse) VisitGetSchemaVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaVersions500JSONResponse struct{ N500JSONResponse }
+
+func (response GetSchemaVersions500JSONResponse) VisitGetSchemaVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishStateRequestObject struct {
+}
+
+type PublishStateResponseObject interface {
+	VisitPublishStateResponse(w http.ResponseWriter) error
+}
+
+type PublishState202JSONResponse PublishIdentityStateResponse
+
+func (response PublishState202JSONResponse) VisitPublishStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishState400JSONResponse struct{ N400JSONResponse }
+
+func (response PublishState400JSONResponse) VisitPublishStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishState500JSONResponse struct{ N500JSONResponse }
+
+func (response PublishState500JSONResponse) VisitPublishStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryPublishStateRequestObject struct {
+}
+
+type RetryPublishStateResponseObject interface {
+	VisitRetryPublishStateResponse(w http.ResponseWriter) error
+}
+
+type RetryPublishState202JSONResponse PublishIdentityStateResponse
+
+func (response RetryPublishState202JSONResponse) VisitRetryPublishStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryPublishState400JSONResponse struct{ N400JSONResponse }
+
+func (response RetryPublishState400JSONResponse) VisitRetryPublishStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryPublishState500JSONResponse struct{ N500JSONResponse }
+
+func (response RetryPublishState500JSONResponse) VisitRetryPublishStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStateStatusRequestObject struct {
+}
+
+type GetStateStatusResponseObject interface {
+	VisitGetStateStatusResponse(w http.ResponseWriter) error
+}
+
+type GetStateStatus200JSONResponse StateStatusResponse
+
+func (response GetStateStatus200JSONResponse) VisitGetStateStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStateStatus500JSONResponse struct{ N500JSONResponse }
+
+func (response GetStateStatus500JSONResponse) VisitGetStateStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStateTransactionsRequestObject struct {
+	Params GetStateTransactionsParams
+}
+
+type GetStateTransactionsResponseObject interface {
+	VisitGetStateTransactionsResponse(w http.ResponseWriter) error
+}
+
+type GetStateTransactions200JSONResponse StateTransactionsResponse
+
+func (response GetStateTransactions200JSONResponse) VisitGetStateTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStateTransactions500JSONResponse struct{ N500JSONResponse }
+
+func (response GetStateTransactions500JSONResponse) VisitGetStateTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseSubjectDataRequestObject struct {
+	Did PathDid `json:"did"`
+}
+
+type EraseSubjectDataResponseObject interface {
+	VisitEraseSubjectDataResponse(w http.ResponseWriter) error
+}
+
+type EraseSubjectData200JSONResponse SubjectErasure
+
+func (response EraseSubjectData200JSONResponse) VisitEraseSubjectDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseSubjectData400JSONResponse struct{ N400JSONResponse }
+
+func (response EraseSubjectData400JSONResponse) VisitEraseSubjectDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseSubjectData500JSONResponse struct{ N500JSONResponse }
+
+func (response EraseSubjectData500JSONResponse) VisitEraseSubjectDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubjectDataRequestObject struct {
+	Did PathDid `json:"did"`
+}
+
+type GetSubjectDataResponseObject interface {
+	VisitGetSubjectDataResponse(w http.ResponseWriter) error
+}
+
+type GetSubjectData200JSONResponse SubjectData
+
+func (response GetSubjectData200JSONResponse) VisitGetSubjectDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubjectData400JSONResponse struct{ N400JSONResponse }
+
+func (response GetSubjectData400JSONResponse) VisitGetSubjectDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubjectData500JSONResponse struct{ N500JSONResponse }
+
+func (response GetSubjectData500JSONResponse) VisitGetSubjectDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVerificationsRequestObject struct {
+	Params GetVerificationsParams
+}
+
+type GetVerificationsResponseObject interface {
+	VisitGetVerificationsResponse(w http.ResponseWriter) error
+}
+
+type GetVerifications200JSONResponse []Verification
+
+func (response GetVerifications200JSONResponse) VisitGetVerificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVerifications400JSONResponse struct{ N400JSONResponse }
+
+func (response GetVerifications400JSONResponse) VisitGetVerificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVerifications500JSONResponse struct{ N500JSONResponse }
+
+func (response GetVerifications500JSONResponse) VisitGetVerificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get the documentation
+	// (GET /)
+	GetDocumentation(ctx context.Context, request GetDocumentationRequestObject) (GetDocumentationResponseObject, error)
 	// Gets the favicon
 	// (GET /favicon.ico)
 	GetFavicon(ctx context.Context, request GetFaviconRequestObject) (GetFaviconResponseObject, error)
@@ -2855,12 +5529,27 @@ type StrictServerInterface interface {
 	// Agent
 	// (POST /v1/agent)
 	Agent(ctx context.Context, request AgentRequestObject) (AgentResponseObject, error)
+	// Get API Keys
+	// (GET /v1/api-keys)
+	GetAPIKeys(ctx context.Context, request GetAPIKeysRequestObject) (GetAPIKeysResponseObject, error)
+	// Create API Key
+	// (POST /v1/api-keys)
+	CreateAPIKey(ctx context.Context, request CreateAPIKeyRequestObject) (CreateAPIKeyResponseObject, error)
+	// Delete API Key
+	// (DELETE /v1/api-keys/{id})
+	DeleteAPIKey(ctx context.Context, request DeleteAPIKeyRequestObject) (DeleteAPIKeyResponseObject, error)
 	// Authentication Callback
 	// (POST /v1/authentication/callback)
 	AuthCallback(ctx context.Context, request AuthCallbackRequestObject) (AuthCallbackResponseObject, error)
 	// Get Connection QRCode
 	// (GET /v1/authentication/qrcode)
 	AuthQRCode(ctx context.Context, request AuthQRCodeRequestObject) (AuthQRCodeResponseObject, error)
+	// Get Branding
+	// (GET /v1/branding)
+	GetBranding(ctx context.Context, request GetBrandingRequestObject) (GetBrandingResponseObject, error)
+	// Update Branding
+	// (PUT /v1/branding)
+	UpdateBranding(ctx context.Context, request UpdateBrandingRequestObject) (UpdateBrandingResponseObject, error)
 	// Get Connections
 	// (GET /v1/connections)
 	GetConnections(ctx context.Context, request GetConnectionsRequestObject) (GetConnectionsResponseObject, error)
@@ -2873,6 +5562,12 @@ type StrictServerInterface interface {
 	// Delete Connection Credentials
 	// (DELETE /v1/connections/{id}/credentials)
 	DeleteConnectionCredentials(ctx context.Context, request DeleteConnectionCredentialsRequestObject) (DeleteConnectionCredentialsResponseObject, error)
+	// Get Connection Credentials
+	// (GET /v1/connections/{id}/credentials)
+	GetConnectionCredentials(ctx context.Context, request GetConnectionCredentialsRequestObject) (GetConnectionCredentialsResponseObject, error)
+	// Create Connection Credential
+	// (POST /v1/connections/{id}/credentials)
+	CreateConnectionCredential(ctx context.Context, request CreateConnectionCredentialRequestObject) (CreateConnectionCredentialResponseObject, error)
 	// Revoke Connection Credentials
 	// (POST /v1/connections/{id}/credentials/revoke)
 	RevokeConnectionCredentials(ctx context.Context, request RevokeConnectionCredentialsRequestObject) (RevokeConnectionCredentialsResponseObject, error)
@@ -2906,6 +5601,12 @@ type StrictServerInterface interface {
 	// Create Authentication Link QRCode
 	// (POST /v1/credentials/links/{id}/qrcode)
 	CreateLinkQrCode(ctx context.Context, request CreateLinkQrCodeRequestObject) (CreateLinkQrCodeResponseObject, error)
+	// Get Link Sessions
+	// (GET /v1/credentials/links/{id}/sessions)
+	GetLinkSessions(ctx context.Context, request GetLinkSessionsRequestObject) (GetLinkSessionsResponseObject, error)
+	// Get Link Session
+	// (GET /v1/credentials/links/{id}/sessions/{sessionID})
+	GetLinkSession(ctx context.Context, request GetLinkSessionRequestObject) (GetLinkSessionResponseObject, error)
 	// Get Revocation Status
 	// (GET /v1/credentials/revocation/status/{nonce})
 	GetRevocationStatus(ctx context.Context, request GetRevocationStatusRequestObject) (GetRevocationStatusResponseObject, error)
@@ -2921,15 +5622,48 @@ type StrictServerInterface interface {
 	// Get Credential QR code
 	// (GET /v1/credentials/{id}/qrcode)
 	GetCredentialQrCode(ctx context.Context, request GetCredentialQrCodeRequestObject) (GetCredentialQrCodeResponseObject, error)
+	// Reissue Credential
+	// (POST /v1/credentials/{id}/reissue)
+	ReissueCredential(ctx context.Context, request ReissueCredentialRequestObject) (ReissueCredentialResponseObject, error)
+	// Get Hook Deliveries
+	// (GET /v1/hooks/deliveries)
+	GetHookDeliveries(ctx context.Context, request GetHookDeliveriesRequestObject) (GetHookDeliveriesResponseObject, error)
 	// Get Schemas
 	// (GET /v1/schemas)
 	GetSchemas(ctx context.Context, request GetSchemasRequestObject) (GetSchemasResponseObject, error)
 	// Import JSON schema
 	// (POST /v1/schemas)
 	ImportSchema(ctx context.Context, request ImportSchemaRequestObject) (ImportSchemaResponseObject, error)
+	// Purge Schema Cache
+	// (DELETE /v1/schemas/cache)
+	PurgeSchemaCache(ctx context.Context, request PurgeSchemaCacheRequestObject) (PurgeSchemaCacheResponseObject, error)
+	// Build Schema
+	// (POST /v1/schemas/builder)
+	BuildSchema(ctx context.Context, request BuildSchemaRequestObject) (BuildSchemaResponseObject, error)
+	// Create Schema Version
+	// (POST /v1/schemas/registry)
+	CreateSchema(ctx context.Context, request CreateSchemaRequestObject) (CreateSchemaResponseObject, error)
 	// Get Schema
 	// (GET /v1/schemas/{id})
 	GetSchema(ctx context.Context, request GetSchemaRequestObject) (GetSchemaResponseObject, error)
+	// Update Schema
+	// (PATCH /v1/schemas/{id})
+	UpdateSchema(ctx context.Context, request UpdateSchemaRequestObject) (UpdateSchemaResponseObject, error)
+	// Get Schema JSON-LD Context
+	// (GET /v1/schemas/{id}/context)
+	GetSchemaContext(ctx context.Context, request GetSchemaContextRequestObject) (GetSchemaContextResponseObject, error)
+	// Test Post-Issuance Hook
+	// (POST /v1/schemas/{id}/hooks/{name}/test)
+	TestPostIssuanceHook(ctx context.Context, request TestPostIssuanceHookRequestObject) (TestPostIssuanceHookResponseObject, error)
+	// Get Schema JSON Schema
+	// (GET /v1/schemas/{id}/jsonschema)
+	GetSchemaJSON(ctx context.Context, request GetSchemaJSONRequestObject) (GetSchemaJSONResponseObject, error)
+	// Get Schema Stats
+	// (GET /v1/schemas/{id}/stats)
+	GetSchemaStats(ctx context.Context, request GetSchemaStatsRequestObject) (GetSchemaStatsResponseObject, error)
+	// Get Schema Versions
+	// (GET /v1/schemas/{id}/versions)
+	GetSchemaVersions(ctx context.Context, request GetSchemaVersionsRequestObject) (GetSchemaVersionsResponseObject, error)
 	// Publish Identity State
 	// (POST /v1/state/publish)
 	PublishState(ctx context.Context, request PublishStateRequestObject) (PublishStateResponseObject, error)
@@ -2942,55 +5676,578 @@ type StrictServerInterface interface {
 	// Get Identity State Transactions
 	// (GET /v1/state/transactions)
 	GetStateTransactions(ctx context.Context, request GetStateTransactionsRequestObject) (GetStateTransactionsResponseObject, error)
+	// Erase Subject Data
+	// (DELETE /v1/subjects/{did})
+	EraseSubjectData(ctx context.Context, request EraseSubjectDataRequestObject) (EraseSubjectDataResponseObject, error)
+	// Get Subject Data
+	// (GET /v1/subjects/{did})
+	GetSubjectData(ctx context.Context, request GetSubjectDataRequestObject) (GetSubjectDataResponseObject, error)
+	// Get Verifications
+	// (GET /v1/verifications)
+	GetVerifications(ctx context.Context, request GetVerificationsRequestObject) (GetVerificationsResponseObject, error)
+}
+
+type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
+
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetDocumentation operation middleware
+func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request) {
+	var request GetDocumentationRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDocumentation(ctx, request.(GetDocumentationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDocumentation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDocumentationResponseObject); ok {
+		if err := validResponse.VisitGetDocumentationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetFavicon operation middleware
+func (sh *strictHandler) GetFavicon(w http.ResponseWriter, r *http.Request) {
+	var request GetFaviconRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFavicon(ctx, request.(GetFaviconRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFavicon")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFaviconResponseObject); ok {
+		if err := validResponse.VisitGetFaviconResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetYaml operation middleware
+func (sh *strictHandler) GetYaml(w http.ResponseWriter, r *http.Request) {
+	var request GetYamlRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetYaml(ctx, request.(GetYamlRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetYaml")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetYamlResponseObject); ok {
+		if err := validResponse.VisitGetYamlResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// Health operation middleware
+func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
+	var request HealthRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Health(ctx, request.(HealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Health")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(HealthResponseObject); ok {
+		if err := validResponse.VisitHealthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// Agent operation middleware
+func (sh *strictHandler) Agent(w http.ResponseWriter, r *http.Request) {
+	var request AgentRequestObject
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+	body := AgentTextRequestBody(data)
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Agent(ctx, request.(AgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Agent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AgentResponseObject); ok {
+		if err := validResponse.VisitAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetAPIKeys operation middleware
+func (sh *strictHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	var request GetAPIKeysRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAPIKeys(ctx, request.(GetAPIKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAPIKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAPIKeysResponseObject); ok {
+		if err := validResponse.VisitGetAPIKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateAPIKey operation middleware
+func (sh *strictHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var request CreateAPIKeyRequestObject
+
+	var body CreateAPIKeyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAPIKey(ctx, request.(CreateAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAPIKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAPIKeyResponseObject); ok {
+		if err := validResponse.VisitCreateAPIKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteAPIKey operation middleware
+func (sh *strictHandler) DeleteAPIKey(w http.ResponseWriter, r *http.Request, id Id) {
+	var request DeleteAPIKeyRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAPIKey(ctx, request.(DeleteAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAPIKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteAPIKeyResponseObject); ok {
+		if err := validResponse.VisitDeleteAPIKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// AuthCallback operation middleware
+func (sh *strictHandler) AuthCallback(w http.ResponseWriter, r *http.Request, params AuthCallbackParams) {
+	var request AuthCallbackRequestObject
+
+	request.Params = params
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+	body := AuthCallbackTextRequestBody(data)
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AuthCallback(ctx, request.(AuthCallbackRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AuthCallback")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AuthCallbackResponseObject); ok {
+		if err := validResponse.VisitAuthCallbackResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
+// AuthQRCode operation middleware
+func (sh *strictHandler) AuthQRCode(w http.ResponseWriter, r *http.Request) {
+	var request AuthQRCodeRequestObject
 
-type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AuthQRCode(ctx, request.(AuthQRCodeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AuthQRCode")
+	}
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AuthQRCodeResponseObject); ok {
+		if err := validResponse.VisitAuthQRCodeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
+// GetBranding operation middleware
+func (sh *strictHandler) GetBranding(w http.ResponseWriter, r *http.Request) {
+	var request GetBrandingRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBranding(ctx, request.(GetBrandingRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBranding")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetBrandingResponseObject); ok {
+		if err := validResponse.VisitGetBrandingResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+// UpdateBranding operation middleware
+func (sh *strictHandler) UpdateBranding(w http.ResponseWriter, r *http.Request) {
+	var request UpdateBrandingRequestObject
+
+	var body UpdateBrandingJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateBranding(ctx, request.(UpdateBrandingRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateBranding")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateBrandingResponseObject); ok {
+		if err := validResponse.VisitUpdateBrandingResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+// GetConnections operation middleware
+func (sh *strictHandler) GetConnections(w http.ResponseWriter, r *http.Request, params GetConnectionsParams) {
+	var request GetConnectionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetConnections(ctx, request.(GetConnectionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetConnections")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetConnectionsResponseObject); ok {
+		if err := validResponse.VisitGetConnectionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteConnection operation middleware
+func (sh *strictHandler) DeleteConnection(w http.ResponseWriter, r *http.Request, id Id, params DeleteConnectionParams) {
+	var request DeleteConnectionRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteConnection(ctx, request.(DeleteConnectionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteConnection")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteConnectionResponseObject); ok {
+		if err := validResponse.VisitDeleteConnectionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetConnection operation middleware
+func (sh *strictHandler) GetConnection(w http.ResponseWriter, r *http.Request, id Id) {
+	var request GetConnectionRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetConnection(ctx, request.(GetConnectionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetConnection")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetConnectionResponseObject); ok {
+		if err := validResponse.VisitGetConnectionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteConnectionCredentials operation middleware
+func (sh *strictHandler) DeleteConnectionCredentials(w http.ResponseWriter, r *http.Request, id Id) {
+	var request DeleteConnectionCredentialsRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteConnectionCredentials(ctx, request.(DeleteConnectionCredentialsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteConnectionCredentials")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteConnectionCredentialsResponseObject); ok {
+		if err := validResponse.VisitDeleteConnectionCredentialsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetConnectionCredentials operation middleware
+func (sh *strictHandler) GetConnectionCredentials(w http.ResponseWriter, r *http.Request, id Id) {
+	var request GetConnectionCredentialsRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetConnectionCredentials(ctx, request.(GetConnectionCredentialsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetConnectionCredentials")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetConnectionCredentialsResponseObject); ok {
+		if err := validResponse.VisitGetConnectionCredentialsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateConnectionCredential operation middleware
+func (sh *strictHandler) CreateConnectionCredential(w http.ResponseWriter, r *http.Request, id Id) {
+	var request CreateConnectionCredentialRequestObject
+
+	request.Id = id
+
+	var body CreateConnectionCredentialJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateConnectionCredential(ctx, request.(CreateConnectionCredentialRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateConnectionCredential")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateConnectionCredentialResponseObject); ok {
+		if err := validResponse.VisitCreateConnectionCredentialResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// RevokeConnectionCredentials operation middleware
+func (sh *strictHandler) RevokeConnectionCredentials(w http.ResponseWriter, r *http.Request, id Id) {
+	var request RevokeConnectionCredentialsRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeConnectionCredentials(ctx, request.(RevokeConnectionCredentialsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeConnectionCredentials")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeConnectionCredentialsResponseObject); ok {
+		if err := validResponse.VisitRevokeConnectionCredentialsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-// GetDocumentation operation middleware
-func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request) {
-	var request GetDocumentationRequestObject
+// GetCredentials operation middleware
+func (sh *strictHandler) GetCredentials(w http.ResponseWriter, r *http.Request, params GetCredentialsParams) {
+	var request GetCredentialsRequestObject
+
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetDocumentation(ctx, request.(GetDocumentationRequestObject))
+		return sh.ssi.GetCredentials(ctx, request.(GetCredentialsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetDocumentation")
+		handler = middleware(handler, "GetCredentials")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetDocumentationResponseObject); ok {
-		if err := validResponse.VisitGetDocumentationResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetCredentialsResponseObject); ok {
+		if err := validResponse.VisitGetCredentialsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2998,23 +6255,30 @@ func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// GetFavicon operation middleware
-func (sh *strictHandler) GetFavicon(w http.ResponseWriter, r *http.Request) {
-	var request GetFaviconRequestObject
+// CreateCredential operation middleware
+func (sh *strictHandler) CreateCredential(w http.ResponseWriter, r *http.Request) {
+	var request CreateCredentialRequestObject
+
+	var body CreateCredentialJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetFavicon(ctx, request.(GetFaviconRequestObject))
+		return sh.ssi.CreateCredential(ctx, request.(CreateCredentialRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetFavicon")
+		handler = middleware(handler, "CreateCredential")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetFaviconResponseObject); ok {
-		if err := validResponse.VisitGetFaviconResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateCredentialResponseObject); ok {
+		if err := validResponse.VisitCreateCredentialResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3022,23 +6286,25 @@ func (sh *strictHandler) GetFavicon(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetYaml operation middleware
-func (sh *strictHandler) GetYaml(w http.ResponseWriter, r *http.Request) {
-	var request GetYamlRequestObject
+// GetLinks operation middleware
+func (sh *strictHandler) GetLinks(w http.ResponseWriter, r *http.Request, params GetLinksParams) {
+	var request GetLinksRequestObject
+
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetYaml(ctx, request.(GetYamlRequestObject))
+		return sh.ssi.GetLinks(ctx, request.(GetLinksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetYaml")
+		handler = middleware(handler, "GetLinks")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetYamlResponseObject); ok {
-		if err := validResponse.VisitGetYamlResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetLinksResponseObject); ok {
+		if err := validResponse.VisitGetLinksResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3046,23 +6312,30 @@ func (sh *strictHandler) GetYaml(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Health operation middleware
-func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
-	var request HealthRequestObject
+// CreateLink operation middleware
+func (sh *strictHandler) CreateLink(w http.ResponseWriter, r *http.Request) {
+	var request CreateLinkRequestObject
+
+	var body CreateLinkJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Health(ctx, request.(HealthRequestObject))
+		return sh.ssi.CreateLink(ctx, request.(CreateLinkRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Health")
+		handler = middleware(handler, "CreateLink")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(HealthResponseObject); ok {
-		if err := validResponse.VisitHealthResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateLinkResponseObject); ok {
+		if err := validResponse.VisitCreateLinkResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3070,31 +6343,33 @@ func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Agent operation middleware
-func (sh *strictHandler) Agent(w http.ResponseWriter, r *http.Request) {
-	var request AgentRequestObject
+// CreateLinkQrCodeCallback operation middleware
+func (sh *strictHandler) CreateLinkQrCodeCallback(w http.ResponseWriter, r *http.Request, params CreateLinkQrCodeCallbackParams) {
+	var request CreateLinkQrCodeCallbackRequestObject
+
+	request.Params = params
 
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
 		return
 	}
-	body := AgentTextRequestBody(data)
+	body := CreateLinkQrCodeCallbackTextRequestBody(data)
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Agent(ctx, request.(AgentRequestObject))
+		return sh.ssi.CreateLinkQrCodeCallback(ctx, request.(CreateLinkQrCodeCallbackRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Agent")
+		handler = middleware(handler, "CreateLinkQrCodeCallback")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AgentResponseObject); ok {
-		if err := validResponse.VisitAgentResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateLinkQrCodeCallbackResponseObject); ok {
+		if err := validResponse.VisitCreateLinkQrCodeCallbackResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3102,33 +6377,51 @@ func (sh *strictHandler) Agent(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// AuthCallback operation middleware
-func (sh *strictHandler) AuthCallback(w http.ResponseWriter, r *http.Request, params AuthCallbackParams) {
-	var request AuthCallbackRequestObject
+// DeleteLink operation middleware
+func (sh *strictHandler) DeleteLink(w http.ResponseWriter, r *http.Request, id Id) {
+	var request DeleteLinkRequestObject
 
-	request.Params = params
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteLink(ctx, request.(DeleteLinkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteLink")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
 
-	data, err := io.ReadAll(r.Body)
 	if err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
-		return
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteLinkResponseObject); ok {
+		if err := validResponse.VisitDeleteLinkResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
 	}
-	body := AuthCallbackTextRequestBody(data)
-	request.Body = &body
+}
+
+// GetLink operation middleware
+func (sh *strictHandler) GetLink(w http.ResponseWriter, r *http.Request, id Id) {
+	var request GetLinkRequestObject
+
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AuthCallback(ctx, request.(AuthCallbackRequestObject))
+		return sh.ssi.GetLink(ctx, request.(GetLinkRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AuthCallback")
+		handler = middleware(handler, "GetLink")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AuthCallbackResponseObject); ok {
-		if err := validResponse.VisitAuthCallbackResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetLinkResponseObject); ok {
+		if err := validResponse.VisitGetLinkResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3136,23 +6429,32 @@ func (sh *strictHandler) AuthCallback(w http.ResponseWriter, r *http.Request, pa
 	}
 }
 
-// AuthQRCode operation middleware
-func (sh *strictHandler) AuthQRCode(w http.ResponseWriter, r *http.Request) {
-	var request AuthQRCodeRequestObject
+// AcivateLink operation middleware
+func (sh *strictHandler) AcivateLink(w http.ResponseWriter, r *http.Request, id Id) {
+	var request AcivateLinkRequestObject
+
+	request.Id = id
+
+	var body AcivateLinkJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AuthQRCode(ctx, request.(AuthQRCodeRequestObject))
+		return sh.ssi.AcivateLink(ctx, request.(AcivateLinkRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AuthQRCode")
+		handler = middleware(handler, "AcivateLink")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AuthQRCodeResponseObject); ok {
-		if err := validResponse.VisitAuthQRCodeResponse(w); err != nil {
+	} else if validResponse, ok := response.(AcivateLinkResponseObject); ok {
+		if err := validResponse.VisitAcivateLinkResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3160,25 +6462,26 @@ func (sh *strictHandler) AuthQRCode(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetConnections operation middleware
-func (sh *strictHandler) GetConnections(w http.ResponseWriter, r *http.Request, params GetConnectionsParams) {
-	var request GetConnectionsRequestObject
+// GetLinkQRCode operation middleware
+func (sh *strictHandler) GetLinkQRCode(w http.ResponseWriter, r *http.Request, id Id, params GetLinkQRCodeParams) {
+	var request GetLinkQRCodeRequestObject
 
+	request.Id = id
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetConnections(ctx, request.(GetConnectionsRequestObject))
+		return sh.ssi.GetLinkQRCode(ctx, request.(GetLinkQRCodeRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetConnections")
+		handler = middleware(handler, "GetLinkQRCode")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetConnectionsResponseObject); ok {
-		if err := validResponse.VisitGetConnectionsResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetLinkQRCodeResponseObject); ok {
+		if err := validResponse.VisitGetLinkQRCodeResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3186,26 +6489,25 @@ func (sh *strictHandler) GetConnections(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// DeleteConnection operation middleware
-func (sh *strictHandler) DeleteConnection(w http.ResponseWriter, r *http.Request, id Id, params DeleteConnectionParams) {
-	var request DeleteConnectionRequestObject
+// CreateLinkQrCode operation middleware
+func (sh *strictHandler) CreateLinkQrCode(w http.ResponseWriter, r *http.Request, id Id) {
+	var request CreateLinkQrCodeRequestObject
 
 	request.Id = id
-	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteConnection(ctx, request.(DeleteConnectionRequestObject))
+		return sh.ssi.CreateLinkQrCode(ctx, request.(CreateLinkQrCodeRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteConnection")
+		handler = middleware(handler, "CreateLinkQrCode")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteConnectionResponseObject); ok {
-		if err := validResponse.VisitDeleteConnectionResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateLinkQrCodeResponseObject); ok {
+		if err := validResponse.VisitCreateLinkQrCodeResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3213,25 +6515,26 @@ func (sh *strictHandler) DeleteConnection(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// GetConnection operation middleware
-func (sh *strictHandler) GetConnection(w http.ResponseWriter, r *http.Request, id Id) {
-	var request GetConnectionRequestObject
+// GetLinkSessions operation middleware
+func (sh *strictHandler) GetLinkSessions(w http.ResponseWriter, r *http.Request, id Id, params GetLinkSessionsParams) {
+	var request GetLinkSessionsRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetConnection(ctx, request.(GetConnectionRequestObject))
+		return sh.ssi.GetLinkSessions(ctx, request.(GetLinkSessionsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetConnection")
+		handler = middleware(handler, "GetLinkSessions")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetConnectionResponseObject); ok {
-		if err := validResponse.VisitGetConnectionResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetLinkSessionsResponseObject); ok {
+		if err := validResponse.VisitGetLinkSessionsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3239,25 +6542,26 @@ func (sh *strictHandler) GetConnection(w http.ResponseWriter, r *http.Request, i
 	}
 }
 
-// DeleteConnectionCredentials operation middleware
-func (sh *strictHandler) DeleteConnectionCredentials(w http.ResponseWriter, r *http.Request, id Id) {
-	var request DeleteConnectionCredentialsRequestObject
+// GetLinkSession operation middleware
+func (sh *strictHandler) GetLinkSession(w http.ResponseWriter, r *http.Request, id Id, sessionID uuid.UUID) {
+	var request GetLinkSessionRequestObject
 
 	request.Id = id
+	request.SessionID = sessionID
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteConnectionCredentials(ctx, request.(DeleteConnectionCredentialsRequestObject))
+		return sh.ssi.GetLinkSession(ctx, request.(GetLinkSessionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteConnectionCredentials")
+		handler = middleware(handler, "GetLinkSession")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteConnectionCredentialsResponseObject); ok {
-		if err := validResponse.VisitDeleteConnectionCredentialsResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetLinkSessionResponseObject); ok {
+		if err := validResponse.VisitGetLinkSessionResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3265,25 +6569,25 @@ func (sh *strictHandler) DeleteConnectionCredentials(w http.ResponseWriter, r *h
 	}
 }
 
-// RevokeConnectionCredentials operation middleware
-func (sh *strictHandler) RevokeConnectionCredentials(w http.ResponseWriter, r *http.Request, id Id) {
-	var request RevokeConnectionCredentialsRequestObject
+// GetRevocationStatus operation middleware
+func (sh *strictHandler) GetRevocationStatus(w http.ResponseWriter, r *http.Request, nonce PathNonce) {
+	var request GetRevocationStatusRequestObject
 
-	request.Id = id
+	request.Nonce = nonce
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.RevokeConnectionCredentials(ctx, request.(RevokeConnectionCredentialsRequestObject))
+		return sh.ssi.GetRevocationStatus(ctx, request.(GetRevocationStatusRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RevokeConnectionCredentials")
+		handler = middleware(handler, "GetRevocationStatus")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RevokeConnectionCredentialsResponseObject); ok {
-		if err := validResponse.VisitRevokeConnectionCredentialsResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetRevocationStatusResponseObject); ok {
+		if err := validResponse.VisitGetRevocationStatusResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3291,25 +6595,25 @@ func (sh *strictHandler) RevokeConnectionCredentials(w http.ResponseWriter, r *h
 	}
 }
 
-// GetCredentials operation middleware
-func (sh *strictHandler) GetCredentials(w http.ResponseWriter, r *http.Request, params GetCredentialsParams) {
-	var request GetCredentialsRequestObject
+// RevokeCredential operation middleware
+func (sh *strictHandler) RevokeCredential(w http.ResponseWriter, r *http.Request, nonce PathNonce) {
+	var request RevokeCredentialRequestObject
 
-	request.Params = params
+	request.Nonce = nonce
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetCredentials(ctx, request.(GetCredentialsRequestObject))
+		return sh.ssi.RevokeCredential(ctx, request.(RevokeCredentialRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetCredentials")
+		handler = middleware(handler, "RevokeCredential")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetCredentialsResponseObject); ok {
-		if err := validResponse.VisitGetCredentialsResponse(w); err != nil {
+	} else if validResponse, ok := response.(RevokeCredentialResponseObject); ok {
+		if err := validResponse.VisitRevokeCredentialResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3317,30 +6621,25 @@ func (sh *strictHandler) GetCredentials(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// CreateCredential operation middleware
-func (sh *strictHandler) CreateCredential(w http.ResponseWriter, r *http.Request) {
-	var request CreateCredentialRequestObject
+// DeleteCredential operation middleware
+func (sh *strictHandler) DeleteCredential(w http.ResponseWriter, r *http.Request, id Id) {
+	var request DeleteCredentialRequestObject
 
-	var body CreateCredentialJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateCredential(ctx, request.(CreateCredentialRequestObject))
+		return sh.ssi.DeleteCredential(ctx, request.(DeleteCredentialRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateCredential")
+		handler = middleware(handler, "DeleteCredential")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateCredentialResponseObject); ok {
-		if err := validResponse.VisitCreateCredentialResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteCredentialResponseObject); ok {
+		if err := validResponse.VisitDeleteCredentialResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3348,25 +6647,26 @@ func (sh *strictHandler) CreateCredential(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// GetLinks operation middleware
-func (sh *strictHandler) GetLinks(w http.ResponseWriter, r *http.Request, params GetLinksParams) {
-	var request GetLinksRequestObject
+// GetCredential operation middleware
+func (sh *strictHandler) GetCredential(w http.ResponseWriter, r *http.Request, id Id, params GetCredentialParams) {
+	var request GetCredentialRequestObject
 
+	request.Id = id
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetLinks(ctx, request.(GetLinksRequestObject))
+		return sh.ssi.GetCredential(ctx, request.(GetCredentialRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetLinks")
+		handler = middleware(handler, "GetCredential")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetLinksResponseObject); ok {
-		if err := validResponse.VisitGetLinksResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetCredentialResponseObject); ok {
+		if err := validResponse.VisitGetCredentialResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3374,30 +6674,26 @@ func (sh *strictHandler) GetLinks(w http.ResponseWriter, r *http.Request, params
 	}
 }
 
-// CreateLink operation middleware
-func (sh *strictHandler) CreateLink(w http.ResponseWriter, r *http.Request) {
-	var request CreateLinkRequestObject
+// GetCredentialQrCode operation middleware
+func (sh *strictHandler) GetCredentialQrCode(w http.ResponseWriter, r *http.Request, id Id, params GetCredentialQrCodeParams) {
+	var request GetCredentialQrCodeRequestObject
 
-	var body CreateLinkJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateLink(ctx, request.(CreateLinkRequestObject))
+		return sh.ssi.GetCredentialQrCode(ctx, request.(GetCredentialQrCodeRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateLink")
+		handler = middleware(handler, "GetCredentialQrCode")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateLinkResponseObject); ok {
-		if err := validResponse.VisitCreateLinkResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetCredentialQrCodeResponseObject); ok {
+		if err := validResponse.VisitGetCredentialQrCodeResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3405,33 +6701,32 @@ func (sh *strictHandler) CreateLink(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateLinkQrCodeCallback operation middleware
-func (sh *strictHandler) CreateLinkQrCodeCallback(w http.ResponseWriter, r *http.Request, params CreateLinkQrCodeCallbackParams) {
-	var request CreateLinkQrCodeCallbackRequestObject
+// ReissueCredential operation middleware
+func (sh *strictHandler) ReissueCredential(w http.ResponseWriter, r *http.Request, id Id) {
+	var request ReissueCredentialRequestObject
 
-	request.Params = params
+	request.Id = id
 
-	data, err := io.ReadAll(r.Body)
-	if err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
+	var body ReissueCredentialJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
 	}
-	body := CreateLinkQrCodeCallbackTextRequestBody(data)
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateLinkQrCodeCallback(ctx, request.(CreateLinkQrCodeCallbackRequestObject))
+		return sh.ssi.ReissueCredential(ctx, request.(ReissueCredentialRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateLinkQrCodeCallback")
+		handler = middleware(handler, "ReissueCredential")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateLinkQrCodeCallbackResponseObject); ok {
-		if err := validResponse.VisitCreateLinkQrCodeCallbackResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReissueCredentialResponseObject); ok {
+		if err := validResponse.VisitReissueCredentialResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3439,25 +6734,23 @@ func (sh *strictHandler) CreateLinkQrCodeCallback(w http.ResponseWriter, r *http
 	}
 }
 
-// DeleteLink operation middleware
-func (sh *strictHandler) DeleteLink(w http.ResponseWriter, r *http.Request, id Id) {
-	var request DeleteLinkRequestObject
-
-	request.Id = id
+// GetHookDeliveries operation middleware
+func (sh *strictHandler) GetHookDeliveries(w http.ResponseWriter, r *http.Request) {
+	var request GetHookDeliveriesRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteLink(ctx, request.(DeleteLinkRequestObject))
+		return sh.ssi.GetHookDeliveries(ctx, request.(GetHookDeliveriesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteLink")
+		handler = middleware(handler, "GetHookDeliveries")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteLinkResponseObject); ok {
-		if err := validResponse.VisitDeleteLinkResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetHookDeliveriesResponseObject); ok {
+		if err := validResponse.VisitGetHookDeliveriesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3465,25 +6758,25 @@ func (sh *strictHandler) DeleteLink(w http.ResponseWriter, r *http.Request, id I
 	}
 }
 
-// GetLink operation middleware
-func (sh *strictHandler) GetLink(w http.ResponseWriter, r *http.Request, id Id) {
-	var request GetLinkRequestObject
+// GetSchemas operation middleware
+func (sh *strictHandler) GetSchemas(w http.ResponseWriter, r *http.Request, params GetSchemasParams) {
+	var request GetSchemasRequestObject
 
-	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetLink(ctx, request.(GetLinkRequestObject))
+		return sh.ssi.GetSchemas(ctx, request.(GetSchemasRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetLink")
+		handler = middleware(handler, "GetSchemas")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetLinkResponseObject); ok {
-		if err := validResponse.VisitGetLinkResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetSchemasResponseObject); ok {
+		if err := validResponse.VisitGetSchemasResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3491,13 +6784,11 @@ func (sh *strictHandler) GetLink(w http.ResponseWriter, r *http.Request, id Id)
 	}
 }
 
-// AcivateLink operation middleware
-func (sh *strictHandler) AcivateLink(w http.ResponseWriter, r *http.Request, id Id) {
-	var request AcivateLinkRequestObject
-
-	request.Id = id
+// ImportSchema operation middleware
+func (sh *strictHandler) ImportSchema(w http.ResponseWriter, r *http.Request) {
+	var request ImportSchemaRequestObject
 
-	var body AcivateLinkJSONRequestBody
+	var body ImportSchemaJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -3505,18 +6796,18 @@ func (sh *strictHandler) AcivateLink(w http.ResponseWriter, r *http.Request, id
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AcivateLink(ctx, request.(AcivateLinkRequestObject))
+		return sh.ssi.ImportSchema(ctx, request.(ImportSchemaRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AcivateLink")
+		handler = middleware(handler, "ImportSchema")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AcivateLinkResponseObject); ok {
-		if err := validResponse.VisitAcivateLinkResponse(w); err != nil {
+	} else if validResponse, ok := response.(ImportSchemaResponseObject); ok {
+		if err := validResponse.VisitImportSchemaResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3524,26 +6815,25 @@ func (sh *strictHandler) AcivateLink(w http.ResponseWriter, r *http.Request, id
 	}
 }
 
-// GetLinkQRCode operation middleware
-func (sh *strictHandler) GetLinkQRCode(w http.ResponseWriter, r *http.Request, id Id, params GetLinkQRCodeParams) {
-	var request GetLinkQRCodeRequestObject
+// PurgeSchemaCache operation middleware
+func (sh *strictHandler) PurgeSchemaCache(w http.ResponseWriter, r *http.Request, params PurgeSchemaCacheParams) {
+	var request PurgeSchemaCacheRequestObject
 
-	request.Id = id
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetLinkQRCode(ctx, request.(GetLinkQRCodeRequestObject))
+		return sh.ssi.PurgeSchemaCache(ctx, request.(PurgeSchemaCacheRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetLinkQRCode")
+		handler = middleware(handler, "PurgeSchemaCache")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetLinkQRCodeResponseObject); ok {
-		if err := validResponse.VisitGetLinkQRCodeResponse(w); err != nil {
+	} else if validResponse, ok := response.(PurgeSchemaCacheResponseObject); ok {
+		if err := validResponse.VisitPurgeSchemaCacheResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3551,25 +6841,30 @@ func (sh *strictHandler) GetLinkQRCode(w http.ResponseWriter, r *http.Request, i
 	}
 }
 
-// CreateLinkQrCode operation middleware
-func (sh *strictHandler) CreateLinkQrCode(w http.ResponseWriter, r *http.Request, id Id) {
-	var request CreateLinkQrCodeRequestObject
+// BuildSchema operation middleware
+func (sh *strictHandler) BuildSchema(w http.ResponseWriter, r *http.Request) {
+	var request BuildSchemaRequestObject
 
-	request.Id = id
+	var body BuildSchemaJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateLinkQrCode(ctx, request.(CreateLinkQrCodeRequestObject))
+		return sh.ssi.BuildSchema(ctx, request.(BuildSchemaRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateLinkQrCode")
+		handler = middleware(handler, "BuildSchema")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateLinkQrCodeResponseObject); ok {
-		if err := validResponse.VisitCreateLinkQrCodeResponse(w); err != nil {
+	} else if validResponse, ok := response.(BuildSchemaResponseObject); ok {
+		if err := validResponse.VisitBuildSchemaResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3577,25 +6872,30 @@ func (sh *strictHandler) CreateLinkQrCode(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// GetRevocationStatus operation middleware
-func (sh *strictHandler) GetRevocationStatus(w http.ResponseWriter, r *http.Request, nonce PathNonce) {
-	var request GetRevocationStatusRequestObject
+// CreateSchema operation middleware
+func (sh *strictHandler) CreateSchema(w http.ResponseWriter, r *http.Request) {
+	var request CreateSchemaRequestObject
 
-	request.Nonce = nonce
+	var body CreateSchemaJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetRevocationStatus(ctx, request.(GetRevocationStatusRequestObject))
+		return sh.ssi.CreateSchema(ctx, request.(CreateSchemaRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetRevocationStatus")
+		handler = middleware(handler, "CreateSchema")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetRevocationStatusResponseObject); ok {
-		if err := validResponse.VisitGetRevocationStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateSchemaResponseObject); ok {
+		if err := validResponse.VisitCreateSchemaResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3603,25 +6903,25 @@ func (sh *strictHandler) GetRevocationStatus(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// RevokeCredential operation middleware
-func (sh *strictHandler) RevokeCredential(w http.ResponseWriter, r *http.Request, nonce PathNonce) {
-	var request RevokeCredentialRequestObject
+// GetSchema operation middleware
+func (sh *strictHandler) GetSchema(w http.ResponseWriter, r *http.Request, id Id) {
+	var request GetSchemaRequestObject
 
-	request.Nonce = nonce
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.RevokeCredential(ctx, request.(RevokeCredentialRequestObject))
+		return sh.ssi.GetSchema(ctx, request.(GetSchemaRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RevokeCredential")
+		handler = middleware(handler, "GetSchema")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RevokeCredentialResponseObject); ok {
-		if err := validResponse.VisitRevokeCredentialResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetSchemaResponseObject); ok {
+		if err := validResponse.VisitGetSchemaResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3629,25 +6929,32 @@ func (sh *strictHandler) RevokeCredential(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// DeleteCredential operation middleware
-func (sh *strictHandler) DeleteCredential(w http.ResponseWriter, r *http.Request, id Id) {
-	var request DeleteCredentialRequestObject
+// UpdateSchema operation middleware
+func (sh *strictHandler) UpdateSchema(w http.ResponseWriter, r *http.Request, id Id) {
+	var request UpdateSchemaRequestObject
 
 	request.Id = id
 
+	var body UpdateSchemaJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteCredential(ctx, request.(DeleteCredentialRequestObject))
+		return sh.ssi.UpdateSchema(ctx, request.(UpdateSchemaRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteCredential")
+		handler = middleware(handler, "UpdateSchema")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteCredentialResponseObject); ok {
-		if err := validResponse.VisitDeleteCredentialResponse(w); err != nil {
+	} else if validResponse, ok := response.(UpdateSchemaResponseObject); ok {
+		if err := validResponse.VisitUpdateSchemaResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3655,25 +6962,25 @@ func (sh *strictHandler) DeleteCredential(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// GetCredential operation middleware
-func (sh *strictHandler) GetCredential(w http.ResponseWriter, r *http.Request, id Id) {
-	var request GetCredentialRequestObject
+// GetSchemaContext operation middleware
+func (sh *strictHandler) GetSchemaContext(w http.ResponseWriter, r *http.Request, id Id) {
+	var request GetSchemaContextRequestObject
 
 	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetCredential(ctx, request.(GetCredentialRequestObject))
+		return sh.ssi.GetSchemaContext(ctx, request.(GetSchemaContextRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetCredential")
+		handler = middleware(handler, "GetSchemaContext")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetCredentialResponseObject); ok {
-		if err := validResponse.VisitGetCredentialResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetSchemaContextResponseObject); ok {
+		if err := validResponse.VisitGetSchemaContextResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3681,25 +6988,26 @@ func (sh *strictHandler) GetCredential(w http.ResponseWriter, r *http.Request, i
 	}
 }
 
-// GetCredentialQrCode operation middleware
-func (sh *strictHandler) GetCredentialQrCode(w http.ResponseWriter, r *http.Request, id Id) {
-	var request GetCredentialQrCodeRequestObject
+// TestPostIssuanceHook operation middleware
+func (sh *strictHandler) TestPostIssuanceHook(w http.ResponseWriter, r *http.Request, id Id, name string) {
+	var request TestPostIssuanceHookRequestObject
 
 	request.Id = id
+	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetCredentialQrCode(ctx, request.(GetCredentialQrCodeRequestObject))
+		return sh.ssi.TestPostIssuanceHook(ctx, request.(TestPostIssuanceHookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetCredentialQrCode")
+		handler = middleware(handler, "TestPostIssuanceHook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetCredentialQrCodeResponseObject); ok {
-		if err := validResponse.VisitGetCredentialQrCodeResponse(w); err != nil {
+	} else if validResponse, ok := response.(TestPostIssuanceHookResponseObject); ok {
+		if err := validResponse.VisitTestPostIssuanceHookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3707,25 +7015,25 @@ func (sh *strictHandler) GetCredentialQrCode(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// GetSchemas operation middleware
-func (sh *strictHandler) GetSchemas(w http.ResponseWriter, r *http.Request, params GetSchemasParams) {
-	var request GetSchemasRequestObject
+// GetSchemaJSON operation middleware
+func (sh *strictHandler) GetSchemaJSON(w http.ResponseWriter, r *http.Request, id Id) {
+	var request GetSchemaJSONRequestObject
 
-	request.Params = params
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetSchemas(ctx, request.(GetSchemasRequestObject))
+		return sh.ssi.GetSchemaJSON(ctx, request.(GetSchemaJSONRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetSchemas")
+		handler = middleware(handler, "GetSchemaJSON")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetSchemasResponseObject); ok {
-		if err := validResponse.VisitGetSchemasResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetSchemaJSONResponseObject); ok {
+		if err := validResponse.VisitGetSchemaJSONResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3733,30 +7041,26 @@ func (sh *strictHandler) GetSchemas(w http.ResponseWriter, r *http.Request, para
 	}
 }
 
-// ImportSchema operation middleware
-func (sh *strictHandler) ImportSchema(w http.ResponseWriter, r *http.Request) {
-	var request ImportSchemaRequestObject
+// GetSchemaStats operation middleware
+func (sh *strictHandler) GetSchemaStats(w http.ResponseWriter, r *http.Request, id Id, params GetSchemaStatsParams) {
+	var request GetSchemaStatsRequestObject
 
-	var body ImportSchemaJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ImportSchema(ctx, request.(ImportSchemaRequestObject))
+		return sh.ssi.GetSchemaStats(ctx, request.(GetSchemaStatsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ImportSchema")
+		handler = middleware(handler, "GetSchemaStats")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ImportSchemaResponseObject); ok {
-		if err := validResponse.VisitImportSchemaResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetSchemaStatsResponseObject); ok {
+		if err := validResponse.VisitGetSchemaStatsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3764,25 +7068,25 @@ func (sh *strictHandler) ImportSchema(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetSchema operation middleware
-func (sh *strictHandler) GetSchema(w http.ResponseWriter, r *http.Request, id Id) {
-	var request GetSchemaRequestObject
+// GetSchemaVersions operation middleware
+func (sh *strictHandler) GetSchemaVersions(w http.ResponseWriter, r *http.Request, id Id) {
+	var request GetSchemaVersionsRequestObject
 
 	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetSchema(ctx, request.(GetSchemaRequestObject))
+		return sh.ssi.GetSchemaVersions(ctx, request.(GetSchemaVersionsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetSchema")
+		handler = middleware(handler, "GetSchemaVersions")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetSchemaResponseObject); ok {
-		if err := validResponse.VisitGetSchemaResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetSchemaVersionsResponseObject); ok {
+		if err := validResponse.VisitGetSchemaVersionsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -3863,9 +7167,11 @@ func (sh *strictHandler) GetStateStatus(w http.ResponseWriter, r *http.Request)
 }
 
 // GetStateTransactions operation middleware
-func (sh *strictHandler) GetStateTransactions(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) GetStateTransactions(w http.ResponseWriter, r *http.Request, params GetStateTransactionsParams) {
 	var request GetStateTransactionsRequestObject
 
+	request.Params = params
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetStateTransactions(ctx, request.(GetStateTransactionsRequestObject))
 	}
@@ -3885,3 +7191,81 @@ func (sh *strictHandler) GetStateTransactions(w http.ResponseWriter, r *http.Req
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
 	}
 }
+
+// EraseSubjectData operation middleware
+func (sh *strictHandler) EraseSubjectData(w http.ResponseWriter, r *http.Request, did PathDid) {
+	var request EraseSubjectDataRequestObject
+
+	request.Did = did
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.EraseSubjectData(ctx, request.(EraseSubjectDataRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "EraseSubjectData")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(EraseSubjectDataResponseObject); ok {
+		if err := validResponse.VisitEraseSubjectDataResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetSubjectData operation middleware
+func (sh *strictHandler) GetSubjectData(w http.ResponseWriter, r *http.Request, did PathDid) {
+	var request GetSubjectDataRequestObject
+
+	request.Did = did
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSubjectData(ctx, request.(GetSubjectDataRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSubjectData")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSubjectDataResponseObject); ok {
+		if err := validResponse.VisitGetSubjectDataResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetVerifications operation middleware
+func (sh *strictHandler) GetVerifications(w http.ResponseWriter, r *http.Request, params GetVerificationsParams) {
+	var request GetVerificationsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVerifications(ctx, request.(GetVerificationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVerifications")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetVerificationsResponseObject); ok {
+		if err := validResponse.VisitGetVerificationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
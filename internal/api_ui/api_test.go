@@ -3,11 +3,14 @@ package api_ui
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
 )
 
 // TestValid verifies that the api spec can ve validated by github.com/getkin/kin-openapi
@@ -20,3 +23,37 @@ func TestValid(t *testing.T) {
 
 	assert.NoError(t, spec.Validate(context.Background()))
 }
+
+// TestRolesClassifyEveryOperation verifies that every operation with basicAuth is in one of the role lists, so the
+// operators and the read-only clients get the operations they should when one is added
+func TestRolesClassifyEveryOperation(t *testing.T) {
+	file, err := os.ReadFile("../../api_ui/api.yaml")
+	require.NoError(t, err)
+	spec, err := openapi3.NewLoader().LoadFromData(file)
+	require.NoError(t, err)
+
+	for path, item := range spec.Paths {
+		for method, operation := range item.Operations() {
+			if operation.Security == nil || !hasBasicAuth(*operation.Security) {
+				continue
+			}
+			id := strings.ToUpper(operation.OperationID[:1]) + operation.OperationID[1:]
+			lists := 0
+			for _, operations := range []map[string]bool{domain.ReadOnlyOperations, domain.OperatorOperations, domain.AdminOperations} {
+				if operations[id] {
+					lists++
+				}
+			}
+			assert.Equal(t, 1, lists, "%s %s %s must be in one of the role lists", method, path, id)
+		}
+	}
+}
+
+func hasBasicAuth(requirements openapi3.SecurityRequirements) bool {
+	for _, requirement := range requirements {
+		if _, ok := requirement["basicAuth"]; ok {
+			return true
+		}
+	}
+	return false
+}
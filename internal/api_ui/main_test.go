@@ -10,9 +10,11 @@ import (
 	"github.com/go-chi/chi/v5"
 	vaultApi "github.com/hashicorp/vault/api"
 	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
 	"github.com/iden3/iden3comm"
 
 	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/db/tests"
@@ -138,12 +140,12 @@ func (kpm *KMSMock) RegisterKeyProvider(kt kms.KeyType, kp kms.KeyProvider) erro
 	return nil
 }
 
-func (kpm *KMSMock) CreateKey(kt kms.KeyType, identity *core.DID) (kms.KeyID, error) {
+func (kpm *KMSMock) CreateKey(ctx context.Context, kt kms.KeyType, identity *core.DID) (kms.KeyID, error) {
 	var key kms.KeyID
 	return key, nil
 }
 
-func (kpm *KMSMock) PublicKey(keyID kms.KeyID) ([]byte, error) {
+func (kpm *KMSMock) PublicKey(ctx context.Context, keyID kms.KeyID) ([]byte, error) {
 	var pubKey []byte
 	return pubKey, nil
 }
@@ -191,3 +193,24 @@ func NewConnectionsMock() ports.ConnectionsService {
 func NewLinkMock() ports.LinkService {
 	return nil
 }
+
+func NewSubjectMock() ports.SubjectService {
+	return nil
+}
+
+func NewNotificationMock() ports.NotificationService {
+	return nil
+}
+
+func NewBrandingMock() ports.BrandingService {
+	return nil
+}
+
+type notificationGatewayMock struct {
+	result *domain.UserNotificationResult
+	err    error
+}
+
+func (n *notificationGatewayMock) Notify(_ context.Context, _ json.RawMessage, _ verifiable.DIDDocument) (*domain.UserNotificationResult, error) {
+	return n.result, n.err
+}
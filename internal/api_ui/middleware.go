@@ -4,12 +4,33 @@ import (
 	"context"
 	"crypto/subtle"
 	"errors"
+	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
 
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
 	apiErrors "github.com/polygonid/sh-id-platform/internal/errors"
 	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/pkg/antiabuse"
+)
+
+const (
+	// challengeResponseHeader carries the solution of the anti-abuse challenge
+	challengeResponseHeader = "X-Challenge-Response"
+	// issuerDIDHeader selects the issuer identity a request is scoped to
+	issuerDIDHeader = "X-Issuer-DID"
+	// issuerDIDParam selects the issuer identity of the wallet callbacks and the public link pages, that can't set
+	// headers
+	issuerDIDParam = "issuerDID"
 )
 
 // LogMiddleware returns a middleware that adds general log configuration to each context request
@@ -25,23 +46,327 @@ func LogMiddleware(ctx context.Context) StrictMiddlewareFunc {
 	}
 }
 
+// MaintenanceMiddleware rejects with a 503 and a Retry-After the requests that change the node while the maintenance
+// mode is enabled. The reads and the domain.MaintenanceExemptOperations are always served, and so is every request
+// when the mode can't be read.
+func MaintenanceMiddleware(maintenanceService ports.MaintenanceService) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+			if !domain.MaintenanceApplies(r.Method, operationID) {
+				return f(ctx, w, r, args)
+			}
+			maintenance, err := maintenanceService.Get(ctx)
+			if err != nil {
+				log.Error(ctx, "reading the maintenance mode", "err", err)
+				return f(ctx, w, r, args)
+			}
+			if !maintenance.Rejects(r.Method, operationID) {
+				return f(ctx, w, r, args)
+			}
+			message := "the node is under maintenance"
+			if maintenance.Message != "" {
+				message += ": " + maintenance.Message
+			}
+			return nil, apiErrors.UnavailableError{Err: errors.New(message), RetryAfter: maintenance.RetryAfter}
+		}
+	}
+}
+
+// RegionMiddleware rejects with a 503 and a Retry-After the requests that change the node while the region of the
+// server is passive. The reads and the domain.PassiveExemptOperations are always served, and so is every request when
+// the role of the region can't be read.
+func RegionMiddleware(regionService ports.RegionService, retryAfter time.Duration) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+			if !domain.PassiveApplies(r.Method, operationID) {
+				return f(ctx, w, r, args)
+			}
+			region, err := regionService.Get(ctx)
+			if err != nil {
+				log.Error(ctx, "reading the region", "err", err)
+				return f(ctx, w, r, args)
+			}
+			if !region.Rejects(r.Method, operationID) {
+				return f(ctx, w, r, args)
+			}
+			message := fmt.Sprintf("the region %s is passive", region.Name)
+			if region.Lease != nil && region.Lease.Region != region.Name {
+				message += ", the active one is " + region.Lease.Region
+			}
+			return nil, apiErrors.UnavailableError{Err: errors.New(message), RetryAfter: retryAfter}
+		}
+	}
+}
+
+type piiScopeKey struct{}
+
+// delegatedOperations are the operations that the delegated api keys can call
+var delegatedOperations = map[string]bool{
+	"CreateCredential":    true,
+	"GetCredential":       true,
+	"GetCredentialQrCode": true,
+	"CreateLinkQrCode":    true,
+}
+
 // BasicAuthMiddleware returns a middleware that performs an http basic authorization for endpoints configured with
 // basic auth in the api spec.
 // In uses the BasicAuthScopes value in context to figure if and endpoint needs authorization or not, because this
 // value is injected automatically by openapi when basic auth is selected
 func BasicAuthMiddleware(ctx context.Context, user, pass string) StrictMiddlewareFunc {
+	return BasicAuthWithPIIMiddleware(ctx, user, pass, "", "")
+}
+
+// BasicAuthWithPIIMiddleware works like BasicAuthMiddleware but it also accepts the piiUser and piiPass credentials.
+// Requests authorized with them have the PII scope and get the schema PII attributes unmasked.
+func BasicAuthWithPIIMiddleware(ctx context.Context, user, pass, piiUser, piiPass string) StrictMiddlewareFunc {
+	return BasicAuthWithAPIKeysMiddleware(ctx, user, pass, piiUser, piiPass, core.DID{}, nil)
+}
+
+// BasicAuthWithAPIKeysMiddleware works like BasicAuthWithPIIMiddleware but it also accepts the delegated api keys of
+// the issuer, with the key id as user and its secret as password. The keys can only call the delegatedOperations, and
+// the claims service limits them to the schema types and links of the key. A nil claimsService disables the keys.
+func BasicAuthWithAPIKeysMiddleware(ctx context.Context, user, pass, piiUser, piiPass string, issuerDID core.DID, claimsService ports.ClaimsService) StrictMiddlewareFunc {
+	return BasicAuthWithTenantsMiddleware(ctx, user, pass, piiUser, piiPass, nil, issuerDID, claimsService)
+}
+
+// BasicAuthWithTenantsMiddleware works like BasicAuthWithAPIKeysMiddleware but it also accepts the credentials of the
+// operators of the tenants. They can only call the requests scoped to their identity, which is the scope of their
+// requests that don't select one, and allowed by their role. The api keys with a role call the operations of the role
+// instead of the delegatedOperations. The api keys authenticate against the identity of the request, issuerDID by default.
+func BasicAuthWithTenantsMiddleware(_ context.Context, user, pass, piiUser, piiPass string, tenants []config.Tenant, issuerDID core.DID, claimsService ports.ClaimsService) StrictMiddlewareFunc {
 	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
 		return func(ctxReq context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
-			if ctxReq.Value(BasicAuthScopes) != nil && user != "" && pass != "" {
+			if ctxReq.Value(BasicAuthScopes) != nil {
 				userReq, passReq, ok := r.BasicAuth()
-				if !ok {
-					return nil, apiErrors.AuthError{Err: errors.New("unauthorized")}
+				scope := tenantFromContext(ctxReq, issuerDID)
+				key, err := authenticateAPIKey(ctxReq, claimsService, scope.did, userReq, passReq, ok)
+				if err != nil {
+					return nil, err
 				}
-				if subtle.ConstantTimeCompare([]byte(user), []byte(userReq)) != 1 || subtle.ConstantTimeCompare([]byte(pass), []byte(passReq)) != 1 {
+				operator, isOperator := tenantOperator(tenants, userReq, passReq, ok)
+				if ok && validCredentials(piiUser, piiPass, userReq, passReq) {
+					ctxReq = context.WithValue(ctxReq, piiScopeKey{}, true)
+				} else if key != nil {
+					if !apiKeyAllows(key, r.Method, operationID) {
+						log.Warn(ctxReq, "api key calling a forbidden operation", "apiKey", key.ID, "role", key.Role, "operation", operationID)
+						return nil, apiErrors.ForbiddenError{Err: errors.New("forbidden")}
+					}
+					ctxReq = services.WithAPIKey(ctxReq, key)
+				} else if isOperator {
+					if scope.selected && scope.did.String() != operator.DID.String() {
+						log.Warn(ctxReq, "tenant operator calling another identity", "user", operator.User, "issuerDID", scope.did.String())
+						return nil, apiErrors.ForbiddenError{Err: errors.New("forbidden")}
+					}
+					if operator.Role != "" && !operator.Role.Allows(r.Method, operationID) {
+						log.Warn(ctxReq, "tenant operator calling a forbidden operation", "user", operator.User, "role", operator.Role, "operation", operationID)
+						return nil, apiErrors.ForbiddenError{Err: errors.New("forbidden")}
+					}
+					ctxReq = WithIssuerDID(ctxReq, operator.DID)
+				} else if user != "" && pass != "" && (!ok || !validCredentials(user, pass, userReq, passReq)) {
 					return nil, apiErrors.AuthError{Err: errors.New("unauthorized")}
 				}
 			}
+			return f(ctxReq, w, r, args)
+		}
+	}
+}
+
+// apiKeyAllows tells whether the api key can call the operation with the http method: the ones of its role, or the
+// delegatedOperations when it has none
+func apiKeyAllows(key *domain.APIKey, method, operationID string) bool {
+	if key.Role == "" {
+		return delegatedOperations[operationID]
+	}
+	return key.Role.Allows(method, operationID)
+}
+
+// hasPIIScope tells whether the request was authorized with the PII credentials
+func hasPIIScope(ctx context.Context) bool {
+	scope, _ := ctx.Value(piiScopeKey{}).(bool)
+	return scope
+}
+
+// authenticateAPIKey returns the api key of the basic auth credentials, nil when they are not the ones of an api key
+func authenticateAPIKey(ctx context.Context, claimsService ports.ClaimsService, issuerDID core.DID, userReq, passReq string, ok bool) (*domain.APIKey, error) {
+	if !ok || claimsService == nil {
+		return nil, nil
+	}
+	id, err := uuid.Parse(userReq)
+	if err != nil {
+		return nil, nil
+	}
+	key, err := claimsService.AuthenticateAPIKey(ctx, issuerDID, id, passReq)
+	if errors.Is(err, services.ErrAPIKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error(ctx, "authenticating api key", "err", err, "id", id)
+		return nil, err
+	}
+	return key, nil
+}
+
+// tenantOperator returns the tenant of the basic auth credentials, if they are the ones of its operator
+func tenantOperator(tenants []config.Tenant, userReq, passReq string, ok bool) (config.Tenant, bool) {
+	if !ok {
+		return config.Tenant{}, false
+	}
+	for _, tenant := range tenants {
+		if validCredentials(tenant.User, tenant.Password, userReq, passReq) {
+			return tenant, true
+		}
+	}
+	return config.Tenant{}, false
+}
+
+type tenantKey struct{}
+
+// tenant is the issuer identity a request is scoped to, and whether the client selected it
+type tenant struct {
+	did      core.DID
+	selected bool
+}
+
+// WithIssuerDID scopes the requests with ctx to the issuer identity did
+func WithIssuerDID(ctx context.Context, did core.DID) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant{did: did, selected: true})
+}
+
+// tenantFromContext returns the issuer identity the request is scoped to, defaultDID when there is none
+func tenantFromContext(ctx context.Context, defaultDID core.DID) tenant {
+	if scope, ok := ctx.Value(tenantKey{}).(tenant); ok {
+		return scope
+	}
+	return tenant{did: defaultDID}
+}
+
+// TenantMiddleware scopes each request to an issuer identity of the node: the one of the X-Issuer-DID header, or of
+// the issuerDID query parameter of the wallet callbacks and the public link pages, and the default one of the server
+// otherwise. The requests for an identity the node doesn't have get a 404. It must run before the basic auth, so the
+// api keys and the tenant operators are checked against the identity of the request.
+func TenantMiddleware(identityService ports.IdentityService) StrictMiddlewareFunc {
+	var known sync.Map
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+			raw := r.Header.Get(issuerDIDHeader)
+			if raw == "" {
+				raw = r.URL.Query().Get(issuerDIDParam)
+			}
+			if raw == "" {
+				return f(ctx, w, r, args)
+			}
+			did, err := core.ParseDID(raw)
+			if err != nil {
+				return nil, apiErrors.NotFoundError{Err: fmt.Errorf("unknown issuer <%s>", raw)}
+			}
+			// the identities are never removed, so the existing ones are only checked once
+			if _, found := known.Load(did.String()); !found {
+				_, err := identityService.GetByDID(ctx, *did)
+				if errors.Is(err, pgx.ErrNoRows) {
+					return nil, apiErrors.NotFoundError{Err: fmt.Errorf("unknown issuer <%s>", raw)}
+				}
+				if err != nil {
+					log.Error(ctx, "reading the issuer of the request", "err", err, "issuerDID", raw)
+					return nil, err
+				}
+				known.Store(did.String(), true)
+			}
+			return f(WithIssuerDID(ctx, *did), w, r, args)
+		}
+	}
+}
+
+func validCredentials(user, pass, userReq, passReq string) bool {
+	return user != "" && pass != "" &&
+		subtle.ConstantTimeCompare([]byte(user), []byte(userReq)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(pass), []byte(passReq)) == 1
+}
+
+// AntiAbuseMiddleware protects the public link session endpoints of open issuance campaigns. Their requests are
+// throttled by client IP and creating a link session requires the solution of a challenge. A nil challenger or
+// throttle disables its control. With trustProxy the client IP is taken from the X-Forwarded-For header.
+func AntiAbuseMiddleware(challenger antiabuse.Challenger, throttle antiabuse.Throttle, trustProxy bool) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		if operationID != "CreateLinkQrCode" && operationID != "GetLinkQRCode" && operationID != "CreateLinkQrCodeCallback" {
+			return f
+		}
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+			ip := antiabuse.ClientIP(r, trustProxy)
+			if throttle != nil {
+				if wait, err := throttle.Allow(ctx, ip); err != nil {
+					log.Warn(ctx, "throttling public link request", "ip", ip, "operation", operationID)
+					return throttledResponse(operationID, wait), nil
+				}
+			}
+			if challenger == nil || operationID != "CreateLinkQrCode" {
+				return f(ctx, w, r, args)
+			}
+
+			message := "challenge required"
+			if solution := r.Header.Get(challengeResponseHeader); solution != "" {
+				err := challenger.Verify(ctx, solution, ip)
+				if err != nil && !errors.Is(err, antiabuse.ErrChallengeFailed) {
+					log.Error(ctx, "verifying the anti-abuse challenge", "err", err)
+					return CreateLinkQrCode500JSONResponse{N500JSONResponse{Message: "error verifying the challenge"}}, nil
+				}
+				if throttle != nil {
+					throttle.Report(ctx, ip, err == nil)
+				}
+				if err == nil {
+					return f(ctx, w, r, args)
+				}
+				log.Warn(ctx, "failed anti-abuse challenge", "ip", ip)
+				message = "challenge failed"
+			}
+			challenge, err := challenger.New(ctx)
+			if err != nil {
+				log.Error(ctx, "creating the anti-abuse challenge", "err", err)
+				return CreateLinkQrCode500JSONResponse{N500JSONResponse{Message: "error creating the challenge"}}, nil
+			}
+			resp := CreateLinkQrCode403JSONResponse{
+				Message:   message,
+				Challenge: Challenge{Type: ChallengeType(challenge.Type), Value: challenge.Value},
+			}
+			if challenge.Difficulty > 0 {
+				resp.Challenge.Difficulty = &challenge.Difficulty
+			}
+			return resp, nil
+		}
+	}
+}
+
+// SchemaProxyMiddleware throttles by client IP the requests to the public endpoints that serve the imported schemas.
+// A nil throttle disables it. With trustProxy the client IP is taken from the X-Forwarded-For header.
+func SchemaProxyMiddleware(throttle antiabuse.Throttle, trustProxy bool) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		if throttle == nil || (operationID != "GetSchemaJSON" && operationID != "GetSchemaContext") {
+			return f
+		}
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+			ip := antiabuse.ClientIP(r, trustProxy)
+			if wait, err := throttle.Allow(ctx, ip); err != nil {
+				log.Warn(ctx, "throttling schema proxy request", "ip", ip, "operation", operationID)
+				return throttledResponse(operationID, wait), nil
+			}
 			return f(ctx, w, r, args)
 		}
 	}
 }
+
+func throttledResponse(operationID string, wait time.Duration) interface{} {
+	resp := N429JSONResponse{
+		Body:    GenericErrorMessage{Message: "too many requests"},
+		Headers: N429ResponseHeaders{RetryAfter: apiErrors.RetryAfterSeconds(wait)},
+	}
+	switch operationID {
+	case "GetLinkQRCode":
+		return GetLinkQRCode429JSONResponse{resp}
+	case "CreateLinkQrCodeCallback":
+		return CreateLinkQrCodeCallback429JSONResponse{resp}
+	case "GetSchemaJSON":
+		return GetSchemaJSON429JSONResponse{resp}
+	case "GetSchemaContext":
+		return GetSchemaContext429JSONResponse{resp}
+	}
+	return CreateLinkQrCode429JSONResponse{resp}
+}
@@ -0,0 +1,290 @@
+package api_ui
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	apiErrors "github.com/polygonid/sh-id-platform/internal/errors"
+	"github.com/polygonid/sh-id-platform/pkg/antiabuse"
+)
+
+func TestAntiAbuseMiddleware(t *testing.T) {
+	ctx := context.Background()
+	challenger, err := antiabuse.NewProofOfWork(4, []byte("secret"))
+	require.NoError(t, err)
+	calls := 0
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+		calls++
+		return CreateLinkQrCode200JSONResponse{}, nil
+	}
+	middleware := AntiAbuseMiddleware(challenger, antiabuse.NewIPThrottle(3), true)
+	request := func(solution string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/v1/credentials/links/8edd8112-c415-11ed-b036-debe37e1cbd6/qrcode", nil)
+		r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+		if solution != "" {
+			r.Header.Set(challengeResponseHeader, solution)
+		}
+		return r
+	}
+
+	resp, err := middleware(handler, "CreateLinkQrCode")(ctx, nil, request(""), nil)
+	require.NoError(t, err)
+	required, ok := resp.(CreateLinkQrCode403JSONResponse)
+	require.True(t, ok)
+	assert.Equal(t, "challenge required", required.Message)
+	assert.Equal(t, Pow, required.Challenge.Type)
+	require.NotNil(t, required.Challenge.Difficulty)
+	assert.Equal(t, 0, calls)
+
+	resp, err = middleware(handler, "CreateLinkQrCode")(ctx, nil, request(required.Challenge.Value+":wrong"), nil)
+	require.NoError(t, err)
+	failed, ok := resp.(CreateLinkQrCode403JSONResponse)
+	require.True(t, ok)
+	assert.Equal(t, "challenge failed", failed.Message)
+
+	for nonce := 0; ; nonce++ {
+		solution := failed.Challenge.Value + ":" + strconv.Itoa(nonce)
+		hash := sha256.Sum256([]byte(solution))
+		if hash[0]>>4 == 0 {
+			resp, err = middleware(handler, "CreateLinkQrCode")(ctx, nil, request(solution), nil)
+			break
+		}
+	}
+	require.NoError(t, err)
+	assert.IsType(t, CreateLinkQrCode200JSONResponse{}, resp)
+	assert.Equal(t, 1, calls)
+
+	t.Run("should throttle the client after its failed challenge", func(t *testing.T) {
+		resp, err := middleware(handler, "GetLinkQRCode")(ctx, nil, request(""), nil)
+		require.NoError(t, err)
+		throttled, ok := resp.(GetLinkQRCode429JSONResponse)
+		require.True(t, ok)
+		assert.Positive(t, throttled.Headers.RetryAfter)
+	})
+
+	t.Run("should not protect the other endpoints", func(t *testing.T) {
+		resp, err := middleware(handler, "GetLinks")(ctx, nil, request(""), nil)
+		require.NoError(t, err)
+		assert.IsType(t, CreateLinkQrCode200JSONResponse{}, resp)
+	})
+}
+
+func TestSchemaProxyMiddleware(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+		calls++
+		return GetSchemaJSON200JSONResponse{}, nil
+	}
+	middleware := SchemaProxyMiddleware(antiabuse.NewIPThrottle(2), false)
+	request := httptest.NewRequest(http.MethodGet, "/v1/schemas/8edd8112-c415-11ed-b036-debe37e1cbd6/jsonschema", nil)
+
+	for i := 0; i < 2; i++ {
+		resp, err := middleware(handler, "GetSchemaJSON")(ctx, nil, request, nil)
+		require.NoError(t, err)
+		assert.IsType(t, GetSchemaJSON200JSONResponse{}, resp)
+	}
+	resp, err := middleware(handler, "GetSchemaContext")(ctx, nil, request, nil)
+	require.NoError(t, err)
+	throttled, ok := resp.(GetSchemaContext429JSONResponse)
+	require.True(t, ok, "both endpoints share the limit of the IP")
+	assert.Positive(t, throttled.Headers.RetryAfter)
+	assert.Equal(t, 2, calls)
+
+	resp, err = middleware(handler, "GetSchema")(ctx, nil, request, nil)
+	require.NoError(t, err)
+	assert.IsType(t, GetSchemaJSON200JSONResponse{}, resp, "the other endpoints are not throttled")
+}
+
+type maintenanceMock struct {
+	maintenance *domain.Maintenance
+}
+
+func (m *maintenanceMock) Get(_ context.Context) (*domain.Maintenance, error) {
+	return m.maintenance, nil
+}
+
+func (m *maintenanceMock) Update(_ context.Context, enabled bool, message string, retryAfter time.Duration) (*domain.Maintenance, error) {
+	m.maintenance = &domain.Maintenance{Enabled: enabled, Message: message, RetryAfter: retryAfter}
+	return m.maintenance, nil
+}
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	ctx := context.Background()
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+		return CreateCredential201JSONResponse{}, nil
+	}
+	service := &maintenanceMock{maintenance: &domain.Maintenance{}}
+	middleware := MaintenanceMiddleware(service)
+	post := httptest.NewRequest(http.MethodPost, "/v1/credentials", nil)
+
+	resp, err := middleware(handler, "CreateCredential")(ctx, nil, post, nil)
+	require.NoError(t, err)
+	assert.IsType(t, CreateCredential201JSONResponse{}, resp)
+
+	_, err = service.Update(ctx, true, "rotating keys", 2*time.Minute)
+	require.NoError(t, err)
+	_, err = middleware(handler, "CreateCredential")(ctx, nil, post, nil)
+	var unavailable apiErrors.UnavailableError
+	require.ErrorAs(t, err, &unavailable)
+	assert.Equal(t, 2*time.Minute, unavailable.RetryAfter)
+	assert.Equal(t, "the node is under maintenance: rotating keys", unavailable.Error())
+
+	rec := httptest.NewRecorder()
+	apiErrors.ResponseErrorHandlerFunc(rec, post, err)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "120", rec.Header().Get("Retry-After"))
+
+	t.Run("should serve the reads", func(t *testing.T) {
+		resp, err := middleware(handler, "GetCredentials")(ctx, nil, httptest.NewRequest(http.MethodGet, "/v1/credentials", nil), nil)
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+
+	t.Run("should serve the agent", func(t *testing.T) {
+		resp, err := middleware(handler, "Agent")(ctx, nil, httptest.NewRequest(http.MethodPost, "/v1/agent", nil), nil)
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+}
+
+// identitiesMock knows the identities of its map, and counts the lookups
+type identitiesMock struct {
+	ports.IdentityService
+	identities map[string]bool
+	lookups    int
+}
+
+func (m *identitiesMock) GetByDID(_ context.Context, did core.DID) (*domain.Identity, error) {
+	m.lookups++
+	if !m.identities[did.String()] {
+		return nil, pgx.ErrNoRows
+	}
+	return &domain.Identity{Identifier: did.String()}, nil
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	ctx := context.Background()
+	defaultDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+	acme, err := core.ParseDID("did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5")
+	require.NoError(t, err)
+	identities := &identitiesMock{identities: map[string]bool{defaultDID.String(): true, acme.String(): true}}
+	var scope tenant
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+		scope = tenantFromContext(ctx, *defaultDID)
+		return GetLinks200JSONResponse{}, nil
+	}
+	middleware := TenantMiddleware(identities)(handler, "GetLinks")
+
+	t.Run("should scope the requests to the default issuer", func(t *testing.T) {
+		_, err := middleware(ctx, nil, httptest.NewRequest(http.MethodGet, "/v1/credentials/links", nil), nil)
+		require.NoError(t, err)
+		assert.Equal(t, defaultDID.String(), scope.did.String())
+		assert.False(t, scope.selected)
+	})
+
+	t.Run("should scope the requests to the issuer of the header", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/v1/credentials/links", nil)
+		request.Header.Set(issuerDIDHeader, acme.String())
+		for i := 0; i < 2; i++ {
+			_, err := middleware(ctx, nil, request, nil)
+			require.NoError(t, err)
+			assert.Equal(t, acme.String(), scope.did.String())
+			assert.True(t, scope.selected)
+		}
+		assert.Equal(t, 1, identities.lookups, "the existing issuers are only read once")
+	})
+
+	t.Run("should scope the wallet callbacks to the issuer of the query", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "/v1/authentication/callback?sessionID=8edd8112-c415-11ed-b036-debe37e1cbd6&issuerDID="+url.QueryEscape(acme.String()), nil)
+		_, err := middleware(ctx, nil, request, nil)
+		require.NoError(t, err)
+		assert.Equal(t, acme.String(), scope.did.String())
+	})
+
+	t.Run("should reject the unknown issuers", func(t *testing.T) {
+		for _, did := range []string{"did:polygonid:polygon:mumbai:2qFpPHotk6oyaX1fcrpQFT4BMnmg8YszUwxYtaoGoe", "not a did"} {
+			request := httptest.NewRequest(http.MethodGet, "/v1/credentials/links", nil)
+			request.Header.Set(issuerDIDHeader, did)
+			_, err := middleware(ctx, nil, request, nil)
+			var notFound apiErrors.NotFoundError
+			require.ErrorAs(t, err, &notFound, did)
+		}
+	})
+}
+
+func TestBasicAuthWithTenantsMiddleware(t *testing.T) {
+	ctx := context.WithValue(context.Background(), BasicAuthScopes, []string{""})
+	defaultDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+	acme, err := core.ParseDID("did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5")
+	require.NoError(t, err)
+	tenants := []config.Tenant{{DID: *acme, User: "acme", Password: "acme-secret"}}
+	var scope tenant
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+		scope = tenantFromContext(ctx, *defaultDID)
+		return GetLinks200JSONResponse{}, nil
+	}
+	middleware := BasicAuthWithTenantsMiddleware(ctx, "admin", "admin-secret", "", "", tenants, *defaultDID, nil)(handler, "GetLinks")
+	request := func(user, pass string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/v1/credentials/links", nil)
+		r.SetBasicAuth(user, pass)
+		return r
+	}
+
+	t.Run("should scope the operator to its issuer", func(t *testing.T) {
+		_, err := middleware(ctx, nil, request("acme", "acme-secret"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, acme.String(), scope.did.String())
+
+		_, err = middleware(WithIssuerDID(ctx, *acme), nil, request("acme", "acme-secret"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, acme.String(), scope.did.String())
+	})
+
+	t.Run("should forbid the operator another issuer", func(t *testing.T) {
+		_, err := middleware(WithIssuerDID(ctx, *defaultDID), nil, request("acme", "acme-secret"), nil)
+		var forbidden apiErrors.ForbiddenError
+		require.ErrorAs(t, err, &forbidden)
+	})
+
+	t.Run("should let the admin call any issuer", func(t *testing.T) {
+		_, err := middleware(WithIssuerDID(ctx, *acme), nil, request("admin", "admin-secret"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, acme.String(), scope.did.String())
+	})
+
+	t.Run("should reject the wrong password of an operator", func(t *testing.T) {
+		_, err := middleware(ctx, nil, request("acme", "admin-secret"), nil)
+		var unauthorized apiErrors.AuthError
+		require.ErrorAs(t, err, &unauthorized)
+	})
+
+	t.Run("should limit the operator to its role", func(t *testing.T) {
+		support := []config.Tenant{{DID: *acme, User: "support", Password: "support-secret", Role: domain.RoleReadOnly}}
+		auth := BasicAuthWithTenantsMiddleware(ctx, "admin", "admin-secret", "", "", support, *defaultDID, nil)
+		_, err := auth(handler, "GetLinks")(ctx, nil, request("support", "support-secret"), nil)
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodPost, "/v1/credentials/revoke/1", nil)
+		r.SetBasicAuth("support", "support-secret")
+		_, err = auth(handler, "RevokeCredential")(ctx, nil, r, nil)
+		var forbidden apiErrors.ForbiddenError
+		require.ErrorAs(t, err, &forbidden)
+	})
+}
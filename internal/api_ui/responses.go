@@ -1,6 +1,7 @@
 package api_ui
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/iden3/iden3comm/packers"
 	"github.com/iden3/iden3comm/protocol"
 
+	"github.com/polygonid/sh-id-platform/internal/common"
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	link_state "github.com/polygonid/sh-id-platform/pkg/link"
 	"github.com/polygonid/sh-id-platform/pkg/schema"
@@ -22,7 +24,7 @@ const (
 
 func schemaResponse(s *domain.Schema) Schema {
 	hash, _ := s.Hash.MarshalText()
-	return Schema{
+	resp := Schema{
 		Id:        s.ID.String(),
 		Type:      s.Type,
 		Url:       s.URL,
@@ -30,6 +32,280 @@ func schemaResponse(s *domain.Schema) Schema {
 		Hash:      string(hash),
 		CreatedAt: s.CreatedAt,
 	}
+	if s.DefaultProofTypes != nil {
+		proofTypes := make([]ProofType, len(s.DefaultProofTypes))
+		for i, proofType := range s.DefaultProofTypes {
+			proofTypes[i] = ProofType(proofType)
+		}
+		resp.DefaultProofTypes = &proofTypes
+	}
+	if s.DefaultExpiration != nil {
+		resp.DefaultExpiration = common.ToPointer(s.DefaultExpiration.String())
+	}
+	if s.PIIAttributes != nil {
+		resp.PiiAttributes = common.ToPointer([]string(s.PIIAttributes))
+	}
+	if s.MaxActivePerSubject != nil {
+		resp.MaxActivePerSubject = common.ToPointer(*s.MaxActivePerSubject)
+	}
+	if s.RevokeSuperseded {
+		resp.RevokeSuperseded = common.ToPointer(true)
+	}
+	if s.PostIssuanceHooks != nil {
+		hooks := make([]PostIssuanceHook, len(s.PostIssuanceHooks))
+		for i, hook := range s.PostIssuanceHooks {
+			hooks[i] = PostIssuanceHook{Name: hook.Name, Url: hook.URL, Mode: PostIssuanceHookMode(hook.Mode)}
+		}
+		resp.PostIssuanceHooks = &hooks
+	}
+	resp.DisplayStrings = displayStringsResponse(s.DisplayStrings)
+	resp.Prerequisites = prerequisitesResponse(s.Prerequisites)
+	if s.Version != "" {
+		resp.Version = common.ToPointer(s.Version)
+	}
+	if s.Title != "" {
+		resp.Title = common.ToPointer(s.Title)
+	}
+	if s.Description != "" {
+		resp.Description = common.ToPointer(s.Description)
+	}
+	resp.DeprecatedAt = s.DeprecatedAt
+	return resp
+}
+
+func prerequisitesResponse(prerequisites domain.Prerequisites) *[]Prerequisite {
+	if prerequisites == nil {
+		return nil
+	}
+	resp := make([]Prerequisite, len(prerequisites))
+	for i, prerequisite := range prerequisites {
+		resp[i] = Prerequisite{
+			Context:        prerequisite.Context,
+			Type:           prerequisite.Type,
+			AllowedIssuers: prerequisite.AllowedIssuers,
+			ProofType:      PrerequisiteProofType(prerequisite.ProofType),
+		}
+		if prerequisite.CredentialSubject != nil {
+			resp[i].CredentialSubject = common.ToPointer(prerequisite.CredentialSubject)
+		}
+	}
+	return &resp
+}
+
+// toPrerequisites returns the domain prerequisites of a request, nil when it has none
+func toPrerequisites(prerequisites *[]Prerequisite) domain.Prerequisites {
+	if prerequisites == nil || len(*prerequisites) == 0 {
+		return nil
+	}
+	res := make(domain.Prerequisites, len(*prerequisites))
+	for i, prerequisite := range *prerequisites {
+		res[i] = domain.Prerequisite{
+			Context:        prerequisite.Context,
+			Type:           prerequisite.Type,
+			AllowedIssuers: prerequisite.AllowedIssuers,
+			ProofType:      string(prerequisite.ProofType),
+		}
+		if prerequisite.CredentialSubject != nil {
+			res[i].CredentialSubject = *prerequisite.CredentialSubject
+		}
+	}
+	return res
+}
+
+func displayStringsResponse(display domain.DisplayStrings) *DisplayStrings {
+	if display == nil {
+		return nil
+	}
+	resp := make(DisplayStrings, len(display))
+	for locale, item := range display {
+		value := DisplayString{Name: item.Name}
+		if item.Description != "" {
+			value.Description = common.ToPointer(item.Description)
+		}
+		resp[locale] = value
+	}
+	return &resp
+}
+
+// toDisplayStrings returns the domain display strings of a request, nil when it has none
+func toDisplayStrings(display *DisplayStrings) domain.DisplayStrings {
+	if display == nil || len(*display) == 0 {
+		return nil
+	}
+	res := make(domain.DisplayStrings, len(*display))
+	for locale, item := range *display {
+		value := domain.DisplayString{Name: item.Name}
+		if item.Description != nil {
+			value.Description = *item.Description
+		}
+		res[locale] = value
+	}
+	return res
+}
+
+func schemaUsageResponse(usage *domain.SchemaUsage) SchemaUsage {
+	return SchemaUsage{
+		Issued:         usage.Issued,
+		IssuedByLink:   usage.IssuedByLink,
+		Revoked:        usage.Revoked,
+		RevocationRate: usage.RevocationRate(),
+	}
+}
+
+func schemaStatsResponse(usage *domain.SchemaUsage) SchemaStats {
+	days := make([]SchemaUsageDay, len(usage.Days))
+	for i, day := range usage.Days {
+		days[i] = SchemaUsageDay{
+			Day:          openapi_types.Date{Time: day.Day},
+			Issued:       day.Issued,
+			IssuedByLink: day.IssuedByLink,
+			Revoked:      day.Revoked,
+		}
+	}
+	return SchemaStats{
+		Issued:         usage.Issued,
+		IssuedByLink:   usage.IssuedByLink,
+		Revoked:        usage.Revoked,
+		RevocationRate: usage.RevocationRate(),
+		Days:           days,
+	}
+}
+
+func hookDeliveryResponse(delivery *domain.HookDelivery) HookDelivery {
+	var request map[string]interface{}
+	_ = json.Unmarshal(delivery.Request, &request)
+	return HookDelivery{
+		Id:         delivery.ID,
+		Hook:       delivery.Hook,
+		Url:        delivery.URL,
+		Test:       delivery.Test,
+		Request:    request,
+		Status:     delivery.Status,
+		Response:   delivery.Response,
+		Error:      delivery.Error,
+		DurationMs: delivery.Duration.Milliseconds(),
+		CreatedAt:  delivery.CreatedAt,
+	}
+}
+
+func hookDeliveriesResponse(deliveries []domain.HookDelivery) []HookDelivery {
+	resp := make([]HookDelivery, len(deliveries))
+	for i := range deliveries {
+		resp[i] = hookDeliveryResponse(&deliveries[i])
+	}
+	return resp
+}
+
+func verificationsResponse(verifications []domain.Verification) []Verification {
+	resp := make([]Verification, len(verifications))
+	for i, v := range verifications {
+		queries := make([]map[string]interface{}, 0)
+		_ = json.Unmarshal(v.Queries, &queries)
+		resp[i] = Verification{
+			Id:          v.ID,
+			SessionId:   v.SessionID,
+			UserDID:     v.UserDID,
+			Queries:     queries,
+			ProofHashes: v.ProofHashes,
+			Verified:    v.Verified,
+			Error:       v.Error,
+			CreatedAt:   v.CreatedAt,
+		}
+		if resp[i].ProofHashes == nil {
+			resp[i].ProofHashes = []string{}
+		}
+	}
+	return resp
+}
+
+func apiKeysResponse(keys []domain.APIKey) []APIKey {
+	resp := make([]APIKey, len(keys))
+	for i, key := range keys {
+		resp[i] = APIKey{
+			Id:          key.ID,
+			Name:        key.Name,
+			SchemaTypes: key.SchemaTypes,
+			LinkIDs:     key.LinkIDs,
+			CreatedAt:   key.CreatedAt,
+		}
+		if resp[i].SchemaTypes == nil {
+			resp[i].SchemaTypes = []string{}
+		}
+		if resp[i].LinkIDs == nil {
+			resp[i].LinkIDs = []uuid.UUID{}
+		}
+		if key.Role != "" {
+			resp[i].Role = common.ToPointer(Role(key.Role))
+		}
+	}
+	return resp
+}
+
+func brandingResponse(branding *domain.Branding) Branding {
+	return Branding{
+		DisplayName:  branding.DisplayName,
+		Logo:         branding.LogoURL,
+		PrimaryColor: branding.PrimaryColor,
+		TextColor:    branding.TextColor,
+	}
+}
+
+func buildSchemaResponse(built *domain.BuiltSchema) (*BuildSchemaResponse, error) {
+	resp := &BuildSchemaResponse{}
+	if err := json.Unmarshal(built.JSONSchema, &resp.JsonSchema); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(built.JSONLdContext, &resp.JsonLdContext); err != nil {
+		return nil, err
+	}
+	if built.SchemaURL != "" {
+		resp.SchemaUrl = common.ToPointer(built.SchemaURL)
+		resp.ContextUrl = common.ToPointer(built.ContextURL)
+	}
+	if built.Schema != nil {
+		resp.Id = common.ToPointer(built.Schema.ID.String())
+	}
+	return resp, nil
+}
+
+func schemaFormResponse(form *domain.SchemaForm) SchemaForm {
+	return SchemaForm{
+		Title:       form.Title,
+		Description: form.Description,
+		Fields:      schemaFormFieldsResponse(form.Fields),
+	}
+}
+
+func schemaFormFieldsResponse(fields []domain.SchemaFormField) []SchemaFormField {
+	res := make([]SchemaFormField, len(fields))
+	for i, f := range fields {
+		res[i] = SchemaFormField{
+			Id:          f.ID,
+			Label:       f.Label,
+			Description: f.Description,
+			Type:        f.Type,
+			Input:       SchemaFormFieldInput(f.Input),
+			Required:    f.Required,
+			Default:     f.Default,
+			Constraints: SchemaFormConstraints{
+				Minimum:   f.Constraints.Minimum,
+				Maximum:   f.Constraints.Maximum,
+				MinLength: f.Constraints.MinLength,
+				MaxLength: f.Constraints.MaxLength,
+			},
+			Fields: schemaFormFieldsResponse(f.Fields),
+		}
+		if len(f.Constraints.Enum) > 0 {
+			res[i].Constraints.Enum = common.ToPointer(f.Constraints.Enum)
+		}
+		if f.Constraints.Format != "" {
+			res[i].Constraints.Format = common.ToPointer(f.Constraints.Format)
+		}
+		if f.Constraints.Pattern != "" {
+			res[i].Constraints.Pattern = common.ToPointer(f.Constraints.Pattern)
+		}
+	}
+	return res
 }
 
 func schemaCollectionResponse(schemas []domain.Schema) []Schema {
@@ -41,28 +317,39 @@ func schemaCollectionResponse(schemas []domain.Schema) []Schema {
 }
 
 func credentialResponse(w3c *verifiable.W3CCredential, credential *domain.Claim) Credential {
+	return credentialResponseAt(w3c, credential, time.Now())
+}
+
+// credentialResponseAt returns the credential with the expired flag it had at the given time
+func credentialResponseAt(w3c *verifiable.W3CCredential, credential *domain.Claim, at time.Time) Credential {
 	expired := false
 	if w3c.Expiration != nil {
-		if time.Now().UTC().After(w3c.Expiration.UTC()) {
+		if at.UTC().After(w3c.Expiration.UTC()) {
 			expired = true
 		}
 	}
 
 	proofs := getProofs(credential)
 
+	var externalReferences *map[string]string
+	if len(credential.ExternalReferences) > 0 {
+		externalReferences = &credential.ExternalReferences
+	}
+
 	return Credential{
-		CredentialSubject: w3c.CredentialSubject,
-		CreatedAt:         *w3c.IssuanceDate,
-		Expired:           expired,
-		ExpiresAt:         w3c.Expiration,
-		Id:                credential.ID,
-		ProofTypes:        proofs,
-		RevNonce:          uint64(credential.RevNonce),
-		Revoked:           credential.Revoked,
-		SchemaHash:        credential.SchemaHash,
-		SchemaType:        shortType(credential.SchemaType),
-		SchemaUrl:         credential.SchemaURL,
-		UserID:            credential.OtherIdentifier,
+		CredentialSubject:  w3c.CredentialSubject,
+		CreatedAt:          *w3c.IssuanceDate,
+		Expired:            expired,
+		ExpiresAt:          w3c.Expiration,
+		ExternalReferences: externalReferences,
+		Id:                 credential.ID,
+		ProofTypes:         proofs,
+		RevNonce:           uint64(credential.RevNonce),
+		Revoked:            credential.Revoked,
+		SchemaHash:         credential.SchemaHash,
+		SchemaType:         shortType(credential.SchemaType),
+		SchemaUrl:          credential.SchemaURL,
+		UserID:             credential.OtherIdentifier,
 	}
 }
 
@@ -78,7 +365,11 @@ func shortType(id string) string {
 func getProofs(credential *domain.Claim) []string {
 	proofs := make([]string, 0)
 	if credential.SignatureProof.Bytes != nil {
-		proofs = append(proofs, string(verifiable.BJJSignatureProofType))
+		if proof, err := credential.GetSignatureProof(); err == nil {
+			proofs = append(proofs, string(proof.ProofType()))
+		} else {
+			proofs = append(proofs, string(verifiable.BJJSignatureProofType))
+		}
 	}
 
 	if credential.MtProof {
@@ -120,10 +411,49 @@ func connectionResponse(conn *domain.Connection, w3cs []*verifiable.W3CCredentia
 		Id:          conn.ID.String(),
 		UserID:      conn.UserDID.String(),
 		IssuerID:    conn.IssuerDID.String(),
+		LastSeen:    conn.LastSeen,
 		Credentials: credResp,
 	}
 }
 
+func subjectDataResponse(data *domain.SubjectData) (SubjectData, error) {
+	w3cs, err := schema.FromClaimsModelToW3CCredential(data.Credentials)
+	if err != nil {
+		return SubjectData{}, err
+	}
+
+	credentials := make([]Credential, len(data.Credentials))
+	for i := range data.Credentials {
+		credentials[i] = credentialResponse(w3cs[i], data.Credentials[i])
+	}
+
+	resp := SubjectData{
+		Subject:     data.Subject.String(),
+		Credentials: credentials,
+		Erasures:    make([]SubjectErasure, len(data.Erasures)),
+	}
+	if data.Connection != nil {
+		conn := connectionResponse(data.Connection, nil, nil)
+		conn.Credentials = credentials
+		resp.Connection = &conn
+	}
+	for i, erasure := range data.Erasures {
+		resp.Erasures[i] = subjectErasureResponse(erasure)
+	}
+
+	return resp, nil
+}
+
+func subjectErasureResponse(erasure *domain.SubjectErasure) SubjectErasure {
+	return SubjectErasure{
+		Id:          erasure.ID,
+		SubjectHash: erasure.SubjectHash,
+		Credentials: erasure.Credentials,
+		Connections: erasure.Connections,
+		CreatedAt:   erasure.CreatedAt,
+	}
+}
+
 func stateTransactionsResponse(states []domain.IdentityState) StateTransactionsResponse {
 	stateTransactions := make([]StateTransaction, len(states))
 	for i := range states {
@@ -192,24 +522,41 @@ func getLinkResponse(link domain.Link) Link {
 	if link.CredentialExpiration != nil {
 		date = &openapi_types.Date{Time: *link.CredentialExpiration}
 	}
+	var expirationPolicy *string
+	if link.CredentialExpirationPolicy != nil {
+		expirationPolicy = common.ToPointer(link.CredentialExpirationPolicy.String())
+	}
 
 	return Link{
-		Id:                   link.ID,
-		Active:               link.Active,
-		CredentialSubject:    link.CredentialSubject,
-		IssuedClaims:         link.IssuedClaims,
-		MaxIssuance:          link.MaxIssuance,
-		SchemaType:           link.Schema.Type,
-		SchemaUrl:            link.Schema.URL,
-		SchemaHash:           string(hash),
-		Status:               LinkStatus(link.Status()),
-		ProofTypes:           getLinkProofs(link),
-		CreatedAt:            link.CreatedAt,
-		Expiration:           link.ValidUntil,
-		CredentialExpiration: date,
+		Id:                         link.ID,
+		Active:                     link.Active,
+		CredentialSubject:          link.CredentialSubject,
+		IssuedClaims:               link.IssuedClaims,
+		MaxIssuance:                link.MaxIssuance,
+		SchemaType:                 link.Schema.Type,
+		SchemaUrl:                  link.Schema.URL,
+		SchemaHash:                 string(hash),
+		Status:                     LinkStatus(link.Status()),
+		ProofTypes:                 getLinkProofs(link),
+		CreatedAt:                  link.CreatedAt,
+		Expiration:                 link.ValidUntil,
+		CredentialExpiration:       date,
+		CredentialExpirationPolicy: expirationPolicy,
+		Locale:                     link.Locale,
+		DisplayStrings:             displayStringsResponse(link.DisplayStrings),
+		Prerequisites:              prerequisitesResponse(link.Prerequisites),
 	}
 }
 
+// linkQrCodeScope returns the proof requests of the link prerequisites, an empty list when the link has none
+func linkQrCodeScope(scope []protocol.ZeroKnowledgeProofRequest) []interface{} {
+	res := make([]interface{}, len(scope))
+	for i, request := range scope {
+		res[i] = request
+	}
+	return res
+}
+
 func getLinkSimpleResponse(link domain.Link) LinkSimple {
 	hash, _ := link.Schema.Hash.MarshalText()
 	return LinkSimple{
@@ -242,6 +589,27 @@ func getLinkResponses(links []domain.Link) []Link {
 	return res
 }
 
+func linkSessionResponse(session *domain.LinkSession) LinkSession {
+	return LinkSession{
+		Id:           session.ID,
+		LinkID:       session.LinkID,
+		Status:       LinkSessionStatus(session.Status),
+		UserDID:      session.UserDID,
+		CredentialID: session.ClaimID,
+		Error:        session.Error,
+		CreatedAt:    session.CreatedAt,
+		ModifiedAt:   session.ModifiedAt,
+	}
+}
+
+func linkSessionsResponse(sessions []*domain.LinkSession) []LinkSession {
+	res := make([]LinkSession, len(sessions))
+	for i, session := range sessions {
+		res[i] = linkSessionResponse(session)
+	}
+	return res
+}
+
 func getLinkQrCodeResponse(linkQrCode *link_state.QRCodeMessage) *QrCodeResponse {
 	if linkQrCode == nil {
 		return nil
@@ -2,6 +2,7 @@ package api_ui
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -11,7 +12,9 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
 	"github.com/iden3/iden3comm"
 	"github.com/iden3/iden3comm/packers"
 
@@ -20,6 +23,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/core/services"
+	apiErrors "github.com/polygonid/sh-id-platform/internal/errors"
 	"github.com/polygonid/sh-id-platform/internal/gateways"
 	"github.com/polygonid/sh-id-platform/internal/health"
 	"github.com/polygonid/sh-id-platform/internal/log"
@@ -28,51 +32,325 @@ import (
 	"github.com/polygonid/sh-id-platform/pkg/schema"
 )
 
+// maxVerificationsLimit is the maximum number of verifications returned by GetVerifications
+const maxVerificationsLimit = 1000
+
+const (
+	// defaultLinkSessionsLimit is the number of sessions returned by GetLinkSessions when the limit is not given
+	defaultLinkSessionsLimit = 100
+	// maxLinkSessionsLimit is the maximum number of sessions returned by GetLinkSessions
+	maxLinkSessionsLimit = 1000
+)
+
 // Server implements StrictServerInterface and holds the implementation of all API controllers
 // This is the glue to the API autogenerated code
 type Server struct {
-	cfg                *config.Configuration
-	identityService    ports.IdentityService
-	claimService       ports.ClaimsService
-	schemaService      ports.SchemaService
-	connectionsService ports.ConnectionsService
-	linkService        ports.LinkService
-	publisherGateway   ports.Publisher
-	packageManager     *iden3comm.PackageManager
-	health             *health.Status
+	cfg                 *config.Configuration
+	identityService     ports.IdentityService
+	claimService        ports.ClaimsService
+	schemaService       ports.SchemaService
+	connectionsService  ports.ConnectionsService
+	linkService         ports.LinkService
+	subjectService      ports.SubjectService
+	notificationService ports.NotificationService
+	brandingService     ports.BrandingService
+	publisherGateway    ports.Publisher
+	packageManager      *iden3comm.PackageManager
+	health              *health.Status
 }
 
 // NewServer is a Server constructor
-func NewServer(cfg *config.Configuration, identityService ports.IdentityService, claimsService ports.ClaimsService, schemaService ports.SchemaService, connectionsService ports.ConnectionsService, linkService ports.LinkService, publisherGateway ports.Publisher, packageManager *iden3comm.PackageManager, health *health.Status) *Server {
+func NewServer(cfg *config.Configuration, identityService ports.IdentityService, claimsService ports.ClaimsService, schemaService ports.SchemaService, connectionsService ports.ConnectionsService, linkService ports.LinkService, subjectService ports.SubjectService, notificationService ports.NotificationService, brandingService ports.BrandingService, publisherGateway ports.Publisher, packageManager *iden3comm.PackageManager, health *health.Status) *Server {
 	return &Server{
-		cfg:                cfg,
-		identityService:    identityService,
-		claimService:       claimsService,
-		schemaService:      schemaService,
-		connectionsService: connectionsService,
-		linkService:        linkService,
-		publisherGateway:   publisherGateway,
-		packageManager:     packageManager,
-		health:             health,
+		cfg:                 cfg,
+		identityService:     identityService,
+		claimService:        claimsService,
+		schemaService:       schemaService,
+		connectionsService:  connectionsService,
+		linkService:         linkService,
+		subjectService:      subjectService,
+		notificationService: notificationService,
+		brandingService:     brandingService,
+		publisherGateway:    publisherGateway,
+		packageManager:      packageManager,
+		health:              health,
 	}
 }
 
+// issuerDID returns the issuer identity the request is scoped to, the one of the configuration by default
+func (s *Server) issuerDID(ctx context.Context) core.DID {
+	return tenantFromContext(ctx, s.cfg.APIUI.IssuerDID).did
+}
+
 // GetSchema is the UI endpoint that searches and schema by Id and returns it.
 func (s *Server) GetSchema(ctx context.Context, request GetSchemaRequestObject) (GetSchemaResponseObject, error) {
-	schema, err := s.schemaService.GetByID(ctx, s.cfg.APIUI.IssuerDID, request.Id)
+	schema, err := s.schemaService.GetByID(ctx, s.issuerDID(ctx), request.Id)
 	if errors.Is(err, services.ErrSchemaNotFound) {
 		log.Debug(ctx, "schema not found", "id", request.Id)
 		return GetSchema404JSONResponse{N404JSONResponse{Message: "schema not found"}}, nil
 	}
 	if err != nil {
 		log.Error(ctx, "loading schema", "err", err, "id", request.Id)
+		return GetSchema500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	resp := schemaResponse(schema)
+	form, err := s.schemaService.GetForm(ctx, s.issuerDID(ctx), request.Id)
+	if err != nil {
+		log.Warn(ctx, "the schema form is not available", "err", err, "id", request.Id)
+	} else {
+		resp.Form = common.ToPointer(schemaFormResponse(form))
+	}
+	usage, err := s.schemaService.GetUsage(ctx, s.issuerDID(ctx), request.Id, nil, nil)
+	if err != nil {
+		log.Warn(ctx, "the schema usage is not available", "err", err, "id", request.Id)
+	} else {
+		resp.Usage = common.ToPointer(schemaUsageResponse(usage))
+	}
+	return GetSchema200JSONResponse(resp), nil
+}
+
+// GetSchemaJSON is the public proxy of the JSON schema of an imported schema
+func (s *Server) GetSchemaJSON(ctx context.Context, request GetSchemaJSONRequestObject) (GetSchemaJSONResponseObject, error) {
+	doc, err := s.schemaService.GetJSONSchema(ctx, s.issuerDID(ctx), request.Id)
+	if errors.Is(err, services.ErrSchemaNotFound) {
+		return GetSchemaJSON404JSONResponse{N404JSONResponse{Message: "schema not found"}}, nil
+	}
+	if err != nil {
+		return GetSchemaJSON500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	var resp GetSchemaJSON200JSONResponse
+	if err := json.Unmarshal(doc, &resp); err != nil {
+		log.Error(ctx, "decoding json schema", "err", err, "id", request.Id)
+		return GetSchemaJSON500JSONResponse{N500JSONResponse{Message: services.ErrProcessSchema.Error()}}, nil
+	}
+	return resp, nil
+}
+
+// GetSchemaContext is the public proxy of the JSON-LD context of an imported schema
+func (s *Server) GetSchemaContext(ctx context.Context, request GetSchemaContextRequestObject) (GetSchemaContextResponseObject, error) {
+	doc, err := s.schemaService.GetJSONLdContext(ctx, s.issuerDID(ctx), request.Id)
+	if errors.Is(err, services.ErrSchemaNotFound) {
+		return GetSchemaContext404JSONResponse{N404JSONResponse{Message: "schema not found"}}, nil
+	}
+	if err != nil {
+		return GetSchemaContext500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	var resp GetSchemaContext200JSONResponse
+	if err := json.Unmarshal(doc, &resp); err != nil {
+		log.Error(ctx, "decoding jsonld context", "err", err, "id", request.Id)
+		return GetSchemaContext500JSONResponse{N500JSONResponse{Message: services.ErrProcessSchema.Error()}}, nil
+	}
+	return resp, nil
+}
+
+// GetSchemaStats returns the credentials of the schema issued and revoked per day between the from and to days
+func (s *Server) GetSchemaStats(ctx context.Context, request GetSchemaStatsRequestObject) (GetSchemaStatsResponseObject, error) {
+	var from, to *time.Time
+	if request.Params.From != nil {
+		from = &request.Params.From.Time
+	}
+	if request.Params.To != nil {
+		to = &request.Params.To.Time
+	}
+	usage, err := s.schemaService.GetUsage(ctx, s.issuerDID(ctx), request.Id, from, to)
+	if err != nil {
+		if errors.Is(err, services.ErrSchemaNotFound) {
+			return GetSchemaStats404JSONResponse{N404JSONResponse{Message: "schema not found"}}, nil
+		}
+		if errors.Is(err, services.ErrInvalidUsagePeriod) {
+			return GetSchemaStats400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "getting schema stats", "err", err, "id", request.Id)
+		return GetSchemaStats500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetSchemaStats200JSONResponse(schemaStatsResponse(usage)), nil
+}
+
+// TestPostIssuanceHook sends a sample credential of the schema to one of its post-issuance hooks and returns the call
+func (s *Server) TestPostIssuanceHook(ctx context.Context, request TestPostIssuanceHookRequestObject) (TestPostIssuanceHookResponseObject, error) {
+	delivery, err := s.claimService.TestPostIssuanceHook(ctx, s.issuerDID(ctx), request.Id, request.Name)
+	if err != nil {
+		if errors.Is(err, services.ErrSchemaNotFound) {
+			return TestPostIssuanceHook404JSONResponse{N404JSONResponse{Message: "schema not found"}}, nil
+		}
+		if errors.Is(err, services.ErrPostIssuanceHookNotFound) {
+			return TestPostIssuanceHook404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "testing post-issuance hook", "err", err, "id", request.Id, "hook", request.Name)
+		return TestPostIssuanceHook500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return TestPostIssuanceHook200JSONResponse(hookDeliveryResponse(delivery)), nil
+}
+
+// GetHookDeliveries returns the last calls to the post-issuance hooks kept by the hook capture
+func (s *Server) GetHookDeliveries(ctx context.Context, _ GetHookDeliveriesRequestObject) (GetHookDeliveriesResponseObject, error) {
+	deliveries, err := s.claimService.GetHookDeliveries(ctx, s.issuerDID(ctx))
+	if err != nil {
+		log.Error(ctx, "getting hook deliveries", "err", err)
+		return GetHookDeliveries500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetHookDeliveries200JSONResponse(hookDeliveriesResponse(deliveries)), nil
+}
+
+// GetAPIKeys returns the delegated api keys of the issuer
+func (s *Server) GetAPIKeys(ctx context.Context, _ GetAPIKeysRequestObject) (GetAPIKeysResponseObject, error) {
+	keys, err := s.claimService.GetAPIKeys(ctx, s.issuerDID(ctx))
+	if err != nil {
+		log.Error(ctx, "getting api keys", "err", err)
+		return GetAPIKeys500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetAPIKeys200JSONResponse(apiKeysResponse(keys)), nil
+}
+
+// CreateAPIKey creates a delegated api key of the issuer and returns its secret
+func (s *Server) CreateAPIKey(ctx context.Context, request CreateAPIKeyRequestObject) (CreateAPIKeyResponseObject, error) {
+	var schemaTypes []string
+	if request.Body.SchemaTypes != nil {
+		schemaTypes = *request.Body.SchemaTypes
+	}
+	var linkIDs []uuid.UUID
+	if request.Body.LinkIDs != nil {
+		linkIDs = *request.Body.LinkIDs
+	}
+	var role domain.Role
+	if request.Body.Role != nil {
+		role = domain.Role(*request.Body.Role)
+	}
+	key, secret, err := s.claimService.CreateAPIKey(ctx, s.issuerDID(ctx), request.Body.Name, role, schemaTypes, linkIDs)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAPIKey) {
+			return CreateAPIKey400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return CreateAPIKey500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return CreateAPIKey201JSONResponse{Id: key.ID, Secret: secret}, nil
+}
+
+// DeleteAPIKey revokes a delegated api key of the issuer
+func (s *Server) DeleteAPIKey(ctx context.Context, request DeleteAPIKeyRequestObject) (DeleteAPIKeyResponseObject, error) {
+	if err := s.claimService.DeleteAPIKey(ctx, s.issuerDID(ctx), request.Id); err != nil {
+		if errors.Is(err, services.ErrAPIKeyNotFound) {
+			return DeleteAPIKey404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "deleting api key", "err", err, "id", request.Id)
+		return DeleteAPIKey500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return DeleteAPIKey200JSONResponse{Message: "api key deleted"}, nil
+}
+
+// GetBranding returns how the issuer is presented to the holders
+func (s *Server) GetBranding(ctx context.Context, _ GetBrandingRequestObject) (GetBrandingResponseObject, error) {
+	branding, err := s.brandingService.Get(ctx, s.issuerDID(ctx))
+	if err != nil {
+		log.Error(ctx, "getting branding", "err", err)
+		return GetBranding500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetBranding200JSONResponse(brandingResponse(branding)), nil
+}
+
+// UpdateBranding replaces the branding of the issuer that overrides the one of the node
+func (s *Server) UpdateBranding(ctx context.Context, request UpdateBrandingRequestObject) (UpdateBrandingResponseObject, error) {
+	branding, err := s.brandingService.Update(ctx, s.issuerDID(ctx), domain.BrandingOverrides{
+		DisplayName:  request.Body.DisplayName,
+		LogoURL:      request.Body.Logo,
+		PrimaryColor: request.Body.PrimaryColor,
+		TextColor:    request.Body.TextColor,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidBranding) {
+			return UpdateBranding400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "updating branding", "err", err)
+		return UpdateBranding500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return UpdateBranding200JSONResponse(brandingResponse(branding)), nil
+}
+
+// UpdateSchema sets the default proof types and expiration policy of the schema credentials, its PII attributes, its
+// issuance policies per subject and its post-issuance hooks. Only the fields present in the request are changed, an
+// empty value removes the default.
+func (s *Server) UpdateSchema(ctx context.Context, request UpdateSchemaRequestObject) (UpdateSchemaResponseObject, error) {
+	var proofTypes domain.ProofTypes
+	if request.Body.DefaultProofTypes != nil && len(*request.Body.DefaultProofTypes) > 0 {
+		proofTypes = make(domain.ProofTypes, len(*request.Body.DefaultProofTypes))
+		for i, proofType := range *request.Body.DefaultProofTypes {
+			proofTypes[i] = verifiable.ProofType(proofType)
+		}
+	}
+	var expirationPolicy *domain.ExpirationPolicy
+	if request.Body.DefaultExpiration != nil && *request.Body.DefaultExpiration != "" {
+		var err error
+		if expirationPolicy, err = domain.ParseExpirationPolicy(*request.Body.DefaultExpiration); err != nil {
+			return UpdateSchema400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+	}
+
+	schema, err := s.schemaService.GetByID(ctx, s.issuerDID(ctx), request.Id)
+	if err == nil && request.Body.DefaultProofTypes != nil {
+		schema, err = s.schemaService.UpdateDefaultProofTypes(ctx, s.issuerDID(ctx), request.Id, proofTypes)
+	}
+	if err == nil && request.Body.DefaultExpiration != nil {
+		schema, err = s.schemaService.UpdateDefaultExpiration(ctx, s.issuerDID(ctx), request.Id, expirationPolicy)
+	}
+	if err == nil && request.Body.PiiAttributes != nil {
+		var piiAttributes domain.SchemaAttrs
+		if len(*request.Body.PiiAttributes) > 0 {
+			piiAttributes = *request.Body.PiiAttributes
+		}
+		schema, err = s.schemaService.UpdatePIIAttributes(ctx, s.issuerDID(ctx), request.Id, piiAttributes)
+	}
+	if err == nil && request.Body.MaxActivePerSubject != nil {
+		var maxActive *int
+		if *request.Body.MaxActivePerSubject != 0 {
+			maxActive = request.Body.MaxActivePerSubject
+		}
+		schema, err = s.schemaService.UpdateMaxActivePerSubject(ctx, s.issuerDID(ctx), request.Id, maxActive)
+	}
+	if err == nil && request.Body.RevokeSuperseded != nil {
+		schema, err = s.schemaService.UpdateRevokeSuperseded(ctx, s.issuerDID(ctx), request.Id, *request.Body.RevokeSuperseded)
+	}
+	if err == nil && request.Body.PostIssuanceHooks != nil {
+		var hooks domain.PostIssuanceHooks
+		for _, hook := range *request.Body.PostIssuanceHooks {
+			hooks = append(hooks, domain.PostIssuanceHook{Name: hook.Name, URL: hook.Url, Mode: string(hook.Mode)})
+		}
+		schema, err = s.schemaService.UpdatePostIssuanceHooks(ctx, s.issuerDID(ctx), request.Id, hooks)
+	}
+	if err == nil && request.Body.DisplayStrings != nil {
+		schema, err = s.schemaService.UpdateDisplayStrings(ctx, s.issuerDID(ctx), request.Id, toDisplayStrings(request.Body.DisplayStrings))
+	}
+	if err == nil && request.Body.Prerequisites != nil {
+		schema, err = s.schemaService.UpdatePrerequisites(ctx, s.issuerDID(ctx), request.Id, toPrerequisites(request.Body.Prerequisites))
+	}
+	if err == nil && (request.Body.Title != nil || request.Body.Description != nil) {
+		title, description := schema.Title, schema.Description
+		if request.Body.Title != nil {
+			title = *request.Body.Title
+		}
+		if request.Body.Description != nil {
+			description = *request.Body.Description
+		}
+		schema, err = s.schemaService.UpdateMetadata(ctx, s.issuerDID(ctx), request.Id, title, description)
+	}
+	if err == nil && request.Body.Deprecated != nil {
+		schema, err = s.schemaService.Deprecate(ctx, s.issuerDID(ctx), request.Id, *request.Body.Deprecated)
+	}
+	if err != nil {
+		if errors.Is(err, services.ErrSchemaNotFound) {
+			return UpdateSchema404JSONResponse{N404JSONResponse{Message: "schema not found"}}, nil
+		}
+		if isInvalidProofTypes(err) || errors.Is(err, services.ErrUnknownSchemaAttribute) || errors.Is(err, services.ErrInvalidMaxActivePerSubject) || errors.Is(err, domain.ErrInvalidPostIssuanceHook) || errors.Is(err, domain.ErrInvalidDisplayStrings) || errors.Is(err, domain.ErrInvalidPrerequisite) {
+			return UpdateSchema400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "updating schema", "err", err, "id", request.Id)
+		return UpdateSchema500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 	}
-	return GetSchema200JSONResponse(schemaResponse(schema)), nil
+	return UpdateSchema200JSONResponse(schemaResponse(schema)), nil
 }
 
 // GetSchemas returns the list of schemas that match the request.Params.Query filter. If param query is nil it will return all
 func (s *Server) GetSchemas(ctx context.Context, request GetSchemasRequestObject) (GetSchemasResponseObject, error) {
-	col, err := s.schemaService.GetAll(ctx, s.cfg.APIUI.IssuerDID, request.Params.Query)
+	col, err := s.schemaService.GetAll(ctx, s.issuerDID(ctx), request.Params.Query)
 	if err != nil {
 		return GetSchemas500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 	}
@@ -93,14 +371,134 @@ func (s *Server) ImportSchema(ctx context.Context, request ImportSchemaRequestOb
 		log.Debug(ctx, "Importing schema bad request", "err", err, "req", req)
 		return ImportSchema400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("bad request: %s", err.Error())}}, nil
 	}
-	schema, err := s.schemaService.ImportSchema(ctx, s.cfg.APIUI.IssuerDID, req.Url, req.SchemaType)
+	schema, err := s.schemaService.ImportSchema(ctx, s.issuerDID(ctx), req.Url, req.SchemaType)
 	if err != nil {
+		if errors.Is(err, services.ErrQuotaExceeded) {
+			return ImportSchema400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
 		log.Error(ctx, "Importing schema", "err", err, "req", req)
 		return ImportSchema500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 	}
 	return ImportSchema201JSONResponse{Id: schema.ID.String()}, nil
 }
 
+// CreateSchema creates a version of a schema in the registry of the node, served by the public schema endpoints of this API
+func (s *Server) CreateSchema(ctx context.Context, request CreateSchemaRequestObject) (CreateSchemaResponseObject, error) {
+	req := &ports.CreateSchemaRequest{
+		Type:      request.Body.Type,
+		Version:   request.Body.Version,
+		ServerURL: s.cfg.APIUI.ServerURL,
+	}
+	if request.Body.Title != nil {
+		req.Title = *request.Body.Title
+	}
+	if request.Body.Description != nil {
+		req.Description = *request.Body.Description
+	}
+	var err error
+	if req.JSONSchema, err = json.Marshal(request.Body.JsonSchema); err != nil {
+		return CreateSchema400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("bad request: %s", err.Error())}}, nil
+	}
+	if request.Body.JsonLdContext != nil {
+		if req.JSONLdContext, err = json.Marshal(request.Body.JsonLdContext); err != nil {
+			return CreateSchema400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("bad request: %s", err.Error())}}, nil
+		}
+	}
+	schema, err := s.schemaService.CreateSchema(ctx, s.issuerDID(ctx), req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSchemaVersion) || errors.Is(err, services.ErrSchemaVersionNotGreater) || errors.Is(err, services.ErrInvalidSchemaDocument) || errors.Is(err, services.ErrQuotaExceeded) {
+			return CreateSchema400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "creating schema version", "err", err, "type", req.Type, "version", req.Version)
+		return CreateSchema500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return CreateSchema201JSONResponse{Id: schema.ID.String()}, nil
+}
+
+// BuildSchema generates the json schema and the JSON-LD context of a credential from the definitions of its attributes,
+// and registers or publishes them in IPFS when requested
+func (s *Server) BuildSchema(ctx context.Context, request BuildSchemaRequestObject) (BuildSchemaResponseObject, error) {
+	req := &ports.BuildSchemaRequest{
+		Type:       request.Body.Type,
+		Version:    request.Body.Version,
+		Attributes: make([]domain.SchemaAttributeDefinition, len(request.Body.Attributes)),
+		Register:   request.Body.Register != nil && *request.Body.Register,
+		IPFS:       request.Body.Ipfs != nil && *request.Body.Ipfs,
+		ServerURL:  s.cfg.APIUI.ServerURL,
+	}
+	if request.Body.Title != nil {
+		req.Title = *request.Body.Title
+	}
+	if request.Body.Description != nil {
+		req.Description = *request.Body.Description
+	}
+	for i, attr := range request.Body.Attributes {
+		req.Attributes[i] = domain.SchemaAttributeDefinition{
+			Name:     attr.Name,
+			Type:     domain.SchemaAttributeType(attr.Type),
+			Required: attr.Required != nil && *attr.Required,
+		}
+		if attr.Title != nil {
+			req.Attributes[i].Title = *attr.Title
+		}
+		if attr.Description != nil {
+			req.Attributes[i].Description = *attr.Description
+		}
+	}
+
+	built, err := s.schemaService.BuildSchema(ctx, s.issuerDID(ctx), req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSchemaVersion) || errors.Is(err, services.ErrSchemaVersionNotGreater) || errors.Is(err, services.ErrInvalidSchemaDocument) || errors.Is(err, services.ErrQuotaExceeded) || errors.Is(err, services.ErrIPFSDisabled) {
+			return BuildSchema400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "building schema", "err", err, "type", req.Type, "version", req.Version)
+		return BuildSchema500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	resp, err := buildSchemaResponse(built)
+	if err != nil {
+		log.Error(ctx, "building schema response", "err", err, "type", req.Type)
+		return BuildSchema500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return BuildSchema200JSONResponse(*resp), nil
+}
+
+// GetSchemaVersions returns the versions of the type of a schema of the registry
+func (s *Server) GetSchemaVersions(ctx context.Context, request GetSchemaVersionsRequestObject) (GetSchemaVersionsResponseObject, error) {
+	versions, err := s.schemaService.GetVersions(ctx, s.issuerDID(ctx), request.Id)
+	if errors.Is(err, services.ErrSchemaNotFound) {
+		return GetSchemaVersions404JSONResponse{N404JSONResponse{Message: "schema not found"}}, nil
+	}
+	if errors.Is(err, services.ErrSchemaNotInRegistry) {
+		return GetSchemaVersions400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	if err != nil {
+		log.Error(ctx, "getting schema versions", "err", err, "id", request.Id)
+		return GetSchemaVersions500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetSchemaVersions200JSONResponse(schemaCollectionResponse(versions)), nil
+}
+
+// PurgeSchemaCache removes the json schema or JSON-LD context in request.Params.Url from the schema cache, or the
+// documents of all the imported schemas when it is not set
+func (s *Server) PurgeSchemaCache(ctx context.Context, request PurgeSchemaCacheRequestObject) (PurgeSchemaCacheResponseObject, error) {
+	var schemaURL string
+	if request.Params.Url != nil {
+		schemaURL = strings.TrimSpace(*request.Params.Url)
+		if _, err := url.ParseRequestURI(schemaURL); err != nil {
+			return PurgeSchemaCache400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("bad request: parsing url: %s", err.Error())}}, nil
+		}
+	}
+	purged, err := s.schemaService.PurgeCache(ctx, s.issuerDID(ctx), schemaURL)
+	if err != nil {
+		if errors.Is(err, services.ErrSchemaCacheDisabled) {
+			return PurgeSchemaCache400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "purging schema cache", "err", err, "url", schemaURL)
+		return PurgeSchemaCache500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return PurgeSchemaCache200JSONResponse{Purged: purged}, nil
+}
+
 func guardImportSchemaReq(req *ImportSchemaJSONRequestBody) error {
 	if req == nil {
 		return errors.New("empty body")
@@ -134,7 +532,7 @@ func (s *Server) AuthCallback(ctx context.Context, request AuthCallbackRequestOb
 		return AuthCallback400JSONResponse{N400JSONResponse{"Cannot proceed with empty body"}}, nil
 	}
 
-	_, err := s.identityService.Authenticate(ctx, *request.Body, request.Params.SessionID, s.cfg.APIUI.ServerURL, s.cfg.APIUI.IssuerDID)
+	_, err := s.identityService.Authenticate(ctx, *request.Body, request.Params.SessionID, s.cfg.APIUI.ServerURL, s.issuerDID(ctx))
 	if err != nil {
 		log.Debug(ctx, "error authenticating", err.Error())
 		return AuthCallback500JSONResponse{}, nil
@@ -143,9 +541,31 @@ func (s *Server) AuthCallback(ctx context.Context, request AuthCallbackRequestOb
 	return AuthCallback200Response{}, nil
 }
 
+// GetVerifications returns the results of the verifications of the proofs sent to the issuer, the newest first
+func (s *Server) GetVerifications(ctx context.Context, request GetVerificationsRequestObject) (GetVerificationsResponseObject, error) {
+	filter := domain.VerificationFilter{
+		From:     request.Params.From,
+		To:       request.Params.To,
+		UserDID:  request.Params.Did,
+		Verified: request.Params.Verified,
+	}
+	if request.Params.Limit != nil {
+		if *request.Params.Limit < 1 || *request.Params.Limit > maxVerificationsLimit {
+			return GetVerifications400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("limit must be between 1 and %d", maxVerificationsLimit)}}, nil
+		}
+		filter.Limit = *request.Params.Limit
+	}
+	verifications, err := s.identityService.GetVerifications(ctx, s.issuerDID(ctx), filter)
+	if err != nil {
+		log.Error(ctx, "getting verifications", "err", err)
+		return GetVerifications500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetVerifications200JSONResponse(verificationsResponse(verifications)), nil
+}
+
 // AuthQRCode returns the qr code for authenticating a user
 func (s *Server) AuthQRCode(ctx context.Context, _ AuthQRCodeRequestObject) (AuthQRCodeResponseObject, error) {
-	qrCode, err := s.identityService.CreateAuthenticationQRCode(ctx, s.cfg.APIUI.ServerURL, s.cfg.APIUI.IssuerDID)
+	qrCode, err := s.identityService.CreateAuthenticationQRCode(ctx, s.cfg.APIUI.ServerURL, s.issuerDID(ctx))
 	if err != nil {
 		return AuthQRCode500JSONResponse{N500JSONResponse{"Unexpected error while creating qr code"}}, nil
 	}
@@ -170,7 +590,7 @@ func (s *Server) AuthQRCode(ctx context.Context, _ AuthQRCodeRequestObject) (Aut
 
 // GetConnection returns a connection with its related credentials
 func (s *Server) GetConnection(ctx context.Context, request GetConnectionRequestObject) (GetConnectionResponseObject, error) {
-	conn, err := s.connectionsService.GetByIDAndIssuerID(ctx, request.Id, s.cfg.APIUI.IssuerDID)
+	conn, err := s.connectionsService.GetByIDAndIssuerID(ctx, request.Id, s.issuerDID(ctx))
 	if err != nil {
 		if errors.Is(err, services.ErrConnectionDoesNotExist) {
 			return GetConnection400JSONResponse{N400JSONResponse{"The given connection does not exist"}}, nil
@@ -181,8 +601,9 @@ func (s *Server) GetConnection(ctx context.Context, request GetConnectionRequest
 
 	filter := &ports.ClaimsFilter{
 		Subject: conn.UserDID.String(),
+		PII:     hasPIIScope(ctx),
 	}
-	credentials, err := s.claimService.GetAll(ctx, s.cfg.APIUI.IssuerDID, filter)
+	credentials, err := s.claimService.GetAll(ctx, s.issuerDID(ctx), filter)
 	if err != nil && !errors.Is(err, services.ErrClaimNotFound) {
 		log.Debug(ctx, "get connection internal server error retrieving credentials", "err", err, "req", request)
 		return GetConnection500JSONResponse{N500JSONResponse{"There was an error retrieving the connection"}}, nil
@@ -197,10 +618,40 @@ func (s *Server) GetConnection(ctx context.Context, request GetConnectionRequest
 	return GetConnection200JSONResponse(connectionResponse(conn, w3credentials, credentials)), nil
 }
 
+// GetConnectionCredentials returns the credentials issued to the user of a connection
+func (s *Server) GetConnectionCredentials(ctx context.Context, request GetConnectionCredentialsRequestObject) (GetConnectionCredentialsResponseObject, error) {
+	conn, err := s.connectionsService.GetByIDAndIssuerID(ctx, request.Id, s.issuerDID(ctx))
+	if err != nil {
+		if errors.Is(err, services.ErrConnectionDoesNotExist) {
+			return GetConnectionCredentials400JSONResponse{N400JSONResponse{"The given connection does not exist"}}, nil
+		}
+		log.Debug(ctx, "get connection credentials internal server error", "err", err, "req", request)
+		return GetConnectionCredentials500JSONResponse{N500JSONResponse{"There was an error retrieving the connection"}}, nil
+	}
+
+	credentials, err := s.claimService.GetAll(ctx, s.issuerDID(ctx), &ports.ClaimsFilter{Subject: conn.UserDID.String(), PII: hasPIIScope(ctx)})
+	if err != nil && !errors.Is(err, services.ErrClaimNotFound) {
+		log.Debug(ctx, "get connection credentials internal server error retrieving credentials", "err", err, "req", request)
+		return GetConnectionCredentials500JSONResponse{N500JSONResponse{"There was an error retrieving the credentials of the connection"}}, nil
+	}
+
+	w3credentials, err := schema.FromClaimsModelToW3CCredential(credentials)
+	if err != nil {
+		log.Debug(ctx, "get connection credentials internal server error converting credentials to w3c", "err", err, "req", request)
+		return GetConnectionCredentials500JSONResponse{N500JSONResponse{"There was an error parsing the credentials of the connection"}}, nil
+	}
+
+	resp := make(GetConnectionCredentials200JSONResponse, len(credentials))
+	for i := range credentials {
+		resp[i] = credentialResponse(w3credentials[i], credentials[i])
+	}
+	return resp, nil
+}
+
 // GetConnections returns the list of credentials of a determined issuer
 func (s *Server) GetConnections(ctx context.Context, request GetConnectionsRequestObject) (GetConnectionsResponseObject, error) {
 	req := ports.NewGetAllRequest(request.Params.Credentials, request.Params.Query)
-	conns, err := s.connectionsService.GetAllByIssuerID(ctx, s.cfg.APIUI.IssuerDID, req.Query, req.WithCredentials)
+	conns, err := s.connectionsService.GetAllByIssuerID(ctx, s.issuerDID(ctx), req.Query, req.WithCredentials)
 	if err != nil {
 		log.Error(ctx, "get connection request", "err", err)
 		return GetConnections500JSONResponse{N500JSONResponse{"Unexpected error while retrieving connections"}}, nil
@@ -220,14 +671,14 @@ func (s *Server) GetConnections(ctx context.Context, request GetConnectionsReque
 func (s *Server) DeleteConnection(ctx context.Context, request DeleteConnectionRequestObject) (DeleteConnectionResponseObject, error) {
 	req := ports.NewDeleteRequest(request.Id, request.Params.DeleteCredentials, request.Params.RevokeCredentials)
 	if req.RevokeCredentials {
-		err := s.claimService.RevokeAllFromConnection(ctx, req.ConnID, s.cfg.APIUI.IssuerDID)
+		err := s.claimService.RevokeAllFromConnection(ctx, req.ConnID, s.issuerDID(ctx))
 		if err != nil {
 			log.Error(ctx, "delete connection, revoking credentials", "err", err, "req", request.Id.String())
 			return DeleteConnection500JSONResponse{N500JSONResponse{"There was an error revoking the credentials of the given connection"}}, nil
 		}
 	}
 
-	err := s.connectionsService.Delete(ctx, request.Id, req.DeleteCredentials, s.cfg.APIUI.IssuerDID)
+	err := s.connectionsService.Delete(ctx, request.Id, req.DeleteCredentials, s.issuerDID(ctx))
 	if err != nil {
 		if errors.Is(err, services.ErrConnectionDoesNotExist) {
 			log.Info(ctx, "delete connection, non existing conn", "err", err, "req", request.Id.String())
@@ -242,7 +693,7 @@ func (s *Server) DeleteConnection(ctx context.Context, request DeleteConnectionR
 
 // DeleteConnectionCredentials deletes all the credentials of the given connection
 func (s *Server) DeleteConnectionCredentials(ctx context.Context, request DeleteConnectionCredentialsRequestObject) (DeleteConnectionCredentialsResponseObject, error) {
-	err := s.connectionsService.DeleteCredentials(ctx, request.Id, s.cfg.APIUI.IssuerDID)
+	err := s.connectionsService.DeleteCredentials(ctx, request.Id, s.issuerDID(ctx))
 	if err != nil {
 		log.Error(ctx, "delete connection request", err, "req", request)
 		return DeleteConnectionCredentials500JSONResponse{N500JSONResponse{"There was an error deleting the credentials of the given connection"}}, nil
@@ -253,7 +704,13 @@ func (s *Server) DeleteConnectionCredentials(ctx context.Context, request Delete
 
 // GetCredential returns a credential
 func (s *Server) GetCredential(ctx context.Context, request GetCredentialRequestObject) (GetCredentialResponseObject, error) {
-	credential, err := s.claimService.GetByID(ctx, &s.cfg.APIUI.IssuerDID, request.Id)
+	var credential *domain.Claim
+	var err error
+	if request.Params.AsOf != nil {
+		credential, err = s.claimService.GetByIDAsOf(ctx, common.ToPointer(s.issuerDID(ctx)), request.Id, *request.Params.AsOf)
+	} else {
+		credential, err = s.claimService.GetByID(ctx, common.ToPointer(s.issuerDID(ctx)), request.Id)
+	}
 	if err != nil {
 		if errors.Is(err, services.ErrClaimNotFound) {
 			return GetCredential400JSONResponse{N400JSONResponse{"The given credential id does not exist"}}, nil
@@ -266,20 +723,29 @@ func (s *Server) GetCredential(ctx context.Context, request GetCredentialRequest
 		return GetCredential500JSONResponse{N500JSONResponse{"Invalid claim format"}}, nil
 	}
 
-	return GetCredential200JSONResponse(credentialResponse(w3c, credential)), nil
+	return GetCredential200JSONResponse(credentialResponseAt(w3c, credential, asOfOrNow(request.Params.AsOf))), nil
 }
 
 // GetCredentials returns a collection of credentials that matches the request.
 func (s *Server) GetCredentials(ctx context.Context, request GetCredentialsRequestObject) (GetCredentialsResponseObject, error) {
-	filter, err := getCredentialsFilter(ctx, request.Params.Did, request.Params.Status, request.Params.Query)
+	filter, err := getCredentialsFilter(ctx, request.Params.Did, request.Params.Status, request.Params.Query, request.Params.AsOf)
 	if err != nil {
 		return GetCredentials400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
-	credentials, err := s.claimService.GetAll(ctx, s.cfg.APIUI.IssuerDID, filter)
+	filter.PII = hasPIIScope(ctx)
+	if err := filter.Paginate(request.Params.Limit, request.Params.PageToken); err != nil {
+		return GetCredentials400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	credentials, err := s.claimService.GetAll(ctx, s.issuerDID(ctx), filter)
 	if err != nil {
 		log.Error(ctx, "loading credentials", "err", err, "req", request)
 		return GetCredentials500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 	}
+	total, err := s.claimService.Count(ctx, s.issuerDID(ctx), filter)
+	if err != nil {
+		log.Error(ctx, "counting credentials", "err", err, "req", request)
+		return GetCredentials500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
 	response := make([]Credential, len(credentials))
 	for i, credential := range credentials {
 		w3c, err := schema.FromClaimModelToW3CCredential(*credential)
@@ -287,17 +753,20 @@ func (s *Server) GetCredentials(ctx context.Context, request GetCredentialsReque
 			log.Error(ctx, "creating credentials response", "err", err, "req", request)
 			return GetCredentials500JSONResponse{N500JSONResponse{"Invalid claim format"}}, nil
 		}
-		response[i] = credentialResponse(w3c, credential)
+		response[i] = credentialResponseAt(w3c, credential, asOfOrNow(request.Params.AsOf))
 	}
-	return GetCredentials200JSONResponse(response), nil
+	return GetCredentials200JSONResponse{
+		Body:    response,
+		Headers: GetCredentials200ResponseHeaders{XTotalCount: total, XNextPageToken: domain.NextPageToken(filter.Offset, len(credentials), total)},
+	}, nil
 }
 
 // DeleteCredential deletes a credential
 func (s *Server) DeleteCredential(ctx context.Context, request DeleteCredentialRequestObject) (DeleteCredentialResponseObject, error) {
-	err := s.claimService.Delete(ctx, request.Id)
+	err := s.claimService.Delete(ctx, s.issuerDID(ctx), request.Id)
 	if err != nil {
 		if errors.Is(err, services.ErrClaimNotFound) {
-			return DeleteCredential400JSONResponse{N400JSONResponse{"The given credential does not exist"}}, nil
+			return DeleteCredential404JSONResponse{N404JSONResponse{"The given credential does not exist"}}, nil
 		}
 		return DeleteCredential500JSONResponse{N500JSONResponse{"There was an error deleting the credential"}}, nil
 	}
@@ -312,41 +781,158 @@ func (s *Server) GetYaml(_ context.Context, _ GetYamlRequestObject) (GetYamlResp
 
 // CreateCredential - creates a new credential
 func (s *Server) CreateCredential(ctx context.Context, request CreateCredentialRequestObject) (CreateCredentialResponseObject, error) {
-	if request.Body.SignatureProof == nil && request.Body.MtProof == nil {
-		return CreateCredential400JSONResponse{N400JSONResponse{Message: "you must to provide at least one proof type"}}, nil
+	validationMode, err := toValidationMode(request.Body.ValidationMode)
+	if err != nil {
+		return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
-	req := ports.NewCreateClaimRequest(&s.cfg.APIUI.IssuerDID, request.Body.CredentialSchema, request.Body.CredentialSubject, request.Body.Expiration, request.Body.Type, nil, nil, nil, request.Body.SignatureProof, request.Body.MtProof, nil, true)
+	req := ports.NewCreateClaimRequest(common.ToPointer(s.issuerDID(ctx)), request.Body.CredentialSchema, request.Body.CredentialSubject, request.Body.Expiration, request.Body.Type, nil, nil, nil, request.Body.SignatureProof, request.Body.MtProof, nil, true)
+	req.DisplayMethod = toDisplayMethod(request.Body.DisplayMethod)
+	req.ValidationMode = validationMode
 	resp, err := s.claimService.Save(ctx, req)
 	if err != nil {
-		if errors.Is(err, services.ErrJSONLdContext) {
-			return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		if errors.Is(err, services.ErrLoadingSchema) {
+			return CreateCredential422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrIssuanceLimitExceeded) || errors.Is(err, services.ErrQuotaExceeded) {
+			return CreateCredential409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrIssuanceDenied) || errors.Is(err, services.ErrAPIKeyScope) {
+			return CreateCredential403JSONResponse{N403JSONResponse{Message: err.Error()}}, nil
 		}
-		if errors.Is(err, services.ErrProcessSchema) {
+		if isInvalidCredentialRequest(err) {
 			return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 		}
+		return CreateCredential500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return CreateCredential201JSONResponse{Id: resp.ID.String(), Warnings: toWarnings(req.Warnings)}, nil
+}
+
+// CreateConnectionCredential issues a credential to the user of a connection and pushes the offer to the user devices
+func (s *Server) CreateConnectionCredential(ctx context.Context, request CreateConnectionCredentialRequestObject) (CreateConnectionCredentialResponseObject, error) {
+	conn, err := s.connectionsService.GetByIDAndIssuerID(ctx, request.Id, s.issuerDID(ctx))
+	if err != nil {
+		if errors.Is(err, services.ErrConnectionDoesNotExist) {
+			return CreateConnectionCredential404JSONResponse{N404JSONResponse{Message: "The given connection does not exist"}}, nil
+		}
+		log.Error(ctx, "create connection credential, getting the connection", "err", err, "id", request.Id)
+		return CreateConnectionCredential500JSONResponse{N500JSONResponse{Message: "There was an error retrieving the connection"}}, nil
+	}
+
+	credentialSubject := make(map[string]any, len(request.Body.CredentialSubject)+1)
+	for k, v := range request.Body.CredentialSubject {
+		credentialSubject[k] = v
+	}
+	credentialSubject["id"] = conn.UserDID.String()
+
+	req := ports.NewCreateClaimRequest(common.ToPointer(s.issuerDID(ctx)), request.Body.CredentialSchema, credentialSubject, request.Body.Expiration, request.Body.Type, nil, nil, nil, request.Body.SignatureProof, request.Body.MtProof, nil, true)
+	req.SkipNotification = true
+	req.DisplayMethod = toDisplayMethod(request.Body.DisplayMethod)
+	credential, err := s.claimService.Save(ctx, req)
+	if err != nil {
 		if errors.Is(err, services.ErrLoadingSchema) {
-			return CreateCredential422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
+			return CreateConnectionCredential422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
 		}
-		if errors.Is(err, services.ErrParseClaim) {
-			return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		if errors.Is(err, services.ErrIssuanceLimitExceeded) || errors.Is(err, services.ErrQuotaExceeded) {
+			return CreateConnectionCredential409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
 		}
-		if errors.Is(err, services.ErrInvalidCredentialSubject) {
-			return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		if errors.Is(err, services.ErrIssuanceDenied) {
+			return CreateConnectionCredential403JSONResponse{N403JSONResponse{Message: err.Error()}}, nil
+		}
+		if isInvalidCredentialRequest(err) {
+			return CreateConnectionCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "create connection credential", "err", err, "id", request.Id)
+		return CreateConnectionCredential500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	return CreateConnectionCredential201JSONResponse{
+		Id:           credential.ID,
+		Notification: s.sendCredentialOffer(ctx, conn, credential, req.SignatureProof),
+	}, nil
+}
+
+// ReissueCredential replaces a credential with a new one with the changed attributes and revokes it
+func (s *Server) ReissueCredential(ctx context.Context, request ReissueCredentialRequestObject) (ReissueCredentialResponseObject, error) {
+	credential, err := s.claimService.Reissue(ctx, &ports.ReissueClaimRequest{
+		DID:               common.ToPointer(s.issuerDID(ctx)),
+		ID:                request.Id,
+		CredentialSubject: request.Body.CredentialSubject,
+		Expiration:        request.Body.Expiration,
+		SingleIssuer:      true,
+		SkipNotification:  true,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrClaimNotFound) {
+			return ReissueCredential404JSONResponse{N404JSONResponse{Message: "credential not found"}}, nil
 		}
 		if errors.Is(err, services.ErrLoadingSchema) {
-			return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+			return ReissueCredential422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
 		}
-		if errors.Is(err, services.ErrMalformedURL) {
-			return CreateCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		if errors.Is(err, services.ErrIssuanceLimitExceeded) || errors.Is(err, services.ErrQuotaExceeded) {
+			return ReissueCredential409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
 		}
-		return CreateCredential500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		if errors.Is(err, services.ErrIssuanceDenied) {
+			return ReissueCredential403JSONResponse{N403JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrClaimRevoked) || isInvalidCredentialRequest(err) {
+			return ReissueCredential400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "reissue credential", "err", err, "id", request.Id)
+		return ReissueCredential500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	signatureProof := credential.SignatureProof.Bytes != nil
+	notification := NotificationStatus{Devices: []DeviceNotificationStatus{}, Status: NotificationStatusStatusSkipped}
+	if holderDID, err := core.ParseDID(credential.OtherIdentifier); err != nil {
+		notification.Reason = common.ToPointer("the credential has no holder")
+	} else if conn, err := s.connectionsService.GetByUserID(ctx, s.issuerDID(ctx), *holderDID); err != nil {
+		notification.Reason = common.ToPointer("the holder has no connection with the issuer")
+		if !errors.Is(err, services.ErrConnectionDoesNotExist) {
+			log.Error(ctx, "reissue credential, getting the holder connection", "err", err, "id", request.Id)
+			notification.Reason = common.ToPointer("cannot get the holder connection")
+		}
+	} else {
+		notification = s.sendCredentialOffer(ctx, conn, credential, signatureProof)
 	}
-	return CreateCredential201JSONResponse{Id: resp.ID.String()}, nil
+
+	return ReissueCredential201JSONResponse{
+		Id:           credential.ID,
+		Replaces:     request.Id,
+		Notification: notification,
+	}, nil
+}
+
+// sendCredentialOffer pushes the offer of a new credential to the devices of the connection. Failures are reported
+// in the returned status instead of failing the request, as the credential has already been issued.
+func (s *Server) sendCredentialOffer(ctx context.Context, conn *domain.Connection, credential *domain.Claim, signatureProof bool) NotificationStatus {
+	status := NotificationStatus{Devices: []DeviceNotificationStatus{}}
+	if !signatureProof {
+		status.Status = NotificationStatusStatusSkipped
+		status.Reason = common.ToPointer("credentials without signature proof can be fetched once the issuer state is published")
+		return status
+	}
+
+	res, err := s.notificationService.SendCredentialOffer(ctx, conn, credential)
+	if err != nil {
+		log.Warn(ctx, "sending credential offer to the connection", "err", err, "connectionID", conn.ID, "credentialID", credential.ID)
+		status.Status = NotificationStatusStatusFailed
+		status.Reason = common.ToPointer(err.Error())
+		return status
+	}
+
+	status.Status = NotificationStatusStatusSent
+	for _, device := range res.Devices {
+		status.Devices = append(status.Devices, DeviceNotificationStatus{Status: string(device.Status), Reason: device.Reason})
+		if device.Status != domain.DeviceNotificationStatusSuccess {
+			status.Status = NotificationStatusStatusFailed
+		}
+	}
+	return status
 }
 
 // RevokeCredential - revokes a credential per a given nonce
 func (s *Server) RevokeCredential(ctx context.Context, request RevokeCredentialRequestObject) (RevokeCredentialResponseObject, error) {
-	if err := s.claimService.Revoke(ctx, s.cfg.APIUI.IssuerDID, uint64(request.Nonce), ""); err != nil {
+	if err := s.claimService.Revoke(ctx, s.issuerDID(ctx), uint64(request.Nonce), ""); err != nil {
 		if errors.Is(err, repositories.ErrClaimDoesNotExist) {
 			return RevokeCredential404JSONResponse{N404JSONResponse{
 				Message: "the claim does not exist",
@@ -362,7 +948,7 @@ func (s *Server) RevokeCredential(ctx context.Context, request RevokeCredentialR
 
 // GetRevocationStatus - returns weather a credential is revoked or not, this endpoint must be public available
 func (s *Server) GetRevocationStatus(ctx context.Context, request GetRevocationStatusRequestObject) (GetRevocationStatusResponseObject, error) {
-	rs, err := s.claimService.GetRevocationStatus(ctx, s.cfg.APIUI.IssuerDID, uint64(request.Nonce))
+	rs, err := s.claimService.GetRevocationStatus(ctx, s.issuerDID(ctx), uint64(request.Nonce))
 	if err != nil {
 		return GetRevocationStatus500JSONResponse{N500JSONResponse{
 			Message: err.Error(),
@@ -374,7 +960,7 @@ func (s *Server) GetRevocationStatus(ctx context.Context, request GetRevocationS
 
 // PublishState - pubish the state onchange
 func (s *Server) PublishState(ctx context.Context, request PublishStateRequestObject) (PublishStateResponseObject, error) {
-	publishedState, err := s.publisherGateway.PublishState(ctx, &s.cfg.APIUI.IssuerDID)
+	publishedState, err := s.publisherGateway.PublishState(ctx, common.ToPointer(s.issuerDID(ctx)))
 	if err != nil {
 		log.Error(ctx, "error publishing the state", "err", err)
 		if errors.Is(err, gateways.ErrStateIsBeingProcessed) || errors.Is(err, gateways.ErrNoStatesToProcess) {
@@ -394,7 +980,7 @@ func (s *Server) PublishState(ctx context.Context, request PublishStateRequestOb
 
 // RetryPublishState - retry to publish the current state if it failed previously.
 func (s *Server) RetryPublishState(ctx context.Context, request RetryPublishStateRequestObject) (RetryPublishStateResponseObject, error) {
-	publishedState, err := s.publisherGateway.RetryPublishState(ctx, &s.cfg.APIUI.IssuerDID)
+	publishedState, err := s.publisherGateway.RetryPublishState(ctx, common.ToPointer(s.issuerDID(ctx)))
 	if err != nil {
 		log.Error(ctx, "error retrying the publishing the state", "err", err)
 		if errors.Is(err, gateways.ErrStateIsBeingProcessed) || errors.Is(err, gateways.ErrNoFailedStatesToProcess) {
@@ -413,7 +999,7 @@ func (s *Server) RetryPublishState(ctx context.Context, request RetryPublishStat
 
 // GetStateStatus - get the state status
 func (s *Server) GetStateStatus(ctx context.Context, _ GetStateStatusRequestObject) (GetStateStatusResponseObject, error) {
-	pendingActions, err := s.identityService.HasUnprocessedAndFailedStatesByID(ctx, s.cfg.APIUI.IssuerDID)
+	pendingActions, err := s.identityService.HasUnprocessedAndFailedStatesByID(ctx, s.issuerDID(ctx))
 	if err != nil {
 		log.Error(ctx, "get state status", "err", err)
 		return GetStateStatus500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
@@ -423,19 +1009,22 @@ func (s *Server) GetStateStatus(ctx context.Context, _ GetStateStatusRequestObje
 }
 
 // GetStateTransactions - get the state transactions
-func (s *Server) GetStateTransactions(ctx context.Context, _ GetStateTransactionsRequestObject) (GetStateTransactionsResponseObject, error) {
-	states, err := s.identityService.GetStates(ctx, s.cfg.APIUI.IssuerDID)
+func (s *Server) GetStateTransactions(ctx context.Context, request GetStateTransactionsRequestObject) (GetStateTransactionsResponseObject, error) {
+	states, err := s.identityService.GetStates(ctx, s.issuerDID(ctx))
 	if err != nil {
 		log.Error(ctx, "get state transactions", "err", err)
 		return GetStateTransactions500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 	}
+	if request.Params.AsOf != nil {
+		states = domain.IdentityStatesAsOf(states, *request.Params.AsOf)
+	}
 
 	return GetStateTransactions200JSONResponse(stateTransactionsResponse(states)), nil
 }
 
 // RevokeConnectionCredentials revoke all the non revoked credentials of the given connection
 func (s *Server) RevokeConnectionCredentials(ctx context.Context, request RevokeConnectionCredentialsRequestObject) (RevokeConnectionCredentialsResponseObject, error) {
-	err := s.claimService.RevokeAllFromConnection(ctx, request.Id, s.cfg.APIUI.IssuerDID)
+	err := s.claimService.RevokeAllFromConnection(ctx, request.Id, s.issuerDID(ctx))
 	if err != nil {
 		log.Error(ctx, "revoke connection credentials", "err", err, "req", request)
 		return RevokeConnectionCredentials500JSONResponse{N500JSONResponse{"There was an error revoking the credentials of the given connection"}}, nil
@@ -474,7 +1063,36 @@ func (s *Server) CreateLink(ctx context.Context, request CreateLinkRequestObject
 		expirationDate = &request.Body.CredentialExpiration.Time
 	}
 
-	createdLink, err := s.linkService.Save(ctx, s.cfg.APIUI.IssuerDID, request.Body.LimitedClaims, request.Body.Expiration, request.Body.SchemaID, expirationDate, request.Body.SignatureProof, request.Body.MtProof, credSubject)
+	var expirationPolicy *domain.ExpirationPolicy
+	if request.Body.CredentialExpirationPolicy != nil {
+		if expirationDate != nil {
+			return CreateLink400JSONResponse{N400JSONResponse{Message: "credentialExpiration and credentialExpirationPolicy cannot be used together"}}, nil
+		}
+		var err error
+		if expirationPolicy, err = domain.ParseExpirationPolicy(*request.Body.CredentialExpirationPolicy); err != nil {
+			return CreateLink400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+	}
+
+	displayStrings := toDisplayStrings(request.Body.DisplayStrings)
+	if err := displayStrings.Validate(); err != nil {
+		return CreateLink400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	if request.Body.Locale != nil {
+		if err := domain.ValidateLocale(*request.Body.Locale); err != nil {
+			return CreateLink400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+	}
+	prerequisites := toPrerequisites(request.Body.Prerequisites)
+	if err := prerequisites.Validate(); err != nil {
+		return CreateLink400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	validationMode, err := toValidationMode(request.Body.ValidationMode)
+	if err != nil {
+		return CreateLink400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	createdLink, err := s.linkService.Save(ctx, s.issuerDID(ctx), request.Body.LimitedClaims, request.Body.Expiration, request.Body.SchemaID, expirationDate, expirationPolicy, request.Body.SignatureProof, request.Body.MtProof, credSubject, validationMode)
 	if err != nil {
 		log.Error(ctx, "error saving the link", "err", err.Error())
 		if errors.Is(err, services.ErrLoadingSchema) {
@@ -482,12 +1100,24 @@ func (s *Server) CreateLink(ctx context.Context, request CreateLinkRequestObject
 		}
 		return CreateLink400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
-	return CreateLink201JSONResponse{Id: createdLink.ID.String()}, nil
+	if request.Body.Locale != nil || displayStrings != nil {
+		if _, err := s.linkService.UpdateDisplay(ctx, s.issuerDID(ctx), createdLink.ID, request.Body.Locale, displayStrings); err != nil {
+			log.Error(ctx, "error saving the link display", "err", err.Error(), "id", createdLink.ID)
+			return CreateLink500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		}
+	}
+	if prerequisites != nil {
+		if _, err := s.linkService.UpdatePrerequisites(ctx, s.issuerDID(ctx), createdLink.ID, prerequisites); err != nil {
+			log.Error(ctx, "error saving the link prerequisites", "err", err.Error(), "id", createdLink.ID)
+			return CreateLink500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		}
+	}
+	return CreateLink201JSONResponse{Id: createdLink.ID.String(), Warnings: toWarnings(createdLink.ValidationWarnings)}, nil
 }
 
 // GetLink returns a link from an id
 func (s *Server) GetLink(ctx context.Context, request GetLinkRequestObject) (GetLinkResponseObject, error) {
-	link, err := s.linkService.GetByID(ctx, s.cfg.APIUI.IssuerDID, request.Id)
+	link, err := s.linkService.GetByID(ctx, s.issuerDID(ctx), request.Id)
 	if err != nil {
 		if errors.Is(err, services.ErrLinkNotFound) {
 			return GetLink404JSONResponse{N404JSONResponse{Message: "link not found"}}, nil
@@ -509,7 +1139,7 @@ func (s *Server) GetLinks(ctx context.Context, request GetLinksRequestObject) (G
 			return GetLinks400JSONResponse{N400JSONResponse{Message: "unknown request type. Allowed: all|active|inactive|exceed"}}, nil
 		}
 	}
-	links, err := s.linkService.GetAll(ctx, s.cfg.APIUI.IssuerDID, status, request.Params.Query)
+	links, err := s.linkService.GetAll(ctx, s.issuerDID(ctx), status, request.Params.Query)
 	if err != nil {
 		log.Error(ctx, "getting links", "err", err, "req", request)
 	}
@@ -519,9 +1149,9 @@ func (s *Server) GetLinks(ctx context.Context, request GetLinksRequestObject) (G
 
 // AcivateLink - Activates or deactivates a link
 func (s *Server) AcivateLink(ctx context.Context, request AcivateLinkRequestObject) (AcivateLinkResponseObject, error) {
-	err := s.linkService.Activate(ctx, s.cfg.APIUI.IssuerDID, request.Id, request.Body.Active)
+	err := s.linkService.Activate(ctx, s.issuerDID(ctx), request.Id, request.Body.Active)
 	if err != nil {
-		if errors.Is(err, repositories.ErrLinkDoesNotExist) || errors.Is(err, services.ErrLinkAlreadyActive) || errors.Is(err, services.ErrLinkAlreadyInactive) {
+		if errors.Is(err, repositories.ErrLinkDoesNotExist) || errors.Is(err, services.ErrLinkAlreadyActive) || errors.Is(err, services.ErrLinkAlreadyInactive) || errors.Is(err, services.ErrQuotaExceeded) {
 			return AcivateLink400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 		}
 		log.Error(ctx, "error activating or deactivating link", err.Error(), "id", request.Id)
@@ -532,7 +1162,7 @@ func (s *Server) AcivateLink(ctx context.Context, request AcivateLinkRequestObje
 
 // DeleteLink - delete a link
 func (s *Server) DeleteLink(ctx context.Context, request DeleteLinkRequestObject) (DeleteLinkResponseObject, error) {
-	if err := s.linkService.Delete(ctx, request.Id, s.cfg.APIUI.IssuerDID); err != nil {
+	if err := s.linkService.Delete(ctx, request.Id, s.issuerDID(ctx)); err != nil {
 		if errors.Is(err, repositories.ErrLinkDoesNotExist) {
 			return DeleteLink400JSONResponse{N400JSONResponse{Message: "link does not exist"}}, nil
 		}
@@ -543,7 +1173,7 @@ func (s *Server) DeleteLink(ctx context.Context, request DeleteLinkRequestObject
 
 // CreateLinkQrCode - Creates a link QrCode
 func (s *Server) CreateLinkQrCode(ctx context.Context, request CreateLinkQrCodeRequestObject) (CreateLinkQrCodeResponseObject, error) {
-	createLinkQrCodeResponse, err := s.linkService.CreateQRCode(ctx, s.cfg.APIUI.IssuerDID, request.Id, s.cfg.APIUI.ServerURL)
+	createLinkQrCodeResponse, err := s.linkService.CreateQRCode(ctx, s.issuerDID(ctx), request.Id, s.cfg.APIUI.ServerURL)
 	if err != nil {
 		if errors.Is(err, services.ErrLinkNotFound) {
 			return CreateLinkQrCode404JSONResponse{N404JSONResponse{Message: "error: link not found"}}, nil
@@ -554,10 +1184,17 @@ func (s *Server) CreateLinkQrCode(ctx context.Context, request CreateLinkQrCodeR
 		log.Error(ctx, "Unexpected error while creating qr code", "err", err)
 		return CreateLinkQrCode500JSONResponse{N500JSONResponse{"Unexpected error while creating qr code"}}, nil
 	}
+	branding, err := s.brandingService.Get(ctx, s.issuerDID(ctx))
+	if err != nil {
+		log.Error(ctx, "getting the branding of the link qr code", "err", err)
+		return CreateLinkQrCode500JSONResponse{N500JSONResponse{"Unexpected error while creating qr code"}}, nil
+	}
 	return CreateLinkQrCode200JSONResponse{
 		Issuer: IssuerDescription{
-			DisplayName: s.cfg.APIUI.IssuerName,
-			Logo:        s.cfg.APIUI.IssuerLogo,
+			DisplayName:  branding.DisplayName,
+			Logo:         branding.LogoURL,
+			PrimaryColor: branding.PrimaryColor,
+			TextColor:    branding.TextColor,
 		},
 		QrCode: AuthenticationQrCodeResponse{
 			Body: struct {
@@ -567,7 +1204,7 @@ func (s *Server) CreateLinkQrCode(ctx context.Context, request CreateLinkQrCodeR
 			}{
 				CallbackUrl: createLinkQrCodeResponse.QrCode.Body.CallbackURL,
 				Reason:      createLinkQrCodeResponse.QrCode.Body.Reason,
-				Scope:       []interface{}{},
+				Scope:       linkQrCodeScope(createLinkQrCodeResponse.QrCode.Body.Scope),
 			},
 			From: createLinkQrCodeResponse.QrCode.From,
 			Id:   createLinkQrCodeResponse.QrCode.ID,
@@ -582,7 +1219,7 @@ func (s *Server) CreateLinkQrCode(ctx context.Context, request CreateLinkQrCodeR
 
 // GetCredentialQrCode - returns a QR Code for fetching the credential
 func (s *Server) GetCredentialQrCode(ctx context.Context, request GetCredentialQrCodeRequestObject) (GetCredentialQrCodeResponseObject, error) {
-	credential, err := s.claimService.GetByID(ctx, &s.cfg.APIUI.IssuerDID, request.Id)
+	credential, err := s.claimService.GetByID(ctx, common.ToPointer(s.issuerDID(ctx)), request.Id)
 	if err != nil {
 		if errors.Is(err, services.ErrClaimNotFound) {
 			return GetCredentialQrCode400JSONResponse{N400JSONResponse{"Credential not found"}}, nil
@@ -590,7 +1227,16 @@ func (s *Server) GetCredentialQrCode(ctx context.Context, request GetCredentialQ
 		return GetCredentialQrCode500JSONResponse{N500JSONResponse{err.Error()}}, nil
 	}
 
-	return GetCredentialQrCode200JSONResponse(getCredentialQrCodeResponse(credential, s.cfg.APIUI.ServerURL)), nil
+	resp := getCredentialQrCodeResponse(credential, s.cfg.APIUI.ServerURL)
+	if request.Params.AcceptLanguage != nil {
+		displayStrings, err := s.schemaService.GetDisplayStringsByURL(ctx, s.issuerDID(ctx), credential.SchemaURL)
+		if err != nil {
+			log.Warn(ctx, "the schema display strings are not available", "err", err, "schema", credential.SchemaURL)
+		} else if display, found := displayStrings.Localize(domain.ParseAcceptLanguage(*request.Params.AcceptLanguage)...); found {
+			resp.Body.Credentials[0].Description = display.Name
+		}
+	}
+	return GetCredentialQrCode200JSONResponse(resp), nil
 }
 
 // CreateLinkQrCodeCallback - Callback endpoint for the link qr code creation.
@@ -600,7 +1246,7 @@ func (s *Server) CreateLinkQrCodeCallback(ctx context.Context, request CreateLin
 		return CreateLinkQrCodeCallback400JSONResponse{N400JSONResponse{"Cannot proceed with empty body"}}, nil
 	}
 
-	arm, err := s.identityService.Authenticate(ctx, *request.Body, request.Params.SessionID, s.cfg.APIUI.ServerURL, s.cfg.APIUI.IssuerDID)
+	arm, err := s.identityService.Authenticate(ctx, *request.Body, request.Params.SessionID, s.cfg.APIUI.ServerURL, s.issuerDID(ctx))
 	if err != nil {
 		log.Debug(ctx, "error authenticating", err.Error())
 		return CreateLinkQrCodeCallback500JSONResponse{}, nil
@@ -612,7 +1258,7 @@ func (s *Server) CreateLinkQrCodeCallback(ctx context.Context, request CreateLin
 		return CreateLinkQrCodeCallback500JSONResponse{}, nil
 	}
 
-	err = s.linkService.IssueClaim(ctx, request.Params.SessionID.String(), s.cfg.APIUI.IssuerDID, *userDID, request.Params.LinkID, s.cfg.APIUI.ServerURL)
+	err = s.linkService.IssueClaim(ctx, request.Params.SessionID.String(), s.issuerDID(ctx), *userDID, request.Params.LinkID, s.cfg.APIUI.ServerURL)
 	if err != nil {
 		log.Debug(ctx, "error issuing the claim", "error", err)
 		return CreateLinkQrCodeCallback500JSONResponse{}, nil
@@ -623,7 +1269,7 @@ func (s *Server) CreateLinkQrCodeCallback(ctx context.Context, request CreateLin
 
 // GetLinkQRCode - returns te qr code for adding the credential
 func (s *Server) GetLinkQRCode(ctx context.Context, request GetLinkQRCodeRequestObject) (GetLinkQRCodeResponseObject, error) {
-	getQRCodeResponse, err := s.linkService.GetQRCode(ctx, request.Params.SessionID, s.cfg.APIUI.IssuerDID, request.Id)
+	getQRCodeResponse, err := s.linkService.GetQRCode(ctx, request.Params.SessionID, s.issuerDID(ctx), request.Id, s.cfg.APIUI.ServerURL)
 	if err != nil {
 		if errors.Is(services.ErrLinkNotFound, err) {
 			return GetLinkQRCode404JSONResponse{Message: "error: link not found"}, nil
@@ -632,9 +1278,19 @@ func (s *Server) GetLinkQRCode(ctx context.Context, request GetLinkQRCodeRequest
 	}
 
 	if getQRCodeResponse.State.Status == link_state.StatusPending || getQRCodeResponse.State.Status == link_state.StatusDone || getQRCodeResponse.State.Status == link_state.StatusPendingPublish {
+		var locales []string
+		if request.Params.AcceptLanguage != nil {
+			locales = domain.ParseAcceptLanguage(*request.Params.AcceptLanguage)
+		}
+		qrCode := getLinkQrCodeResponse(getQRCodeResponse.State.QRCode)
+		if display, found := getQRCodeResponse.Link.Display(locales...); found && qrCode != nil {
+			for i := range qrCode.Body.Credentials {
+				qrCode.Body.Credentials[i].Description = display.Name
+			}
+		}
 		return GetLinkQRCode200JSONResponse{
 			Status:     common.ToPointer(getQRCodeResponse.State.Status),
-			QrCode:     getLinkQrCodeResponse(getQRCodeResponse.State.QRCode),
+			QrCode:     qrCode,
 			LinkDetail: getLinkSimpleResponse(*getQRCodeResponse.Link),
 		}, nil
 	}
@@ -644,6 +1300,47 @@ func (s *Server) GetLinkQRCode(ctx context.Context, request GetLinkQRCodeRequest
 	}}, nil
 }
 
+// GetLinkSessions returns the sessions of a link, to follow the wallets claiming its credential
+func (s *Server) GetLinkSessions(ctx context.Context, request GetLinkSessionsRequestObject) (GetLinkSessionsResponseObject, error) {
+	filter := ports.LinkSessionsFilter{UserDID: request.Params.UserDID, Limit: defaultLinkSessionsLimit}
+	if request.Params.Status != nil {
+		status, err := domain.LinkSessionStatusFromString(string(*request.Params.Status))
+		if err != nil {
+			return GetLinkSessions400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		filter.Status = &status
+	}
+	if request.Params.Limit != nil {
+		if *request.Params.Limit < 1 || *request.Params.Limit > maxLinkSessionsLimit {
+			return GetLinkSessions400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("limit must be between 1 and %d", maxLinkSessionsLimit)}}, nil
+		}
+		filter.Limit = *request.Params.Limit
+	}
+
+	sessions, err := s.linkService.GetSessions(ctx, s.issuerDID(ctx), request.Id, filter)
+	if err != nil {
+		if errors.Is(err, services.ErrLinkNotFound) {
+			return GetLinkSessions404JSONResponse{N404JSONResponse{Message: "link not found"}}, nil
+		}
+		log.Error(ctx, "getting the link sessions", "err", err, "id", request.Id)
+		return GetLinkSessions500JSONResponse{N500JSONResponse{Message: "error getting the link sessions"}}, nil
+	}
+	return GetLinkSessions200JSONResponse(linkSessionsResponse(sessions)), nil
+}
+
+// GetLinkSession returns a session of a link
+func (s *Server) GetLinkSession(ctx context.Context, request GetLinkSessionRequestObject) (GetLinkSessionResponseObject, error) {
+	session, err := s.linkService.GetSession(ctx, s.issuerDID(ctx), request.Id, request.SessionID)
+	if err != nil {
+		if errors.Is(err, services.ErrLinkSessionNotFound) {
+			return GetLinkSession404JSONResponse{N404JSONResponse{Message: "link session not found"}}, nil
+		}
+		log.Error(ctx, "getting the link session", "err", err, "id", request.Id, "session", request.SessionID)
+		return GetLinkSession500JSONResponse{N500JSONResponse{Message: "error getting the link session"}}, nil
+	}
+	return GetLinkSession200JSONResponse(linkSessionResponse(session)), nil
+}
+
 // Agent is the controller to fetch credentials from mobile
 func (s *Server) Agent(ctx context.Context, request AgentRequestObject) (AgentResponseObject, error) {
 	if request.Body == nil || *request.Body == "" {
@@ -656,18 +1353,30 @@ func (s *Server) Agent(ctx context.Context, request AgentRequestObject) (AgentRe
 		return Agent400JSONResponse{N400JSONResponse{"cannot proceed with the given request"}}, nil
 	}
 
-	req, err := ports.NewAgentRequest(basicMessage)
+	req, err := ports.NewAgentRequest([]byte(*request.Body), basicMessage)
 	if err != nil {
 		log.Error(ctx, "agent parsing request", "err", err)
 		return Agent400JSONResponse{N400JSONResponse{err.Error()}}, nil
 	}
 
 	agent, err := s.claimService.Agent(ctx, req)
+	if errors.Is(err, services.ErrAgentMessageReplayed) || errors.Is(err, services.ErrAgentMessageOutsideWindow) || errors.Is(err, services.ErrAgentMessageNoCreatedTime) {
+		log.Warn(ctx, "agent rejected message", "err", err, "userDID", req.UserDID, "type", req.Type)
+		return Agent400JSONResponse{N400JSONResponse{err.Error()}}, nil
+	}
+	if errors.Is(err, services.ErrRegionPassive) {
+		log.Warn(ctx, "agent message rejected by the passive region", "err", err, "userDID", req.UserDID, "type", req.Type)
+		return nil, apiErrors.UnavailableError{Err: err, RetryAfter: s.cfg.Region.LeaseTTL}
+	}
 	if err != nil {
 		log.Error(ctx, "agent error", "err", err)
 		return Agent400JSONResponse{N400JSONResponse{err.Error()}}, nil
 	}
 
+	if err := s.connectionsService.Seen(ctx, *req.IssuerDID, *req.UserDID); err != nil {
+		log.Warn(ctx, "agent: recording connection last seen", "err", err, "userDID", req.UserDID)
+	}
+
 	return Agent200JSONResponse{
 		Body:     agent.Body,
 		From:     agent.From,
@@ -679,8 +1388,12 @@ func (s *Server) Agent(ctx context.Context, request AgentRequestObject) (AgentRe
 	}, nil
 }
 
-func getCredentialsFilter(ctx context.Context, userDID *string, status *GetCredentialsParamsStatus, query *string) (*ports.ClaimsFilter, error) {
-	filter := &ports.ClaimsFilter{}
+func getCredentialsFilter(ctx context.Context, userDID *string, status *GetCredentialsParamsStatus, query *string, asOf *time.Time) (*ports.ClaimsFilter, error) {
+	filter := &ports.ClaimsFilter{AsOf: asOf}
+	now := time.Now()
+	if asOf != nil {
+		now = *asOf
+	}
 	if userDID != nil {
 		did, err := core.ParseDID(*userDID)
 		if err != nil {
@@ -694,7 +1407,7 @@ func getCredentialsFilter(ctx context.Context, userDID *string, status *GetCrede
 		case Revoked:
 			filter.Revoked = common.ToPointer(true)
 		case Expired:
-			filter.ExpiredOn = common.ToPointer(time.Now())
+			filter.ExpiredOn = common.ToPointer(now)
 		case All:
 			// Nothing to be done
 		default:
@@ -707,6 +1420,14 @@ func getCredentialsFilter(ctx context.Context, userDID *string, status *GetCrede
 	return filter, nil
 }
 
+// asOfOrNow returns the time of an as-of query, now when it is not set
+func asOfOrNow(asOf *time.Time) time.Time {
+	if asOf != nil {
+		return *asOf
+	}
+	return time.Now()
+}
+
 func isBeforeNow(t time.Time) bool {
 	today := time.Now().UTC()
 	return t.Before(today)
@@ -741,3 +1462,82 @@ func writeFile(path string, mimeType string, w http.ResponseWriter) {
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(f)
 }
+
+// GetSubjectData exports all the data held about a subject
+func (s *Server) GetSubjectData(ctx context.Context, request GetSubjectDataRequestObject) (GetSubjectDataResponseObject, error) {
+	subject, err := core.ParseDID(request.Did)
+	if err != nil {
+		return GetSubjectData400JSONResponse{N400JSONResponse{"invalid subject did"}}, nil
+	}
+
+	data, err := s.subjectService.Export(ctx, s.issuerDID(ctx), *subject)
+	if err != nil {
+		log.Error(ctx, "exporting subject data", "err", err)
+		return GetSubjectData500JSONResponse{N500JSONResponse{"There was an error exporting the subject data"}}, nil
+	}
+
+	resp, err := subjectDataResponse(data)
+	if err != nil {
+		log.Error(ctx, "exporting subject data invalid claim format", "err", err)
+		return GetSubjectData500JSONResponse{N500JSONResponse{"There was an error exporting the subject data"}}, nil
+	}
+
+	return GetSubjectData200JSONResponse(resp), nil
+}
+
+// EraseSubjectData erases the personal data held about a subject keeping what is needed to revoke its credentials
+func (s *Server) EraseSubjectData(ctx context.Context, request EraseSubjectDataRequestObject) (EraseSubjectDataResponseObject, error) {
+	subject, err := core.ParseDID(request.Did)
+	if err != nil {
+		return EraseSubjectData400JSONResponse{N400JSONResponse{"invalid subject did"}}, nil
+	}
+
+	erasure, err := s.subjectService.Erase(ctx, s.issuerDID(ctx), *subject)
+	if err != nil {
+		if errors.Is(err, services.ErrSubjectIsIssuer) {
+			return EraseSubjectData400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		log.Error(ctx, "erasing subject data", "err", err)
+		return EraseSubjectData500JSONResponse{N500JSONResponse{"There was an error erasing the subject data"}}, nil
+	}
+
+	return EraseSubjectData200JSONResponse(subjectErasureResponse(erasure)), nil
+}
+
+func isInvalidCredentialRequest(err error) bool {
+	return errors.Is(err, services.ErrJSONLdContext) ||
+		errors.Is(err, services.ErrProcessSchema) ||
+		errors.Is(err, services.ErrParseClaim) ||
+		errors.Is(err, services.ErrInvalidCredentialSubject) ||
+		errors.Is(err, services.ErrMalformedURL) ||
+		errors.Is(err, services.ErrSchemaDeprecated) ||
+		errors.Is(err, domain.ErrInvalidDisplayMethod) ||
+		isInvalidProofTypes(err)
+}
+
+func isInvalidProofTypes(err error) bool {
+	return errors.Is(err, domain.ErrNoProofTypes) ||
+		errors.Is(err, domain.ErrUnsupportedProofType) ||
+		errors.Is(err, domain.ErrIncompatibleProof)
+}
+
+func toDisplayMethod(displayMethod *DisplayMethod) *domain.DisplayMethod {
+	if displayMethod == nil {
+		return nil
+	}
+	return &domain.DisplayMethod{ID: displayMethod.Id, Type: displayMethod.Type}
+}
+
+func toValidationMode(mode *ValidationMode) (domain.ValidationMode, error) {
+	if mode == nil {
+		return domain.ValidationModeStrict, nil
+	}
+	return domain.ParseValidationMode(string(*mode))
+}
+
+func toWarnings(warnings []string) *[]string {
+	if len(warnings) == 0 {
+		return nil
+	}
+	return &warnings
+}
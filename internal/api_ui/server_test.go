@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -17,6 +18,7 @@ import (
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
 	"github.com/iden3/go-schema-processor/utils"
+	"github.com/iden3/go-schema-processor/verifiable"
 	"github.com/mitchellh/mapstructure"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,6 +34,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/loader"
 	"github.com/polygonid/sh-id-platform/internal/log"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/cache"
 	linkState "github.com/polygonid/sh-id-platform/pkg/link"
 	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
@@ -48,7 +51,7 @@ func TestServer_CheckStatus(t *testing.T) {
 	connectionsRepository := repositories.NewConnections()
 	identityService := services.NewIdentity(&KMSMock{}, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
 	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
-	schemaService := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
+	schemaService := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
 
 	claimsConf := services.ClaimCfg{
 		RHSEnabled: false,
@@ -56,7 +59,7 @@ func TestServer_CheckStatus(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 
-	server := NewServer(&cfg, identityService, claimsService, schemaService, NewConnectionsMock(), NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), &health.Status{})
+	server := NewServer(&cfg, identityService, claimsService, schemaService, NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), &health.Status{})
 	handler := getHandler(context.Background(), server)
 
 	t.Run("should return 200", func(t *testing.T) {
@@ -72,7 +75,7 @@ func TestServer_CheckStatus(t *testing.T) {
 }
 
 func TestServer_AuthCallback(t *testing.T) {
-	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), NewSchemaMock(), NewConnectionsMock(), NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), NewSchemaMock(), NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 	handler := getHandler(context.Background(), server)
 
 	type expected struct {
@@ -132,7 +135,7 @@ func TestServer_AuthQRCode(t *testing.T) {
 	sessionRepository := repositories.NewSessionCached(cachex)
 
 	identityService := services.NewIdentity(&KMSMock{}, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, sessionRepository, pubsub.NewMock())
-	server := NewServer(&cfg, identityService, NewClaimsMock(), NewSchemaMock(), NewConnectionsMock(), NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, identityService, NewClaimsMock(), NewSchemaMock(), NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 	issuerDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
 	require.NoError(t, err)
 	server.cfg.APIUI.IssuerDID = *issuerDID
@@ -193,10 +196,186 @@ func TestServer_AuthQRCode(t *testing.T) {
 	}
 }
 
+func TestServer_GetVerifications(t *testing.T) {
+	ctx := context.Background()
+	identityService := services.NewIdentity(&KMSMock{}, repositories.NewIdentity(), repositories.NewIdentityMerkleTreeRepository(), repositories.NewIdentityState(), nil, repositories.NewClaims(), repositories.NewRevocation(), repositories.NewConnections(), storage, nil, nil, nil, pubsub.NewMock())
+	server := NewServer(&cfg, identityService, NewClaimsMock(), NewSchemaMock(), NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	issuerDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+	server.cfg.APIUI.IssuerDID = *issuerDID
+	handler := getHandler(ctx, server)
+
+	userDID := "did:polygonid:polygon:mumbai:" + uuid.NewString()
+	verificationRepo := repositories.NewVerification()
+	verified := &domain.Verification{
+		ID:          uuid.New(),
+		IssuerDID:   *issuerDID,
+		SessionID:   uuid.New(),
+		UserDID:     &userDID,
+		Queries:     json.RawMessage(`[{"id": 1, "circuitId": "credentialAtomicQuerySigV2"}]`),
+		ProofHashes: []string{"015abd7f5cc57a2dd94b7590f04ad8084273905ee33ec5cebeae62276a97f862"},
+		Verified:    true,
+		CreatedAt:   time.Now().Add(-time.Minute),
+	}
+	require.NoError(t, verificationRepo.Save(ctx, storage.Pgx, verified))
+	require.NoError(t, verificationRepo.Save(ctx, storage.Pgx, &domain.Verification{
+		ID:        uuid.New(),
+		IssuerDID: *issuerDID,
+		SessionID: uuid.New(),
+		UserDID:   &userDID,
+		Error:     common.ToPointer("proof is not valid"),
+		CreatedAt: time.Now(),
+	}))
+
+	type expected struct {
+		httpCode      int
+		verifications int
+	}
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		url      string
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:     "Not authorized",
+			auth:     authWrong,
+			url:      "/v1/verifications",
+			expected: expected{httpCode: http.StatusUnauthorized},
+		},
+		{
+			name:     "Wrong limit",
+			auth:     authOk,
+			url:      "/v1/verifications?limit=0",
+			expected: expected{httpCode: http.StatusBadRequest},
+		},
+		{
+			name:     "Happy path. All the verifications of a DID",
+			auth:     authOk,
+			url:      "/v1/verifications?did=" + url.QueryEscape(userDID),
+			expected: expected{httpCode: http.StatusOK, verifications: 2},
+		},
+		{
+			name:     "Happy path. The verified proofs of a DID",
+			auth:     authOk,
+			url:      "/v1/verifications?verified=true&did=" + url.QueryEscape(userDID),
+			expected: expected{httpCode: http.StatusOK, verifications: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", tc.url, nil)
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode == http.StatusOK {
+				var response GetVerifications200JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				require.Len(t, response, tc.expected.verifications)
+				last := response[len(response)-1]
+				assert.Equal(t, verified.ID, last.Id)
+				assert.Equal(t, verified.ProofHashes, last.ProofHashes)
+				assert.Equal(t, "credentialAtomicQuerySigV2", last.Queries[0]["circuitId"])
+				assert.True(t, last.Verified)
+			}
+		})
+	}
+}
+
+func TestServer_Branding(t *testing.T) {
+	ctx := context.Background()
+	identityService := services.NewIdentity(keyStore, repositories.NewIdentity(), repositories.NewIdentityMerkleTreeRepository(), repositories.NewIdentityState(), services.NewIdentityMerkleTrees(repositories.NewIdentityMerkleTreeRepository()), repositories.NewClaims(), repositories.NewRevocation(), repositories.NewConnections(), storage, reverse_hash.NewRhsPublisher(nil, false), nil, nil, pubsub.NewMock())
+	iden, err := identityService.Create(ctx, "polygonid", "polygon", "mumbai", "polygon-test")
+	require.NoError(t, err)
+	issuerDID, err := core.ParseDID(iden.Identifier)
+	require.NoError(t, err)
+
+	brandingService := services.NewBranding(storage, domain.Branding{DisplayName: "my issuer", LogoURL: "https://example.com/logo.png", PrimaryColor: "#6c4ce0", TextColor: "#ffffff"})
+	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), NewSchemaMock(), NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), brandingService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server.cfg.APIUI.IssuerDID = *issuerDID
+	handler := getHandler(ctx, server)
+
+	type expected struct {
+		httpCode int
+		message  string
+		branding Branding
+	}
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		method   string
+		body     *UpdateBrandingRequest
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:     "Not authorized",
+			auth:     authWrong,
+			method:   http.MethodGet,
+			expected: expected{httpCode: http.StatusUnauthorized},
+		},
+		{
+			name:     "Branding of the node",
+			auth:     authOk,
+			method:   http.MethodGet,
+			expected: expected{httpCode: http.StatusOK, branding: Branding{DisplayName: "my issuer", Logo: "https://example.com/logo.png", PrimaryColor: "#6c4ce0", TextColor: "#ffffff"}},
+		},
+		{
+			name:     "Wrong color",
+			auth:     authOk,
+			method:   http.MethodPut,
+			body:     &UpdateBrandingRequest{TextColor: common.ToPointer("#ffff")},
+			expected: expected{httpCode: http.StatusBadRequest, message: "invalid branding: the colors must be hex colors like #6c4ce0 <#ffff>"},
+		},
+		{
+			name:     "Happy path. Override the name and the text color",
+			auth:     authOk,
+			method:   http.MethodPut,
+			body:     &UpdateBrandingRequest{DisplayName: common.ToPointer("Acme"), TextColor: common.ToPointer("#000")},
+			expected: expected{httpCode: http.StatusOK, branding: Branding{DisplayName: "Acme", Logo: "https://example.com/logo.png", PrimaryColor: "#6c4ce0", TextColor: "#000"}},
+		},
+		{
+			name:     "Branding with the overrides",
+			auth:     authOk,
+			method:   http.MethodGet,
+			expected: expected{httpCode: http.StatusOK, branding: Branding{DisplayName: "Acme", Logo: "https://example.com/logo.png", PrimaryColor: "#6c4ce0", TextColor: "#000"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			var body io.Reader
+			if tc.body != nil {
+				body = tests.JSONBody(t, tc.body)
+			}
+			req, err := http.NewRequest(tc.method, "/v1/branding", body)
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			switch tc.expected.httpCode {
+			case http.StatusOK:
+				var response Branding
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.branding, response)
+			case http.StatusBadRequest:
+				var response UpdateBranding400JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			}
+		})
+	}
+}
+
 func TestServer_GetSchema(t *testing.T) {
 	ctx := context.Background()
-	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
-	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), schemaSrv, NewConnectionsMock(), NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
+	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), schemaSrv, NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 	issuerDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
 	require.NoError(t, err)
 	server.cfg.APIUI.IssuerDID = *issuerDID
@@ -215,6 +394,26 @@ func TestServer_GetSchema(t *testing.T) {
 	fixture.CreateSchema(t, ctx, s)
 	sHash, _ := s.Hash.MarshalText()
 
+	jsonSchema := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"title": "Employee", "properties": {"credentialSubject": {"type": "object", "required": ["position"], "properties": {
+			"position": {"title": "Position", "type": "string", "maxLength": 32},
+			"id": {"type": "string", "format": "uri"},
+			"hireDate": {"type": "string", "format": "date"},
+			"level": {"type": "integer", "enum": [1, 2]}}}}}`))
+	}))
+	defer jsonSchema.Close()
+	withForm := &domain.Schema{
+		ID:         uuid.New(),
+		IssuerDID:  *issuerDID,
+		URL:        jsonSchema.URL,
+		Type:       "KYCEmployee",
+		Attributes: domain.SchemaAttrsFromString("position, hireDate, level"),
+		CreatedAt:  time.Now(),
+	}
+	withForm.Hash = utils.CreateSchemaHash([]byte(withForm.URL + "#" + withForm.Type))
+	fixture.CreateSchema(t, ctx, withForm)
+	withFormHash, _ := withForm.Hash.MarshalText()
+
 	handler := getHandler(ctx, server)
 	type expected struct {
 		httpCode int
@@ -270,6 +469,42 @@ func TestServer_GetSchema(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Happy path. Existing schema with form",
+			auth: authOk,
+			id:   withForm.ID.String(),
+			expected: expected{
+				httpCode: http.StatusOK,
+				schema: &Schema{
+					BigInt:    withForm.Hash.BigInt().String(),
+					CreatedAt: withForm.CreatedAt,
+					Hash:      string(withFormHash),
+					Id:        withForm.ID.String(),
+					Type:      withForm.Type,
+					Url:       withForm.URL,
+					Form: &SchemaForm{
+						Title: "Employee",
+						Fields: []SchemaFormField{
+							{
+								Id: "position", Label: "Position", Type: "string", Input: Text, Required: true,
+								Constraints: SchemaFormConstraints{MaxLength: common.ToPointer(32)},
+								Fields:      []SchemaFormField{},
+							},
+							{
+								Id: "hireDate", Label: "hireDate", Type: "string", Input: Date,
+								Constraints: SchemaFormConstraints{Format: common.ToPointer("date")},
+								Fields:      []SchemaFormField{},
+							},
+							{
+								Id: "level", Label: "level", Type: "integer", Input: Select,
+								Constraints: SchemaFormConstraints{Enum: &[]interface{}{float64(1), float64(2)}},
+								Fields:      []SchemaFormField{},
+							},
+						},
+					},
+				},
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			rr := httptest.NewRecorder()
@@ -290,6 +525,7 @@ func TestServer_GetSchema(t *testing.T) {
 				assert.Equal(t, tc.expected.schema.Url, response.Url)
 				assert.Equal(t, tc.expected.schema.Hash, response.Hash)
 				assert.InDelta(t, tc.expected.schema.CreatedAt.UnixMilli(), response.CreatedAt.UnixMilli(), 10)
+				assert.Equal(t, tc.expected.schema.Form, response.Form)
 			case http.StatusNotFound:
 				var response GetSchema404JSONResponse
 				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
@@ -303,6 +539,390 @@ func TestServer_GetSchema(t *testing.T) {
 	}
 }
 
+func TestServer_GetSchemaStats(t *testing.T) {
+	ctx := context.Background()
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
+	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), schemaSrv, NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	issuerDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+	server.cfg.APIUI.IssuerDID = *issuerDID
+	fixture := tests.NewFixture(storage)
+
+	s := &domain.Schema{
+		ID:         uuid.New(),
+		IssuerDID:  *issuerDID,
+		URL:        fmt.Sprintf("https://domain.org/%s.json", uuid.NewString()),
+		Type:       "schemaType",
+		Attributes: domain.SchemaAttrsFromString("attr1, attr2"),
+		CreatedAt:  time.Now(),
+	}
+	s.Hash = utils.CreateSchemaHash([]byte(s.URL + "#" + s.Type))
+	fixture.CreateSchema(t, ctx, s)
+
+	usageRepo := repositories.NewSchemaUsage()
+	day := time.Date(2023, 4, 20, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, usageRepo.AddIssued(ctx, storage.Pgx, *issuerDID, s.URL, true, day))
+	require.NoError(t, usageRepo.AddIssued(ctx, storage.Pgx, *issuerDID, s.URL, false, day.AddDate(0, 0, 2)))
+	require.NoError(t, usageRepo.AddRevoked(ctx, storage.Pgx, *issuerDID, s.URL, day.AddDate(0, 0, 2)))
+
+	handler := getHandler(ctx, server)
+	type expected struct {
+		httpCode int
+		errorMsg string
+		stats    *SchemaStats
+	}
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		url      string
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name: "Not authorized",
+			auth: authWrong,
+			url:  fmt.Sprintf("/v1/schemas/%s/stats", s.ID),
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "Non existing uuid",
+			auth: authOk,
+			url:  fmt.Sprintf("/v1/schemas/%s/stats", uuid.New()),
+			expected: expected{
+				httpCode: http.StatusNotFound,
+				errorMsg: "schema not found",
+			},
+		},
+		{
+			name: "Period ending before it starts",
+			auth: authOk,
+			url:  fmt.Sprintf("/v1/schemas/%s/stats?from=2023-04-22&to=2023-04-20", s.ID),
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				errorMsg: "the start of the period must not be after its end",
+			},
+		},
+		{
+			name: "Happy path. All the days",
+			auth: authOk,
+			url:  fmt.Sprintf("/v1/schemas/%s/stats", s.ID),
+			expected: expected{
+				httpCode: http.StatusOK,
+				stats: &SchemaStats{
+					Issued:         2,
+					IssuedByLink:   1,
+					Revoked:        1,
+					RevocationRate: 0.5,
+					Days: []SchemaUsageDay{
+						{Day: types.Date{Time: time.Date(2023, 4, 20, 0, 0, 0, 0, time.UTC)}, Issued: 1, IssuedByLink: 1},
+						{Day: types.Date{Time: time.Date(2023, 4, 22, 0, 0, 0, 0, time.UTC)}, Issued: 1, Revoked: 1},
+					},
+				},
+			},
+		},
+		{
+			name: "Happy path. A period",
+			auth: authOk,
+			url:  fmt.Sprintf("/v1/schemas/%s/stats?from=2023-04-19&to=2023-04-21", s.ID),
+			expected: expected{
+				httpCode: http.StatusOK,
+				stats: &SchemaStats{
+					Issued:         1,
+					IssuedByLink:   1,
+					RevocationRate: 0,
+					Days: []SchemaUsageDay{
+						{Day: types.Date{Time: time.Date(2023, 4, 20, 0, 0, 0, 0, time.UTC)}, Issued: 1, IssuedByLink: 1},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", tc.url, nil)
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			switch tc.expected.httpCode {
+			case http.StatusOK:
+				var response GetSchemaStats200JSONResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, *tc.expected.stats, SchemaStats(response))
+			case http.StatusNotFound:
+				var response GetSchemaStats404JSONResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.errorMsg, response.Message)
+			case http.StatusBadRequest:
+				var response GetSchemaStats400JSONResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.errorMsg, response.Message)
+			}
+		})
+	}
+}
+
+func TestServer_GetSchemaProxy(t *testing.T) {
+	ctx := context.Background()
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schema.json":
+			_, _ = fmt.Fprintf(w, `{"$metadata": {"uris": {"jsonLdContext": "http://%s/context.jsonld"}}, "type": "object"}`, r.Host)
+		case "/context.jsonld":
+			_, _ = w.Write([]byte(`{"@context": [{"@version": 1.1}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer source.Close()
+
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
+	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), schemaSrv, NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	issuerDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+	server.cfg.APIUI.IssuerDID = *issuerDID
+	fixture := tests.NewFixture(storage)
+
+	s := &domain.Schema{
+		ID:         uuid.New(),
+		IssuerDID:  *issuerDID,
+		URL:        source.URL + "/schema.json",
+		Type:       "schemaType",
+		Attributes: domain.SchemaAttrsFromString("attr1, attr2"),
+		CreatedAt:  time.Now(),
+	}
+	s.Hash = utils.CreateSchemaHash([]byte(s.URL + "#" + s.Type))
+	fixture.CreateSchema(t, ctx, s)
+
+	handler := getHandler(ctx, server)
+	type expected struct {
+		httpCode    int
+		contentType string
+		key         string
+	}
+	type testConfig struct {
+		name     string
+		url      string
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name: "Non existing schema",
+			url:  fmt.Sprintf("/v1/schemas/%s/jsonschema", uuid.New()),
+			expected: expected{
+				httpCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "JSON schema without auth",
+			url:  fmt.Sprintf("/v1/schemas/%s/jsonschema", s.ID),
+			expected: expected{
+				httpCode:    http.StatusOK,
+				contentType: "application/json",
+				key:         "$metadata",
+			},
+		},
+		{
+			name: "JSON-LD context without auth",
+			url:  fmt.Sprintf("/v1/schemas/%s/context", s.ID),
+			expected: expected{
+				httpCode:    http.StatusOK,
+				contentType: "application/ld+json",
+				key:         "@context",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", tc.url, nil)
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode == http.StatusOK {
+				assert.Equal(t, tc.expected.contentType, rr.Header().Get("Content-Type"))
+				var response map[string]interface{}
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Contains(t, response, tc.expected.key)
+			}
+		})
+	}
+}
+
+func TestServer_UpdateSchema(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "mumbai"
+		schemaURL  = "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	)
+	ctx := log.NewContext(context.Background(), log.LevelDebug, log.OutputText, os.Stdout)
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "http://host"}, pubsub.NewMock())
+	schemaService := services.NewSchema(repositories.NewSchema(*storage), schemaLoader, nil, nil, false)
+	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
+	require.NoError(t, err)
+	did, err := core.ParseDID(iden.Identifier)
+	require.NoError(t, err)
+
+	s := &domain.Schema{
+		ID:         uuid.New(),
+		IssuerDID:  *did,
+		URL:        schemaURL,
+		Type:       "KYCAgeCredential",
+		Attributes: domain.SchemaAttrsFromString("birthday, documentType"),
+		CreatedAt:  time.Now(),
+	}
+	s.Hash = utils.CreateSchemaHash([]byte(s.URL + "#" + s.Type))
+	tests.NewFixture(storage).CreateSchema(t, ctx, s)
+
+	cfg.APIUI.IssuerDID = *did
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, schemaService, NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	handler := getHandler(ctx, server)
+
+	type expected struct {
+		httpCode   int
+		message    string
+		proofTypes *[]ProofType
+		expiration *string
+	}
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		id       uuid.UUID
+		body     UpdateSchemaRequest
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name: "No auth header",
+			auth: authWrong,
+			id:   s.ID,
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "Not existing schema",
+			auth: authOk,
+			id:   uuid.New(),
+			body: UpdateSchemaRequest{DefaultProofTypes: &[]ProofType{BJJSignature2021}},
+			expected: expected{
+				httpCode: http.StatusNotFound,
+				message:  "schema not found",
+			},
+		},
+		{
+			name: "Unsupported proof type",
+			auth: authOk,
+			id:   s.ID,
+			body: UpdateSchemaRequest{DefaultProofTypes: &[]ProofType{"JsonWebSignature2020"}},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  "unsupported proof type: JsonWebSignature2020",
+			},
+		},
+		{
+			name: "Invalid expiration policy",
+			auth: authOk,
+			id:   s.ID,
+			body: UpdateSchemaRequest{DefaultExpiration: common.ToPointer("1 month")},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  `invalid expiration policy: "1 month", use an ISO 8601 duration like P1Y or a number of hours, days, weeks or years like 90d`,
+			},
+		},
+		{
+			name: "Happy path, only merkle tree proofs",
+			auth: authOk,
+			id:   s.ID,
+			body: UpdateSchemaRequest{DefaultProofTypes: &[]ProofType{Iden3SparseMerkleTreeProof}},
+			expected: expected{
+				httpCode:   http.StatusOK,
+				proofTypes: &[]ProofType{Iden3SparseMerkleTreeProof},
+			},
+		},
+		{
+			name: "Happy path, expiration policy keeps the proof types",
+			auth: authOk,
+			id:   s.ID,
+			body: UpdateSchemaRequest{DefaultExpiration: common.ToPointer("P1Y")},
+			expected: expected{
+				httpCode:   http.StatusOK,
+				proofTypes: &[]ProofType{Iden3SparseMerkleTreeProof},
+				expiration: common.ToPointer("P1Y"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/v1/schemas/%s", tc.id), tests.JSONBody(t, tc.body))
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			switch tc.expected.httpCode {
+			case http.StatusOK:
+				var response UpdateSchema200JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, s.ID.String(), response.Id)
+				assert.Equal(t, tc.expected.proofTypes, response.DefaultProofTypes)
+				assert.Equal(t, tc.expected.expiration, response.DefaultExpiration)
+			case http.StatusBadRequest, http.StatusNotFound:
+				var response GenericErrorMessage
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			}
+		})
+	}
+
+	credentialSubject := map[string]any{
+		"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+		"birthday":     19960424,
+		"documentType": 2,
+	}
+	claimReq := ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, nil, "KYCAgeCredential", nil, nil, nil, nil, nil, nil, true)
+	issuedAt := time.Now()
+	credential, err := claimsService.Save(ctx, claimReq)
+	require.NoError(t, err)
+	assert.True(t, credential.MtProof)
+	assert.Nil(t, credential.SignatureProof.Bytes)
+	assert.InDelta(t, issuedAt.AddDate(1, 0, 0).Unix(), credential.Expiration, 5)
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/v1/schemas/%s", s.ID), tests.JSONBody(t, UpdateSchemaRequest{DefaultProofTypes: &[]ProofType{}, DefaultExpiration: common.ToPointer("")}))
+	req.SetBasicAuth(authOk())
+	require.NoError(t, err)
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response UpdateSchema200JSONResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Nil(t, response.DefaultProofTypes)
+	assert.Nil(t, response.DefaultExpiration)
+
+	credential, err = claimsService.Save(ctx, ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, nil, "KYCAgeCredential", nil, nil, nil, nil, nil, nil, true))
+	require.NoError(t, err)
+	assert.True(t, credential.MtProof)
+	assert.NotNil(t, credential.SignatureProof.Bytes)
+	assert.Zero(t, credential.Expiration)
+}
+
 // Refer to the schema repository tests for more deep test related to Postgres Full Text Search
 func TestServer_GetSchemas(t *testing.T) {
 	ctx := context.Background()
@@ -315,8 +935,8 @@ func TestServer_GetSchemas(t *testing.T) {
 	require.NoError(t, err)
 	defer teardown()
 
-	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
-	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), schemaSrv, NewConnectionsMock(), NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
+	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), schemaSrv, NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 	issuerDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
 	require.NoError(t, err)
 	server.cfg.APIUI.IssuerDID = *issuerDID
@@ -434,12 +1054,87 @@ func TestServer_GetSchemas(t *testing.T) {
 	}
 }
 
+func TestServer_PurgeSchemaCache(t *testing.T) {
+	const schemaURL = "https://domain.org/this/is/an/url/purged"
+	ctx := context.Background()
+	issuerDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+	require.NoError(t, cachex.Set(ctx, "schema-"+schemaURL, "cached", cache.ForEver))
+
+	type expected struct {
+		httpCode int
+		purged   []string
+	}
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		loader   loader.Factory
+		url      string
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:     "Not authorized",
+			auth:     authWrong,
+			loader:   loader.CachedFactory(loader.HTTPFactory, cachex),
+			url:      schemaURL,
+			expected: expected{httpCode: http.StatusUnauthorized},
+		},
+		{
+			name:     "Schema cache disabled",
+			auth:     authOk,
+			loader:   loader.HTTPFactory,
+			url:      schemaURL,
+			expected: expected{httpCode: http.StatusBadRequest},
+		},
+		{
+			name:     "Invalid url",
+			auth:     authOk,
+			loader:   loader.CachedFactory(loader.HTTPFactory, cachex),
+			url:      "not an url",
+			expected: expected{httpCode: http.StatusBadRequest},
+		},
+		{
+			name:   "Happy path",
+			auth:   authOk,
+			loader: loader.CachedFactory(loader.HTTPFactory, cachex),
+			url:    schemaURL,
+			expected: expected{
+				httpCode: http.StatusOK,
+				purged:   []string{schemaURL},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schemaSrv := services.NewSchema(repositories.NewSchema(*storage), tc.loader, nil, nil, false)
+			server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), schemaSrv, NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+			server.cfg.APIUI.IssuerDID = *issuerDID
+			handler := getHandler(ctx, server)
+
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodDelete, "/v1/schemas/cache?url="+url.QueryEscape(tc.url), nil)
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			if tc.expected.httpCode == http.StatusOK {
+				var response PurgeSchemaCache200JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.purged, response.Purged)
+				assert.False(t, cachex.Exists(ctx, "schema-"+schemaURL))
+			}
+		})
+	}
+}
+
 func TestServer_ImportSchema(t *testing.T) {
 	const url = "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
 	const schemaType = "KYCCountryOfResidenceCredential"
 	ctx := context.Background()
-	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
-	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), schemaSrv, NewConnectionsMock(), NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
+	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), schemaSrv, NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 	issuerDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
 	require.NoError(t, err)
 	server.cfg.APIUI.IssuerDID = *issuerDID
@@ -524,6 +1219,128 @@ func TestServer_ImportSchema(t *testing.T) {
 	}
 }
 
+type ipfsMock struct {
+	added int
+}
+
+func (m *ipfsMock) Add(_ context.Context, _ []byte) (string, error) {
+	m.added++
+	return fmt.Sprintf("bafkreicid%d", m.added), nil
+}
+
+func (m *ipfsMock) Cat(_ context.Context, path string) ([]byte, error) {
+	return nil, fmt.Errorf("%s not found", path)
+}
+
+func (m *ipfsMock) Pin(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestServer_BuildSchema(t *testing.T) {
+	ctx := context.Background()
+	ipfs := &ipfsMock{}
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, ipfs, false)
+	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), schemaSrv, NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	issuerDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+	server.cfg.APIUI.IssuerDID = *issuerDID
+	server.cfg.APIUI.ServerURL = "https://testing.env"
+
+	handler := getHandler(ctx, server)
+
+	attributes := []SchemaAttributeDefinition{
+		{Name: "birthday", Type: SchemaAttributeDefinitionTypeDate, Title: common.ToPointer("Birthday"), Required: common.ToPointer(true)},
+		{Name: "documentType", Type: SchemaAttributeDefinitionTypeInteger},
+	}
+	schemaType := "BuiltCredential" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	type expected struct {
+		httpCode   int
+		errorMsg   string
+		registered bool
+		schemaURL  string
+	}
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		request  *BuildSchemaJSONRequestBody
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:     "Not authorized",
+			auth:     authWrong,
+			request:  &BuildSchemaRequest{Type: schemaType, Version: "1.0.0", Attributes: attributes},
+			expected: expected{httpCode: http.StatusUnauthorized},
+		},
+		{
+			name:    "Invalid attribute",
+			auth:    authOk,
+			request: &BuildSchemaRequest{Type: schemaType, Version: "1.0.0", Attributes: []SchemaAttributeDefinition{{Name: "first name", Type: SchemaAttributeDefinitionTypeString}}},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				errorMsg: "invalid schema document: invalid schema attributes: <first name> is not a valid attribute name",
+			},
+		},
+		{
+			name:     "Preview",
+			auth:     authOk,
+			request:  &BuildSchemaRequest{Type: schemaType, Version: "1.0.0", Attributes: attributes},
+			expected: expected{httpCode: http.StatusOK},
+		},
+		{
+			name:     "Register",
+			auth:     authOk,
+			request:  &BuildSchemaRequest{Type: schemaType, Version: "1.0.0", Attributes: attributes, Register: common.ToPointer(true)},
+			expected: expected{httpCode: http.StatusOK, registered: true, schemaURL: "https://testing.env/v1/schemas/"},
+		},
+		{
+			name:     "Publish in IPFS",
+			auth:     authOk,
+			request:  &BuildSchemaRequest{Type: schemaType, Version: "1.1.0", Attributes: attributes, Ipfs: common.ToPointer(true)},
+			expected: expected{httpCode: http.StatusOK, schemaURL: "ipfs://bafkreicid2"},
+		},
+		{
+			name:    "Register an old version",
+			auth:    authOk,
+			request: &BuildSchemaRequest{Type: schemaType, Version: "0.9.0", Attributes: attributes, Register: common.ToPointer(true), Ipfs: common.ToPointer(true)},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				errorMsg: "the schema version must be greater than the last version of its type: the last version of " + schemaType + " is 1.0.0",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest("POST", "/v1/schemas/builder", tests.JSONBody(t, tc.request))
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			switch tc.expected.httpCode {
+			case http.StatusOK:
+				var response BuildSchema200JSONResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Contains(t, response.JsonSchema["properties"].(map[string]interface{})["credentialSubject"].(map[string]interface{})["properties"], "birthday")
+				assert.NotEmpty(t, response.JsonLdContext["@context"])
+				assert.Equal(t, tc.expected.registered, response.Id != nil)
+				if tc.expected.schemaURL == "" {
+					assert.Nil(t, response.SchemaUrl)
+					return
+				}
+				require.NotNil(t, response.SchemaUrl)
+				assert.True(t, strings.HasPrefix(*response.SchemaUrl, tc.expected.schemaURL))
+			case http.StatusBadRequest:
+				var response BuildSchema400JSONResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.errorMsg, response.Message)
+			}
+		})
+	}
+}
+
 func TestServer_DeleteConnection(t *testing.T) {
 	const (
 		method     = "polygonid"
@@ -554,7 +1371,7 @@ func TestServer_DeleteConnection(t *testing.T) {
 	issuerDID, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 	server.cfg.APIUI.IssuerDID = *issuerDID
 	handler := getHandler(context.Background(), server)
 
@@ -691,7 +1508,7 @@ func TestServer_DeleteConnectionCredentials(t *testing.T) {
 	connectionsRepository := repositories.NewConnections()
 
 	connectionsService := services.NewConnection(connectionsRepository, storage)
-	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 	handler := getHandler(context.Background(), server)
 
 	fixture := tests.NewFixture(storage)
@@ -806,7 +1623,7 @@ func TestServer_RevokeConnectionCredentials(t *testing.T) {
 	issuerDID, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 	server.cfg.APIUI.IssuerDID = *issuerDID
 	handler := getHandler(context.Background(), server)
 
@@ -921,7 +1738,7 @@ func TestServer_CreateCredential(t *testing.T) {
 	require.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	handler := getHandler(ctx, server)
 
@@ -966,7 +1783,7 @@ func TestServer_CreateCredential(t *testing.T) {
 			},
 		},
 		{
-			name: "Wrong request - no proof provided",
+			name: "Happy path - no proof provided, the issuer defaults are used",
 			auth: authOk,
 			body: CreateCredentialRequest{
 				CredentialSchema: "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
@@ -979,8 +1796,9 @@ func TestServer_CreateCredential(t *testing.T) {
 				Expiration: common.ToPointer(time.Now()),
 			},
 			expected: expected{
-				response: CreateCredential400JSONResponse{N400JSONResponse{Message: "you must to provide at least one proof type"}},
-				httpCode: http.StatusBadRequest,
+				response:                    CreateCredential201JSONResponse{},
+				httpCode:                    http.StatusCreated,
+				createCredentialEventsCount: 1,
 			},
 		},
 		{
@@ -1057,6 +1875,199 @@ func TestServer_CreateCredential(t *testing.T) {
 	}
 }
 
+func TestServer_CreateConnectionCredential(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "mumbai"
+	)
+	ctx := log.NewContext(context.Background(), log.LevelDebug, log.OutputText, os.Stdout)
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsConf := services.ClaimCfg{
+		RHSEnabled: false,
+		Host:       "http://host",
+	}
+	pubSub := pubsub.NewMock()
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubSub)
+	connectionsService := services.NewConnection(connectionsRepository, storage)
+	notificationGateway := &notificationGatewayMock{}
+	notificationService := services.NewNotification(notificationGateway, connectionsService, claimsService)
+	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
+	require.NoError(t, err)
+
+	did, err := core.ParseDID(iden.Identifier)
+	require.NoError(t, err)
+
+	userDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qKDJmySKNi4GD4vYdqfLb37MSTSijg77NoRZaKfDX")
+	require.NoError(t, err)
+
+	fixture := tests.NewFixture(storage)
+	connID := fixture.CreateConnection(t, &domain.Connection{
+		IssuerDID:  *did,
+		UserDID:    *userDID,
+		IssuerDoc:  json.RawMessage(fmt.Sprintf(`{"id": "%[1]s", "service": [{"id": "%[1]s#%[2]s", "type": "%[2]s", "serviceEndpoint": "http://host/v1/agent"}], "@context": ["https://www.w3.org/ns/did/v1"]}`, did, verifiable.Iden3CommServiceType)),
+		UserDoc:    json.RawMessage(fmt.Sprintf(`{"id": "%[1]s", "service": [{"id": "%[1]s#push", "type": "push-notification", "metadata": {"devices": [{"alg": "RSA-OAEP-512", "ciphertext": "someToken"}]}, "serviceEndpoint": "https://push.example.com/api/v1"}], "@context": ["https://www.w3.org/ns/did/v1"]}`, userDID)),
+		CreatedAt:  time.Now(),
+		ModifiedAt: time.Now(),
+	})
+
+	cfg.APIUI.IssuerDID = *did
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), notificationService, NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	handler := getHandler(ctx, server)
+
+	body := func(sigProof, mtProof *bool) CreateCredentialRequest {
+		return CreateCredentialRequest{
+			CredentialSchema: "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+			Type:             "KYCAgeCredential",
+			CredentialSubject: map[string]any{
+				"birthday":     19960424,
+				"documentType": 2,
+			},
+			SignatureProof: sigProof,
+			MtProof:        mtProof,
+		}
+	}
+
+	type expected struct {
+		httpCode     int
+		message      string
+		notification NotificationStatus
+	}
+
+	type testConfig struct {
+		name         string
+		auth         func() (string, string)
+		connectionID uuid.UUID
+		body         CreateCredentialRequest
+		gateway      notificationGatewayMock
+		expected     expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name:         "No auth header",
+			auth:         authWrong,
+			connectionID: connID,
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name:         "Happy path, no proof provided, the issuer defaults are used",
+			auth:         authOk,
+			connectionID: connID,
+			body:         body(nil, nil),
+			gateway: notificationGatewayMock{result: &domain.UserNotificationResult{Devices: []domain.DeviceNotificationResult{
+				{Status: domain.DeviceNotificationStatusSuccess},
+			}}},
+			expected: expected{
+				httpCode:     http.StatusCreated,
+				notification: NotificationStatus{Status: NotificationStatusStatusSent, Devices: []DeviceNotificationStatus{{Status: "success"}}},
+			},
+		},
+		{
+			name:         "Not existing connection",
+			auth:         authOk,
+			connectionID: uuid.New(),
+			body:         body(common.ToPointer(true), nil),
+			expected: expected{
+				httpCode: http.StatusNotFound,
+				message:  "The given connection does not exist",
+			},
+		},
+		{
+			name:         "Happy path, offer sent",
+			auth:         authOk,
+			connectionID: connID,
+			body:         body(common.ToPointer(true), nil),
+			gateway: notificationGatewayMock{result: &domain.UserNotificationResult{Devices: []domain.DeviceNotificationResult{
+				{Status: domain.DeviceNotificationStatusSuccess},
+			}}},
+			expected: expected{
+				httpCode:     http.StatusCreated,
+				notification: NotificationStatus{Status: NotificationStatusStatusSent, Devices: []DeviceNotificationStatus{{Status: "success"}}},
+			},
+		},
+		{
+			name:         "Happy path, device rejected the offer",
+			auth:         authOk,
+			connectionID: connID,
+			body:         body(common.ToPointer(true), nil),
+			gateway: notificationGatewayMock{result: &domain.UserNotificationResult{Devices: []domain.DeviceNotificationResult{
+				{Status: "rejected", Reason: "token expired"},
+			}}},
+			expected: expected{
+				httpCode:     http.StatusCreated,
+				notification: NotificationStatus{Status: NotificationStatusStatusFailed, Devices: []DeviceNotificationStatus{{Status: "rejected", Reason: "token expired"}}},
+			},
+		},
+		{
+			name:         "Happy path, push service unreachable",
+			auth:         authOk,
+			connectionID: connID,
+			body:         body(common.ToPointer(true), nil),
+			gateway:      notificationGatewayMock{err: errors.New("connection refused")},
+			expected: expected{
+				httpCode:     http.StatusCreated,
+				notification: NotificationStatus{Status: NotificationStatusStatusFailed, Reason: common.ToPointer("connection refused"), Devices: []DeviceNotificationStatus{}},
+			},
+		},
+		{
+			name:         "Happy path, only mtp proof",
+			auth:         authOk,
+			connectionID: connID,
+			body:         body(nil, common.ToPointer(true)),
+			expected: expected{
+				httpCode: http.StatusCreated,
+				notification: NotificationStatus{
+					Status:  NotificationStatusStatusSkipped,
+					Reason:  common.ToPointer("credentials without signature proof can be fetched once the issuer state is published"),
+					Devices: []DeviceNotificationStatus{},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pubSub.Clear(event.CreateCredentialEvent)
+			*notificationGateway = tc.gateway
+
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v1/connections/%s/credentials", tc.connectionID)
+
+			req, err := http.NewRequest(http.MethodPost, url, tests.JSONBody(t, tc.body))
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			assert.Empty(t, pubSub.AllPublishedEvents(event.CreateCredentialEvent))
+
+			switch tc.expected.httpCode {
+			case http.StatusCreated:
+				var response CreateConnectionCredentialResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.notification, response.Notification)
+				credential, err := claimsService.GetByID(ctx, did, response.Id)
+				require.NoError(t, err)
+				assert.Equal(t, userDID.String(), credential.OtherIdentifier)
+			case http.StatusBadRequest, http.StatusNotFound:
+				var response GenericErrorMessage
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			}
+		})
+	}
+}
+
 func TestServer_DeleteCredential(t *testing.T) {
 	identityRepo := repositories.NewIdentity()
 	claimsRepo := repositories.NewClaims()
@@ -1074,7 +2085,7 @@ func TestServer_DeleteCredential(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), NewConnectionsMock(), NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), NewConnectionsMock(), NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 	handler := getHandler(context.Background(), server)
 
 	fixture := tests.NewFixture(storage)
@@ -1082,8 +2093,13 @@ func TestServer_DeleteCredential(t *testing.T) {
 	issuerDID, err := core.ParseDID("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ")
 	require.NoError(t, err)
 
+	cfg.APIUI.IssuerDID = *issuerDID
 	cred := fixture.NewClaim(t, issuerDID.String())
 	fCred := fixture.CreateClaim(t, cred)
+	// a credential of another tenant of the node
+	otherDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qLQGgjpP5Yq7r7jbRrQZbWy8ikADvxamSLB7CqR4F")
+	require.NoError(t, err)
+	otherCred := fixture.CreateClaim(t, fixture.NewClaim(t, otherDID.String()))
 
 	type expected struct {
 		httpCode int
@@ -1110,7 +2126,16 @@ func TestServer_DeleteCredential(t *testing.T) {
 			credentialID: uuid.New(),
 			auth:         authOk,
 			expected: expected{
-				httpCode: http.StatusBadRequest,
+				httpCode: http.StatusNotFound,
+				message:  common.ToPointer("The given credential does not exist"),
+			},
+		},
+		{
+			name:         "should not delete the credential of another tenant",
+			credentialID: otherCred,
+			auth:         authOk,
+			expected: expected{
+				httpCode: http.StatusNotFound,
 				message:  common.ToPointer("The given credential does not exist"),
 			},
 		},
@@ -1128,7 +2153,7 @@ func TestServer_DeleteCredential(t *testing.T) {
 			credentialID: fCred,
 			auth:         authOk,
 			expected: expected{
-				httpCode: http.StatusBadRequest,
+				httpCode: http.StatusNotFound,
 				message:  common.ToPointer("The given credential does not exist"),
 			},
 		},
@@ -1144,8 +2169,8 @@ func TestServer_DeleteCredential(t *testing.T) {
 
 			require.Equal(t, tc.expected.httpCode, rr.Code)
 			switch tc.expected.httpCode {
-			case http.StatusBadRequest:
-				var response DeleteCredential400JSONResponse
+			case http.StatusNotFound:
+				var response DeleteCredential404JSONResponse
 				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
 				assert.Equal(t, *tc.expected.message, response.Message)
 			case http.StatusOK:
@@ -1155,6 +2180,9 @@ func TestServer_DeleteCredential(t *testing.T) {
 			}
 		})
 	}
+
+	_, err = claimsRepo.GetByIdAndIssuer(context.Background(), storage.Pgx, otherDID, otherCred)
+	assert.NoError(t, err, "the credential of the other tenant is kept")
 }
 
 func TestServer_GetCredential(t *testing.T) {
@@ -1186,7 +2214,7 @@ func TestServer_GetCredential(t *testing.T) {
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	credentialSubject := map[string]any{
 		"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
@@ -1240,6 +2268,18 @@ func TestServer_GetCredential(t *testing.T) {
 				httpCode: http.StatusBadRequest,
 			},
 		},
+		{
+			name: "should return an error, claim not issued yet as of the given time",
+			auth: authOk,
+			request: GetCredentialRequestObject{
+				Id:     createdClaim1.ID,
+				Params: GetCredentialParams{AsOf: common.ToPointer(time.Now().Add(-24 * time.Hour))},
+			},
+			expected: expected{
+				message:  common.ToPointer("The given credential id does not exist"),
+				httpCode: http.StatusBadRequest,
+			},
+		},
 		{
 			name: "happy path with two proof",
 			auth: authOk,
@@ -1328,6 +2368,9 @@ func TestServer_GetCredential(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			rr := httptest.NewRecorder()
 			url := fmt.Sprintf("/v1/credentials/%s", tc.request.Id.String())
+			if tc.request.Params.AsOf != nil {
+				url += "?asOf=" + tc.request.Params.AsOf.UTC().Format(time.RFC3339)
+			}
 
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			req.SetBasicAuth(tc.auth())
@@ -1374,7 +2417,7 @@ func TestServer_GetCredentials(t *testing.T) {
 		Host:       "http://host",
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
-	schemaService := services.NewSchema(schemaRepository, schemaLoader)
+	schemaService := services.NewSchema(schemaRepository, schemaLoader, nil, nil, false)
 	connectionsService := services.NewConnection(connectionsRepository, storage)
 	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
 	require.NoError(t, err)
@@ -1382,7 +2425,7 @@ func TestServer_GetCredentials(t *testing.T) {
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	credentialSubject := map[string]any{
 		"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
@@ -1420,6 +2463,7 @@ func TestServer_GetCredentials(t *testing.T) {
 
 	type expected struct {
 		count    int
+		total    string
 		httpCode int
 		errorMsg string
 	}
@@ -1430,6 +2474,7 @@ func TestServer_GetCredentials(t *testing.T) {
 		did      *string
 		query    *string
 		status   *string
+		limit    *string
 		expected expected
 	}
 	for _, tc := range []testConfig{
@@ -1495,6 +2540,25 @@ func TestServer_GetCredentials(t *testing.T) {
 				count:    0,
 			},
 		},
+		{
+			name:  "First page",
+			auth:  authOk,
+			limit: common.ToPointer("3"),
+			expected: expected{
+				httpCode: http.StatusOK,
+				count:    3,
+				total:    "4",
+			},
+		},
+		{
+			name:  "Wrong limit",
+			auth:  authOk,
+			limit: common.ToPointer("0"),
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				errorMsg: "limit must be between 1 and 1000",
+			},
+		},
 		{
 			name:   "Revoked",
 			auth:   authOk,
@@ -1591,6 +2655,9 @@ func TestServer_GetCredentials(t *testing.T) {
 			if tc.did != nil {
 				queryParams = append(queryParams, "did="+*tc.did)
 			}
+			if tc.limit != nil {
+				queryParams = append(queryParams, "limit="+*tc.limit)
+			}
 			endpoint.RawQuery = strings.Join(queryParams, "&")
 			req, err := http.NewRequest("GET", endpoint.String(), nil)
 			req.SetBasicAuth(tc.auth())
@@ -1601,9 +2668,13 @@ func TestServer_GetCredentials(t *testing.T) {
 			require.Equal(t, tc.expected.httpCode, rr.Code)
 			switch tc.expected.httpCode {
 			case http.StatusOK:
-				var response GetCredentials200JSONResponse
+				var response []Credential
 				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
 				assert.Len(t, response, tc.expected.count)
+				if tc.expected.total != "" {
+					assert.Equal(t, tc.expected.total, rr.Header().Get("X-Total-Count"))
+					assert.NotEmpty(t, rr.Header().Get("X-Next-Page-Token"))
+				}
 			case http.StatusBadRequest:
 				var response GetCredentials400JSONResponse
 				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
@@ -1642,7 +2713,7 @@ func TestServer_GetCredentialQrCode(t *testing.T) {
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 	handler := getHandler(ctx, server)
 
 	credentialSubject := map[string]any{
@@ -1758,7 +2829,7 @@ func TestServer_GetConnection(t *testing.T) {
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	fixture := tests.NewFixture(storage)
 	claim := fixture.NewClaim(t, did.String())
@@ -1946,7 +3017,7 @@ func TestServer_GetConnections(t *testing.T) {
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	fixture := tests.NewFixture(storage)
 
@@ -2406,7 +3477,7 @@ func TestServer_RevokeCredential(t *testing.T) {
 
 	cfg.APIUI.IssuerDID = *did
 
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	idClaim, err := uuid.NewUUID()
 	require.NoError(t, err)
@@ -2511,6 +3582,188 @@ func TestServer_RevokeCredential(t *testing.T) {
 	}
 }
 
+func TestServer_ReissueCredential(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "mumbai"
+		schemaURL  = "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	)
+	ctx := log.NewContext(context.Background(), log.LevelDebug, log.OutputText, os.Stdout)
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsConf := services.ClaimCfg{
+		RHSEnabled: false,
+		Host:       "http://host",
+	}
+	pubSub := pubsub.NewMock()
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubSub)
+	connectionsService := services.NewConnection(connectionsRepository, storage)
+	notificationGateway := &notificationGatewayMock{result: &domain.UserNotificationResult{Devices: []domain.DeviceNotificationResult{
+		{Status: domain.DeviceNotificationStatusSuccess},
+	}}}
+	notificationService := services.NewNotification(notificationGateway, connectionsService, claimsService)
+	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
+	require.NoError(t, err)
+	did, err := core.ParseDID(iden.Identifier)
+	require.NoError(t, err)
+
+	userDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qKDJmySKNi4GD4vYdqfLb37MSTSijg77NoRZaKfDX")
+	require.NoError(t, err)
+	unknownUserDID := "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+
+	fixture := tests.NewFixture(storage)
+	fixture.CreateConnection(t, &domain.Connection{
+		IssuerDID:  *did,
+		UserDID:    *userDID,
+		IssuerDoc:  json.RawMessage(fmt.Sprintf(`{"id": "%[1]s", "service": [{"id": "%[1]s#%[2]s", "type": "%[2]s", "serviceEndpoint": "http://host/v1/agent"}], "@context": ["https://www.w3.org/ns/did/v1"]}`, did, verifiable.Iden3CommServiceType)),
+		UserDoc:    json.RawMessage(fmt.Sprintf(`{"id": "%[1]s", "service": [{"id": "%[1]s#push", "type": "push-notification", "metadata": {"devices": [{"alg": "RSA-OAEP-512", "ciphertext": "someToken"}]}, "serviceEndpoint": "https://push.example.com/api/v1"}], "@context": ["https://www.w3.org/ns/did/v1"]}`, userDID)),
+		CreatedAt:  time.Now(),
+		ModifiedAt: time.Now(),
+	})
+
+	expiration := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	issue := func(subjectDID string) *domain.Claim {
+		credentialSubject := map[string]any{
+			"id":           subjectDID,
+			"birthday":     19960424,
+			"documentType": 2,
+		}
+		claim, err := claimsService.Save(ctx, ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, &expiration, "KYCAgeCredential", nil, nil, nil, common.ToPointer(true), nil, nil, true))
+		require.NoError(t, err)
+		return claim
+	}
+	credential := issue(userDID.String())
+	revoked := issue(userDID.String())
+	require.NoError(t, claimsService.Revoke(ctx, *did, uint64(revoked.RevNonce), ""))
+	withoutConnection := issue(unknownUserDID)
+
+	cfg.APIUI.IssuerDID = *did
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), notificationService, NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	handler := getHandler(ctx, server)
+
+	type expected struct {
+		httpCode     int
+		message      string
+		holder       string
+		notification NotificationStatus
+	}
+	type testConfig struct {
+		name     string
+		auth     func() (string, string)
+		id       uuid.UUID
+		body     ReissueCredentialRequest
+		expected expected
+	}
+	for _, tc := range []testConfig{
+		{
+			name: "No auth header",
+			auth: authWrong,
+			id:   credential.ID,
+			expected: expected{
+				httpCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "Not existing credential",
+			auth: authOk,
+			id:   uuid.New(),
+			body: ReissueCredentialRequest{CredentialSubject: map[string]any{"documentType": 3}},
+			expected: expected{
+				httpCode: http.StatusNotFound,
+				message:  "credential not found",
+			},
+		},
+		{
+			name: "Revoked credential",
+			auth: authOk,
+			id:   revoked.ID,
+			body: ReissueCredentialRequest{CredentialSubject: map[string]any{"documentType": 3}},
+			expected: expected{
+				httpCode: http.StatusBadRequest,
+				message:  "claim is revoked",
+			},
+		},
+		{
+			name: "Happy path, the holder cannot be changed and the offer is sent",
+			auth: authOk,
+			id:   credential.ID,
+			body: ReissueCredentialRequest{CredentialSubject: map[string]any{"documentType": 3, "id": unknownUserDID}},
+			expected: expected{
+				httpCode:     http.StatusCreated,
+				holder:       userDID.String(),
+				notification: NotificationStatus{Status: NotificationStatusStatusSent, Devices: []DeviceNotificationStatus{{Status: "success"}}},
+			},
+		},
+		{
+			name: "Happy path, holder without connection",
+			auth: authOk,
+			id:   withoutConnection.ID,
+			body: ReissueCredentialRequest{CredentialSubject: map[string]any{"documentType": 3}},
+			expected: expected{
+				httpCode: http.StatusCreated,
+				holder:   unknownUserDID,
+				notification: NotificationStatus{
+					Status:  NotificationStatusStatusSkipped,
+					Reason:  common.ToPointer("the holder has no connection with the issuer"),
+					Devices: []DeviceNotificationStatus{},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pubSub.Clear(event.CreateCredentialEvent)
+			rr := httptest.NewRecorder()
+			url := fmt.Sprintf("/v1/credentials/%s/reissue", tc.id)
+			req, err := http.NewRequest(http.MethodPost, url, tests.JSONBody(t, tc.body))
+			req.SetBasicAuth(tc.auth())
+			require.NoError(t, err)
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected.httpCode, rr.Code)
+			assert.Empty(t, pubSub.AllPublishedEvents(event.CreateCredentialEvent))
+
+			switch tc.expected.httpCode {
+			case http.StatusCreated:
+				var response ReissueCredentialResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.id, response.Replaces)
+				assert.Equal(t, tc.expected.notification, response.Notification)
+
+				replaced, err := claimsService.GetByID(ctx, did, tc.id)
+				require.NoError(t, err)
+				assert.True(t, replaced.Revoked)
+
+				replacement, err := claimsService.GetByID(ctx, did, response.Id)
+				require.NoError(t, err)
+				assert.False(t, replacement.Revoked)
+				assert.Equal(t, &tc.id, replacement.ReplacesID)
+				assert.Equal(t, tc.expected.holder, replacement.OtherIdentifier)
+				vc, err := replacement.GetVerifiableCredential()
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected.holder, vc.CredentialSubject["id"])
+				assert.EqualValues(t, 3, vc.CredentialSubject["documentType"])
+				assert.EqualValues(t, 19960424, vc.CredentialSubject["birthday"])
+				require.NotNil(t, vc.Expiration)
+				assert.True(t, expiration.Equal(*vc.Expiration))
+			case http.StatusBadRequest, http.StatusNotFound:
+				var response GenericErrorMessage
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tc.expected.message, response.Message)
+			}
+		})
+	}
+}
+
 func TestServer_CreateLink(t *testing.T) {
 	const (
 		method     = "polygonid"
@@ -2540,19 +3793,19 @@ func TestServer_CreateLink(t *testing.T) {
 	pubSub := pubsub.NewMock()
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubSub)
 	connectionsService := services.NewConnection(connectionsRepository, storage)
-	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRespository, loader.HTTPFactory, sessionRepository, pubSub)
+	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRespository, loader.HTTPFactory, sessionRepository, pubSub, nil, nil)
 	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
 	require.NoError(t, err)
 
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 
-	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
 	importedSchema, err := schemaSrv.ImportSchema(ctx, *did, url, schemaType)
 	assert.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	handler := getHandler(ctx, server)
 
@@ -2660,6 +3913,55 @@ func TestServer_CreateLink(t *testing.T) {
 				httpCode: http.StatusCreated,
 			},
 		},
+		{
+			name: "Claim expiration policy",
+			auth: authOk,
+			body: CreateLinkRequest{
+				SchemaID:                   importedSchema.ID,
+				CredentialExpirationPolicy: common.ToPointer("P6M"),
+				LimitedClaims:              common.ToPointer(10),
+				CredentialSubject:          CredentialSubject{"birthday": 19790911, "documentType": 12},
+				MtProof:                    true,
+				SignatureProof:             true,
+			},
+			expected: expected{
+				response: CreateLink201JSONResponse{},
+				httpCode: http.StatusCreated,
+			},
+		},
+		{
+			name: "Claim expiration date and expiration policy",
+			auth: authOk,
+			body: CreateLinkRequest{
+				SchemaID:                   importedSchema.ID,
+				CredentialExpiration:       &types.Date{Time: time.Date(2025, 8, 15, 14, 30, 45, 100, time.Local)},
+				CredentialExpirationPolicy: common.ToPointer("P6M"),
+				LimitedClaims:              common.ToPointer(10),
+				CredentialSubject:          CredentialSubject{"birthday": 19790911, "documentType": 12},
+				MtProof:                    true,
+				SignatureProof:             true,
+			},
+			expected: expected{
+				response: CreateLink400JSONResponse{N400JSONResponse{Message: "credentialExpiration and credentialExpirationPolicy cannot be used together"}},
+				httpCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "Claim expiration policy too long",
+			auth: authOk,
+			body: CreateLinkRequest{
+				SchemaID:                   importedSchema.ID,
+				CredentialExpirationPolicy: common.ToPointer("101y"),
+				LimitedClaims:              common.ToPointer(10),
+				CredentialSubject:          CredentialSubject{"birthday": 19790911, "documentType": 12},
+				MtProof:                    true,
+				SignatureProof:             true,
+			},
+			expected: expected{
+				response: CreateLink400JSONResponse{N400JSONResponse{Message: `invalid expiration policy: "101y" is longer than 100 years`}},
+				httpCode: http.StatusBadRequest,
+			},
+		},
 		{
 			name: "Claim link wrong number of attributes",
 			auth: authOk,
@@ -2690,10 +3992,46 @@ func TestServer_CreateLink(t *testing.T) {
 				SignatureProof:       true,
 			},
 			expected: expected{
-				response: CreateLink400JSONResponse{N400JSONResponse{Message: "cannot parse claim"}},
+				response: CreateLink400JSONResponse{N400JSONResponse{Message: "credential subject does not match the provided schema: attribute <documentType>: expected an integer"}},
+				httpCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "Claim link unknown attribute",
+			auth: authOk,
+			body: CreateLinkRequest{
+				SchemaID:          importedSchema.ID,
+				Expiration:        common.ToPointer(time.Date(2025, 8, 15, 14, 30, 45, 100, time.Local)),
+				LimitedClaims:     common.ToPointer(10),
+				CredentialSubject: CredentialSubject{"birthday": 19790911, "documentType": 12, "nickname": "john"},
+				MtProof:           true,
+				SignatureProof:    true,
+			},
+			expected: expected{
+				response: CreateLink400JSONResponse{N400JSONResponse{Message: "credential subject does not match the provided schema: attribute <nickname> is not in the schema"}},
 				httpCode: http.StatusBadRequest,
 			},
 		},
+		{
+			name: "Claim link lenient validation",
+			auth: authOk,
+			body: CreateLinkRequest{
+				SchemaID:          importedSchema.ID,
+				Expiration:        common.ToPointer(time.Date(2025, 8, 15, 14, 30, 45, 100, time.Local)),
+				LimitedClaims:     common.ToPointer(10),
+				CredentialSubject: CredentialSubject{"birthday": "19790911", "documentType": 12, "nickname": "john"},
+				MtProof:           true,
+				SignatureProof:    true,
+				ValidationMode:    common.ToPointer(Lenient),
+			},
+			expected: expected{
+				response: CreateLink201JSONResponse{Warnings: &[]string{
+					"attribute <birthday> was converted from a string to an integer",
+					"attribute <nickname> is not in the schema, it was dropped",
+				}},
+				httpCode: http.StatusCreated,
+			},
+		},
 		{
 			name: "Claim link wrong schema id",
 			auth: authOk,
@@ -2730,6 +4068,9 @@ func TestServer_CreateLink(t *testing.T) {
 				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
 				_, err := uuid.Parse(response.Id)
 				assert.NoError(t, err)
+				expected, ok := tc.expected.response.(CreateLink201JSONResponse)
+				require.True(t, ok)
+				assert.Equal(t, expected.Warnings, response.Warnings)
 			case http.StatusBadRequest:
 				var response CreateLink400JSONResponse
 				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
@@ -2767,22 +4108,22 @@ func TestServer_ActivateLink(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 	connectionsService := services.NewConnection(connectionsRepository, storage)
-	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock())
+	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock(), nil, nil)
 	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
 	require.NoError(t, err)
 
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 
-	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
 	importedSchema, err := schemaSrv.ImportSchema(ctx, *did, url, schemaType)
 	assert.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	tomorrow := time.Now().Add(24 * time.Hour)
-	link, err := linkService.Save(ctx, *did, common.ToPointer(10), &tomorrow, importedSchema.ID, nil, true, true, CredentialSubject{"birthday": 19790911, "documentType": 12})
+	link, err := linkService.Save(ctx, *did, common.ToPointer(10), &tomorrow, importedSchema.ID, nil, nil, true, true, CredentialSubject{"birthday": 19790911, "documentType": 12}, domain.ValidationModeStrict)
 	require.NoError(t, err)
 
 	handler := getHandler(ctx, server)
@@ -2918,28 +4259,28 @@ func TestServer_GetLink(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 	connectionsService := services.NewConnection(connectionsRepository, storage)
-	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock())
+	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock(), nil, nil)
 	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
 	require.NoError(t, err)
 
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 
-	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
 	importedSchema, err := schemaSrv.ImportSchema(ctx, *did, url, schemaType)
 	assert.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	tomorrow := time.Now().Add(24 * time.Hour)
 	yesterday := time.Now().Add(-24 * time.Hour)
 
-	link, err := linkService.Save(ctx, *did, common.ToPointer(10), &tomorrow, importedSchema.ID, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	link, err := linkService.Save(ctx, *did, common.ToPointer(10), &tomorrow, importedSchema.ID, nil, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12}, domain.ValidationModeStrict)
 	require.NoError(t, err)
 	hash, _ := link.Schema.Hash.MarshalText()
 
-	linkExpired, err := linkService.Save(ctx, *did, common.ToPointer(10), &yesterday, importedSchema.ID, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	linkExpired, err := linkService.Save(ctx, *did, common.ToPointer(10), &yesterday, importedSchema.ID, nil, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12}, domain.ValidationModeStrict)
 	require.NoError(t, err)
 
 	handler := getHandler(ctx, server)
@@ -3089,36 +4430,36 @@ func TestServer_GetAllLinks(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 	connectionsService := services.NewConnection(connectionsRepository, storage)
-	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock())
+	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock(), nil, nil)
 	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
 	require.NoError(t, err)
 
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 
-	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
 	importedSchema, err := schemaSrv.ImportSchema(ctx, *did, sUrl, schemaType)
 	assert.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	tomorrow := time.Now().Add(24 * time.Hour)
 	yesterday := time.Now().Add(-24 * time.Hour)
 
-	link1, err := linkService.Save(ctx, *did, common.ToPointer(10), &tomorrow, importedSchema.ID, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	link1, err := linkService.Save(ctx, *did, common.ToPointer(10), &tomorrow, importedSchema.ID, nil, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12}, domain.ValidationModeStrict)
 	require.NoError(t, err)
 	linkActive := getLinkResponse(*link1)
 
 	time.Sleep(10 * time.Millisecond)
 
-	link2, err := linkService.Save(ctx, *did, common.ToPointer(10), &yesterday, importedSchema.ID, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	link2, err := linkService.Save(ctx, *did, common.ToPointer(10), &yesterday, importedSchema.ID, nil, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12}, domain.ValidationModeStrict)
 	require.NoError(t, err)
 	linkExpired := getLinkResponse(*link2)
 	require.NoError(t, err)
 	time.Sleep(10 * time.Millisecond)
 
-	link3, err := linkService.Save(ctx, *did, common.ToPointer(10), &yesterday, importedSchema.ID, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	link3, err := linkService.Save(ctx, *did, common.ToPointer(10), &yesterday, importedSchema.ID, nil, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12}, domain.ValidationModeStrict)
 	link3.Active = false
 	require.NoError(t, err)
 	require.NoError(t, linkService.Activate(ctx, *did, link3.ID, false))
@@ -3303,23 +4644,23 @@ func TestServer_DeleteLink(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 	connectionsService := services.NewConnection(connectionsRepository, storage)
-	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock())
+	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock(), nil, nil)
 	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
 	require.NoError(t, err)
 
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 
-	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
 	importedSchema, err := schemaSrv.ImportSchema(ctx, *did, url, schemaType)
 	assert.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	validUntil := common.ToPointer(time.Date(2023, 8, 15, 14, 30, 45, 100, time.Local))
 	credentialExpiration := common.ToPointer(time.Date(2025, 8, 15, 14, 30, 45, 100, time.Local))
-	link, err := linkService.Save(ctx, *did, common.ToPointer(10), validUntil, importedSchema.ID, credentialExpiration, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	link, err := linkService.Save(ctx, *did, common.ToPointer(10), validUntil, importedSchema.ID, credentialExpiration, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12}, domain.ValidationModeStrict)
 	assert.NoError(t, err)
 	handler := getHandler(ctx, server)
 
@@ -3420,7 +4761,7 @@ func TestServer_DeleteLinkForDifferentDID(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 	connectionsService := services.NewConnection(connectionsRepository, storage)
-	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock())
+	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock(), nil, nil)
 	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
 	require.NoError(t, err)
 
@@ -3430,7 +4771,7 @@ func TestServer_DeleteLinkForDifferentDID(t *testing.T) {
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 
-	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
 	importedSchema, err := schemaSrv.ImportSchema(ctx, *did, url, schemaType)
 	assert.NoError(t, err)
 
@@ -3438,11 +4779,11 @@ func TestServer_DeleteLinkForDifferentDID(t *testing.T) {
 	require.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did2
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	validUntil := common.ToPointer(time.Date(2023, 8, 15, 14, 30, 45, 100, time.Local))
 	credentialExpiration := common.ToPointer(time.Date(2025, 8, 15, 14, 30, 45, 100, time.Local))
-	link, err := linkService.Save(ctx, *did, common.ToPointer(10), validUntil, importedSchema.ID, credentialExpiration, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	link, err := linkService.Save(ctx, *did, common.ToPointer(10), validUntil, importedSchema.ID, credentialExpiration, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12}, domain.ValidationModeStrict)
 	assert.NoError(t, err)
 	handler := getHandler(ctx, server)
 
@@ -3534,29 +4875,32 @@ func TestServer_CreateLinkQRCode(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 	connectionsService := services.NewConnection(connectionsRepository, storage)
-	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock())
+	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock(), nil, nil)
 	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
 	require.NoError(t, err)
 
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 
-	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
 	importedSchema, err := schemaSrv.ImportSchema(ctx, *did, url, schemaType)
 	assert.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did
 	cfg.APIUI.ServerURL = "http://localhost/issuer-admin"
 
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	brandingService := services.NewBranding(storage, domain.Branding{DisplayName: "my issuer", LogoURL: "https://example.com/logo.png", PrimaryColor: "#6c4ce0", TextColor: "#ffffff"})
+	_, err = brandingService.Update(ctx, *did, domain.BrandingOverrides{PrimaryColor: common.ToPointer("#112233")})
+	require.NoError(t, err)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewSubjectMock(), NewNotificationMock(), brandingService, NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	validUntil := common.ToPointer(time.Date(2023, 8, 15, 14, 30, 45, 0, time.Local))
 	credentialExpiration := common.ToPointer(time.Date(2025, 8, 15, 14, 30, 45, 0, time.Local))
-	link, err := linkService.Save(ctx, *did, common.ToPointer(10), validUntil, importedSchema.ID, credentialExpiration, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	link, err := linkService.Save(ctx, *did, common.ToPointer(10), validUntil, importedSchema.ID, credentialExpiration, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12}, domain.ValidationModeStrict)
 	assert.NoError(t, err)
 
 	yesterday := time.Now().Add(-24 * time.Hour)
-	linkExpired, err := linkService.Save(ctx, *did, common.ToPointer(10), &yesterday, importedSchema.ID, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	linkExpired, err := linkService.Save(ctx, *did, common.ToPointer(10), &yesterday, importedSchema.ID, nil, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12}, domain.ValidationModeStrict)
 	require.NoError(t, err)
 
 	handler := getHandler(ctx, server)
@@ -3632,6 +4976,7 @@ func TestServer_CreateLinkQRCode(t *testing.T) {
 				assert.NotNil(t, response.SessionID)
 				assert.Equal(t, tc.expected.linkDetail.Id, response.LinkDetail.Id)
 				assert.Equal(t, tc.expected.linkDetail.SchemaType, response.LinkDetail.SchemaType)
+				assert.Equal(t, IssuerDescription{DisplayName: "my issuer", Logo: "https://example.com/logo.png", PrimaryColor: "#112233", TextColor: "#ffffff"}, response.Issuer)
 			case http.StatusNotFound:
 				var response CreateLinkQrCode404JSONResponse
 				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
@@ -3669,25 +5014,25 @@ func TestServer_GetLinkQRCode(t *testing.T) {
 	}
 	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
 	connectionsService := services.NewConnection(connectionsRepository, storage)
-	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock())
+	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, loader.HTTPFactory, sessionRepository, pubsub.NewMock(), nil, nil)
 	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
 	require.NoError(t, err)
 
 	did, err := core.ParseDID(iden.Identifier)
 	require.NoError(t, err)
 
-	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory)
+	schemaSrv := services.NewSchema(repositories.NewSchema(*storage), loader.HTTPFactory, nil, nil, false)
 	importedSchema, err := schemaSrv.ImportSchema(ctx, *did, url, schemaType)
 	assert.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did
 	cfg.APIUI.ServerURL = "http://localhost/issuer-admin"
 
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, linkService, NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	validUntil := common.ToPointer(time.Date(2023, 8, 15, 14, 30, 45, 0, time.Local))
 	credentialExpiration := common.ToPointer(time.Date(2025, 8, 15, 14, 30, 45, 0, time.Local))
-	link, err := linkService.Save(ctx, *did, common.ToPointer(10), validUntil, importedSchema.ID, credentialExpiration, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	link, err := linkService.Save(ctx, *did, common.ToPointer(10), validUntil, importedSchema.ID, credentialExpiration, nil, true, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12}, domain.ValidationModeStrict)
 	assert.NoError(t, err)
 	handler := getHandler(ctx, server)
 
@@ -3855,7 +5200,7 @@ func TestServer_GetStateStatus(t *testing.T) {
 	require.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, identityService, claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, identityService, claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	handler := getHandler(ctx, server)
 
@@ -3954,7 +5299,7 @@ func TestServer_GetStateTransactions(t *testing.T) {
 	require.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, identityService, claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, identityService, claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	handler := getHandler(ctx, server)
 
@@ -4044,7 +5389,7 @@ func TestServer_GetRevocationStatus(t *testing.T) {
 	require.NoError(t, err)
 
 	cfg.APIUI.IssuerDID = *did
-	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server := NewServer(&cfg, NewIdentityMock(), claimsService, NewSchemaMock(), connectionsService, NewLinkMock(), NewSubjectMock(), NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
 
 	credentialSubject := map[string]any{
 		"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
@@ -4106,3 +5451,109 @@ func TestServer_GetRevocationStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_SubjectData(t *testing.T) {
+	const (
+		method     = "polygonid"
+		blockchain = "polygon"
+		network    = "mumbai"
+		subject    = "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+	)
+	ctx := log.NewContext(context.Background(), log.LevelDebug, log.OutputText, os.Stdout)
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	identityStateRepo := repositories.NewIdentityState()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	revocationRepository := repositories.NewRevocation()
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	subjectService := services.NewSubject(claimsRepo, connectionsRepository, repositories.NewSubject(), storage)
+
+	iden, err := identityService.Create(ctx, method, blockchain, network, "polygon-test")
+	require.NoError(t, err)
+	issuerDID, err := core.ParseDID(iden.Identifier)
+	require.NoError(t, err)
+	userDID, err := core.ParseDID(subject)
+	require.NoError(t, err)
+
+	server := NewServer(&cfg, NewIdentityMock(), NewClaimsMock(), NewSchemaMock(), NewConnectionsMock(), NewLinkMock(), subjectService, NewNotificationMock(), NewBrandingMock(), NewPublisherMock(), NewPackageManagerMock(), nil)
+	server.cfg.APIUI.IssuerDID = *issuerDID
+	handler := getHandler(context.Background(), server)
+
+	fixture := tests.NewFixture(storage)
+	fixture.CreateConnection(t, &domain.Connection{
+		ID:         uuid.New(),
+		IssuerDID:  *issuerDID,
+		UserDID:    *userDID,
+		CreatedAt:  time.Now(),
+		ModifiedAt: time.Now(),
+	})
+	credentialID := fixture.CreateClaim(t, fixture.NewClaim(t, issuerDID.String()))
+
+	t.Run("No auth header", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/v1/subjects/"+subject, nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authWrong())
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should get an error, wrong did", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodDelete, "/v1/subjects/wrong-did", nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should export the subject data", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/v1/subjects/"+subject, nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response GetSubjectData200JSONResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, subject, response.Subject)
+		require.NotNil(t, response.Connection)
+		require.Len(t, response.Credentials, 1)
+		assert.Equal(t, credentialID, response.Credentials[0].Id)
+		assert.Empty(t, response.Erasures)
+	})
+
+	t.Run("should erase the subject data", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodDelete, "/v1/subjects/"+subject, nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response EraseSubjectData200JSONResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, int64(1), response.Credentials)
+		assert.Equal(t, int64(1), response.Connections)
+		assert.Equal(t, domain.SubjectHash(*userDID), response.SubjectHash)
+	})
+
+	t.Run("should only export the erasure record after erasing", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/v1/subjects/"+subject, nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(authOk())
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response GetSubjectData200JSONResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Nil(t, response.Connection)
+		assert.Empty(t, response.Credentials)
+		require.Len(t, response.Erasures, 1)
+	})
+}
@@ -0,0 +1,215 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-merkletree-sql/v2"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+// batchSize is the number of events read at a time
+const batchSize = 1000
+
+// IdentityReport is the verification result of the credential log of an identity
+type IdentityReport struct {
+	Identity string `json:"identity"`
+	Events   int64  `json:"events"`
+	// Anchored is the number of events up to the last anchor in a confirmed state
+	Anchored int64 `json:"anchored"`
+	// PendingAnchors are the anchors whose state is not confirmed yet
+	PendingAnchors int      `json:"pendingAnchors"`
+	Errors         []string `json:"errors,omitempty"`
+}
+
+// Ok returns true if the log of the identity passed all the checks
+func (r *IdentityReport) Ok() bool {
+	return len(r.Errors) == 0
+}
+
+// Report is the verification result of the credential logs
+type Report struct {
+	Identities []IdentityReport `json:"identities"`
+}
+
+// Ok returns true if every log passed all the checks
+func (r *Report) Ok() bool {
+	for i := range r.Identities {
+		if !r.Identities[i].Ok() {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify checks the credential log of the identity, or of every identity when identifier is empty. Each event must
+// follow the previous one with the hash computed from its fields, the anchored events must have the anchored hashes,
+// and the anchors of the confirmed states must be in the claims tree of the last confirmed state, whose roots must
+// hash to that state. A changed, inserted or removed event breaks the chain, and rewriting the chain after it breaks
+// the anchors, which are published on chain with the states.
+func Verify(ctx context.Context, storage *db.Storage, identifier string) (*Report, error) {
+	identities := []string{identifier}
+	if identifier == "" {
+		var err error
+		if identities, err = repositories.NewIdentity().Get(ctx, storage.Pgx); err != nil {
+			return nil, err
+		}
+	}
+
+	v := &verifier{
+		conn:      storage.Pgx,
+		events:    repositories.NewCredentialEvent(),
+		states:    repositories.NewIdentityState(),
+		mtService: services.NewIdentityMerkleTrees(repositories.NewIdentityMerkleTreeRepository()),
+	}
+	report := &Report{Identities: make([]IdentityReport, 0, len(identities))}
+	for _, identity := range identities {
+		did, err := core.ParseDID(identity)
+		if err != nil {
+			return nil, fmt.Errorf("parsing identity %s: %w", identity, err)
+		}
+		report.Identities = append(report.Identities, v.verify(ctx, did))
+	}
+	return report, nil
+}
+
+type verifier struct {
+	conn      db.Querier
+	events    ports.CredentialEventRepository
+	states    ports.IdentityStateRepository
+	mtService ports.MtService
+}
+
+func (v *verifier) verify(ctx context.Context, did *core.DID) IdentityReport {
+	ir := IdentityReport{Identity: did.String()}
+	fail := func(format string, args ...any) {
+		ir.Errors = append(ir.Errors, fmt.Sprintf(format, args...))
+	}
+
+	anchors, err := v.events.GetAnchors(ctx, v.conn, *did)
+	if err != nil {
+		fail("reading the anchors: %v", err)
+		return ir
+	}
+	anchored := make(map[int64][]byte, len(anchors))
+	for _, anchor := range anchors {
+		anchored[anchor.Seq] = anchor.Hash
+	}
+
+	var previous *domain.CredentialEvent
+	for {
+		var afterSeq int64
+		if previous != nil {
+			afterSeq = previous.Seq
+		}
+		events, err := v.events.GetEvents(ctx, v.conn, *did, afterSeq, batchSize)
+		if err != nil {
+			fail("reading the events: %v", err)
+			return ir
+		}
+		for i := range events {
+			event := &events[i]
+			if !event.Follows(previous) {
+				fail("event %d doesn't follow the previous one or its hash is wrong", event.Seq)
+			}
+			if hash, ok := anchored[event.Seq]; ok && !bytes.Equal(hash, event.Hash) {
+				fail("event %d doesn't match its anchor", event.Seq)
+			}
+			previous = event
+			ir.Events++
+		}
+		if len(events) < batchSize {
+			break
+		}
+	}
+	for _, anchor := range anchors {
+		if previous == nil || anchor.Seq > previous.Seq {
+			fail("the anchored event %d is missing", anchor.Seq)
+		}
+	}
+
+	v.verifyAnchors(ctx, did, anchors, &ir, fail)
+	return ir
+}
+
+// verifyAnchors checks that the anchors of the confirmed states are in the claims tree of the last confirmed state
+func (v *verifier) verifyAnchors(ctx context.Context, did *core.DID, anchors []domain.CredentialEventAnchor, ir *IdentityReport, fail func(format string, args ...any)) {
+	if len(anchors) == 0 {
+		return
+	}
+	states, err := v.states.GetStates(ctx, v.conn, *did)
+	if err != nil {
+		fail("reading the states: %v", err)
+		return
+	}
+	confirmed := make(map[string]bool, len(states))
+	for _, state := range states {
+		if state.State != nil && state.Status == domain.StatusConfirmed {
+			confirmed[*state.State] = true
+		}
+	}
+	last, err := v.states.GetLatestStateByIdentifier(ctx, v.conn, did)
+	if err != nil {
+		fail("reading the last confirmed state: %v", err)
+		return
+	}
+	treeState := last.TreeState()
+	if treeState.ClaimsRoot == nil || treeState.RevocationRoot == nil || treeState.RootOfRoots == nil || treeState.State == nil {
+		fail("the last confirmed state has no tree roots")
+		return
+	}
+	state, err := merkletree.HashElems(treeState.ClaimsRoot.BigInt(), treeState.RevocationRoot.BigInt(), treeState.RootOfRoots.BigInt())
+	if err != nil || state.BigInt().Cmp(treeState.State.BigInt()) != 0 {
+		fail("the tree roots of the last confirmed state don't hash to it")
+		return
+	}
+	trees, err := v.mtService.GetIdentityMerkleTrees(ctx, v.conn, did)
+	if err != nil {
+		fail("reading the merkle trees: %v", err)
+		return
+	}
+	claimsTree, err := trees.ClaimsTree()
+	if err != nil {
+		fail("reading the claims tree: %v", err)
+		return
+	}
+
+	for i := range anchors {
+		anchor := anchors[i]
+		if !confirmed[anchor.State] {
+			ir.PendingAnchors++
+			continue
+		}
+		event := domain.CredentialEvent{Seq: anchor.Seq, Hash: anchor.Hash}
+		anchorClaim, err := event.AnchorClaim()
+		if err != nil {
+			fail("anchor %d: %v", anchor.Seq, err)
+			continue
+		}
+		hi, hv, err := anchorClaim.HiHv()
+		if err != nil {
+			fail("anchor %d: %v", anchor.Seq, err)
+			continue
+		}
+		proof, value, err := claimsTree.GenerateProof(ctx, hi, treeState.ClaimsRoot)
+		if errors.Is(err, merkletree.ErrKeyNotFound) || (err == nil && (!proof.Existence || value.Cmp(hv) != 0)) {
+			fail("anchor %d is not in the claims tree", anchor.Seq)
+			continue
+		}
+		if err != nil {
+			fail("anchor %d: proving it in the claims tree: %v", anchor.Seq, err)
+			continue
+		}
+		if anchor.Seq > ir.Anchored {
+			ir.Anchored = anchor.Seq
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// Version is the format version of the bundles produced by Export
+const Version = 1
+
+var (
+	// ErrUnsupportedVersion is returned when the bundle was produced by an incompatible version
+	ErrUnsupportedVersion = errors.New("unsupported backup bundle version")
+	// ErrDatabaseNotEmpty is returned when trying to restore a bundle on a database that already has identities
+	ErrDatabaseNotEmpty = errors.New("target database already contains identities")
+)
+
+// tables are the issuer tables included in a bundle, in an order that satisfies the foreign keys on restore.
+// Every table of the schema must be either here or in skippedTables.
+var tables = []string{
+	"identities",
+	"identity_mts",
+	"mt_roots",
+	"mt_nodes",
+	"identity_states",
+	"revocation",
+	"schemas",
+	"links",
+	"claims",
+	"credential_events",
+	"credential_event_anchors",
+	"claim_timings",
+	"payloads",
+	"claim_payloads",
+	"connections",
+	"subject_erasures",
+	"erased_claims",
+	"archived_claims",
+	"verifications",
+	"key_rotations",
+	"api_keys",
+	"quotas",
+	"brandings",
+	"schema_webhooks",
+	"schema_usage",
+	"metering",
+	"hook_deliveries",
+	"link_sessions",
+}
+
+// skippedTables are the tables left out of the bundles, because they only hold the transient state of the running
+// nodes, with the reason
+var skippedTables = map[string]string{
+	"sessions":       "authentication requests of the qr codes being scanned",
+	"agent_messages": "replay protection of the agent messages, they expire in minutes",
+	"cache_entries":  "cache shared by the servers",
+	"throttles":      "rate limits of the public endpoints",
+	"maintenance":    "maintenance mode of the running node",
+	"region_lease":   "lease of the region that writes in a multi-region deployment",
+}
+
+// sequences are the generated columns whose sequences must be moved forward after a restore.
+var sequences = map[string]string{
+	"identity_mts":    "id",
+	"identity_states": "state_id",
+	"revocation":      "id",
+}
+
+// Manifest describes the node configuration a bundle was taken from. It never contains secrets.
+type Manifest struct {
+	ServerURL          string `json:"serverUrl"`
+	ContractAddress    string `json:"contractAddress"`
+	ResolverPrefix     string `json:"resolverPrefix"`
+	RHSEnabled         bool   `json:"rhsEnabled"`
+	RHSUrl             string `json:"rhsUrl"`
+	KeyStoreMountPath  string `json:"keyStoreMountPath"`
+	IssuerDID          string `json:"issuerDID,omitempty"`
+	IdentityMethod     string `json:"identityMethod,omitempty"`
+	IdentityBlockchain string `json:"identityBlockchain,omitempty"`
+	IdentityNetwork    string `json:"identityNetwork,omitempty"`
+}
+
+// KeyReference links an identity to a key stored in the KMS. Key material is never exported.
+type KeyReference struct {
+	Identity string      `json:"identity"`
+	KeyType  kms.KeyType `json:"keyType"`
+	KeyID    string      `json:"keyID"`
+}
+
+// Bundle is a consistent snapshot of an issuer node
+type Bundle struct {
+	Version   int                        `json:"version"`
+	CreatedAt time.Time                  `json:"createdAt"`
+	Manifest  Manifest                   `json:"manifest"`
+	Keys      []KeyReference             `json:"keys"`
+	Tables    map[string]json.RawMessage `json:"tables"`
+}
+
+// NewManifest builds the manifest from the node configuration
+func NewManifest(cfg *config.Configuration) Manifest {
+	return Manifest{
+		ServerURL:          cfg.ServerUrl,
+		ContractAddress:    cfg.Ethereum.ContractAddress,
+		ResolverPrefix:     cfg.Ethereum.ResolverPrefix,
+		RHSEnabled:         cfg.ReverseHashService.Enabled,
+		RHSUrl:             cfg.ReverseHashService.URL,
+		KeyStoreMountPath:  cfg.KeyStore.PluginIden3MountPath,
+		IssuerDID:          cfg.APIUI.Issuer,
+		IdentityMethod:     cfg.APIUI.IdentityMethod,
+		IdentityBlockchain: cfg.APIUI.IdentityBlockchain,
+		IdentityNetwork:    cfg.APIUI.IdentityNetwork,
+	}
+}
+
+// Export reads all the issuer tables inside a single read only repeatable read transaction, so the
+// bundle reflects one point in time. If keyStore is not nil, the key references of every identity are included.
+func Export(ctx context.Context, storage *db.Storage, keyStore kms.KMSType, manifest Manifest) (*Bundle, error) {
+	tx, err := storage.Pgx.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("starting backup transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	bundle := &Bundle{
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		Manifest:  manifest,
+		Keys:      []KeyReference{},
+		Tables:    make(map[string]json.RawMessage, len(tables)),
+	}
+
+	for _, table := range tables {
+		var rows []byte
+		// table names come from the fixed list above, never from user input
+		if err := tx.QueryRow(ctx, fmt.Sprintf(`SELECT coalesce(json_agg(t), '[]'::json) FROM %s t`, table)).Scan(&rows); err != nil {
+			return nil, fmt.Errorf("exporting table %s: %w", table, err)
+		}
+		bundle.Tables[table] = rows
+	}
+
+	if keyStore == nil {
+		return bundle, nil
+	}
+
+	identities, err := bundleIdentities(bundle)
+	if err != nil {
+		return nil, err
+	}
+	for _, identifier := range identities {
+		did, err := core.ParseDID(identifier)
+		if err != nil {
+			return nil, fmt.Errorf("parsing identity %s: %w", identifier, err)
+		}
+		keys, err := keyStore.KeysByIdentity(ctx, *did)
+		if err != nil {
+			return nil, fmt.Errorf("listing keys of %s: %w", identifier, err)
+		}
+		for _, key := range keys {
+			bundle.Keys = append(bundle.Keys, KeyReference{Identity: identifier, KeyType: key.Type, KeyID: key.ID})
+		}
+	}
+
+	return bundle, nil
+}
+
+// Restore loads a bundle into an empty database in a single transaction.
+// Migrations must have been applied to the target database before calling it.
+func Restore(ctx context.Context, storage *db.Storage, bundle *Bundle) error {
+	if bundle.Version != Version {
+		return ErrUnsupportedVersion
+	}
+
+	tx, err := storage.Pgx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting restore transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var count int
+	if err := tx.QueryRow(ctx, `SELECT count(*) FROM identities`).Scan(&count); err != nil {
+		return fmt.Errorf("checking target database: %w", err)
+	}
+	if count > 0 {
+		return ErrDatabaseNotEmpty
+	}
+
+	// the credential log of the bundle is restored as it is, the claims must not add events to it
+	if err := db.SetCredentialEvent(ctx, tx, "none"); err != nil {
+		return fmt.Errorf("disabling the credential log: %w", err)
+	}
+
+	for _, table := range tables {
+		rows, ok := bundle.Tables[table]
+		if !ok {
+			continue
+		}
+		tag, err := tx.Exec(ctx, fmt.Sprintf(`INSERT INTO %[1]s OVERRIDING SYSTEM VALUE SELECT * FROM json_populate_recordset(NULL::%[1]s, $1::json)`, table), string(rows))
+		if err != nil {
+			return fmt.Errorf("restoring table %s: %w", table, err)
+		}
+		log.Info(ctx, "table restored", "table", table, "rows", tag.RowsAffected())
+	}
+
+	for table, column := range sequences {
+		_, err := tx.Exec(ctx, fmt.Sprintf(`SELECT setval(pg_get_serial_sequence('%[1]s', '%[2]s'), coalesce((SELECT max(%[2]s) FROM %[1]s), 0) + 1, false)`, table, column))
+		if err != nil {
+			return fmt.Errorf("resetting sequence of %s.%s: %w", table, column, err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func bundleIdentities(bundle *Bundle) ([]string, error) {
+	var rows []struct {
+		Identifier string `json:"identifier"`
+	}
+	if err := json.Unmarshal(bundle.Tables["identities"], &rows); err != nil {
+		return nil, fmt.Errorf("decoding identities: %w", err)
+	}
+	identities := make([]string, 0, len(rows))
+	for _, row := range rows {
+		identities = append(identities, row.Identifier)
+	}
+	return identities, nil
+}
@@ -0,0 +1,31 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/db/schema"
+)
+
+func TestTablesCoverSchema(t *testing.T) {
+	created, err := schema.Tables()
+	require.NoError(t, err)
+
+	classified := make(map[string]bool, len(tables)+len(skippedTables))
+	for _, table := range tables {
+		assert.False(t, classified[table], "%s is in the bundles twice", table)
+		classified[table] = true
+	}
+	for table := range skippedTables {
+		assert.False(t, classified[table], "%s is both in the bundles and skipped", table)
+		classified[table] = true
+	}
+
+	for _, table := range created {
+		assert.True(t, classified[table], "the migrations create %s, add it to tables or skippedTables", table)
+		delete(classified, table)
+	}
+	assert.Empty(t, classified, "tables that the migrations don't create")
+}
@@ -0,0 +1,149 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iden3/contracts-abi/state/go/abi"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-merkletree-sql/v2"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+// StateReader reads the latest published state of an identity from the state contract
+type StateReader interface {
+	GetLatestStateByID(ctx context.Context, addr common.Address, id *big.Int) (abi.IStateStateInfo, error)
+}
+
+// IdentityReport is the verification result of a single restored identity
+type IdentityReport struct {
+	Identity     string   `json:"identity"`
+	DBState      string   `json:"dbState,omitempty"`
+	OnChainState string   `json:"onChainState,omitempty"`
+	MissingKeys  []string `json:"missingKeys,omitempty"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
+// Ok returns true if the identity passed all the checks
+func (r *IdentityReport) Ok() bool {
+	return len(r.MissingKeys) == 0 && len(r.Errors) == 0
+}
+
+// Report is the verification result of a restored bundle
+type Report struct {
+	Identities []IdentityReport `json:"identities"`
+}
+
+// Ok returns true if every identity passed all the checks
+func (r *Report) Ok() bool {
+	for i := range r.Identities {
+		if !r.Identities[i].Ok() {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify checks a restored node against the bundle: every referenced key must be reachable in the key store and the
+// latest confirmed state of every identity must match the state published on chain.
+// Identities that have never been published are only checked for keys.
+func Verify(ctx context.Context, storage *db.Storage, keyStore kms.KMSType, states StateReader, contract common.Address, bundle *Bundle) (*Report, error) {
+	identities, err := bundleIdentities(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	stateRepo := repositories.NewIdentityState()
+	report := &Report{Identities: make([]IdentityReport, 0, len(identities))}
+	for _, identifier := range identities {
+		ir := IdentityReport{Identity: identifier}
+		did, err := core.ParseDID(identifier)
+		if err != nil {
+			ir.Errors = append(ir.Errors, fmt.Sprintf("invalid did: %v", err))
+			report.Identities = append(report.Identities, ir)
+			continue
+		}
+
+		if keyStore != nil {
+			ir.MissingKeys = missingKeys(ctx, keyStore, did, bundle.Keys, &ir)
+		}
+
+		if states != nil {
+			verifyState(ctx, storage, stateRepo, states, contract, did, &ir)
+		}
+
+		report.Identities = append(report.Identities, ir)
+	}
+
+	return report, nil
+}
+
+func missingKeys(ctx context.Context, keyStore kms.KMSType, did *core.DID, refs []KeyReference, ir *IdentityReport) []string {
+	keys, err := keyStore.KeysByIdentity(ctx, *did)
+	if err != nil {
+		ir.Errors = append(ir.Errors, fmt.Sprintf("listing keys: %v", err))
+		return nil
+	}
+
+	available := make(map[kms.KeyID]struct{}, len(keys))
+	for _, key := range keys {
+		available[key] = struct{}{}
+	}
+
+	var missing []string
+	for _, ref := range refs {
+		if ref.Identity != ir.Identity {
+			continue
+		}
+		if _, ok := available[kms.KeyID{Type: ref.KeyType, ID: ref.KeyID}]; !ok {
+			missing = append(missing, ref.KeyID)
+		}
+	}
+	return missing
+}
+
+func verifyState(ctx context.Context, storage *db.Storage, stateRepo ports.IdentityStateRepository, states StateReader, contract common.Address, did *core.DID, ir *IdentityReport) {
+	state, err := stateRepo.GetLatestStateByIdentifier(ctx, storage.Pgx, did)
+	if err != nil {
+		ir.Errors = append(ir.Errors, fmt.Sprintf("reading latest confirmed state: %v", err))
+		return
+	}
+	if state.State == nil {
+		ir.Errors = append(ir.Errors, "latest confirmed state is empty")
+		return
+	}
+	ir.DBState = *state.State
+
+	// genesis states are confirmed without being published, there is nothing to compare on chain
+	if state.PreviousState == nil {
+		return
+	}
+
+	dbState, err := merkletree.NewHashFromHex(*state.State)
+	if err != nil {
+		ir.Errors = append(ir.Errors, fmt.Sprintf("decoding state: %v", err))
+		return
+	}
+
+	onChain, err := states.GetLatestStateByID(ctx, contract, did.ID.BigInt())
+	if err != nil {
+		ir.Errors = append(ir.Errors, fmt.Sprintf("reading on chain state: %v", err))
+		return
+	}
+	onChainHash, err := merkletree.NewHashFromBigInt(onChain.State)
+	if err != nil {
+		ir.Errors = append(ir.Errors, fmt.Sprintf("decoding on chain state: %v", err))
+		return
+	}
+	ir.OnChainState = onChainHash.Hex()
+
+	if dbState.BigInt().Cmp(onChain.State) != 0 {
+		ir.Errors = append(ir.Errors, "latest confirmed state does not match the on chain state")
+	}
+}
@@ -0,0 +1,406 @@
+package claimarchive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// DefaultBatchSize is the number of claims archived or restored in each transaction
+const DefaultBatchSize = 500
+
+// ErrNotArchived means the claim to restore is not in the archive
+var ErrNotArchived = errors.New("the claim is not archived")
+
+// candidatesSQL selects the claims that can be archived: the revoked before the cutoff and the expired before the
+// cutoff, except the authentication claims of the identities. $1 is the cutoff, $2 the identity or empty for all of
+// them and $3 the auth claims types.
+const candidatesSQL = `
+SELECT c.id, c.identifier, c.schema_url, c.rev_nonce::text, coalesce(c.revoked, false), c.expiration,
+       coalesce(c.other_identifier, ''), c.schema_hash, c.index_hash, row_to_json(c)::text
+FROM claims c
+WHERE ($2 = '' OR c.identifier = $2)
+  AND NOT (c.schema_type = ANY($3))
+  AND ((c.expiration IS NOT NULL AND c.expiration > 0 AND c.expiration < extract(epoch FROM $1::timestamptz))
+   OR (c.revoked AND EXISTS (SELECT 1 FROM revocation r
+		WHERE r.identifier = c.identifier AND r.nonce = c.rev_nonce AND r.created_at < $1)))
+ORDER BY c.id`
+
+// authClaimTypes are the schema types of the auth claims, that are never archived
+var authClaimTypes = []string{domain.AuthBJJCredentialSchemaType, domain.AuthEthCredentialSchemaType}
+
+// Options of an archive run
+type Options struct {
+	// Age is how long the claims are kept in the claims table after they are revoked or expire
+	Age time.Duration
+	// Identifier limits the run to the claims of one identity. Empty archives the claims of every identity.
+	Identifier string
+	// BatchSize is the number of claims moved in each transaction. Defaults to DefaultBatchSize.
+	BatchSize int
+	// DryRun only reports the claims that would be archived, nothing is moved
+	DryRun bool
+}
+
+// RestoreOptions of a restore run
+type RestoreOptions struct {
+	// Identifier limits the run to the claims of one identity. Empty restores the claims of every identity.
+	Identifier string
+	// ID restores only this claim
+	ID *uuid.UUID
+	// BatchSize is the number of claims moved in each transaction. Defaults to DefaultBatchSize.
+	BatchSize int
+}
+
+// Report is the result of an archive or restore run
+type Report struct {
+	DryRun bool       `json:"dryRun,omitempty"`
+	Cutoff *time.Time `json:"cutoff,omitempty"`
+	Claims int64      `json:"claims"`
+	// Bytes is the size of the claims as json, and CompressedBytes their size in the archive
+	Bytes           int64 `json:"bytes"`
+	CompressedBytes int64 `json:"compressedBytes"`
+}
+
+type candidate struct {
+	id         uuid.UUID
+	identifier string
+	schemaURL  string
+	revNonce   string
+	revoked    bool
+	expiration *int64
+	subject    string
+	schemaHash string
+	indexHash  *string
+	row        string
+}
+
+// Claim is an archived claim, with the row it had in the claims table as json
+type Claim struct {
+	ID      uuid.UUID
+	Revoked bool
+	Row     []byte
+}
+
+// Archive moves the revoked and expired claims older than the age into the archived_claims table, compressed. Only
+// their identity, subject, schema hash, index hash, revocation nonce, revoked flag and expiration are kept
+// uncompressed, so the nonces are still unique, the archived claims can still be revoked and the claims of a subject
+// can still be exported and erased, while the claims table only keeps the claims in use.
+// The revocation status of the archived claims is served from the revocation tree as before. Each batch is moved
+// in its own transaction, and in dry run mode the claims are only counted.
+func Archive(ctx context.Context, storage *db.Storage, opts Options) (*Report, error) {
+	cutoff := time.Now().UTC().Add(-opts.Age)
+	report := &Report{DryRun: opts.DryRun, Cutoff: &cutoff}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	if !opts.DryRun {
+		if err := FillSubjects(ctx, storage.Pgx, opts.Identifier); err != nil {
+			return nil, fmt.Errorf("filling the subjects of the archived claims: %w", err)
+		}
+	}
+
+	for {
+		moved, err := archiveBatch(ctx, storage, opts, cutoff, batchSize, report)
+		if err != nil {
+			return nil, fmt.Errorf("archiving claims: %w", err)
+		}
+		log.Info(ctx, "claims archived", "claims", moved, "identifier", opts.Identifier, "dryRun", opts.DryRun)
+		// a dry run moves nothing, so the next batch would select the same claims
+		if moved < batchSize || opts.DryRun {
+			return report, nil
+		}
+	}
+}
+
+func archiveBatch(ctx context.Context, storage *db.Storage, opts Options, cutoff time.Time, batchSize int, report *Report) (int, error) {
+	tx, err := storage.Pgx.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	count := func(c candidate) ([]byte, error) {
+		compressed, err := compress([]byte(c.row))
+		if err != nil {
+			return nil, fmt.Errorf("compressing claim %s: %w", c.id, err)
+		}
+		report.Claims++
+		report.Bytes += int64(len(c.row))
+		report.CompressedBytes += int64(len(compressed))
+		return compressed, nil
+	}
+
+	// a dry run counts every candidate in one pass, without locking them
+	if opts.DryRun {
+		counted := 0
+		err := scanCandidates(ctx, tx, candidatesSQL, []any{cutoff, opts.Identifier, authClaimTypes}, func(c candidate) error {
+			counted++
+			_, err := count(c)
+			return err
+		})
+		return counted, err
+	}
+
+	if err := db.SetCredentialEvent(ctx, tx, domain.CredentialEventArchived); err != nil {
+		return 0, err
+	}
+	var candidates []candidate
+	err = scanCandidates(ctx, tx, candidatesSQL+"\nLIMIT $4\nFOR UPDATE OF c SKIP LOCKED", []any{cutoff, opts.Identifier, authClaimTypes, batchSize}, func(c candidate) error {
+		candidates = append(candidates, c)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, c := range candidates {
+		compressed, err := count(c)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(ctx, `INSERT INTO archived_claims (id, identifier, schema_url, rev_nonce, revoked, expiration, claim, subject, schema_hash, index_hash)
+			VALUES ($1, $2, $3, $4::numeric, $5, $6, $7, $8, $9, $10)`,
+			c.id, c.identifier, c.schemaURL, c.revNonce, c.revoked, c.expiration, compressed, c.subject, c.schemaHash, c.indexHash); err != nil {
+			return 0, fmt.Errorf("archiving claim %s: %w", c.id, err)
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM claims WHERE id = $1 AND identifier = $2`, c.id, c.identifier); err != nil {
+			return 0, fmt.Errorf("deleting archived claim %s: %w", c.id, err)
+		}
+	}
+	return len(candidates), tx.Commit(ctx)
+}
+
+func scanCandidates(ctx context.Context, tx pgx.Tx, query string, args []any, fn func(c candidate) error) error {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c candidate
+		if err := rows.Scan(&c.id, &c.identifier, &c.schemaURL, &c.revNonce, &c.revoked, &c.expiration, &c.subject, &c.schemaHash, &c.indexHash, &c.row); err != nil {
+			return err
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Restore moves archived claims back to the claims table, with their revoked flag updated with the revocations
+// done while they were archived. Each batch is moved in its own transaction.
+func Restore(ctx context.Context, storage *db.Storage, opts RestoreOptions) (*Report, error) {
+	report := &Report{}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	for {
+		moved, err := restoreBatch(ctx, storage, opts, batchSize, report)
+		if err != nil {
+			return nil, fmt.Errorf("restoring claims: %w", err)
+		}
+		log.Info(ctx, "claims restored", "claims", moved, "identifier", opts.Identifier)
+		if moved < batchSize {
+			break
+		}
+	}
+	if opts.ID != nil && report.Claims == 0 {
+		return nil, ErrNotArchived
+	}
+	return report, nil
+}
+
+func restoreBatch(ctx context.Context, storage *db.Storage, opts RestoreOptions, batchSize int, report *Report) (int, error) {
+	tx, err := storage.Pgx.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := db.SetCredentialEvent(ctx, tx, domain.CredentialEventRestored); err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.Query(ctx, `SELECT id, identifier, revoked, claim FROM archived_claims
+		WHERE ($1 = '' OR identifier = $1) AND ($2::uuid IS NULL OR id = $2)
+		ORDER BY id
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED`, opts.Identifier, opts.ID, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	type archived struct {
+		id         uuid.UUID
+		identifier string
+		revoked    bool
+		claim      []byte
+	}
+	var batch []archived
+	for rows.Next() {
+		var a archived
+		if err := rows.Scan(&a.id, &a.identifier, &a.revoked, &a.claim); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		batch = append(batch, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, a := range batch {
+		row, err := decompress(a.claim)
+		if err != nil {
+			return 0, fmt.Errorf("decompressing claim %s: %w", a.id, err)
+		}
+		// json_populate_record fills the columns of the claims table as they are now, the ones added after the claim
+		// was archived are null. The reference to the replaced claim is dropped when that claim is not in the table.
+		if _, err := tx.Exec(ctx, `INSERT INTO claims SELECT (json_populate_record(NULL::claims,
+			(CASE WHEN EXISTS (SELECT 1 FROM claims WHERE id = ($1::jsonb->>'replaces_id')::uuid) THEN $1::jsonb
+			ELSE $1::jsonb - 'replaces_id' END)::json)).*`, string(row)); err != nil {
+			return 0, fmt.Errorf("restoring claim %s: %w", a.id, err)
+		}
+		if a.revoked {
+			if _, err := tx.Exec(ctx, `UPDATE claims SET revoked = true WHERE id = $1 AND identifier = $2`, a.id, a.identifier); err != nil {
+				return 0, fmt.Errorf("revoking restored claim %s: %w", a.id, err)
+			}
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM archived_claims WHERE id = $1`, a.id); err != nil {
+			return 0, fmt.Errorf("deleting restored claim %s: %w", a.id, err)
+		}
+		report.Claims++
+		report.Bytes += int64(len(row))
+		report.CompressedBytes += int64(len(a.claim))
+	}
+	return len(batch), tx.Commit(ctx)
+}
+
+// FillSubjects extracts the subject, schema hash and index hash of the claims archived before they were kept
+// uncompressed, and the references to their offloaded payloads. identifier limits it to the claims of one identity,
+// empty fills the claims of every identity.
+func FillSubjects(ctx context.Context, conn db.Querier, identifier string) error {
+	rows, err := conn.Query(ctx, `SELECT id, claim FROM archived_claims WHERE subject IS NULL AND ($1 = '' OR identifier = $1)`, identifier)
+	if err != nil {
+		return err
+	}
+	var batch []Claim
+	for rows.Next() {
+		var a Claim
+		if err := rows.Scan(&a.ID, &a.Row); err != nil {
+			rows.Close()
+			return err
+		}
+		batch = append(batch, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, a := range batch {
+		row, err := decompress(a.Row)
+		if err != nil {
+			return fmt.Errorf("decompressing claim %s: %w", a.ID, err)
+		}
+		var fields struct {
+			Subject    *string `json:"other_identifier"`
+			SchemaHash string  `json:"schema_hash"`
+			IndexHash  *string `json:"index_hash"`
+			Data       struct {
+				CredentialSubject json.RawMessage `json:"credentialSubject"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(row, &fields); err != nil {
+			return fmt.Errorf("reading claim %s: %w", a.ID, err)
+		}
+		var attributes map[string]struct {
+			Ref string `json:"$payloadRef"`
+		}
+		_ = json.Unmarshal(fields.Data.CredentialSubject, &attributes) // attributes that aren't objects have no ref
+		for _, attribute := range attributes {
+			if attribute.Ref == "" {
+				continue
+			}
+			if _, err := conn.Exec(ctx, `INSERT INTO payloads (key) VALUES ($1) ON CONFLICT DO NOTHING`, attribute.Ref); err != nil {
+				return fmt.Errorf("keeping the payload of claim %s: %w", a.ID, err)
+			}
+			if _, err := conn.Exec(ctx, `INSERT INTO claim_payloads (claim_id, payload_key) VALUES ($1, $2) ON CONFLICT DO NOTHING`, a.ID, attribute.Ref); err != nil {
+				return fmt.Errorf("referencing the payload of claim %s: %w", a.ID, err)
+			}
+		}
+		subject := ""
+		if fields.Subject != nil {
+			subject = *fields.Subject
+		}
+		if _, err := conn.Exec(ctx, `UPDATE archived_claims SET subject = $2, schema_hash = $3, index_hash = $4 WHERE id = $1`,
+			a.ID, subject, fields.SchemaHash, fields.IndexHash); err != nil {
+			return fmt.Errorf("filling the subject of claim %s: %w", a.ID, err)
+		}
+	}
+	return nil
+}
+
+// SubjectClaims returns the claims of the subject archived by the identity, decompressed
+func SubjectClaims(ctx context.Context, conn db.Querier, identifier string, subject string) ([]Claim, error) {
+	rows, err := conn.Query(ctx, `SELECT id, revoked, claim FROM archived_claims WHERE identifier = $1 AND subject = $2 ORDER BY id`, identifier, subject)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	claims := make([]Claim, 0)
+	for rows.Next() {
+		var a Claim
+		if err := rows.Scan(&a.ID, &a.Revoked, &a.Row); err != nil {
+			return nil, err
+		}
+		if a.Row, err = decompress(a.Row); err != nil {
+			return nil, fmt.Errorf("decompressing claim %s: %w", a.ID, err)
+		}
+		claims = append(claims, a)
+	}
+	return claims, rows.Err()
+}
+
+func compress(raw []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(raw); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(compressed []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+	return io.ReadAll(r)
+}
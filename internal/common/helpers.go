@@ -7,6 +7,15 @@ func ToPointer[T any](p T) *T {
 	return &p
 }
 
+// FromPointer returns the value of p, the zero value when it is nil
+func FromPointer[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
 // CopyMap returns a deep copy of the input map
 func CopyMap(m map[string]interface{}) map[string]interface{} {
 	cp := make(map[string]interface{})
@@ -88,6 +88,11 @@ func RandInt64() (uint64, error) {
 	return binary.LittleEndian.Uint64(buf[:]), err
 }
 
+// DIDNetwork returns the blockchain:network of the did, like polygon:amoy
+func DIDNetwork(did *core.DID) string {
+	return fmt.Sprintf("%s:%s", did.Blockchain, did.NetworkID)
+}
+
 // CheckGenesisStateDID return nil if state is genesis state.
 func CheckGenesisStateDID(did *core.DID, state *big.Int) error {
 	stateHash, err := merkletree.NewHashFromBigInt(state)
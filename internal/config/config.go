@@ -3,53 +3,364 @@ package config
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
 	core "github.com/iden3/go-iden3-core"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 
 	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/pkg/credentials/credentialid"
+	"github.com/polygonid/sh-id-platform/pkg/oidc"
 )
 
 // CIConfigPath variable contain the CI configuration path
 const CIConfigPath = "/home/runner/work/sh-id-platform/sh-id-platform/"
 
+const (
+	// CacheRedis keeps the cache in redis and sends the events through its pubsub
+	CacheRedis = "redis"
+	// CachePostgres keeps the cache in the database and sends the events with LISTEN/NOTIFY, so redis is not needed
+	CachePostgres = "postgres"
+)
+
+const (
+	// ThrottleMemory keeps the rate limits in the memory of each server
+	ThrottleMemory = "memory"
+	// ThrottlePostgres keeps the rate limits in the database, shared by every server
+	ThrottlePostgres = "postgres"
+)
+
+const (
+	// SessionStoreRedis keeps the qr code payloads in the redis cache
+	SessionStoreRedis = "redis"
+	// SessionStorePostgres keeps the qr code payloads in the database
+	SessionStorePostgres = "postgres"
+)
+
+const (
+	// SigningKeySelectionDefault signs the credentials with the first active signing key of the identity
+	SigningKeySelectionDefault = "default"
+	// SigningKeySelectionRoundRobin signs the credentials with each active signing key of the identity in turn
+	SigningKeySelectionRoundRobin = "roundRobin"
+)
+
+const (
+	// KeyStoreVault keeps the keys in HashiCorp Vault, with the iden3 plugin
+	KeyStoreVault = "vault"
+	// KeyStoreAWS keeps the Ethereum keys in AWS KMS and the BabyJubJub keys in AWS Secrets Manager
+	KeyStoreAWS = "aws"
+	// KeyStoreGCP keeps the Ethereum keys in Google Cloud KMS and the BabyJubJub keys in Google Secret Manager
+	KeyStoreGCP = "gcp"
+	// KeyStoreAzure keeps the Ethereum keys and the BabyJubJub keys in Azure Key Vault
+	KeyStoreAzure = "azure"
+	// KeyStoreFile keeps the keys in a local file encrypted with a passphrase, only for development
+	KeyStoreFile = "file"
+)
+
 // Configuration holds the project configuration
 type Configuration struct {
 	ServerUrl                    string
 	ServerPort                   int
+	ServerAddress                string `mapstructure:"ServerAddress" tip:"Comma separated IPv4 or IPv6 addresses the API listens on. Empty listens on every interface"`
 	NativeProofGenerationEnabled bool
-	Database                     Database           `mapstructure:"Database"`
-	Cache                        Cache              `mapstructure:"Cache"`
-	HTTPBasicAuth                HTTPBasicAuth      `mapstructure:"HTTPBasicAuth"`
-	KeyStore                     KeyStore           `mapstructure:"KeyStore"`
-	Log                          Log                `mapstructure:"Log"`
-	ReverseHashService           ReverseHashService `mapstructure:"ReverseHashService"`
-	Ethereum                     Ethereum           `mapstructure:"Ethereum"`
-	Prover                       Prover             `mapstructure:"Prover"`
-	Circuit                      Circuit            `mapstructure:"Circuit"`
-	PublishingKeyPath            string             `mapstructure:"PublishingKeyPath"`
-	OnChainCheckStatusFrequency  time.Duration      `mapstructure:"OnChainCheckStatusFrequency"`
-	SchemaCache                  *bool              `mapstructure:"SchemaCache"`
-	APIUI                        APIUI              `mapstructure:"APIUI"`
+	Sandbox                      bool
+	Database                     Database            `mapstructure:"Database"`
+	Cache                        Cache               `mapstructure:"Cache"`
+	SessionStore                 SessionStore        `mapstructure:"SessionStore"`
+	PayloadStore                 PayloadStore        `mapstructure:"PayloadStore"`
+	EventSink                    EventSink           `mapstructure:"EventSink"`
+	TrustRegistry                TrustRegistry       `mapstructure:"TrustRegistry"`
+	PolicyHook                   PolicyHook          `mapstructure:"PolicyHook"`
+	VerificationWebhook          VerificationWebhook `mapstructure:"VerificationWebhook"`
+	SchemaWebhooks               SchemaWebhooks      `mapstructure:"SchemaWebhooks"`
+	StateCache                   StateCache          `mapstructure:"StateCache"`
+	PushGateways                 PushGateways        `mapstructure:"PushGateways"`
+	Alerts                       Alerts              `mapstructure:"Alerts"`
+	Outbound                     Outbound            `mapstructure:"Outbound"`
+	HookCaptureSize              int                 `mapstructure:"HookCaptureSize" tip:"Number of calls to the post-issuance hooks kept per issuer for inspection. Zero disables it"`
+	AgentReplayWindow            time.Duration       `mapstructure:"AgentReplayWindow" tip:"How long an agent message is remembered to reject its replays. A negative value disables it"`
+	ClockSkew                    time.Duration       `mapstructure:"ClockSkew" tip:"Tolerated difference between the clock of this node and the timestamps of the wallet proofs. A negative value disables it"`
+	SigningKeySelection          string              `mapstructure:"SigningKeySelection" tip:"How the credentials that don't ask for a signing key pick one: default or roundRobin"`
+	CredentialIDs                CredentialIDs       `mapstructure:"CredentialIDs"`
+	HTTPBasicAuth                HTTPBasicAuth       `mapstructure:"HTTPBasicAuth"`
+	OIDC                         OIDC                `mapstructure:"OIDC"`
+	RateLimit                    RateLimit           `mapstructure:"RateLimit"`
+	CORS                         CORS                `mapstructure:"CORS" tip:"CORS of the API"`
+	SecurityHeaders              SecurityHeaders     `mapstructure:"SecurityHeaders" tip:"Security headers of the API responses"`
+	KeyStore                     KeyStore            `mapstructure:"KeyStore"`
+	Log                          Log                 `mapstructure:"Log"`
+	ReverseHashService           ReverseHashService  `mapstructure:"ReverseHashService"`
+	Ethereum                     Ethereum            `mapstructure:"Ethereum"`
+	NetworksFile                 string              `mapstructure:"NetworksFile" tip:"YAML file with the ethereum and reverse hash service settings of each network the identities are published on. Empty publishes on the network of the resolver prefix"`
+	Prover                       Prover              `mapstructure:"Prover"`
+	Circuit                      Circuit             `mapstructure:"Circuit"`
+	Protocol                     Protocol            `mapstructure:"Protocol"`
+	PublishingKeyPath            string              `mapstructure:"PublishingKeyPath"`
+	OnChainCheckStatusFrequency  time.Duration       `mapstructure:"OnChainCheckStatusFrequency"`
+	StartupTimeout               time.Duration       `mapstructure:"StartupTimeout" tip:"How long to keep retrying the connections to the dependencies on startup"`
+	Timeouts                     Timeouts            `mapstructure:"Timeouts"`
+	SchemaCache                  *bool               `mapstructure:"SchemaCache"`
+	SchemaCacheTTL               time.Duration       `mapstructure:"SchemaCacheTTL" tip:"How long the schemas stay in the cache, forever when it is 0"`
+	IPFS                         IPFS                `mapstructure:"IPFS"`
+	SchemaBundle                 SchemaBundle        `mapstructure:"SchemaBundle"`
+	SchemaLoader                 SchemaLoader        `mapstructure:"SchemaLoader"`
+	JSONLD                       JSONLD              `mapstructure:"JSONLD"`
+	Maintenance                  Maintenance         `mapstructure:"Maintenance"`
+	Region                       Region              `mapstructure:"Region"`
+	Quotas                       Quotas              `mapstructure:"Quotas"`
+	Ingest                       Ingest              `mapstructure:"Ingest"`
+	Branding                     Branding            `mapstructure:"Branding"`
+	APIUI                        APIUI               `mapstructure:"APIUI"`
+}
+
+// IPFS configures how the node publishes and fetches the documents of IPFS, the ipfs:// schemas and JSON-LD contexts.
+// The documents are fetched through the HTTP gateway when it is set, and through the node otherwise.
+type IPFS struct {
+	URL         string `mapstructure:"URL" tip:"HTTP API of the IPFS node where the schemas are published and pinned, like http://localhost:5001"`
+	GatewayURL  string `mapstructure:"GatewayURL" tip:"HTTP gateway the ipfs:// documents are fetched from, like https://ipfs.io"`
+	PinImported bool   `mapstructure:"PinImported" tip:"Pin the ipfs:// json schemas and JSON-LD contexts of the imported schemas in the IPFS node"`
+}
+
+// SchemaBundle configures the local copies of the json schemas and JSON-LD contexts, for the deployments without
+// outbound internet. The copy of an url is in the directory under its host and path, like
+// raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json.
+type SchemaBundle struct {
+	Dir   string `mapstructure:"Dir" tip:"Directory with the local copies of the schemas. Empty disables them"`
+	Order string `mapstructure:"Order" tip:"When the local copies are used: local-first, remote-first or local-only"`
+}
+
+// SchemaLoader configures the retries, the circuit breakers and the mirrors of the schema and JSON-LD context
+// downloads. Each download attempt is bounded by Timeouts.SchemaLoader.
+type SchemaLoader struct {
+	Mirrors         string        `mapstructure:"Mirrors" tip:"Comma separated url prefixes and their mirrors, like https://raw.githubusercontent.com/=https://mirror.internal/github/"`
+	Attempts        int           `mapstructure:"Attempts" tip:"Attempts of each source of a download"`
+	Backoff         time.Duration `mapstructure:"Backoff" tip:"Wait before the second attempt, doubled for each next one"`
+	MaxBackoff      time.Duration `mapstructure:"MaxBackoff" tip:"Maximum wait between attempts"`
+	BreakerFailures int           `mapstructure:"BreakerFailures" tip:"Consecutive failed downloads that disable a source. 0 never disables them"`
+	BreakerCooldown time.Duration `mapstructure:"BreakerCooldown" tip:"How long a source stays disabled"`
+}
+
+// JSONLD configures the loader of the JSON-LD contexts of the merklization and the canonicalization of the
+// credentials, with the snapshot of the core W3C and iden3 contexts embedded in the binaries
+type JSONLD struct {
+	Contexts string `mapstructure:"Contexts" tip:"When the embedded contexts are used: remote, local-first, remote-first or local-only"`
+	SafeMode *bool  `mapstructure:"SafeMode" tip:"Reject the contexts whose terms would be dropped from the credentials"`
+}
+
+// Maintenance configures the maintenance mode, that the operators switch on with the issuer API
+type Maintenance struct {
+	RetryAfter time.Duration `mapstructure:"RetryAfter" tip:"Default Retry-After of the requests rejected in maintenance mode"`
+	Refresh    time.Duration `mapstructure:"Refresh" tip:"How long a server keeps the maintenance mode before reading it again"`
+}
+
+// Region configures the active/passive deployment of the node in several regions. The servers of the passive regions
+// read from a replica of the database of the active one, and serve the reads, the agent and the credential checks.
+type Region struct {
+	Name     string        `mapstructure:"Name" tip:"Name of the region of the server, like eu-west. Empty runs the node in a single region"`
+	LeaseTTL time.Duration `mapstructure:"LeaseTTL" tip:"How long the active region keeps the lease after its last renewal"`
+	Refresh  time.Duration `mapstructure:"Refresh" tip:"How often the servers renew the lease, and how long they keep the role of the region before reading it again"`
+}
+
+// Quotas are the default limits of the resources of each identity, that the issuer API can override per identity.
+// Zero is unlimited.
+type Quotas struct {
+	MaxActiveLinks       int `mapstructure:"MaxActiveLinks" tip:"Maximum links of an identity that can issue credentials"`
+	MaxCredentialsPerDay int `mapstructure:"MaxCredentialsPerDay" tip:"Maximum credentials an identity can issue per UTC day"`
+	MaxSchemas           int `mapstructure:"MaxSchemas" tip:"Maximum schemas an identity can import or create"`
+}
+
+// Ingest configures the issuance of the credential requests streamed to the issuer API
+type Ingest struct {
+	Workers    int `mapstructure:"Workers" tip:"Credentials of the streams issued at the same time"`
+	QueueDepth int `mapstructure:"QueueDepth" tip:"Credential requests of the streams waiting for a worker. The streams are not read while the queue is full"`
+}
+
+// Branding is how the identities are presented to the holders in the credential offers, the credential cards and the
+// issuer metadata, that the issuer API can override per identity
+type Branding struct {
+	DisplayName  string `mapstructure:"DisplayName" tip:"Issuer name shown to the holders, ISSUER_API_UI_ISSUER_NAME when empty"`
+	LogoURL      string `mapstructure:"LogoURL" tip:"Issuer logo (URL) shown to the holders, ISSUER_API_UI_ISSUER_LOGO when empty"`
+	PrimaryColor string `mapstructure:"PrimaryColor" tip:"Background color of the credential cards, like #6c4ce0"`
+	TextColor    string `mapstructure:"TextColor" tip:"Text color of the credential cards, like #ffffff"`
 }
 
 // Database has the database configuration
 // URL: The database connection string
+// ReplicaURL: The connection string of a read-only replica for the listings. Empty reads from URL.
+// Pool: The size of the connection pools of the primary and the replica
 type Database struct {
-	URL string `mapstructure:"Url" tip:"The Datasource name locator"`
+	URL        string       `mapstructure:"Url" tip:"The Datasource name locator"`
+	ReplicaURL string       `mapstructure:"ReplicaUrl" tip:"The Datasource name locator of a read-only replica used by the listings"`
+	Pool       DatabasePool `mapstructure:"Pool"`
+}
+
+// DatabasePool sizes the connection pool of each database. A zero value keeps the pgx default, or the pool_* parameter
+// of the connection string.
+type DatabasePool struct {
+	MaxConns          int32         `mapstructure:"MaxConns" tip:"Maximum number of connections, the greater of 4 and the number of CPUs by default"`
+	MinConns          int32         `mapstructure:"MinConns" tip:"Number of connections kept open when idle, 0 by default"`
+	MaxConnLifetime   time.Duration `mapstructure:"MaxConnLifetime" tip:"Age after which a connection is closed, 1h by default"`
+	HealthCheckPeriod time.Duration `mapstructure:"HealthCheckPeriod" tip:"Period of the checks of the idle connections, 1m by default"`
 }
 
-// Cache configurations
+// Timeouts bounds the time each layer can spend on behalf of a request. A zero value disables the timeout.
+// Calls to the ethereum node are bounded by Ethereum.RPCResponseTimeout.
+type Timeouts struct {
+	Request      time.Duration `mapstructure:"Request" tip:"Maximum duration of an API request"`
+	Database     time.Duration `mapstructure:"Database" tip:"Maximum duration of a database statement"`
+	KeyStore     time.Duration `mapstructure:"KeyStore" tip:"Maximum duration of a call to the key store"`
+	SchemaLoader time.Duration `mapstructure:"SchemaLoader" tip:"Maximum duration of each attempt of a schema or JSON-LD context download"`
+}
+
+// CORS configures the cross-origin requests of a server, needed when the UI is served from another domain than the
+// API. The lists are comma separated, and * allows any origin or header.
+type CORS struct {
+	AllowedOrigins   string        `mapstructure:"AllowedOrigins" tip:"Comma separated origins allowed to call the server, like https://ui.example.com, * by default"`
+	AllowedMethods   string        `mapstructure:"AllowedMethods" tip:"Comma separated methods of the cross-origin requests"`
+	AllowedHeaders   string        `mapstructure:"AllowedHeaders" tip:"Comma separated headers of the cross-origin requests, * by default"`
+	ExposedHeaders   string        `mapstructure:"ExposedHeaders" tip:"Comma separated response headers the browsers let the UI read"`
+	AllowCredentials bool          `mapstructure:"AllowCredentials" tip:"Allow the cross-origin requests with cookies or basic auth credentials. The origins can't be * then"`
+	MaxAge           time.Duration `mapstructure:"MaxAge" tip:"How long the browsers cache the preflight responses"`
+}
+
+// SecurityHeaders configures the security headers added to every response of a server. X-Content-Type-Options is
+// always nosniff.
+type SecurityHeaders struct {
+	HSTSMaxAge            time.Duration `mapstructure:"HSTSMaxAge" tip:"Max age of the Strict-Transport-Security header. Zero doesn't send it"`
+	HSTSIncludeSubdomains bool          `mapstructure:"HSTSIncludeSubdomains" tip:"Apply the Strict-Transport-Security to the subdomains"`
+	FrameOptions          string        `mapstructure:"FrameOptions" tip:"X-Frame-Options header: DENY or SAMEORIGIN, DENY by default"`
+	ReferrerPolicy        string        `mapstructure:"ReferrerPolicy" tip:"Referrer-Policy header, no-referrer by default"`
+}
+
+// Cache configurations. The postgres backend runs the node without redis: the cache entries and the events go through
+// the database, and the expired entries are removed every CleanupPeriod.
 type Cache struct {
-	RedisUrl string `mapstructure:"RedisUrl" tip:"The redis url to use as a cache"`
+	Backend       string        `mapstructure:"Backend" tip:"Where the cache and the events are kept: redis or postgres"`
+	RedisUrl      string        `mapstructure:"RedisUrl" tip:"The redis url to use as a cache"`
+	CleanupPeriod time.Duration `mapstructure:"CleanupPeriod" tip:"How often the expired cache entries, rate limits and agent messages are removed from the database"`
+}
+
+// SessionStore configures where the qr code payloads are kept until the wallets read them: the authorization
+// requests and the link offers.
+type SessionStore struct {
+	Backend        string        `mapstructure:"Backend" tip:"Where the qr code payloads are stored: redis or postgres"`
+	AuthRequestTTL time.Duration `mapstructure:"AuthRequestTTL" tip:"How long an authorization request qr code can be scanned"`
+	LinkStateTTL   time.Duration `mapstructure:"LinkStateTTL" tip:"How long the offer of a link can be fetched after the wallet authenticates"`
+}
+
+// PayloadStore configures where the large credentialSubject attributes are kept instead of the claims table.
+// Without a backend every credential is kept in the claims table.
+type PayloadStore struct {
+	Backend       string        `mapstructure:"Backend" tip:"Where the large credential attributes are stored: file or http. Empty keeps them in the database"`
+	URL           string        `mapstructure:"URL" tip:"Directory of the file backend or base url of the http backend"`
+	Authorization string        `mapstructure:"Authorization" tip:"Authorization header of the http backend requests"`
+	Threshold     int           `mapstructure:"Threshold" tip:"Size in bytes from which a credential attribute is moved to the payload store"`
+	CleanupPeriod time.Duration `mapstructure:"CleanupPeriod" tip:"How often the payloads no credential references are deleted from the payload store"`
+}
+
+// EventSink configures the cloud queue or topic that receives the issuer events, besides the redis subscribers.
+// Without a backend the events are not forwarded.
+type EventSink struct {
+	Backend         string `mapstructure:"Backend" tip:"Cloud messaging service that receives the issuer events: sqs, sns, pubsub or servicebus. Empty disables it"`
+	Destination     string `mapstructure:"Destination" tip:"SQS queue url, SNS topic ARN, Pub/Sub topic (projects/<project>/topics/<topic>) or Service Bus queue or topic url"`
+	Region          string `mapstructure:"Region" tip:"AWS region of the SQS queue or SNS topic"`
+	KeyID           string `mapstructure:"KeyID" tip:"AWS access key id or Service Bus shared access key name"`
+	Key             string `mapstructure:"Key" tip:"AWS secret access key or Service Bus shared access key"`
+	CredentialsFile string `mapstructure:"CredentialsFile" tip:"Google service account key file of the Pub/Sub publisher"`
+}
+
+// TrustRegistry configures the registry asked whether the issuer of a credential is accredited for its type when
+// the credential validity is checked. Without a backend the accreditation is not checked.
+type TrustRegistry struct {
+	Backend       string        `mapstructure:"Backend" tip:"Kind of trust registry: http or ebsi. Empty disables it"`
+	URL           string        `mapstructure:"URL" tip:"Base url of the trust registry api"`
+	Authorization string        `mapstructure:"Authorization" tip:"Authorization header of the trust registry requests"`
+	CacheTTL      time.Duration `mapstructure:"CacheTTL" tip:"How long the answers of the trust registry are cached"`
+}
+
+// PolicyHook configures the service that approves, denies or changes every credential before it is issued.
+// Without a URL the credentials are issued without a policy.
+type PolicyHook struct {
+	URL           string        `mapstructure:"URL" tip:"Endpoint the issuance requests are POSTed to. Empty disables it"`
+	Authorization string        `mapstructure:"Authorization" tip:"Authorization header of the policy hook requests"`
+	Timeout       time.Duration `mapstructure:"Timeout" tip:"Maximum duration of a policy hook request"`
+	FailOpen      bool          `mapstructure:"FailOpen" tip:"Issue the credentials as requested when the policy hook fails"`
+}
+
+// VerificationWebhook configures the endpoint the notifications service POSTs the result of every proof verification
+// to. Without a URL the results are only kept in the verification history.
+type VerificationWebhook struct {
+	URL           string        `mapstructure:"URL" tip:"Endpoint the verification results are POSTed to. Empty disables it"`
+	Authorization string        `mapstructure:"Authorization" tip:"Authorization header of the verification webhook requests"`
+	Timeout       time.Duration `mapstructure:"Timeout" tip:"Maximum duration of a verification webhook request"`
+}
+
+// SchemaWebhooks configures the calls of the notifications service to the webhooks the issuers register for the
+// credentials of a schema type
+type SchemaWebhooks struct {
+	Timeout time.Duration `mapstructure:"Timeout" tip:"Maximum duration of a schema webhook request"`
+}
+
+// StateCache configures the cache of the identity states and global state roots resolved by the verifications. An
+// answer that a state is the latest one is served for TTL, and for StaleTTL more while it is refreshed.
+type StateCache struct {
+	TTL      time.Duration `mapstructure:"TTL" tip:"How long a latest state is served without asking the chain. A negative value disables the cache"`
+	StaleTTL time.Duration `mapstructure:"StaleTTL" tip:"How long after the TTL a latest state is still served while it is refreshed in the background"`
+}
+
+// PushGateways is a group of push gateways that can replace each other, like the replicas of the push gateway of a
+// wallet. The pushes to the devices whose DID document points to one of them fail over to the others.
+type PushGateways struct {
+	URLs              string        `mapstructure:"URLs" tip:"Comma separated urls of the push gateways of the group, as in the DID documents. Empty disables the failover"`
+	HealthCheckPeriod time.Duration `mapstructure:"HealthCheckPeriod" tip:"How often the push gateways are checked"`
+}
+
+// Alerts configures the notifications to the operators about the background work that failed: the state transitions
+// that failed, the RHS that doesn't have the published states, the key store that can't be reached and the backlogs
+// of unpublished work. They are checked by the pending publisher and sent by email and to a webhook.
+type Alerts struct {
+	Period               time.Duration `mapstructure:"Period" tip:"How often the alerts are checked. A negative value disables them"`
+	Repeat               time.Duration `mapstructure:"Repeat" tip:"How often an alert that keeps firing is sent again"`
+	BacklogSize          int           `mapstructure:"BacklogSize" tip:"Number of credentials of an issuer waiting to be published that raises an alert"`
+	BacklogAge           time.Duration `mapstructure:"BacklogAge" tip:"Age of a state transaction still not confirmed that raises an alert"`
+	WebhookURL           string        `mapstructure:"WebhookURL" tip:"Url the alerts are posted to. Empty disables it"`
+	WebhookAuthorization string        `mapstructure:"WebhookAuthorization" tip:"Authorization header of the posts to the webhook"`
+	Timeout              time.Duration `mapstructure:"Timeout" tip:"Timeout of the posts to the webhook"`
+	SMTPAddress          string        `mapstructure:"SMTPAddress" tip:"host:port of the SMTP server the alert emails are sent through. Empty disables them"`
+	SMTPUser             string        `mapstructure:"SMTPUser" tip:"User of the SMTP server"`
+	SMTPPassword         string        `mapstructure:"SMTPPassword" tip:"Password of the SMTP server"`
+	EmailFrom            string        `mapstructure:"EmailFrom" tip:"Sender of the alert emails"`
+	EmailTo              string        `mapstructure:"EmailTo" tip:"Comma separated recipients of the alert emails"`
+}
+
+// Recipients returns the recipients of the alert emails
+func (a Alerts) Recipients() []string {
+	var recipients []string
+	for _, to := range strings.Split(a.EmailTo, ",") {
+		if to = strings.TrimSpace(to); to != "" {
+			recipients = append(recipients, to)
+		}
+	}
+	return recipients
+}
+
+// Outbound configures the proxy and the certificate authorities of the requests the issuer sends to other services.
+// The HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored without it.
+type Outbound struct {
+	ProxyURL string `mapstructure:"ProxyURL" tip:"Proxy of the outbound requests, replaces HTTP_PROXY and HTTPS_PROXY"`
+	NoProxy  string `mapstructure:"NoProxy" tip:"Comma separated hosts reached without the proxy, replaces NO_PROXY"`
+	CABundle string `mapstructure:"CABundle" tip:"PEM file with the certificate authorities trusted besides the ones of the system"`
 }
 
 // ReverseHashService contains the reverse hash service properties
@@ -74,6 +385,43 @@ type Ethereum struct {
 	ResolverPrefix         string        `tip:"blockchain:network e.g polygon:mumbai"`
 }
 
+// Network is the ethereum connection and the reverse hash service of a network the identities are published on
+type Network struct {
+	Ethereum           Ethereum
+	ReverseHashService ReverseHashService
+	// ChainID is the chain id the ethereum node must be on. Zero doesn't check it.
+	ChainID int64
+	// DIDMethods are the network bytes of the DIDs of the network by DID method, for the networks go-iden3-core
+	// doesn't know, like private chains
+	DIDMethods map[string]byte
+}
+
+// Networks are the networks the identities are published on, keyed by blockchain:network like polygon:amoy
+type Networks map[string]Network
+
+// RHSUrls returns the reverse hash service url of each network
+func (n Networks) RHSUrls() map[string]string {
+	urls := make(map[string]string, len(n))
+	for key, network := range n {
+		urls[key] = network.ReverseHashService.URL
+	}
+	return urls
+}
+
+// networkSettings are the settings of a network in the networks file. The ones that are not set are taken from
+// Ethereum and ReverseHashService.
+type networkSettings struct {
+	URL                    string           `yaml:"url"`
+	ContractAddress        string           `yaml:"contractAddress"`
+	DefaultGasLimit        int              `yaml:"defaultGasLimit"`
+	ConfirmationBlockCount int64            `yaml:"confirmationBlockCount"`
+	MinGasPrice            int              `yaml:"minGasPrice"`
+	MaxGasPrice            int              `yaml:"maxGasPrice"`
+	ReverseHashServiceURL  string           `yaml:"reverseHashServiceUrl"`
+	ChainID                int64            `yaml:"chainID"`
+	DIDMethods             map[string]uint8 `yaml:"didMethods"`
+}
+
 // Prover struct
 type Prover struct {
 	ServerURL       string
@@ -85,11 +433,59 @@ type Circuit struct {
 	Path string `tip:"Circuit path"`
 }
 
+// Protocol selects what the node accepts in the incoming iden3comm messages. Empty lists allow everything the node
+// supports.
+type Protocol struct {
+	Circuits []string `mapstructure:"Circuits" tip:"Circuits accepted in zero knowledge packed messages, e.g. authV2"`
+	Packers  []string `mapstructure:"Packers" tip:"Media types of the accepted messages, e.g. application/iden3-zkp-json"`
+}
+
 // KeyStore defines the keystore
 type KeyStore struct {
-	Address              string `tip:"Keystore address"`
-	Token                string `tip:"Token"`
-	PluginIden3MountPath string `tip:"PluginIden3MountPath"`
+	Backend              string        `tip:"Where the keys are kept: vault, aws, gcp, azure or file"`
+	Address              string        `tip:"Keystore address"`
+	Token                string        `tip:"Token"`
+	PluginIden3MountPath string        `tip:"PluginIden3MountPath"`
+	AWS                  KeyStoreAWS   `mapstructure:"AWS"`
+	GCP                  KeyStoreGCP   `mapstructure:"GCP"`
+	Azure                KeyStoreAzure `mapstructure:"Azure"`
+	File                 KeyStoreFile  `mapstructure:"File"`
+}
+
+// KeyStoreAWS configures the keys in AWS, used when the key store backend is aws
+type KeyStoreAWS struct {
+	Region   string `mapstructure:"Region" tip:"AWS region of the KMS keys and the secrets"`
+	KeyID    string `mapstructure:"KeyID" tip:"AWS access key id"`
+	Key      string `mapstructure:"Key" tip:"AWS secret access key"`
+	Prefix   string `mapstructure:"Prefix" tip:"First part of the names of the keys, so several nodes can share an account"`
+	Endpoint string `mapstructure:"Endpoint" tip:"Endpoint of AWS KMS and Secrets Manager, like http://localhost:4566 for localstack. Empty uses the ones of the region"`
+}
+
+// KeyStoreGCP configures the keys in Google Cloud, used when the key store backend is gcp
+type KeyStoreGCP struct {
+	Project         string `mapstructure:"Project" tip:"Google Cloud project of the keys and the secrets"`
+	Location        string `mapstructure:"Location" tip:"Location of the Cloud KMS key ring, like global or europe-west1"`
+	KeyRing         string `mapstructure:"KeyRing" tip:"Cloud KMS key ring of the Ethereum keys"`
+	CredentialsFile string `mapstructure:"CredentialsFile" tip:"Google service account key file. Empty uses the service account of the instance"`
+	Prefix          string `mapstructure:"Prefix" tip:"First part of the names of the keys, so several nodes can share a project"`
+	Endpoint        string `mapstructure:"Endpoint" tip:"Endpoint of Cloud KMS and Secret Manager, for emulators. Empty uses the ones of Google"`
+}
+
+// KeyStoreAzure configures the keys in Azure Key Vault, used when the key store backend is azure
+type KeyStoreAzure struct {
+	VaultURL      string `mapstructure:"VaultURL" tip:"Url of the key vault, like https://issuer.vault.azure.net"`
+	TenantID      string `mapstructure:"TenantID" tip:"Tenant of the service principal"`
+	ClientID      string `mapstructure:"ClientID" tip:"Client id of the service principal, or of the user assigned managed identity"`
+	ClientSecret  string `mapstructure:"ClientSecret" tip:"Client secret of the service principal. Empty uses the managed identity"`
+	Prefix        string `mapstructure:"Prefix" tip:"First part of the key paths and names, so several nodes can share a vault"`
+	AuthorityHost string `mapstructure:"AuthorityHost" tip:"Microsoft Entra endpoint of the sovereign clouds. Empty uses https://login.microsoftonline.com"`
+}
+
+// KeyStoreFile configures the keys in a local file, used when the key store backend is file. It is only meant for
+// development.
+type KeyStoreFile struct {
+	Path       string `mapstructure:"Path" tip:"File of the keys, created when it doesn't exist"`
+	Passphrase string `mapstructure:"Passphrase" tip:"Passphrase that encrypts the private keys of the file"`
 }
 
 // Log holds runtime configurations
@@ -116,21 +512,132 @@ type Log struct {
 type HTTPBasicAuth struct {
 	User     string `mapstructure:"User" tip:"Basic auth username"`
 	Password string `mapstructure:"Password" tip:"Basic auth password"`
+	// PIIUser and PIIPassword also give access to the protected endpoints and return the PII attributes unmasked
+	PIIUser     string `mapstructure:"PIIUser" tip:"Basic auth username that can read the PII attributes"`
+	PIIPassword string `mapstructure:"PIIPassword" tip:"Basic auth password that can read the PII attributes"`
+}
+
+// OIDC protects the issuer API with the JWT bearer tokens of an OpenID Connect identity provider, instead of or
+// besides the basic auth credentials.
+type OIDC struct {
+	Issuer         string `mapstructure:"Issuer" tip:"Issuer of the tokens, the iss claim. Empty disables the bearer tokens"`
+	JWKSURL        string `mapstructure:"JWKSURL" tip:"Url of the keys of the identity provider. Taken from the discovery document of the issuer when empty"`
+	Audience       string `mapstructure:"Audience" tip:"Audience of the tokens, the aud claim"`
+	RequiredClaims string `mapstructure:"RequiredClaims" tip:"Comma separated claims the tokens must have, like groups=issuer-admins"`
+	PIIClaims      string `mapstructure:"PIIClaims" tip:"Comma separated claims of the tokens that can read the PII attributes, like groups=issuer-pii"`
+	AdminClaims    string `mapstructure:"AdminClaims" tip:"Comma separated claims of the tokens with the admin role, like groups=issuer-admins"`
+	OperatorClaims string `mapstructure:"OperatorClaims" tip:"Comma separated claims of the tokens with the issuer-operator role"`
+	ReadOnlyClaims string `mapstructure:"ReadOnlyClaims" tip:"Comma separated claims of the tokens with the read-only role"`
+	AllowBasicAuth bool   `mapstructure:"AllowBasicAuth" tip:"Accept the basic auth credentials besides the bearer tokens"`
+}
+
+// RoleClaims returns the claims of the tokens with each role. The roles without claims are not given, and every token
+// is an admin when there are none.
+func (o OIDC) RoleClaims() (map[domain.Role]map[string]string, error) {
+	roles := make(map[domain.Role]map[string]string)
+	for role, raw := range map[domain.Role]string{
+		domain.RoleAdmin:    o.AdminClaims,
+		domain.RoleOperator: o.OperatorClaims,
+		domain.RoleReadOnly: o.ReadOnlyClaims,
+	} {
+		claims, err := oidc.ParseClaims(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid claims of the %s role: %w", role, err)
+		}
+		if len(claims) > 0 {
+			roles[role] = claims
+		}
+	}
+	return roles, nil
+}
+
+// Enabled tells whether the bearer tokens are accepted
+func (o OIDC) Enabled() bool {
+	return o.Issuer != ""
+}
+
+func (o OIDC) validate() error {
+	if !o.Enabled() {
+		return nil
+	}
+	if o.Audience == "" {
+		return fmt.Errorf("the audience of the tokens is required")
+	}
+	if _, err := oidc.ParseClaims(o.RequiredClaims); err != nil {
+		return fmt.Errorf("invalid required claims: %w", err)
+	}
+	if _, err := oidc.ParseClaims(o.PIIClaims); err != nil {
+		return fmt.Errorf("invalid PII claims: %w", err)
+	}
+	if _, err := o.RoleClaims(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RateLimit limits the requests per minute of each IP to the route groups of the issuer API. A group with 0 is not
+// limited. The limits are shared by the servers through the cache backend, redis or the database.
+type RateLimit struct {
+	Agent      int  `mapstructure:"Agent" tip:"Requests per minute of each IP to the agent endpoint. 0 disables it"`
+	QRCode     int  `mapstructure:"QRCode" tip:"Requests per minute of each IP to the credential qr codes and cards. 0 disables it"`
+	Status     int  `mapstructure:"Status" tip:"Requests per minute of each IP to the revocation status and validity checks. 0 disables it"`
+	Default    int  `mapstructure:"Default" tip:"Requests per minute of each IP to every other endpoint. 0 disables it"`
+	TrustProxy bool `mapstructure:"TrustProxy" tip:"Take the client IP from the X-Forwarded-For header"`
+}
+
+func (r RateLimit) validate() error {
+	if r.Agent < 0 || r.QRCode < 0 || r.Status < 0 || r.Default < 0 {
+		return fmt.Errorf("the rate limits can't be negative")
+	}
+	return nil
+}
+
+// CredentialIDs is how the ids of the credentials and of their revocation status are built
+type CredentialIDs struct {
+	Generator    string `mapstructure:"Generator" tip:"Generator of the unique part of the credential ids: uuid or ulid"`
+	Format       string `mapstructure:"Format" tip:"Format of the credential ids: url, did, urn or a template with {host}, {issuer} and {id}"`
+	StatusFormat string `mapstructure:"StatusFormat" tip:"Template of the revocation status ids with {host}, {issuer} and {nonce}. Empty uses the url of the node"`
+}
+
+// Scheme returns the scheme that builds the ids
+func (c CredentialIDs) Scheme() (*credentialid.Scheme, error) {
+	return credentialid.New(c.Generator, c.Format, c.StatusFormat, nil)
 }
 
 // APIUI - APIUI backend service configuration.
 type APIUI struct {
-	ServerPort         int       `mapstructure:"ServerPort" tip:"Server UI API backend port"`
-	ServerURL          string    `mapstructure:"ServerUrl" tip:"Server UI API backend url"`
-	APIUIAuth          APIUIAuth `mapstructure:"APIUIAuth" tip:"Server UI API backend basic auth credentials"`
-	IssuerName         string    `mapstructure:"IssuerName" tip:"Server UI API backend issuer name"`
-	IssuerLogo         string    `mapstructure:"IssuerLogo" tip:"Server UI API backend issuer logo (URL)"`
-	Issuer             string    `mapstructure:"IssuerDID" tip:"Server UI API backend issuer DID (already created in the issuer node)"`
-	IssuerDID          core.DID  `mapstructure:"-"`
-	SchemaCache        *bool     `mapstructure:"SchemaCache" tip:"Server UI API backend for enabling schema caching"`
-	IdentityMethod     string    `mapstructure:"IdentityMethod" tip:"Server UI API backend Identity Method"`
-	IdentityBlockchain string    `mapstructure:"IdentityBlockchain" tip:"Server UI API backend Identity Blockchain"`
-	IdentityNetwork    string    `mapstructure:"IdentityNetwork" tip:"Server UI API backend Identity Network"`
+	ServerPort           int             `mapstructure:"ServerPort" tip:"Server UI API backend port"`
+	ServerURL            string          `mapstructure:"ServerUrl" tip:"Server UI API backend url"`
+	ServerAddress        string          `mapstructure:"ServerAddress" tip:"Comma separated IPv4 or IPv6 addresses the UI API listens on. Empty listens on every interface"`
+	APIUIAuth            APIUIAuth       `mapstructure:"APIUIAuth" tip:"Server UI API backend basic auth credentials"`
+	IssuerName           string          `mapstructure:"IssuerName" tip:"Server UI API backend issuer name"`
+	IssuerLogo           string          `mapstructure:"IssuerLogo" tip:"Server UI API backend issuer logo (URL)"`
+	Issuer               string          `mapstructure:"IssuerDID" tip:"Server UI API backend issuer DID (already created in the issuer node)"`
+	IssuerDID            core.DID        `mapstructure:"-"`
+	TenantsFile          string          `mapstructure:"TenantsFile" tip:"YAML file with the credentials of the operators of each issuer identity managed by the UI API. Empty only lets the basic auth users in"`
+	Tenants              []Tenant        `mapstructure:"-"`
+	SchemaCache          *bool           `mapstructure:"SchemaCache" tip:"Server UI API backend for enabling schema caching"`
+	IdentityMethod       string          `mapstructure:"IdentityMethod" tip:"Server UI API backend Identity Method"`
+	IdentityBlockchain   string          `mapstructure:"IdentityBlockchain" tip:"Server UI API backend Identity Blockchain"`
+	IdentityNetwork      string          `mapstructure:"IdentityNetwork" tip:"Server UI API backend Identity Network"`
+	AntiAbuse            AntiAbuse       `mapstructure:"AntiAbuse" tip:"Server UI API anti-abuse controls of the public link endpoints"`
+	SchemaProxyRateLimit int             `mapstructure:"SchemaProxyRateLimit" tip:"Requests per minute of each IP to the public schema proxy endpoints. A negative value disables it"`
+	CORS                 CORS            `mapstructure:"CORS" tip:"CORS of the UI API"`
+	SecurityHeaders      SecurityHeaders `mapstructure:"SecurityHeaders" tip:"Security headers of the UI API responses"`
+}
+
+// AntiAbuse configures the protection of the public link session endpoints of open issuance campaigns.
+// Without a challenge type and a rate limit they are not protected.
+type AntiAbuse struct {
+	Challenge        string `mapstructure:"Challenge" tip:"Challenge to create a link session: captcha or pow. Empty disables it"`
+	CaptchaVerifyURL string `mapstructure:"CaptchaVerifyURL" tip:"Siteverify endpoint of the CAPTCHA provider"`
+	CaptchaSecret    string `mapstructure:"CaptchaSecret" tip:"Secret key of the CAPTCHA provider"`
+	CaptchaSiteKey   string `mapstructure:"CaptchaSiteKey" tip:"Site key of the CAPTCHA provider"`
+	PoWDifficulty    int    `mapstructure:"PoWDifficulty" tip:"Leading zero bits of the proof of work hash"`
+	PoWSecret        string `mapstructure:"PoWSecret" tip:"Secret that signs the proof of work challenges, the same in every server"`
+	RateLimit        int    `mapstructure:"RateLimit" tip:"Requests per minute of each IP to the public link endpoints. 0 disables it"`
+	ThrottleBackend  string `mapstructure:"ThrottleBackend" tip:"Where the rate limits are kept: memory, per server, or postgres, shared by every server"`
+	TrustProxy       bool   `mapstructure:"TrustProxy" tip:"Take the client IP from the X-Forwarded-For header"`
 }
 
 // APIUIAuth configuration. Some of the UI API endpoints are protected with basic http auth. Here you can set the
@@ -138,6 +645,25 @@ type APIUI struct {
 type APIUIAuth struct {
 	User     string `mapstructure:"User" tip:"Server UI APIBasic auth username"`
 	Password string `mapstructure:"Password" tip:"Server UI API Basic auth password"`
+	// PIIUser and PIIPassword also give access to the protected endpoints and return the PII attributes unmasked
+	PIIUser     string `mapstructure:"PIIUser" tip:"Server UI API Basic auth username that can read the PII attributes"`
+	PIIPassword string `mapstructure:"PIIPassword" tip:"Server UI API Basic auth password that can read the PII attributes"`
+}
+
+// Tenant is an issuer identity of the node managed through the UI API by its own operators. Their basic auth
+// credentials only give access to the requests scoped to the identity that their role allows.
+type Tenant struct {
+	DID      core.DID
+	User     string
+	Password string
+	// Role is what the operators can call, everything when empty. The tenants file sets read-only when it has no role.
+	Role domain.Role
+}
+
+type tenantSettings struct {
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Role     string `yaml:"role"`
 }
 
 // Sanitize perform some basic checks and sanitizations in the configuration.
@@ -149,6 +675,52 @@ func (c *Configuration) Sanitize() error {
 	}
 	c.ServerUrl = sUrl
 
+	if _, err := ListenAddresses(c.ServerAddress, c.ServerPort); err != nil {
+		return fmt.Errorf("invalid server address <%s>: %w", c.ServerAddress, err)
+	}
+
+	if c.Cache.Backend != CacheRedis && c.Cache.Backend != CachePostgres {
+		return fmt.Errorf("invalid cache backend <%s>, it must be %s or %s", c.Cache.Backend, CacheRedis, CachePostgres)
+	}
+
+	switch c.KeyStore.Backend {
+	case "", KeyStoreVault, KeyStoreAWS, KeyStoreGCP, KeyStoreAzure, KeyStoreFile:
+	default:
+		return fmt.Errorf("unknown key store backend <%s>, it must be %s, %s, %s, %s or %s", c.KeyStore.Backend, KeyStoreVault, KeyStoreAWS, KeyStoreGCP, KeyStoreAzure, KeyStoreFile)
+	}
+	if c.KeyStore.Backend == KeyStoreFile && c.KeyStore.File.Passphrase == "" {
+		return fmt.Errorf("the passphrase of the key store file is required")
+	}
+
+	if err := c.validateSigningKeySelection(); err != nil {
+		return err
+	}
+
+	if _, err := c.CredentialIDs.Scheme(); err != nil {
+		return err
+	}
+
+	if _, err := c.Networks(); err != nil {
+		return err
+	}
+
+	if c.Region.Name != "" && c.Region.LeaseTTL <= c.Region.Refresh {
+		return fmt.Errorf("the region lease ttl <%s> must be longer than its refresh <%s>", c.Region.LeaseTTL, c.Region.Refresh)
+	}
+
+	if err := c.CORS.validate(); err != nil {
+		return fmt.Errorf("invalid API CORS: %w", err)
+	}
+	if err := c.SecurityHeaders.validate(); err != nil {
+		return fmt.Errorf("invalid API security headers: %w", err)
+	}
+	if err := c.OIDC.validate(); err != nil {
+		return fmt.Errorf("invalid API OIDC: %w", err)
+	}
+	if err := c.RateLimit.validate(); err != nil {
+		return fmt.Errorf("invalid API rate limit: %w", err)
+	}
+
 	return nil
 }
 
@@ -163,6 +735,16 @@ func (c *Configuration) SanitizeAPIUI() error {
 		return fmt.Errorf("the UI API server url must be provided")
 	}
 
+	serverURL, err := sanitizeURL(c.APIUI.ServerURL)
+	if err != nil {
+		return fmt.Errorf("the UI API server url is not a valid URL <%s>: %w", c.APIUI.ServerURL, err)
+	}
+	c.APIUI.ServerURL = serverURL
+
+	if _, err := ListenAddresses(c.APIUI.ServerAddress, c.APIUI.ServerPort); err != nil {
+		return fmt.Errorf("invalid UI API server address <%s>: %w", c.APIUI.ServerAddress, err)
+	}
+
 	if c.APIUI.Issuer == "" {
 		return fmt.Errorf("an issuer DID must be provided")
 	}
@@ -174,21 +756,246 @@ func (c *Configuration) SanitizeAPIUI() error {
 
 	c.APIUI.IssuerDID = *issuerDID
 
+	tenants, err := c.APIUI.tenants()
+	if err != nil {
+		return err
+	}
+	c.APIUI.Tenants = tenants
+
+	if c.SessionStore.Backend != SessionStoreRedis && c.SessionStore.Backend != SessionStorePostgres {
+		return fmt.Errorf("invalid session store backend <%s>, it must be %s or %s", c.SessionStore.Backend, SessionStoreRedis, SessionStorePostgres)
+	}
+
+	if c.Cache.Backend != CacheRedis && c.Cache.Backend != CachePostgres {
+		return fmt.Errorf("invalid cache backend <%s>, it must be %s or %s", c.Cache.Backend, CacheRedis, CachePostgres)
+	}
+	if c.Cache.Backend == CachePostgres && c.SessionStore.Backend == SessionStoreRedis {
+		return fmt.Errorf("the session store backend can't be %s without redis, with the %s cache backend", SessionStoreRedis, CachePostgres)
+	}
+
+	if c.APIUI.AntiAbuse.ThrottleBackend != ThrottleMemory && c.APIUI.AntiAbuse.ThrottleBackend != ThrottlePostgres {
+		return fmt.Errorf("invalid throttle backend <%s>, it must be %s or %s", c.APIUI.AntiAbuse.ThrottleBackend, ThrottleMemory, ThrottlePostgres)
+	}
+
+	if err := c.validateSigningKeySelection(); err != nil {
+		return err
+	}
+
+	if _, err := c.CredentialIDs.Scheme(); err != nil {
+		return err
+	}
+
+	if pool := c.Database.Pool; pool.MaxConns < 0 || pool.MinConns < 0 || (pool.MaxConns > 0 && pool.MinConns > pool.MaxConns) {
+		return fmt.Errorf("invalid database pool size, min conns <%d> must be between 0 and max conns <%d>", pool.MinConns, pool.MaxConns)
+	}
+
+	if err := c.APIUI.CORS.validate(); err != nil {
+		return fmt.Errorf("invalid UI API CORS: %w", err)
+	}
+	if err := c.APIUI.SecurityHeaders.validate(); err != nil {
+		return fmt.Errorf("invalid UI API security headers: %w", err)
+	}
+
+	return nil
+}
+
+// validate rejects the credentials with any origin, that would let every site call the server with the credentials
+// of the user
+func (c CORS) validate() error {
+	if !c.AllowCredentials {
+		return nil
+	}
+	for _, origin := range strings.Split(c.AllowedOrigins, ",") {
+		if strings.Contains(origin, "*") {
+			return fmt.Errorf("the allowed origins <%s> can't have wildcards with credentials", c.AllowedOrigins)
+		}
+	}
 	return nil
 }
 
+func (s SecurityHeaders) validate() error {
+	switch strings.ToUpper(s.FrameOptions) {
+	case "", "DENY", "SAMEORIGIN":
+	default:
+		return fmt.Errorf("unknown frame options <%s>, it must be DENY or SAMEORIGIN", s.FrameOptions)
+	}
+	if s.HSTSMaxAge < 0 {
+		return fmt.Errorf("the HSTS max age <%s> can't be negative", s.HSTSMaxAge)
+	}
+	return nil
+}
+
+func (c *Configuration) validateSigningKeySelection() error {
+	switch c.SigningKeySelection {
+	case "", SigningKeySelectionDefault, SigningKeySelectionRoundRobin:
+		return nil
+	default:
+		return fmt.Errorf("unknown signing key selection <%s>, it must be %s or %s", c.SigningKeySelection, SigningKeySelectionDefault, SigningKeySelectionRoundRobin)
+	}
+}
+
+// Networks returns the networks the identities are published on. They are the ones of the networks file, shaped as
+// blockchain, network and settings:
+//
+//	polygon:
+//	  amoy:
+//	    url: https://rpc-amoy.polygon.technology
+//	    contractAddress: "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124"
+//	    reverseHashServiceUrl: https://rhs-staging.polygonid.me
+//
+// A private network sets its chainID and the network byte of its DIDs for each DID method in didMethods, like
+// {polygonid: 0xe1}. The byte is the second byte of the DID type, so it can't be 0, that is the one of the read-only
+// identities.
+//
+// Without the file the only network is the one of the resolver prefix.
+func (c *Configuration) Networks() (Networks, error) {
+	defaultNetwork := Network{Ethereum: c.Ethereum, ReverseHashService: c.ReverseHashService}
+	if c.NetworksFile == "" {
+		return Networks{c.Ethereum.ResolverPrefix: defaultNetwork}, nil
+	}
+
+	content, err := os.ReadFile(c.NetworksFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the networks file <%s>: %w", c.NetworksFile, err)
+	}
+	var file map[string]map[string]networkSettings
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid networks file <%s>: %w", c.NetworksFile, err)
+	}
+
+	networks := make(Networks)
+	for blockchain, blockchainNetworks := range file {
+		for name, settings := range blockchainNetworks {
+			key := blockchain + ":" + name
+			if !c.Sandbox && (settings.URL == "" || settings.ContractAddress == "") {
+				return nil, fmt.Errorf("the network <%s> of the networks file needs its url and contractAddress", key)
+			}
+			network := defaultNetwork
+			network.Ethereum.ResolverPrefix = key
+			network.Ethereum.URL = settings.URL
+			network.Ethereum.ContractAddress = settings.ContractAddress
+			if settings.DefaultGasLimit != 0 {
+				network.Ethereum.DefaultGasLimit = settings.DefaultGasLimit
+			}
+			if settings.ConfirmationBlockCount != 0 {
+				network.Ethereum.ConfirmationBlockCount = settings.ConfirmationBlockCount
+			}
+			if settings.MinGasPrice != 0 {
+				network.Ethereum.MinGasPrice = settings.MinGasPrice
+			}
+			if settings.MaxGasPrice != 0 {
+				network.Ethereum.MaxGasPrice = settings.MaxGasPrice
+			}
+			if settings.ReverseHashServiceURL != "" {
+				network.ReverseHashService.URL = settings.ReverseHashServiceURL
+			}
+			if settings.ChainID < 0 {
+				return nil, fmt.Errorf("the chainID of the network <%s> can't be negative", key)
+			}
+			network.ChainID = settings.ChainID
+			for method, networkByte := range settings.DIDMethods {
+				if networkByte == 0 {
+					return nil, fmt.Errorf("the DID network byte of the method <%s> of the network <%s> can't be 0", method, key)
+				}
+			}
+			if len(settings.DIDMethods) > 0 {
+				network.DIDMethods = settings.DIDMethods
+			}
+			networks[key] = network
+		}
+	}
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("the networks file <%s> has no networks", c.NetworksFile)
+	}
+	return networks, nil
+}
+
+// tenants returns the operators of the tenants file, shaped as the DID of the identity and its credentials:
+//
+//	did:polygonid:polygon:amoy:2qQ68JkRcf3xrHPQPWZei3YeVzHPP58wYNxx2mEouR:
+//	  user: acme
+//	  password: secret
+//
+// The users can't be the ones of the basic auth, and each one has a single identity.
+func (a APIUI) tenants() ([]Tenant, error) {
+	if a.TenantsFile == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(a.TenantsFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the tenants file <%s>: %w", a.TenantsFile, err)
+	}
+	var file map[string]tenantSettings
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid tenants file <%s>: %w", a.TenantsFile, err)
+	}
+
+	users := map[string]bool{a.APIUIAuth.User: true, a.APIUIAuth.PIIUser: true}
+	tenants := make([]Tenant, 0, len(file))
+	for identifier, settings := range file {
+		did, err := core.ParseDID(identifier)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DID <%s> in the tenants file: %w", identifier, err)
+		}
+		if settings.User == "" || settings.Password == "" {
+			return nil, fmt.Errorf("the tenant <%s> of the tenants file needs its user and password", identifier)
+		}
+		if users[settings.User] {
+			return nil, fmt.Errorf("the user <%s> of the tenant <%s> is already used", settings.User, identifier)
+		}
+		users[settings.User] = true
+		// a tenant only gets more than reading its identity when its role says so
+		role := domain.RoleReadOnly
+		if settings.Role != "" {
+			if role, err = domain.ParseRole(settings.Role); err != nil {
+				return nil, fmt.Errorf("invalid role of the tenant <%s> of the tenants file: %w", identifier, err)
+			}
+		}
+		tenants = append(tenants, Tenant{DID: *did, User: settings.User, Password: settings.Password, Role: role})
+	}
+	return tenants, nil
+}
+
 func (c *Configuration) validateServerUrl() (string, error) {
-	sUrl, err := url.ParseRequestURI(c.ServerUrl)
+	return sanitizeURL(c.ServerUrl)
+}
+
+// sanitizeURL checks that raw is an absolute url and removes its query and trailing slashes. It is the base of the
+// urls in the QR codes, so the IPv6 hosts must be in brackets, like http://[2001:db8::1]:3001, for the wallets to tell
+// the address from the port.
+func sanitizeURL(raw string) (string, error) {
+	sUrl, err := url.ParseRequestURI(raw)
 	if err != nil {
-		return c.ServerUrl, err
+		return raw, err
 	}
 	if sUrl.Scheme == "" {
-		return c.ServerUrl, fmt.Errorf("server URL must be an absolute URL")
+		return raw, fmt.Errorf("server URL must be an absolute URL")
+	}
+	if strings.Count(sUrl.Host, ":") > 1 && !strings.HasPrefix(sUrl.Host, "[") {
+		return raw, fmt.Errorf("IPv6 hosts must be in brackets, like http://[2001:db8::1]:3001")
 	}
 	sUrl.RawQuery = ""
 	return strings.Trim(strings.Trim(sUrl.String(), "/"), "?"), nil
 }
 
+// ListenAddresses returns the addresses a server listens on: the port in each IP address of the comma separated list
+// bind, with or without brackets for IPv6. An empty list listens on every interface, in IPv4 and IPv6, and so does ::
+// unless the system has dual stack disabled. 0.0.0.0 only listens on the IPv4 interfaces.
+func ListenAddresses(bind string, port int) ([]string, error) {
+	if strings.TrimSpace(bind) == "" {
+		return []string{fmt.Sprintf(":%d", port)}, nil
+	}
+	var addresses []string
+	for _, host := range strings.Split(bind, ",") {
+		host = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(host), "["), "]")
+		if net.ParseIP(host) == nil {
+			return nil, fmt.Errorf("<%s> is not an IP address", host)
+		}
+		addresses = append(addresses, net.JoinHostPort(host, strconv.Itoa(port)))
+	}
+	return addresses, nil
+}
+
 // Load loads the configuration from a file
 func Load(fileName string) (*Configuration, error) {
 	bindEnv()
@@ -284,21 +1091,80 @@ func bindEnv() {
 	viper.SetEnvPrefix("ISSUER")
 	_ = viper.BindEnv("ServerUrl", "ISSUER_SERVER_URL")
 	_ = viper.BindEnv("ServerPort", "ISSUER_SERVER_PORT")
+	_ = viper.BindEnv("ServerAddress", "ISSUER_SERVER_ADDRESS")
 	_ = viper.BindEnv("NativeProofGenerationEnabled", "ISSUER_NATIVE_PROOF_GENERATION_ENABLED")
+	_ = viper.BindEnv("Sandbox", "ISSUER_SANDBOX")
 	_ = viper.BindEnv("PublishingKeyPath", "ISSUER_PUBLISH_KEY_PATH")
 	_ = viper.BindEnv("OnChainCheckStatusFrequency", "ISSUER_ONCHAIN_CHECK_STATUS_FREQUENCY")
+	_ = viper.BindEnv("StartupTimeout", "ISSUER_STARTUP_TIMEOUT")
+	_ = viper.BindEnv("Timeouts.Request", "ISSUER_TIMEOUT_REQUEST")
+	_ = viper.BindEnv("Timeouts.Database", "ISSUER_TIMEOUT_DATABASE")
+	_ = viper.BindEnv("Timeouts.KeyStore", "ISSUER_TIMEOUT_KEY_STORE")
+	_ = viper.BindEnv("Timeouts.SchemaLoader", "ISSUER_TIMEOUT_SCHEMA_LOADER")
+	_ = viper.BindEnv("CORS.AllowedOrigins", "ISSUER_CORS_ALLOWED_ORIGINS")
+	_ = viper.BindEnv("CORS.AllowedMethods", "ISSUER_CORS_ALLOWED_METHODS")
+	_ = viper.BindEnv("CORS.AllowedHeaders", "ISSUER_CORS_ALLOWED_HEADERS")
+	_ = viper.BindEnv("CORS.ExposedHeaders", "ISSUER_CORS_EXPOSED_HEADERS")
+	_ = viper.BindEnv("CORS.AllowCredentials", "ISSUER_CORS_ALLOW_CREDENTIALS")
+	_ = viper.BindEnv("CORS.MaxAge", "ISSUER_CORS_MAX_AGE")
+	_ = viper.BindEnv("SecurityHeaders.HSTSMaxAge", "ISSUER_SECURITY_HEADERS_HSTS_MAX_AGE")
+	_ = viper.BindEnv("SecurityHeaders.HSTSIncludeSubdomains", "ISSUER_SECURITY_HEADERS_HSTS_INCLUDE_SUBDOMAINS")
+	_ = viper.BindEnv("SecurityHeaders.FrameOptions", "ISSUER_SECURITY_HEADERS_FRAME_OPTIONS")
+	_ = viper.BindEnv("SecurityHeaders.ReferrerPolicy", "ISSUER_SECURITY_HEADERS_REFERRER_POLICY")
 
 	_ = viper.BindEnv("Database.URL", "ISSUER_DATABASE_URL")
+	_ = viper.BindEnv("Database.ReplicaURL", "ISSUER_DATABASE_REPLICA_URL")
+	_ = viper.BindEnv("Database.Pool.MaxConns", "ISSUER_DATABASE_POOL_MAX_CONNS")
+	_ = viper.BindEnv("Database.Pool.MinConns", "ISSUER_DATABASE_POOL_MIN_CONNS")
+	_ = viper.BindEnv("Database.Pool.MaxConnLifetime", "ISSUER_DATABASE_POOL_MAX_CONN_LIFETIME")
+	_ = viper.BindEnv("Database.Pool.HealthCheckPeriod", "ISSUER_DATABASE_POOL_HEALTH_CHECK_PERIOD")
 
 	_ = viper.BindEnv("Log.Level", "ISSUER_LOG_LEVEL")
 	_ = viper.BindEnv("Log.Mode", "ISSUER_LOG_MODE")
 
 	_ = viper.BindEnv("HTTPBasicAuth.User", "ISSUER_API_AUTH_USER")
 	_ = viper.BindEnv("HTTPBasicAuth.Password", "ISSUER_API_AUTH_PASSWORD")
+	_ = viper.BindEnv("HTTPBasicAuth.PIIUser", "ISSUER_API_AUTH_PII_USER")
+	_ = viper.BindEnv("HTTPBasicAuth.PIIPassword", "ISSUER_API_AUTH_PII_PASSWORD")
+	_ = viper.BindEnv("OIDC.Issuer", "ISSUER_API_OIDC_ISSUER")
+	_ = viper.BindEnv("OIDC.JWKSURL", "ISSUER_API_OIDC_JWKS_URL")
+	_ = viper.BindEnv("OIDC.Audience", "ISSUER_API_OIDC_AUDIENCE")
+	_ = viper.BindEnv("OIDC.RequiredClaims", "ISSUER_API_OIDC_REQUIRED_CLAIMS")
+	_ = viper.BindEnv("OIDC.PIIClaims", "ISSUER_API_OIDC_PII_CLAIMS")
+	_ = viper.BindEnv("OIDC.AdminClaims", "ISSUER_API_OIDC_ADMIN_CLAIMS")
+	_ = viper.BindEnv("OIDC.OperatorClaims", "ISSUER_API_OIDC_OPERATOR_CLAIMS")
+	_ = viper.BindEnv("OIDC.ReadOnlyClaims", "ISSUER_API_OIDC_READ_ONLY_CLAIMS")
+	_ = viper.BindEnv("OIDC.AllowBasicAuth", "ISSUER_API_OIDC_ALLOW_BASIC_AUTH")
+
+	_ = viper.BindEnv("RateLimit.Agent", "ISSUER_API_RATE_LIMIT_AGENT")
+	_ = viper.BindEnv("RateLimit.QRCode", "ISSUER_API_RATE_LIMIT_QR_CODE")
+	_ = viper.BindEnv("RateLimit.Status", "ISSUER_API_RATE_LIMIT_STATUS")
+	_ = viper.BindEnv("RateLimit.Default", "ISSUER_API_RATE_LIMIT_DEFAULT")
+	_ = viper.BindEnv("RateLimit.TrustProxy", "ISSUER_API_RATE_LIMIT_TRUST_PROXY")
 
 	_ = viper.BindEnv("KeyStore.Address", "ISSUER_KEY_STORE_ADDRESS")
 	_ = viper.BindEnv("KeyStore.Token", "ISSUER_KEY_STORE_TOKEN")
+	_ = viper.BindEnv("KeyStore.Backend", "ISSUER_KEY_STORE_BACKEND")
 	_ = viper.BindEnv("KeyStore.PluginIden3MountPath", "ISSUER_KEY_STORE_PLUGIN_IDEN3_MOUNT_PATH")
+	_ = viper.BindEnv("KeyStore.AWS.Region", "ISSUER_KEY_STORE_AWS_REGION")
+	_ = viper.BindEnv("KeyStore.AWS.KeyID", "ISSUER_KEY_STORE_AWS_ACCESS_KEY_ID")
+	_ = viper.BindEnv("KeyStore.AWS.Key", "ISSUER_KEY_STORE_AWS_SECRET_ACCESS_KEY")
+	_ = viper.BindEnv("KeyStore.AWS.Prefix", "ISSUER_KEY_STORE_AWS_PREFIX")
+	_ = viper.BindEnv("KeyStore.AWS.Endpoint", "ISSUER_KEY_STORE_AWS_ENDPOINT")
+	_ = viper.BindEnv("KeyStore.GCP.Project", "ISSUER_KEY_STORE_GCP_PROJECT")
+	_ = viper.BindEnv("KeyStore.GCP.Location", "ISSUER_KEY_STORE_GCP_LOCATION")
+	_ = viper.BindEnv("KeyStore.GCP.KeyRing", "ISSUER_KEY_STORE_GCP_KEY_RING")
+	_ = viper.BindEnv("KeyStore.GCP.CredentialsFile", "ISSUER_KEY_STORE_GCP_CREDENTIALS_FILE")
+	_ = viper.BindEnv("KeyStore.GCP.Prefix", "ISSUER_KEY_STORE_GCP_PREFIX")
+	_ = viper.BindEnv("KeyStore.GCP.Endpoint", "ISSUER_KEY_STORE_GCP_ENDPOINT")
+	_ = viper.BindEnv("KeyStore.Azure.VaultURL", "ISSUER_KEY_STORE_AZURE_VAULT_URL")
+	_ = viper.BindEnv("KeyStore.Azure.TenantID", "ISSUER_KEY_STORE_AZURE_TENANT_ID")
+	_ = viper.BindEnv("KeyStore.Azure.ClientID", "ISSUER_KEY_STORE_AZURE_CLIENT_ID")
+	_ = viper.BindEnv("KeyStore.Azure.ClientSecret", "ISSUER_KEY_STORE_AZURE_CLIENT_SECRET")
+	_ = viper.BindEnv("KeyStore.Azure.Prefix", "ISSUER_KEY_STORE_AZURE_PREFIX")
+	_ = viper.BindEnv("KeyStore.Azure.AuthorityHost", "ISSUER_KEY_STORE_AZURE_AUTHORITY_HOST")
+	_ = viper.BindEnv("KeyStore.File.Path", "ISSUER_KEY_STORE_FILE_PATH")
+	_ = viper.BindEnv("KeyStore.File.Passphrase", "ISSUER_KEY_STORE_FILE_PASSPHRASE")
 
 	_ = viper.BindEnv("ReverseHashService.URL", "ISSUER_REVERSE_HASH_SERVICE_URL")
 	_ = viper.BindEnv("ReverseHashService.Enabled", "ISSUER_REVERSE_HASH_SERVICE_ENABLED")
@@ -315,23 +1181,149 @@ func bindEnv() {
 	_ = viper.BindEnv("Ethereum.WaitReceiptCycleTime", "ISSUER_ETHEREUM_WAIT_RECEIPT_CYCLE_TIME")
 	_ = viper.BindEnv("Ethereum.WaitBlockCycleTime", "ISSUER_ETHEREUM_WAIT_BLOCK_CYCLE_TIME")
 	_ = viper.BindEnv("Ethereum.ResolverPrefix", "ISSUER_ETHEREUM_RESOLVER_PREFIX")
+	_ = viper.BindEnv("NetworksFile", "ISSUER_NETWORKS_FILE")
 
 	_ = viper.BindEnv("Prover.ServerURL", "ISSUER_PROVER_SERVER_URL")
 	_ = viper.BindEnv("Prover.ResponseTimeout", "ISSUER_PROVER_TIMEOUT")
 
 	_ = viper.BindEnv("Circuit.Path", "ISSUER_CIRCUIT_PATH")
 
+	_ = viper.BindEnv("Protocol.Circuits", "ISSUER_PROTOCOL_CIRCUITS")
+	_ = viper.BindEnv("Protocol.Packers", "ISSUER_PROTOCOL_PACKERS")
+
+	_ = viper.BindEnv("Cache.Backend", "ISSUER_CACHE_BACKEND")
 	_ = viper.BindEnv("Cache.RedisUrl", "ISSUER_REDIS_URL")
+	_ = viper.BindEnv("Cache.CleanupPeriod", "ISSUER_CACHE_CLEANUP_PERIOD")
 	_ = viper.BindEnv("SchemaCache", "ISSUER_SCHEMA_CACHE")
+	_ = viper.BindEnv("SchemaCacheTTL", "ISSUER_SCHEMA_CACHE_TTL")
+	_ = viper.BindEnv("IPFS.URL", "ISSUER_IPFS_URL")
+	_ = viper.BindEnv("IPFS.GatewayURL", "ISSUER_IPFS_GATEWAY_URL")
+	_ = viper.BindEnv("IPFS.PinImported", "ISSUER_IPFS_PIN_IMPORTED")
+	_ = viper.BindEnv("SchemaBundle.Dir", "ISSUER_SCHEMA_BUNDLE_DIR")
+	_ = viper.BindEnv("SchemaBundle.Order", "ISSUER_SCHEMA_BUNDLE_ORDER")
+
+	_ = viper.BindEnv("SchemaLoader.Mirrors", "ISSUER_SCHEMA_LOADER_MIRRORS")
+	_ = viper.BindEnv("SchemaLoader.Attempts", "ISSUER_SCHEMA_LOADER_ATTEMPTS")
+	_ = viper.BindEnv("SchemaLoader.Backoff", "ISSUER_SCHEMA_LOADER_BACKOFF")
+	_ = viper.BindEnv("SchemaLoader.MaxBackoff", "ISSUER_SCHEMA_LOADER_MAX_BACKOFF")
+	_ = viper.BindEnv("SchemaLoader.BreakerFailures", "ISSUER_SCHEMA_LOADER_BREAKER_FAILURES")
+	_ = viper.BindEnv("SchemaLoader.BreakerCooldown", "ISSUER_SCHEMA_LOADER_BREAKER_COOLDOWN")
+
+	_ = viper.BindEnv("JSONLD.Contexts", "ISSUER_JSONLD_CONTEXTS")
+	_ = viper.BindEnv("JSONLD.SafeMode", "ISSUER_JSONLD_SAFE_MODE")
+
+	_ = viper.BindEnv("Maintenance.RetryAfter", "ISSUER_MAINTENANCE_RETRY_AFTER")
+	_ = viper.BindEnv("Maintenance.Refresh", "ISSUER_MAINTENANCE_REFRESH")
+	_ = viper.BindEnv("Region.Name", "ISSUER_REGION_NAME")
+	_ = viper.BindEnv("Region.LeaseTTL", "ISSUER_REGION_LEASE_TTL")
+	_ = viper.BindEnv("Region.Refresh", "ISSUER_REGION_REFRESH")
+
+	_ = viper.BindEnv("Quotas.MaxActiveLinks", "ISSUER_QUOTA_MAX_ACTIVE_LINKS")
+	_ = viper.BindEnv("Quotas.MaxCredentialsPerDay", "ISSUER_QUOTA_MAX_CREDENTIALS_PER_DAY")
+	_ = viper.BindEnv("Quotas.MaxSchemas", "ISSUER_QUOTA_MAX_SCHEMAS")
+
+	_ = viper.BindEnv("Ingest.Workers", "ISSUER_INGEST_WORKERS")
+	_ = viper.BindEnv("Ingest.QueueDepth", "ISSUER_INGEST_QUEUE_DEPTH")
+
+	_ = viper.BindEnv("Branding.DisplayName", "ISSUER_BRANDING_DISPLAY_NAME")
+	_ = viper.BindEnv("Branding.LogoURL", "ISSUER_BRANDING_LOGO_URL")
+	_ = viper.BindEnv("Branding.PrimaryColor", "ISSUER_BRANDING_PRIMARY_COLOR")
+	_ = viper.BindEnv("Branding.TextColor", "ISSUER_BRANDING_TEXT_COLOR")
+
+	_ = viper.BindEnv("AgentReplayWindow", "ISSUER_AGENT_REPLAY_WINDOW")
+	_ = viper.BindEnv("ClockSkew", "ISSUER_CLOCK_SKEW")
+	_ = viper.BindEnv("SigningKeySelection", "ISSUER_SIGNING_KEY_SELECTION")
+
+	_ = viper.BindEnv("CredentialIDs.Generator", "ISSUER_CREDENTIAL_ID_GENERATOR")
+	_ = viper.BindEnv("CredentialIDs.Format", "ISSUER_CREDENTIAL_ID_FORMAT")
+	_ = viper.BindEnv("CredentialIDs.StatusFormat", "ISSUER_CREDENTIAL_STATUS_ID_FORMAT")
+
+	_ = viper.BindEnv("PayloadStore.Backend", "ISSUER_PAYLOAD_STORE_BACKEND")
+	_ = viper.BindEnv("PayloadStore.URL", "ISSUER_PAYLOAD_STORE_URL")
+	_ = viper.BindEnv("PayloadStore.Authorization", "ISSUER_PAYLOAD_STORE_AUTHORIZATION")
+	_ = viper.BindEnv("PayloadStore.Threshold", "ISSUER_PAYLOAD_STORE_THRESHOLD")
+	_ = viper.BindEnv("PayloadStore.CleanupPeriod", "ISSUER_PAYLOAD_STORE_CLEANUP_PERIOD")
+
+	_ = viper.BindEnv("EventSink.Backend", "ISSUER_EVENT_SINK_BACKEND")
+	_ = viper.BindEnv("EventSink.Destination", "ISSUER_EVENT_SINK_DESTINATION")
+	_ = viper.BindEnv("EventSink.Region", "ISSUER_EVENT_SINK_REGION")
+	_ = viper.BindEnv("EventSink.KeyID", "ISSUER_EVENT_SINK_KEY_ID")
+	_ = viper.BindEnv("EventSink.Key", "ISSUER_EVENT_SINK_KEY")
+	_ = viper.BindEnv("EventSink.CredentialsFile", "ISSUER_EVENT_SINK_CREDENTIALS_FILE")
+	_ = viper.BindEnv("TrustRegistry.Backend", "ISSUER_TRUST_REGISTRY_BACKEND")
+	_ = viper.BindEnv("TrustRegistry.URL", "ISSUER_TRUST_REGISTRY_URL")
+	_ = viper.BindEnv("TrustRegistry.Authorization", "ISSUER_TRUST_REGISTRY_AUTHORIZATION")
+	_ = viper.BindEnv("TrustRegistry.CacheTTL", "ISSUER_TRUST_REGISTRY_CACHE_TTL")
+	_ = viper.BindEnv("PolicyHook.URL", "ISSUER_POLICY_HOOK_URL")
+	_ = viper.BindEnv("PolicyHook.Authorization", "ISSUER_POLICY_HOOK_AUTHORIZATION")
+	_ = viper.BindEnv("PolicyHook.Timeout", "ISSUER_POLICY_HOOK_TIMEOUT")
+	_ = viper.BindEnv("PolicyHook.FailOpen", "ISSUER_POLICY_HOOK_FAIL_OPEN")
+
+	_ = viper.BindEnv("VerificationWebhook.URL", "ISSUER_VERIFICATION_WEBHOOK_URL")
+	_ = viper.BindEnv("VerificationWebhook.Authorization", "ISSUER_VERIFICATION_WEBHOOK_AUTHORIZATION")
+	_ = viper.BindEnv("VerificationWebhook.Timeout", "ISSUER_VERIFICATION_WEBHOOK_TIMEOUT")
+
+	_ = viper.BindEnv("SchemaWebhooks.Timeout", "ISSUER_SCHEMA_WEBHOOKS_TIMEOUT")
+	_ = viper.BindEnv("StateCache.TTL", "ISSUER_STATE_CACHE_TTL")
+	_ = viper.BindEnv("StateCache.StaleTTL", "ISSUER_STATE_CACHE_STALE_TTL")
+
+	_ = viper.BindEnv("PushGateways.URLs", "ISSUER_PUSH_GATEWAYS_URLS")
+	_ = viper.BindEnv("PushGateways.HealthCheckPeriod", "ISSUER_PUSH_GATEWAYS_HEALTH_CHECK_PERIOD")
+
+	_ = viper.BindEnv("Alerts.Period", "ISSUER_ALERTS_PERIOD")
+	_ = viper.BindEnv("Alerts.Repeat", "ISSUER_ALERTS_REPEAT")
+	_ = viper.BindEnv("Alerts.BacklogSize", "ISSUER_ALERTS_BACKLOG_SIZE")
+	_ = viper.BindEnv("Alerts.BacklogAge", "ISSUER_ALERTS_BACKLOG_AGE")
+	_ = viper.BindEnv("Alerts.WebhookURL", "ISSUER_ALERTS_WEBHOOK_URL")
+	_ = viper.BindEnv("Alerts.WebhookAuthorization", "ISSUER_ALERTS_WEBHOOK_AUTHORIZATION")
+	_ = viper.BindEnv("Alerts.Timeout", "ISSUER_ALERTS_TIMEOUT")
+	_ = viper.BindEnv("Alerts.SMTPAddress", "ISSUER_ALERTS_SMTP_ADDRESS")
+	_ = viper.BindEnv("Alerts.SMTPUser", "ISSUER_ALERTS_SMTP_USER")
+	_ = viper.BindEnv("Alerts.SMTPPassword", "ISSUER_ALERTS_SMTP_PASSWORD")
+	_ = viper.BindEnv("Alerts.EmailFrom", "ISSUER_ALERTS_EMAIL_FROM")
+	_ = viper.BindEnv("Alerts.EmailTo", "ISSUER_ALERTS_EMAIL_TO")
+
+	_ = viper.BindEnv("HookCaptureSize", "ISSUER_HOOK_CAPTURE_SIZE")
+	_ = viper.BindEnv("Outbound.ProxyURL", "ISSUER_OUTBOUND_PROXY_URL")
+	_ = viper.BindEnv("Outbound.NoProxy", "ISSUER_OUTBOUND_NO_PROXY")
+	_ = viper.BindEnv("Outbound.CABundle", "ISSUER_OUTBOUND_CA_BUNDLE")
+
+	_ = viper.BindEnv("SessionStore.Backend", "ISSUER_SESSION_STORE_BACKEND")
+	_ = viper.BindEnv("SessionStore.AuthRequestTTL", "ISSUER_SESSION_STORE_AUTH_REQUEST_TTL")
+	_ = viper.BindEnv("SessionStore.LinkStateTTL", "ISSUER_SESSION_STORE_LINK_STATE_TTL")
 
 	_ = viper.BindEnv("APIUI.ServerPort", "ISSUER_API_UI_SERVER_PORT")
 	_ = viper.BindEnv("APIUI.ServerURL", "ISSUER_API_UI_SERVER_URL")
+	_ = viper.BindEnv("APIUI.ServerAddress", "ISSUER_API_UI_SERVER_ADDRESS")
 	_ = viper.BindEnv("APIUI.APIUIAuth.User", "ISSUER_API_UI_AUTH_USER")
 	_ = viper.BindEnv("APIUI.APIUIAuth.Password", "ISSUER_API_UI_AUTH_PASSWORD")
+	_ = viper.BindEnv("APIUI.APIUIAuth.PIIUser", "ISSUER_API_UI_AUTH_PII_USER")
+	_ = viper.BindEnv("APIUI.APIUIAuth.PIIPassword", "ISSUER_API_UI_AUTH_PII_PASSWORD")
+	_ = viper.BindEnv("APIUI.TenantsFile", "ISSUER_API_UI_TENANTS_FILE")
 	_ = viper.BindEnv("APIUI.IssuerName", "ISSUER_API_UI_ISSUER_NAME")
 	_ = viper.BindEnv("APIUI.IssuerLogo", "ISSUER_API_UI_ISSUER_LOGO")
 	_ = viper.BindEnv("APIUI.IssuerDID", "ISSUER_API_UI_ISSUER_DID")
 	_ = viper.BindEnv("APIUI.SchemaCache", "ISSUER_API_UI_SCHEMA_CACHE")
+	_ = viper.BindEnv("APIUI.AntiAbuse.Challenge", "ISSUER_API_UI_ANTI_ABUSE_CHALLENGE")
+	_ = viper.BindEnv("APIUI.AntiAbuse.CaptchaVerifyURL", "ISSUER_API_UI_ANTI_ABUSE_CAPTCHA_VERIFY_URL")
+	_ = viper.BindEnv("APIUI.AntiAbuse.CaptchaSecret", "ISSUER_API_UI_ANTI_ABUSE_CAPTCHA_SECRET")
+	_ = viper.BindEnv("APIUI.AntiAbuse.CaptchaSiteKey", "ISSUER_API_UI_ANTI_ABUSE_CAPTCHA_SITE_KEY")
+	_ = viper.BindEnv("APIUI.AntiAbuse.PoWDifficulty", "ISSUER_API_UI_ANTI_ABUSE_POW_DIFFICULTY")
+	_ = viper.BindEnv("APIUI.AntiAbuse.PoWSecret", "ISSUER_API_UI_ANTI_ABUSE_POW_SECRET")
+	_ = viper.BindEnv("APIUI.AntiAbuse.RateLimit", "ISSUER_API_UI_ANTI_ABUSE_RATE_LIMIT")
+	_ = viper.BindEnv("APIUI.AntiAbuse.ThrottleBackend", "ISSUER_API_UI_ANTI_ABUSE_THROTTLE_BACKEND")
+	_ = viper.BindEnv("APIUI.AntiAbuse.TrustProxy", "ISSUER_API_UI_ANTI_ABUSE_TRUST_PROXY")
+	_ = viper.BindEnv("APIUI.SchemaProxyRateLimit", "ISSUER_API_UI_SCHEMA_PROXY_RATE_LIMIT")
+	_ = viper.BindEnv("APIUI.CORS.AllowedOrigins", "ISSUER_API_UI_CORS_ALLOWED_ORIGINS")
+	_ = viper.BindEnv("APIUI.CORS.AllowedMethods", "ISSUER_API_UI_CORS_ALLOWED_METHODS")
+	_ = viper.BindEnv("APIUI.CORS.AllowedHeaders", "ISSUER_API_UI_CORS_ALLOWED_HEADERS")
+	_ = viper.BindEnv("APIUI.CORS.ExposedHeaders", "ISSUER_API_UI_CORS_EXPOSED_HEADERS")
+	_ = viper.BindEnv("APIUI.CORS.AllowCredentials", "ISSUER_API_UI_CORS_ALLOW_CREDENTIALS")
+	_ = viper.BindEnv("APIUI.CORS.MaxAge", "ISSUER_API_UI_CORS_MAX_AGE")
+	_ = viper.BindEnv("APIUI.SecurityHeaders.HSTSMaxAge", "ISSUER_API_UI_SECURITY_HEADERS_HSTS_MAX_AGE")
+	_ = viper.BindEnv("APIUI.SecurityHeaders.HSTSIncludeSubdomains", "ISSUER_API_UI_SECURITY_HEADERS_HSTS_INCLUDE_SUBDOMAINS")
+	_ = viper.BindEnv("APIUI.SecurityHeaders.FrameOptions", "ISSUER_API_UI_SECURITY_HEADERS_FRAME_OPTIONS")
+	_ = viper.BindEnv("APIUI.SecurityHeaders.ReferrerPolicy", "ISSUER_API_UI_SECURITY_HEADERS_REFERRER_POLICY")
 	_ = viper.BindEnv("APIUI.IdentityMethod", "ISSUER_API_IDENTITY_METHOD")
 	_ = viper.BindEnv("APIUI.IdentityBlockchain", "ISSUER_API_IDENTITY_BLOCKCHAIN")
 	_ = viper.BindEnv("APIUI.IdentityNetwork", "ISSUER_API_IDENTITY_NETWORK")
@@ -357,76 +1349,141 @@ func checkEnvVars(ctx context.Context, cfg *Configuration) {
 		log.Info(ctx, "ISSUER_ONCHAIN_CHECK_STATUS_FREQUENCY value is missing")
 	}
 
-	if cfg.Database.URL == "" {
-		log.Info(ctx, "ISSUER_DATABASE_URL value is missing")
+	if cfg.StartupTimeout == 0 {
+		log.Info(ctx, "ISSUER_STARTUP_TIMEOUT value is missing and the server set up it as 2m")
+		cfg.StartupTimeout = 2 * time.Minute
 	}
 
-	if cfg.HTTPBasicAuth.User == "" {
-		log.Info(ctx, "ISSUER_API_AUTH_USER value is missing")
+	if cfg.Timeouts.Request == 0 {
+		log.Info(ctx, "ISSUER_TIMEOUT_REQUEST value is missing, API requests are only bounded by the client")
 	}
 
-	if cfg.HTTPBasicAuth.Password == "" {
-		log.Info(ctx, "ISSUER_API_AUTH_PASSWORD value is missing")
+	if cfg.Timeouts.KeyStore == 0 {
+		log.Info(ctx, "ISSUER_TIMEOUT_KEY_STORE value is missing and the server set up it as 10s")
+		cfg.Timeouts.KeyStore = 10 * time.Second
 	}
 
-	if cfg.KeyStore.Address == "" {
-		log.Info(ctx, "ISSUER_KEY_STORE_ADDRESS value is missing")
+	if cfg.Timeouts.SchemaLoader == 0 {
+		log.Info(ctx, "ISSUER_TIMEOUT_SCHEMA_LOADER value is missing and the server set up it as 30s")
+		cfg.Timeouts.SchemaLoader = 30 * time.Second
 	}
 
-	if cfg.KeyStore.Token == "" {
-		log.Info(ctx, "ISSUER_KEY_STORE_TOKEN value is missing")
+	if cfg.Ingest.Workers <= 0 {
+		log.Info(ctx, "ISSUER_INGEST_WORKERS value is missing and the server set up it as 4")
+		cfg.Ingest.Workers = 4
 	}
 
-	if cfg.KeyStore.PluginIden3MountPath == "" {
-		log.Info(ctx, "ISSUER_KEY_STORE_PLUGIN_IDEN3_MOUNT_PATH value is missing")
+	if cfg.Ingest.QueueDepth <= 0 {
+		log.Info(ctx, "ISSUER_INGEST_QUEUE_DEPTH value is missing and the server set up it as 100")
+		cfg.Ingest.QueueDepth = 100
 	}
 
-	if cfg.Ethereum.URL == "" {
-		log.Info(ctx, "ISSUER_ETHEREUM_URL value is missing")
+	if cfg.CORS.AllowedOrigins == "" {
+		log.Info(ctx, "ISSUER_CORS_ALLOWED_ORIGINS is missing and the server set up it as *")
+		cfg.CORS.AllowedOrigins = "*"
 	}
 
-	if cfg.Ethereum.ContractAddress == "" {
-		log.Info(ctx, "ISSUER_ETHEREUM_CONTRACT_ADDRESS value is missing")
+	if cfg.CORS.AllowedMethods == "" {
+		log.Info(ctx, "ISSUER_CORS_ALLOWED_METHODS is missing and the server set up it as GET,POST,PUT,PATCH,DELETE,HEAD")
+		cfg.CORS.AllowedMethods = "GET,POST,PUT,PATCH,DELETE,HEAD"
 	}
 
-	if cfg.Ethereum.URL == "" {
-		log.Info(ctx, "ISSUER_ETHEREUM_URL value is missing")
+	if cfg.CORS.AllowedHeaders == "" {
+		log.Info(ctx, "ISSUER_CORS_ALLOWED_HEADERS is missing and the server set up it as *")
+		cfg.CORS.AllowedHeaders = "*"
 	}
 
-	if cfg.Ethereum.DefaultGasLimit == 0 {
-		log.Info(ctx, "ISSUER_ETHEREUM_DEFAULT_GAS_LIMIT value is missing")
+	if cfg.SecurityHeaders.FrameOptions == "" {
+		log.Info(ctx, "ISSUER_SECURITY_HEADERS_FRAME_OPTIONS is missing and the server set up it as DENY")
+		cfg.SecurityHeaders.FrameOptions = "DENY"
 	}
 
-	if cfg.Ethereum.ConfirmationTimeout == 0 {
-		log.Info(ctx, "ISSUER_ETHEREUM_CONFIRMATION_TIME_OUT value is missing")
+	if cfg.SecurityHeaders.ReferrerPolicy == "" {
+		log.Info(ctx, "ISSUER_SECURITY_HEADERS_REFERRER_POLICY is missing and the server set up it as no-referrer")
+		cfg.SecurityHeaders.ReferrerPolicy = "no-referrer"
 	}
 
-	if cfg.Ethereum.ConfirmationBlockCount == 0 {
-		log.Info(ctx, "ISSUER_ETHEREUM_CONFIRMATION_BLOCK_COUNT value is missing")
+	if cfg.SchemaLoader.Attempts <= 0 {
+		log.Info(ctx, "ISSUER_SCHEMA_LOADER_ATTEMPTS value is missing and the server set up it as 3")
+		cfg.SchemaLoader.Attempts = 3
 	}
 
-	if cfg.Ethereum.ReceiptTimeout == 0 {
-		log.Info(ctx, "ISSUER_ETHEREUM_RECEIPT_TIMEOUT value is missing")
+	if cfg.SchemaLoader.Backoff == 0 {
+		log.Info(ctx, "ISSUER_SCHEMA_LOADER_BACKOFF value is missing and the server set up it as 200ms")
+		cfg.SchemaLoader.Backoff = 200 * time.Millisecond
 	}
 
-	if cfg.Ethereum.MaxGasPrice == 0 {
-		log.Info(ctx, "ISSUER_ETHEREUM_MAX_GAS_PRICE value is missing or is 0")
+	if cfg.SchemaLoader.MaxBackoff == 0 {
+		log.Info(ctx, "ISSUER_SCHEMA_LOADER_MAX_BACKOFF value is missing and the server set up it as 2s")
+		cfg.SchemaLoader.MaxBackoff = 2 * time.Second
 	}
 
-	if cfg.Ethereum.RPCResponseTimeout == 0 {
-		log.Info(ctx, "ISSUER_ETHEREUM_RPC_RESPONSE_TIMEOUT value is missing")
+	if cfg.SchemaLoader.BreakerFailures > 0 && cfg.SchemaLoader.BreakerCooldown == 0 {
+		log.Info(ctx, "ISSUER_SCHEMA_LOADER_BREAKER_COOLDOWN value is missing and the server set up it as 30s")
+		cfg.SchemaLoader.BreakerCooldown = 30 * time.Second
 	}
 
-	if cfg.Ethereum.WaitReceiptCycleTime == 0 {
-		log.Info(ctx, "ISSUER_ETHEREUM_WAIT_RECEIPT_CYCLE_TIME value is missing")
+	if cfg.Database.URL == "" {
+		log.Info(ctx, "ISSUER_DATABASE_URL value is missing")
 	}
 
-	if cfg.Ethereum.WaitBlockCycleTime == 0 {
-		log.Info(ctx, "ISSUER_ETHEREUM_WAIT_BLOCK_CYCLE_TIME value is missing")
+	if cfg.HTTPBasicAuth.User == "" {
+		log.Info(ctx, "ISSUER_API_AUTH_USER value is missing")
 	}
 
-	if cfg.Ethereum.ResolverPrefix == "" {
-		log.Info(ctx, "ISSUER_ETHEREUM_RESOLVER_PREFIX value is missing")
+	if cfg.HTTPBasicAuth.Password == "" {
+		log.Info(ctx, "ISSUER_API_AUTH_PASSWORD value is missing")
+	}
+
+	if cfg.KeyStore.Backend == "" {
+		log.Info(ctx, "ISSUER_KEY_STORE_BACKEND value is missing and the server set up it as vault")
+		cfg.KeyStore.Backend = KeyStoreVault
+	}
+
+	if cfg.KeyStore.Backend == KeyStoreVault {
+		if cfg.KeyStore.Address == "" {
+			log.Info(ctx, "ISSUER_KEY_STORE_ADDRESS value is missing")
+		}
+
+		if cfg.KeyStore.Token == "" {
+			log.Info(ctx, "ISSUER_KEY_STORE_TOKEN value is missing")
+		}
+
+		if cfg.KeyStore.PluginIden3MountPath == "" {
+			log.Info(ctx, "ISSUER_KEY_STORE_PLUGIN_IDEN3_MOUNT_PATH value is missing")
+		}
+	}
+
+	if cfg.KeyStore.Backend == KeyStoreAWS && cfg.KeyStore.AWS.Region == "" {
+		log.Info(ctx, "ISSUER_KEY_STORE_AWS_REGION value is missing")
+	}
+
+	if cfg.KeyStore.Backend == KeyStoreGCP && cfg.KeyStore.GCP.KeyRing == "" {
+		log.Info(ctx, "ISSUER_KEY_STORE_GCP_KEY_RING value is missing")
+	}
+
+	if cfg.KeyStore.Backend == KeyStoreAzure && cfg.KeyStore.Azure.VaultURL == "" {
+		log.Info(ctx, "ISSUER_KEY_STORE_AZURE_VAULT_URL value is missing")
+	}
+
+	if cfg.KeyStore.Backend == KeyStoreFile {
+		if cfg.KeyStore.File.Path == "" {
+			log.Info(ctx, "ISSUER_KEY_STORE_FILE_PATH value is missing and the server set up it as ./keystore.json")
+			cfg.KeyStore.File.Path = "./keystore.json"
+		}
+		if cfg.KeyStore.File.Passphrase == "" {
+			log.Info(ctx, "ISSUER_KEY_STORE_FILE_PASSPHRASE value is missing")
+		}
+	}
+
+	if cfg.Sandbox {
+		log.Info(ctx, "ISSUER_SANDBOX is enabled, ethereum and reverse hash service settings are ignored")
+		cfg.ReverseHashService.Enabled = false
+		if cfg.Ethereum.ResolverPrefix == "" {
+			cfg.Ethereum.ResolverPrefix = "polygon:mumbai"
+		}
+	} else {
+		checkEthereumEnvVars(ctx, cfg)
 	}
 
 	if cfg.Prover.ServerURL == "" {
@@ -441,15 +1498,192 @@ func checkEnvVars(ctx context.Context, cfg *Configuration) {
 		log.Info(ctx, "ISSUER_CIRCUIT_PATH value is missing")
 	}
 
-	if cfg.Cache.RedisUrl == "" {
+	if cfg.Cache.Backend == "" {
+		log.Info(ctx, "ISSUER_CACHE_BACKEND is missing and the server set up it as redis")
+		cfg.Cache.Backend = CacheRedis
+	}
+
+	if cfg.Cache.Backend == CacheRedis && cfg.Cache.RedisUrl == "" {
 		log.Info(ctx, "ISSUER_REDIS_URL value is missing")
 	}
 
+	if cfg.Cache.CleanupPeriod == 0 {
+		log.Info(ctx, "ISSUER_CACHE_CLEANUP_PERIOD is missing and the server set up it as 1m")
+		cfg.Cache.CleanupPeriod = time.Minute
+	}
+
 	if cfg.SchemaCache == nil {
 		log.Info(ctx, "ISSUER_SCHEMA_CACHE is missing and the server set up it as false")
 		cfg.SchemaCache = common.ToPointer(false)
 	}
 
+	if cfg.JSONLD.Contexts == "" {
+		log.Info(ctx, "ISSUER_JSONLD_CONTEXTS is missing and the server set up it as local-first")
+		cfg.JSONLD.Contexts = "local-first"
+	}
+
+	if cfg.JSONLD.SafeMode == nil {
+		log.Info(ctx, "ISSUER_JSONLD_SAFE_MODE is missing and the server set up it as true")
+		cfg.JSONLD.SafeMode = common.ToPointer(true)
+	}
+
+	if cfg.IPFS.URL == "" && cfg.IPFS.GatewayURL == "" {
+		log.Info(ctx, "ISSUER_IPFS_URL and ISSUER_IPFS_GATEWAY_URL are missing and the server set up the gateway as https://ipfs.io")
+		cfg.IPFS.GatewayURL = "https://ipfs.io"
+	}
+
+	if cfg.IPFS.PinImported && cfg.IPFS.URL == "" {
+		log.Info(ctx, "ISSUER_IPFS_PIN_IMPORTED needs ISSUER_IPFS_URL, the imported schemas are not pinned")
+		cfg.IPFS.PinImported = false
+	}
+
+	if cfg.Maintenance.RetryAfter == 0 {
+		log.Info(ctx, "ISSUER_MAINTENANCE_RETRY_AFTER is missing and the server set up it as 1m")
+		cfg.Maintenance.RetryAfter = time.Minute
+	}
+
+	if cfg.Maintenance.Refresh == 0 {
+		log.Info(ctx, "ISSUER_MAINTENANCE_REFRESH is missing and the server set up it as 5s")
+		cfg.Maintenance.Refresh = 5 * time.Second
+	}
+
+	if cfg.Region.Refresh == 0 {
+		log.Info(ctx, "ISSUER_REGION_REFRESH is missing and the server set up it as 5s")
+		cfg.Region.Refresh = 5 * time.Second
+	}
+
+	if cfg.Region.LeaseTTL == 0 {
+		log.Info(ctx, "ISSUER_REGION_LEASE_TTL is missing and the server set up it as 30s")
+		cfg.Region.LeaseTTL = 30 * time.Second
+	}
+
+	if cfg.AgentReplayWindow == 0 {
+		log.Info(ctx, "ISSUER_AGENT_REPLAY_WINDOW is missing and the server set up it as 1h")
+		cfg.AgentReplayWindow = time.Hour
+	}
+
+	if cfg.SigningKeySelection == "" {
+		log.Info(ctx, "ISSUER_SIGNING_KEY_SELECTION is missing and the server set up it as default")
+		cfg.SigningKeySelection = SigningKeySelectionDefault
+	}
+
+	if cfg.ClockSkew == 0 {
+		log.Info(ctx, "ISSUER_CLOCK_SKEW is missing and the server set up it as 30s")
+		cfg.ClockSkew = 30 * time.Second
+	} else if cfg.ClockSkew < 0 {
+		cfg.ClockSkew = 0
+	}
+
+	if cfg.PayloadStore.Backend != "" && cfg.PayloadStore.Threshold == 0 {
+		log.Info(ctx, "ISSUER_PAYLOAD_STORE_THRESHOLD is missing and the server set up it as 16384")
+		cfg.PayloadStore.Threshold = 16384
+	}
+
+	if cfg.PayloadStore.Backend != "" && cfg.PayloadStore.CleanupPeriod == 0 {
+		log.Info(ctx, "ISSUER_PAYLOAD_STORE_CLEANUP_PERIOD is missing and the server set up it as 1h")
+		cfg.PayloadStore.CleanupPeriod = time.Hour
+	}
+
+	if cfg.TrustRegistry.Backend != "" && cfg.TrustRegistry.CacheTTL == 0 {
+		log.Info(ctx, "ISSUER_TRUST_REGISTRY_CACHE_TTL is missing and the server set up it as 1h")
+		cfg.TrustRegistry.CacheTTL = time.Hour
+	}
+
+	if cfg.PolicyHook.URL != "" && cfg.PolicyHook.Timeout == 0 {
+		log.Info(ctx, "ISSUER_POLICY_HOOK_TIMEOUT is missing and the server set up it as 5s")
+		cfg.PolicyHook.Timeout = 5 * time.Second
+	}
+
+	if cfg.VerificationWebhook.URL != "" && cfg.VerificationWebhook.Timeout == 0 {
+		log.Info(ctx, "ISSUER_VERIFICATION_WEBHOOK_TIMEOUT is missing and the server set up it as 10s")
+		cfg.VerificationWebhook.Timeout = 10 * time.Second
+	}
+
+	if cfg.SchemaWebhooks.Timeout == 0 {
+		log.Info(ctx, "ISSUER_SCHEMA_WEBHOOKS_TIMEOUT is missing and the server set up it as 10s")
+		cfg.SchemaWebhooks.Timeout = 10 * time.Second
+	}
+
+	if cfg.StateCache.TTL == 0 {
+		log.Info(ctx, "ISSUER_STATE_CACHE_TTL is missing and the server set up it as 30s")
+		cfg.StateCache.TTL = 30 * time.Second
+	}
+
+	if cfg.StateCache.StaleTTL == 0 {
+		log.Info(ctx, "ISSUER_STATE_CACHE_STALE_TTL is missing and the server set up it as 2m")
+		cfg.StateCache.StaleTTL = 2 * time.Minute
+	}
+
+	if cfg.PushGateways.URLs != "" && cfg.PushGateways.HealthCheckPeriod == 0 {
+		log.Info(ctx, "ISSUER_PUSH_GATEWAYS_HEALTH_CHECK_PERIOD is missing and the server set up it as 30s")
+		cfg.PushGateways.HealthCheckPeriod = 30 * time.Second
+	}
+
+	if cfg.Alerts.Period == 0 {
+		log.Info(ctx, "ISSUER_ALERTS_PERIOD is missing and the server set up it as 1m")
+		cfg.Alerts.Period = time.Minute
+	}
+
+	if cfg.Alerts.Repeat == 0 {
+		log.Info(ctx, "ISSUER_ALERTS_REPEAT is missing and the server set up it as 1h")
+		cfg.Alerts.Repeat = time.Hour
+	}
+
+	if cfg.Alerts.BacklogSize == 0 {
+		log.Info(ctx, "ISSUER_ALERTS_BACKLOG_SIZE is missing and the server set up it as 1000")
+		cfg.Alerts.BacklogSize = 1000
+	}
+
+	if cfg.Alerts.BacklogAge == 0 {
+		log.Info(ctx, "ISSUER_ALERTS_BACKLOG_AGE is missing and the server set up it as 30m")
+		cfg.Alerts.BacklogAge = 30 * time.Minute
+	}
+
+	if cfg.Alerts.Timeout == 0 {
+		log.Info(ctx, "ISSUER_ALERTS_TIMEOUT is missing and the server set up it as 10s")
+		cfg.Alerts.Timeout = 10 * time.Second
+	}
+
+	if cfg.Branding.DisplayName == "" {
+		log.Info(ctx, "ISSUER_BRANDING_DISPLAY_NAME is missing and the server set up it as ISSUER_API_UI_ISSUER_NAME")
+		cfg.Branding.DisplayName = cfg.APIUI.IssuerName
+	}
+
+	if cfg.Branding.LogoURL == "" {
+		log.Info(ctx, "ISSUER_BRANDING_LOGO_URL is missing and the server set up it as ISSUER_API_UI_ISSUER_LOGO")
+		cfg.Branding.LogoURL = cfg.APIUI.IssuerLogo
+	}
+
+	if cfg.Branding.PrimaryColor == "" {
+		log.Info(ctx, "ISSUER_BRANDING_PRIMARY_COLOR is missing and the server set up it as "+domain.DefaultBrandingPrimaryColor)
+		cfg.Branding.PrimaryColor = domain.DefaultBrandingPrimaryColor
+	}
+
+	if cfg.Branding.TextColor == "" {
+		log.Info(ctx, "ISSUER_BRANDING_TEXT_COLOR is missing and the server set up it as "+domain.DefaultBrandingTextColor)
+		cfg.Branding.TextColor = domain.DefaultBrandingTextColor
+	}
+
+	if cfg.SessionStore.Backend == "" && cfg.Cache.Backend == CachePostgres {
+		log.Info(ctx, "ISSUER_SESSION_STORE_BACKEND is missing and the server set up it as postgres, like the cache")
+		cfg.SessionStore.Backend = SessionStorePostgres
+	}
+
+	if cfg.SessionStore.Backend == "" {
+		log.Info(ctx, "ISSUER_SESSION_STORE_BACKEND is missing and the server set up it as redis")
+		cfg.SessionStore.Backend = SessionStoreRedis
+	}
+
+	if cfg.SessionStore.AuthRequestTTL == 0 {
+		log.Info(ctx, "ISSUER_SESSION_STORE_AUTH_REQUEST_TTL is missing and the server set up it as 5m")
+		cfg.SessionStore.AuthRequestTTL = 5 * time.Minute
+	}
+
+	if cfg.SessionStore.LinkStateTTL == 0 {
+		log.Info(ctx, "ISSUER_SESSION_STORE_LINK_STATE_TTL is missing and the server set up it as 5m")
+		cfg.SessionStore.LinkStateTTL = 5 * time.Minute
+	}
+
 	if cfg.APIUI.ServerPort == 0 {
 		log.Info(ctx, "ISSUER_API_UI_SERVER_PORT value is missing")
 	}
@@ -479,6 +1713,41 @@ func checkEnvVars(ctx context.Context, cfg *Configuration) {
 		cfg.APIUI.SchemaCache = common.ToPointer(false)
 	}
 
+	if cfg.APIUI.SchemaProxyRateLimit == 0 {
+		log.Info(ctx, "ISSUER_API_UI_SCHEMA_PROXY_RATE_LIMIT is missing and the server set up it as 60")
+		cfg.APIUI.SchemaProxyRateLimit = 60
+	}
+
+	if cfg.APIUI.AntiAbuse.ThrottleBackend == "" {
+		log.Info(ctx, "ISSUER_API_UI_ANTI_ABUSE_THROTTLE_BACKEND is missing and the server set up it as memory")
+		cfg.APIUI.AntiAbuse.ThrottleBackend = ThrottleMemory
+	}
+
+	if cfg.APIUI.CORS.AllowedOrigins == "" {
+		log.Info(ctx, "ISSUER_API_UI_CORS_ALLOWED_ORIGINS is missing and the server set up it as *")
+		cfg.APIUI.CORS.AllowedOrigins = "*"
+	}
+
+	if cfg.APIUI.CORS.AllowedMethods == "" {
+		log.Info(ctx, "ISSUER_API_UI_CORS_ALLOWED_METHODS is missing and the server set up it as GET,POST,PUT,PATCH,DELETE,HEAD")
+		cfg.APIUI.CORS.AllowedMethods = "GET,POST,PUT,PATCH,DELETE,HEAD"
+	}
+
+	if cfg.APIUI.CORS.AllowedHeaders == "" {
+		log.Info(ctx, "ISSUER_API_UI_CORS_ALLOWED_HEADERS is missing and the server set up it as *")
+		cfg.APIUI.CORS.AllowedHeaders = "*"
+	}
+
+	if cfg.APIUI.SecurityHeaders.FrameOptions == "" {
+		log.Info(ctx, "ISSUER_API_UI_SECURITY_HEADERS_FRAME_OPTIONS is missing and the server set up it as DENY")
+		cfg.APIUI.SecurityHeaders.FrameOptions = "DENY"
+	}
+
+	if cfg.APIUI.SecurityHeaders.ReferrerPolicy == "" {
+		log.Info(ctx, "ISSUER_API_UI_SECURITY_HEADERS_REFERRER_POLICY is missing and the server set up it as no-referrer")
+		cfg.APIUI.SecurityHeaders.ReferrerPolicy = "no-referrer"
+	}
+
 	if cfg.APIUI.IdentityMethod == "" {
 		log.Info(ctx, "ISSUER_API_IDENTITY_METHOD value is missing and the server set up it as polygonid")
 		cfg.APIUI.IdentityMethod = "polygonid"
@@ -495,6 +1764,56 @@ func checkEnvVars(ctx context.Context, cfg *Configuration) {
 	}
 }
 
+func checkEthereumEnvVars(ctx context.Context, cfg *Configuration) {
+	if cfg.Ethereum.URL == "" {
+		log.Info(ctx, "ISSUER_ETHEREUM_URL value is missing")
+	}
+
+	if cfg.Ethereum.ContractAddress == "" {
+		log.Info(ctx, "ISSUER_ETHEREUM_CONTRACT_ADDRESS value is missing")
+	}
+
+	if cfg.Ethereum.URL == "" {
+		log.Info(ctx, "ISSUER_ETHEREUM_URL value is missing")
+	}
+
+	if cfg.Ethereum.DefaultGasLimit == 0 {
+		log.Info(ctx, "ISSUER_ETHEREUM_DEFAULT_GAS_LIMIT value is missing")
+	}
+
+	if cfg.Ethereum.ConfirmationTimeout == 0 {
+		log.Info(ctx, "ISSUER_ETHEREUM_CONFIRMATION_TIME_OUT value is missing")
+	}
+
+	if cfg.Ethereum.ConfirmationBlockCount == 0 {
+		log.Info(ctx, "ISSUER_ETHEREUM_CONFIRMATION_BLOCK_COUNT value is missing")
+	}
+
+	if cfg.Ethereum.ReceiptTimeout == 0 {
+		log.Info(ctx, "ISSUER_ETHEREUM_RECEIPT_TIMEOUT value is missing")
+	}
+
+	if cfg.Ethereum.MaxGasPrice == 0 {
+		log.Info(ctx, "ISSUER_ETHEREUM_MAX_GAS_PRICE value is missing or is 0")
+	}
+
+	if cfg.Ethereum.RPCResponseTimeout == 0 {
+		log.Info(ctx, "ISSUER_ETHEREUM_RPC_RESPONSE_TIMEOUT value is missing")
+	}
+
+	if cfg.Ethereum.WaitReceiptCycleTime == 0 {
+		log.Info(ctx, "ISSUER_ETHEREUM_WAIT_RECEIPT_CYCLE_TIME value is missing")
+	}
+
+	if cfg.Ethereum.WaitBlockCycleTime == 0 {
+		log.Info(ctx, "ISSUER_ETHEREUM_WAIT_BLOCK_CYCLE_TIME value is missing")
+	}
+
+	if cfg.Ethereum.ResolverPrefix == "" {
+		log.Info(ctx, "ISSUER_ETHEREUM_RESOLVER_PREFIX value is missing")
+	}
+}
+
 func getWorkingDirectory() string {
 	_, b, _, _ := runtime.Caller(0)
 	return filepath.Join(filepath.Dir(b), "../..") + "/"
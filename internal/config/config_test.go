@@ -1,9 +1,15 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
 )
 
 func TestLookupVaultTokenFromFile(t *testing.T) {
@@ -95,6 +101,22 @@ func TestConfiguration_validateServerUrl(t *testing.T) {
 				error: false,
 			},
 		},
+		{
+			name: "IPv6 url",
+			url:  "http://[2001:db8::1]:3001/",
+			expected: expected{
+				url:   "http://[2001:db8::1]:3001",
+				error: false,
+			},
+		},
+		{
+			name: "IPv6 url without brackets",
+			url:  "http://2001:db8::1:3001",
+			expected: expected{
+				url:   "http://2001:db8::1:3001",
+				error: true,
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			cfg := &Configuration{
@@ -110,3 +132,144 @@ func TestConfiguration_validateServerUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestListenAddresses(t *testing.T) {
+	for _, tc := range []struct {
+		bind     string
+		expected []string
+	}{
+		{bind: "", expected: []string{":3001"}},
+		{bind: "0.0.0.0", expected: []string{"0.0.0.0:3001"}},
+		{bind: "::", expected: []string{"[::]:3001"}},
+		{bind: "[2001:db8::1], 10.0.0.1", expected: []string{"[2001:db8::1]:3001", "10.0.0.1:3001"}},
+	} {
+		addresses, err := ListenAddresses(tc.bind, 3001)
+		assert.NoError(t, err, tc.bind)
+		assert.Equal(t, tc.expected, addresses, tc.bind)
+	}
+
+	for _, bind := range []string{"localhost", "2001:db8::1:3001:x", "10.0.0.1,"} {
+		_, err := ListenAddresses(bind, 3001)
+		assert.Error(t, err, bind)
+	}
+}
+
+func TestCORS_validate(t *testing.T) {
+	assert.NoError(t, CORS{AllowedOrigins: "*"}.validate())
+	assert.NoError(t, CORS{AllowedOrigins: "https://ui.example.com,https://admin.example.com", AllowCredentials: true}.validate())
+	assert.Error(t, CORS{AllowedOrigins: "*", AllowCredentials: true}.validate())
+	assert.Error(t, CORS{AllowedOrigins: "https://ui.example.com, https://*.example.com", AllowCredentials: true}.validate())
+}
+
+func TestSecurityHeaders_validate(t *testing.T) {
+	assert.NoError(t, SecurityHeaders{FrameOptions: "sameorigin"}.validate())
+	assert.Error(t, SecurityHeaders{FrameOptions: "ALLOW-FROM https://ui.example.com"}.validate())
+	assert.Error(t, SecurityHeaders{HSTSMaxAge: -time.Second}.validate())
+}
+
+func TestConfiguration_Networks(t *testing.T) {
+	cfg := Configuration{
+		Ethereum: Ethereum{
+			URL:                    "https://polygon-rpc.com",
+			ContractAddress:        "0x624ce98D2d27b20b8f8d521723Df8fC4db71D79D",
+			DefaultGasLimit:        600000,
+			ConfirmationBlockCount: 5,
+			ResolverPrefix:         "polygon:main",
+		},
+		ReverseHashService: ReverseHashService{URL: "https://rhs.example.com", Enabled: true},
+	}
+
+	networks, err := cfg.Networks()
+	require.NoError(t, err)
+	assert.Equal(t, Networks{"polygon:main": {Ethereum: cfg.Ethereum, ReverseHashService: cfg.ReverseHashService}}, networks)
+
+	cfg.NetworksFile = filepath.Join(t.TempDir(), "networks.yaml")
+	require.NoError(t, os.WriteFile(cfg.NetworksFile, []byte(`
+polygon:
+  main:
+    url: https://polygon-rpc.com
+    contractAddress: "0x624ce98D2d27b20b8f8d521723Df8fC4db71D79D"
+  amoy:
+    url: https://rpc-amoy.polygon.technology
+    contractAddress: "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124"
+    confirmationBlockCount: 10
+    reverseHashServiceUrl: https://rhs-amoy.example.com
+`), 0o600))
+	networks, err = cfg.Networks()
+	require.NoError(t, err)
+	require.Len(t, networks, 2)
+	assert.Equal(t, cfg.Ethereum, networks["polygon:main"].Ethereum)
+	amoy := networks["polygon:amoy"]
+	assert.Equal(t, "polygon:amoy", amoy.Ethereum.ResolverPrefix)
+	assert.Equal(t, "https://rpc-amoy.polygon.technology", amoy.Ethereum.URL)
+	assert.Equal(t, "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124", amoy.Ethereum.ContractAddress)
+	assert.Equal(t, int64(10), amoy.Ethereum.ConfirmationBlockCount)
+	assert.Equal(t, 600000, amoy.Ethereum.DefaultGasLimit)
+	assert.Equal(t, map[string]string{"polygon:main": "https://rhs.example.com", "polygon:amoy": "https://rhs-amoy.example.com"}, networks.RHSUrls())
+
+	require.NoError(t, os.WriteFile(cfg.NetworksFile, []byte(`
+acme:
+  private:
+    url: http://geth.acme.internal:8545
+    contractAddress: "0x134B1BE34911E39A8397ec6289782989729807a4"
+    chainID: 4242
+    didMethods:
+      polygonid: 0xe1
+`), 0o600))
+	networks, err = cfg.Networks()
+	require.NoError(t, err)
+	private := networks["acme:private"]
+	assert.Equal(t, "acme:private", private.Ethereum.ResolverPrefix)
+	assert.Equal(t, int64(4242), private.ChainID)
+	assert.Equal(t, map[string]byte{"polygonid": 0xe1}, private.DIDMethods)
+
+	require.NoError(t, os.WriteFile(cfg.NetworksFile, []byte("acme:\n  private:\n    url: http://geth.acme.internal:8545\n    contractAddress: \"0x134B1BE34911E39A8397ec6289782989729807a4\"\n    didMethods:\n      polygonid: 0\n"), 0o600))
+	_, err = cfg.Networks()
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(cfg.NetworksFile, []byte("polygon:\n  amoy:\n    url: https://rpc-amoy.polygon.technology\n"), 0o600))
+	_, err = cfg.Networks()
+	assert.Error(t, err)
+
+	cfg.NetworksFile = filepath.Join(t.TempDir(), "missing.yaml")
+	_, err = cfg.Networks()
+	assert.Error(t, err)
+}
+
+func TestAPIUI_tenants(t *testing.T) {
+	apiUI := APIUI{APIUIAuth: APIUIAuth{User: "admin", Password: "secret"}}
+	tenants, err := apiUI.tenants()
+	require.NoError(t, err)
+	assert.Empty(t, tenants)
+
+	apiUI.TenantsFile = filepath.Join(t.TempDir(), "tenants.yaml")
+	require.NoError(t, os.WriteFile(apiUI.TenantsFile, []byte(`
+did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5:
+  user: acme
+  password: acme-secret
+`), 0o600))
+	tenants, err = apiUI.tenants()
+	require.NoError(t, err)
+	require.Len(t, tenants, 1)
+	assert.Equal(t, "did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5", tenants[0].DID.String())
+	assert.Equal(t, "acme", tenants[0].User)
+	assert.Equal(t, "acme-secret", tenants[0].Password)
+	assert.Equal(t, domain.RoleReadOnly, tenants[0].Role, "a tenant without a role only reads")
+
+	require.NoError(t, os.WriteFile(apiUI.TenantsFile, []byte("did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5:\n  user: acme\n  password: acme-secret\n  role: admin\n"), 0o600))
+	tenants, err = apiUI.tenants()
+	require.NoError(t, err)
+	require.Len(t, tenants, 1)
+	assert.Equal(t, domain.RoleAdmin, tenants[0].Role)
+
+	for _, content := range []string{
+		"did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5:\n  user: admin\n  password: other\n",
+		"did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5:\n  user: acme\n",
+		"not-a-did:\n  user: acme\n  password: acme-secret\n",
+		"did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5:\n  user: acme\n  password: acme-secret\n  role: owner\n",
+	} {
+		require.NoError(t, os.WriteFile(apiUI.TenantsFile, []byte(content), 0o600))
+		_, err = apiUI.tenants()
+		assert.Error(t, err, content)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 
 	core "github.com/iden3/go-iden3-core"
 	"github.com/iden3/go-merkletree-sql/v2"
@@ -140,6 +141,43 @@ func (imts *IdentityMerkleTrees) AddClaim(ctx context.Context, c *Claim) error {
 	return nil
 }
 
+// AddClaims adds the claims into the claims MerkleTree. They are inserted in the order of their paths in the tree,
+// so consecutive insertions share most of the nodes they read and write.
+func (imts *IdentityMerkleTrees) AddClaims(ctx context.Context, claims []Claim) error {
+	if len(imts.Trees) < mtTypesCount {
+		return errorMsgNotCreated
+	}
+
+	type leaf struct{ hi, hv *big.Int }
+	leaves := make([]leaf, len(claims))
+	for i := range claims {
+		coreClaim := claims[i].CoreClaim.Get()
+		hi, hv, err := coreClaim.HiHv()
+		if err != nil {
+			return fmt.Errorf("error getting index and value: %w", err)
+		}
+		leaves[i] = leaf{hi: hi, hv: hv}
+	}
+	sort.Slice(leaves, func(i, j int) bool { return treePathLess(leaves[i].hi, leaves[j].hi) })
+
+	for _, l := range leaves {
+		if err := imts.Trees[MerkleTreeTypeClaims].Add(ctx, l.hi, l.hv); err != nil {
+			return fmt.Errorf("cannot add entry to claims merkle tree: %w", err)
+		}
+	}
+	return nil
+}
+
+// treePathLess compares two indexes by their path in a merkle tree, which follows the bits of the index
+// starting from the least significant one.
+func treePathLess(a, b *big.Int) bool {
+	diff := new(big.Int).Xor(a, b)
+	if diff.Sign() == 0 {
+		return false
+	}
+	return a.Bit(int(diff.TrailingZeroBits())) == 0
+}
+
 // RevsTree returns revocations merkle tree
 func (imts *IdentityMerkleTrees) RevsTree() (*merkletree.MerkleTree, error) {
 	if len(imts.Trees) < mtTypesCount {
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"testing"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-merkletree-sql/v2/db/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTreePathLess(t *testing.T) {
+	indexes := []*big.Int{big.NewInt(3), big.NewInt(1), big.NewInt(2), big.NewInt(4)}
+	sort.Slice(indexes, func(i, j int) bool { return treePathLess(indexes[i], indexes[j]) })
+	assert.Equal(t, []*big.Int{big.NewInt(4), big.NewInt(2), big.NewInt(1), big.NewInt(3)}, indexes)
+	assert.False(t, treePathLess(big.NewInt(5), big.NewInt(5)))
+}
+
+func TestIdentityMerkleTrees_AddClaims(t *testing.T) {
+	ctx := context.Background()
+	newTrees := func() *IdentityMerkleTrees {
+		trees := make([]*merkletree.MerkleTree, mtTypesCount)
+		for i := range trees {
+			tree, err := merkletree.NewMerkleTree(ctx, memory.NewMemoryStorage(), 40)
+			require.NoError(t, err)
+			trees[i] = tree
+		}
+		return &IdentityMerkleTrees{Trees: trees}
+	}
+
+	claims := make([]Claim, 10)
+	for i := range claims {
+		coreClaim, err := core.NewClaim(core.SchemaHash{}, core.WithIndexDataInts(big.NewInt(int64(i)), nil), core.WithRevocationNonce(uint64(i)))
+		require.NoError(t, err)
+		claims[i].CoreClaim = CoreClaim(*coreClaim)
+	}
+
+	oneByOne := newTrees()
+	for i := range claims {
+		require.NoError(t, oneByOne.AddClaim(ctx, &claims[i]))
+	}
+	sorted := newTrees()
+	require.NoError(t, sorted.AddClaims(ctx, claims))
+
+	assert.Equal(t, oneByOne.Trees[MerkleTreeTypeClaims].Root(), sorted.Trees[MerkleTreeTypeClaims].Root())
+}
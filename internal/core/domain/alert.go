@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+)
+
+// AlertKind is the kind of operational failure an alert is about
+type AlertKind string
+
+const (
+	// AlertPublishFailed is raised for the identities whose last state transaction failed. Their credentials are not
+	// published until the state is published again.
+	AlertPublishFailed AlertKind = "publishFailed"
+	// AlertRHSDivergence is raised for the identities whose last confirmed state is missing or different in the reverse
+	// hash service, so the holders can't prove their credentials are not revoked
+	AlertRHSDivergence AlertKind = "rhsDivergence"
+	// AlertKeyStoreUnavailable is raised when the key store can't be reached or its credentials are lost or about to
+	// expire, so nothing can be signed
+	AlertKeyStoreUnavailable AlertKind = "keyStoreUnavailable"
+	// AlertBacklog is raised when the credentials waiting for the publication of a state, or the state transactions
+	// waiting for their confirmation, pile up over the thresholds
+	AlertBacklog AlertKind = "backlog"
+)
+
+// Alert tells the operators of the node about an operational failure of the background work, before the holders
+// notice it. An alert is sent when it is raised, again while it lasts, and once more when it is resolved.
+type Alert struct {
+	Kind AlertKind
+	// Subject is what failed, like the DID of an identity. With the kind it identifies the alert.
+	Subject  string
+	Message  string
+	Resolved bool
+	Since    time.Time // Since is when the alert was raised
+	At       time.Time // At is when the alert was sent
+}
+
+// Key identifies the alert among the ones raised
+func (a Alert) Key() string {
+	return string(a.Kind) + " " + a.Subject
+}
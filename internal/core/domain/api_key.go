@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// apiKeySecretSize is the number of random bytes of an api key secret
+const apiKeySecretSize = 32
+
+// ErrInvalidAPIKey is returned by NewAPIKey
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// APIKey is a delegated key of an issuer for a third party. It authenticates in the UI API with its id as user and
+// its secret as password and it can only issue credentials, of its schema types and through its links when it has them.
+// A key with a role can call the operations of the role on its issuer instead. Only the hash of the secret is kept.
+type APIKey struct {
+	ID         uuid.UUID
+	IssuerDID  core.DID
+	Name       string
+	SecretHash []byte
+	// Role is what the key can call. Empty is a delegated key that can only issue credentials.
+	Role Role
+	// SchemaTypes are the types of the schemas of the credentials that the key can issue. Empty is any schema.
+	SchemaTypes []string
+	// LinkIDs are the links that the key issues the credentials through. Empty allows issuing without links.
+	LinkIDs   []uuid.UUID
+	CreatedAt time.Time
+}
+
+// NewAPIKey returns a new api key and its secret
+func NewAPIKey(issuerDID core.DID, name string, role Role, schemaTypes []string, linkIDs []uuid.UUID) (*APIKey, string, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, "", fmt.Errorf("%w: the name is empty", ErrInvalidAPIKey)
+	}
+	if role != "" {
+		if _, err := ParseRole(string(role)); err != nil {
+			return nil, "", fmt.Errorf("%w: %v", ErrInvalidAPIKey, err)
+		}
+	}
+	for _, schemaType := range schemaTypes {
+		if strings.TrimSpace(schemaType) == "" {
+			return nil, "", fmt.Errorf("%w: empty schema type", ErrInvalidAPIKey)
+		}
+	}
+	secret := make([]byte, apiKeySecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(secret)
+	return &APIKey{
+		ID:          uuid.New(),
+		IssuerDID:   issuerDID,
+		Name:        name,
+		SecretHash:  hashAPIKeySecret(encoded),
+		Role:        role,
+		SchemaTypes: schemaTypes,
+		LinkIDs:     linkIDs,
+		CreatedAt:   time.Now(),
+	}, encoded, nil
+}
+
+// VerifySecret tells whether secret is the secret of the key
+func (k *APIKey) VerifySecret(secret string) bool {
+	return subtle.ConstantTimeCompare(k.SecretHash, hashAPIKeySecret(secret)) == 1
+}
+
+// AllowsIssuance tells whether the key can issue a credential of the schema type through the link, nil when the
+// credential is not issued through a link. The schema types are compared without their JSON-LD context, so
+// https://example.com/kyc-v3.json-ld#KYCAgeCredential matches KYCAgeCredential.
+func (k *APIKey) AllowsIssuance(schemaType string, linkID *uuid.UUID) bool {
+	if len(k.SchemaTypes) > 0 {
+		allowed := false
+		for _, item := range k.SchemaTypes {
+			if shortSchemaType(item) == shortSchemaType(schemaType) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	if len(k.LinkIDs) == 0 {
+		return true
+	}
+	if linkID == nil {
+		return false
+	}
+	for _, item := range k.LinkIDs {
+		if item == *linkID {
+			return true
+		}
+	}
+	return false
+}
+
+func hashAPIKeySecret(secret string) []byte {
+	hash := sha256.Sum256([]byte(secret))
+	return hash[:]
+}
+
+func shortSchemaType(schemaType string) string {
+	return schemaType[strings.LastIndex(schemaType, "#")+1:]
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	did, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+
+	key, secret, err := NewAPIKey(*did, "partner", "", []string{"KYCAgeCredential"}, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, secret)
+	assert.NotContains(t, string(key.SecretHash), secret)
+	assert.True(t, key.VerifySecret(secret))
+	assert.False(t, key.VerifySecret(secret+"x"))
+	assert.False(t, key.VerifySecret(""))
+
+	_, other, err := NewAPIKey(*did, "partner", "", nil, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	support, _, err := NewAPIKey(*did, "support", RoleReadOnly, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, RoleReadOnly, support.Role)
+
+	_, _, err = NewAPIKey(*did, " ", "", nil, nil)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	_, _, err = NewAPIKey(*did, "partner", "", []string{""}, nil)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	_, _, err = NewAPIKey(*did, "partner", "owner", nil, nil)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+}
+
+func TestAPIKeyAllowsIssuance(t *testing.T) {
+	link, otherLink := uuid.New(), uuid.New()
+	for _, tc := range []struct {
+		name       string
+		key        APIKey
+		schemaType string
+		linkID     *uuid.UUID
+		expected   bool
+	}{
+		{name: "unrestricted", key: APIKey{}, schemaType: "KYCAgeCredential", expected: true},
+		{name: "unrestricted through a link", key: APIKey{}, schemaType: "KYCAgeCredential", linkID: &link, expected: true},
+		{name: "allowed schema type", key: APIKey{SchemaTypes: []string{"KYCAgeCredential"}}, schemaType: "KYCAgeCredential", expected: true},
+		{name: "allowed schema type with context", key: APIKey{SchemaTypes: []string{"KYCAgeCredential"}}, schemaType: "https://example.com/kyc-v3.json-ld#KYCAgeCredential", expected: true},
+		{name: "other schema type", key: APIKey{SchemaTypes: []string{"KYCAgeCredential"}}, schemaType: "KYCCountryOfResidenceCredential", expected: false},
+		{name: "allowed link", key: APIKey{LinkIDs: []uuid.UUID{link}}, schemaType: "KYCAgeCredential", linkID: &link, expected: true},
+		{name: "other link", key: APIKey{LinkIDs: []uuid.UUID{link}}, schemaType: "KYCAgeCredential", linkID: &otherLink, expected: false},
+		{name: "without link", key: APIKey{LinkIDs: []uuid.UUID{link}}, schemaType: "KYCAgeCredential", expected: false},
+		{name: "allowed link of other schema type", key: APIKey{SchemaTypes: []string{"KYCAgeCredential"}, LinkIDs: []uuid.UUID{link}}, schemaType: "Membership", linkID: &link, expected: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.key.AllowsIssuance(tc.schemaType, tc.linkID))
+		})
+	}
+}
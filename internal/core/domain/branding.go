@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// Colors of the credential cards of the node when they are not configured
+const (
+	DefaultBrandingPrimaryColor = "#6c4ce0"
+	DefaultBrandingTextColor    = "#ffffff"
+)
+
+// ErrInvalidBranding is returned by BrandingOverrides.Validate
+var ErrInvalidBranding = errors.New("invalid branding")
+
+var brandingColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Branding is how an identity is presented to the holders: in the credential offers of its links, the cards of its
+// credentials and the issuer metadata of the node
+type Branding struct {
+	DisplayName string
+	LogoURL     string
+	// PrimaryColor is the background of the credential cards, and TextColor the color of their texts
+	PrimaryColor string
+	TextColor    string
+}
+
+// BrandingOverrides is the branding of an identity that replaces the one of the node. Nil keeps the node branding,
+// and an empty display name or logo removes it for the identity.
+type BrandingOverrides struct {
+	DisplayName  *string
+	LogoURL      *string
+	PrimaryColor *string
+	TextColor    *string
+}
+
+// With returns the branding with the overrides of an identity applied
+func (b Branding) With(overrides BrandingOverrides) Branding {
+	if overrides.DisplayName != nil {
+		b.DisplayName = *overrides.DisplayName
+	}
+	if overrides.LogoURL != nil {
+		b.LogoURL = *overrides.LogoURL
+	}
+	if overrides.PrimaryColor != nil {
+		b.PrimaryColor = *overrides.PrimaryColor
+	}
+	if overrides.TextColor != nil {
+		b.TextColor = *overrides.TextColor
+	}
+	return b
+}
+
+// Apply returns a copy of the card template with the parts of its design it leaves empty taken from the branding
+func (b Branding) Apply(t DisplayTemplate) DisplayTemplate {
+	if t.IssuerName == "" {
+		t.IssuerName = b.DisplayName
+	}
+	if t.Logo.URI == "" {
+		t.Logo = DisplayLogo{URI: b.LogoURL, Alt: b.DisplayName}
+	}
+	if t.BackgroundColor == "" {
+		t.BackgroundColor = b.PrimaryColor
+	}
+	for _, color := range []*string{&t.TitleTextColor, &t.DescriptionTextColor, &t.IssuerTextColor} {
+		if *color == "" {
+			*color = b.TextColor
+		}
+	}
+	return t
+}
+
+// Validate checks that the logo is an http or https url and that the colors are hex colors like #6c4ce0
+func (o BrandingOverrides) Validate() error {
+	if o.LogoURL != nil && *o.LogoURL != "" {
+		u, err := url.ParseRequestURI(*o.LogoURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: the logo must be an http or https url <%s>", ErrInvalidBranding, *o.LogoURL)
+		}
+	}
+	for _, color := range []*string{o.PrimaryColor, o.TextColor} {
+		if color != nil && !brandingColor.MatchString(*color) {
+			return fmt.Errorf("%w: the colors must be hex colors like %s <%s>", ErrInvalidBranding, DefaultBrandingPrimaryColor, *color)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+)
+
+func TestBranding_With(t *testing.T) {
+	node := Branding{DisplayName: "Polygon ID", LogoURL: "https://example.com/logo.png", PrimaryColor: DefaultBrandingPrimaryColor, TextColor: DefaultBrandingTextColor}
+
+	assert.Equal(t, node, node.With(BrandingOverrides{}))
+
+	branding := node.With(BrandingOverrides{DisplayName: common.ToPointer("Acme"), LogoURL: common.ToPointer(""), PrimaryColor: common.ToPointer("#000")})
+	assert.Equal(t, Branding{DisplayName: "Acme", PrimaryColor: "#000", TextColor: DefaultBrandingTextColor}, branding)
+}
+
+func TestBranding_Apply(t *testing.T) {
+	branding := Branding{DisplayName: "Acme", LogoURL: "https://acme.com/logo.png", PrimaryColor: "#112233", TextColor: "#000000"}
+
+	got := branding.Apply(DisplayTemplate{Title: "Membership", TitleTextColor: "#ffffff"})
+	assert.Equal(t, DisplayTemplate{
+		Title:                "Membership",
+		IssuerName:           "Acme",
+		TitleTextColor:       "#ffffff",
+		DescriptionTextColor: "#000000",
+		IssuerTextColor:      "#000000",
+		BackgroundColor:      "#112233",
+		Logo:                 DisplayLogo{URI: "https://acme.com/logo.png", Alt: "Acme"},
+	}, got)
+
+	template := DisplayTemplate{IssuerName: "Acme Europe", Logo: DisplayLogo{URI: "https://acme.eu/logo.png", Alt: "logo"}, BackgroundColor: "#ffffff"}
+	got = branding.Apply(template)
+	assert.Equal(t, template.IssuerName, got.IssuerName)
+	assert.Equal(t, template.Logo, got.Logo)
+	assert.Equal(t, template.BackgroundColor, got.BackgroundColor)
+}
+
+func TestBrandingOverrides_Validate(t *testing.T) {
+	assert.NoError(t, BrandingOverrides{}.Validate())
+	assert.NoError(t, BrandingOverrides{LogoURL: common.ToPointer(""), PrimaryColor: common.ToPointer("#6C4CE0"), TextColor: common.ToPointer("#fff")}.Validate())
+	assert.ErrorIs(t, BrandingOverrides{LogoURL: common.ToPointer("logo.png")}.Validate(), ErrInvalidBranding)
+	assert.ErrorIs(t, BrandingOverrides{LogoURL: common.ToPointer("javascript:alert(1)")}.Validate(), ErrInvalidBranding)
+	assert.ErrorIs(t, BrandingOverrides{PrimaryColor: common.ToPointer("purple")}.Validate(), ErrInvalidBranding)
+	assert.ErrorIs(t, BrandingOverrides{TextColor: common.ToPointer("")}.Validate(), ErrInvalidBranding)
+}
@@ -41,8 +41,11 @@ type Claim struct {
 	CredentialStatus pgtype.JSONB    `json:"credential_status"`
 	HIndex           string          `json:"-"`
 
-	MtProof bool       `json:"mt_poof"`
-	LinkID  *uuid.UUID `json:"-"`
+	MtProof    bool       `json:"mt_poof"`
+	LinkID     *uuid.UUID `json:"-"`
+	ReplacesID *uuid.UUID `json:"-"`
+	// ExternalReferences are the references answered by the post-issuance hooks, by hook name
+	ExternalReferences map[string]string `json:"-"`
 }
 
 // Credentials is the type of array of credential
@@ -137,6 +140,32 @@ func (c *Claim) GetBJJSignatureProof2021() (*verifiable.BJJSignatureProof2021, e
 	if err != nil {
 		return &sigProof, err
 	}
+	if sigProof.Type != verifiable.BJJSignatureProofType {
+		return &sigProof, fmt.Errorf("the credential is signed with a %s proof", sigProof.Type)
+	}
+	return &sigProof, nil
+}
+
+// GetSignatureProof returns the signature proof of the credential, a BJJSignature2021 or an
+// EcdsaSecp256k1Signature2019 one
+func (c *Claim) GetSignatureProof() (verifiable.CredentialProof, error) {
+	var proofType struct {
+		Type verifiable.ProofType `json:"type"`
+	}
+	if err := c.SignatureProof.AssignTo(&proofType); err != nil {
+		return nil, err
+	}
+	if proofType.Type == EcdsaSecp256k1SignatureProofType {
+		var sigProof EcdsaSecp256k1SignatureProof2019
+		if err := c.SignatureProof.AssignTo(&sigProof); err != nil {
+			return nil, err
+		}
+		return &sigProof, nil
+	}
+	var sigProof verifiable.BJJSignatureProof2021
+	if err := c.SignatureProof.AssignTo(&sigProof); err != nil {
+		return nil, err
+	}
 	return &sigProof, nil
 }
 
@@ -150,6 +179,17 @@ func (c *Claim) GetVerifiableCredential() (verifiable.W3CCredential, error) {
 	return vc, nil
 }
 
+// GetDisplayMethod returns the display method embedded in the verifiable credential, or nil if it has none
+func (c *Claim) GetDisplayMethod() (*DisplayMethod, error) {
+	var vc struct {
+		DisplayMethod *DisplayMethod `json:"displayMethod"`
+	}
+	if err := c.Data.AssignTo(&vc); err != nil {
+		return nil, err
+	}
+	return vc.DisplayMethod, nil
+}
+
 // GetCircuitIncProof TBD
 func (c *Claim) GetCircuitIncProof() (circuits.MTProof, error) {
 	var proof verifiable.Iden3SparseMerkleTreeProof
@@ -17,5 +17,6 @@ type Connection struct {
 	UserDoc     json.RawMessage
 	CreatedAt   time.Time
 	ModifiedAt  time.Time
+	LastSeen    *time.Time
 	Credentials *Credentials
 }
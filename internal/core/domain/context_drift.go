@@ -0,0 +1,20 @@
+package domain
+
+import "github.com/google/uuid"
+
+// ContextDrift is a signed credential whose core claim is no longer the one built from its content, because a remote
+// JSON-LD context of its type changed after it was signed, so its signature doesn't verify anymore
+type ContextDrift struct {
+	CredentialID uuid.UUID
+	SchemaURL    string
+	// ReplacedBy is the re-signed replacement, nil when it was only detected or it couldn't be replaced
+	ReplacedBy *uuid.UUID
+	// Error is why the credential couldn't be replaced
+	Error string
+}
+
+// ContextDriftReport is the result of checking the signed credentials of an issuer against the current contexts
+type ContextDriftReport struct {
+	Checked int
+	Drifted []ContextDrift
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/iden3/go-schema-processor/verifiable"
+)
+
+// ErrInvalidDataModel means the requested data model of the credentials is not 1.1 or 2.0
+var ErrInvalidDataModel = errors.New("invalid data model, it must be 1.1 or 2.0")
+
+// W3CCredentialContextV2 is the base JSON-LD context of the W3C Verifiable Credentials Data Model 2.0
+const W3CCredentialContextV2 = "https://www.w3.org/ns/credentials/v2"
+
+// CredentialDataModel is the version of the W3C Verifiable Credentials Data Model of the credential documents
+// returned by the node. The credentials are issued in 1.1, 2.0 is a serialization of the same credential.
+type CredentialDataModel string
+
+const (
+	// CredentialDataModelV1 is the data model 1.1, with issuanceDate and expirationDate
+	CredentialDataModelV1 CredentialDataModel = "1.1"
+	// CredentialDataModelV2 is the data model 2.0, with validFrom and validUntil and the v2 base context
+	CredentialDataModelV2 CredentialDataModel = "2.0"
+)
+
+// ParseCredentialDataModel is a CredentialDataModel constructor. An empty data model is 1.1.
+func ParseCredentialDataModel(model string) (CredentialDataModel, error) {
+	switch CredentialDataModel(model) {
+	case "", CredentialDataModelV1:
+		return CredentialDataModelV1, nil
+	case CredentialDataModelV2:
+		return CredentialDataModelV2, nil
+	default:
+		return "", ErrInvalidDataModel
+	}
+}
+
+// NegotiateCredentialDataModel returns the data model asked by the Accept header of a request: 2.0 when one of its
+// media ranges is application/vc, the media type of the data model 2.0, or has the v2 context as profile, and 1.1
+// otherwise. The ranges with q=0 are refused, so they are ignored.
+func NegotiateCredentialDataModel(accept string) CredentialDataModel {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			continue
+		}
+		if mediaType == "application/vc" || strings.Contains(params["profile"], W3CCredentialContextV2) {
+			return CredentialDataModelV2
+		}
+	}
+	return CredentialDataModelV1
+}
+
+// ContextsV2 returns the JSON-LD contexts of a credential of the data model 1.1 for the data model 2.0: the 1.1 base
+// context is replaced with the v2 one, that must be the first, and the rest are kept
+func ContextsV2(contexts []string) []string {
+	v2 := make([]string, 0, len(contexts))
+	v2 = append(v2, W3CCredentialContextV2)
+	for _, c := range contexts {
+		if c != verifiable.JSONLDSchemaW3CCredential2018 && c != W3CCredentialContextV2 {
+			v2 = append(v2, c)
+		}
+	}
+	return v2
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCredentialDataModel(t *testing.T) {
+	model, err := ParseCredentialDataModel("")
+	require.NoError(t, err)
+	assert.Equal(t, CredentialDataModelV1, model)
+
+	model, err = ParseCredentialDataModel("2.0")
+	require.NoError(t, err)
+	assert.Equal(t, CredentialDataModelV2, model)
+
+	_, err = ParseCredentialDataModel("2")
+	assert.ErrorIs(t, err, ErrInvalidDataModel)
+}
+
+func TestNegotiateCredentialDataModel(t *testing.T) {
+	for accept, expected := range map[string]CredentialDataModel{
+		"":                                     CredentialDataModelV1,
+		"*/*":                                  CredentialDataModelV1,
+		"application/json":                     CredentialDataModelV1,
+		"application/vc":                       CredentialDataModelV2,
+		"application/json, application/vc":     CredentialDataModelV2,
+		"application/vc;q=0, application/json": CredentialDataModelV1,
+		`application/ld+json; profile="https://www.w3.org/ns/credentials/v2"`:   CredentialDataModelV2,
+		`application/ld+json; profile="https://www.w3.org/2018/credentials/v1"`: CredentialDataModelV1,
+		"not a media type": CredentialDataModelV1,
+	} {
+		assert.Equal(t, expected, NegotiateCredentialDataModel(accept), accept)
+	}
+}
+
+func TestContextsV2(t *testing.T) {
+	assert.Equal(t,
+		[]string{W3CCredentialContextV2, verifiable.JSONLDSchemaIden3Credential, "https://example.com/kyc.jsonld"},
+		ContextsV2([]string{verifiable.JSONLDSchemaW3CCredential2018, verifiable.JSONLDSchemaIden3Credential, "https://example.com/kyc.jsonld"}),
+	)
+}
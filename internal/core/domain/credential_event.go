@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/utils"
+)
+
+// The events of the credential log. The database records them when the claims are inserted, revoked or deleted.
+const (
+	CredentialEventIssued   = "issued"
+	CredentialEventRevoked  = "revoked"
+	CredentialEventDeleted  = "deleted"
+	CredentialEventArchived = "archived"
+	CredentialEventRestored = "restored"
+	CredentialEventErased   = "erased"
+)
+
+// CredentialEventAnchorSchemaHash is the schema of the claims that anchor the head of the credential log of an
+// identity in its claims tree
+var CredentialEventAnchorSchemaHash = utils.CreateSchemaHash([]byte("https://schema.iden3.io/issuer-node/credential-events#CredentialEventAnchor"))
+
+// CredentialEvent is an entry of the hash chained log of the credentials of an issuer
+type CredentialEvent struct {
+	Issuer    string
+	Seq       int64
+	ClaimID   uuid.UUID
+	Event     string
+	CreatedAt time.Time
+	PrevHash  []byte
+	Hash      []byte
+}
+
+// CredentialEventAnchor is the head of the credential log of an issuer added to its claims tree in a state
+type CredentialEventAnchor struct {
+	Issuer    string
+	Seq       int64
+	Hash      []byte
+	State     string
+	CreatedAt time.Time
+}
+
+// ComputeHash returns the hash the event must have after PrevHash, the one computed by the database when it was
+// recorded
+func (e *CredentialEvent) ComputeHash() []byte {
+	h := sha256.New()
+	h.Write(e.PrevHash)
+	_, _ = fmt.Fprintf(h, "%s|%d|%s|%s|%d", e.Issuer, e.Seq, e.ClaimID, e.Event, e.CreatedAt.UnixMicro())
+	return h.Sum(nil)
+}
+
+// Follows tells whether the event is the next one of the log after previous, nil for the first one, and its hash is
+// right
+func (e *CredentialEvent) Follows(previous *CredentialEvent) bool {
+	prevHash, seq := make([]byte, sha256.Size), int64(1)
+	if previous != nil {
+		prevHash, seq = previous.Hash, previous.Seq+1
+	}
+	return e.Seq == seq && bytes.Equal(e.PrevHash, prevHash) && bytes.Equal(e.Hash, e.ComputeHash())
+}
+
+// AnchorClaim returns the claim of the claims tree that anchors the log up to the event: its index holds the hash and
+// its value the sequence number
+func (e *CredentialEvent) AnchorClaim() (*core.Claim, error) {
+	if len(e.Hash) != sha256.Size {
+		return nil, fmt.Errorf("wrong hash length %d", len(e.Hash))
+	}
+	return core.NewClaim(CredentialEventAnchorSchemaHash,
+		core.WithIndexDataInts(new(big.Int).SetBytes(e.Hash[:16]), new(big.Int).SetBytes(e.Hash[16:])),
+		core.WithValueDataInts(big.NewInt(e.Seq), nil))
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialEvent_Follows(t *testing.T) {
+	first := CredentialEvent{Issuer: "did:iden3:issuer", Seq: 1, ClaimID: uuid.New(), Event: CredentialEventIssued, CreatedAt: time.Now(), PrevHash: make([]byte, 32)}
+	first.Hash = first.ComputeHash()
+	second := CredentialEvent{Issuer: first.Issuer, Seq: 2, ClaimID: first.ClaimID, Event: CredentialEventRevoked, CreatedAt: time.Now(), PrevHash: first.Hash}
+	second.Hash = second.ComputeHash()
+
+	assert.True(t, first.Follows(nil))
+	assert.True(t, second.Follows(&first))
+	assert.False(t, second.Follows(nil), "the first event")
+
+	modified := second
+	modified.Event = CredentialEventDeleted
+	assert.False(t, modified.Follows(&first), "a modified event")
+
+	rechained := first
+	rechained.ClaimID = uuid.New()
+	rechained.Hash = rechained.ComputeHash()
+	assert.False(t, second.Follows(&rechained), "the events after a rewritten one")
+}
+
+func TestCredentialEvent_AnchorClaim(t *testing.T) {
+	event := CredentialEvent{Seq: 7, Hash: make([]byte, 32)}
+	event.Hash[0], event.Hash[31] = 0xff, 0x01
+	claim, err := event.AnchorClaim()
+	require.NoError(t, err)
+	assert.Equal(t, CredentialEventAnchorSchemaHash, claim.GetSchemaHash())
+	other := event
+	other.Hash = append([]byte{}, event.Hash...)
+	other.Hash[31] = 0x02
+	otherClaim, err := other.AnchorClaim()
+	require.NoError(t, err)
+	hi, _, err := claim.HiHv()
+	require.NoError(t, err)
+	otherHi, _, err := otherClaim.HiHv()
+	require.NoError(t, err)
+	assert.NotEqual(t, hi, otherHi, "each head has its own leaf")
+
+	_, err = (&CredentialEvent{Hash: []byte{1}}).AnchorClaim()
+	assert.Error(t, err)
+}
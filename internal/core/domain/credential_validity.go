@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+)
+
+// Credential validity verdicts
+const (
+	CredentialValid         = "valid"   // CredentialValid the credential is not revoked nor expired
+	CredentialRevoked       = "revoked" // CredentialRevoked the issuer revoked the credential
+	CredentialExpired       = "expired" // CredentialExpired the credential expiration date has passed
+	CredentialStatusUnknown = "unknown" // CredentialStatusUnknown the revocation status cannot be resolved
+)
+
+// CredentialValidity is the result of checking the revocation and expiration of a credential
+type CredentialValidity struct {
+	Issuer               string
+	CredentialStatusType string
+	RevocationNonce      uint64
+	LocalIssuer          bool
+	Revoked              *bool
+	Expired              bool
+	ExpirationDate       *time.Time
+	CheckedAt            time.Time
+	Reason               *string
+	Accreditation        *IssuerAccreditation
+}
+
+// IssuerAccreditation is the answer of the trust registry about the issuer of a credential and its type.
+// Accredited is nil when the registry cannot be consulted.
+type IssuerAccreditation struct {
+	CredentialType string
+	Accredited     *bool
+	CheckedAt      time.Time
+	Reason         *string
+}
+
+// Verdict consolidates the checks in a single value. A revoked credential is reported as revoked even if it is
+// also expired, and a credential is only valid when its revocation status could be resolved.
+func (v *CredentialValidity) Verdict() string {
+	switch {
+	case v.Revoked != nil && *v.Revoked:
+		return CredentialRevoked
+	case v.Expired:
+		return CredentialExpired
+	case v.Revoked == nil:
+		return CredentialStatusUnknown
+	default:
+		return CredentialValid
+	}
+}
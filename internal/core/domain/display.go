@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// Iden3BasicDisplayMethodV1 is the display method defined by iden3. Its id is the url of a JSON card template.
+const Iden3BasicDisplayMethodV1 = "Iden3BasicDisplayMethodV1"
+
+// ErrInvalidDisplayMethod is returned by DisplayMethod.Validate
+var ErrInvalidDisplayMethod = errors.New("invalid display method, the id must be an url and the type " + Iden3BasicDisplayMethodV1)
+
+var displayPlaceholder = regexp.MustCompile(`{{\s*([^{}\s]+)\s*}}`)
+
+// DisplayMethod references the template wallets use to render a credential card
+type DisplayMethod struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Validate checks the display method is supported by the issuer
+func (d DisplayMethod) Validate() error {
+	if d.Type != Iden3BasicDisplayMethodV1 {
+		return ErrInvalidDisplayMethod
+	}
+	if _, err := url.ParseRequestURI(d.ID); err != nil {
+		return ErrInvalidDisplayMethod
+	}
+	return nil
+}
+
+// DisplayTemplate is the card design of an Iden3BasicDisplayMethodV1 display method. The texts can include
+// placeholders with the credential values, like {{credentialSubject.name}} or {{expirationDate}}.
+type DisplayTemplate struct {
+	Title                string      `json:"title"`
+	Description          string      `json:"description"`
+	IssuerName           string      `json:"issuerName"`
+	TitleTextColor       string      `json:"titleTextColor"`
+	DescriptionTextColor string      `json:"descriptionTextColor"`
+	IssuerTextColor      string      `json:"issuerTextColor"`
+	BackgroundColor      string      `json:"backgroundColor"`
+	BackgroundImageURL   string      `json:"backgroundImageUrl"`
+	Logo                 DisplayLogo `json:"logo"`
+}
+
+// DisplayLogo is the logo shown in a credential card
+type DisplayLogo struct {
+	URI string `json:"uri"`
+	Alt string `json:"alt"`
+}
+
+// Render returns a copy of the template with the placeholders replaced by the given values.
+// Placeholders without value are removed.
+func (t DisplayTemplate) Render(values map[string]string) DisplayTemplate {
+	replace := func(text string) string {
+		return displayPlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+			return values[displayPlaceholder.FindStringSubmatch(placeholder)[1]]
+		})
+	}
+
+	t.Title = replace(t.Title)
+	t.Description = replace(t.Description)
+	t.IssuerName = replace(t.IssuerName)
+	t.Logo.Alt = replace(t.Logo.Alt)
+	return t
+}
+
+// CredentialCard is the display template of a credential rendered with the credential values
+type CredentialCard struct {
+	CredentialID  uuid.UUID
+	DisplayMethod DisplayMethod
+	Template      DisplayTemplate
+}
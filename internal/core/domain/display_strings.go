@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidDisplayStrings is returned by DisplayStrings.Validate and ValidateLocale
+var ErrInvalidDisplayStrings = errors.New("invalid display strings")
+
+// localeRegex matches the BCP 47 language tags, like en, pt-BR or zh-Hant-TW
+var localeRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// DisplayString is the name and description of a credential that the wallets show in a locale
+type DisplayString struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// DisplayStrings are the display strings of a credential per locale
+type DisplayStrings map[string]DisplayString
+
+// ValidateLocale checks that locale is a language tag, like en or pt-BR
+func ValidateLocale(locale string) error {
+	if !localeRegex.MatchString(locale) {
+		return fmt.Errorf("%w: invalid locale <%s>", ErrInvalidDisplayStrings, locale)
+	}
+	return nil
+}
+
+// Validate checks that every locale is a language tag with a name
+func (d DisplayStrings) Validate() error {
+	for locale, display := range d {
+		if err := ValidateLocale(locale); err != nil {
+			return err
+		}
+		if strings.TrimSpace(display.Name) == "" {
+			return fmt.Errorf("%w: the name of <%s> is empty", ErrInvalidDisplayStrings, locale)
+		}
+	}
+	return nil
+}
+
+// Localize returns the display strings of the first of the locales that has them. A locale without display strings
+// matches the display strings of its language, so pt-BR gets the pt ones, and a language gets the ones of any of its
+// regions, so pt gets the pt-PT ones when there are no pt ones.
+func (d DisplayStrings) Localize(locales ...string) (DisplayString, bool) {
+	for _, locale := range locales {
+		if display, found := d.get(locale); found {
+			return display, true
+		}
+		language := localeLanguage(locale)
+		if display, found := d.get(language); found {
+			return display, true
+		}
+		for _, key := range d.sortedLocales() {
+			if strings.EqualFold(localeLanguage(key), language) {
+				return d[key], true
+			}
+		}
+	}
+	return DisplayString{}, false
+}
+
+func (d DisplayStrings) get(locale string) (DisplayString, bool) {
+	for key, display := range d {
+		if strings.EqualFold(key, locale) {
+			return display, true
+		}
+	}
+	return DisplayString{}, false
+}
+
+// sortedLocales returns the locales with display strings in order, so Localize doesn't depend on the map order
+func (d DisplayStrings) sortedLocales() []string {
+	locales := make([]string, 0, len(d))
+	for locale := range d {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+func localeLanguage(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	return language
+}
+
+// ParseAcceptLanguage returns the locales of an Accept-Language header from the most to the least preferred. The
+// wildcard and the locales with zero quality are left out.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+	parsed := make([]weighted, 0)
+	for _, part := range strings.Split(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			value, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = value
+		}
+		if quality <= 0 {
+			continue
+		}
+		parsed = append(parsed, weighted{locale: locale, quality: quality})
+	}
+	sort.SliceStable(parsed, func(i, j int) bool { return parsed[i].quality > parsed[j].quality })
+
+	locales := make([]string, len(parsed))
+	for i := range parsed {
+		locales[i] = parsed[i].locale
+	}
+	return locales
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayStringsValidate(t *testing.T) {
+	assert.NoError(t, DisplayStrings{"en": {Name: "Age"}, "pt-BR": {Name: "Idade", Description: "Prova de idade"}}.Validate())
+	assert.NoError(t, DisplayStrings(nil).Validate())
+	assert.ErrorIs(t, DisplayStrings{"english": {Name: "Age"}}.Validate(), ErrInvalidDisplayStrings)
+	assert.ErrorIs(t, DisplayStrings{"en": {Name: " "}}.Validate(), ErrInvalidDisplayStrings)
+	assert.ErrorIs(t, ValidateLocale("en_US"), ErrInvalidDisplayStrings)
+}
+
+func TestDisplayStringsLocalize(t *testing.T) {
+	display := DisplayStrings{
+		"en":    {Name: "Age"},
+		"es":    {Name: "Edad"},
+		"pt-PT": {Name: "Idade"},
+	}
+	for _, tc := range []struct {
+		locales  []string
+		expected string
+		found    bool
+	}{
+		{locales: []string{"es"}, expected: "Edad", found: true},
+		{locales: []string{"ES"}, expected: "Edad", found: true},
+		{locales: []string{"es-AR"}, expected: "Edad", found: true},
+		{locales: []string{"pt-BR"}, expected: "Idade", found: true},
+		{locales: []string{"de", "en"}, expected: "Age", found: true},
+		{locales: []string{"de"}, found: false},
+		{locales: nil, found: false},
+	} {
+		got, found := display.Localize(tc.locales...)
+		assert.Equal(t, tc.found, found, tc.locales)
+		assert.Equal(t, tc.expected, got.Name, tc.locales)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert.Equal(t, []string{"fr-CH", "fr", "en"}, ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5"))
+	assert.Equal(t, []string{"en", "de"}, ParseAcceptLanguage("de;q=0.5,en,es;q=0"))
+	assert.Empty(t, ParseAcceptLanguage(""))
+}
+
+func TestLinkDisplay(t *testing.T) {
+	locale := "es"
+	link := &Link{
+		Schema:         &Schema{DisplayStrings: DisplayStrings{"en": {Name: "Age"}, "es": {Name: "Edad"}}},
+		DisplayStrings: DisplayStrings{"en": {Name: "Event ticket"}},
+	}
+
+	display, found := link.Display("en-GB")
+	assert.True(t, found)
+	assert.Equal(t, "Event ticket", display.Name)
+
+	display, found = link.Display("es")
+	assert.True(t, found)
+	assert.Equal(t, "Edad", display.Name)
+
+	_, found = link.Display("de")
+	assert.False(t, found)
+
+	link.Locale = &locale
+	display, found = link.Display("de")
+	assert.True(t, found)
+	assert.Equal(t, "Edad", display.Name)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayMethod_Validate(t *testing.T) {
+	assert.NoError(t, DisplayMethod{ID: "https://example.com/card.json", Type: Iden3BasicDisplayMethodV1}.Validate())
+	assert.ErrorIs(t, DisplayMethod{ID: "https://example.com/card.json", Type: "SVGDisplayMethod"}.Validate(), ErrInvalidDisplayMethod)
+	assert.ErrorIs(t, DisplayMethod{ID: "card.json", Type: Iden3BasicDisplayMethodV1}.Validate(), ErrInvalidDisplayMethod)
+}
+
+func TestDisplayTemplate_Render(t *testing.T) {
+	template := DisplayTemplate{
+		Title:           "{{credentialSubject.name}}'s membership",
+		Description:     "Valid until {{ expirationDate }}{{unknown}}",
+		IssuerName:      "Polygon ID",
+		TitleTextColor:  "#ffffff",
+		Logo:            DisplayLogo{URI: "https://example.com/logo.png", Alt: "{{type}} logo"},
+		IssuerTextColor: "{{credentialSubject.name}}",
+	}
+
+	got := template.Render(map[string]string{
+		"credentialSubject.name": "Alice",
+		"expirationDate":         "2024-01-01",
+		"type":                   "Membership",
+	})
+
+	assert.Equal(t, DisplayTemplate{
+		Title:           "Alice's membership",
+		Description:     "Valid until 2024-01-01",
+		IssuerName:      "Polygon ID",
+		TitleTextColor:  "#ffffff",
+		Logo:            DisplayLogo{URI: "https://example.com/logo.png", Alt: "Membership logo"},
+		IssuerTextColor: "{{credentialSubject.name}}",
+	}, got)
+	assert.Equal(t, "{{credentialSubject.name}}'s membership", template.Title)
+}
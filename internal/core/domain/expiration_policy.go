@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// maxExpirationPolicyYears is the longest validity period accepted by an expiration policy
+const maxExpirationPolicyYears = 100
+
+var (
+	// ErrInvalidExpirationPolicy means the expiration policy is not an ISO 8601 duration or a shorthand like 90d
+	ErrInvalidExpirationPolicy = errors.New("invalid expiration policy")
+
+	iso8601Duration   = regexp.MustCompile(`^P(?:(\d{1,6})Y)?(?:(\d{1,6})M)?(?:(\d{1,6})W)?(?:(\d{1,6})D)?(?:T(?:(\d{1,6})H)?(?:(\d{1,6})M)?(?:(\d{1,6})S)?)?$`)
+	shorthandDuration = regexp.MustCompile(`^(\d{1,6})([hdwy])$`)
+)
+
+// ExpirationPolicy is a credential validity period relative to the issuance date. It is written as an ISO 8601
+// duration, like P1Y or P1M15D, or as a number of hours, days, weeks or years, like 90d.
+type ExpirationPolicy struct {
+	Years  int
+	Months int
+	Days   int
+	// Time is the hours, minutes and seconds part of the period
+	Time time.Duration
+	raw  string
+}
+
+// ParseExpirationPolicy is an ExpirationPolicy constructor from its string representation
+func ParseExpirationPolicy(value string) (*ExpirationPolicy, error) {
+	policy := &ExpirationPolicy{raw: value}
+	if m := shorthandDuration.FindStringSubmatch(value); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "h":
+			policy.Time = time.Duration(n) * time.Hour
+		case "d":
+			policy.Days = n
+		case "w":
+			policy.Days = 7 * n
+		case "y":
+			policy.Years = n
+		}
+	} else if m := iso8601Duration.FindStringSubmatch(value); m != nil && value != "P" && value[len(value)-1] != 'T' {
+		n := make([]int, len(m))
+		for i := 1; i < len(m); i++ {
+			n[i], _ = strconv.Atoi(m[i])
+		}
+		policy.Years, policy.Months, policy.Days = n[1], n[2], 7*n[3]+n[4]
+		policy.Time = time.Duration(n[5])*time.Hour + time.Duration(n[6])*time.Minute + time.Duration(n[7])*time.Second
+	} else {
+		return nil, fmt.Errorf("%w: %q, use an ISO 8601 duration like P1Y or a number of hours, days, weeks or years like 90d", ErrInvalidExpirationPolicy, value)
+	}
+
+	epoch := time.Unix(0, 0).UTC()
+	expiration := policy.ExpiresAt(epoch)
+	if !expiration.After(epoch) {
+		return nil, fmt.Errorf("%w: %q is an empty period", ErrInvalidExpirationPolicy, value)
+	}
+	if expiration.After(epoch.AddDate(maxExpirationPolicyYears, 0, 0)) {
+		return nil, fmt.Errorf("%w: %q is longer than 100 years", ErrInvalidExpirationPolicy, value)
+	}
+	return policy, nil
+}
+
+// String returns the policy as it was written
+func (p ExpirationPolicy) String() string {
+	return p.raw
+}
+
+// ExpiresAt returns the expiration of a credential issued at issuedAt. The calendar part is added in UTC, so
+// daylight saving changes do not move the expiration, and the day is clamped to the end of shorter months:
+// one month after January 31 is the last day of February.
+func (p ExpirationPolicy) ExpiresAt(issuedAt time.Time) time.Time {
+	t := issuedAt.UTC()
+	year, month, day := t.Date()
+	firstDay := time.Date(year+p.Years, month+time.Month(p.Months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	if lastDay := firstDay.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return firstDay.AddDate(0, 0, day-1+p.Days).Add(p.Time)
+}
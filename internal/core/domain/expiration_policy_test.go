@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpirationPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected ExpirationPolicy
+	}{
+		{value: "P1Y", expected: ExpirationPolicy{Years: 1}},
+		{value: "P1M15D", expected: ExpirationPolicy{Months: 1, Days: 15}},
+		{value: "P2W", expected: ExpirationPolicy{Days: 14}},
+		{value: "PT36H", expected: ExpirationPolicy{Time: 36 * time.Hour}},
+		{value: "P1DT1H30M", expected: ExpirationPolicy{Days: 1, Time: 90 * time.Minute}},
+		{value: "P100Y", expected: ExpirationPolicy{Years: 100}},
+		{value: "90d", expected: ExpirationPolicy{Days: 90}},
+		{value: "12h", expected: ExpirationPolicy{Time: 12 * time.Hour}},
+		{value: "2w", expected: ExpirationPolicy{Days: 14}},
+		{value: "1y", expected: ExpirationPolicy{Years: 1}},
+	} {
+		policy, err := ParseExpirationPolicy(tc.value)
+		require.NoError(t, err, tc.value)
+		tc.expected.raw = tc.value
+		assert.Equal(t, tc.expected, *policy, tc.value)
+		assert.Equal(t, tc.value, policy.String())
+	}
+
+	for _, value := range []string{"", "P", "PT", "P1YT", "1m", "90", "d", "-1d", "P0D", "0d", "P1.5Y", "p1y", "101y", "P100Y1D"} {
+		_, err := ParseExpirationPolicy(value)
+		assert.ErrorIs(t, err, ErrInvalidExpirationPolicy, value)
+	}
+}
+
+func TestExpirationPolicy_ExpiresAt(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		policy   string
+		issuedAt time.Time
+		expected time.Time
+	}{
+		{policy: "P1M", issuedAt: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC), expected: time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC)},
+		{policy: "P1M", issuedAt: time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC), expected: time.Date(2023, 2, 28, 10, 0, 0, 0, time.UTC)},
+		{policy: "P1Y", issuedAt: time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC), expected: time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC)},
+		{policy: "P1M1D", issuedAt: time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC), expected: time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{policy: "PT25H", issuedAt: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), expected: time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)},
+		// The day before the daylight saving change in Madrid: 24 hours later in UTC, not at the same local hour
+		{policy: "1d", issuedAt: time.Date(2023, 3, 25, 12, 0, 0, 0, madrid), expected: time.Date(2023, 3, 26, 11, 0, 0, 0, time.UTC)},
+	} {
+		policy, err := ParseExpirationPolicy(tc.policy)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, policy.ExpiresAt(tc.issuedAt), tc.policy)
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// HookDelivery is a call to a post-issuance hook, kept for inspection when the capture of the hook calls is enabled
+type HookDelivery struct {
+	ID        uuid.UUID
+	IssuerDID core.DID
+	Hook      string
+	URL       string
+	// Test is true for the sample events sent to test the hook, which don't belong to any credential
+	Test    bool
+	Request json.RawMessage
+	// Status is the http status answered by the hook, nil when it didn't answer
+	Status   *int
+	Response *string
+	// Error is why the call failed, nil when the hook answered a 2xx status
+	Error     *string
+	Duration  time.Duration
+	CreatedAt time.Time
+}
@@ -73,3 +73,23 @@ func ContainsID(states []IdentityState, id *core.DID) bool {
 	}
 	return false
 }
+
+// IdentityStatesAsOf returns the states as they were at t. The states created later are left out. There is no history
+// of the status changes, so the states modified later are returned with the status they had when created.
+func IdentityStatesAsOf(states []IdentityState, t time.Time) []IdentityState {
+	asOf := make([]IdentityState, 0, len(states))
+	for _, state := range states {
+		if state.CreatedAt.After(t) {
+			continue
+		}
+		if state.ModifiedAt.After(t) {
+			state.Status = StatusCreated
+			state.TxID = nil
+			state.BlockNumber = nil
+			state.BlockTimestamp = nil
+			state.ModifiedAt = state.CreatedAt
+		}
+		asOf = append(asOf, state)
+	}
+	return asOf
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+)
+
+func TestIdentityStatesAsOf(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, time.April, d, 10, 0, 0, 0, time.UTC) }
+	states := []IdentityState{
+		{StateID: 3, Status: StatusCreated, CreatedAt: day(5), ModifiedAt: day(5)},
+		{StateID: 2, Status: StatusConfirmed, TxID: common.ToPointer("0x02"), CreatedAt: day(3), ModifiedAt: day(4)},
+		{StateID: 1, Status: StatusConfirmed, TxID: common.ToPointer("0x01"), CreatedAt: day(1), ModifiedAt: day(2)},
+	}
+
+	asOf := IdentityStatesAsOf(states, day(3))
+	assert.Len(t, asOf, 2)
+	assert.Equal(t, int64(2), asOf[0].StateID)
+	assert.Equal(t, StatusCreated, asOf[0].Status)
+	assert.Nil(t, asOf[0].TxID)
+	assert.Equal(t, day(3), asOf[0].ModifiedAt)
+	assert.Equal(t, states[2], asOf[1])
+
+	assert.Equal(t, StatusConfirmed, states[1].Status)
+	assert.Equal(t, states, IdentityStatesAsOf(states, day(6)))
+	assert.Empty(t, IdentityStatesAsOf(states, day(0)))
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// IssuancePhase is a step of the issuance of a credential whose duration is measured
+type IssuancePhase string
+
+// The phases of the issuance of a credential, in the order they run
+const (
+	IssuancePhaseSchemaLoad   IssuancePhase = "schema_load"  // IssuancePhaseSchemaLoad downloading the schema, or reading it from the cache
+	IssuancePhaseValidation   IssuancePhase = "validation"   // IssuancePhaseValidation validating the credential subject against the schema
+	IssuancePhaseMerklization IssuancePhase = "merklization" // IssuancePhaseMerklization making the core claim, merklizing the credential with its JSON-LD context
+	IssuancePhaseSigning      IssuancePhase = "signing"      // IssuancePhaseSigning signing the core claim with the key of the issuer
+	IssuancePhaseDBCommit     IssuancePhase = "db_commit"    // IssuancePhaseDBCommit saving the credential, with its policies, quotas and metering
+	IssuancePhaseNotification IssuancePhase = "notification" // IssuancePhaseNotification publishing the event that notifies the holder
+)
+
+// IssuancePhases are all the phases of the issuance, in the order they run
+var IssuancePhases = []IssuancePhase{
+	IssuancePhaseSchemaLoad,
+	IssuancePhaseValidation,
+	IssuancePhaseMerklization,
+	IssuancePhaseSigning,
+	IssuancePhaseDBCommit,
+	IssuancePhaseNotification,
+}
+
+// IssuanceTiming is the time spent issuing a credential in each phase. The phases that didn't run, like the signing of
+// a credential without signature proof, are not in Phases. Total is the whole issuance, that includes the steps between
+// the phases, like the issuance policy.
+type IssuanceTiming struct {
+	ClaimID   uuid.UUID
+	IssuerDID core.DID
+	Phases    map[IssuancePhase]time.Duration
+	Total     time.Duration
+	CreatedAt time.Time
+}
+
+// Slowest returns the phase that took longest and its duration, or an empty phase when none ran
+func (t *IssuanceTiming) Slowest() (IssuancePhase, time.Duration) {
+	var slowest IssuancePhase
+	var longest time.Duration
+	for _, phase := range IssuancePhases {
+		if d, ok := t.Phases[phase]; ok && (slowest == "" || d > longest) {
+			slowest, longest = phase, d
+		}
+	}
+	return slowest, longest
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuanceTiming_Slowest(t *testing.T) {
+	timing := IssuanceTiming{}
+	phase, d := timing.Slowest()
+	assert.Equal(t, IssuancePhase(""), phase)
+	assert.Zero(t, d)
+
+	timing.Phases = map[IssuancePhase]time.Duration{
+		IssuancePhaseSchemaLoad:   40 * time.Millisecond,
+		IssuancePhaseMerklization: 250 * time.Millisecond,
+		IssuancePhaseDBCommit:     250 * time.Millisecond,
+		IssuancePhaseValidation:   2 * time.Millisecond,
+	}
+	phase, d = timing.Slowest()
+	assert.Equal(t, IssuancePhaseMerklization, phase, "the first phase wins the ties")
+	assert.Equal(t, 250*time.Millisecond, d)
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+)
+
+// PublicKeyTypeBJJ is the type of the baby jubjub public keys of the identities auth claims
+const PublicKeyTypeBJJ = "BJJ"
+
+// IssuerMetadata describes the issuers of the node and how to reach them, for the verifiers trust list tooling
+type IssuerMetadata struct {
+	URL                   string
+	Issuers               []IssuerMetadataIssuer
+	CredentialStatusTypes []string
+	AgentURL              string
+	CredentialStatusURL   string
+	CredentialValidityURL string
+	ReverseHashServiceURL *string
+	GeneratedAt           time.Time
+}
+
+// IssuerMetadataIssuer describes an identity of the node
+type IssuerMetadataIssuer struct {
+	DID        string
+	PublicKeys []IssuerPublicKey
+	ProofTypes ProofTypes
+	Schemas    []IssuerSchema
+	Branding   Branding
+}
+
+// IssuerPublicKey is a public key of an identity. X and Y are the decimal coordinates of the key.
+type IssuerPublicKey struct {
+	Type string
+	X    string
+	Y    string
+}
+
+// IssuerSchema is a schema imported by an identity
+type IssuerSchema struct {
+	URL  string
+	Type string
+}
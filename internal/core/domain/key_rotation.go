@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// KeyRotationStatus is the step of the rotation of the BabyJubJub auth key of an identity
+type KeyRotationStatus string
+
+// The steps of a key rotation. The new auth claim is published first, with a state transition signed with the old key,
+// and the old auth claim is revoked once that state is confirmed, with a state transition signed with the new key.
+const (
+	KeyRotationAdding    KeyRotationStatus = "adding"    // KeyRotationAdding the state with the new auth claim is being published
+	KeyRotationRevoking  KeyRotationStatus = "revoking"  // KeyRotationRevoking the state that revokes the old auth claim is being published
+	KeyRotationCompleted KeyRotationStatus = "completed" // KeyRotationCompleted both states are confirmed
+)
+
+// KeyRotation is the rotation of the BabyJubJub auth key of an identity, tracked until its two state transitions are
+// confirmed. AddState and RevokeState are the states that include the new auth claim and the revocation of the old one.
+// Error is the last problem that stopped the rotation from advancing, like a failed state transition.
+type KeyRotation struct {
+	ID             uuid.UUID
+	IssuerDID      core.DID
+	Status         KeyRotationStatus
+	OldAuthClaimID uuid.UUID
+	NewAuthClaimID uuid.UUID
+	NewKeyID       string
+	AddState       *string
+	RevokeState    *string
+	Error          *string
+	CreatedAt      time.Time
+	ModifiedAt     time.Time
+}
+
+// Active tells whether the rotation still has a state transition to confirm
+func (r *KeyRotation) Active() bool {
+	return r.Status != KeyRotationCompleted
+}
@@ -61,19 +61,30 @@ type LinkCoreDID core.DID
 
 // Link - represents a credential offer
 type Link struct {
-	ID                       uuid.UUID
-	IssuerDID                LinkCoreDID
-	CreatedAt                time.Time
-	MaxIssuance              *int
-	ValidUntil               *time.Time
-	SchemaID                 uuid.UUID
-	CredentialExpiration     *time.Time
-	CredentialSignatureProof bool
-	CredentialMTPProof       bool
-	CredentialSubject        CredentialSubject
-	Active                   bool
-	Schema                   *Schema
-	IssuedClaims             int // TODO: Give a value when link redemption is implemented
+	ID                         uuid.UUID
+	IssuerDID                  LinkCoreDID
+	CreatedAt                  time.Time
+	MaxIssuance                *int
+	ValidUntil                 *time.Time
+	SchemaID                   uuid.UUID
+	CredentialExpiration       *time.Time
+	CredentialExpirationPolicy *ExpirationPolicy // used when CredentialExpiration is not set
+	CredentialSignatureProof   bool
+	CredentialMTPProof         bool
+	CredentialSubject          CredentialSubject
+	Active                     bool
+	Schema                     *Schema
+	IssuedClaims               int // TODO: Give a value when link redemption is implemented
+	// Locale is the locale of the credential offers when the holder doesn't ask for any, or asks for one without display strings
+	Locale *string
+	// DisplayStrings are the localized name and description of the link credentials. They take precedence over the schema ones.
+	DisplayStrings DisplayStrings
+	// Prerequisites are the credentials that the holder must prove to hold before a link credential is issued to it,
+	// on top of the schema ones
+	Prerequisites Prerequisites
+	// ValidationWarnings are the warnings of the lenient validation of the credential subject when the link is created.
+	// They are not stored.
+	ValidationWarnings []string
 }
 
 // NewLink - Constructor
@@ -102,6 +113,30 @@ func NewLink(
 	}
 }
 
+// Display returns the display strings of the link credentials in the first of the locales that has them, or else in
+// the link locale. The link display strings are looked up before the schema ones.
+func (l *Link) Display(locales ...string) (DisplayString, bool) {
+	if l.Locale != nil {
+		locales = append(locales, *l.Locale)
+	}
+	if display, found := l.DisplayStrings.Localize(locales...); found {
+		return display, true
+	}
+	if l.Schema == nil {
+		return DisplayString{}, false
+	}
+	return l.Schema.DisplayStrings.Localize(locales...)
+}
+
+// AllPrerequisites returns the prerequisites of the schema followed by the link ones
+func (l *Link) AllPrerequisites() Prerequisites {
+	var prerequisites Prerequisites
+	if l.Schema != nil {
+		prerequisites = append(prerequisites, l.Schema.Prerequisites...)
+	}
+	return append(prerequisites, l.Prerequisites...)
+}
+
 // IssuerCoreDID - return the Core DID value
 func (l *Link) IssuerCoreDID() *core.DID {
 	return common.ToPointer(core.DID(l.IssuerDID))
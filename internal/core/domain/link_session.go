@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+)
+
+// ErrLinkSessionTransition the link session can't move to the status
+var ErrLinkSessionTransition = errors.New("invalid link session transition")
+
+// LinkSessionStatus is the step of a wallet claiming the credential of a link
+type LinkSessionStatus string
+
+// The steps of a link session, in order
+const (
+	LinkSessionCreated       LinkSessionStatus = "created"       // LinkSessionCreated the qr code of the link was generated
+	LinkSessionAuthenticated LinkSessionStatus = "authenticated" // LinkSessionAuthenticated the wallet authenticated with the qr code
+	LinkSessionIssued        LinkSessionStatus = "issued"        // LinkSessionIssued the credential was issued to the wallet
+	LinkSessionFetched       LinkSessionStatus = "fetched"       // LinkSessionFetched the wallet fetched the credential
+)
+
+var linkSessionSteps = map[LinkSessionStatus]int{
+	LinkSessionCreated:       0,
+	LinkSessionAuthenticated: 1,
+	LinkSessionIssued:        2,
+	LinkSessionFetched:       3,
+}
+
+// LinkSessionStatusFromString constructs a LinkSessionStatus from a string
+func LinkSessionStatusFromString(s string) (LinkSessionStatus, error) {
+	if _, found := linkSessionSteps[LinkSessionStatus(s)]; !found {
+		return "", fmt.Errorf("unknown link session status: %s", s)
+	}
+	return LinkSessionStatus(s), nil
+}
+
+// LinkSession is a wallet claiming the credential of a link, from the qr code to the fetch of the credential. It is kept
+// in the database, so an interrupted claim can be resumed and the support teams can see where it stopped. Error is the
+// last problem that stopped it, like a link that expired before the credential was issued.
+type LinkSession struct {
+	ID         uuid.UUID
+	LinkID     uuid.UUID
+	IssuerDID  core.DID
+	Status     LinkSessionStatus
+	UserDID    *string
+	ClaimID    *uuid.UUID
+	Error      *string
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+}
+
+// NewLinkSession returns a session of the link that has just generated its qr code
+func NewLinkSession(id uuid.UUID, issuerDID core.DID, linkID uuid.UUID, now time.Time) *LinkSession {
+	return &LinkSession{
+		ID:         id,
+		LinkID:     linkID,
+		IssuerDID:  issuerDID,
+		Status:     LinkSessionCreated,
+		CreatedAt:  now,
+		ModifiedAt: now,
+	}
+}
+
+// Authenticate records the wallet that authenticated with the qr code. A session is only claimed by one wallet.
+func (s *LinkSession) Authenticate(userDID core.DID, now time.Time) error {
+	if s.UserDID != nil && *s.UserDID != userDID.String() {
+		return fmt.Errorf("%w: the session was claimed by other wallet", ErrLinkSessionTransition)
+	}
+	s.UserDID = common.ToPointer(userDID.String())
+	return s.advance(LinkSessionAuthenticated, now)
+}
+
+// Issue records the credential issued to the wallet
+func (s *LinkSession) Issue(claimID uuid.UUID, now time.Time) error {
+	if err := s.advance(LinkSessionIssued, now); err != nil {
+		return err
+	}
+	s.ClaimID = &claimID
+	s.Error = nil
+	return nil
+}
+
+// Fail keeps the problem that stopped the session, that stays in its status so it can be resumed
+func (s *LinkSession) Fail(err error, now time.Time) {
+	s.Error = common.ToPointer(err.Error())
+	s.ModifiedAt = now
+}
+
+// Issued tells whether the credential of the session was issued, so the wallet can fetch it
+func (s *LinkSession) Issued() bool {
+	return s.ClaimID != nil && linkSessionSteps[s.Status] >= linkSessionSteps[LinkSessionIssued]
+}
+
+// advance moves the session forward. Staying in the same status is allowed, so the steps can be retried.
+func (s *LinkSession) advance(to LinkSessionStatus, now time.Time) error {
+	if linkSessionSteps[to] < linkSessionSteps[s.Status] {
+		return fmt.Errorf("%w: from %s to %s", ErrLinkSessionTransition, s.Status, to)
+	}
+	s.Status = to
+	s.ModifiedAt = now
+	return nil
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"net/http"
+	"time"
+)
+
+// Maintenance is the maintenance mode of the node. While it is enabled the APIs reject the requests that change the
+// node, the ones in MaintenanceExemptOperations aside, and keep serving the rest.
+type Maintenance struct {
+	Enabled    bool
+	Message    string
+	RetryAfter time.Duration // RetryAfter the Retry-After of the rejected requests, the configured one when it is 0
+	UpdatedAt  time.Time
+}
+
+// MaintenanceExemptOperations are the operations that change the node but are served in maintenance mode: the agent,
+// that the wallets need to fetch their credentials, the credential checks, that only read, and the maintenance mode
+// switch itself.
+var MaintenanceExemptOperations = map[string]bool{
+	"Agent":                   true,
+	"CheckCredentialValidity": true,
+	"UpdateMaintenance":       true,
+}
+
+// Rejects tells whether the maintenance mode rejects a request with the http method to the operation
+func (m *Maintenance) Rejects(method string, operationID string) bool {
+	return m != nil && m.Enabled && MaintenanceApplies(method, operationID)
+}
+
+// MaintenanceApplies tells whether a request with the http method to the operation changes the node and is not exempt
+func MaintenanceApplies(method string, operationID string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return !MaintenanceExemptOperations[operationID]
+}
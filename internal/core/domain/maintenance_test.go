@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenance_Rejects(t *testing.T) {
+	enabled := &Maintenance{Enabled: true}
+	type testConfig struct {
+		name        string
+		maintenance *Maintenance
+		method      string
+		operationID string
+		expected    bool
+	}
+	for _, tc := range []testConfig{
+		{name: "no maintenance", method: http.MethodPost, operationID: "CreateClaim"},
+		{name: "disabled", maintenance: &Maintenance{}, method: http.MethodPost, operationID: "CreateClaim"},
+		{name: "mutating operation", maintenance: enabled, method: http.MethodPost, operationID: "CreateClaim", expected: true},
+		{name: "delete", maintenance: enabled, method: http.MethodDelete, operationID: "DeleteConnection", expected: true},
+		{name: "read", maintenance: enabled, method: http.MethodGet, operationID: "GetClaim"},
+		{name: "agent", maintenance: enabled, method: http.MethodPost, operationID: "Agent"},
+		{name: "maintenance switch", maintenance: enabled, method: http.MethodPut, operationID: "UpdateMaintenance"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.maintenance.Rejects(tc.method, tc.operationID))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// MeteredOperation is a billable operation of the node
+type MeteredOperation string
+
+const (
+	MeteredIssuance     MeteredOperation = "issuance"     // MeteredIssuance a credential issued by the APIs, a link or a reissue
+	MeteredVerification MeteredOperation = "verification" // MeteredVerification a credential of an identity of the node checked by a relying party
+	MeteredPublish      MeteredOperation = "publish"      // MeteredPublish an identity state published on chain
+)
+
+// ErrInvalidMeteringPeriod is returned by NewMeteringPeriod
+var ErrInvalidMeteringPeriod = errors.New("invalid metering period")
+
+// MeteringPeriod are the months between From and To, both included, in UTC
+type MeteringPeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+// NewMeteringPeriod returns the period between the from and to months, formatted like 2023-04. An empty from is the
+// to month and an empty to is the current month.
+func NewMeteringPeriod(from, to string, now time.Time) (MeteringPeriod, error) {
+	period := MeteringPeriod{To: firstDayOfMonth(now)}
+	var err error
+	if to != "" {
+		if period.To, err = time.Parse(meteringMonthLayout, to); err != nil {
+			return MeteringPeriod{}, ErrInvalidMeteringPeriod
+		}
+	}
+	period.From = period.To
+	if from != "" {
+		if period.From, err = time.Parse(meteringMonthLayout, from); err != nil {
+			return MeteringPeriod{}, ErrInvalidMeteringPeriod
+		}
+	}
+	if period.From.After(period.To) {
+		return MeteringPeriod{}, ErrInvalidMeteringPeriod
+	}
+	return period, nil
+}
+
+// End is the first instant after the period
+func (p MeteringPeriod) End() time.Time {
+	return p.To.AddDate(0, 1, 0)
+}
+
+// MeteringMonth counts the operations of a kind of an issuer, or of one of its api keys, in a month in UTC
+type MeteringMonth struct {
+	Month     time.Time
+	IssuerDID core.DID
+	// APIKeyID is the delegated api key of the operations, nil for the ones of the issuer credentials
+	APIKeyID  *uuid.UUID
+	Operation MeteredOperation
+	Count     int64
+}
+
+// MonthString returns the month formatted like 2023-04
+func (m *MeteringMonth) MonthString() string {
+	return m.Month.Format(meteringMonthLayout)
+}
+
+const meteringMonthLayout = "2006-01"
+
+func firstDayOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMeteringPeriod(t *testing.T) {
+	now := time.Date(2023, 4, 21, 10, 0, 0, 0, time.UTC)
+	april := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name     string
+		from     string
+		to       string
+		expected MeteringPeriod
+	}{
+		{name: "current month", expected: MeteringPeriod{From: april, To: april}},
+		{name: "from", from: "2023-01", expected: MeteringPeriod{From: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), To: april}},
+		{name: "to", to: "2023-02", expected: MeteringPeriod{From: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)}},
+		{name: "from and to", from: "2022-11", to: "2023-02", expected: MeteringPeriod{From: time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			period, err := NewMeteringPeriod(tc.from, tc.to, now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, period)
+		})
+	}
+
+	period, err := NewMeteringPeriod("2023-12", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), MeteringPeriod{To: period.From}.End())
+
+	for _, tc := range []struct{ from, to string }{{from: "2023-13"}, {to: "2023-04-01"}, {from: "2023-05", to: "2023-04"}} {
+		_, err := NewMeteringPeriod(tc.from, tc.to, now)
+		assert.ErrorIs(t, err, ErrInvalidMeteringPeriod)
+	}
+}
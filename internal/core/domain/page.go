@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPageLimit is the number of items of the pages requested without a limit
+	DefaultPageLimit = 100
+	// MaxPageLimit is the maximum number of items of a page
+	MaxPageLimit = 1000
+)
+
+// ErrInvalidPageToken is returned by ParsePageToken
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+const pageTokenPrefix = "offset:"
+
+// PageToken returns the token of the page that starts at offset. The clients must not rely on its format.
+func PageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(pageTokenPrefix + strconv.Itoa(offset)))
+}
+
+// ParsePageToken returns the offset of the page of a token returned by PageToken
+func ParsePageToken(token string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(decoded), pageTokenPrefix))
+	if err != nil || offset < 0 || !strings.HasPrefix(string(decoded), pageTokenPrefix) {
+		return 0, ErrInvalidPageToken
+	}
+	return offset, nil
+}
+
+// NextPageToken returns the token of the page after the one that starts at offset with count of the total items, or an
+// empty string when it is the last one
+func NextPageToken(offset int, count int, total int) string {
+	if count == 0 || offset+count >= total {
+		return ""
+	}
+	return PageToken(offset + count)
+}
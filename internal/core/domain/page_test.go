@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	offset, err := ParsePageToken(PageToken(200))
+	require.NoError(t, err)
+	assert.Equal(t, 200, offset)
+
+	for _, token := range []string{"200", "b2Zmc2V0Oi0x", "!"} {
+		_, err := ParsePageToken(token)
+		assert.ErrorIs(t, err, ErrInvalidPageToken, token)
+	}
+}
+
+func TestNextPageToken(t *testing.T) {
+	assert.Equal(t, PageToken(100), NextPageToken(0, 100, 250))
+	assert.Equal(t, PageToken(250), NextPageToken(200, 50, 300))
+	assert.Empty(t, NextPageToken(200, 50, 250), "the last page")
+	assert.Empty(t, NextPageToken(300, 0, 250), "past the last page")
+}
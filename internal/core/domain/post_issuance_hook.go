@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Modes of a post-issuance hook
+const (
+	PostIssuanceHookSync   = "sync"   // PostIssuanceHookSync the hook is called before the issuance request returns
+	PostIssuanceHookQueued = "queued" // PostIssuanceHookQueued the hook is called by the notifications service
+)
+
+// ErrInvalidPostIssuanceHook is returned by PostIssuanceHooks.Validate
+var ErrInvalidPostIssuanceHook = errors.New("invalid post-issuance hook")
+
+// PostIssuanceHook is an endpoint that receives the credentials of a schema once they are issued. The reference it
+// answers is kept in the credential external references under the hook name.
+type PostIssuanceHook struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Mode string `json:"mode"`
+}
+
+// PostIssuanceHooks are the hooks of a schema
+type PostIssuanceHooks []PostIssuanceHook
+
+// Validate checks that every hook has a unique name, an http or https url and a known mode
+func (h PostIssuanceHooks) Validate() error {
+	names := make(map[string]bool, len(h))
+	for _, hook := range h {
+		if hook.Name == "" || names[hook.Name] {
+			return fmt.Errorf("%w: the name must be unique and not empty <%s>", ErrInvalidPostIssuanceHook, hook.Name)
+		}
+		names[hook.Name] = true
+		u, err := url.ParseRequestURI(hook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: invalid url <%s>", ErrInvalidPostIssuanceHook, hook.URL)
+		}
+		if hook.Mode != PostIssuanceHookSync && hook.Mode != PostIssuanceHookQueued {
+			return fmt.Errorf("%w: the mode must be %s or %s <%s>", ErrInvalidPostIssuanceHook, PostIssuanceHookSync, PostIssuanceHookQueued, hook.Mode)
+		}
+	}
+	return nil
+}
+
+// Get returns the hook with the given name
+func (h PostIssuanceHooks) Get(name string) (*PostIssuanceHook, bool) {
+	for i := range h {
+		if h[i].Name == name {
+			return &h[i], true
+		}
+	}
+	return nil, false
+}
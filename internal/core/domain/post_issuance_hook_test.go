@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostIssuanceHooksValidate(t *testing.T) {
+	valid := PostIssuanceHooks{
+		{Name: "crm", URL: "https://crm.example.com/credentials", Mode: PostIssuanceHookSync},
+		{Name: "archive", URL: "http://archive:8080/hook", Mode: PostIssuanceHookQueued},
+	}
+	assert.NoError(t, valid.Validate())
+	assert.NoError(t, PostIssuanceHooks(nil).Validate())
+
+	hook, found := valid.Get("archive")
+	assert.True(t, found)
+	assert.Equal(t, "http://archive:8080/hook", hook.URL)
+	_, found = valid.Get("other")
+	assert.False(t, found)
+
+	for _, tc := range []PostIssuanceHooks{
+		{{Name: "", URL: "https://crm.example.com", Mode: PostIssuanceHookSync}},
+		{{Name: "crm", URL: "https://crm.example.com", Mode: PostIssuanceHookSync}, {Name: "crm", URL: "https://other.example.com", Mode: PostIssuanceHookSync}},
+		{{Name: "crm", URL: "crm.example.com", Mode: PostIssuanceHookSync}},
+		{{Name: "crm", URL: "ftp://crm.example.com", Mode: PostIssuanceHookSync}},
+		{{Name: "crm", URL: "https://crm.example.com", Mode: "async"}},
+	} {
+		assert.ErrorIs(t, tc.Validate(), ErrInvalidPostIssuanceHook)
+	}
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/iden3comm/protocol"
+)
+
+// Proof types of a prerequisite
+const (
+	PrerequisiteSig = "sig" // PrerequisiteSig the holder proves the prerequisite with its signature proof
+	PrerequisiteMTP = "mtp" // PrerequisiteMTP the holder proves the prerequisite with its merkle tree proof
+)
+
+// AnyIssuer allows a prerequisite credential of any issuer
+const AnyIssuer = "*"
+
+// prerequisiteCircuits are the query circuits that verify the proof types of a prerequisite
+var prerequisiteCircuits = map[string]string{
+	PrerequisiteSig: "credentialAtomicQuerySigV2",
+	PrerequisiteMTP: "credentialAtomicQueryMTPV2",
+}
+
+// prerequisiteOperators are the query operators supported by the credentialSubject of a prerequisite
+var prerequisiteOperators = map[string]bool{"$eq": true, "$lt": true, "$gt": true, "$in": true, "$nin": true, "$ne": true}
+
+// ErrInvalidPrerequisite is returned by Prerequisites.Validate
+var ErrInvalidPrerequisite = errors.New("invalid prerequisite")
+
+// Prerequisite is a credential that the holder must prove to hold, with a zero-knowledge proof, before a credential
+// is issued to it.
+type Prerequisite struct {
+	// Context is the JSON-LD context url of the prerequisite credential and Type its type in that context
+	Context string `json:"context"`
+	Type    string `json:"type"`
+	// AllowedIssuers are the DIDs of the issuers of the prerequisite credential, or * for any issuer
+	AllowedIssuers []string `json:"allowedIssuers"`
+	// CredentialSubject optionally queries an attribute of the prerequisite credential, e.g. {"birthday": {"$lt": 20000101}}
+	CredentialSubject map[string]any `json:"credentialSubject,omitempty"`
+	ProofType         string         `json:"proofType"`
+}
+
+// Prerequisites are the prerequisite credentials of a schema or a link
+type Prerequisites []Prerequisite
+
+// Validate checks that every prerequisite has a context url, a type, allowed issuers, a known proof type and a
+// query of one attribute with one known operator at most
+func (p Prerequisites) Validate() error {
+	for _, prerequisite := range p {
+		u, err := url.Parse(prerequisite.Context)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "ipfs") {
+			return fmt.Errorf("%w: invalid context <%s>", ErrInvalidPrerequisite, prerequisite.Context)
+		}
+		if strings.TrimSpace(prerequisite.Type) == "" {
+			return fmt.Errorf("%w: the type of <%s> is empty", ErrInvalidPrerequisite, prerequisite.Context)
+		}
+		if len(prerequisite.AllowedIssuers) == 0 {
+			return fmt.Errorf("%w: the allowed issuers of <%s> are empty", ErrInvalidPrerequisite, prerequisite.Type)
+		}
+		for _, issuer := range prerequisite.AllowedIssuers {
+			if issuer == AnyIssuer {
+				continue
+			}
+			if _, err := core.ParseDID(issuer); err != nil {
+				return fmt.Errorf("%w: invalid allowed issuer <%s>", ErrInvalidPrerequisite, issuer)
+			}
+		}
+		if _, found := prerequisiteCircuits[prerequisite.ProofType]; !found {
+			return fmt.Errorf("%w: the proof type must be %s or %s <%s>", ErrInvalidPrerequisite, PrerequisiteSig, PrerequisiteMTP, prerequisite.ProofType)
+		}
+		if err := validatePrerequisiteQuery(prerequisite.CredentialSubject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Scope returns the zero-knowledge proof requests of the prerequisites, to be set in an authorization request
+func (p Prerequisites) Scope() []protocol.ZeroKnowledgeProofRequest {
+	if len(p) == 0 {
+		return nil
+	}
+	scope := make([]protocol.ZeroKnowledgeProofRequest, len(p))
+	for i, prerequisite := range p {
+		query := map[string]interface{}{
+			"allowedIssuers": prerequisite.AllowedIssuers,
+			"context":        prerequisite.Context,
+			"type":           prerequisite.Type,
+		}
+		if len(prerequisite.CredentialSubject) > 0 {
+			query["credentialSubject"] = prerequisite.CredentialSubject
+		}
+		scope[i] = protocol.ZeroKnowledgeProofRequest{
+			ID:        uint32(i + 1),
+			CircuitID: prerequisiteCircuits[prerequisite.ProofType],
+			Query:     query,
+		}
+	}
+	return scope
+}
+
+func validatePrerequisiteQuery(credentialSubject map[string]any) error {
+	if len(credentialSubject) > 1 {
+		return fmt.Errorf("%w: the credential subject can query one attribute only", ErrInvalidPrerequisite)
+	}
+	for attr, value := range credentialSubject {
+		operators, ok := value.(map[string]any)
+		if !ok || len(operators) > 1 {
+			return fmt.Errorf("%w: the query of <%s> must have one operator at most", ErrInvalidPrerequisite, attr)
+		}
+		for operator := range operators {
+			if !prerequisiteOperators[operator] {
+				return fmt.Errorf("%w: unknown operator <%s>", ErrInvalidPrerequisite, operator)
+			}
+		}
+	}
+	return nil
+}
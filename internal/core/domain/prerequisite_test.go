@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrerequisitesValidate(t *testing.T) {
+	valid := Prerequisites{
+		{
+			Context:           "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+			Type:              "KYCAgeCredential",
+			AllowedIssuers:    []string{"did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"},
+			CredentialSubject: map[string]any{"birthday": map[string]any{"$lt": 20000101}},
+			ProofType:         PrerequisiteSig,
+		},
+		{Context: "ipfs://QmdH1Vu79p2NcZLFbHxzJnLuUHJiMZnBeT7SNpLaqK7k9X", Type: "Membership", AllowedIssuers: []string{AnyIssuer}, ProofType: PrerequisiteMTP},
+	}
+	assert.NoError(t, valid.Validate())
+	assert.NoError(t, Prerequisites(nil).Validate())
+
+	for _, tc := range []Prerequisite{
+		{Context: "kyc-v3.json-ld", Type: "KYCAgeCredential", AllowedIssuers: []string{AnyIssuer}, ProofType: PrerequisiteSig},
+		{Context: "https://example.com/kyc.json-ld", Type: " ", AllowedIssuers: []string{AnyIssuer}, ProofType: PrerequisiteSig},
+		{Context: "https://example.com/kyc.json-ld", Type: "KYCAgeCredential", ProofType: PrerequisiteSig},
+		{Context: "https://example.com/kyc.json-ld", Type: "KYCAgeCredential", AllowedIssuers: []string{"issuer"}, ProofType: PrerequisiteSig},
+		{Context: "https://example.com/kyc.json-ld", Type: "KYCAgeCredential", AllowedIssuers: []string{AnyIssuer}, ProofType: "bbs"},
+		{Context: "https://example.com/kyc.json-ld", Type: "KYCAgeCredential", AllowedIssuers: []string{AnyIssuer}, ProofType: PrerequisiteSig, CredentialSubject: map[string]any{"birthday": 20000101}},
+		{Context: "https://example.com/kyc.json-ld", Type: "KYCAgeCredential", AllowedIssuers: []string{AnyIssuer}, ProofType: PrerequisiteSig, CredentialSubject: map[string]any{"birthday": map[string]any{"$lte": 20000101}}},
+		{Context: "https://example.com/kyc.json-ld", Type: "KYCAgeCredential", AllowedIssuers: []string{AnyIssuer}, ProofType: PrerequisiteSig, CredentialSubject: map[string]any{"birthday": map[string]any{}, "country": map[string]any{}}},
+	} {
+		assert.ErrorIs(t, Prerequisites{tc}.Validate(), ErrInvalidPrerequisite)
+	}
+}
+
+func TestPrerequisitesScope(t *testing.T) {
+	assert.Nil(t, Prerequisites(nil).Scope())
+
+	scope := Prerequisites{
+		{Context: "https://example.com/kyc.json-ld", Type: "KYCAgeCredential", AllowedIssuers: []string{AnyIssuer}, ProofType: PrerequisiteSig, CredentialSubject: map[string]any{"birthday": map[string]any{"$lt": 20000101}}},
+		{Context: "https://example.com/member.json-ld", Type: "Membership", AllowedIssuers: []string{AnyIssuer}, ProofType: PrerequisiteMTP},
+	}.Scope()
+	require.Len(t, scope, 2)
+	assert.Equal(t, uint32(1), scope[0].ID)
+	assert.Equal(t, "credentialAtomicQuerySigV2", scope[0].CircuitID)
+	assert.Equal(t, "KYCAgeCredential", scope[0].Query["type"])
+	assert.Equal(t, map[string]any{"birthday": map[string]any{"$lt": 20000101}}, scope[0].Query["credentialSubject"])
+	assert.Equal(t, uint32(2), scope[1].ID)
+	assert.Equal(t, "credentialAtomicQueryMTPV2", scope[1].CircuitID)
+	assert.NotContains(t, scope[1].Query, "credentialSubject")
+}
+
+func TestLinkAllPrerequisites(t *testing.T) {
+	schemaPrerequisite := Prerequisite{Context: "https://example.com/kyc.json-ld", Type: "KYCAgeCredential", AllowedIssuers: []string{AnyIssuer}, ProofType: PrerequisiteSig}
+	linkPrerequisite := Prerequisite{Context: "https://example.com/member.json-ld", Type: "Membership", AllowedIssuers: []string{AnyIssuer}, ProofType: PrerequisiteMTP}
+
+	link := Link{Prerequisites: Prerequisites{linkPrerequisite}}
+	assert.Equal(t, Prerequisites{linkPrerequisite}, link.AllPrerequisites())
+	link.Schema = &Schema{Prerequisites: Prerequisites{schemaPrerequisite}}
+	assert.Equal(t, Prerequisites{schemaPrerequisite, linkPrerequisite}, link.AllPrerequisites())
+}
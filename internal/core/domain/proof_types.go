@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+)
+
+var (
+	ErrNoProofTypes         = errors.New("at least one proof type must be provided")       // ErrNoProofTypes means the list of proof types is empty
+	ErrUnsupportedProofType = errors.New("unsupported proof type")                         // ErrUnsupportedProofType means the proof type is not BJJSignature2021 or Iden3SparseMerkleTreeProof
+	ErrIncompatibleProof    = errors.New("proof type is not compatible with the identity") // ErrIncompatibleProof means the identity cannot issue credentials with that proof type
+)
+
+// ProofTypes is the list of proofs attached to a credential
+type ProofTypes []verifiable.ProofType
+
+// NewProofTypes returns the proof types of a credential that has the given proofs
+func NewProofTypes(signatureProof bool, mtProof bool) ProofTypes {
+	proofTypes := make(ProofTypes, 0, 2)
+	if signatureProof {
+		proofTypes = append(proofTypes, verifiable.BJJSignatureProofType)
+	}
+	if mtProof {
+		proofTypes = append(proofTypes, verifiable.Iden3SparseMerkleTreeProofType)
+	}
+	return proofTypes
+}
+
+// ProofTypesFromStrings is a ProofTypes constructor from the values stored in the database. It returns nil for nil.
+func ProofTypesFromStrings(values []string) ProofTypes {
+	if values == nil {
+		return nil
+	}
+	proofTypes := make(ProofTypes, len(values))
+	for i, value := range values {
+		proofTypes[i] = verifiable.ProofType(value)
+	}
+	return proofTypes
+}
+
+// DefaultProofTypes returns the proof types used when neither the request, the schema nor the identity set them:
+// both proofs, or only the signature for identities that cannot publish their state on chain.
+func DefaultProofTypes(did core.DID) ProofTypes {
+	return NewProofTypes(true, canPublishState(did))
+}
+
+// SignatureProof tells whether the list contains BJJSignature2021
+func (p ProofTypes) SignatureProof() bool {
+	return p.contains(verifiable.BJJSignatureProofType)
+}
+
+// MTProof tells whether the list contains Iden3SparseMerkleTreeProof
+func (p ProofTypes) MTProof() bool {
+	return p.contains(verifiable.Iden3SparseMerkleTreeProofType)
+}
+
+// Strings returns the proof types as strings, to be stored in the database. It returns nil for nil.
+func (p ProofTypes) Strings() []string {
+	if p == nil {
+		return nil
+	}
+	values := make([]string, len(p))
+	for i, proofType := range p {
+		values[i] = string(proofType)
+	}
+	return values
+}
+
+// Validate checks that the list is not empty, that all the proof types are supported and that the identity
+// can issue credentials with them. Merkle tree proofs need an identity that publishes its state on chain.
+func (p ProofTypes) Validate(did core.DID) error {
+	if len(p) == 0 {
+		return ErrNoProofTypes
+	}
+	for _, proofType := range p {
+		switch proofType {
+		case verifiable.BJJSignatureProofType:
+		case verifiable.Iden3SparseMerkleTreeProofType:
+			if !canPublishState(did) {
+				return fmt.Errorf("%w: %s identities cannot publish their state, %s is not available", ErrIncompatibleProof, did.Blockchain, proofType)
+			}
+		default:
+			return fmt.Errorf("%w: %s", ErrUnsupportedProofType, proofType)
+		}
+	}
+	return nil
+}
+
+func (p ProofTypes) contains(proofType verifiable.ProofType) bool {
+	for _, item := range p {
+		if item == proofType {
+			return true
+		}
+	}
+	return false
+}
+
+func canPublishState(did core.DID) bool {
+	return did.Blockchain != core.ReadOnly && did.Blockchain != core.NoChain
+}
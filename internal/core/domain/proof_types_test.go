@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofTypes_Validate(t *testing.T) {
+	did, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+	readOnly := core.DID{Method: core.DIDMethodPolygonID, Blockchain: core.ReadOnly}
+
+	assert.NoError(t, NewProofTypes(true, true).Validate(*did))
+	assert.NoError(t, NewProofTypes(false, true).Validate(*did))
+	assert.NoError(t, NewProofTypes(true, false).Validate(readOnly))
+	assert.ErrorIs(t, NewProofTypes(false, true).Validate(readOnly), ErrIncompatibleProof)
+	assert.ErrorIs(t, NewProofTypes(false, false).Validate(*did), ErrNoProofTypes)
+	assert.ErrorIs(t, ProofTypes{"JsonWebSignature2020"}.Validate(*did), ErrUnsupportedProofType)
+}
+
+func TestDefaultProofTypes(t *testing.T) {
+	did, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+
+	assert.Equal(t, ProofTypes{verifiable.BJJSignatureProofType, verifiable.Iden3SparseMerkleTreeProofType}, DefaultProofTypes(*did))
+	assert.Equal(t, ProofTypes{verifiable.BJJSignatureProofType}, DefaultProofTypes(core.DID{Method: core.DIDMethodPolygonID, Blockchain: core.ReadOnly}))
+}
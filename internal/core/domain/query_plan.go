@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// largeTableRows is the number of rows from which a sequential scan of a table is reported
+	largeTableRows = 10000
+	// bloatedDeadRatio is the ratio of dead rows per live row from which a table is reported as bloated
+	bloatedDeadRatio = 0.2
+	// bloatedDeadRows is the number of dead rows from which a table can be reported as bloated
+	bloatedDeadRows = 1000
+)
+
+// QueryPlan is the plan postgres chooses for a frequent query of the node, with the data it has now
+type QueryPlan struct {
+	Name string
+	// Index is the index that serves the query, suggested when it reads a large table sequentially
+	Index     string
+	TotalCost float64
+	Rows      float64
+	// SeqScans are the tables the query reads sequentially
+	SeqScans []string
+	// Indexes are the indexes the query reads
+	Indexes []string
+}
+
+// queryPlanNode is a node of the output of EXPLAIN (FORMAT JSON)
+type queryPlanNode struct {
+	NodeType     string          `json:"Node Type"`
+	RelationName string          `json:"Relation Name"`
+	IndexName    string          `json:"Index Name"`
+	TotalCost    float64         `json:"Total Cost"`
+	PlanRows     float64         `json:"Plan Rows"`
+	Plans        []queryPlanNode `json:"Plans"`
+}
+
+// ParseQueryPlan returns the plan of the query from the output of EXPLAIN (FORMAT JSON)
+func ParseQueryPlan(name string, index string, explain []byte) (*QueryPlan, error) {
+	var plans []struct {
+		Plan queryPlanNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(explain, &plans); err != nil {
+		return nil, fmt.Errorf("invalid plan of %s: %w", name, err)
+	}
+	if len(plans) == 0 {
+		return nil, errors.New("empty plan of " + name)
+	}
+
+	root := plans[0].Plan
+	plan := &QueryPlan{Name: name, Index: index, TotalCost: root.TotalCost, Rows: root.PlanRows, SeqScans: []string{}, Indexes: []string{}}
+	var walk func(node queryPlanNode)
+	walk = func(node queryPlanNode) {
+		switch {
+		case node.NodeType == "Seq Scan":
+			plan.SeqScans = appendUnique(plan.SeqScans, node.RelationName)
+		case node.IndexName != "":
+			plan.Indexes = appendUnique(plan.Indexes, node.IndexName)
+		}
+		for _, child := range node.Plans {
+			walk(child)
+		}
+	}
+	walk(root)
+	return plan, nil
+}
+
+// TableStats are the statistics postgres keeps of a table
+type TableStats struct {
+	Table      string
+	LiveRows   int64
+	DeadRows   int64
+	SizeBytes  int64
+	SeqScans   int64
+	IndexScans int64
+	// LastVacuum is the last manual or automatic vacuum, nil if it was never vacuumed
+	LastVacuum *time.Time
+}
+
+// DeadRatio is the number of dead rows per live row, that vacuum hasn't reclaimed yet
+func (s TableStats) DeadRatio() float64 {
+	if s.LiveRows == 0 {
+		return 0
+	}
+	return float64(s.DeadRows) / float64(s.LiveRows)
+}
+
+// Bloated tells if the table has enough dead rows to slow down its reads
+func (s TableStats) Bloated() bool {
+	return s.DeadRows >= bloatedDeadRows && s.DeadRatio() >= bloatedDeadRatio
+}
+
+// QueryPlanReport are the plans of the frequent queries of the node and the statistics of their tables, with the
+// advice to speed them up
+type QueryPlanReport struct {
+	Plans  []QueryPlan
+	Tables []TableStats
+	Advice []string
+}
+
+// NewQueryPlanReport returns the report of the plans and tables. It advises an index for every query that reads a
+// large table sequentially, and a vacuum for every bloated table.
+func NewQueryPlanReport(plans []QueryPlan, tables []TableStats) *QueryPlanReport {
+	report := &QueryPlanReport{Plans: plans, Tables: tables, Advice: []string{}}
+	rows := make(map[string]int64, len(tables))
+	for _, table := range tables {
+		rows[table.Table] = table.LiveRows
+	}
+	for _, plan := range plans {
+		for _, table := range plan.SeqScans {
+			if rows[table] >= largeTableRows {
+				report.Advice = append(report.Advice, fmt.Sprintf("the %s query reads the %d rows of %s sequentially, an index on %s would serve it", plan.Name, rows[table], table, plan.Index))
+			}
+		}
+	}
+	for _, table := range tables {
+		if table.Bloated() {
+			report.Advice = append(report.Advice, fmt.Sprintf("%s has %d dead rows, %.0f%% of the live ones, vacuum it or tune its autovacuum", table.Table, table.DeadRows, table.DeadRatio()*100))
+		}
+	}
+	return report
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
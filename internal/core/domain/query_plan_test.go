@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryPlan(t *testing.T) {
+	explain := []byte(`[{"Plan": {"Node Type": "Hash Right Join", "Total Cost": 1234.5, "Plan Rows": 20, "Plans": [
+		{"Node Type": "Seq Scan", "Relation Name": "claims", "Total Cost": 1000, "Plan Rows": 50000},
+		{"Node Type": "Hash", "Plans": [
+			{"Node Type": "Index Scan", "Relation Name": "links", "Index Name": "links_issuer_id_idx", "Plan Rows": 20},
+			{"Node Type": "Seq Scan", "Relation Name": "claims", "Plan Rows": 10}
+		]}
+	]}}]`)
+
+	plan, err := ParseQueryPlan("links_by_issuer", "claims (link_id)", explain)
+	require.NoError(t, err)
+	assert.Equal(t, &QueryPlan{
+		Name:      "links_by_issuer",
+		Index:     "claims (link_id)",
+		TotalCost: 1234.5,
+		Rows:      20,
+		SeqScans:  []string{"claims"},
+		Indexes:   []string{"links_issuer_id_idx"},
+	}, plan)
+
+	_, err = ParseQueryPlan("links_by_issuer", "claims (link_id)", []byte(`[]`))
+	assert.Error(t, err)
+}
+
+func TestNewQueryPlanReport(t *testing.T) {
+	plans := []QueryPlan{
+		{Name: "claims_by_subject", Index: "claims (identifier, other_identifier)", SeqScans: []string{"claims"}},
+		{Name: "links_by_issuer", Index: "links (issuer_id)", SeqScans: []string{"links"}},
+		{Name: "claim_by_revocation_nonce", Index: "claims (identifier, rev_nonce)", Indexes: []string{"claims_identifier_rev_nonce_key"}},
+	}
+	tables := []TableStats{
+		{Table: "claims", LiveRows: 200000, DeadRows: 50000},
+		{Table: "links", LiveRows: 300, DeadRows: 200},
+	}
+
+	report := NewQueryPlanReport(plans, tables)
+	assert.Equal(t, []string{
+		"the claims_by_subject query reads the 200000 rows of claims sequentially, an index on claims (identifier, other_identifier) would serve it",
+		"claims has 50000 dead rows, 25% of the live ones, vacuum it or tune its autovacuum",
+	}, report.Advice)
+	assert.False(t, tables[1].Bloated(), "a small table is not reported")
+}
@@ -0,0 +1,70 @@
+package domain
+
+// QuotaResource is a resource of an identity limited by the quotas
+type QuotaResource string
+
+const (
+	QuotaActiveLinks       QuotaResource = "activeLinks"       // QuotaActiveLinks the links that can issue credentials
+	QuotaCredentialsPerDay QuotaResource = "credentialsPerDay" // QuotaCredentialsPerDay the credentials issued in the current UTC day
+	QuotaSchemas           QuotaResource = "schemas"           // QuotaSchemas the imported and created schemas
+)
+
+// Quotas are the limits of the resources of an identity. Zero is unlimited.
+type Quotas struct {
+	MaxActiveLinks       int
+	MaxCredentialsPerDay int
+	MaxSchemas           int
+}
+
+// QuotaOverrides are the quotas of an identity that replace the ones of the node. Nil keeps the node quota, and zero
+// makes the resource unlimited for the identity.
+type QuotaOverrides struct {
+	MaxActiveLinks       *int
+	MaxCredentialsPerDay *int
+	MaxSchemas           *int
+}
+
+// QuotaUsage is the usage of the resources of an identity, with its quotas
+type QuotaUsage struct {
+	Quotas           Quotas
+	ActiveLinks      int
+	CredentialsToday int
+	Schemas          int
+}
+
+// With returns the quotas with the overrides of an identity applied
+func (q Quotas) With(overrides QuotaOverrides) Quotas {
+	if overrides.MaxActiveLinks != nil {
+		q.MaxActiveLinks = *overrides.MaxActiveLinks
+	}
+	if overrides.MaxCredentialsPerDay != nil {
+		q.MaxCredentialsPerDay = *overrides.MaxCredentialsPerDay
+	}
+	if overrides.MaxSchemas != nil {
+		q.MaxSchemas = *overrides.MaxSchemas
+	}
+	return q
+}
+
+// Limit returns the quota of the resource, zero when it is unlimited
+func (q Quotas) Limit(resource QuotaResource) int {
+	switch resource {
+	case QuotaActiveLinks:
+		return q.MaxActiveLinks
+	case QuotaCredentialsPerDay:
+		return q.MaxCredentialsPerDay
+	case QuotaSchemas:
+		return q.MaxSchemas
+	}
+	return 0
+}
+
+// Valid tells whether the overrides are not negative
+func (o QuotaOverrides) Valid() bool {
+	for _, value := range []*int{o.MaxActiveLinks, o.MaxCredentialsPerDay, o.MaxSchemas} {
+		if value != nil && *value < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+)
+
+func TestQuotas_With(t *testing.T) {
+	node := Quotas{MaxActiveLinks: 10, MaxCredentialsPerDay: 1000, MaxSchemas: 20}
+
+	assert.Equal(t, node, node.With(QuotaOverrides{}))
+
+	quotas := node.With(QuotaOverrides{MaxActiveLinks: common.ToPointer(50), MaxSchemas: common.ToPointer(0)})
+	assert.Equal(t, 50, quotas.Limit(QuotaActiveLinks))
+	assert.Equal(t, 1000, quotas.Limit(QuotaCredentialsPerDay))
+	assert.Equal(t, 0, quotas.Limit(QuotaSchemas), "zero makes the resource unlimited")
+}
+
+func TestQuotaOverrides_Valid(t *testing.T) {
+	assert.True(t, QuotaOverrides{}.Valid())
+	assert.True(t, QuotaOverrides{MaxSchemas: common.ToPointer(0)}.Valid())
+	assert.False(t, QuotaOverrides{MaxCredentialsPerDay: common.ToPointer(-1)}.Valid())
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"net/http"
+	"time"
+)
+
+// RegionLease is the lease of the active region of a node deployed in several regions. Only the servers of the region
+// that holds it change the node, and the other regions serve the reads from their replica of the database. Epoch grows
+// with each promotion, so the servers of the previous active region can't renew it anymore.
+type RegionLease struct {
+	Region    string
+	Epoch     int64
+	ExpiresAt time.Time
+	UpdatedAt time.Time
+}
+
+// Region is the role of the region of a server. A region is active while its database is the primary one and it holds
+// an unexpired lease, and passive otherwise: it rejects the requests that change the node, the ones in
+// PassiveExemptOperations aside. A node without a region name runs in a single region and is always active.
+type Region struct {
+	Name       string
+	Active     bool
+	InRecovery bool         // InRecovery the database of the region is a replica that follows the one of the active region
+	Lease      *RegionLease // Lease nil when no region took it yet
+}
+
+// PassiveExemptOperations are the operations that the passive regions serve although they change the node: the agent,
+// that rejects the messages it can't check for replays there, the credential checks, that only read, and the promotion
+// itself.
+var PassiveExemptOperations = map[string]bool{
+	"Agent":                   true,
+	"CheckCredentialValidity": true,
+	"PromoteRegion":           true,
+}
+
+// Rejects tells whether the region rejects a request with the http method to the operation
+func (r *Region) Rejects(method string, operationID string) bool {
+	return r != nil && !r.Active && PassiveApplies(method, operationID)
+}
+
+// PassiveApplies tells whether a request with the http method to the operation changes the node and is not served by
+// the passive regions
+func PassiveApplies(method string, operationID string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return !PassiveExemptOperations[operationID]
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegion_Rejects(t *testing.T) {
+	passive := &Region{Name: "eu-west", InRecovery: true}
+	type testConfig struct {
+		name        string
+		region      *Region
+		method      string
+		operationID string
+		expected    bool
+	}
+	for _, tc := range []testConfig{
+		{name: "no region", method: http.MethodPost, operationID: "CreateClaim"},
+		{name: "active", region: &Region{Name: "eu-west", Active: true}, method: http.MethodPost, operationID: "CreateClaim"},
+		{name: "mutating operation", region: passive, method: http.MethodPost, operationID: "CreateClaim", expected: true},
+		{name: "maintenance switch", region: passive, method: http.MethodPut, operationID: "UpdateMaintenance", expected: true},
+		{name: "read", region: passive, method: http.MethodGet, operationID: "GetClaim"},
+		{name: "agent", region: passive, method: http.MethodPost, operationID: "Agent"},
+		{name: "credential validity", region: passive, method: http.MethodPost, operationID: "CheckCredentialValidity"},
+		{name: "promotion", region: passive, method: http.MethodPost, operationID: "PromoteRegion"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.region.Rejects(tc.method, tc.operationID))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Role is what the clients of the APIs can do
+type Role string
+
+const (
+	// RoleAdmin can call every operation
+	RoleAdmin Role = "admin"
+	// RoleOperator issues, revokes and manages the credentials, links, connections and schemas and publishes the
+	// states: the ReadOnlyOperations and the OperatorOperations
+	RoleOperator Role = "issuer-operator"
+	// RoleReadOnly can only read, like the support staff: the ReadOnlyOperations
+	RoleReadOnly Role = "read-only"
+)
+
+// ErrInvalidRole is returned by ParseRole
+var ErrInvalidRole = errors.New("invalid role")
+
+// ReadOnlyOperations are the operations of the issuer API and the UI API that every role can call. The operations
+// that are in none of the lists can only be called by the admins, so a new operation is closed until it is added to
+// one of them.
+var ReadOnlyOperations = map[string]bool{
+	"GetIdentities":                true,
+	"GetMetering":                  true,
+	"GetMaintenance":               true,
+	"GetRegion":                    true,
+	"GetIdentityDefaultProofTypes": true,
+	"GetSigningKeys":               true,
+	"GetKeyRotation":               true,
+	"GetIdentityStats":             true,
+	"GetIdentityBranding":          true,
+	"GetSchemaWebhooks":            true,
+	"GetClaims":                    true,
+	"GetClaim":                     true,
+	"GetClaimQrCode":               true,
+	"GetClaimIssuanceTiming":       true,
+	"GetVerifications":             true,
+	"GetConnection":                true,
+	"GetConnectionCredentials":     true,
+	"GetConnections":               true,
+	"GetCredentials":               true,
+	"GetCredential":                true,
+	"GetSchemas":                   true,
+	"GetSchema":                    true,
+	"GetSchemaVersions":            true,
+	"GetSchemaStats":               true,
+	"GetHookDeliveries":            true,
+	"GetBranding":                  true,
+	"GetStateStatus":               true,
+	"GetStateTransactions":         true,
+	"GetLinks":                     true,
+	"GetLink":                      true,
+	"GetLinkSessions":              true,
+	"GetLinkSession":               true,
+}
+
+// OperatorOperations are the operations that the issuer operators can call besides the ReadOnlyOperations
+var OperatorOperations = map[string]bool{
+	"PublishIdentityState":        true,
+	"CreateClaim":                 true,
+	"RevokeClaim":                 true,
+	"CheckCredentialValidity":     true,
+	"StreamClaims":                true,
+	"DeleteConnection":            true,
+	"CreateConnectionCredential":  true,
+	"DeleteConnectionCredentials": true,
+	"RevokeConnectionCredentials": true,
+	"CreateCredential":            true,
+	"DeleteCredential":            true,
+	"RevokeCredential":            true,
+	"ReissueCredential":           true,
+	"ImportSchema":                true,
+	"CreateSchema":                true,
+	"BuildSchema":                 true,
+	"UpdateSchema":                true,
+	"PublishState":                true,
+	"RetryPublishState":           true,
+	"CreateLink":                  true,
+	"AcivateLink":                 true,
+	"DeleteLink":                  true,
+}
+
+// AdminOperations are the operations that only the admins can call: the ones that manage the node, the identities
+// and their keys, the webhooks, the api keys and the personal data of the subjects. They are listed so every
+// operation has an explicit classification, the admins can call any operation.
+var AdminOperations = map[string]bool{
+	"CreateIdentity":                  true,
+	"UpdateMaintenance":               true,
+	"PromoteRegion":                   true,
+	"GetQueryPlans":                   true,
+	"UpdateIdentityBranding":          true,
+	"UpdateIdentityDefaultProofTypes": true,
+	"UpdateIdentityQuotas":            true,
+	"AddSigningKey":                   true,
+	"RotateIdentityKey":               true,
+	"RemoveSigningKey":                true,
+	"CreateSchemaWebhook":             true,
+	"DeleteSchemaWebhook":             true,
+	"ImportClaim":                     true,
+	"ResignClaims":                    true,
+	"TestPostIssuanceHook":            true,
+	"GetAPIKeys":                      true,
+	"CreateAPIKey":                    true,
+	"DeleteAPIKey":                    true,
+	"UpdateBranding":                  true,
+	"PurgeSchemaCache":                true,
+	"GetSubjectData":                  true,
+	"EraseSubjectData":                true,
+}
+
+// ParseRole returns the role of its name
+func ParseRole(name string) (Role, error) {
+	switch role := Role(name); role {
+	case RoleAdmin, RoleOperator, RoleReadOnly:
+		return role, nil
+	default:
+		return "", fmt.Errorf("%w <%s>, it must be %s, %s or %s", ErrInvalidRole, name, RoleAdmin, RoleOperator, RoleReadOnly)
+	}
+}
+
+// Allows tells whether the role can call the operation with the http method. The read-only role can only call the
+// ReadOnlyOperations with a safe method.
+func (r Role) Allows(method string, operationID string) bool {
+	switch r {
+	case RoleAdmin:
+		return true
+	case RoleOperator:
+		return ReadOnlyOperations[operationID] || OperatorOperations[operationID]
+	case RoleReadOnly:
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return ReadOnlyOperations[operationID]
+		}
+		return false
+	default:
+		return false
+	}
+}
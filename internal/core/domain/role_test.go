@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole_Allows(t *testing.T) {
+	type testConfig struct {
+		name        string
+		role        Role
+		method      string
+		operationID string
+		expected    bool
+	}
+	for _, tc := range []testConfig{
+		{name: "admin revokes", role: RoleAdmin, method: http.MethodPost, operationID: "RevokeClaim", expected: true},
+		{name: "admin creates an identity", role: RoleAdmin, method: http.MethodPost, operationID: "CreateIdentity", expected: true},
+		{name: "operator revokes", role: RoleOperator, method: http.MethodPost, operationID: "RevokeCredential", expected: true},
+		{name: "operator publishes", role: RoleOperator, method: http.MethodPost, operationID: "PublishState", expected: true},
+		{name: "operator rotates a key", role: RoleOperator, method: http.MethodPost, operationID: "RotateIdentityKey"},
+		{name: "operator lists the api keys", role: RoleOperator, method: http.MethodGet, operationID: "GetAPIKeys"},
+		{name: "read-only lists credentials", role: RoleReadOnly, method: http.MethodGet, operationID: "GetCredentials", expected: true},
+		{name: "read-only lists connections", role: RoleReadOnly, method: http.MethodGet, operationID: "GetConnections", expected: true},
+		{name: "read-only revokes", role: RoleReadOnly, method: http.MethodPost, operationID: "RevokeCredential"},
+		{name: "read-only publishes", role: RoleReadOnly, method: http.MethodPost, operationID: "PublishIdentityState"},
+		{name: "read-only reads personal data", role: RoleReadOnly, method: http.MethodGet, operationID: "GetSubjectData"},
+		{name: "operator creates a webhook", role: RoleOperator, method: http.MethodPost, operationID: "CreateSchemaWebhook"},
+		{name: "operator imports a credential", role: RoleOperator, method: http.MethodPost, operationID: "ImportClaim"},
+		{name: "operator calls an unclassified operation", role: RoleOperator, method: http.MethodPost, operationID: "NewOperation"},
+		{name: "read-only calls an unclassified operation", role: RoleReadOnly, method: http.MethodGet, operationID: "NewOperation"},
+		{name: "admin calls an unclassified operation", role: RoleAdmin, method: http.MethodPost, operationID: "NewOperation", expected: true},
+		{name: "no role", method: http.MethodGet, operationID: "GetCredentials"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.role.Allows(tc.method, tc.operationID))
+		})
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole("issuer-operator")
+	require.NoError(t, err)
+	assert.Equal(t, RoleOperator, role)
+
+	_, err = ParseRole("owner")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
@@ -14,6 +14,10 @@ const (
 	AuthBJJCredentialJSONSchemaURL = "https://schema.iden3.io/core/json/auth.json"
 	AuthBJJCredentialSchemaJSON    = `{"$schema":"http://json-schema.org/draft-07/schema#","$metadata":{"uris":{"jsonLdContext":"https://schema.iden3.io/core/jsonld/auth.jsonld","jsonSchema":"https://schema.iden3.io/core/json/auth.json"},"serialization":{"indexDataSlotA":"x","indexDataSlotB":"y"}},"type":"object","required":["@context","id","type","issuanceDate","credentialSubject","credentialSchema","credentialStatus","issuer"],"properties":{"@context":{"type":["string","array","object"]},"id":{"type":"string"},"type":{"type":["string","array"],"items":{"type":"string"}},"issuer":{"type":["string","object"],"format":"uri","required":["id"],"properties":{"id":{"type":"string","format":"uri"}}},"issuanceDate":{"type":"string","format":"date-time"},"expirationDate":{"type":"string","format":"date-time"},"credentialSchema":{"type":"object","required":["id","type"],"properties":{"id":{"type":"string","format":"uri"},"type":{"type":"string"}}},"credentialSubject":{"type":"object","required":["x","y"],"properties":{"id":{"title":"Credential Subject ID","type":"string","format":"uri"},"x":{"type":"string"},"y":{"type":"string"}}}}}`
 	AuthBJJCredentialSchemaType    = "https://schema.iden3.io/core/jsonld/auth.jsonld#AuthBJJCredential"
+	AuthEthCredential              = "AuthEthCredential"
+	AuthEthCredentialJSONSchemaURL = "urn:sh-id-platform:schemas:json:auth-eth.json"
+	AuthEthCredentialSchemaJSON    = `{"$schema":"http://json-schema.org/draft-07/schema#","$metadata":{"uris":{"jsonLdContext":"urn:sh-id-platform:schemas:jsonld:auth-eth.jsonld","jsonSchema":"urn:sh-id-platform:schemas:json:auth-eth.json"},"serialization":{"indexDataSlotA":"address"}},"type":"object","required":["@context","id","type","issuanceDate","credentialSubject","credentialSchema","credentialStatus","issuer"],"properties":{"@context":{"type":["string","array","object"]},"id":{"type":"string"},"type":{"type":["string","array"],"items":{"type":"string"}},"issuer":{"type":["string","object"],"format":"uri","required":["id"],"properties":{"id":{"type":"string","format":"uri"}}},"issuanceDate":{"type":"string","format":"date-time"},"expirationDate":{"type":"string","format":"date-time"},"credentialSchema":{"type":"object","required":["id","type"],"properties":{"id":{"type":"string","format":"uri"},"type":{"type":"string"}}},"credentialSubject":{"type":"object","required":["address"],"properties":{"id":{"title":"Credential Subject ID","type":"string","format":"uri"},"address":{"type":"string"}}}}}`
+	AuthEthCredentialSchemaType    = "urn:sh-id-platform:schemas:jsonld:auth-eth.jsonld#AuthEthCredential"
 )
 
 // SchemaFormat type
@@ -27,6 +31,9 @@ const (
 	JSON SchemaFormat = "json"
 )
 
+// MaskedValue replaces the value of the PII attributes of a credential when they are masked
+const MaskedValue = "***"
+
 // SchemaAttrs is a collection of schema attributes
 type SchemaAttrs []string
 
@@ -48,6 +55,29 @@ func SchemaAttrsFromString(commaAttrs string) SchemaAttrs {
 	return schemaAttrs
 }
 
+// Contains tells whether attr is in the collection
+func (a SchemaAttrs) Contains(attr string) bool {
+	for _, item := range a {
+		if item == attr {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask returns a copy of the credential subject with the value of the attributes in the collection replaced by
+// MaskedValue. The id and type of the subject are never masked.
+func (a SchemaAttrs) Mask(credentialSubject map[string]any) map[string]any {
+	masked := make(map[string]any, len(credentialSubject))
+	for name, value := range credentialSubject {
+		if name != "id" && name != "type" && a.Contains(name) {
+			value = MaskedValue
+		}
+		masked[name] = value
+	}
+	return masked
+}
+
 // Schema defines a domain.Schema entity
 type Schema struct {
 	ID         uuid.UUID
@@ -56,5 +86,44 @@ type Schema struct {
 	Type       string
 	Hash       core.SchemaHash
 	Attributes SchemaAttrs
-	CreatedAt  time.Time
+	// DefaultProofTypes are the proofs of the credentials of this schema when the request does not set them
+	DefaultProofTypes ProofTypes
+	// DefaultExpiration is the validity period of the credentials of this schema when the request does not set an expiration
+	DefaultExpiration *ExpirationPolicy
+	// PIIAttributes are the credential subject attributes with personal data, masked in the credential listings and logs
+	PIIAttributes SchemaAttrs
+	// MaxActivePerSubject is the maximum number of active credentials of this schema that a subject can hold. Nil is unlimited.
+	MaxActivePerSubject *int
+	// RevokeSuperseded revokes the active credentials of this schema of a subject when a new one is issued to it
+	RevokeSuperseded bool
+	// PostIssuanceHooks receive the credentials of this schema once they are issued
+	PostIssuanceHooks PostIssuanceHooks
+	// DisplayStrings are the localized name and description of the credentials of this schema shown by the wallets
+	DisplayStrings DisplayStrings
+	// Prerequisites are the credentials that the holder must prove to hold before a credential of this schema is
+	// issued to it through a link
+	Prerequisites Prerequisites
+	// Version is the semantic version of the schemas of the registry, empty for the imported ones
+	Version string
+	// Title and Description describe the schema to the issuer users
+	Title       string
+	Description string
+	// DeprecatedAt is when the schema was deprecated. Deprecated schemas can't be used for new credentials or links.
+	DeprecatedAt *time.Time
+	// Document and ContextDocument are the json schema and the JSON-LD context of the schemas of the registry, served
+	// by the node in URL and ContextURL
+	Document        []byte
+	ContextDocument []byte
+	ContextURL      string
+	CreatedAt       time.Time
+}
+
+// InRegistry tells whether the schema was created in the registry of the node instead of imported from a url
+func (s *Schema) InRegistry() bool {
+	return s.Version != ""
+}
+
+// Deprecated tells whether the schema is deprecated
+func (s *Schema) Deprecated() bool {
+	return s.DeprecatedAt != nil
 }
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrInvalidSchemaAttributes means the attribute definitions of a schema to build are not valid
+var ErrInvalidSchemaAttributes = errors.New("invalid schema attributes")
+
+// SchemaAttributeType is the type of an attribute of a schema built by the node
+type SchemaAttributeType string
+
+const (
+	SchemaAttributeString   SchemaAttributeType = "string"    // SchemaAttributeString a text
+	SchemaAttributeInteger  SchemaAttributeType = "integer"   // SchemaAttributeInteger an integer number
+	SchemaAttributeNumber   SchemaAttributeType = "number"    // SchemaAttributeNumber a decimal number
+	SchemaAttributeBoolean  SchemaAttributeType = "boolean"   // SchemaAttributeBoolean true or false
+	SchemaAttributeDate     SchemaAttributeType = "date"      // SchemaAttributeDate a full date, like 2023-04-21
+	SchemaAttributeDateTime SchemaAttributeType = "date-time" // SchemaAttributeDateTime an RFC 3339 timestamp
+)
+
+var attributeNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SchemaAttributeDefinition is an attribute of the credential subject of a schema built by the node
+type SchemaAttributeDefinition struct {
+	Name        string
+	Type        SchemaAttributeType
+	Title       string
+	Description string
+	Required    bool
+}
+
+// BuiltSchema is a json schema built by the node with its JSON-LD context. The urls are the ones where they are
+// published, empty when they were not. Schema is the schema saved in the node, nil when it was not registered.
+type BuiltSchema struct {
+	JSONSchema    []byte
+	JSONLdContext []byte
+	SchemaURL     string
+	ContextURL    string
+	Schema        *Schema
+}
+
+// ValidateSchemaAttributes checks that there is at least one attribute, that the names are unique identifiers other
+// than id, which is added by the node, and that the types are supported.
+func ValidateSchemaAttributes(attrs []SchemaAttributeDefinition) error {
+	if len(attrs) == 0 {
+		return fmt.Errorf("%w: at least one attribute must be provided", ErrInvalidSchemaAttributes)
+	}
+	names := make(map[string]bool, len(attrs))
+	for _, attr := range attrs {
+		if !attributeNameRegexp.MatchString(attr.Name) || attr.Name == "id" {
+			return fmt.Errorf("%w: <%s> is not a valid attribute name", ErrInvalidSchemaAttributes, attr.Name)
+		}
+		if names[attr.Name] {
+			return fmt.Errorf("%w: attribute <%s> is repeated", ErrInvalidSchemaAttributes, attr.Name)
+		}
+		names[attr.Name] = true
+		switch attr.Type {
+		case SchemaAttributeString, SchemaAttributeInteger, SchemaAttributeNumber, SchemaAttributeBoolean, SchemaAttributeDate, SchemaAttributeDateTime:
+		default:
+			return fmt.Errorf("%w: type <%s> of attribute <%s> is not supported", ErrInvalidSchemaAttributes, attr.Type, attr.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchemaAttributes(t *testing.T) {
+	assert.NoError(t, ValidateSchemaAttributes([]SchemaAttributeDefinition{
+		{Name: "birthday", Type: SchemaAttributeDate, Title: "Birthday", Required: true},
+		{Name: "document_type", Type: SchemaAttributeInteger},
+		{Name: "verified", Type: SchemaAttributeBoolean},
+	}))
+
+	for _, attrs := range [][]SchemaAttributeDefinition{
+		nil,
+		{{Name: "id", Type: SchemaAttributeString}},
+		{{Name: "", Type: SchemaAttributeString}},
+		{{Name: "first name", Type: SchemaAttributeString}},
+		{{Name: "2fa", Type: SchemaAttributeBoolean}},
+		{{Name: "tags", Type: "array"}},
+		{{Name: "age", Type: SchemaAttributeInteger}, {Name: "age", Type: SchemaAttributeNumber}},
+	} {
+		assert.ErrorIs(t, ValidateSchemaAttributes(attrs), ErrInvalidSchemaAttributes)
+	}
+}
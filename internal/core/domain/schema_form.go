@@ -0,0 +1,46 @@
+package domain
+
+// Form inputs of the schema form fields
+const (
+	FormInputText     = "text"
+	FormInputNumber   = "number"
+	FormInputCheckbox = "checkbox"
+	FormInputDate     = "date"
+	FormInputDateTime = "datetime"
+	FormInputSelect   = "select"
+	FormInputEmail    = "email"
+	FormInputURL      = "url"
+	FormInputObject   = "object"
+)
+
+// SchemaForm describes the form to fill the credential subject of a schema. It is derived from the
+// credentialSubject properties of the JSON schema, keeping the order they have in the document.
+type SchemaForm struct {
+	Title       string
+	Description string
+	Fields      []SchemaFormField
+}
+
+// SchemaFormField is an attribute of the credential subject. Fields of type object have the nested attributes in Fields.
+type SchemaFormField struct {
+	ID          string
+	Label       string
+	Description string
+	Type        string
+	Input       string
+	Required    bool
+	Default     any
+	Constraints SchemaFormConstraints
+	Fields      []SchemaFormField
+}
+
+// SchemaFormConstraints are the JSON schema validations of a field value
+type SchemaFormConstraints struct {
+	Enum      []any
+	Format    string
+	Minimum   *float64
+	Maximum   *float64
+	MinLength *int
+	MaxLength *int
+	Pattern   string
+}
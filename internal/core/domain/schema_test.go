@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaAttrsMask(t *testing.T) {
+	subject := map[string]any{
+		"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+		"type":         "KYCAgeCredential",
+		"birthday":     19960424,
+		"documentType": 2,
+	}
+
+	masked := SchemaAttrs{"birthday", "id", "type"}.Mask(subject)
+	assert.Equal(t, map[string]any{
+		"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+		"type":         "KYCAgeCredential",
+		"birthday":     MaskedValue,
+		"documentType": 2,
+	}, masked)
+	assert.Equal(t, 19960424, subject["birthday"], "the credential subject is not changed")
+
+	assert.Equal(t, subject, SchemaAttrs(nil).Mask(subject))
+}
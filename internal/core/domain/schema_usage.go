@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// SchemaUsage counts the credentials of a schema issued and revoked in a period
+type SchemaUsage struct {
+	Issued int64
+	// IssuedByLink are the credentials of Issued that were issued through a link
+	IssuedByLink int64
+	Revoked      int64
+	// Days are the counts of every day of the period with issuances or revocations, from the oldest
+	Days []SchemaUsageDay
+}
+
+// SchemaUsageDay counts the credentials of a schema issued and revoked in a day, in UTC
+type SchemaUsageDay struct {
+	Day          time.Time
+	Issued       int64
+	IssuedByLink int64
+	Revoked      int64
+}
+
+// RevocationRate is the number of revoked credentials per issued credential in the period, zero if none was issued.
+// The revocations are counted on the day they happen, so they can belong to credentials issued before the period.
+func (u *SchemaUsage) RevocationRate() float64 {
+	if u.Issued == 0 {
+		return 0
+	}
+	return float64(u.Revoked) / float64(u.Issued)
+}
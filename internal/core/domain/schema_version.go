@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"golang.org/x/mod/semver"
+)
+
+// ValidSchemaVersion tells whether v is a semantic version of a schema of the registry, MAJOR.MINOR.PATCH with an
+// optional pre-release, like 1.0.0 or 2.1.0-beta.1. Build metadata is not accepted because it doesn't order versions.
+func ValidSchemaVersion(v string) bool {
+	return semver.IsValid("v"+v) && semver.Canonical("v"+v) == "v"+v
+}
+
+// CompareSchemaVersions returns -1, 0 or +1 when the schema version a is lower, equal or greater than b
+func CompareSchemaVersions(a, b string) int {
+	return semver.Compare("v"+a, "v"+b)
+}
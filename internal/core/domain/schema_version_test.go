@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidSchemaVersion(t *testing.T) {
+	for _, v := range []string{"0.1.0", "1.0.0", "2.10.3", "1.0.0-beta.1"} {
+		assert.True(t, ValidSchemaVersion(v), v)
+	}
+	for _, v := range []string{"", "1", "1.0", "v1.0.0", "1.0.0+build.5", "01.0.0", "latest"} {
+		assert.False(t, ValidSchemaVersion(v), v)
+	}
+}
+
+func TestCompareSchemaVersions(t *testing.T) {
+	assert.Equal(t, -1, CompareSchemaVersions("1.0.0", "1.1.0"))
+	assert.Equal(t, 1, CompareSchemaVersions("1.10.0", "1.9.0"))
+	assert.Equal(t, -1, CompareSchemaVersions("2.0.0-beta.1", "2.0.0"))
+	assert.Equal(t, 0, CompareSchemaVersions("1.2.3", "1.2.3"))
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// Schema webhook events
+const (
+	SchemaWebhookIssued  = "issued"  // SchemaWebhookIssued a credential of the schema type was issued
+	SchemaWebhookRevoked = "revoked" // SchemaWebhookRevoked a credential of the schema type was revoked
+)
+
+// ErrInvalidSchemaWebhook is returned by NewSchemaWebhook
+var ErrInvalidSchemaWebhook = errors.New("invalid schema webhook")
+
+// SchemaWebhook is an endpoint of an issuer that is notified when the credentials of a schema type are issued or
+// revoked, so a downstream system only receives the events of its credential domain.
+type SchemaWebhook struct {
+	ID         uuid.UUID
+	IssuerDID  core.DID
+	SchemaType string
+	URL        string
+	// Events are the events the webhook is notified of, issued and revoked
+	Events []string
+	// Authorization is sent as the Authorization header when it is not empty
+	Authorization string
+	CreatedAt     time.Time
+}
+
+// NewSchemaWebhook returns a new webhook for the credentials of the schema type. It is notified of both events when
+// events is empty.
+func NewSchemaWebhook(issuerDID core.DID, schemaType string, webhookURL string, events []string, authorization string) (*SchemaWebhook, error) {
+	if strings.TrimSpace(schemaType) == "" {
+		return nil, fmt.Errorf("%w: the schema type is empty", ErrInvalidSchemaWebhook)
+	}
+	u, err := url.ParseRequestURI(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: invalid url <%s>", ErrInvalidSchemaWebhook, webhookURL)
+	}
+	if len(events) == 0 {
+		events = []string{SchemaWebhookIssued, SchemaWebhookRevoked}
+	}
+	for _, event := range events {
+		if event != SchemaWebhookIssued && event != SchemaWebhookRevoked {
+			return nil, fmt.Errorf("%w: unknown event <%s>", ErrInvalidSchemaWebhook, event)
+		}
+	}
+	return &SchemaWebhook{
+		ID:            uuid.New(),
+		IssuerDID:     issuerDID,
+		SchemaType:    schemaType,
+		URL:           webhookURL,
+		Events:        events,
+		Authorization: authorization,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// Matches tells whether the webhook is notified of the event of a credential of the schema type. The schema types are
+// compared without their JSON-LD context, like in APIKey.AllowsIssuance.
+func (w *SchemaWebhook) Matches(schemaType string, event string) bool {
+	if shortSchemaType(w.SchemaType) != shortSchemaType(schemaType) {
+		return false
+	}
+	for _, item := range w.Events {
+		if item == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSchemaWebhook(t *testing.T) {
+	did, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+
+	webhook, err := NewSchemaWebhook(*did, "KYCAgeCredential", "https://example.com/hooks/kyc", nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{SchemaWebhookIssued, SchemaWebhookRevoked}, webhook.Events)
+
+	webhook, err = NewSchemaWebhook(*did, "KYCAgeCredential", "https://example.com/hooks/kyc", []string{SchemaWebhookRevoked}, "Bearer token")
+	require.NoError(t, err)
+	assert.Equal(t, []string{SchemaWebhookRevoked}, webhook.Events)
+
+	_, err = NewSchemaWebhook(*did, " ", "https://example.com/hooks/kyc", nil, "")
+	assert.ErrorIs(t, err, ErrInvalidSchemaWebhook)
+	_, err = NewSchemaWebhook(*did, "KYCAgeCredential", "ftp://example.com/hooks/kyc", nil, "")
+	assert.ErrorIs(t, err, ErrInvalidSchemaWebhook)
+	_, err = NewSchemaWebhook(*did, "KYCAgeCredential", "https://example.com/hooks/kyc", []string{"expired"}, "")
+	assert.ErrorIs(t, err, ErrInvalidSchemaWebhook)
+}
+
+func TestSchemaWebhookMatches(t *testing.T) {
+	webhook := SchemaWebhook{SchemaType: "KYCAgeCredential", Events: []string{SchemaWebhookIssued}}
+	for _, tc := range []struct {
+		name       string
+		schemaType string
+		event      string
+		expected   bool
+	}{
+		{name: "same schema type", schemaType: "KYCAgeCredential", event: SchemaWebhookIssued, expected: true},
+		{name: "schema type with context", schemaType: "https://example.com/kyc-v3.json-ld#KYCAgeCredential", event: SchemaWebhookIssued, expected: true},
+		{name: "other schema type", schemaType: "KYCCountryOfResidenceCredential", event: SchemaWebhookIssued, expected: false},
+		{name: "other event", schemaType: "KYCAgeCredential", event: SchemaWebhookRevoked, expected: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, webhook.Matches(tc.schemaType, tc.event))
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+)
+
+// EcdsaSecp256k1SignatureProofType is the proof of the credentials signed with a secp256k1 signing key
+const EcdsaSecp256k1SignatureProofType verifiable.ProofType = "EcdsaSecp256k1Signature2019"
+
+// AuthEthSchemaHash is the schema hash of the auth claims of the secp256k1 keys. It is built like the iden3 schema
+// hashes, from the last 16 bytes of the keccak256 hash of the credential type.
+var AuthEthSchemaHash = func() core.SchemaHash {
+	var sh core.SchemaHash
+	h := crypto.Keccak256([]byte(AuthEthCredentialSchemaType))
+	copy(sh[:], h[len(h)-len(sh):])
+	return sh
+}()
+
+// ErrInvalidSecp256k1Signature is returned when an EcdsaSecp256k1Signature2019 proof is not signed by the key of its
+// auth claim
+var ErrInvalidSecp256k1Signature = errors.New("invalid secp256k1 signature")
+
+// NewAuthEthClaim returns the auth claim of a secp256k1 key, that keeps the Ethereum address of the key in the first
+// index data slot
+func NewAuthEthClaim(address ethcommon.Address, revNonce uint64) (*core.Claim, error) {
+	return core.NewClaim(AuthEthSchemaHash,
+		core.WithIndexDataInts(new(big.Int).SetBytes(address.Bytes()), big.NewInt(0)),
+		core.WithRevocationNonce(revNonce))
+}
+
+// IsAuthEthClaim tells whether the core claim is the auth claim of a secp256k1 key
+func IsAuthEthClaim(claim *core.Claim) bool {
+	return claim.GetSchemaHash() == AuthEthSchemaHash
+}
+
+// AuthEthAddress returns the Ethereum address of the key of a secp256k1 auth claim
+func AuthEthAddress(claim *core.Claim) ethcommon.Address {
+	return ethcommon.BigToAddress(claim.RawSlotsAsInts()[2])
+}
+
+// Secp256k1ClaimDigest returns the hash of the core claim signed by the secp256k1 keys
+func Secp256k1ClaimDigest(claim *core.Claim) ([]byte, error) {
+	b, err := claim.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(b), nil
+}
+
+// EcdsaSecp256k1SignatureProof2019 is the signature of the core claim of a credential with a secp256k1 key of the
+// issuer. The signature is in the [R || S || V] format of ethereum, and the issuer data carries the auth claim of the
+// key with its merkle tree proof, like in the BJJSignature2021 proofs. The zero knowledge circuits only verify
+// BabyJubJub signatures, so the holders can't use these credentials in the iden3 queries.
+type EcdsaSecp256k1SignatureProof2019 struct {
+	Type       verifiable.ProofType  `json:"type"`
+	IssuerData verifiable.IssuerData `json:"issuerData"`
+	CoreClaim  string                `json:"coreClaim"`
+	Signature  string                `json:"signature"`
+}
+
+// ProofType returns EcdsaSecp256k1SignatureProofType
+func (p *EcdsaSecp256k1SignatureProof2019) ProofType() verifiable.ProofType {
+	return p.Type
+}
+
+// GetCoreClaim returns the core claim of the signed credential
+func (p *EcdsaSecp256k1SignatureProof2019) GetCoreClaim() (*core.Claim, error) {
+	var coreClaim core.Claim
+	if err := coreClaim.FromHex(p.CoreClaim); err != nil {
+		return nil, err
+	}
+	return &coreClaim, nil
+}
+
+// Verify checks that the core claim is signed by the key of the auth claim of the issuer data
+func (p *EcdsaSecp256k1SignatureProof2019) Verify() error {
+	coreClaim, err := p.GetCoreClaim()
+	if err != nil {
+		return err
+	}
+	var authClaim core.Claim
+	if err := authClaim.FromHex(p.IssuerData.AuthCoreClaim); err != nil {
+		return fmt.Errorf("invalid auth claim: %w", err)
+	}
+	if !IsAuthEthClaim(&authClaim) {
+		return fmt.Errorf("%w: the auth claim is not of a secp256k1 key", ErrInvalidSecp256k1Signature)
+	}
+	digest, err := Secp256k1ClaimDigest(coreClaim)
+	if err != nil {
+		return err
+	}
+	sig, err := hex.DecodeString(p.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSecp256k1Signature, err)
+	}
+	pubKey, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSecp256k1Signature, err)
+	}
+	if crypto.PubkeyToAddress(*pubKey) != AuthEthAddress(&authClaim) {
+		return ErrInvalidSecp256k1Signature
+	}
+	return nil
+}
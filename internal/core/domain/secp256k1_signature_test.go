@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEcdsaSecp256k1SignatureProof2019_Verify(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	authClaim, err := NewAuthEthClaim(crypto.PubkeyToAddress(privKey.PublicKey), 1)
+	require.NoError(t, err)
+	assert.True(t, IsAuthEthClaim(authClaim))
+	assert.Equal(t, crypto.PubkeyToAddress(privKey.PublicKey), AuthEthAddress(authClaim))
+
+	coreClaim, err := core.NewClaim(core.SchemaHash{1}, core.WithIndexDataInts(big.NewInt(19960424), big.NewInt(2)))
+	require.NoError(t, err)
+
+	sign := func(signed *core.Claim, authClaim *core.Claim) *EcdsaSecp256k1SignatureProof2019 {
+		digest, err := Secp256k1ClaimDigest(signed)
+		require.NoError(t, err)
+		sig, err := crypto.Sign(digest, privKey)
+		require.NoError(t, err)
+		proof := &EcdsaSecp256k1SignatureProof2019{Type: EcdsaSecp256k1SignatureProofType, Signature: hex.EncodeToString(sig)}
+		proof.CoreClaim, err = coreClaim.Hex()
+		require.NoError(t, err)
+		proof.IssuerData.AuthCoreClaim, err = authClaim.Hex()
+		require.NoError(t, err)
+		return proof
+	}
+
+	assert.NoError(t, sign(coreClaim, authClaim).Verify())
+
+	other, err := core.NewClaim(core.SchemaHash{1}, core.WithIndexDataInts(big.NewInt(19960425), big.NewInt(2)))
+	require.NoError(t, err)
+	assert.ErrorIs(t, sign(other, authClaim).Verify(), ErrInvalidSecp256k1Signature, "the signature is of another claim")
+
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherAuthClaim, err := NewAuthEthClaim(crypto.PubkeyToAddress(otherKey.PublicKey), 1)
+	require.NoError(t, err)
+	assert.ErrorIs(t, sign(coreClaim, otherAuthClaim).Verify(), ErrInvalidSecp256k1Signature, "the key is not the one of the auth claim")
+
+	bjjAuthClaim, err := core.NewClaim(core.AuthSchemaHash, core.WithIndexDataInts(big.NewInt(1), big.NewInt(2)))
+	require.NoError(t, err)
+	assert.ErrorIs(t, sign(coreClaim, bjjAuthClaim).Verify(), ErrInvalidSecp256k1Signature, "the auth claim is not of a secp256k1 key")
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// SigningKeyStatus tells whether a signing key of an identity can sign credentials
+type SigningKeyStatus string
+
+const (
+	SigningKeyPending SigningKeyStatus = "pending" // SigningKeyPending the auth claim of the key is not in a published state yet
+	SigningKeyActive  SigningKeyStatus = "active"  // SigningKeyActive the key signs credentials
+)
+
+// SigningKeyType is the curve of a signing key
+type SigningKeyType string
+
+const (
+	SigningKeyBJJ       SigningKeyType = "BJJ"       // SigningKeyBJJ BabyJubJub keys, that sign BJJSignature2021 proofs
+	SigningKeySecp256k1 SigningKeyType = "secp256k1" // SigningKeySecp256k1 secp256k1 keys, that sign EcdsaSecp256k1Signature2019 proofs
+)
+
+// SigningKey is a key of an identity that signs its credentials, with the auth claim that proves it in the claims tree
+// of the identity. A key signs once the state with its auth claim is published, as the signatures carry the merkle
+// tree proof of the auth claim. The secp256k1 keys can be kept in the HSMs that can't hold BabyJubJub keys, but their
+// signatures can't be used in the zero knowledge circuits, so they only sign the credentials that select them.
+type SigningKey struct {
+	AuthClaimID uuid.UUID
+	KeyID       string
+	Type        SigningKeyType
+	PublicKey   string
+	Status      SigningKeyStatus
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// SubjectData is all the data the issuer holds about a subject
+type SubjectData struct {
+	Subject     core.DID
+	Connection  *Connection
+	Credentials []*Claim
+	Erasures    []*SubjectErasure
+}
+
+// SubjectErasure is the audit record of an erasure of the personal data of a subject.
+// It only keeps a hash of the subject DID.
+type SubjectErasure struct {
+	ID          uuid.UUID
+	IssuerDID   core.DID
+	SubjectHash string
+	Credentials int64
+	Connections int64
+	CreatedAt   time.Time
+}
+
+// NewSubjectErasure returns a new erasure record for the given subject
+func NewSubjectErasure(issuerDID core.DID, subject core.DID) *SubjectErasure {
+	return &SubjectErasure{
+		ID:          uuid.New(),
+		IssuerDID:   issuerDID,
+		SubjectHash: SubjectHash(subject),
+		CreatedAt:   time.Now(),
+	}
+}
+
+// SubjectHash returns the hex encoded sha256 of the subject DID
+func SubjectHash(subject core.DID) string {
+	h := sha256.Sum256([]byte(subject.String()))
+	return hex.EncodeToString(h[:])
+}
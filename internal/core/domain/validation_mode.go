@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+)
+
+// ErrInvalidValidationMode means the validation mode is not strict or lenient
+var ErrInvalidValidationMode = errors.New("invalid validation mode, it must be strict or lenient")
+
+// ValidationMode tells how a credential subject is checked against the attributes of its schema
+type ValidationMode string
+
+const (
+	// ValidationModeStrict rejects the attributes that are not in the schema and the values that don't have the schema types
+	ValidationModeStrict ValidationMode = "strict"
+	// ValidationModeLenient converts the values to the schema types and drops the attributes that are not in the schema,
+	// returning a warning for each one
+	ValidationModeLenient ValidationMode = "lenient"
+)
+
+// ParseValidationMode is a ValidationMode constructor. An empty mode is strict.
+func ParseValidationMode(mode string) (ValidationMode, error) {
+	switch ValidationMode(mode) {
+	case "", ValidationModeStrict:
+		return ValidationModeStrict, nil
+	case ValidationModeLenient:
+		return ValidationModeLenient, nil
+	default:
+		return "", ErrInvalidValidationMode
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValidationMode(t *testing.T) {
+	mode, err := ParseValidationMode("")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationModeStrict, mode)
+
+	mode, err = ParseValidationMode("lenient")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationModeLenient, mode)
+
+	_, err = ParseValidationMode("loose")
+	assert.ErrorIs(t, err, ErrInvalidValidationMode)
+}
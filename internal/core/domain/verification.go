@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// DefaultVerificationsLimit is the number of verifications returned when the filter has no limit
+const DefaultVerificationsLimit = 100
+
+// Verification is the result of the verification of the proofs sent by a wallet to authenticate or to get a
+// credential of a link
+type Verification struct {
+	ID        uuid.UUID
+	IssuerDID core.DID
+	SessionID uuid.UUID
+	// UserDID is the sender of the proofs, nil when the message can't be verified
+	UserDID *string
+	// Queries are the scope of the authorization request, the proofs asked to the wallet
+	Queries json.RawMessage
+	// ProofHashes are the hex sha256 of the proofs answered to each query, in the order of the response
+	ProofHashes []string
+	Verified    bool
+	// Error is why the verification failed, nil when it is verified
+	Error     *string
+	CreatedAt time.Time
+}
+
+// VerificationFilter selects the verifications of an issuer. The empty fields don't filter.
+type VerificationFilter struct {
+	From     *time.Time
+	To       *time.Time
+	UserDID  *string
+	Verified *bool
+	Limit    int
+}
+
+// HashProof returns the hex sha256 of the json of a proof
+func HashProof(proof any) (string, error) {
+	raw, err := json.Marshal(proof)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(raw)
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashProof(t *testing.T) {
+	hash, err := HashProof(map[string]int{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "015abd7f5cc57a2dd94b7590f04ad8084273905ee33ec5cebeae62276a97f862", hash)
+
+	_, err = HashProof(make(chan int))
+	assert.Error(t, err)
+}
@@ -8,7 +8,11 @@ import (
 
 const (
 	CreateCredentialEvent = "createCredentialEvent" // CreateCredentialEvent create credential event
+	RevokeCredentialEvent = "revokeCredentialEvent" // RevokeCredentialEvent revoke credential event
 	CreateConnectionEvent = "createConnectionEvent" // CreateConnectionEvent create connection MyEvent
+	PostIssuanceHookEvent = "postIssuanceHookEvent" // PostIssuanceHookEvent call a queued post-issuance hook event
+	VerificationEvent     = "verificationEvent"     // VerificationEvent a proof verification completed event
+	StateTransitionEvent  = "stateTransitionEvent"  // StateTransitionEvent a state of an identity confirmed on chain event
 )
 
 // CreateCredential defines the createCredential data
@@ -27,6 +31,22 @@ func (ev *CreateCredential) Unmarshal(msg pubsub.Message) error {
 	return json.Unmarshal(msg, &ev)
 }
 
+// RevokeCredential defines the revokeCredential data
+type RevokeCredential struct {
+	CredentialIDs []string `json:"credentialsID"`
+	IssuerID      string   `json:"issuerID"`
+}
+
+// Marshal marshals the event into a pubsub.Message
+func (ev *RevokeCredential) Marshal() (msg pubsub.Message, err error) {
+	return json.Marshal(ev)
+}
+
+// Unmarshal creates an event from that message
+func (ev *RevokeCredential) Unmarshal(msg pubsub.Message) error {
+	return json.Unmarshal(msg, &ev)
+}
+
 // CreateConnection defines the createCredential data
 type CreateConnection struct {
 	ConnectionID string `json:"connectionID"`
@@ -42,3 +62,52 @@ func (ev *CreateConnection) Marshal() (msg pubsub.Message, err error) {
 func (ev *CreateConnection) Unmarshal(msg pubsub.Message) error {
 	return json.Unmarshal(msg, &ev)
 }
+
+// PostIssuanceHook defines the postIssuanceHook data
+type PostIssuanceHook struct {
+	CredentialID string `json:"credentialID"`
+	IssuerID     string `json:"issuerID"`
+	Hook         string `json:"hook"`
+}
+
+// Marshal marshals the event into a pubsub.Message
+func (ev *PostIssuanceHook) Marshal() (msg pubsub.Message, err error) {
+	return json.Marshal(ev)
+}
+
+// Unmarshal creates an event from that message
+func (ev *PostIssuanceHook) Unmarshal(msg pubsub.Message) error {
+	return json.Unmarshal(msg, &ev)
+}
+
+// Verification defines the verification data
+type Verification struct {
+	VerificationID string `json:"verificationID"`
+	IssuerID       string `json:"issuerID"`
+}
+
+// Marshal marshals the event into a pubsub.Message
+func (ev *Verification) Marshal() (msg pubsub.Message, err error) {
+	return json.Marshal(ev)
+}
+
+// Unmarshal creates an event from that message
+func (ev *Verification) Unmarshal(msg pubsub.Message) error {
+	return json.Unmarshal(msg, &ev)
+}
+
+// StateTransition defines the stateTransition data
+type StateTransition struct {
+	IssuerID string `json:"issuerID"`
+	State    string `json:"state"`
+}
+
+// Marshal marshals the event into a pubsub.Message
+func (ev *StateTransition) Marshal() (msg pubsub.Message, err error) {
+	return json.Marshal(ev)
+}
+
+// Unmarshal creates an event from that message
+func (ev *StateTransition) Unmarshal(msg pubsub.Message) error {
+	return json.Unmarshal(msg, &ev)
+}
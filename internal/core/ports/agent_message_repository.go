@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// AgentMessageRepository keeps the hashes of the processed agent messages to detect replays
+type AgentMessageRepository interface {
+	Record(ctx context.Context, conn db.Querier, hash []byte, expiresAt time.Time) (bool, error)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// APIKeyRepository keeps the delegated api keys of the issuers
+type APIKeyRepository interface {
+	Save(ctx context.Context, conn db.Querier, key *domain.APIKey) error
+	GetByID(ctx context.Context, conn db.Querier, issuerDID core.DID, id uuid.UUID) (*domain.APIKey, error)
+	GetAll(ctx context.Context, conn db.Querier, issuerDID core.DID) ([]domain.APIKey, error)
+	Delete(ctx context.Context, conn db.Querier, issuerDID core.DID, id uuid.UUID) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// BrandingRepository keeps the branding of the identities that replaces the one of the node
+type BrandingRepository interface {
+	GetOverrides(ctx context.Context, conn db.Querier, issuerDID core.DID) (*domain.BrandingOverrides, error)
+	SaveOverrides(ctx context.Context, conn db.Querier, issuerDID core.DID, overrides domain.BrandingOverrides) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// BrandingService is the interface implemented by the branding service
+type BrandingService interface {
+	Get(ctx context.Context, issuerDID core.DID) (*domain.Branding, error)
+	Update(ctx context.Context, issuerDID core.DID, overrides domain.BrandingOverrides) (*domain.Branding, error)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// ClaimsIngestService issues the credentials of the streamed requests from a bounded queue
+type ClaimsIngestService interface {
+	// Submit queues the request, waiting while the queue is full until ctx is done. done is called with the issued
+	// claim or the error once a worker has processed the request.
+	Submit(ctx context.Context, req *CreateClaimRequest, done func(*domain.Claim, error)) error
+	// Depth returns the number of queued requests
+	Depth() int
+	Run(ctx context.Context)
+}
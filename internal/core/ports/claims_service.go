@@ -2,26 +2,35 @@ package ports
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-jwz"
 	"github.com/iden3/go-schema-processor/verifiable"
 	comm "github.com/iden3/iden3comm"
 	"github.com/iden3/iden3comm/protocol"
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/exp/slog"
 
 	"github.com/polygonid/sh-id-platform/internal/common"
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 )
 
 // CreateClaimRequest struct
 type CreateClaimRequest struct {
-	DID                   *core.DID
-	Schema                string
-	CredentialSubject     map[string]any
-	Expiration            *time.Time
+	DID               *core.DID
+	Schema            string
+	CredentialSubject map[string]any
+	Expiration        *time.Time
+	// ExpirationPolicy sets the expiration relative to the issuance date when Expiration is nil
+	ExpirationPolicy      *domain.ExpirationPolicy
 	Type                  string
 	Version               uint32
 	SubjectPos            string
@@ -30,6 +39,50 @@ type CreateClaimRequest struct {
 	MTProof               bool
 	LinkID                *uuid.UUID
 	SingleIssuer          bool
+	DisplayMethod         *domain.DisplayMethod
+	// SkipNotification avoids publishing the credential creation event, for callers that send the offer themselves
+	SkipNotification bool
+	// PIIAttributes are the attributes of CredentialSubject masked when the request is logged
+	PIIAttributes domain.SchemaAttrs
+	// ValidationMode tells how CredentialSubject is checked against the schema, strict when empty
+	ValidationMode domain.ValidationMode
+	// Warnings are set by the lenient validation, one for each converted or dropped attribute
+	Warnings []string
+	// SigningKeyID is the key of the key store that signs the credential, one of the active signing keys of the issuer.
+	// Nil signs with the default key, or in turn with each active key with the round robin selection.
+	SigningKeyID *string
+}
+
+// LogValue implements slog.LogValuer. The PII attributes of the credential subject are masked.
+func (r *CreateClaimRequest) LogValue() slog.Value {
+	if r == nil {
+		return slog.AnyValue(nil)
+	}
+	masked := *r
+	masked.CredentialSubject = r.PIIAttributes.Mask(r.CredentialSubject)
+	return slog.AnyValue(masked)
+}
+
+// ReissueClaimRequest holds the changes of a credential replacement. CredentialSubject is merged into the
+// credential subject of the replaced credential, a null value removes the attribute.
+type ReissueClaimRequest struct {
+	DID               *core.DID
+	ID                uuid.UUID
+	CredentialSubject map[string]any
+	Expiration        *time.Time
+	SingleIssuer      bool
+	// SkipNotification avoids publishing the credential creation event, for callers that send the offer themselves
+	SkipNotification bool
+}
+
+// ResignDriftedRequest selects the signed credentials of the issuer checked against the current JSON-LD contexts
+type ResignDriftedRequest struct {
+	DID *core.DID
+	// SchemaType limits the check to the credentials of the type, all of them when empty
+	SchemaType string
+	// DryRun only reports the drifted credentials, without replacing them
+	DryRun       bool
+	SingleIssuer bool
 }
 
 // AgentRequest struct
@@ -41,6 +94,13 @@ type AgentRequest struct {
 	ClaimID   uuid.UUID
 	Typ       comm.MediaType
 	Type      comm.ProtocolMessage
+	// MessageHash is the hash of the packed message. Every message signed by a wallet is different, so a repeated
+	// hash is a replay of an already processed message.
+	MessageHash []byte
+	// CreatedTime and ExpiresTime are the created_time and expires_time of the message, nil when the wallet didn't
+	// set them
+	CreatedTime *time.Time
+	ExpiresTime *time.Time
 }
 
 // ClaimsFilter struct
@@ -56,6 +116,34 @@ type ClaimsFilter struct {
 	FTSQuery        string
 	FTSAndCond      bool
 	Proofs          []verifiable.ProofType
+	// PII returns the values of the schema PII attributes. They are masked otherwise.
+	PII bool
+	// AsOf returns the claims issued up to that time, with the revocation status they had then.
+	AsOf *time.Time
+	// Limit returns a page of at most Limit claims that starts at Offset, the newest issued first. Every claim is
+	// returned when it is 0.
+	Limit  int
+	Offset int
+}
+
+// Paginate sets the page of the filter from the limit and the page token of a request. A request without a limit
+// returns a page of domain.DefaultPageLimit claims.
+func (f *ClaimsFilter) Paginate(limit *int, pageToken *string) error {
+	f.Limit = domain.DefaultPageLimit
+	if limit != nil {
+		if *limit < 1 || *limit > domain.MaxPageLimit {
+			return fmt.Errorf("limit must be between 1 and %d", domain.MaxPageLimit)
+		}
+		f.Limit = *limit
+	}
+	if pageToken != nil && *pageToken != "" {
+		offset, err := domain.ParsePageToken(*pageToken)
+		if err != nil {
+			return err
+		}
+		f.Offset = offset
+	}
+	return nil
 }
 
 // NewClaimsFilter returns a valid claims filter
@@ -127,8 +215,9 @@ func NewCreateClaimRequest(did *core.DID, credentialSchema string, credentialSub
 	return req
 }
 
-// NewAgentRequest validates the inputs and returns a new AgentRequest
-func NewAgentRequest(basicMessage *comm.BasicMessage) (*AgentRequest, error) {
+// NewAgentRequest validates the inputs and returns a new AgentRequest. packedMessage is the message as received,
+// before unpacking it into basicMessage.
+func NewAgentRequest(packedMessage []byte, basicMessage *comm.BasicMessage) (*AgentRequest, error) {
 	if basicMessage.To == "" {
 		return nil, fmt.Errorf("'to' field cannot be empty")
 	}
@@ -164,30 +253,85 @@ func NewAgentRequest(basicMessage *comm.BasicMessage) (*AgentRequest, error) {
 		return nil, fmt.Errorf("'id' field cannot be empty")
 	}
 
+	messageHash := sha256.Sum256(packedMessage)
+	createdTime, expiresTime := messageTimes(packedMessage)
 	return &AgentRequest{
-		Body:      basicMessage.Body,
-		UserDID:   fromDID,
-		IssuerDID: toDID,
-		ThreadID:  basicMessage.ThreadID,
-		ClaimID:   claimID,
-		Typ:       basicMessage.Typ,
-		Type:      basicMessage.Type,
+		Body:        basicMessage.Body,
+		UserDID:     fromDID,
+		IssuerDID:   toDID,
+		ThreadID:    basicMessage.ThreadID,
+		ClaimID:     claimID,
+		Typ:         basicMessage.Typ,
+		Type:        basicMessage.Type,
+		MessageHash: messageHash[:],
+		CreatedTime: createdTime,
+		ExpiresTime: expiresTime,
 	}, nil
 }
 
+// messageTimes returns the created_time and expires_time of the message, that the unpacked BasicMessage doesn't
+// keep. They are read from the payload of the JWZ token or the signed JWS, that were already verified, or from the
+// message itself when it isn't packed.
+func messageTimes(packedMessage []byte) (*time.Time, *time.Time) {
+	payload := packedMessage
+	if token, err := jwz.Parse(string(packedMessage)); err == nil {
+		payload = token.GetPayload()
+	} else if parts := strings.Split(string(packedMessage), "."); len(parts) == 3 {
+		if jwsPayload, err := base64.RawURLEncoding.DecodeString(parts[1]); err == nil {
+			payload = jwsPayload
+		}
+	}
+	var times struct {
+		CreatedTime *int64 `json:"created_time"`
+		ExpiresTime *int64 `json:"expires_time"`
+	}
+	if err := json.Unmarshal(payload, &times); err != nil {
+		return nil, nil
+	}
+	unix := func(seconds *int64) *time.Time {
+		if seconds == nil {
+			return nil
+		}
+		t := time.Unix(*seconds, 0)
+		return &t
+	}
+	return unix(times.CreatedTime), unix(times.ExpiresTime)
+}
+
 // ClaimsService is the interface implemented by the claim service
 type ClaimsService interface {
 	Save(ctx context.Context, claimReq *CreateClaimRequest) (*domain.Claim, error)
+	SaveBatch(ctx context.Context, reqs []*CreateClaimRequest) ([]*domain.Claim, []error)
 	CreateCredential(ctx context.Context, req *CreateClaimRequest) (*domain.Claim, error)
+	SaveCredential(ctx context.Context, tx pgx.Tx, issuerDID core.DID, claim *domain.Claim) (uuid.UUID, error)
+	Import(ctx context.Context, did core.DID, credential verifiable.W3CCredential) (*domain.Claim, error)
 	Revoke(ctx context.Context, id core.DID, nonce uint64, description string) error
+	Reissue(ctx context.Context, req *ReissueClaimRequest) (*domain.Claim, error)
+	ResignDrifted(ctx context.Context, req *ResignDriftedRequest) (*domain.ContextDriftReport, error)
 	GetAll(ctx context.Context, did core.DID, filter *ClaimsFilter) ([]*domain.Claim, error)
+	Count(ctx context.Context, did core.DID, filter *ClaimsFilter) (int, error)
 	RevokeAllFromConnection(ctx context.Context, connID uuid.UUID, issuerID core.DID) error
 	GetRevocationStatus(ctx context.Context, issuerDID core.DID, nonce uint64) (*verifiable.RevocationStatus, error)
 	GetByID(ctx context.Context, issID *core.DID, id uuid.UUID) (*domain.Claim, error)
+	GetByIDAsOf(ctx context.Context, issID *core.DID, id uuid.UUID, asOf time.Time) (*domain.Claim, error)
+	GetIssuanceTiming(ctx context.Context, issuerDID core.DID, claimID uuid.UUID) (*domain.IssuanceTiming, error)
 	Agent(ctx context.Context, req *AgentRequest) (*domain.Agent, error)
 	GetAuthClaim(ctx context.Context, did *core.DID) (*domain.Claim, error)
 	GetAuthClaimForPublishing(ctx context.Context, did *core.DID, state string) (*domain.Claim, error)
+	GetSigningAuthClaims(ctx context.Context, did *core.DID) ([]*domain.Claim, error)
+	GetSecp256k1SigningAuthClaims(ctx context.Context, did *core.DID) ([]*domain.Claim, error)
 	UpdateClaimsMTPAndState(ctx context.Context, currentState *domain.IdentityState) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, issuerDID core.DID, id uuid.UUID) error
 	GetByStateIDWithMTPProof(ctx context.Context, did *core.DID, state string) ([]*domain.Claim, error)
+	RunPostIssuanceHooks(ctx context.Context, issuerDID core.DID, claim *domain.Claim)
+	RunQueuedPostIssuanceHook(ctx context.Context, e pubsub.Message) error
+	TestPostIssuanceHook(ctx context.Context, issuerDID core.DID, schemaID uuid.UUID, hookName string) (*domain.HookDelivery, error)
+	GetHookDeliveries(ctx context.Context, issuerDID core.DID) ([]domain.HookDelivery, error)
+	CreateAPIKey(ctx context.Context, issuerDID core.DID, name string, role domain.Role, schemaTypes []string, linkIDs []uuid.UUID) (*domain.APIKey, string, error)
+	GetAPIKeys(ctx context.Context, issuerDID core.DID) ([]domain.APIKey, error)
+	DeleteAPIKey(ctx context.Context, issuerDID core.DID, id uuid.UUID) error
+	AuthenticateAPIKey(ctx context.Context, issuerDID core.DID, id uuid.UUID, secret string) (*domain.APIKey, error)
+	CreateSchemaWebhook(ctx context.Context, issuerDID core.DID, schemaType string, url string, events []string, authorization string) (*domain.SchemaWebhook, error)
+	GetSchemaWebhooks(ctx context.Context, issuerDID core.DID) ([]domain.SchemaWebhook, error)
+	DeleteSchemaWebhook(ctx context.Context, issuerDID core.DID, id uuid.UUID) error
 }
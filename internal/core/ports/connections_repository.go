@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
@@ -13,6 +14,7 @@ import (
 // ConnectionsRepository defines the available methods for connections repository
 type ConnectionsRepository interface {
 	Save(ctx context.Context, conn db.Querier, connection *domain.Connection) (uuid.UUID, error)
+	Seen(ctx context.Context, conn db.Querier, issuerDID core.DID, userDID core.DID, at time.Time) (uuid.UUID, error)
 	Delete(ctx context.Context, conn db.Querier, id uuid.UUID, issuerDID core.DID) error
 	DeleteCredentials(ctx context.Context, conn db.Querier, id uuid.UUID, issuerID core.DID) error
 	GetByIDAndIssuerID(ctx context.Context, conn db.Querier, id uuid.UUID, issuerDID core.DID) (*domain.Connection, error)
@@ -51,4 +51,5 @@ type ConnectionsService interface {
 	GetByIDAndIssuerID(ctx context.Context, id uuid.UUID, issuerDID core.DID) (*domain.Connection, error)
 	GetByUserID(ctx context.Context, issuerDID core.DID, userID core.DID) (*domain.Connection, error)
 	GetAllByIssuerID(ctx context.Context, issuerDID core.DID, query string, withCredentials bool) ([]*domain.Connection, error)
+	Seen(ctx context.Context, issuerDID core.DID, userDID core.DID) error
 }
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// CredentialEventRepository reads the hash chained log of the credentials of the issuers, written by the database
+// when the claims change, and keeps the anchors of its heads
+type CredentialEventRepository interface {
+	GetHead(ctx context.Context, conn db.Querier, issuerDID core.DID) (*domain.CredentialEvent, error)
+	GetEvents(ctx context.Context, conn db.Querier, issuerDID core.DID, afterSeq int64, limit int) ([]domain.CredentialEvent, error)
+	GetLastAnchor(ctx context.Context, conn db.Querier, issuerDID core.DID) (*domain.CredentialEventAnchor, error)
+	GetAnchors(ctx context.Context, conn db.Querier, issuerDID core.DID) ([]domain.CredentialEventAnchor, error)
+	SaveAnchor(ctx context.Context, conn db.Querier, anchor *domain.CredentialEventAnchor) error
+}
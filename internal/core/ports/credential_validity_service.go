@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// CredentialValidityService is the interface implemented by the credential validity service
+type CredentialValidityService interface {
+	Check(ctx context.Context, credential verifiable.W3CCredential) (*domain.CredentialValidity, error)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// DiagnosticsService is the interface implemented by the diagnostics service
+type DiagnosticsService interface {
+	QueryPlans(ctx context.Context) (*domain.QueryPlanReport, error)
+}
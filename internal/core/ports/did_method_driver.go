@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"math/big"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/kms"
+)
+
+// DIDMethodDriver creates and resolves the identities of a DID method, so the identity service doesn't depend on
+// the methods it supports
+type DIDMethodDriver interface {
+	// Method is the DID method of the driver, like polygonid
+	Method() string
+	// KeyType is the type of the key of the authentication claim of the identities of the method
+	KeyType() kms.KeyType
+	// GenesisDID returns the DID of the identity whose genesis state is state in the blockchain and network
+	GenesisDID(state *big.Int, blockchain, network string) (*core.DID, error)
+	// CheckGenesisState returns nil when state is the genesis state of did
+	CheckGenesisState(did *core.DID, state *big.Int) error
+}
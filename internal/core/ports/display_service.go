@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// DisplayService renders the cards of the credentials issued with a display method
+type DisplayService interface {
+	GetCredentialCard(ctx context.Context, issuerDID core.DID, credentialID uuid.UUID) (*domain.CredentialCard, error)
+}
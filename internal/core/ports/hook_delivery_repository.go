@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// HookDeliveryRepository keeps the last calls to the post-issuance hooks of every issuer
+type HookDeliveryRepository interface {
+	Save(ctx context.Context, conn db.Querier, delivery *domain.HookDelivery, keep int) error
+	GetAll(ctx context.Context, conn db.Querier, issuerDID core.DID) ([]domain.HookDelivery, error)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
 
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/db"
@@ -15,4 +16,5 @@ type IdentityMerkleTreeRepository interface {
 	UpdateByID(ctx context.Context, conn db.Querier, imt *domain.IdentityMerkleTree) error
 	GetByID(ctx context.Context, conn db.Querier, mtID uint64) (*domain.IdentityMerkleTree, error)
 	GetByIdentifierAndTypes(ctx context.Context, conn db.Querier, identifier *core.DID, mtTypes []uint16) ([]domain.IdentityMerkleTree, error)
+	Lock(ctx context.Context, tx pgx.Tx, identifier *core.DID) error
 }
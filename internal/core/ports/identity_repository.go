@@ -17,4 +17,6 @@ type IndentityRepository interface {
 	GetUnprocessedIssuersIDs(ctx context.Context, conn db.Querier) (issuersIDs []*core.DID, err error)
 	HasUnprocessedStatesByID(ctx context.Context, conn db.Querier, identifier *core.DID) (bool, error)
 	HasUnprocessedAndFailedStatesByID(ctx context.Context, conn db.Querier, identifier *core.DID) (bool, error)
+	GetDefaultProofTypes(ctx context.Context, conn db.Querier, identifier core.DID) (domain.ProofTypes, error)
+	UpdateDefaultProofTypes(ctx context.Context, conn db.Querier, identifier core.DID, proofTypes domain.ProofTypes) error
 }
@@ -17,6 +17,7 @@ type IdentityService interface {
 	GetByDID(ctx context.Context, identifier core.DID) (*domain.Identity, error)
 	Create(ctx context.Context, DIDMethod string, Blockchain, NetworkID, hostURL string) (*domain.Identity, error)
 	SignClaimEntry(ctx context.Context, authClaim *domain.Claim, claimEntry *core.Claim) (*verifiable.BJJSignatureProof2021, error)
+	SignClaimEntrySecp256k1(ctx context.Context, authClaim *domain.Claim, claimEntry *core.Claim) (*domain.EcdsaSecp256k1SignatureProof2019, error)
 	Get(ctx context.Context) (identities []string, err error)
 	UpdateState(ctx context.Context, did core.DID) (*domain.IdentityState, error)
 	Exists(ctx context.Context, identifier core.DID) (bool, error)
@@ -31,5 +32,8 @@ type IdentityService interface {
 	GetStates(ctx context.Context, issuerDID core.DID) ([]domain.IdentityState, error)
 	CreateAuthenticationQRCode(ctx context.Context, serverURL string, issuerDID core.DID) (*protocol.AuthorizationRequestMessage, error)
 	Authenticate(ctx context.Context, message string, sessionID uuid.UUID, serverURL string, issuerDID core.DID) (*protocol.AuthorizationResponseMessage, error)
+	GetVerifications(ctx context.Context, issuerDID core.DID, filter domain.VerificationFilter) ([]domain.Verification, error)
 	GetFailedState(ctx context.Context, identifier core.DID) (*domain.IdentityState, error)
+	GetDefaultProofTypes(ctx context.Context, identifier core.DID) (domain.ProofTypes, error)
+	UpdateDefaultProofTypes(ctx context.Context, identifier core.DID, proofTypes domain.ProofTypes) error
 }
@@ -0,0 +1,13 @@
+package ports
+
+import "context"
+
+// IPFSGateway publishes, fetches and pins documents in IPFS
+type IPFSGateway interface {
+	// Add publishes doc, pinned in the IPFS node, and returns its CID
+	Add(ctx context.Context, doc []byte) (string, error)
+	// Cat returns the document of path, a CID optionally followed by a path inside it
+	Cat(ctx context.Context, path string) ([]byte, error)
+	// Pin pins the document of path in the IPFS node, so it is kept there
+	Pin(ctx context.Context, path string) error
+}
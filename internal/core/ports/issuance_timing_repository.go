@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// IssuanceTimingRepository keeps the time spent in each phase of the issuance of the credentials
+type IssuanceTimingRepository interface {
+	Save(ctx context.Context, conn db.Querier, timing *domain.IssuanceTiming) error
+	GetByClaimID(ctx context.Context, conn db.Querier, issuerDID core.DID, claimID uuid.UUID) (*domain.IssuanceTiming, error)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// IssuerMetadataService is the interface implemented by the issuer metadata service
+type IssuerMetadataService interface {
+	Get(ctx context.Context) (*domain.IssuerMetadata, error)
+}
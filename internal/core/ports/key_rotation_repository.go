@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// KeyRotationRepository keeps the rotations of the auth keys of the identities
+type KeyRotationRepository interface {
+	Save(ctx context.Context, conn db.Querier, rotation *domain.KeyRotation) error
+	GetByID(ctx context.Context, conn db.Querier, issuerDID core.DID, id uuid.UUID) (*domain.KeyRotation, error)
+	GetActive(ctx context.Context, conn db.Querier) ([]*domain.KeyRotation, error)
+	GetActiveByIssuer(ctx context.Context, conn db.Querier, issuerDID core.DID) (*domain.KeyRotation, error)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// KeyRotationService rotates the BabyJubJub auth keys of the identities
+type KeyRotationService interface {
+	Rotate(ctx context.Context, issuerDID core.DID) (*domain.KeyRotation, error)
+	Get(ctx context.Context, issuerDID core.DID, id uuid.UUID) (*domain.KeyRotation, error)
+	Advance(ctx context.Context)
+}
@@ -46,12 +46,16 @@ type GetQRCodeResponse struct {
 
 // LinkService - the interface that defines the available methods
 type LinkService interface {
-	Save(ctx context.Context, did core.DID, maxIssuance *int, validUntil *time.Time, schemaID uuid.UUID, credentialExpiration *time.Time, credentialSignatureProof bool, credentialMTPProof bool, credentialAttributes domain.CredentialSubject) (*domain.Link, error)
+	Save(ctx context.Context, did core.DID, maxIssuance *int, validUntil *time.Time, schemaID uuid.UUID, credentialExpiration *time.Time, credentialExpirationPolicy *domain.ExpirationPolicy, credentialSignatureProof bool, credentialMTPProof bool, credentialAttributes domain.CredentialSubject, validationMode domain.ValidationMode) (*domain.Link, error)
 	Activate(ctx context.Context, issuerID core.DID, linkID uuid.UUID, active bool) error
+	UpdateDisplay(ctx context.Context, issuerID core.DID, linkID uuid.UUID, locale *string, display domain.DisplayStrings) (*domain.Link, error)
+	UpdatePrerequisites(ctx context.Context, issuerID core.DID, linkID uuid.UUID, prerequisites domain.Prerequisites) (*domain.Link, error)
 	Delete(ctx context.Context, id uuid.UUID, did core.DID) error
 	GetByID(ctx context.Context, issuerID core.DID, id uuid.UUID) (*domain.Link, error)
 	GetAll(ctx context.Context, issuerDID core.DID, status LinkStatus, query *string) ([]domain.Link, error)
 	CreateQRCode(ctx context.Context, issuerDID core.DID, linkID uuid.UUID, serverURL string) (*CreateQRCodeResponse, error)
 	IssueClaim(ctx context.Context, sessionID string, issuerDID core.DID, userDID core.DID, linkID uuid.UUID, hostURL string) error
-	GetQRCode(ctx context.Context, sessionID uuid.UUID, issuerID core.DID, linkID uuid.UUID) (*GetQRCodeResponse, error)
+	GetQRCode(ctx context.Context, sessionID uuid.UUID, issuerID core.DID, linkID uuid.UUID, hostURL string) (*GetQRCodeResponse, error)
+	GetSessions(ctx context.Context, issuerID core.DID, linkID uuid.UUID, filter LinkSessionsFilter) ([]*domain.LinkSession, error)
+	GetSession(ctx context.Context, issuerID core.DID, linkID uuid.UUID, sessionID uuid.UUID) (*domain.LinkSession, error)
 }
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// LinkSessionsFilter selects the sessions of a link. The newest sessions are returned first, up to Limit.
+type LinkSessionsFilter struct {
+	Status  *domain.LinkSessionStatus
+	UserDID *string
+	Limit   int
+}
+
+// LinkSessionRepository keeps the sessions of the wallets claiming the credentials of the links
+type LinkSessionRepository interface {
+	Save(ctx context.Context, conn db.Querier, session *domain.LinkSession) error
+	GetByID(ctx context.Context, conn db.Querier, issuerDID core.DID, linkID uuid.UUID, id uuid.UUID) (*domain.LinkSession, error)
+	GetByLink(ctx context.Context, conn db.Querier, issuerDID core.DID, linkID uuid.UUID, filter LinkSessionsFilter) ([]*domain.LinkSession, error)
+	MarkFetched(ctx context.Context, conn db.Querier, claimID uuid.UUID) error
+}
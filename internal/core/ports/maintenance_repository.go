@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// MaintenanceRepository keeps the maintenance mode of the node
+type MaintenanceRepository interface {
+	Get(ctx context.Context, conn db.Querier) (*domain.Maintenance, error)
+	Save(ctx context.Context, conn db.Querier, maintenance *domain.Maintenance) error
+}
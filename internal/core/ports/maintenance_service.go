@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// MaintenanceService is the interface implemented by the maintenance service
+type MaintenanceService interface {
+	Get(ctx context.Context) (*domain.Maintenance, error)
+	Update(ctx context.Context, enabled bool, message string, retryAfter time.Duration) (*domain.Maintenance, error)
+}
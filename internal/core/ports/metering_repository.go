@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// MeteringRepository keeps the daily counts of the billable operations per issuer and api key
+type MeteringRepository interface {
+	Add(ctx context.Context, conn db.Querier, issuerDID core.DID, apiKeyID *uuid.UUID, operation domain.MeteredOperation, at time.Time) error
+	GetMonthly(ctx context.Context, conn db.Querier, period domain.MeteringPeriod) ([]domain.MeteringMonth, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// MeteringService is the interface implemented by the metering service
+type MeteringService interface {
+	Record(ctx context.Context, issuerDID core.DID, operation domain.MeteredOperation) error
+	GetMonthly(ctx context.Context, period domain.MeteringPeriod) ([]domain.MeteringMonth, error)
+}
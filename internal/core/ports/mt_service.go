@@ -4,6 +4,7 @@ import (
 	"context"
 
 	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
 
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/db"
@@ -13,4 +14,5 @@ import (
 type MtService interface {
 	CreateIdentityMerkleTrees(ctx context.Context, conn db.Querier) (*domain.IdentityMerkleTrees, error)
 	GetIdentityMerkleTrees(ctx context.Context, conn db.Querier, identifier *core.DID) (*domain.IdentityMerkleTrees, error)
+	GetIdentityMerkleTreesForUpdate(ctx context.Context, tx pgx.Tx, identifier *core.DID) (*domain.IdentityMerkleTrees, error)
 }
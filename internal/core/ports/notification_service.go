@@ -14,6 +14,7 @@ import (
 type NotificationService interface {
 	SendCreateCredentialNotification(ctx context.Context, payload pubsub.Message) error
 	SendCreateConnectionNotification(ctx context.Context, payload pubsub.Message) error
+	SendCredentialOffer(ctx context.Context, conn *domain.Connection, credentials ...*domain.Claim) (*domain.UserNotificationResult, error)
 }
 
 // NotificationGateway represents the notification interface
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// OperationsRepository reads the state of the background work of every identity, for the operator alerts
+type OperationsRepository interface {
+	UnpublishedCredentials(ctx context.Context, conn db.Querier, minCount int) (map[string]int, error)
+	LatestConfirmedStates(ctx context.Context, conn db.Querier) ([]domain.IdentityState, error)
+}
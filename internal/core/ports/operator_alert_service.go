@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// OperatorAlertService is the interface implemented by the service that checks the operational failures and alerts
+// the operators about them
+type OperatorAlertService interface {
+	Check(ctx context.Context)
+}
+
+// AlertChannel sends the alerts to the operators, like a webhook or an email
+type AlertChannel interface {
+	Name() string
+	Send(ctx context.Context, alert domain.Alert) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// QueryPlanRepository explains the frequent queries of the node and reads the statistics of their tables
+type QueryPlanRepository interface {
+	Explain(ctx context.Context, conn db.Querier) ([]domain.QueryPlan, error)
+	TableStats(ctx context.Context, conn db.Querier) ([]domain.TableStats, error)
+}
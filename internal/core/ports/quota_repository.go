@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// QuotaRepository keeps the quota overrides of the identities and counts the resources limited by the quotas
+type QuotaRepository interface {
+	GetOverrides(ctx context.Context, conn db.Querier, issuerDID core.DID) (*domain.QuotaOverrides, error)
+	SaveOverrides(ctx context.Context, conn db.Querier, issuerDID core.DID, overrides domain.QuotaOverrides) error
+	Lock(ctx context.Context, tx pgx.Tx, issuerDID core.DID, resource domain.QuotaResource) error
+	CountActiveLinks(ctx context.Context, conn db.Querier, issuerDID core.DID, now time.Time) (int, error)
+	CountCredentialsOfDay(ctx context.Context, conn db.Querier, issuerDID core.DID, day time.Time) (int, error)
+	CountSchemas(ctx context.Context, conn db.Querier, issuerDID core.DID) (int, error)
+}
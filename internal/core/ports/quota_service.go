@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// QuotaService is the interface implemented by the quota service
+type QuotaService interface {
+	Get(ctx context.Context, issuerDID core.DID) (*domain.Quotas, error)
+	Update(ctx context.Context, issuerDID core.DID, overrides domain.QuotaOverrides) (*domain.Quotas, error)
+	GetUsage(ctx context.Context, issuerDID core.DID) (*domain.QuotaUsage, error)
+	Check(ctx context.Context, issuerDID core.DID, resource domain.QuotaResource) error
+	CheckTx(ctx context.Context, tx pgx.Tx, issuerDID core.DID, resource domain.QuotaResource) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// RegionRepository keeps the lease of the active region of the node, and promotes the replica of a passive region
+type RegionRepository interface {
+	Get(ctx context.Context, conn db.Querier) (*domain.RegionLease, error)
+	Take(ctx context.Context, conn db.Querier, lease *domain.RegionLease, previousEpoch int64) (bool, error)
+	Renew(ctx context.Context, conn db.Querier, region string, expiresAt time.Time, updatedAt time.Time) (bool, error)
+	InRecovery(ctx context.Context, conn db.Querier) (bool, error)
+	PromoteDatabase(ctx context.Context, conn db.Querier) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// RegionService is the interface implemented by the region service
+type RegionService interface {
+	Get(ctx context.Context) (*domain.Region, error)
+	Fence(ctx context.Context) error
+	Renew(ctx context.Context) (*domain.Region, error)
+	Promote(ctx context.Context, force bool, promoteDatabase bool) (*domain.Region, error)
+	Run(ctx context.Context)
+}
@@ -12,4 +12,5 @@ import (
 // RevocationRepository interface that defines the available methods
 type RevocationRepository interface {
 	UpdateStatus(ctx context.Context, conn db.Querier, did *core.DID) ([]*domain.Revocation, error)
+	GetStatus(ctx context.Context, conn db.Querier, did *core.DID, nonce domain.RevNonceUint64) (domain.RevStatus, error)
 }
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
@@ -14,4 +15,25 @@ type SchemaRepository interface {
 	Save(ctx context.Context, schema *domain.Schema) error
 	GetByID(ctx context.Context, issuerDID core.DID, id uuid.UUID) (*domain.Schema, error)
 	GetAll(ctx context.Context, issuerDID core.DID, query *string) ([]domain.Schema, error)
+	UpdateDefaultProofTypes(ctx context.Context, issuerDID core.DID, id uuid.UUID, proofTypes domain.ProofTypes) error
+	GetDefaultProofTypesByURL(ctx context.Context, issuerDID core.DID, url string) (domain.ProofTypes, error)
+	UpdateDefaultExpiration(ctx context.Context, issuerDID core.DID, id uuid.UUID, policy *domain.ExpirationPolicy) error
+	GetDefaultExpirationByURL(ctx context.Context, issuerDID core.DID, url string) (*domain.ExpirationPolicy, error)
+	UpdatePIIAttributes(ctx context.Context, issuerDID core.DID, id uuid.UUID, attrs domain.SchemaAttrs) error
+	GetPIIAttributesByURL(ctx context.Context, issuerDID core.DID, url string) (domain.SchemaAttrs, error)
+	UpdateMaxActivePerSubject(ctx context.Context, issuerDID core.DID, id uuid.UUID, maxActive *int) error
+	GetMaxActivePerSubjectByURL(ctx context.Context, issuerDID core.DID, url string) (*int, error)
+	UpdateRevokeSuperseded(ctx context.Context, issuerDID core.DID, id uuid.UUID, revokeSuperseded bool) error
+	GetRevokeSupersededByURL(ctx context.Context, issuerDID core.DID, url string) (bool, error)
+	UpdatePostIssuanceHooks(ctx context.Context, issuerDID core.DID, id uuid.UUID, hooks domain.PostIssuanceHooks) error
+	GetPostIssuanceHooksByURL(ctx context.Context, issuerDID core.DID, url string) (domain.PostIssuanceHooks, error)
+	GetUsageByURL(ctx context.Context, issuerDID core.DID, url string, from, to *time.Time) (*domain.SchemaUsage, error)
+	UpdateDisplayStrings(ctx context.Context, issuerDID core.DID, id uuid.UUID, display domain.DisplayStrings) error
+	GetDisplayStringsByURL(ctx context.Context, issuerDID core.DID, url string) (domain.DisplayStrings, error)
+	UpdatePrerequisites(ctx context.Context, issuerDID core.DID, id uuid.UUID, prerequisites domain.Prerequisites) error
+	GetVersions(ctx context.Context, issuerDID core.DID, sType string) ([]domain.Schema, error)
+	UpdateMetadata(ctx context.Context, issuerDID core.DID, id uuid.UUID, title, description string) error
+	UpdateDeprecatedAt(ctx context.Context, issuerDID core.DID, id uuid.UUID, deprecatedAt *time.Time) error
+	GetDeprecatedAtByURL(ctx context.Context, issuerDID core.DID, url string) (*time.Time, error)
+	GetDocumentByURL(ctx context.Context, url string) ([]byte, error)
 }
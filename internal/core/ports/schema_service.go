@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
@@ -9,9 +10,55 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 )
 
+// CreateSchemaRequest is the request to create a version of a schema in the registry of the node.
+// JSONLdContext is optional, without it the context is generated from the json schema.
+// ServerURL is the public url of the API that serves the json schema and the context of the new version.
+type CreateSchemaRequest struct {
+	Type          string
+	Version       string
+	Title         string
+	Description   string
+	JSONSchema    []byte
+	JSONLdContext []byte
+	ServerURL     string
+}
+
+// BuildSchemaRequest is the request to build the json schema and the JSON-LD context of a credential from the
+// definitions of its attributes. Register creates it in the registry of the node and IPFS publishes it in IPFS.
+// ServerURL is the public url of the API that serves the schemas of the registry.
+type BuildSchemaRequest struct {
+	Type        string
+	Version     string
+	Title       string
+	Description string
+	Attributes  []domain.SchemaAttributeDefinition
+	Register    bool
+	IPFS        bool
+	ServerURL   string
+}
+
 // SchemaService defines the methods that Schema manager will expose.
 type SchemaService interface {
 	ImportSchema(ctx context.Context, issuerDID core.DID, url string, sType string) (*domain.Schema, error)
 	GetByID(ctx context.Context, issuerDID core.DID, id uuid.UUID) (*domain.Schema, error)
+	GetForm(ctx context.Context, issuerDID core.DID, id uuid.UUID) (*domain.SchemaForm, error)
+	GetJSONSchema(ctx context.Context, issuerDID core.DID, id uuid.UUID) ([]byte, error)
+	GetJSONLdContext(ctx context.Context, issuerDID core.DID, id uuid.UUID) ([]byte, error)
 	GetAll(ctx context.Context, issuerDID core.DID, query *string) ([]domain.Schema, error)
+	UpdateDefaultProofTypes(ctx context.Context, issuerDID core.DID, id uuid.UUID, proofTypes domain.ProofTypes) (*domain.Schema, error)
+	UpdateDefaultExpiration(ctx context.Context, issuerDID core.DID, id uuid.UUID, policy *domain.ExpirationPolicy) (*domain.Schema, error)
+	UpdatePIIAttributes(ctx context.Context, issuerDID core.DID, id uuid.UUID, attrs domain.SchemaAttrs) (*domain.Schema, error)
+	UpdateMaxActivePerSubject(ctx context.Context, issuerDID core.DID, id uuid.UUID, maxActive *int) (*domain.Schema, error)
+	UpdateRevokeSuperseded(ctx context.Context, issuerDID core.DID, id uuid.UUID, revokeSuperseded bool) (*domain.Schema, error)
+	UpdatePostIssuanceHooks(ctx context.Context, issuerDID core.DID, id uuid.UUID, hooks domain.PostIssuanceHooks) (*domain.Schema, error)
+	UpdateDisplayStrings(ctx context.Context, issuerDID core.DID, id uuid.UUID, display domain.DisplayStrings) (*domain.Schema, error)
+	GetDisplayStringsByURL(ctx context.Context, issuerDID core.DID, url string) (domain.DisplayStrings, error)
+	GetUsage(ctx context.Context, issuerDID core.DID, id uuid.UUID, from, to *time.Time) (*domain.SchemaUsage, error)
+	UpdatePrerequisites(ctx context.Context, issuerDID core.DID, id uuid.UUID, prerequisites domain.Prerequisites) (*domain.Schema, error)
+	PurgeCache(ctx context.Context, issuerDID core.DID, url string) ([]string, error)
+	CreateSchema(ctx context.Context, issuerDID core.DID, req *CreateSchemaRequest) (*domain.Schema, error)
+	BuildSchema(ctx context.Context, issuerDID core.DID, req *BuildSchemaRequest) (*domain.BuiltSchema, error)
+	GetVersions(ctx context.Context, issuerDID core.DID, id uuid.UUID) ([]domain.Schema, error)
+	UpdateMetadata(ctx context.Context, issuerDID core.DID, id uuid.UUID, title, description string) (*domain.Schema, error)
+	Deprecate(ctx context.Context, issuerDID core.DID, id uuid.UUID, deprecated bool) (*domain.Schema, error)
 }
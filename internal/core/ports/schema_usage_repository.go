@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// SchemaUsageRepository keeps the daily counts of the credentials issued and revoked per schema
+type SchemaUsageRepository interface {
+	AddIssued(ctx context.Context, conn db.Querier, issuerDID core.DID, schemaURL string, byLink bool, at time.Time) error
+	AddRevoked(ctx context.Context, conn db.Querier, issuerDID core.DID, schemaURL string, at time.Time) error
+}
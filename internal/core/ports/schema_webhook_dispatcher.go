@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+)
+
+// SchemaWebhookDispatcher delivers the issued and revoked credentials to the webhooks registered for their schema type
+type SchemaWebhookDispatcher interface {
+	DeliverIssued(ctx context.Context, payload pubsub.Message) error
+	DeliverRevoked(ctx context.Context, payload pubsub.Message) error
+}
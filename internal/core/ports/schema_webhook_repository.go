@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// SchemaWebhookRepository keeps the schema webhooks of the issuers
+type SchemaWebhookRepository interface {
+	Save(ctx context.Context, conn db.Querier, webhook *domain.SchemaWebhook) error
+	GetAll(ctx context.Context, conn db.Querier, issuerDID core.DID) ([]domain.SchemaWebhook, error)
+	Delete(ctx context.Context, conn db.Querier, issuerDID core.DID, id uuid.UUID) error
+}
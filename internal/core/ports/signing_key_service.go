@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// SigningKeyService manages the BabyJubJub and secp256k1 keys that sign the credentials of the identities
+type SigningKeyService interface {
+	GetAll(ctx context.Context, issuerDID core.DID) ([]*domain.SigningKey, error)
+	Add(ctx context.Context, issuerDID core.DID, keyType domain.SigningKeyType) (*domain.SigningKey, error)
+	Remove(ctx context.Context, issuerDID core.DID, authClaimID uuid.UUID) error
+	// Secp256k1Signers returns the Ethereum addresses of the active secp256k1 keys of the identity
+	Secp256k1Signers(ctx context.Context, did core.DID) ([]ethcommon.Address, error)
+}
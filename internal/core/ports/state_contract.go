@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"github.com/iden3/contracts-abi/state/go/abi"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// StateContracts returns the state contract of the network of an identity
+type StateContracts interface {
+	StateContract(did *core.DID) (*abi.State, error)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// SubjectRepository defines the available methods for the subject data repository
+type SubjectRepository interface {
+	Erase(ctx context.Context, conn db.Querier, erasure *domain.SubjectErasure, subject core.DID) error
+	GetErasures(ctx context.Context, conn db.Querier, issuerDID core.DID, subjectHash string) ([]*domain.SubjectErasure, error)
+	GetArchivedClaims(ctx context.Context, conn db.Querier, issuerDID core.DID, subject core.DID) ([]*domain.Claim, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// SubjectService is the interface implemented by the subject data service
+type SubjectService interface {
+	Export(ctx context.Context, issuerDID core.DID, subject core.DID) (*domain.SubjectData, error)
+	Erase(ctx context.Context, issuerDID core.DID, subject core.DID) (*domain.SubjectErasure, error)
+}
@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	core "github.com/iden3/go-iden3-core"
 )
 
 // TransactionService interface
@@ -15,3 +16,8 @@ type TransactionService interface {
 	CheckConfirmation(ctx context.Context, receipt *types.Receipt) (bool, error)
 	GetTransactionReceiptByID(ctx context.Context, txID string) (*types.Receipt, error)
 }
+
+// TransactionServices returns the transaction service of the network of an identity
+type TransactionServices interface {
+	TransactionService(did *core.DID) (TransactionService, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// VerificationRepository keeps the results of the verifications of the proofs sent by the wallets
+type VerificationRepository interface {
+	Save(ctx context.Context, conn db.Querier, verification *domain.Verification) error
+	GetByID(ctx context.Context, conn db.Querier, issuerDID core.DID, id uuid.UUID) (*domain.Verification, error)
+	GetAll(ctx context.Context, conn db.Querier, issuerDID core.DID, filter domain.VerificationFilter) ([]domain.Verification, error)
+}
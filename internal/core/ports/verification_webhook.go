@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+)
+
+// VerificationWebhook delivers the results of the proof verifications to an external endpoint
+type VerificationWebhook interface {
+	Deliver(ctx context.Context, payload pubsub.Message) error
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+type apiKeyContextKey struct{}
+
+// WithAPIKey returns a copy of ctx for a request authenticated with a delegated api key. The credentials created
+// and read with the returned context are limited to the schema types and links of the key.
+func WithAPIKey(ctx context.Context, key *domain.APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, key)
+}
+
+func apiKeyFromContext(ctx context.Context) (*domain.APIKey, bool) {
+	key, found := ctx.Value(apiKeyContextKey{}).(*domain.APIKey)
+	return key, found && key != nil
+}
+
+// CreateAPIKey creates a delegated api key of the issuer that can only issue credentials of the schema types and
+// through the links, when they are set, or call the operations of its role when it has one. It returns the key and
+// its secret, that is not kept.
+func (c *claim) CreateAPIKey(ctx context.Context, issuerDID core.DID, name string, role domain.Role, schemaTypes []string, linkIDs []uuid.UUID) (*domain.APIKey, string, error) {
+	key, secret, err := domain.NewAPIKey(issuerDID, name, role, schemaTypes, linkIDs)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := c.apiKeyRepository.Save(ctx, c.storage.Pgx, key); err != nil {
+		log.Error(ctx, "saving api key", "err", err)
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+// GetAPIKeys returns the api keys of the issuer, the newest first
+func (c *claim) GetAPIKeys(ctx context.Context, issuerDID core.DID) ([]domain.APIKey, error) {
+	return c.apiKeyRepository.GetAll(ctx, c.storage.Reader(), issuerDID)
+}
+
+// DeleteAPIKey revokes an api key of the issuer
+func (c *claim) DeleteAPIKey(ctx context.Context, issuerDID core.DID, id uuid.UUID) error {
+	err := c.apiKeyRepository.Delete(ctx, c.storage.Pgx, issuerDID, id)
+	if errors.Is(err, repositories.ErrAPIKeyDoesNotExist) {
+		return ErrAPIKeyNotFound
+	}
+	return err
+}
+
+// AuthenticateAPIKey returns the api key of the issuer with the id and secret
+func (c *claim) AuthenticateAPIKey(ctx context.Context, issuerDID core.DID, id uuid.UUID, secret string) (*domain.APIKey, error) {
+	key, err := c.apiKeyRepository.GetByID(ctx, c.storage.Pgx, issuerDID, id)
+	if errors.Is(err, repositories.ErrAPIKeyDoesNotExist) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !key.VerifySecret(secret) {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+func apiKeyIDFromContext(ctx context.Context) *uuid.UUID {
+	if key, found := apiKeyFromContext(ctx); found {
+		return &key.ID
+	}
+	return nil
+}
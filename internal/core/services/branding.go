@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+type branding struct {
+	repository ports.BrandingRepository
+	storage    *db.Storage
+	defaults   domain.Branding
+}
+
+// NewBranding returns the service that keeps how the identities of the node are presented to the holders. defaults
+// is the branding of the identities without overrides.
+func NewBranding(storage *db.Storage, defaults domain.Branding) ports.BrandingService {
+	return &branding{
+		repository: repositories.NewBranding(),
+		storage:    storage,
+		defaults:   defaults,
+	}
+}
+
+// Get returns the branding of the identity: the one of the node with its overrides
+func (b *branding) Get(ctx context.Context, issuerDID core.DID) (*domain.Branding, error) {
+	overrides, err := b.repository.GetOverrides(ctx, b.storage.Pgx, issuerDID)
+	if errors.Is(err, repositories.ErrIdentityNotFound) {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	branding := b.defaults.With(*overrides)
+	return &branding, nil
+}
+
+// Update replaces the branding overrides of the identity and returns its branding
+func (b *branding) Update(ctx context.Context, issuerDID core.DID, overrides domain.BrandingOverrides) (*domain.Branding, error) {
+	if err := overrides.Validate(); err != nil {
+		return nil, err
+	}
+	err := b.repository.SaveOverrides(ctx, b.storage.Pgx, issuerDID, overrides)
+	if errors.Is(err, repositories.ErrIdentityNotFound) {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	branding := b.defaults.With(overrides)
+	return &branding, nil
+}
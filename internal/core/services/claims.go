@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"expvar"
 	"fmt"
 	"math/big"
+	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +19,7 @@ import (
 	"github.com/iden3/go-merkletree-sql/v2"
 	"github.com/iden3/go-schema-processor/processor"
 	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/iden3/iden3comm"
 	"github.com/iden3/iden3comm/packers"
 	"github.com/iden3/iden3comm/protocol"
 	"github.com/jackc/pgx/v4"
@@ -24,59 +29,131 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/core/event"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/jsonschema"
 	"github.com/polygonid/sh-id-platform/internal/loader"
 	"github.com/polygonid/sh-id-platform/internal/log"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/credentials/credentialid"
+	"github.com/polygonid/sh-id-platform/pkg/policy"
 	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 	"github.com/polygonid/sh-id-platform/pkg/rand"
 	schemaPkg "github.com/polygonid/sh-id-platform/pkg/schema"
 )
 
 var (
-	ErrClaimNotFound            = errors.New("claim not found")                                       // ErrClaimNotFound Cannot retrieve the given claim
-	ErrSchemaNotFound           = errors.New("schema not found")                                      // ErrSchemaNotFound Cannot retrieve the given schema from DB
-	ErrLinkNotFound             = errors.New("link not found")                                        // ErrLinkNotFound Cannot get the given link from the DB
-	ErrJSONLdContext            = errors.New("jsonLdContext must be a string")                        // ErrJSONLdContext Field jsonLdContext must be a string
-	ErrLoadingSchema            = errors.New("cannot load schema")                                    // ErrLoadingSchema means the system cannot load the schema file
-	ErrMalformedURL             = errors.New("malformed url")                                         // ErrMalformedURL The schema url is wrong
-	ErrProcessSchema            = errors.New("cannot process schema")                                 // ErrProcessSchema Cannot process schema
-	ErrParseClaim               = errors.New("cannot parse claim")                                    // ErrParseClaim Cannot parse claim
-	ErrInvalidCredentialSubject = errors.New("credential subject does not match the provided schema") // ErrInvalidCredentialSubject means the credentialSubject does not match the schema provided
+	ErrClaimNotFound              = errors.New("claim not found")                                                                  // ErrClaimNotFound Cannot retrieve the given claim
+	ErrSchemaNotFound             = errors.New("schema not found")                                                                 // ErrSchemaNotFound Cannot retrieve the given schema from DB
+	ErrLinkNotFound               = errors.New("link not found")                                                                   // ErrLinkNotFound Cannot get the given link from the DB
+	ErrJSONLdContext              = errors.New("jsonLdContext must be a string")                                                   // ErrJSONLdContext Field jsonLdContext must be a string
+	ErrLoadingSchema              = errors.New("cannot load schema")                                                               // ErrLoadingSchema means the system cannot load the schema file
+	ErrMalformedURL               = errors.New("malformed url")                                                                    // ErrMalformedURL The schema url is wrong
+	ErrProcessSchema              = errors.New("cannot process schema")                                                            // ErrProcessSchema Cannot process schema
+	ErrParseClaim                 = errors.New("cannot parse claim")                                                               // ErrParseClaim Cannot parse claim
+	ErrInvalidCredentialSubject   = errors.New("credential subject does not match the provided schema")                            // ErrInvalidCredentialSubject means the credentialSubject does not match the schema provided
+	ErrClaimRevoked               = errors.New("claim is revoked")                                                                 // ErrClaimRevoked the claim cannot be changed because it is revoked
+	ErrAgentMessageReplayed       = errors.New("message already processed")                                                        // ErrAgentMessageReplayed the agent message is a replay of an already processed one
+	ErrAgentMessageOutsideWindow  = errors.New("message created outside the replay window or expired")                             // ErrAgentMessageOutsideWindow the agent message is too old, from the future or expired, so its replays can't be detected
+	ErrAgentMessageNoCreatedTime  = errors.New("message without created_time")                                                     // ErrAgentMessageNoCreatedTime the agent message has no created_time, so its replays can't be detected
+	ErrUnknownSchemaAttribute     = errors.New("attribute is not in the schema")                                                   // ErrUnknownSchemaAttribute the attribute is not one of the schema attributes
+	ErrInvalidMaxActivePerSubject = errors.New("max active credentials per subject must be greater than zero")                     // ErrInvalidMaxActivePerSubject the schema limit of active credentials per subject is not positive
+	ErrIssuanceLimitExceeded      = errors.New("the subject already holds the maximum number of active credentials of the schema") // ErrIssuanceLimitExceeded the credential would exceed the schema limit of active credentials per subject
+	ErrIssuanceDenied             = errors.New("the issuance policy denied the credential")                                        // ErrIssuanceDenied the policy hook denied the issuance
+	ErrIssuancePolicyUnavailable  = errors.New("cannot evaluate the issuance policy")                                              // ErrIssuancePolicyUnavailable the policy hook failed and the issuance is not allowed without it
+	ErrPostIssuanceHookNotFound   = errors.New("post-issuance hook not found")                                                     // ErrPostIssuanceHookNotFound the schema has no post-issuance hook with the given name
+	ErrAPIKeyNotFound             = errors.New("api key not found")                                                                // ErrAPIKeyNotFound the issuer has no api key with the given id and secret
+	ErrAPIKeyScope                = errors.New("the api key cannot issue the credential")                                          // ErrAPIKeyScope the credential is not of the schema types or links of the api key of the request
+	ErrSchemaDeprecated           = errors.New("the schema is deprecated")                                                         // ErrSchemaDeprecated the schema can't be used for new credentials or links
+	ErrIssuanceTimingNotFound     = errors.New("the credential has no issuance timing")                                            // ErrIssuanceTimingNotFound the credential was issued before the timings were kept, or its timing couldn't be saved
+	ErrSchemaWebhookNotFound      = errors.New("schema webhook not found")                                                         // ErrSchemaWebhookNotFound the issuer has no schema webhook with the given id
 )
 
+// agentReplays counts the rejected agent replays by message type
+var agentReplays = expvar.NewMap("agent_replays_rejected")
+
 // ClaimCfg claim service configuration
 type ClaimCfg struct {
 	RHSEnabled bool // ReverseHash Enabled
 	RHSUrl     string
-	Host       string
+	// NetworkRHSUrls are the reverse hash services of the networks keyed by blockchain:network, the networks without
+	// one use RHSUrl
+	NetworkRHSUrls map[string]string
+	Host           string
+	// AgentReplayWindow is how long a processed agent message is remembered to reject its replays. Zero disables it.
+	// The messages created before the window, or without a creation time, are rejected.
+	AgentReplayWindow time.Duration
+	// ClockSkew is the tolerated difference between the clock of the node and the times of the agent messages
+	ClockSkew time.Duration
+	// PolicyHook approves, denies or changes every credential before it is created. Nil issues without a policy.
+	PolicyHook policy.Hook
+	// PolicyFailOpen issues the credentials as requested when the policy hook fails, instead of rejecting them
+	PolicyFailOpen bool
+	// HookCaptureSize is the number of calls to the post-issuance hooks kept per issuer for inspection. Zero disables it.
+	HookCaptureSize int
+	// HookTransport sends the calls to the post-issuance hooks. Nil sends them with http.DefaultTransport.
+	HookTransport http.RoundTripper
+	// Quotas limits the credentials issued per day by each identity. Nil issues without quotas.
+	Quotas ports.QuotaService
+	// CredentialIDs builds the ids of the credentials and of their revocation status. Nil builds UUIDs shown as the urls
+	// of the node.
+	CredentialIDs *credentialid.Scheme
+	// SigningKeyRoundRobin signs the credentials that don't ask for a signing key with each active key of the identity
+	// in turn, instead of with its first one
+	SigningKeyRoundRobin bool
 }
 
 type claim struct {
-	cfg                     ClaimCfg
-	icRepo                  ports.ClaimsRepository
-	identitySrv             ports.IdentityService
-	mtService               ports.MtService
-	identityStateRepository ports.IdentityStateRepository
-	storage                 *db.Storage
-	loaderFactory           loader.Factory
-	publisher               pubsub.Publisher
+	cfg                      ClaimCfg
+	icRepo                   ports.ClaimsRepository
+	identitySrv              ports.IdentityService
+	mtService                ports.MtService
+	identityStateRepository  ports.IdentityStateRepository
+	agentMessageRepository   ports.AgentMessageRepository
+	schemaUsageRepository    ports.SchemaUsageRepository
+	hookDeliveryRepository   ports.HookDeliveryRepository
+	apiKeyRepository         ports.APIKeyRepository
+	meteringRepository       ports.MeteringRepository
+	issuanceTimingRepository ports.IssuanceTimingRepository
+	linkSessionRepository    ports.LinkSessionRepository
+	schemaWebhookRepository  ports.SchemaWebhookRepository
+	storage                  *db.Storage
+	loaderFactory            loader.Factory
+	publisher                pubsub.Publisher
+	signingKeyTurn           atomic.Uint64
 }
 
 // NewClaim creates a new claim service
 func NewClaim(repo ports.ClaimsRepository, idenSrv ports.IdentityService, mtService ports.MtService, identityStateRepository ports.IdentityStateRepository, ld loader.Factory, storage *db.Storage, cfg ClaimCfg, ps pubsub.Publisher) ports.ClaimsService {
 	s := &claim{
 		cfg: ClaimCfg{
-			RHSEnabled: cfg.RHSEnabled,
-			RHSUrl:     cfg.RHSUrl,
-			Host:       cfg.Host,
+			RHSEnabled:           cfg.RHSEnabled,
+			RHSUrl:               cfg.RHSUrl,
+			NetworkRHSUrls:       cfg.NetworkRHSUrls,
+			Host:                 cfg.Host,
+			AgentReplayWindow:    cfg.AgentReplayWindow,
+			ClockSkew:            cfg.ClockSkew,
+			PolicyHook:           cfg.PolicyHook,
+			PolicyFailOpen:       cfg.PolicyFailOpen,
+			HookCaptureSize:      cfg.HookCaptureSize,
+			HookTransport:        cfg.HookTransport,
+			Quotas:               cfg.Quotas,
+			CredentialIDs:        cfg.CredentialIDs,
+			SigningKeyRoundRobin: cfg.SigningKeyRoundRobin,
 		},
-		icRepo:                  repo,
-		identitySrv:             idenSrv,
-		mtService:               mtService,
-		identityStateRepository: identityStateRepository,
-		storage:                 storage,
-		loaderFactory:           ld,
-		publisher:               ps,
+		icRepo:                   repo,
+		identitySrv:              idenSrv,
+		mtService:                mtService,
+		identityStateRepository:  identityStateRepository,
+		agentMessageRepository:   repositories.NewAgentMessage(),
+		schemaUsageRepository:    repositories.NewSchemaUsage(),
+		hookDeliveryRepository:   repositories.NewHookDelivery(),
+		apiKeyRepository:         repositories.NewAPIKey(),
+		meteringRepository:       repositories.NewMetering(),
+		issuanceTimingRepository: repositories.NewIssuanceTiming(),
+		linkSessionRepository:    repositories.NewLinkSession(),
+		schemaWebhookRepository:  repositories.NewSchemaWebhook(),
+		storage:                  storage,
+		loaderFactory:            ld,
+		publisher:                ps,
 	}
 	return s
 }
@@ -85,43 +162,370 @@ func NewClaim(repo ports.ClaimsRepository, idenSrv ports.IdentityService, mtServ
 // 1.- Creates document
 // 2.- Signature proof
 // 3.- MerkelTree proof
+// The credentials of a deprecated schema are only issued through the links created before the deprecation.
 func (c *claim) Save(ctx context.Context, req *ports.CreateClaimRequest) (*domain.Claim, error) {
+	if err := c.checkSchemaDeprecation(ctx, req); err != nil {
+		return nil, err
+	}
+	ctx = withIssuanceTimer(ctx)
 	claim, err := c.CreateCredential(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	claim.ID, err = c.icRepo.Save(ctx, c.storage.Pgx, claim)
+	commitDone := timeIssuancePhase(ctx, domain.IssuancePhaseDBCommit)
+	err = c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		claim.ID, err = c.SaveCredential(ctx, tx, *req.DID, claim)
+		return err
+	})
+	commitDone()
 	if err != nil {
 		return nil, err
 	}
-	if req.SignatureProof {
-		err = c.publisher.Publish(ctx, event.CreateCredentialEvent, &event.CreateCredential{CredentialIDs: []string{claim.ID.String()}, IssuerID: req.DID.String()})
+	c.afterSave(ctx, req, claim)
+
+	return claim, nil
+}
+
+// SaveBatch creates the claims of the requests like Save, and saves them in one transaction with multi-row inserts.
+// The results are in the order of the requests, with the claim or the error of each request. A request failing does
+// not fail the others.
+// The credentials of the schemas with issuance policies are saved one by one after the batch, and when the batch
+// transaction fails, its credentials are saved one by one too, so only the failing ones are lost.
+func (c *claim) SaveBatch(ctx context.Context, reqs []*ports.CreateClaimRequest) ([]*domain.Claim, []error) {
+	claims := make([]*domain.Claim, len(reqs))
+	errs := make([]error, len(reqs))
+	ctxs := make([]context.Context, len(reqs))
+	for i, req := range reqs {
+		if errs[i] = c.checkSchemaDeprecation(ctx, req); errs[i] != nil {
+			continue
+		}
+		ctxs[i] = withIssuanceTimer(ctx)
+		claims[i], errs[i] = c.CreateCredential(ctxs[i], req)
+	}
+
+	var batch, single []int
+	for i, claim := range claims {
+		if errs[i] != nil {
+			continue
+		}
+		hasPolicy, err := c.hasIssuancePolicy(ctxs[i], *reqs[i].DID, claim)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		if hasPolicy {
+			single = append(single, i)
+		} else {
+			batch = append(batch, i)
+		}
+	}
+
+	if len(batch) > 0 {
+		commitDone := make([]func(), len(batch))
+		for j, i := range batch {
+			commitDone[j] = timeIssuancePhase(ctxs[i], domain.IssuancePhaseDBCommit)
+		}
+		batchErrs := make([]error, len(reqs))
+		err := c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+			var saved []*domain.Claim
+			for _, i := range batch {
+				batchErrs[i] = c.countIssued(ctxs[i], tx, *reqs[i].DID, claims[i])
+				if batchErrs[i] != nil && !errors.Is(batchErrs[i], ErrQuotaExceeded) {
+					return batchErrs[i]
+				}
+				if batchErrs[i] == nil {
+					saved = append(saved, claims[i])
+				}
+			}
+			_, err := c.icRepo.SaveBatch(ctx, tx, saved)
+			return err
+		})
+		for _, done := range commitDone {
+			done()
+		}
+		if err != nil {
+			log.Warn(ctx, "saving the credentials batch, saving them one by one", "err", err, "credentials", len(batch))
+			single = append(single, batch...)
+			sort.Ints(single)
+		} else {
+			for _, i := range batch {
+				errs[i] = batchErrs[i]
+			}
+		}
+	}
+
+	for _, i := range single {
+		commitDone := timeIssuancePhase(ctxs[i], domain.IssuancePhaseDBCommit)
+		errs[i] = c.storage.Pgx.BeginFunc(ctxs[i], func(tx pgx.Tx) error {
+			var err error
+			claims[i].ID, err = c.SaveCredential(ctxs[i], tx, *reqs[i].DID, claims[i])
+			return err
+		})
+		commitDone()
+	}
+
+	for i, req := range reqs {
+		if errs[i] != nil {
+			claims[i] = nil
+			continue
+		}
+		c.afterSave(ctxs[i], req, claims[i])
+	}
+	return claims, errs
+}
+
+// checkSchemaDeprecation fails with ErrSchemaDeprecated when the schema of the request is deprecated and the request
+// is not from a link
+func (c *claim) checkSchemaDeprecation(ctx context.Context, req *ports.CreateClaimRequest) error {
+	if req.DID == nil || req.LinkID != nil {
+		return nil
+	}
+	deprecatedAt, err := repositories.NewSchema(*c.storage).GetDeprecatedAtByURL(ctx, *req.DID, req.Schema)
+	if err != nil {
+		log.Error(ctx, "getting the schema deprecation", "err", err, "schema", req.Schema)
+		return err
+	}
+	if deprecatedAt != nil {
+		return ErrSchemaDeprecated
+	}
+	return nil
+}
+
+// afterSave runs the post issuance hooks of a saved claim, notifies the holder and saves the issuance timing
+func (c *claim) afterSave(ctx context.Context, req *ports.CreateClaimRequest, claim *domain.Claim) {
+	c.RunPostIssuanceHooks(ctx, *req.DID, claim)
+	if req.SignatureProof && !req.SkipNotification {
+		notificationDone := timeIssuancePhase(ctx, domain.IssuancePhaseNotification)
+		err := c.publisher.Publish(ctx, event.CreateCredentialEvent, &event.CreateCredential{CredentialIDs: []string{claim.ID.String()}, IssuerID: req.DID.String()})
+		notificationDone()
 		if err != nil {
 			log.Error(ctx, "publish CreateCredentialEvent", "err", err.Error(), "credential", claim.ID.String())
 		}
 	}
+	saveIssuanceTiming(ctx, c.storage, *req.DID, claim.ID)
+}
 
-	return claim, nil
+// SaveCredential saves in tx a credential created with CreateCredential, applying the issuance policies of its schema:
+// 1.- When the schema revokes the superseded credentials, the active credentials of the schema issued to the subject
+// are revoked, and the credential replaces the last of them.
+// 2.- When the schema limits the active credentials per subject, it fails with ErrIssuanceLimitExceeded if the subject
+// already holds the maximum.
+// The subject and schema are locked until the end of tx, so concurrent issuances are serialized.
+// It fails with ErrQuotaExceeded when the issuer already issued its quota of credentials of the day.
+// The credential is counted in the schema usage and metered as an issuance.
+func (c *claim) SaveCredential(ctx context.Context, tx pgx.Tx, issuerDID core.DID, claim *domain.Claim) (uuid.UUID, error) {
+	if c.cfg.Quotas != nil && !claim.Revoked {
+		if err := c.cfg.Quotas.CheckTx(ctx, tx, issuerDID, domain.QuotaCredentialsPerDay); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	id, err := c.saveCredential(ctx, tx, issuerDID, claim)
+	if err != nil || claim.Revoked {
+		return id, err
+	}
+	if err := c.recordIssued(ctx, tx, issuerDID, claim); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+// countIssued checks the credentials per day quota of the issuer for a claim about to be saved in tx, and counts it in
+// the schema usage and meters it as an issuance
+func (c *claim) countIssued(ctx context.Context, tx pgx.Tx, issuerDID core.DID, claim *domain.Claim) error {
+	if claim.Revoked {
+		return nil
+	}
+	if c.cfg.Quotas != nil {
+		if err := c.cfg.Quotas.CheckTx(ctx, tx, issuerDID, domain.QuotaCredentialsPerDay); err != nil {
+			return err
+		}
+	}
+	return c.recordIssued(ctx, tx, issuerDID, claim)
+}
+
+func (c *claim) recordIssued(ctx context.Context, tx pgx.Tx, issuerDID core.DID, claim *domain.Claim) error {
+	if err := c.schemaUsageRepository.AddIssued(ctx, tx, issuerDID, claim.SchemaURL, claim.LinkID != nil, time.Now()); err != nil {
+		log.Error(ctx, "counting the schema usage", "err", err, "schema", claim.SchemaURL)
+		return err
+	}
+	if err := c.meteringRepository.Add(ctx, tx, issuerDID, apiKeyIDFromContext(ctx), domain.MeteredIssuance, time.Now()); err != nil {
+		log.Error(ctx, "metering the issuance", "err", err)
+		return err
+	}
+	return nil
+}
+
+// issuancePolicy returns the issuance policies of the schema of claim: whether it revokes the superseded credentials
+// and the maximum of active credentials per subject
+func (c *claim) issuancePolicy(ctx context.Context, issuerDID core.DID, claim *domain.Claim) (bool, *int, error) {
+	schemaRepo := repositories.NewSchema(*c.storage)
+	revokeSuperseded, err := schemaRepo.GetRevokeSupersededByURL(ctx, issuerDID, claim.SchemaURL)
+	if err != nil {
+		log.Error(ctx, "getting the schema revoke superseded option", "err", err, "schema", claim.SchemaURL)
+		return false, nil, err
+	}
+	maxActive, err := schemaRepo.GetMaxActivePerSubjectByURL(ctx, issuerDID, claim.SchemaURL)
+	if err != nil {
+		log.Error(ctx, "getting the schema max active credentials per subject", "err", err, "schema", claim.SchemaURL)
+		return false, nil, err
+	}
+	return revokeSuperseded, maxActive, nil
+}
+
+// hasIssuancePolicy tells whether saving claim applies an issuance policy of its schema
+func (c *claim) hasIssuancePolicy(ctx context.Context, issuerDID core.DID, claim *domain.Claim) (bool, error) {
+	if claim.OtherIdentifier == "" || claim.Revoked {
+		return false, nil
+	}
+	revokeSuperseded, maxActive, err := c.issuancePolicy(ctx, issuerDID, claim)
+	return revokeSuperseded || maxActive != nil, err
+}
+
+func (c *claim) saveCredential(ctx context.Context, tx pgx.Tx, issuerDID core.DID, claim *domain.Claim) (uuid.UUID, error) {
+	if claim.OtherIdentifier == "" || claim.Revoked {
+		return c.icRepo.Save(ctx, tx, claim)
+	}
+	revokeSuperseded, maxActive, err := c.issuancePolicy(ctx, issuerDID, claim)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !revokeSuperseded && maxActive == nil {
+		return c.icRepo.Save(ctx, tx, claim)
+	}
+
+	if err := c.icRepo.LockSubjectSchema(ctx, tx, issuerDID, claim.OtherIdentifier, claim.SchemaURL); err != nil {
+		return uuid.Nil, err
+	}
+	if revokeSuperseded {
+		if err := c.revokeSuperseded(ctx, tx, issuerDID, claim); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	if maxActive != nil {
+		active, err := c.icRepo.CountActiveBySubjectAndSchema(ctx, tx, issuerDID, claim.OtherIdentifier, claim.SchemaURL)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if active >= *maxActive {
+			log.Info(ctx, "issuance limit exceeded", "schema", claim.SchemaURL, "subject", claim.OtherIdentifier, "max", *maxActive)
+			return uuid.Nil, ErrIssuanceLimitExceeded
+		}
+	}
+	return c.icRepo.Save(ctx, tx, claim)
+}
+
+// revokeSuperseded revokes the active credentials of the schema issued to the subject of claim, that supersedes them.
+// The revocations are described as superseded by claim, and claim replaces the last of them unless it already
+// replaces another credential.
+func (c *claim) revokeSuperseded(ctx context.Context, tx pgx.Tx, issuerDID core.DID, claim *domain.Claim) error {
+	superseded, err := c.icRepo.GetActiveBySubjectAndSchema(ctx, tx, issuerDID, claim.OtherIdentifier, claim.SchemaURL)
+	if err != nil {
+		return err
+	}
+	for _, previous := range superseded {
+		if _, err := c.revoke(ctx, &issuerDID, uint64(previous.RevNonce), fmt.Sprintf("superseded by %s", claim.ID), tx); err != nil {
+			return fmt.Errorf("revoking superseded credential %s: %w", previous.ID, err)
+		}
+		log.Info(ctx, "superseded credential revoked", "credential", previous.ID.String(), "supersededBy", claim.ID.String())
+	}
+	if len(superseded) > 0 && claim.ReplacesID == nil {
+		claim.ReplacesID = &superseded[len(superseded)-1].ID
+	}
+	return nil
 }
 
 // CreateCredential - Create a new Credential, but this method doesn't save it in the repository.
+// When the request has no proof types, the defaults of the schema or of the issuer are set in the request.
+// When it has no expiration, the default expiration policy of the schema is set in the request.
+// The PII attributes of the schema are set in the request to mask them in the logs.
+// The credential subject is validated with the validation mode of the request, and the warnings of the lenient mode are
+// set in the request.
 func (c *claim) CreateCredential(ctx context.Context, req *ports.CreateClaimRequest) (*domain.Claim, error) {
+	if key, found := apiKeyFromContext(ctx); found && !key.AllowsIssuance(req.Type, req.LinkID) {
+		log.Warn(ctx, "the api key cannot issue the credential", "apiKey", key.ID, "type", req.Type, "linkID", req.LinkID)
+		return nil, ErrAPIKeyScope
+	}
+	if req.DID != nil && req.PIIAttributes == nil {
+		piiAttributes, err := repositories.NewSchema(*c.storage).GetPIIAttributesByURL(ctx, *req.DID, req.Schema)
+		if err != nil {
+			log.Error(ctx, "getting the schema pii attributes", "err", err, "schema", req.Schema)
+			return nil, err
+		}
+		req.PIIAttributes = piiAttributes
+	}
+	if err := c.evaluatePolicy(ctx, req); err != nil {
+		return nil, err
+	}
 	if err := c.guardCreateClaimRequest(req); err != nil {
 		log.Warn(ctx, "validating create claim request", "req", req)
 		return nil, err
 	}
 
+	if !req.SignatureProof && !req.MTProof {
+		proofTypes, err := c.defaultProofTypes(ctx, req)
+		if err != nil {
+			log.Error(ctx, "getting the default proof types", "err", err, "schema", req.Schema)
+			return nil, err
+		}
+		req.SignatureProof, req.MTProof = proofTypes.SignatureProof(), proofTypes.MTProof()
+	}
+	if err := domain.NewProofTypes(req.SignatureProof, req.MTProof).Validate(*req.DID); err != nil {
+		log.Warn(ctx, "validating create claim request proof types", "err", err, "req", req)
+		return nil, err
+	}
+	if req.Expiration == nil && req.ExpirationPolicy == nil {
+		policy, err := repositories.NewSchema(*c.storage).GetDefaultExpirationByURL(ctx, *req.DID, req.Schema)
+		if err != nil {
+			log.Error(ctx, "getting the default expiration policy", "err", err, "schema", req.Schema)
+			return nil, err
+		}
+		req.ExpirationPolicy = policy
+	}
+
 	nonce, err := rand.Int64()
 	if err != nil {
 		log.Error(ctx, "create a nonce", "err", err)
 		return nil, err
 	}
 
-	schema, err := schemaPkg.LoadSchema(ctx, c.loaderFactory(req.Schema))
+	schemaLoadDone := timeIssuancePhase(ctx, domain.IssuancePhaseSchemaLoad)
+	schemaBytes, _, err := c.loaderFactory(req.Schema).Load(ctx)
 	if err != nil {
 		log.Error(ctx, "loading schema", "err", err, "schema", req.Schema)
 		return nil, ErrLoadingSchema
 	}
+	if jsonschema.IsJSONLdContext(schemaBytes) {
+		derived, err := jsonschema.FromJSONLdContext(schemaBytes, req.Schema, req.Type)
+		if err != nil {
+			log.Error(ctx, "deriving the schema from the jsonld context", "err", err, "schema", req.Schema)
+			return nil, ErrProcessSchema
+		}
+		schemaBytes = derived.Raw()
+	}
+	schemaLoadDone()
+	validationDone := timeIssuancePhase(ctx, domain.IssuancePhaseValidation)
+	remoteSchema, err := jsonschema.Parse(schemaBytes)
+	if err != nil {
+		log.Error(ctx, "parsing schema", "err", err, "schema", req.Schema)
+		return nil, ErrLoadingSchema
+	}
+	credentialSubject, warnings, err := remoteSchema.ValidateAndConvert(req.CredentialSubject, req.ValidationMode)
+	if err != nil {
+		log.Warn(ctx, "validating the credential subject", "err", err, "schema", req.Schema)
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCredentialSubject, err)
+	}
+	if err := remoteSchema.ValidateSubject(ctx, credentialSubject, false); err != nil {
+		log.Warn(ctx, "validating the credential subject", "err", err, "schema", req.Schema)
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCredentialSubject, err)
+	}
+	validationDone()
+	req.CredentialSubject, req.Warnings = credentialSubject, warnings
+
+	schema, err := schemaPkg.ParseSchema(schemaBytes)
+	if err != nil {
+		log.Error(ctx, "parsing schema", "err", err, "schema", req.Schema)
+		return nil, ErrLoadingSchema
+	}
 
 	jsonLdContext, ok := schema.Metadata.Uris["jsonLdContext"].(string)
 	if !ok {
@@ -129,7 +533,7 @@ func (c *claim) CreateCredential(ctx context.Context, req *ports.CreateClaimRequ
 		return nil, ErrJSONLdContext
 	}
 
-	vcID, err := uuid.NewUUID()
+	vcID, err := c.cfg.CredentialIDs.NewID()
 	if err != nil {
 		return nil, err
 	}
@@ -143,13 +547,15 @@ func (c *claim) CreateCredential(ctx context.Context, req *ports.CreateClaimRequ
 	credentialType := fmt.Sprintf("%s#%s", jsonLdContext, req.Type)
 	mtRootPostion := common.DefineMerklizedRootPosition(schema.Metadata, req.MerklizedRootPosition)
 
-	coreClaim, err := schemaPkg.Process(ctx, c.loaderFactory(req.Schema), credentialType, vc, &processor.CoreClaimOptions{
+	merklizationDone := timeIssuancePhase(ctx, domain.IssuancePhaseMerklization)
+	coreClaim, err := schemaPkg.Process(ctx, schemaBytes, credentialType, vc, &processor.CoreClaimOptions{
 		RevNonce:              nonce,
 		MerklizedRootPosition: mtRootPostion,
 		Version:               req.Version,
 		SubjectPosition:       req.SubjectPos,
 		Updatable:             false,
 	})
+	merklizationDone()
 	if err != nil {
 		log.Error(ctx, "credential subject attributes don't match the provided schema", "err", err)
 		if errors.Is(err, schemaPkg.ErrParseClaim) {
@@ -179,20 +585,20 @@ func (c *claim) CreateCredential(ctx context.Context, req *ports.CreateClaimRequ
 	claim.ID = vcID
 
 	if req.SignatureProof {
-		authClaim, err := c.GetAuthClaim(ctx, req.DID)
+		authClaim, err := c.signingAuthClaim(ctx, req.DID, req.SigningKeyID)
 		if err != nil {
 			log.Error(ctx, "cannot retrieve the auth claim", "err", err)
 			return nil, err
 		}
 
-		proof, err := c.identitySrv.SignClaimEntry(ctx, authClaim, coreClaim)
+		signingDone := timeIssuancePhase(ctx, domain.IssuancePhaseSigning)
+		proof, err := c.signClaimEntry(ctx, req, authClaim, coreClaim)
+		signingDone()
 		if err != nil {
 			log.Error(ctx, "cannot sign claim entry", "err", err)
 			return nil, err
 		}
 
-		proof.IssuerData.CredentialStatus = c.getRevocationSource(issuerDIDString, uint64(authClaim.RevNonce), req.SingleIssuer)
-
 		jsonSignatureProof, err := json.Marshal(proof)
 		if err != nil {
 			log.Error(ctx, "cannot encode the json signature proof", "err", err)
@@ -205,7 +611,7 @@ func (c *claim) CreateCredential(ctx context.Context, req *ports.CreateClaimRequ
 		}
 	}
 
-	err = claim.Data.Set(vc)
+	err = claim.Data.Set(schemaPkg.W3CCredential{W3CCredential: vc, DisplayMethod: req.DisplayMethod})
 	if err != nil {
 		log.Error(ctx, "cannot set the credential", "err", err)
 		return nil, err
@@ -223,7 +629,93 @@ func (c *claim) CreateCredential(ctx context.Context, req *ports.CreateClaimRequ
 }
 
 func (c *claim) Revoke(ctx context.Context, id core.DID, nonce uint64, description string) error {
-	return c.revoke(ctx, &id, nonce, description, c.storage.Pgx)
+	var revoked *domain.Claim
+	err := c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) (err error) {
+		revoked, err = c.revoke(ctx, &id, nonce, description, tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	c.publishRevoked(ctx, id, revoked)
+	return nil
+}
+
+// Reissue replaces a credential with a new one with the changed attributes. The replacement is saved and
+// the replaced credential revoked in the same transaction, so both succeed or none.
+// The holder and type attributes of the credential subject cannot be changed.
+func (c *claim) Reissue(ctx context.Context, req *ports.ReissueClaimRequest) (*domain.Claim, error) {
+	replaced, err := c.GetByID(ctx, req.DID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if replaced.Revoked {
+		return nil, ErrClaimRevoked
+	}
+
+	vc, err := replaced.GetVerifiableCredential()
+	if err != nil {
+		return nil, err
+	}
+	displayMethod, err := replaced.GetDisplayMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	credentialSubject := make(map[string]any, len(vc.CredentialSubject)+len(req.CredentialSubject))
+	for attr, value := range vc.CredentialSubject {
+		credentialSubject[attr] = value
+	}
+	for attr, value := range req.CredentialSubject {
+		if attr == "id" || attr == "type" {
+			continue
+		}
+		if value == nil {
+			delete(credentialSubject, attr)
+			continue
+		}
+		credentialSubject[attr] = value
+	}
+	delete(credentialSubject, "type")
+
+	expiration := vc.Expiration
+	if req.Expiration != nil {
+		expiration = req.Expiration
+	}
+
+	claimReq := ports.NewCreateClaimRequest(req.DID, replaced.SchemaURL, credentialSubject, expiration, vc.Type[len(vc.Type)-1], nil, nil, nil,
+		common.ToPointer(replaced.SignatureProof.Bytes != nil), common.ToPointer(replaced.MtProof), nil, req.SingleIssuer)
+	claimReq.DisplayMethod = displayMethod
+	claim, err := c.CreateCredential(ctx, claimReq)
+	if err != nil {
+		return nil, err
+	}
+	claim.ReplacesID = &replaced.ID
+
+	var revoked *domain.Claim
+	err = c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) (err error) {
+		// the replaced credential is revoked first, so it isn't superseded and doesn't count in the schema limit per subject
+		if revoked, err = c.revoke(ctx, req.DID, uint64(replaced.RevNonce), fmt.Sprintf("replaced by %s", claim.ID), tx); err != nil {
+			return err
+		}
+		_, err = c.SaveCredential(ctx, tx, *req.DID, claim)
+		return err
+	})
+	if err != nil {
+		log.Error(ctx, "reissuing credential", "err", err, "credential", replaced.ID.String())
+		return nil, err
+	}
+	c.publishRevoked(ctx, *req.DID, revoked)
+	c.RunPostIssuanceHooks(ctx, *req.DID, claim)
+
+	if claimReq.SignatureProof && !req.SkipNotification {
+		err = c.publisher.Publish(ctx, event.CreateCredentialEvent, &event.CreateCredential{CredentialIDs: []string{claim.ID.String()}, IssuerID: req.DID.String()})
+		if err != nil {
+			log.Error(ctx, "publish CreateCredentialEvent", "err", err.Error(), "credential", claim.ID.String())
+		}
+	}
+
+	return claim, nil
 }
 
 func (c *claim) RevokeAllFromConnection(ctx context.Context, connID uuid.UUID, issuerID core.DID) error {
@@ -232,20 +724,28 @@ func (c *claim) RevokeAllFromConnection(ctx context.Context, connID uuid.UUID, i
 		return err
 	}
 
-	return c.storage.Pgx.BeginFunc(ctx,
+	revoked := make([]*domain.Claim, 0, len(credentials))
+	err = c.storage.Pgx.BeginFunc(ctx,
 		func(tx pgx.Tx) error {
 			for _, credential := range credentials {
-				err := c.revoke(ctx, &issuerID, uint64(credential.RevNonce), "", tx)
+				claim, err := c.revoke(ctx, &issuerID, uint64(credential.RevNonce), "", tx)
 				if err != nil {
 					return err
 				}
+				revoked = append(revoked, claim)
 			}
 			return nil
 		})
+	if err != nil {
+		return err
+	}
+	c.publishRevoked(ctx, issuerID, revoked...)
+	return nil
 }
 
-func (c *claim) Delete(ctx context.Context, id uuid.UUID) error {
-	err := c.icRepo.Delete(ctx, c.storage.Pgx, id)
+// Delete deletes the claim of the issuer. The claims of other issuers are not found.
+func (c *claim) Delete(ctx context.Context, issuerDID core.DID, id uuid.UUID) error {
+	err := c.icRepo.Delete(ctx, c.storage.Pgx, issuerDID, id)
 	if err != nil {
 		if errors.Is(err, repositories.ErrClaimDoesNotExist) {
 			return ErrClaimNotFound
@@ -264,6 +764,33 @@ func (c *claim) GetByID(ctx context.Context, issID *core.DID, id uuid.UUID) (*do
 		}
 		return nil, err
 	}
+	if key, found := apiKeyFromContext(ctx); found && !key.AllowsIssuance(claim.SchemaType, claim.LinkID) {
+		return nil, ErrClaimNotFound
+	}
+
+	return claim, nil
+}
+
+// GetByIDAsOf returns the claim as it was at asOf. The claims issued later are not found and the claims revoked later
+// are returned as not revoked.
+func (c *claim) GetByIDAsOf(ctx context.Context, issID *core.DID, id uuid.UUID, asOf time.Time) (*domain.Claim, error) {
+	claim, err := c.GetByID(ctx, issID, id)
+	if err != nil {
+		return nil, err
+	}
+	vc, err := claim.GetVerifiableCredential()
+	if err != nil {
+		return nil, err
+	}
+	if vc.IssuanceDate != nil && vc.IssuanceDate.After(asOf) {
+		return nil, ErrClaimNotFound
+	}
+	revokedAt, err := c.icRepo.GetRevokedAt(ctx, c.storage.Pgx, issID, claim.RevNonce)
+	if err != nil {
+		log.Error(ctx, "loading claim revocation", "err", err, "id", id)
+		return nil, err
+	}
+	claim.Revoked = revokedAt != nil && !revokedAt.After(asOf)
 
 	return claim, nil
 }
@@ -280,9 +807,49 @@ func (c *claim) Agent(ctx context.Context, req *ports.AgentRequest) (*domain.Age
 		return nil, fmt.Errorf("cannot proceed with this identity, not found")
 	}
 
+	if c.cfg.AgentReplayWindow > 0 {
+		// a message without a creation time, or out of the window, would be forgotten before its replays arrive, so it
+		// is not recorded
+		if req.CreatedTime == nil {
+			log.Warn(ctx, "agent message without created_time", "type", req.Type)
+			return nil, ErrAgentMessageNoCreatedTime
+		}
+		now := time.Now()
+		if req.CreatedTime.Before(now.Add(-c.cfg.AgentReplayWindow)) || req.CreatedTime.After(now.Add(c.cfg.ClockSkew)) ||
+			req.ExpiresTime != nil && req.ExpiresTime.Before(now.Add(-c.cfg.ClockSkew)) {
+			log.Warn(ctx, "agent message outside the replay window", "created", req.CreatedTime, "expires", req.ExpiresTime)
+			return nil, ErrAgentMessageOutsideWindow
+		}
+		recorded, err := c.agentMessageRepository.Record(ctx, c.storage.Pgx, req.MessageHash, now.Add(c.cfg.AgentReplayWindow+c.cfg.ClockSkew))
+		if db.IsReadOnly(err) {
+			// the servers of a passive region read a replica, where the messages can't be recorded, so only the
+			// messages whose replays don't matter are served there
+			if !agentUnrecordedMessageTypes[req.Type] {
+				return nil, fmt.Errorf("%w: the %s messages can't be checked for replays", ErrRegionPassive, req.Type)
+			}
+			log.Debug(ctx, "agent message not recorded, the database only reads")
+			recorded, err = true, nil
+		}
+		if err != nil {
+			log.Error(ctx, "recording agent message", "err", err)
+			return nil, err
+		}
+		if !recorded {
+			agentReplays.Add(string(req.Type), 1)
+			return nil, ErrAgentMessageReplayed
+		}
+	}
+
 	return c.getAgentCredential(ctx, req) // at this point the type is already validated
 }
 
+// agentUnrecordedMessageTypes are the agent messages that the passive regions serve without recording them. Their
+// replays only read public data: the revocation status of a credential. A replayed fetch request would hand the
+// credential to whoever replays it, and mark the session of its link fetched.
+var agentUnrecordedMessageTypes = map[iden3comm.ProtocolMessage]bool{
+	protocol.RevocationStatusRequestMessageType: true,
+}
+
 func (c *claim) GetAuthClaim(ctx context.Context, did *core.DID) (*domain.Claim, error) {
 	authHash, err := core.AuthSchemaHash.MarshalText()
 	if err != nil {
@@ -291,8 +858,92 @@ func (c *claim) GetAuthClaim(ctx context.Context, did *core.DID) (*domain.Claim,
 	return c.icRepo.FindOneClaimBySchemaHash(ctx, c.storage.Pgx, did, string(authHash))
 }
 
+// GetSigningAuthClaims returns the auth claims of the signing keys of the identity that are not revoked, the ones
+// already in a published state first
+func (c *claim) GetSigningAuthClaims(ctx context.Context, did *core.DID) ([]*domain.Claim, error) {
+	authHash, err := core.AuthSchemaHash.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return c.icRepo.FindClaimsBySchemaHash(ctx, c.storage.Pgx, did, string(authHash))
+}
+
+// GetSecp256k1SigningAuthClaims returns the auth claims of the secp256k1 signing keys of the identity that are not
+// revoked, the ones already in a published state first
+func (c *claim) GetSecp256k1SigningAuthClaims(ctx context.Context, did *core.DID) ([]*domain.Claim, error) {
+	authHash, err := domain.AuthEthSchemaHash.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return c.icRepo.FindClaimsBySchemaHash(ctx, c.storage.Pgx, did, string(authHash))
+}
+
+// signingAuthClaim returns the auth claim of the key that signs a credential: the active key with keyID, or with
+// SigningKeyRoundRobin each active key in turn, and otherwise the auth claim of the identity. The secp256k1 keys only
+// sign the credentials that select them with keyID, as their signatures can't be used in the zero knowledge circuits.
+func (c *claim) signingAuthClaim(ctx context.Context, did *core.DID, keyID *string) (*domain.Claim, error) {
+	if keyID == nil && !c.cfg.SigningKeyRoundRobin {
+		return c.GetAuthClaim(ctx, did)
+	}
+	authClaims, err := c.GetSigningAuthClaims(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+	active := make([]*domain.Claim, 0, len(authClaims))
+	for _, authClaim := range authClaims {
+		if signingKeyStatus(authClaim) == domain.SigningKeyActive {
+			active = append(active, authClaim)
+		}
+	}
+	if keyID == nil {
+		if len(active) == 0 {
+			return c.GetAuthClaim(ctx, did)
+		}
+		return active[c.signingKeyTurn.Add(1)%uint64(len(active))], nil
+	}
+	secp256k1AuthClaims, err := c.GetSecp256k1SigningAuthClaims(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+	for _, authClaim := range secp256k1AuthClaims {
+		if signingKeyStatus(authClaim) == domain.SigningKeyActive {
+			active = append(active, authClaim)
+		}
+	}
+	for _, authClaim := range active {
+		authKeyID, err := c.identitySrv.GetKeyIDFromAuthClaim(ctx, authClaim)
+		if err != nil {
+			return nil, err
+		}
+		if authKeyID.ID == *keyID {
+			return authClaim, nil
+		}
+	}
+	return nil, ErrSigningKeyNotFound
+}
+
+// signClaimEntry signs the core claim with the key of the auth claim, in a BJJSignature2021 proof, or in an
+// EcdsaSecp256k1Signature2019 proof when the key is a secp256k1 one
+func (c *claim) signClaimEntry(ctx context.Context, req *ports.CreateClaimRequest, authClaim *domain.Claim, coreClaim *core.Claim) (any, error) {
+	credentialStatus := c.getRevocationSource(req.DID, uint64(authClaim.RevNonce), req.SingleIssuer)
+	if domain.IsAuthEthClaim(authClaim.CoreClaim.Get()) {
+		proof, err := c.identitySrv.SignClaimEntrySecp256k1(ctx, authClaim, coreClaim)
+		if err != nil {
+			return nil, err
+		}
+		proof.IssuerData.CredentialStatus = credentialStatus
+		return proof, nil
+	}
+	proof, err := c.identitySrv.SignClaimEntry(ctx, authClaim, coreClaim)
+	if err != nil {
+		return nil, err
+	}
+	proof.IssuerData.CredentialStatus = credentialStatus
+	return proof, nil
+}
+
 func (c *claim) GetAll(ctx context.Context, did core.DID, filter *ports.ClaimsFilter) ([]*domain.Claim, error) {
-	claims, err := c.icRepo.GetAllByIssuerID(ctx, c.storage.Pgx, did, filter)
+	claims, err := c.icRepo.GetAllByIssuerID(ctx, c.storage.Reader(), did, filter)
 	if err != nil {
 		if errors.Is(err, repositories.ErrClaimDoesNotExist) {
 			return nil, ErrClaimNotFound
@@ -303,6 +954,11 @@ func (c *claim) GetAll(ctx context.Context, did core.DID, filter *ports.ClaimsFi
 	return claims, nil
 }
 
+// Count returns the number of claims of the identity that match the filter, in every page
+func (c *claim) Count(ctx context.Context, did core.DID, filter *ports.ClaimsFilter) (int, error) {
+	return c.icRepo.CountByIssuerID(ctx, c.storage.Reader(), did, filter)
+}
+
 func (c *claim) GetRevocationStatus(ctx context.Context, issuerDID core.DID, nonce uint64) (*verifiable.RevocationStatus, error) {
 	rID := new(big.Int).SetUint64(nonce)
 	revocationStatus := &verifiable.RevocationStatus{}
@@ -432,18 +1088,19 @@ func (c *claim) UpdateClaimsMTPAndState(ctx context.Context, currentState *domai
 			return fmt.Errorf("can't marshal proof: %w", err)
 		}
 
-		var affected int64
 		err = claims[i].MTPProof.Set(jsonProof)
 		if err != nil {
 			return fmt.Errorf("failed set mtp proof: %w", err)
 		}
-		affected, err = c.icRepo.UpdateClaimMTP(ctx, c.storage.Pgx, &claims[i])
+	}
 
+	if len(claims) > 0 {
+		affected, err := c.icRepo.UpdateClaimMTPBatch(ctx, c.storage.Pgx, did, claims)
 		if err != nil {
 			return fmt.Errorf("can't update claim mtp:  %w", err)
 		}
-		if affected == 0 {
-			return fmt.Errorf("claim has not been updated %v", claims[i])
+		if affected != int64(len(claims)) {
+			return fmt.Errorf("only %d of %d claims have been updated", affected, len(claims))
 		}
 	}
 	_, err = c.identityStateRepository.UpdateState(ctx, c.storage.Pgx, currentState)
@@ -458,7 +1115,9 @@ func (c *claim) GetByStateIDWithMTPProof(ctx context.Context, did *core.DID, sta
 	return c.icRepo.GetByStateIDWithMTPProof(ctx, c.storage.Pgx, did, state)
 }
 
-func (c *claim) revoke(ctx context.Context, did *core.DID, nonce uint64, description string, pgx db.Querier) error {
+// revoke revokes the credential with the revocation nonce. It returns the credential when this revocation revoked it,
+// and nil when it was already revoked, erased or archived.
+func (c *claim) revoke(ctx context.Context, did *core.DID, nonce uint64, description string, tx pgx.Tx) (*domain.Claim, error) {
 	rID := new(big.Int).SetUint64(nonce)
 	revocation := domain.Revocation{
 		Identifier:  did.String(),
@@ -468,33 +1127,70 @@ func (c *claim) revoke(ctx context.Context, did *core.DID, nonce uint64, descrip
 		Description: description,
 	}
 
-	identityTrees, err := c.mtService.GetIdentityMerkleTrees(ctx, pgx, did)
+	identityTrees, err := c.mtService.GetIdentityMerkleTreesForUpdate(ctx, tx, did)
 	if err != nil {
-		return fmt.Errorf("error getting merkle trees: %w", err)
+		return nil, fmt.Errorf("error getting merkle trees: %w", err)
 	}
 
 	err = identityTrees.RevokeClaim(ctx, rID)
 	if err != nil {
-		return fmt.Errorf("error revoking the claim: %w", err)
+		return nil, fmt.Errorf("error revoking the claim: %w", err)
 	}
 
 	var claim *domain.Claim
-	claim, err = c.icRepo.GetByRevocationNonce(ctx, pgx, did, domain.RevNonceUint64(nonce))
+	claim, err = c.icRepo.GetByRevocationNonce(ctx, tx, did, domain.RevNonceUint64(nonce))
 
 	if err != nil {
 		if errors.Is(err, repositories.ErrClaimDoesNotExist) {
-			return err
+			// the subject data could have been erased, only the revocation nonce is kept in that case,
+			// or the claim could have been archived
+			err := c.icRepo.RevokeErased(ctx, tx, did, domain.RevNonceUint64(nonce))
+			if errors.Is(err, repositories.ErrClaimDoesNotExist) {
+				err = c.icRepo.RevokeArchived(ctx, tx, did, domain.RevNonceUint64(nonce))
+			}
+			if err != nil {
+				return nil, err
+			}
+			return nil, c.icRepo.RevokeNonce(ctx, tx, &revocation)
 		}
-		return fmt.Errorf("error getting the claim by revocation nonce: %w", err)
+		return nil, fmt.Errorf("error getting the claim by revocation nonce: %w", err)
 	}
 
+	alreadyRevoked := claim.Revoked
 	claim.Revoked = true
-	_, err = c.icRepo.Save(ctx, pgx, claim)
+	_, err = c.icRepo.Save(ctx, tx, claim)
 	if err != nil {
-		return fmt.Errorf("error saving the claim: %w", err)
+		return nil, fmt.Errorf("error saving the claim: %w", err)
+	}
+	if alreadyRevoked {
+		return nil, c.icRepo.RevokeNonce(ctx, tx, &revocation)
+	}
+	if err := c.schemaUsageRepository.AddRevoked(ctx, tx, *did, claim.SchemaURL, time.Now()); err != nil {
+		return nil, fmt.Errorf("error counting the schema usage: %w", err)
 	}
 
-	return c.icRepo.RevokeNonce(ctx, pgx, &revocation)
+	if err := c.icRepo.RevokeNonce(ctx, tx, &revocation); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// publishRevoked publishes a RevokeCredentialEvent with the credentials revoked by a committed transaction.
+// The nil ones, that weren't revoked by it, are skipped.
+func (c *claim) publishRevoked(ctx context.Context, issuerDID core.DID, revoked ...*domain.Claim) {
+	ids := make([]string, 0, len(revoked))
+	for _, claim := range revoked {
+		if claim != nil {
+			ids = append(ids, claim.ID.String())
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	err := c.publisher.Publish(ctx, event.RevokeCredentialEvent, &event.RevokeCredential{CredentialIDs: ids, IssuerID: issuerDID.String()})
+	if err != nil {
+		log.Error(ctx, "publish RevokeCredentialEvent", "err", err.Error(), "credentials", ids)
+	}
 }
 
 func (c *claim) getAgentCredential(ctx context.Context, basicMessage *ports.AgentRequest) (*domain.Agent, error) {
@@ -523,23 +1219,35 @@ func (c *claim) getAgentCredential(ctx context.Context, basicMessage *ports.Agen
 		return nil, err
 	}
 
-	vc, err := schemaPkg.FromClaimModelToW3CCredential(*claim)
+	vc, err := schemaPkg.FromClaimModelToW3CCredentialWithDisplay(*claim)
 	if err != nil {
 		log.Error(ctx, "creating W3 credential", "err", err)
 		return nil, fmt.Errorf("failed to convert claim to  w3cCredential: %w", err)
 	}
 
+	if claim.LinkID != nil {
+		// the servers of a passive region read a replica, where the link sessions can't be updated
+		if err := c.linkSessionRepository.MarkFetched(ctx, c.storage.Pgx, claim.ID); err != nil && !db.IsReadOnly(err) {
+			log.Error(ctx, "marking the link session fetched", "err", err, "claimID", claim.ID)
+		}
+	}
+
 	return &domain.Agent{
 		ID:       uuid.NewString(),
 		Typ:      packers.MediaTypePlainMessage,
 		Type:     protocol.CredentialIssuanceResponseMessageType,
 		ThreadID: basicMessage.ThreadID,
-		Body:     protocol.IssuanceMessageBody{Credential: *vc},
+		Body:     credentialIssuanceBody{Credential: *vc},
 		From:     basicMessage.IssuerDID.String(),
 		To:       basicMessage.UserDID.String(),
 	}, err
 }
 
+// credentialIssuanceBody is protocol.IssuanceMessageBody with the display method of the credential
+type credentialIssuanceBody struct {
+	Credential schemaPkg.W3CCredential `json:"credential"`
+}
+
 func (c *claim) createVC(claimReq *ports.CreateClaimRequest, vcID uuid.UUID, jsonLdContext string, nonce uint64) (verifiable.W3CCredential, error) {
 	vCredential, err := c.newVerifiableCredential(claimReq, vcID, jsonLdContext, nonce) // create vc credential
 	if err != nil {
@@ -549,10 +1257,86 @@ func (c *claim) createVC(claimReq *ports.CreateClaimRequest, vcID uuid.UUID, jso
 	return vCredential, nil
 }
 
+// defaultProofTypes returns the proof types of a request that does not set them: the schema defaults of the issuer,
+// otherwise the issuer defaults.
+func (c *claim) defaultProofTypes(ctx context.Context, req *ports.CreateClaimRequest) (domain.ProofTypes, error) {
+	proofTypes, err := repositories.NewSchema(*c.storage).GetDefaultProofTypesByURL(ctx, *req.DID, req.Schema)
+	if err != nil {
+		return nil, err
+	}
+	if proofTypes != nil {
+		return proofTypes, nil
+	}
+	return c.identitySrv.GetDefaultProofTypes(ctx, *req.DID)
+}
+
+// evaluatePolicy asks the policy hook about the request. A denial fails with ErrIssuanceDenied, and the changes of
+// an approval are applied to a copy of the credential subject, because links share theirs with the request.
+func (c *claim) evaluatePolicy(ctx context.Context, req *ports.CreateClaimRequest) error {
+	if c.cfg.PolicyHook == nil {
+		return nil
+	}
+	policyReq := policy.Request{
+		Schema:            req.Schema,
+		Type:              req.Type,
+		CredentialSubject: req.CredentialSubject,
+		Expiration:        req.Expiration,
+	}
+	if req.DID != nil {
+		policyReq.Issuer = req.DID.String()
+	}
+	if subject, ok := req.CredentialSubject["id"].(string); ok {
+		policyReq.Subject = subject
+	}
+	if req.LinkID != nil {
+		policyReq.LinkID = req.LinkID.String()
+	}
+
+	decision, err := c.cfg.PolicyHook.Evaluate(ctx, policyReq)
+	if err != nil {
+		if c.cfg.PolicyFailOpen {
+			log.Warn(ctx, "evaluating the issuance policy, issuing without it", "err", err, "schema", req.Schema)
+			return nil
+		}
+		log.Error(ctx, "evaluating the issuance policy", "err", err, "schema", req.Schema)
+		return fmt.Errorf("%w: %s", ErrIssuancePolicyUnavailable, err)
+	}
+	if decision.Decision == policy.Deny {
+		log.Info(ctx, "the issuance policy denied the credential", "reason", decision.Reason, "schema", req.Schema)
+		if decision.Reason == "" {
+			return ErrIssuanceDenied
+		}
+		return fmt.Errorf("%w: %s", ErrIssuanceDenied, decision.Reason)
+	}
+
+	if decision.CredentialSubject != nil {
+		credentialSubject := make(map[string]any, len(req.CredentialSubject)+len(decision.CredentialSubject))
+		for attr, value := range req.CredentialSubject {
+			credentialSubject[attr] = value
+		}
+		for attr, value := range decision.CredentialSubject {
+			if value == nil {
+				delete(credentialSubject, attr)
+				continue
+			}
+			credentialSubject[attr] = value
+		}
+		req.CredentialSubject = credentialSubject
+	}
+	if decision.Expiration != nil {
+		req.Expiration = decision.Expiration
+		req.ExpirationPolicy = nil
+	}
+	return nil
+}
+
 func (c *claim) guardCreateClaimRequest(req *ports.CreateClaimRequest) error {
 	if _, err := url.ParseRequestURI(req.Schema); err != nil {
 		return ErrMalformedURL
 	}
+	if req.DisplayMethod != nil {
+		return req.DisplayMethod.Validate()
+	}
 	return nil
 }
 
@@ -572,14 +1356,18 @@ func (c *claim) newVerifiableCredential(claimReq *ports.CreateClaimRequest, vcID
 
 	credentialSubject["type"] = claimReq.Type
 
-	cs := c.getRevocationSource(claimReq.DID.String(), nonce, claimReq.SingleIssuer)
+	cs := c.getRevocationSource(claimReq.DID, nonce, claimReq.SingleIssuer)
 
 	issuanceDate := time.Now()
+	expiration := claimReq.Expiration
+	if expiration == nil && claimReq.ExpirationPolicy != nil {
+		expiration = common.ToPointer(claimReq.ExpirationPolicy.ExpiresAt(issuanceDate))
+	}
 	return verifiable.W3CCredential{
 		ID:                c.buildCredentialID(*claimReq.DID, vcID, claimReq.SingleIssuer),
 		Context:           credentialCtx,
 		Type:              credentialType,
-		Expiration:        claimReq.Expiration,
+		Expiration:        expiration,
 		IssuanceDate:      &issuanceDate,
 		CredentialSubject: credentialSubject,
 		Issuer:            claimReq.DID.String(),
@@ -591,40 +1379,35 @@ func (c *claim) newVerifiableCredential(claimReq *ports.CreateClaimRequest, vcID
 	}, nil
 }
 
-func (c *claim) getRevocationSource(issuerDID string, nonce uint64, singleIssuer bool) interface{} {
+func (c *claim) getRevocationSource(did *core.DID, nonce uint64, singleIssuer bool) interface{} {
+	issuerDID := did.String()
 	if c.cfg.RHSEnabled {
 		return &verifiable.RHSCredentialStatus{
-			ID:              fmt.Sprintf("%s/node", strings.TrimSuffix(c.cfg.RHSUrl, "/")),
+			ID:              fmt.Sprintf("%s/node", strings.TrimSuffix(c.rhsURL(did), "/")),
 			Type:            verifiable.Iden3ReverseSparseMerkleTreeProof,
 			RevocationNonce: nonce,
 			StatusIssuer: &verifiable.CredentialStatus{
-				ID:              buildRevocationURL(c.cfg.Host, issuerDID, nonce, singleIssuer),
+				ID:              c.cfg.CredentialIDs.StatusID(c.cfg.Host, issuerDID, nonce, singleIssuer),
 				Type:            verifiable.SparseMerkleTreeProof,
 				RevocationNonce: nonce,
 			},
 		}
 	}
 	return &verifiable.CredentialStatus{
-		ID:              buildRevocationURL(c.cfg.Host, issuerDID, nonce, singleIssuer),
+		ID:              c.cfg.CredentialIDs.StatusID(c.cfg.Host, issuerDID, nonce, singleIssuer),
 		Type:            verifiable.SparseMerkleTreeProof,
 		RevocationNonce: nonce,
 	}
 }
 
-func (c *claim) buildCredentialID(issuerDID core.DID, credID uuid.UUID, singleIssuer bool) string {
-	if singleIssuer {
-		return fmt.Sprintf("%s/v1/credentials/%s", strings.TrimSuffix(c.cfg.Host, "/"), credID.String())
+// rhsURL returns the reverse hash service of the network of the issuer
+func (c *claim) rhsURL(issuerDID *core.DID) string {
+	if rhsURL, ok := c.cfg.NetworkRHSUrls[common.DIDNetwork(issuerDID)]; ok && rhsURL != "" {
+		return rhsURL
 	}
-
-	return fmt.Sprintf("%s/v1/%s/claims/%s", strings.TrimSuffix(c.cfg.Host, "/"), issuerDID.String(), credID.String())
+	return c.cfg.RHSUrl
 }
 
-func buildRevocationURL(host, issuerDID string, nonce uint64, singleIssuer bool) string {
-	if singleIssuer {
-		return fmt.Sprintf("%s/v1/credentials/revocation/status/%d",
-			host, nonce)
-	}
-
-	return fmt.Sprintf("%s/v1/%s/claims/revocation/status/%d",
-		host, url.QueryEscape(issuerDID), nonce)
+func (c *claim) buildCredentialID(issuerDID core.DID, credID uuid.UUID, singleIssuer bool) string {
+	return c.cfg.CredentialIDs.CredentialID(c.cfg.Host, issuerDID.String(), credID, singleIssuer)
 }
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/processor"
+	"github.com/iden3/go-schema-processor/utils"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/credentials/credentialid"
+	"github.com/polygonid/sh-id-platform/pkg/credentials/signature/circuit/signer"
+	schemaPkg "github.com/polygonid/sh-id-platform/pkg/schema"
+)
+
+var (
+	// ErrCredentialNotVerified is returned when the proofs of an imported credential don't match the issuer keys or trees
+	ErrCredentialNotVerified = errors.New("the credential cannot be verified")
+	// ErrClaimAlreadyExists is returned when an imported credential id or revocation nonce is already in use
+	ErrClaimAlreadyExists = errors.New("the credential already exists")
+)
+
+// Import registers a credential issued by the identity in another deployment, so it can be revoked and its
+// status served as if it was issued by this node. Before saving it:
+//   - the core claim of every proof must be the one built from the credential and its schema
+//   - the signature proof must be made with an auth claim of the issuer that is in its claims tree and not revoked
+//   - the claim of a merkle tree proof must be in the issuer claims tree
+//
+// The credential keeps its id and revocation nonce. The id must end with the UUID or the ULID it is kept by, like the
+// ids the nodes issue. Claims that are not in the claims tree yet are added on the next state publication, like new
+// ones.
+func (c *claim) Import(ctx context.Context, did core.DID, credential verifiable.W3CCredential) (*domain.Claim, error) {
+	if credential.Issuer != did.String() {
+		return nil, fmt.Errorf("%w: the issuer must be %s", ErrInvalidCredential, did.String())
+	}
+	exists, err := c.identitySrv.Exists(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: the issuer is not an identity of this node", ErrInvalidCredential)
+	}
+	if credential.CredentialSchema.ID == "" || credential.CredentialStatus == nil || len(credential.Type) == 0 {
+		return nil, fmt.Errorf("%w: credentialSchema, credentialStatus and type are required", ErrInvalidCredential)
+	}
+	id, err := importedCredentialID(credential.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var signatureProof *verifiable.BJJSignatureProof2021
+	var mtpProof *verifiable.Iden3SparseMerkleTreeProof
+	for _, proof := range credential.Proof {
+		switch p := proof.(type) {
+		case *verifiable.BJJSignatureProof2021:
+			signatureProof = p
+		case *verifiable.Iden3SparseMerkleTreeProof:
+			mtpProof = p
+		}
+	}
+	if signatureProof == nil && mtpProof == nil {
+		return nil, fmt.Errorf("%w: the credential has no signature or merkle tree proof", ErrInvalidCredential)
+	}
+
+	coreClaim, err := importedCoreClaim(signatureProof, mtpProof)
+	if err != nil {
+		return nil, err
+	}
+
+	credential.Proof = nil
+	credentialType, err := c.verifyCoreClaim(ctx, credential, coreClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	claim, err := domain.FromClaimer(coreClaim, credential.CredentialSchema.ID, credentialType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCredential, err)
+	}
+	issuerDIDString := did.String()
+	claim.Identifier = &issuerDIDString
+	claim.Issuer = issuerDIDString
+	claim.ID = id
+	claim.MtProof = mtpProof != nil
+	if err := claim.Data.Set(schemaPkg.W3CCredential{W3CCredential: credential}); err != nil {
+		return nil, err
+	}
+	if err := claim.CredentialStatus.Set(credential.CredentialStatus); err != nil {
+		return nil, err
+	}
+	if signatureProof != nil {
+		if err := claim.SignatureProof.Set(signatureProof); err != nil {
+			return nil, err
+		}
+	}
+	if mtpProof != nil {
+		if err := claim.MTPProof.Set(mtpProof); err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := c.guardImportedClaim(ctx, tx, &did, claim); err != nil {
+			return err
+		}
+
+		iTrees, err := c.mtService.GetIdentityMerkleTreesForUpdate(ctx, tx, &did)
+		if err != nil {
+			return err
+		}
+
+		if signatureProof != nil {
+			if err := verifyImportedSignature(ctx, iTrees, coreClaim, signatureProof); err != nil {
+				return err
+			}
+		}
+
+		inClaimsTree, err := claimInTree(ctx, iTrees, coreClaim)
+		if err != nil {
+			return err
+		}
+		if mtpProof != nil && !inClaimsTree {
+			return fmt.Errorf("%w: the claim is not in the issuer claims tree", ErrCredentialNotVerified)
+		}
+		if inClaimsTree {
+			// the claim must not be added to the tree again on the next state publication
+			state, err := c.identityStateRepository.GetLatestStateByIdentifier(ctx, tx, &did)
+			if err != nil {
+				return err
+			}
+			claim.IdentityState = state.State
+		}
+
+		claim.Revoked, err = nonceRevoked(ctx, iTrees, coreClaim.GetRevocationNonce())
+		if err != nil {
+			return err
+		}
+
+		claim.ID, err = c.icRepo.Save(ctx, tx, claim)
+		return err
+	})
+	if err != nil {
+		log.Warn(ctx, "importing credential", "err", err, "issuer", did.String(), "id", credential.ID)
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// importedCoreClaim returns the core claim of the proofs, which must be the same in all of them
+func importedCoreClaim(signatureProof *verifiable.BJJSignatureProof2021, mtpProof *verifiable.Iden3SparseMerkleTreeProof) (*core.Claim, error) {
+	var coreClaimHex string
+	if signatureProof != nil {
+		coreClaimHex = signatureProof.CoreClaim
+	}
+	if mtpProof != nil {
+		if coreClaimHex != "" && coreClaimHex != mtpProof.CoreClaim {
+			return nil, fmt.Errorf("%w: the proofs are for different claims", ErrInvalidCredential)
+		}
+		coreClaimHex = mtpProof.CoreClaim
+	}
+
+	coreClaim := &core.Claim{}
+	if err := coreClaim.FromHex(coreClaimHex); err != nil {
+		return nil, fmt.Errorf("%w: invalid coreClaim: %s", ErrInvalidCredential, err)
+	}
+	return coreClaim, nil
+}
+
+// verifyCoreClaim builds the core claim from the credential and its schema, as on issuance, and checks it is
+// the claim of the proofs. It returns the credential type.
+func (c *claim) verifyCoreClaim(ctx context.Context, credential verifiable.W3CCredential, coreClaim *core.Claim) (string, error) {
+	schemaBytes, _, err := c.loaderFactory(credential.CredentialSchema.ID).Load(ctx)
+	if err != nil {
+		log.Error(ctx, "loading schema", "err", err, "schema", credential.CredentialSchema.ID)
+		return "", ErrLoadingSchema
+	}
+	schema, err := schemaPkg.ParseSchema(schemaBytes)
+	if err != nil {
+		return "", ErrLoadingSchema
+	}
+	jsonLdContext, ok := schema.Metadata.Uris["jsonLdContext"].(string)
+	if !ok {
+		return "", ErrJSONLdContext
+	}
+	credentialType := fmt.Sprintf("%s#%s", jsonLdContext, credential.Type[len(credential.Type)-1])
+
+	options, err := coreClaimOptions(coreClaim)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidCredential, err)
+	}
+	builtClaim, err := schemaPkg.Process(ctx, schemaBytes, credentialType, credential, options)
+	if err != nil {
+		if errors.Is(err, schemaPkg.ErrValidateData) {
+			return "", ErrInvalidCredentialSubject
+		}
+		return "", ErrParseClaim
+	}
+
+	hi, hv, err := coreClaim.HiHv()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidCredential, err)
+	}
+	builtHi, builtHv, err := builtClaim.HiHv()
+	if err != nil {
+		return "", err
+	}
+	if hi.Cmp(builtHi) != 0 || hv.Cmp(builtHv) != 0 {
+		return "", fmt.Errorf("%w: the proofs are not for the credential content", ErrCredentialNotVerified)
+	}
+	return credentialType, nil
+}
+
+// coreClaimOptions returns the options to build a core claim like the given one
+func coreClaimOptions(coreClaim *core.Claim) (*processor.CoreClaimOptions, error) {
+	options := &processor.CoreClaimOptions{
+		RevNonce:  coreClaim.GetRevocationNonce(),
+		Version:   coreClaim.GetVersion(),
+		Updatable: coreClaim.GetFlagUpdatable(),
+	}
+
+	subjectPosition, err := coreClaim.GetIDPosition()
+	if err != nil {
+		return nil, err
+	}
+	switch subjectPosition {
+	case core.IDPositionIndex:
+		options.SubjectPosition = utils.SubjectPositionIndex
+	case core.IDPositionValue:
+		options.SubjectPosition = utils.SubjectPositionValue
+	}
+
+	merklizedPosition, err := coreClaim.GetMerklizedPosition()
+	if err != nil {
+		return nil, err
+	}
+	switch merklizedPosition {
+	case core.MerklizedRootPositionIndex:
+		options.MerklizedRootPosition = utils.MerklizedRootPositionIndex
+	case core.MerklizedRootPositionValue:
+		options.MerklizedRootPosition = utils.MerklizedRootPositionValue
+	}
+	return options, nil
+}
+
+// guardImportedClaim checks that neither the id nor the revocation nonce of the claim are in use
+func (c *claim) guardImportedClaim(ctx context.Context, tx pgx.Tx, did *core.DID, claim *domain.Claim) error {
+	_, err := c.icRepo.GetByIdAndIssuer(ctx, tx, did, claim.ID)
+	if err == nil {
+		return fmt.Errorf("%w: id %s", ErrClaimAlreadyExists, claim.ID)
+	}
+	if !errors.Is(err, repositories.ErrClaimDoesNotExist) {
+		return err
+	}
+
+	_, err = c.icRepo.GetByRevocationNonce(ctx, tx, did, claim.RevNonce)
+	if err == nil {
+		return fmt.Errorf("%w: revocation nonce %d", ErrClaimAlreadyExists, claim.RevNonce)
+	}
+	if !errors.Is(err, repositories.ErrClaimDoesNotExist) {
+		return err
+	}
+	return nil
+}
+
+// verifyImportedSignature checks the signature of the claim and that the auth claim that signed it is in the
+// issuer claims tree and not revoked
+func verifyImportedSignature(ctx context.Context, iTrees *domain.IdentityMerkleTrees, coreClaim *core.Claim, proof *verifiable.BJJSignatureProof2021) error {
+	authClaim := &core.Claim{}
+	if err := authClaim.FromHex(proof.IssuerData.AuthCoreClaim); err != nil {
+		return fmt.Errorf("%w: invalid authCoreClaim: %s", ErrInvalidCredential, err)
+	}
+	if err := signer.Verify(coreClaim, authClaim, proof.Signature); err != nil {
+		return fmt.Errorf("%w: %s", ErrCredentialNotVerified, err)
+	}
+
+	inClaimsTree, err := claimInTree(ctx, iTrees, authClaim)
+	if err != nil {
+		return err
+	}
+	if !inClaimsTree {
+		return fmt.Errorf("%w: the signing auth claim is not in the issuer claims tree", ErrCredentialNotVerified)
+	}
+	revoked, err := nonceRevoked(ctx, iTrees, authClaim.GetRevocationNonce())
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return fmt.Errorf("%w: the signing auth claim is revoked", ErrCredentialNotVerified)
+	}
+	return nil
+}
+
+// claimInTree tells whether the claim is in the claims tree with the same value
+func claimInTree(ctx context.Context, iTrees *domain.IdentityMerkleTrees, coreClaim *core.Claim) (bool, error) {
+	claimsTree, err := iTrees.ClaimsTree()
+	if err != nil {
+		return false, err
+	}
+	hi, hv, err := coreClaim.HiHv()
+	if err != nil {
+		return false, err
+	}
+	proof, value, err := claimsTree.GenerateProof(ctx, hi, nil)
+	if err != nil {
+		return false, err
+	}
+	return proof.Existence && value.Cmp(hv) == 0, nil
+}
+
+// nonceRevoked tells whether the revocation nonce is in the revocation tree
+func nonceRevoked(ctx context.Context, iTrees *domain.IdentityMerkleTrees, nonce uint64) (bool, error) {
+	revocationsTree, err := iTrees.RevsTree()
+	if err != nil {
+		return false, err
+	}
+	proof, _, err := revocationsTree.GenerateProof(ctx, new(big.Int).SetUint64(nonce), nil)
+	if err != nil {
+		return false, err
+	}
+	return proof.Existence, nil
+}
+
+// importedCredentialID returns the UUID or the ULID at the end of the credential id, after its last / or :, the id
+// the node keeps the credential by
+func importedCredentialID(credentialID string) (uuid.UUID, error) {
+	id, err := credentialid.Parse(credentialID[strings.LastIndexAny(credentialID, "/:")+1:])
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: the id <%s> doesn't end with a UUID or an ULID", ErrInvalidCredential, credentialID)
+	}
+	return id, nil
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// claimsIngestBatchSize is the maximum of queued requests of a stream a worker saves in one transaction
+const claimsIngestBatchSize = 50
+
+type claimsIngestItem struct {
+	ctx  context.Context
+	req  *ports.CreateClaimRequest
+	done func(*domain.Claim, error)
+}
+
+type claimsIngest struct {
+	claimsService ports.ClaimsService
+	workers       int
+	queue         chan claimsIngestItem
+}
+
+// NewClaimsIngest returns the service that issues the streamed credential requests with workers goroutines. The queue
+// holds depth requests, and the streams wait to submit more while it is full, so the clients are slowed down to the
+// pace of the issuance. Each worker takes the requests queued at once and saves the ones of the same stream in a batch.
+func NewClaimsIngest(claimsService ports.ClaimsService, workers int, depth int) ports.ClaimsIngestService {
+	return &claimsIngest{
+		claimsService: claimsService,
+		workers:       workers,
+		queue:         make(chan claimsIngestItem, depth),
+	}
+}
+
+// Submit queues the request, waiting while the queue is full until ctx is done
+func (c *claimsIngest) Submit(ctx context.Context, req *ports.CreateClaimRequest, done func(*domain.Claim, error)) error {
+	select {
+	case c.queue <- claimsIngestItem{ctx: ctx, req: req, done: done}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Depth returns the number of queued requests
+func (c *claimsIngest) Depth() int {
+	return len(c.queue)
+}
+
+// Run issues the queued requests until ctx is done
+func (c *claimsIngest) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < c.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item := <-c.queue:
+					c.issue(c.batch(item))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// batch returns item with the requests queued after it, up to claimsIngestBatchSize, without waiting for more
+func (c *claimsIngest) batch(item claimsIngestItem) []claimsIngestItem {
+	items := []claimsIngestItem{item}
+	for len(items) < claimsIngestBatchSize {
+		select {
+		case next := <-c.queue:
+			items = append(items, next)
+		default:
+			return items
+		}
+	}
+	return items
+}
+
+// issue saves the claims of the items of each stream in a batch, skipping the items whose stream is gone
+func (c *claimsIngest) issue(items []claimsIngestItem) {
+	var streams []context.Context
+	byStream := map[context.Context][]claimsIngestItem{}
+	for _, item := range items {
+		if err := item.ctx.Err(); err != nil {
+			item.done(nil, err)
+			continue
+		}
+		if _, found := byStream[item.ctx]; !found {
+			streams = append(streams, item.ctx)
+		}
+		byStream[item.ctx] = append(byStream[item.ctx], item)
+	}
+	for _, ctx := range streams {
+		stream := byStream[ctx]
+		reqs := make([]*ports.CreateClaimRequest, len(stream))
+		for i := range stream {
+			reqs[i] = stream[i].req
+		}
+		claims, errs := c.claimsService.SaveBatch(ctx, reqs)
+		for i := range stream {
+			stream[i].done(claims[i], errs[i])
+		}
+	}
+}
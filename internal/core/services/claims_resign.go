@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// ResignDrifted finds the signed credentials of the issuer that are not revoked and whose core claim, built again from
+// the credential content with the current JSON-LD contexts, is not the signed one anymore. This happens when a remote
+// context changes after the issuance, and the holders cannot prove them since.
+// Unless it is a dry run, every drifted credential is reissued with the same content, that builds and signs the core
+// claim with the current contexts, and revoked. The holders are offered the replacements like any reissued credential.
+// A credential that cannot be built with the current contexts is reported with the error, and not replaced.
+func (c *claim) ResignDrifted(ctx context.Context, req *ports.ResignDriftedRequest) (*domain.ContextDriftReport, error) {
+	filter := &ports.ClaimsFilter{
+		Revoked:    common.ToPointer(false),
+		SchemaType: req.SchemaType,
+		Proofs:     []verifiable.ProofType{verifiable.BJJSignatureProofType},
+		PII:        true,
+	}
+	// read from the primary, the credentials are replaced after the check
+	claims, err := c.icRepo.GetAllByIssuerID(ctx, c.storage.Pgx, *req.DID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ContextDriftReport{Drifted: []domain.ContextDrift{}}
+	for _, claim := range claims {
+		drifted, err := c.contextDrifted(ctx, claim)
+		if err != nil {
+			return nil, fmt.Errorf("checking credential %s: %w", claim.ID, err)
+		}
+		report.Checked++
+		if drifted == nil {
+			continue
+		}
+		if drifted.Error == "" && !req.DryRun {
+			replacement, err := c.Reissue(ctx, &ports.ReissueClaimRequest{DID: req.DID, ID: claim.ID, SingleIssuer: req.SingleIssuer})
+			if err != nil {
+				log.Warn(ctx, "re-signing drifted credential", "err", err, "credential", claim.ID.String())
+				drifted.Error = err.Error()
+			} else {
+				drifted.ReplacedBy = &replacement.ID
+				log.Info(ctx, "drifted credential re-signed", "credential", claim.ID.String(), "replacedBy", replacement.ID.String())
+			}
+		}
+		report.Drifted = append(report.Drifted, *drifted)
+	}
+	return report, nil
+}
+
+// contextDrifted returns the drift of the claim, or nil if its core claim is still the one built from its content.
+// It fails when the schema or the contexts of the claim cannot be loaded.
+func (c *claim) contextDrifted(ctx context.Context, claim *domain.Claim) (*domain.ContextDrift, error) {
+	credential, err := claim.GetVerifiableCredential()
+	if err != nil {
+		return nil, err
+	}
+	credential.Proof = nil
+	coreClaim := core.Claim(claim.CoreClaim)
+
+	_, err = c.verifyCoreClaim(ctx, credential, &coreClaim)
+	switch {
+	case err == nil:
+		return nil, nil
+	case errors.Is(err, ErrCredentialNotVerified):
+		return &domain.ContextDrift{CredentialID: claim.ID, SchemaURL: claim.SchemaURL}, nil
+	case errors.Is(err, ErrInvalidCredentialSubject), errors.Is(err, ErrParseClaim), errors.Is(err, ErrInvalidCredential):
+		// the credential content doesn't fit the current contexts, so it cannot be signed again as it is
+		return &domain.ContextDrift{
+			CredentialID: claim.ID,
+			SchemaURL:    claim.SchemaURL,
+			Error:        fmt.Sprintf("the credential cannot be built with the current contexts: %s", err),
+		}, nil
+	default:
+		return nil, err
+	}
+}
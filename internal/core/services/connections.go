@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
@@ -71,12 +72,23 @@ func (c *connection) GetByUserID(ctx context.Context, issuerDID core.DID, userID
 	return conn, nil
 }
 
+// Seen refreshes the last seen time of the connection between the issuer and the user, creating it if the user
+// contacts the issuer for the first time without authenticating, e.g. when fetching an offered credential.
+func (c *connection) Seen(ctx context.Context, issuerDID core.DID, userDID core.DID) error {
+	_, err := c.connRepo.Seen(ctx, c.storage.Pgx, issuerDID, userDID, time.Now())
+	if db.IsReadOnly(err) {
+		// the servers of a passive region read a replica, where the connections are not updated
+		return nil
+	}
+	return err
+}
+
 func (c *connection) GetAllByIssuerID(ctx context.Context, issuerDID core.DID, query string, withCredentials bool) ([]*domain.Connection, error) {
 	if withCredentials {
-		return c.connRepo.GetAllWithCredentialsByIssuerID(ctx, c.storage.Pgx, issuerDID, query)
+		return c.connRepo.GetAllWithCredentialsByIssuerID(ctx, c.storage.Reader(), issuerDID, query)
 	}
 
-	return c.connRepo.GetAllByIssuerID(ctx, c.storage.Pgx, issuerDID, query)
+	return c.connRepo.GetAllByIssuerID(ctx, c.storage.Reader(), issuerDID, query)
 }
 
 func (c *connection) delete(ctx context.Context, id uuid.UUID, issuerDID core.DID, pgx db.Querier) error {
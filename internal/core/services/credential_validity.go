@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+	"github.com/polygonid/sh-id-platform/pkg/trustregistry"
+)
+
+// ErrInvalidCredential is returned when the credential issuer or status cannot be read
+var ErrInvalidCredential = errors.New("invalid credential")
+
+type credentialValidity struct {
+	identityService   ports.IdentityService
+	claimsService     ports.ClaimsService
+	revocationService ports.RevocationService
+	trustRegistry     trustregistry.Registry
+	metering          ports.MeteringService
+	clock             clock.Clock
+}
+
+// NewCredentialValidity returns a service that checks the revocation and expiration of credentials issued by
+// this node or by any other iden3 issuer. When trustRegistry is not nil it is also asked whether the issuer is
+// accredited for the credential type. When metering is not nil the checks of the credentials of the identities of the
+// node are metered as verifications. clk is the time source of the expiration check, the system clock when nil.
+func NewCredentialValidity(identityService ports.IdentityService, claimsService ports.ClaimsService, revocationService ports.RevocationService, trustRegistry trustregistry.Registry, metering ports.MeteringService, clk clock.Clock) ports.CredentialValidityService {
+	return &credentialValidity{
+		identityService:   identityService,
+		claimsService:     claimsService,
+		revocationService: revocationService,
+		trustRegistry:     trustRegistry,
+		metering:          metering,
+		clock:             clock.OrSystem(clk),
+	}
+}
+
+// Check resolves the credential status and expiration. The revocation status of credentials issued by identities
+// of this node is read from the database, for the others it is fetched from the credential status url.
+// A revocation status that cannot be resolved is not an error, it is reported in the result reason, without the
+// error of the fetch, which is only logged.
+func (v *credentialValidity) Check(ctx context.Context, credential verifiable.W3CCredential) (*domain.CredentialValidity, error) {
+	issuerDID, err := core.ParseDID(credential.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: the issuer must be a did", ErrInvalidCredential)
+	}
+
+	status, err := parseCredentialStatus(credential.CredentialStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	validity := &domain.CredentialValidity{
+		Issuer:               credential.Issuer,
+		CredentialStatusType: string(status.Type),
+		RevocationNonce:      status.RevocationNonce,
+		ExpirationDate:       credential.Expiration,
+		CheckedAt:            v.clock.Now().UTC(),
+	}
+	validity.Expired = credential.Expiration != nil && !credential.Expiration.After(validity.CheckedAt)
+
+	validity.LocalIssuer, err = v.identityService.Exists(ctx, *issuerDID)
+	if err != nil {
+		return nil, err
+	}
+	if validity.LocalIssuer && v.metering != nil {
+		// the passive regions read a replica, where the checks are not metered
+		if err := v.metering.Record(ctx, *issuerDID, domain.MeteredVerification); err != nil && !db.IsReadOnly(err) {
+			log.Error(ctx, "metering the credential verification", "err", err, "issuer", credential.Issuer)
+		}
+	}
+
+	if v.trustRegistry != nil {
+		validity.Accreditation = v.accreditation(ctx, credential)
+	}
+
+	var revocationStatus *verifiable.RevocationStatus
+	if validity.LocalIssuer {
+		revocationStatus, err = v.claimsService.GetRevocationStatus(ctx, *issuerDID, status.RevocationNonce)
+	} else {
+		revocationStatus, err = v.revocationService.Status(ctx, credential.CredentialStatus, issuerDID)
+	}
+	if err != nil {
+		log.Warn(ctx, "resolving credential revocation status", "err", err, "issuer", credential.Issuer, "nonce", status.RevocationNonce)
+		validity.Reason = common.ToPointer("cannot resolve the revocation status")
+		return validity, nil
+	}
+
+	validity.Revoked = common.ToPointer(revocationStatus.MTP.Existence)
+	return validity, nil
+}
+
+// accreditation asks the trust registry whether the issuer is accredited for the credential type. As with the
+// revocation status, a registry that cannot be consulted is reported in the accreditation reason.
+func (v *credentialValidity) accreditation(ctx context.Context, credential verifiable.W3CCredential) *domain.IssuerAccreditation {
+	accreditation := &domain.IssuerAccreditation{CheckedAt: v.clock.Now().UTC()}
+	for _, credentialType := range credential.Type {
+		if credentialType != verifiable.TypeW3CVerifiableCredential {
+			accreditation.CredentialType = credentialType
+		}
+	}
+	if accreditation.CredentialType == "" {
+		accreditation.Reason = common.ToPointer("the credential has no type")
+		return accreditation
+	}
+
+	answer, err := v.trustRegistry.Accreditation(ctx, credential.Issuer, accreditation.CredentialType)
+	if err != nil {
+		log.Warn(ctx, "consulting the trust registry", "err", err, "issuer", credential.Issuer, "type", accreditation.CredentialType)
+		accreditation.Reason = common.ToPointer(fmt.Sprintf("cannot consult the trust registry: %s", err))
+		return accreditation
+	}
+	accreditation.Accredited = common.ToPointer(answer.Accredited)
+	accreditation.CheckedAt = answer.CheckedAt
+	return accreditation
+}
+
+// parseCredentialStatus reads the type and nonce of a credential status decoded from JSON
+func parseCredentialStatus(credentialStatus interface{}) (*verifiable.CredentialStatus, error) {
+	if credentialStatus == nil {
+		return nil, fmt.Errorf("%w: the credential has no credentialStatus", ErrInvalidCredential)
+	}
+
+	raw, err := json.Marshal(credentialStatus)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCredential, err)
+	}
+	status := &verifiable.CredentialStatus{}
+	if err := json.Unmarshal(raw, status); err != nil {
+		return nil, fmt.Errorf("%w: malformed credentialStatus", ErrInvalidCredential)
+	}
+	if status.ID == "" {
+		return nil, fmt.Errorf("%w: the credentialStatus has no id", ErrInvalidCredential)
+	}
+	if status.Type != verifiable.SparseMerkleTreeProof && status.Type != verifiable.Iden3ReverseSparseMerkleTreeProof {
+		return nil, fmt.Errorf("%w: credentialStatus type %s is not supported", ErrInvalidCredential, status.Type)
+	}
+	return status, nil
+}
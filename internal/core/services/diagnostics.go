@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+type diagnostics struct {
+	queryPlans ports.QueryPlanRepository
+	storage    *db.Storage
+}
+
+// NewDiagnostics returns the service that helps the operators to tune the database of the node
+func NewDiagnostics(storage *db.Storage) ports.DiagnosticsService {
+	return &diagnostics{
+		queryPlans: repositories.NewQueryPlans(),
+		storage:    storage,
+	}
+}
+
+// QueryPlans explains the frequent queries of the claims and links in the primary database, and advises the indexes
+// they lack and the tables to vacuum. The queries are only planned, not run.
+func (d *diagnostics) QueryPlans(ctx context.Context) (*domain.QueryPlanReport, error) {
+	plans, err := d.queryPlans.Explain(ctx, d.storage.Pgx)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := d.queryPlans.TableStats(ctx, d.storage.Pgx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewQueryPlanReport(plans, tables), nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+)
+
+// ErrUnsupportedDIDMethod means there is no driver registered for a DID method
+var ErrUnsupportedDIDMethod = errors.New("unsupported DID method")
+
+var (
+	didMethodsMu sync.RWMutex
+	didMethods   = map[string]ports.DIDMethodDriver{}
+)
+
+func init() {
+	RegisterDIDMethodDriver(&iden3Driver{method: core.DIDMethodIden3})
+	RegisterDIDMethodDriver(&iden3Driver{method: core.DIDMethodPolygonID})
+}
+
+// RegisterDIDMethodDriver makes the identities of the method of driver available to the identity service.
+// It replaces the driver already registered for the method, if any.
+// The identities are iden3 identities, so the DIDs of the drivers must be core.DID, and methods like ethr or web,
+// whose DIDs are not derived from an identity state, can't be registered until they are supported by go-iden3-core.
+func RegisterDIDMethodDriver(driver ports.DIDMethodDriver) {
+	didMethodsMu.Lock()
+	defer didMethodsMu.Unlock()
+	didMethods[driver.Method()] = driver
+}
+
+// DIDMethodDriver returns the driver registered for method
+func DIDMethodDriver(method string) (ports.DIDMethodDriver, error) {
+	didMethodsMu.RLock()
+	defer didMethodsMu.RUnlock()
+	driver, found := didMethods[method]
+	if !found {
+		return nil, fmt.Errorf("%w <%s>", ErrUnsupportedDIDMethod, method)
+	}
+	return driver, nil
+}
+
+// DIDMethods returns the sorted DID methods with a registered driver
+func DIDMethods() []string {
+	didMethodsMu.RLock()
+	defer didMethodsMu.RUnlock()
+	methods := make([]string, 0, len(didMethods))
+	for method := range didMethods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// checkGenesisState returns nil when state is the genesis state of did, as resolved by the driver of its method
+func checkGenesisState(did *core.DID, state *big.Int) error {
+	driver, err := DIDMethodDriver(string(did.Method))
+	if err != nil {
+		return err
+	}
+	return driver.CheckGenesisState(did, state)
+}
+
+// iden3Driver is the driver of the DID methods of go-iden3-core, whose identifiers are derived from the genesis
+// state and authenticated with a BabyJubJub key
+type iden3Driver struct {
+	method core.DIDMethod
+}
+
+func (d *iden3Driver) Method() string {
+	return string(d.method)
+}
+
+func (d *iden3Driver) KeyType() kms.KeyType {
+	return kms.KeyTypeBabyJubJub
+}
+
+func (d *iden3Driver) GenesisDID(state *big.Int, blockchain, network string) (*core.DID, error) {
+	didType, err := core.BuildDIDType(d.method, core.Blockchain(blockchain), core.NetworkID(network))
+	if err != nil {
+		return nil, ErrWrongDIDMetada
+	}
+	identifier, err := core.IdGenesisFromIdenState(didType, state)
+	if err != nil {
+		return nil, fmt.Errorf("can't genesis from state: %w", err)
+	}
+	did, err := core.ParseDIDFromID(*identifier)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse did: %w", err)
+	}
+	return did, nil
+}
+
+func (d *iden3Driver) CheckGenesisState(did *core.DID, state *big.Int) error {
+	return common.CheckGenesisStateDID(did, state)
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+var (
+	ErrDisplayMethodNotFound  = errors.New("the credential has no display method") // ErrDisplayMethodNotFound the credential was issued without display method
+	ErrLoadingDisplayTemplate = errors.New("cannot load the display template")     // ErrLoadingDisplayTemplate the display method template cannot be loaded or parsed
+)
+
+type display struct {
+	claimsService   ports.ClaimsService
+	brandingService ports.BrandingService
+	loaderFactory   loader.Factory
+}
+
+// NewDisplay returns a DisplayService that loads the display templates with the given loader and completes them with
+// the branding of the issuer
+func NewDisplay(claimsService ports.ClaimsService, brandingService ports.BrandingService, lf loader.Factory) ports.DisplayService {
+	return &display{
+		claimsService:   claimsService,
+		brandingService: brandingService,
+		loaderFactory:   lf,
+	}
+}
+
+// GetCredentialCard loads the template of the credential display method and renders it with the credential values
+func (d *display) GetCredentialCard(ctx context.Context, issuerDID core.DID, credentialID uuid.UUID) (*domain.CredentialCard, error) {
+	claim, err := d.claimsService.GetByID(ctx, &issuerDID, credentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	displayMethod, err := claim.GetDisplayMethod()
+	if err != nil {
+		return nil, err
+	}
+	if displayMethod == nil {
+		return nil, ErrDisplayMethodNotFound
+	}
+
+	content, _, err := d.loaderFactory(displayMethod.ID).Load(ctx)
+	if err != nil {
+		log.Warn(ctx, "loading display template", "err", err, "url", displayMethod.ID)
+		return nil, ErrLoadingDisplayTemplate
+	}
+	var template domain.DisplayTemplate
+	if err := json.Unmarshal(content, &template); err != nil {
+		log.Warn(ctx, "parsing display template", "err", err, "url", displayMethod.ID)
+		return nil, ErrLoadingDisplayTemplate
+	}
+
+	vc, err := claim.GetVerifiableCredential()
+	if err != nil {
+		return nil, err
+	}
+
+	branding, err := d.brandingService.Get(ctx, issuerDID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CredentialCard{
+		CredentialID:  claim.ID,
+		DisplayMethod: *displayMethod,
+		Template:      branding.Apply(template.Render(displayValues(vc))),
+	}, nil
+}
+
+// displayValues returns the values the display template placeholders can reference
+func displayValues(vc verifiable.W3CCredential) map[string]string {
+	values := map[string]string{
+		"id":     vc.ID,
+		"issuer": vc.Issuer,
+	}
+	if len(vc.Type) > 0 {
+		values["type"] = vc.Type[len(vc.Type)-1]
+	}
+	if vc.IssuanceDate != nil {
+		values["issuanceDate"] = vc.IssuanceDate.Format(time.DateOnly)
+	}
+	if vc.Expiration != nil {
+		values["expirationDate"] = vc.Expiration.Format(time.DateOnly)
+	}
+	for attr, value := range vc.CredentialSubject {
+		if number, ok := value.(float64); ok {
+			values["credentialSubject."+attr] = strconv.FormatFloat(number, 'f', -1, 64)
+			continue
+		}
+		values["credentialSubject."+attr] = fmt.Sprint(value)
+	}
+	return values
+}
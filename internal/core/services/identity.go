@@ -12,6 +12,8 @@ import (
 	"strings"
 	"time"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
 	auth "github.com/iden3/go-iden3-auth"
 	"github.com/iden3/go-iden3-auth/pubsignals"
@@ -31,6 +33,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/kms"
 	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
 	"github.com/polygonid/sh-id-platform/pkg/credentials/signature/circuit/signer"
 	"github.com/polygonid/sh-id-platform/pkg/credentials/signature/suite"
 	"github.com/polygonid/sh-id-platform/pkg/credentials/signature/suite/babyjubjub"
@@ -45,9 +48,11 @@ const (
 	authReason      = "authentication"
 )
 
-// ErrWrongDIDMetada - represents an error in the identity metadata
 var (
-	ErrWrongDIDMetada = errors.New("wrong DID Metadata")
+	ErrWrongDIDMetada   = errors.New("wrong DID Metadata") // ErrWrongDIDMetada - represents an error in the identity metadata
+	ErrIdentityNotFound = errors.New("identity not found") // ErrIdentityNotFound the identity does not exist
+	// ErrAuthSessionIssuer the authentication session was created by another issuer identity
+	ErrAuthSessionIssuer = errors.New("the authentication session belongs to another issuer")
 )
 
 type identity struct {
@@ -57,6 +62,8 @@ type identity struct {
 	claimsRepository        ports.ClaimsRepository
 	revocationRepository    ports.RevocationRepository
 	connectionsRepository   ports.ConnectionsRepository
+	verificationRepository  ports.VerificationRepository
+	credentialEvents        ports.CredentialEventRepository
 	sessionManager          ports.SessionRepository
 	storage                 *db.Storage
 	mtService               ports.MtService
@@ -77,6 +84,8 @@ func NewIdentity(kms kms.KMSType, identityRepository ports.IndentityRepository,
 		claimsRepository:        claimsRepository,
 		revocationRepository:    revocationRepository,
 		connectionsRepository:   connectionsRepository,
+		verificationRepository:  repositories.NewVerification(),
+		credentialEvents:        repositories.NewCredentialEvent(),
 		sessionManager:          sessionRepository,
 		storage:                 storage,
 		mtService:               mtservice,
@@ -169,6 +178,43 @@ func (i *identity) SignClaimEntry(ctx context.Context, authClaim *domain.Claim,
 	return &proof, nil
 }
 
+// SignClaimEntrySecp256k1 signs the core claim with the secp256k1 key of the auth claim, in an
+// EcdsaSecp256k1Signature2019 proof with the issuer data of the auth claim
+func (i *identity) SignClaimEntrySecp256k1(ctx context.Context, authClaim *domain.Claim, claimEntry *core.Claim) (*domain.EcdsaSecp256k1SignatureProof2019, error) {
+	keyID, err := i.GetKeyIDFromAuthClaim(ctx, authClaim)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := domain.Secp256k1ClaimDigest(claimEntry)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := i.kms.Sign(ctx, keyID, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	var issuerMTP verifiable.Iden3SparseMerkleTreeProof
+	if err := authClaim.MTPProof.AssignTo(&issuerMTP); err != nil {
+		return nil, err
+	}
+	proof := domain.EcdsaSecp256k1SignatureProof2019{
+		Type:      domain.EcdsaSecp256k1SignatureProofType,
+		Signature: hex.EncodeToString(signature),
+	}
+	issuerMTP.IssuerData.AuthCoreClaim, err = authClaim.CoreClaim.Get().Hex()
+	if err != nil {
+		return nil, err
+	}
+	proof.IssuerData = issuerMTP.IssuerData
+	proof.IssuerData.MTP = issuerMTP.MTP
+	proof.CoreClaim, err = claimEntry.Hex()
+	if err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
 func (i *identity) Exists(ctx context.Context, identifier core.DID) (bool, error) {
 	identity, err := i.identityRepository.GetByID(ctx, i.storage.Pgx, identifier)
 	if err != nil {
@@ -210,7 +256,7 @@ func (i *identity) getKeyIDFromAuthClaim(ctx context.Context, authClaim *domain.
 			continue
 		}
 
-		pubKeyBytes, err := i.kms.PublicKey(keyID)
+		pubKeyBytes, err := i.kms.PublicKey(ctx, keyID)
 		if err != nil {
 			return keyID, err
 		}
@@ -242,7 +288,7 @@ func (i *identity) GetLatestStateByID(ctx context.Context, identifier core.DID)
 }
 
 // GetKeyIDFromAuthClaim finds BJJ KeyID of auth claim
-// in registered key providers
+// in registered key providers. The key of a secp256k1 auth claim is the Ethereum key with its address.
 func (i *identity) GetKeyIDFromAuthClaim(ctx context.Context, authClaim *domain.Claim) (kms.KeyID, error) {
 	var keyID kms.KeyID
 
@@ -256,6 +302,9 @@ func (i *identity) GetKeyIDFromAuthClaim(ctx context.Context, authClaim *domain.
 	}
 
 	entry := authClaim.CoreClaim.Get()
+	if domain.IsAuthEthClaim(entry) {
+		return i.ethKeyIDFromAuthClaim(ctx, *identity, entry)
+	}
 	bjjClaim := entry.RawSlotsAsInts()
 
 	var publicKey babyjub.PublicKey
@@ -274,7 +323,7 @@ func (i *identity) GetKeyIDFromAuthClaim(ctx context.Context, authClaim *domain.
 			continue
 		}
 
-		pubKeyBytes, err := i.kms.PublicKey(keyID)
+		pubKeyBytes, err := i.kms.PublicKey(ctx, keyID)
 		if err != nil {
 			return keyID, err
 		}
@@ -286,6 +335,28 @@ func (i *identity) GetKeyIDFromAuthClaim(ctx context.Context, authClaim *domain.
 	return keyID, errors.New("private key not found")
 }
 
+// ethKeyIDFromAuthClaim finds the Ethereum key of the identity with the address of a secp256k1 auth claim
+func (i *identity) ethKeyIDFromAuthClaim(ctx context.Context, identity core.DID, authClaim *core.Claim) (kms.KeyID, error) {
+	keyIDs, err := i.kms.KeysByIdentity(ctx, identity)
+	if err != nil {
+		return kms.KeyID{}, err
+	}
+	address := domain.AuthEthAddress(authClaim)
+	for _, keyID := range keyIDs {
+		if keyID.Type != kms.KeyTypeEthereum {
+			continue
+		}
+		keyAddress, err := ethAddress(ctx, i.kms, keyID)
+		if err != nil {
+			return keyID, err
+		}
+		if keyAddress == address {
+			return keyID, nil
+		}
+	}
+	return kms.KeyID{}, errors.New("private key not found")
+}
+
 func (i *identity) UpdateState(ctx context.Context, did core.DID) (*domain.IdentityState, error) {
 	newState := &domain.IdentityState{
 		Identifier: did.String(),
@@ -294,7 +365,7 @@ func (i *identity) UpdateState(ctx context.Context, did core.DID) (*domain.Ident
 
 	err := i.storage.Pgx.BeginFunc(ctx,
 		func(tx pgx.Tx) error {
-			iTrees, err := i.mtService.GetIdentityMerkleTrees(ctx, tx, &did)
+			iTrees, err := i.mtService.GetIdentityMerkleTreesForUpdate(ctx, tx, &did)
 			if err != nil {
 				return err
 			}
@@ -309,11 +380,14 @@ func (i *identity) UpdateState(ctx context.Context, did core.DID) (*domain.Ident
 				return fmt.Errorf("error getting the states: %w", err)
 			}
 
-			for i := range lc {
-				err = iTrees.AddClaim(ctx, &lc[i])
-				if err != nil {
-					return err
-				}
+			err = iTrees.AddClaims(ctx, lc)
+			if err != nil {
+				return err
+			}
+
+			anchor, err := i.anchorCredentialEvents(ctx, tx, did, iTrees)
+			if err != nil {
+				return err
 			}
 
 			err = populateIdentityState(ctx, iTrees, newState, previousState)
@@ -336,6 +410,13 @@ func (i *identity) UpdateState(ctx context.Context, did core.DID) (*domain.Ident
 				return fmt.Errorf("error saving new identity state: %w", err)
 			}
 
+			if anchor != nil {
+				anchor.State = *newState.State
+				if err := i.credentialEvents.SaveAnchor(ctx, tx, anchor); err != nil {
+					return fmt.Errorf("error saving the credential log anchor: %w", err)
+				}
+			}
+
 			err = i.rhsPublisher.PushHashesToRHS(ctx, newState, previousState, updatedRevocations, iTrees)
 			if err != nil {
 				log.Error(ctx, "publishing hashes to RHS", "err", err)
@@ -356,6 +437,42 @@ func (i *identity) UpdateState(ctx context.Context, did core.DID) (*domain.Ident
 	return newState, err
 }
 
+// anchorCredentialEvents adds the head of the credential log of the identity to its claims tree when there are events
+// after the last anchor, so the log can't be changed without it no longer matching the published states
+func (i *identity) anchorCredentialEvents(ctx context.Context, conn db.Querier, did core.DID, trees *domain.IdentityMerkleTrees) (*domain.CredentialEventAnchor, error) {
+	head, err := i.credentialEvents.GetHead(ctx, conn, did)
+	if errors.Is(err, repositories.ErrCredentialEventDoesNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting the credential log head: %w", err)
+	}
+	last, err := i.credentialEvents.GetLastAnchor(ctx, conn, did)
+	if err != nil && !errors.Is(err, repositories.ErrCredentialEventAnchorDoesNotExist) {
+		return nil, fmt.Errorf("error getting the credential log anchor: %w", err)
+	}
+	if last != nil && last.Seq >= head.Seq {
+		return nil, nil
+	}
+
+	anchorClaim, err := head.AnchorClaim()
+	if err != nil {
+		return nil, err
+	}
+	hi, hv, err := anchorClaim.HiHv()
+	if err != nil {
+		return nil, err
+	}
+	claimsTree, err := trees.ClaimsTree()
+	if err != nil {
+		return nil, err
+	}
+	if err := claimsTree.Add(ctx, hi, hv); err != nil {
+		return nil, fmt.Errorf("error anchoring the credential log: %w", err)
+	}
+	return &domain.CredentialEventAnchor{Issuer: did.String(), Seq: head.Seq, Hash: head.Hash}, nil
+}
+
 func (i *identity) UpdateIdentityState(ctx context.Context, state *domain.IdentityState) error {
 	// save identity to store
 	err := i.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
@@ -378,8 +495,13 @@ func (i *identity) Authenticate(ctx context.Context, message string, sessionID u
 		log.Warn(ctx, "authentication session not found")
 		return nil, err
 	}
+	if authReq.From != issuerDID.String() {
+		log.Warn(ctx, "authentication session of another issuer", "issuerDID", issuerDID.String())
+		return nil, ErrAuthSessionIssuer
+	}
 
 	arm, err := i.verifier.FullVerify(ctx, message, authReq, pubsignals.WithAcceptedStateTransitionDelay(transitionDelay))
+	i.recordVerification(ctx, issuerDID, sessionID, authReq, arm, err)
 	if err != nil {
 		log.Error(ctx, "authentication failed", "err", err)
 		return nil, err
@@ -422,6 +544,53 @@ func (i *identity) Authenticate(ctx context.Context, message string, sessionID u
 	return arm, nil
 }
 
+// recordVerification keeps the result of the verification of the proofs of an authorization response and publishes
+// it to the verification webhook. The failures are logged and don't affect the authentication.
+func (i *identity) recordVerification(ctx context.Context, issuerDID core.DID, sessionID uuid.UUID, authReq protocol.AuthorizationRequestMessage, arm *protocol.AuthorizationResponseMessage, verifyErr error) {
+	verification := &domain.Verification{
+		ID:          uuid.New(),
+		IssuerDID:   issuerDID,
+		SessionID:   sessionID,
+		Verified:    verifyErr == nil,
+		ProofHashes: []string{},
+		CreatedAt:   time.Now(),
+	}
+	queries, err := json.Marshal(authReq.Body.Scope)
+	if err != nil {
+		log.Error(ctx, "marshalling the verification queries", "err", err, "session", sessionID)
+		return
+	}
+	verification.Queries = queries
+	if verifyErr != nil {
+		verification.Error = common.ToPointer(verifyErr.Error())
+	}
+	if arm != nil {
+		verification.UserDID = common.ToPointer(arm.From)
+		for _, proof := range arm.Body.Scope {
+			hash, err := domain.HashProof(proof)
+			if err != nil {
+				log.Error(ctx, "hashing the verified proof", "err", err, "session", sessionID)
+				return
+			}
+			verification.ProofHashes = append(verification.ProofHashes, hash)
+		}
+	}
+
+	if err := i.verificationRepository.Save(ctx, i.storage.Pgx, verification); err != nil {
+		log.Error(ctx, "saving the verification", "err", err, "session", sessionID)
+		return
+	}
+	err = i.pubsub.Publish(ctx, event.VerificationEvent, &event.Verification{VerificationID: verification.ID.String(), IssuerID: issuerDID.String()})
+	if err != nil {
+		log.Error(ctx, "publish VerificationEvent", "err", err, "verification", verification.ID)
+	}
+}
+
+// GetVerifications returns the verifications of the proofs sent to the issuer that match the filter, the newest first
+func (i *identity) GetVerifications(ctx context.Context, issuerDID core.DID, filter domain.VerificationFilter) ([]domain.Verification, error) {
+	return i.verificationRepository.GetAll(ctx, i.storage.Reader(), issuerDID, filter)
+}
+
 func (i *identity) CreateAuthenticationQRCode(ctx context.Context, serverURL string, issuerDID core.DID) (*protocol.AuthorizationRequestMessage, error) {
 	sessionID := uuid.New().String()
 	reqID := uuid.New().String()
@@ -433,7 +602,7 @@ func (i *identity) CreateAuthenticationQRCode(ctx context.Context, serverURL str
 		Typ:      packers.MediaTypePlainMessage,
 		Type:     protocol.AuthorizationRequestMessageType,
 		Body: protocol.AuthorizationRequestMessageBody{
-			CallbackURL: fmt.Sprintf("%s/v1/authentication/callback?sessionID=%s", serverURL, sessionID),
+			CallbackURL: fmt.Sprintf("%s/v1/authentication/callback?sessionID=%s&issuerDID=%s", serverURL, sessionID, url.QueryEscape(issuerDID.String())),
 			Reason:      authReason,
 		},
 	}
@@ -454,17 +623,17 @@ func (i *identity) update(ctx context.Context, conn db.Querier, id *core.DID, cu
 		return nil
 	}
 
+	ids := make([]uuid.UUID, len(claims))
 	for j := range claims {
-		var err error
-		claims[j].IdentityState = currentState.State
+		ids[j] = claims[j].ID
+	}
 
-		affected, err := i.claimsRepository.UpdateState(ctx, i.storage.Pgx, &claims[j])
-		if err != nil {
-			return fmt.Errorf("can't update claim: %w", err)
-		}
-		if affected == 0 {
-			return fmt.Errorf("claim has not been updated %v", claims[j])
-		}
+	affected, err := i.claimsRepository.UpdateStateBatch(ctx, conn, id, *currentState.State, ids)
+	if err != nil {
+		return fmt.Errorf("can't update claims: %w", err)
+	}
+	if affected != int64(len(ids)) {
+		return fmt.Errorf("only %d of %d claims have been updated", affected, len(ids))
 	}
 
 	return nil
@@ -520,17 +689,26 @@ func populateIdentityState(ctx context.Context, trees *domain.IdentityMerkleTree
 }
 
 func (i *identity) createIdentity(ctx context.Context, tx db.Querier, DIDMethod string, blockchain, networkID, hostURL string) (*core.DID, *big.Int, error) {
+	driver, err := DIDMethodDriver(DIDMethod)
+	if err != nil {
+		log.Warn(ctx, "creating identity", "err", err, "supported", DIDMethods())
+		return nil, nil, ErrWrongDIDMetada
+	}
+	if driver.KeyType() != kms.KeyTypeBabyJubJub {
+		return nil, nil, fmt.Errorf("the auth claims of the %s identities need %s keys, only %s keys are supported", DIDMethod, driver.KeyType(), kms.KeyTypeBabyJubJub)
+	}
+
 	mts, err := i.mtService.CreateIdentityMerkleTrees(ctx, tx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("can't create identity markle tree: %w", err)
 	}
 
-	key, err := i.kms.CreateKey(kms.KeyTypeBabyJubJub, nil)
+	key, err := i.kms.CreateKey(ctx, kms.KeyTypeBabyJubJub, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("can't create babyJubJub key: %w", err)
 	}
 
-	pubKey, err := bjjPubKey(i.kms, key)
+	pubKey, err := bjjPubKey(ctx, i.kms, key)
 	if err != nil {
 		return nil, nil, fmt.Errorf("can't get babyJubJub public key: %w", err)
 	}
@@ -558,21 +736,9 @@ func (i *identity) createIdentity(ctx context.Context, tx db.Querier, DIDMethod
 		return nil, nil, fmt.Errorf("can't add get current state from merkle tree: %w", err)
 	}
 
-	// TODO: add config options for blockchain and network
-	// didType, err := core.BuildDIDType(core.DIDMethodPolygonID, core.Polygon, core.Mumbai)
-	didType, err := core.BuildDIDType(core.DIDMethod(DIDMethod), core.Blockchain(blockchain), core.NetworkID(networkID))
+	did, err := driver.GenesisDID(currentState.BigInt(), blockchain, networkID)
 	if err != nil {
-		return nil, nil, ErrWrongDIDMetada
-	}
-
-	identifier, err := core.IdGenesisFromIdenState(didType, currentState.BigInt())
-	if err != nil {
-		return nil, nil, fmt.Errorf("can't genesis from state: %w", err)
-	}
-
-	did, err := core.ParseDIDFromID(*identifier)
-	if err != nil {
-		return nil, nil, fmt.Errorf("can't parse did: %w", err)
+		return nil, nil, err
 	}
 
 	err = mts.BindToIdentifier(tx, did)
@@ -596,18 +762,78 @@ func (i *identity) createIdentity(ctx context.Context, tx db.Querier, DIDMethod
 	claimsTreeHex := claimsTree.Root().Hex()
 	identity.State.ClaimsTreeRoot = &claimsTreeHex
 
-	claimData := make(map[string]interface{})
-	claimData["x"] = pubKey.X.String()
-	claimData["y"] = pubKey.Y.String()
+	authClaimModel, err := newAuthClaimModel(did, authClaim, pubKey, revNonce, hostURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mtpProof, err := i.getAuthClaimMtpProof(ctx, claimsTree, currentState, authClaim, did)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't add get current state from merkle tree: %w", err)
+	}
+
+	jsonProof, err := json.Marshal(mtpProof)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't marshal proof: %w", err)
+	}
 
+	err = authClaimModel.MTPProof.Set(jsonProof)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't set mtp proof to auth claim: %w", err)
+	}
+
+	if err = i.identityRepository.Save(ctx, tx, identity); err != nil {
+		return nil, nil, fmt.Errorf("can't save identity: %w", err)
+	}
+
+	// mark genesis state like `confirmed` state.
+	identity.State.Status = domain.StatusConfirmed
+	err = i.identityStateRepository.Save(ctx, tx, identity.State)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't save identity state: %w", err)
+	}
+
+	authClaimModel.IdentityState = identity.State.State
+	authClaimModel.Identifier = &identity.Identifier
+	authClaimModel.MtProof = true
+	_, err = i.claimsRepository.Save(ctx, tx, authClaimModel)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't save auth claim: %w", err)
+	}
+
+	return did, currentState.BigInt(), nil
+}
+
+// authClaimSchema is the schema of the credential of an auth claim
+type authClaimSchema struct {
+	url  string
+	typ  string
+	json string
+}
+
+var (
+	authBJJClaimSchema = authClaimSchema{url: domain.AuthBJJCredentialJSONSchemaURL, typ: domain.AuthBJJCredential, json: domain.AuthBJJCredentialSchemaJSON}
+	authEthClaimSchema = authClaimSchema{url: domain.AuthEthCredentialJSONSchemaURL, typ: domain.AuthEthCredential, json: domain.AuthEthCredentialSchemaJSON}
+)
+
+// newAuthClaimModel returns the claim of the AuthBJJCredential of the identity with its BabyJubJub public key. Its MTP
+// proof is left to the caller, as it is only known once the claim is in a state.
+func newAuthClaimModel(did *core.DID, authClaim *core.Claim, pubKey *babyjub.PublicKey, revNonce uint64, hostURL string) (*domain.Claim, error) {
+	claimData := map[string]interface{}{"x": pubKey.X.String(), "y": pubKey.Y.String()}
+	return newAuthClaimModelOf(did, authClaim, authBJJClaimSchema, claimData, revNonce, hostURL)
+}
+
+// newAuthClaimModelOf returns the claim of the auth credential of the schema with the claim data. Its MTP proof is left
+// to the caller.
+func newAuthClaimModelOf(did *core.DID, authClaim *core.Claim, authSchema authClaimSchema, claimData map[string]interface{}, revNonce uint64, hostURL string) (*domain.Claim, error) {
 	marshalledClaimData, err := json.Marshal(claimData)
 	if err != nil {
-		return nil, nil, fmt.Errorf("can't marshal claim data: %w", err)
+		return nil, fmt.Errorf("can't marshal claim data: %w", err)
 	}
 
 	cr := common.CredentialRequest{
-		CredentialSchema:  domain.AuthBJJCredentialJSONSchemaURL,
-		Type:              domain.AuthBJJCredential,
+		CredentialSchema:  authSchema.url,
+		Type:              authSchema.typ,
 		CredentialSubject: marshalledClaimData,
 		Version:           0,
 		RevNonce:          &revNonce,
@@ -621,28 +847,28 @@ func (i *identity) createIdentity(ctx context.Context, tx db.Querier, DIDMethod
 	}
 
 	var schema jsonSuite.Schema
-	err = json.Unmarshal([]byte(domain.AuthBJJCredentialSchemaJSON), &schema)
+	err = json.Unmarshal([]byte(authSchema.json), &schema)
 	if err != nil {
-		return nil, nil, fmt.Errorf("can't unmarshal the shema: %w", err)
+		return nil, fmt.Errorf("can't unmarshal the shema: %w", err)
 	}
 
 	var jsonLdContext string
 	if jsonLdContext, ok = schema.Metadata.Uris["jsonLdContext"].(string); !ok {
-		return nil, nil, fmt.Errorf("invalid: %w", err)
+		return nil, fmt.Errorf("invalid: %w", err)
 	}
 
-	credentialType := fmt.Sprintf("%s#%s", jsonLdContext, domain.AuthBJJCredential)
+	credentialType := fmt.Sprintf("%s#%s", jsonLdContext, authSchema.typ)
 	claimID, err := uuid.NewUUID()
 	if err != nil {
-		return nil, nil, fmt.Errorf("can't crate uuid: %w", err)
+		return nil, fmt.Errorf("can't crate uuid: %w", err)
 	}
 
 	cred, err := common.CreateCredential(did, cr, schema)
 	if err != nil {
-		return nil, nil, fmt.Errorf("can't create credential: %w", err)
+		return nil, fmt.Errorf("can't create credential: %w", err)
 	}
 
-	cred.ID = fmt.Sprintf("%s/v1/%s/claims/%s", strings.TrimSuffix(hostURL, "/"), identifier, claimID)
+	cred.ID = fmt.Sprintf("%s/v1/%s/claims/%s", strings.TrimSuffix(hostURL, "/"), did.String(), claimID)
 	cs := &verifiable.CredentialStatus{
 		ID: fmt.Sprintf("%s/v1/%s/claims/revocation/status/%d",
 			hostURL, url.QueryEscape(did.String()), revNonce),
@@ -654,61 +880,27 @@ func (i *identity) createIdentity(ctx context.Context, tx db.Querier, DIDMethod
 
 	marshaledCredential, err := json.Marshal(cred)
 	if err != nil {
-		return nil, nil, fmt.Errorf("can't marshal credential: %w", err)
-	}
-
-	authClaimModel, err := domain.FromClaimer(authClaim, domain.AuthBJJCredentialJSONSchemaURL, credentialType)
-	if err != nil {
-		return nil, nil, fmt.Errorf("can't create authClaimModel: %w", err)
+		return nil, fmt.Errorf("can't marshal credential: %w", err)
 	}
 
-	mtpProof, err := i.getAuthClaimMtpProof(ctx, claimsTree, currentState, authClaim, did)
+	authClaimModel, err := domain.FromClaimer(authClaim, authSchema.url, credentialType)
 	if err != nil {
-		return nil, nil, fmt.Errorf("can't add get current state from merkle tree: %w", err)
+		return nil, fmt.Errorf("can't create authClaimModel: %w", err)
 	}
 
 	err = authClaimModel.Data.Set(marshaledCredential)
 	if err != nil {
-		return nil, nil, fmt.Errorf("can't set data to auth claim: %w", err)
+		return nil, fmt.Errorf("can't set data to auth claim: %w", err)
 	}
 
 	err = authClaimModel.CredentialStatus.Set(cs)
 	if err != nil {
-		return nil, nil, fmt.Errorf("can't set credential status to auth claim: %w", err)
-	}
-
-	jsonProof, err := json.Marshal(mtpProof)
-	if err != nil {
-		return nil, nil, fmt.Errorf("can't marshal proof: %w", err)
-	}
-
-	err = authClaimModel.MTPProof.Set(jsonProof)
-	if err != nil {
-		return nil, nil, fmt.Errorf("can't set mtp proof to auth claim: %w", err)
+		return nil, fmt.Errorf("can't set credential status to auth claim: %w", err)
 	}
 
 	authClaimModel.Issuer = did.String()
 
-	if err = i.identityRepository.Save(ctx, tx, identity); err != nil {
-		return nil, nil, fmt.Errorf("can't save identity: %w", err)
-	}
-
-	// mark genesis state like `confirmed` state.
-	identity.State.Status = domain.StatusConfirmed
-	err = i.identityStateRepository.Save(ctx, tx, identity.State)
-	if err != nil {
-		return nil, nil, fmt.Errorf("can't save identity state: %w", err)
-	}
-
-	authClaimModel.IdentityState = identity.State.State
-	authClaimModel.Identifier = &identity.Identifier
-	authClaimModel.MtProof = true
-	_, err = i.claimsRepository.Save(ctx, tx, authClaimModel)
-	if err != nil {
-		return nil, nil, fmt.Errorf("can't save auth claim: %w", err)
-	}
-
-	return did, currentState.BigInt(), nil
+	return authClaimModel, nil
 }
 
 func (i *identity) getAuthClaimMtpProof(ctx context.Context, claimsTree *merkletree.MerkleTree, currentState *merkletree.Hash, authClaim *core.Claim, did *core.DID) (verifiable.Iden3SparseMerkleTreeProof, error) {
@@ -796,6 +988,37 @@ func (i *identity) GetFailedState(ctx context.Context, identifier core.DID) (*do
 	return nil, nil
 }
 
+// GetDefaultProofTypes returns the default proof types of the identity credentials. When the identity has no
+// defaults, it returns the ones applied by the node, see domain.DefaultProofTypes.
+func (i *identity) GetDefaultProofTypes(ctx context.Context, identifier core.DID) (domain.ProofTypes, error) {
+	proofTypes, err := i.identityRepository.GetDefaultProofTypes(ctx, i.storage.Pgx, identifier)
+	if errors.Is(err, repositories.ErrIdentityNotFound) {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if proofTypes == nil {
+		return domain.DefaultProofTypes(identifier), nil
+	}
+	return proofTypes, nil
+}
+
+// UpdateDefaultProofTypes validates and sets the default proof types of the identity credentials.
+// Nil removes them, so the ones applied by the node are used again.
+func (i *identity) UpdateDefaultProofTypes(ctx context.Context, identifier core.DID, proofTypes domain.ProofTypes) error {
+	if proofTypes != nil {
+		if err := proofTypes.Validate(identifier); err != nil {
+			return err
+		}
+	}
+	err := i.identityRepository.UpdateDefaultProofTypes(ctx, i.storage.Pgx, identifier, proofTypes)
+	if errors.Is(err, repositories.ErrIdentityNotFound) {
+		return ErrIdentityNotFound
+	}
+	return err
+}
+
 // newAuthClaim generate BabyJubKeyTypeAuthorizeKSign claimL
 func newAuthClaim(key *babyjub.PublicKey) (*core.Claim, error) {
 	revNonce, err := common.RandInt64()
@@ -807,8 +1030,78 @@ func newAuthClaim(key *babyjub.PublicKey) (*core.Claim, error) {
 		core.WithRevocationNonce(revNonce))
 }
 
-func bjjPubKey(keyMS kms.KMSType, keyID kms.KeyID) (*babyjub.PublicKey, error) {
-	keyBytes, err := keyMS.PublicKey(keyID)
+// newKeyAuthClaim creates a new BabyJubJub key of the identity and the model of its auth claim. The auth claim has no
+// state, so it is added to the claims tree by the next state of the identity.
+func newKeyAuthClaim(ctx context.Context, keyMS kms.KMSType, issuerDID core.DID, hostURL string) (kms.KeyID, *domain.Claim, error) {
+	key, err := keyMS.CreateKey(ctx, kms.KeyTypeBabyJubJub, &issuerDID)
+	if err != nil {
+		return key, nil, fmt.Errorf("can't create babyJubJub key: %w", err)
+	}
+	pubKey, err := bjjPubKey(ctx, keyMS, key)
+	if err != nil {
+		return key, nil, fmt.Errorf("can't get babyJubJub public key: %w", err)
+	}
+	authClaim, err := newAuthClaim(pubKey)
+	if err != nil {
+		return key, nil, fmt.Errorf("can't create auth claim: %w", err)
+	}
+	revNonce, err := common.RandInt64()
+	if err != nil {
+		return key, nil, err
+	}
+	authClaim.SetRevocationNonce(revNonce)
+	authClaimModel, err := newAuthClaimModel(&issuerDID, authClaim, pubKey, revNonce, hostURL)
+	if err != nil {
+		return key, nil, err
+	}
+	authClaimModel.Identifier = common.ToPointer(issuerDID.String())
+	authClaimModel.MtProof = true
+	return key, authClaimModel, nil
+}
+
+// newEthKeyAuthClaim creates a new secp256k1 key of the identity and the model of its auth claim, with the Ethereum
+// address of the key. Like the BabyJubJub ones, the auth claim is added to the claims tree by the next state.
+func newEthKeyAuthClaim(ctx context.Context, keyMS kms.KMSType, issuerDID core.DID, hostURL string) (kms.KeyID, *domain.Claim, error) {
+	key, err := keyMS.CreateKey(ctx, kms.KeyTypeEthereum, &issuerDID)
+	if err != nil {
+		return key, nil, fmt.Errorf("can't create secp256k1 key: %w", err)
+	}
+	address, err := ethAddress(ctx, keyMS, key)
+	if err != nil {
+		return key, nil, fmt.Errorf("can't get secp256k1 public key: %w", err)
+	}
+	revNonce, err := common.RandInt64()
+	if err != nil {
+		return key, nil, err
+	}
+	authClaim, err := domain.NewAuthEthClaim(address, revNonce)
+	if err != nil {
+		return key, nil, fmt.Errorf("can't create auth claim: %w", err)
+	}
+	authClaimModel, err := newAuthClaimModelOf(&issuerDID, authClaim, authEthClaimSchema, map[string]interface{}{"address": address.Hex()}, revNonce, hostURL)
+	if err != nil {
+		return key, nil, err
+	}
+	authClaimModel.Identifier = common.ToPointer(issuerDID.String())
+	authClaimModel.MtProof = true
+	return key, authClaimModel, nil
+}
+
+// ethAddress returns the Ethereum address of a secp256k1 key
+func ethAddress(ctx context.Context, keyMS kms.KMSType, keyID kms.KeyID) (ethcommon.Address, error) {
+	keyBytes, err := keyMS.PublicKey(ctx, keyID)
+	if err != nil {
+		return ethcommon.Address{}, fmt.Errorf("can't get bytes from public key: %w", err)
+	}
+	pubKey, err := kms.DecodeEthPubKey(keyBytes)
+	if err != nil {
+		return ethcommon.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+func bjjPubKey(ctx context.Context, keyMS kms.KMSType, keyID kms.KeyID) (*babyjub.PublicKey, error) {
+	keyBytes, err := keyMS.PublicKey(ctx, keyID)
 	if err != nil {
 		return nil, fmt.Errorf("can't get bytes from public key: %w", err)
 	}
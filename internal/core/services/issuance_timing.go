@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/histogram"
+)
+
+// issuanceTotal is the histogram of the whole issuance in issuanceLatency
+const issuanceTotal = "total"
+
+// issuanceLatency has the histograms of the durations of each phase of the issuances, and of the whole issuance.
+// They are published with the rest of the expvar variables.
+var issuanceLatency = newIssuanceLatency()
+
+func newIssuanceLatency() *expvar.Map {
+	m := expvar.NewMap("issuance_latency")
+	for _, phase := range domain.IssuancePhases {
+		m.Set(string(phase), histogram.New())
+	}
+	m.Set(issuanceTotal, histogram.New())
+	return m
+}
+
+func observeIssuanceLatency(name string, d time.Duration) {
+	if h, ok := issuanceLatency.Get(name).(*histogram.Histogram); ok {
+		h.Observe(d)
+	}
+}
+
+type issuanceTimerContextKey struct{}
+
+// issuanceTimer collects the durations of the phases of an issuance, that run one after the other
+type issuanceTimer struct {
+	start  time.Time
+	phases map[domain.IssuancePhase]time.Duration
+}
+
+// withIssuanceTimer returns a copy of ctx that collects the durations of the phases of the issuance of a credential
+func withIssuanceTimer(ctx context.Context) context.Context {
+	return context.WithValue(ctx, issuanceTimerContextKey{}, &issuanceTimer{start: time.Now(), phases: map[domain.IssuancePhase]time.Duration{}})
+}
+
+// timeIssuancePhase starts a phase of an issuance, and returns the function that ends it. The duration is added to the
+// histogram of the phase, and to the timer of ctx when it has one.
+func timeIssuancePhase(ctx context.Context, phase domain.IssuancePhase) func() {
+	start := time.Now()
+	return func() {
+		d := time.Since(start)
+		observeIssuanceLatency(string(phase), d)
+		if timer, ok := ctx.Value(issuanceTimerContextKey{}).(*issuanceTimer); ok {
+			timer.phases[phase] += d
+		}
+	}
+}
+
+// saveIssuanceTiming ends the issuance timed by the timer of ctx and saves its timing for the credential. The timing is
+// only for diagnosis, so it is not saved when it fails.
+func saveIssuanceTiming(ctx context.Context, storage *db.Storage, issuerDID core.DID, claimID uuid.UUID) {
+	timer, ok := ctx.Value(issuanceTimerContextKey{}).(*issuanceTimer)
+	if !ok {
+		return
+	}
+	timing := &domain.IssuanceTiming{
+		ClaimID:   claimID,
+		IssuerDID: issuerDID,
+		Phases:    timer.phases,
+		Total:     time.Since(timer.start),
+		CreatedAt: time.Now(),
+	}
+	observeIssuanceLatency(issuanceTotal, timing.Total)
+	if err := repositories.NewIssuanceTiming().Save(ctx, storage.Pgx, timing); err != nil {
+		log.Warn(ctx, "saving the issuance timing", "err", err, "credential", claimID.String())
+	}
+}
+
+// GetIssuanceTiming returns the time spent in each phase of the issuance of a credential of the issuer
+func (c *claim) GetIssuanceTiming(ctx context.Context, issuerDID core.DID, claimID uuid.UUID) (*domain.IssuanceTiming, error) {
+	timing, err := c.issuanceTimingRepository.GetByClaimID(ctx, c.storage.Reader(), issuerDID, claimID)
+	if errors.Is(err, repositories.ErrIssuanceTimingDoesNotExist) {
+		return nil, ErrIssuanceTimingNotFound
+	}
+	return timing, err
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+// issuerMetadataTTL is how long the metadata document is served before it is generated again
+const issuerMetadataTTL = time.Minute
+
+// IssuerMetadataCfg issuer metadata service configuration
+type IssuerMetadataCfg struct {
+	Host       string
+	RHSEnabled bool // ReverseHash Enabled
+	RHSUrl     string
+}
+
+type issuerMetadata struct {
+	cfg              IssuerMetadataCfg
+	identityService  ports.IdentityService
+	claimsService    ports.ClaimsService
+	schemaRepository ports.SchemaRepository
+	brandingService  ports.BrandingService
+
+	mu       sync.Mutex
+	metadata *domain.IssuerMetadata
+}
+
+// NewIssuerMetadata returns a service that describes the identities of the node, their public keys, proof types and
+// schemas, their branding, and the endpoints to fetch and check their credentials.
+func NewIssuerMetadata(cfg IssuerMetadataCfg, identityService ports.IdentityService, claimsService ports.ClaimsService, schemaRepository ports.SchemaRepository, brandingService ports.BrandingService) ports.IssuerMetadataService {
+	return &issuerMetadata{
+		cfg:              cfg,
+		identityService:  identityService,
+		claimsService:    claimsService,
+		schemaRepository: schemaRepository,
+		brandingService:  brandingService,
+	}
+}
+
+// Get returns the metadata of the node. It is generated from the identities in the database at most once per minute.
+func (m *issuerMetadata) Get(ctx context.Context) (*domain.IssuerMetadata, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.metadata != nil && time.Since(m.metadata.GeneratedAt) < issuerMetadataTTL {
+		return m.metadata, nil
+	}
+
+	identities, err := m.identityService.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	host := strings.TrimSuffix(m.cfg.Host, "/")
+	metadata := &domain.IssuerMetadata{
+		URL:                   host,
+		Issuers:               make([]domain.IssuerMetadataIssuer, 0, len(identities)),
+		CredentialStatusTypes: []string{string(verifiable.SparseMerkleTreeProof)},
+		AgentURL:              host + "/v1/agent",
+		CredentialStatusURL:   host + "/v1/{identifier}/claims/revocation/status/{nonce}",
+		CredentialValidityURL: host + "/v1/credentials/validity",
+		GeneratedAt:           time.Now().UTC(),
+	}
+	if m.cfg.RHSEnabled {
+		metadata.CredentialStatusTypes = append(metadata.CredentialStatusTypes, string(verifiable.Iden3ReverseSparseMerkleTreeProof))
+		metadata.ReverseHashServiceURL = &m.cfg.RHSUrl
+	}
+
+	for _, identifier := range identities {
+		did, err := core.ParseDID(identifier)
+		if err != nil {
+			log.Warn(ctx, "issuer metadata, parsing the identity did", "err", err, "did", identifier)
+			continue
+		}
+		issuer, err := m.issuer(ctx, *did)
+		if err != nil {
+			log.Error(ctx, "issuer metadata, describing the identity", "err", err, "did", identifier)
+			return nil, err
+		}
+		metadata.Issuers = append(metadata.Issuers, *issuer)
+	}
+
+	m.metadata = metadata
+	return metadata, nil
+}
+
+// issuer describes an identity: the keys of its auth claims, the proof types it can issue, its imported schemas and its
+// branding
+func (m *issuerMetadata) issuer(ctx context.Context, did core.DID) (*domain.IssuerMetadataIssuer, error) {
+	issuer := &domain.IssuerMetadataIssuer{
+		DID:        did.String(),
+		PublicKeys: []domain.IssuerPublicKey{},
+		ProofTypes: domain.DefaultProofTypes(did),
+		Schemas:    []domain.IssuerSchema{},
+	}
+
+	authClaims, err := m.claimsService.GetSigningAuthClaims(ctx, &did)
+	if err != nil {
+		return nil, err
+	}
+	if len(authClaims) == 0 {
+		return nil, repositories.ErrClaimDoesNotExist
+	}
+	for i, authClaim := range authClaims {
+		// the keys that are not published yet don't sign, unless the identity has no other
+		if i > 0 && signingKeyStatus(authClaim) == domain.SigningKeyPending {
+			break
+		}
+		pubKey := authClaimPubKey(authClaim)
+		issuer.PublicKeys = append(issuer.PublicKeys, domain.IssuerPublicKey{
+			Type: domain.PublicKeyTypeBJJ,
+			X:    pubKey.X.String(),
+			Y:    pubKey.Y.String(),
+		})
+	}
+
+	schemas, err := m.schemaRepository.GetAll(ctx, did, nil)
+	if err != nil {
+		return nil, err
+	}
+	imported := make(map[string]bool, len(schemas))
+	for _, schema := range schemas {
+		if imported[schema.URL+"#"+schema.Type] {
+			continue
+		}
+		imported[schema.URL+"#"+schema.Type] = true
+		issuer.Schemas = append(issuer.Schemas, domain.IssuerSchema{URL: schema.URL, Type: schema.Type})
+	}
+
+	branding, err := m.brandingService.Get(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+	issuer.Branding = *branding
+	return issuer, nil
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+var (
+	// ErrKeyRotationNotFound the key rotation does not exist
+	ErrKeyRotationNotFound = errors.New("key rotation not found")
+	// ErrKeyRotationInProgress the identity is already rotating its key
+	ErrKeyRotationInProgress = errors.New("the identity is already rotating its key")
+)
+
+type keyRotation struct {
+	kms                     kms.KMSType
+	claimsService           ports.ClaimsService
+	claimsRepository        ports.ClaimsRepository
+	identityStateRepository ports.IdentityStateRepository
+	revocationRepository    ports.RevocationRepository
+	keyRotationRepository   ports.KeyRotationRepository
+	publisher               ports.Publisher
+	storage                 *db.Storage
+	hostURL                 string
+}
+
+// NewKeyRotation returns the service that rotates the BabyJubJub auth keys of the identities. The states are published
+// with publisher, and the rotations advance when Advance finds their states confirmed.
+func NewKeyRotation(kms kms.KMSType, claimsService ports.ClaimsService, claimsRepository ports.ClaimsRepository, identityStateRepository ports.IdentityStateRepository, revocationRepository ports.RevocationRepository, publisher ports.Publisher, storage *db.Storage, hostURL string) ports.KeyRotationService {
+	return &keyRotation{
+		kms:                     kms,
+		claimsService:           claimsService,
+		claimsRepository:        claimsRepository,
+		identityStateRepository: identityStateRepository,
+		revocationRepository:    revocationRepository,
+		keyRotationRepository:   repositories.NewKeyRotation(),
+		publisher:               publisher,
+		storage:                 storage,
+		hostURL:                 hostURL,
+	}
+}
+
+// Rotate starts the rotation of the auth key of the identity: it creates a new BabyJubJub key and its auth claim, and
+// publishes the state with it, signed with the current key. The current auth claim is revoked by Advance once that
+// state is confirmed, as the state transitions can only be signed with an auth claim of a published state.
+func (r *keyRotation) Rotate(ctx context.Context, issuerDID core.DID) (*domain.KeyRotation, error) {
+	oldAuthClaim, err := r.claimsService.GetAuthClaim(ctx, &issuerDID)
+	if errors.Is(err, repositories.ErrClaimDoesNotExist) {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get the auth claim: %w", err)
+	}
+	if _, err := r.keyRotationRepository.GetActiveByIssuer(ctx, r.storage.Pgx, issuerDID); err == nil {
+		return nil, ErrKeyRotationInProgress
+	} else if !errors.Is(err, repositories.ErrKeyRotationDoesNotExist) {
+		return nil, err
+	}
+
+	key, authClaimModel, err := newKeyAuthClaim(ctx, r.kms, issuerDID, r.hostURL)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rotation := &domain.KeyRotation{
+		ID:             uuid.New(),
+		IssuerDID:      issuerDID,
+		Status:         domain.KeyRotationAdding,
+		OldAuthClaimID: oldAuthClaim.ID,
+		NewKeyID:       key.ID,
+		CreatedAt:      now,
+		ModifiedAt:     now,
+	}
+	err = r.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if rotation.NewAuthClaimID, err = r.claimsRepository.Save(ctx, tx, authClaimModel); err != nil {
+			return fmt.Errorf("can't save auth claim: %w", err)
+		}
+		return r.keyRotationRepository.Save(ctx, tx, rotation)
+	})
+	if errors.Is(err, repositories.ErrKeyRotationActive) {
+		return nil, ErrKeyRotationInProgress
+	}
+	if err != nil {
+		log.Error(ctx, "starting the key rotation", "err", err, "did", issuerDID.String())
+		return nil, err
+	}
+	log.Info(ctx, "key rotation started", "rotation", rotation.ID.String(), "did", issuerDID.String(), "key", key.ID)
+
+	if err := r.advance(ctx, rotation); err != nil {
+		log.Error(ctx, "advancing the key rotation", "err", err, "rotation", rotation.ID.String())
+	}
+	return rotation, nil
+}
+
+// Get returns a key rotation of the identity
+func (r *keyRotation) Get(ctx context.Context, issuerDID core.DID, id uuid.UUID) (*domain.KeyRotation, error) {
+	rotation, err := r.keyRotationRepository.GetByID(ctx, r.storage.Pgx, issuerDID, id)
+	if errors.Is(err, repositories.ErrKeyRotationDoesNotExist) {
+		return nil, ErrKeyRotationNotFound
+	}
+	return rotation, err
+}
+
+// Advance moves the active key rotations to their next step when their states are confirmed, and publishes the states
+// they are waiting for. It is run periodically with the check of the transactions.
+func (r *keyRotation) Advance(ctx context.Context) {
+	rotations, err := r.keyRotationRepository.GetActive(ctx, r.storage.Pgx)
+	if err != nil {
+		log.Error(ctx, "getting the active key rotations", "err", err)
+		return
+	}
+	for _, rotation := range rotations {
+		if err := r.advance(ctx, rotation); err != nil {
+			log.Error(ctx, "advancing the key rotation", "err", err, "rotation", rotation.ID.String(), "did", rotation.IssuerDID.String())
+		}
+	}
+}
+
+// advance runs the next step of the rotation and saves it when it changed. The error that stops it, like a failed state
+// transition, is kept in the rotation, that goes on once the state is published again.
+func (r *keyRotation) advance(ctx context.Context, rotation *domain.KeyRotation) error {
+	before := *rotation
+	var err error
+	switch rotation.Status {
+	case domain.KeyRotationAdding:
+		err = r.advanceAdding(ctx, rotation)
+	case domain.KeyRotationRevoking:
+		err = r.advanceRevoking(ctx, rotation)
+	}
+	rotation.Error = nil
+	if err != nil {
+		rotation.Error = common.ToPointer(err.Error())
+	}
+	if rotation.Status == before.Status && equalStrPtr(rotation.AddState, before.AddState) &&
+		equalStrPtr(rotation.RevokeState, before.RevokeState) && equalStrPtr(rotation.Error, before.Error) {
+		return err
+	}
+	rotation.ModifiedAt = time.Now()
+	if errSaving := r.keyRotationRepository.Save(ctx, r.storage.Pgx, rotation); errSaving != nil {
+		return errSaving
+	}
+	return err
+}
+
+// advanceAdding publishes the state with the new auth claim, and revokes the old auth claim once it is confirmed
+func (r *keyRotation) advanceAdding(ctx context.Context, rotation *domain.KeyRotation) error {
+	newClaim, err := r.claimsRepository.GetByIdAndIssuer(ctx, r.storage.Pgx, &rotation.IssuerDID, rotation.NewAuthClaimID)
+	if err != nil {
+		return fmt.Errorf("can't get the new auth claim: %w", err)
+	}
+	if newClaim.IdentityState == nil {
+		return r.publish(ctx, rotation)
+	}
+	rotation.AddState = newClaim.IdentityState
+
+	confirmed, err := r.confirmed(ctx, rotation.IssuerDID, *rotation.AddState)
+	if err != nil || !confirmed {
+		return err
+	}
+
+	oldAuthClaim, err := r.claimsRepository.GetByIdAndIssuer(ctx, r.storage.Pgx, &rotation.IssuerDID, rotation.OldAuthClaimID)
+	if err != nil {
+		return fmt.Errorf("can't get the old auth claim: %w", err)
+	}
+	if !oldAuthClaim.Revoked {
+		if err := r.claimsService.Revoke(ctx, rotation.IssuerDID, uint64(oldAuthClaim.RevNonce), fmt.Sprintf("rotated by %s", rotation.ID)); err != nil {
+			return fmt.Errorf("can't revoke the old auth claim: %w", err)
+		}
+	}
+	rotation.Status = domain.KeyRotationRevoking
+	log.Info(ctx, "key rotation added the new auth claim", "rotation", rotation.ID.String(), "state", *rotation.AddState)
+	return r.publish(ctx, rotation)
+}
+
+// advanceRevoking publishes the state that revokes the old auth claim, and completes the rotation once it is confirmed
+func (r *keyRotation) advanceRevoking(ctx context.Context, rotation *domain.KeyRotation) error {
+	if rotation.RevokeState == nil {
+		oldAuthClaim, err := r.claimsRepository.GetByIdAndIssuer(ctx, r.storage.Pgx, &rotation.IssuerDID, rotation.OldAuthClaimID)
+		if err != nil {
+			return fmt.Errorf("can't get the old auth claim: %w", err)
+		}
+		status, err := r.revocationRepository.GetStatus(ctx, r.storage.Pgx, &rotation.IssuerDID, oldAuthClaim.RevNonce)
+		if err != nil {
+			return fmt.Errorf("can't get the revocation of the old auth claim: %w", err)
+		}
+		if status == domain.RevPending {
+			return r.publish(ctx, rotation)
+		}
+		// the revocation was published with other changes, the latest state includes it
+		states, err := r.identityStateRepository.GetStates(ctx, r.storage.Pgx, rotation.IssuerDID)
+		if err != nil {
+			return err
+		}
+		if len(states) == 0 {
+			return errors.New("the identity has no state with the revocation of the old auth claim")
+		}
+		rotation.RevokeState = states[len(states)-1].State
+	}
+
+	confirmed, err := r.confirmed(ctx, rotation.IssuerDID, *rotation.RevokeState)
+	if err != nil || !confirmed {
+		return err
+	}
+	rotation.Status = domain.KeyRotationCompleted
+	log.Info(ctx, "key rotation completed", "rotation", rotation.ID.String(), "did", rotation.IssuerDID.String())
+	return nil
+}
+
+// publish publishes the pending changes of the identity, and keeps the new state as the one the rotation waits for
+func (r *keyRotation) publish(ctx context.Context, rotation *domain.KeyRotation) error {
+	published, err := r.publisher.PublishState(ctx, &rotation.IssuerDID)
+	if err != nil {
+		return fmt.Errorf("can't publish the state: %w", err)
+	}
+	if rotation.Status == domain.KeyRotationAdding {
+		rotation.AddState = published.State
+	} else {
+		rotation.RevokeState = published.State
+	}
+	return nil
+}
+
+// confirmed tells whether the state of the identity is confirmed, and fails when its transaction failed
+func (r *keyRotation) confirmed(ctx context.Context, issuerDID core.DID, state string) (bool, error) {
+	states, err := r.identityStateRepository.GetStates(ctx, r.storage.Pgx, issuerDID)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range states {
+		if s.State == nil || *s.State != state {
+			continue
+		}
+		if s.Status == domain.StatusFailed {
+			return false, fmt.Errorf("the state %s failed to publish, publish it again to go on with the rotation", state)
+		}
+		return s.Status == domain.StatusConfirmed, nil
+	}
+	return false, fmt.Errorf("the state %s does not exist", state)
+}
+
+func equalStrPtr(a, b *string) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
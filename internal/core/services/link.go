@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,6 +22,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/loader"
 	"github.com/polygonid/sh-id-platform/internal/log"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
 	linkState "github.com/polygonid/sh-id-platform/pkg/link"
 	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 )
@@ -38,6 +40,8 @@ var (
 	ErrLinkInactive = errors.New("cannot issue a credential for an inactive link")
 	// ErrClaimAlreadyIssued - claim already issued
 	ErrClaimAlreadyIssued = errors.New("the claim was already issued for the user")
+	// ErrLinkSessionNotFound - link session not found
+	ErrLinkSessionNotFound = errors.New("link session not found")
 )
 
 // Link - represents a link in the issuer node
@@ -49,11 +53,15 @@ type Link struct {
 	schemaRepository ports.SchemaRepository
 	loaderFactory    loader.Factory
 	sessionManager   ports.SessionRepository
+	linkSessions     ports.LinkSessionRepository
 	publisher        pubsub.Publisher
+	quotas           ports.QuotaService
+	clock            clock.Clock
 }
 
-// NewLinkService - constructor
-func NewLinkService(storage *db.Storage, claimsService ports.ClaimsService, claimRepository ports.ClaimsRepository, linkRepository ports.LinkRepository, schemaRepository ports.SchemaRepository, loaderFactory loader.Factory, sessionManager ports.SessionRepository, publisher pubsub.Publisher) ports.LinkService {
+// NewLinkService - constructor. quotas limits the active links of each issuer, nil disables it. clk is the time source
+// of the link expirations, the system clock when nil.
+func NewLinkService(storage *db.Storage, claimsService ports.ClaimsService, claimRepository ports.ClaimsRepository, linkRepository ports.LinkRepository, schemaRepository ports.SchemaRepository, loaderFactory loader.Factory, sessionManager ports.SessionRepository, publisher pubsub.Publisher, quotas ports.QuotaService, clk clock.Clock) ports.LinkService {
 	return &Link{
 		storage:          storage,
 		claimsService:    claimsService,
@@ -62,7 +70,10 @@ func NewLinkService(storage *db.Storage, claimsService ports.ClaimsService, clai
 		schemaRepository: schemaRepository,
 		loaderFactory:    loaderFactory,
 		sessionManager:   sessionManager,
+		linkSessions:     repositories.NewLinkSession(),
 		publisher:        publisher,
+		quotas:           quotas,
+		clock:            clock.OrSystem(clk),
 	}
 }
 
@@ -74,21 +85,34 @@ func (ls *Link) Save(
 	validUntil *time.Time,
 	schemaID uuid.UUID,
 	credentialExpiration *time.Time,
+	credentialExpirationPolicy *domain.ExpirationPolicy,
 	credentialSignatureProof bool,
 	credentialMTPProof bool,
 	credentialSubject domain.CredentialSubject,
+	validationMode domain.ValidationMode,
 ) (*domain.Link, error) {
 	schemaDB, err := ls.schemaRepository.GetByID(ctx, did, schemaID)
 	if err != nil {
 		return nil, err
 	}
+	if schemaDB.Deprecated() {
+		return nil, ErrSchemaDeprecated
+	}
+	if ls.quotas != nil {
+		if err := ls.quotas.Check(ctx, did, domain.QuotaActiveLinks); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := ls.validateCredentialSubjectAgainstSchema(ctx, credentialSubject, schemaDB); err != nil {
+	credentialSubject, warnings, err := ls.validateCredentialSubjectAgainstSchema(ctx, credentialSubject, schemaDB, validationMode)
+	if err != nil {
 		log.Error(ctx, "validating credential subject", "err", err)
-		return nil, ErrParseClaim
+		return nil, err
 	}
 
 	link := domain.NewLink(did, maxIssuance, validUntil, schemaID, credentialExpiration, credentialSignatureProof, credentialMTPProof, credentialSubject)
+	link.CredentialExpirationPolicy = credentialExpirationPolicy
+	link.ValidationWarnings = warnings
 	_, err = ls.linkRepository.Save(ctx, ls.storage.Pgx, link)
 	if err != nil {
 		return nil, err
@@ -114,11 +138,59 @@ func (ls *Link) Activate(ctx context.Context, issuerID core.DID, linkID uuid.UUI
 		return ErrLinkAlreadyInactive
 	}
 
+	if active && ls.quotas != nil {
+		if err := ls.quotas.Check(ctx, issuerID, domain.QuotaActiveLinks); err != nil {
+			return err
+		}
+	}
+
 	link.Active = active
 	_, err = ls.linkRepository.Save(ctx, ls.storage.Pgx, link)
 	return err
 }
 
+// UpdateDisplay sets the default locale of the link credential offers and the link display strings, that take
+// precedence over the schema ones. Nil removes them.
+func (ls *Link) UpdateDisplay(ctx context.Context, issuerID core.DID, linkID uuid.UUID, locale *string, display domain.DisplayStrings) (*domain.Link, error) {
+	if locale != nil {
+		if err := domain.ValidateLocale(*locale); err != nil {
+			return nil, err
+		}
+	}
+	if err := display.Validate(); err != nil {
+		return nil, err
+	}
+	link, err := ls.GetByID(ctx, issuerID, linkID)
+	if err != nil {
+		return nil, err
+	}
+	link.Locale = locale
+	link.DisplayStrings = display
+	if _, err := ls.linkRepository.Save(ctx, ls.storage.Pgx, link); err != nil {
+		log.Error(ctx, "updating link display", "err", err, "id", linkID)
+		return nil, err
+	}
+	return link, nil
+}
+
+// UpdatePrerequisites sets the credentials that the holders must prove to hold, with a zero-knowledge proof, before a
+// link credential is issued to them. They are asked on top of the schema ones. Nil removes them.
+func (ls *Link) UpdatePrerequisites(ctx context.Context, issuerID core.DID, linkID uuid.UUID, prerequisites domain.Prerequisites) (*domain.Link, error) {
+	if err := prerequisites.Validate(); err != nil {
+		return nil, err
+	}
+	link, err := ls.GetByID(ctx, issuerID, linkID)
+	if err != nil {
+		return nil, err
+	}
+	link.Prerequisites = prerequisites
+	if _, err := ls.linkRepository.Save(ctx, ls.storage.Pgx, link); err != nil {
+		log.Error(ctx, "updating link prerequisites", "err", err, "id", linkID)
+		return nil, err
+	}
+	return link, nil
+}
+
 // GetByID returns a link by id and issuerDID
 func (ls *Link) GetByID(ctx context.Context, issuerID core.DID, id uuid.UUID) (*domain.Link, error) {
 	link, err := ls.linkRepository.GetByID(ctx, issuerID, id)
@@ -149,12 +221,17 @@ func (ls *Link) CreateQRCode(ctx context.Context, issuerDID core.DID, linkID uui
 		return nil, err
 	}
 
+	// The delegated api keys only see their links
+	if key, found := apiKeyFromContext(ctx); found && (link.Schema == nil || !key.AllowsIssuance(link.Schema.Type, &link.ID)) {
+		return nil, ErrLinkNotFound
+	}
+
 	err = ls.validate(ctx, link)
 	if err != nil {
 		return nil, err
 	}
 
-	sessionID := uuid.New().String()
+	sessionID := uuid.New()
 	reqID := uuid.New().String()
 	qrCode := &protocol.AuthorizationRequestMessage{
 		From:     issuerDID.String(),
@@ -163,29 +240,37 @@ func (ls *Link) CreateQRCode(ctx context.Context, issuerDID core.DID, linkID uui
 		Typ:      packers.MediaTypePlainMessage,
 		Type:     protocol.AuthorizationRequestMessageType,
 		Body: protocol.AuthorizationRequestMessageBody{
-			CallbackURL: fmt.Sprintf("%s/v1/credentials/links/callback?sessionID=%s&linkID=%s", serverURL, sessionID, linkID.String()),
+			CallbackURL: fmt.Sprintf("%s/v1/credentials/links/callback?sessionID=%s&linkID=%s&issuerDID=%s", serverURL, sessionID.String(), linkID.String(), url.QueryEscape(issuerDID.String())),
 			Reason:      authReason,
+			// The holder proves the prerequisites in the authorization response, verified before the credential is issued
+			Scope: link.AllPrerequisites().Scope(),
 		},
 	}
 
-	err = ls.sessionManager.Set(ctx, sessionID, *qrCode)
+	err = ls.sessionManager.Set(ctx, sessionID.String(), *qrCode)
 	if err != nil {
 		return nil, err
 	}
 
-	err = ls.sessionManager.SetLink(ctx, linkState.CredentialStateCacheKey(linkID.String(), sessionID), *linkState.NewStatePending())
+	err = ls.sessionManager.SetLink(ctx, linkState.CredentialStateCacheKey(linkID.String(), sessionID.String()), *linkState.NewStatePending())
 	if err != nil {
 		return nil, err
 	}
 
+	if err := ls.linkSessions.Save(ctx, ls.storage.Pgx, domain.NewLinkSession(sessionID, issuerDID, linkID, ls.clock.Now())); err != nil {
+		log.Error(ctx, "saving the link session", "err", err, "session", sessionID)
+		return nil, err
+	}
+
 	return &ports.CreateQRCodeResponse{
-		SessionID: sessionID,
+		SessionID: sessionID.String(),
 		QrCode:    qrCode,
 		Link:      link,
 	}, nil
 }
 
-// IssueClaim - Create a new claim
+// IssueClaim - Create a new claim. The session of the link keeps each step, so a wallet that authenticates again with
+// the same qr code is offered the credential it was already issued.
 func (ls *Link) IssueClaim(ctx context.Context, sessionID string, issuerDID core.DID, userDID core.DID, linkID uuid.UUID, hostURL string) error {
 	link, err := ls.linkRepository.GetByID(ctx, issuerDID, linkID)
 	if err != nil {
@@ -193,7 +278,46 @@ func (ls *Link) IssueClaim(ctx context.Context, sessionID string, issuerDID core
 		return err
 	}
 
-	issuedByUser, err := ls.claimRepository.GetClaimsIssuedForUser(ctx, ls.storage.Pgx, issuerDID, userDID, linkID)
+	session, err := ls.getOrCreateSession(ctx, issuerDID, linkID, sessionID)
+	if err != nil {
+		log.Error(ctx, "cannot fetch the link session", "err", err, "session", sessionID)
+		return err
+	}
+
+	if session.Issued() {
+		if session.UserDID == nil || *session.UserDID != userDID.String() {
+			return fmt.Errorf("%w: the session was claimed by other wallet", domain.ErrLinkSessionTransition)
+		}
+		log.Info(ctx, "resuming the link session", "session", sessionID, "status", session.Status)
+		return ls.sessionManager.SetLink(ctx, linkState.CredentialStateCacheKey(linkID.String(), sessionID), *ls.sessionState(link, session, hostURL))
+	}
+
+	if err := session.Authenticate(userDID, ls.clock.Now()); err != nil {
+		return err
+	}
+	if err := ls.linkSessions.Save(ctx, ls.storage.Pgx, session); err != nil {
+		log.Error(ctx, "cannot save the link session", "err", err, "session", sessionID)
+		return err
+	}
+
+	if err := ls.issueClaim(ctx, link, session, hostURL); err != nil {
+		session.Fail(err, ls.clock.Now())
+		if err := ls.linkSessions.Save(ctx, ls.storage.Pgx, session); err != nil {
+			log.Error(ctx, "cannot save the link session", "err", err, "session", sessionID)
+		}
+		return err
+	}
+	return nil
+}
+
+func (ls *Link) issueClaim(ctx context.Context, link *domain.Link, session *domain.LinkSession, hostURL string) error {
+	issuerDID, linkID, sessionID := session.IssuerDID, session.LinkID, session.ID.String()
+	userDID, err := core.ParseDID(*session.UserDID)
+	if err != nil {
+		return err
+	}
+
+	issuedByUser, err := ls.claimRepository.GetClaimsIssuedForUser(ctx, ls.storage.Pgx, issuerDID, *userDID, linkID)
 	if err != nil {
 		log.Error(ctx, "cannot fetch the claims issued for the user", "err", err, "issuerDID", issuerDID, "userDID", userDID)
 		return err
@@ -205,13 +329,18 @@ func (ls *Link) IssueClaim(ctx context.Context, sessionID string, issuerDID core
 	}
 
 	if err := ls.validate(ctx, link); err != nil {
+		// the wallet reads the error in the state of the qr code
+		session.Fail(err, ls.clock.Now())
+		if err := ls.linkSessions.Save(ctx, ls.storage.Pgx, session); err != nil {
+			log.Error(ctx, "cannot save the link session", "err", err, "session", sessionID)
+		}
 		err := ls.sessionManager.SetLink(ctx, linkState.CredentialStateCacheKey(linkID.String(), sessionID), *linkState.NewStateError(err))
 		if err != nil {
 			log.Error(ctx, "cannot set the sate", "err", err)
 			return err
 		}
 
-		return err
+		return nil
 	}
 
 	schema, err := ls.schemaRepository.GetByID(ctx, issuerDID, link.SchemaID)
@@ -233,7 +362,9 @@ func (ls *Link) IssueClaim(ctx context.Context, sessionID string, issuerDID core
 		&linkID,
 		true,
 	)
+	claimReq.ExpirationPolicy = link.CredentialExpirationPolicy
 
+	ctx = withIssuanceTimer(ctx)
 	credentialIssued, err := ls.claimsService.CreateCredential(ctx, claimReq)
 	if err != nil {
 		log.Error(ctx, "cannot create the claim", "err", err.Error())
@@ -241,21 +372,32 @@ func (ls *Link) IssueClaim(ctx context.Context, sessionID string, issuerDID core
 	}
 
 	var credentialIssuedID uuid.UUID
+	// the notification is published in the transaction, so its duration is also part of the db commit
+	commitDone := timeIssuancePhase(ctx, domain.IssuancePhaseDBCommit)
 	err = ls.storage.Pgx.BeginFunc(ctx,
 		func(tx pgx.Tx) error {
 			link.IssuedClaims += 1
-			_, err := ls.linkRepository.Save(ctx, ls.storage.Pgx, link)
+			_, err := ls.linkRepository.Save(ctx, tx, link)
 			if err != nil {
 				return err
 			}
 
-			credentialIssuedID, err = ls.claimRepository.Save(ctx, ls.storage.Pgx, credentialIssued)
+			credentialIssuedID, err = ls.claimsService.SaveCredential(ctx, tx, issuerDID, credentialIssued)
 			if err != nil {
 				return err
 			}
 
+			if err := session.Issue(credentialIssuedID, ls.clock.Now()); err != nil {
+				return err
+			}
+			if err := ls.linkSessions.Save(ctx, tx, session); err != nil {
+				return err
+			}
+
 			if link.CredentialSignatureProof {
+				notificationDone := timeIssuancePhase(ctx, domain.IssuancePhaseNotification)
 				err = ls.publisher.Publish(ctx, event.CreateCredentialEvent, &event.CreateCredential{CredentialIDs: []string{credentialIssued.ID.String()}, IssuerID: issuerDID.String()})
+				notificationDone()
 				if err != nil {
 					log.Error(ctx, "publish CreateCredentialEvent", "err", err.Error(), "credential", credentialIssued.ID.String())
 				}
@@ -263,33 +405,15 @@ func (ls *Link) IssueClaim(ctx context.Context, sessionID string, issuerDID core
 
 			return nil
 		})
+	commitDone()
 	if err != nil {
 		return err
 	}
 	credentialIssued.ID = credentialIssuedID
+	saveIssuanceTiming(ctx, ls.storage, issuerDID, credentialIssued.ID)
+	ls.claimsService.RunPostIssuanceHooks(ctx, issuerDID, credentialIssued)
 
-	r := &linkState.QRCodeMessage{
-		ID:       uuid.NewString(),
-		Typ:      "application/iden3comm-plain-json",
-		Type:     linkState.CredentialOfferMessageType,
-		ThreadID: uuid.NewString(),
-		Body: linkState.CredentialsLinkMessageBody{
-			URL: fmt.Sprintf("%s/v1/agent", hostURL),
-			Credentials: []linkState.CredentialLink{{
-				ID:          credentialIssued.ID.String(),
-				Description: schema.Type,
-			}},
-		},
-		From: issuerDID.String(),
-		To:   userDID.String(),
-	}
-
-	if link.CredentialSignatureProof {
-		err = ls.sessionManager.SetLink(ctx, linkState.CredentialStateCacheKey(linkID.String(), sessionID), *linkState.NewStateDone(r))
-	} else {
-		err = ls.sessionManager.SetLink(ctx, linkState.CredentialStateCacheKey(linkID.String(), sessionID), *linkState.NewStatePendingPublish())
-	}
-
+	err = ls.sessionManager.SetLink(ctx, linkState.CredentialStateCacheKey(linkID.String(), sessionID), *ls.sessionState(link, session, hostURL))
 	if err != nil {
 		log.Error(ctx, "cannot set the sate", "err", err)
 		return err
@@ -298,8 +422,9 @@ func (ls *Link) IssueClaim(ctx context.Context, sessionID string, issuerDID core
 	return nil
 }
 
-// GetQRCode - return the link qr code.
-func (ls *Link) GetQRCode(ctx context.Context, sessionID uuid.UUID, issuerID core.DID, linkID uuid.UUID) (*ports.GetQRCodeResponse, error) {
+// GetQRCode - return the link qr code. When the state is no longer in the session store, it is rebuilt from the link
+// session, so the wallets can resume the claims that were interrupted.
+func (ls *Link) GetQRCode(ctx context.Context, sessionID uuid.UUID, issuerID core.DID, linkID uuid.UUID, hostURL string) (*ports.GetQRCodeResponse, error) {
 	link, err := ls.GetByID(ctx, issuerID, linkID)
 	if err != nil {
 		log.Error(ctx, "error fetching the link from the database", "err", err)
@@ -307,18 +432,89 @@ func (ls *Link) GetQRCode(ctx context.Context, sessionID uuid.UUID, issuerID cor
 	}
 
 	linkStateInCache, err := ls.sessionManager.GetLink(ctx, linkState.CredentialStateCacheKey(linkID.String(), sessionID.String()))
-	if err != nil {
-		log.Error(ctx, "error fetching the link state from the cache", "err", err)
+	if err == nil {
+		return &ports.GetQRCodeResponse{
+			State: &linkStateInCache,
+			Link:  link,
+		}, nil
+	}
+
+	session, errSession := ls.linkSessions.GetByID(ctx, ls.storage.Reader(), issuerID, linkID, sessionID)
+	if errSession != nil {
+		log.Error(ctx, "error fetching the link state from the cache", "err", err, "session", errSession)
 		return nil, err
 	}
 	return &ports.GetQRCodeResponse{
-		State: &linkStateInCache,
+		State: ls.sessionState(link, session, hostURL),
 		Link:  link,
 	}, nil
 }
 
+// GetSessions returns the sessions of the link, the newest first
+func (ls *Link) GetSessions(ctx context.Context, issuerID core.DID, linkID uuid.UUID, filter ports.LinkSessionsFilter) ([]*domain.LinkSession, error) {
+	if _, err := ls.GetByID(ctx, issuerID, linkID); err != nil {
+		return nil, err
+	}
+	return ls.linkSessions.GetByLink(ctx, ls.storage.Reader(), issuerID, linkID, filter)
+}
+
+// GetSession returns a session of the link
+func (ls *Link) GetSession(ctx context.Context, issuerID core.DID, linkID uuid.UUID, sessionID uuid.UUID) (*domain.LinkSession, error) {
+	session, err := ls.linkSessions.GetByID(ctx, ls.storage.Reader(), issuerID, linkID, sessionID)
+	if errors.Is(err, repositories.ErrLinkSessionDoesNotExist) {
+		return nil, ErrLinkSessionNotFound
+	}
+	return session, err
+}
+
+// getOrCreateSession returns the session of the qr code. The qr codes generated before the sessions were kept in the
+// database don't have one, and it is created.
+func (ls *Link) getOrCreateSession(ctx context.Context, issuerDID core.DID, linkID uuid.UUID, sessionID string) (*domain.LinkSession, error) {
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session id: %w", err)
+	}
+	session, err := ls.linkSessions.GetByID(ctx, ls.storage.Pgx, issuerDID, linkID, id)
+	if errors.Is(err, repositories.ErrLinkSessionDoesNotExist) {
+		return domain.NewLinkSession(id, issuerDID, linkID, ls.clock.Now()), nil
+	}
+	return session, err
+}
+
+// sessionState is the state of the qr code of the session: the credential offer once the credential is issued
+func (ls *Link) sessionState(link *domain.Link, session *domain.LinkSession, hostURL string) *linkState.State {
+	if !session.Issued() {
+		if session.Error != nil {
+			return &linkState.State{Status: linkState.StatusError, Message: *session.Error}
+		}
+		return linkState.NewStatePending()
+	}
+	if !link.CredentialSignatureProof {
+		return linkState.NewStatePendingPublish()
+	}
+	var description string
+	if link.Schema != nil {
+		description = link.Schema.Type
+	}
+	return linkState.NewStateDone(&linkState.QRCodeMessage{
+		ID:       uuid.NewString(),
+		Typ:      "application/iden3comm-plain-json",
+		Type:     linkState.CredentialOfferMessageType,
+		ThreadID: uuid.NewString(),
+		Body: linkState.CredentialsLinkMessageBody{
+			URL: fmt.Sprintf("%s/v1/agent", hostURL),
+			Credentials: []linkState.CredentialLink{{
+				ID:          session.ClaimID.String(),
+				Description: description,
+			}},
+		},
+		From: session.IssuerDID.String(),
+		To:   *session.UserDID,
+	})
+}
+
 func (ls *Link) validate(ctx context.Context, link *domain.Link) error {
-	if link.ValidUntil != nil && time.Now().UTC().After(*link.ValidUntil) {
+	if link.ValidUntil != nil && ls.clock.Now().After(*link.ValidUntil) {
 		log.Debug(ctx, "cannot issue a credential for an expired link")
 		return ErrLinkAlreadyExpired
 	}
@@ -336,6 +532,23 @@ func (ls *Link) validate(ctx context.Context, link *domain.Link) error {
 	return nil
 }
 
-func (ls *Link) validateCredentialSubjectAgainstSchema(ctx context.Context, cSubject domain.CredentialSubject, schemaDB *domain.Schema) error {
-	return jsonschema.ValidateCredentialSubject(ctx, ls.loaderFactory(schemaDB.URL), schemaDB.Type, cSubject)
+// validateCredentialSubjectAgainstSchema returns the credential subject of a link converted to the attribute types of
+// its schema with the warnings of the validation mode, or ErrInvalidCredentialSubject when it doesn't match them
+func (ls *Link) validateCredentialSubjectAgainstSchema(ctx context.Context, cSubject domain.CredentialSubject, schemaDB *domain.Schema, mode domain.ValidationMode) (domain.CredentialSubject, []string, error) {
+	schema, err := jsonschema.LoadForType(ctx, ls.loaderFactory(schemaDB.URL), schemaDB.URL, schemaDB.Type)
+	if err != nil {
+		log.Error(ctx, "loading jsonschema", "err", err, "jsonschema", schemaDB.URL)
+		return nil, nil, ErrLoadingSchema
+	}
+	converted, warnings, err := schema.ValidateAndConvert(cSubject, mode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidCredentialSubject, err)
+	}
+	if err := schema.ValidateSubject(ctx, converted, false); err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidCredentialSubject, err)
+	}
+	if err := jsonschema.ValidateCredentialSubject(ctx, ls.loaderFactory(schemaDB.URL), schemaDB.URL, schemaDB.Type, converted); err != nil {
+		return nil, nil, ErrParseClaim
+	}
+	return converted, warnings, nil
 }
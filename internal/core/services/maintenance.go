@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+type maintenance struct {
+	repository ports.MaintenanceRepository
+	storage    *db.Storage
+	retryAfter time.Duration
+	refresh    time.Duration
+	clock      clock.Clock
+
+	mu        sync.Mutex
+	current   *domain.Maintenance
+	fetchedAt time.Time
+}
+
+// NewMaintenance returns the service that keeps the maintenance mode of the node. The mode is kept in the database so
+// every server of the node sees it, and each server reads it again after refresh. retryAfter is the Retry-After of the
+// rejected requests when the operator doesn't set one.
+func NewMaintenance(storage *db.Storage, retryAfter time.Duration, refresh time.Duration, clk clock.Clock) ports.MaintenanceService {
+	return &maintenance{
+		repository: repositories.NewMaintenance(),
+		storage:    storage,
+		retryAfter: retryAfter,
+		refresh:    refresh,
+		clock:      clock.OrSystem(clk),
+	}
+}
+
+// Get returns the maintenance mode of the node, with the configured retry after when the operator didn't set one
+func (m *maintenance) Get(ctx context.Context) (*domain.Maintenance, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.current != nil && m.clock.Now().Sub(m.fetchedAt) < m.refresh {
+		return m.current, nil
+	}
+	current, err := m.repository.Get(ctx, m.storage.Pgx)
+	if err != nil {
+		return nil, err
+	}
+	m.set(current)
+	return current, nil
+}
+
+// Update switches the maintenance mode on or off. A zero retryAfter uses the configured one.
+func (m *maintenance) Update(ctx context.Context, enabled bool, message string, retryAfter time.Duration) (*domain.Maintenance, error) {
+	updated := &domain.Maintenance{
+		Enabled:    enabled,
+		Message:    message,
+		RetryAfter: retryAfter,
+		UpdatedAt:  m.clock.Now(),
+	}
+	if !enabled {
+		updated.Message, updated.RetryAfter = "", 0
+	}
+	if err := m.repository.Save(ctx, m.storage.Pgx, updated); err != nil {
+		return nil, err
+	}
+	log.Info(ctx, "maintenance mode updated", "enabled", enabled, "message", message)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set(updated)
+	return updated, nil
+}
+
+// set caches the maintenance mode, filling the configured retry after
+func (m *maintenance) set(current *domain.Maintenance) {
+	if current.RetryAfter == 0 {
+		current.RetryAfter = m.retryAfter
+	}
+	m.current = current
+	m.fetchedAt = m.clock.Now()
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+type metering struct {
+	repository ports.MeteringRepository
+	storage    *db.Storage
+}
+
+// NewMetering returns the service that counts the billable operations of the issuers of the node
+func NewMetering(storage *db.Storage) ports.MeteringService {
+	return &metering{
+		repository: repositories.NewMetering(),
+		storage:    storage,
+	}
+}
+
+// Record counts an operation of the issuer, done now. It is counted for the delegated api key of the request, if any.
+func (m *metering) Record(ctx context.Context, issuerDID core.DID, operation domain.MeteredOperation) error {
+	return m.repository.Add(ctx, m.storage.Pgx, issuerDID, apiKeyIDFromContext(ctx), operation, time.Now())
+}
+
+// GetMonthly returns the operations of every issuer and api key per month in the period
+func (m *metering) GetMonthly(ctx context.Context, period domain.MeteringPeriod) ([]domain.MeteringMonth, error) {
+	return m.repository.GetMonthly(ctx, m.storage.Pgx, period)
+}
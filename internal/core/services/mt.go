@@ -8,6 +8,7 @@ import (
 	core "github.com/iden3/go-iden3-core"
 	sql "github.com/iden3/go-merkletree-sql/db/pgx/v2"
 	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/jackc/pgx/v4"
 	"github.com/mr-tron/base58"
 	"github.com/pkg/errors"
 
@@ -27,6 +28,9 @@ const (
 	mtTypesCount        = 3
 
 	mtDepth = 40
+
+	// maxCachedNodes bounds the memory used by the node cache of the trees of an identity
+	maxCachedNodes = 100_000
 )
 
 var (
@@ -93,7 +97,7 @@ func (mts *mtService) GetIdentityMerkleTrees(ctx context.Context, conn db.Querie
 			return nil, errNotFound
 		}
 		imtModels[mtType] = imt
-		treeStorage := sql.NewSqlStorage(conn, imt.ID)
+		treeStorage := newNodeCacheStorage(sql.NewSqlStorage(conn, imt.ID))
 		tree, err := merkletree.NewMerkleTree(ctx, treeStorage, mtDepth)
 		if err != nil {
 			return nil, err
@@ -109,6 +113,58 @@ func (mts *mtService) GetIdentityMerkleTrees(ctx context.Context, conn db.Querie
 	return imTrees, nil
 }
 
+// GetIdentityMerkleTreesForUpdate returns the merkle trees of the identity after locking them until the transaction ends,
+// so updates of the same identity are serialized while the trees of different identities are updated concurrently.
+func (mts *mtService) GetIdentityMerkleTreesForUpdate(ctx context.Context, tx pgx.Tx, identifier *core.DID) (*domain.IdentityMerkleTrees, error) {
+	if err := mts.imtRepo.Lock(ctx, tx, identifier); err != nil {
+		return nil, fmt.Errorf("error locking merkle trees: %w", err)
+	}
+	return mts.GetIdentityMerkleTrees(ctx, tx, identifier)
+}
+
+// nodeCacheStorage keeps the nodes read or written through it in memory. Nodes are stored by the hash of their
+// content, so a cached node never gets stale and only the root is always read from the underlying storage.
+// Consecutive insertions into a tree read back the nodes written by the previous ones, this avoids those queries.
+type nodeCacheStorage struct {
+	merkletree.Storage
+	nodes map[merkletree.Hash]*merkletree.Node
+}
+
+func newNodeCacheStorage(storage merkletree.Storage) *nodeCacheStorage {
+	return &nodeCacheStorage{Storage: storage, nodes: make(map[merkletree.Hash]*merkletree.Node)}
+}
+
+func (s *nodeCacheStorage) Get(ctx context.Context, key []byte) (*merkletree.Node, error) {
+	var k merkletree.Hash
+	copy(k[:], key)
+	if node, ok := s.nodes[k]; ok {
+		return node, nil
+	}
+	node, err := s.Storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	s.cache(k, node)
+	return node, nil
+}
+
+func (s *nodeCacheStorage) Put(ctx context.Context, key []byte, node *merkletree.Node) error {
+	if err := s.Storage.Put(ctx, key, node); err != nil {
+		return err
+	}
+	var k merkletree.Hash
+	copy(k[:], key)
+	s.cache(k, node)
+	return nil
+}
+
+func (s *nodeCacheStorage) cache(key merkletree.Hash, node *merkletree.Node) {
+	if len(s.nodes) >= maxCachedNodes {
+		s.nodes = make(map[merkletree.Hash]*merkletree.Node)
+	}
+	s.nodes[key] = node
+}
+
 func findByType(mts []domain.IdentityMerkleTree, tp uint16) *domain.IdentityMerkleTree {
 	for i := range mts {
 		if mts[i].Type == tp {
@@ -51,6 +51,17 @@ func (n *notification) SendCreateConnectionNotification(ctx context.Context, e p
 	return n.sendCreateConnectionNotification(ctx, cEvent.IssuerID, cEvent.ConnectionID)
 }
 
+// SendCredentialOffer pushes an offer of the credentials to the devices of the connection and returns the result for every device
+func (n *notification) SendCredentialOffer(ctx context.Context, conn *domain.Connection, credentials ...*domain.Claim) (*domain.UserNotificationResult, error) {
+	credOfferBytes, subjectDIDDoc, err := getCredentialOfferData(conn, credentials...)
+	if err != nil {
+		log.Error(ctx, "sendCredentialOffer: getCredentialOfferData", "err", err.Error(), "connectionID", conn.ID)
+		return nil, err
+	}
+
+	return n.notificationGateway.Notify(ctx, credOfferBytes, subjectDIDDoc)
+}
+
 func (n *notification) sendCreateCredentialNotification(ctx context.Context, issuerID string, credIDs []string) error {
 	issuerDID, err := core.ParseDID(issuerID)
 	if err != nil {
@@ -127,7 +138,7 @@ func (n *notification) sendCreateConnectionNotification(ctx context.Context, iss
 		return err
 	}
 
-	credentials, err := n.credService.GetAll(ctx, conn.IssuerDID, &ports.ClaimsFilter{Subject: conn.UserDID.String(), Proofs: []verifiable.ProofType{domain.AnyProofType}})
+	credentials, err := n.credService.GetAll(ctx, conn.IssuerDID, &ports.ClaimsFilter{Subject: conn.UserDID.String(), Proofs: []verifiable.ProofType{domain.AnyProofType}, PII: true})
 	if err != nil {
 		log.Error(ctx, "sendCreateConnectionNotification: failed to retrieve the connection credentials", "err", err.Error(), "issuerID", issuerID, "connectionID", connID)
 		return err
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-merkletree-sql/v2"
+	proof "github.com/iden3/merkletree-proof"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+// rhsCheckTimeout bounds the read of a state in the reverse hash service
+const rhsCheckTimeout = 10 * time.Second
+
+// operatorAlerts counts the alerts by kind: the raised, resolved and failed to send ones, and the firing ones.
+// They are published with the rest of the expvar variables.
+var operatorAlerts = expvar.NewMap("operator_alerts")
+
+// RHSStateReader returns the children of a state in the reverse hash service of rhsURL: the roots of the claims, the
+// revocations and the roots trees
+type RHSStateReader func(ctx context.Context, rhsURL string, state *merkletree.Hash) ([]*merkletree.Hash, error)
+
+// OperatorAlertsConfig sets the checks of the operator alerts
+type OperatorAlertsConfig struct {
+	// Repeat is how often an alert is sent again while it lasts
+	Repeat time.Duration
+	// BacklogSize is how many credentials of an identity can wait for the publication of a state. 0 disables the check.
+	BacklogSize int
+	// BacklogAge is how long a state transaction can wait for its confirmation. 0 disables the check.
+	BacklogAge time.Duration
+	// RHSURLs are the reverse hash services of the networks. Without them the divergences are not checked.
+	RHSURLs map[string]string
+	// RHSReader reads the states of the reverse hash services, with their http client when it is nil
+	RHSReader RHSStateReader
+	// KeyStoreCheck returns an error when the key store can't be used. Nil doesn't check it.
+	KeyStoreCheck func(ctx context.Context) error
+	// Clock is the time source of the alerts, the system clock when nil
+	Clock clock.Clock
+}
+
+// alertCheck returns the alerts of a kind that are raised now
+type alertCheck struct {
+	kind  domain.AlertKind
+	check func(ctx context.Context) ([]domain.Alert, error)
+}
+
+type operatorAlert struct {
+	cfg        OperatorAlertsConfig
+	storage    *db.Storage
+	states     ports.IdentityStateRepository
+	operations ports.OperationsRepository
+	channels   []ports.AlertChannel
+
+	mu     sync.Mutex
+	firing map[string]domain.Alert
+}
+
+// NewOperatorAlerts returns the service that checks the failures of the background work of the node, and sends an
+// alert to each channel when one is raised, every cfg.Repeat while it lasts and when it is resolved. The firing
+// alerts are kept in memory, so they are sent again after a restart.
+func NewOperatorAlerts(storage *db.Storage, cfg OperatorAlertsConfig, channels ...ports.AlertChannel) ports.OperatorAlertService {
+	cfg.Clock = clock.OrSystem(cfg.Clock)
+	if cfg.RHSReader == nil {
+		cfg.RHSReader = readRHSState
+	}
+	return &operatorAlert{
+		cfg:        cfg,
+		storage:    storage,
+		states:     repositories.NewIdentityState(),
+		operations: repositories.NewOperations(),
+		channels:   channels,
+		firing:     make(map[string]domain.Alert),
+	}
+}
+
+// Check raises the alerts of the failures found, and resolves the firing ones that are not found anymore. The alerts
+// of a check that fails are kept as they are.
+func (o *operatorAlert) Check(ctx context.Context) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := o.cfg.Clock.Now()
+	checked := make(map[domain.AlertKind]bool)
+	raised := make(map[string]domain.Alert)
+	for _, c := range o.checks() {
+		alerts, err := c.check(ctx)
+		if err != nil {
+			log.Error(ctx, "checking the operator alerts", "err", err, "kind", c.kind)
+			continue
+		}
+		checked[c.kind] = true
+		for _, alert := range alerts {
+			raised[alert.Key()] = alert
+		}
+	}
+
+	for key, alert := range raised {
+		previous, firing := o.firing[key]
+		switch {
+		case !firing:
+			alert.Since = now
+			operatorAlerts.Add(string(alert.Kind)+".raised", 1)
+		case now.Sub(previous.At) >= o.cfg.Repeat:
+			alert.Since = previous.Since
+		default:
+			continue
+		}
+		alert.At = now
+		o.send(ctx, alert)
+		o.firing[key] = alert
+	}
+	for key, alert := range o.firing {
+		if _, found := raised[key]; found || !checked[alert.Kind] {
+			continue
+		}
+		alert.Resolved = true
+		alert.Message = "resolved: " + alert.Message
+		alert.At = now
+		operatorAlerts.Add(string(alert.Kind)+".resolved", 1)
+		o.send(ctx, alert)
+		delete(o.firing, key)
+	}
+
+	firing := make(map[domain.AlertKind]int64)
+	for _, alert := range o.firing {
+		firing[alert.Kind]++
+	}
+	for _, kind := range []domain.AlertKind{domain.AlertPublishFailed, domain.AlertRHSDivergence, domain.AlertKeyStoreUnavailable, domain.AlertBacklog} {
+		gauge := new(expvar.Int)
+		gauge.Set(firing[kind])
+		operatorAlerts.Set(string(kind)+".firing", gauge)
+	}
+}
+
+func (o *operatorAlert) send(ctx context.Context, alert domain.Alert) {
+	log.Warn(ctx, "operator alert", "kind", alert.Kind, "subject", alert.Subject, "message", alert.Message, "resolved", alert.Resolved)
+	for _, channel := range o.channels {
+		if err := channel.Send(ctx, alert); err != nil {
+			operatorAlerts.Add(string(alert.Kind)+".send_error", 1)
+			log.Error(ctx, "sending the operator alert", "err", err, "channel", channel.Name(), "kind", alert.Kind, "subject", alert.Subject)
+		}
+	}
+}
+
+func (o *operatorAlert) checks() []alertCheck {
+	checks := []alertCheck{{kind: domain.AlertPublishFailed, check: o.checkFailedStates}}
+	if o.cfg.BacklogSize > 0 || o.cfg.BacklogAge > 0 {
+		checks = append(checks, alertCheck{kind: domain.AlertBacklog, check: o.checkBacklogs})
+	}
+	if len(o.cfg.RHSURLs) > 0 {
+		checks = append(checks, alertCheck{kind: domain.AlertRHSDivergence, check: o.checkRHS})
+	}
+	if o.cfg.KeyStoreCheck != nil {
+		checks = append(checks, alertCheck{kind: domain.AlertKeyStoreUnavailable, check: o.checkKeyStore})
+	}
+	return checks
+}
+
+// checkFailedStates raises an alert for each identity with a failed state transaction
+func (o *operatorAlert) checkFailedStates(ctx context.Context) ([]domain.Alert, error) {
+	states, err := o.states.GetStatesByStatus(ctx, o.storage.Pgx, domain.StatusFailed)
+	if err != nil {
+		return nil, err
+	}
+	alerts := make([]domain.Alert, 0, len(states))
+	for _, state := range states {
+		alerts = append(alerts, domain.Alert{
+			Kind:    domain.AlertPublishFailed,
+			Subject: state.Identifier,
+			Message: fmt.Sprintf("the transaction %s of the state %s failed, publish the state again", common.FromPointer(state.TxID), common.FromPointer(state.State)),
+		})
+	}
+	return alerts, nil
+}
+
+// checkBacklogs raises an alert for each identity with too many credentials waiting for a state, and for each one
+// with a state transaction waiting for its confirmation for too long
+func (o *operatorAlert) checkBacklogs(ctx context.Context) ([]domain.Alert, error) {
+	var alerts []domain.Alert
+	if o.cfg.BacklogSize > 0 {
+		unpublished, err := o.operations.UnpublishedCredentials(ctx, o.storage.Pgx, o.cfg.BacklogSize)
+		if err != nil {
+			return nil, err
+		}
+		for issuer, count := range unpublished {
+			alerts = append(alerts, domain.Alert{
+				Kind:    domain.AlertBacklog,
+				Subject: "credentials " + issuer,
+				Message: fmt.Sprintf("%d credentials are waiting for the publication of a state", count),
+			})
+		}
+	}
+	if o.cfg.BacklogAge > 0 {
+		states, err := o.states.GetStatesByStatus(ctx, o.storage.Pgx, domain.StatusTransacted)
+		if err != nil {
+			return nil, err
+		}
+		now := o.cfg.Clock.Now()
+		for _, state := range states {
+			if waiting := now.Sub(state.ModifiedAt); waiting > o.cfg.BacklogAge {
+				alerts = append(alerts, domain.Alert{
+					Kind:    domain.AlertBacklog,
+					Subject: "transactions " + state.Identifier,
+					Message: fmt.Sprintf("the transaction %s has waited for its confirmation for %s", common.FromPointer(state.TxID), waiting.Round(time.Second)),
+				})
+			}
+		}
+	}
+	return alerts, nil
+}
+
+// checkRHS raises an alert for each identity whose last confirmed state is not the one of the reverse hash service of
+// its network
+func (o *operatorAlert) checkRHS(ctx context.Context) ([]domain.Alert, error) {
+	states, err := o.operations.LatestConfirmedStates(ctx, o.storage.Pgx)
+	if err != nil {
+		return nil, err
+	}
+	var alerts []domain.Alert
+	for _, state := range states {
+		did, err := core.ParseDID(state.Identifier)
+		if err != nil {
+			log.Warn(ctx, "invalid identity", "err", err, "identifier", state.Identifier)
+			continue
+		}
+		rhsURL, ok := o.cfg.RHSURLs[common.DIDNetwork(did)]
+		if !ok || state.State == nil {
+			continue
+		}
+		if divergence := o.rhsDivergence(ctx, rhsURL, state); divergence != "" {
+			alerts = append(alerts, domain.Alert{Kind: domain.AlertRHSDivergence, Subject: state.Identifier, Message: divergence})
+		}
+	}
+	return alerts, nil
+}
+
+// rhsDivergence tells how the state differs in the reverse hash service, empty when it doesn't
+func (o *operatorAlert) rhsDivergence(ctx context.Context, rhsURL string, state domain.IdentityState) string {
+	stateHash, err := merkletree.NewHashFromHex(*state.State)
+	if err != nil {
+		return fmt.Sprintf("the state %s is not a valid hash", *state.State)
+	}
+	ctx, cancel := context.WithTimeout(ctx, rhsCheckTimeout)
+	defer cancel()
+	children, err := o.cfg.RHSReader(ctx, rhsURL, stateHash)
+	if err != nil {
+		return fmt.Sprintf("the reverse hash service %s can't return the state %s: %s", rhsURL, *state.State, err)
+	}
+	expected := []*string{state.ClaimsTreeRoot, state.RevocationTreeRoot, state.RootOfRoots}
+	if len(children) != len(expected) {
+		return fmt.Sprintf("the state %s has %d children in the reverse hash service %s", *state.State, len(children), rhsURL)
+	}
+	for i, root := range expected {
+		if root != nil && children[i].Hex() != *root {
+			return fmt.Sprintf("the roots of the state %s are different in the reverse hash service %s", *state.State, rhsURL)
+		}
+	}
+	return ""
+}
+
+// checkKeyStore raises an alert when the key store can't be used
+func (o *operatorAlert) checkKeyStore(ctx context.Context) ([]domain.Alert, error) {
+	if err := o.cfg.KeyStoreCheck(ctx); err != nil {
+		return []domain.Alert{{Kind: domain.AlertKeyStoreUnavailable, Subject: "key store", Message: err.Error()}}, nil
+	}
+	return nil, nil
+}
+
+func readRHSState(ctx context.Context, rhsURL string, state *merkletree.Hash) ([]*merkletree.Hash, error) {
+	rhsCli := proof.HTTPReverseHashCli{
+		URL:         strings.TrimSuffix(rhsURL, "/node"),
+		HTTPTimeout: rhsCheckTimeout,
+	}
+	node, err := rhsCli.GetNode(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	return node.Children, nil
+}
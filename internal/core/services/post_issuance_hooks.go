@@ -0,0 +1,244 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/event"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+)
+
+const (
+	// postIssuanceHookTimeout bounds every call to a post-issuance hook
+	postIssuanceHookTimeout = 10 * time.Second
+	// postIssuanceHookMaxResponse is the size of the body answered by a post-issuance hook that is read
+	postIssuanceHookMaxResponse = 64 << 10
+)
+
+// postIssuanceHookRequest is the body POSTed to the post-issuance hooks
+type postIssuanceHookRequest struct {
+	Hook         string                   `json:"hook"`
+	CredentialID string                   `json:"credentialId"`
+	Issuer       string                   `json:"issuer"`
+	Subject      string                   `json:"subject,omitempty"`
+	Schema       string                   `json:"schema"`
+	Type         string                   `json:"type"`
+	Credential   verifiable.W3CCredential `json:"credential"`
+	// Test is true for the sample credentials sent by TestPostIssuanceHook
+	Test bool `json:"test,omitempty"`
+}
+
+// postIssuanceHookResponse is the optional body answered by the post-issuance hooks
+type postIssuanceHookResponse struct {
+	Reference string `json:"reference"`
+}
+
+// RunPostIssuanceHooks calls the sync post-issuance hooks of the schema of an issued claim and queues the calls to its
+// queued hooks. It must be called once the claim is committed. The failures are logged and don't affect the issuance.
+func (c *claim) RunPostIssuanceHooks(ctx context.Context, issuerDID core.DID, claim *domain.Claim) {
+	hooks, err := repositories.NewSchema(*c.storage).GetPostIssuanceHooksByURL(ctx, issuerDID, claim.SchemaURL)
+	if err != nil {
+		log.Error(ctx, "getting the schema post-issuance hooks", "err", err, "schema", claim.SchemaURL)
+		return
+	}
+	for i := range hooks {
+		hook := &hooks[i]
+		if hook.Mode == domain.PostIssuanceHookQueued {
+			err := c.publisher.Publish(ctx, event.PostIssuanceHookEvent, &event.PostIssuanceHook{CredentialID: claim.ID.String(), IssuerID: issuerDID.String(), Hook: hook.Name})
+			if err != nil {
+				log.Error(ctx, "publish PostIssuanceHookEvent", "err", err, "credential", claim.ID.String(), "hook", hook.Name)
+			}
+			continue
+		}
+		if err := c.callPostIssuanceHook(ctx, issuerDID, hook, claim); err != nil {
+			log.Error(ctx, "calling post-issuance hook", "err", err, "credential", claim.ID.String(), "hook", hook.Name)
+		}
+	}
+}
+
+// RunQueuedPostIssuanceHook calls the queued post-issuance hook of a PostIssuanceHookEvent
+func (c *claim) RunQueuedPostIssuanceHook(ctx context.Context, e pubsub.Message) error {
+	var hookEvent event.PostIssuanceHook
+	if err := hookEvent.Unmarshal(e); err != nil {
+		return errors.New("runQueuedPostIssuanceHook unexpected data type")
+	}
+	issuerDID, err := core.ParseDID(hookEvent.IssuerID)
+	if err != nil {
+		return fmt.Errorf("runQueuedPostIssuanceHook invalid issuer: %w", err)
+	}
+	claimID, err := uuid.Parse(hookEvent.CredentialID)
+	if err != nil {
+		return fmt.Errorf("runQueuedPostIssuanceHook invalid credential id: %w", err)
+	}
+
+	claim, err := c.GetByID(ctx, issuerDID, claimID)
+	if err != nil {
+		return err
+	}
+	hooks, err := repositories.NewSchema(*c.storage).GetPostIssuanceHooksByURL(ctx, *issuerDID, claim.SchemaURL)
+	if err != nil {
+		return err
+	}
+	hook, found := hooks.Get(hookEvent.Hook)
+	if !found {
+		log.Info(ctx, "the post-issuance hook was removed from the schema", "credential", hookEvent.CredentialID, "hook", hookEvent.Hook)
+		return nil
+	}
+	if err := c.callPostIssuanceHook(ctx, *issuerDID, hook, claim); err != nil {
+		log.Error(ctx, "calling queued post-issuance hook", "err", err, "credential", hookEvent.CredentialID, "hook", hook.Name)
+		return err
+	}
+	return nil
+}
+
+// callPostIssuanceHook POSTs the credential to the hook and keeps the reference it answers in the claim
+func (c *claim) callPostIssuanceHook(ctx context.Context, issuerDID core.DID, hook *domain.PostIssuanceHook, claim *domain.Claim) error {
+	vc, err := claim.GetVerifiableCredential()
+	if err != nil {
+		return err
+	}
+	_, respBody, err := c.deliverPostIssuanceHook(ctx, issuerDID, hook, postIssuanceHookRequest{
+		Hook:         hook.Name,
+		CredentialID: claim.ID.String(),
+		Issuer:       issuerDID.String(),
+		Subject:      claim.OtherIdentifier,
+		Schema:       claim.SchemaURL,
+		Type:         claim.SchemaType,
+		Credential:   vc,
+	})
+	if err != nil || len(bytes.TrimSpace(respBody)) == 0 {
+		return err
+	}
+	var hookResp postIssuanceHookResponse
+	if err := json.Unmarshal(respBody, &hookResp); err != nil {
+		return fmt.Errorf("post-issuance hook %s: malformed response: %w", hook.Name, err)
+	}
+	if hookResp.Reference == "" {
+		return nil
+	}
+	if claim.ExternalReferences == nil {
+		claim.ExternalReferences = make(map[string]string, 1)
+	}
+	claim.ExternalReferences[hook.Name] = hookResp.Reference
+	return c.icRepo.SetExternalReference(ctx, c.storage.Pgx, claim.ID, hook.Name, hookResp.Reference)
+}
+
+// TestPostIssuanceHook POSTs a sample credential of the schema to one of its hooks, flagged as a test. The returned
+// delivery tells how the hook answered, the failures of the hook are not an error. The answered reference is not kept.
+func (c *claim) TestPostIssuanceHook(ctx context.Context, issuerDID core.DID, schemaID uuid.UUID, hookName string) (*domain.HookDelivery, error) {
+	schema, err := repositories.NewSchema(*c.storage).GetByID(ctx, issuerDID, schemaID)
+	if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	hook, found := schema.PostIssuanceHooks.Get(hookName)
+	if !found {
+		return nil, ErrPostIssuanceHookNotFound
+	}
+
+	credentialID := uuid.New()
+	issuanceDate := time.Now()
+	delivery, _, err := c.deliverPostIssuanceHook(ctx, issuerDID, hook, postIssuanceHookRequest{
+		Hook:         hook.Name,
+		CredentialID: credentialID.String(),
+		Issuer:       issuerDID.String(),
+		Schema:       schema.URL,
+		Type:         schema.Type,
+		Credential: verifiable.W3CCredential{
+			ID:                c.buildCredentialID(issuerDID, credentialID, false),
+			Context:           []string{verifiable.JSONLDSchemaW3CCredential2018, verifiable.JSONLDSchemaIden3Credential},
+			Type:              []string{verifiable.TypeW3CVerifiableCredential, schema.Type},
+			IssuanceDate:      &issuanceDate,
+			CredentialSubject: map[string]interface{}{"type": schema.Type},
+			Issuer:            issuerDID.String(),
+			CredentialSchema: verifiable.CredentialSchema{
+				ID:   schema.URL,
+				Type: verifiable.JSONSchemaValidator2018,
+			},
+		},
+		Test: true,
+	})
+	if delivery == nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// GetHookDeliveries returns the last calls to the post-issuance hooks of the issuer, the newest first. It is empty
+// unless the capture is enabled with ClaimCfg.HookCaptureSize.
+func (c *claim) GetHookDeliveries(ctx context.Context, issuerDID core.DID) ([]domain.HookDelivery, error) {
+	return c.hookDeliveryRepository.GetAll(ctx, c.storage.Reader(), issuerDID)
+}
+
+// deliverPostIssuanceHook POSTs the request to the hook and returns the call and the body answered by the hook. The
+// call is captured when ClaimCfg.HookCaptureSize is set. The returned delivery is nil when the request can't be sent.
+func (c *claim) deliverPostIssuanceHook(ctx context.Context, issuerDID core.DID, hook *domain.PostIssuanceHook, hookReq postIssuanceHookRequest) (*domain.HookDelivery, []byte, error) {
+	body, err := json.Marshal(hookReq)
+	if err != nil {
+		return nil, nil, err
+	}
+	hookCtx, cancel := context.WithTimeout(ctx, postIssuanceHookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(hookCtx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	delivery := &domain.HookDelivery{
+		ID:        uuid.New(),
+		IssuerDID: issuerDID,
+		Hook:      hook.Name,
+		URL:       hook.URL,
+		Test:      hookReq.Test,
+		Request:   body,
+		CreatedAt: time.Now(),
+	}
+	respBody, err := sendPostIssuanceHook(&http.Client{Transport: c.cfg.HookTransport}, req, hook.Name, delivery)
+	delivery.Duration = time.Since(delivery.CreatedAt)
+	if err != nil {
+		delivery.Error = common.ToPointer(err.Error())
+	}
+	if c.cfg.HookCaptureSize > 0 {
+		if err := c.hookDeliveryRepository.Save(ctx, c.storage.Pgx, delivery, c.cfg.HookCaptureSize); err != nil {
+			log.Error(ctx, "capturing post-issuance hook call", "err", err, "hook", hook.Name)
+		}
+	}
+	return delivery, respBody, err
+}
+
+// sendPostIssuanceHook sends the request with client and keeps the status and the body answered by the hook in
+// delivery
+func sendPostIssuanceHook(client *http.Client, req *http.Request, hookName string, delivery *domain.HookDelivery) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	delivery.Status = common.ToPointer(resp.StatusCode)
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, postIssuanceHookMaxResponse))
+	if err != nil {
+		return nil, err
+	}
+	delivery.Response = common.ToPointer(string(respBody))
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("post-issuance hook %s: unexpected status %d", hookName, resp.StatusCode)
+	}
+	return respBody, nil
+}
@@ -51,12 +51,12 @@ type Proof struct {
 	claimsRepository ports.ClaimsRepository
 	keyProvider      *kms.KMS
 	storage          *db.Storage
-	stateContract    *abi.State
+	stateContracts   ports.StateContracts
 	schemaLoader     loader.Factory
 }
 
 // NewProofService init proof service
-func NewProofService(claimSrv ports.ClaimsService, revocationSrv ports.RevocationService, identitySrv ports.IdentityService, mtService ports.MtService, claimsRepository ports.ClaimsRepository, keyProvider *kms.KMS, storage *db.Storage, stateContract *abi.State, ld loader.Factory) ports.ProofService {
+func NewProofService(claimSrv ports.ClaimsService, revocationSrv ports.RevocationService, identitySrv ports.IdentityService, mtService ports.MtService, claimsRepository ports.ClaimsRepository, keyProvider *kms.KMS, storage *db.Storage, stateContracts ports.StateContracts, ld loader.Factory) ports.ProofService {
 	return &Proof{
 		claimSrv:         claimSrv,
 		revocationSrv:    revocationSrv,
@@ -65,7 +65,7 @@ func NewProofService(claimSrv ports.ClaimsService, revocationSrv ports.Revocatio
 		claimsRepository: claimsRepository,
 		keyProvider:      keyProvider,
 		storage:          storage,
-		stateContract:    stateContract,
+		stateContracts:   stateContracts,
 		schemaLoader:     ld,
 	}
 }
@@ -269,7 +269,7 @@ func (p *Proof) findClaimForQuery(ctx context.Context, identifier *core.DID, que
 
 	// TODO "query_value":    value,
 	// TODO "query_operator": operator,
-	filter := &ports.ClaimsFilter{SchemaType: query.SchemaType()}
+	filter := &ports.ClaimsFilter{SchemaType: query.SchemaType(), PII: true}
 	if !query.SkipClaimRevocationCheck {
 		filter.Revoked = common.ToPointer(false)
 	}
@@ -308,7 +308,7 @@ func (p *Proof) checkRevocationStatus(ctx context.Context, claim *domain.Claim)
 		if errIn != nil {
 			return nil, err
 		}
-		if common.CheckGenesisStateDID(issuerDID, state.BigInt()) != nil {
+		if checkGenesisState(issuerDID, state.BigInt()) != nil {
 			return nil, errors.New("issuer identity is not genesis and not published")
 		}
 
@@ -484,7 +484,7 @@ func (p *Proof) callNonRevProof(ctx context.Context, issuerData verifiable.Issue
 		if errIn != nil {
 			return circuits.MTProof{}, err
 		}
-		if common.CheckGenesisStateDID(issuerDID, state.BigInt()) != nil {
+		if checkGenesisState(issuerDID, state.BigInt()) != nil {
 			return circuits.MTProof{}, errors.New("issuer identity is not genesis and not published")
 		}
 		return circuits.MTProof{
@@ -529,7 +529,11 @@ func (p *Proof) prepareAuthV2Circuit(ctx context.Context, identifier *core.DID,
 	if err != nil {
 		return circuits.AuthV2Inputs{}, err
 	}
-	globalTree, err := populateGlobalTree(ctx, identifier, p.stateContract)
+	stateContract, err := p.stateContracts.StateContract(identifier)
+	if err != nil {
+		return circuits.AuthV2Inputs{}, err
+	}
+	globalTree, err := populateGlobalTree(ctx, identifier, stateContract)
 	if err != nil {
 		return circuits.AuthV2Inputs{}, err
 	}
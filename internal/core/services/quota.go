@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+var (
+	ErrQuotaExceeded = errors.New("quota exceeded")            // ErrQuotaExceeded the identity already uses the maximum of a resource allowed by its quotas
+	ErrInvalidQuota  = errors.New("quotas cannot be negative") // ErrInvalidQuota a quota override is negative
+)
+
+// quotaNames are the names of the resources in the quota errors
+var quotaNames = map[domain.QuotaResource]string{
+	domain.QuotaActiveLinks:       "active links",
+	domain.QuotaCredentialsPerDay: "credentials issued today",
+	domain.QuotaSchemas:           "schemas",
+}
+
+type quota struct {
+	repository ports.QuotaRepository
+	storage    *db.Storage
+	defaults   domain.Quotas
+	clock      clock.Clock
+}
+
+// NewQuota returns the service that limits the resources of the identities of the node. defaults are the quotas of
+// the identities without overrides. clk is the time source of the daily quotas, the system clock when nil.
+func NewQuota(storage *db.Storage, defaults domain.Quotas, clk clock.Clock) ports.QuotaService {
+	return &quota{
+		repository: repositories.NewQuota(),
+		storage:    storage,
+		defaults:   defaults,
+		clock:      clock.OrSystem(clk),
+	}
+}
+
+// Get returns the quotas of the identity: the ones of the node with its overrides
+func (q *quota) Get(ctx context.Context, issuerDID core.DID) (*domain.Quotas, error) {
+	overrides, err := q.repository.GetOverrides(ctx, q.storage.Pgx, issuerDID)
+	if errors.Is(err, repositories.ErrIdentityNotFound) {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	quotas := q.defaults.With(*overrides)
+	return &quotas, nil
+}
+
+// Update replaces the quota overrides of the identity and returns its quotas
+func (q *quota) Update(ctx context.Context, issuerDID core.DID, overrides domain.QuotaOverrides) (*domain.Quotas, error) {
+	if !overrides.Valid() {
+		return nil, ErrInvalidQuota
+	}
+	err := q.repository.SaveOverrides(ctx, q.storage.Pgx, issuerDID, overrides)
+	if errors.Is(err, repositories.ErrIdentityNotFound) {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	quotas := q.defaults.With(overrides)
+	return &quotas, nil
+}
+
+// GetUsage returns the usage of the resources of the identity limited by the quotas
+func (q *quota) GetUsage(ctx context.Context, issuerDID core.DID) (*domain.QuotaUsage, error) {
+	quotas, err := q.Get(ctx, issuerDID)
+	if err != nil {
+		return nil, err
+	}
+	usage := &domain.QuotaUsage{Quotas: *quotas}
+	for resource, used := range map[domain.QuotaResource]*int{
+		domain.QuotaActiveLinks:       &usage.ActiveLinks,
+		domain.QuotaCredentialsPerDay: &usage.CredentialsToday,
+		domain.QuotaSchemas:           &usage.Schemas,
+	} {
+		if *used, err = q.count(ctx, q.storage.Pgx, issuerDID, resource); err != nil {
+			return nil, err
+		}
+	}
+	return usage, nil
+}
+
+// Check fails with ErrQuotaExceeded when the identity already uses the maximum of the resource allowed by its quotas,
+// so it can't use one more
+func (q *quota) Check(ctx context.Context, issuerDID core.DID, resource domain.QuotaResource) error {
+	return q.check(ctx, q.storage.Pgx, nil, issuerDID, resource)
+}
+
+// CheckTx works like Check, counting the resource in tx. The uses of the resource by the identity are serialized until
+// the end of tx, so concurrent ones can't exceed the quota.
+func (q *quota) CheckTx(ctx context.Context, tx pgx.Tx, issuerDID core.DID, resource domain.QuotaResource) error {
+	return q.check(ctx, tx, tx, issuerDID, resource)
+}
+
+func (q *quota) check(ctx context.Context, conn db.Querier, tx pgx.Tx, issuerDID core.DID, resource domain.QuotaResource) error {
+	quotas, err := q.Get(ctx, issuerDID)
+	if err != nil {
+		log.Error(ctx, "getting the quotas", "err", err, "resource", resource)
+		return err
+	}
+	limit := quotas.Limit(resource)
+	if limit == 0 {
+		return nil
+	}
+	if tx != nil {
+		if err := q.repository.Lock(ctx, tx, issuerDID, resource); err != nil {
+			return err
+		}
+	}
+	used, err := q.count(ctx, conn, issuerDID, resource)
+	if err != nil {
+		log.Error(ctx, "counting the quota usage", "err", err, "resource", resource)
+		return err
+	}
+	if used >= limit {
+		log.Warn(ctx, "quota exceeded", "resource", resource, "used", used, "limit", limit)
+		return fmt.Errorf("%w: the identity already has %d %s, the maximum allowed", ErrQuotaExceeded, used, quotaNames[resource])
+	}
+	return nil
+}
+
+func (q *quota) count(ctx context.Context, conn db.Querier, issuerDID core.DID, resource domain.QuotaResource) (int, error) {
+	switch resource {
+	case domain.QuotaActiveLinks:
+		return q.repository.CountActiveLinks(ctx, conn, issuerDID, q.clock.Now())
+	case domain.QuotaCredentialsPerDay:
+		return q.repository.CountCredentialsOfDay(ctx, conn, issuerDID, q.clock.Now())
+	case domain.QuotaSchemas:
+		return q.repository.CountSchemas(ctx, conn, issuerDID)
+	}
+	return 0, fmt.Errorf("unknown quota resource %s", resource)
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+var (
+	ErrRegionNotConfigured = errors.New("the node runs in a single region")                                // ErrRegionNotConfigured the server has no region name
+	ErrRegionInRecovery    = errors.New("the database of the region is a replica, promote it first")       // ErrRegionInRecovery the promotion needs a primary database
+	ErrRegionLeaseHeld     = errors.New("another region holds the lease")                                  // ErrRegionLeaseHeld the lease of another region didn't expire
+	ErrRegionPassive       = errors.New("the region is passive, the node is changed by the active region") // ErrRegionPassive the region doesn't hold the lease
+)
+
+type region struct {
+	repository ports.RegionRepository
+	storage    *db.Storage
+	name       string
+	ttl        time.Duration
+	refresh    time.Duration
+	clock      clock.Clock
+
+	mu        sync.Mutex
+	current   *domain.Region
+	fetchedAt time.Time
+}
+
+// NewRegion returns the service that keeps the role of the region of the server, named name, in an active/passive
+// deployment. The lease of the active region is kept in the database, so the passive regions read it from their
+// replica. The active region renews it every refresh and keeps it for ttl after its last renewal, and each server reads
+// it again after refresh. An empty name runs the node in a single region, always active.
+func NewRegion(storage *db.Storage, name string, ttl time.Duration, refresh time.Duration, clk clock.Clock) ports.RegionService {
+	return &region{
+		repository: repositories.NewRegion(),
+		storage:    storage,
+		name:       name,
+		ttl:        ttl,
+		refresh:    refresh,
+		clock:      clock.OrSystem(clk),
+	}
+}
+
+// Get returns the role of the region of the server
+func (r *region) Get(ctx context.Context) (*domain.Region, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current != nil && r.clock.Now().Sub(r.fetchedAt) < r.refresh {
+		return r.current, nil
+	}
+	return r.load(ctx)
+}
+
+// Fence returns ErrRegionPassive unless the region of the server is active. It reads the lease again, for the jobs that
+// change the node outside of the APIs, like the publishing of the states.
+func (r *region) Fence(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current, err := r.load(ctx)
+	if err != nil {
+		return err
+	}
+	if !current.Active {
+		return ErrRegionPassive
+	}
+	return nil
+}
+
+// Renew extends the lease while the region holds it, and takes it when no region did yet, so the first region that
+// runs is the active one. It does nothing when the database of the region is a replica.
+func (r *region) Renew(ctx context.Context) (*domain.Region, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.name == "" {
+		return r.load(ctx)
+	}
+	inRecovery, err := r.repository.InRecovery(ctx, r.storage.Pgx)
+	if err != nil {
+		return nil, err
+	}
+	if !inRecovery {
+		now := r.clock.Now()
+		renewed, err := r.repository.Renew(ctx, r.storage.Pgx, r.name, now.Add(r.ttl), now)
+		if err != nil {
+			return nil, err
+		}
+		if !renewed {
+			taken, err := r.repository.Take(ctx, r.storage.Pgx, &domain.RegionLease{Region: r.name, Epoch: 1, ExpiresAt: now.Add(r.ttl), UpdatedAt: now}, 0)
+			if err != nil {
+				return nil, err
+			}
+			if taken {
+				log.Info(ctx, "region lease taken", "region", r.name)
+			}
+		}
+	}
+	return r.load(ctx)
+}
+
+// Promote makes the region of the server the active one. The database of the region must be the primary one, and it
+// is promoted first when promoteDatabase is set. The lease of another region is only taken after it expires, unless
+// force is set because the other region is known to be down. The epoch of the lease grows, so the servers of the
+// previous active region turn passive when they read it.
+func (r *region) Promote(ctx context.Context, force bool, promoteDatabase bool) (*domain.Region, error) {
+	if r.name == "" {
+		return nil, ErrRegionNotConfigured
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inRecovery, err := r.repository.InRecovery(ctx, r.storage.Pgx)
+	if err != nil {
+		return nil, err
+	}
+	if inRecovery {
+		if !promoteDatabase {
+			return nil, ErrRegionInRecovery
+		}
+		log.Warn(ctx, "promoting the database of the region", "region", r.name)
+		if err := r.repository.PromoteDatabase(ctx, r.storage.Pgx); err != nil {
+			return nil, fmt.Errorf("promoting the database: %w", err)
+		}
+	}
+
+	lease, err := r.repository.Get(ctx, r.storage.Pgx)
+	if err != nil {
+		return nil, err
+	}
+	now := r.clock.Now()
+	if lease != nil && lease.Region == r.name {
+		if _, err := r.repository.Renew(ctx, r.storage.Pgx, r.name, now.Add(r.ttl), now); err != nil {
+			return nil, err
+		}
+		return r.load(ctx)
+	}
+	if lease != nil && now.Before(lease.ExpiresAt) && !force {
+		return nil, fmt.Errorf("%w: %s until %s", ErrRegionLeaseHeld, lease.Region, lease.ExpiresAt.Format(time.RFC3339))
+	}
+
+	promoted := &domain.RegionLease{Region: r.name, Epoch: 1, ExpiresAt: now.Add(r.ttl), UpdatedAt: now}
+	var previousEpoch int64
+	if lease != nil {
+		previousEpoch = lease.Epoch
+		promoted.Epoch = lease.Epoch + 1
+	}
+	taken, err := r.repository.Take(ctx, r.storage.Pgx, promoted, previousEpoch)
+	if err != nil {
+		return nil, err
+	}
+	if !taken {
+		return nil, ErrRegionLeaseHeld
+	}
+	log.Warn(ctx, "region promoted", "region", r.name, "epoch", promoted.Epoch, "force", force)
+	return r.load(ctx)
+}
+
+// Run renews the lease every refresh until the context is done
+func (r *region) Run(ctx context.Context) {
+	if r.name == "" {
+		return
+	}
+	ticker := time.NewTicker(r.refresh)
+	defer ticker.Stop()
+	for {
+		if _, err := r.Renew(ctx); err != nil {
+			log.Error(ctx, "renewing the region lease", "err", err, "region", r.name)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// load reads the lease and the role of the database of the region, and caches the role of the region
+func (r *region) load(ctx context.Context) (*domain.Region, error) {
+	current := &domain.Region{Name: r.name, Active: true}
+	if r.name != "" {
+		inRecovery, err := r.repository.InRecovery(ctx, r.storage.Pgx)
+		if err != nil {
+			return nil, err
+		}
+		lease, err := r.repository.Get(ctx, r.storage.Pgx)
+		if err != nil {
+			return nil, err
+		}
+		current.InRecovery = inRecovery
+		current.Lease = lease
+		current.Active = !inRecovery && lease != nil && lease.Region == r.name && r.clock.Now().Before(lease.ExpiresAt)
+	}
+	r.current = current
+	r.fetchedAt = r.clock.Now()
+	return current, nil
+}
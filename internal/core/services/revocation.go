@@ -32,17 +32,23 @@ type StateStore interface {
 	GetLatestStateByID(ctx context.Context, addr common.Address, id *big.Int) (abi.IStateStateInfo, error)
 }
 
+// StateStores returns the state store and the state contract address of the network of an identity
+type StateStores interface {
+	StateStore(did *core.DID) (StateStore, common.Address, error)
+}
+
 // Revocation TBD
 type Revocation struct {
-	eth      StateStore
-	contract common.Address
+	eth       StateStores
+	transport http.RoundTripper
 }
 
-// NewRevocationService returns the Revocation struct
-func NewRevocationService(ethStore StateStore, contract common.Address) *Revocation {
+// NewRevocationService returns the Revocation struct. The revocation statuses of the issuers are requested with
+// transport, nil requests them with http.DefaultTransport.
+func NewRevocationService(ethStores StateStores, transport http.RoundTripper) *Revocation {
 	return &Revocation{
-		eth:      ethStore,
-		contract: contract,
+		eth:       ethStores,
+		transport: transport,
 	}
 }
 
@@ -50,7 +56,11 @@ func NewRevocationService(ethStore StateStore, contract common.Address) *Revocat
 func (r *Revocation) Status(ctx context.Context, credStatus interface{}, issuerDID *core.DID) (*verifiable.RevocationStatus, error) {
 	switch status := credStatus.(type) {
 	case *verifiable.RHSCredentialStatus:
-		latestStateInfo, err := r.eth.GetLatestStateByID(ctx, r.contract, issuerDID.ID.BigInt())
+		ethStore, contract, err := r.eth.StateStore(issuerDID)
+		if err != nil {
+			return nil, err
+		}
+		latestStateInfo, err := ethStore.GetLatestStateByID(ctx, contract, issuerDID.ID.BigInt())
 		if err != nil && strings.Contains(err.Error(), protocol.ErrStateNotFound.Error()) {
 			return nil, protocol.ErrStateNotFound
 		}
@@ -74,7 +84,7 @@ func (r *Revocation) Status(ctx context.Context, credStatus interface{}, issuerD
 		if err != nil && status.StatusIssuer.Type == verifiable.SparseMerkleTreeProof {
 			// try to get proof from issuer
 			log.Warn(ctx, "failed build revocation status from enabled RHS. Then try to fetch from issuer")
-			revocStatus, err := getRevocationProofFromIssuer(ctx, status.StatusIssuer.ID)
+			revocStatus, err := r.getRevocationProofFromIssuer(ctx, status.StatusIssuer.ID)
 			if err != nil {
 				return nil, err
 			}
@@ -82,7 +92,7 @@ func (r *Revocation) Status(ctx context.Context, credStatus interface{}, issuerD
 		}
 		return rs, nil
 	case *verifiable.CredentialStatus:
-		return getRevocationProofFromIssuer(ctx, status.ID)
+		return r.getRevocationProofFromIssuer(ctx, status.ID)
 	case verifiable.RHSCredentialStatus:
 		return r.Status(ctx, &status, issuerDID)
 	case verifiable.CredentialStatus:
@@ -117,8 +127,8 @@ func (r *Revocation) Status(ctx context.Context, credStatus interface{}, issuerD
 	}
 }
 
-func getRevocationProofFromIssuer(ctx context.Context, url string) (*verifiable.RevocationStatus, error) {
-	b, err := client.NewClient(*http.DefaultClient).Get(ctx, url)
+func (r *Revocation) getRevocationProofFromIssuer(ctx context.Context, url string) (*verifiable.RevocationStatus, error) {
+	b, err := client.NewClient(http.Client{Timeout: time.Second * defaultRevocationTime, Transport: r.transport}).Get(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -3,11 +3,14 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
 
+	"github.com/polygonid/sh-id-platform/internal/common"
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/jsonschema"
@@ -16,14 +19,33 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/repositories"
 )
 
+// ErrInvalidUsagePeriod the start of the schema usage period is after its end
+var ErrInvalidUsagePeriod = errors.New("the start of the period must not be after its end")
+
+// ErrSchemaCacheDisabled the schemas are loaded without a cache, so there is nothing to purge
+var ErrSchemaCacheDisabled = errors.New("the schema cache is disabled")
+
+var (
+	ErrInvalidSchemaVersion    = errors.New("the schema version must be a semantic version like 1.0.0")                // ErrInvalidSchemaVersion the version of a new schema of the registry is not a semantic version
+	ErrSchemaVersionNotGreater = errors.New("the schema version must be greater than the last version of its type")    // ErrSchemaVersionNotGreater a new version of a schema of the registry is not greater than the existing ones
+	ErrInvalidSchemaDocument   = errors.New("invalid schema document")                                                 // ErrInvalidSchemaDocument the json schema or the JSON-LD context of a new schema of the registry is not valid
+	ErrSchemaNotInRegistry     = errors.New("the schema was imported, only the schemas of the registry have versions") // ErrSchemaNotInRegistry the operation is only available for the schemas created in the registry
+	ErrIPFSDisabled            = errors.New("publishing in IPFS is not configured")                                    // ErrIPFSDisabled the schema builder can't publish in IPFS without an IPFS node
+)
+
 type schema struct {
 	repo          ports.SchemaRepository
 	loaderFactory loader.Factory
+	quotas        ports.QuotaService
+	ipfs          ports.IPFSGateway
+	pinImported   bool
 }
 
-// NewSchema is the schema service constructor
-func NewSchema(repo ports.SchemaRepository, lf loader.Factory) *schema {
-	return &schema{repo: repo, loaderFactory: lf}
+// NewSchema is the schema service constructor. quotas limits the schemas of each issuer, nil disables it. ipfs is
+// where the schema builder publishes the schemas, nil disables publishing in IPFS. With pinImported the ipfs:// json
+// schemas and JSON-LD contexts of the imported schemas are pinned in ipfs, so they stay resolvable after issuance.
+func NewSchema(repo ports.SchemaRepository, lf loader.Factory, quotas ports.QuotaService, ipfs ports.IPFSGateway, pinImported bool) *schema {
+	return &schema{repo: repo, loaderFactory: lf, quotas: quotas, ipfs: ipfs, pinImported: pinImported && ipfs != nil}
 }
 
 // GetByID returns a domain.Schema by ID
@@ -38,14 +60,542 @@ func (s *schema) GetByID(ctx context.Context, issuerDID core.DID, id uuid.UUID)
 	return schema, nil
 }
 
+// GetForm returns the description of the form to fill the credential subject of a schema, derived from its JSON schema
+func (s *schema) GetForm(ctx context.Context, issuerDID core.DID, id uuid.UUID) (*domain.SchemaForm, error) {
+	schema, err := s.GetByID(ctx, issuerDID, id)
+	if err != nil {
+		return nil, err
+	}
+	remoteSchema, err := jsonschema.LoadForType(ctx, s.loaderFactory(schema.URL), schema.URL, schema.Type)
+	if err != nil {
+		log.Error(ctx, "loading jsonschema", "err", err, "jsonschema", schema.URL)
+		return nil, ErrLoadingSchema
+	}
+	form, err := remoteSchema.Form(schema.Type)
+	if err != nil {
+		log.Error(ctx, "building schema form", "err", err, "jsonschema", schema.URL)
+		return nil, ErrProcessSchema
+	}
+	return form, nil
+}
+
+// GetJSONSchema returns the json schema document of an imported schema, through the schema loader and its cache.
+// The json schema of a schema imported from a JSON-LD context is the one derived from the context.
+// The schemas of the registry return the document stored by the node.
+func (s *schema) GetJSONSchema(ctx context.Context, issuerDID core.DID, id uuid.UUID) ([]byte, error) {
+	schema, err := s.GetByID(ctx, issuerDID, id)
+	if err != nil {
+		return nil, err
+	}
+	if schema.InRegistry() {
+		return schema.Document, nil
+	}
+	remoteSchema, err := jsonschema.LoadForType(ctx, s.loaderFactory(schema.URL), schema.URL, schema.Type)
+	if err != nil {
+		log.Error(ctx, "loading jsonschema", "err", err, "jsonschema", schema.URL)
+		return nil, ErrLoadingSchema
+	}
+	return remoteSchema.Raw(), nil
+}
+
+// GetJSONLdContext returns the JSON-LD context document of an imported schema, the one in the $metadata of its json
+// schema, through the schema loader and its cache. The schemas of the registry return the context stored by the node.
+func (s *schema) GetJSONLdContext(ctx context.Context, issuerDID core.DID, id uuid.UUID) ([]byte, error) {
+	schema, err := s.GetByID(ctx, issuerDID, id)
+	if err != nil {
+		return nil, err
+	}
+	if schema.InRegistry() {
+		return schema.ContextDocument, nil
+	}
+	remoteSchema, err := jsonschema.LoadForType(ctx, s.loaderFactory(schema.URL), schema.URL, schema.Type)
+	if err != nil {
+		log.Error(ctx, "loading jsonschema", "err", err, "jsonschema", schema.URL)
+		return nil, ErrLoadingSchema
+	}
+	jsonLdContext, err := remoteSchema.JSONLdContext()
+	if err != nil {
+		log.Error(ctx, "processing jsonschema", "err", err, "jsonschema", schema.URL)
+		return nil, ErrProcessSchema
+	}
+	doc, _, err := s.loaderFactory(jsonLdContext).Load(ctx)
+	if err != nil {
+		log.Error(ctx, "loading jsonld context", "err", err, "context", jsonLdContext)
+		return nil, ErrLoadingSchema
+	}
+	return doc, nil
+}
+
+// PurgeCache removes documents from the schema cache, so they are fetched again the next time they are used.
+// With an url only that document is purged. Without it, the json schemas imported by the issuer and their JSON-LD
+// contexts are purged, and every JSON-LD document the credentials were merklized with. It returns the purged urls.
+func (s *schema) PurgeCache(ctx context.Context, issuerDID core.DID, url string) ([]string, error) {
+	urls := []string{url}
+	if url == "" {
+		schemas, err := s.repo.GetAll(ctx, issuerDID, nil)
+		if err != nil {
+			return nil, err
+		}
+		urls = s.cachedURLs(ctx, schemas)
+	}
+	if url == "" {
+		jsonschema.PurgeDocuments()
+	} else {
+		jsonschema.PurgeDocuments(url)
+	}
+	for _, u := range urls {
+		purger, ok := s.loaderFactory(u).(loader.Purger)
+		if !ok {
+			return nil, ErrSchemaCacheDisabled
+		}
+		if err := purger.Purge(ctx); err != nil {
+			log.Error(ctx, "purging schema from the cache", "err", err, "url", u)
+			return nil, err
+		}
+	}
+	return urls, nil
+}
+
+// cachedURLs returns the urls of the json schemas and the JSON-LD contexts of schemas, without duplicates.
+// The schemas that can't be loaded only contribute their own url.
+func (s *schema) cachedURLs(ctx context.Context, schemas []domain.Schema) []string {
+	urls := make([]string, 0, 2*len(schemas))
+	seen := make(map[string]bool, 2*len(schemas))
+	add := func(url string) {
+		if url != "" && !seen[url] {
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+	for _, schema := range schemas {
+		add(schema.URL)
+		remoteSchema, err := jsonschema.LoadForType(ctx, s.loaderFactory(schema.URL), schema.URL, schema.Type)
+		if err != nil {
+			log.Warn(ctx, "loading jsonschema to find its context", "err", err, "jsonschema", schema.URL)
+			continue
+		}
+		jsonLdContext, err := remoteSchema.JSONLdContext()
+		if err != nil {
+			log.Warn(ctx, "processing jsonschema to find its context", "err", err, "jsonschema", schema.URL)
+			continue
+		}
+		add(jsonLdContext)
+	}
+	return urls
+}
+
+// UpdateDefaultProofTypes sets the proof types of the schema credentials when the creation request does not set them.
+// They are validated against the issuer identity. Nil removes them.
+func (s *schema) UpdateDefaultProofTypes(ctx context.Context, issuerDID core.DID, id uuid.UUID, proofTypes domain.ProofTypes) (*domain.Schema, error) {
+	if proofTypes != nil {
+		if err := proofTypes.Validate(issuerDID); err != nil {
+			return nil, err
+		}
+	}
+	err := s.repo.UpdateDefaultProofTypes(ctx, issuerDID, id, proofTypes)
+	if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		log.Error(ctx, "updating schema default proof types", "err", err, "id", id)
+		return nil, err
+	}
+	return s.GetByID(ctx, issuerDID, id)
+}
+
+// UpdateDefaultExpiration sets the validity period of the schema credentials when the creation request does not set
+// an expiration. Nil removes it.
+func (s *schema) UpdateDefaultExpiration(ctx context.Context, issuerDID core.DID, id uuid.UUID, policy *domain.ExpirationPolicy) (*domain.Schema, error) {
+	err := s.repo.UpdateDefaultExpiration(ctx, issuerDID, id, policy)
+	if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		log.Error(ctx, "updating schema default expiration", "err", err, "id", id)
+		return nil, err
+	}
+	return s.GetByID(ctx, issuerDID, id)
+}
+
+// UpdatePIIAttributes tags the schema attributes that hold personal data. Their values are masked in the credential
+// listings and logs unless they are explicitly requested. Nil removes the tags.
+func (s *schema) UpdatePIIAttributes(ctx context.Context, issuerDID core.DID, id uuid.UUID, attrs domain.SchemaAttrs) (*domain.Schema, error) {
+	schema, err := s.GetByID(ctx, issuerDID, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, attr := range attrs {
+		if !schema.Attributes.Contains(attr) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSchemaAttribute, attr)
+		}
+	}
+	err = s.repo.UpdatePIIAttributes(ctx, issuerDID, id, attrs)
+	if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		log.Error(ctx, "updating schema pii attributes", "err", err, "id", id)
+		return nil, err
+	}
+	return s.GetByID(ctx, issuerDID, id)
+}
+
+// UpdateMaxActivePerSubject limits the number of active credentials of the schema that a subject can hold. One makes
+// the credential unique per subject. Nil removes the limit.
+func (s *schema) UpdateMaxActivePerSubject(ctx context.Context, issuerDID core.DID, id uuid.UUID, maxActive *int) (*domain.Schema, error) {
+	if maxActive != nil && *maxActive <= 0 {
+		return nil, ErrInvalidMaxActivePerSubject
+	}
+	err := s.repo.UpdateMaxActivePerSubject(ctx, issuerDID, id, maxActive)
+	if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		log.Error(ctx, "updating schema max active credentials per subject", "err", err, "id", id)
+		return nil, err
+	}
+	return s.GetByID(ctx, issuerDID, id)
+}
+
+// UpdateRevokeSuperseded sets whether issuing a credential of the schema to a subject revokes the active credentials of
+// the schema that the subject already holds.
+func (s *schema) UpdateRevokeSuperseded(ctx context.Context, issuerDID core.DID, id uuid.UUID, revokeSuperseded bool) (*domain.Schema, error) {
+	err := s.repo.UpdateRevokeSuperseded(ctx, issuerDID, id, revokeSuperseded)
+	if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		log.Error(ctx, "updating schema revoke superseded", "err", err, "id", id)
+		return nil, err
+	}
+	return s.GetByID(ctx, issuerDID, id)
+}
+
+// UpdatePostIssuanceHooks sets the endpoints that receive the credentials of the schema once they are issued.
+// Nil removes them.
+func (s *schema) UpdatePostIssuanceHooks(ctx context.Context, issuerDID core.DID, id uuid.UUID, hooks domain.PostIssuanceHooks) (*domain.Schema, error) {
+	if err := hooks.Validate(); err != nil {
+		return nil, err
+	}
+	err := s.repo.UpdatePostIssuanceHooks(ctx, issuerDID, id, hooks)
+	if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		log.Error(ctx, "updating schema post-issuance hooks", "err", err, "id", id)
+		return nil, err
+	}
+	return s.GetByID(ctx, issuerDID, id)
+}
+
+// UpdateDisplayStrings sets the localized name and description of the schema credentials shown by the wallets in the
+// credential offers. Nil removes them.
+func (s *schema) UpdateDisplayStrings(ctx context.Context, issuerDID core.DID, id uuid.UUID, display domain.DisplayStrings) (*domain.Schema, error) {
+	if err := display.Validate(); err != nil {
+		return nil, err
+	}
+	err := s.repo.UpdateDisplayStrings(ctx, issuerDID, id, display)
+	if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		log.Error(ctx, "updating schema display strings", "err", err, "id", id)
+		return nil, err
+	}
+	return s.GetByID(ctx, issuerDID, id)
+}
+
+// UpdatePrerequisites sets the credentials that the holders must prove to hold, with a zero-knowledge proof, before a
+// credential of the schema is issued to them through a link. Nil removes them.
+func (s *schema) UpdatePrerequisites(ctx context.Context, issuerDID core.DID, id uuid.UUID, prerequisites domain.Prerequisites) (*domain.Schema, error) {
+	if err := prerequisites.Validate(); err != nil {
+		return nil, err
+	}
+	err := s.repo.UpdatePrerequisites(ctx, issuerDID, id, prerequisites)
+	if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		log.Error(ctx, "updating schema prerequisites", "err", err, "id", id)
+		return nil, err
+	}
+	return s.GetByID(ctx, issuerDID, id)
+}
+
+// GetDisplayStringsByURL returns the localized display strings of the credentials of the schema url, nil if it has none
+func (s *schema) GetDisplayStringsByURL(ctx context.Context, issuerDID core.DID, url string) (domain.DisplayStrings, error) {
+	return s.repo.GetDisplayStringsByURL(ctx, issuerDID, url)
+}
+
+// GetUsage returns the credentials of the schema issued and revoked between from and to, both included and optional.
+// The imported schemas with the same url share their usage.
+func (s *schema) GetUsage(ctx context.Context, issuerDID core.DID, id uuid.UUID, from, to *time.Time) (*domain.SchemaUsage, error) {
+	if from != nil && to != nil && from.After(*to) {
+		return nil, ErrInvalidUsagePeriod
+	}
+	schema, err := s.GetByID(ctx, issuerDID, id)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.repo.GetUsageByURL(ctx, issuerDID, schema.URL, from, to)
+	if err != nil {
+		log.Error(ctx, "getting schema usage", "err", err, "id", id)
+		return nil, err
+	}
+	return usage, nil
+}
+
 // GetAll return all schemas in the database that matches the query string
 func (s *schema) GetAll(ctx context.Context, issuerDID core.DID, query *string) ([]domain.Schema, error) {
 	return s.repo.GetAll(ctx, issuerDID, query)
 }
 
-// ImportSchema process an schema url and imports into the system
+// CreateSchema creates a version of a schema in the registry of the node. The node serves its json schema and its
+// JSON-LD context, so each version has its own immutable urls in the UI API and the credentials and links of a version
+// keep working when newer ones are created. The version must be greater than the existing versions of the type.
+// Without a JSON-LD context in the request, it is generated from the json schema.
+func (s *schema) CreateSchema(ctx context.Context, issuerDID core.DID, req *ports.CreateSchemaRequest) (*domain.Schema, error) {
+	if err := s.checkNewVersion(ctx, issuerDID, req.Type, req.Version); err != nil {
+		return nil, err
+	}
+
+	document, err := jsonschema.Parse(req.JSONSchema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchemaDocument, err)
+	}
+	attributes, err := document.Attributes()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchemaDocument, err)
+	}
+
+	id := uuid.New()
+	baseURL := fmt.Sprintf("%s/v1/schemas/%s", strings.TrimSuffix(req.ServerURL, "/"), id)
+	schemaURL, contextURL := baseURL+"/jsonschema", baseURL+"/context"
+	contextDoc := req.JSONLdContext
+	if contextDoc == nil {
+		if contextDoc, err = document.NewJSONLdContext(req.Type, contextURL); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSchemaDocument, err)
+		}
+	} else if err := document.CheckJSONLdContext(contextDoc, req.Type); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchemaDocument, err)
+	}
+	document, err = document.WithMetadata(schemaURL, contextURL, req.Type, req.Version)
+	if err != nil {
+		log.Error(ctx, "setting the schema metadata", "err", err, "type", req.Type)
+		return nil, ErrProcessSchema
+	}
+	hash, err := document.SchemaHash(req.Type)
+	if err != nil {
+		log.Error(ctx, "hashing schema", "err", err, "type", req.Type)
+		return nil, ErrProcessSchema
+	}
+
+	schema := &domain.Schema{
+		ID:              id,
+		IssuerDID:       issuerDID,
+		URL:             schemaURL,
+		Type:            req.Type,
+		Hash:            hash,
+		Attributes:      attributes.SchemaAttrs(),
+		Version:         req.Version,
+		Title:           req.Title,
+		Description:     req.Description,
+		Document:        document.Raw(),
+		ContextDocument: contextDoc,
+		ContextURL:      contextURL,
+		CreatedAt:       time.Now(),
+	}
+	if err := s.repo.Save(ctx, schema); err != nil {
+		log.Error(ctx, "saving schema version", "err", err, "type", req.Type, "version", req.Version)
+		return nil, err
+	}
+	return schema, nil
+}
+
+// BuildSchema generates the json schema of a credential with the attributes of req, and its JSON-LD context.
+// Without Register or IPFS they are only returned, so they can be reviewed. With Register the schema is created in the
+// registry of the node, which serves both documents. With IPFS both are published in IPFS, and the json schema
+// references the context by its ipfs url; with Register too, the published schema is saved in the registry.
+func (s *schema) BuildSchema(ctx context.Context, issuerDID core.DID, req *ports.BuildSchemaRequest) (*domain.BuiltSchema, error) {
+	if req.IPFS && s.ipfs == nil {
+		return nil, ErrIPFSDisabled
+	}
+	document, err := jsonschema.Build(req.Type, req.Title, req.Description, req.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchemaDocument, err)
+	}
+
+	if req.Register && !req.IPFS {
+		schema, err := s.CreateSchema(ctx, issuerDID, &ports.CreateSchemaRequest{
+			Type:        req.Type,
+			Version:     req.Version,
+			Title:       req.Title,
+			Description: req.Description,
+			JSONSchema:  document.Raw(),
+			ServerURL:   req.ServerURL,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &domain.BuiltSchema{JSONSchema: schema.Document, JSONLdContext: schema.ContextDocument, SchemaURL: schema.URL, ContextURL: schema.ContextURL, Schema: schema}, nil
+	}
+
+	if req.Register {
+		err = s.checkNewVersion(ctx, issuerDID, req.Type, req.Version)
+	} else {
+		err = checkSchemaTypeAndVersion(req.Type, req.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The context of a document published in IPFS can't contain its own url, so its terms are in a uuid vocabulary
+	contextDoc, err := document.NewJSONLdContext(req.Type, "urn:uuid:"+uuid.NewString())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchemaDocument, err)
+	}
+	if !req.IPFS {
+		return &domain.BuiltSchema{JSONSchema: document.Raw(), JSONLdContext: contextDoc}, nil
+	}
+
+	contextCID, err := s.ipfs.Add(ctx, contextDoc)
+	if err != nil {
+		log.Error(ctx, "publishing the schema context in ipfs", "err", err, "type", req.Type)
+		return nil, err
+	}
+	contextURL := "ipfs://" + contextCID
+	if document, err = document.WithMetadata("", contextURL, req.Type, req.Version); err != nil {
+		log.Error(ctx, "setting the schema metadata", "err", err, "type", req.Type)
+		return nil, ErrProcessSchema
+	}
+	schemaCID, err := s.ipfs.Add(ctx, document.Raw())
+	if err != nil {
+		log.Error(ctx, "publishing the json schema in ipfs", "err", err, "type", req.Type)
+		return nil, err
+	}
+	built := &domain.BuiltSchema{JSONSchema: document.Raw(), JSONLdContext: contextDoc, SchemaURL: "ipfs://" + schemaCID, ContextURL: contextURL}
+	if !req.Register {
+		return built, nil
+	}
+
+	attributes, err := document.Attributes()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchemaDocument, err)
+	}
+	hash, err := document.SchemaHash(req.Type)
+	if err != nil {
+		log.Error(ctx, "hashing schema", "err", err, "type", req.Type)
+		return nil, ErrProcessSchema
+	}
+	built.Schema = &domain.Schema{
+		ID:              uuid.New(),
+		IssuerDID:       issuerDID,
+		URL:             built.SchemaURL,
+		Type:            req.Type,
+		Hash:            hash,
+		Attributes:      attributes.SchemaAttrs(),
+		Version:         req.Version,
+		Title:           req.Title,
+		Description:     req.Description,
+		Document:        built.JSONSchema,
+		ContextDocument: built.JSONLdContext,
+		ContextURL:      built.ContextURL,
+		CreatedAt:       time.Now(),
+	}
+	if err := s.repo.Save(ctx, built.Schema); err != nil {
+		log.Error(ctx, "saving schema version", "err", err, "type", req.Type, "version", req.Version)
+		return nil, err
+	}
+	return built, nil
+}
+
+// checkSchemaTypeAndVersion checks the type and the version of a schema of the registry
+func checkSchemaTypeAndVersion(schemaType, version string) error {
+	if !domain.ValidSchemaVersion(version) {
+		return ErrInvalidSchemaVersion
+	}
+	if schemaType == "" || strings.ContainsAny(schemaType, " #/:") {
+		return fmt.Errorf("%w: the type must be a name without spaces, #, / or :", ErrInvalidSchemaDocument)
+	}
+	return nil
+}
+
+// checkNewVersion checks that the issuer can create the version of a schema type in the registry: the version must be
+// greater than the existing ones, and the issuer must be under its schemas quota
+func (s *schema) checkNewVersion(ctx context.Context, issuerDID core.DID, schemaType, version string) error {
+	if err := checkSchemaTypeAndVersion(schemaType, version); err != nil {
+		return err
+	}
+	if err := s.checkQuota(ctx, issuerDID); err != nil {
+		return err
+	}
+	versions, err := s.repo.GetVersions(ctx, issuerDID, schemaType)
+	if err != nil {
+		log.Error(ctx, "getting schema versions", "err", err, "type", schemaType)
+		return err
+	}
+	if len(versions) > 0 && domain.CompareSchemaVersions(version, versions[0].Version) <= 0 {
+		return fmt.Errorf("%w: the last version of %s is %s", ErrSchemaVersionNotGreater, schemaType, versions[0].Version)
+	}
+	return nil
+}
+
+// GetVersions returns the versions of the type of a schema of the registry, from the newest to the oldest
+func (s *schema) GetVersions(ctx context.Context, issuerDID core.DID, id uuid.UUID) ([]domain.Schema, error) {
+	schema, err := s.GetByID(ctx, issuerDID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !schema.InRegistry() {
+		return nil, ErrSchemaNotInRegistry
+	}
+	return s.repo.GetVersions(ctx, issuerDID, schema.Type)
+}
+
+// UpdateMetadata sets the title and the description of a schema. Empty values remove them.
+func (s *schema) UpdateMetadata(ctx context.Context, issuerDID core.DID, id uuid.UUID, title, description string) (*domain.Schema, error) {
+	err := s.repo.UpdateMetadata(ctx, issuerDID, id, title, description)
+	if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		log.Error(ctx, "updating schema metadata", "err", err, "id", id)
+		return nil, err
+	}
+	return s.GetByID(ctx, issuerDID, id)
+}
+
+// Deprecate deprecates or undeprecates a schema. The deprecated schemas can't be used to issue new credentials or to
+// create links, but the existing links and credentials keep working.
+func (s *schema) Deprecate(ctx context.Context, issuerDID core.DID, id uuid.UUID, deprecated bool) (*domain.Schema, error) {
+	schema, err := s.GetByID(ctx, issuerDID, id)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Deprecated() == deprecated {
+		return schema, nil
+	}
+	var deprecatedAt *time.Time
+	if deprecated {
+		deprecatedAt = common.ToPointer(time.Now())
+	}
+	err = s.repo.UpdateDeprecatedAt(ctx, issuerDID, id, deprecatedAt)
+	if errors.Is(err, repositories.ErrSchemaDoesNotExist) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		log.Error(ctx, "updating schema deprecation", "err", err, "id", id)
+		return nil, err
+	}
+	return s.GetByID(ctx, issuerDID, id)
+}
+
+// ImportSchema process an schema url and imports into the system. The url can be the json schema of the type or, when
+// the type has no json schema, its JSON-LD context, where the attributes are derived from.
 func (s *schema) ImportSchema(ctx context.Context, did core.DID, url string, sType string) (*domain.Schema, error) {
-	remoteSchema, err := jsonschema.Load(ctx, s.loaderFactory(url))
+	if err := s.checkQuota(ctx, did); err != nil {
+		return nil, err
+	}
+	remoteSchema, err := jsonschema.LoadForType(ctx, s.loaderFactory(url), url, sType)
 	if err != nil {
 		log.Error(ctx, "loading jsonschema", "err", err, "jsonschema", url)
 		return nil, ErrLoadingSchema
@@ -61,6 +611,16 @@ func (s *schema) ImportSchema(ctx context.Context, did core.DID, url string, sTy
 		log.Error(ctx, "hashing schema", "err", err, "jsonschema", url)
 		return nil, ErrProcessSchema
 	}
+	// The context is loaded to keep it in the schema cache, so the schema proxy can serve it when its source is down
+	jsonLdContext, err := remoteSchema.JSONLdContext()
+	if err == nil {
+		if _, _, err := s.loaderFactory(jsonLdContext).Load(ctx); err != nil {
+			log.Warn(ctx, "loading jsonld context", "err", err, "context", jsonLdContext)
+		}
+	}
+	if err := s.pin(ctx, url, jsonLdContext); err != nil {
+		return nil, err
+	}
 
 	schema := &domain.Schema{
 		ID:         uuid.New(),
@@ -78,3 +638,28 @@ func (s *schema) ImportSchema(ctx context.Context, did core.DID, url string, sTy
 	}
 	return schema, nil
 }
+
+// pin pins the ipfs:// urls in the IPFS node when the imported schemas are pinned. The rest of the urls are ignored.
+func (s *schema) pin(ctx context.Context, urls ...string) error {
+	if !s.pinImported {
+		return nil
+	}
+	for _, url := range urls {
+		if !loader.IsIPFS(url) {
+			continue
+		}
+		if err := s.ipfs.Pin(ctx, loader.IPFSPath(url)); err != nil {
+			log.Error(ctx, "pinning imported schema document in ipfs", "err", err, "url", url)
+			return fmt.Errorf("%w: %s can't be pinned in ipfs", ErrLoadingSchema, url)
+		}
+	}
+	return nil
+}
+
+// checkQuota fails with ErrQuotaExceeded when the issuer already has the maximum schemas allowed by its quotas
+func (s *schema) checkQuota(ctx context.Context, issuerDID core.DID) error {
+	if s.quotas == nil {
+		return nil
+	}
+	return s.quotas.Check(ctx, issuerDID, domain.QuotaSchemas)
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/event"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+)
+
+// schemaWebhookRequest is the body POSTed to the schema webhooks
+type schemaWebhookRequest struct {
+	Event        string                    `json:"event"`
+	WebhookID    string                    `json:"webhookId"`
+	CredentialID string                    `json:"credentialId"`
+	Issuer       string                    `json:"issuer"`
+	Subject      string                    `json:"subject,omitempty"`
+	Schema       string                    `json:"schema"`
+	Type         string                    `json:"type"`
+	RevNonce     uint64                    `json:"revNonce"`
+	Credential   *verifiable.W3CCredential `json:"credential,omitempty"`
+}
+
+// CreateSchemaWebhook registers a webhook of the issuer that is notified of the events of the credentials of the
+// schema type
+func (c *claim) CreateSchemaWebhook(ctx context.Context, issuerDID core.DID, schemaType string, url string, events []string, authorization string) (*domain.SchemaWebhook, error) {
+	webhook, err := domain.NewSchemaWebhook(issuerDID, schemaType, url, events, authorization)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.schemaWebhookRepository.Save(ctx, c.storage.Pgx, webhook); err != nil {
+		log.Error(ctx, "saving schema webhook", "err", err)
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// GetSchemaWebhooks returns the schema webhooks of the issuer, the oldest first
+func (c *claim) GetSchemaWebhooks(ctx context.Context, issuerDID core.DID) ([]domain.SchemaWebhook, error) {
+	return c.schemaWebhookRepository.GetAll(ctx, c.storage.Reader(), issuerDID)
+}
+
+// DeleteSchemaWebhook removes a schema webhook of the issuer
+func (c *claim) DeleteSchemaWebhook(ctx context.Context, issuerDID core.DID, id uuid.UUID) error {
+	err := c.schemaWebhookRepository.Delete(ctx, c.storage.Pgx, issuerDID, id)
+	if errors.Is(err, repositories.ErrSchemaWebhookDoesNotExist) {
+		return ErrSchemaWebhookNotFound
+	}
+	return err
+}
+
+type schemaWebhookDispatcher struct {
+	claimsService ports.ClaimsService
+	client        *http.Client
+}
+
+// NewSchemaWebhookDispatcher returns the dispatcher that POSTs the credentials of the CreateCredentialEvents and
+// RevokeCredentialEvents to the webhooks of their issuer registered for their schema type, with transport or with
+// http.DefaultTransport when it is nil
+func NewSchemaWebhookDispatcher(claimsService ports.ClaimsService, timeout time.Duration, transport http.RoundTripper) ports.SchemaWebhookDispatcher {
+	return &schemaWebhookDispatcher{
+		claimsService: claimsService,
+		client:        &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+// DeliverIssued POSTs the credentials of a CreateCredentialEvent to the webhooks of their schema type
+func (d *schemaWebhookDispatcher) DeliverIssued(ctx context.Context, e pubsub.Message) error {
+	var createCredentialEvent event.CreateCredential
+	if err := createCredentialEvent.Unmarshal(e); err != nil {
+		return errors.New("deliverIssued unexpected data type")
+	}
+	return d.dispatch(ctx, domain.SchemaWebhookIssued, createCredentialEvent.IssuerID, createCredentialEvent.CredentialIDs)
+}
+
+// DeliverRevoked POSTs the credentials of a RevokeCredentialEvent to the webhooks of their schema type
+func (d *schemaWebhookDispatcher) DeliverRevoked(ctx context.Context, e pubsub.Message) error {
+	var revokeCredentialEvent event.RevokeCredential
+	if err := revokeCredentialEvent.Unmarshal(e); err != nil {
+		return errors.New("deliverRevoked unexpected data type")
+	}
+	return d.dispatch(ctx, domain.SchemaWebhookRevoked, revokeCredentialEvent.IssuerID, revokeCredentialEvent.CredentialIDs)
+}
+
+// dispatch POSTs each credential to the webhooks of the issuer that match its schema type and the event. A failed
+// delivery is logged and doesn't stop the others.
+func (d *schemaWebhookDispatcher) dispatch(ctx context.Context, eventName string, issuerID string, credentialIDs []string) error {
+	issuerDID, err := core.ParseDID(issuerID)
+	if err != nil {
+		return fmt.Errorf("schema webhooks invalid issuer: %w", err)
+	}
+	webhooks, err := d.claimsService.GetSchemaWebhooks(ctx, *issuerDID)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	var failed error
+	for _, credentialID := range credentialIDs {
+		id, err := uuid.Parse(credentialID)
+		if err != nil {
+			return fmt.Errorf("schema webhooks invalid credential id: %w", err)
+		}
+		claim, err := d.claimsService.GetByID(ctx, issuerDID, id)
+		if errors.Is(err, ErrClaimNotFound) {
+			log.Warn(ctx, "schema webhooks: the credential is gone", "credential", credentialID, "event", eventName)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for i := range webhooks {
+			webhook := &webhooks[i]
+			if !webhook.Matches(claim.SchemaType, eventName) {
+				continue
+			}
+			if err := d.post(ctx, webhook, eventName, claim); err != nil {
+				log.Warn(ctx, "schema webhook failed", "err", err, "webhook", webhook.ID.String(), "credential", credentialID, "event", eventName)
+				failed = err
+			}
+		}
+	}
+	return failed
+}
+
+func (d *schemaWebhookDispatcher) post(ctx context.Context, webhook *domain.SchemaWebhook, eventName string, claim *domain.Claim) error {
+	request := schemaWebhookRequest{
+		Event:        eventName,
+		WebhookID:    webhook.ID.String(),
+		CredentialID: claim.ID.String(),
+		Issuer:       claim.Issuer,
+		Subject:      claim.OtherIdentifier,
+		Schema:       claim.SchemaURL,
+		Type:         claim.SchemaType,
+		RevNonce:     uint64(claim.RevNonce),
+	}
+	if eventName == domain.SchemaWebhookIssued {
+		vc, err := claim.GetVerifiableCredential()
+		if err != nil {
+			return err
+		}
+		request.Credential = &vc
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if webhook.Authorization != "" {
+		req.Header.Set("Authorization", webhook.Authorization)
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("schema webhook: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/jackc/pgtype"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+var (
+	// ErrSigningKeyNotFound the identity has no active signing key with the id
+	ErrSigningKeyNotFound = errors.New("signing key not found")
+	// ErrLastSigningKey the last active signing key of an identity can't be removed
+	ErrLastSigningKey = errors.New("the last active signing key of the identity can't be removed")
+	// ErrSigningKeyType the signing keys can't be of the type
+	ErrSigningKeyType = errors.New("unsupported signing key type")
+)
+
+type signingKeys struct {
+	kms              kms.KMSType
+	identityService  ports.IdentityService
+	claimsService    ports.ClaimsService
+	claimsRepository ports.ClaimsRepository
+	storage          *db.Storage
+	hostURL          string
+}
+
+// NewSigningKeys returns the service that manages the signing keys of the identities. Each key has its own auth claim,
+// and it signs credentials once the state with its auth claim is published. The keys are BabyJubJub keys, or secp256k1
+// keys for the key stores that can't hold BabyJubJub keys.
+func NewSigningKeys(kms kms.KMSType, identityService ports.IdentityService, claimsService ports.ClaimsService, claimsRepository ports.ClaimsRepository, storage *db.Storage, hostURL string) ports.SigningKeyService {
+	return &signingKeys{
+		kms:              kms,
+		identityService:  identityService,
+		claimsService:    claimsService,
+		claimsRepository: claimsRepository,
+		storage:          storage,
+		hostURL:          hostURL,
+	}
+}
+
+// GetAll returns the signing keys of the identity, the BabyJubJub ones and then the secp256k1 ones, the active ones
+// first
+func (s *signingKeys) GetAll(ctx context.Context, issuerDID core.DID) ([]*domain.SigningKey, error) {
+	authClaims, err := s.authClaims(ctx, issuerDID)
+	if err != nil {
+		return nil, err
+	}
+	secp256k1AuthClaims, err := s.claimsService.GetSecp256k1SigningAuthClaims(ctx, &issuerDID)
+	if err != nil {
+		return nil, err
+	}
+	authClaims = append(authClaims, secp256k1AuthClaims...)
+	keys := make([]*domain.SigningKey, len(authClaims))
+	for i, authClaim := range authClaims {
+		if keys[i], err = s.signingKey(ctx, authClaim); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// Add creates a new signing key of the identity, of keyType. It is pending until the next state of the identity, that
+// adds its auth claim, is published.
+func (s *signingKeys) Add(ctx context.Context, issuerDID core.DID, keyType domain.SigningKeyType) (*domain.SigningKey, error) {
+	if _, err := s.authClaims(ctx, issuerDID); err != nil {
+		return nil, err
+	}
+	newSigningAuthClaim := newKeyAuthClaim
+	switch keyType {
+	case domain.SigningKeyBJJ:
+	case domain.SigningKeySecp256k1:
+		newSigningAuthClaim = newEthKeyAuthClaim
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrSigningKeyType, keyType)
+	}
+	key, authClaim, err := newSigningAuthClaim(ctx, s.kms, issuerDID, s.hostURL)
+	if err != nil {
+		return nil, err
+	}
+	if authClaim.ID, err = s.claimsRepository.Save(ctx, s.storage.Pgx, authClaim); err != nil {
+		log.Error(ctx, "saving the auth claim of the signing key", "err", err, "did", issuerDID.String())
+		return nil, fmt.Errorf("can't save auth claim: %w", err)
+	}
+	log.Info(ctx, "signing key added", "did", issuerDID.String(), "key", key.ID, "authClaim", authClaim.ID.String())
+	return s.signingKey(ctx, authClaim)
+}
+
+// Remove revokes the auth claim of a signing key of the identity, so it stops signing credentials. The credentials it
+// signed stop being valid once the state with the revocation is published. The last active BabyJubJub key can't be
+// removed, as the states of the identity are signed with them.
+func (s *signingKeys) Remove(ctx context.Context, issuerDID core.DID, authClaimID uuid.UUID) error {
+	authClaims, err := s.authClaims(ctx, issuerDID)
+	if err != nil {
+		return err
+	}
+	var removed *domain.Claim
+	active := 0
+	for _, authClaim := range authClaims {
+		if authClaim.ID == authClaimID {
+			removed = authClaim
+		} else if signingKeyStatus(authClaim) == domain.SigningKeyActive {
+			active++
+		}
+	}
+	if removed == nil {
+		secp256k1AuthClaims, err := s.claimsService.GetSecp256k1SigningAuthClaims(ctx, &issuerDID)
+		if err != nil {
+			return err
+		}
+		for _, authClaim := range secp256k1AuthClaims {
+			if authClaim.ID == authClaimID {
+				removed = authClaim
+			}
+		}
+		if removed == nil {
+			return ErrSigningKeyNotFound
+		}
+	} else if active == 0 {
+		return ErrLastSigningKey
+	}
+	if err := s.claimsService.Revoke(ctx, issuerDID, uint64(removed.RevNonce), "signing key removed"); err != nil {
+		return fmt.Errorf("can't revoke the auth claim of the signing key: %w", err)
+	}
+	log.Info(ctx, "signing key removed", "did", issuerDID.String(), "authClaim", authClaimID.String())
+	return nil
+}
+
+// authClaims returns the auth claims of the signing keys of the identity that are not revoked
+func (s *signingKeys) authClaims(ctx context.Context, issuerDID core.DID) ([]*domain.Claim, error) {
+	authClaims, err := s.claimsService.GetSigningAuthClaims(ctx, &issuerDID)
+	if err != nil {
+		return nil, err
+	}
+	if len(authClaims) == 0 {
+		return nil, ErrIdentityNotFound
+	}
+	return authClaims, nil
+}
+
+// Secp256k1Signers returns the Ethereum addresses of the active secp256k1 signing keys of the identity, that sign its
+// messages
+func (s *signingKeys) Secp256k1Signers(ctx context.Context, did core.DID) ([]ethcommon.Address, error) {
+	authClaims, err := s.claimsService.GetSecp256k1SigningAuthClaims(ctx, &did)
+	if err != nil {
+		return nil, err
+	}
+	signers := make([]ethcommon.Address, 0, len(authClaims))
+	for _, authClaim := range authClaims {
+		if signingKeyStatus(authClaim) == domain.SigningKeyActive {
+			signers = append(signers, domain.AuthEthAddress(authClaim.CoreClaim.Get()))
+		}
+	}
+	return signers, nil
+}
+
+func (s *signingKeys) signingKey(ctx context.Context, authClaim *domain.Claim) (*domain.SigningKey, error) {
+	keyID, err := s.identityService.GetKeyIDFromAuthClaim(ctx, authClaim)
+	if err != nil {
+		return nil, fmt.Errorf("can't get the key of the auth claim %s: %w", authClaim.ID, err)
+	}
+	key := &domain.SigningKey{
+		AuthClaimID: authClaim.ID,
+		KeyID:       keyID.ID,
+		Type:        domain.SigningKeyBJJ,
+		Status:      signingKeyStatus(authClaim),
+	}
+	if domain.IsAuthEthClaim(authClaim.CoreClaim.Get()) {
+		keyBytes, err := s.kms.PublicKey(ctx, keyID)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := kms.DecodeEthPubKey(keyBytes)
+		if err != nil {
+			return nil, err
+		}
+		key.Type, key.PublicKey = domain.SigningKeySecp256k1, hex.EncodeToString(crypto.CompressPubkey(pubKey))
+		return key, nil
+	}
+	pubKey := authClaimPubKey(authClaim).Compress()
+	key.PublicKey = hex.EncodeToString(pubKey[:])
+	return key, nil
+}
+
+// signingKeyStatus tells whether the auth claim is in a published state, so its key can sign
+func signingKeyStatus(authClaim *domain.Claim) domain.SigningKeyStatus {
+	if authClaim.MTPProof.Status == pgtype.Present {
+		return domain.SigningKeyActive
+	}
+	return domain.SigningKeyPending
+}
+
+func authClaimPubKey(authClaim *domain.Claim) *babyjub.PublicKey {
+	slots := authClaim.CoreClaim.Get().RawSlotsAsInts()
+	return &babyjub.PublicKey{X: slots[2], Y: slots[3]}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+// ErrSubjectIsIssuer the issuer data cannot be erased through the subject endpoints
+var ErrSubjectIsIssuer = errors.New("the subject cannot be the issuer")
+
+type subject struct {
+	claimsRepo  ports.ClaimsRepository
+	connRepo    ports.ConnectionsRepository
+	subjectRepo ports.SubjectRepository
+	storage     *db.Storage
+}
+
+// NewSubject returns a new subject data service
+func NewSubject(claimsRepo ports.ClaimsRepository, connRepo ports.ConnectionsRepository, subjectRepo ports.SubjectRepository, storage *db.Storage) ports.SubjectService {
+	return &subject{
+		claimsRepo:  claimsRepo,
+		connRepo:    connRepo,
+		subjectRepo: subjectRepo,
+		storage:     storage,
+	}
+}
+
+// Export returns the connection, credentials, archived ones included, and erasure records held about the subject
+func (s *subject) Export(ctx context.Context, issuerDID core.DID, subject core.DID) (*domain.SubjectData, error) {
+	data := &domain.SubjectData{Subject: subject}
+
+	conn, err := s.connRepo.GetByUserID(ctx, s.storage.Pgx, issuerDID, subject)
+	if err != nil && !errors.Is(err, repositories.ErrConnectionDoesNotExist) {
+		return nil, err
+	}
+	data.Connection = conn
+
+	credentials, err := s.claimsRepo.GetAllByIssuerID(ctx, s.storage.Pgx, issuerDID, &ports.ClaimsFilter{Subject: subject.String(), PII: true})
+	if err != nil && !errors.Is(err, repositories.ErrClaimDoesNotExist) {
+		return nil, err
+	}
+	archived, err := s.subjectRepo.GetArchivedClaims(ctx, s.storage.Pgx, issuerDID, subject)
+	if err != nil {
+		return nil, err
+	}
+	data.Credentials = append(credentials, archived...)
+
+	data.Erasures, err = s.subjectRepo.GetErasures(ctx, s.storage.Pgx, issuerDID, domain.SubjectHash(subject))
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Erase deletes the personal data held about the subject. Credentials keep their revocation nonce so they can be revoked later.
+func (s *subject) Erase(ctx context.Context, issuerDID core.DID, subject core.DID) (*domain.SubjectErasure, error) {
+	if issuerDID.String() == subject.String() {
+		return nil, ErrSubjectIsIssuer
+	}
+
+	erasure := domain.NewSubjectErasure(issuerDID, subject)
+	err := s.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		return s.subjectRepo.Erase(ctx, tx, erasure, subject)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "subject data erased", "erasure", erasure.ID, "credentials", erasure.Credentials, "connections", erasure.Connections)
+	return erasure, nil
+}
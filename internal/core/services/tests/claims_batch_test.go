@@ -0,0 +1,63 @@
+package services_tests
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+)
+
+func Test_claim_SaveBatch(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "https://host.com"}, pubsub.NewMock())
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	schemaURL := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	newRequest := func(birthday any) *ports.CreateClaimRequest {
+		credentialSubject := map[string]any{
+			"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+			"birthday":     birthday,
+			"documentType": 2,
+		}
+		return ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, nil, "KYCAgeCredential", nil, nil, common.ToPointer("index"), common.ToPointer(true), common.ToPointer(false), nil, false)
+	}
+
+	reqs := []*ports.CreateClaimRequest{newRequest(19960424), newRequest("not a date"), newRequest(19960425)}
+	claims, errs := claimsService.SaveBatch(ctx, reqs)
+	require.Len(t, claims, len(reqs))
+	require.Len(t, errs, len(reqs))
+
+	assert.Error(t, errs[1])
+	assert.Nil(t, claims[1])
+	for _, i := range []int{0, 2} {
+		require.NoError(t, errs[i])
+		saved, err := claimsService.GetByID(ctx, did, claims[i].ID)
+		require.NoError(t, err)
+		assert.Equal(t, claims[i].ID, saved.ID)
+	}
+	assert.NotEqual(t, claims[0].ID, claims[2].ID)
+}
@@ -0,0 +1,132 @@
+package services_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+	"github.com/polygonid/sh-id-platform/pkg/schema"
+)
+
+func Test_claim_Import(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+
+	claimsConf := services.ClaimCfg{
+		RHSEnabled: false,
+		Host:       "https://host.com",
+	}
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+	otherIdentity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	otherDID, err := core.ParseDID(otherIdentity.Identifier)
+	require.NoError(t, err)
+
+	credentialSubject := map[string]any{
+		"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+		"birthday":     19960424,
+		"documentType": 2,
+	}
+	schemaURL := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	merklizedRootPosition := "index"
+	issued, err := claimsService.Save(ctx, ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, common.ToPointer(time.Now().Add(time.Hour)), "KYCAgeCredential", nil, nil, &merklizedRootPosition, common.ToPointer(true), common.ToPointer(false), nil, false))
+	require.NoError(t, err)
+	credential, err := schema.FromClaimModelToW3CCredential(*issued)
+	require.NoError(t, err)
+
+	// the credential was issued by the identity in a previous deployment
+	require.NoError(t, claimsService.Delete(ctx, *did, issued.ID))
+
+	t.Run("should reject a credential of another issuer", func(t *testing.T) {
+		_, err := claimsService.Import(ctx, *otherDID, *credential)
+		assert.ErrorIs(t, err, services.ErrInvalidCredential)
+	})
+
+	t.Run("should reject a credential without proofs", func(t *testing.T) {
+		withoutProofs := *credential
+		withoutProofs.Proof = nil
+		_, err := claimsService.Import(ctx, *did, withoutProofs)
+		assert.ErrorIs(t, err, services.ErrInvalidCredential)
+	})
+
+	t.Run("should reject a credential whose id doesn't end with a uuid or an ulid", func(t *testing.T) {
+		withoutUUID := *credential
+		withoutUUID.ID = "https://issuer.example.com/credentials/kyc-age-1"
+		_, err := claimsService.Import(ctx, *did, withoutUUID)
+		assert.ErrorIs(t, err, services.ErrInvalidCredential)
+	})
+
+	t.Run("should reject a credential whose content was changed", func(t *testing.T) {
+		changed := *credential
+		changed.CredentialSubject = map[string]any{
+			"id":           credentialSubject["id"],
+			"birthday":     20000101,
+			"documentType": 2,
+			"type":         "KYCAgeCredential",
+		}
+		_, err := claimsService.Import(ctx, *did, changed)
+		assert.ErrorIs(t, err, services.ErrCredentialNotVerified)
+	})
+
+	t.Run("should reject a credential with a signature of another key", func(t *testing.T) {
+		forged := *credential
+		otherAuthClaim, err := claimsService.GetAuthClaim(ctx, otherDID)
+		require.NoError(t, err)
+		proof := *credential.Proof[0].(*verifiable.BJJSignatureProof2021)
+		proof.IssuerData.AuthCoreClaim, err = otherAuthClaim.CoreClaim.Get().Hex()
+		require.NoError(t, err)
+		forged.Proof = verifiable.CredentialProofs{&proof}
+		_, err = claimsService.Import(ctx, *did, forged)
+		assert.ErrorIs(t, err, services.ErrCredentialNotVerified)
+	})
+
+	t.Run("should import the credential", func(t *testing.T) {
+		imported, err := claimsService.Import(ctx, *did, *credential)
+		require.NoError(t, err)
+		assert.Equal(t, issued.ID, imported.ID)
+		assert.Equal(t, issued.RevNonce, imported.RevNonce)
+		assert.Equal(t, issued.HIndex, imported.HIndex)
+		assert.False(t, imported.Revoked)
+		assert.Nil(t, imported.IdentityState)
+
+		status, err := claimsService.GetRevocationStatus(ctx, *did, uint64(imported.RevNonce))
+		require.NoError(t, err)
+		assert.False(t, status.MTP.Existence)
+
+		require.NoError(t, claimsService.Revoke(ctx, *did, uint64(imported.RevNonce), "imported"))
+		revoked, err := claimsService.GetByID(ctx, did, imported.ID)
+		require.NoError(t, err)
+		assert.True(t, revoked.Revoked)
+	})
+
+	t.Run("should reject a credential already imported", func(t *testing.T) {
+		_, err := claimsService.Import(ctx, *did, *credential)
+		assert.ErrorIs(t, err, services.ErrClaimAlreadyExists)
+	})
+}
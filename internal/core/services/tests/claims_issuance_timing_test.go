@@ -0,0 +1,72 @@
+package services_tests
+
+import (
+	"context"
+	"expvar"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/histogram"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+)
+
+func Test_claim_IssuanceTiming(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "https://host.com"}, pubsub.NewMock())
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	latency, ok := expvar.Get("issuance_latency").(*expvar.Map)
+	require.True(t, ok)
+	signings := latency.Get(string(domain.IssuancePhaseSigning)).(*histogram.Histogram).Snapshot().Count
+
+	credentialSubject := map[string]any{
+		"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+		"birthday":     19960424,
+		"documentType": 2,
+	}
+	schemaURL := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	req := ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, nil, "KYCAgeCredential", nil, nil, common.ToPointer("index"), common.ToPointer(true), common.ToPointer(false), nil, false)
+	claim, err := claimsService.Save(ctx, req)
+	require.NoError(t, err)
+
+	timing, err := claimsService.GetIssuanceTiming(ctx, *did, claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, claim.ID, timing.ClaimID)
+	for _, phase := range domain.IssuancePhases {
+		assert.Contains(t, timing.Phases, phase)
+	}
+	var phases int64
+	for _, d := range timing.Phases {
+		phases += int64(d)
+	}
+	assert.GreaterOrEqual(t, int64(timing.Total), phases, "the total includes all the phases")
+	assert.Equal(t, signings+1, latency.Get(string(domain.IssuancePhaseSigning)).(*histogram.Histogram).Snapshot().Count)
+
+	_, err = claimsService.GetIssuanceTiming(ctx, *did, uuid.New())
+	assert.ErrorIs(t, err, services.ErrIssuanceTimingNotFound)
+}
@@ -0,0 +1,100 @@
+package services_tests
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+)
+
+func Test_claim_SaveCredentialPolicies(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "https://host.com"}, pubsub.NewMock())
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	schemaURL := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	schemaRepo := repositories.NewSchema(*storage)
+	i := &big.Int{}
+	i.SetInt64(rand.Int63())
+	schema := &domain.Schema{
+		ID:               uuid.New(),
+		IssuerDID:        *did,
+		URL:              schemaURL,
+		Type:             "KYCAgeCredential",
+		Hash:             core.NewSchemaHashFromInt(i),
+		Attributes:       domain.SchemaAttrs{"birthday", "documentType"},
+		RevokeSuperseded: true,
+		CreatedAt:        time.Now(),
+	}
+	require.NoError(t, schemaRepo.Save(ctx, schema))
+
+	issue := func() (*domain.Claim, error) {
+		credentialSubject := map[string]any{
+			"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+			"birthday":     19960424,
+			"documentType": 2,
+		}
+		merklizedRootPosition := "index"
+		return claimsService.Save(ctx, ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, nil, "KYCAgeCredential", nil, nil, &merklizedRootPosition, common.ToPointer(true), common.ToPointer(false), nil, false))
+	}
+
+	first, err := issue()
+	require.NoError(t, err)
+	assert.Nil(t, first.ReplacesID)
+
+	t.Run("should revoke the superseded credential", func(t *testing.T) {
+		second, err := issue()
+		require.NoError(t, err)
+		require.NotNil(t, second.ReplacesID)
+		assert.Equal(t, first.ID, *second.ReplacesID)
+
+		superseded, err := claimsService.GetByID(ctx, did, first.ID)
+		require.NoError(t, err)
+		assert.True(t, superseded.Revoked)
+
+		active, err := claimsRepo.GetActiveBySubjectAndSchema(ctx, storage.Pgx, *did, second.OtherIdentifier, schemaURL)
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, second.ID, active[0].ID)
+	})
+
+	t.Run("should reject the credentials over the limit per subject", func(t *testing.T) {
+		require.NoError(t, schemaRepo.UpdateRevokeSuperseded(ctx, *did, schema.ID, false))
+		require.NoError(t, schemaRepo.UpdateMaxActivePerSubject(ctx, *did, schema.ID, common.ToPointer(1)))
+		_, err := issue()
+		assert.ErrorIs(t, err, services.ErrIssuanceLimitExceeded)
+
+		require.NoError(t, schemaRepo.UpdateRevokeSuperseded(ctx, *did, schema.ID, true))
+		_, err = issue()
+		assert.NoError(t, err, "the superseded credential doesn't count in the limit")
+	})
+}
@@ -0,0 +1,96 @@
+package services_tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/policy"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+)
+
+func Test_claim_CreateCredentialPolicyHook(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+
+	var requests []policy.Request
+	hook := policy.HookFunc(func(_ context.Context, req policy.Request) (*policy.Decision, error) {
+		requests = append(requests, req)
+		switch req.CredentialSubject["documentType"] {
+		case 1:
+			return &policy.Decision{Decision: policy.Deny, Reason: "unsupported document"}, nil
+		case 2:
+			return &policy.Decision{Decision: policy.Approve, CredentialSubject: map[string]any{"documentType": 3}}, nil
+		default:
+			return nil, errors.New("hook down")
+		}
+	})
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	schemaURL := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	subject := "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+	request := func(documentType int) *ports.CreateClaimRequest {
+		credentialSubject := map[string]any{
+			"id":           subject,
+			"birthday":     19960424,
+			"documentType": documentType,
+		}
+		merklizedRootPosition := "index"
+		return ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, nil, "KYCAgeCredential", nil, nil, &merklizedRootPosition, common.ToPointer(true), common.ToPointer(false), nil, false)
+	}
+
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "https://host.com", PolicyHook: hook}, pubsub.NewMock())
+
+	t.Run("should deny the credential", func(t *testing.T) {
+		_, err := claimsService.Save(ctx, request(1))
+		assert.ErrorIs(t, err, services.ErrIssuanceDenied)
+		require.NotEmpty(t, requests)
+		last := requests[len(requests)-1]
+		assert.Equal(t, did.String(), last.Issuer)
+		assert.Equal(t, subject, last.Subject)
+		assert.Equal(t, "KYCAgeCredential", last.Type)
+	})
+
+	t.Run("should apply the changes of the approval", func(t *testing.T) {
+		req := request(2)
+		claim, err := claimsService.Save(ctx, req)
+		require.NoError(t, err)
+		vc, err := claim.GetVerifiableCredential()
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, vc.CredentialSubject["documentType"])
+	})
+
+	t.Run("should fail when the hook fails", func(t *testing.T) {
+		_, err := claimsService.Save(ctx, request(0))
+		assert.ErrorIs(t, err, services.ErrIssuancePolicyUnavailable)
+	})
+
+	t.Run("should issue when the hook fails and fails open", func(t *testing.T) {
+		failOpen := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "https://host.com", PolicyHook: hook, PolicyFailOpen: true}, pubsub.NewMock())
+		_, err := failOpen.Save(ctx, request(0))
+		assert.NoError(t, err)
+	})
+}
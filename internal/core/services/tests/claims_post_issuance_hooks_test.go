@@ -0,0 +1,180 @@
+package services_tests
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+)
+
+func Test_claim_PostIssuanceHooks(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "https://host.com"}, pubsub.NewMock())
+
+	received := make(map[string]string)
+	hookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Hook         string `json:"hook"`
+			CredentialID string `json:"credentialId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received[body.Hook] = body.CredentialID
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"reference": "crm-1"}`))
+	}))
+	defer hookServer.Close()
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	schemaURL := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	i := &big.Int{}
+	i.SetInt64(rand.Int63())
+	schema := &domain.Schema{
+		ID:         uuid.New(),
+		IssuerDID:  *did,
+		URL:        schemaURL,
+		Type:       "KYCAgeCredential",
+		Hash:       core.NewSchemaHashFromInt(i),
+		Attributes: domain.SchemaAttrs{"birthday", "documentType"},
+		PostIssuanceHooks: domain.PostIssuanceHooks{
+			{Name: "crm", URL: hookServer.URL + "/crm", Mode: domain.PostIssuanceHookSync},
+			{Name: "failing", URL: hookServer.URL + "/failing", Mode: domain.PostIssuanceHookSync},
+		},
+		CreatedAt: time.Now(),
+	}
+	require.NoError(t, repositories.NewSchema(*storage).Save(ctx, schema))
+
+	credentialSubject := map[string]any{
+		"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+		"birthday":     19960424,
+		"documentType": 2,
+	}
+	merklizedRootPosition := "index"
+	claim, err := claimsService.Save(ctx, ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, nil, "KYCAgeCredential", nil, nil, &merklizedRootPosition, common.ToPointer(true), common.ToPointer(false), nil, false))
+	require.NoError(t, err, "a failing hook doesn't fail the issuance")
+
+	assert.Equal(t, claim.ID.String(), received["crm"])
+	assert.Equal(t, claim.ID.String(), received["failing"])
+
+	saved, err := claimsService.GetByID(ctx, did, claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"crm": "crm-1"}, saved.ExternalReferences)
+}
+
+func Test_claim_TestPostIssuanceHook(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "https://host.com", HookCaptureSize: 2}, pubsub.NewMock())
+
+	hookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Test bool `json:"test"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || !body.Test {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"reference": "crm-1"}`))
+	}))
+	defer hookServer.Close()
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	i := &big.Int{}
+	i.SetInt64(rand.Int63())
+	schema := &domain.Schema{
+		ID:         uuid.New(),
+		IssuerDID:  *did,
+		URL:        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+		Type:       "KYCAgeCredential",
+		Hash:       core.NewSchemaHashFromInt(i),
+		Attributes: domain.SchemaAttrs{"birthday", "documentType"},
+		PostIssuanceHooks: domain.PostIssuanceHooks{
+			{Name: "crm", URL: hookServer.URL + "/crm", Mode: domain.PostIssuanceHookSync},
+			{Name: "failing", URL: hookServer.URL + "/failing", Mode: domain.PostIssuanceHookQueued},
+		},
+		CreatedAt: time.Now(),
+	}
+	require.NoError(t, repositories.NewSchema(*storage).Save(ctx, schema))
+
+	delivery, err := claimsService.TestPostIssuanceHook(ctx, *did, schema.ID, "crm")
+	require.NoError(t, err)
+	assert.True(t, delivery.Test)
+	assert.Equal(t, hookServer.URL+"/crm", delivery.URL)
+	require.NotNil(t, delivery.Status)
+	assert.Equal(t, http.StatusOK, *delivery.Status)
+	assert.Equal(t, `{"reference": "crm-1"}`, *delivery.Response)
+	assert.Nil(t, delivery.Error)
+
+	delivery, err = claimsService.TestPostIssuanceHook(ctx, *did, schema.ID, "failing")
+	require.NoError(t, err, "the failures of the hook are returned in the delivery")
+	require.NotNil(t, delivery.Status)
+	assert.Equal(t, http.StatusInternalServerError, *delivery.Status)
+	assert.NotNil(t, delivery.Error)
+
+	_, err = claimsService.TestPostIssuanceHook(ctx, *did, schema.ID, "unknown")
+	assert.ErrorIs(t, err, services.ErrPostIssuanceHookNotFound)
+	_, err = claimsService.TestPostIssuanceHook(ctx, *did, uuid.New(), "crm")
+	assert.ErrorIs(t, err, services.ErrSchemaNotFound)
+
+	_, err = claimsService.TestPostIssuanceHook(ctx, *did, schema.ID, "crm")
+	require.NoError(t, err)
+	deliveries, err := claimsService.GetHookDeliveries(ctx, *did)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2, "only the last HookCaptureSize calls are kept")
+	assert.Equal(t, "crm", deliveries[0].Hook)
+	assert.Equal(t, "failing", deliveries[1].Hook)
+}
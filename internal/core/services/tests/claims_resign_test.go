@@ -0,0 +1,107 @@
+package services_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+)
+
+func Test_claim_ResignDrifted(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+
+	claimsConf := services.ClaimCfg{
+		RHSEnabled: false,
+		Host:       "https://host.com",
+	}
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	schemaURL := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	merklizedRootPosition := "index"
+	issue := func(birthday int) uuid.UUID {
+		credentialSubject := map[string]any{
+			"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+			"birthday":     birthday,
+			"documentType": 2,
+		}
+		issued, err := claimsService.Save(ctx, ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, common.ToPointer(time.Now().Add(time.Hour)), "KYCAgeCredential", nil, nil, &merklizedRootPosition, common.ToPointer(true), common.ToPointer(false), nil, false))
+		require.NoError(t, err)
+		return issued.ID
+	}
+	unchanged := issue(19960424)
+	drifted := issue(19960425)
+
+	// the content of the credential no longer builds its signed core claim, like after a change of its context
+	_, err = storage.Pgx.Exec(ctx, `UPDATE claims SET data = jsonb_set(data, '{credentialSubject,birthday}', '19960426') WHERE id = $1`, drifted)
+	require.NoError(t, err)
+
+	t.Run("should only report the drifted credentials on a dry run", func(t *testing.T) {
+		report, err := claimsService.ResignDrifted(ctx, &ports.ResignDriftedRequest{DID: did, DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Checked)
+		require.Len(t, report.Drifted, 1)
+		assert.Equal(t, drifted, report.Drifted[0].CredentialID)
+		assert.Equal(t, schemaURL, report.Drifted[0].SchemaURL)
+		assert.Nil(t, report.Drifted[0].ReplacedBy)
+		assert.Empty(t, report.Drifted[0].Error)
+	})
+
+	t.Run("should not check the credentials of other types", func(t *testing.T) {
+		report, err := claimsService.ResignDrifted(ctx, &ports.ResignDriftedRequest{DID: did, SchemaType: "KYCCountryOfResidenceCredential"})
+		require.NoError(t, err)
+		assert.Zero(t, report.Checked)
+		assert.Empty(t, report.Drifted)
+	})
+
+	t.Run("should replace the drifted credentials", func(t *testing.T) {
+		report, err := claimsService.ResignDrifted(ctx, &ports.ResignDriftedRequest{DID: did, SchemaType: "KYCAgeCredential"})
+		require.NoError(t, err)
+		require.Len(t, report.Drifted, 1)
+		require.NotNil(t, report.Drifted[0].ReplacedBy)
+
+		replaced, err := claimsService.GetByID(ctx, did, drifted)
+		require.NoError(t, err)
+		assert.True(t, replaced.Revoked)
+		kept, err := claimsService.GetByID(ctx, did, unchanged)
+		require.NoError(t, err)
+		assert.False(t, kept.Revoked)
+
+		replacement, err := claimsService.GetByID(ctx, did, *report.Drifted[0].ReplacedBy)
+		require.NoError(t, err)
+		assert.False(t, replacement.Revoked)
+		require.NotNil(t, replacement.ReplacesID)
+		assert.Equal(t, drifted, *replacement.ReplacesID)
+
+		report, err = claimsService.ResignDrifted(ctx, &ports.ResignDriftedRequest{DID: did, DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Checked)
+		assert.Empty(t, report.Drifted)
+	})
+}
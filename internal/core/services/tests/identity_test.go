@@ -2,11 +2,13 @@ package services_tests
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
 	core "github.com/iden3/go-iden3-core"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/polygonid/sh-id-platform/internal/common"
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
@@ -172,3 +174,95 @@ func Test_identity_GetByDID(t *testing.T) {
 		})
 	}
 }
+
+type countingDIDMethodDriver struct {
+	ports.DIDMethodDriver
+	created int
+}
+
+func (d *countingDIDMethodDriver) GenesisDID(state *big.Int, blockchain, network string) (*core.DID, error) {
+	d.created++
+	return d.DIDMethodDriver.GenesisDID(state, blockchain, network)
+}
+
+func Test_identity_Create_DIDMethodDriver(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+
+	assert.Equal(t, []string{"iden3", "polygonid"}, services.DIDMethods())
+
+	polygonID, err := services.DIDMethodDriver(method)
+	require.NoError(t, err)
+	driver := &countingDIDMethodDriver{DIDMethodDriver: polygonID}
+	services.RegisterDIDMethodDriver(driver)
+	defer services.RegisterDIDMethodDriver(polygonID)
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	assert.Equal(t, 1, driver.created)
+	assert.Contains(t, identity.Identifier, "did:polygonid:polygon:mumbai:")
+
+	_, err = identityService.Create(ctx, "ethr", blockchain, network, "http://localhost:3001")
+	assert.ErrorIs(t, err, services.ErrWrongDIDMetada)
+	_, err = services.DIDMethodDriver("web")
+	assert.ErrorIs(t, err, services.ErrUnsupportedDIDMethod)
+}
+
+func Test_identity_Create_Iden3(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "https://host.com"}, pubsub.NewMock())
+
+	for _, tc := range []struct {
+		blockchain string
+		network    string
+		prefix     string
+	}{
+		{blockchain: "polygon", network: "mumbai", prefix: "did:iden3:polygon:mumbai:"},
+		{blockchain: "polygon", network: "main", prefix: "did:iden3:polygon:main:"},
+		{blockchain: "eth", network: "goerli", prefix: "did:iden3:eth:goerli:"},
+	} {
+		t.Run(tc.prefix, func(t *testing.T) {
+			identity, err := identityService.Create(ctx, "iden3", tc.blockchain, tc.network, "http://localhost:3001")
+			require.NoError(t, err)
+			assert.Contains(t, identity.Identifier, tc.prefix)
+
+			did, err := core.ParseDID(identity.Identifier)
+			require.NoError(t, err)
+			assert.Equal(t, core.DIDMethodIden3, did.Method)
+			got, err := identityService.GetByDID(ctx, *did)
+			require.NoError(t, err)
+			assert.Equal(t, identity.Identifier, got.Identifier)
+
+			credentialSubject := map[string]any{
+				"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+				"birthday":     19960424,
+				"documentType": 2,
+			}
+			schemaURL := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+			claim, err := claimsService.Save(ctx, ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, common.ToPointer(time.Now().Add(time.Hour)), "KYCAgeCredential", nil, nil, common.ToPointer("index"), common.ToPointer(true), common.ToPointer(false), nil, false))
+			require.NoError(t, err)
+			assert.Equal(t, identity.Identifier, claim.Issuer)
+		})
+	}
+
+	_, err := identityService.Create(ctx, "iden3", "polygon", "unknown", "http://localhost:3001")
+	assert.ErrorIs(t, err, services.ErrWrongDIDMetada)
+}
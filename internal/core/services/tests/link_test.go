@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/polygonid/sh-id-platform/internal/common"
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
@@ -16,6 +17,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/core/services"
 	"github.com/polygonid/sh-id-platform/internal/loader"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/cache"
 	linkState "github.com/polygonid/sh-id-platform/pkg/link"
 	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
@@ -35,7 +37,7 @@ func Test_link_issueClaim(t *testing.T) {
 	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
 	schemaLoader := loader.HTTPFactory
 	sessionRepository := repositories.NewSessionCached(cachex)
-	schemaService := services.NewSchema(schemaRepository, schemaLoader)
+	schemaService := services.NewSchema(schemaRepository, schemaLoader, nil, nil, false)
 	claimsConf := services.ClaimCfg{
 		RHSEnabled: false,
 		Host:       "https://host.com",
@@ -82,15 +84,20 @@ func Test_link_issueClaim(t *testing.T) {
 	assert.NoError(t, err)
 
 	linkRepository := repositories.NewLink(*storage)
-	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, schemaLoader, sessionRepository, pubsub.NewMock())
+	linkService := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, schemaLoader, sessionRepository, pubsub.NewMock(), nil, nil)
 
 	tomorrow := time.Now().Add(24 * time.Hour)
 	nextWeek := time.Now().Add(7 * 24 * time.Hour)
 
-	link, err := linkService.Save(ctx, *did, common.ToPointer(100), &tomorrow, schema.ID, &nextWeek, true, false, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	link, err := linkService.Save(ctx, *did, common.ToPointer(100), &tomorrow, schema.ID, &nextWeek, nil, true, false, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
 	assert.NoError(t, err)
 
-	link2, err := linkService.Save(ctx, *did, common.ToPointer(100), &tomorrow, schema.ID, &nextWeek, false, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	link2, err := linkService.Save(ctx, *did, common.ToPointer(100), &tomorrow, schema.ID, &nextWeek, nil, false, true, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+	assert.NoError(t, err)
+
+	ninetyDays, err := domain.ParseExpirationPolicy("90d")
+	require.NoError(t, err)
+	link3, err := linkService.Save(ctx, *did, common.ToPointer(100), &tomorrow, schema.ID, nil, ninetyDays, true, false, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
 	assert.NoError(t, err)
 
 	type expected struct {
@@ -176,4 +183,60 @@ func Test_link_issueClaim(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("should resolve the expiration policy at issuance", func(t *testing.T) {
+		issuedAt := time.Now()
+		require.NoError(t, linkService.IssueClaim(ctx, uuid.New().String(), *did, userDID1, link3.ID, "host_url"))
+		claims, err := claimsRepo.GetClaimsIssuedForUser(ctx, storage.Pgx, *did, userDID1, link3.ID)
+		require.NoError(t, err)
+		require.Len(t, claims, 1)
+		assert.InDelta(t, issuedAt.AddDate(0, 0, 90).Unix(), claims[0].Expiration, 5)
+	})
+
+	t.Run("should resume an interrupted link session", func(t *testing.T) {
+		link4, err := linkService.Save(ctx, *did, common.ToPointer(100), &tomorrow, schema.ID, &nextWeek, nil, true, false, domain.CredentialSubject{"birthday": 19791109, "documentType": 12})
+		require.NoError(t, err)
+		qrCode, err := linkService.CreateQRCode(ctx, *did, link4.ID, "https://issuer.com")
+		require.NoError(t, err)
+		sessionID := uuid.MustParse(qrCode.SessionID)
+
+		session, err := linkService.GetSession(ctx, *did, link4.ID, sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.LinkSessionCreated, session.Status)
+
+		require.NoError(t, linkService.IssueClaim(ctx, qrCode.SessionID, *did, userDID1, link4.ID, "https://issuer.com"))
+		session, err = linkService.GetSession(ctx, *did, link4.ID, sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.LinkSessionIssued, session.Status)
+		require.NotNil(t, session.ClaimID)
+		assert.Equal(t, userDID1.String(), *session.UserDID)
+
+		// the wallet authenticates again with the same qr code and is offered the same credential
+		require.NoError(t, linkService.IssueClaim(ctx, qrCode.SessionID, *did, userDID1, link4.ID, "https://issuer.com"))
+		claims, err := claimsRepo.GetClaimsIssuedForUser(ctx, storage.Pgx, *did, userDID1, link4.ID)
+		require.NoError(t, err)
+		assert.Len(t, claims, 1)
+
+		userDID2 := core.DID{}
+		require.NoError(t, userDID2.SetString("did:polygonid:polygon:mumbai:2qFrLQA6R1bfUTxjRnZEN9st77g6ZN2c7Vw1Dq6Vpp"))
+		err = linkService.IssueClaim(ctx, qrCode.SessionID, *did, userDID2, link4.ID, "https://issuer.com")
+		assert.ErrorIs(t, err, domain.ErrLinkSessionTransition)
+
+		// the state is rebuilt from the link session when it is no longer in the session store
+		restarted := services.NewLinkService(storage, claimsService, claimsRepo, linkRepository, schemaRepository, schemaLoader, repositories.NewSessionCached(cache.NewMemoryCache()), pubsub.NewMock(), nil, nil)
+		resp, err := restarted.GetQRCode(ctx, sessionID, *did, link4.ID, "https://issuer.com")
+		require.NoError(t, err)
+		assert.Equal(t, linkState.StatusDone, resp.State.Status)
+		require.NotNil(t, resp.State.QRCode)
+		assert.Equal(t, session.ClaimID.String(), resp.State.QRCode.Body.Credentials[0].ID)
+		assert.Equal(t, "https://issuer.com/v1/agent", resp.State.QRCode.Body.URL)
+
+		sessions, err := linkService.GetSessions(ctx, *did, link4.ID, ports.LinkSessionsFilter{Status: common.ToPointer(domain.LinkSessionIssued)})
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, sessionID, sessions[0].ID)
+
+		_, err = linkService.GetSession(ctx, *did, link4.ID, uuid.New())
+		assert.ErrorIs(t, err, services.ErrLinkSessionNotFound)
+	})
 }
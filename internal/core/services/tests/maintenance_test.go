@@ -0,0 +1,52 @@
+package services_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+func TestMaintenance_Update(t *testing.T) {
+	ctx := context.Background()
+	now := clock.NewFake(time.Now())
+	admin := services.NewMaintenance(storage, time.Minute, 5*time.Second, now)
+	server := services.NewMaintenance(storage, time.Minute, 5*time.Second, now)
+	t.Cleanup(func() {
+		_, err := admin.Update(ctx, false, "", 0)
+		require.NoError(t, err)
+	})
+
+	current, err := server.Get(ctx)
+	require.NoError(t, err)
+	assert.False(t, current.Enabled)
+
+	updated, err := admin.Update(ctx, true, "rotating keys", 0)
+	require.NoError(t, err)
+	assert.True(t, updated.Enabled)
+	assert.Equal(t, time.Minute, updated.RetryAfter)
+
+	current, err = server.Get(ctx)
+	require.NoError(t, err)
+	assert.False(t, current.Enabled, "the other server reads the mode again after the refresh")
+
+	now.Advance(5 * time.Second)
+	current, err = server.Get(ctx)
+	require.NoError(t, err)
+	assert.True(t, current.Enabled)
+	assert.Equal(t, "rotating keys", current.Message)
+
+	updated, err = admin.Update(ctx, true, "", 10*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, updated.RetryAfter)
+
+	updated, err = admin.Update(ctx, false, "ignored", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, updated.Enabled)
+	assert.Empty(t, updated.Message)
+}
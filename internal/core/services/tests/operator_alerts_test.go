@@ -0,0 +1,87 @@
+package services_tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+// alertsRecorder is an alert channel that keeps the alerts of a kind it is sent
+type alertsRecorder struct {
+	kind   domain.AlertKind
+	mu     sync.Mutex
+	alerts []domain.Alert
+}
+
+func (r *alertsRecorder) Name() string {
+	return "recorder"
+}
+
+func (r *alertsRecorder) Send(_ context.Context, alert domain.Alert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if alert.Kind == r.kind {
+		r.alerts = append(r.alerts, alert)
+	}
+	return nil
+}
+
+func (r *alertsRecorder) sent() []domain.Alert {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domain.Alert(nil), r.alerts...)
+}
+
+func TestOperatorAlerts_Check(t *testing.T) {
+	ctx := context.Background()
+	now := clock.NewFake(time.Now())
+	var keyStoreErr error
+	recorder := &alertsRecorder{kind: domain.AlertKeyStoreUnavailable}
+	alerts := services.NewOperatorAlerts(storage, services.OperatorAlertsConfig{
+		Repeat:        time.Hour,
+		KeyStoreCheck: func(context.Context) error { return keyStoreErr },
+		Clock:         now,
+	}, recorder)
+
+	alerts.Check(ctx)
+	assert.Empty(t, recorder.sent())
+
+	keyStoreErr = errors.New("the vault token expires in 2h0m0s")
+	alerts.Check(ctx)
+	sent := recorder.sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, "key store", sent[0].Subject)
+	assert.Equal(t, "the vault token expires in 2h0m0s", sent[0].Message)
+	assert.False(t, sent[0].Resolved)
+	raisedAt := sent[0].Since
+
+	now.Advance(10 * time.Minute)
+	alerts.Check(ctx)
+	assert.Len(t, recorder.sent(), 1, "a firing alert is not sent again before the repeat")
+
+	now.Advance(time.Hour)
+	alerts.Check(ctx)
+	sent = recorder.sent()
+	require.Len(t, sent, 2)
+	assert.Equal(t, raisedAt, sent[1].Since)
+	assert.Equal(t, now.Now(), sent[1].At)
+
+	keyStoreErr = nil
+	alerts.Check(ctx)
+	sent = recorder.sent()
+	require.Len(t, sent, 3)
+	assert.True(t, sent[2].Resolved)
+	assert.Equal(t, raisedAt, sent[2].Since)
+
+	alerts.Check(ctx)
+	assert.Len(t, recorder.sent(), 3, "a resolved alert is sent once")
+}
@@ -0,0 +1,85 @@
+package services_tests
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+)
+
+func TestQuota_Schemas(t *testing.T) {
+	const jsonSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "credentialSubject": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string", "format": "uri"},
+        "birthday": {"type": "integer"}
+      }
+    }
+  }
+}`
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	connectionsRepository := repositories.NewConnections()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	quotas := services.NewQuota(storage, domain.Quotas{MaxSchemas: 1, MaxActiveLinks: 10}, nil)
+	schemaRepository := repositories.NewSchema(*storage)
+	schemaService := services.NewSchema(schemaRepository, loader.StoredFactory(loader.HTTPFactory, schemaRepository), quotas, nil, false)
+	req := func(schemaType string) *ports.CreateSchemaRequest {
+		return &ports.CreateSchemaRequest{Type: schemaType, Version: "1.0.0", JSONSchema: []byte(jsonSchema), ServerURL: "https://issuer.example.com/"}
+	}
+
+	_, err = schemaService.CreateSchema(ctx, *did, req("AgeCredential"))
+	require.NoError(t, err)
+	_, err = schemaService.CreateSchema(ctx, *did, req("BirthdayCredential"))
+	assert.ErrorIs(t, err, services.ErrQuotaExceeded)
+
+	updated, err := quotas.Update(ctx, *did, domain.QuotaOverrides{MaxSchemas: common.ToPointer(0)})
+	require.NoError(t, err)
+	assert.Equal(t, domain.Quotas{MaxSchemas: 0, MaxActiveLinks: 10}, *updated)
+	_, err = schemaService.CreateSchema(ctx, *did, req("BirthdayCredential"))
+	require.NoError(t, err)
+
+	usage, err := quotas.GetUsage(ctx, *did)
+	require.NoError(t, err)
+	assert.Equal(t, 2, usage.Schemas)
+	assert.Equal(t, 0, usage.ActiveLinks)
+	assert.Equal(t, 0, usage.CredentialsToday)
+	assert.Equal(t, 10, usage.Quotas.MaxActiveLinks)
+
+	_, err = quotas.Update(ctx, *did, domain.QuotaOverrides{MaxActiveLinks: common.ToPointer(-1)})
+	assert.ErrorIs(t, err, services.ErrInvalidQuota)
+
+	unknown := core.DID{}
+	require.NoError(t, unknown.SetString("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"))
+	_, err = quotas.Update(ctx, unknown, domain.QuotaOverrides{})
+	assert.ErrorIs(t, err, services.ErrIdentityNotFound)
+	_, err = quotas.GetUsage(ctx, unknown)
+	assert.ErrorIs(t, err, services.ErrIdentityNotFound)
+}
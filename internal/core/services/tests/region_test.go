@@ -0,0 +1,65 @@
+package services_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+func TestRegion_Promote(t *testing.T) {
+	ctx := context.Background()
+	now := clock.NewFake(time.Now())
+	cleanup := func() {
+		_, err := storage.Pgx.Exec(ctx, `DELETE FROM region_lease`)
+		require.NoError(t, err)
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+
+	single := services.NewRegion(storage, "", 30*time.Second, 5*time.Second, now)
+	current, err := single.Get(ctx)
+	require.NoError(t, err)
+	assert.True(t, current.Active, "a single region is always active")
+	_, err = single.Promote(ctx, false, false)
+	assert.ErrorIs(t, err, services.ErrRegionNotConfigured)
+
+	eu := services.NewRegion(storage, "eu-west", 30*time.Second, 5*time.Second, now)
+	us := services.NewRegion(storage, "us-east", 30*time.Second, 5*time.Second, now)
+
+	current, err = eu.Renew(ctx)
+	require.NoError(t, err)
+	assert.True(t, current.Active, "the first region takes the lease")
+	assert.Equal(t, int64(1), current.Lease.Epoch)
+	current, err = us.Renew(ctx)
+	require.NoError(t, err)
+	assert.False(t, current.Active)
+	assert.Equal(t, "eu-west", current.Lease.Region)
+	assert.ErrorIs(t, us.Fence(ctx), services.ErrRegionPassive)
+
+	_, err = us.Promote(ctx, false, false)
+	assert.ErrorIs(t, err, services.ErrRegionLeaseHeld)
+
+	now.Advance(31 * time.Second)
+	assert.ErrorIs(t, eu.Fence(ctx), services.ErrRegionPassive, "the lease expired")
+	current, err = us.Promote(ctx, false, false)
+	require.NoError(t, err)
+	assert.True(t, current.Active)
+	assert.Equal(t, int64(2), current.Lease.Epoch)
+	require.NoError(t, us.Fence(ctx))
+
+	current, err = eu.Renew(ctx)
+	require.NoError(t, err)
+	assert.False(t, current.Active, "the previous region can't renew the lease")
+	assert.ErrorIs(t, eu.Fence(ctx), services.ErrRegionPassive)
+
+	current, err = eu.Promote(ctx, true, false)
+	require.NoError(t, err)
+	assert.True(t, current.Active)
+	assert.Equal(t, int64(3), current.Lease.Epoch)
+}
@@ -2,6 +2,7 @@ package services_tests
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/core/services"
 	"github.com/polygonid/sh-id-platform/internal/loader"
 	"github.com/polygonid/sh-id-platform/internal/repositories"
@@ -29,7 +32,7 @@ func TestSchema_ImportSchema(t *testing.T) {
 
 	expectHash := utils.CreateSchemaHash([]byte(urlLD + "#" + schemaType))
 
-	s := services.NewSchema(repo, loader.HTTPFactory)
+	s := services.NewSchema(repo, loader.HTTPFactory, nil, nil, false)
 	got, err := s.ImportSchema(ctx, issuerDID, url, schemaType)
 	require.NoError(t, err)
 	_, err = uuid.Parse(got.ID.String())
@@ -41,3 +44,116 @@ func TestSchema_ImportSchema(t *testing.T) {
 	assert.Len(t, got.Attributes, 3)
 	assert.InDelta(t, time.Now().UnixMilli(), got.CreatedAt.UnixMilli(), 1)
 }
+
+type ipfsStore struct {
+	docs   map[string][]byte
+	pinned []string
+}
+
+func (m *ipfsStore) Add(_ context.Context, doc []byte) (string, error) {
+	cid := fmt.Sprintf("bafkreicid%d", len(m.docs))
+	m.docs[cid] = doc
+	return cid, nil
+}
+
+func (m *ipfsStore) Cat(_ context.Context, path string) ([]byte, error) {
+	doc, ok := m.docs[path]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", path)
+	}
+	return doc, nil
+}
+
+func (m *ipfsStore) Pin(_ context.Context, path string) error {
+	m.pinned = append(m.pinned, path)
+	return nil
+}
+
+func TestSchema_ImportSchema_IPFS(t *testing.T) {
+	const schemaType = "MembershipCredential"
+	ctx := context.Background()
+	repo := repositories.NewSchemaInMemory()
+	issuerDID := core.DID{}
+	require.NoError(t, issuerDID.SetString("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ"))
+	ipfs := &ipfsStore{docs: map[string][]byte{}}
+	s := services.NewSchema(repo, loader.IPFSFactory(loader.HTTPFactory, ipfs), nil, ipfs, true)
+
+	built, err := s.BuildSchema(ctx, issuerDID, &ports.BuildSchemaRequest{
+		Type:       schemaType,
+		Version:    "1.0.0",
+		Attributes: []domain.SchemaAttributeDefinition{{Name: "memberSince", Type: domain.SchemaAttributeDate}},
+		IPFS:       true,
+	})
+	require.NoError(t, err)
+
+	got, err := s.ImportSchema(ctx, issuerDID, built.SchemaURL, schemaType)
+	require.NoError(t, err)
+	assert.Equal(t, built.SchemaURL, got.URL)
+	assert.Equal(t, utils.CreateSchemaHash([]byte(built.ContextURL+"#"+schemaType)), got.Hash)
+	assert.ElementsMatch(t, []string{"id", "memberSince"}, got.Attributes)
+	assert.Equal(t, []string{loader.IPFSPath(built.SchemaURL), loader.IPFSPath(built.ContextURL)}, ipfs.pinned)
+
+	_, err = s.ImportSchema(ctx, issuerDID, "ipfs://bafkreiunknown", schemaType)
+	assert.ErrorIs(t, err, services.ErrLoadingSchema)
+}
+
+func TestSchema_CreateSchema(t *testing.T) {
+	const jsonSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "credentialSubject": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string", "format": "uri"},
+        "birthday": {"type": "integer"},
+        "documentType": {"type": "integer"}
+      }
+    }
+  }
+}`
+	ctx := context.Background()
+	repo := repositories.NewSchemaInMemory()
+	issuerDID := core.DID{}
+	require.NoError(t, issuerDID.SetString("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ"))
+	s := services.NewSchema(repo, loader.StoredFactory(loader.HTTPFactory, repo), nil, nil, false)
+
+	req := &ports.CreateSchemaRequest{Type: "AgeCredential", Version: "1.0.0", Title: "Proof of age", JSONSchema: []byte(jsonSchema), ServerURL: "https://issuer.example.com/"}
+	got, err := s.CreateSchema(ctx, issuerDID, req)
+	require.NoError(t, err)
+	assert.Equal(t, "https://issuer.example.com/v1/schemas/"+got.ID.String()+"/jsonschema", got.URL)
+	assert.Equal(t, "https://issuer.example.com/v1/schemas/"+got.ID.String()+"/context", got.ContextURL)
+	assert.Equal(t, utils.CreateSchemaHash([]byte(got.ContextURL+"#AgeCredential")), got.Hash)
+	assert.ElementsMatch(t, []string{"id", "birthday", "documentType"}, got.Attributes)
+
+	doc, err := s.GetJSONSchema(ctx, issuerDID, got.ID)
+	require.NoError(t, err)
+	assert.Contains(t, string(doc), got.ContextURL)
+	form, err := s.GetForm(ctx, issuerDID, got.ID)
+	require.NoError(t, err)
+	assert.Len(t, form.Fields, 2)
+
+	_, err = s.CreateSchema(ctx, issuerDID, req)
+	assert.ErrorIs(t, err, services.ErrSchemaVersionNotGreater)
+	req.Version = "1.1"
+	_, err = s.CreateSchema(ctx, issuerDID, req)
+	assert.ErrorIs(t, err, services.ErrInvalidSchemaVersion)
+	req.Version, req.JSONSchema = "1.1.0", []byte(`{"type": "object"}`)
+	_, err = s.CreateSchema(ctx, issuerDID, req)
+	assert.ErrorIs(t, err, services.ErrInvalidSchemaDocument)
+
+	req.JSONSchema = []byte(jsonSchema)
+	next, err := s.CreateSchema(ctx, issuerDID, req)
+	require.NoError(t, err)
+	versions, err := s.GetVersions(ctx, issuerDID, got.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, next.ID, versions[0].ID)
+
+	deprecated, err := s.Deprecate(ctx, issuerDID, got.ID, true)
+	require.NoError(t, err)
+	assert.True(t, deprecated.Deprecated())
+	undeprecated, err := s.Deprecate(ctx, issuerDID, got.ID, false)
+	require.NoError(t, err)
+	assert.False(t, undeprecated.Deprecated())
+}
@@ -0,0 +1,137 @@
+package services_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/event"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+)
+
+func Test_schemaWebhooks(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	ps := pubsub.NewMock()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, ps)
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, services.ClaimCfg{Host: "https://host.com"}, ps)
+	dispatcher := services.NewSchemaWebhookDispatcher(claimsService, time.Second, nil)
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	type delivery struct {
+		Path          string
+		Authorization string
+		Event         string `json:"event"`
+		CredentialID  string `json:"credentialId"`
+		Type          string `json:"type"`
+		Credential    any    `json:"credential"`
+	}
+	var mu sync.Mutex
+	var deliveries []delivery
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received := delivery{Path: r.URL.Path, Authorization: r.Header.Get("Authorization")}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		mu.Lock()
+		deliveries = append(deliveries, received)
+		mu.Unlock()
+	}))
+	defer webhookServer.Close()
+
+	kyc, err := claimsService.CreateSchemaWebhook(ctx, *did, "KYCAgeCredential", webhookServer.URL+"/kyc", nil, "Bearer secret")
+	require.NoError(t, err)
+	revocations, err := claimsService.CreateSchemaWebhook(ctx, *did, "https://example.com/kyc-v3.json-ld#KYCAgeCredential", webhookServer.URL+"/revocations", []string{domain.SchemaWebhookRevoked}, "")
+	require.NoError(t, err)
+	_, err = claimsService.CreateSchemaWebhook(ctx, *did, "KYCCountryOfResidenceCredential", webhookServer.URL+"/residence", nil, "")
+	require.NoError(t, err)
+
+	credentialSubject := map[string]any{
+		"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+		"birthday":     19960424,
+		"documentType": 2,
+	}
+	schemaURL := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	claim, err := claimsService.Save(ctx, ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, common.ToPointer(time.Now().Add(time.Hour)), "KYCAgeCredential", nil, nil, nil, common.ToPointer(true), common.ToPointer(false), nil, false))
+	require.NoError(t, err)
+
+	deliver := func(t *testing.T, topic string, handler pubsub.EventHandler) []delivery {
+		t.Helper()
+		mu.Lock()
+		deliveries = nil
+		mu.Unlock()
+		events := ps.AllPublishedEvents(topic)
+		require.Len(t, events, 1)
+		payload, err := events[0].Marshal()
+		require.NoError(t, err)
+		require.NoError(t, handler(ctx, payload))
+		ps.Clear(topic)
+		mu.Lock()
+		defer mu.Unlock()
+		return deliveries
+	}
+
+	t.Run("should notify the webhooks of the schema type of the issued credential", func(t *testing.T) {
+		received := deliver(t, event.CreateCredentialEvent, dispatcher.DeliverIssued)
+		require.Len(t, received, 1)
+		assert.Equal(t, "/kyc", received[0].Path)
+		assert.Equal(t, "Bearer secret", received[0].Authorization)
+		assert.Equal(t, domain.SchemaWebhookIssued, received[0].Event)
+		assert.Equal(t, claim.ID.String(), received[0].CredentialID)
+		assert.NotNil(t, received[0].Credential)
+	})
+
+	t.Run("should notify the webhooks of the schema type of the revoked credential", func(t *testing.T) {
+		require.NoError(t, claimsService.Revoke(ctx, *did, uint64(claim.RevNonce), "test"))
+		received := deliver(t, event.RevokeCredentialEvent, dispatcher.DeliverRevoked)
+		require.Len(t, received, 2)
+		paths := []string{received[0].Path, received[1].Path}
+		assert.ElementsMatch(t, []string{"/kyc", "/revocations"}, paths)
+		for _, item := range received {
+			assert.Equal(t, domain.SchemaWebhookRevoked, item.Event)
+			assert.Equal(t, claim.ID.String(), item.CredentialID)
+			assert.Nil(t, item.Credential)
+		}
+	})
+
+	t.Run("should list and delete the webhooks", func(t *testing.T) {
+		webhooks, err := claimsService.GetSchemaWebhooks(ctx, *did)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 3)
+		assert.Equal(t, kyc.ID, webhooks[0].ID)
+		assert.Equal(t, revocations.ID, webhooks[1].ID)
+
+		require.NoError(t, claimsService.DeleteSchemaWebhook(ctx, *did, kyc.ID))
+		assert.ErrorIs(t, claimsService.DeleteSchemaWebhook(ctx, *did, kyc.ID), services.ErrSchemaWebhookNotFound)
+		assert.ErrorIs(t, claimsService.DeleteSchemaWebhook(ctx, *did, uuid.New()), services.ErrSchemaWebhookNotFound)
+		webhooks, err = claimsService.GetSchemaWebhooks(ctx, *did)
+		require.NoError(t, err)
+		assert.Len(t, webhooks, 2)
+	})
+}
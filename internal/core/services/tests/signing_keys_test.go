@@ -0,0 +1,168 @@
+package services_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+)
+
+func Test_signingKeys(t *testing.T) {
+	ctx := context.Background()
+	identityRepo := repositories.NewIdentity()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	rhsp := reverse_hash.NewRhsPublisher(nil, false)
+	connectionsRepository := repositories.NewConnections()
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, connectionsRepository, storage, rhsp, nil, nil, pubsub.NewMock())
+	schemaLoader := loader.CachedFactory(loader.HTTPFactory, cachex)
+
+	claimsConf := services.ClaimCfg{
+		RHSEnabled:           false,
+		Host:                 "https://host.com",
+		SigningKeyRoundRobin: true,
+	}
+	claimsService := services.NewClaim(claimsRepo, identityService, mtService, identityStateRepo, schemaLoader, storage, claimsConf, pubsub.NewMock())
+	signingKeyService := services.NewSigningKeys(keyStore, identityService, claimsService, claimsRepo, storage, "https://host.com")
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	keys, err := signingKeyService.GetAll(ctx, *did)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, domain.SigningKeyActive, keys[0].Status)
+	activeKey := keys[0]
+
+	added, err := signingKeyService.Add(ctx, *did, domain.SigningKeyBJJ)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SigningKeyPending, added.Status)
+	assert.Equal(t, domain.SigningKeyBJJ, added.Type)
+	assert.NotEqual(t, activeKey.KeyID, added.KeyID)
+
+	newRequest := func(keyID *string) *ports.CreateClaimRequest {
+		credentialSubject := map[string]any{
+			"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+			"birthday":     19960424,
+			"documentType": 2,
+		}
+		schemaURL := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+		req := ports.NewCreateClaimRequest(did, schemaURL, credentialSubject, common.ToPointer(time.Now().Add(time.Hour)), "KYCAgeCredential", nil, nil, common.ToPointer("index"), common.ToPointer(true), common.ToPointer(false), nil, false)
+		req.SigningKeyID = keyID
+		return req
+	}
+
+	t.Run("should list the active keys first", func(t *testing.T) {
+		keys, err := signingKeyService.GetAll(ctx, *did)
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		assert.Equal(t, activeKey.AuthClaimID, keys[0].AuthClaimID)
+		assert.Equal(t, added.AuthClaimID, keys[1].AuthClaimID)
+	})
+
+	t.Run("should sign with the requested key", func(t *testing.T) {
+		claim, err := claimsService.Save(ctx, newRequest(&activeKey.KeyID))
+		require.NoError(t, err)
+		proof, err := claim.GetBJJSignatureProof2021()
+		require.NoError(t, err)
+		authClaim, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, did, activeKey.AuthClaimID)
+		require.NoError(t, err)
+		authCoreClaim, err := authClaim.CoreClaim.Get().Hex()
+		require.NoError(t, err)
+		assert.Equal(t, authCoreClaim, proof.IssuerData.AuthCoreClaim)
+	})
+
+	t.Run("should not sign with a pending key", func(t *testing.T) {
+		_, err := claimsService.Save(ctx, newRequest(&added.KeyID))
+		assert.ErrorIs(t, err, services.ErrSigningKeyNotFound)
+	})
+
+	t.Run("should sign in turn with the active keys", func(t *testing.T) {
+		_, err := claimsService.Save(ctx, newRequest(nil))
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not remove the last active key", func(t *testing.T) {
+		err := signingKeyService.Remove(ctx, *did, activeKey.AuthClaimID)
+		assert.ErrorIs(t, err, services.ErrLastSigningKey)
+	})
+
+	t.Run("should not remove an unknown key", func(t *testing.T) {
+		err := signingKeyService.Remove(ctx, *did, uuid.New())
+		assert.ErrorIs(t, err, services.ErrSigningKeyNotFound)
+	})
+
+	t.Run("should remove a key", func(t *testing.T) {
+		require.NoError(t, signingKeyService.Remove(ctx, *did, added.AuthClaimID))
+		keys, err := signingKeyService.GetAll(ctx, *did)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, activeKey.AuthClaimID, keys[0].AuthClaimID)
+	})
+
+	t.Run("should not add a key of an unknown type", func(t *testing.T) {
+		_, err := signingKeyService.Add(ctx, *did, "RSA")
+		assert.ErrorIs(t, err, services.ErrSigningKeyType)
+	})
+
+	t.Run("should sign with a secp256k1 key once its auth claim is published", func(t *testing.T) {
+		secp256k1Key, err := signingKeyService.Add(ctx, *did, domain.SigningKeySecp256k1)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SigningKeySecp256k1, secp256k1Key.Type)
+		assert.Equal(t, domain.SigningKeyPending, secp256k1Key.Status)
+		_, err = claimsService.Save(ctx, newRequest(&secp256k1Key.KeyID))
+		assert.ErrorIs(t, err, services.ErrSigningKeyNotFound)
+
+		// the auth claim gets its merkle tree proof when the state that adds it is published
+		authClaim, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, did, secp256k1Key.AuthClaimID)
+		require.NoError(t, err)
+		activeAuthClaim, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, did, activeKey.AuthClaimID)
+		require.NoError(t, err)
+		authClaim.MTPProof = activeAuthClaim.MTPProof
+		_, err = claimsRepo.Save(ctx, storage.Pgx, authClaim)
+		require.NoError(t, err)
+
+		keys, err := signingKeyService.GetAll(ctx, *did)
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		assert.Equal(t, secp256k1Key.AuthClaimID, keys[1].AuthClaimID)
+		assert.Equal(t, domain.SigningKeyActive, keys[1].Status)
+
+		claim, err := claimsService.Save(ctx, newRequest(&secp256k1Key.KeyID))
+		require.NoError(t, err)
+		proof, err := claim.GetSignatureProof()
+		require.NoError(t, err)
+		require.Equal(t, domain.EcdsaSecp256k1SignatureProofType, proof.ProofType())
+		assert.NoError(t, proof.(*domain.EcdsaSecp256k1SignatureProof2019).Verify())
+		_, err = claim.GetBJJSignatureProof2021()
+		assert.Error(t, err)
+
+		signers, err := signingKeyService.Secp256k1Signers(ctx, *did)
+		require.NoError(t, err)
+		require.Len(t, signers, 1)
+		assert.Equal(t, domain.AuthEthAddress(authClaim.CoreClaim.Get()), signers[0])
+
+		require.NoError(t, signingKeyService.Remove(ctx, *did, secp256k1Key.AuthClaimID))
+		signers, err = signingKeyService.Secp256k1Signers(ctx, *did)
+		require.NoError(t, err)
+		assert.Empty(t, signers)
+	})
+}
@@ -0,0 +1,79 @@
+package services_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/event"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func Test_verificationWebhook_Deliver(t *testing.T) {
+	ctx := context.Background()
+	did, err := core.ParseDID("did:polygonid:polygon:mumbai:2qKZg1vCMwJeMzvVayn9ebUHpnD6QCxTgk6T28THxy")
+	require.NoError(t, err)
+	verification := &domain.Verification{
+		ID:          uuid.New(),
+		IssuerDID:   *did,
+		SessionID:   uuid.New(),
+		UserDID:     common.ToPointer("did:polygonid:polygon:mumbai:2qFXmNqGWPrLqDowKz37Gq2FETk4yQwVUVUqeBLmf9"),
+		Queries:     json.RawMessage(`[{"id": 1, "circuitId": "credentialAtomicQuerySigV2"}]`),
+		ProofHashes: []string{"015abd7f5cc57a2dd94b7590f04ad8084273905ee33ec5cebeae62276a97f862"},
+		Verified:    true,
+		CreatedAt:   time.Now(),
+	}
+	require.NoError(t, repositories.NewVerification().Save(ctx, storage.Pgx, verification))
+
+	var received struct {
+		ID          string          `json:"id"`
+		Issuer      string          `json:"issuer"`
+		User        string          `json:"user"`
+		Queries     json.RawMessage `json:"queries"`
+		ProofHashes []string        `json:"proofHashes"`
+		Verified    bool            `json:"verified"`
+	}
+	var authorization string
+	status := http.StatusOK
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(status)
+	}))
+	defer webhookServer.Close()
+
+	webhook, err := services.NewVerificationWebhook(webhookServer.URL, "Bearer secret", time.Second, nil, storage)
+	require.NoError(t, err)
+
+	payload, err := (&event.Verification{VerificationID: verification.ID.String(), IssuerID: did.String()}).Marshal()
+	require.NoError(t, err)
+	require.NoError(t, webhook.Deliver(ctx, payload))
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Equal(t, verification.ID.String(), received.ID)
+	assert.Equal(t, did.String(), received.Issuer)
+	assert.Equal(t, *verification.UserDID, received.User)
+	assert.JSONEq(t, string(verification.Queries), string(received.Queries))
+	assert.Equal(t, verification.ProofHashes, received.ProofHashes)
+	assert.True(t, received.Verified)
+
+	status = http.StatusInternalServerError
+	assert.Error(t, webhook.Deliver(ctx, payload))
+
+	payload, err = (&event.Verification{VerificationID: uuid.NewString(), IssuerID: did.String()}).Marshal()
+	require.NoError(t, err)
+	assert.ErrorIs(t, webhook.Deliver(ctx, payload), repositories.ErrVerificationDoesNotExist)
+
+	_, err = services.NewVerificationWebhook("ftp://example.com", "", time.Second, nil, storage)
+	assert.ErrorIs(t, err, services.ErrInvalidVerificationWebhook)
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/event"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+)
+
+// ErrInvalidVerificationWebhook means the url of the verification webhook is not an http or https url
+var ErrInvalidVerificationWebhook = errors.New("invalid verification webhook url")
+
+// verificationWebhookRequest is the body POSTed to the verification webhook
+type verificationWebhookRequest struct {
+	ID          string          `json:"id"`
+	Issuer      string          `json:"issuer"`
+	SessionID   string          `json:"sessionId"`
+	User        *string         `json:"user,omitempty"`
+	Queries     json.RawMessage `json:"queries"`
+	ProofHashes []string        `json:"proofHashes"`
+	Verified    bool            `json:"verified"`
+	Error       *string         `json:"error,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+type verificationWebhook struct {
+	url           string
+	authorization string
+	client        *http.Client
+	repo          ports.VerificationRepository
+	storage       *db.Storage
+}
+
+// NewVerificationWebhook returns the webhook that POSTs the verifications of the VerificationEvents as JSON to
+// webhookURL, with transport or with http.DefaultTransport when it is nil. If authorization is not empty it is sent as
+// the Authorization header.
+func NewVerificationWebhook(webhookURL string, authorization string, timeout time.Duration, transport http.RoundTripper, storage *db.Storage) (ports.VerificationWebhook, error) {
+	u, err := url.ParseRequestURI(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w <%s>", ErrInvalidVerificationWebhook, webhookURL)
+	}
+	return &verificationWebhook{
+		url:           webhookURL,
+		authorization: authorization,
+		client:        &http.Client{Timeout: timeout, Transport: transport},
+		repo:          repositories.NewVerification(),
+		storage:       storage,
+	}, nil
+}
+
+// Deliver POSTs the verification of a VerificationEvent to the webhook
+func (w *verificationWebhook) Deliver(ctx context.Context, e pubsub.Message) error {
+	var verificationEvent event.Verification
+	if err := verificationEvent.Unmarshal(e); err != nil {
+		return errors.New("deliverVerification unexpected data type")
+	}
+	issuerDID, err := core.ParseDID(verificationEvent.IssuerID)
+	if err != nil {
+		return fmt.Errorf("deliverVerification invalid issuer: %w", err)
+	}
+	verificationID, err := uuid.Parse(verificationEvent.VerificationID)
+	if err != nil {
+		return fmt.Errorf("deliverVerification invalid verification id: %w", err)
+	}
+	verification, err := w.repo.GetByID(ctx, w.storage.Pgx, *issuerDID, verificationID)
+	if err != nil {
+		return err
+	}
+	return w.post(ctx, verification)
+}
+
+func (w *verificationWebhook) post(ctx context.Context, verification *domain.Verification) error {
+	body, err := json.Marshal(verificationWebhookRequest{
+		ID:          verification.ID.String(),
+		Issuer:      verification.IssuerDID.String(),
+		SessionID:   verification.SessionID.String(),
+		User:        verification.UserDID,
+		Queries:     verification.Queries,
+		ProofHashes: verification.ProofHashes,
+		Verified:    verification.Verified,
+		Error:       verification.Error,
+		CreatedAt:   verification.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.authorization != "" {
+		req.Header.Set("Authorization", w.authorization)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("verification webhook: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
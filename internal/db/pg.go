@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
@@ -16,3 +17,20 @@ type Querier interface {
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 	QueryFunc(ctx context.Context, sql string, args []interface{}, scans []interface{}, f func(pgx.QueryFuncRow) error) (pgconn.CommandTag, error)
 }
+
+// readOnlyTransactionCode is the postgres error of a write to a database that only reads, like a replica in recovery
+const readOnlyTransactionCode = "25006"
+
+// IsReadOnly tells whether err is postgres refusing a write because the database only reads, like the replica of a
+// passive region
+func IsReadOnly(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == readOnlyTransactionCode
+}
+
+// SetCredentialEvent names the insertions and deletions of claims recorded in the credential log until the
+// transaction ends, like "archived" or "erased". "none" records nothing.
+func SetCredentialEvent(ctx context.Context, tx Querier, event string) error {
+	_, err := tx.Exec(ctx, `SELECT set_config('issuer.credential_event', $1, true)`, event)
+	return err
+}
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE subject_erasures
+(
+    id           uuid        NOT NULL PRIMARY KEY,
+    issuer_id    text        NOT NULL,
+    subject_hash text        NOT NULL,
+    credentials  int8        NOT NULL,
+    connections  int8        NOT NULL,
+    created_at   timestamptz NOT NULL,
+    CONSTRAINT subject_erasures_identities_id_key foreign key (issuer_id) references identities (identifier)
+);
+CREATE INDEX subject_erasures_issuer_subject_hash_idx ON subject_erasures (issuer_id, subject_hash);
+
+CREATE TABLE erased_claims
+(
+    id          uuid    NOT NULL PRIMARY KEY,
+    erasure_id  uuid    NOT NULL,
+    identifier  text    NOT NULL,
+    schema_hash text    NOT NULL,
+    rev_nonce   numeric NOT NULL,
+    index_hash  varchar NULL,
+    revoked     bool    NOT NULL DEFAULT false,
+    CONSTRAINT erased_claims_identifier_rev_nonce_key UNIQUE (identifier, rev_nonce),
+    CONSTRAINT erased_claims_subject_erasures_id_key foreign key (erasure_id) references subject_erasures (id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS erased_claims;
+DROP TABLE IF EXISTS subject_erasures;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE connections
+    ADD COLUMN last_seen timestamptz NULL;
+UPDATE connections SET last_seen = modified_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE connections DROP COLUMN last_seen;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE schemas ADD COLUMN default_proof_types text[] NULL;
+ALTER TABLE identities ADD COLUMN default_proof_types text[] NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE identities DROP COLUMN default_proof_types;
+ALTER TABLE schemas DROP COLUMN default_proof_types;
+-- +goose StatementEnd
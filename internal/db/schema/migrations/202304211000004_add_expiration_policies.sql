@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE schemas ADD COLUMN default_expiration text NULL;
+ALTER TABLE links ADD COLUMN credential_expiration_policy text NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE links DROP COLUMN credential_expiration_policy;
+ALTER TABLE schemas DROP COLUMN default_expiration;
+-- +goose StatementEnd
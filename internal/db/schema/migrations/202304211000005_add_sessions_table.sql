@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE sessions
+(
+    key          text PRIMARY KEY,
+    payload_type text                     NOT NULL,
+    payload      jsonb                    NOT NULL,
+    expires_at   timestamp with time zone NOT NULL,
+    created_at   timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE INDEX sessions_expires_at_index ON sessions (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE sessions;
+-- +goose StatementEnd
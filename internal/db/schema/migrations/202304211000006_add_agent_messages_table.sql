@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE agent_messages
+(
+    hash       bytea PRIMARY KEY,
+    expires_at timestamp with time zone NOT NULL
+);
+CREATE INDEX agent_messages_expires_at_index ON agent_messages (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE agent_messages;
+-- +goose StatementEnd
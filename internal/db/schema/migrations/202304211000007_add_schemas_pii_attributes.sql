@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE schemas ADD COLUMN pii_attributes text[] NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE schemas DROP COLUMN pii_attributes;
+-- +goose StatementEnd
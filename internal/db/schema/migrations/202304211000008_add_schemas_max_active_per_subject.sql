@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE schemas ADD COLUMN max_active_per_subject integer NULL CHECK (max_active_per_subject > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE schemas DROP COLUMN max_active_per_subject;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE schemas ADD COLUMN post_issuance_hooks jsonb;
+ALTER TABLE claims ADD COLUMN external_references jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE claims DROP COLUMN external_references;
+ALTER TABLE schemas DROP COLUMN post_issuance_hooks;
+-- +goose StatementEnd
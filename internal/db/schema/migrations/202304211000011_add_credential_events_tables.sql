@@ -0,0 +1,85 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE credential_events
+(
+    issuer     text        NOT NULL,
+    seq        int8        NOT NULL,
+    claim_id   uuid        NOT NULL,
+    event      text        NOT NULL,
+    created_at timestamptz NOT NULL,
+    prev_hash  bytea       NOT NULL,
+    hash       bytea       NOT NULL,
+    PRIMARY KEY (issuer, seq)
+);
+
+CREATE TABLE credential_event_anchors
+(
+    issuer     text        NOT NULL,
+    seq        int8        NOT NULL,
+    hash       bytea       NOT NULL,
+    state      text        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (issuer, seq)
+);
+
+-- record_credential_event chains an event of a claim to the log of its issuer: the hash of each event is the sha256
+-- of the hash of the previous one and of "issuer|seq|claim id|event|created_at in microseconds". The lock serializes
+-- the events of an issuer until the transaction ends.
+CREATE FUNCTION record_credential_event(event_issuer text, event_claim_id uuid, event_kind text) RETURNS void AS
+$$
+DECLARE
+    previous bytea;
+    next_seq int8;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('credential_events:' || event_issuer));
+    SELECT seq + 1, hash INTO next_seq, previous FROM credential_events WHERE issuer = event_issuer ORDER BY seq DESC LIMIT 1;
+    IF NOT FOUND THEN
+        next_seq := 1;
+        previous := decode(repeat('00', 32), 'hex');
+    END IF;
+    INSERT INTO credential_events (issuer, seq, claim_id, event, created_at, prev_hash, hash)
+    VALUES (event_issuer, next_seq, event_claim_id, event_kind, NOW(), previous,
+            sha256(previous || convert_to(event_issuer || '|' || next_seq || '|' || event_claim_id || '|' || event_kind || '|' ||
+                                          (extract(EPOCH FROM NOW()) * 1000000)::int8, 'UTF8')));
+END;
+$$ LANGUAGE plpgsql;
+
+-- append_credential_event records the insertions, revocations and deletions of the claims. The transaction setting
+-- issuer.credential_event names the insertions and deletions, like archived or erased, and none records nothing.
+CREATE FUNCTION append_credential_event() RETURNS TRIGGER AS
+$$
+DECLARE
+    setting text := coalesce(current_setting('issuer.credential_event', true), '');
+BEGIN
+    IF setting = 'none' THEN
+        RETURN NULL;
+    END IF;
+    IF TG_OP = 'UPDATE' THEN
+        PERFORM record_credential_event(NEW.identifier, NEW.id, 'revoked');
+    ELSIF TG_OP = 'INSERT' THEN
+        PERFORM record_credential_event(NEW.identifier, NEW.id, CASE setting WHEN '' THEN 'issued' ELSE setting END);
+    ELSE
+        PERFORM record_credential_event(OLD.identifier, OLD.id, CASE setting WHEN '' THEN 'deleted' ELSE setting END);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER append_credential_event_insert AFTER INSERT ON claims
+    FOR EACH ROW EXECUTE PROCEDURE append_credential_event();
+CREATE TRIGGER append_credential_event_revoke AFTER UPDATE OF revoked ON claims
+    FOR EACH ROW WHEN (NEW.revoked IS TRUE AND OLD.revoked IS NOT TRUE) EXECUTE PROCEDURE append_credential_event();
+CREATE TRIGGER append_credential_event_delete AFTER DELETE ON claims
+    FOR EACH ROW EXECUTE PROCEDURE append_credential_event();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS append_credential_event_insert ON claims;
+DROP TRIGGER IF EXISTS append_credential_event_revoke ON claims;
+DROP TRIGGER IF EXISTS append_credential_event_delete ON claims;
+DROP FUNCTION IF EXISTS append_credential_event;
+DROP FUNCTION IF EXISTS record_credential_event;
+DROP TABLE IF EXISTS credential_event_anchors;
+DROP TABLE IF EXISTS credential_events;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE schema_usage (
+    issuer_id text NOT NULL,
+    schema_url text NOT NULL,
+    day date NOT NULL,
+    issued int8 NOT NULL DEFAULT 0,
+    issued_by_link int8 NOT NULL DEFAULT 0,
+    revoked int8 NOT NULL DEFAULT 0,
+    CONSTRAINT schema_usage_pkey PRIMARY KEY (issuer_id, schema_url, day)
+);
+
+INSERT INTO schema_usage (issuer_id, schema_url, day, issued, issued_by_link)
+SELECT identifier, schema_url, COALESCE((data->>'issuanceDate')::timestamptz, NOW())::date, COUNT(*), COUNT(link_id)
+FROM claims
+WHERE schema_url <> 'https://schema.iden3.io/core/json/auth.json'
+GROUP BY 1, 2, 3;
+
+INSERT INTO schema_usage (issuer_id, schema_url, day, revoked)
+SELECT claims.identifier, claims.schema_url, revocation.created_at::date, COUNT(*)
+FROM revocation
+JOIN claims ON claims.identifier = revocation.identifier AND claims.rev_nonce = revocation.nonce
+WHERE claims.revoked AND claims.schema_url <> 'https://schema.iden3.io/core/json/auth.json'
+GROUP BY 1, 2, 3
+ON CONFLICT (issuer_id, schema_url, day) DO UPDATE SET revoked = EXCLUDED.revoked;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS schema_usage;
+-- +goose StatementEnd
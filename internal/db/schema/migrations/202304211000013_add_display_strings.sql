@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE schemas ADD COLUMN display_strings jsonb;
+ALTER TABLE links ADD COLUMN display_strings jsonb;
+ALTER TABLE links ADD COLUMN locale text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE links DROP COLUMN locale;
+ALTER TABLE links DROP COLUMN display_strings;
+ALTER TABLE schemas DROP COLUMN display_strings;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE hook_deliveries (
+    id uuid NOT NULL,
+    issuer_id text NOT NULL,
+    hook text NOT NULL,
+    url text NOT NULL,
+    test boolean NOT NULL DEFAULT false,
+    request jsonb NOT NULL,
+    status int,
+    response text,
+    error text,
+    duration_ms int8 NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    CONSTRAINT hook_deliveries_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX hook_deliveries_issuer_id_created_at_idx ON hook_deliveries (issuer_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS hook_deliveries;
+-- +goose StatementEnd
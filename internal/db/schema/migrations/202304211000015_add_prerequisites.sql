@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE schemas ADD COLUMN prerequisites jsonb;
+ALTER TABLE links ADD COLUMN prerequisites jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE links DROP COLUMN prerequisites;
+ALTER TABLE schemas DROP COLUMN prerequisites;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE api_keys (
+    id uuid NOT NULL,
+    issuer_id text NOT NULL,
+    name text NOT NULL,
+    secret_hash bytea NOT NULL,
+    schema_types text[],
+    link_ids uuid[],
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    CONSTRAINT api_keys_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX api_keys_issuer_id_idx ON api_keys (issuer_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS api_keys;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE metering (
+    issuer_id text NOT NULL,
+    api_key_id uuid NOT NULL,
+    operation text NOT NULL,
+    day date NOT NULL,
+    count int8 NOT NULL DEFAULT 0,
+    CONSTRAINT metering_pkey PRIMARY KEY (issuer_id, api_key_id, operation, day)
+);
+
+INSERT INTO metering (issuer_id, api_key_id, operation, day, count)
+SELECT identifier, '00000000-0000-0000-0000-000000000000', 'issuance', COALESCE((data->>'issuanceDate')::timestamptz, NOW())::date, COUNT(*)
+FROM claims
+WHERE schema_url <> 'https://schema.iden3.io/core/json/auth.json'
+GROUP BY 1, 4;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS metering;
+-- +goose StatementEnd
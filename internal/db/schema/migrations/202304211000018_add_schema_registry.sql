@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE schemas ADD COLUMN version text;
+ALTER TABLE schemas ADD COLUMN title text;
+ALTER TABLE schemas ADD COLUMN description text;
+ALTER TABLE schemas ADD COLUMN deprecated_at timestamptz;
+ALTER TABLE schemas ADD COLUMN json_schema bytea;
+ALTER TABLE schemas ADD COLUMN jsonld_context bytea;
+ALTER TABLE schemas ADD COLUMN context_url text;
+CREATE UNIQUE INDEX schemas_issuer_id_type_version_key ON schemas (issuer_id, type, version) WHERE version IS NOT NULL;
+CREATE INDEX schemas_context_url_idx ON schemas (context_url) WHERE context_url IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS schemas_context_url_idx;
+DROP INDEX IF EXISTS schemas_issuer_id_type_version_key;
+ALTER TABLE schemas DROP COLUMN context_url;
+ALTER TABLE schemas DROP COLUMN jsonld_context;
+ALTER TABLE schemas DROP COLUMN json_schema;
+ALTER TABLE schemas DROP COLUMN deprecated_at;
+ALTER TABLE schemas DROP COLUMN description;
+ALTER TABLE schemas DROP COLUMN title;
+ALTER TABLE schemas DROP COLUMN version;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE maintenance (
+    id boolean NOT NULL DEFAULT true,
+    enabled boolean NOT NULL DEFAULT false,
+    message text,
+    retry_after int8,
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    CONSTRAINT maintenance_pkey PRIMARY KEY (id),
+    CONSTRAINT maintenance_single_row CHECK (id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS maintenance;
+-- +goose StatementEnd
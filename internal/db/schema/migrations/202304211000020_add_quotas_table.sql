@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE quotas (
+    issuer_id text NOT NULL,
+    max_active_links int4,
+    max_credentials_per_day int4,
+    max_schemas int4,
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    CONSTRAINT quotas_pkey PRIMARY KEY (issuer_id),
+    CONSTRAINT quotas_identities_id_key FOREIGN KEY (issuer_id) REFERENCES identities (identifier)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS quotas;
+-- +goose StatementEnd
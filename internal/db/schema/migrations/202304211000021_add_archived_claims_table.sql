@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE archived_claims
+(
+    id          uuid        NOT NULL PRIMARY KEY,
+    identifier  text        NOT NULL,
+    schema_url  text        NOT NULL,
+    rev_nonce   numeric     NOT NULL,
+    revoked     bool        NOT NULL DEFAULT false,
+    expiration  int8        NULL,
+    claim       bytea       NOT NULL,
+    archived_at timestamptz NOT NULL DEFAULT NOW(),
+    CONSTRAINT archived_claims_identifier_rev_nonce_key UNIQUE (identifier, rev_nonce)
+);
+CREATE INDEX archived_claims_identifier_archived_at_idx ON archived_claims (identifier, archived_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS archived_claims;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE verifications
+(
+    id           uuid        NOT NULL PRIMARY KEY,
+    issuer_id    text        NOT NULL,
+    session_id   uuid        NOT NULL,
+    user_id      text        NULL,
+    queries      jsonb       NOT NULL DEFAULT '[]',
+    proof_hashes text[]      NOT NULL DEFAULT '{}',
+    verified     bool        NOT NULL,
+    error        text        NULL,
+    created_at   timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX verifications_issuer_id_created_at_idx ON verifications (issuer_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS verifications;
+-- +goose StatementEnd
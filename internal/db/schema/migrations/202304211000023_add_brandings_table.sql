@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE brandings (
+    issuer_id text NOT NULL,
+    display_name text,
+    logo_url text,
+    primary_color text,
+    text_color text,
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    CONSTRAINT brandings_pkey PRIMARY KEY (issuer_id),
+    CONSTRAINT brandings_identities_id_key FOREIGN KEY (issuer_id) REFERENCES identities (identifier)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS brandings;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE region_lease (
+    id boolean NOT NULL DEFAULT true,
+    region text NOT NULL,
+    epoch int8 NOT NULL,
+    expires_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    CONSTRAINT region_lease_pkey PRIMARY KEY (id),
+    CONSTRAINT region_lease_single_row CHECK (id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS region_lease;
+-- +goose StatementEnd
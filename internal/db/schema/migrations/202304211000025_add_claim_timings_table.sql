@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE claim_timings
+(
+    claim_id   uuid        NOT NULL PRIMARY KEY REFERENCES claims (id) ON DELETE CASCADE,
+    issuer_id  text        NOT NULL,
+    phases     jsonb       NOT NULL DEFAULT '{}',
+    total_us   int8        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX claim_timings_issuer_id_created_at_idx ON claim_timings (issuer_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS claim_timings;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE key_rotations
+(
+    id                uuid        NOT NULL PRIMARY KEY,
+    issuer_id         text        NOT NULL REFERENCES identities (identifier),
+    status            text        NOT NULL,
+    old_auth_claim_id uuid        NOT NULL,
+    new_auth_claim_id uuid        NOT NULL,
+    new_key_id        text        NOT NULL,
+    add_state         text,
+    revoke_state      text,
+    error             text,
+    created_at        timestamptz NOT NULL DEFAULT NOW(),
+    modified_at       timestamptz NOT NULL DEFAULT NOW()
+);
+-- an identity rotates one key at a time
+CREATE UNIQUE INDEX key_rotations_issuer_id_active_idx ON key_rotations (issuer_id) WHERE status <> 'completed';
+CREATE INDEX key_rotations_issuer_id_created_at_idx ON key_rotations (issuer_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS key_rotations;
+-- +goose StatementEnd
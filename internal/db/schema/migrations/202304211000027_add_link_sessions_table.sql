@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE link_sessions
+(
+    id          uuid        NOT NULL PRIMARY KEY,
+    link_id     uuid        NOT NULL REFERENCES links (id) ON DELETE CASCADE,
+    issuer_id   text        NOT NULL REFERENCES identities (identifier),
+    status      text        NOT NULL,
+    user_id     text,
+    claim_id    uuid,
+    error       text,
+    created_at  timestamptz NOT NULL DEFAULT NOW(),
+    modified_at timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX link_sessions_link_id_created_at_idx ON link_sessions (link_id, created_at);
+CREATE INDEX link_sessions_claim_id_idx ON link_sessions (claim_id) WHERE claim_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS link_sessions;
+-- +goose StatementEnd
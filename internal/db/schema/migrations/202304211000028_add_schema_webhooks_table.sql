@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE schema_webhooks
+(
+    id            uuid        NOT NULL PRIMARY KEY,
+    issuer_id     text        NOT NULL REFERENCES identities (identifier),
+    schema_type   text        NOT NULL,
+    url           text        NOT NULL,
+    events        text[]      NOT NULL,
+    auth_header   text        NOT NULL DEFAULT '',
+    created_at    timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX schema_webhooks_issuer_id_idx ON schema_webhooks (issuer_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS schema_webhooks;
+-- +goose StatementEnd
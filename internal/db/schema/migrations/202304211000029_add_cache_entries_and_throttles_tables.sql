@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE cache_entries
+(
+    key        text PRIMARY KEY,
+    value      bytea       NOT NULL,
+    expires_at timestamptz
+);
+CREATE INDEX cache_entries_expires_at_idx ON cache_entries (expires_at) WHERE expires_at IS NOT NULL;
+
+CREATE TABLE throttles
+(
+    scope     text             NOT NULL,
+    ip        text             NOT NULL,
+    tokens    double precision NOT NULL,
+    strikes   double precision NOT NULL,
+    last_seen timestamptz      NOT NULL,
+    PRIMARY KEY (scope, ip)
+);
+CREATE INDEX throttles_last_seen_idx ON throttles (last_seen);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS throttles;
+DROP TABLE IF EXISTS cache_entries;
+-- +goose StatementEnd
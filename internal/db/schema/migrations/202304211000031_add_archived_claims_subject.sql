@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE archived_claims
+    ADD COLUMN subject     text    NULL,
+    ADD COLUMN schema_hash text    NULL,
+    ADD COLUMN index_hash  varchar NULL;
+CREATE INDEX archived_claims_identifier_subject_idx ON archived_claims (identifier, subject);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS archived_claims_identifier_subject_idx;
+ALTER TABLE archived_claims
+    DROP COLUMN subject,
+    DROP COLUMN schema_hash,
+    DROP COLUMN index_hash;
+-- +goose StatementEnd
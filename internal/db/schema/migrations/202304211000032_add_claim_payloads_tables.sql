@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE payloads
+(
+    key        text        NOT NULL PRIMARY KEY,
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE claim_payloads
+(
+    claim_id    uuid NOT NULL,
+    payload_key text NOT NULL,
+    PRIMARY KEY (claim_id, payload_key)
+);
+CREATE INDEX claim_payloads_payload_key_idx ON claim_payloads (payload_key);
+
+-- the references of the payloads offloaded before they were kept
+INSERT INTO claim_payloads (claim_id, payload_key)
+SELECT DISTINCT c.id, s.value ->> '$payloadRef'
+FROM claims c,
+     jsonb_each(CASE WHEN jsonb_typeof(c.data -> 'credentialSubject') = 'object' THEN c.data -> 'credentialSubject' ELSE '{}'::jsonb END) s
+WHERE jsonb_typeof(s.value) = 'object' AND s.value ? '$payloadRef';
+INSERT INTO payloads (key) SELECT DISTINCT payload_key FROM claim_payloads;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS claim_payloads;
+DROP TABLE IF EXISTS payloads;
+-- +goose StatementEnd
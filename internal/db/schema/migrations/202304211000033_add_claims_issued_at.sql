@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE claims ADD COLUMN issued_at timestamptz NULL;
+UPDATE claims SET issued_at = (data->>'issuanceDate')::timestamptz;
+
+-- set_claim_issued_at copies the issuance date of the credential to issued_at, so the listings are sorted by an
+-- indexed column, whatever inserts the claims.
+CREATE FUNCTION set_claim_issued_at() RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.issued_at := (NEW.data->>'issuanceDate')::timestamptz;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER set_claim_issued_at BEFORE INSERT OR UPDATE OF data ON claims
+    FOR EACH ROW EXECUTE PROCEDURE set_claim_issued_at();
+CREATE INDEX claims_identifier_issued_at_idx ON claims (identifier, issued_at DESC NULLS LAST, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS claims_identifier_issued_at_idx;
+DROP TRIGGER IF EXISTS set_claim_issued_at ON claims;
+DROP FUNCTION IF EXISTS set_claim_issued_at;
+ALTER TABLE claims DROP COLUMN issued_at;
+-- +goose StatementEnd
@@ -4,7 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/pressly/goose/v3"
 
@@ -14,10 +20,76 @@ import (
 //go:embed migrations/*.sql
 var embedMigrations embed.FS
 
+// ErrUnknownVersion means the version to migrate to is not one of the migrations
+var ErrUnknownVersion = errors.New("unknown migration version")
+
+var (
+	createTableRE = regexp.MustCompile(`(?i)CREATE TABLE (?:IF NOT EXISTS )?([a-z_]+)`)
+	dropTableRE   = regexp.MustCompile(`(?i)DROP TABLE (?:IF EXISTS )?([a-z_]+)`)
+)
+
 // Migrate runs migrations on the databaseURL
 func Migrate(databaseURL string) error {
-	var db *sql.DB
-	// setup database
+	return withMigrations(databaseURL, func(db *sql.DB) error {
+		if err := goose.Up(db, "migrations"); err != nil {
+			return fmt.Errorf("error trying to run migrations: %w", err)
+		}
+		return nil
+	})
+}
+
+// MigrateDown rolls back the last migration applied to the database on databaseURL
+func MigrateDown(databaseURL string) error {
+	return withMigrations(databaseURL, func(db *sql.DB) error {
+		if err := goose.Down(db, "migrations"); err != nil {
+			return fmt.Errorf("error trying to roll back the last migration: %w", err)
+		}
+		return nil
+	})
+}
+
+// MigrateTo applies or rolls back the migrations of the database on databaseURL until version is the last one
+// applied. Version 0 rolls back every migration.
+func MigrateTo(databaseURL string, version int64) error {
+	return withMigrations(databaseURL, func(db *sql.DB) error {
+		if version != 0 {
+			migrations, err := goose.CollectMigrations("migrations", 0, goose.MaxVersion)
+			if err != nil {
+				return fmt.Errorf("error collecting migrations: %w", err)
+			}
+			if _, err := migrations.Current(version); err != nil {
+				return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+			}
+		}
+		current, err := goose.GetDBVersion(db)
+		if err != nil {
+			return fmt.Errorf("error reading the database version: %w", err)
+		}
+		if version >= current {
+			if err := goose.UpTo(db, "migrations", version); err != nil {
+				return fmt.Errorf("error trying to run migrations: %w", err)
+			}
+			return nil
+		}
+		if err := goose.DownTo(db, "migrations", version); err != nil {
+			return fmt.Errorf("error trying to roll back migrations: %w", err)
+		}
+		return nil
+	})
+}
+
+// Version returns the version of the last migration applied to the database on databaseURL
+func Version(databaseURL string) (int64, error) {
+	var version int64
+	err := withMigrations(databaseURL, func(db *sql.DB) error {
+		var err error
+		version, err = goose.GetDBVersion(db)
+		return err
+	})
+	return version, err
+}
+
+func withMigrations(databaseURL string, fn func(db *sql.DB) error) error {
 	db, err := sql.Open("postgres", databaseURL)
 	if err != nil {
 		return fmt.Errorf("error open connection with database: %w", err)
@@ -32,10 +104,225 @@ func Migrate(databaseURL string) error {
 	if err := goose.SetDialect("postgres"); err != nil {
 		return fmt.Errorf("error setting dialect: %w", err)
 	}
+	return fn(db)
+}
+
+// Pending returns the versions of the migrations that are not applied to the database on databaseURL.
+// Unlike Migrate, it doesn't change the database.
+func Pending(databaseURL string) ([]int64, error) {
+	statuses, err := Status(databaseURL)
+	if err != nil {
+		return nil, err
+	}
+	var pending []int64
+	for _, status := range statuses {
+		if !status.Applied {
+			pending = append(pending, status.Version)
+		}
+	}
+	return pending, nil
+}
+
+// MigrationStatus is a migration and whether it is applied to the database
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Source    string     `json:"source"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+}
+
+// Status returns every migration, applied or pending, in version order. It doesn't change the database.
+func Status(databaseURL string) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := withMigrations(databaseURL, func(db *sql.DB) error {
+		migrations, err := goose.CollectMigrations("migrations", 0, goose.MaxVersion)
+		if err != nil {
+			return fmt.Errorf("error collecting migrations: %w", err)
+		}
+		applied, err := appliedVersions(db)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			status := MigrationStatus{Version: m.Version, Source: path.Base(m.Source)}
+			if at, found := applied[m.Version]; found {
+				status.Applied = true
+				status.AppliedAt = &at
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// Step is a migration a command would apply, or roll back when Down, and its SQL
+type Step struct {
+	Version int64  `json:"version"`
+	Source  string `json:"source"`
+	Down    bool   `json:"down"`
+	SQL     string `json:"sql"`
+}
+
+// Plan returns the steps MigrateTo would run to reach version, without running them. goose.MaxVersion plans
+// Migrate, the pending migrations.
+func Plan(databaseURL string, version int64) ([]Step, error) {
+	var steps []Step
+	err := withMigrations(databaseURL, func(db *sql.DB) error {
+		migrations, err := goose.CollectMigrations("migrations", 0, goose.MaxVersion)
+		if err != nil {
+			return fmt.Errorf("error collecting migrations: %w", err)
+		}
+		current, err := goose.GetDBVersion(db)
+		if err != nil {
+			return fmt.Errorf("error reading the database version: %w", err)
+		}
+		steps, err = plan(migrations, current, version)
+		return err
+	})
+	return steps, err
+}
+
+// PlanDown returns the step MigrateDown would run, without running it
+func PlanDown(databaseURL string) ([]Step, error) {
+	var steps []Step
+	err := withMigrations(databaseURL, func(db *sql.DB) error {
+		migrations, err := goose.CollectMigrations("migrations", 0, goose.MaxVersion)
+		if err != nil {
+			return fmt.Errorf("error collecting migrations: %w", err)
+		}
+		current, err := goose.GetDBVersion(db)
+		if err != nil {
+			return fmt.Errorf("error reading the database version: %w", err)
+		}
+		var previous int64
+		if m, err := migrations.Previous(current); err == nil {
+			previous = m.Version
+		}
+		steps, err = plan(migrations, current, previous)
+		return err
+	})
+	return steps, err
+}
+
+// plan returns the migrations applied from current up to version, or rolled back from current down to version,
+// in the order goose runs them
+func plan(migrations goose.Migrations, current int64, version int64) ([]Step, error) {
+	var steps []Step
+	if version >= current {
+		for _, m := range migrations {
+			if m.Version > current && m.Version <= version {
+				step, err := newStep(m, false)
+				if err != nil {
+					return nil, err
+				}
+				steps = append(steps, step)
+			}
+		}
+		return steps, nil
+	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.Version > version && m.Version <= current {
+			step, err := newStep(m, true)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+		}
+	}
+	return steps, nil
+}
 
-	if err := goose.Up(db, "migrations"); err != nil {
-		return fmt.Errorf("error trying to run migrations: %w", err)
+func newStep(m *goose.Migration, down bool) (Step, error) {
+	raw, err := embedMigrations.ReadFile(m.Source)
+	if err != nil {
+		return Step{}, fmt.Errorf("error reading migration %d: %w", m.Version, err)
 	}
+	return Step{Version: m.Version, Source: path.Base(m.Source), Down: down, SQL: section(string(raw), down)}, nil
+}
 
-	return nil
+// Tables returns the tables created by the migrations, without the ones a later migration drops, in the order they
+// are created
+func Tables() ([]string, error) {
+	files, err := fs.Glob(embedMigrations, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	var tables []string
+	for _, file := range files {
+		raw, err := embedMigrations.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration %s: %w", file, err)
+		}
+		up := section(string(raw), false)
+		for _, match := range dropTableRE.FindAllStringSubmatch(up, -1) {
+			for i := range tables {
+				if tables[i] == match[1] {
+					tables = append(tables[:i], tables[i+1:]...)
+					break
+				}
+			}
+		}
+		for _, match := range createTableRE.FindAllStringSubmatch(up, -1) {
+			tables = append(tables, match[1])
+		}
+	}
+	return tables, nil
+}
+
+// section returns the SQL of the Up or Down section of a goose migration, without the goose annotations
+func section(migration string, down bool) string {
+	var lines []string
+	in := false
+	for _, line := range strings.Split(migration, "\n") {
+		annotation := strings.TrimSpace(line)
+		if strings.HasPrefix(annotation, "-- +goose ") {
+			switch strings.TrimPrefix(annotation, "-- +goose ") {
+			case "Up":
+				in = !down
+			case "Down":
+				in = down
+			}
+			continue
+		}
+		if in {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// appliedVersions reads the goose version table, and returns when each applied version was applied. The last row of
+// a version tells whether it is applied or rolled back.
+func appliedVersions(db *sql.DB) (map[int64]time.Time, error) {
+	var exists bool
+	if err := db.QueryRow("SELECT to_regclass('goose_db_version') IS NOT NULL").Scan(&exists); err != nil {
+		return nil, fmt.Errorf("error reading migrations table: %w", err)
+	}
+	applied := make(map[int64]time.Time)
+	if !exists {
+		return applied, nil
+	}
+	rows, err := db.Query("SELECT version_id, is_applied, tstamp FROM goose_db_version ORDER BY id DESC")
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations table: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	seen := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		var isApplied bool
+		var appliedAt sql.NullTime
+		if err := rows.Scan(&version, &isApplied, &appliedAt); err != nil {
+			return nil, fmt.Errorf("error reading migrations table: %w", err)
+		}
+		if !seen[version] {
+			seen[version] = true
+			if isApplied {
+				applied[version] = appliedAt.Time
+			}
+		}
+	}
+	return applied, rows.Err()
 }
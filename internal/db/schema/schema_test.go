@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSection(t *testing.T) {
+	raw, err := embedMigrations.ReadFile("migrations/202304211000020_add_quotas_table.sql")
+	require.NoError(t, err)
+
+	up := section(string(raw), false)
+	assert.Contains(t, up, "CREATE TABLE quotas (")
+	assert.NotContains(t, up, "+goose")
+	assert.NotContains(t, up, "DROP TABLE")
+
+	assert.Equal(t, "DROP TABLE IF EXISTS quotas;", section(string(raw), true))
+}
+
+func TestTables(t *testing.T) {
+	tables, err := Tables()
+	require.NoError(t, err)
+	assert.Equal(t, "claims", tables[0])
+	assert.Contains(t, tables, "quotas")
+	assert.Contains(t, tables, "claim_payloads")
+	assert.NotContains(t, tables, "migrations")
+}
+
+func TestPlan(t *testing.T) {
+	goose.SetBaseFS(embedMigrations)
+	migrations, err := goose.CollectMigrations("migrations", 0, goose.MaxVersion)
+	require.NoError(t, err)
+	require.Greater(t, len(migrations), 5)
+	current := migrations[len(migrations)-3].Version
+
+	steps, err := plan(migrations, current, goose.MaxVersion)
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	assert.Equal(t, migrations[len(migrations)-2].Version, steps[0].Version)
+	assert.Equal(t, migrations[len(migrations)-1].Version, steps[1].Version)
+	assert.False(t, steps[0].Down)
+	assert.NotEmpty(t, steps[0].SQL)
+
+	steps, err = plan(migrations, current, migrations[len(migrations)-5].Version)
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	assert.Equal(t, current, steps[0].Version)
+	assert.Equal(t, migrations[len(migrations)-4].Version, steps[1].Version)
+	assert.True(t, steps[0].Down)
+
+	steps, err = plan(migrations, current, current)
+	require.NoError(t, err)
+	assert.Empty(t, steps)
+}
@@ -2,6 +2,8 @@ package db
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
@@ -9,11 +11,65 @@ import (
 // Storage defines the postgres storage
 type Storage struct {
 	Pgx *pgxpool.Pool
+	// Replica is an optional read-only copy of the database for the listings. Nil sends every read to Pgx.
+	Replica *pgxpool.Pool
+}
+
+// StorageOption changes the configuration of the connection pool before connecting
+type StorageOption func(cfg *pgxpool.Config)
+
+// WithStatementTimeout makes postgres abort any statement that takes longer than timeout. Zero disables it.
+func WithStatementTimeout(timeout time.Duration) StorageOption {
+	return func(cfg *pgxpool.Config) {
+		if timeout > 0 {
+			cfg.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(timeout.Milliseconds(), 10)
+		}
+	}
+}
+
+// Pool sizes the connection pool. A zero value keeps the one of the connection string, or the pgx default.
+type Pool struct {
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	HealthCheckPeriod time.Duration
+}
+
+// WithPool sizes the connection pool
+func WithPool(pool Pool) StorageOption {
+	return func(cfg *pgxpool.Config) {
+		if pool.MaxConns > 0 {
+			cfg.MaxConns = pool.MaxConns
+		}
+		if pool.MinConns > 0 {
+			cfg.MinConns = pool.MinConns
+		}
+		if pool.MaxConnLifetime > 0 {
+			cfg.MaxConnLifetime = pool.MaxConnLifetime
+		}
+		if pool.HealthCheckPeriod > 0 {
+			cfg.HealthCheckPeriod = pool.HealthCheckPeriod
+		}
+	}
+}
+
+// PoolStats is a snapshot of a connection pool. The counts are cumulative since the pool was created: WaitCount is
+// the acquires that found no idle connection and had to wait for one, and AcquireDuration the time spent by all of them.
+type PoolStats struct {
+	MaxConns          int32         `json:"maxConns"`
+	TotalConns        int32         `json:"totalConns"`
+	InUseConns        int32         `json:"inUseConns"`
+	IdleConns         int32         `json:"idleConns"`
+	ConstructingConns int32         `json:"constructingConns"`
+	AcquireCount      int64         `json:"acquireCount"`
+	WaitCount         int64         `json:"waitCount"`
+	CanceledCount     int64         `json:"canceledCount"`
+	AcquireDuration   time.Duration `json:"acquireDurationNs"`
 }
 
 // NewStorage creates and returns a new Pgx storage connection
-func NewStorage(connectionString string) (*Storage, error) {
-	pgxConn, err := pgxpool.Connect(context.Background(), connectionString)
+func NewStorage(connectionString string, opts ...StorageOption) (*Storage, error) {
+	pgxConn, err := connect(connectionString, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -22,13 +78,82 @@ func NewStorage(connectionString string) (*Storage, error) {
 	}, nil
 }
 
+// ConnectReplica connects the read-only replica used by Reader. An empty connection string keeps the reads on
+// the primary. It must be called before the storage is handed to the repositories that copy it.
+func (s *Storage) ConnectReplica(connectionString string, opts ...StorageOption) error {
+	if connectionString == "" {
+		return nil
+	}
+	replica, err := connect(connectionString, opts...)
+	if err != nil {
+		return err
+	}
+	s.Replica = replica
+	return nil
+}
+
+// Reader returns the connection for the listings that tolerate a small replication lag: the replica when there is
+// one and the primary otherwise. Writes, and the reads that precede a write, must use Pgx.
+func (s *Storage) Reader() Querier {
+	if s.Replica != nil {
+		return s.Replica
+	}
+	return s.Pgx
+}
+
+// Stats returns a snapshot of the connection pools, by database: primary, and replica when there is one
+func (s *Storage) Stats() map[string]PoolStats {
+	stats := map[string]PoolStats{"primary": poolStats(s.Pgx)}
+	if s.Replica != nil {
+		stats["replica"] = poolStats(s.Replica)
+	}
+	return stats
+}
+
 // Ping is a wrapper for Pgx Ping
 func (s *Storage) Ping(ctx context.Context) error {
 	return s.Pgx.Ping(ctx)
 }
 
+// PingReplica pings the replica, or does nothing when there is none
+func (s *Storage) PingReplica(ctx context.Context) error {
+	if s.Replica == nil {
+		return nil
+	}
+	return s.Replica.Ping(ctx)
+}
+
 // Close all connections to database
 func (s *Storage) Close() error {
 	s.Pgx.Close()
+	if s.Replica != nil {
+		s.Replica.Close()
+	}
 	return nil
 }
+
+func connect(connectionString string, opts ...StorageOption) (*pgxpool.Pool, error) {
+	cfg, err := pgxpool.ParseConfig(connectionString)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return pgxpool.ConnectConfig(context.Background(), cfg)
+}
+
+func poolStats(pool *pgxpool.Pool) PoolStats {
+	stat := pool.Stat()
+	return PoolStats{
+		MaxConns:          stat.MaxConns(),
+		TotalConns:        stat.TotalConns(),
+		InUseConns:        stat.AcquiredConns(),
+		IdleConns:         stat.IdleConns(),
+		ConstructingConns: stat.ConstructingConns(),
+		AcquireCount:      stat.AcquireCount(),
+		WaitCount:         stat.EmptyAcquireCount(),
+		CanceledCount:     stat.CanceledAcquireCount(),
+		AcquireDuration:   stat.AcquireDuration(),
+	}
+}
@@ -0,0 +1,582 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/iden3/go-circuits"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/db/schema"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/providers"
+	"github.com/polygonid/sh-id-platform/internal/providers/blockchain"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/loaders"
+)
+
+// Status of a check
+type Status string
+
+// Check statuses
+const (
+	StatusOK      Status = "ok"      // StatusOK the check passed
+	StatusWarning Status = "warning" // StatusWarning the node works, but something should be looked at
+	StatusFailed  Status = "failed"  // StatusFailed the node must not be put in service
+	StatusSkipped Status = "skipped" // StatusSkipped the check doesn't apply or needs a dependency that failed
+)
+
+// tokenRenewalWarning is how long before the expiration of the vault token the vault check warns about it
+const tokenRenewalWarning = 24 * time.Hour
+
+// chainIDs are the chain ids of the known networks of ISSUER_ETHEREUM_RESOLVER_PREFIX. The other networks set theirs in
+// the networks file.
+var chainIDs = map[string]int64{
+	"eth:main":       1,
+	"eth:goerli":     5,
+	"eth:sepolia":    11155111,
+	"polygon:main":   137,
+	"polygon:mumbai": 80001,
+	"polygon:amoy":   80002,
+}
+
+// requiredCircuits are the circuits the node proves and verifies with: the auth of the wallets and the state transition
+// of the publications
+var requiredCircuits = []circuits.CircuitID{circuits.AuthV2CircuitID, circuits.StateTransitionCircuitID}
+
+// Result is the outcome of a check. Fix tells the operator what to do when it is not ok.
+type Result struct {
+	Check  string `json:"check"`
+	Status Status `json:"status"`
+	Detail string `json:"detail"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// Report is the outcome of all the checks, in the order they were run
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// Failed returns true if any check failed
+func (r *Report) Failed() bool {
+	for _, result := range r.Results {
+		if result.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// Write prints the report as text, one line per check followed by the fix of the checks that are not ok
+func (r *Report) Write(w io.Writer) error {
+	for _, result := range r.Results {
+		if _, err := fmt.Fprintf(w, "[%-7s] %-15s %s\n", result.Status, result.Check, result.Detail); err != nil {
+			return err
+		}
+		if result.Fix != "" {
+			if _, err := fmt.Fprintf(w, "%26s %s\n", "fix:", result.Fix); err != nil {
+				return err
+			}
+		}
+	}
+	summary := "the node is ready to be put in service"
+	if r.Failed() {
+		summary = "the node is NOT ready to be put in service"
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", summary)
+	return err
+}
+
+// Doctor checks that a node is ready to be put in service with its configuration: the database, the keys in vault,
+// the blockchain, the reverse hash service, the circuits and the clock.
+// The connections it opens are kept between checks, so the checks that need a dependency that failed are skipped.
+type Doctor struct {
+	cfg       *config.Configuration
+	timeout   time.Duration
+	transport *http.Transport
+	http      *http.Client
+	now       func() time.Time
+
+	storage  *db.Storage
+	keyStore kms.KMSType
+	eth      map[string]*ethclient.Client
+}
+
+// New returns a Doctor for the node configuration cfg. Each check is given timeout to complete. The outbound
+// requests are sent with transport, nil sends them with http.DefaultTransport.
+func New(cfg *config.Configuration, timeout time.Duration, transport *http.Transport) *Doctor {
+	d := &Doctor{
+		cfg:       cfg,
+		timeout:   timeout,
+		transport: transport,
+		http:      &http.Client{},
+		now:       time.Now,
+	}
+	if transport != nil {
+		d.http.Transport = transport
+	}
+	return d
+}
+
+// Run runs all the checks and closes the connections they opened
+func (d *Doctor) Run(ctx context.Context) *Report {
+	defer d.close(ctx)
+
+	checks := []func(ctx context.Context) Result{
+		d.checkDatabase,
+		d.checkMigrations,
+		d.checkKeyStore,
+		d.checkKeys,
+		d.checkChainID,
+		d.checkStateContract,
+		d.checkRHS,
+		d.checkCircuits,
+		d.checkClock,
+	}
+	report := &Report{Results: make([]Result, 0, len(checks))}
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, d.timeout)
+		report.Results = append(report.Results, check(checkCtx))
+		cancel()
+	}
+	return report
+}
+
+func (d *Doctor) close(ctx context.Context) {
+	if d.storage != nil {
+		if err := d.storage.Close(); err != nil {
+			log.Error(ctx, "error closing database connection", "err", err)
+		}
+	}
+	for _, ec := range d.eth {
+		ec.Close()
+	}
+}
+
+func (d *Doctor) checkDatabase(ctx context.Context) Result {
+	const check = "database"
+	storage, err := db.NewStorage(d.cfg.Database.URL)
+	if err == nil {
+		err = storage.Ping(ctx)
+	}
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("cannot connect: %s", err), Fix: "check ISSUER_DATABASE_URL and that postgres is running"}
+	}
+	d.storage = storage
+	return Result{Check: check, Status: StatusOK, Detail: "connected"}
+}
+
+func (d *Doctor) checkMigrations(_ context.Context) Result {
+	const check = "migrations"
+	if d.storage == nil {
+		return Result{Check: check, Status: StatusSkipped, Detail: "the database cannot be reached"}
+	}
+	pending, err := schema.Pending(d.cfg.Database.URL)
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("cannot read the migrations state: %s", err)}
+	}
+	if len(pending) > 0 {
+		versions := make([]string, len(pending))
+		for i, version := range pending {
+			versions[i] = fmt.Sprint(version)
+		}
+		return Result{
+			Check:  check,
+			Status: StatusFailed,
+			Detail: fmt.Sprintf("%d migrations pending: %s", len(pending), strings.Join(versions, ", ")),
+			Fix:    "apply them with the migrate command (make db/migrate)",
+		}
+	}
+	return Result{Check: check, Status: StatusOK, Detail: "all migrations applied"}
+}
+
+// keyStoreFixes are the fixes of the key stores that cannot be opened, by backend
+var keyStoreFixes = map[string]string{
+	config.KeyStoreVault: "check ISSUER_KEY_STORE_ADDRESS, that vault is running and unsealed, ISSUER_KEY_STORE_PLUGIN_IDEN3_MOUNT_PATH and that the iden3 plugin is enabled",
+	config.KeyStoreAWS:   "check ISSUER_KEY_STORE_AWS_REGION, ISSUER_KEY_STORE_AWS_ACCESS_KEY_ID and ISSUER_KEY_STORE_AWS_SECRET_ACCESS_KEY",
+	config.KeyStoreGCP:   "check ISSUER_KEY_STORE_GCP_PROJECT, ISSUER_KEY_STORE_GCP_LOCATION, ISSUER_KEY_STORE_GCP_KEY_RING and ISSUER_KEY_STORE_GCP_CREDENTIALS_FILE",
+	config.KeyStoreAzure: "check ISSUER_KEY_STORE_AZURE_VAULT_URL, ISSUER_KEY_STORE_AZURE_TENANT_ID, ISSUER_KEY_STORE_AZURE_CLIENT_ID and ISSUER_KEY_STORE_AZURE_PREFIX",
+	config.KeyStoreFile:  "check ISSUER_KEY_STORE_FILE_PATH and ISSUER_KEY_STORE_FILE_PASSPHRASE",
+}
+
+// checkKeyStore opens the key store of the configured backend. The keys check reads the public keys from it. The file
+// key store works, but it is only meant for development.
+func (d *Doctor) checkKeyStore(ctx context.Context) Result {
+	backend := d.cfg.KeyStore.Backend
+	if backend == "" {
+		backend = config.KeyStoreVault
+	}
+	check := backend + " key store"
+	keyStore, err := kms.NewFromConfig(ctx, d.cfg)
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("cannot open the key store: %s", err), Fix: keyStoreFixes[backend]}
+	}
+	d.keyStore = keyStore
+	switch backend {
+	case config.KeyStoreVault:
+		return d.checkVaultToken(ctx, check)
+	case config.KeyStoreFile:
+		return Result{Check: check, Status: StatusWarning, Detail: "the keys are kept in a local file", Fix: "use vault or the key store of a cloud in production"}
+	}
+	return Result{Check: check, Status: StatusOK, Detail: "configured, the keys check reaches it"}
+}
+
+// checkVaultToken checks that the vault token is valid and not about to expire
+func (d *Doctor) checkVaultToken(ctx context.Context, check string) Result {
+	vaultCli, err := providers.NewVaultClient(d.cfg.KeyStore.Address, d.cfg.KeyStore.Token)
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("invalid configuration: %s", err), Fix: "check ISSUER_KEY_STORE_ADDRESS and ISSUER_KEY_STORE_TOKEN"}
+	}
+	token, err := vaultCli.Auth().Token().LookupSelfWithContext(ctx)
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("the token is not valid: %s", err), Fix: "set a valid vault token in ISSUER_KEY_STORE_TOKEN"}
+	}
+	ttl, err := token.TokenTTL()
+	if err == nil && ttl > 0 && ttl < tokenRenewalWarning {
+		return Result{Check: check, Status: StatusWarning, Detail: fmt.Sprintf("the token expires in %s", ttl.Round(time.Second)), Fix: "renew the vault token or replace it with a longer lived one"}
+	}
+	return Result{Check: check, Status: StatusOK, Detail: "connected, unsealed and the token is valid"}
+}
+
+// checkKeys checks that the key that signs the state publications and the keys of every identity of the database
+// can be read from vault
+func (d *Doctor) checkKeys(ctx context.Context) Result {
+	const check = "keys"
+	if d.keyStore == nil {
+		return Result{Check: check, Status: StatusSkipped, Detail: "the key store cannot be reached"}
+	}
+	if !d.cfg.Sandbox {
+		if _, err := d.keyStore.PublicKey(ctx, kms.KeyID{Type: kms.KeyTypeEthereum, ID: d.cfg.PublishingKeyPath}); err != nil {
+			return Result{
+				Check:  check,
+				Status: StatusFailed,
+				Detail: fmt.Sprintf("cannot read the publishing key %s: %s", d.cfg.PublishingKeyPath, err),
+				Fix:    "import the ethereum key that pays the state transitions in vault and set its path in ISSUER_PUBLISH_KEY_PATH",
+			}
+		}
+	}
+	if d.storage == nil {
+		return Result{Check: check, Status: StatusWarning, Detail: "the publishing key is readable, the keys of the identities were not checked as the database cannot be reached"}
+	}
+	identities, err := repositories.NewIdentity().Get(ctx, d.storage.Pgx)
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("cannot list the identities: %s", err)}
+	}
+	var problems []string
+	for _, identifier := range identities {
+		did, err := core.ParseDID(identifier)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid did", identifier))
+			continue
+		}
+		keys, err := d.keyStore.KeysByIdentity(ctx, *did)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", identifier, err))
+			continue
+		}
+		if len(keys) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: no keys", identifier))
+		}
+	}
+	if len(problems) > 0 {
+		return Result{
+			Check:  check,
+			Status: StatusFailed,
+			Detail: fmt.Sprintf("the keys of %d of %d identities cannot be read: %s", len(problems), len(identities), strings.Join(problems, "; ")),
+			Fix:    "restore the vault storage of these identities or point ISSUER_KEY_STORE_ADDRESS to the vault that has them",
+		}
+	}
+	return Result{Check: check, Status: StatusOK, Detail: fmt.Sprintf("the keys of %d identities are readable", len(identities))}
+}
+
+func (d *Doctor) checkChainID(ctx context.Context) Result {
+	const check = "chain id"
+	if d.cfg.Sandbox {
+		return Result{Check: check, Status: StatusSkipped, Detail: "sandbox mode doesn't use a blockchain"}
+	}
+	networks, err := d.cfg.Networks()
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: err.Error(), Fix: "fix ISSUER_NETWORKS_FILE"}
+	}
+	d.eth = make(map[string]*ethclient.Client, len(networks))
+	results := make(map[string]Result, len(networks))
+	for network, settings := range networks {
+		results[network] = d.checkNetworkChainID(ctx, network, settings.Ethereum.URL, settings.ChainID)
+	}
+	return mergeResults(check, results)
+}
+
+func (d *Doctor) checkNetworkChainID(ctx context.Context, network string, url string, configured int64) Result {
+	const check = "chain id"
+	setting := d.networkSetting("ISSUER_ETHEREUM_URL", "url")
+	ec, err := blockchain.Dial(ctx, url, d.transport)
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("cannot connect: %s", err), Fix: "check " + setting}
+	}
+	chainID, err := ec.ChainID(ctx)
+	if err != nil {
+		ec.Close()
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("cannot read the chain id: %s", err), Fix: fmt.Sprintf("check %s and that the RPC endpoint is up", setting)}
+	}
+	d.eth[network] = ec
+	result := chainIDResult(network, configured, chainID)
+	if result.Status == StatusFailed && d.cfg.NetworksFile != "" {
+		result.Fix = fmt.Sprintf("point %s to a node of the network", setting)
+	}
+	return result
+}
+
+// chainIDResult compares the chain id of the RPC endpoint with the one of the network of the resolver prefix, the
+// configured one when it is not zero
+func chainIDResult(resolverPrefix string, configured int64, chainID *big.Int) Result {
+	const check = "chain id"
+	expected, ok := chainIDs[resolverPrefix]
+	if configured != 0 {
+		expected, ok = configured, true
+	}
+	if !ok {
+		return Result{Check: check, Status: StatusWarning, Detail: fmt.Sprintf("the RPC endpoint is on chain %s, the network %s is unknown so it was not compared", chainID, resolverPrefix)}
+	}
+	if chainID.Cmp(big.NewInt(expected)) != 0 {
+		return Result{
+			Check:  check,
+			Status: StatusFailed,
+			Detail: fmt.Sprintf("the RPC endpoint is on chain %s, but the network %s is chain %d", chainID, resolverPrefix, expected),
+			Fix:    "point ISSUER_ETHEREUM_URL to a node of the network, or fix ISSUER_ETHEREUM_RESOLVER_PREFIX",
+		}
+	}
+	return Result{Check: check, Status: StatusOK, Detail: fmt.Sprintf("chain %s, %s", chainID, resolverPrefix)}
+}
+
+func (d *Doctor) checkStateContract(ctx context.Context) Result {
+	const check = "state contract"
+	if d.cfg.Sandbox {
+		return Result{Check: check, Status: StatusSkipped, Detail: "sandbox mode doesn't use a blockchain"}
+	}
+	networks, err := d.cfg.Networks()
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: err.Error(), Fix: "fix ISSUER_NETWORKS_FILE"}
+	}
+	results := make(map[string]Result, len(networks))
+	for network, settings := range networks {
+		results[network] = d.checkNetworkStateContract(ctx, d.eth[network], settings.Ethereum.ContractAddress)
+	}
+	return mergeResults(check, results)
+}
+
+func (d *Doctor) checkNetworkStateContract(ctx context.Context, ec *ethclient.Client, contractAddress string) Result {
+	const check = "state contract"
+	if ec == nil {
+		return Result{Check: check, Status: StatusSkipped, Detail: "the RPC endpoint cannot be reached"}
+	}
+	fix := "set the state contract address of the network in " + d.networkSetting("ISSUER_ETHEREUM_CONTRACT_ADDRESS", "contractAddress")
+	if !common.IsHexAddress(contractAddress) {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("%q is not an address", contractAddress), Fix: fix}
+	}
+	address := common.HexToAddress(contractAddress)
+	code, err := ec.CodeAt(ctx, address, nil)
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("cannot read the contract code: %s", err)}
+	}
+	if len(code) == 0 {
+		return Result{
+			Check:  check,
+			Status: StatusFailed,
+			Detail: fmt.Sprintf("there is no contract at %s", address.Hex()),
+			Fix:    fix,
+		}
+	}
+	return Result{Check: check, Status: StatusOK, Detail: fmt.Sprintf("contract deployed at %s", address.Hex())}
+}
+
+func (d *Doctor) checkRHS(ctx context.Context) Result {
+	const check = "reverse hash"
+	if !d.cfg.ReverseHashService.Enabled {
+		return Result{Check: check, Status: StatusSkipped, Detail: "the reverse hash service is disabled"}
+	}
+	networks, err := d.cfg.Networks()
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: err.Error(), Fix: "fix ISSUER_NETWORKS_FILE"}
+	}
+	results := make(map[string]Result, len(networks))
+	for network, url := range networks.RHSUrls() {
+		results[network] = d.checkRHSURL(ctx, url)
+	}
+	return mergeResults(check, results)
+}
+
+func (d *Doctor) checkRHSURL(ctx context.Context, url string) Result {
+	const check = "reverse hash"
+	fix := fmt.Sprintf("check %s, or disable it with ISSUER_REVERSE_HASH_SERVICE_ENABLED=false", d.networkSetting("ISSUER_REVERSE_HASH_SERVICE_URL", "reverseHashServiceUrl"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("invalid url: %s", err), Fix: fix}
+	}
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("cannot connect: %s", err), Fix: fix}
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("the service answered %d", resp.StatusCode), Fix: fix}
+	}
+	return Result{Check: check, Status: StatusOK, Detail: fmt.Sprintf("reachable at %s", url)}
+}
+
+// checkCircuits checks that the files of the required circuits are in the circuits path. The proving keys are large,
+// so the files are not read.
+func (d *Doctor) checkCircuits(_ context.Context) Result {
+	const check = "circuits"
+	verificationKeys := loaders.NewVerificationKeys(d.cfg.Circuit.Path)
+	var missing []string
+	for _, circuitID := range requiredCircuits {
+		for _, file := range []string{"circuit.wasm", "circuit_final.zkey"} {
+			path := filepath.Join(d.cfg.Circuit.Path, string(circuitID), file)
+			if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+				missing = append(missing, path)
+			}
+		}
+		if _, err := verificationKeys.Load(circuitID); err != nil {
+			missing = append(missing, fmt.Sprintf("verification key of %s", circuitID))
+		}
+	}
+	if len(missing) > 0 {
+		return Result{
+			Check:  check,
+			Status: StatusFailed,
+			Detail: fmt.Sprintf("missing or empty: %s", strings.Join(missing, ", ")),
+			Fix:    "copy the circuits to ISSUER_CIRCUIT_PATH, one directory per circuit",
+		}
+	}
+	return Result{Check: check, Status: StatusOK, Detail: fmt.Sprintf("found in %s", d.cfg.Circuit.Path)}
+}
+
+// checkClock compares the clock of this host with the clocks of postgres and of the last block. The offset to the
+// last block also includes the time since it was mined, so only a last block in the future fails the check.
+func (d *Doctor) checkClock(ctx context.Context) Result {
+	const check = "clock"
+	var dbOffset, chainOffset *time.Duration
+	if d.storage != nil {
+		before := d.now()
+		var dbNow time.Time
+		if err := d.storage.Pgx.QueryRow(ctx, "SELECT now()").Scan(&dbNow); err == nil {
+			after := d.now()
+			offset := before.Add(after.Sub(before) / 2).Sub(dbNow)
+			dbOffset = &offset
+		}
+	}
+	if ec := d.firstEth(); ec != nil {
+		if header, err := ec.HeaderByNumber(ctx, nil); err == nil {
+			offset := d.now().Sub(time.Unix(int64(header.Time), 0))
+			chainOffset = &offset
+		}
+	}
+	if dbOffset == nil && chainOffset == nil {
+		return Result{Check: check, Status: StatusSkipped, Detail: "neither the database nor the RPC endpoint can be reached"}
+	}
+	return clockResult(d.cfg.ClockSkew, dbOffset, chainOffset)
+}
+
+// firstEth returns the RPC endpoint of the first network, in alphabetical order, that could be reached
+func (d *Doctor) firstEth() *ethclient.Client {
+	networks := sortedKeys(d.eth)
+	if len(networks) == 0 {
+		return nil
+	}
+	return d.eth[networks[0]]
+}
+
+// networkSetting names where a setting of the networks is configured: the environment variable env, or field in the
+// networks file when there is one
+func (d *Doctor) networkSetting(env string, field string) string {
+	if d.cfg.NetworksFile == "" {
+		return env
+	}
+	return fmt.Sprintf("the %s of the network in ISSUER_NETWORKS_FILE", field)
+}
+
+// mergeResults merges the results of a check on each network. The merged status is the worst one, and the details
+// are prefixed with their network when there are several.
+func mergeResults(check string, results map[string]Result) Result {
+	networks := sortedKeys(results)
+	if len(networks) == 1 {
+		return results[networks[0]]
+	}
+	severity := map[Status]int{StatusSkipped: 0, StatusOK: 1, StatusWarning: 2, StatusFailed: 3}
+	merged := Result{Check: check, Status: StatusSkipped}
+	var details, fixes []string
+	for _, network := range networks {
+		result := results[network]
+		if severity[result.Status] > severity[merged.Status] {
+			merged.Status = result.Status
+		}
+		details = append(details, fmt.Sprintf("%s: %s", network, result.Detail))
+		if result.Fix != "" {
+			fixes = append(fixes, fmt.Sprintf("%s: %s", network, result.Fix))
+		}
+	}
+	merged.Detail = strings.Join(details, "; ")
+	merged.Fix = strings.Join(fixes, "; ")
+	return merged
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// clockResult evaluates the offsets of the local clock to the database and to the last block. skew is the difference
+// the node tolerates, ISSUER_CLOCK_SKEW; when it is disabled, 30 seconds are used.
+func clockResult(skew time.Duration, dbOffset, chainOffset *time.Duration) Result {
+	const check = "clock"
+	if skew <= 0 {
+		skew = 30 * time.Second
+	}
+	fix := "synchronize the clock of this host with NTP"
+	var details []string
+	if dbOffset != nil {
+		if abs(*dbOffset) > skew {
+			return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("%s off the database clock", dbOffset.Round(time.Millisecond)), Fix: fix}
+		}
+		details = append(details, fmt.Sprintf("%s off the database clock", dbOffset.Round(time.Millisecond)))
+	}
+	if chainOffset != nil {
+		if *chainOffset < -skew {
+			return Result{Check: check, Status: StatusFailed, Detail: fmt.Sprintf("the last block is %s in the future", (-*chainOffset).Round(time.Second)), Fix: fix}
+		}
+		if *chainOffset > skew {
+			return Result{
+				Check:  check,
+				Status: StatusWarning,
+				Detail: fmt.Sprintf("the last block is %s old", chainOffset.Round(time.Second)),
+				Fix:    "the RPC endpoint may be out of sync, or the clock of this host ahead",
+			}
+		}
+		details = append(details, fmt.Sprintf("last block %s old", chainOffset.Round(time.Second)))
+	}
+	return Result{Check: check, Status: StatusOK, Detail: strings.Join(details, ", ")}
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,152 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+)
+
+func TestChainIDResult(t *testing.T) {
+	assert.Equal(t, StatusOK, chainIDResult("polygon:mumbai", 0, big.NewInt(80001)).Status)
+	assert.Equal(t, StatusFailed, chainIDResult("polygon:main", 0, big.NewInt(80001)).Status)
+	assert.Equal(t, StatusWarning, chainIDResult("polygon:unknown", 0, big.NewInt(80001)).Status)
+	assert.Equal(t, StatusOK, chainIDResult("acme:private", 4242, big.NewInt(4242)).Status)
+	assert.Equal(t, StatusFailed, chainIDResult("acme:private", 4242, big.NewInt(80001)).Status)
+}
+
+func TestMergeResults(t *testing.T) {
+	ok := Result{Check: "chain id", Status: StatusOK, Detail: "chain 137, polygon:main"}
+	assert.Equal(t, ok, mergeResults("chain id", map[string]Result{"polygon:main": ok}))
+
+	merged := mergeResults("chain id", map[string]Result{
+		"polygon:main": ok,
+		"polygon:amoy": {Check: "chain id", Status: StatusFailed, Detail: "cannot connect", Fix: "check the url"},
+	})
+	assert.Equal(t, StatusFailed, merged.Status)
+	assert.Equal(t, "polygon:amoy: cannot connect; polygon:main: chain 137, polygon:main", merged.Detail)
+	assert.Equal(t, "polygon:amoy: check the url", merged.Fix)
+}
+
+func TestClockResult(t *testing.T) {
+	offset := func(d time.Duration) *time.Duration { return &d }
+	type testConfig struct {
+		name        string
+		skew        time.Duration
+		dbOffset    *time.Duration
+		chainOffset *time.Duration
+		expected    Status
+	}
+	for _, tc := range []testConfig{
+		{name: "in sync", skew: 30 * time.Second, dbOffset: offset(time.Second), chainOffset: offset(3 * time.Second), expected: StatusOK},
+		{name: "ahead of the database", skew: 30 * time.Second, dbOffset: offset(time.Minute), expected: StatusFailed},
+		{name: "behind the database", skew: 30 * time.Second, dbOffset: offset(-time.Minute), expected: StatusFailed},
+		{name: "last block in the future", skew: 30 * time.Second, chainOffset: offset(-time.Minute), expected: StatusFailed},
+		{name: "old last block", skew: 30 * time.Second, chainOffset: offset(time.Hour), expected: StatusWarning},
+		{name: "skew disabled uses the default", dbOffset: offset(10 * time.Second), expected: StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, clockResult(tc.skew, tc.dbOffset, tc.chainOffset).Status)
+		})
+	}
+}
+
+func TestDoctor_CheckCircuits(t *testing.T) {
+	dir := t.TempDir()
+	d := New(&config.Configuration{Circuit: config.Circuit{Path: dir}}, time.Second, nil)
+
+	result := d.checkCircuits(context.Background())
+	assert.Equal(t, StatusFailed, result.Status)
+	assert.Contains(t, result.Detail, filepath.Join(dir, "authV2", "circuit.wasm"))
+
+	files := map[string][]string{
+		"authV2":          {"circuit.wasm", "circuit_final.zkey", "authV2.json"},
+		"stateTransition": {"circuit.wasm", "circuit_final.zkey", "verification_key.json"},
+	}
+	for circuit, names := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, circuit), 0o750))
+		for _, name := range names {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, circuit, name), []byte("{}"), 0o600))
+		}
+	}
+	assert.Equal(t, StatusOK, d.checkCircuits(context.Background()).Status)
+}
+
+func TestDoctor_CheckRHS(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer up.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer broken.Close()
+
+	type testConfig struct {
+		name     string
+		rhs      config.ReverseHashService
+		expected Status
+	}
+	for _, tc := range []testConfig{
+		{name: "disabled", rhs: config.ReverseHashService{URL: broken.URL}, expected: StatusSkipped},
+		{name: "reachable", rhs: config.ReverseHashService{URL: up.URL, Enabled: true}, expected: StatusOK},
+		{name: "server error", rhs: config.ReverseHashService{URL: broken.URL, Enabled: true}, expected: StatusFailed},
+		{name: "unreachable", rhs: config.ReverseHashService{URL: "http://127.0.0.1:1", Enabled: true}, expected: StatusFailed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := New(&config.Configuration{ReverseHashService: tc.rhs}, time.Second, nil)
+			assert.Equal(t, tc.expected, d.checkRHS(context.Background()).Status)
+		})
+	}
+}
+
+func TestDoctor_CheckRHS_Networks(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer up.Close()
+
+	networksFile := filepath.Join(t.TempDir(), "networks.yaml")
+	require.NoError(t, os.WriteFile(networksFile, []byte(`
+polygon:
+  main:
+    url: https://polygon-rpc.com
+    contractAddress: "0x624ce98D2d27b20b8f8d521723Df8fC4db71D79D"
+  amoy:
+    url: https://rpc-amoy.polygon.technology
+    contractAddress: "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124"
+    reverseHashServiceUrl: http://127.0.0.1:1
+`), 0o600))
+	d := New(&config.Configuration{ReverseHashService: config.ReverseHashService{URL: up.URL, Enabled: true}, NetworksFile: networksFile}, time.Second, nil)
+	result := d.checkRHS(context.Background())
+	assert.Equal(t, StatusFailed, result.Status)
+	assert.Contains(t, result.Detail, "polygon:main: reachable at "+up.URL)
+	assert.Contains(t, result.Fix, "polygon:amoy: check the reverseHashServiceUrl of the network in ISSUER_NETWORKS_FILE")
+}
+
+func TestReport_Write(t *testing.T) {
+	report := &Report{Results: []Result{
+		{Check: "database", Status: StatusOK, Detail: "connected"},
+		{Check: "migrations", Status: StatusFailed, Detail: "1 migrations pending: 202304211000016", Fix: "apply them with the migrate command (make db/migrate)"},
+	}}
+	assert.True(t, report.Failed())
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+	assert.Contains(t, out.String(), "[ok     ] database")
+	assert.Contains(t, out.String(), "fix: apply them with the migrate command")
+	assert.Contains(t, out.String(), "the node is NOT ready to be put in service")
+
+	report.Results = report.Results[:1]
+	assert.False(t, report.Failed())
+}
@@ -1,6 +1,12 @@
 package errors
 
-import "net/http"
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
 
 // AuthError is a special error type used to signal an authorization error
 type AuthError struct {
@@ -12,6 +18,61 @@ func (a AuthError) Error() string {
 	return a.Err.Error()
 }
 
+// ForbiddenError is a special error type used to signal that the authorized credentials can't call an endpoint
+type ForbiddenError struct {
+	Err error
+}
+
+// Error satisfies error interface for ForbiddenError
+func (f ForbiddenError) Error() string {
+	return f.Err.Error()
+}
+
+// NotFoundError is a special error type used to signal that a resource a request is scoped to doesn't exist, like
+// the issuer identity of a request
+type NotFoundError struct {
+	Err error
+}
+
+// Error satisfies error interface for NotFoundError
+func (n NotFoundError) Error() string {
+	return n.Err.Error()
+}
+
+// UnavailableError is a special error type used to signal that the node can't serve a request for a while, like in
+// maintenance mode. The client should retry after RetryAfter.
+type UnavailableError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+// Error satisfies error interface for UnavailableError
+func (u UnavailableError) Error() string {
+	return u.Err.Error()
+}
+
+// TooManyRequestsError is a special error type used to signal that a client made too many requests. The client should
+// retry after RetryAfter.
+type TooManyRequestsError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+// Error satisfies error interface for TooManyRequestsError
+func (t TooManyRequestsError) Error() string {
+	return t.Err.Error()
+}
+
+// RetryAfterSeconds returns the seconds of the Retry-After header of a wait, rounded up and at least 1 so the clients
+// never retry before the wait is over nor right away
+func RetryAfterSeconds(wait time.Duration) int {
+	seconds := int(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
 // RequestErrorHandlerFunc is a Request Error Handler that can be injected in oapi-codegen to handler errors in requests
 func RequestErrorHandlerFunc(w http.ResponseWriter, _ *http.Request, err error) {
 	http.Error(w, err.Error(), http.StatusBadRequest)
@@ -21,11 +82,25 @@ func RequestErrorHandlerFunc(w http.ResponseWriter, _ *http.Request, err error)
 // We use it to create custom responses to some errors that may occur, like an authentication error.
 func ResponseErrorHandlerFunc(w http.ResponseWriter, _ *http.Request, err error) {
 	w.Header().Add("Content-Type", "application/json")
-	switch err.(type) {
+	switch e := err.(type) {
 	case AuthError:
 		w.WriteHeader(http.StatusUnauthorized)
 		w.Header().Add("WWW-Authenticate", `Basic realm="restricted", charset="UTF-8"`)
 		_, _ = w.Write([]byte("\"Unauthorized\""))
+	case ForbiddenError:
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("\"Forbidden\""))
+	case NotFoundError:
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": err.Error()})
+	case UnavailableError:
+		w.Header().Set("Retry-After", strconv.Itoa(RetryAfterSeconds(e.RetryAfter)))
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": err.Error()})
+	case TooManyRequestsError:
+		w.Header().Set("Retry-After", strconv.Itoa(RetryAfterSeconds(e.RetryAfter)))
+		w.WriteHeader(http.StatusTooManyRequests)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": err.Error()})
 	default:
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
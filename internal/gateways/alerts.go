@@ -0,0 +1,140 @@
+package gateways
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+var (
+	// ErrInvalidAlertWebhook means the url of the alert webhook is not an http or https url
+	ErrInvalidAlertWebhook = errors.New("invalid alert webhook url")
+	// ErrInvalidAlertEmail means the SMTP server, the sender or the recipients of the alert emails are missing
+	ErrInvalidAlertEmail = errors.New("the alert emails need the SMTP server address, the sender and the recipients")
+)
+
+// alertWebhookRequest is the body POSTed to the alert webhook
+type alertWebhookRequest struct {
+	Kind     string    `json:"kind"`
+	Subject  string    `json:"subject"`
+	Message  string    `json:"message"`
+	Resolved bool      `json:"resolved"`
+	Since    time.Time `json:"since"`
+	At       time.Time `json:"at"`
+}
+
+type alertWebhook struct {
+	url           string
+	authorization string
+	client        *http.Client
+}
+
+// NewAlertWebhook returns the channel that POSTs the operator alerts as JSON to webhookURL with transport, or with
+// http.DefaultTransport when it is nil. If authorization is not empty it is sent as the Authorization header.
+func NewAlertWebhook(webhookURL string, authorization string, timeout time.Duration, transport http.RoundTripper) (ports.AlertChannel, error) {
+	u, err := url.ParseRequestURI(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w <%s>", ErrInvalidAlertWebhook, webhookURL)
+	}
+	return &alertWebhook{url: webhookURL, authorization: authorization, client: &http.Client{Timeout: timeout, Transport: transport}}, nil
+}
+
+// Name of the channel
+func (w *alertWebhook) Name() string {
+	return "webhook"
+}
+
+// Send POSTs the alert to the webhook
+func (w *alertWebhook) Send(ctx context.Context, alert domain.Alert) error {
+	body, err := json.Marshal(alertWebhookRequest{
+		Kind:     string(alert.Kind),
+		Subject:  alert.Subject,
+		Message:  alert.Message,
+		Resolved: alert.Resolved,
+		Since:    alert.Since,
+		At:       alert.At,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.authorization != "" {
+		req.Header.Set("Authorization", w.authorization)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("alert webhook: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// AlertEmailConfig is the SMTP server and the addresses of the alert emails
+type AlertEmailConfig struct {
+	Address  string // Address of the SMTP server, like smtp.example.com:587
+	User     string // User of the SMTP server, empty when it doesn't need authentication
+	Password string
+	From     string
+	To       []string
+}
+
+type alertEmail struct {
+	cfg AlertEmailConfig
+}
+
+// NewAlertEmail returns the channel that sends the operator alerts by email. The SMTP server must accept STARTTLS
+// when it needs authentication.
+func NewAlertEmail(cfg AlertEmailConfig) (ports.AlertChannel, error) {
+	if _, _, err := net.SplitHostPort(cfg.Address); err != nil || cfg.From == "" || len(cfg.To) == 0 {
+		return nil, ErrInvalidAlertEmail
+	}
+	return &alertEmail{cfg: cfg}, nil
+}
+
+// Name of the channel
+func (e *alertEmail) Name() string {
+	return "email"
+}
+
+// Send emails the alert to the recipients
+func (e *alertEmail) Send(_ context.Context, alert domain.Alert) error {
+	var auth smtp.Auth
+	if e.cfg.User != "" {
+		host, _, _ := net.SplitHostPort(e.cfg.Address)
+		auth = smtp.PlainAuth("", e.cfg.User, e.cfg.Password, host)
+	}
+	return smtp.SendMail(e.cfg.Address, auth, e.cfg.From, e.cfg.To, alertEmailMessage(e.cfg.From, e.cfg.To, alert))
+}
+
+func alertEmailMessage(from string, to []string, alert domain.Alert) []byte {
+	status := "FIRING"
+	if alert.Resolved {
+		status = "RESOLVED"
+	}
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: [issuer node %s] %s %s\r\n", status, alert.Kind, alert.Subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", alert.At.Format(time.RFC1123Z))
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\nRaised at %s\r\n", alert.Message, alert.Since.Format(time.RFC3339))
+	return []byte(msg.String())
+}
@@ -0,0 +1,140 @@
+package gateways
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// IPFSClient publishes, fetches and pins documents through the HTTP API of an IPFS node, like kubo
+type IPFSClient struct {
+	url  string
+	conn *http.Client
+}
+
+// NewIPFSClient returns the client of the IPFS node whose HTTP API is in url, like http://localhost:5001
+func NewIPFSClient(url string, conn *http.Client) ports.IPFSGateway {
+	return &IPFSClient{
+		url:  strings.TrimSuffix(url, "/"),
+		conn: conn,
+	}
+}
+
+// Add publishes doc, pinned in the IPFS node, and returns its CID v1
+func (c *IPFSClient) Add(ctx context.Context, doc []byte) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "document.json")
+	if err != nil {
+		return "", err
+	}
+	if _, err := part.Write(doc); err != nil {
+		return "", err
+	}
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	raw, err := c.post(ctx, "/api/v0/add?pin=true&cid-version=1", form.FormDataContentType(), &body)
+	if err != nil {
+		return "", err
+	}
+	var added struct {
+		Hash string `json:"Hash"`
+	}
+	if err := json.Unmarshal(raw, &added); err != nil {
+		return "", err
+	}
+	if added.Hash == "" {
+		return "", fmt.Errorf("ipfs add returned no CID: %s", raw)
+	}
+	return added.Hash, nil
+}
+
+// Cat returns the document of path from the IPFS node, that fetches it from the network when it does not have it
+func (c *IPFSClient) Cat(ctx context.Context, path string) ([]byte, error) {
+	return c.post(ctx, "/api/v0/cat?arg="+url.QueryEscape(path), "", nil)
+}
+
+// Pin pins the document of path in the IPFS node, fetching it from the network when it does not have it
+func (c *IPFSClient) Pin(ctx context.Context, path string) error {
+	_, err := c.post(ctx, "/api/v0/pin/add?arg="+url.QueryEscape(path), "", nil)
+	return err
+}
+
+// post calls an endpoint of the HTTP API of the node, all of them are POST, and returns the response body
+func (c *IPFSClient) post(ctx context.Context, endpoint string, contentType string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return doIPFSRequest(ctx, c.conn, req)
+}
+
+// IPFSHTTPGateway fetches the documents published in IPFS through an HTTP gateway, like https://ipfs.io
+type IPFSHTTPGateway struct {
+	url  string
+	conn *http.Client
+}
+
+// NewIPFSHTTPGateway returns the client of the IPFS HTTP gateway in url
+func NewIPFSHTTPGateway(url string, conn *http.Client) *IPFSHTTPGateway {
+	return &IPFSHTTPGateway{
+		url:  strings.TrimSuffix(url, "/"),
+		conn: conn,
+	}
+}
+
+// Cat returns the document of path from the gateway
+func (g *IPFSHTTPGateway) Cat(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.url+"/ipfs/"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return doIPFSRequest(ctx, g.conn, req)
+}
+
+// NewIPFSFetcher returns the fetcher of the ipfs:// documents of the schema loaders: the HTTP gateway in gatewayURL
+// when it is set, and the IPFS node whose HTTP API is in nodeURL otherwise. Nil when none of them is set.
+func NewIPFSFetcher(nodeURL string, gatewayURL string, conn *http.Client) loader.IPFSFetcher {
+	if gatewayURL != "" {
+		return NewIPFSHTTPGateway(gatewayURL, conn)
+	}
+	if nodeURL != "" {
+		return NewIPFSClient(nodeURL, conn)
+	}
+	return nil
+}
+
+func doIPFSRequest(ctx context.Context, conn *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := conn.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Error(ctx, "cannot close ipfs response body", "err", err)
+		}
+	}()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ipfs request %s failed with status %d: %s", req.URL.Path, resp.StatusCode, raw)
+	}
+	return raw, nil
+}
@@ -27,7 +27,8 @@ const (
 
 // PushClient PPG for notify devices.
 type PushClient struct {
-	conn *http.Client
+	conn     *http.Client
+	gateways *notifications.Gateways
 }
 
 // NewPushNotificationClient create PPG client.
@@ -37,6 +38,15 @@ func NewPushNotificationClient(conn *http.Client) ports.NotificationGateway {
 	}
 }
 
+// NewPushNotificationClientWithGateways creates a PPG client that fails over between the gateways of the group when
+// the push service of a DID document is one of them. A nil group sends every push to the push service.
+func NewPushNotificationClientWithGateways(conn *http.Client, gateways *notifications.Gateways) ports.NotificationGateway {
+	return &PushClient{
+		conn:     conn,
+		gateways: gateways,
+	}
+}
+
 // Notify send notification in json format to push service with device metadata.
 func (c *PushClient) Notify(ctx context.Context, msg json.RawMessage, userDIDDocument verifiable.DIDDocument) (*domain.UserNotificationResult, error) {
 	// find service for push in did document
@@ -58,7 +68,18 @@ func (c *PushClient) Notify(ctx context.Context, msg json.RawMessage, userDIDDoc
 		return nil, errors.WithStack(err)
 	}
 
-	resp, err := c.conn.Post(ctx, pushService.ServiceEndpoint, reqBody)
+	var resp []byte
+	for _, gateway := range c.gateways.For(pushService.ServiceEndpoint, userDIDDocument.ID) {
+		resp, err = c.conn.Post(ctx, gateway, reqBody)
+		if err == nil {
+			c.gateways.MarkUp(ctx, gateway)
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		c.gateways.MarkDown(ctx, gateway)
+	}
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
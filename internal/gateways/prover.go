@@ -19,6 +19,8 @@ import (
 type ProverConfig struct {
 	ServerURL       string
 	ResponseTimeout time.Duration
+	// Transport sends the requests to the prover server. Nil sends them with http.DefaultTransport.
+	Transport http.RoundTripper
 }
 
 // ProverService service responsible for zk generation
@@ -33,8 +35,8 @@ func NewProverService(config *ProverConfig) *ProverService {
 
 // NewProver returns a new prover with the given configuration.
 // If NativeProofGenerationEnabled is true it will return a NativeProverService
-// If NativeProofGenerationEnabled is false it will return an external ProverService
-func NewProver(ctx context.Context, config *config.Configuration, circuitLoaderService *loaders.Circuits) ports.ZKGenerator {
+// If NativeProofGenerationEnabled is false it will return an external ProverService that sends its requests with transport
+func NewProver(ctx context.Context, config *config.Configuration, circuitLoaderService *loaders.Circuits, transport http.RoundTripper) ports.ZKGenerator {
 	log.Info(ctx, "native prover enabled", "enabled", config.NativeProofGenerationEnabled)
 	if config.NativeProofGenerationEnabled {
 		proverConfig := &services.NativeProverConfig{
@@ -46,6 +48,7 @@ func NewProver(ctx context.Context, config *config.Configuration, circuitLoaderS
 	proverConfig := &ProverConfig{
 		ServerURL:       config.Prover.ServerURL,
 		ResponseTimeout: config.Prover.ResponseTimeout,
+		Transport:       transport,
 	}
 
 	return NewProverService(proverConfig)
@@ -69,7 +72,7 @@ func (s *ProverService) Verify(ctx context.Context, zkp *domain.FullProof, circu
 
 	url := s.proverConfig.ServerURL + "/api/v1/proof/verify"
 
-	res, err := client.NewClient(http.Client{Timeout: s.proverConfig.ResponseTimeout}).Post(ctx, url, proverReq)
+	res, err := client.NewClient(http.Client{Timeout: s.proverConfig.ResponseTimeout, Transport: s.proverConfig.Transport}).Post(ctx, url, proverReq)
 	if err != nil {
 		return false, err
 	}
@@ -107,7 +110,7 @@ func (s *ProverService) Generate(ctx context.Context, inputs json.RawMessage, ci
 
 	url := s.proverConfig.ServerURL + "/api/v1/proof/generate"
 
-	res, err := client.NewClient(http.Client{Timeout: s.proverConfig.ResponseTimeout}).Post(ctx, url, req)
+	res, err := client.NewClient(http.Client{Timeout: s.proverConfig.ResponseTimeout, Transport: s.proverConfig.Transport}).Post(ctx, url, req)
 	if err != nil {
 		return nil, err
 	}
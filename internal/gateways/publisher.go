@@ -21,6 +21,7 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/internal/kms"
 	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
 	"github.com/polygonid/sh-id-platform/pkg/pubsub"
 	"github.com/polygonid/sh-id-platform/pkg/sync_ttl_map"
 )
@@ -53,16 +54,17 @@ type publisher struct {
 	claimService          ports.ClaimsService
 	mtService             ports.MtService
 	kms                   kms.KMSType
-	transactionService    ports.TransactionService
+	transactionServices   ports.TransactionServices
 	confirmationTimeout   time.Duration
 	zkService             ports.ZKGenerator
 	publisherGateway      PublisherGateway
 	pendingTransactions   *sync_ttl_map.TTLMap
 	notificationPublisher pubsub.Publisher
+	meteringRepository    ports.MeteringRepository
 }
 
 // NewPublisher - Constructor
-func NewPublisher(storage *db.Storage, identityService ports.IdentityService, claimService ports.ClaimsService, mtService ports.MtService, kms kms.KMSType, transactionService ports.TransactionService, zkService ports.ZKGenerator, publisherGateway PublisherGateway, confirmationTimeout time.Duration, notificationPublisher pubsub.Publisher) *publisher {
+func NewPublisher(storage *db.Storage, identityService ports.IdentityService, claimService ports.ClaimsService, mtService ports.MtService, kms kms.KMSType, transactionServices ports.TransactionServices, zkService ports.ZKGenerator, publisherGateway PublisherGateway, confirmationTimeout time.Duration, notificationPublisher pubsub.Publisher) *publisher {
 	pendingTransactions := sync_ttl_map.New(ttl)
 	pendingTransactions.CleaningBackground(transactionCleanup)
 
@@ -72,12 +74,13 @@ func NewPublisher(storage *db.Storage, identityService ports.IdentityService, cl
 		storage:               storage,
 		mtService:             mtService,
 		kms:                   kms,
-		transactionService:    transactionService,
+		transactionServices:   transactionServices,
 		zkService:             zkService,
 		publisherGateway:      publisherGateway,
 		confirmationTimeout:   confirmationTimeout,
 		pendingTransactions:   pendingTransactions,
 		notificationPublisher: notificationPublisher,
+		meteringRepository:    repositories.NewMetering(),
 	}
 }
 
@@ -144,6 +147,11 @@ func (p *publisher) publishState(ctx context.Context, identifier *core.DID) (*do
 		return nil, err
 	}
 
+	// The state is already published, so a failure metering it is only logged
+	if err := p.meteringRepository.Add(ctx, p.storage.Pgx, *identifier, nil, domain.MeteredPublish, time.Now()); err != nil {
+		log.Error(ctx, "metering the state publish", "err", err, "did", identifier.String())
+	}
+
 	return &domain.PublishedState{
 		TxID:               txID,
 		ClaimsTreeRoot:     updatedState.ClaimsTreeRoot,
@@ -290,14 +298,14 @@ func (p *publisher) publishProof(ctx context.Context, identifier *core.DID, newS
 		return nil, err
 	}
 
-	// add go routine that will listen for transaction status update
-
+	// add go routine that will listen for transaction status update.
+	// It outlives the request that published the state, so it only keeps the logger of the request context.
 	go func(ctx context.Context) {
 		if err := p.updateTransactionStatus(ctx, newState, *txID); err != nil {
 			log.Error(ctx, "cannot update transaction status", "err", err)
 		}
 		p.pendingTransactions.Delete(identifier.String())
-	}(ctx)
+	}(log.CopyFromContext(ctx, context.Background()))
 
 	return txID, nil
 }
@@ -374,7 +382,12 @@ func (p *publisher) fillAuthClaimData(ctx context.Context, identifier *core.DID,
 
 // updateTransactionStatus update identity state with transaction status
 func (p *publisher) updateTransactionStatus(ctx context.Context, state domain.IdentityState, txID string) error {
-	receipt, err := p.transactionService.WaitForTransactionReceipt(ctx, txID)
+	transactionService, err := p.transactionService(&state)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := transactionService.WaitForTransactionReceipt(ctx, txID)
 	if err != nil {
 		log.Error(ctx, "error during receipt receiving: ", "err", err, "txID", txID)
 		return err
@@ -383,7 +396,7 @@ func (p *publisher) updateTransactionStatus(ctx context.Context, state domain.Id
 	if receipt.Status == types.ReceiptStatusSuccessful {
 		// wait until transaction will be confirmed if transaction has enough confirmation blocks
 		log.Debug(ctx, "Waiting for confirmation", "tx", receipt.TxHash.Hex())
-		confirmed, rErr := p.transactionService.WaitForConfirmation(ctx, receipt)
+		confirmed, rErr := transactionService.WaitForConfirmation(ctx, receipt)
 		if rErr != nil {
 			return fmt.Errorf("transaction receipt is found, but not confirmed - %s", *state.TxID)
 		}
@@ -395,7 +408,7 @@ func (p *publisher) updateTransactionStatus(ctx context.Context, state domain.Id
 		log.Info(ctx, "transaction failed", "tx", *state.TxID)
 	}
 
-	err = p.updateIdentityStateTxStatus(ctx, &state, receipt)
+	err = p.updateIdentityStateTxStatus(ctx, transactionService, &state, receipt)
 	if err != nil {
 		log.Error(ctx, "updating identity state", "err", err, "txID", txID)
 		return err
@@ -405,8 +418,8 @@ func (p *publisher) updateTransactionStatus(ctx context.Context, state domain.Id
 	return nil
 }
 
-func (p *publisher) updateIdentityStateTxStatus(ctx context.Context, state *domain.IdentityState, receipt *types.Receipt) error {
-	header, err := p.transactionService.GetHeaderByNumber(ctx, receipt.BlockNumber)
+func (p *publisher) updateIdentityStateTxStatus(ctx context.Context, transactionService ports.TransactionService, state *domain.IdentityState, receipt *types.Receipt) error {
+	header, err := transactionService.GetHeaderByNumber(ctx, receipt.BlockNumber)
 	if err != nil {
 		log.Error(ctx, "couldn't find receipt block: ", "err", err, "block", receipt.BlockNumber)
 		return err
@@ -431,6 +444,10 @@ func (p *publisher) updateIdentityStateTxStatus(ctx context.Context, state *doma
 			log.Error(ctx, "couldn't fetch the credentials to send notifications: ", "err", err, "state", state.StateID)
 			return err
 		}
+		if err := p.notificationPublisher.Publish(ctx, event.StateTransitionEvent, &event.StateTransition{IssuerID: state.Identifier, State: *state.State}); err != nil {
+			log.Error(ctx, "publish EventStateTransition", "err", err.Error(), "state", state.StateID)
+		}
+
 		log.Info(ctx, "sending notifications:", "numberOfClaims", len(claimsToNotify))
 
 		grupedCredentials := groupByUserId(claimsToNotify)
@@ -502,15 +519,20 @@ func (p *publisher) CheckTransactionStatus(ctx context.Context) {
 }
 
 func (p *publisher) checkStatus(ctx context.Context, state *domain.IdentityState) error {
+	transactionService, err := p.transactionService(state)
+	if err != nil {
+		return err
+	}
+
 	// Get receipt and check status
-	receipt, err := p.transactionService.GetTransactionReceiptByID(ctx, *state.TxID)
+	receipt, err := transactionService.GetTransactionReceiptByID(ctx, *state.TxID)
 	if err != nil {
 		log.Error(ctx, "error during receipt receiving:", "err", err, "state-id", *state.TxID)
 		return fmt.Errorf("error during receipt receiving::%s: %w", *state.TxID, err)
 	}
 
 	// Check if transaction has enough confirmation blocks
-	confirmed, err := p.transactionService.CheckConfirmation(ctx, receipt)
+	confirmed, err := transactionService.CheckConfirmation(ctx, receipt)
 	if err != nil {
 		log.Error(ctx, fmt.Sprintf("transaction receipt is found, but confirmation is not checked - %s", *state.TxID), "err", err)
 		return fmt.Errorf("transaction receipt is found, but confirmation is not checked:%s - %w", *state.TxID, err)
@@ -521,7 +543,7 @@ func (p *publisher) checkStatus(ctx context.Context, state *domain.IdentityState
 		return ErrStateIsBeingProcessed
 	}
 
-	err = p.updateIdentityStateTxStatus(ctx, state, receipt)
+	err = p.updateIdentityStateTxStatus(ctx, transactionService, state, receipt)
 	if err != nil {
 		log.Error(ctx, "error during identity state update: ", "err", err)
 		return err
@@ -530,3 +552,12 @@ func (p *publisher) checkStatus(ctx context.Context, state *domain.IdentityState
 	log.Info(ctx, "transaction status updated", "tx", *state.TxID)
 	return nil
 }
+
+// transactionService returns the transaction service of the network the identity of the state is published on
+func (p *publisher) transactionService(state *domain.IdentityState) (ports.TransactionService, error) {
+	did, err := core.ParseDID(state.Identifier)
+	if err != nil {
+		return nil, fmt.Errorf("error getting did from state %s: %w", state.Identifier, err)
+	}
+	return p.transactionServices.TransactionService(did)
+}
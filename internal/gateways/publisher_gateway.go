@@ -2,7 +2,6 @@ package gateways
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -57,7 +56,7 @@ func (pb *PublisherEthGateway) PublishState(ctx context.Context, identifier *cor
 		return nil, errors.New("state hasn't been changed")
 	}
 
-	fromAddress, err := pb.getAddressForTxInitiator()
+	fromAddress, err := pb.getAddressForTxInitiator(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -111,19 +110,12 @@ func (pb *PublisherEthGateway) PublishState(ctx context.Context, identifier *cor
 	return &txID, nil
 }
 
-func (pb *PublisherEthGateway) getAddressForTxInitiator() (ethCommon.Address, error) {
-	bytesPubKey, err := pb.kms.PublicKey(pb.publishingKeyID)
+func (pb *PublisherEthGateway) getAddressForTxInitiator(ctx context.Context) (ethCommon.Address, error) {
+	bytesPubKey, err := pb.kms.PublicKey(ctx, pb.publishingKeyID)
 	if err != nil {
 		return ethCommon.Address{}, err
 	}
-	var pubKey *ecdsa.PublicKey
-	bytesPubKeyLen := 33
-	switch len(bytesPubKey) {
-	case bytesPubKeyLen:
-		pubKey, err = crypto.DecompressPubkey(bytesPubKey)
-	default:
-		pubKey, err = crypto.UnmarshalPubkey(bytesPubKey)
-	}
+	pubKey, err := kms.DecodeEthPubKey(bytesPubKey)
 	if err != nil {
 		return ethCommon.Address{}, err
 	}
@@ -0,0 +1,179 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// ReadinessPath is the path the readiness report is served at
+const ReadinessPath = "/readiness"
+
+// Readiness statuses
+const (
+	ReadinessStarting = "starting" // ReadinessStarting the server is connecting to its dependencies
+	ReadinessDegraded = "degraded" // ReadinessDegraded some dependency cannot be reached
+	ReadinessReady    = "ready"    // ReadinessReady the server is accepting requests
+)
+
+// Dependency states
+const (
+	DependencyConnecting = "connecting" // DependencyConnecting first connection attempt in progress
+	DependencyRetrying   = "retrying"   // DependencyRetrying the last connection attempt failed and it will be retried
+	DependencyDown       = "down"       // DependencyDown the dependency cannot be reached
+	DependencyUp         = "up"         // DependencyUp the dependency is reachable
+)
+
+const backoffFactor = 2
+
+// Backoff configures the waits between connection attempts. Each wait doubles the previous one up to Max.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// DefaultBackoff is the backoff used by NewReadiness
+var DefaultBackoff = Backoff{Initial: 500 * time.Millisecond, Max: 10 * time.Second}
+
+// ReadinessReport is the body of the readiness endpoint response
+type ReadinessReport struct {
+	Status       string            `json:"status"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+// Readiness keeps track of the server startup. It connects to the dependencies retrying with exponential backoff,
+// and serves the readiness endpoint while they come up, so orchestrators can tell a starting server from a broken one.
+type Readiness struct {
+	sync.RWMutex
+	backoff      Backoff
+	dependencies map[string]string
+	monitor      *Status
+	handler      http.Handler
+}
+
+// NewReadiness returns a Readiness in the starting status
+func NewReadiness() *Readiness {
+	return &Readiness{
+		backoff:      DefaultBackoff,
+		dependencies: make(map[string]string),
+	}
+}
+
+// WithBackoff changes the waits between connection attempts
+func (r *Readiness) WithBackoff(b Backoff) *Readiness {
+	r.backoff = b
+	return r
+}
+
+// Connect calls connect until it succeeds, waiting between attempts. It gives up and returns the last error
+// when ctx is done, so the overall startup deadline is set by the caller context.
+func (r *Readiness) Connect(ctx context.Context, name string, connect func(ctx context.Context) error) error {
+	r.setDependency(name, DependencyConnecting)
+	wait := r.backoff.Initial
+	for attempt := 1; ; attempt++ {
+		err := connect(ctx)
+		if err == nil {
+			r.setDependency(name, DependencyUp)
+			log.Info(ctx, "dependency is up", "dependency", name, "attempts", attempt)
+			return nil
+		}
+
+		r.setDependency(name, DependencyRetrying)
+		log.Warn(ctx, "cannot connect to dependency", "dependency", name, "attempt", attempt, "retryIn", wait.String(), "err", err)
+		select {
+		case <-ctx.Done():
+			r.setDependency(name, DependencyDown)
+			return fmt.Errorf("giving up connecting to %s after %d attempts: %w", name, attempt, err)
+		case <-time.After(wait):
+		}
+
+		wait *= backoffFactor
+		if wait > r.backoff.Max {
+			wait = r.backoff.Max
+		}
+	}
+}
+
+// Ready finishes the startup. From now on the requests are forwarded to handler, and the dependencies
+// reported by the readiness endpoint are the ones checked by monitor, if not nil.
+func (r *Readiness) Ready(handler http.Handler, monitor *Status) {
+	r.Lock()
+	defer r.Unlock()
+	r.handler = handler
+	r.monitor = monitor
+}
+
+// Report returns the current readiness status
+func (r *Readiness) Report() ReadinessReport {
+	r.RLock()
+	defer r.RUnlock()
+
+	if r.handler == nil {
+		report := ReadinessReport{Status: ReadinessStarting, Dependencies: make(map[string]string, len(r.dependencies))}
+		for name, state := range r.dependencies {
+			report.Dependencies[name] = state
+			if state == DependencyRetrying || state == DependencyDown {
+				report.Status = ReadinessDegraded
+			}
+		}
+		return report
+	}
+
+	report := ReadinessReport{Status: ReadinessReady, Dependencies: map[string]string{}}
+	if r.monitor == nil {
+		return report
+	}
+	r.monitor.RLock()
+	defer r.monitor.RUnlock()
+	for name, up := range r.monitor.lastStatuses {
+		report.Dependencies[name] = DependencyUp
+		if !up {
+			report.Dependencies[name] = DependencyDown
+			report.Status = ReadinessDegraded
+		}
+	}
+	return report
+}
+
+// Handler serves the readiness report on ReadinessPath and forwards any other request to the handler
+// given to Ready. Until then those requests are answered with 503 Service Unavailable.
+func (r *Readiness) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == ReadinessPath {
+			r.serveReport(w)
+			return
+		}
+
+		r.RLock()
+		handler := r.handler
+		r.RUnlock()
+		if handler == nil {
+			w.Header().Set("Retry-After", "5")
+			http.Error(w, "server is starting", http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
+func (r *Readiness) serveReport(w http.ResponseWriter) {
+	report := r.Report()
+	w.Header().Set("Content-Type", "application/json")
+	if report.Status == ReadinessReady {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+func (r *Readiness) setDependency(name, state string) {
+	r.Lock()
+	defer r.Unlock()
+	r.dependencies[name] = state
+}
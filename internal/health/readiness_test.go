@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBackoff = Backoff{Initial: time.Millisecond, Max: 5 * time.Millisecond}
+
+func TestReadiness_Connect(t *testing.T) {
+	t.Run("retries until the dependency is up", func(t *testing.T) {
+		r := NewReadiness().WithBackoff(testBackoff)
+		attempts := 0
+		err := r.Connect(context.Background(), "postgres", func(context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("connection refused")
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, ReadinessReport{Status: ReadinessStarting, Dependencies: map[string]string{"postgres": DependencyUp}}, r.Report())
+	})
+
+	t.Run("gives up when the deadline is exceeded", func(t *testing.T) {
+		r := NewReadiness().WithBackoff(testBackoff)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		errRefused := errors.New("connection refused")
+		err := r.Connect(ctx, "redis", func(context.Context) error { return errRefused })
+		assert.ErrorIs(t, err, errRefused)
+		assert.Equal(t, ReadinessReport{Status: ReadinessDegraded, Dependencies: map[string]string{"redis": DependencyDown}}, r.Report())
+	})
+}
+
+func TestReadiness_Handler(t *testing.T) {
+	r := NewReadiness().WithBackoff(testBackoff)
+	handler := r.Handler()
+
+	get := func(path string) (*httptest.ResponseRecorder, ReadinessReport) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		var report ReadinessReport
+		if path == ReadinessPath {
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+		}
+		return rr, report
+	}
+
+	rr, report := get(ReadinessPath)
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, ReadinessStarting, report.Status)
+
+	rr, _ = get("/status")
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+	require.NoError(t, r.Connect(context.Background(), "postgres", func(context.Context) error { return nil }))
+	monitor := New(Monitors{"postgres": func(context.Context) error { return nil }})
+	monitor.checkStatus(context.Background())
+	r.Ready(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) }), monitor)
+
+	rr, report = get(ReadinessPath)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, ReadinessReport{Status: ReadinessReady, Dependencies: map[string]string{"postgres": DependencyUp}}, report)
+
+	rr, _ = get("/status")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	monitor.monitors["postgres"] = func(context.Context) error { return errors.New("down") }
+	monitor.checkStatus(context.Background())
+	rr, report = get(ReadinessPath)
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, ReadinessDegraded, report.Status)
+}
@@ -0,0 +1,99 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// Build returns the iden3 json schema of a credential of type schemaType whose credential subject has the attributes
+// attrs, besides the id of the subject. The $metadata of the schema only has the type, the urls and the version are set
+// with WithMetadata when it is published.
+func Build(schemaType, title, description string, attrs []domain.SchemaAttributeDefinition) (*JSONSchema, error) {
+	if err := domain.ValidateSchemaAttributes(attrs); err != nil {
+		return nil, err
+	}
+
+	subjectProps := map[string]any{
+		subjectIDAttribute: map[string]any{
+			"title":       "Credential subject ID",
+			"description": "Stores the DID of the subject that owns the credential",
+			"type":        "string",
+			"format":      "uri",
+		},
+	}
+	var required []string
+	for _, attr := range attrs {
+		prop := map[string]any{"type": string(attr.Type)}
+		if attr.Type == domain.SchemaAttributeDate || attr.Type == domain.SchemaAttributeDateTime {
+			prop["type"] = "string"
+			prop["format"] = string(attr.Type)
+		}
+		if attr.Title != "" {
+			prop["title"] = attr.Title
+		}
+		if attr.Description != "" {
+			prop["description"] = attr.Description
+		}
+		subjectProps[attr.Name] = prop
+		if attr.Required {
+			required = append(required, attr.Name)
+		}
+	}
+	credentialSubject := map[string]any{
+		"title":       "Credential subject",
+		"description": "Stores the data of the credential",
+		"type":        "object",
+		"properties":  subjectProps,
+	}
+	if len(required) > 0 {
+		credentialSubject["required"] = required
+	}
+
+	uriObject := func(title, description string) map[string]any {
+		return map[string]any{
+			"title":       title,
+			"description": description,
+			"type":        "object",
+			"required":    []string{"id", "type"},
+			"properties": map[string]any{
+				"id":   map[string]any{"type": "string", "format": "uri"},
+				"type": map[string]any{"type": "string"},
+			},
+		}
+	}
+	content := map[string]any{
+		"$schema":   "http://json-schema.org/draft-07/schema#",
+		"$metadata": map[string]any{"type": schemaType},
+		"type":      "object",
+		"required":  []string{"@context", "id", "issuanceDate", "issuer", "type", "credentialSubject", "credentialSchema"},
+		"properties": map[string]any{
+			"@context":          map[string]any{"type": []string{"string", "array", "object"}},
+			"id":                map[string]any{"type": "string"},
+			"type":              map[string]any{"type": []string{"string", "array"}, "items": map[string]any{"type": "string"}},
+			"issuanceDate":      map[string]any{"type": "string", "format": "date-time"},
+			"expirationDate":    map[string]any{"type": "string", "format": "date-time"},
+			"credentialSubject": credentialSubject,
+			"credentialSchema":  uriObject("Credential schema", "Json schema of the credential"),
+			"credentialStatus":  uriObject("Credential status", "Allows the discovery of the status of the credential, such as whether it is revoked"),
+			"issuer": map[string]any{
+				"type":       []string{"string", "object"},
+				"format":     "uri",
+				"required":   []string{"id"},
+				"properties": map[string]any{"id": map[string]any{"type": "string", "format": "uri"}},
+			},
+		},
+	}
+	if title != "" {
+		content["title"] = title
+	}
+	if description != "" {
+		content["description"] = description
+	}
+
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(raw)
+}
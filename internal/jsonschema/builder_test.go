@@ -0,0 +1,42 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func TestBuild(t *testing.T) {
+	schema, err := Build("KYCAgeCredential", "Proof of age", "Proof that the holder is an adult", []domain.SchemaAttributeDefinition{
+		{Name: "birthday", Type: domain.SchemaAttributeDate, Title: "Birthday", Required: true},
+		{Name: "documentType", Type: domain.SchemaAttributeInteger, Description: "Type of the identity document"},
+		{Name: "verified", Type: domain.SchemaAttributeBoolean},
+	})
+	require.NoError(t, err)
+
+	attrs, err := schema.Attributes()
+	require.NoError(t, err)
+	types := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		types[attr.ID] = attr.Type + attr.Format
+	}
+	assert.Equal(t, map[string]string{"id": "stringuri", "birthday": "stringdate", "documentType": "integer", "verified": "boolean"}, types)
+
+	var content map[string]any
+	require.NoError(t, json.Unmarshal(schema.Raw(), &content))
+	assert.Equal(t, "Proof of age", content["title"])
+	subject := content["properties"].(map[string]any)["credentialSubject"].(map[string]any)
+	assert.Equal(t, []any{"birthday"}, subject["required"])
+
+	contextURL := "https://issuer.example.com/v1/schemas/1/context"
+	doc, err := schema.NewJSONLdContext("KYCAgeCredential", contextURL)
+	require.NoError(t, err)
+	assert.NoError(t, schema.CheckJSONLdContext(doc, "KYCAgeCredential"))
+
+	_, err = Build("KYCAgeCredential", "", "", []domain.SchemaAttributeDefinition{{Name: "id", Type: domain.SchemaAttributeString}})
+	assert.ErrorIs(t, err, domain.ErrInvalidSchemaAttributes)
+}
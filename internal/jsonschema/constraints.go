@@ -0,0 +1,118 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// validateConstraints checks the converted value of an attribute against the constraints of its schema property prop:
+// enum and const for any type, pattern, minLength and maxLength for strings, minimum, maximum, exclusiveMinimum and
+// exclusiveMaximum for numbers and minItems and maxItems for arrays.
+func validateConstraints(prop map[string]any, value any) error {
+	if enum, ok := prop["enum"].([]any); ok && !containsValue(enum, value) {
+		values, _ := json.Marshal(enum)
+		return fmt.Errorf("must be one of %s", values)
+	}
+	if constant, ok := prop["const"]; ok && !equalValues(constant, value) {
+		expected, _ := json.Marshal(constant)
+		return fmt.Errorf("must be %s", expected)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return validateStringConstraints(prop, v)
+	case int64:
+		return validateNumberConstraints(prop, float64(v))
+	case float64:
+		return validateNumberConstraints(prop, v)
+	case []any:
+		if min, ok := number(prop["minItems"]); ok && float64(len(v)) < min {
+			return fmt.Errorf("must have at least %v items", min)
+		}
+		if max, ok := number(prop["maxItems"]); ok && float64(len(v)) > max {
+			return fmt.Errorf("must have at most %v items", max)
+		}
+	}
+	return nil
+}
+
+func validateStringConstraints(prop map[string]any, value string) error {
+	length := float64(utf8.RuneCountInString(value))
+	if min, ok := number(prop["minLength"]); ok && length < min {
+		return fmt.Errorf("must be at least %v characters long", min)
+	}
+	if max, ok := number(prop["maxLength"]); ok && length > max {
+		return fmt.Errorf("must be at most %v characters long", max)
+	}
+	if pattern, ok := prop["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern <%s> in the schema: %w", pattern, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("must match the pattern <%s>", pattern)
+		}
+	}
+	return nil
+}
+
+func validateNumberConstraints(prop map[string]any, value float64) error {
+	if min, ok := number(prop["minimum"]); ok && value < min {
+		return fmt.Errorf("must be greater than or equal to %v", min)
+	}
+	if max, ok := number(prop["maximum"]); ok && value > max {
+		return fmt.Errorf("must be less than or equal to %v", max)
+	}
+	if min, ok := number(prop["exclusiveMinimum"]); ok && value <= min {
+		return fmt.Errorf("must be greater than %v", min)
+	}
+	if max, ok := number(prop["exclusiveMaximum"]); ok && value >= max {
+		return fmt.Errorf("must be less than %v", max)
+	}
+	return nil
+}
+
+// number returns the numeric value of a schema keyword. The boolean exclusiveMinimum and exclusiveMaximum of draft 4
+// are not numbers, so they are ignored.
+func number(keyword any) (float64, bool) {
+	switch v := keyword.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if equalValues(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValues compares a value of the schema with a converted attribute value. The numbers of the schema are float64
+// and the integer attributes int64, so numbers are compared by value.
+func equalValues(schemaValue any, value any) bool {
+	a, aIsNumber := number(schemaValue)
+	b, bIsNumber := number(value)
+	if aIsNumber || bIsNumber {
+		return aIsNumber && bIsNumber && a == b
+	}
+	x, err := json.Marshal(schemaValue)
+	if err != nil {
+		return false
+	}
+	y, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	return string(x) == string(y)
+}
@@ -0,0 +1,189 @@
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/iden3/go-schema-processor/merklize"
+
+	"github.com/polygonid/sh-id-platform/internal/loader"
+)
+
+// ErrTypeNotInContext means that the JSON-LD context has no term definition with a scoped context for the credential type
+var ErrTypeNotInContext = errors.New("credential type not defined in the jsonld context")
+
+// LoadForType loads the json schema of a credential type from url. The url can point to a json schema or, for the
+// credential types that only ship a JSON-LD context, to the context document. In the latter case the json schema is
+// derived from the context with FromJSONLdContext.
+func LoadForType(ctx context.Context, loader loader.Loader, url string, schemaType string) (*JSONSchema, error) {
+	raw, _, err := loader.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if IsJSONLdContext(raw) {
+		return FromJSONLdContext(raw, url, schemaType)
+	}
+	return Parse(raw)
+}
+
+// Parse returns the JSONSchema of the json schema document raw
+func Parse(raw []byte) (*JSONSchema, error) {
+	schema := &JSONSchema{content: make(map[string]any), raw: raw}
+	if err := json.Unmarshal(raw, &schema.content); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// IsJSONLdContext tells whether doc is a JSON-LD context document instead of a json schema
+func IsJSONLdContext(doc []byte) bool {
+	var content map[string]any
+	if err := json.Unmarshal(doc, &content); err != nil {
+		return false
+	}
+	_, hasContext := content["@context"]
+	_, hasMetadata := content["$metadata"]
+	return hasContext && !hasMetadata
+}
+
+// FromJSONLdContext derives the json schema of schemaType from the JSON-LD context doc, published in contextURL.
+// The credential subject attributes are the terms of the scoped context of the type, each one checked with the
+// merklizer, and their types come from the xsd type of the terms.
+// No attribute is required in the derived schema.
+func FromJSONLdContext(doc []byte, contextURL string, schemaType string) (*JSONSchema, error) {
+	props, err := contextProperties(doc, schemaType)
+	if err != nil {
+		return nil, err
+	}
+	props["id"] = map[string]any{"title": "Credential Subject ID", "type": "string", "format": "uri"}
+
+	content := map[string]any{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"$metadata": map[string]any{
+			"uris": map[string]any{"jsonLdContext": contextURL},
+		},
+		"type":     "object",
+		"required": []any{"@context", "id", "type", "issuanceDate", "credentialSubject", "credentialSchema", "credentialStatus", "issuer"},
+		"properties": map[string]any{
+			"@context":       map[string]any{"type": []any{"string", "array", "object"}},
+			"id":             map[string]any{"type": "string"},
+			"type":           map[string]any{"type": []any{"string", "array"}, "items": map[string]any{"type": "string"}},
+			"issuer":         map[string]any{"type": []any{"string", "object"}, "format": "uri", "required": []any{"id"}, "properties": map[string]any{"id": map[string]any{"type": "string", "format": "uri"}}},
+			"issuanceDate":   map[string]any{"type": "string", "format": "date-time"},
+			"expirationDate": map[string]any{"type": "string", "format": "date-time"},
+			"credentialSchema": map[string]any{
+				"type":       "object",
+				"required":   []any{"id", "type"},
+				"properties": map[string]any{"id": map[string]any{"type": "string", "format": "uri"}, "type": map[string]any{"type": "string"}},
+			},
+			"credentialSubject": map[string]any{"type": "object", "properties": props},
+		},
+	}
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(raw)
+}
+
+// contextProperties returns the json schema properties of the terms of the scoped context of schemaType
+func contextProperties(doc []byte, schemaType string) (map[string]any, error) {
+	var content map[string]any
+	if err := json.Unmarshal(doc, &content); err != nil {
+		return nil, err
+	}
+	var contexts []any
+	switch c := content["@context"].(type) {
+	case []any:
+		contexts = c
+	default:
+		contexts = []any{c}
+	}
+	for _, c := range contexts {
+		terms, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		def, ok := terms[schemaType].(map[string]any)
+		if !ok {
+			continue
+		}
+		scoped, ok := def["@context"].(map[string]any)
+		if !ok {
+			continue
+		}
+		return termProperties(doc, schemaType, "", scoped)
+	}
+	return nil, ErrTypeNotInContext
+}
+
+// termProperties converts the term definitions of a scoped context into json schema properties. The terms with a
+// scoped context of their own are objects with nested properties. path is the path of the scoped context in the
+// credential subject, empty for the type.
+func termProperties(doc []byte, schemaType string, path string, terms map[string]any) (map[string]any, error) {
+	props := make(map[string]any)
+	for term, def := range terms {
+		if strings.HasPrefix(term, "@") || isKeywordAlias(def) || isPrefix(def) {
+			continue
+		}
+		fieldPath := term
+		if path != "" {
+			fieldPath = path + "." + term
+		}
+		if _, err := merklize.NewFieldPathFromContext(doc, schemaType, fieldPath); err != nil {
+			return nil, fmt.Errorf("resolving term <%s>: %w", fieldPath, err)
+		}
+
+		termDef, _ := def.(map[string]any)
+		if scoped, ok := termDef["@context"].(map[string]any); ok {
+			nested, err := termProperties(doc, schemaType, fieldPath, scoped)
+			if err != nil {
+				return nil, err
+			}
+			props[term] = map[string]any{"type": "object", "properties": nested}
+			continue
+		}
+		xsdType, _ := termDef["@type"].(string)
+		props[term] = xsdProperty(xsdType)
+	}
+	return props, nil
+}
+
+// xsdProperty returns the json schema property of a term with the given xsd type, compact (xsd:integer) or expanded
+func xsdProperty(xsdType string) map[string]any {
+	name := xsdType
+	if i := strings.LastIndexAny(name, "#:"); i >= 0 {
+		name = name[i+1:]
+	}
+	switch name {
+	case "integer", "int", "long", "short", "positiveInteger", "nonNegativeInteger", "negativeInteger", "nonPositiveInteger":
+		return map[string]any{"type": "integer"}
+	case "double", "decimal", "float":
+		return map[string]any{"type": "number"}
+	case "boolean":
+		return map[string]any{"type": "boolean"}
+	case "dateTime":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case "date":
+		return map[string]any{"type": "string", "format": "date"}
+	case "@id":
+		return map[string]any{"type": "string", "format": "uri"}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+// isKeywordAlias tells whether a term definition aliases a keyword, like "id": "@id"
+func isKeywordAlias(def any) bool {
+	s, ok := def.(string)
+	return ok && strings.HasPrefix(s, "@")
+}
+
+// isPrefix tells whether a term definition is a vocabulary prefix, like "xsd": "http://www.w3.org/2001/XMLSchema#"
+func isPrefix(def any) bool {
+	s, ok := def.(string)
+	return ok && (strings.HasSuffix(s, "#") || strings.HasSuffix(s, "/") || strings.HasSuffix(s, ":"))
+}
@@ -0,0 +1,73 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const kycAgeContext = `{
+  "@context": [
+    {
+      "@protected": true,
+      "@version": 1.1,
+      "id": "@id",
+      "type": "@type",
+      "KYCAgeCredential": {
+        "@id": "https://example.com/kyc-v1.jsonld#KYCAgeCredential",
+        "@context": {
+          "@propagate": true,
+          "@protected": true,
+          "kyc-vocab": "https://example.com/kyc-v1-vocab.md#",
+          "xsd": "http://www.w3.org/2001/XMLSchema#",
+          "birthday": {"@id": "kyc-vocab:birthday", "@type": "xsd:integer"},
+          "documentType": {"@id": "kyc-vocab:documentType", "@type": "xsd:integer"},
+          "verified": {"@id": "kyc-vocab:verified", "@type": "xsd:boolean"},
+          "issued": {"@id": "kyc-vocab:issued", "@type": "http://www.w3.org/2001/XMLSchema#dateTime"},
+          "nickname": "kyc-vocab:nickname"
+        }
+      }
+    }
+  ]
+}`
+
+func TestIsJSONLdContext(t *testing.T) {
+	assert.True(t, IsJSONLdContext([]byte(kycAgeContext)))
+	assert.False(t, IsJSONLdContext([]byte(`{"$metadata":{"uris":{"jsonLdContext":"https://example.com"}},"@context":{}}`)))
+	assert.False(t, IsJSONLdContext([]byte(`{"type":"object"}`)))
+	assert.False(t, IsJSONLdContext([]byte(`not json`)))
+}
+
+func TestFromJSONLdContext(t *testing.T) {
+	contextURL := "https://example.com/kyc-v1.jsonld"
+	schema, err := FromJSONLdContext([]byte(kycAgeContext), contextURL, "KYCAgeCredential")
+	require.NoError(t, err)
+
+	jsonLdContext, err := schema.JSONLdContext()
+	require.NoError(t, err)
+	assert.Equal(t, contextURL, jsonLdContext)
+
+	attrs, err := schema.Attributes()
+	require.NoError(t, err)
+	types := make(map[string]string, len(attrs))
+	formats := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		types[attr.ID] = attr.Type
+		formats[attr.ID] = attr.Format
+	}
+	assert.Equal(t, map[string]string{
+		"id":           "string",
+		"birthday":     "integer",
+		"documentType": "integer",
+		"verified":     "boolean",
+		"issued":       "string",
+		"nickname":     "string",
+	}, types)
+	assert.Equal(t, "date-time", formats["issued"])
+}
+
+func TestFromJSONLdContext_UnknownType(t *testing.T) {
+	_, err := FromJSONLdContext([]byte(kycAgeContext), "https://example.com/kyc-v1.jsonld", "KYCCountryOfResidenceCredential")
+	assert.ErrorIs(t, err, ErrTypeNotInContext)
+}
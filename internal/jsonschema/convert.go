@@ -0,0 +1,228 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// ValidateAndConvert checks the credential subject attributes against the types declared in the schema and returns a
+// copy of the credential subject with the values converted to them.
+// In strict mode the attributes that are not in the schema are rejected, and so are the values that don't have the
+// schema type. In lenient mode the values given as strings, like the ones of forms and CSV files, are parsed and the
+// attributes that are not in the schema are dropped. Each conversion and dropped attribute is returned as a warning.
+// The subject id and type are set by the issuance, so they are always accepted.
+func (s *JSONSchema) ValidateAndConvert(cSubject domain.CredentialSubject, mode domain.ValidationMode) (domain.CredentialSubject, []string, error) {
+	props, err := s.credentialSubjectProperties()
+	if err != nil {
+		return nil, nil, err
+	}
+	c := &converter{lenient: mode == domain.ValidationModeLenient}
+	converted, err := c.properties("", props, cSubject)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(c.warnings)
+	return converted, c.warnings, nil
+}
+
+func (s *JSONSchema) credentialSubjectProperties() (map[string]any, error) {
+	props, ok := s.content["properties"].(map[string]any)
+	if !ok {
+		return nil, errors.New("missing properties field")
+	}
+	credSubject, ok := props["credentialSubject"].(map[string]any)
+	if !ok {
+		return nil, errors.New("missing properties.credentialSubject field")
+	}
+	props, ok = credSubject["properties"].(map[string]any)
+	if !ok {
+		return nil, errors.New("missing properties.credentialSubject.properties field")
+	}
+	return props, nil
+}
+
+// converter converts the values of a credential subject, keeping the warnings of the lenient mode
+type converter struct {
+	lenient  bool
+	warnings []string
+}
+
+func (c *converter) warn(format string, args ...any) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+// properties converts the attributes of obj with the schema properties props. path is the path of obj in the
+// credential subject, empty for the credential subject itself.
+func (c *converter) properties(path string, props map[string]any, obj map[string]any) (map[string]any, error) {
+	converted := make(map[string]any, len(obj))
+	for key, value := range obj {
+		id := key
+		if path != "" {
+			id = path + "." + key
+		}
+		prop, ok := props[key].(map[string]any)
+		if !ok {
+			if path == "" && (key == subjectIDAttribute || key == "type") {
+				converted[key] = value
+				continue
+			}
+			if !c.lenient {
+				return nil, fmt.Errorf("attribute <%s> is not in the schema", id)
+			}
+			c.warn("attribute <%s> is not in the schema, it was dropped", id)
+			continue
+		}
+		var err error
+		if converted[key], err = c.validateCredentialLinkAttribute(id, prop, value); err != nil {
+			return nil, err
+		}
+	}
+	return converted, nil
+}
+
+// validateCredentialLinkAttribute converts the value of the attribute id to the type of its schema property prop and
+// checks the constraints of the property, like enum, pattern or minimum, on the converted value.
+func (c *converter) validateCredentialLinkAttribute(id string, prop map[string]any, value any) (any, error) {
+	converted, err := c.convert(id, prop, value)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateConstraints(prop, converted); err != nil {
+		return nil, fmt.Errorf("attribute <%s>: %w", id, err)
+	}
+	return converted, nil
+}
+
+// convert converts the value of the attribute id to the type of its schema property prop.
+// The items of arrays are converted with the items subschema and the attributes of objects with their properties.
+func (c *converter) convert(id string, prop map[string]any, value any) (any, error) {
+	attrType, _ := prop["type"].(string)
+	switch attrType {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("attribute <%s>: expected a string", id)
+		}
+		format, _ := prop["format"].(string)
+		if err := validateFormat(format, s); err != nil {
+			return nil, fmt.Errorf("attribute <%s>: %w", id, err)
+		}
+		return s, nil
+	case "integer":
+		return c.toInteger(id, value)
+	case "number":
+		return c.toNumber(id, value)
+	case "boolean":
+		return c.toBoolean(id, value)
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("attribute <%s>: expected an array", id)
+		}
+		itemsProp, ok := prop["items"].(map[string]any)
+		if !ok {
+			return items, nil
+		}
+		converted := make([]any, len(items))
+		for i, item := range items {
+			var err error
+			if converted[i], err = c.validateCredentialLinkAttribute(fmt.Sprintf("%s[%d]", id, i), itemsProp, item); err != nil {
+				return nil, err
+			}
+		}
+		return converted, nil
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("attribute <%s>: expected an object", id)
+		}
+		props, ok := prop["properties"].(map[string]any)
+		if !ok {
+			return obj, nil
+		}
+		return c.properties(id, props, obj)
+	default:
+		return nil, fmt.Errorf("attribute <%s>: type <%s> not supported", id, attrType)
+	}
+}
+
+func (c *converter) toInteger(id string, value any) (int64, error) {
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("attribute <%s>: expected an integer", id)
+		}
+		return int64(v), nil
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case string:
+		i, err := strconv.ParseInt(v, 10, 64)
+		if !c.lenient || err != nil {
+			return 0, fmt.Errorf("attribute <%s>: expected an integer", id)
+		}
+		c.warn("attribute <%s> was converted from a string to an integer", id)
+		return i, nil
+	default:
+		return 0, fmt.Errorf("attribute <%s>: expected an integer", id)
+	}
+}
+
+func (c *converter) toNumber(id string, value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if !c.lenient || err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return 0, fmt.Errorf("attribute <%s>: expected a number", id)
+		}
+		c.warn("attribute <%s> was converted from a string to a number", id)
+		return f, nil
+	default:
+		return 0, fmt.Errorf("attribute <%s>: expected a number", id)
+	}
+}
+
+func (c *converter) toBoolean(id string, value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if !c.lenient || err != nil {
+			return false, fmt.Errorf("attribute <%s>: expected a boolean", id)
+		}
+		c.warn("attribute <%s> was converted from a string to a boolean", id)
+		return b, nil
+	default:
+		return false, fmt.Errorf("attribute <%s>: expected a boolean", id)
+	}
+}
+
+// validateFormat checks the date and date-time formats of a string attribute. Dates are full dates, like 2023-04-21, and
+// date times are RFC 3339 timestamps, like 2023-04-21T10:00:00Z. Other formats are not checked.
+func validateFormat(format string, value string) error {
+	switch format {
+	case "date":
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return errors.New("expected a date like 2023-04-21")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return errors.New("expected an RFC 3339 date time like 2023-04-21T10:00:00Z")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+const convertSchema = `{
+  "$metadata": {"uris": {"jsonLdContext": "https://example.com/kyc.jsonld"}},
+  "properties": {
+    "credentialSubject": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string", "format": "uri"},
+        "name": {"type": "string"},
+        "age": {"type": "integer"},
+        "verified": {"type": "boolean"},
+        "height": {"type": "number"},
+        "born": {"type": "string", "format": "date"},
+        "seen": {"type": "string", "format": "date-time"},
+        "tags": {"type": "array", "items": {"type": "string"}},
+        "scores": {"type": "array", "items": {"type": "integer"}},
+        "anything": {"type": "array"},
+        "address": {"type": "object", "properties": {"zip": {"type": "integer"}}},
+        "level": {"type": "string", "enum": ["basic", "full"]},
+        "documentType": {"type": "integer", "enum": [1, 2, 3]},
+        "code": {"type": "string", "pattern": "^[A-Z]{2}[0-9]{4}$"},
+        "nick": {"type": "string", "minLength": 2, "maxLength": 5},
+        "rating": {"type": "integer", "minimum": 1, "maximum": 5},
+        "ratio": {"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 1},
+        "phones": {"type": "array", "maxItems": 2, "items": {"type": "string", "pattern": "^\\+[0-9]+$"}}
+      }
+    }
+  }
+}`
+
+func TestJSONSchema_ValidateAndConvert(t *testing.T) {
+	schema := &JSONSchema{}
+	require.NoError(t, json.Unmarshal([]byte(convertSchema), &schema.content))
+
+	for _, tc := range []struct {
+		name             string
+		mode             domain.ValidationMode
+		cSubject         domain.CredentialSubject
+		expected         domain.CredentialSubject
+		expectedWarnings []string
+		expectedErr      string
+	}{
+		{
+			name:     "typed values",
+			mode:     domain.ValidationModeStrict,
+			cSubject: domain.CredentialSubject{"id": "did:example:1", "type": "KYC", "name": "John", "age": float64(42), "verified": true},
+			expected: domain.CredentialSubject{"id": "did:example:1", "type": "KYC", "name": "John", "age": int64(42), "verified": true},
+		},
+		{
+			name:        "strict string values",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"age": "42"},
+			expectedErr: "attribute <age>: expected an integer",
+		},
+		{
+			name:             "lenient string values",
+			mode:             domain.ValidationModeLenient,
+			cSubject:         domain.CredentialSubject{"age": "42", "verified": "false"},
+			expected:         domain.CredentialSubject{"age": int64(42), "verified": false},
+			expectedWarnings: []string{"attribute <age> was converted from a string to an integer", "attribute <verified> was converted from a string to a boolean"},
+		},
+		{
+			name:     "arrays",
+			mode:     domain.ValidationModeStrict,
+			cSubject: domain.CredentialSubject{"tags": []any{"a", "b"}, "scores": []any{float64(1), float64(2)}, "anything": []any{true, "x"}},
+			expected: domain.CredentialSubject{"tags": []any{"a", "b"}, "scores": []any{int64(1), int64(2)}, "anything": []any{true, "x"}},
+		},
+		{
+			name:             "lenient array items",
+			mode:             domain.ValidationModeLenient,
+			cSubject:         domain.CredentialSubject{"scores": []any{"1", float64(2)}},
+			expected:         domain.CredentialSubject{"scores": []any{int64(1), int64(2)}},
+			expectedWarnings: []string{"attribute <scores[0]> was converted from a string to an integer"},
+		},
+		{
+			name:        "strict unknown attributes",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"name": "John", "other": 1},
+			expectedErr: "attribute <other> is not in the schema",
+		},
+		{
+			name:             "lenient unknown attributes",
+			mode:             domain.ValidationModeLenient,
+			cSubject:         domain.CredentialSubject{"address": map[string]any{"zip": float64(8001), "city": "Barcelona"}, "other": 1},
+			expected:         domain.CredentialSubject{"address": map[string]any{"zip": int64(8001)}},
+			expectedWarnings: []string{"attribute <address.city> is not in the schema, it was dropped", "attribute <other> is not in the schema, it was dropped"},
+		},
+		{
+			name:     "numbers and dates",
+			mode:     domain.ValidationModeStrict,
+			cSubject: domain.CredentialSubject{"height": 1.82, "born": "1979-09-11", "seen": "2023-04-21T10:00:00+02:00"},
+			expected: domain.CredentialSubject{"height": 1.82, "born": "1979-09-11", "seen": "2023-04-21T10:00:00+02:00"},
+		},
+		{
+			name:             "lenient number",
+			mode:             domain.ValidationModeLenient,
+			cSubject:         domain.CredentialSubject{"height": "1.82"},
+			expected:         domain.CredentialSubject{"height": 1.82},
+			expectedWarnings: []string{"attribute <height> was converted from a string to a number"},
+		},
+		{
+			name:        "strict number",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"height": "1.82"},
+			expectedErr: "attribute <height>: expected a number",
+		},
+		{
+			name:        "invalid date",
+			mode:        domain.ValidationModeLenient,
+			cSubject:    domain.CredentialSubject{"born": "11/09/1979"},
+			expectedErr: "attribute <born>: expected a date like 2023-04-21",
+		},
+		{
+			name:        "invalid date time",
+			mode:        domain.ValidationModeLenient,
+			cSubject:    domain.CredentialSubject{"seen": "2023-04-21 10:00"},
+			expectedErr: "attribute <seen>: expected an RFC 3339 date time like 2023-04-21T10:00:00Z",
+		},
+		{
+			name:        "not an array",
+			mode:        domain.ValidationModeLenient,
+			cSubject:    domain.CredentialSubject{"tags": "a"},
+			expectedErr: "attribute <tags>: expected an array",
+		},
+		{
+			name:        "wrong item",
+			mode:        domain.ValidationModeLenient,
+			cSubject:    domain.CredentialSubject{"scores": []any{float64(1), "two"}},
+			expectedErr: "attribute <scores[1]>: expected an integer",
+		},
+		{
+			name:        "not an integer",
+			mode:        domain.ValidationModeLenient,
+			cSubject:    domain.CredentialSubject{"age": 4.5},
+			expectedErr: "attribute <age>: expected an integer",
+		},
+		{
+			name:     "valid constraints",
+			mode:     domain.ValidationModeStrict,
+			cSubject: domain.CredentialSubject{"level": "full", "documentType": float64(2), "code": "ES1234", "nick": "jd", "rating": float64(5), "ratio": 0.5, "phones": []any{"+34600000000"}},
+			expected: domain.CredentialSubject{"level": "full", "documentType": int64(2), "code": "ES1234", "nick": "jd", "rating": int64(5), "ratio": 0.5, "phones": []any{"+34600000000"}},
+		},
+		{
+			name:             "lenient value in enum",
+			mode:             domain.ValidationModeLenient,
+			cSubject:         domain.CredentialSubject{"documentType": "3"},
+			expected:         domain.CredentialSubject{"documentType": int64(3)},
+			expectedWarnings: []string{"attribute <documentType> was converted from a string to an integer"},
+		},
+		{
+			name:        "string not in enum",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"level": "premium"},
+			expectedErr: `attribute <level>: must be one of ["basic","full"]`,
+		},
+		{
+			name:        "integer not in enum",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"documentType": float64(4)},
+			expectedErr: "attribute <documentType>: must be one of [1,2,3]",
+		},
+		{
+			name:        "pattern",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"code": "es1234"},
+			expectedErr: "attribute <code>: must match the pattern <^[A-Z]{2}[0-9]{4}$>",
+		},
+		{
+			name:        "min length",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"nick": "j"},
+			expectedErr: "attribute <nick>: must be at least 2 characters long",
+		},
+		{
+			name:        "max length",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"nick": "johnny"},
+			expectedErr: "attribute <nick>: must be at most 5 characters long",
+		},
+		{
+			name:        "minimum",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"rating": float64(0)},
+			expectedErr: "attribute <rating>: must be greater than or equal to 1",
+		},
+		{
+			name:        "maximum",
+			mode:        domain.ValidationModeLenient,
+			cSubject:    domain.CredentialSubject{"rating": "6"},
+			expectedErr: "attribute <rating>: must be less than or equal to 5",
+		},
+		{
+			name:        "exclusive minimum",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"ratio": float64(0)},
+			expectedErr: "attribute <ratio>: must be greater than 0",
+		},
+		{
+			name:        "exclusive maximum",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"ratio": float64(1)},
+			expectedErr: "attribute <ratio>: must be less than 1",
+		},
+		{
+			name:        "array item pattern",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"phones": []any{"+34600000000", "600000000"}},
+			expectedErr: "attribute <phones[1]>: must match the pattern <^\\+[0-9]+$>",
+		},
+		{
+			name:        "max items",
+			mode:        domain.ValidationModeStrict,
+			cSubject:    domain.CredentialSubject{"phones": []any{"+1", "+2", "+3"}},
+			expectedErr: "attribute <phones>: must have at most 2 items",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			converted, warnings, err := schema.ValidateAndConvert(tc.cSubject, tc.mode)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, converted)
+			assert.Equal(t, tc.expectedWarnings, warnings)
+		})
+	}
+}
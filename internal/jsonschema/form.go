@@ -0,0 +1,204 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// subjectIDAttribute is the holder DID. It is set by the issuance flow, so it is not part of the form.
+const subjectIDAttribute = "id"
+
+// Form returns the description of the form to fill the credential subject of this schema.
+// The title defaults to schemaType when the schema has no title.
+func (s *JSONSchema) Form(schemaType string) (*domain.SchemaForm, error) {
+	var doc struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Properties  struct {
+			CredentialSubject *formProperty `json:"credentialSubject"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(s.raw, &doc); err != nil {
+		return nil, err
+	}
+	subject := doc.Properties.CredentialSubject
+	if subject == nil || len(subject.Properties.keys) == 0 {
+		return nil, errors.New("missing properties.credentialSubject.properties field")
+	}
+
+	fields, err := subject.fields(subjectIDAttribute)
+	if err != nil {
+		return nil, err
+	}
+
+	form := &domain.SchemaForm{
+		Title:       doc.Title,
+		Description: doc.Description,
+		Fields:      fields,
+	}
+	if form.Title == "" {
+		form.Title = schemaType
+	}
+	return form, nil
+}
+
+// formProperty holds the JSON schema keywords used to describe a form field
+type formProperty struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Type        any               `json:"type"`
+	Format      string            `json:"format"`
+	Enum        []any             `json:"enum"`
+	Default     any               `json:"default"`
+	Minimum     *float64          `json:"minimum"`
+	Maximum     *float64          `json:"maximum"`
+	MinLength   *int              `json:"minLength"`
+	MaxLength   *int              `json:"maxLength"`
+	Pattern     string            `json:"pattern"`
+	Required    []string          `json:"required"`
+	Properties  orderedProperties `json:"properties"`
+}
+
+// fields returns the form fields of the property attributes, in document order
+func (p *formProperty) fields(skip ...string) ([]domain.SchemaFormField, error) {
+	if len(p.Properties.keys) == 0 {
+		return nil, nil
+	}
+	required := make(map[string]bool, len(p.Required))
+	for _, id := range p.Required {
+		required[id] = true
+	}
+	skipped := make(map[string]bool, len(skip))
+	for _, id := range skip {
+		skipped[id] = true
+	}
+
+	fields := make([]domain.SchemaFormField, 0, len(p.Properties.keys))
+	for _, id := range p.Properties.keys {
+		if skipped[id] {
+			continue
+		}
+		var prop formProperty
+		if err := json.Unmarshal(p.Properties.values[id], &prop); err != nil {
+			return nil, fmt.Errorf("parsing attribute <%s>: %w", id, err)
+		}
+		nested, err := prop.fields()
+		if err != nil {
+			return nil, err
+		}
+
+		jsonType := prop.jsonType()
+		field := domain.SchemaFormField{
+			ID:          id,
+			Label:       prop.Title,
+			Description: prop.Description,
+			Type:        jsonType,
+			Input:       prop.input(jsonType),
+			Required:    required[id],
+			Default:     prop.Default,
+			Constraints: domain.SchemaFormConstraints{
+				Enum:      prop.Enum,
+				Format:    prop.Format,
+				Minimum:   prop.Minimum,
+				Maximum:   prop.Maximum,
+				MinLength: prop.MinLength,
+				MaxLength: prop.MaxLength,
+				Pattern:   prop.Pattern,
+			},
+			Fields: nested,
+		}
+		if field.Label == "" {
+			field.Label = id
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// jsonType returns the type of the property. When it is a list of types, the first one that is not null.
+func (p *formProperty) jsonType() string {
+	switch t := p.Type.(type) {
+	case string:
+		return t
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	if len(p.Properties.keys) > 0 {
+		return "object"
+	}
+	return "string"
+}
+
+func (p *formProperty) input(jsonType string) string {
+	switch {
+	case len(p.Enum) > 0:
+		return domain.FormInputSelect
+	case jsonType == "boolean":
+		return domain.FormInputCheckbox
+	case jsonType == "integer", jsonType == "number":
+		return domain.FormInputNumber
+	case jsonType == "object":
+		return domain.FormInputObject
+	}
+
+	switch p.Format {
+	case "date":
+		return domain.FormInputDate
+	case "date-time":
+		return domain.FormInputDateTime
+	case "email":
+		return domain.FormInputEmail
+	case "uri":
+		return domain.FormInputURL
+	default:
+		return domain.FormInputText
+	}
+}
+
+// orderedProperties is a JSON object that keeps the order of its keys, lost when decoding into a map
+type orderedProperties struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// UnmarshalJSON reads the object keys in document order
+func (o *orderedProperties) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return errors.New("properties must be an object")
+	}
+
+	o.keys = nil
+	o.values = make(map[string]json.RawMessage)
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return errors.New("properties must be an object")
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if _, found := o.values[key]; !found {
+			o.keys = append(o.keys, key)
+		}
+		o.values[key] = value
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package jsonschema
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+)
+
+const formSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "Membership",
+  "description": "Club membership card",
+  "type": "object",
+  "properties": {
+    "credentialSubject": {
+      "type": "object",
+      "required": ["name", "level"],
+      "properties": {
+        "name": {"title": "Full name", "type": "string", "minLength": 2, "maxLength": 64},
+        "id": {"title": "Credential Subject ID", "type": "string", "format": "uri"},
+        "level": {"type": "integer", "enum": [1, 2, 3], "default": 1},
+        "birthday": {"type": "string", "format": "date", "description": "Date of birth"},
+        "email": {"type": ["null", "string"], "format": "email", "pattern": "^.+@.+$"},
+        "score": {"type": "number", "minimum": 0, "maximum": 10.5},
+        "active": {"type": "boolean"},
+        "address": {
+          "type": "object",
+          "required": ["city"],
+          "properties": {
+            "street": {"type": "string"},
+            "city": {"type": "string"}
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestJSONSchema_Form(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "membership.json")
+	require.NoError(t, os.WriteFile(path, []byte(formSchema), 0o600))
+	schema, err := Load(context.Background(), loader.FileFactory(path))
+	require.NoError(t, err)
+
+	form, err := schema.Form("Membership")
+	require.NoError(t, err)
+
+	assert.Equal(t, &domain.SchemaForm{
+		Title:       "Membership",
+		Description: "Club membership card",
+		Fields: []domain.SchemaFormField{
+			{
+				ID: "name", Label: "Full name", Type: "string", Input: domain.FormInputText, Required: true,
+				Constraints: domain.SchemaFormConstraints{MinLength: common.ToPointer(2), MaxLength: common.ToPointer(64)},
+			},
+			{
+				ID: "level", Label: "level", Type: "integer", Input: domain.FormInputSelect, Required: true, Default: float64(1),
+				Constraints: domain.SchemaFormConstraints{Enum: []any{float64(1), float64(2), float64(3)}},
+			},
+			{
+				ID: "birthday", Label: "birthday", Description: "Date of birth", Type: "string", Input: domain.FormInputDate,
+				Constraints: domain.SchemaFormConstraints{Format: "date"},
+			},
+			{
+				ID: "email", Label: "email", Type: "string", Input: domain.FormInputEmail,
+				Constraints: domain.SchemaFormConstraints{Format: "email", Pattern: "^.+@.+$"},
+			},
+			{
+				ID: "score", Label: "score", Type: "number", Input: domain.FormInputNumber,
+				Constraints: domain.SchemaFormConstraints{Minimum: common.ToPointer(0.0), Maximum: common.ToPointer(10.5)},
+			},
+			{ID: "active", Label: "active", Type: "boolean", Input: domain.FormInputCheckbox},
+			{
+				ID: "address", Label: "address", Type: "object", Input: domain.FormInputObject,
+				Fields: []domain.SchemaFormField{
+					{ID: "street", Label: "street", Type: "string", Input: domain.FormInputText},
+					{ID: "city", Label: "city", Type: "string", Input: domain.FormInputText, Required: true},
+				},
+			},
+		},
+	}, form)
+}
+
+func TestJSONSchema_Form_MissingSubject(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"properties": {}}`), 0o600))
+	schema, err := Load(context.Background(), loader.FileFactory(path))
+	require.NoError(t, err)
+
+	_, err = schema.Form("Empty")
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const xsdNamespace = "http://www.w3.org/2001/XMLSchema#"
+
+// WithMetadata returns a copy of the json schema with the $metadata of a schema published by the node: the urls of
+// the json schema and of its JSON-LD context, the credential type and the version. The rest of $metadata is kept.
+// An empty schemaURL is left out, as the schemas published in IPFS can't contain their own url.
+func (s *JSONSchema) WithMetadata(schemaURL, contextURL, schemaType, version string) (*JSONSchema, error) {
+	content := make(map[string]any, len(s.content))
+	for key, value := range s.content {
+		content[key] = value
+	}
+	metadata := make(map[string]any)
+	if current, ok := s.content["$metadata"].(map[string]any); ok {
+		for key, value := range current {
+			metadata[key] = value
+		}
+	}
+	uris := map[string]any{"jsonLdContext": contextURL}
+	if schemaURL != "" {
+		uris["jsonSchema"] = schemaURL
+	}
+	metadata["uris"] = uris
+	metadata["type"] = schemaType
+	metadata["version"] = version
+	content["$metadata"] = metadata
+
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(raw)
+}
+
+// NewJSONLdContext returns a JSON-LD context document published in contextURL that defines schemaType with a term for
+// each credential subject attribute of the json schema. The terms get the xsd type of the attribute type and the
+// objects get a scoped context with their properties. Arrays can't be described, so their schemas need a context
+// written by hand.
+func (s *JSONSchema) NewJSONLdContext(schemaType string, contextURL string) ([]byte, error) {
+	props, err := s.credentialSubjectProperties()
+	if err != nil {
+		return nil, err
+	}
+	vocab := schemaType + "-vocab"
+	terms, err := contextTerms(vocab, "", props)
+	if err != nil {
+		return nil, err
+	}
+	terms["@propagate"] = true
+	terms["@protected"] = true
+	terms[vocab] = contextURL + "#"
+	terms["xsd"] = xsdNamespace
+
+	doc, err := json.Marshal(map[string]any{
+		"@context": []any{
+			map[string]any{
+				"@protected": true,
+				"@version":   1.1,
+				"id":         "@id",
+				"type":       "@type",
+				schemaType: map[string]any{
+					"@id":      contextURL + "#" + schemaType,
+					"@context": terms,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.CheckJSONLdContext(doc, schemaType); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// contextTerms returns the term definitions of the json schema properties props. path is the path of props in the
+// credential subject, empty for the credential subject itself.
+func contextTerms(vocab string, path string, props map[string]any) (map[string]any, error) {
+	terms := make(map[string]any, len(props))
+	for name, value := range props {
+		if path == "" && name == subjectIDAttribute {
+			continue
+		}
+		id := name
+		if path != "" {
+			id = path + "." + name
+		}
+		prop, _ := value.(map[string]any)
+		attrType, _ := prop["type"].(string)
+		term := map[string]any{"@id": vocab + ":" + name}
+		switch attrType {
+		case "object":
+			nested, _ := prop["properties"].(map[string]any)
+			scoped, err := contextTerms(vocab, id, nested)
+			if err != nil {
+				return nil, err
+			}
+			term["@context"] = scoped
+		case "array":
+			return nil, fmt.Errorf("attribute <%s>: arrays can't be described in a generated JSON-LD context", id)
+		default:
+			xsdType, err := xsdTypeOf(attrType, prop)
+			if err != nil {
+				return nil, fmt.Errorf("attribute <%s>: %w", id, err)
+			}
+			term["@type"] = xsdType
+		}
+		terms[name] = term
+	}
+	return terms, nil
+}
+
+// xsdTypeOf returns the compact xsd type of a json schema property of type attrType
+func xsdTypeOf(attrType string, prop map[string]any) (string, error) {
+	switch attrType {
+	case "integer":
+		return "xsd:integer", nil
+	case "number":
+		return "xsd:double", nil
+	case "boolean":
+		return "xsd:boolean", nil
+	case "string":
+		switch format, _ := prop["format"].(string); format {
+		case "date-time":
+			return "xsd:dateTime", nil
+		case "date":
+			return "xsd:date", nil
+		default:
+			return "xsd:string", nil
+		}
+	default:
+		return "", fmt.Errorf("type <%s> not supported", attrType)
+	}
+}
+
+// CheckJSONLdContext checks that the JSON-LD context doc defines schemaType with a term for each credential subject
+// attribute of the json schema, and that every term can be merklized
+func (s *JSONSchema) CheckJSONLdContext(doc []byte, schemaType string) error {
+	props, err := s.credentialSubjectProperties()
+	if err != nil {
+		return err
+	}
+	terms, err := contextProperties(doc, schemaType)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for name := range props {
+		if name == subjectIDAttribute {
+			continue
+		}
+		if _, ok := terms[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("attributes %v are not defined in the JSON-LD context of %s", missing, schemaType)
+	}
+	return nil
+}
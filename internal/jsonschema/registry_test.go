@@ -0,0 +1,112 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const registrySchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$metadata": {"uris": {"jsonLdContext": "https://example.com/old.jsonld"}, "owner": "kyc team"},
+  "properties": {
+    "credentialSubject": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string", "format": "uri"},
+        "birthday": {"type": "integer"},
+        "height": {"type": "number"},
+        "verified": {"type": "boolean"},
+        "issued": {"type": "string", "format": "date-time"},
+        "nickname": {"type": "string"},
+        "address": {"type": "object", "properties": {"zip": {"type": "integer"}}}
+      }
+    }
+  }
+}`
+
+func TestJSONSchema_NewJSONLdContext(t *testing.T) {
+	schema, err := Parse([]byte(registrySchema))
+	require.NoError(t, err)
+
+	contextURL := "https://issuer.example.com/v1/schemas/1/context"
+	doc, err := schema.NewJSONLdContext("KYCAgeCredential", contextURL)
+	require.NoError(t, err)
+	assert.True(t, IsJSONLdContext(doc))
+
+	derived, err := FromJSONLdContext(doc, contextURL, "KYCAgeCredential")
+	require.NoError(t, err)
+	attrs, err := derived.Attributes()
+	require.NoError(t, err)
+	types := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		types[attr.ID] = attr.Type
+	}
+	assert.Equal(t, map[string]string{
+		"id":       "string",
+		"birthday": "integer",
+		"height":   "number",
+		"verified": "boolean",
+		"issued":   "string",
+		"nickname": "string",
+		"address":  "object",
+		"zip":      "integer",
+	}, types)
+}
+
+func TestJSONSchema_NewJSONLdContext_Arrays(t *testing.T) {
+	schema, err := Parse([]byte(`{"properties": {"credentialSubject": {"properties": {"tags": {"type": "array", "items": {"type": "string"}}}}}}`))
+	require.NoError(t, err)
+	_, err = schema.NewJSONLdContext("TagsCredential", "https://issuer.example.com/v1/schemas/1/context")
+	assert.ErrorContains(t, err, "attribute <tags>")
+}
+
+func TestJSONSchema_CheckJSONLdContext(t *testing.T) {
+	schema, err := Parse([]byte(registrySchema))
+	require.NoError(t, err)
+
+	err = schema.CheckJSONLdContext([]byte(kycAgeContext), "KYCAgeCredential")
+	assert.ErrorContains(t, err, "[address height]")
+
+	err = schema.CheckJSONLdContext([]byte(kycAgeContext), "KYCCountryOfResidenceCredential")
+	assert.ErrorIs(t, err, ErrTypeNotInContext)
+}
+
+func TestJSONSchema_WithMetadata(t *testing.T) {
+	schema, err := Parse([]byte(registrySchema))
+	require.NoError(t, err)
+
+	published, err := schema.WithMetadata("https://issuer.example.com/v1/schemas/1/jsonschema", "https://issuer.example.com/v1/schemas/1/context", "KYCAgeCredential", "1.2.0")
+	require.NoError(t, err)
+
+	jsonLdContext, err := published.JSONLdContext()
+	require.NoError(t, err)
+	assert.Equal(t, "https://issuer.example.com/v1/schemas/1/context", jsonLdContext)
+
+	var content map[string]any
+	require.NoError(t, json.Unmarshal(published.Raw(), &content))
+	assert.Equal(t, map[string]any{
+		"uris":    map[string]any{"jsonLdContext": "https://issuer.example.com/v1/schemas/1/context", "jsonSchema": "https://issuer.example.com/v1/schemas/1/jsonschema"},
+		"type":    "KYCAgeCredential",
+		"version": "1.2.0",
+		"owner":   "kyc team",
+	}, content["$metadata"])
+
+	original, err := schema.JSONLdContext()
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/old.jsonld", original)
+}
+
+func TestJSONSchema_WithMetadata_WithoutSchemaURL(t *testing.T) {
+	schema, err := Parse([]byte(registrySchema))
+	require.NoError(t, err)
+
+	published, err := schema.WithMetadata("", "ipfs://QmdH1Vu79p2NcZLFbHxzJnLuUHJiMZnBeT7SNpLaqK7k9X", "KYCAgeCredential", "1.0.0")
+	require.NoError(t, err)
+
+	var content map[string]any
+	require.NoError(t, json.Unmarshal(published.Raw(), &content))
+	assert.Equal(t, map[string]any{"jsonLdContext": "ipfs://QmdH1Vu79p2NcZLFbHxzJnLuUHJiMZnBeT7SNpLaqK7k9X"}, content["$metadata"].(map[string]any)["uris"])
+}
@@ -22,6 +22,28 @@ const (
 	fakeIssuerDID = "did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5"
 )
 
+var (
+	// documentLoader keeps the JSON-LD contexts used to validate credential subjects, so they are not fetched once per
+	// validation
+	documentLoader = loader.NewDocumentLoader(loader.HTTPFactory, 0)
+	// safeMode rejects the contexts whose terms would be dropped from the credentials
+	safeMode = true
+)
+
+// SetDocumentLoader sets the loader of the JSON-LD contexts of the merklization of the credentials and of the
+// credential subject validations, and whether they are validated in the JSON-LD safe mode. It must be called before
+// the first credential is issued or validated.
+func SetDocumentLoader(l *loader.DocumentLoader, safe bool) {
+	documentLoader, safeMode = l, safe
+	merklize.SetDocumentLoader(l)
+}
+
+// PurgeDocuments removes the JSON-LD documents of urls from the memory of the document loader, or every document when
+// there is none
+func PurgeDocuments(urls ...string) {
+	documentLoader.Purge(urls...)
+}
+
 // Attributes is a list of Attribute entities
 type Attributes []Attribute
 
@@ -53,6 +75,7 @@ func (a Attribute) String() string {
 // JSONSchema provides some methods to load a schema and do some inspections over it.
 type JSONSchema struct {
 	content map[string]any
+	raw     []byte
 }
 
 // Load loads the json file doing some validations..
@@ -67,28 +90,23 @@ func Load(ctx context.Context, loader loader.Loader) (*JSONSchema, error) {
 		return nil, err
 	}
 
-	schema := &JSONSchema{content: make(map[string]any)}
+	schema := &JSONSchema{content: make(map[string]any), raw: raw}
 	if err := json.Unmarshal(raw, &schema.content); err != nil {
 		return nil, err
 	}
 	return schema, nil
 }
 
+// Raw returns the json schema document as it was loaded
+func (s *JSONSchema) Raw() []byte {
+	return s.raw
+}
+
 // Attributes returns a list with the attributes in properties.credentialSubject.properties
 func (s *JSONSchema) Attributes() (Attributes, error) {
-	var props map[string]any
-	var ok bool
-	props, ok = s.content["properties"].(map[string]any)
-	if !ok {
-		return nil, errors.New("missing properties field")
-	}
-	credSubject, ok := props["credentialSubject"].(map[string]any)
-	if !ok {
-		return nil, errors.New("missing properties.credentialSubject field")
-	}
-	props, ok = credSubject["properties"].(map[string]any)
-	if !ok {
-		return nil, errors.New("missing properties.credentialSubject.properties field")
+	props, err := s.credentialSubjectProperties()
+	if err != nil {
+		return nil, err
 	}
 	attrs, err := processProperties(props)
 	if err != nil {
@@ -139,9 +157,10 @@ func (s *JSONSchema) SchemaHash(schemaType string) (core.SchemaHash, error) {
 	return utils.CreateSchemaHash([]byte(id)), nil
 }
 
-// ValidateCredentialSubject validates that the given credential subject matches the given schema
-func ValidateCredentialSubject(ctx context.Context, loader loader.Loader, schemaType string, cSubject map[string]interface{}) error {
-	schema, err := Load(ctx, loader)
+// ValidateCredentialSubject validates that the given credential subject matches the given schema, the json schema or
+// JSON-LD context published in schemaURL
+func ValidateCredentialSubject(ctx context.Context, loader loader.Loader, schemaURL string, schemaType string, cSubject map[string]interface{}) error {
+	schema, err := LoadForType(ctx, loader, schemaURL, schemaType)
 	if err != nil {
 		return err
 	}
@@ -156,7 +175,7 @@ func ValidateCredentialSubject(ctx context.Context, loader loader.Loader, schema
 		return err
 	}
 
-	return validateDummyVC(dummyVC)
+	return validateDummyVC(ctx, dummyVC)
 }
 
 func createDummyVC(cSubject map[string]interface{}, schemaType string, schemaContext string) (map[string]interface{}, error) {
@@ -192,11 +211,12 @@ func createDummyVC(cSubject map[string]interface{}, schemaType string, schemaCon
 	return resp, err
 }
 
-func validateDummyVC(vc map[string]interface{}) error {
+func validateDummyVC(ctx context.Context, vc map[string]interface{}) error {
 	proc := ld.NewJsonLdProcessor()
 	options := ld.NewJsonLdOptions("")
+	options.DocumentLoader = documentLoader.WithContext(ctx)
 	options.Algorithm = ld.AlgorithmURDNA2015
-	options.SafeMode = true
+	options.SafeMode = safeMode
 
 	normDoc, err := proc.Normalize(vc, options)
 	if err != nil {
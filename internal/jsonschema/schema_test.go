@@ -91,7 +91,7 @@ func TestValidateCredentialSubject(t *testing.T) {
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			err := ValidateCredentialSubject(ctx, schemaLoader(schemaURL), schemaType, tc.credentialSubject)
+			err := ValidateCredentialSubject(ctx, schemaLoader(schemaURL), schemaURL, schemaType, tc.credentialSubject)
 			if tc.expectedError {
 				assert.Error(t, err)
 			} else {
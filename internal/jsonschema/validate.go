@@ -0,0 +1,114 @@
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	qri "github.com/qri-io/jsonschema"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// ValidateSubject runs the complete json schema validation of the credential subject: required attributes,
+// additionalProperties, formats, constraints and $ref resolution, against the credentialSubject of the schema.
+// The subject id is the holder DID, unknown until a link is claimed, so it is only required with subjectIDRequired.
+// The error lists every attribute that doesn't match.
+func (s *JSONSchema) ValidateSubject(ctx context.Context, cSubject domain.CredentialSubject, subjectIDRequired bool) error {
+	raw, err := s.subjectSchema(subjectIDRequired)
+	if err != nil {
+		return err
+	}
+	validator := &qri.Schema{}
+	if err := json.Unmarshal(raw, validator); err != nil {
+		return fmt.Errorf("parsing the json schema: %w", err)
+	}
+	doc, err := json.Marshal(map[string]any{"credentialSubject": cSubject})
+	if err != nil {
+		return err
+	}
+	keyErrs, err := validator.ValidateBytes(ctx, doc)
+	if err != nil {
+		return fmt.Errorf("validating the credential subject: %w", err)
+	}
+	if len(keyErrs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(keyErrs))
+	for i, keyErr := range keyErrs {
+		msgs[i] = fmt.Sprintf("attribute <%s>: %s", attributePath(keyErr.PropertyPath), keyErr.Message)
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// subjectSchema returns a schema that only checks the credentialSubject property of the credentials. It keeps the
+// identifiers and definitions of the schema, so the local and relative $ref are resolved as in the original one.
+func (s *JSONSchema) subjectSchema(subjectIDRequired bool) ([]byte, error) {
+	props, ok := s.content["properties"].(map[string]any)
+	if !ok {
+		return nil, errors.New("missing properties field")
+	}
+	credSubject, ok := props["credentialSubject"].(map[string]any)
+	if !ok {
+		return nil, errors.New("missing properties.credentialSubject field")
+	}
+	if !subjectIDRequired {
+		credSubject = withoutRequired(credSubject, subjectIDAttribute)
+	}
+
+	content := map[string]any{
+		"type":       "object",
+		"required":   []any{"credentialSubject"},
+		"properties": map[string]any{"credentialSubject": credSubject},
+	}
+	for _, key := range []string{"$schema", "$id", "$defs", "definitions"} {
+		if value, ok := s.content[key]; ok {
+			content[key] = value
+		}
+	}
+	return json.Marshal(content)
+}
+
+// withoutRequired returns a copy of the schema without attr in its required list
+func withoutRequired(schema map[string]any, attr string) map[string]any {
+	required, ok := schema["required"].([]any)
+	if !ok {
+		return schema
+	}
+	copied := make(map[string]any, len(schema))
+	for k, v := range schema {
+		copied[k] = v
+	}
+	filtered := make([]any, 0, len(required))
+	for _, r := range required {
+		if r != attr {
+			filtered = append(filtered, r)
+		}
+	}
+	copied["required"] = filtered
+	return copied
+}
+
+// attributePath converts the json pointer of a validation error, like /credentialSubject/scores/1, into the attribute
+// path used in the other validation errors, like scores[1]
+func attributePath(pointer string) string {
+	path := strings.TrimPrefix(strings.TrimPrefix(pointer, "/credentialSubject"), "/")
+	if path == "" {
+		return "credentialSubject"
+	}
+	var b strings.Builder
+	for i, segment := range strings.Split(path, "/") {
+		if _, err := strconv.Atoi(segment); err == nil && i > 0 {
+			b.WriteString("[" + segment + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
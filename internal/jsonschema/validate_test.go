@@ -0,0 +1,113 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+const validateSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$metadata": {"uris": {"jsonLdContext": "https://example.com/kyc.jsonld"}},
+  "$defs": {
+    "address": {
+      "type": "object",
+      "required": ["zip"],
+      "additionalProperties": false,
+      "properties": {"zip": {"type": "integer"}, "city": {"type": "string"}}
+    }
+  },
+  "type": "object",
+  "required": ["credentialSubject"],
+  "properties": {
+    "credentialSubject": {
+      "type": "object",
+      "required": ["id", "name", "email"],
+      "additionalProperties": false,
+      "properties": {
+        "id": {"type": "string", "format": "uri"},
+        "name": {"type": "string"},
+        "email": {"type": "string", "format": "email"},
+        "address": {"$ref": "#/$defs/address"},
+        "scores": {"type": "array", "items": {"type": "integer", "minimum": 0}}
+      }
+    }
+  }
+}`
+
+func TestJSONSchema_ValidateSubject(t *testing.T) {
+	ctx := context.Background()
+	schema, err := Parse([]byte(validateSchema))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name              string
+		cSubject          domain.CredentialSubject
+		subjectIDRequired bool
+		expectedErr       []string
+	}{
+		{
+			name:              "valid",
+			cSubject:          domain.CredentialSubject{"id": "did:example:1", "name": "John", "email": "john@example.com", "address": map[string]any{"zip": 8001}},
+			subjectIDRequired: true,
+		},
+		{
+			name:     "subject id not required",
+			cSubject: domain.CredentialSubject{"name": "John", "email": "john@example.com"},
+		},
+		{
+			name:              "subject id required",
+			cSubject:          domain.CredentialSubject{"name": "John", "email": "john@example.com"},
+			subjectIDRequired: true,
+			expectedErr:       []string{"attribute <credentialSubject>", `"id" value is required`},
+		},
+		{
+			name:        "required attribute",
+			cSubject:    domain.CredentialSubject{"name": "John"},
+			expectedErr: []string{"attribute <credentialSubject>", `"email" value is required`},
+		},
+		{
+			name:        "additional properties",
+			cSubject:    domain.CredentialSubject{"name": "John", "email": "john@example.com", "nickname": "jd"},
+			expectedErr: []string{"nickname"},
+		},
+		{
+			name:        "format",
+			cSubject:    domain.CredentialSubject{"name": "John", "email": "not an email"},
+			expectedErr: []string{"attribute <email>"},
+		},
+		{
+			name:        "ref",
+			cSubject:    domain.CredentialSubject{"name": "John", "email": "john@example.com", "address": map[string]any{"city": "Barcelona"}},
+			expectedErr: []string{"attribute <address>", `"zip" value is required`},
+		},
+		{
+			name:        "array items",
+			cSubject:    domain.CredentialSubject{"name": "John", "email": "john@example.com", "scores": []any{1, -1}},
+			expectedErr: []string{"attribute <scores[1]>"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := schema.ValidateSubject(ctx, tc.cSubject, tc.subjectIDRequired)
+			if len(tc.expectedErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, expected := range tc.expectedErr {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
+
+func TestAttributePath(t *testing.T) {
+	assert.Equal(t, "credentialSubject", attributePath("/credentialSubject"))
+	assert.Equal(t, "address.zip", attributePath("/credentialSubject/address/zip"))
+	assert.Equal(t, "scores[1]", attributePath("/credentialSubject/scores/1"))
+	assert.Equal(t, "phones[0].number", attributePath("/credentialSubject/phones/0/number"))
+}
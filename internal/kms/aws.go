@@ -0,0 +1,97 @@
+package kms
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	awskms "github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+const (
+	awsDefaultPrefix  = "issuer"
+	awsDefaultTimeout = 10 * time.Second
+)
+
+// AWSConfig configures the key providers of AWS. The Ethereum keys are AWS KMS keys and never leave it. AWS KMS doesn't
+// support BabyJubJub, so those keys are kept in Secrets Manager, encrypted with its KMS key, and used in the issuer.
+type AWSConfig struct {
+	Region string
+	KeyID  string
+	Key    string
+	// Prefix is the first part of the names of the keys, so several nodes can share an account. "issuer" by default.
+	Prefix string
+	// Endpoint replaces the endpoints of AWS KMS and Secrets Manager, like http://localhost:4566 for localstack
+	Endpoint string
+}
+
+// awsClients are the AWS KMS and Secrets Manager clients of the key providers, and the prefix of the names of the keys
+type awsClients struct {
+	kms     *awskms.Client
+	secrets *secretsmanager.Client
+	prefix  string
+}
+
+func newAWSClients(cfg AWSConfig, timeout time.Duration, transport http.RoundTripper) (*awsClients, error) {
+	if cfg.Region == "" || cfg.KeyID == "" || cfg.Key == "" {
+		return nil, fmt.Errorf("the aws region, access key id and secret access key are required")
+	}
+	prefix := strings.Trim(cfg.Prefix, "/")
+	if prefix == "" {
+		prefix = awsDefaultPrefix
+	}
+	if timeout <= 0 {
+		timeout = awsDefaultTimeout
+	}
+
+	var endpoint *string
+	if cfg.Endpoint != "" {
+		endpoint = aws.String(strings.TrimRight(cfg.Endpoint, "/"))
+	}
+	httpClient := &http.Client{Timeout: timeout, Transport: transport}
+	credentialsProvider := aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(cfg.KeyID, cfg.Key, ""))
+	return &awsClients{
+		kms: awskms.New(awskms.Options{
+			Region:       cfg.Region,
+			Credentials:  credentialsProvider,
+			BaseEndpoint: endpoint,
+			HTTPClient:   httpClient,
+		}),
+		secrets: secretsmanager.New(secretsmanager.Options{
+			Region:       cfg.Region,
+			Credentials:  credentialsProvider,
+			BaseEndpoint: endpoint,
+			HTTPClient:   httpClient,
+		}),
+		prefix: prefix,
+	}, nil
+}
+
+// awsIdentityName is the part of the AWS names of the keys of an identity. The names of AWS KMS aliases and Secrets
+// Manager secrets can't have colons, so they are replaced with underscores.
+func awsIdentityName(identity string) string {
+	return strings.ReplaceAll(identity, ":", "_")
+}
+
+// OpenAWS returns a KMS with the keys in AWS: the Ethereum keys in AWS KMS and the BabyJubJub keys in Secrets Manager.
+// Each call to AWS is bounded by timeout, 10s when it is 0, and sent with transport, or with http.DefaultTransport when
+// it is nil.
+func OpenAWS(cfg AWSConfig, timeout time.Duration, transport http.RoundTripper) (*KMS, error) {
+	clients, err := newAWSClients(cfg, timeout, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	keyStore := NewKMS()
+	if err := keyStore.RegisterKeyProvider(KeyTypeBabyJubJub, newAWSSecretsBJJKeyProvider(clients)); err != nil {
+		return nil, fmt.Errorf("cannot register BabyJubJub key provider: %+v", err)
+	}
+	if err := keyStore.RegisterKeyProvider(KeyTypeEthereum, newAWSKMSEthKeyProvider(clients)); err != nil {
+		return nil, fmt.Errorf("cannot register Ethereum key provider: %+v", err)
+	}
+	return keyStore, nil
+}
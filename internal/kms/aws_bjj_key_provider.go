@@ -0,0 +1,152 @@
+package kms
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+)
+
+// awsSecretsBJJKeyProvider keeps the BabyJubJub keys as secrets of AWS Secrets Manager, named like
+// <prefix>/<identity>/BJJ-<public key> or <prefix>/BJJ-<public key> when they are unbound. The secret is the hex
+// encoded private key, and the signatures are made in the issuer.
+type awsSecretsBJJKeyProvider struct {
+	clients *awsClients
+	reName  *regexp.Regexp
+}
+
+func newAWSSecretsBJJKeyProvider(clients *awsClients) KeyProvider {
+	return &awsSecretsBJJKeyProvider{
+		clients: clients,
+		reName: regexp.MustCompile("^(?i)" + regexp.QuoteMeta(clients.prefix+"/") + "(?:[^/]+/)?" +
+			regexp.QuoteMeta(string(KeyTypeBabyJubJub)) + "-([a-f0-9]{64})$"),
+	}
+}
+
+func (p *awsSecretsBJJKeyProvider) New(ctx context.Context, identity *core.DID) (KeyID, error) {
+	privKey := babyjub.NewRandPrivKey()
+	keyID := KeyID{Type: KeyTypeBabyJubJub, ID: p.name(identity, privKey.Public().String())}
+	return keyID, p.create(ctx, keyID.ID, hex.EncodeToString(privKey[:]))
+}
+
+func (p *awsSecretsBJJKeyProvider) PublicKey(_ context.Context, keyID KeyID) ([]byte, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return nil, ErrIncorrectKeyType
+	}
+	ss := p.reName.FindStringSubmatch(keyID.ID)
+	if len(ss) != partsNumber {
+		return nil, errors.New("unable to get public key from key ID")
+	}
+	return hex.DecodeString(ss[1])
+}
+
+// Sign signs *big.Int using poseidon algorithm.
+// data should be a little-endian bytes representation of *big.Int.
+func (p *awsSecretsBJJKeyProvider) Sign(ctx context.Context, keyID KeyID, data []byte) ([]byte, error) {
+	i, err := bjjMessage(data)
+	if err != nil {
+		return nil, err
+	}
+	privKeyData, err := p.privateKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return signBJJ(privKeyData, i)
+}
+
+func (p *awsSecretsBJJKeyProvider) ListByIdentity(ctx context.Context, identity core.DID) ([]KeyID, error) {
+	prefix := p.name(&identity, "")
+	var keys []KeyID
+	pages := secretsmanager.NewListSecretsPaginator(p.clients.secrets, &secretsmanager.ListSecretsInput{
+		MaxResults: aws.Int32(100),
+		Filters:    []smtypes.Filter{{Key: smtypes.FilterNameStringTypeName, Values: []string{prefix}}},
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range page.SecretList {
+			// the name filter is a case insensitive prefix match
+			name := aws.ToString(secret.Name)
+			if strings.HasPrefix(name, prefix) && p.reName.MatchString(name) {
+				keys = append(keys, KeyID{Type: KeyTypeBabyJubJub, ID: name})
+			}
+		}
+	}
+	return keys, nil
+}
+
+// LinkToIdentity copies the secret of an unbound key to one of the identity and deletes the unbound one
+func (p *awsSecretsBJJKeyProvider) LinkToIdentity(ctx context.Context, keyID KeyID, identity core.DID) (KeyID, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return keyID, ErrIncorrectKeyType
+	}
+	pubKeyHex := strings.TrimPrefix(keyID.ID, p.name(nil, ""))
+	if pubKeyHex == keyID.ID || !p.reName.MatchString(keyID.ID) {
+		return keyID, errors.New("key ID does not looks like unbound")
+	}
+
+	privKeyData, err := p.privateKey(ctx, keyID)
+	if err != nil {
+		return keyID, err
+	}
+	newKeyID := KeyID{Type: KeyTypeBabyJubJub, ID: p.name(&identity, pubKeyHex)}
+	if err := p.create(ctx, newKeyID.ID, hex.EncodeToString(privKeyData)); err != nil {
+		return keyID, err
+	}
+	_, err = p.clients.secrets.DeleteSecret(ctx, &secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String(keyID.ID),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})
+	return newKeyID, err
+}
+
+// name is the name of the secret of the key of the identity, or of an unbound key when identity is nil
+func (p *awsSecretsBJJKeyProvider) name(identity *core.DID, pubKeyHex string) string {
+	if identity == nil {
+		return fmt.Sprintf("%s/%s-%s", p.clients.prefix, KeyTypeBabyJubJub, pubKeyHex)
+	}
+	return fmt.Sprintf("%s/%s/%s-%s", p.clients.prefix, awsIdentityName(identity.String()), KeyTypeBabyJubJub, pubKeyHex)
+}
+
+func (p *awsSecretsBJJKeyProvider) create(ctx context.Context, name string, privKeyHex string) error {
+	_, err := p.clients.secrets.CreateSecret(ctx, &secretsmanager.CreateSecretInput{
+		Name:               aws.String(name),
+		SecretString:       aws.String(privKeyHex),
+		Description:        aws.String("BabyJubJub key of the issuer node"),
+		ClientRequestToken: aws.String(uuid.NewString()),
+	})
+	return err
+}
+
+func (p *awsSecretsBJJKeyProvider) privateKey(ctx context.Context, keyID KeyID) ([]byte, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return nil, ErrIncorrectKeyType
+	}
+	if !p.reName.MatchString(keyID.ID) {
+		return nil, errors.New("incorrect key ID")
+	}
+
+	secret, err := p.clients.secrets.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(keyID.ID)})
+	if err != nil {
+		return nil, err
+	}
+	val, err := hex.DecodeString(aws.ToString(secret.SecretString))
+	if err != nil {
+		return nil, err
+	}
+	if len(val) != defaultLength {
+		return nil, errors.New("incorrect private key")
+	}
+	return val, nil
+}
@@ -0,0 +1,214 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awskms "github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// awsKMSEthKeyProvider keeps the Ethereum keys in AWS KMS, as secp256k1 signing keys. The key of an identity has an
+// alias like alias/<prefix>/<identity>/ETH-<key id>, which is its KeyID, and the unbound keys one like
+// alias/<prefix>/ETH-<key id>. Any other identifier of an AWS KMS key, like its id, ARN or another alias, can be used
+// as the KeyID of an existing key, like the publishing key.
+type awsKMSEthKeyProvider struct {
+	clients    *awsClients
+	publicKeys sync.Map // KeyID.ID -> uncompressed public key, as they never change
+}
+
+func newAWSKMSEthKeyProvider(clients *awsClients) KeyProvider {
+	return &awsKMSEthKeyProvider{clients: clients}
+}
+
+func (p *awsKMSEthKeyProvider) New(ctx context.Context, identity *core.DID) (KeyID, error) {
+	description := "Ethereum key of the issuer node"
+	if identity != nil {
+		description = "Ethereum key of " + identity.String()
+	}
+	created, err := p.clients.kms.CreateKey(ctx, &awskms.CreateKeyInput{
+		KeySpec:     kmstypes.KeySpecEccSecgP256k1,
+		KeyUsage:    kmstypes.KeyUsageTypeSignVerify,
+		Description: aws.String(description),
+	})
+	if err != nil {
+		return KeyID{}, err
+	}
+
+	keyID := KeyID{Type: KeyTypeEthereum, ID: p.alias(identity, aws.ToString(created.KeyMetadata.KeyId))}
+	_, err = p.clients.kms.CreateAlias(ctx, &awskms.CreateAliasInput{
+		AliasName:   aws.String(keyID.ID),
+		TargetKeyId: created.KeyMetadata.KeyId,
+	})
+	return keyID, err
+}
+
+func (p *awsKMSEthKeyProvider) PublicKey(ctx context.Context, keyID KeyID) ([]byte, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return nil, ErrIncorrectKeyType
+	}
+	pubKey, err := p.publicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaPubKey, err := crypto.UnmarshalPubkey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.CompressPubkey(ecdsaPubKey), nil
+}
+
+// Sign signs the 32 bytes hash with the key, and returns the signature in the [R || S || V] format of ethereum,
+// where V is 0 or 1
+func (p *awsKMSEthKeyProvider) Sign(ctx context.Context, keyID KeyID, data []byte) ([]byte, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return nil, ErrIncorrectKeyType
+	}
+	if len(data) != common.HashLength {
+		return nil, fmt.Errorf("data to sign should be %v bytes length", common.HashLength)
+	}
+	pubKey, err := p.publicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := p.clients.kms.Sign(ctx, &awskms.SignInput{
+		KeyId:            aws.String(keyID.ID),
+		Message:          data,
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ethSignature(data, signed.Signature, pubKey)
+}
+
+func (p *awsKMSEthKeyProvider) ListByIdentity(ctx context.Context, identity core.DID) ([]KeyID, error) {
+	prefix := p.alias(&identity, "")
+	var keys []KeyID
+	pages := awskms.NewListAliasesPaginator(p.clients.kms, &awskms.ListAliasesInput{Limit: aws.Int32(100)})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, alias := range page.Aliases {
+			name := aws.ToString(alias.AliasName)
+			if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+				keys = append(keys, KeyID{Type: KeyTypeEthereum, ID: name})
+			}
+		}
+	}
+	return keys, nil
+}
+
+// LinkToIdentity replaces the alias of an unbound key with one of the identity
+func (p *awsKMSEthKeyProvider) LinkToIdentity(ctx context.Context, keyID KeyID, identity core.DID) (KeyID, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return keyID, ErrIncorrectKeyType
+	}
+	unbound := p.alias(nil, "")
+	awsKeyID := strings.TrimPrefix(keyID.ID, unbound)
+	if awsKeyID == keyID.ID || awsKeyID == "" {
+		return keyID, errors.New("key ID does not looks like unbound")
+	}
+
+	newKeyID := KeyID{Type: KeyTypeEthereum, ID: p.alias(&identity, awsKeyID)}
+	_, err := p.clients.kms.CreateAlias(ctx, &awskms.CreateAliasInput{
+		AliasName:   aws.String(newKeyID.ID),
+		TargetKeyId: aws.String(awsKeyID),
+	})
+	if err != nil {
+		return keyID, err
+	}
+	if _, err := p.clients.kms.DeleteAlias(ctx, &awskms.DeleteAliasInput{AliasName: aws.String(keyID.ID)}); err != nil {
+		return keyID, err
+	}
+	p.publicKeys.Delete(keyID.ID)
+	return newKeyID, nil
+}
+
+// alias is the alias of the key of the identity, or of an unbound key when identity is nil
+func (p *awsKMSEthKeyProvider) alias(identity *core.DID, awsKeyID string) string {
+	if identity == nil {
+		return fmt.Sprintf("alias/%s/%s-%s", p.clients.prefix, KeyTypeEthereum, awsKeyID)
+	}
+	return fmt.Sprintf("alias/%s/%s/%s-%s", p.clients.prefix, awsIdentityName(identity.String()), KeyTypeEthereum, awsKeyID)
+}
+
+// publicKey returns the uncompressed public key
+func (p *awsKMSEthKeyProvider) publicKey(ctx context.Context, keyID KeyID) ([]byte, error) {
+	if pubKey, ok := p.publicKeys.Load(keyID.ID); ok {
+		return pubKey.([]byte), nil
+	}
+	answer, err := p.clients.kms.GetPublicKey(ctx, &awskms.GetPublicKeyInput{KeyId: aws.String(keyID.ID)})
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := secp256k1PublicKey(answer.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	p.publicKeys.Store(keyID.ID, pubKey)
+	return pubKey, nil
+}
+
+// secp256k1PublicKey returns the uncompressed public key of the DER encoded SubjectPublicKeyInfo of a secp256k1 key
+func secp256k1PublicKey(der []byte) ([]byte, error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(der, &spki); err != nil {
+		return nil, fmt.Errorf("unexpected format of the public key: %w", err)
+	}
+	if _, err := crypto.UnmarshalPubkey(spki.PublicKey.Bytes); err != nil {
+		return nil, fmt.Errorf("the key is not a secp256k1 key: %w", err)
+	}
+	return spki.PublicKey.Bytes, nil
+}
+
+// ethSignature converts the DER encoded ECDSA signature of hash made by AWS KMS or Cloud KMS to the [R || S || V]
+// format of ethereum
+func ethSignature(hash []byte, der []byte, pubKey []byte) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("unexpected format of the signature: %w", err)
+	}
+	return ethSignatureRS(hash, sig.R, sig.S, pubKey)
+}
+
+// ethSignatureRS returns the ECDSA signature (r, s) of hash in the [R || S || V] format of ethereum. Ethereum only
+// accepts the signatures with the lower S, and V is the one that recovers the public key.
+func ethSignatureRS(hash []byte, r *big.Int, s *big.Int, pubKey []byte) ([]byte, error) {
+	n := crypto.S256().Params().N
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s = new(big.Int).Sub(n, s)
+	}
+
+	ethSig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(ethSig[:32])
+	s.FillBytes(ethSig[32:64])
+	for v := byte(0); v < 2; v++ {
+		ethSig[64] = v
+		recovered, err := crypto.Ecrecover(hash, ethSig)
+		if err == nil && bytes.Equal(recovered, pubKey) {
+			return ethSig, nil
+		}
+	}
+	return nil, errors.New("the signature doesn't match the public key")
+}
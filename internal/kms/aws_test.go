@@ -0,0 +1,251 @@
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWS_EthereumKeys(t *testing.T) {
+	ctx := context.Background()
+	keyStore := openFakeAWS(t)
+	identity := awsTestIdentity(t)
+
+	unbound, err := keyStore.CreateKey(ctx, KeyTypeEthereum, nil)
+	require.NoError(t, err)
+	assert.Regexp(t, `^alias/test/ETH-[0-9a-f-]{36}$`, unbound.ID)
+
+	keyID, err := keyStore.LinkToIdentity(ctx, unbound, *identity)
+	require.NoError(t, err)
+	assert.Equal(t, "alias/test/did_polygonid_polygon_mumbai_2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5/ETH-"+strings.TrimPrefix(unbound.ID, "alias/test/ETH-"), keyID.ID)
+
+	keys, err := keyStore.KeysByIdentity(ctx, *identity)
+	require.NoError(t, err)
+	assert.Equal(t, []KeyID{keyID}, keys)
+
+	pubKey, err := keyStore.PublicKey(ctx, keyID)
+	require.NoError(t, err)
+	require.Len(t, pubKey, 33)
+	ecdsaPubKey, err := crypto.DecompressPubkey(pubKey)
+	require.NoError(t, err)
+
+	// the signatures of AWS KMS have a random S, that ethereum only accepts when it is the lower one
+	for i := 0; i < 10; i++ {
+		hash := crypto.Keccak256([]byte(uuid.NewString()))
+		sig, err := keyStore.Sign(ctx, keyID, hash)
+		require.NoError(t, err)
+		require.Len(t, sig, crypto.SignatureLength)
+		assert.True(t, new(big.Int).SetBytes(sig[32:64]).Cmp(new(big.Int).Rsh(crypto.S256().Params().N, 1)) <= 0)
+		recovered, err := crypto.SigToPub(hash, sig)
+		require.NoError(t, err)
+		assert.Equal(t, crypto.PubkeyToAddress(*ecdsaPubKey), crypto.PubkeyToAddress(*recovered))
+	}
+
+	_, err = keyStore.Sign(ctx, keyID, []byte("short"))
+	assert.Error(t, err)
+}
+
+func TestAWS_BabyJubJubKeys(t *testing.T) {
+	ctx := context.Background()
+	keyStore := openFakeAWS(t)
+	identity := awsTestIdentity(t)
+
+	unbound, err := keyStore.CreateKey(ctx, KeyTypeBabyJubJub, nil)
+	require.NoError(t, err)
+	assert.Regexp(t, `^test/BJJ-[0-9a-f]{64}$`, unbound.ID)
+
+	keyID, err := keyStore.LinkToIdentity(ctx, unbound, *identity)
+	require.NoError(t, err)
+	assert.Equal(t, "test/did_polygonid_polygon_mumbai_2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5/BJJ-"+strings.TrimPrefix(unbound.ID, "test/BJJ-"), keyID.ID)
+	_, err = keyStore.Sign(ctx, unbound, BJJDigest(big.NewInt(1)))
+	assert.Error(t, err, "the unbound secret is deleted")
+
+	other, err := keyStore.CreateKey(ctx, KeyTypeBabyJubJub, identity)
+	require.NoError(t, err)
+	keys, err := keyStore.KeysByIdentity(ctx, *identity)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []KeyID{keyID, other}, keys)
+
+	pubKeyBytes, err := keyStore.PublicKey(ctx, keyID)
+	require.NoError(t, err)
+	pubKey, err := DecodeBJJPubKey(pubKeyBytes)
+	require.NoError(t, err)
+
+	message := big.NewInt(123456)
+	sigBytes, err := keyStore.Sign(ctx, keyID, BJJDigest(message))
+	require.NoError(t, err)
+	sig, err := DecodeBJJSignature(sigBytes)
+	require.NoError(t, err)
+	assert.True(t, pubKey.VerifyPoseidon(message, sig))
+}
+
+func TestAWS_Errors(t *testing.T) {
+	_, err := OpenAWS(AWSConfig{Region: "eu-west-1"}, 0, nil)
+	assert.Error(t, err)
+
+	keyStore := openFakeAWS(t)
+	_, err = keyStore.PublicKey(context.Background(), KeyID{Type: KeyTypeEthereum, ID: "alias/missing"})
+	var notFound *kmstypes.NotFoundException
+	assert.ErrorAs(t, err, &notFound)
+}
+
+func awsTestIdentity(t *testing.T) *core.DID {
+	t.Helper()
+	did, err := core.ParseDID("did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5")
+	require.NoError(t, err)
+	return did
+}
+
+func openFakeAWS(t *testing.T) *KMS {
+	t.Helper()
+	server := httptest.NewServer(newFakeAWS())
+	t.Cleanup(server.Close)
+	keyStore, err := OpenAWS(AWSConfig{Region: "eu-west-1", KeyID: "AKIDEXAMPLE", Key: "secret", Prefix: "test", Endpoint: server.URL}, time.Second, nil)
+	require.NoError(t, err)
+	return keyStore
+}
+
+// fakeAWS answers the calls of the AWS SDK clients of the key providers to AWS KMS and Secrets Manager
+type fakeAWS struct {
+	mu      sync.Mutex
+	keys    map[string]*ecdsa.PrivateKey
+	aliases map[string]string
+	secrets map[string]string
+}
+
+func newFakeAWS() *fakeAWS {
+	return &fakeAWS{keys: map[string]*ecdsa.PrivateKey{}, aliases: map[string]string{}, secrets: map[string]string{}}
+}
+
+func (f *fakeAWS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	var in map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	str := func(name string) string { s, _ := in[name].(string); return s }
+	notFound := func() {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type": "com.amazonaws.kms#NotFoundException", "message": "not found"}`))
+	}
+	answer := func(out any) {
+		_ = json.NewEncoder(w).Encode(out)
+	}
+
+	switch r.Header.Get("X-Amz-Target") {
+	case "TrentService.CreateKey":
+		key, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		id := uuid.NewString()
+		f.keys[id] = key
+		answer(map[string]any{"KeyMetadata": map[string]any{"KeyId": id}})
+	case "TrentService.CreateAlias":
+		f.aliases[str("AliasName")] = str("TargetKeyId")
+		answer(map[string]any{})
+	case "TrentService.DeleteAlias":
+		delete(f.aliases, str("AliasName"))
+		answer(map[string]any{})
+	case "TrentService.ListAliases":
+		aliases := make([]map[string]any, 0)
+		for name := range f.aliases {
+			aliases = append(aliases, map[string]any{"AliasName": name})
+		}
+		answer(map[string]any{"Aliases": aliases, "Truncated": false})
+	case "TrentService.GetPublicKey":
+		key, ok := f.keys[f.aliases[str("KeyId")]]
+		if !ok {
+			notFound()
+			return
+		}
+		der, err := asn1.Marshal(struct {
+			Algorithm pkix.AlgorithmIdentifier
+			PublicKey asn1.BitString
+		}{
+			Algorithm: pkix.AlgorithmIdentifier{
+				Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+				Parameters: asn1.RawValue{FullBytes: mustMarshal(asn1.ObjectIdentifier{1, 3, 132, 0, 10})},
+			},
+			PublicKey: asn1.BitString{Bytes: crypto.FromECDSAPub(&key.PublicKey), BitLength: 65 * 8},
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		answer(map[string]any{"PublicKey": der})
+	case "TrentService.Sign":
+		key, ok := f.keys[f.aliases[str("KeyId")]]
+		if !ok {
+			notFound()
+			return
+		}
+		var message struct{ Message []byte }
+		raw, _ := json.Marshal(in)
+		_ = json.Unmarshal(raw, &message)
+		der, err := ecdsa.SignASN1(rand.Reader, key, message.Message)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		answer(map[string]any{"Signature": der})
+	case "secretsmanager.CreateSecret":
+		f.secrets[str("Name")] = str("SecretString")
+		answer(map[string]any{"Name": str("Name")})
+	case "secretsmanager.GetSecretValue":
+		secret, ok := f.secrets[str("SecretId")]
+		if !ok {
+			notFound()
+			return
+		}
+		answer(map[string]any{"SecretString": secret})
+	case "secretsmanager.DeleteSecret":
+		delete(f.secrets, str("SecretId"))
+		answer(map[string]any{})
+	case "secretsmanager.ListSecrets":
+		filters, _ := in["Filters"].([]any)
+		prefix := filters[0].(map[string]any)["Values"].([]any)[0].(string)
+		secrets := make([]map[string]any, 0)
+		for name := range f.secrets {
+			if strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix)) {
+				secrets = append(secrets, map[string]any{"Name": name})
+			}
+		}
+		answer(map[string]any{"SecretList": secrets})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func mustMarshal(v any) []byte {
+	b, err := asn1.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,156 @@
+package kms
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azkeys"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	core "github.com/iden3/go-iden3-core"
+)
+
+const (
+	azureDefaultPrefix  = "issuer"
+	azureDefaultTimeout = 10 * time.Second
+	// azureIdentityTag is the tag of the Key Vault keys and secrets with the identity they belong to
+	azureIdentityTag = "identity"
+)
+
+// the names of the Key Vault objects can only have letters, digits and dashes
+var azurePrefixRegex = regexp.MustCompile(`^[0-9a-zA-Z-]+$`)
+
+// AzureConfig configures the key providers of Azure Key Vault. The Ethereum keys are P-256K keys of the vault and never
+// leave it. Key Vault doesn't support BabyJubJub, so those keys are kept as secrets of the vault and used in the issuer.
+type AzureConfig struct {
+	// VaultURL is the url of the vault, like https://issuer.vault.azure.net
+	VaultURL string
+	// TenantID, ClientID and ClientSecret are the credentials of a service principal. When ClientSecret is empty, the
+	// managed identity of the machine the issuer runs in is used, the user assigned one with ClientID when it is set.
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	// Prefix is the first part of the key paths and of the names of the keys, so several nodes can share a vault.
+	// "issuer" by default.
+	Prefix string
+	// AuthorityHost replaces https://login.microsoftonline.com, for the sovereign clouds
+	AuthorityHost string
+}
+
+// azureClients are the Key Vault keys and secrets clients of the key providers, with the prefix of the names of the
+// objects. They get the access tokens of a service principal or a managed identity with azidentity.
+type azureClients struct {
+	keys    *azkeys.Client
+	secrets *azsecrets.Client
+	prefix  string
+}
+
+// newAzureClients returns the clients of the vault, that send their requests and the ones of the access tokens with the
+// transport of options
+func newAzureClients(cfg AzureConfig, options azcore.ClientOptions) (*azureClients, error) {
+	vaultURL, err := url.Parse(strings.TrimRight(cfg.VaultURL, "/"))
+	if err != nil || vaultURL.Scheme == "" || vaultURL.Host == "" {
+		return nil, fmt.Errorf("the azure vault url <%s> is not valid", cfg.VaultURL)
+	}
+	if cfg.ClientSecret != "" && (cfg.TenantID == "" || cfg.ClientID == "") {
+		return nil, fmt.Errorf("the azure tenant id and client id are required with a client secret")
+	}
+	prefix := strings.Trim(cfg.Prefix, "/-")
+	if prefix == "" {
+		prefix = azureDefaultPrefix
+	}
+	if !azurePrefixRegex.MatchString(prefix) {
+		return nil, fmt.Errorf("the azure prefix <%s> can only have letters, digits and dashes", cfg.Prefix)
+	}
+	if cfg.AuthorityHost != "" {
+		options.Cloud = cloud.AzurePublic
+		options.Cloud.ActiveDirectoryAuthorityHost = strings.TrimRight(cfg.AuthorityHost, "/") + "/"
+	}
+
+	var credential azcore.TokenCredential
+	if cfg.ClientSecret != "" {
+		credential, err = azidentity.NewClientSecretCredential(cfg.TenantID, cfg.ClientID, cfg.ClientSecret, &azidentity.ClientSecretCredentialOptions{ClientOptions: options})
+	} else {
+		managedIdentityOptions := &azidentity.ManagedIdentityCredentialOptions{ClientOptions: options}
+		if cfg.ClientID != "" {
+			managedIdentityOptions.ID = azidentity.ClientID(cfg.ClientID)
+		}
+		credential, err = azidentity.NewManagedIdentityCredential(managedIdentityOptions)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("azure credential: %w", err)
+	}
+
+	keysClient, err := azkeys.NewClient(vaultURL.String(), credential, &azkeys.ClientOptions{ClientOptions: options})
+	if err != nil {
+		return nil, fmt.Errorf("azure keys client: %w", err)
+	}
+	secretsClient, err := azsecrets.NewClient(vaultURL.String(), credential, &azsecrets.ClientOptions{ClientOptions: options})
+	if err != nil {
+		return nil, fmt.Errorf("azure secrets client: %w", err)
+	}
+	return &azureClients{keys: keysClient, secrets: secretsClient, prefix: prefix}, nil
+}
+
+// keyPath is the KeyID of a key with the layout of the vault key provider, <prefix>/<identity>/<type>:<name>, or
+// <prefix>/<type>:<name> when the key is unbound
+func (c *azureClients) keyPath(keyType KeyType, identity *core.DID, name string) string {
+	if identity == nil {
+		return fmt.Sprintf("%s/%s:%s", c.prefix, keyType, name)
+	}
+	return fmt.Sprintf("%s/%s/%s:%s", c.prefix, identity.String(), keyType, name)
+}
+
+// objectName returns the name of the Key Vault key or secret of a KeyID of the key path layout, <prefix>-<type>-<name>.
+// The identity is not part of the name, as the objects can't be renamed, and it is kept in a tag.
+func (c *azureClients) objectName(keyID KeyID) (string, bool) {
+	if !strings.HasPrefix(keyID.ID, c.prefix+"/") {
+		return "", false
+	}
+	keyType, name, ok := strings.Cut(keyID.ID[strings.LastIndex(keyID.ID, "/")+1:], ":")
+	if !ok || keyType != string(keyID.Type) {
+		return "", false
+	}
+	return fmt.Sprintf("%s-%s-%s", c.prefix, keyType, name), true
+}
+
+// keyPathOfObject is the KeyID of the Key Vault object of the name, with the identity of its tags
+func (c *azureClients) keyPathOfObject(keyType KeyType, identity *core.DID, objectName string) (string, bool) {
+	name := strings.TrimPrefix(objectName, fmt.Sprintf("%s-%s-", c.prefix, keyType))
+	if name == objectName || name == "" {
+		return "", false
+	}
+	return c.keyPath(keyType, identity, name), true
+}
+
+// OpenAzure returns a KMS with the keys in Azure Key Vault: the Ethereum keys as keys of the vault and the BabyJubJub
+// keys as its secrets. Each call to Azure is bounded by timeout, 10s when it is 0, and sent with transport, or with
+// http.DefaultTransport when it is nil.
+func OpenAzure(cfg AzureConfig, timeout time.Duration, transport http.RoundTripper) (*KMS, error) {
+	if timeout <= 0 {
+		timeout = azureDefaultTimeout
+	}
+	return openAzure(cfg, azcore.ClientOptions{Transport: &http.Client{Timeout: timeout, Transport: transport}})
+}
+
+func openAzure(cfg AzureConfig, options azcore.ClientOptions) (*KMS, error) {
+	clients, err := newAzureClients(cfg, options)
+	if err != nil {
+		return nil, err
+	}
+
+	keyStore := NewKMS()
+	if err := keyStore.RegisterKeyProvider(KeyTypeBabyJubJub, newAzureSecretsBJJKeyProvider(clients)); err != nil {
+		return nil, fmt.Errorf("cannot register BabyJubJub key provider: %+v", err)
+	}
+	if err := keyStore.RegisterKeyProvider(KeyTypeEthereum, newAzureKeysEthKeyProvider(clients)); err != nil {
+		return nil, fmt.Errorf("cannot register Ethereum key provider: %+v", err)
+	}
+	return keyStore, nil
+}
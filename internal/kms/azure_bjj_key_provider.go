@@ -0,0 +1,138 @@
+package kms
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+)
+
+// azureSecretsBJJKeyProvider keeps the BabyJubJub keys as secrets of Azure Key Vault, named <prefix>-BJJ-<public key>
+// and tagged with their identity. Their KeyID is <prefix>/<identity>/BJJ:<public key>, like the ones of vault. The
+// secret is the hex encoded private key, and the signatures are made in the issuer.
+type azureSecretsBJJKeyProvider struct {
+	clients *azureClients
+}
+
+func newAzureSecretsBJJKeyProvider(clients *azureClients) KeyProvider {
+	return &azureSecretsBJJKeyProvider{clients: clients}
+}
+
+func (p *azureSecretsBJJKeyProvider) New(ctx context.Context, identity *core.DID) (KeyID, error) {
+	privKey := babyjub.NewRandPrivKey()
+	keyID := KeyID{Type: KeyTypeBabyJubJub, ID: p.clients.keyPath(KeyTypeBabyJubJub, identity, privKey.Public().String())}
+	name, _ := p.clients.objectName(keyID)
+	tags := map[string]*string{}
+	if identity != nil {
+		tags[azureIdentityTag] = to.Ptr(identity.String())
+	}
+	_, err := p.clients.secrets.SetSecret(ctx, name, azsecrets.SetSecretParameters{
+		Value:       to.Ptr(hex.EncodeToString(privKey[:])),
+		ContentType: to.Ptr("BabyJubJub private key"),
+		Tags:        tags,
+	}, nil)
+	return keyID, err
+}
+
+func (p *azureSecretsBJJKeyProvider) PublicKey(_ context.Context, keyID KeyID) ([]byte, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return nil, ErrIncorrectKeyType
+	}
+	if _, ok := p.clients.objectName(keyID); !ok {
+		return nil, errors.New("unable to get public key from key ID")
+	}
+	pubKey, err := hex.DecodeString(keyID.ID[strings.LastIndex(keyID.ID, ":")+1:])
+	if err != nil || len(pubKey) != defaultLength {
+		return nil, errors.New("unable to get public key from key ID")
+	}
+	return pubKey, nil
+}
+
+// Sign signs *big.Int using poseidon algorithm.
+// data should be a little-endian bytes representation of *big.Int.
+func (p *azureSecretsBJJKeyProvider) Sign(ctx context.Context, keyID KeyID, data []byte) ([]byte, error) {
+	i, err := bjjMessage(data)
+	if err != nil {
+		return nil, err
+	}
+	privKeyData, _, err := p.privateKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return signBJJ(privKeyData, i)
+}
+
+func (p *azureSecretsBJJKeyProvider) ListByIdentity(ctx context.Context, identity core.DID) ([]KeyID, error) {
+	var keys []KeyID
+	pager := p.clients.secrets.NewListSecretPropertiesPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range page.Value {
+			if secret.ID == nil || secret.Tags[azureIdentityTag] == nil || *secret.Tags[azureIdentityTag] != identity.String() {
+				continue
+			}
+			if path, ok := p.clients.keyPathOfObject(KeyTypeBabyJubJub, &identity, secret.ID.Name()); ok {
+				keys = append(keys, KeyID{Type: KeyTypeBabyJubJub, ID: path})
+			}
+		}
+	}
+	return keys, nil
+}
+
+// LinkToIdentity tags the secret of an unbound key with the identity, and returns the KeyID with the identity
+func (p *azureSecretsBJJKeyProvider) LinkToIdentity(ctx context.Context, keyID KeyID, identity core.DID) (KeyID, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return keyID, ErrIncorrectKeyType
+	}
+	pubKeyHex := keyID.ID[strings.LastIndex(keyID.ID, ":")+1:]
+	if keyID.ID != p.clients.keyPath(KeyTypeBabyJubJub, nil, pubKeyHex) {
+		return keyID, errors.New("key ID does not looks like unbound")
+	}
+
+	_, secretID, err := p.privateKey(ctx, keyID)
+	if err != nil {
+		return keyID, err
+	}
+	_, err = p.clients.secrets.UpdateSecretProperties(ctx, secretID.Name(), secretID.Version(), azsecrets.UpdateSecretPropertiesParameters{
+		Tags: map[string]*string{azureIdentityTag: to.Ptr(identity.String())},
+	}, nil)
+	if err != nil {
+		return keyID, err
+	}
+	return KeyID{Type: KeyTypeBabyJubJub, ID: p.clients.keyPath(KeyTypeBabyJubJub, &identity, pubKeyHex)}, nil
+}
+
+// privateKey returns the private key of the secret and the id of its version
+func (p *azureSecretsBJJKeyProvider) privateKey(ctx context.Context, keyID KeyID) ([]byte, azsecrets.ID, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return nil, "", ErrIncorrectKeyType
+	}
+	name, ok := p.clients.objectName(keyID)
+	if !ok {
+		return nil, "", errors.New("incorrect key ID")
+	}
+
+	secret, err := p.clients.secrets.GetSecret(ctx, name, "", nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if secret.Value == nil || secret.ID == nil {
+		return nil, "", errors.New("incorrect private key")
+	}
+	val, err := hex.DecodeString(*secret.Value)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(val) != defaultLength {
+		return nil, "", errors.New("incorrect private key")
+	}
+	return val, *secret.ID, nil
+}
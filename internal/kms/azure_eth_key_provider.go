@@ -0,0 +1,180 @@
+package kms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azkeys"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// azureKeysEthKeyProvider keeps the Ethereum keys as P-256K keys of Azure Key Vault, named <prefix>-ETH-<uuid> and
+// tagged with their identity. Key Vault makes the public key after the name, so their KeyID is
+// <prefix>/<identity>/ETH:<uuid>, with the layout of the ones of vault. Any other key of the vault can be used by its
+// name as the KeyID of an existing key, like the publishing key.
+type azureKeysEthKeyProvider struct {
+	clients *azureClients
+	keys    sync.Map // KeyID.ID -> azureEthKey, as the key versions never change
+}
+
+// azureEthKey is the version of a Key Vault key that signs and its uncompressed public key
+type azureEthKey struct {
+	name    string
+	version string
+	pubKey  []byte
+}
+
+func newAzureKeysEthKeyProvider(clients *azureClients) KeyProvider {
+	return &azureKeysEthKeyProvider{clients: clients}
+}
+
+func (p *azureKeysEthKeyProvider) New(ctx context.Context, identity *core.DID) (KeyID, error) {
+	keyID := KeyID{Type: KeyTypeEthereum, ID: p.clients.keyPath(KeyTypeEthereum, identity, uuid.NewString())}
+	name, _ := p.clients.objectName(keyID)
+	tags := map[string]*string{}
+	if identity != nil {
+		tags[azureIdentityTag] = to.Ptr(identity.String())
+	}
+	_, err := p.clients.keys.CreateKey(ctx, name, azkeys.CreateKeyParameters{
+		Kty:    to.Ptr(azkeys.KeyTypeEC),
+		Curve:  to.Ptr(azkeys.CurveNameP256K),
+		KeyOps: []*azkeys.KeyOperation{to.Ptr(azkeys.KeyOperationSign), to.Ptr(azkeys.KeyOperationVerify)},
+		Tags:   tags,
+	}, nil)
+	if err != nil {
+		return KeyID{}, err
+	}
+	return keyID, nil
+}
+
+func (p *azureKeysEthKeyProvider) PublicKey(ctx context.Context, keyID KeyID) ([]byte, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return nil, ErrIncorrectKeyType
+	}
+	key, err := p.key(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaPubKey, err := crypto.UnmarshalPubkey(key.pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.CompressPubkey(ecdsaPubKey), nil
+}
+
+// Sign signs the 32 bytes hash with the key, and returns the signature in the [R || S || V] format of ethereum,
+// where V is 0 or 1
+func (p *azureKeysEthKeyProvider) Sign(ctx context.Context, keyID KeyID, data []byte) ([]byte, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return nil, ErrIncorrectKeyType
+	}
+	if len(data) != common.HashLength {
+		return nil, fmt.Errorf("data to sign should be %v bytes length", common.HashLength)
+	}
+	key, err := p.key(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := p.clients.keys.Sign(ctx, key.name, key.version, azkeys.SignParameters{
+		Algorithm: to.Ptr(azkeys.SignatureAlgorithmES256K),
+		Value:     data,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Key Vault signs in the JWS format, R || S
+	sig := signed.Result
+	if len(sig) != 64 {
+		return nil, errors.New("unexpected format of the signature")
+	}
+	return ethSignatureRS(data, new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:]), key.pubKey)
+}
+
+func (p *azureKeysEthKeyProvider) ListByIdentity(ctx context.Context, identity core.DID) ([]KeyID, error) {
+	var keys []KeyID
+	pager := p.clients.keys.NewListKeyPropertiesPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Value {
+			if item.KID == nil || item.Tags[azureIdentityTag] == nil || *item.Tags[azureIdentityTag] != identity.String() {
+				continue
+			}
+			if path, ok := p.clients.keyPathOfObject(KeyTypeEthereum, &identity, item.KID.Name()); ok {
+				keys = append(keys, KeyID{Type: KeyTypeEthereum, ID: path})
+			}
+		}
+	}
+	return keys, nil
+}
+
+// LinkToIdentity tags an unbound key with the identity, and returns the KeyID with the identity
+func (p *azureKeysEthKeyProvider) LinkToIdentity(ctx context.Context, keyID KeyID, identity core.DID) (KeyID, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return keyID, ErrIncorrectKeyType
+	}
+	name := keyID.ID[strings.LastIndex(keyID.ID, ":")+1:]
+	if keyID.ID != p.clients.keyPath(KeyTypeEthereum, nil, name) {
+		return keyID, errors.New("key ID does not looks like unbound")
+	}
+
+	key, err := p.key(ctx, keyID)
+	if err != nil {
+		return keyID, err
+	}
+	_, err = p.clients.keys.UpdateKey(ctx, key.name, key.version, azkeys.UpdateKeyParameters{
+		Tags: map[string]*string{azureIdentityTag: to.Ptr(identity.String())},
+	}, nil)
+	if err != nil {
+		return keyID, err
+	}
+	p.keys.Delete(keyID.ID)
+	return KeyID{Type: KeyTypeEthereum, ID: p.clients.keyPath(KeyTypeEthereum, &identity, name)}, nil
+}
+
+// key returns the current version of the key and its public key
+func (p *azureKeysEthKeyProvider) key(ctx context.Context, keyID KeyID) (azureEthKey, error) {
+	if key, ok := p.keys.Load(keyID.ID); ok {
+		return key.(azureEthKey), nil
+	}
+	name, ok := p.clients.objectName(keyID)
+	if !ok {
+		name = keyID.ID
+	}
+	answer, err := p.clients.keys.GetKey(ctx, name, "", nil)
+	if err != nil {
+		return azureEthKey{}, err
+	}
+	jwk := answer.Key
+	if jwk == nil || jwk.KID == nil {
+		return azureEthKey{}, errors.New("unexpected format of the public key")
+	}
+	if jwk.Crv == nil || *jwk.Crv != azkeys.CurveNameP256K {
+		return azureEthKey{}, errors.New("the key is not a secp256k1 key")
+	}
+	x, y := jwk.X, jwk.Y
+	if len(x) > 32 || len(y) > 32 {
+		return azureEthKey{}, errors.New("unexpected format of the public key")
+	}
+	pubKey := make([]byte, 65)
+	pubKey[0] = 4
+	copy(pubKey[33-len(x):33], x)
+	copy(pubKey[65-len(y):], y)
+	if _, err := crypto.UnmarshalPubkey(pubKey); err != nil {
+		return azureEthKey{}, fmt.Errorf("the key is not a secp256k1 key: %w", err)
+	}
+	key := azureEthKey{name: jwk.KID.Name(), version: jwk.KID.Version(), pubKey: pubKey}
+	p.keys.Store(keyID.ID, key)
+	return key, nil
+}
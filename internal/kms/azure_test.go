@@ -0,0 +1,298 @@
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAzure_EthereumKeys(t *testing.T) {
+	ctx := context.Background()
+	keyStore, fake := openFakeAzure(t)
+	identity := awsTestIdentity(t)
+
+	unbound, err := keyStore.CreateKey(ctx, KeyTypeEthereum, nil)
+	require.NoError(t, err)
+	assert.Regexp(t, `^test/ETH:[0-9a-f-]{36}$`, unbound.ID)
+
+	keyID, err := keyStore.LinkToIdentity(ctx, unbound, *identity)
+	require.NoError(t, err)
+	assert.Equal(t, "test/did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5/ETH:"+strings.TrimPrefix(unbound.ID, "test/ETH:"), keyID.ID)
+	_, err = keyStore.CreateKey(ctx, KeyTypeEthereum, nil)
+	require.NoError(t, err)
+
+	keys, err := keyStore.KeysByIdentity(ctx, *identity)
+	require.NoError(t, err)
+	assert.Equal(t, []KeyID{keyID}, keys)
+
+	pubKey, err := keyStore.PublicKey(ctx, keyID)
+	require.NoError(t, err)
+	require.Len(t, pubKey, 33)
+	ecdsaPubKey, err := crypto.DecompressPubkey(pubKey)
+	require.NoError(t, err)
+
+	// the signatures of Key Vault have a random S, that ethereum only accepts when it is the lower one
+	for i := 0; i < 10; i++ {
+		hash := crypto.Keccak256([]byte(uuid.NewString()))
+		sig, err := keyStore.Sign(ctx, keyID, hash)
+		require.NoError(t, err)
+		require.Len(t, sig, crypto.SignatureLength)
+		assert.True(t, new(big.Int).SetBytes(sig[32:64]).Cmp(new(big.Int).Rsh(crypto.S256().Params().N, 1)) <= 0)
+		recovered, err := crypto.SigToPub(hash, sig)
+		require.NoError(t, err)
+		assert.Equal(t, crypto.PubkeyToAddress(*ecdsaPubKey), crypto.PubkeyToAddress(*recovered))
+	}
+
+	// an existing key is used by its name
+	fake.addKey("publisher")
+	_, err = keyStore.PublicKey(ctx, KeyID{Type: KeyTypeEthereum, ID: "publisher"})
+	require.NoError(t, err)
+}
+
+func TestAzure_BabyJubJubKeys(t *testing.T) {
+	ctx := context.Background()
+	keyStore, _ := openFakeAzure(t)
+	identity := awsTestIdentity(t)
+
+	unbound, err := keyStore.CreateKey(ctx, KeyTypeBabyJubJub, nil)
+	require.NoError(t, err)
+	assert.Regexp(t, `^test/BJJ:[0-9a-f]{64}$`, unbound.ID)
+
+	keyID, err := keyStore.LinkToIdentity(ctx, unbound, *identity)
+	require.NoError(t, err)
+	assert.Equal(t, "test/did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5/BJJ:"+strings.TrimPrefix(unbound.ID, "test/BJJ:"), keyID.ID)
+	_, err = keyStore.LinkToIdentity(ctx, keyID, *identity)
+	assert.Error(t, err, "the key is already bound")
+
+	other, err := keyStore.CreateKey(ctx, KeyTypeBabyJubJub, identity)
+	require.NoError(t, err)
+	_, err = keyStore.CreateKey(ctx, KeyTypeBabyJubJub, nil)
+	require.NoError(t, err)
+	keys, err := keyStore.KeysByIdentity(ctx, *identity)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []KeyID{keyID, other}, keys)
+
+	pubKeyBytes, err := keyStore.PublicKey(ctx, keyID)
+	require.NoError(t, err)
+	pubKey, err := DecodeBJJPubKey(pubKeyBytes)
+	require.NoError(t, err)
+
+	message := big.NewInt(123456)
+	sigBytes, err := keyStore.Sign(ctx, keyID, BJJDigest(message))
+	require.NoError(t, err)
+	sig, err := DecodeBJJSignature(sigBytes)
+	require.NoError(t, err)
+	assert.True(t, pubKey.VerifyPoseidon(message, sig))
+}
+
+func TestAzure_Errors(t *testing.T) {
+	_, err := OpenAzure(AzureConfig{VaultURL: "issuer.vault.azure.net"}, 0, nil)
+	assert.Error(t, err)
+	_, err = OpenAzure(AzureConfig{VaultURL: "https://issuer.vault.azure.net", Prefix: "issuer_node"}, 0, nil)
+	assert.Error(t, err)
+
+	keyStore, _ := openFakeAzure(t)
+	_, err = keyStore.PublicKey(context.Background(), KeyID{Type: KeyTypeEthereum, ID: "missing"})
+	var azureErr *azcore.ResponseError
+	require.ErrorAs(t, err, &azureErr)
+	assert.Equal(t, "KeyNotFound", azureErr.ErrorCode)
+}
+
+func openFakeAzure(t *testing.T) (*KMS, *fakeAzure) {
+	t.Helper()
+	fake := newFakeAzure()
+	server := httptest.NewTLSServer(fake)
+	t.Cleanup(server.Close)
+	keyStore, err := openAzure(AzureConfig{VaultURL: "https://issuer.vault.azure.net", TenantID: "tenant", ClientID: "client", ClientSecret: "secret", Prefix: "test"}, azcore.ClientOptions{Transport: fakeAzureTransport{server: server}})
+	require.NoError(t, err)
+	return keyStore, fake
+}
+
+// fakeAzureTransport sends the requests to the vault and to the login endpoint to the fake server, with their host
+type fakeAzureTransport struct {
+	server *httptest.Server
+}
+
+func (t fakeAzureTransport) Do(req *http.Request) (*http.Response, error) {
+	target, err := url.Parse(t.server.URL)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+	return t.server.Client().Do(req)
+}
+
+// fakeAzure answers the calls of the key providers to Azure Key Vault and the ones of azidentity to the login endpoint.
+// It lists one object per page.
+type fakeAzure struct {
+	mu      sync.Mutex
+	keys    map[string]*ecdsa.PrivateKey
+	secrets map[string]string
+	tags    map[string]map[string]string
+}
+
+func newFakeAzure() *fakeAzure {
+	return &fakeAzure{keys: map[string]*ecdsa.PrivateKey{}, secrets: map[string]string{}, tags: map[string]map[string]string{}}
+}
+
+func (f *fakeAzure) addKey(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	f.keys[name] = key
+}
+
+func (f *fakeAzure) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	answer := func(out any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	}
+	if r.Host == "login.microsoftonline.com" {
+		switch r.URL.Path {
+		case "/tenant/v2.0/.well-known/openid-configuration":
+			answer(map[string]any{
+				"authorization_endpoint": "https://login.microsoftonline.com/tenant/oauth2/v2.0/authorize",
+				"token_endpoint":         "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+				"issuer":                 "https://login.microsoftonline.com/tenant/v2.0",
+			})
+		case "/tenant/oauth2/v2.0/token":
+			_ = r.ParseForm()
+			scopes := strings.Fields(r.Form.Get("scope"))
+			if r.Form.Get("client_secret") != "secret" || len(scopes) == 0 || scopes[0] != "https://vault.azure.net/.default" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			answer(map[string]any{"token_type": "Bearer", "access_token": "token", "expires_in": 3599})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer token" || r.URL.Query().Get("api-version") == "" {
+		// the clients ask for a token of the tenant and the resource of the challenge
+		w.Header().Set("WWW-Authenticate", `Bearer authorization="https://login.microsoftonline.com/tenant", resource="https://vault.azure.net"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var in struct {
+		Value string            `json:"value"`
+		Tags  map[string]string `json:"tags"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&in)
+	base := "https://" + r.Host
+	notFound := func(code string) {
+		w.WriteHeader(http.StatusNotFound)
+		answer(map[string]any{"error": map[string]any{"code": code, "message": "not found"}})
+	}
+	jwk := func(name string) map[string]any {
+		key := f.keys[name]
+		return map[string]any{
+			"kid": base + "/keys/" + name + "/v1",
+			"kty": "EC",
+			"crv": "P-256K",
+			"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+			"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+		}
+	}
+	list := func(collection string, names []string, idField string) {
+		sort.Strings(names)
+		skip, _ := strconv.Atoi(r.URL.Query().Get("$skiptoken"))
+		page := map[string]any{"value": []map[string]any{}}
+		if skip < len(names) {
+			page["value"] = []map[string]any{{idField: base + "/" + collection + "/" + names[skip], "tags": f.tags[collection+"/"+names[skip]]}}
+		}
+		if skip+1 < len(names) {
+			page["nextLink"] = base + "/" + collection + "?api-version=" + r.URL.Query().Get("api-version") + "&$skiptoken=" + strconv.Itoa(skip+1)
+		}
+		answer(page)
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodPost && len(parts) == 3 && parts[0] == "keys" && parts[2] == "create":
+		key, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		f.keys[parts[1]] = key
+		f.tags["keys/"+parts[1]] = in.Tags
+		answer(map[string]any{"key": jwk(parts[1])})
+	case r.Method == http.MethodGet && len(parts) == 2 && parts[0] == "keys":
+		if _, ok := f.keys[parts[1]]; !ok {
+			notFound("KeyNotFound")
+			return
+		}
+		answer(map[string]any{"key": jwk(parts[1])})
+	case r.Method == http.MethodGet && len(parts) == 1 && parts[0] == "keys":
+		names := make([]string, 0, len(f.keys))
+		for name := range f.keys {
+			names = append(names, name)
+		}
+		list("keys", names, "kid")
+	case r.Method == http.MethodPatch && len(parts) == 3 && parts[0] == "keys":
+		f.tags["keys/"+parts[1]] = in.Tags
+		answer(map[string]any{"key": jwk(parts[1])})
+	case r.Method == http.MethodPost && len(parts) == 4 && parts[0] == "keys" && parts[3] == "sign":
+		key, ok := f.keys[parts[1]]
+		if !ok {
+			notFound("KeyNotFound")
+			return
+		}
+		digest, err := base64.RawURLEncoding.DecodeString(in.Value)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sigR, sigS, err := ecdsa.Sign(rand.Reader, key, digest)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		sig := append(sigR.FillBytes(make([]byte, 32)), sigS.FillBytes(make([]byte, 32))...)
+		answer(map[string]any{"kid": base + "/keys/" + parts[1] + "/v1", "value": base64.RawURLEncoding.EncodeToString(sig)})
+	case r.Method == http.MethodPut && len(parts) == 2 && parts[0] == "secrets":
+		f.secrets[parts[1]] = in.Value
+		f.tags["secrets/"+parts[1]] = in.Tags
+		answer(map[string]any{"id": base + "/secrets/" + parts[1] + "/v1"})
+	case r.Method == http.MethodGet && len(parts) == 2 && parts[0] == "secrets":
+		value, ok := f.secrets[parts[1]]
+		if !ok {
+			notFound("SecretNotFound")
+			return
+		}
+		answer(map[string]any{"id": base + "/secrets/" + parts[1] + "/v1", "value": value, "tags": f.tags["secrets/"+parts[1]]})
+	case r.Method == http.MethodGet && len(parts) == 1 && parts[0] == "secrets":
+		names := make([]string, 0, len(f.secrets))
+		for name := range f.secrets {
+			names = append(names, name)
+		}
+		list("secrets", names, "id")
+	case r.Method == http.MethodPatch && len(parts) == 3 && parts[0] == "secrets":
+		f.tags["secrets/"+parts[1]] = in.Tags
+		answer(map[string]any{"id": base + "/secrets/" + parts[1] + "/v1"})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
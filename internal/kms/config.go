@@ -0,0 +1,72 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/providers"
+	client "github.com/polygonid/sh-id-platform/pkg/http"
+)
+
+// NewFromConfig opens the key store of the backend of the configuration: vault with the iden3 plugin by default, AWS,
+// Google Cloud, Azure or a local file. The calls to the key store are bounded by the key store timeout. Vault is
+// pinged with ctx, so the servers can retry the whole call until it is up.
+func NewFromConfig(ctx context.Context, cfg *config.Configuration) (*KMS, error) {
+	switch cfg.KeyStore.Backend {
+	case config.KeyStoreAWS, config.KeyStoreGCP, config.KeyStoreAzure:
+		return openCloud(cfg)
+	case config.KeyStoreFile:
+		keyStore, err := OpenFile(FileConfig(cfg.KeyStore.File))
+		if err != nil {
+			return nil, err
+		}
+		log.Warn(ctx, "the keys are kept in a local file, the file key store is only meant for development", "path", cfg.KeyStore.File.Path)
+		return keyStore, nil
+	}
+
+	vaultCli, err := providers.NewVaultClient(cfg.KeyStore.Address, cfg.KeyStore.Token)
+	if err != nil {
+		return nil, fmt.Errorf("cannot init vault client: %w", err)
+	}
+	vaultCli.SetClientTimeout(cfg.Timeouts.KeyStore)
+	if err := providers.PingVault(ctx, vaultCli); err != nil {
+		return nil, fmt.Errorf("cannot connect to vault: %w", err)
+	}
+	return Open(cfg.KeyStore.PluginIden3MountPath, vaultCli)
+}
+
+// VaultAuthCheck returns a check of the vault token of the configuration that fails when it expires within minTTL, or
+// nil when the keys are not in vault
+func VaultAuthCheck(cfg *config.Configuration, minTTL time.Duration) (func(ctx context.Context) error, error) {
+	switch cfg.KeyStore.Backend {
+	case config.KeyStoreAWS, config.KeyStoreGCP, config.KeyStoreAzure, config.KeyStoreFile:
+		return nil, nil
+	}
+	vaultCli, err := providers.NewVaultClient(cfg.KeyStore.Address, cfg.KeyStore.Token)
+	if err != nil {
+		return nil, fmt.Errorf("cannot init vault client: %w", err)
+	}
+	vaultCli.SetClientTimeout(cfg.Timeouts.KeyStore)
+	return func(ctx context.Context) error {
+		return providers.CheckVaultAuth(ctx, vaultCli, minTTL)
+	}, nil
+}
+
+// openCloud opens the AWS, Google Cloud or Azure key store of the configuration, reached with the outbound proxy and
+// certificate authorities of the node
+func openCloud(cfg *config.Configuration) (*KMS, error) {
+	transport, err := client.NewTransport(cfg.Outbound.ProxyURL, cfg.Outbound.NoProxy, cfg.Outbound.CABundle)
+	if err != nil {
+		return nil, err
+	}
+	switch cfg.KeyStore.Backend {
+	case config.KeyStoreAWS:
+		return OpenAWS(AWSConfig(cfg.KeyStore.AWS), cfg.Timeouts.KeyStore, transport)
+	case config.KeyStoreGCP:
+		return OpenGCP(GCPConfig(cfg.KeyStore.GCP), cfg.Timeouts.KeyStore, transport)
+	}
+	return OpenAzure(AzureConfig(cfg.KeyStore.Azure), cfg.Timeouts.KeyStore, transport)
+}
@@ -0,0 +1,387 @@
+package kms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gofrs/flock"
+	core "github.com/iden3/go-iden3-core"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	fileKeyStoreVersion = 1
+	// the parameters of scrypt, the key of AES-256 is derived once when the file is opened
+	fileScryptN      = 1 << 15
+	fileScryptR      = 8
+	fileScryptP      = 1
+	fileScryptKeyLen = 32
+	// fileCheckPlaintext is encrypted in the file to tell a wrong passphrase when it is opened
+	fileCheckPlaintext = "issuer-node key store"
+)
+
+// ErrFileKeyNotFound the key is not in the file of the key store
+var ErrFileKeyNotFound = errors.New("key not found in the key store file")
+
+// FileConfig configures the key store kept in a local file. It is only meant for development: the keys are encrypted
+// with the passphrase, but they are read by the issuer, and the file is not shared by several hosts.
+type FileConfig struct {
+	// Path is the file of the keys, created when it doesn't exist
+	Path string
+	// Passphrase encrypts the private keys
+	Passphrase string
+}
+
+// fileKeyStoreContent is the JSON of the file. The private keys are encrypted with AES-256-GCM and a key derived from
+// the passphrase with scrypt. The public keys and the key IDs, with the identities, are not encrypted.
+type fileKeyStoreContent struct {
+	Version int                     `json:"version"`
+	KDF     fileKDF                 `json:"kdf"`
+	Check   fileCipherText          `json:"check"`
+	Keys    map[string]fileKeyEntry `json:"keys"`
+}
+
+type fileKDF struct {
+	Name string `json:"name"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt string `json:"salt"`
+}
+
+type fileCipherText struct {
+	Nonce      string `json:"nonce"`
+	CipherText string `json:"ciphertext"`
+}
+
+type fileKeyEntry struct {
+	Type      KeyType `json:"type"`
+	PublicKey string  `json:"publicKey"`
+	fileCipherText
+}
+
+// fileKeyStore keeps the keys of the providers of the file. The file is read again when a key is missing, so the
+// keys created by the other processes of the node, like the publisher, are found.
+type fileKeyStore struct {
+	path string
+
+	mu          sync.Mutex
+	aead        cipher.AEAD
+	content     fileKeyStoreContent
+	privateKeys map[string][]byte // KeyID.ID -> decrypted private key
+}
+
+func openFileKeyStore(cfg FileConfig) (*fileKeyStore, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("the path of the key store file is required")
+	}
+	if cfg.Passphrase == "" {
+		return nil, errors.New("the passphrase of the key store file is required")
+	}
+	s := &fileKeyStore{path: cfg.Path, privateKeys: map[string][]byte{}}
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	found, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		salt := make([]byte, 32)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		s.content = fileKeyStoreContent{
+			Version: fileKeyStoreVersion,
+			KDF:     fileKDF{Name: "scrypt", N: fileScryptN, R: fileScryptR, P: fileScryptP, Salt: hex.EncodeToString(salt)},
+			Keys:    map[string]fileKeyEntry{},
+		}
+	}
+	if s.content.Version != fileKeyStoreVersion || s.content.KDF.Name != "scrypt" {
+		return nil, fmt.Errorf("unsupported key store file version %d", s.content.Version)
+	}
+
+	salt, err := hex.DecodeString(s.content.KDF.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt of the key store file: %w", err)
+	}
+	key, err := scrypt.Key([]byte(cfg.Passphrase), salt, s.content.KDF.N, s.content.KDF.R, s.content.KDF.P, fileScryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if s.aead, err = cipher.NewGCM(block); err != nil {
+		return nil, err
+	}
+
+	if !found {
+		if s.content.Check, err = s.encrypt([]byte(fileCheckPlaintext)); err != nil {
+			return nil, err
+		}
+		if err := s.write(); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	if check, err := s.decrypt(s.content.Check); err != nil || string(check) != fileCheckPlaintext {
+		return nil, errors.New("wrong passphrase of the key store file")
+	}
+	return s, nil
+}
+
+// read loads the file, and returns false when it doesn't exist
+func (s *fileKeyStore) read() (bool, error) {
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var content fileKeyStoreContent
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return false, fmt.Errorf("invalid key store file %s: %w", s.path, err)
+	}
+	if content.Keys == nil {
+		content.Keys = map[string]fileKeyEntry{}
+	}
+	s.content = content
+	return true, nil
+}
+
+// lock takes the exclusive lock of the file, that the processes of the node hold from the read to the write of a
+// change, not to lose the keys added by the others. It is the lock of <path>.lock, as write replaces the key file.
+func (s *fileKeyStore) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return nil, err
+	}
+	fileLock := flock.New(s.path + ".lock")
+	if err := fileLock.Lock(); err != nil {
+		return nil, fmt.Errorf("locking the key store file: %w", err)
+	}
+	return func() { _ = fileLock.Unlock() }, nil
+}
+
+// write replaces the file atomically, readable only by its owner. The content is synced to the disk before the
+// temporary file is renamed, so a crash doesn't leave an empty key store.
+func (s *fileKeyStore) write() error {
+	raw, err := json.MarshalIndent(s.content, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *fileKeyStore) encrypt(plaintext []byte) (fileCipherText, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fileCipherText{}, err
+	}
+	return fileCipherText{
+		Nonce:      hex.EncodeToString(nonce),
+		CipherText: hex.EncodeToString(s.aead.Seal(nil, nonce, plaintext, nil)),
+	}, nil
+}
+
+func (s *fileKeyStore) decrypt(c fileCipherText) ([]byte, error) {
+	nonce, err := hex.DecodeString(c.Nonce)
+	if err != nil || len(nonce) != s.aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	cipherText, err := hex.DecodeString(c.CipherText)
+	if err != nil {
+		return nil, err
+	}
+	return s.aead.Open(nil, nonce, cipherText, nil)
+}
+
+// add encrypts and saves a private key with its public key. The file is read again first, under its lock, not to lose
+// the keys added by other processes.
+func (s *fileKeyStore) add(keyID KeyID, privateKey []byte, publicKey []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if _, err := s.read(); err != nil {
+		return err
+	}
+	if _, ok := s.content.Keys[keyID.ID]; ok {
+		return fmt.Errorf("the key %s already exists", keyID.ID)
+	}
+	encrypted, err := s.encrypt(privateKey)
+	if err != nil {
+		return err
+	}
+	s.content.Keys[keyID.ID] = fileKeyEntry{Type: keyID.Type, PublicKey: hex.EncodeToString(publicKey), fileCipherText: encrypted}
+	if err := s.write(); err != nil {
+		delete(s.content.Keys, keyID.ID)
+		return err
+	}
+	s.privateKeys[keyID.ID] = privateKey
+	return nil
+}
+
+// entry returns the key of keyID, reading the file again when it is missing
+func (s *fileKeyStore) entry(keyID KeyID) (fileKeyEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entryLocked(keyID)
+}
+
+func (s *fileKeyStore) entryLocked(keyID KeyID) (fileKeyEntry, error) {
+	entry, ok := s.content.Keys[keyID.ID]
+	if !ok {
+		if _, err := s.read(); err != nil {
+			return fileKeyEntry{}, err
+		}
+		entry, ok = s.content.Keys[keyID.ID]
+	}
+	if !ok {
+		return fileKeyEntry{}, ErrFileKeyNotFound
+	}
+	if entry.Type != keyID.Type {
+		return fileKeyEntry{}, ErrIncorrectKeyType
+	}
+	return entry, nil
+}
+
+// privateKey returns the decrypted private key of keyID
+func (s *fileKeyStore) privateKey(keyID KeyID) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if privateKey, ok := s.privateKeys[keyID.ID]; ok {
+		return privateKey, nil
+	}
+	entry, err := s.entryLocked(keyID)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := s.decrypt(entry.fileCipherText)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt the key %s: %w", keyID.ID, err)
+	}
+	s.privateKeys[keyID.ID] = privateKey
+	return privateKey, nil
+}
+
+// listByIdentity returns the keys of the type bound to the identity
+func (s *fileKeyStore) listByIdentity(keyType KeyType, identity core.DID) ([]KeyID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.read(); err != nil {
+		return nil, err
+	}
+	prefix := identityPath(&identity) + "/"
+	var keys []KeyID
+	for id, entry := range s.content.Keys {
+		if entry.Type == keyType && strings.HasPrefix(id, prefix) {
+			keys = append(keys, KeyID{Type: keyType, ID: id})
+		}
+	}
+	return keys, nil
+}
+
+// link binds an unbound key, named name, to the identity, and returns its new KeyID
+func (s *fileKeyStore) link(keyID KeyID, identity core.DID, name string) (KeyID, error) {
+	if keyID.ID != keyPath(nil, keyID.Type, name) {
+		return keyID, errors.New("key ID does not looks like unbound")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unlock, err := s.lock()
+	if err != nil {
+		return keyID, err
+	}
+	defer unlock()
+	if _, err := s.read(); err != nil {
+		return keyID, err
+	}
+	entry, ok := s.content.Keys[keyID.ID]
+	if !ok {
+		return keyID, ErrFileKeyNotFound
+	}
+	linked := KeyID{Type: keyID.Type, ID: keyPath(&identity, keyID.Type, name)}
+	s.content.Keys[linked.ID] = entry
+	delete(s.content.Keys, keyID.ID)
+	if err := s.write(); err != nil {
+		return keyID, err
+	}
+	if privateKey, ok := s.privateKeys[keyID.ID]; ok {
+		s.privateKeys[linked.ID] = privateKey
+		delete(s.privateKeys, keyID.ID)
+	}
+	return linked, nil
+}
+
+// OpenFile returns a KMS with the keys in a local file encrypted with a passphrase, that is created when it doesn't
+// exist. It needs no external service, so it is only meant for development and tests: the private keys are decrypted
+// by the issuer and kept in its memory.
+func OpenFile(cfg FileConfig) (*KMS, error) {
+	store, err := openFileKeyStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	keyStore := NewKMS()
+	if err := keyStore.RegisterKeyProvider(KeyTypeBabyJubJub, newFileBJJKeyProvider(store)); err != nil {
+		return nil, fmt.Errorf("cannot register BabyJubJub key provider: %+v", err)
+	}
+	if err := keyStore.RegisterKeyProvider(KeyTypeEthereum, newFileEthKeyProvider(store)); err != nil {
+		return nil, fmt.Errorf("cannot register Ethereum key provider: %+v", err)
+	}
+	return keyStore, nil
+}
+
+// ImportFileKey adds an existing private key to the key store file with keyID, like the Ethereum key that publishes
+// the states, whose KeyID is the publishing key path
+func ImportFileKey(cfg FileConfig, keyID KeyID, privateKey []byte) error {
+	store, err := openFileKeyStore(cfg)
+	if err != nil {
+		return err
+	}
+	var publicKey []byte
+	switch keyID.Type {
+	case KeyTypeEthereum:
+		publicKey, err = ethPublicKey(privateKey)
+	case KeyTypeBabyJubJub:
+		publicKey, err = bjjPublicKey(privateKey)
+	default:
+		return ErrUnknownKeyType
+	}
+	if err != nil {
+		return err
+	}
+	return store.add(keyID, privateKey, publicKey)
+}
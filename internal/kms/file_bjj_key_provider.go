@@ -0,0 +1,80 @@
+package kms
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+)
+
+// fileBJJKeyProvider keeps the BabyJubJub keys in the key store file. Their KeyID is keys/<identity>/BJJ:<public key>,
+// like the ones of vault.
+type fileBJJKeyProvider struct {
+	store *fileKeyStore
+}
+
+func newFileBJJKeyProvider(store *fileKeyStore) KeyProvider {
+	return &fileBJJKeyProvider{store: store}
+}
+
+func (p *fileBJJKeyProvider) New(_ context.Context, identity *core.DID) (KeyID, error) {
+	privKey := babyjub.NewRandPrivKey()
+	keyID := KeyID{Type: KeyTypeBabyJubJub, ID: keyPath(identity, KeyTypeBabyJubJub, privKey.Public().String())}
+	pubKey := privKey.Public().Compress()
+	return keyID, p.store.add(keyID, privKey[:], pubKey[:])
+}
+
+func (p *fileBJJKeyProvider) PublicKey(_ context.Context, keyID KeyID) ([]byte, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return nil, ErrIncorrectKeyType
+	}
+	entry, err := p.store.entry(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(entry.PublicKey)
+}
+
+// Sign signs *big.Int using poseidon algorithm.
+// data should be a little-endian bytes representation of *big.Int.
+func (p *fileBJJKeyProvider) Sign(_ context.Context, keyID KeyID, data []byte) ([]byte, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return nil, ErrIncorrectKeyType
+	}
+	i, err := bjjMessage(data)
+	if err != nil {
+		return nil, err
+	}
+	privKeyData, err := p.store.privateKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return signBJJ(privKeyData, i)
+}
+
+func (p *fileBJJKeyProvider) ListByIdentity(_ context.Context, identity core.DID) ([]KeyID, error) {
+	return p.store.listByIdentity(KeyTypeBabyJubJub, identity)
+}
+
+func (p *fileBJJKeyProvider) LinkToIdentity(_ context.Context, keyID KeyID, identity core.DID) (KeyID, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return keyID, ErrIncorrectKeyType
+	}
+	return p.store.link(keyID, identity, keyID.ID[strings.LastIndex(keyID.ID, ":")+1:])
+}
+
+// bjjPublicKey returns the compressed public key of a BabyJubJub private key
+func bjjPublicKey(privKeyData []byte) ([]byte, error) {
+	privKey, err := decodeBJJPrivateKey(privKeyData)
+	if err != nil {
+		return nil, err
+	}
+	if len(privKeyData) != len(privKey) {
+		return nil, errors.New("unexpected length of private key")
+	}
+	pubKey := privKey.Public().Compress()
+	return pubKey[:], nil
+}
@@ -0,0 +1,83 @@
+package kms
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// fileEthKeyProvider keeps the Ethereum keys in the key store file. Their KeyID is keys/<identity>/ETH:<compressed
+// public key>, like the ones of vault, or the one they were imported with, like the publishing key.
+type fileEthKeyProvider struct {
+	store *fileKeyStore
+}
+
+func newFileEthKeyProvider(store *fileKeyStore) KeyProvider {
+	return &fileEthKeyProvider{store: store}
+}
+
+func (p *fileEthKeyProvider) New(_ context.Context, identity *core.DID) (KeyID, error) {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		return KeyID{}, err
+	}
+	pubKey := crypto.CompressPubkey(&privKey.PublicKey)
+	keyID := KeyID{Type: KeyTypeEthereum, ID: keyPath(identity, KeyTypeEthereum, hex.EncodeToString(pubKey))}
+	return keyID, p.store.add(keyID, crypto.FromECDSA(privKey), pubKey)
+}
+
+func (p *fileEthKeyProvider) PublicKey(_ context.Context, keyID KeyID) ([]byte, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return nil, ErrIncorrectKeyType
+	}
+	entry, err := p.store.entry(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(entry.PublicKey)
+}
+
+// Sign signs the 32 bytes hash with the key, and returns the signature in the [R || S || V] format of ethereum,
+// where V is 0 or 1
+func (p *fileEthKeyProvider) Sign(_ context.Context, keyID KeyID, data []byte) ([]byte, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return nil, ErrIncorrectKeyType
+	}
+	if len(data) != common.HashLength {
+		return nil, fmt.Errorf("data to sign should be %v bytes length", common.HashLength)
+	}
+	privKeyData, err := p.store.privateKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	privKey, err := crypto.ToECDSA(privKeyData)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(data, privKey)
+}
+
+func (p *fileEthKeyProvider) ListByIdentity(_ context.Context, identity core.DID) ([]KeyID, error) {
+	return p.store.listByIdentity(KeyTypeEthereum, identity)
+}
+
+func (p *fileEthKeyProvider) LinkToIdentity(_ context.Context, keyID KeyID, identity core.DID) (KeyID, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return keyID, ErrIncorrectKeyType
+	}
+	return p.store.link(keyID, identity, keyID.ID[strings.LastIndex(keyID.ID, ":")+1:])
+}
+
+// ethPublicKey returns the compressed public key of an Ethereum private key
+func ethPublicKey(privKeyData []byte) ([]byte, error) {
+	privKey, err := crypto.ToECDSA(privKeyData)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.CompressPubkey(&privKey.PublicKey), nil
+}
@@ -0,0 +1,161 @@
+package kms
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_Keys(t *testing.T) {
+	ctx := context.Background()
+	cfg := FileConfig{Path: filepath.Join(t.TempDir(), "keys", "keystore.json"), Passphrase: "secret"}
+	keyStore, err := OpenFile(cfg)
+	require.NoError(t, err)
+	other, err := OpenFile(cfg)
+	require.NoError(t, err)
+	identity := awsTestIdentity(t)
+
+	unbound, err := keyStore.CreateKey(ctx, KeyTypeBabyJubJub, nil)
+	require.NoError(t, err)
+	assert.Regexp(t, `^BJJ:[0-9a-f]{64}$`, unbound.ID)
+	bjjKeyID, err := keyStore.LinkToIdentity(ctx, unbound, *identity)
+	require.NoError(t, err)
+	assert.Equal(t, "keys/"+identity.String()+"/"+unbound.ID, bjjKeyID.ID)
+	_, err = keyStore.LinkToIdentity(ctx, bjjKeyID, *identity)
+	assert.Error(t, err, "the key is already bound")
+
+	ethKeyID, err := keyStore.CreateKey(ctx, KeyTypeEthereum, identity)
+	require.NoError(t, err)
+	_, err = keyStore.CreateKey(ctx, KeyTypeEthereum, nil)
+	require.NoError(t, err)
+
+	// the keys are found by another process of the node, that opened the file before they were created
+	keys, err := other.KeysByIdentity(ctx, *identity)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []KeyID{bjjKeyID, ethKeyID}, keys)
+
+	pubKeyBytes, err := other.PublicKey(ctx, bjjKeyID)
+	require.NoError(t, err)
+	pubKey, err := DecodeBJJPubKey(pubKeyBytes)
+	require.NoError(t, err)
+	message := big.NewInt(123456)
+	sigBytes, err := other.Sign(ctx, bjjKeyID, BJJDigest(message))
+	require.NoError(t, err)
+	sig, err := DecodeBJJSignature(sigBytes)
+	require.NoError(t, err)
+	assert.True(t, pubKey.VerifyPoseidon(message, sig))
+
+	ethPubKey, err := other.PublicKey(ctx, ethKeyID)
+	require.NoError(t, err)
+	require.Len(t, ethPubKey, 33)
+	hash := crypto.Keccak256([]byte("state transition"))
+	ethSig, err := other.Sign(ctx, ethKeyID, hash)
+	require.NoError(t, err)
+	recovered, err := crypto.SigToPub(hash, ethSig)
+	require.NoError(t, err)
+	assert.Equal(t, ethPubKey, crypto.CompressPubkey(recovered))
+
+	// the private keys are encrypted
+	raw, err := os.ReadFile(cfg.Path)
+	require.NoError(t, err)
+	privKey, err := other.registry[KeyTypeEthereum].(*fileEthKeyProvider).store.privateKey(ethKeyID)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), hex.EncodeToString(ethPubKey))
+	assert.NotContains(t, string(raw), hex.EncodeToString(privKey))
+	info, err := os.Stat(cfg.Path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestFile_ImportKey(t *testing.T) {
+	ctx := context.Background()
+	cfg := FileConfig{Path: filepath.Join(t.TempDir(), "keystore.json"), Passphrase: "secret"}
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	publishingKey := KeyID{Type: KeyTypeEthereum, ID: "pbkey"}
+	require.NoError(t, ImportFileKey(cfg, publishingKey, crypto.FromECDSA(privKey)))
+	assert.Error(t, ImportFileKey(cfg, publishingKey, crypto.FromECDSA(privKey)), "the key already exists")
+	assert.Error(t, ImportFileKey(cfg, KeyID{Type: KeyTypeEthereum, ID: "short"}, []byte{1, 2, 3}))
+
+	keyStore, err := OpenFile(cfg)
+	require.NoError(t, err)
+	pubKey, err := keyStore.PublicKey(ctx, publishingKey)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.CompressPubkey(&privKey.PublicKey), pubKey)
+	hash := crypto.Keccak256([]byte("state transition"))
+	sig, err := keyStore.Sign(ctx, publishingKey, hash)
+	require.NoError(t, err)
+	recovered, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(privKey.PublicKey), crypto.PubkeyToAddress(*recovered))
+
+	_, err = keyStore.PublicKey(ctx, KeyID{Type: KeyTypeEthereum, ID: "missing"})
+	assert.ErrorIs(t, err, ErrFileKeyNotFound)
+	_, err = keyStore.PublicKey(ctx, KeyID{Type: KeyTypeBabyJubJub, ID: "pbkey"})
+	assert.ErrorIs(t, err, ErrIncorrectKeyType)
+}
+
+func TestFile_ConcurrentKeys(t *testing.T) {
+	ctx := context.Background()
+	cfg := FileConfig{Path: filepath.Join(t.TempDir(), "keystore.json"), Passphrase: "secret"}
+	identity := awsTestIdentity(t)
+
+	// each key store is a process of the node that adds keys to the file at the same time
+	var wg sync.WaitGroup
+	created := make(chan KeyID, 20)
+	for i := 0; i < 4; i++ {
+		keyStore, err := OpenFile(cfg)
+		require.NoError(t, err)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				keyID, err := keyStore.CreateKey(ctx, KeyTypeEthereum, identity)
+				assert.NoError(t, err)
+				created <- keyID
+			}
+		}()
+	}
+	wg.Wait()
+	close(created)
+
+	var keyIDs []KeyID
+	for keyID := range created {
+		keyIDs = append(keyIDs, keyID)
+	}
+	keyStore, err := OpenFile(cfg)
+	require.NoError(t, err)
+	keys, err := keyStore.KeysByIdentity(ctx, *identity)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, keyIDs, keys, "no key is lost")
+
+	matches, err := filepath.Glob(cfg.Path + ".*.tmp")
+	require.NoError(t, err)
+	assert.Empty(t, matches, "the temporary files are removed")
+}
+
+func TestFile_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	_, err := OpenFile(FileConfig{Path: path})
+	assert.Error(t, err, "the passphrase is required")
+	_, err = OpenFile(FileConfig{Passphrase: "secret"})
+	assert.Error(t, err, "the path is required")
+
+	_, err = OpenFile(FileConfig{Path: path, Passphrase: "secret"})
+	require.NoError(t, err)
+	_, err = OpenFile(FileConfig{Path: path, Passphrase: "wrong"})
+	assert.EqualError(t, err, "wrong passphrase of the key store file")
+
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+	_, err = OpenFile(FileConfig{Path: path, Passphrase: "secret"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	kmsapi "cloud.google.com/go/kms/apiv1"
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+)
+
+const (
+	gcpScopeCloudPlatform = "https://www.googleapis.com/auth/cloud-platform"
+	gcpDefaultPrefix      = "issuer"
+	gcpDefaultTimeout     = 10 * time.Second
+)
+
+// GCPConfig configures the key providers of Google Cloud. The Ethereum keys are Cloud KMS keys and never leave it.
+// Cloud KMS doesn't support BabyJubJub, so those keys are kept in Secret Manager and used in the issuer.
+type GCPConfig struct {
+	Project string
+	// Location and KeyRing are the key ring of the Cloud KMS keys, that must exist
+	Location string
+	KeyRing  string
+	// CredentialsFile is a service account key file. When it is empty, the application default credentials are used,
+	// like the service account of the instance the issuer runs in.
+	CredentialsFile string
+	// Prefix is the first part of the names of the keys, so several nodes can share a project. "issuer" by default.
+	Prefix string
+	// Endpoint replaces the endpoints of Cloud KMS and Secret Manager, for emulators and tests
+	Endpoint string
+}
+
+// gcpClients are the Cloud KMS and Secret Manager clients of the key providers, with the key ring and the prefix of
+// the names of the keys
+type gcpClients struct {
+	kms     *kmsapi.KeyManagementClient
+	secrets *secretmanager.Client
+	project string
+	// keyRing is the resource name of the key ring, projects/<project>/locations/<location>/keyRings/<key ring>
+	keyRing string
+	prefix  string
+}
+
+func newGCPClients(ctx context.Context, cfg GCPConfig, timeout time.Duration, transport http.RoundTripper) (*gcpClients, error) {
+	if cfg.Project == "" || cfg.Location == "" || cfg.KeyRing == "" {
+		return nil, fmt.Errorf("the gcp project, location and key ring are required")
+	}
+	prefix := strings.Trim(cfg.Prefix, "_")
+	if prefix == "" {
+		prefix = gcpDefaultPrefix
+	}
+	if timeout <= 0 {
+		timeout = gcpDefaultTimeout
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	// the access tokens are asked for with transport too
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport, Timeout: timeout})
+
+	var credentials *google.Credentials
+	if cfg.CredentialsFile != "" {
+		content, err := os.ReadFile(cfg.CredentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading the gcp credentials file: %w", err)
+		}
+		if credentials, err = google.CredentialsFromJSON(ctx, content, gcpScopeCloudPlatform); err != nil {
+			return nil, fmt.Errorf("gcp credentials file: %w", err)
+		}
+	} else {
+		var err error
+		if credentials, err = google.FindDefaultCredentials(ctx, gcpScopeCloudPlatform); err != nil {
+			return nil, fmt.Errorf("gcp default credentials: %w", err)
+		}
+	}
+
+	// the REST clients send their requests with this client, that adds the access tokens and bounds each call
+	opts := []option.ClientOption{option.WithHTTPClient(&http.Client{
+		Transport: &oauth2.Transport{Source: credentials.TokenSource, Base: transport},
+		Timeout:   timeout,
+	})}
+	if cfg.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(strings.TrimRight(cfg.Endpoint, "/")))
+	}
+	kmsClient, err := kmsapi.NewKeyManagementRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("cloud kms client: %w", err)
+	}
+	secretsClient, err := secretmanager.NewRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("secret manager client: %w", err)
+	}
+	return &gcpClients{
+		kms:     kmsClient,
+		secrets: secretsClient,
+		project: cfg.Project,
+		keyRing: fmt.Sprintf("projects/%s/locations/%s/keyRings/%s", cfg.Project, cfg.Location, cfg.KeyRing),
+		prefix:  prefix,
+	}, nil
+}
+
+// secretName is the resource name of the Secret Manager secret of the project
+func (c *gcpClients) secretName(name string) string {
+	return "projects/" + c.project + "/secrets/" + name
+}
+
+// OpenGCP returns a KMS with the keys in Google Cloud: the Ethereum keys in Cloud KMS and the BabyJubJub keys in
+// Secret Manager. Each call to Google Cloud is bounded by timeout, 10s when it is 0, and sent with transport, or with
+// http.DefaultTransport when it is nil.
+func OpenGCP(cfg GCPConfig, timeout time.Duration, transport http.RoundTripper) (*KMS, error) {
+	clients, err := newGCPClients(context.Background(), cfg, timeout, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	keyStore := NewKMS()
+	if err := keyStore.RegisterKeyProvider(KeyTypeBabyJubJub, newGCPSecretsBJJKeyProvider(clients)); err != nil {
+		return nil, fmt.Errorf("cannot register BabyJubJub key provider: %+v", err)
+	}
+	if err := keyStore.RegisterKeyProvider(KeyTypeEthereum, newGCPKMSEthKeyProvider(clients)); err != nil {
+		return nil, fmt.Errorf("cannot register Ethereum key provider: %+v", err)
+	}
+	return keyStore, nil
+}
@@ -0,0 +1,161 @@
+package kms
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"google.golang.org/api/iterator"
+)
+
+// gcpSecretsBJJKeyProvider keeps the BabyJubJub keys as secrets of Secret Manager, named like
+// <prefix>_<identity>_BJJ-<public key> or <prefix>_BJJ-<public key> when they are unbound, as the names of the secrets
+// can't have slashes or colons. The secret is the hex encoded private key, and the signatures are made in the issuer.
+type gcpSecretsBJJKeyProvider struct {
+	clients *gcpClients
+	reName  *regexp.Regexp
+}
+
+func newGCPSecretsBJJKeyProvider(clients *gcpClients) KeyProvider {
+	return &gcpSecretsBJJKeyProvider{
+		clients: clients,
+		reName: regexp.MustCompile("^" + regexp.QuoteMeta(clients.prefix+"_") + "(?:[A-Za-z0-9_-]+_)?" +
+			regexp.QuoteMeta(string(KeyTypeBabyJubJub)) + "-([a-f0-9]{64})$"),
+	}
+}
+
+func (p *gcpSecretsBJJKeyProvider) New(ctx context.Context, identity *core.DID) (KeyID, error) {
+	privKey := babyjub.NewRandPrivKey()
+	keyID := KeyID{Type: KeyTypeBabyJubJub, ID: p.name(identity, privKey.Public().String())}
+	return keyID, p.create(ctx, keyID.ID, hex.EncodeToString(privKey[:]))
+}
+
+func (p *gcpSecretsBJJKeyProvider) PublicKey(_ context.Context, keyID KeyID) ([]byte, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return nil, ErrIncorrectKeyType
+	}
+	ss := p.reName.FindStringSubmatch(keyID.ID)
+	if len(ss) != partsNumber {
+		return nil, errors.New("unable to get public key from key ID")
+	}
+	return hex.DecodeString(ss[1])
+}
+
+// Sign signs *big.Int using poseidon algorithm.
+// data should be a little-endian bytes representation of *big.Int.
+func (p *gcpSecretsBJJKeyProvider) Sign(ctx context.Context, keyID KeyID, data []byte) ([]byte, error) {
+	i, err := bjjMessage(data)
+	if err != nil {
+		return nil, err
+	}
+	privKeyData, err := p.privateKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return signBJJ(privKeyData, i)
+}
+
+func (p *gcpSecretsBJJKeyProvider) ListByIdentity(ctx context.Context, identity core.DID) ([]KeyID, error) {
+	prefix := p.name(&identity, "")
+	var keys []KeyID
+	secrets := p.clients.secrets.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{
+		Parent:   "projects/" + p.clients.project,
+		PageSize: 100,
+		Filter:   "name:" + prefix,
+	})
+	for {
+		secret, err := secrets.Next()
+		if errors.Is(err, iterator.Done) {
+			return keys, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		// the name is the resource name, projects/<project number>/secrets/<name>, and the filter a substring match
+		name := secret.Name[strings.LastIndex(secret.Name, "/")+1:]
+		if strings.HasPrefix(name, prefix) && p.reName.MatchString(name) {
+			keys = append(keys, KeyID{Type: KeyTypeBabyJubJub, ID: name})
+		}
+	}
+}
+
+// LinkToIdentity copies the secret of an unbound key to one of the identity and deletes the unbound one
+func (p *gcpSecretsBJJKeyProvider) LinkToIdentity(ctx context.Context, keyID KeyID, identity core.DID) (KeyID, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return keyID, ErrIncorrectKeyType
+	}
+	pubKeyHex := strings.TrimPrefix(keyID.ID, p.name(nil, ""))
+	if pubKeyHex == keyID.ID || !p.reName.MatchString(keyID.ID) {
+		return keyID, errors.New("key ID does not looks like unbound")
+	}
+
+	privKeyData, err := p.privateKey(ctx, keyID)
+	if err != nil {
+		return keyID, err
+	}
+	newKeyID := KeyID{Type: KeyTypeBabyJubJub, ID: p.name(&identity, pubKeyHex)}
+	if err := p.create(ctx, newKeyID.ID, hex.EncodeToString(privKeyData)); err != nil {
+		return keyID, err
+	}
+	err = p.clients.secrets.DeleteSecret(ctx, &secretmanagerpb.DeleteSecretRequest{Name: p.clients.secretName(keyID.ID)})
+	return newKeyID, err
+}
+
+// name is the name of the secret of the key of the identity, or of an unbound key when identity is nil
+func (p *gcpSecretsBJJKeyProvider) name(identity *core.DID, pubKeyHex string) string {
+	if identity == nil {
+		return fmt.Sprintf("%s_%s-%s", p.clients.prefix, KeyTypeBabyJubJub, pubKeyHex)
+	}
+	return fmt.Sprintf("%s_%s_%s-%s", p.clients.prefix, strings.ReplaceAll(identity.String(), ":", "_"), KeyTypeBabyJubJub, pubKeyHex)
+}
+
+// create creates the secret and its first version, with the private key as payload
+func (p *gcpSecretsBJJKeyProvider) create(ctx context.Context, name string, privKeyHex string) error {
+	_, err := p.clients.secrets.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+		Parent:   "projects/" + p.clients.project,
+		SecretId: name,
+		Secret: &secretmanagerpb.Secret{
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_Automatic_{Automatic: &secretmanagerpb.Replication_Automatic{}},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = p.clients.secrets.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent:  p.clients.secretName(name),
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte(privKeyHex)},
+	})
+	return err
+}
+
+func (p *gcpSecretsBJJKeyProvider) privateKey(ctx context.Context, keyID KeyID) ([]byte, error) {
+	if keyID.Type != KeyTypeBabyJubJub {
+		return nil, ErrIncorrectKeyType
+	}
+	if !p.reName.MatchString(keyID.ID) {
+		return nil, errors.New("incorrect key ID")
+	}
+
+	secret, err := p.clients.secrets.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: p.clients.secretName(keyID.ID) + "/versions/latest",
+	})
+	if err != nil {
+		return nil, err
+	}
+	val, err := hex.DecodeString(string(secret.GetPayload().GetData()))
+	if err != nil {
+		return nil, err
+	}
+	if len(val) != defaultLength {
+		return nil, errors.New("incorrect private key")
+	}
+	return val, nil
+}
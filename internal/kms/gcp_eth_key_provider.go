@@ -0,0 +1,180 @@
+package kms
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// gcpIdentityLabel is the label of the Cloud KMS keys with the identity they belong to
+const gcpIdentityLabel = "identity"
+
+// gcpKMSEthKeyProvider keeps the Ethereum keys in Cloud KMS, as secp256k1 signing keys in HSM named
+// <prefix>-ETH-<uuid> in the key ring. Their KeyID is the resource name of the key, and they are signed with its
+// first version. Cloud KMS keys can't be renamed nor deleted, so a key is bound to an identity with a label. Any
+// other key or key version of Cloud KMS can be used as the KeyID of an existing key, like the publishing key.
+type gcpKMSEthKeyProvider struct {
+	clients    *gcpClients
+	publicKeys sync.Map // KeyID.ID -> uncompressed public key, as they never change
+}
+
+func newGCPKMSEthKeyProvider(clients *gcpClients) KeyProvider {
+	return &gcpKMSEthKeyProvider{clients: clients}
+}
+
+func (p *gcpKMSEthKeyProvider) New(ctx context.Context, identity *core.DID) (KeyID, error) {
+	labels := map[string]string{}
+	if identity != nil {
+		labels[gcpIdentityLabel] = gcpIdentityLabelValue(*identity)
+	}
+	created, err := p.clients.kms.CreateCryptoKey(ctx, &kmspb.CreateCryptoKeyRequest{
+		Parent:      p.clients.keyRing,
+		CryptoKeyId: fmt.Sprintf("%s-%s-%s", p.clients.prefix, KeyTypeEthereum, uuid.NewString()),
+		CryptoKey: &kmspb.CryptoKey{
+			Purpose: kmspb.CryptoKey_ASYMMETRIC_SIGN,
+			VersionTemplate: &kmspb.CryptoKeyVersionTemplate{
+				Algorithm:       kmspb.CryptoKeyVersion_EC_SIGN_SECP256K1_SHA256,
+				ProtectionLevel: kmspb.ProtectionLevel_HSM,
+			},
+			Labels: labels,
+		},
+	})
+	if err != nil {
+		return KeyID{}, err
+	}
+	return KeyID{Type: KeyTypeEthereum, ID: created.GetName()}, nil
+}
+
+func (p *gcpKMSEthKeyProvider) PublicKey(ctx context.Context, keyID KeyID) ([]byte, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return nil, ErrIncorrectKeyType
+	}
+	pubKey, err := p.publicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaPubKey, err := crypto.UnmarshalPubkey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.CompressPubkey(ecdsaPubKey), nil
+}
+
+// Sign signs the 32 bytes hash with the key, and returns the signature in the [R || S || V] format of ethereum,
+// where V is 0 or 1
+func (p *gcpKMSEthKeyProvider) Sign(ctx context.Context, keyID KeyID, data []byte) ([]byte, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return nil, ErrIncorrectKeyType
+	}
+	if len(data) != common.HashLength {
+		return nil, fmt.Errorf("data to sign should be %v bytes length", common.HashLength)
+	}
+	pubKey, err := p.publicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := p.clients.kms.AsymmetricSign(ctx, &kmspb.AsymmetricSignRequest{
+		Name:   gcpKeyVersion(keyID.ID),
+		Digest: &kmspb.Digest{Digest: &kmspb.Digest_Sha256{Sha256: data}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ethSignature(data, signed.GetSignature(), pubKey)
+}
+
+func (p *gcpKMSEthKeyProvider) ListByIdentity(ctx context.Context, identity core.DID) ([]KeyID, error) {
+	label := gcpIdentityLabelValue(identity)
+	var keys []KeyID
+	cryptoKeys := p.clients.kms.ListCryptoKeys(ctx, &kmspb.ListCryptoKeysRequest{
+		Parent:   p.clients.keyRing,
+		PageSize: 100,
+		Filter:   "labels." + gcpIdentityLabel + "=" + label,
+	})
+	for {
+		key, err := cryptoKeys.Next()
+		if errors.Is(err, iterator.Done) {
+			return keys, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if key.GetLabels()[gcpIdentityLabel] == label && p.owns(key.GetName()) {
+			keys = append(keys, KeyID{Type: KeyTypeEthereum, ID: key.GetName()})
+		}
+	}
+}
+
+// LinkToIdentity labels an unbound key with the identity. The KeyID doesn't change.
+func (p *gcpKMSEthKeyProvider) LinkToIdentity(ctx context.Context, keyID KeyID, identity core.DID) (KeyID, error) {
+	if keyID.Type != KeyTypeEthereum {
+		return keyID, ErrIncorrectKeyType
+	}
+	if !p.owns(keyID.ID) {
+		return keyID, errors.New("key ID does not looks like unbound")
+	}
+	_, err := p.clients.kms.UpdateCryptoKey(ctx, &kmspb.UpdateCryptoKeyRequest{
+		CryptoKey: &kmspb.CryptoKey{
+			Name:   keyID.ID,
+			Labels: map[string]string{gcpIdentityLabel: gcpIdentityLabelValue(identity)},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"labels"}},
+	})
+	return keyID, err
+}
+
+// owns tells if the key was created by the provider, in the key ring and with the prefix
+func (p *gcpKMSEthKeyProvider) owns(name string) bool {
+	return strings.HasPrefix(name, fmt.Sprintf("%s/cryptoKeys/%s-%s-", p.clients.keyRing, p.clients.prefix, KeyTypeEthereum))
+}
+
+// publicKey returns the uncompressed public key
+func (p *gcpKMSEthKeyProvider) publicKey(ctx context.Context, keyID KeyID) ([]byte, error) {
+	if pubKey, ok := p.publicKeys.Load(keyID.ID); ok {
+		return pubKey.([]byte), nil
+	}
+	answer, err := p.clients.kms.GetPublicKey(ctx, &kmspb.GetPublicKeyRequest{Name: gcpKeyVersion(keyID.ID)})
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(answer.GetPem()))
+	if block == nil {
+		return nil, errors.New("unexpected format of the public key: no pem block")
+	}
+	pubKey, err := secp256k1PublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	p.publicKeys.Store(keyID.ID, pubKey)
+	return pubKey, nil
+}
+
+// gcpKeyVersion is the resource name of the key version that signs with the key, its first one when the name is the
+// one of the key
+func gcpKeyVersion(name string) string {
+	if strings.Contains(name, "/cryptoKeyVersions/") {
+		return name
+	}
+	return name + "/cryptoKeyVersions/1"
+}
+
+// gcpIdentityLabelValue is the value of the identity label of the keys of the identity. The label values are lowercase
+// and up to 63 characters long, so they are the hex encoded first 16 bytes of the SHA-256 of the DID.
+func gcpIdentityLabelValue(identity core.DID) string {
+	hash := sha256.Sum256([]byte(identity.String()))
+	return hex.EncodeToString(hash[:16])
+}
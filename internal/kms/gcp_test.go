@@ -0,0 +1,275 @@
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+)
+
+const gcpTestKeyRing = "projects/project/locations/global/keyRings/issuer"
+
+func TestGCP_EthereumKeys(t *testing.T) {
+	ctx := context.Background()
+	keyStore := openFakeGCP(t)
+	identity := awsTestIdentity(t)
+
+	unbound, err := keyStore.CreateKey(ctx, KeyTypeEthereum, nil)
+	require.NoError(t, err)
+	assert.Regexp(t, `^`+gcpTestKeyRing+`/cryptoKeys/test-ETH-[0-9a-f-]{36}$`, unbound.ID)
+	keys, err := keyStore.KeysByIdentity(ctx, *identity)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	keyID, err := keyStore.LinkToIdentity(ctx, unbound, *identity)
+	require.NoError(t, err)
+	assert.Equal(t, unbound, keyID, "the keys are labeled, not renamed")
+	keys, err = keyStore.KeysByIdentity(ctx, *identity)
+	require.NoError(t, err)
+	assert.Equal(t, []KeyID{keyID}, keys)
+
+	pubKey, err := keyStore.PublicKey(ctx, keyID)
+	require.NoError(t, err)
+	require.Len(t, pubKey, 33)
+	ecdsaPubKey, err := crypto.DecompressPubkey(pubKey)
+	require.NoError(t, err)
+
+	for _, id := range []string{keyID.ID, keyID.ID + "/cryptoKeyVersions/1"} {
+		hash := crypto.Keccak256([]byte(uuid.NewString()))
+		sig, err := keyStore.Sign(ctx, KeyID{Type: KeyTypeEthereum, ID: id}, hash)
+		require.NoError(t, err)
+		require.Len(t, sig, crypto.SignatureLength)
+		assert.True(t, new(big.Int).SetBytes(sig[32:64]).Cmp(new(big.Int).Rsh(crypto.S256().Params().N, 1)) <= 0)
+		recovered, err := crypto.SigToPub(hash, sig)
+		require.NoError(t, err)
+		assert.Equal(t, crypto.PubkeyToAddress(*ecdsaPubKey), crypto.PubkeyToAddress(*recovered))
+	}
+
+	_, err = keyStore.LinkToIdentity(ctx, KeyID{Type: KeyTypeEthereum, ID: "projects/other/locations/global/keyRings/other/cryptoKeys/publisher"}, *identity)
+	assert.Error(t, err)
+}
+
+func TestGCP_BabyJubJubKeys(t *testing.T) {
+	ctx := context.Background()
+	keyStore := openFakeGCP(t)
+	identity := awsTestIdentity(t)
+
+	unbound, err := keyStore.CreateKey(ctx, KeyTypeBabyJubJub, nil)
+	require.NoError(t, err)
+	assert.Regexp(t, `^test_BJJ-[0-9a-f]{64}$`, unbound.ID)
+
+	keyID, err := keyStore.LinkToIdentity(ctx, unbound, *identity)
+	require.NoError(t, err)
+	assert.Equal(t, "test_did_polygonid_polygon_mumbai_2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5_BJJ-"+strings.TrimPrefix(unbound.ID, "test_BJJ-"), keyID.ID)
+	_, err = keyStore.Sign(ctx, unbound, BJJDigest(big.NewInt(1)))
+	assert.Error(t, err, "the unbound secret is deleted")
+
+	other, err := keyStore.CreateKey(ctx, KeyTypeBabyJubJub, identity)
+	require.NoError(t, err)
+	keys, err := keyStore.KeysByIdentity(ctx, *identity)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []KeyID{keyID, other}, keys)
+
+	pubKeyBytes, err := keyStore.PublicKey(ctx, keyID)
+	require.NoError(t, err)
+	pubKey, err := DecodeBJJPubKey(pubKeyBytes)
+	require.NoError(t, err)
+
+	message := big.NewInt(123456)
+	sigBytes, err := keyStore.Sign(ctx, keyID, BJJDigest(message))
+	require.NoError(t, err)
+	sig, err := DecodeBJJSignature(sigBytes)
+	require.NoError(t, err)
+	assert.True(t, pubKey.VerifyPoseidon(message, sig))
+}
+
+func TestGCP_Errors(t *testing.T) {
+	_, err := OpenGCP(GCPConfig{Project: "project"}, 0, nil)
+	assert.Error(t, err)
+
+	keyStore := openFakeGCP(t)
+	_, err = keyStore.PublicKey(context.Background(), KeyID{Type: KeyTypeEthereum, ID: gcpTestKeyRing + "/cryptoKeys/missing"})
+	var apiErr *googleapi.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.Code)
+}
+
+func openFakeGCP(t *testing.T) *KMS {
+	t.Helper()
+	server := httptest.NewServer(newFakeGCP())
+	t.Cleanup(server.Close)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	credentials, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "issuer@project.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"token_uri":    server.URL + "/token",
+	})
+	require.NoError(t, err)
+	credentialsFile := filepath.Join(t.TempDir(), "credentials.json")
+	require.NoError(t, os.WriteFile(credentialsFile, credentials, 0o600))
+
+	keyStore, err := OpenGCP(GCPConfig{Project: "project", Location: "global", KeyRing: "issuer", CredentialsFile: credentialsFile, Prefix: "test", Endpoint: server.URL}, time.Second, nil)
+	require.NoError(t, err)
+	return keyStore
+}
+
+// fakeGCP answers the calls of the REST clients of the key providers to Cloud KMS and Secret Manager
+type fakeGCP struct {
+	mu      sync.Mutex
+	keys    map[string]*ecdsa.PrivateKey
+	labels  map[string]map[string]string
+	secrets map[string][]byte
+}
+
+func newFakeGCP() *fakeGCP {
+	return &fakeGCP{keys: map[string]*ecdsa.PrivateKey{}, labels: map[string]map[string]string{}, secrets: map[string][]byte{}}
+}
+
+func (f *fakeGCP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	answer := func(out any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	}
+	if r.URL.Path == "/token" {
+		answer(map[string]any{"access_token": "token", "expires_in": 3600})
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var in struct {
+		Labels  map[string]string
+		Digest  struct{ Sha256 []byte }
+		Payload struct{ Data []byte }
+	}
+	_ = json.NewDecoder(r.Body).Decode(&in)
+	notFound := func() {
+		w.WriteHeader(http.StatusNotFound)
+		answer(map[string]any{"error": map[string]any{"code": 404, "status": "NOT_FOUND", "message": "not found"}})
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	secrets := "projects/project/secrets"
+	switch {
+	case r.Method == http.MethodPost && path == gcpTestKeyRing+"/cryptoKeys":
+		key, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		name := path + "/" + r.URL.Query().Get("cryptoKeyId")
+		f.keys[name] = key
+		f.labels[name] = in.Labels
+		answer(map[string]any{"name": name})
+	case r.Method == http.MethodGet && path == gcpTestKeyRing+"/cryptoKeys":
+		label := strings.TrimPrefix(r.URL.Query().Get("filter"), "labels.identity=")
+		keys := make([]map[string]any, 0)
+		for name, labels := range f.labels {
+			if labels["identity"] == label {
+				keys = append(keys, map[string]any{"name": name, "labels": labels})
+			}
+		}
+		answer(map[string]any{"cryptoKeys": keys})
+	case r.Method == http.MethodPatch:
+		if _, ok := f.keys[path]; !ok {
+			notFound()
+			return
+		}
+		f.labels[path] = in.Labels
+		answer(map[string]any{"name": path})
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "/cryptoKeyVersions/1/publicKey"):
+		key, ok := f.keys[strings.TrimSuffix(path, "/cryptoKeyVersions/1/publicKey")]
+		if !ok {
+			notFound()
+			return
+		}
+		der, err := asn1.Marshal(struct {
+			Algorithm pkix.AlgorithmIdentifier
+			PublicKey asn1.BitString
+		}{
+			Algorithm: pkix.AlgorithmIdentifier{
+				Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+				Parameters: asn1.RawValue{FullBytes: mustMarshal(asn1.ObjectIdentifier{1, 3, 132, 0, 10})},
+			},
+			PublicKey: asn1.BitString{Bytes: crypto.FromECDSAPub(&key.PublicKey), BitLength: 65 * 8},
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		answer(map[string]any{"pem": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), "algorithm": "EC_SIGN_SECP256K1_SHA256"})
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/cryptoKeyVersions/1:asymmetricSign"):
+		key, ok := f.keys[strings.TrimSuffix(path, "/cryptoKeyVersions/1:asymmetricSign")]
+		if !ok {
+			notFound()
+			return
+		}
+		der, err := ecdsa.SignASN1(rand.Reader, key, in.Digest.Sha256)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		answer(map[string]any{"signature": base64.StdEncoding.EncodeToString(der)})
+	case r.Method == http.MethodPost && path == secrets:
+		f.secrets[r.URL.Query().Get("secretId")] = nil
+		answer(map[string]any{})
+	case r.Method == http.MethodPost && strings.HasSuffix(path, ":addVersion"):
+		name := strings.TrimSuffix(strings.TrimPrefix(path, secrets+"/"), ":addVersion")
+		if _, ok := f.secrets[name]; !ok {
+			notFound()
+			return
+		}
+		f.secrets[name] = in.Payload.Data
+		answer(map[string]any{})
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "/versions/latest:access"):
+		data, ok := f.secrets[strings.TrimSuffix(strings.TrimPrefix(path, secrets+"/"), "/versions/latest:access")]
+		if !ok {
+			notFound()
+			return
+		}
+		answer(map[string]any{"payload": map[string]any{"data": data}})
+	case r.Method == http.MethodDelete:
+		delete(f.secrets, strings.TrimPrefix(path, secrets+"/"))
+		answer(map[string]any{})
+	case r.Method == http.MethodGet && path == secrets:
+		filter := strings.TrimPrefix(r.URL.Query().Get("filter"), "name:")
+		list := make([]map[string]any, 0)
+		for name := range f.secrets {
+			if strings.Contains(name, filter) {
+				// the secrets are listed with the project number
+				list = append(list, map[string]any{"name": "projects/123456/secrets/" + name})
+			}
+		}
+		answer(map[string]any{"secrets": list})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
@@ -2,10 +2,12 @@ package kms
 
 import (
 	"context"
+	"crypto/ecdsa"
 	stderr "errors"
 	"fmt"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/hashicorp/vault/api"
 	core "github.com/iden3/go-iden3-core"
 	"github.com/pkg/errors"
@@ -15,8 +17,8 @@ import (
 // revive:disable-next-line
 type KMSType interface {
 	RegisterKeyProvider(kt KeyType, kp KeyProvider) error
-	CreateKey(kt KeyType, identity *core.DID) (KeyID, error)
-	PublicKey(keyID KeyID) ([]byte, error)
+	CreateKey(ctx context.Context, kt KeyType, identity *core.DID) (KeyID, error)
+	PublicKey(ctx context.Context, keyID KeyID) ([]byte, error)
 	Sign(ctx context.Context, keyID KeyID, data []byte) ([]byte, error)
 	KeysByIdentity(ctx context.Context, identity core.DID) ([]KeyID, error)
 	LinkToIdentity(ctx context.Context, keyID KeyID, identity core.DID) (KeyID, error)
@@ -26,9 +28,9 @@ type KMSType interface {
 type KeyProvider interface {
 	// New generates random key.
 	// If identity is nil, create new key without binding to identity.
-	New(identity *core.DID) (KeyID, error)
+	New(ctx context.Context, identity *core.DID) (KeyID, error)
 	// PublicKey returns byte representation of public key
-	PublicKey(keyID KeyID) ([]byte, error)
+	PublicKey(ctx context.Context, keyID KeyID) ([]byte, error)
 	// Sign the data and return signature.
 	Sign(ctx context.Context, keyID KeyID, data []byte) ([]byte, error)
 	// ListByIdentity returns all keys associated with identity
@@ -53,6 +55,9 @@ const (
 	KeyTypeEthereum   KeyType = "ETH"
 )
 
+// compressedEthPubKeyLen is the length of the compressed public keys of the Ethereum keys
+const compressedEthPubKeyLen = 33
+
 // ErrUnknownKeyType returns when we do not support this type of keys
 var ErrUnknownKeyType = stderr.New("unknown key type")
 
@@ -89,22 +94,22 @@ func (k *KMS) RegisterKeyProvider(kt KeyType, kp KeyProvider) error {
 // CreateKey creates new random key of specified type.
 // If identity is not nil, store key for that identity. If nil, do not bind
 // key to identity.
-func (k *KMS) CreateKey(kt KeyType, identity *core.DID) (KeyID, error) {
+func (k *KMS) CreateKey(ctx context.Context, kt KeyType, identity *core.DID) (KeyID, error) {
 	var id KeyID
 	kp, ok := k.registry[kt]
 	if !ok {
 		return id, errors.WithStack(ErrUnknownKeyType)
 	}
-	return kp.New(identity)
+	return kp.New(ctx, identity)
 }
 
 // PublicKey returns bytes representation for public key for specified key ID
-func (k *KMS) PublicKey(keyID KeyID) ([]byte, error) {
+func (k *KMS) PublicKey(ctx context.Context, keyID KeyID) ([]byte, error) {
 	kp, ok := k.registry[keyID.Type]
 	if !ok {
 		return nil, errors.WithStack(ErrUnknownKeyType)
 	}
-	return kp.PublicKey(keyID)
+	return kp.PublicKey(ctx, keyID)
 }
 
 // Sign signs digest with private key
@@ -199,3 +204,11 @@ func Open(pluginIden3MountPath string, vault *api.Client) (*KMS, error) {
 
 	return keyStore, nil
 }
+
+// DecodeEthPubKey decodes the public key of an Ethereum key, compressed or not
+func DecodeEthPubKey(key []byte) (*ecdsa.PublicKey, error) {
+	if len(key) == compressedEthPubKeyLen {
+		return crypto.DecompressPubkey(key)
+	}
+	return crypto.UnmarshalPubkey(key)
+}
@@ -48,13 +48,13 @@ func (v *vaultPluginIden3KeyProvider) keyPathFromID(keyID KeyID) keyPathT {
 	}
 }
 
-func (v *vaultPluginIden3KeyProvider) LinkToIdentity(_ context.Context, keyID KeyID, identity core.DID) (KeyID, error) {
+func (v *vaultPluginIden3KeyProvider) LinkToIdentity(ctx context.Context, keyID KeyID, identity core.DID) (KeyID, error) {
 	if keyID.Type != v.keyType {
 		return keyID, ErrIncorrectKeyType
 	}
 
 	keyPath := v.keyPathFromID(keyID)
-	pubKeyStr, err := publicKey(v.vaultCli, keyPath)
+	pubKeyStr, err := publicKey(ctx, v.vaultCli, keyPath)
 	if err != nil {
 		return KeyID{}, err
 	}
@@ -64,7 +64,7 @@ func (v *vaultPluginIden3KeyProvider) LinkToIdentity(_ context.Context, keyID Ke
 		return keyID, nil
 	}
 
-	err = moveKey(v.vaultCli, keyPath, newKeyPath)
+	err = moveKey(ctx, v.vaultCli, keyPath, newKeyPath)
 	if err != nil {
 		return KeyID{}, err
 	}
@@ -75,7 +75,7 @@ func (v *vaultPluginIden3KeyProvider) LinkToIdentity(_ context.Context, keyID Ke
 
 // Sign signs *big.Int using poseidon algorithm.
 // data should be a little-endian bytes representation of *big.Int.
-func (v *vaultPluginIden3KeyProvider) Sign(_ context.Context, keyID KeyID, dataToSign []byte) ([]byte, error) {
+func (v *vaultPluginIden3KeyProvider) Sign(ctx context.Context, keyID KeyID, dataToSign []byte) ([]byte, error) {
 	switch keyID.Type {
 	case KeyTypeBabyJubJub:
 		if len(dataToSign) > defaultLength {
@@ -95,15 +95,15 @@ func (v *vaultPluginIden3KeyProvider) Sign(_ context.Context, keyID KeyID, dataT
 		return nil, errors.New("unsupported key type")
 	}
 
-	return signData(v.vaultCli, v.keyPathFromID(keyID), dataToSign)
+	return signData(ctx, v.vaultCli, v.keyPathFromID(keyID), dataToSign)
 }
 
-func (v *vaultPluginIden3KeyProvider) ListByIdentity(_ context.Context, identity core.DID) ([]KeyID, error) {
+func (v *vaultPluginIden3KeyProvider) ListByIdentity(ctx context.Context, identity core.DID) ([]KeyID, error) {
 	identityKeysPath := keyPathT{
 		keyID:     v.identityPath(identity),
 		mountPath: v.keysMountPath,
 	}
-	entries, err := listKVv1Entries(v.vaultCli, identityKeysPath.keys())
+	entries, err := listKVv1Entries(ctx, v.vaultCli, identityKeysPath.keys())
 	if err != nil {
 		return nil, err
 	}
@@ -124,12 +124,12 @@ func (v *vaultPluginIden3KeyProvider) ListByIdentity(_ context.Context, identity
 	return result[:ln], nil
 }
 
-func (v *vaultPluginIden3KeyProvider) PublicKey(keyID KeyID) ([]byte, error) {
+func (v *vaultPluginIden3KeyProvider) PublicKey(ctx context.Context, keyID KeyID) ([]byte, error) {
 	if keyID.Type != v.keyType {
 		return nil, errors.New("incorrect key type")
 	}
 
-	publicKeyStr, err := publicKey(v.vaultCli, v.keyPathFromID(keyID))
+	publicKeyStr, err := publicKey(ctx, v.vaultCli, v.keyPathFromID(keyID))
 	if err != nil {
 		return nil, err
 	}
@@ -138,25 +138,25 @@ func (v *vaultPluginIden3KeyProvider) PublicKey(keyID KeyID) ([]byte, error) {
 	return val, err
 }
 
-func (v *vaultPluginIden3KeyProvider) New(identity *core.DID) (KeyID, error) {
+func (v *vaultPluginIden3KeyProvider) New(ctx context.Context, identity *core.DID) (KeyID, error) {
 	randomKeyPath, err := v.randomKeyPath()
 	if err != nil {
 		return KeyID{}, err
 	}
 
-	err = newRandomKey(v.vaultCli, randomKeyPath, v.keyType)
+	err = newRandomKey(ctx, v.vaultCli, randomKeyPath, v.keyType)
 	if err != nil {
 		return KeyID{}, err
 	}
 
-	pubKeyStr, err := publicKey(v.vaultCli, randomKeyPath)
+	pubKeyStr, err := publicKey(ctx, v.vaultCli, randomKeyPath)
 	if err != nil {
 		return KeyID{}, err
 	}
 
 	keyPath := v.keyPathFromPublic(identity, pubKeyStr)
 	keyID := KeyID{Type: v.keyType, ID: keyPath.keyID}
-	err = moveKey(v.vaultCli, randomKeyPath, keyPath)
+	err = moveKey(ctx, v.vaultCli, randomKeyPath, keyPath)
 	if err != nil {
 		return KeyID{}, err
 	}
@@ -238,27 +238,27 @@ func NewVaultPluginIden3KeyProvider(vaultCli *api.Client, keysPath string, keyTy
 }
 
 // create random key in vault
-func newRandomKey(vaultCli *api.Client, keyPath keyPathT, keyType KeyType) error {
+func newRandomKey(ctx context.Context, vaultCli *api.Client, keyPath keyPathT, keyType KeyType) error {
 	pluginKeyType, err := toPluginKeyType(keyType)
 	if err != nil {
 		return err
 	}
 
-	_, err = vaultCli.Logical().Write(keyPath.new(),
+	_, err = vaultCli.Logical().WriteWithContext(ctx, keyPath.new(),
 		map[string]interface{}{jsonKeyType: pluginKeyType})
 	return err
 }
 
 // move the key under new path
-func moveKey(vaultCli *api.Client, oldPath, newPath keyPathT) error {
+func moveKey(ctx context.Context, vaultCli *api.Client, oldPath, newPath keyPathT) error {
 	data := map[string]interface{}{keyDest: newPath.keys()}
-	_, err := vaultCli.Logical().Write(oldPath.move(), data)
+	_, err := vaultCli.Logical().WriteWithContext(ctx, oldPath.move(), data)
 	return err
 }
 
 // get string representation of public key
-func publicKey(vaultCli *api.Client, keyPath keyPathT) (string, error) {
-	secret, err := vaultCli.Logical().Read(keyPath.keys())
+func publicKey(ctx context.Context, vaultCli *api.Client, keyPath keyPathT) (string, error) {
+	secret, err := vaultCli.Logical().ReadWithContext(ctx, keyPath.keys())
 	if err != nil {
 		return "", err
 	}
@@ -289,10 +289,10 @@ func getSecretData(secret *api.Secret) (map[string]interface{}, error) {
 	return secret.Data, nil
 }
 
-func signData(vaultCli *api.Client, keyPath keyPathT, dataToSign []byte) ([]byte, error) {
+func signData(ctx context.Context, vaultCli *api.Client, keyPath keyPathT, dataToSign []byte) ([]byte, error) {
 	dataStr := hex.EncodeToString(dataToSign)
 	data := map[string][]string{keyData: {dataStr}}
-	secret, err := vaultCli.Logical().ReadWithData(keyPath.sign(), data)
+	secret, err := vaultCli.Logical().ReadWithDataWithContext(ctx, keyPath.sign(), data)
 	if err != nil {
 		return nil, err
 	}
@@ -308,8 +308,8 @@ func signData(vaultCli *api.Client, keyPath keyPathT, dataToSign []byte) ([]byte
 	return sig, err
 }
 
-func listKVv1Entries(vaultCli *api.Client, dirPath string) ([]string, error) {
-	se, err := vaultCli.Logical().List(dirPath)
+func listKVv1Entries(ctx context.Context, vaultCli *api.Client, dirPath string) ([]string, error) {
+	se, err := vaultCli.Logical().ListWithContext(ctx, dirPath)
 	if err != nil {
 		return nil, err
 	}
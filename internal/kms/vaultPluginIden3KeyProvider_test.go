@@ -40,7 +40,7 @@ func TestVaultPluginBJJProvider_Ethereum(t *testing.T) {
 	}
 
 	// generate new random key not bound to any identity
-	newKey, err := kp.New(nil)
+	newKey, err := kp.New(ctx, nil)
 	require.NoError(t, err)
 	rmKey(getKeyPath(newKey))
 	require.Equal(t, newKey.Type, KeyTypeEthereum)
@@ -62,7 +62,7 @@ func TestVaultPluginBJJProvider_Ethereum(t *testing.T) {
 	require.Equal(t, boundKey, boundKey2)
 
 	// test public key
-	pubKeyBytes, err := kp.PublicKey(boundKey)
+	pubKeyBytes, err := kp.PublicKey(ctx, boundKey)
 	require.NoError(t, err)
 	privKey := getETHPrivateKey(t, vaultCli, getKeyPath(boundKey))
 	pubKey2, ok := privKey.Public().(*ecdsa.PublicKey)
@@ -81,7 +81,7 @@ func TestVaultPluginBJJProvider_Ethereum(t *testing.T) {
 	require.Equal(t, sig1Bytes, sig2Bytes)
 
 	// generate new random key bounded to identity
-	newKey2, err := kp.New(&did)
+	newKey2, err := kp.New(ctx, &did)
 	require.NoError(t, err)
 	rmKey(getKeyPath(newKey2))
 	require.Equal(t, newKey.Type, KeyTypeEthereum)
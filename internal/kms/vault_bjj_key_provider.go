@@ -37,7 +37,7 @@ func NewVaultBJJKeyProvider(vaultCli *api.Client, keyType KeyType) KeyProvider {
 	return &vaultBJJKeyProvider{keyType, vaultCli, reIdenKeyPathHex, reAnonKeyPathHex}
 }
 
-func (v *vaultBJJKeyProvider) New(identity *core.DID) (KeyID, error) {
+func (v *vaultBJJKeyProvider) New(ctx context.Context, identity *core.DID) (KeyID, error) {
 	bjjPrivKey := babyjub.NewRandPrivKey()
 	keyID := KeyID{
 		Type: v.keyType,
@@ -47,7 +47,7 @@ func (v *vaultBJJKeyProvider) New(identity *core.DID) (KeyID, error) {
 		jsonKeyType: string(keyID.Type),
 		jsonKeyData: hex.EncodeToString(bjjPrivKey[:]),
 	}
-	return keyID, saveKeyMaterial(v.vaultCli, keyID.ID, keyMaterial)
+	return keyID, saveKeyMaterial(ctx, v.vaultCli, keyID.ID, keyMaterial)
 }
 
 func (v *vaultBJJKeyProvider) LinkToIdentity(_ context.Context, keyID KeyID, identity core.DID) (KeyID, error) {
@@ -70,33 +70,23 @@ func (v *vaultBJJKeyProvider) LinkToIdentity(_ context.Context, keyID KeyID, ide
 
 // Sign signs *big.Int using poseidon algorithm.
 // data should be a little-endian bytes representation of *big.Int.
-func (v *vaultBJJKeyProvider) Sign(_ context.Context, keyID KeyID, data []byte) ([]byte, error) {
-	if len(data) > defaultLength {
-		return nil, errors.New("data to sign is too large")
-	}
-
-	i := new(big.Int).SetBytes(utils.SwapEndianness(data))
-	if !utils.CheckBigIntInField(i) {
-		return nil, errors.New("data to sign is too large")
-	}
-
-	privKeyData, err := v.privateKey(keyID)
+func (v *vaultBJJKeyProvider) Sign(ctx context.Context, keyID KeyID, data []byte) ([]byte, error) {
+	i, err := bjjMessage(data)
 	if err != nil {
 		return nil, err
 	}
 
-	privKey, err := decodeBJJPrivateKey(privKeyData)
+	privKeyData, err := v.privateKey(ctx, keyID)
 	if err != nil {
 		return nil, err
 	}
 
-	sig := privKey.SignPoseidon(i).Compress()
-	return sig[:], nil
+	return signBJJ(privKeyData, i)
 }
 
-func (v *vaultBJJKeyProvider) ListByIdentity(_ context.Context, identity core.DID) ([]KeyID, error) {
+func (v *vaultBJJKeyProvider) ListByIdentity(ctx context.Context, identity core.DID) ([]KeyID, error) {
 	path := identityPath(&identity)
-	entries, err := listDirectoryEntries(v.vaultCli, path)
+	entries, err := listDirectoryEntries(ctx, v.vaultCli, path)
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +112,7 @@ func (v *vaultBJJKeyProvider) ListByIdentity(_ context.Context, identity core.DI
 	return result, nil
 }
 
-func (v *vaultBJJKeyProvider) PublicKey(keyID KeyID) ([]byte, error) {
+func (v *vaultBJJKeyProvider) PublicKey(_ context.Context, keyID KeyID) ([]byte, error) {
 	if keyID.Type != v.keyType {
 		return nil, errors.New("incorrect key type")
 	}
@@ -139,7 +129,7 @@ func (v *vaultBJJKeyProvider) PublicKey(keyID KeyID) ([]byte, error) {
 	return val, err
 }
 
-func (v *vaultBJJKeyProvider) privateKey(keyID KeyID) ([]byte, error) {
+func (v *vaultBJJKeyProvider) privateKey(ctx context.Context, keyID KeyID) ([]byte, error) {
 	if keyID.Type != v.keyType {
 		return nil, ErrIncorrectKeyType
 	}
@@ -150,7 +140,7 @@ func (v *vaultBJJKeyProvider) privateKey(keyID KeyID) ([]byte, error) {
 	}
 
 	path := absVaultSecretPath(keyID.ID)
-	secret, err := v.vaultCli.Logical().Read(path)
+	secret, err := v.vaultCli.Logical().ReadWithContext(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -192,6 +182,31 @@ func (v *vaultBJJKeyProvider) privateKey(keyID KeyID) ([]byte, error) {
 	return val, nil
 }
 
+// bjjMessage converts the little-endian bytes representation of *big.Int to sign to *big.Int, checking it is in the
+// field
+func bjjMessage(data []byte) (*big.Int, error) {
+	if len(data) > defaultLength {
+		return nil, errors.New("data to sign is too large")
+	}
+
+	i := new(big.Int).SetBytes(utils.SwapEndianness(data))
+	if !utils.CheckBigIntInField(i) {
+		return nil, errors.New("data to sign is too large")
+	}
+	return i, nil
+}
+
+// signBJJ signs the message with the private key using poseidon algorithm and returns the compressed signature
+func signBJJ(privKeyData []byte, message *big.Int) ([]byte, error) {
+	privKey, err := decodeBJJPrivateKey(privKeyData)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := privKey.SignPoseidon(message).Compress()
+	return sig[:], nil
+}
+
 // DecodeBJJPubKey is a helper method to convert byte representation of public
 // key to *babyjub.PublicKey
 func DecodeBJJPubKey(key []byte) (*babyjub.PublicKey, error) {
@@ -50,7 +50,7 @@ func TestVaultBJJKeyProvider_PublicKey(t *testing.T) {
 				Type: KeyTypeBabyJubJub,
 				ID:   tc.keyID,
 			}
-			result, err := keyProvider.PublicKey(keyID)
+			result, err := keyProvider.PublicKey(context.Background(), keyID)
 			if tc.wantErr == "" {
 				require.NoError(t, err)
 				require.Equal(t, tc.want, result)
@@ -65,13 +65,13 @@ func TestVaultBJJKeyProvider_PublicKey(t *testing.T) {
 func TestBJJKeyProvider_NoIdentity(t *testing.T) {
 	k := testKMSSetup(t)
 
-	keyID, err := k.KMS.CreateKey(KeyTypeBabyJubJub, nil)
+	keyID, err := k.KMS.CreateKey(context.Background(), KeyTypeBabyJubJub, nil)
 	require.NoError(t, err)
 	require.Equal(t, KeyTypeBabyJubJub, keyID.Type)
 
 	privKey := testBJJKeyContent(t, k, keyID)
 
-	pubKeyBytes, err := k.KMS.PublicKey(keyID)
+	pubKeyBytes, err := k.KMS.PublicKey(context.Background(), keyID)
 	require.NoError(t, err)
 	pubKey, err := DecodeBJJPubKey(pubKeyBytes)
 	require.NoError(t, err)
@@ -127,7 +127,7 @@ func testBJJKeyContent(t testing.TB, k TestKMS, keyID KeyID) babyjub.PrivateKey
 	var privKey babyjub.PrivateKey
 	require.Equal(t, 32, copy(privKey[:], privKeyBytes))
 
-	pubKeyBytes, err := k.KMS.PublicKey(keyID)
+	pubKeyBytes, err := k.KMS.PublicKey(context.Background(), keyID)
 	require.NoError(t, err)
 	compPubKey := privKey.Public().Compress()
 	require.Equal(t, pubKeyBytes, compPubKey[:])
@@ -149,7 +149,7 @@ func testBoundedBJJKey(t *testing.T, k TestKMS, keyID KeyID, identity core.DID)
 	require.EqualError(t, err, "key ID does not looks like unbound")
 
 	// Check public key
-	pubKeyBytes, err := k.KMS.PublicKey(keyID)
+	pubKeyBytes, err := k.KMS.PublicKey(context.Background(), keyID)
 	require.NoError(t, err)
 	pubKey, err := DecodeBJJPubKey(pubKeyBytes)
 	require.NoError(t, err)
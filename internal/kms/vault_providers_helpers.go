@@ -1,6 +1,7 @@
 package kms
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -18,17 +19,17 @@ const (
 	jsonKeyData = "key_data"
 )
 
-func saveKeyMaterial(vaultCli *api.Client, path string, jsonObj map[string]string) error {
+func saveKeyMaterial(ctx context.Context, vaultCli *api.Client, path string, jsonObj map[string]string) error {
 	secret := map[string]interface{}{"data": jsonObj}
 	vaultPath := absVaultSecretPath(path)
-	_, err := vaultCli.Logical().Write(vaultPath, secret)
+	_, err := vaultCli.Logical().WriteWithContext(ctx, vaultPath, secret)
 	return errors.WithStack(err)
 }
 
-func listDirectoryEntries(vaultCli *api.Client, path string) ([]string, error) {
+func listDirectoryEntries(ctx context.Context, vaultCli *api.Client, path string) ([]string, error) {
 	path = strings.TrimPrefix(path, "/")
 	path = kvStoragePath + "/metadata/" + path
-	se, err := vaultCli.Logical().List(path)
+	se, err := vaultCli.Logical().ListWithContext(ctx, path)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -3,6 +3,7 @@ package loader
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/polygonid/sh-id-platform/internal/log"
 	"github.com/polygonid/sh-id-platform/pkg/cache"
@@ -13,16 +14,22 @@ type schemaData struct {
 	Extension string
 }
 
+// Purger is implemented by the loaders that cache their document
+type Purger interface {
+	// Purge removes the document from the cache, so the next Load fetches it again
+	Purge(ctx context.Context) error
+}
+
 type cached struct {
 	url    string
 	loader Loader
 	cache  cache.Cache
+	ttl    time.Duration
 }
 
 // Load returns a schema. It uses an internal cache and a loader. This caches can, and probably is, shared with
 // other loaders. If the file is found in the cache it returns it. If not, loads the file using the internal loader
-// and caches it.
-// TTL for cached items is forever
+// and caches it for the ttl of the loader, forever when it is cache.ForEver.
 func (c *cached) Load(ctx context.Context) (schema []byte, extension string, err error) {
 	ctx = log.With(ctx, "key", c.key(c.url))
 	d := schemaData{}
@@ -35,30 +42,47 @@ func (c *cached) Load(ctx context.Context) (schema []byte, extension string, err
 		return nil, "", err
 	}
 
-	if err := c.cache.Set(ctx, c.key(c.url), d, cache.ForEver); err != nil {
+	if err := c.cache.Set(ctx, c.key(c.url), d, c.ttl); err != nil {
 		log.Warn(ctx, "adding schema to Redis. Bypassing cache")
 	}
 
 	return d.Schema, d.Extension, nil
 }
 
+// Purge removes the document from the cache, so the next Load fetches it again
+func (c *cached) Purge(ctx context.Context) error {
+	return c.cache.Delete(ctx, c.key(c.url))
+}
+
 func (c *cached) key(url string) string {
 	return fmt.Sprintf("schema-%s", url)
 }
 
 // Cached is a file loader that uses a cache. That cache can be shared by multiple loaders.
 func Cached(l Loader, c cache.Cache, url string) Loader {
+	return CachedWithTTL(l, c, url, cache.ForEver)
+}
+
+// CachedWithTTL is a file loader that keeps the file in a cache for ttl
+func CachedWithTTL(l Loader, c cache.Cache, url string, ttl time.Duration) Loader {
 	return &cached{
 		url:    url,
 		loader: l,
 		cache:  c,
+		ttl:    ttl,
 	}
 }
 
 // CachedFactory returns a function factory able to create Cached Loaders. That means, file loaders that
 // looks on a cache for a file before trying to fetch it
 func CachedFactory(f Factory, c cache.Cache) Factory {
+	return CachedFactoryWithTTL(f, c, cache.ForEver)
+}
+
+// CachedFactoryWithTTL returns a function factory of Cached Loaders that keep the files in the cache for ttl.
+// The loaders it creates are Purgers.
+func CachedFactoryWithTTL(f Factory, c cache.Cache, ttl time.Duration) Factory {
 	return func(url string) Loader {
-		return Cached(f(url), c, url)
+		return CachedWithTTL(f(url), c, url, ttl)
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -34,3 +35,25 @@ func TestCached_Load(t *testing.T) {
 		assert.True(t, c.Exists(ctx, fmt.Sprintf("schema-%s", "http://this/is/an/url")))
 	}
 }
+
+func TestCached_Purge(t *testing.T) {
+	ctx := context.Background()
+	spy := &spyLoader{}
+	c := cache.NewMemoryCache()
+	myLoader := CachedFactoryWithTTL(func(url string) Loader { return spy }, c, time.Hour)("http://this/is/an/url")
+
+	_, _, err := myLoader.Load(ctx)
+	assert.NoError(t, err)
+	_, _, err = myLoader.Load(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, spy.called)
+
+	purger, ok := myLoader.(Purger)
+	assert.True(t, ok)
+	assert.NoError(t, purger.Purge(ctx))
+	assert.False(t, c.Exists(ctx, "schema-http://this/is/an/url"))
+
+	_, _, err = myLoader.Load(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, spy.called, "the purged schema is loaded again")
+}
@@ -0,0 +1,239 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+const backoffFactor = 2
+
+var (
+	// ErrUnsupportedURL means a source of a chain has nothing to load for an url, so the chain goes on with the next one
+	ErrUnsupportedURL = errors.New("the source does not load this url")
+	// ErrSourceUnavailable means the circuit breaker of a source of a chain is open, so the source is skipped
+	ErrSourceUnavailable = errors.New("the source is unavailable after too many failures")
+	// ErrInvalidMirror means a mirror of the schema downloads is not a prefix=mirror pair
+	ErrInvalidMirror = errors.New("invalid schema mirror, it must be a prefix=mirror pair")
+)
+
+// Source is one of the sources a chain loads the documents from
+type Source struct {
+	Name    string        // Name identifies the source in the logs and errors
+	Factory Factory       // Factory builds the loaders of the source
+	Timeout time.Duration // Timeout bounds each attempt. Zero means no timeout.
+}
+
+// Retry configures the attempts of each source of a chain. Each wait between attempts doubles the previous one up
+// to MaxBackoff.
+type Retry struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Breaker configures the circuit breakers of the sources of a chain, one for each host: after Failures consecutive
+// failed loads from a host the source is skipped for its urls for Cooldown, and then a load is let through to check
+// it again. Zero failures disables them.
+type Breaker struct {
+	Failures int
+	Cooldown time.Duration
+}
+
+// breaker is the circuit breaker of a source for a host, shared by all its loaders
+type breaker struct {
+	sync.Mutex
+	cfg       Breaker
+	failures  int
+	openUntil time.Time
+}
+
+func (b *breaker) allow() bool {
+	if b.cfg.Failures <= 0 {
+		return true
+	}
+	b.Lock()
+	defer b.Unlock()
+	return !time.Now().Before(b.openUntil)
+}
+
+func (b *breaker) success() {
+	b.Lock()
+	defer b.Unlock()
+	b.failures = 0
+}
+
+// failure counts a failed load and returns true when it opens the breaker
+func (b *breaker) failure() bool {
+	if b.cfg.Failures <= 0 {
+		return false
+	}
+	b.Lock()
+	defer b.Unlock()
+	b.failures++
+	if b.failures < b.cfg.Failures {
+		return false
+	}
+	b.openUntil = time.Now().Add(b.cfg.Cooldown)
+	return true
+}
+
+// breakers are the circuit breakers of a source by host
+type breakers struct {
+	sync.Mutex
+	cfg   Breaker
+	hosts map[string]*breaker
+}
+
+func (b *breakers) get(rawURL string) *breaker {
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		host = u.Scheme + "://" + u.Host
+	}
+	b.Lock()
+	defer b.Unlock()
+	if _, found := b.hosts[host]; !found {
+		b.hosts[host] = &breaker{cfg: b.cfg}
+	}
+	return b.hosts[host]
+}
+
+type chainSource struct {
+	Source
+	breakers *breakers
+}
+
+type chain struct {
+	url     string
+	sources []chainSource
+	retry   Retry
+}
+
+// Load tries the sources in order and returns the first document loaded. Each source is attempted up to the retry
+// attempts, and skipped while its breaker is open.
+func (c *chain) Load(ctx context.Context) (schema []byte, extension string, err error) {
+	var errs []error
+	for _, source := range c.sources {
+		cb := source.breakers.get(c.url)
+		if !cb.allow() {
+			errs = append(errs, fmt.Errorf("%s: %w", source.Name, ErrSourceUnavailable))
+			continue
+		}
+		schema, extension, err = c.load(ctx, source)
+		if err == nil {
+			cb.success()
+			return schema, extension, nil
+		}
+		if errors.Is(err, ErrUnsupportedURL) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil, "", err
+		}
+		if cb.failure() {
+			log.Warn(ctx, "schema source disabled after consecutive failures", "source", source.Name, "url", c.url, "cooldown", cb.cfg.Cooldown.String())
+		}
+		log.Warn(ctx, "cannot load schema from source", "source", source.Name, "url", c.url, "err", err)
+		errs = append(errs, fmt.Errorf("%s: %w", source.Name, err))
+	}
+	if len(errs) == 0 {
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedURL, c.url)
+	}
+	return nil, "", errors.Join(errs...)
+}
+
+func (c *chain) load(ctx context.Context, source chainSource) (schema []byte, extension string, err error) {
+	wait := c.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		schema, extension, err = c.attempt(ctx, source)
+		if err == nil || errors.Is(err, ErrUnsupportedURL) || attempt >= c.retry.Attempts {
+			return schema, extension, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, "", err
+		case <-time.After(wait):
+		}
+		wait *= backoffFactor
+		if c.retry.MaxBackoff > 0 && wait > c.retry.MaxBackoff {
+			wait = c.retry.MaxBackoff
+		}
+	}
+}
+
+func (c *chain) attempt(ctx context.Context, source chainSource) ([]byte, string, error) {
+	if source.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, source.Timeout)
+		defer cancel()
+	}
+	return source.Factory(c.url).Load(ctx)
+}
+
+// ChainFactory returns a function factory of loaders that try the sources in order, like the origin and then an
+// HTTP mirror, so a flaky source doesn't fail the loads the next ones can serve. Each source is attempted as set by
+// retry, and has circuit breakers configured by b that skip it for the hosts it keeps failing to load.
+func ChainFactory(sources []Source, retry Retry, b Breaker) Factory {
+	chained := make([]chainSource, 0, len(sources))
+	for _, source := range sources {
+		chained = append(chained, chainSource{Source: source, breakers: &breakers{cfg: b, hosts: make(map[string]*breaker)}})
+	}
+	return func(url string) Loader {
+		return &chain{url: url, sources: chained, retry: retry}
+	}
+}
+
+// Mirror serves the documents whose url starts with Prefix from the url with Prefix replaced by URL
+type Mirror struct {
+	Prefix string
+	URL    string
+}
+
+// ParseMirrors is a constructor of the mirrors of a comma separated list of prefix=mirror pairs, like
+// https://raw.githubusercontent.com/=https://mirror.internal/github/,ipfs://=https://gateway.internal/ipfs/
+func ParseMirrors(mirrors string) ([]Mirror, error) {
+	var parsed []Mirror
+	for _, pair := range strings.Split(mirrors, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		prefix, mirror, found := strings.Cut(pair, "=")
+		if !found || prefix == "" || mirror == "" {
+			return nil, fmt.Errorf("%w: <%s>", ErrInvalidMirror, pair)
+		}
+		parsed = append(parsed, Mirror{Prefix: prefix, URL: mirror})
+	}
+	return parsed, nil
+}
+
+// MirrorFactory returns a function factory of loaders of f that load the documents from their mirror. The longest
+// matching prefix wins, and the loaders of the urls without a mirror return ErrUnsupportedURL.
+func MirrorFactory(f Factory, mirrors []Mirror) Factory {
+	sorted := append([]Mirror(nil), mirrors...)
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i].Prefix) > len(sorted[j].Prefix) })
+	return func(url string) Loader {
+		for _, m := range sorted {
+			if strings.HasPrefix(url, m.Prefix) {
+				return f(m.URL + strings.TrimPrefix(url, m.Prefix))
+			}
+		}
+		return &unsupported{url: url}
+	}
+}
+
+type unsupported struct {
+	url string
+}
+
+// Load returns ErrUnsupportedURL
+func (u *unsupported) Load(_ context.Context) ([]byte, string, error) {
+	return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedURL, u.url)
+}
@@ -0,0 +1,142 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type flakyLoader struct {
+	failures int
+	called   int
+}
+
+func (f *flakyLoader) Load(_ context.Context) (schema []byte, extension string, err error) {
+	f.called++
+	if f.called <= f.failures {
+		return nil, "", errors.New("bad gateway")
+	}
+	return []byte("this is an schema content"), "json", nil
+}
+
+func TestChainFactory_Retry(t *testing.T) {
+	flaky := &flakyLoader{failures: 2}
+	factory := ChainFactory(
+		[]Source{{Name: "origin", Factory: func(url string) Loader { return flaky }}},
+		Retry{Attempts: 3, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+		Breaker{},
+	)
+	doc, _, err := factory("https://example.com/kyc.json").Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("this is an schema content"), doc)
+	assert.Equal(t, 3, flaky.called)
+}
+
+func TestChainFactory_Fallback(t *testing.T) {
+	failing := &failingLoader{}
+	spy := &spyLoader{}
+	factory := ChainFactory(
+		[]Source{
+			{Name: "origin", Factory: func(url string) Loader { return failing }},
+			{Name: "mirror", Factory: func(url string) Loader { return spy }},
+		},
+		Retry{Attempts: 2, Backoff: time.Millisecond},
+		Breaker{},
+	)
+	doc, _, err := factory("https://example.com/kyc.json").Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("this is an schema content"), doc)
+	assert.Equal(t, 2, failing.called)
+	assert.Equal(t, 1, spy.called)
+}
+
+func TestChainFactory_Timeout(t *testing.T) {
+	spy := &spyLoader{}
+	factory := ChainFactory(
+		[]Source{
+			{Name: "origin", Factory: func(url string) Loader { return &blockingLoader{} }, Timeout: 10 * time.Millisecond},
+			{Name: "mirror", Factory: func(url string) Loader { return spy }},
+		},
+		Retry{Attempts: 1},
+		Breaker{},
+	)
+	_, _, err := factory("https://example.com/kyc.json").Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, spy.called)
+}
+
+func TestChainFactory_Breaker(t *testing.T) {
+	failing := &failingLoader{}
+	spy := &spyLoader{}
+	factory := ChainFactory(
+		[]Source{
+			{Name: "origin", Factory: func(url string) Loader { return failing }},
+			{Name: "mirror", Factory: func(url string) Loader { return spy }},
+		},
+		Retry{Attempts: 1},
+		Breaker{Failures: 2, Cooldown: time.Hour},
+	)
+	for i := 0; i < 4; i++ {
+		_, _, err := factory("https://example.com/kyc.json").Load(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, failing.called)
+	assert.Equal(t, 4, spy.called)
+
+	_, _, err := factory("https://schemas.example.org/kyc.json").Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, failing.called)
+}
+
+func TestChainFactory_AllFail(t *testing.T) {
+	factory := ChainFactory(
+		[]Source{
+			{Name: "origin", Factory: func(url string) Loader { return &failingLoader{} }},
+			{Name: "mirror", Factory: MirrorFactory(HTTPFactory, nil)},
+		},
+		Retry{Attempts: 1},
+		Breaker{},
+	)
+	_, _, err := factory("https://example.com/kyc.json").Load(context.Background())
+	assert.EqualError(t, err, "origin: no route to host")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = ChainFactory([]Source{{Name: "origin", Factory: func(url string) Loader { return &blockingLoader{} }}}, Retry{Attempts: 3}, Breaker{})("https://example.com/kyc.json").Load(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMirrorFactory(t *testing.T) {
+	var loaded string
+	f := func(url string) Loader {
+		loaded = url
+		return &spyLoader{}
+	}
+	mirrors, err := ParseMirrors("https://raw.githubusercontent.com/=https://mirror.internal/github/, https://raw.githubusercontent.com/iden3/=https://mirror.internal/iden3/,ipfs://=https://gateway.internal/ipfs/")
+	require.NoError(t, err)
+	factory := MirrorFactory(f, mirrors)
+
+	_, _, err = factory("https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json").Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://mirror.internal/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json", loaded)
+
+	_, _, err = factory("ipfs://bafkreischema").Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://gateway.internal/ipfs/bafkreischema", loaded)
+
+	_, _, err = factory("https://example.com/kyc.json").Load(context.Background())
+	assert.ErrorIs(t, err, ErrUnsupportedURL)
+}
+
+func TestParseMirrors(t *testing.T) {
+	mirrors, err := ParseMirrors("")
+	require.NoError(t, err)
+	assert.Empty(t, mirrors)
+
+	_, err = ParseMirrors("https://example.com/")
+	assert.ErrorIs(t, err, ErrInvalidMirror)
+}
@@ -0,0 +1,165 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+)
+
+// ContextsRemote loads the JSON-LD contexts without the embedded snapshot
+const ContextsRemote = "remote"
+
+const (
+	// DocumentLoadTimeout bounds each load of a JSON-LD document without a context, retries and mirrors included
+	DocumentLoadTimeout = time.Minute
+	// DocumentCacheTTL is how long the JSON-LD documents stay in memory by default
+	DocumentCacheTTL = time.Hour
+	// DocumentCacheSize is the maximum number of JSON-LD documents kept in memory
+	DocumentCacheSize = 512
+)
+
+// ErrInvalidContextsMode means the JSON-LD contexts mode is not remote or one of the LocalOrder
+var ErrInvalidContextsMode = errors.New("invalid JSON-LD contexts mode, it must be remote, local-first, remote-first or local-only")
+
+// embeddedContexts is the snapshot of the core W3C and iden3 JSON-LD contexts listed in contexts/contexts.txt
+//
+//go:embed contexts
+var embeddedContexts embed.FS
+
+// EmbeddedContexts returns the snapshot of the core W3C and iden3 JSON-LD contexts shipped in the binaries, under the
+// paths returned by LocalPath
+func EmbeddedContexts() fs.FS {
+	contexts, _ := fs.Sub(embeddedContexts, "contexts")
+	return contexts
+}
+
+// ContextsFactory returns a function factory of loaders of the JSON-LD contexts: the ones of f, with the embedded
+// snapshot before, after or instead of them as set by mode, or without it when mode is remote. An empty mode is
+// local-first.
+func ContextsFactory(f Factory, mode string) (Factory, error) {
+	if mode == ContextsRemote {
+		return f, nil
+	}
+	order, err := ParseLocalOrder(mode)
+	if err != nil {
+		return nil, ErrInvalidContextsMode
+	}
+	return LocalFactory(f, EmbeddedContexts(), order), nil
+}
+
+// DocumentLoader is a concurrency safe ld.DocumentLoader that loads the documents with the loaders of a factory and
+// keeps up to DocumentCacheSize of them in memory for a TTL. ld.CachingDocumentLoader can't be shared between
+// goroutines.
+type DocumentLoader struct {
+	factory Factory
+	timeout time.Duration
+	ttl     time.Duration
+	size    int
+	mu      sync.RWMutex
+	cache   map[string]cachedDocument
+}
+
+type cachedDocument struct {
+	doc       *ld.RemoteDocument
+	expiresAt time.Time
+}
+
+// NewDocumentLoader returns the ld.DocumentLoader of the merklization and the canonicalization of the credentials. It
+// loads the JSON-LD contexts with the loaders of f and keeps them for ttl, DocumentCacheTTL when it is 0.
+func NewDocumentLoader(f Factory, ttl time.Duration) *DocumentLoader {
+	if ttl <= 0 {
+		ttl = DocumentCacheTTL
+	}
+	return &DocumentLoader{factory: f, timeout: DocumentLoadTimeout, ttl: ttl, size: DocumentCacheSize, cache: make(map[string]cachedDocument)}
+}
+
+// LoadDocument returns the cached document of u or loads it. ld.DocumentLoader has no context, so the load is bounded
+// by DocumentLoadTimeout instead, as the factory may retry without a limit per attempt. The callers that have a context
+// should load the documents with WithContext.
+func (l *DocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+	return l.load(ctx, u)
+}
+
+// WithContext returns an ld.DocumentLoader that loads the documents that are not cached with ctx
+func (l *DocumentLoader) WithContext(ctx context.Context) ld.DocumentLoader {
+	return contextDocumentLoader{loader: l, ctx: ctx}
+}
+
+// Purge removes the documents of urls from the cache, or every document when there is none
+func (l *DocumentLoader) Purge(urls ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(urls) == 0 {
+		l.cache = make(map[string]cachedDocument)
+		return
+	}
+	for _, u := range urls {
+		delete(l.cache, u)
+	}
+}
+
+func (l *DocumentLoader) load(ctx context.Context, u string) (*ld.RemoteDocument, error) {
+	l.mu.RLock()
+	cached, found := l.cache[u]
+	l.mu.RUnlock()
+	if found && time.Now().Before(cached.expiresAt) {
+		return cached.doc, nil
+	}
+
+	content, _, err := l.factory(u).Load(ctx)
+	if err != nil {
+		return nil, ld.NewJsonLdError(ld.LoadingDocumentFailed, fmt.Errorf("loading document <%s>: %w", u, err))
+	}
+	document, err := ld.DocumentFromReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, ld.NewJsonLdError(ld.LoadingDocumentFailed, fmt.Errorf("parsing document <%s>: %w", u, err))
+	}
+	doc := &ld.RemoteDocument{DocumentURL: u, Document: document}
+
+	l.mu.Lock()
+	l.store(u, doc)
+	l.mu.Unlock()
+	return doc, nil
+}
+
+// store caches doc, evicting the expired documents, or the one that expires first, when the cache is full. It must
+// be called with the lock held.
+func (l *DocumentLoader) store(u string, doc *ld.RemoteDocument) {
+	now := time.Now()
+	if _, found := l.cache[u]; !found && len(l.cache) >= l.size {
+		var oldest string
+		for cachedURL, cached := range l.cache {
+			if !now.Before(cached.expiresAt) {
+				delete(l.cache, cachedURL)
+				continue
+			}
+			if oldest == "" || cached.expiresAt.Before(l.cache[oldest].expiresAt) {
+				oldest = cachedURL
+			}
+		}
+		if len(l.cache) >= l.size {
+			delete(l.cache, oldest)
+		}
+	}
+	l.cache[u] = cachedDocument{doc: doc, expiresAt: now.Add(l.ttl)}
+}
+
+// contextDocumentLoader is the ld.DocumentLoader of a DocumentLoader with the context of a request
+type contextDocumentLoader struct {
+	loader *DocumentLoader
+	ctx    context.Context
+}
+
+// LoadDocument returns the cached document of u or loads it with the context of the loader
+func (l contextDocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	return l.loader.load(l.ctx, u)
+}
@@ -0,0 +1,141 @@
+package loader
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type spyContextLoader struct {
+	called *int32 // We will count the number of times the Load function is called
+}
+
+func (s spyContextLoader) Load(_ context.Context) (schema []byte, extension string, err error) {
+	atomic.AddInt32(s.called, 1)
+	return []byte(`{"@context": {"name": "https://schema.org/name"}}`), "jsonld", nil
+}
+
+func TestDocumentLoader_LoadDocument(t *testing.T) {
+	var called int32
+	docLoader := NewDocumentLoader(func(url string) Loader { return spyContextLoader{called: &called} }, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := docLoader.LoadDocument("https://this/is/a/context")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	doc, err := docLoader.LoadDocument("https://this/is/a/context")
+	require.NoError(t, err)
+	assert.Equal(t, "https://this/is/a/context", doc.DocumentURL)
+	assert.Equal(t, map[string]any{"@context": map[string]any{"name": "https://schema.org/name"}}, doc.Document)
+	calls := atomic.LoadInt32(&called)
+	assert.GreaterOrEqual(t, calls, int32(1))
+
+	_, err = docLoader.LoadDocument("https://this/is/another/context")
+	require.NoError(t, err)
+	assert.Equal(t, calls+1, atomic.LoadInt32(&called), "a cached document is not loaded again")
+}
+
+func TestDocumentLoader_LoadDocumentFailure(t *testing.T) {
+	failing := &failingLoader{}
+	docLoader := NewDocumentLoader(func(url string) Loader { return failing }, 0)
+	_, err := docLoader.LoadDocument("https://www.w3.org/2018/credentials/v1")
+	require.Error(t, err)
+	_, err = docLoader.LoadDocument("https://www.w3.org/2018/credentials/v1")
+	require.Error(t, err)
+	assert.Equal(t, 2, failing.called, "the failures are not cached")
+}
+
+type blockingLoader struct{}
+
+func (blockingLoader) Load(ctx context.Context) (schema []byte, extension string, err error) {
+	<-ctx.Done()
+	return nil, "", ctx.Err()
+}
+
+func TestDocumentLoader_LoadDocumentTimeout(t *testing.T) {
+	docLoader := NewDocumentLoader(func(url string) Loader { return blockingLoader{} }, 0)
+	assert.Equal(t, DocumentLoadTimeout, docLoader.timeout)
+	docLoader.timeout = 10 * time.Millisecond
+
+	start := time.Now()
+	_, err := docLoader.LoadDocument("https://www.w3.org/2018/credentials/v1")
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second, "a hung load is abandoned")
+}
+
+func TestDocumentLoader_WithContext(t *testing.T) {
+	docLoader := NewDocumentLoader(func(url string) Loader { return blockingLoader{} }, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := docLoader.WithContext(ctx).LoadDocument("https://www.w3.org/2018/credentials/v1")
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second, "the load is canceled with the context")
+}
+
+func TestDocumentLoader_Cache(t *testing.T) {
+	var called int32
+	docLoader := NewDocumentLoader(func(url string) Loader { return spyContextLoader{called: &called} }, time.Hour)
+	docLoader.size = 2
+
+	load := func(u string) {
+		t.Helper()
+		_, err := docLoader.LoadDocument(u)
+		require.NoError(t, err)
+	}
+	load("https://this/is/a/context")
+	load("https://this/is/another/context")
+	load("https://this/is/a/context")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&called))
+
+	load("https://this/is/a/third/context")
+	assert.Len(t, docLoader.cache, 2, "the cache is bounded")
+	load("https://this/is/a/context")
+	assert.Equal(t, int32(4), atomic.LoadInt32(&called), "the document that expires first is evicted")
+
+	docLoader.Purge("https://this/is/a/context")
+	load("https://this/is/a/context")
+	assert.Equal(t, int32(5), atomic.LoadInt32(&called), "a purged document is loaded again")
+
+	docLoader.Purge()
+	assert.Empty(t, docLoader.cache)
+
+	docLoader.ttl = -time.Second
+	load("https://this/is/a/context")
+	load("https://this/is/a/context")
+	assert.Equal(t, int32(7), atomic.LoadInt32(&called), "an expired document is loaded again")
+}
+
+func TestContextsFactory(t *testing.T) {
+	ctx := context.Background()
+	failing := &failingLoader{}
+	remote := func(url string) Loader { return failing }
+
+	factory, err := ContextsFactory(remote, ContextsRemote)
+	require.NoError(t, err)
+	_, _, err = factory("https://www.w3.org/2018/credentials/v1").Load(ctx)
+	require.Error(t, err)
+	assert.Equal(t, 1, failing.called)
+
+	factory, err = ContextsFactory(remote, string(LocalOnly))
+	require.NoError(t, err)
+	_, _, err = factory("https://example.com/not-in-the-snapshot.jsonld").Load(ctx)
+	assert.ErrorIs(t, err, ErrNotInBundle)
+	assert.Equal(t, 1, failing.called, "local-only doesn't load the remote contexts")
+
+	_, err = ContextsFactory(remote, "embedded")
+	assert.ErrorIs(t, err, ErrInvalidContextsMode)
+}
@@ -1,8 +1,49 @@
 package loader
 
-import "github.com/iden3/go-schema-processor/loaders"
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/iden3/go-schema-processor/loaders"
+)
 
 // HTTPFactory returns an http loader
 func HTTPFactory(u string) Loader {
 	return &loaders.HTTP{URL: u}
 }
+
+type httpLoader struct {
+	url    string
+	client *http.Client
+}
+
+// Load returns the document of the url
+func (l *httpLoader) Load(ctx context.Context) (schema []byte, extension string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.url, http.NoBody)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed request to schema url <%s> with status %d", l.url, resp.StatusCode)
+	}
+	schema, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return schema, "json-ld", nil
+}
+
+// HTTPClientFactory returns a function factory of http loaders that send their requests with client, like one with the
+// outbound proxy of the node
+func HTTPClientFactory(client *http.Client) Factory {
+	return func(url string) Loader {
+		return &httpLoader{url: url, client: client}
+	}
+}
@@ -0,0 +1,42 @@
+package loader
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingTransport struct {
+	requests int
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.requests++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestHTTPClientFactory(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/kyc.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"type": "object"}`))
+	}))
+	t.Cleanup(server.Close)
+	transport := &countingTransport{}
+	factory := HTTPClientFactory(&http.Client{Transport: transport})
+
+	doc, _, err := factory(server.URL + "/kyc.json").Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"type": "object"}`), doc)
+
+	_, _, err = factory(server.URL + "/missing.json").Load(ctx)
+	assert.ErrorContains(t, err, "status 404")
+	assert.Equal(t, 2, transport.requests)
+}
@@ -0,0 +1,53 @@
+package loader
+
+import (
+	"context"
+	"strings"
+)
+
+// IPFSScheme is the prefix of the urls of the documents published in IPFS
+const IPFSScheme = "ipfs://"
+
+// IPFSFetcher fetches the documents published in IPFS, through an IPFS node or an HTTP gateway
+type IPFSFetcher interface {
+	// Cat returns the document of path, a CID optionally followed by a path inside it
+	Cat(ctx context.Context, path string) ([]byte, error)
+}
+
+type ipfs struct {
+	path    string
+	fetcher IPFSFetcher
+}
+
+// Load returns the document from IPFS
+func (l *ipfs) Load(ctx context.Context) (schema []byte, extension string, err error) {
+	doc, err := l.fetcher.Cat(ctx, l.path)
+	if err != nil {
+		return nil, "", err
+	}
+	return doc, "json", nil
+}
+
+// IsIPFS tells whether url is the url of a document published in IPFS
+func IsIPFS(url string) bool {
+	return strings.HasPrefix(url, IPFSScheme)
+}
+
+// IPFSPath returns the CID and the path inside it of an ipfs url
+func IPFSPath(url string) string {
+	return strings.TrimPrefix(url, IPFSScheme)
+}
+
+// IPFSFactory returns a function factory that fetches the ipfs:// urls with fetcher and the rest with the loaders of f.
+// A nil fetcher returns f unchanged.
+func IPFSFactory(f Factory, fetcher IPFSFetcher) Factory {
+	if fetcher == nil {
+		return f
+	}
+	return func(url string) Loader {
+		if IsIPFS(url) {
+			return &ipfs{path: IPFSPath(url), fetcher: fetcher}
+		}
+		return f(url)
+	}
+}
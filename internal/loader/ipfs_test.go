@@ -0,0 +1,53 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapFetcher map[string][]byte
+
+func (m mapFetcher) Cat(_ context.Context, path string) ([]byte, error) {
+	doc, ok := m[path]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return doc, nil
+}
+
+func TestIPFSFactory(t *testing.T) {
+	ctx := context.Background()
+	spy := &spyLoader{}
+	fetcher := mapFetcher{"bafkreischema": []byte(`{"type": "object"}`), "bafydir/kyc.json": []byte(`{}`)}
+	factory := IPFSFactory(func(url string) Loader { return spy }, fetcher)
+
+	doc, ext, err := factory("ipfs://bafkreischema").Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"type": "object"}`), doc)
+	assert.Equal(t, "json", ext)
+
+	doc, _, err = factory("ipfs://bafydir/kyc.json").Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{}`), doc)
+
+	_, _, err = factory("ipfs://bafkreiunknown").Load(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 0, spy.called)
+
+	doc, _, err = factory("https://example.com/kyc.json").Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("this is an schema content"), doc)
+	assert.Equal(t, 1, spy.called)
+}
+
+func TestIPFSFactory_WithoutFetcher(t *testing.T) {
+	spy := &spyLoader{}
+	factory := IPFSFactory(func(url string) Loader { return spy }, nil)
+	_, _, err := factory("ipfs://bafkreischema").Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, spy.called)
+}
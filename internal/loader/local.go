@@ -0,0 +1,149 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// ErrNotInBundle means the local bundle has no copy of the document of an url
+var ErrNotInBundle = errors.New("document not found in the local schema bundle")
+
+// ErrInvalidLocalOrder means the resolution order of the local schema bundle is not one of the supported ones
+var ErrInvalidLocalOrder = errors.New("invalid local schema order, it must be local-first, remote-first or local-only")
+
+// LocalOrder is the order in which the local bundle and the remote source of a document are tried
+type LocalOrder string
+
+const (
+	LocalFirst  LocalOrder = "local-first"  // LocalFirst loads the local copy when there is one, and the remote document otherwise
+	RemoteFirst LocalOrder = "remote-first" // RemoteFirst loads the remote document, and the local copy when the remote can't be loaded
+	LocalOnly   LocalOrder = "local-only"   // LocalOnly only loads local copies, for the deployments without outbound internet
+)
+
+// ParseLocalOrder is a LocalOrder constructor. An empty order is local-first.
+func ParseLocalOrder(order string) (LocalOrder, error) {
+	switch LocalOrder(order) {
+	case "", LocalFirst:
+		return LocalFirst, nil
+	case RemoteFirst:
+		return RemoteFirst, nil
+	case LocalOnly:
+		return LocalOnly, nil
+	default:
+		return "", ErrInvalidLocalOrder
+	}
+}
+
+// LocalPath returns the path in a local bundle of the copy of the document of rawURL: the host followed by the path
+// for the http and https urls, like raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json,
+// and ipfs/ followed by the CID and its path for the ipfs urls. The query and the fragment are ignored.
+func LocalPath(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	var p string
+	switch u.Scheme {
+	case "http", "https":
+		p = path.Join(u.Host, path.Clean("/"+u.Path))
+	case "ipfs":
+		p = path.Join("ipfs", u.Host, path.Clean("/"+u.Path))
+	default:
+		return "", fmt.Errorf("%w: unsupported url scheme <%s>", ErrNotInBundle, u.Scheme)
+	}
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if !fs.ValidPath(p) || p == "." {
+		return "", fmt.Errorf("%w: invalid url path <%s>", ErrNotInBundle, rawURL)
+	}
+	return p, nil
+}
+
+type local struct {
+	url    string
+	bundle fs.FS
+	remote Loader
+	order  LocalOrder
+}
+
+// Load returns the document from the bundle or from the remote loader, in the order of the loader
+func (l *local) Load(ctx context.Context) (schema []byte, extension string, err error) {
+	switch l.order {
+	case LocalOnly:
+		return l.loadLocal()
+	case RemoteFirst:
+		schema, extension, err = l.remote.Load(ctx)
+		if err == nil {
+			return schema, extension, nil
+		}
+		doc, ext, localErr := l.loadLocal()
+		if localErr != nil {
+			return nil, "", err
+		}
+		log.Warn(ctx, "remote document can't be loaded, using the local copy", "err", err, "url", l.url)
+		return doc, ext, nil
+	default:
+		doc, ext, err := l.loadLocal()
+		if err == nil {
+			return doc, ext, nil
+		}
+		if !errors.Is(err, ErrNotInBundle) {
+			return nil, "", err
+		}
+		return l.remote.Load(ctx)
+	}
+}
+
+func (l *local) loadLocal() ([]byte, string, error) {
+	p, err := LocalPath(l.url)
+	if err != nil {
+		return nil, "", err
+	}
+	doc, err := fs.ReadFile(l.bundle, p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", fmt.Errorf("%w: %s", ErrNotInBundle, l.url)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return doc, strings.TrimPrefix(path.Ext(p), "."), nil
+}
+
+// LocalFactory returns a function factory of loaders that resolve the urls in the local bundle, a directory or an
+// embedded file system, before, after or instead of loading them with the loaders of f, as set by order. The copies are
+// looked up in the paths returned by LocalPath. A nil bundle returns f unchanged.
+func LocalFactory(f Factory, bundle fs.FS, order LocalOrder) Factory {
+	if bundle == nil {
+		return f
+	}
+	return func(url string) Loader {
+		return &local{url: url, bundle: bundle, remote: f(url), order: order}
+	}
+}
+
+// LocalDirFactory is LocalFactory with the bundle in the directory dir and the order given by its name. An empty dir
+// returns f unchanged.
+func LocalDirFactory(f Factory, dir string, order string) (Factory, error) {
+	if dir == "" {
+		return f, nil
+	}
+	localOrder, err := ParseLocalOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("local schema bundle: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("local schema bundle <%s> is not a directory", dir)
+	}
+	return LocalFactory(f, os.DirFS(dir), localOrder), nil
+}
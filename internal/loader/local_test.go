@@ -0,0 +1,129 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingLoader struct {
+	called int
+}
+
+func (f *failingLoader) Load(_ context.Context) (schema []byte, extension string, err error) {
+	f.called++
+	return nil, "", errors.New("no route to host")
+}
+
+func TestLocalPath(t *testing.T) {
+	for rawURL, expected := range map[string]string{
+		"https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json": "raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+		"http://localhost:3002/v1/schemas/1/context?version=2#KYCAgeCredential":                                 "localhost:3002/v1/schemas/1/context",
+		"ipfs://bafkreischema":                 "ipfs/bafkreischema",
+		"ipfs://bafydir/kyc.json":              "ipfs/bafydir/kyc.json",
+		"https://example.com/../../etc/passwd": "example.com/etc/passwd",
+	} {
+		got, err := LocalPath(rawURL)
+		require.NoError(t, err, rawURL)
+		assert.Equal(t, expected, got)
+	}
+
+	for _, rawURL := range []string{"file:///etc/passwd", "urn:uuid:1234", "https://"} {
+		_, err := LocalPath(rawURL)
+		assert.ErrorIs(t, err, ErrNotInBundle, rawURL)
+	}
+}
+
+func TestLocalFactory(t *testing.T) {
+	ctx := context.Background()
+	const bundled = "https://example.com/schemas/kyc.json"
+	const missing = "https://example.com/schemas/age.json"
+	bundle := fstest.MapFS{"example.com/schemas/kyc.json": {Data: []byte(`{"local": true}`)}}
+
+	t.Run("local first", func(t *testing.T) {
+		spy := &spyLoader{}
+		factory := LocalFactory(func(url string) Loader { return spy }, bundle, LocalFirst)
+		doc, ext, err := factory(bundled).Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(`{"local": true}`), doc)
+		assert.Equal(t, "json", ext)
+		assert.Equal(t, 0, spy.called)
+
+		doc, _, err = factory(missing).Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("this is an schema content"), doc)
+		assert.Equal(t, 1, spy.called)
+	})
+
+	t.Run("remote first", func(t *testing.T) {
+		spy := &spyLoader{}
+		doc, _, err := LocalFactory(func(url string) Loader { return spy }, bundle, RemoteFirst)(bundled).Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("this is an schema content"), doc)
+
+		failing := &failingLoader{}
+		factory := LocalFactory(func(url string) Loader { return failing }, bundle, RemoteFirst)
+		doc, _, err = factory(bundled).Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(`{"local": true}`), doc)
+		_, _, err = factory(missing).Load(ctx)
+		assert.EqualError(t, err, "no route to host")
+		assert.Equal(t, 2, failing.called)
+	})
+
+	t.Run("local only", func(t *testing.T) {
+		spy := &spyLoader{}
+		factory := LocalFactory(func(url string) Loader { return spy }, bundle, LocalOnly)
+		doc, _, err := factory(bundled).Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(`{"local": true}`), doc)
+		_, _, err = factory(missing).Load(ctx)
+		assert.ErrorIs(t, err, ErrNotInBundle)
+		assert.Equal(t, 0, spy.called)
+	})
+}
+
+func TestParseLocalOrder(t *testing.T) {
+	order, err := ParseLocalOrder("")
+	require.NoError(t, err)
+	assert.Equal(t, LocalFirst, order)
+
+	order, err = ParseLocalOrder("local-only")
+	require.NoError(t, err)
+	assert.Equal(t, LocalOnly, order)
+
+	_, err = ParseLocalOrder("offline")
+	assert.ErrorIs(t, err, ErrInvalidLocalOrder)
+}
+
+func TestLocalDirFactory(t *testing.T) {
+	spy := &spyLoader{}
+	f := func(url string) Loader { return spy }
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "example.com", "schemas"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "example.com", "schemas", "kyc.json"), []byte(`{"local": true}`), 0o600))
+
+	factory, err := LocalDirFactory(f, dir, "local-only")
+	require.NoError(t, err)
+	doc, _, err := factory("https://example.com/schemas/kyc.json").Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"local": true}`), doc)
+
+	_, err = LocalDirFactory(f, dir, "offline")
+	assert.ErrorIs(t, err, ErrInvalidLocalOrder)
+	_, err = LocalDirFactory(f, filepath.Join(dir, "missing"), "")
+	assert.Error(t, err)
+
+	unchanged, err := LocalDirFactory(f, "", "offline")
+	require.NoError(t, err)
+	_, _, err = unchanged("https://example.com/schemas/kyc.json").Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, spy.called)
+}
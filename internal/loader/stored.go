@@ -0,0 +1,52 @@
+package loader
+
+import (
+	"context"
+)
+
+// DocumentStore returns the json schemas and JSON-LD contexts published by the node itself
+type DocumentStore interface {
+	// GetDocumentByURL returns the document published in url, nil if the store has none
+	GetDocumentByURL(ctx context.Context, url string) ([]byte, error)
+}
+
+type stored struct {
+	url    string
+	store  DocumentStore
+	loader Loader
+}
+
+// Load returns the document from the store when it has it, and from the underlying loader otherwise
+func (s *stored) Load(ctx context.Context) (schema []byte, extension string, err error) {
+	doc, err := s.store.GetDocumentByURL(ctx, s.url)
+	if err != nil {
+		return nil, "", err
+	}
+	if doc != nil {
+		return doc, "json", nil
+	}
+	return s.loader.Load(ctx)
+}
+
+// storedPurger is a stored loader whose underlying loader is a Purger
+type storedPurger struct {
+	*stored
+}
+
+// Purge purges the document from the cache of the underlying loader
+func (s *storedPurger) Purge(ctx context.Context) error {
+	return s.loader.(Purger).Purge(ctx)
+}
+
+// StoredFactory returns a function factory of loaders that look for the documents in the store before loading them
+// with the loaders of f. So the schemas of the registry of the node are not fetched through its own API.
+// The loaders are Purgers when the loaders of f are.
+func StoredFactory(f Factory, store DocumentStore) Factory {
+	return func(url string) Loader {
+		s := &stored{url: url, store: store, loader: f(url)}
+		if _, ok := s.loader.(Purger); ok {
+			return &storedPurger{stored: s}
+		}
+		return s
+	}
+}
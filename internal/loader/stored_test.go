@@ -0,0 +1,46 @@
+package loader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/pkg/cache"
+)
+
+type mapStore map[string][]byte
+
+func (m mapStore) GetDocumentByURL(_ context.Context, url string) ([]byte, error) {
+	return m[url], nil
+}
+
+func TestStored_Load(t *testing.T) {
+	ctx := context.Background()
+	spy := &spyLoader{}
+	store := mapStore{"https://issuer/v1/schemas/1/jsonschema": []byte(`{"type": "object"}`)}
+	factory := StoredFactory(func(url string) Loader { return spy }, store)
+
+	doc, _, err := factory("https://issuer/v1/schemas/1/jsonschema").Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"type": "object"}`), doc)
+	assert.Equal(t, 0, spy.called)
+
+	doc, _, err = factory("https://example.com/kyc.json").Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("this is an schema content"), doc)
+	assert.Equal(t, 1, spy.called)
+}
+
+func TestStoredFactory_Purger(t *testing.T) {
+	spy := &spyLoader{}
+	uncached := StoredFactory(func(url string) Loader { return spy }, mapStore{})
+	_, ok := uncached("https://example.com/kyc.json").(Purger)
+	assert.False(t, ok)
+
+	cached := StoredFactory(CachedFactory(func(url string) Loader { return spy }, cache.NewMemoryCache()), mapStore{})
+	purger, ok := cached("https://example.com/kyc.json").(Purger)
+	require.True(t, ok)
+	assert.NoError(t, purger.Purge(context.Background()))
+}
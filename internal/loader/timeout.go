@@ -0,0 +1,29 @@
+package loader
+
+import (
+	"context"
+	"time"
+)
+
+type timeout struct {
+	loader  Loader
+	timeout time.Duration
+}
+
+// Load calls the underlying loader with a context that expires after the timeout
+func (t *timeout) Load(ctx context.Context) (schema []byte, extension string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.loader.Load(ctx)
+}
+
+// TimeoutFactory returns a function factory that bounds the time spent by the loaders created by f.
+// A zero duration returns f unchanged.
+func TimeoutFactory(f Factory, d time.Duration) Factory {
+	if d <= 0 {
+		return f
+	}
+	return func(url string) Loader {
+		return &timeout{loader: f(url), timeout: d}
+	}
+}
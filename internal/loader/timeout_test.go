@@ -0,0 +1,28 @@
+package loader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type blockingLoader struct{}
+
+func (b *blockingLoader) Load(ctx context.Context) (schema []byte, extension string, err error) {
+	<-ctx.Done()
+	return nil, "", ctx.Err()
+}
+
+func TestTimeoutFactory(t *testing.T) {
+	factory := func(url string) Loader { return &blockingLoader{} }
+
+	_, _, err := TimeoutFactory(factory, 10*time.Millisecond)("http://this/is/an/url").Load(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = TimeoutFactory(factory, time.Hour)("http://this/is/an/url").Load(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,171 @@
+package mtprune
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// retainedRootsSQL are the roots whose nodes must be kept: the current root of the tree and, for the identity that
+// owns it, the tree roots of the states created after the cutoff, of the states not confirmed yet and of the last
+// confirmed state. $1 is the tree id, $2 its identity, $3 its type and $4 the cutoff.
+const retainedRootsSQL = `
+roots(key) AS (
+	SELECT key FROM mt_roots WHERE mt_id = $1 AND key IS NOT NULL
+	UNION
+	SELECT decode(CASE $3::int2 WHEN 0 THEN s.claims_tree_root WHEN 1 THEN s.revocation_tree_root ELSE s.root_of_roots END, 'hex')
+	FROM identity_states s
+	WHERE s.identifier = $2
+	  AND (s.created_at >= $4
+	   OR s.status <> 'confirmed'
+	   OR s.state_id = (SELECT max(state_id) FROM identity_states WHERE identifier = $2 AND status = 'confirmed'))
+),
+reachable(key) AS (
+	SELECT key FROM roots WHERE key IS NOT NULL
+	UNION
+	SELECT child.key
+	FROM reachable r
+	JOIN mt_nodes p ON p.mt_id = $1 AND p.key = r.key
+	CROSS JOIN LATERAL (VALUES (p.child_l), (p.child_r)) AS child(key)
+	WHERE child.key IS NOT NULL
+)`
+
+// Options of a pruning run
+type Options struct {
+	// Retention is how long the trees of the old states are kept. The last confirmed state is always kept.
+	Retention time.Duration
+	// DryRun only reports the orphaned nodes, nothing is deleted
+	DryRun bool
+	// Identifier limits the run to the trees of one identity. Empty prunes every tree.
+	Identifier string
+}
+
+// TreeReport is the result of pruning a merkle tree
+type TreeReport struct {
+	MTID          uint64 `json:"mtId"`
+	Identifier    string `json:"identifier"`
+	Type          uint16 `json:"type"`
+	RetainedRoots int    `json:"retainedRoots"`
+	Nodes         int64  `json:"nodes"`
+	Orphaned      int64  `json:"orphaned"`
+	OrphanedBytes int64  `json:"orphanedBytes"`
+}
+
+// Report is the result of a pruning run
+type Report struct {
+	DryRun        bool         `json:"dryRun"`
+	Cutoff        time.Time    `json:"cutoff"`
+	Trees         []TreeReport `json:"trees"`
+	Orphaned      int64        `json:"orphaned"`
+	OrphanedBytes int64        `json:"orphanedBytes"`
+}
+
+type tree struct {
+	id         uint64
+	identifier *string
+	mtType     uint16
+}
+
+// Prune removes the merkle tree nodes that are not reachable from any retained root. A node stops being reachable
+// when a newer version of the tree replaces it, and the roots of the states older than the retention are not
+// retained. Each tree is pruned in its own transaction that holds its root row, so the tree cannot be updated
+// while it is pruned. In dry run mode the orphaned nodes are only counted.
+func Prune(ctx context.Context, storage *db.Storage, opts Options) (*Report, error) {
+	report := &Report{
+		DryRun: opts.DryRun,
+		Cutoff: time.Now().UTC().Add(-opts.Retention),
+		Trees:  []TreeReport{},
+	}
+
+	trees, err := listTrees(ctx, storage, opts.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range trees {
+		tr, err := pruneTree(ctx, storage, t, report.Cutoff, opts.DryRun)
+		if err != nil {
+			return nil, fmt.Errorf("pruning merkle tree %d: %w", t.id, err)
+		}
+		if tr == nil {
+			continue
+		}
+		log.Info(ctx, "merkle tree pruned", "mtId", tr.MTID, "identifier", tr.Identifier, "type", tr.Type, "nodes", tr.Nodes, "orphaned", tr.Orphaned, "dryRun", opts.DryRun)
+		report.Trees = append(report.Trees, *tr)
+		report.Orphaned += tr.Orphaned
+		report.OrphanedBytes += tr.OrphanedBytes
+	}
+	return report, nil
+}
+
+func listTrees(ctx context.Context, storage *db.Storage, identifier string) ([]tree, error) {
+	rows, err := storage.Pgx.Query(ctx, `SELECT id, identifier, type FROM identity_mts WHERE ($1 = '' OR identifier = $1) ORDER BY id`, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("listing merkle trees: %w", err)
+	}
+	defer rows.Close()
+
+	var trees []tree
+	for rows.Next() {
+		var t tree
+		if err := rows.Scan(&t.id, &t.identifier, &t.mtType); err != nil {
+			return nil, err
+		}
+		trees = append(trees, t)
+	}
+	return trees, rows.Err()
+}
+
+// pruneTree returns nil when the tree has no root yet
+func pruneTree(ctx context.Context, storage *db.Storage, t tree, cutoff time.Time, dryRun bool) (*TreeReport, error) {
+	tx, err := storage.Pgx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var locked int
+	err = tx.QueryRow(ctx, `SELECT 1 FROM mt_roots WHERE mt_id = $1 FOR UPDATE`, t.id).Scan(&locked)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tr := &TreeReport{MTID: t.id, Type: t.mtType}
+	if t.identifier != nil {
+		tr.Identifier = *t.identifier
+	}
+
+	if err := tx.QueryRow(ctx, `WITH RECURSIVE `+retainedRootsSQL+` SELECT count(*) FROM roots WHERE key IS NOT NULL`,
+		t.id, t.identifier, t.mtType, cutoff).Scan(&tr.RetainedRoots); err != nil {
+		return nil, err
+	}
+	if err := tx.QueryRow(ctx, `SELECT count(*) FROM mt_nodes WHERE mt_id = $1`, t.id).Scan(&tr.Nodes); err != nil {
+		return nil, err
+	}
+
+	orphans := `SELECT pg_column_size(n.*) AS size FROM mt_nodes n
+		WHERE n.mt_id = $1 AND NOT EXISTS (SELECT 1 FROM reachable r WHERE r.key = n.key)`
+	if !dryRun {
+		orphans = `DELETE FROM mt_nodes n
+		WHERE n.mt_id = $1 AND NOT EXISTS (SELECT 1 FROM reachable r WHERE r.key = n.key)
+		RETURNING pg_column_size(n.*) AS size`
+	}
+	if err := tx.QueryRow(ctx, `WITH RECURSIVE `+retainedRootsSQL+`, orphans AS (`+orphans+`)
+		SELECT count(*), coalesce(sum(size), 0) FROM orphans`,
+		t.id, t.identifier, t.mtType, cutoff).Scan(&tr.Orphaned, &tr.OrphanedBytes); err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return tr, nil
+	}
+	return tr, tx.Commit(ctx)
+}
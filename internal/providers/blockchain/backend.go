@@ -0,0 +1,214 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/iden3/contracts-abi/state/go/abi"
+	"github.com/iden3/go-iden3-auth/pubsignals"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-merkletree-sql/v2"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/gateways"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+var (
+	ErrNetworkNotConfigured = errors.New("the network of the identity is not configured")        // ErrNetworkNotConfigured the identity is on a network the node doesn't publish on
+	ErrChainIDMismatch      = errors.New("the ethereum node is not on the chain of the network") // ErrChainIDMismatch the chain id of the ethereum node is not the one configured for the network
+)
+
+// Backend groups the blockchain dependencies of the issuer services. Depending on the configuration
+// they are backed by an ethereum node or by the in-process sandbox chain.
+type Backend struct {
+	StateContract   *abi.State
+	ContractAddress ethCommon.Address
+	StateStore      services.StateStore
+	Transactions    ports.TransactionService
+	Publisher       gateways.PublisherGateway
+	Resolver        pubsignals.StateResolver
+}
+
+// Networks are the blockchain backends of the networks the identities are published on, keyed by blockchain:network
+// like polygon:amoy. Each identity is published on the network of its did.
+type Networks map[string]*Backend
+
+// OpenNetworks returns the blockchain backend of each network of the configuration, after registering their DID
+// network bytes. The ethereum nodes are reached with transport. In sandbox mode all of them share the in-process
+// sandbox chain.
+func OpenNetworks(ctx context.Context, cfg *config.Configuration, storage *db.Storage, keyStore *kms.KMS, transport *http.Transport) (Networks, error) {
+	networksCfg, err := cfg.Networks()
+	if err != nil {
+		return nil, err
+	}
+	if err := RegisterNetworks(networksCfg); err != nil {
+		return nil, err
+	}
+
+	var sandboxBackend *Backend
+	if cfg.Sandbox {
+		if sandboxBackend, err = openSandboxBackend(ctx, cfg, storage); err != nil {
+			return nil, err
+		}
+	}
+
+	networks := make(Networks, len(networksCfg))
+	for key, network := range networksCfg {
+		if sandboxBackend != nil {
+			networks[key] = sandboxBackend
+			continue
+		}
+		backend, err := openBackend(ctx, network.Ethereum, network.ChainID, cfg.PublishingKeyPath, keyStore, transport)
+		if err != nil {
+			return nil, fmt.Errorf("network %s: %w", key, err)
+		}
+		networks[key] = backend
+		log.Info(ctx, "blockchain network opened", "network", key, "contract", backend.ContractAddress.Hex())
+	}
+	return networks, nil
+}
+
+// Backend returns the backend of the network of the did
+func (n Networks) Backend(did *core.DID) (*Backend, error) {
+	network := common.DIDNetwork(did)
+	backend, ok := n[network]
+	if !ok {
+		return nil, fmt.Errorf("%w <%s>", ErrNetworkNotConfigured, network)
+	}
+	return backend, nil
+}
+
+// StateContract returns the state contract of the network of the did
+func (n Networks) StateContract(did *core.DID) (*abi.State, error) {
+	backend, err := n.Backend(did)
+	if err != nil {
+		return nil, err
+	}
+	return backend.StateContract, nil
+}
+
+// StateStore returns the state store and the state contract address of the network of the did
+func (n Networks) StateStore(did *core.DID) (services.StateStore, ethCommon.Address, error) {
+	backend, err := n.Backend(did)
+	if err != nil {
+		return nil, ethCommon.Address{}, err
+	}
+	return backend.StateStore, backend.ContractAddress, nil
+}
+
+// TransactionService returns the transaction service of the network of the did
+func (n Networks) TransactionService(did *core.DID) (ports.TransactionService, error) {
+	backend, err := n.Backend(did)
+	if err != nil {
+		return nil, err
+	}
+	return backend.Transactions, nil
+}
+
+// PublishState publishes the state on the network of the identifier
+func (n Networks) PublishState(ctx context.Context, identifier *core.DID, latestState *merkletree.Hash, newState *merkletree.Hash, isOldStateGenesis bool, proof *domain.ZKProof) (*string, error) {
+	backend, err := n.Backend(identifier)
+	if err != nil {
+		return nil, err
+	}
+	return backend.Publisher.PublishState(ctx, identifier, latestState, newState, isOldStateGenesis, proof)
+}
+
+// Resolvers returns the state resolver of each network, keyed by blockchain:network
+func (n Networks) Resolvers() map[string]pubsignals.StateResolver {
+	resolvers := make(map[string]pubsignals.StateResolver, len(n))
+	for key, backend := range n {
+		resolvers[key] = backend.Resolver
+	}
+	return resolvers
+}
+
+func openBackend(ctx context.Context, cfg config.Ethereum, chainID int64, publishingKeyPath string, keyStore *kms.KMS, transport *http.Transport) (*Backend, error) {
+	if err := Ping(ctx, cfg.URL, transport); err != nil {
+		return nil, err
+	}
+
+	ethereumClient, err := InitEthConnect(cfg, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	if chainID != 0 {
+		nodeChainID, err := ethereumClient.ChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if nodeChainID.Cmp(big.NewInt(chainID)) != 0 {
+			return nil, fmt.Errorf("%w: the node is on chain %s, the network is chain %d", ErrChainIDMismatch, nodeChainID, chainID)
+		}
+	}
+
+	stateContract, err := InitEthClient(cfg.URL, cfg.ContractAddress, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionService, err := gateways.NewTransaction(ethereumClient, cfg.ConfirmationBlockCount)
+	if err != nil {
+		return nil, err
+	}
+
+	contractAddress := ethCommon.HexToAddress(cfg.ContractAddress)
+	publisherGateway, err := gateways.NewPublisherEthGateway(ethereumClient, contractAddress, keyStore, publishingKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := newETHResolver(ctx, cfg.URL, contractAddress, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Backend{
+		StateContract:   stateContract,
+		ContractAddress: contractAddress,
+		StateStore:      ethereumClient,
+		Transactions:    transactionService,
+		Publisher:       publisherGateway,
+		Resolver:        resolver,
+	}, nil
+}
+
+func openSandboxBackend(ctx context.Context, cfg *config.Configuration, storage *db.Storage) (*Backend, error) {
+	chain, err := InitSandbox(ctx, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	contractAddress := ethCommon.HexToAddress(cfg.Ethereum.ContractAddress)
+	stateContract, err := chain.State(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// every state transition is mined in its own block, so waiting for more blocks would never finish
+	transactionService, err := gateways.NewTransaction(chain, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Backend{
+		StateContract:   stateContract,
+		ContractAddress: contractAddress,
+		StateStore:      chain,
+		Transactions:    transactionService,
+		Publisher:       chain,
+		Resolver:        chain.Resolver(),
+	}, nil
+}
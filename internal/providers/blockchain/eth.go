@@ -1,20 +1,50 @@
 package blockchain
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"net/http"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
 	"github.com/iden3/contracts-abi/state/go/abi"
+	"github.com/iden3/go-iden3-auth/state"
 
 	"github.com/polygonid/sh-id-platform/internal/config"
 	"github.com/polygonid/sh-id-platform/pkg/blockchain/eth"
 )
 
+// wsBufferSize is the size of the read and write buffers of the websocket connections, the one of go-ethereum
+const wsBufferSize = 1024
+
+// Dial connects to the ethereum node of ethURL. The http and websocket connections go through the proxy and trust the
+// certificate authorities of transport, or of http.DefaultTransport when it is nil.
+func Dial(ctx context.Context, ethURL string, transport *http.Transport) (*ethclient.Client, error) {
+	if transport == nil {
+		return ethclient.DialContext(ctx, ethURL)
+	}
+	c, err := rpc.DialOptions(ctx, ethURL,
+		rpc.WithHTTPClient(&http.Client{Transport: transport}),
+		rpc.WithWebsocketDialer(websocket.Dialer{
+			Proxy:            transport.Proxy,
+			TLSClientConfig:  transport.TLSClientConfig,
+			HandshakeTimeout: transport.TLSHandshakeTimeout,
+			ReadBufferSize:   wsBufferSize,
+			WriteBufferSize:  wsBufferSize,
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(c), nil
+}
+
 // InitEthClient returns a State Contract Instance
-func InitEthClient(ethURL, contractAddress string) (*abi.State, error) {
-	ec, err := ethclient.Dial(ethURL)
+func InitEthClient(ethURL, contractAddress string, transport *http.Transport) (*abi.State, error) {
+	ec, err := Dial(context.Background(), ethURL, transport)
 	if err != nil {
 		return nil, fmt.Errorf("failed connect to eth node %s: %s", ethURL, err.Error())
 	}
@@ -27,8 +57,8 @@ func InitEthClient(ethURL, contractAddress string) (*abi.State, error) {
 }
 
 // InitEthConnect opens a new eth connection
-func InitEthConnect(cfg config.Ethereum) (*eth.Client, error) {
-	commonClient, err := ethclient.Dial(cfg.URL)
+func InitEthConnect(cfg config.Ethereum, transport *http.Transport) (*eth.Client, error) {
+	commonClient, err := Dial(context.Background(), cfg.URL, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -49,8 +79,8 @@ func InitEthConnect(cfg config.Ethereum) (*eth.Client, error) {
 }
 
 // Open returns an initialized eth Client with the given configuration
-func Open(cfg *config.Configuration) (*eth.Client, error) {
-	ethClient, err := ethclient.Dial(cfg.Ethereum.URL)
+func Open(cfg *config.Configuration, transport *http.Transport) (*eth.Client, error) {
+	ethClient, err := Dial(context.Background(), cfg.Ethereum.URL, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -67,3 +97,45 @@ func Open(cfg *config.Configuration) (*eth.Client, error) {
 		WaitBlockCycleTime:     cfg.Ethereum.WaitBlockCycleTime,
 	}), nil
 }
+
+// Ping checks the ethereum node is reachable by asking for its chain id.
+// Dialing does not connect to http endpoints, so it is the way to know the node is up on startup.
+func Ping(ctx context.Context, ethURL string, transport *http.Transport) error {
+	ec, err := Dial(ctx, ethURL, transport)
+	if err != nil {
+		return err
+	}
+	defer ec.Close()
+
+	_, err = ec.ChainID(ctx)
+	return err
+}
+
+// ethResolver resolves the states with the state contract of an ethereum node, like state.ETHResolver of
+// go-iden3-auth, that dials the node with http.DefaultTransport
+type ethResolver struct {
+	caller *state.StateCaller
+}
+
+// newETHResolver returns the state resolver of the state contract of the node of ethURL
+func newETHResolver(ctx context.Context, ethURL string, contractAddress common.Address, transport *http.Transport) (*ethResolver, error) {
+	ec, err := Dial(ctx, ethURL, transport)
+	if err != nil {
+		return nil, err
+	}
+	caller, err := state.NewStateCaller(contractAddress, ec)
+	if err != nil {
+		return nil, err
+	}
+	return &ethResolver{caller: caller}, nil
+}
+
+// Resolve returns the state of the identity
+func (r *ethResolver) Resolve(ctx context.Context, id, s *big.Int) (*state.ResolvedState, error) {
+	return state.Resolve(ctx, r.caller, id, s)
+}
+
+// ResolveGlobalRoot returns the global state
+func (r *ethResolver) ResolveGlobalRoot(ctx context.Context, s *big.Int) (*state.ResolvedState, error) {
+	return state.ResolveGlobalRoot(ctx, r.caller, s)
+}
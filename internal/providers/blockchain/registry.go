@@ -0,0 +1,47 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+)
+
+// ErrDIDNetworkConflict the DID network byte of a network is the one of another network of the method
+var ErrDIDNetworkConflict = errors.New("the DID network byte is already used")
+
+// RegisterNetworks makes go-iden3-core know the networks of the configuration with DID network bytes, like private
+// chains, so the DIDs of their identities can be created and parsed without changing the resolver and publisher code.
+// It must be called on startup, before any DID is parsed.
+func RegisterNetworks(networks config.Networks) error {
+	for key, network := range networks {
+		blockchain, networkID, _ := strings.Cut(key, ":")
+		flag := core.DIDNetworkFlag{Blockchain: core.Blockchain(blockchain), NetworkID: core.NetworkID(networkID)}
+		for method, networkByte := range network.DIDMethods {
+			if err := registerDIDNetwork(core.DIDMethod(method), flag, networkByte); err != nil {
+				return fmt.Errorf("network %s: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+func registerDIDNetwork(method core.DIDMethod, flag core.DIDNetworkFlag, networkByte byte) error {
+	flags, ok := core.DIDMethodNetwork[method]
+	if !ok {
+		return fmt.Errorf("unknown DID method <%s>", method)
+	}
+	for other, otherByte := range flags {
+		if otherByte == networkByte && other != flag {
+			return fmt.Errorf("%w: %#x is %s:%s for %s", ErrDIDNetworkConflict, networkByte, other.Blockchain, other.NetworkID, method)
+		}
+	}
+	if current, found := flags[flag]; found && current != networkByte {
+		return fmt.Errorf("%w: %s:%s is %#x for %s", ErrDIDNetworkConflict, flag.Blockchain, flag.NetworkID, current, method)
+	}
+	flags[flag] = networkByte
+	return nil
+}
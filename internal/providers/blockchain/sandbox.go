@@ -0,0 +1,36 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/blockchain/sandbox"
+)
+
+// InitSandbox returns an in-process sandbox chain with the states already published by the issuer replayed on it
+func InitSandbox(ctx context.Context, storage *db.Storage) (*sandbox.Chain, error) {
+	chain, err := sandbox.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stateRepository := repositories.NewIdentityState()
+	var published []domain.IdentityState
+	for _, status := range []domain.IdentityStatus{domain.StatusConfirmed, domain.StatusTransacted} {
+		states, err := stateRepository.GetStatesByStatus(ctx, storage.Pgx, status)
+		if err != nil {
+			return nil, err
+		}
+		published = append(published, states...)
+	}
+
+	if err := chain.Replay(ctx, published); err != nil {
+		return nil, err
+	}
+
+	log.Warn(ctx, "sandbox mode enabled: states are published to an in-process chain, nothing is sent to the blockchain", "replayedStates", len(published))
+	return chain, nil
+}
@@ -0,0 +1,43 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/event"
+	"github.com/polygonid/sh-id-platform/pkg/blockchain/statecache"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+)
+
+// CacheResolvers puts the state resolver of each network behind a stale-while-revalidate cache. The networks that
+// share a backend, like in sandbox mode, share the cache.
+func (n Networks) CacheResolvers(cfg statecache.Config) {
+	for _, backend := range n {
+		if _, cached := backend.Resolver.(*statecache.Resolver); !cached {
+			backend.Resolver = statecache.New(backend.Resolver, cfg)
+		}
+	}
+}
+
+// InvalidateStates is the handler of the StateTransitionEvents. It drops the cached states of the identity and the
+// global state roots of its network, so the verifications see the new state at once.
+func (n Networks) InvalidateStates(_ context.Context, msg pubsub.Message) error {
+	var transition event.StateTransition
+	if err := transition.Unmarshal(msg); err != nil {
+		return errors.New("invalidateStates unexpected data type")
+	}
+	did, err := core.ParseDID(transition.IssuerID)
+	if err != nil {
+		return err
+	}
+	backend, err := n.Backend(did)
+	if err != nil {
+		return err
+	}
+	if cached, ok := backend.Resolver.(*statecache.Resolver); ok {
+		cached.InvalidateIdentity(did.ID.BigInt())
+	}
+	return nil
+}
@@ -1,7 +1,9 @@
 package providers
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/vault/api"
@@ -32,3 +34,32 @@ func NewVaultClient(address, token string) (*api.Client, error) {
 
 	return client, nil
 }
+
+// PingVault returns an error if the vault server cannot be reached or is sealed
+func PingVault(ctx context.Context, client *api.Client) error {
+	health, err := client.Sys().HealthWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	if health.Sealed {
+		return errors.New("vault is sealed")
+	}
+	return nil
+}
+
+// CheckVaultAuth returns an error if vault cannot be reached, is sealed, or the token is not valid or expires within
+// minTTL. The tokens without expiration are always valid.
+func CheckVaultAuth(ctx context.Context, client *api.Client, minTTL time.Duration) error {
+	if err := PingVault(ctx, client); err != nil {
+		return err
+	}
+	token, err := client.Auth().Token().LookupSelfWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("the vault token is not valid: %w", err)
+	}
+	ttl, err := token.TokenTTL()
+	if err == nil && ttl > 0 && ttl < minTTL {
+		return fmt.Errorf("the vault token expires in %s", ttl.Round(time.Second))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// agentMessagesPruneBatch is the number of expired agent messages deleted by each statement of Prune
+const agentMessagesPruneBatch = 1000
+
+type agentMessage struct{}
+
+// NewAgentMessage returns a new agent message repository
+func NewAgentMessage() ports.AgentMessageRepository {
+	return &agentMessage{}
+}
+
+// Record stores the hash of an agent message until expiresAt. It returns false when the same message has already
+// been recorded and has not expired yet, that is, when the message is a replay. The expired messages are deleted by
+// the AgentMessagePruner.
+func (r *agentMessage) Record(ctx context.Context, conn db.Querier, hash []byte, expiresAt time.Time) (bool, error) {
+	tag, err := conn.Exec(ctx,
+		`INSERT INTO agent_messages (hash, expires_at) VALUES ($1, $2)
+		ON CONFLICT (hash) DO UPDATE SET expires_at = EXCLUDED.expires_at WHERE agent_messages.expires_at < NOW()`,
+		hash, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// AgentMessagePruner deletes the expired agent messages
+type AgentMessagePruner struct {
+	conn db.Querier
+}
+
+// NewAgentMessagePruner returns a pruner of the agent messages recorded in conn
+func NewAgentMessagePruner(conn db.Querier) *AgentMessagePruner {
+	return &AgentMessagePruner{conn: conn}
+}
+
+// Prune deletes the expired agent messages, in batches that use the expires_at index, and returns how many
+func (p *AgentMessagePruner) Prune(ctx context.Context) (int64, error) {
+	var pruned int64
+	for {
+		tag, err := p.conn.Exec(ctx, `DELETE FROM agent_messages WHERE hash IN
+			(SELECT hash FROM agent_messages WHERE expires_at < NOW() LIMIT $1)`, agentMessagesPruneBatch)
+		if err != nil {
+			return pruned, err
+		}
+		pruned += tag.RowsAffected()
+		if tag.RowsAffected() < agentMessagesPruneBatch {
+			return pruned, nil
+		}
+	}
+}
+
+// Run deletes the expired agent messages every period until the context is done
+func (p *AgentMessagePruner) Run(ctx context.Context, period time.Duration) {
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			if _, err := p.Prune(ctx); err != nil {
+				log.Error(ctx, "removing the expired agent messages", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// ErrAPIKeyDoesNotExist api key does not exist
+var ErrAPIKeyDoesNotExist = errors.New("api key does not exist")
+
+type apiKey struct{}
+
+// NewAPIKey returns a new api key repository
+func NewAPIKey() ports.APIKeyRepository {
+	return &apiKey{}
+}
+
+// Save stores a new api key
+func (r *apiKey) Save(ctx context.Context, conn db.Querier, key *domain.APIKey) error {
+	linkIDs := make([]string, len(key.LinkIDs))
+	for i, linkID := range key.LinkIDs {
+		linkIDs[i] = linkID.String()
+	}
+	_, err := conn.Exec(ctx,
+		`INSERT INTO api_keys (id, issuer_id, name, secret_hash, role, schema_types, link_ids, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		key.ID, key.IssuerDID.String(), key.Name, key.SecretHash, string(key.Role), key.SchemaTypes, linkIDs, key.CreatedAt)
+	return err
+}
+
+// GetByID returns an api key of the issuer
+func (r *apiKey) GetByID(ctx context.Context, conn db.Querier, issuerDID core.DID, id uuid.UUID) (*domain.APIKey, error) {
+	row := conn.QueryRow(ctx,
+		`SELECT id, name, secret_hash, role, schema_types, link_ids, created_at
+		FROM api_keys
+		WHERE issuer_id = $1 AND id = $2`, issuerDID.String(), id)
+	key, err := scanAPIKey(row, issuerDID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAPIKeyDoesNotExist
+	}
+	return key, err
+}
+
+// GetAll returns the api keys of the issuer, the newest first
+func (r *apiKey) GetAll(ctx context.Context, conn db.Querier, issuerDID core.DID) ([]domain.APIKey, error) {
+	rows, err := conn.Query(ctx,
+		`SELECT id, name, secret_hash, role, schema_types, link_ids, created_at
+		FROM api_keys
+		WHERE issuer_id = $1
+		ORDER BY created_at DESC`, issuerDID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]domain.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows, issuerDID)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+// Delete removes an api key of the issuer
+func (r *apiKey) Delete(ctx context.Context, conn db.Querier, issuerDID core.DID, id uuid.UUID) error {
+	res, err := conn.Exec(ctx, `DELETE FROM api_keys WHERE issuer_id = $1 AND id = $2`, issuerDID.String(), id)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrAPIKeyDoesNotExist
+	}
+	return nil
+}
+
+func scanAPIKey(row pgx.Row, issuerDID core.DID) (*domain.APIKey, error) {
+	key := domain.APIKey{IssuerDID: issuerDID}
+	var role string
+	var linkIDs []string
+	if err := row.Scan(&key.ID, &key.Name, &key.SecretHash, &role, &key.SchemaTypes, &linkIDs, &key.CreatedAt); err != nil {
+		return nil, err
+	}
+	key.Role = domain.Role(role)
+	for _, item := range linkIDs {
+		linkID, err := uuid.Parse(item)
+		if err != nil {
+			return nil, fmt.Errorf("parsing api key link id: %w", err)
+		}
+		key.LinkIDs = append(key.LinkIDs, linkID)
+	}
+	return &key, nil
+}
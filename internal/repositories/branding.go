@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+type branding struct{}
+
+// NewBranding returns a new branding repository
+func NewBranding() ports.BrandingRepository {
+	return &branding{}
+}
+
+// GetOverrides returns the branding overrides of the identity, empty when it has none
+func (r *branding) GetOverrides(ctx context.Context, conn db.Querier, issuerDID core.DID) (*domain.BrandingOverrides, error) {
+	var overrides domain.BrandingOverrides
+	err := conn.QueryRow(ctx,
+		`SELECT brandings.display_name, brandings.logo_url, brandings.primary_color, brandings.text_color
+		FROM identities LEFT JOIN brandings ON brandings.issuer_id = identities.identifier
+		WHERE identities.identifier = $1`, issuerDID.String()).
+		Scan(&overrides.DisplayName, &overrides.LogoURL, &overrides.PrimaryColor, &overrides.TextColor)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &overrides, nil
+}
+
+// SaveOverrides replaces the branding overrides of the identity
+func (r *branding) SaveOverrides(ctx context.Context, conn db.Querier, issuerDID core.DID, overrides domain.BrandingOverrides) error {
+	res, err := conn.Exec(ctx,
+		`INSERT INTO brandings (issuer_id, display_name, logo_url, primary_color, text_color, updated_at)
+		SELECT identifier, $2, $3, $4, $5, NOW() FROM identities WHERE identifier = $1
+		ON CONFLICT (issuer_id) DO UPDATE SET display_name = $2, logo_url = $3, primary_color = $4, text_color = $5, updated_at = NOW()`,
+		issuerDID.String(), overrides.DisplayName, overrides.LogoURL, overrides.PrimaryColor, overrides.TextColor)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrIdentityNotFound
+	}
+	return nil
+}
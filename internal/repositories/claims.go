@@ -5,11 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
 	"github.com/iden3/go-merkletree-sql/v2"
 	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
 	"github.com/labstack/gommon/log"
@@ -21,7 +24,13 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/db"
 )
 
-const duplicateViolationErrorCode = "23505"
+const (
+	duplicateViolationErrorCode = "23505"
+
+	// claimsBatchSize is the number of claims inserted by each statement of SaveBatch.
+	// Each claim takes 22 parameters and postgres accepts up to 65535 per statement.
+	claimsBatchSize = 1000
+)
 
 // ErrClaimDuplication claim duplication error
 var (
@@ -66,18 +75,7 @@ func (c *claims) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
 	var err error
 	id := claim.ID
 
-	if claim.MTPProof.Status == pgtype.Undefined {
-		claim.MTPProof.Status = pgtype.Null
-	}
-	if claim.Data.Status == pgtype.Undefined {
-		claim.Data.Status = pgtype.Null
-	}
-	if claim.SignatureProof.Status == pgtype.Undefined {
-		claim.SignatureProof.Status = pgtype.Null
-	}
-	if claim.CredentialStatus.Status == pgtype.Undefined {
-		claim.CredentialStatus.Status = pgtype.Null
-	}
+	setUndefinedJSONToNull(claim)
 
 	if id == uuid.Nil {
 		s := `INSERT INTO claims (identifier,
@@ -99,8 +97,9 @@ func (c *claims) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
                     core_claim,
                     index_hash,
 					mtp, 
-					link_id)
-		VALUES ($1,  $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+					link_id,
+					replaces_id)
+		VALUES ($1,  $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 		RETURNING id`
 
 		err = conn.QueryRow(ctx, s,
@@ -123,8 +122,10 @@ func (c *claims) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
 			claim.CoreClaim,
 			claim.HIndex,
 			claim.MtProof,
-			claim.LinkID).Scan(&id)
+			claim.LinkID,
+			claim.ReplacesID).Scan(&id)
 	} else {
+		// replaces_id is not updated on conflict, it is set when the replacement is created
 		s := `INSERT INTO claims (
 					id,
                     identifier,
@@ -146,10 +147,11 @@ func (c *claims) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
                     core_claim,
                     index_hash,
 					mtp,
-					link_id
+					link_id,
+					replaces_id
 		)
 		VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22
 		)
 		ON CONFLICT ON CONSTRAINT claims_pkey 
 		DO UPDATE SET 
@@ -180,7 +182,8 @@ func (c *claims) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
 			claim.CoreClaim,
 			claim.HIndex,
 			claim.MtProof,
-			claim.LinkID).Scan(&id)
+			claim.LinkID,
+			claim.ReplacesID).Scan(&id)
 	}
 
 	if err == nil {
@@ -198,6 +201,94 @@ func (c *claims) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
 	return uuid.Nil, fmt.Errorf("error saving the claim: %w", err)
 }
 
+// SaveBatch inserts new claims using multi-row inserts of up to claimsBatchSize claims, instead of one
+// statement per claim. Claims without id get a new one, the ids are returned in the same order.
+// Use a transaction as conn to insert all the claims or none.
+func (c *claims) SaveBatch(ctx context.Context, conn db.Querier, claims []*domain.Claim) ([]uuid.UUID, error) {
+	const insert = `INSERT INTO claims (id, identifier, other_identifier, expiration, updatable, version, rev_nonce,
+		signature_proof, issuer, mtp_proof, data, identity_state, schema_hash, schema_url, schema_type, credential_status,
+		revoked, core_claim, index_hash, mtp, link_id, replaces_id) VALUES `
+	const columns = 22
+
+	ids := make([]uuid.UUID, len(claims))
+	for start := 0; start < len(claims); start += claimsBatchSize {
+		end := start + claimsBatchSize
+		if end > len(claims) {
+			end = len(claims)
+		}
+
+		var sb strings.Builder
+		sb.WriteString(insert)
+		args := make([]interface{}, 0, (end-start)*columns)
+		for i, claim := range claims[start:end] {
+			if claim.ID == uuid.Nil {
+				claim.ID = uuid.New()
+			}
+			ids[start+i] = claim.ID
+			setUndefinedJSONToNull(claim)
+
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString("(")
+			for j := 1; j <= columns; j++ {
+				if j > 1 {
+					sb.WriteString(", ")
+				}
+				sb.WriteString(fmt.Sprintf("$%d", len(args)+j))
+			}
+			sb.WriteString(")")
+			args = append(args,
+				claim.ID,
+				claim.Identifier,
+				claim.OtherIdentifier,
+				claim.Expiration,
+				claim.Updatable,
+				claim.Version,
+				claim.RevNonce,
+				claim.SignatureProof,
+				claim.Issuer,
+				claim.MTPProof,
+				claim.Data,
+				claim.IdentityState,
+				claim.SchemaHash,
+				claim.SchemaURL,
+				claim.SchemaType,
+				claim.CredentialStatus,
+				claim.Revoked,
+				claim.CoreClaim,
+				claim.HIndex,
+				claim.MtProof,
+				claim.LinkID,
+				claim.ReplacesID)
+		}
+
+		if _, err := conn.Exec(ctx, sb.String(), args...); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == duplicateViolationErrorCode {
+				return nil, ErrClaimDuplication
+			}
+			return nil, fmt.Errorf("error saving the claims batch: %w", err)
+		}
+	}
+	return ids, nil
+}
+
+func setUndefinedJSONToNull(claim *domain.Claim) {
+	if claim.MTPProof.Status == pgtype.Undefined {
+		claim.MTPProof.Status = pgtype.Null
+	}
+	if claim.Data.Status == pgtype.Undefined {
+		claim.Data.Status = pgtype.Null
+	}
+	if claim.SignatureProof.Status == pgtype.Undefined {
+		claim.SignatureProof.Status = pgtype.Null
+	}
+	if claim.CredentialStatus.Status == pgtype.Undefined {
+		claim.CredentialStatus.Status = pgtype.Null
+	}
+}
+
 func (c *claims) Revoke(ctx context.Context, conn db.Querier, revocation *domain.Revocation) error {
 	_, err := conn.Exec(ctx, `INSERT INTO revocation (identifier, nonce, version, status, description) VALUES($1, $2, $3, $4, $5)`,
 		revocation.Identifier,
@@ -212,9 +303,40 @@ func (c *claims) Revoke(ctx context.Context, conn db.Querier, revocation *domain
 	return nil
 }
 
-func (c *claims) Delete(ctx context.Context, conn db.Querier, id uuid.UUID) error {
-	sql := `DELETE FROM claims WHERE id = $1`
-	cmd, err := conn.Exec(ctx, sql, id.String())
+// Delete deletes the claim of the issuer, and returns ErrClaimDoesNotExist when the issuer has no claim with the id
+func (c *claims) Delete(ctx context.Context, conn db.Querier, issuerID core.DID, id uuid.UUID) error {
+	sql := `DELETE FROM claims WHERE id = $1 AND issuer = $2 RETURNING id`
+	deleted, err := deleteClaims(ctx, conn, sql, id.String(), issuerID.String())
+	if err != nil {
+		return err
+	}
+
+	if len(deleted) == 0 {
+		return ErrClaimDoesNotExist
+	}
+
+	return nil
+}
+
+// SetExternalReference keeps the reference answered by a post-issuance hook in the claim, replacing the previous
+// reference of the same hook
+func (c *claims) SetExternalReference(ctx context.Context, conn db.Querier, id uuid.UUID, hook string, reference string) error {
+	const update = `UPDATE claims SET external_references = COALESCE(external_references, '{}'::jsonb) || jsonb_build_object($2::text, $3::text) WHERE id = $1`
+	cmd, err := conn.Exec(ctx, update, id, hook, reference)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ErrClaimDoesNotExist
+	}
+
+	return nil
+}
+
+// RevokeErased marks as revoked a claim whose data was erased. Only the revocation nonce is kept for those claims.
+func (c *claims) RevokeErased(ctx context.Context, conn db.Querier, identifier *core.DID, revocationNonce domain.RevNonceUint64) error {
+	cmd, err := conn.Exec(ctx, `UPDATE erased_claims SET revoked = true WHERE identifier = $1 AND rev_nonce = $2`, identifier.String(), revocationNonce)
 	if err != nil {
 		return err
 	}
@@ -226,6 +348,76 @@ func (c *claims) Delete(ctx context.Context, conn db.Querier, id uuid.UUID) erro
 	return nil
 }
 
+// RevokeArchived marks as revoked a claim moved to the archive tier. It is revoked again when it is restored.
+func (c *claims) RevokeArchived(ctx context.Context, conn db.Querier, identifier *core.DID, revocationNonce domain.RevNonceUint64) error {
+	cmd, err := conn.Exec(ctx, `UPDATE archived_claims SET revoked = true WHERE identifier = $1 AND rev_nonce = $2`, identifier.String(), revocationNonce)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ErrClaimDoesNotExist
+	}
+
+	return nil
+}
+
+// LockSubjectSchema takes a lock on the claims of a schema issued to a subject that is released when the transaction
+// ends. It serializes the issuance of the schema to the subject, also across issuer node processes.
+func (c *claims) LockSubjectSchema(ctx context.Context, tx pgx.Tx, issuerID core.DID, subject string, schemaURL string) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('claims_subject_schema:' || $1 || ':' || $2 || ':' || $3))`, issuerID.String(), subject, schemaURL)
+	return err
+}
+
+// GetActiveBySubjectAndSchema returns the non revoked and non expired claims of a schema issued to a subject, from the
+// oldest to the newest
+func (c *claims) GetActiveBySubjectAndSchema(ctx context.Context, conn db.Querier, issuerID core.DID, subject string, schemaURL string) ([]*domain.Claim, error) {
+	const query = `SELECT claims.id,
+				   issuer,
+				   schema_hash,
+				   schema_url,
+				   schema_type,
+				   other_identifier,
+				   expiration,
+				   updatable,
+				   claims.version,
+				   rev_nonce,
+				   signature_proof,
+				   mtp_proof,
+				   data,
+				   claims.identifier,
+				   identity_state,
+				   identity_states.status,
+				   credential_status,
+				   core_claim,
+				   revoked,
+				   mtp
+			FROM claims
+			LEFT JOIN identity_states  ON claims.identity_state = identity_states.state
+			WHERE claims.issuer = $1 AND claims.other_identifier = $2 AND claims.schema_url = $3 AND claims.revoked = false 
+				AND (COALESCE(claims.expiration, 0) = 0 OR claims.expiration > $4)
+			ORDER BY claims.issued_at, claims.id`
+
+	rows, err := conn.Query(ctx, query, issuerID.String(), subject, schemaURL, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return processClaims(rows)
+}
+
+// CountActiveBySubjectAndSchema returns the number of non revoked and non expired claims of a schema issued to a subject
+func (c *claims) CountActiveBySubjectAndSchema(ctx context.Context, conn db.Querier, issuerID core.DID, subject string, schemaURL string) (int, error) {
+	const count = `SELECT COUNT(*) 
+		FROM claims 
+		WHERE issuer = $1 AND other_identifier = $2 AND schema_url = $3 AND revoked = false 
+			AND (COALESCE(expiration, 0) = 0 OR expiration > $4)`
+	var total int
+	err := conn.QueryRow(ctx, count, issuerID.String(), subject, schemaURL, time.Now().Unix()).Scan(&total)
+	return total, err
+}
+
 func (c *claims) GetByRevocationNonce(ctx context.Context, conn db.Querier, identifier *core.DID, revocationNonce domain.RevNonceUint64) (*domain.Claim, error) {
 	claim := domain.Claim{}
 	row := conn.QueryRow(
@@ -282,6 +474,8 @@ func (c *claims) GetByRevocationNonce(ctx context.Context, conn db.Querier, iden
 	return &claim, nil
 }
 
+// FindOneClaimBySchemaHash returns a claim of the subject with the schema hash that is not revoked. The ones already in a
+// published state come first, like the auth claim that keeps signing while a new one is being published.
 func (c *claims) FindOneClaimBySchemaHash(ctx context.Context, conn db.Querier, subject *core.DID, schemaHash string) (*domain.Claim, error) {
 	var claim domain.Claim
 
@@ -308,7 +502,9 @@ func (c *claims) FindOneClaimBySchemaHash(ctx context.Context, conn db.Querier,
 		WHERE claims.identifier=$1  
 				AND ( claims.other_identifier = $1 or claims.other_identifier = '') 
 				AND claims.schema_hash = $2 
-				AND claims.revoked = false`, subject.String(), schemaHash)
+				AND claims.revoked = false
+		ORDER BY claims.mtp_proof IS NULL
+		LIMIT 1`, subject.String(), schemaHash)
 
 	err := row.Scan(&claim.ID,
 		&claim.Issuer,
@@ -336,6 +532,45 @@ func (c *claims) FindOneClaimBySchemaHash(ctx context.Context, conn db.Querier,
 	return &claim, err
 }
 
+// FindClaimsBySchemaHash returns the claims of the subject with the schema hash that are not revoked, like the auth
+// claims of the signing keys of an identity. The ones already in a published state come first.
+func (c *claims) FindClaimsBySchemaHash(ctx context.Context, conn db.Querier, subject *core.DID, schemaHash string) ([]*domain.Claim, error) {
+	rows, err := conn.Query(ctx,
+		`SELECT claims.id,
+		   issuer,
+		   schema_hash,
+		   schema_url,
+		   schema_type,
+		   other_identifier,
+		   expiration,
+		   updatable,
+		   claims.version,
+		   rev_nonce,
+		   signature_proof,
+		   mtp_proof,
+		   data,
+		   claims.identifier,
+		   identity_state,
+		   identity_states.status,
+		   credential_status,
+		   core_claim,
+		   revoked,
+		   mtp
+		FROM claims
+		LEFT JOIN identity_states ON claims.identity_state = identity_states.state
+		WHERE claims.identifier = $1
+				AND (claims.other_identifier = $1 OR claims.other_identifier = '')
+				AND claims.schema_hash = $2
+				AND claims.revoked = false
+		ORDER BY claims.mtp_proof IS NULL, claims.id`, subject.String(), schemaHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return processClaims(rows)
+}
+
 func (c *claims) RevokeNonce(ctx context.Context, conn db.Querier, revocation *domain.Revocation) error {
 	_, err := conn.Exec(ctx,
 		`	INSERT INTO revocation (identifier, nonce, version, status, description) 
@@ -371,7 +606,9 @@ func (c *claims) GetByIdAndIssuer(ctx context.Context, conn db.Querier, identifi
        				core_claim,
 					mtp,
 					revoked,
-					link_id
+					link_id,
+					replaces_id,
+					external_references
         FROM claims
         WHERE claims.identifier = $1 AND claims.id = $2`, identifier.String(), claimID).Scan(
 		&claim.ID,
@@ -393,7 +630,9 @@ func (c *claims) GetByIdAndIssuer(ctx context.Context, conn db.Querier, identifi
 		&claim.CoreClaim,
 		&claim.MtProof,
 		&claim.Revoked,
-		&claim.LinkID)
+		&claim.LinkID,
+		&claim.ReplacesID,
+		&claim.ExternalReferences)
 
 	if err != nil && err == pgx.ErrNoRows {
 		return nil, ErrClaimDoesNotExist
@@ -414,9 +653,21 @@ func (c *claims) GetAllByIssuerID(ctx context.Context, conn db.Querier, issuerID
 
 		return nil, err
 	}
-	defer rows.Close()
+	claims, err := processClaims(rows)
+	rows.Close()
+	if err != nil || filter.PII {
+		return claims, err
+	}
 
-	return processClaims(rows)
+	return claims, maskPIIAttributes(ctx, conn, issuerID, claims)
+}
+
+// CountByIssuerID returns the number of claims of the given issuer that match the filter, without its limit and offset
+func (c *claims) CountByIssuerID(ctx context.Context, conn db.Querier, issuerID core.DID, filter *ports.ClaimsFilter) (int, error) {
+	query, args := buildGetAllFilters(issuerID, filter)
+	var total int
+	err := conn.QueryRow(ctx, fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS matches", query), args...).Scan(&total)
+	return total, err
 }
 
 func (c *claims) GetNonRevokedByConnectionAndIssuerID(ctx context.Context, conn db.Querier, connID uuid.UUID, issuerID core.DID) ([]*domain.Claim, error) {
@@ -644,6 +895,35 @@ func (c *claims) UpdateState(ctx context.Context, conn db.Querier, claim *domain
 	return res.RowsAffected(), nil
 }
 
+// GetRevokedAt returns when the claim with the given revocation nonce was revoked, or nil when it is not revoked
+func (c *claims) GetRevokedAt(ctx context.Context, conn db.Querier, identifier *core.DID, revocationNonce domain.RevNonceUint64) (*time.Time, error) {
+	var revokedAt *time.Time
+	err := conn.QueryRow(ctx, `SELECT MIN(created_at) FROM revocation WHERE identifier = $1 AND nonce = $2`,
+		identifier.String(), revocationNonce).Scan(&revokedAt)
+	if err != nil {
+		return nil, err
+	}
+	return revokedAt, nil
+}
+
+// UpdateStateBatch sets the identity state of the given claims of an identity with a single statement
+func (c *claims) UpdateStateBatch(ctx context.Context, conn db.Querier, identifier *core.DID, state string, ids []uuid.UUID) (int64, error) {
+	query := "UPDATE claims SET identity_state = $1 WHERE identifier = $2 AND id = ANY($3::uuid[])"
+	res, err := conn.Exec(ctx, query, state, identifier.String(), uuidStrings(ids))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return values
+}
+
 func processClaims(rows pgx.Rows) ([]*domain.Claim, error) {
 	claims := make([]*domain.Claim, 0)
 
@@ -678,7 +958,20 @@ func processClaims(rows pgx.Rows) ([]*domain.Claim, error) {
 	return claims, rows.Err()
 }
 
+// buildGetAllQueryAndFilters returns the query of the claims that match the filter, the newest first, and its
+// arguments. The page of the filter is applied when it has a limit.
 func buildGetAllQueryAndFilters(issuerID core.DID, filter *ports.ClaimsFilter) (string, []interface{}) {
+	query, filters := buildGetAllFilters(issuerID, filter)
+	query = fmt.Sprintf("%s ORDER BY claims.issued_at DESC NULLS LAST, claims.id", query)
+	if filter.Limit > 0 {
+		filters = append(filters, filter.Limit, filter.Offset)
+		query = fmt.Sprintf("%s LIMIT $%d OFFSET $%d", query, len(filters)-1, len(filters))
+	}
+	return query, filters
+}
+
+// buildGetAllFilters returns the query of the claims that match the filter, unsorted and without its page
+func buildGetAllFilters(issuerID core.DID, filter *ports.ClaimsFilter) (string, []interface{}) {
 	query := `SELECT claims.id,
 				   issuer,
 				   schema_hash,
@@ -697,19 +990,32 @@ func buildGetAllQueryAndFilters(issuerID core.DID, filter *ports.ClaimsFilter) (
 				   identity_states.status,
 				   credential_status,
 				   core_claim,
-				   revoked,
+				   %s,
 				   mtp
 			FROM claims
 			LEFT JOIN identity_states  ON claims.identity_state = identity_states.state
 			`
+	filters := []interface{}{issuerID.String()}
+
+	// The revoked column is the current status. As of a given time, the claims revoked later were not revoked yet.
+	revoked := "claims.revoked"
+	if filter.AsOf != nil {
+		filters = append(filters, *filter.AsOf)
+		revoked = fmt.Sprintf(`EXISTS (SELECT 1 FROM revocation
+				WHERE revocation.identifier = claims.identifier AND revocation.nonce = claims.rev_nonce AND revocation.created_at <= $%d)`, len(filters))
+	}
+	query = fmt.Sprintf(query, revoked)
+
 	if filter.FTSQuery != "" {
 		query = fmt.Sprintf("%s LEFT JOIN schemas ON claims.schema_hash=schemas.hash AND claims.issuer=schemas.issuer_id ", query)
 	}
 
-	filters := []interface{}{issuerID.String()}
-	query = fmt.Sprintf("%s WHERE claims.identifier = $%d ", query, len(filters))
+	query = fmt.Sprintf("%s WHERE claims.identifier = $1 ", query)
+	if filter.AsOf != nil {
+		query = fmt.Sprintf("%s AND claims.issued_at <= $2 ", query)
+	}
 
-	query = fmt.Sprintf("%s AND claims.schema_type <> '%s' ", query, domain.AuthBJJCredentialSchemaType)
+	query = fmt.Sprintf("%s AND claims.schema_type NOT IN ('%s', '%s') ", query, domain.AuthBJJCredentialSchemaType, domain.AuthEthCredentialSchemaType)
 
 	if filter.Self != nil && *filter.Self {
 		query = fmt.Sprintf("%s and other_identifier = '' ", query)
@@ -728,7 +1034,7 @@ func buildGetAllQueryAndFilters(issuerID core.DID, filter *ports.ClaimsFilter) (
 	}
 	if filter.Revoked != nil {
 		filters = append(filters, *filter.Revoked)
-		query = fmt.Sprintf("%s and claims.revoked = $%d", query, len(filters))
+		query = fmt.Sprintf("%s and %s = $%d", query, revoked, len(filters))
 	}
 	if filter.QueryField != "" {
 		filters = append(filters, filter.QueryField, filter.QueryFieldValue)
@@ -743,7 +1049,9 @@ func buildGetAllQueryAndFilters(issuerID core.DID, filter *ports.ClaimsFilter) (
 		for _, proof := range filter.Proofs {
 			switch proof {
 			case verifiable.BJJSignatureProofType:
-				query = fmt.Sprintf("%s AND claims.signature_proof IS NOT NULL", query)
+				query = fmt.Sprintf("%s AND claims.signature_proof->>'type' = '%s'", query, verifiable.BJJSignatureProofType)
+			case domain.EcdsaSecp256k1SignatureProofType:
+				query = fmt.Sprintf("%s AND claims.signature_proof->>'type' = '%s'", query, domain.EcdsaSecp256k1SignatureProofType)
 			case verifiable.Iden3SparseMerkleTreeProofType:
 				query = fmt.Sprintf("%s AND claims.mtp_proof IS NOT NULL", query)
 			case domain.AnyProofType:
@@ -775,6 +1083,26 @@ func (c *claims) UpdateClaimMTP(ctx context.Context, conn db.Querier, claim *dom
 	return res.RowsAffected(), nil
 }
 
+// UpdateClaimMTPBatch sets the merkle tree proofs of the given claims of an identity with a single statement
+func (c *claims) UpdateClaimMTPBatch(ctx context.Context, conn db.Querier, identifier *core.DID, claims []domain.Claim) (int64, error) {
+	query := `UPDATE claims SET mtp_proof = proofs.mtp_proof::jsonb
+		FROM unnest($2::uuid[], $3::text[]) AS proofs(id, mtp_proof)
+		WHERE claims.identifier = $1 AND claims.id = proofs.id`
+	ids := make([]string, len(claims))
+	proofs := make([]*string, len(claims))
+	for i := range claims {
+		ids[i] = claims[i].ID.String()
+		if claims[i].MTPProof.Status == pgtype.Present {
+			proofs[i] = common.ToPointer(string(claims[i].MTPProof.Bytes))
+		}
+	}
+	res, err := conn.Exec(ctx, query, identifier.String(), ids, proofs)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
 // GetAuthClaimsForPublishing of all claims for identity
 func (c *claims) GetAuthClaimsForPublishing(ctx context.Context, conn db.Querier, identifier *core.DID, publishingState string, schemaHash string) ([]*domain.Claim, error) {
 	var err error
@@ -0,0 +1,438 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/claimarchive"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/pkg/blobstore"
+)
+
+const (
+	// DefaultPayloadThreshold is the size in bytes from which a credentialSubject attribute is moved to the payload store
+	DefaultPayloadThreshold = 16 * 1024
+
+	// PayloadGracePeriod is how long a payload without references is kept before it is collected, so the claims being
+	// issued with it can still reference it
+	PayloadGracePeriod = time.Hour
+
+	payloadRefKey = "$payloadRef"
+)
+
+// ErrPayloadCorrupted is returned when a credential payload read from the payload store doesn't match its hash
+var ErrPayloadCorrupted = errors.New("credential payload does not match its hash")
+
+var payloadRefMarker = []byte(`"` + payloadRefKey + `"`)
+
+// payloadRef replaces an offloaded credentialSubject attribute in the claims table. The payload is stored under its
+// sha256, so the same payload issued in many credentials is stored once. The claims that reference each payload are
+// kept in claim_payloads, and the PayloadCollector deletes the payloads no claim references.
+type payloadRef struct {
+	Ref  string `json:"$payloadRef"`
+	Size int    `json:"size"`
+}
+
+type claimsWithPayloads struct {
+	ports.ClaimsRepository
+	store     blobstore.Store
+	threshold int
+}
+
+// NewClaimsWithPayloadStore returns a claims repository that keeps the credentialSubject attributes larger than
+// threshold bytes, like embedded documents or images, in store instead of in the claims table. The table keeps their
+// hash and the claims are returned with the attributes in place. Without store it returns NewClaims.
+//
+// Offloaded attributes can't be used in the credential subject filters of GetAllByIssuerID.
+func NewClaimsWithPayloadStore(store blobstore.Store, threshold int) ports.ClaimsRepository {
+	if store == nil {
+		return NewClaims()
+	}
+	if threshold <= 0 {
+		threshold = DefaultPayloadThreshold
+	}
+	return &claimsWithPayloads{ClaimsRepository: NewClaims(), store: store, threshold: threshold}
+}
+
+func (c *claimsWithPayloads) Save(ctx context.Context, conn db.Querier, claim *domain.Claim) (uuid.UUID, error) {
+	stored, keys, err := c.offload(ctx, conn, claim)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	id, err := c.ClaimsRepository.Save(ctx, conn, stored)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, referencePayloads(ctx, conn, id, keys)
+}
+
+func (c *claimsWithPayloads) SaveBatch(ctx context.Context, conn db.Querier, claims []*domain.Claim) ([]uuid.UUID, error) {
+	stored := make([]*domain.Claim, len(claims))
+	keys := make([][]string, len(claims))
+	for i := range claims {
+		var err error
+		if stored[i], keys[i], err = c.offload(ctx, conn, claims[i]); err != nil {
+			return nil, err
+		}
+	}
+	ids, err := c.ClaimsRepository.SaveBatch(ctx, conn, stored)
+	if err != nil {
+		return nil, err
+	}
+	// SaveBatch gives an id to the claims without one, and they may be copies of the given claims
+	for i := range claims {
+		claims[i].ID = ids[i]
+		if err := referencePayloads(ctx, conn, ids[i], keys[i]); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+func (c *claimsWithPayloads) GetByRevocationNonce(ctx context.Context, conn db.Querier, identifier *core.DID, revocationNonce domain.RevNonceUint64) (*domain.Claim, error) {
+	claim, err := c.ClaimsRepository.GetByRevocationNonce(ctx, conn, identifier, revocationNonce)
+	if err != nil {
+		return nil, err
+	}
+	return claim, c.hydrate(ctx, claim)
+}
+
+func (c *claimsWithPayloads) GetByIdAndIssuer(ctx context.Context, conn db.Querier, identifier *core.DID, claimID uuid.UUID) (*domain.Claim, error) {
+	claim, err := c.ClaimsRepository.GetByIdAndIssuer(ctx, conn, identifier, claimID)
+	if err != nil {
+		return nil, err
+	}
+	return claim, c.hydrate(ctx, claim)
+}
+
+func (c *claimsWithPayloads) FindOneClaimBySchemaHash(ctx context.Context, conn db.Querier, subject *core.DID, schemaHash string) (*domain.Claim, error) {
+	claim, err := c.ClaimsRepository.FindOneClaimBySchemaHash(ctx, conn, subject, schemaHash)
+	if err != nil {
+		return nil, err
+	}
+	return claim, c.hydrate(ctx, claim)
+}
+
+func (c *claimsWithPayloads) FindClaimsBySchemaHash(ctx context.Context, conn db.Querier, subject *core.DID, schemaHash string) ([]*domain.Claim, error) {
+	claims, err := c.ClaimsRepository.FindClaimsBySchemaHash(ctx, conn, subject, schemaHash)
+	if err != nil {
+		return nil, err
+	}
+	return claims, c.hydrateAll(ctx, claims)
+}
+
+func (c *claimsWithPayloads) GetAllByIssuerID(ctx context.Context, conn db.Querier, identifier core.DID, filter *ports.ClaimsFilter) ([]*domain.Claim, error) {
+	claims, err := c.ClaimsRepository.GetAllByIssuerID(ctx, conn, identifier, filter)
+	if err != nil {
+		return nil, err
+	}
+	return claims, c.hydrateAll(ctx, claims)
+}
+
+func (c *claimsWithPayloads) GetNonRevokedByConnectionAndIssuerID(ctx context.Context, conn db.Querier, connID uuid.UUID, issuerID core.DID) ([]*domain.Claim, error) {
+	claims, err := c.ClaimsRepository.GetNonRevokedByConnectionAndIssuerID(ctx, conn, connID, issuerID)
+	if err != nil {
+		return nil, err
+	}
+	return claims, c.hydrateAll(ctx, claims)
+}
+
+func (c *claimsWithPayloads) GetAllByState(ctx context.Context, conn db.Querier, did *core.DID, state *merkletree.Hash) ([]domain.Claim, error) {
+	claims, err := c.ClaimsRepository.GetAllByState(ctx, conn, did, state)
+	if err != nil {
+		return nil, err
+	}
+	for i := range claims {
+		if err := c.hydrate(ctx, &claims[i]); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
+
+func (c *claimsWithPayloads) GetAllByStateWithMTProof(ctx context.Context, conn db.Querier, did *core.DID, state *merkletree.Hash) ([]domain.Claim, error) {
+	claims, err := c.ClaimsRepository.GetAllByStateWithMTProof(ctx, conn, did, state)
+	if err != nil {
+		return nil, err
+	}
+	for i := range claims {
+		if err := c.hydrate(ctx, &claims[i]); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
+
+func (c *claimsWithPayloads) GetAuthClaimsForPublishing(ctx context.Context, conn db.Querier, identifier *core.DID, publishingState string, schemaHash string) ([]*domain.Claim, error) {
+	claims, err := c.ClaimsRepository.GetAuthClaimsForPublishing(ctx, conn, identifier, publishingState, schemaHash)
+	if err != nil {
+		return nil, err
+	}
+	return claims, c.hydrateAll(ctx, claims)
+}
+
+func (c *claimsWithPayloads) GetClaimsIssuedForUser(ctx context.Context, conn db.Querier, identifier core.DID, userDID core.DID, linkID uuid.UUID) ([]*domain.Claim, error) {
+	claims, err := c.ClaimsRepository.GetClaimsIssuedForUser(ctx, conn, identifier, userDID, linkID)
+	if err != nil {
+		return nil, err
+	}
+	return claims, c.hydrateAll(ctx, claims)
+}
+
+func (c *claimsWithPayloads) GetByStateIDWithMTPProof(ctx context.Context, conn db.Querier, did *core.DID, state string) ([]*domain.Claim, error) {
+	claims, err := c.ClaimsRepository.GetByStateIDWithMTPProof(ctx, conn, did, state)
+	if err != nil {
+		return nil, err
+	}
+	return claims, c.hydrateAll(ctx, claims)
+}
+
+// offload returns the claim to store and the keys of its offloaded payloads: the given claim when no attribute is
+// larger than the threshold, or a copy whose large attributes are replaced by their payloadRef once they are in the
+// payload store. The payloads are touched before they are stored, so the collector doesn't delete them meanwhile.
+func (c *claimsWithPayloads) offload(ctx context.Context, conn db.Querier, claim *domain.Claim) (*domain.Claim, []string, error) {
+	if len(claim.Data.Bytes) <= c.threshold {
+		return claim, nil, nil
+	}
+	credential, subject, err := splitCredentialSubject(claim.Data.Bytes)
+	if err != nil || subject == nil {
+		return claim, nil, nil // not a credential, stored as is
+	}
+
+	var keys []string
+	for name, value := range subject {
+		if name == "id" || name == "type" || len(value) <= c.threshold || bytes.Contains(value, payloadRefMarker) {
+			continue
+		}
+		hash := sha256.Sum256(value)
+		key := hex.EncodeToString(hash[:])
+		if _, err := conn.Exec(ctx, `INSERT INTO payloads (key, updated_at) VALUES ($1, NOW())
+			ON CONFLICT (key) DO UPDATE SET updated_at = NOW()`, key); err != nil {
+			return nil, nil, fmt.Errorf("touching the %s payload: %w", name, err)
+		}
+		if err := c.store.Put(ctx, key, value); err != nil {
+			return nil, nil, fmt.Errorf("storing the %s payload: %w", name, err)
+		}
+		if subject[name], err = json.Marshal(payloadRef{Ref: key, Size: len(value)}); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return claim, nil, nil
+	}
+
+	data, err := joinCredentialSubject(credential, subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	stored := *claim
+	stored.Data = pgtype.JSONB{Bytes: data, Status: pgtype.Present}
+	return &stored, keys, nil
+}
+
+// referencePayloads keeps that the claim references the payloads
+func referencePayloads(ctx context.Context, conn db.Querier, claimID uuid.UUID, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := conn.Exec(ctx, `INSERT INTO claim_payloads (claim_id, payload_key) SELECT $1, unnest($2::text[])
+		ON CONFLICT DO NOTHING`, claimID, keys)
+	return err
+}
+
+// releasePayloads drops the references of the deleted claims to their payloads, the payloads left without references
+// are deleted by the PayloadCollector. Every statement that deletes claims calls it, the archive that moves them
+// doesn't.
+func releasePayloads(ctx context.Context, conn db.Querier, claimIDs []uuid.UUID) error {
+	if len(claimIDs) == 0 {
+		return nil
+	}
+	_, err := conn.Exec(ctx, `DELETE FROM claim_payloads WHERE claim_id = ANY($1::uuid[])`, uuidStrings(claimIDs))
+	return err
+}
+
+// deleteClaims runs a statement that deletes claims returning their ids, and releases their payloads
+func deleteClaims(ctx context.Context, conn db.Querier, sql string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, releasePayloads(ctx, conn, ids)
+}
+
+// PayloadCollector deletes from the payload store the payloads that no claim references anymore
+type PayloadCollector struct {
+	conn  db.Querier
+	store blobstore.Store
+}
+
+// NewPayloadCollector returns a collector of the payloads of store
+func NewPayloadCollector(conn db.Querier, store blobstore.Store) *PayloadCollector {
+	return &PayloadCollector{conn: conn, store: store}
+}
+
+// Collect deletes the payloads without references for longer than PayloadGracePeriod and returns how many. Each
+// payload row is deleted in a transaction that is committed after its blob is deleted, so a claim issued with the
+// same payload meanwhile waits for it and stores the blob again.
+func (p *PayloadCollector) Collect(ctx context.Context) (int, error) {
+	// the references of the claims archived before they were kept
+	if err := claimarchive.FillSubjects(ctx, p.conn, ""); err != nil {
+		return 0, fmt.Errorf("filling the archived claims: %w", err)
+	}
+
+	const unreferenced = `updated_at < $1 AND NOT EXISTS (SELECT 1 FROM claim_payloads r WHERE r.payload_key = p.key)`
+	cutoff := time.Now().Add(-PayloadGracePeriod)
+	rows, err := p.conn.Query(ctx, `SELECT key FROM payloads p WHERE `+unreferenced, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	collected := 0
+	for _, key := range keys {
+		err := p.conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+			cmd, err := tx.Exec(ctx, `DELETE FROM payloads p WHERE key = $2 AND `+unreferenced, cutoff, key)
+			if err != nil || cmd.RowsAffected() == 0 {
+				return err
+			}
+			if err := p.store.Delete(ctx, key); err != nil {
+				return err
+			}
+			collected++
+			return nil
+		})
+		if err != nil {
+			return collected, fmt.Errorf("collecting payload %s: %w", key, err)
+		}
+	}
+	return collected, nil
+}
+
+// Run collects the payloads every period until ctx is done
+func (p *PayloadCollector) Run(ctx context.Context, period time.Duration) {
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			if collected, err := p.Collect(ctx); err != nil {
+				log.Error(ctx, "collecting the unreferenced payloads", "err", err)
+			} else if collected > 0 {
+				log.Info(ctx, "unreferenced payloads collected", "payloads", collected)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (c *claimsWithPayloads) hydrateAll(ctx context.Context, claims []*domain.Claim) error {
+	for _, claim := range claims {
+		if err := c.hydrate(ctx, claim); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hydrate puts back the offloaded attributes of the claim
+func (c *claimsWithPayloads) hydrate(ctx context.Context, claim *domain.Claim) error {
+	if claim == nil || !bytes.Contains(claim.Data.Bytes, payloadRefMarker) {
+		return nil
+	}
+	credential, subject, err := splitCredentialSubject(claim.Data.Bytes)
+	if err != nil || subject == nil {
+		return err
+	}
+
+	for name, value := range subject {
+		var ref payloadRef
+		if !bytes.Contains(value, payloadRefMarker) || json.Unmarshal(value, &ref) != nil || ref.Ref == "" {
+			continue
+		}
+		payload, err := c.store.Get(ctx, ref.Ref)
+		if err != nil {
+			return fmt.Errorf("loading the %s payload of claim %s: %w", name, claim.ID, err)
+		}
+		hash := sha256.Sum256(payload)
+		if hex.EncodeToString(hash[:]) != ref.Ref {
+			return fmt.Errorf("%w: %s of claim %s", ErrPayloadCorrupted, name, claim.ID)
+		}
+		subject[name] = payload
+	}
+
+	data, err := joinCredentialSubject(credential, subject)
+	if err != nil {
+		return err
+	}
+	claim.Data = pgtype.JSONB{Bytes: data, Status: pgtype.Present}
+	return nil
+}
+
+// splitCredentialSubject returns the fields of the credential and the attributes of its credentialSubject, which
+// is nil if the credential has no credentialSubject object
+func splitCredentialSubject(data []byte) (map[string]json.RawMessage, map[string]json.RawMessage, error) {
+	var credential map[string]json.RawMessage
+	if err := json.Unmarshal(data, &credential); err != nil {
+		return nil, nil, err
+	}
+	var subject map[string]json.RawMessage
+	if raw, ok := credential["credentialSubject"]; ok {
+		if err := json.Unmarshal(raw, &subject); err != nil {
+			return credential, nil, nil
+		}
+	}
+	return credential, subject, nil
+}
+
+func joinCredentialSubject(credential map[string]json.RawMessage, subject map[string]json.RawMessage) ([]byte, error) {
+	raw, err := json.Marshal(subject)
+	if err != nil {
+		return nil, err
+	}
+	credential["credentialSubject"] = raw
+	return json.Marshal(credential)
+}
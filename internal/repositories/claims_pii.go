@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgtype"
+	"github.com/lib/pq"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// maskPIIAttributes replaces the values of the PII attributes of the claims schemas by domain.MaskedValue
+func maskPIIAttributes(ctx context.Context, conn db.Querier, issuerID core.DID, claims []*domain.Claim) error {
+	if len(claims) == 0 {
+		return nil
+	}
+	piiAttributes, err := piiAttributesBySchemaURL(ctx, conn, issuerID, claims)
+	if err != nil || len(piiAttributes) == 0 {
+		return err
+	}
+	for _, claim := range claims {
+		attrs, ok := piiAttributes[claim.SchemaURL]
+		if !ok {
+			continue
+		}
+		if err := maskClaimData(claim, attrs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// piiAttributesBySchemaURL returns the PII attributes of the last imported schema of each claim schema url that has them
+func piiAttributesBySchemaURL(ctx context.Context, conn db.Querier, issuerID core.DID, claims []*domain.Claim) (map[string]domain.SchemaAttrs, error) {
+	urls := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, claim := range claims {
+		if !seen[claim.SchemaURL] {
+			seen[claim.SchemaURL] = true
+			urls = append(urls, claim.SchemaURL)
+		}
+	}
+
+	const query = `SELECT DISTINCT ON (url) url, pii_attributes 
+		FROM schemas 
+		WHERE issuer_id = $1 AND url = ANY($2) AND pii_attributes IS NOT NULL
+		ORDER BY url, created_at DESC`
+	rows, err := conn.Query(ctx, query, issuerID.String(), pq.Array(urls))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	piiAttributes := make(map[string]domain.SchemaAttrs)
+	for rows.Next() {
+		var url string
+		var attrs []string
+		if err := rows.Scan(&url, &attrs); err != nil {
+			return nil, err
+		}
+		if len(attrs) > 0 {
+			piiAttributes[url] = attrs
+		}
+	}
+	return piiAttributes, rows.Err()
+}
+
+func maskClaimData(claim *domain.Claim, attrs domain.SchemaAttrs) error {
+	var credential map[string]json.RawMessage
+	if err := json.Unmarshal(claim.Data.Bytes, &credential); err != nil {
+		return nil // not a credential, nothing to mask
+	}
+	raw, ok := credential["credentialSubject"]
+	if !ok {
+		return nil
+	}
+	var subject map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&subject); err != nil {
+		return nil
+	}
+
+	masked, err := json.Marshal(attrs.Mask(subject))
+	if err != nil {
+		return err
+	}
+	credential["credentialSubject"] = masked
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return err
+	}
+	claim.Data = pgtype.JSONB{Bytes: data, Status: pgtype.Present}
+	return nil
+}
@@ -27,6 +27,7 @@ type dbConnection struct {
 	UserDoc    pgtype.JSONB
 	CreatedAt  time.Time
 	ModifiedAt time.Time
+	LastSeen   *time.Time
 }
 
 type dbConnectionWithCredentials struct {
@@ -44,15 +45,27 @@ func NewConnections() ports.ConnectionsRepository {
 // Save stores in the database the given connection and updates the modified at in case already exists
 func (c *connections) Save(ctx context.Context, conn db.Querier, connection *domain.Connection) (uuid.UUID, error) {
 	var id uuid.UUID
-	sql := `INSERT INTO connections (id,issuer_id, user_id, issuer_doc, user_doc,created_at,modified_at)
-			VALUES($1, $2, $3, $4,$5,$6,$7) ON CONFLICT ON CONSTRAINT connections_issuer_user_key DO
-			UPDATE SET issuer_id=$2, user_id=$3, issuer_doc=$4, user_doc=$5, modified_at = $7
+	sql := `INSERT INTO connections (id,issuer_id, user_id, issuer_doc, user_doc,created_at,modified_at,last_seen)
+			VALUES($1, $2, $3, $4,$5,$6,$7,$7) ON CONFLICT ON CONSTRAINT connections_issuer_user_key DO
+			UPDATE SET issuer_id=$2, user_id=$3, issuer_doc=$4, user_doc=$5, modified_at = $7, last_seen = $7
 			RETURNING id`
 	err := conn.QueryRow(ctx, sql, connection.ID, connection.IssuerDID.String(), connection.UserDID.String(), connection.IssuerDoc, connection.UserDoc, connection.CreatedAt, connection.ModifiedAt).Scan(&id)
 
 	return id, err
 }
 
+// Seen records that the user contacted the issuer at the given time, creating the connection if it doesn't exist
+func (c *connections) Seen(ctx context.Context, conn db.Querier, issuerDID core.DID, userDID core.DID, at time.Time) (uuid.UUID, error) {
+	var id uuid.UUID
+	sql := `INSERT INTO connections (id, issuer_id, user_id, created_at, modified_at, last_seen)
+			VALUES($1, $2, $3, $4, $4, $4) ON CONFLICT ON CONSTRAINT connections_issuer_user_key DO
+			UPDATE SET last_seen = $4
+			RETURNING id`
+	err := conn.QueryRow(ctx, sql, uuid.New(), issuerDID.String(), userDID.String(), at).Scan(&id)
+
+	return id, err
+}
+
 func (c *connections) Delete(ctx context.Context, conn db.Querier, id uuid.UUID, issuerDID core.DID) error {
 	sql := `DELETE FROM connections WHERE id = $1 AND issuer_id = $2`
 	cmd, err := conn.Exec(ctx, sql, id.String(), issuerDID.String())
@@ -68,8 +81,8 @@ func (c *connections) Delete(ctx context.Context, conn db.Querier, id uuid.UUID,
 }
 
 func (c *connections) DeleteCredentials(ctx context.Context, conn db.Querier, id uuid.UUID, issuerID core.DID) error {
-	sql := `DELETE FROM claims USING connections WHERE claims.issuer = connections.issuer_id AND claims.other_identifier = connections.user_id AND connections.id = $1 AND connections.issuer_id = $2`
-	_, err := conn.Exec(ctx, sql, id.String(), issuerID.String())
+	sql := `DELETE FROM claims USING connections WHERE claims.issuer = connections.issuer_id AND claims.other_identifier = connections.user_id AND connections.id = $1 AND connections.issuer_id = $2 RETURNING claims.id`
+	_, err := deleteClaims(ctx, conn, sql, id.String(), issuerID.String())
 
 	return err
 }
@@ -77,7 +90,7 @@ func (c *connections) DeleteCredentials(ctx context.Context, conn db.Querier, id
 func (c *connections) GetByIDAndIssuerID(ctx context.Context, conn db.Querier, id uuid.UUID, issuerID core.DID) (*domain.Connection, error) {
 	connection := dbConnection{}
 	err := conn.QueryRow(ctx,
-		`SELECT id, issuer_id,user_id,issuer_doc,user_doc,created_at,modified_at 
+		`SELECT id, issuer_id,user_id,issuer_doc,user_doc,created_at,modified_at,last_seen 
 				FROM connections 
 				WHERE connections.id = $1 AND connections.issuer_id = $2`, id.String(), issuerID.String()).Scan(
 		&connection.ID,
@@ -87,6 +100,7 @@ func (c *connections) GetByIDAndIssuerID(ctx context.Context, conn db.Querier, i
 		&connection.UserDoc,
 		&connection.CreatedAt,
 		&connection.ModifiedAt,
+		&connection.LastSeen,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -101,7 +115,7 @@ func (c *connections) GetByIDAndIssuerID(ctx context.Context, conn db.Querier, i
 func (c *connections) GetByUserID(ctx context.Context, conn db.Querier, issuerDID core.DID, userDID core.DID) (*domain.Connection, error) {
 	connection := dbConnection{}
 	err := conn.QueryRow(ctx,
-		`SELECT id, issuer_id,user_id,issuer_doc,user_doc,created_at,modified_at 
+		`SELECT id, issuer_id,user_id,issuer_doc,user_doc,created_at,modified_at,last_seen 
 				FROM connections 
 				WHERE   connections.issuer_id = $1 AND  connections.user_id = $2`, issuerDID.String(), userDID.String()).Scan(
 		&connection.ID,
@@ -111,6 +125,7 @@ func (c *connections) GetByUserID(ctx context.Context, conn db.Querier, issuerDI
 		&connection.UserDoc,
 		&connection.CreatedAt,
 		&connection.ModifiedAt,
+		&connection.LastSeen,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -123,7 +138,7 @@ func (c *connections) GetByUserID(ctx context.Context, conn db.Querier, issuerDI
 }
 
 func (c *connections) GetAllByIssuerID(ctx context.Context, conn db.Querier, issuerDID core.DID, query string) ([]*domain.Connection, error) {
-	all := `SELECT id, issuer_id,user_id,issuer_doc,user_doc,created_at,modified_at 
+	all := `SELECT id, issuer_id,user_id,issuer_doc,user_doc,created_at,modified_at,last_seen 
 FROM connections 
 WHERE connections.issuer_id = $1`
 
@@ -143,7 +158,7 @@ WHERE connections.issuer_id = $1`
 	domainConns := make([]*domain.Connection, 0)
 	dbConn := dbConnection{}
 	for rows.Next() {
-		if err := rows.Scan(&dbConn.ID, &dbConn.IssuerDID, &dbConn.UserDID, &dbConn.IssuerDoc, &dbConn.UserDoc, &dbConn.CreatedAt, &dbConn.ModifiedAt); err != nil {
+		if err := rows.Scan(&dbConn.ID, &dbConn.IssuerDID, &dbConn.UserDID, &dbConn.IssuerDoc, &dbConn.UserDoc, &dbConn.CreatedAt, &dbConn.ModifiedAt, &dbConn.LastSeen); err != nil {
 			return nil, err
 		}
 		domainConn, err := toConnectionDomain(&dbConn)
@@ -175,6 +190,7 @@ func buildGetAllWithCredentialsQueryAndFilters(issuerDID core.DID, query string)
        			   connections.user_doc,
        			   connections.created_at,
        			   connections.modified_at,
+       			   connections.last_seen,
 				   claims.id,
 				   claims.issuer,
 				   claims.schema_hash,
@@ -235,6 +251,7 @@ func toConnectionsWithCredentials(rows pgx.Rows) ([]*domain.Connection, error) {
 			&dbConn.UserDoc,
 			&dbConn.CreatedAt,
 			&dbConn.ModifiedAt,
+			&dbConn.LastSeen,
 			&dbConn.dbClaim.ID,
 			&dbConn.Issuer,
 			&dbConn.SchemaHash,
@@ -307,6 +324,7 @@ func toConnectionDomain(c *dbConnection) (*domain.Connection, error) {
 		UserDID:    *usrDID,
 		CreatedAt:  c.CreatedAt,
 		ModifiedAt: c.ModifiedAt,
+		LastSeen:   c.LastSeen,
 	}
 
 	if err := c.UserDoc.AssignTo(&conn.UserDoc); err != nil {
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+var (
+	// ErrCredentialEventDoesNotExist the issuer has no credential events
+	ErrCredentialEventDoesNotExist = errors.New("credential event does not exist")
+	// ErrCredentialEventAnchorDoesNotExist the credential log of the issuer was never anchored
+	ErrCredentialEventAnchorDoesNotExist = errors.New("credential event anchor does not exist")
+)
+
+const (
+	credentialEventColumns       = `issuer, seq, claim_id, event, created_at, prev_hash, hash`
+	credentialEventAnchorColumns = `issuer, seq, hash, state, created_at`
+)
+
+type credentialEvent struct{}
+
+// NewCredentialEvent returns a new credential event repository
+func NewCredentialEvent() ports.CredentialEventRepository {
+	return &credentialEvent{}
+}
+
+// GetHead returns the last event of the issuer
+func (r *credentialEvent) GetHead(ctx context.Context, conn db.Querier, issuerDID core.DID) (*domain.CredentialEvent, error) {
+	var e domain.CredentialEvent
+	err := conn.QueryRow(ctx, `SELECT `+credentialEventColumns+` FROM credential_events WHERE issuer = $1 ORDER BY seq DESC LIMIT 1`, issuerDID.String()).
+		Scan(&e.Issuer, &e.Seq, &e.ClaimID, &e.Event, &e.CreatedAt, &e.PrevHash, &e.Hash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCredentialEventDoesNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// GetEvents returns up to limit events of the issuer after the afterSeq one, in order
+func (r *credentialEvent) GetEvents(ctx context.Context, conn db.Querier, issuerDID core.DID, afterSeq int64, limit int) ([]domain.CredentialEvent, error) {
+	rows, err := conn.Query(ctx, `SELECT `+credentialEventColumns+` FROM credential_events WHERE issuer = $1 AND seq > $2 ORDER BY seq LIMIT $3`,
+		issuerDID.String(), afterSeq, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var events []domain.CredentialEvent
+	for rows.Next() {
+		var e domain.CredentialEvent
+		if err := rows.Scan(&e.Issuer, &e.Seq, &e.ClaimID, &e.Event, &e.CreatedAt, &e.PrevHash, &e.Hash); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// GetLastAnchor returns the last anchor of the credential log of the issuer
+func (r *credentialEvent) GetLastAnchor(ctx context.Context, conn db.Querier, issuerDID core.DID) (*domain.CredentialEventAnchor, error) {
+	anchors, err := r.anchors(ctx, conn, `SELECT `+credentialEventAnchorColumns+` FROM credential_event_anchors WHERE issuer = $1 ORDER BY seq DESC LIMIT 1`, issuerDID.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(anchors) == 0 {
+		return nil, ErrCredentialEventAnchorDoesNotExist
+	}
+	return &anchors[0], nil
+}
+
+// GetAnchors returns the anchors of the credential log of the issuer, the oldest first
+func (r *credentialEvent) GetAnchors(ctx context.Context, conn db.Querier, issuerDID core.DID) ([]domain.CredentialEventAnchor, error) {
+	return r.anchors(ctx, conn, `SELECT `+credentialEventAnchorColumns+` FROM credential_event_anchors WHERE issuer = $1 ORDER BY seq`, issuerDID.String())
+}
+
+// SaveAnchor records the head of the credential log added to the claims tree of the state
+func (r *credentialEvent) SaveAnchor(ctx context.Context, conn db.Querier, anchor *domain.CredentialEventAnchor) error {
+	_, err := conn.Exec(ctx, `INSERT INTO credential_event_anchors (issuer, seq, hash, state) VALUES ($1, $2, $3, $4)`,
+		anchor.Issuer, anchor.Seq, anchor.Hash, anchor.State)
+	return err
+}
+
+func (r *credentialEvent) anchors(ctx context.Context, conn db.Querier, sql string, args ...interface{}) ([]domain.CredentialEventAnchor, error) {
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var anchors []domain.CredentialEventAnchor
+	for rows.Next() {
+		var a domain.CredentialEventAnchor
+		if err := rows.Scan(&a.Issuer, &a.Seq, &a.Hash, &a.State, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		anchors = append(anchors, a)
+	}
+	return anchors, rows.Err()
+}
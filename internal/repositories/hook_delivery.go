@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+type hookDelivery struct{}
+
+// NewHookDelivery returns a new hook delivery repository
+func NewHookDelivery() ports.HookDeliveryRepository {
+	return &hookDelivery{}
+}
+
+// Save stores the delivery and removes the oldest deliveries of the issuer, keeping the last keep of them
+func (r *hookDelivery) Save(ctx context.Context, conn db.Querier, delivery *domain.HookDelivery, keep int) error {
+	_, err := conn.Exec(ctx,
+		`INSERT INTO hook_deliveries (id, issuer_id, hook, url, test, request, status, response, error, duration_ms, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		delivery.ID, delivery.IssuerDID.String(), delivery.Hook, delivery.URL, delivery.Test, delivery.Request,
+		delivery.Status, delivery.Response, delivery.Error, delivery.Duration.Milliseconds(), delivery.CreatedAt)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Exec(ctx,
+		`DELETE FROM hook_deliveries WHERE issuer_id = $1 AND id NOT IN (
+			SELECT id FROM hook_deliveries WHERE issuer_id = $1 ORDER BY created_at DESC LIMIT $2)`,
+		delivery.IssuerDID.String(), keep)
+	return err
+}
+
+// GetAll returns the deliveries of the issuer, the newest first
+func (r *hookDelivery) GetAll(ctx context.Context, conn db.Querier, issuerDID core.DID) ([]domain.HookDelivery, error) {
+	rows, err := conn.Query(ctx,
+		`SELECT id, hook, url, test, request, status, response, error, duration_ms, created_at
+		FROM hook_deliveries
+		WHERE issuer_id = $1
+		ORDER BY created_at DESC`, issuerDID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.HookDelivery, 0)
+	for rows.Next() {
+		delivery := domain.HookDelivery{IssuerDID: issuerDID}
+		var durationMS int64
+		if err := rows.Scan(&delivery.ID, &delivery.Hook, &delivery.URL, &delivery.Test, &delivery.Request, &delivery.Status,
+			&delivery.Response, &delivery.Error, &durationMS, &delivery.CreatedAt); err != nil {
+			return nil, err
+		}
+		delivery.Duration = time.Duration(durationMS) * time.Millisecond
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
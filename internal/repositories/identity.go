@@ -2,15 +2,20 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
 
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/db"
 )
 
+// ErrIdentityNotFound identity does not exist
+var ErrIdentityNotFound = errors.New("identity not found")
+
 type identity struct{}
 
 // NewIdentity TODO
@@ -187,3 +192,29 @@ func (i *identity) HasUnprocessedAndFailedStatesByID(ctx context.Context, conn d
 
 	return res > 0, nil
 }
+
+// GetDefaultProofTypes returns the proof types of the identity credentials when the request does not set them.
+// It returns nil if the identity has no defaults.
+func (i *identity) GetDefaultProofTypes(ctx context.Context, conn db.Querier, identifier core.DID) (domain.ProofTypes, error) {
+	var proofTypes []string
+	err := conn.QueryRow(ctx, `SELECT default_proof_types FROM identities WHERE identifier = $1`, identifier.String()).Scan(&proofTypes)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return domain.ProofTypesFromStrings(proofTypes), nil
+}
+
+// UpdateDefaultProofTypes sets the default proof types of the identity. Nil removes them.
+func (i *identity) UpdateDefaultProofTypes(ctx context.Context, conn db.Querier, identifier core.DID, proofTypes domain.ProofTypes) error {
+	res, err := conn.Exec(ctx, `UPDATE identities SET default_proof_types = $2 WHERE identifier = $1`, identifier.String(), proofTypes.Strings())
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrIdentityNotFound
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// ErrIssuanceTimingDoesNotExist the credential has no issuance timing
+var ErrIssuanceTimingDoesNotExist = errors.New("issuance timing does not exist")
+
+type issuanceTiming struct{}
+
+// NewIssuanceTiming returns a new issuance timing repository. The durations are kept in microseconds.
+func NewIssuanceTiming() ports.IssuanceTimingRepository {
+	return &issuanceTiming{}
+}
+
+// Save stores the timing of the issuance of a credential
+func (r *issuanceTiming) Save(ctx context.Context, conn db.Querier, timing *domain.IssuanceTiming) error {
+	phases := make(map[domain.IssuancePhase]int64, len(timing.Phases))
+	for phase, d := range timing.Phases {
+		phases[phase] = d.Microseconds()
+	}
+	_, err := conn.Exec(ctx,
+		`INSERT INTO claim_timings (claim_id, issuer_id, phases, total_us, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (claim_id) DO NOTHING`,
+		timing.ClaimID, timing.IssuerDID.String(), phases, timing.Total.Microseconds(), timing.CreatedAt)
+	return err
+}
+
+// GetByClaimID returns the timing of the issuance of a credential of the issuer
+func (r *issuanceTiming) GetByClaimID(ctx context.Context, conn db.Querier, issuerDID core.DID, claimID uuid.UUID) (*domain.IssuanceTiming, error) {
+	timing := domain.IssuanceTiming{ClaimID: claimID, IssuerDID: issuerDID}
+	var phases map[domain.IssuancePhase]int64
+	var total int64
+	err := conn.QueryRow(ctx,
+		`SELECT phases, total_us, created_at
+		FROM claim_timings
+		WHERE issuer_id = $1 AND claim_id = $2`, issuerDID.String(), claimID).Scan(&phases, &total, &timing.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrIssuanceTimingDoesNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	timing.Phases = make(map[domain.IssuancePhase]time.Duration, len(phases))
+	for phase, us := range phases {
+		timing.Phases[phase] = time.Duration(us) * time.Microsecond
+	}
+	timing.Total = time.Duration(total) * time.Microsecond
+	return &timing, nil
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgconn"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+var (
+	// ErrKeyRotationDoesNotExist the key rotation does not exist
+	ErrKeyRotationDoesNotExist = errors.New("key rotation does not exist")
+	// ErrKeyRotationActive the identity already has a key rotation that is not completed
+	ErrKeyRotationActive = errors.New("the identity already has an active key rotation")
+)
+
+const keyRotationColumns = `id, issuer_id, status, old_auth_claim_id, new_auth_claim_id, new_key_id, add_state, revoke_state, error, created_at, modified_at`
+
+type keyRotation struct{}
+
+// NewKeyRotation returns a new key rotation repository
+func NewKeyRotation() ports.KeyRotationRepository {
+	return &keyRotation{}
+}
+
+// Save creates the key rotation or updates its progress
+func (r *keyRotation) Save(ctx context.Context, conn db.Querier, rotation *domain.KeyRotation) error {
+	_, err := conn.Exec(ctx,
+		`INSERT INTO key_rotations (`+keyRotationColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id) DO UPDATE SET status = $3, add_state = $7, revoke_state = $8, error = $9, modified_at = $11`,
+		rotation.ID, rotation.IssuerDID.String(), rotation.Status, rotation.OldAuthClaimID, rotation.NewAuthClaimID, rotation.NewKeyID,
+		rotation.AddState, rotation.RevokeState, rotation.Error, rotation.CreatedAt, rotation.ModifiedAt)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == duplicateViolationErrorCode {
+		return ErrKeyRotationActive
+	}
+	return err
+}
+
+// GetByID returns a key rotation of the issuer
+func (r *keyRotation) GetByID(ctx context.Context, conn db.Querier, issuerDID core.DID, id uuid.UUID) (*domain.KeyRotation, error) {
+	rotations, err := r.query(ctx, conn, `SELECT `+keyRotationColumns+` FROM key_rotations WHERE issuer_id = $1 AND id = $2`, issuerDID.String(), id)
+	if err != nil {
+		return nil, err
+	}
+	if len(rotations) == 0 {
+		return nil, ErrKeyRotationDoesNotExist
+	}
+	return rotations[0], nil
+}
+
+// GetActive returns the key rotations of every issuer that are not completed, the oldest first
+func (r *keyRotation) GetActive(ctx context.Context, conn db.Querier) ([]*domain.KeyRotation, error) {
+	return r.query(ctx, conn, `SELECT `+keyRotationColumns+` FROM key_rotations WHERE status <> $1 ORDER BY created_at`, domain.KeyRotationCompleted)
+}
+
+// GetActiveByIssuer returns the key rotation of the issuer that is not completed
+func (r *keyRotation) GetActiveByIssuer(ctx context.Context, conn db.Querier, issuerDID core.DID) (*domain.KeyRotation, error) {
+	rotations, err := r.query(ctx, conn, `SELECT `+keyRotationColumns+` FROM key_rotations WHERE issuer_id = $1 AND status <> $2`, issuerDID.String(), domain.KeyRotationCompleted)
+	if err != nil {
+		return nil, err
+	}
+	if len(rotations) == 0 {
+		return nil, ErrKeyRotationDoesNotExist
+	}
+	return rotations[0], nil
+}
+
+func (r *keyRotation) query(ctx context.Context, conn db.Querier, sql string, args ...interface{}) ([]*domain.KeyRotation, error) {
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rotations []*domain.KeyRotation
+	for rows.Next() {
+		var rotation domain.KeyRotation
+		var issuerID string
+		if err := rows.Scan(&rotation.ID, &issuerID, &rotation.Status, &rotation.OldAuthClaimID, &rotation.NewAuthClaimID, &rotation.NewKeyID,
+			&rotation.AddState, &rotation.RevokeState, &rotation.Error, &rotation.CreatedAt, &rotation.ModifiedAt); err != nil {
+			return nil, err
+		}
+		issuerDID, err := core.ParseDID(issuerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issuer of the key rotation %s: %w", rotation.ID, err)
+		}
+		rotation.IssuerDID = *issuerDID
+		rotations = append(rotations, &rotation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return rotations, nil
+}
@@ -44,12 +44,12 @@ func (l link) Save(ctx context.Context, conn db.Querier, link *domain.Link) (*uu
 	}
 
 	var id uuid.UUID
-	sql := `INSERT INTO links (id, issuer_id, max_issuance, valid_until, schema_id, credential_expiration, credential_signature_proof, credential_mtp_proof, credential_attributes, active)
-			VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (id) DO
-			UPDATE SET issuer_id=$2, max_issuance=$3, valid_until=$4, schema_id=$5, credential_expiration=$6, credential_signature_proof=$7, credential_mtp_proof=$8, credential_attributes=$9, active=$10 
+	sql := `INSERT INTO links (id, issuer_id, max_issuance, valid_until, schema_id, credential_expiration, credential_signature_proof, credential_mtp_proof, credential_attributes, active, credential_expiration_policy, locale, display_strings, prerequisites)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) ON CONFLICT (id) DO
+			UPDATE SET issuer_id=$2, max_issuance=$3, valid_until=$4, schema_id=$5, credential_expiration=$6, credential_signature_proof=$7, credential_mtp_proof=$8, credential_attributes=$9, active=$10, credential_expiration_policy=$11, locale=$12, display_strings=$13, prerequisites=$14 
 			RETURNING id`
 	err := conn.QueryRow(ctx, sql, link.ID, link.IssuerCoreDID().String(), link.MaxIssuance, link.ValidUntil, link.SchemaID, link.CredentialExpiration, link.CredentialSignatureProof,
-		link.CredentialMTPProof, pgAttrs, link.Active).Scan(&id)
+		link.CredentialMTPProof, pgAttrs, link.Active, expirationPolicyString(link.CredentialExpirationPolicy), link.Locale, displayStringsJSON(link.DisplayStrings), prerequisitesJSON(link.Prerequisites)).Scan(&id)
 
 	if err != nil && strings.Contains(err.Error(), `table "links" violates foreign key constraint "links_schemas_id_key"`) {
 		return nil, errorShemaNotFound
@@ -70,6 +70,10 @@ SELECT links.id,
        links.credential_mtp_proof, 
        links.credential_attributes, 
        links.active, 
+       links.credential_expiration_policy,
+       links.locale,
+       links.display_strings,
+       links.prerequisites,
        count(claims.id) as issued_claims,
        schemas.id as schema_id,
        schemas.issuer_id as schema_issuer_id,
@@ -77,6 +81,8 @@ SELECT links.id,
        schemas.type,
        schemas.hash,
        schemas.attributes, 
+       schemas.display_strings,
+       schemas.prerequisites,
        schemas.created_at
 FROM links
 LEFT JOIN schemas ON schemas.id = links.schema_id AND schemas.issuer_id = links.issuer_id
@@ -87,6 +93,7 @@ GROUP BY links.id, schemas.id
 	link := domain.Link{}
 	s := dbSchema{}
 	var credentialSubject pgtype.JSONB
+	var expirationPolicy *string
 	err := l.conn.Pgx.QueryRow(ctx, sql, id, issuerDID.String()).Scan(
 		&link.ID,
 		&link.IssuerDID,
@@ -99,6 +106,10 @@ GROUP BY links.id, schemas.id
 		&link.CredentialMTPProof,
 		&credentialSubject,
 		&link.Active,
+		&expirationPolicy,
+		&link.Locale,
+		&link.DisplayStrings,
+		&link.Prerequisites,
 		&link.IssuedClaims,
 		&s.ID,
 		&s.IssuerID,
@@ -106,6 +117,8 @@ GROUP BY links.id, schemas.id
 		&s.Type,
 		&s.Hash,
 		&s.Attributes,
+		&s.Display,
+		&s.Prereqs,
 		&s.CreatedAt,
 	)
 	if err == pgx.ErrNoRows {
@@ -120,6 +133,10 @@ GROUP BY links.id, schemas.id
 	if err := d.Decode(&link.CredentialSubject); err != nil {
 		return nil, fmt.Errorf("parsing credential attributes: %w", err)
 	}
+	link.CredentialExpirationPolicy, err = toExpirationPolicy(expirationPolicy)
+	if err != nil {
+		return nil, err
+	}
 	link.Schema, err = toSchemaDomain(&s)
 	if err != nil {
 		return nil, fmt.Errorf("parsing link schema: %w", err)
@@ -140,6 +157,10 @@ SELECT links.id,
        links.credential_mtp_proof, 
        links.credential_attributes, 
        links.active,
+       links.credential_expiration_policy,
+       links.locale,
+       links.display_strings,
+       links.prerequisites,
        count(claims.id) as issued_claims,
        schemas.id as schema_id,
        schemas.issuer_id as schema_issuer_id,
@@ -147,9 +168,11 @@ SELECT links.id,
        schemas.type,
        schemas.hash,
        schemas.attributes, 
+       schemas.display_strings,
+       schemas.prerequisites,
        schemas.created_at
 FROM links
-LEFT JOIN schemas ON schemas.id = links.schema_id
+LEFT JOIN schemas ON schemas.id = links.schema_id AND schemas.issuer_id = links.issuer_id
 LEFT JOIN claims ON claims.link_id = links.id AND claims.identifier = links.issuer_id
 WHERE links.issuer_id = $1
 `
@@ -175,7 +198,7 @@ WHERE links.issuer_id = $1
 	if query != nil {
 		q = fullTextSearchQuery(*query, " | ")
 	}
-	rows, err := l.conn.Pgx.Query(ctx, sql, issuerDID.String(), time.Now(), q)
+	rows, err := l.conn.Reader().Query(ctx, sql, issuerDID.String(), time.Now(), q)
 	if err != nil {
 		return nil, err
 	}
@@ -185,6 +208,7 @@ WHERE links.issuer_id = $1
 	link := domain.Link{}
 	links := make([]domain.Link, 0)
 	var credentialAttributes pgtype.JSONB
+	var expirationPolicy *string
 	for rows.Next() {
 		if err := rows.Scan(
 			&link.ID,
@@ -197,6 +221,10 @@ WHERE links.issuer_id = $1
 			&link.CredentialSignatureProof,
 			&link.CredentialMTPProof, &credentialAttributes,
 			&link.Active,
+			&expirationPolicy,
+			&link.Locale,
+			&link.DisplayStrings,
+			&link.Prerequisites,
 			&link.IssuedClaims,
 			&schema.ID,
 			&schema.IssuerID,
@@ -204,6 +232,8 @@ WHERE links.issuer_id = $1
 			&schema.Type,
 			&schema.Hash,
 			&schema.Attributes,
+			&schema.Display,
+			&schema.Prereqs,
 			&schema.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -213,6 +243,11 @@ WHERE links.issuer_id = $1
 			return nil, fmt.Errorf("parsing credential attributes: %w", err)
 		}
 
+		link.CredentialExpirationPolicy, err = toExpirationPolicy(expirationPolicy)
+		if err != nil {
+			return nil, err
+		}
+
 		link.Schema, err = toSchemaDomain(&schema)
 		if err != nil {
 			return nil, fmt.Errorf("parsing link schema: %w", err)
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// ErrLinkSessionDoesNotExist the link session does not exist
+var ErrLinkSessionDoesNotExist = errors.New("link session does not exist")
+
+const linkSessionColumns = `id, link_id, issuer_id, status, user_id, claim_id, error, created_at, modified_at`
+
+type linkSession struct{}
+
+// NewLinkSession returns a new link session repository
+func NewLinkSession() ports.LinkSessionRepository {
+	return &linkSession{}
+}
+
+// Save creates the link session or updates its progress
+func (r *linkSession) Save(ctx context.Context, conn db.Querier, session *domain.LinkSession) error {
+	_, err := conn.Exec(ctx,
+		`INSERT INTO link_sessions (`+linkSessionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET status = $4, user_id = $5, claim_id = $6, error = $7, modified_at = $9`,
+		session.ID, session.LinkID, session.IssuerDID.String(), session.Status, session.UserDID, session.ClaimID,
+		session.Error, session.CreatedAt, session.ModifiedAt)
+	return err
+}
+
+// GetByID returns a session of the link
+func (r *linkSession) GetByID(ctx context.Context, conn db.Querier, issuerDID core.DID, linkID uuid.UUID, id uuid.UUID) (*domain.LinkSession, error) {
+	sessions, err := r.query(ctx, conn, `SELECT `+linkSessionColumns+` FROM link_sessions WHERE issuer_id = $1 AND link_id = $2 AND id = $3`,
+		issuerDID.String(), linkID, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, ErrLinkSessionDoesNotExist
+	}
+	return sessions[0], nil
+}
+
+// GetByLink returns the sessions of the link, the newest first
+func (r *linkSession) GetByLink(ctx context.Context, conn db.Querier, issuerDID core.DID, linkID uuid.UUID, filter ports.LinkSessionsFilter) ([]*domain.LinkSession, error) {
+	where := []string{"issuer_id = $1", "link_id = $2"}
+	args := []interface{}{issuerDID.String(), linkID}
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.UserDID != nil {
+		args = append(args, *filter.UserDID)
+		where = append(where, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	sql := `SELECT ` + linkSessionColumns + ` FROM link_sessions WHERE ` + strings.Join(where, " AND ") + ` ORDER BY created_at DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		sql += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	return r.query(ctx, conn, sql, args...)
+}
+
+// MarkFetched moves the session that issued the claim to fetched. Claims that were not issued by a link are ignored.
+func (r *linkSession) MarkFetched(ctx context.Context, conn db.Querier, claimID uuid.UUID) error {
+	_, err := conn.Exec(ctx, `UPDATE link_sessions SET status = $1, modified_at = NOW() WHERE claim_id = $2 AND status = $3`,
+		domain.LinkSessionFetched, claimID, domain.LinkSessionIssued)
+	return err
+}
+
+func (r *linkSession) query(ctx context.Context, conn db.Querier, sql string, args ...interface{}) ([]*domain.LinkSession, error) {
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*domain.LinkSession
+	for rows.Next() {
+		var session domain.LinkSession
+		var issuerID string
+		if err := rows.Scan(&session.ID, &session.LinkID, &issuerID, &session.Status, &session.UserDID, &session.ClaimID,
+			&session.Error, &session.CreatedAt, &session.ModifiedAt); err != nil {
+			return nil, err
+		}
+		issuerDID, err := core.ParseDID(issuerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issuer of the link session %s: %w", session.ID, err)
+		}
+		session.IssuerDID = *issuerDID
+		sessions = append(sessions, &session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
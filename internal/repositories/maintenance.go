@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+type maintenance struct{}
+
+// NewMaintenance returns a new maintenance repository
+func NewMaintenance() ports.MaintenanceRepository {
+	return &maintenance{}
+}
+
+// Get returns the maintenance mode of the node, disabled when it was never switched on
+func (r *maintenance) Get(ctx context.Context, conn db.Querier) (*domain.Maintenance, error) {
+	var m domain.Maintenance
+	var retryAfter int64
+	err := conn.QueryRow(ctx,
+		`SELECT enabled, COALESCE(message, ''), COALESCE(retry_after, 0), updated_at FROM maintenance WHERE id`).
+		Scan(&m.Enabled, &m.Message, &retryAfter, &m.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &domain.Maintenance{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.RetryAfter = time.Duration(retryAfter) * time.Second
+	return &m, nil
+}
+
+// Save stores the maintenance mode of the node. The retry after is kept in seconds.
+func (r *maintenance) Save(ctx context.Context, conn db.Querier, m *domain.Maintenance) error {
+	_, err := conn.Exec(ctx,
+		`INSERT INTO maintenance (id, enabled, message, retry_after, updated_at) VALUES (true, $1, NULLIF($2::text, ''), NULLIF($3::int8, 0), $4)
+		ON CONFLICT (id) DO UPDATE SET enabled = $1, message = NULLIF($2::text, ''), retry_after = NULLIF($3::int8, 0), updated_at = $4`,
+		m.Enabled, m.Message, int64(m.RetryAfter/time.Second), m.UpdatedAt)
+	return err
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+type metering struct{}
+
+// NewMetering returns a new metering repository
+func NewMetering() ports.MeteringRepository {
+	return &metering{}
+}
+
+// Add counts an operation of the issuer, or of one of its api keys, done at the given time. The operations without
+// api key are kept with the nil uuid.
+func (r *metering) Add(ctx context.Context, conn db.Querier, issuerDID core.DID, apiKeyID *uuid.UUID, operation domain.MeteredOperation, at time.Time) error {
+	keyID := uuid.Nil
+	if apiKeyID != nil {
+		keyID = *apiKeyID
+	}
+	_, err := conn.Exec(ctx,
+		`INSERT INTO metering (issuer_id, api_key_id, operation, day, count) VALUES ($1, $2, $3, $4, 1)
+		ON CONFLICT (issuer_id, api_key_id, operation, day) DO UPDATE SET count = metering.count + 1`,
+		issuerDID.String(), keyID, string(operation), at.UTC().Format(time.DateOnly))
+	return err
+}
+
+// GetMonthly returns the operations of every issuer and api key per month in the period, ordered by month and issuer
+func (r *metering) GetMonthly(ctx context.Context, conn db.Querier, period domain.MeteringPeriod) ([]domain.MeteringMonth, error) {
+	rows, err := conn.Query(ctx,
+		`SELECT date_trunc('month', day)::date, issuer_id, api_key_id, operation, SUM(count)::int8
+		FROM metering
+		WHERE day >= $1 AND day < $2
+		GROUP BY 1, 2, 3, 4
+		ORDER BY 1, 2, 3, 4`,
+		period.From.Format(time.DateOnly), period.End().Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	months := make([]domain.MeteringMonth, 0)
+	for rows.Next() {
+		var month domain.MeteringMonth
+		var issuerID, operation string
+		var keyID uuid.UUID
+		if err := rows.Scan(&month.Month, &issuerID, &keyID, &operation, &month.Count); err != nil {
+			return nil, err
+		}
+		did, err := core.ParseDID(issuerID)
+		if err != nil {
+			return nil, fmt.Errorf("parsing metering issuer did: %w", err)
+		}
+		month.IssuerDID = *did
+		month.Operation = domain.MeteredOperation(operation)
+		if keyID != uuid.Nil {
+			month.APIKeyID = &keyID
+		}
+		months = append(months, month)
+	}
+	return months, rows.Err()
+}
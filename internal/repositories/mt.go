@@ -6,6 +6,7 @@ import (
 
 	core "github.com/iden3/go-iden3-core"
 	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
 
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
@@ -78,3 +79,10 @@ func (mt *identityMerkleTreeRepository) GetByIdentifierAndTypes(ctx context.Cont
 
 	return trees, nil
 }
+
+// Lock takes a lock on the merkle trees of the identity that is released when the transaction ends.
+// It is a postgres advisory lock, so it also serializes the updates made by other issuer node processes.
+func (mt *identityMerkleTreeRepository) Lock(ctx context.Context, tx pgx.Tx, identifier *core.DID) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('identity_mts:' || $1))`, identifier.String())
+	return err
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+type operations struct{}
+
+// NewOperations returns a new operations repository
+func NewOperations() ports.OperationsRepository {
+	return &operations{}
+}
+
+// UnpublishedCredentials returns the identities with at least minCount credentials waiting for the publication of a
+// state, and how many they have
+func (o *operations) UnpublishedCredentials(ctx context.Context, conn db.Querier, minCount int) (map[string]int, error) {
+	rows, err := conn.Query(ctx, `SELECT issuer, count(*) FROM claims
+		WHERE identity_state IS NULL AND identifier = issuer
+		GROUP BY issuer HAVING count(*) >= $1`, minCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	unpublished := make(map[string]int)
+	for rows.Next() {
+		var issuer string
+		var count int
+		if err := rows.Scan(&issuer, &count); err != nil {
+			return nil, err
+		}
+		unpublished[issuer] = count
+	}
+	return unpublished, rows.Err()
+}
+
+// LatestConfirmedStates returns the last confirmed state of each identity
+func (o *operations) LatestConfirmedStates(ctx context.Context, conn db.Querier) ([]domain.IdentityState, error) {
+	rows, err := conn.Query(ctx, `SELECT DISTINCT ON (identifier) state_id, identifier, state, root_of_roots, claims_tree_root,
+       revocation_tree_root, block_timestamp, block_number, tx_id, previous_state, status, modified_at, created_at
+	FROM identity_states WHERE status = 'confirmed' ORDER BY identifier, state_id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return toIdentityStatesDomain(rows)
+}
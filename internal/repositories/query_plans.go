@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// exampleIssuer is explained when the claims table has no issuer yet
+const exampleIssuer = "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+
+// queryPlanTables are the tables read by the explained queries
+var queryPlanTables = []string{"claims", "links", "identity_states", "connections", "revocation"}
+
+type queryPlans struct{}
+
+// NewQueryPlans returns a new query plan repository
+func NewQueryPlans() ports.QueryPlanRepository {
+	return &queryPlans{}
+}
+
+// hotQuery is a frequent query of the node, with the index that serves it
+type hotQuery struct {
+	name  string
+	index string
+	sql   string
+	args  []any
+}
+
+// Explain returns the plans of the frequent queries of the claims and links. They are explained with the most common
+// values of their columns, so the plans are the ones postgres chooses for the largest issuer and subject.
+func (r *queryPlans) Explain(ctx context.Context, conn db.Querier) ([]domain.QueryPlan, error) {
+	var issuer, subject, schemaURL string
+	err := conn.QueryRow(ctx, `
+		SELECT COALESCE((SELECT (most_common_vals::text::text[])[1] FROM pg_stats WHERE tablename = 'claims' AND attname = 'issuer'), (SELECT issuer FROM claims LIMIT 1), ''),
+		       COALESCE((SELECT (most_common_vals::text::text[])[1] FROM pg_stats WHERE tablename = 'claims' AND attname = 'other_identifier'), (SELECT other_identifier FROM claims WHERE other_identifier IS NOT NULL LIMIT 1), ''),
+		       COALESCE((SELECT (most_common_vals::text::text[])[1] FROM pg_stats WHERE tablename = 'claims' AND attname = 'schema_url'), (SELECT schema_url FROM claims WHERE schema_url IS NOT NULL LIMIT 1), '')`).
+		Scan(&issuer, &subject, &schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	issuerDID, err := core.ParseDID(issuer)
+	if err != nil {
+		issuerDID, err = core.ParseDID(exampleIssuer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	byIssuer, byIssuerArgs := buildGetAllQueryAndFilters(*issuerDID, &ports.ClaimsFilter{})
+	bySubject, bySubjectArgs := buildGetAllQueryAndFilters(*issuerDID, &ports.ClaimsFilter{Subject: subject})
+	queries := []hotQuery{
+		{name: "claims_by_issuer", index: "claims (identifier, issued_at DESC NULLS LAST, id)", sql: byIssuer, args: byIssuerArgs},
+		{name: "claims_by_subject", index: "claims (identifier, other_identifier)", sql: bySubject, args: bySubjectArgs},
+		{
+			name:  "claim_by_revocation_nonce",
+			index: "claims (identifier, rev_nonce)",
+			sql:   `SELECT id FROM claims WHERE identifier = $1 AND rev_nonce = $2`,
+			args:  []any{issuerDID.String(), 0},
+		},
+		{
+			name:  "active_claims_by_subject_and_schema",
+			index: "claims (issuer, other_identifier, schema_url) WHERE NOT revoked",
+			sql:   `SELECT id FROM claims WHERE issuer = $1 AND other_identifier = $2 AND schema_url = $3 AND revoked = false`,
+			args:  []any{issuerDID.String(), subject, schemaURL},
+		},
+		{
+			name:  "unpublished_claims",
+			index: "claims (issuer) WHERE identity_state IS NULL",
+			sql:   `SELECT id FROM claims WHERE issuer = $1 AND identity_state IS NULL AND identifier = issuer`,
+			args:  []any{issuerDID.String()},
+		},
+		{
+			name:  "links_by_issuer",
+			index: "claims (link_id)",
+			sql: `SELECT links.id, count(claims.id) FROM links
+				LEFT JOIN claims ON claims.link_id = links.id AND claims.identifier = links.issuer_id
+				WHERE links.issuer_id = $1 GROUP BY links.id`,
+			args: []any{issuerDID.String()},
+		},
+	}
+
+	plans := make([]domain.QueryPlan, 0, len(queries))
+	for _, query := range queries {
+		var explain []byte
+		if err := conn.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+query.sql, query.args...).Scan(&explain); err != nil {
+			return nil, fmt.Errorf("explaining %s: %w", query.name, err)
+		}
+		plan, err := domain.ParseQueryPlan(query.name, query.index, explain)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, *plan)
+	}
+	return plans, nil
+}
+
+// TableStats returns the statistics of the tables of the explained queries
+func (r *queryPlans) TableStats(ctx context.Context, conn db.Querier) ([]domain.TableStats, error) {
+	rows, err := conn.Query(ctx, `
+		SELECT relname, n_live_tup, n_dead_tup, pg_total_relation_size(relid), COALESCE(seq_scan, 0), COALESCE(idx_scan, 0),
+		       GREATEST(last_vacuum, last_autovacuum)
+		FROM pg_stat_user_tables
+		WHERE relname = ANY($1)
+		ORDER BY relname`, queryPlanTables)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make([]domain.TableStats, 0, len(queryPlanTables))
+	for rows.Next() {
+		var table domain.TableStats
+		if err := rows.Scan(&table.Table, &table.LiveRows, &table.DeadRows, &table.SizeBytes, &table.SeqScans, &table.IndexScans, &table.LastVacuum); err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
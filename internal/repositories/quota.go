@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+type quota struct{}
+
+// NewQuota returns a new quota repository
+func NewQuota() ports.QuotaRepository {
+	return &quota{}
+}
+
+// GetOverrides returns the quota overrides of the identity, empty when it has none
+func (r *quota) GetOverrides(ctx context.Context, conn db.Querier, issuerDID core.DID) (*domain.QuotaOverrides, error) {
+	var overrides domain.QuotaOverrides
+	err := conn.QueryRow(ctx,
+		`SELECT quotas.max_active_links, quotas.max_credentials_per_day, quotas.max_schemas
+		FROM identities LEFT JOIN quotas ON quotas.issuer_id = identities.identifier
+		WHERE identities.identifier = $1`, issuerDID.String()).
+		Scan(&overrides.MaxActiveLinks, &overrides.MaxCredentialsPerDay, &overrides.MaxSchemas)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &overrides, nil
+}
+
+// SaveOverrides replaces the quota overrides of the identity
+func (r *quota) SaveOverrides(ctx context.Context, conn db.Querier, issuerDID core.DID, overrides domain.QuotaOverrides) error {
+	res, err := conn.Exec(ctx,
+		`INSERT INTO quotas (issuer_id, max_active_links, max_credentials_per_day, max_schemas, updated_at)
+		SELECT identifier, $2, $3, $4, NOW() FROM identities WHERE identifier = $1
+		ON CONFLICT (issuer_id) DO UPDATE SET max_active_links = $2, max_credentials_per_day = $3, max_schemas = $4, updated_at = NOW()`,
+		issuerDID.String(), overrides.MaxActiveLinks, overrides.MaxCredentialsPerDay, overrides.MaxSchemas)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrIdentityNotFound
+	}
+	return nil
+}
+
+// Lock serializes until the end of tx the uses of a resource of the identity, so concurrent ones can't exceed its quota
+func (r *quota) Lock(ctx context.Context, tx pgx.Tx, issuerDID core.DID, resource domain.QuotaResource) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('quota:' || $1 || ':' || $2))`, issuerDID.String(), string(resource))
+	return err
+}
+
+// CountActiveLinks returns the links of the identity that can issue credentials at now: the active ones that are not
+// expired and have not issued their maximum
+func (r *quota) CountActiveLinks(ctx context.Context, conn db.Querier, issuerDID core.DID, now time.Time) (int, error) {
+	var count int
+	err := conn.QueryRow(ctx,
+		`SELECT count(*) FROM links
+		WHERE links.issuer_id = $1 AND links.active AND coalesce(links.valid_until > $2, true)
+		AND coalesce(links.max_issuance > (SELECT count(claims.id) FROM claims WHERE claims.link_id = links.id), true)`,
+		issuerDID.String(), now).Scan(&count)
+	return count, err
+}
+
+// CountCredentialsOfDay returns the credentials issued by the identity in the UTC day, as metered
+func (r *quota) CountCredentialsOfDay(ctx context.Context, conn db.Querier, issuerDID core.DID, day time.Time) (int, error) {
+	var count int
+	err := conn.QueryRow(ctx,
+		`SELECT COALESCE(SUM(count), 0)::int FROM metering WHERE issuer_id = $1 AND operation = $2 AND day = $3`,
+		issuerDID.String(), string(domain.MeteredIssuance), day.UTC().Format(time.DateOnly)).Scan(&count)
+	return count, err
+}
+
+// CountSchemas returns the imported and created schemas of the identity
+func (r *quota) CountSchemas(ctx context.Context, conn db.Querier, issuerDID core.DID) (int, error) {
+	var count int
+	err := conn.QueryRow(ctx, `SELECT count(*) FROM schemas WHERE issuer_id = $1`, issuerDID.String()).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// promoteWaitSeconds is how long PromoteDatabase waits for the replica to become a primary
+const promoteWaitSeconds = 60
+
+type region struct{}
+
+// NewRegion returns a new region repository
+func NewRegion() ports.RegionRepository {
+	return &region{}
+}
+
+// Get returns the lease of the active region, nil when no region took it yet
+func (r *region) Get(ctx context.Context, conn db.Querier) (*domain.RegionLease, error) {
+	var lease domain.RegionLease
+	err := conn.QueryRow(ctx, `SELECT region, epoch, expires_at, updated_at FROM region_lease WHERE id`).
+		Scan(&lease.Region, &lease.Epoch, &lease.ExpiresAt, &lease.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lease, nil
+}
+
+// Take stores the lease when the stored one has the epoch previousEpoch, or when there is none and previousEpoch is 0,
+// and tells whether it did. The check and the write are a single statement, so of two regions promoted at once only
+// one takes the lease.
+func (r *region) Take(ctx context.Context, conn db.Querier, lease *domain.RegionLease, previousEpoch int64) (bool, error) {
+	tag, err := conn.Exec(ctx,
+		`INSERT INTO region_lease (id, region, epoch, expires_at, updated_at) VALUES (true, $1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET region = $1, epoch = $2, expires_at = $3, updated_at = $4 WHERE region_lease.epoch = $5`,
+		lease.Region, lease.Epoch, lease.ExpiresAt, lease.UpdatedAt, previousEpoch)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Renew extends the lease until expiresAt when the region holds it, and tells whether it did
+func (r *region) Renew(ctx context.Context, conn db.Querier, region string, expiresAt time.Time, updatedAt time.Time) (bool, error) {
+	tag, err := conn.Exec(ctx, `UPDATE region_lease SET expires_at = $2, updated_at = $3 WHERE id AND region = $1`, region, expiresAt, updatedAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// InRecovery tells whether the database is a replica that follows a primary
+func (r *region) InRecovery(ctx context.Context, conn db.Querier) (bool, error) {
+	var inRecovery bool
+	err := conn.QueryRow(ctx, `SELECT pg_is_in_recovery()`).Scan(&inRecovery)
+	return inRecovery, err
+}
+
+// PromoteDatabase promotes the replica to primary and waits until it accepts the writes. The role of the database
+// needs the permission to run pg_promote.
+func (r *region) PromoteDatabase(ctx context.Context, conn db.Querier) error {
+	var promoted bool
+	if err := conn.QueryRow(ctx, `SELECT pg_promote(true, $1)`, promoteWaitSeconds).Scan(&promoted); err != nil {
+		return err
+	}
+	if !promoted {
+		return errors.New("the database was not promoted in time")
+	}
+	return nil
+}
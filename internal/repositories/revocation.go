@@ -2,14 +2,19 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
 
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
 	"github.com/polygonid/sh-id-platform/internal/db"
 )
 
+// ErrRevocationDoesNotExist the revocation nonce is not revoked
+var ErrRevocationDoesNotExist = errors.New("revocation does not exist")
+
 type revocation struct{}
 
 // NewRevocation TODO
@@ -40,3 +45,13 @@ RETURNING identifier, nonce, version, status, description`,
 
 	return revs, nil
 }
+
+// GetStatus returns whether the revocation of the nonce is already in a state of the identity
+func (r *revocation) GetStatus(ctx context.Context, conn db.Querier, did *core.DID, nonce domain.RevNonceUint64) (domain.RevStatus, error) {
+	var status domain.RevStatus
+	err := conn.QueryRow(ctx, `SELECT status FROM revocation WHERE identifier = $1 AND nonce = $2`, did.String(), nonce).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return status, ErrRevocationDoesNotExist
+	}
+	return status, err
+}
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
@@ -40,3 +42,239 @@ func (s *schemaInMemory) GetAll(_ context.Context, _ core.DID, _ *string) ([]dom
 	}
 	return schemas, nil
 }
+
+func (s *schemaInMemory) UpdateDefaultProofTypes(_ context.Context, _ core.DID, id uuid.UUID, proofTypes domain.ProofTypes) error {
+	schema, found := s.schemas[id]
+	if !found {
+		return ErrSchemaDoesNotExist
+	}
+	schema.DefaultProofTypes = proofTypes
+	s.schemas[id] = schema
+	return nil
+}
+
+func (s *schemaInMemory) GetDefaultProofTypesByURL(_ context.Context, _ core.DID, url string) (domain.ProofTypes, error) {
+	var last *domain.Schema
+	for _, schema := range s.schemas {
+		schema := schema
+		if schema.URL == url && schema.DefaultProofTypes != nil && (last == nil || schema.CreatedAt.After(last.CreatedAt)) {
+			last = &schema
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	return last.DefaultProofTypes, nil
+}
+
+func (s *schemaInMemory) UpdateDefaultExpiration(_ context.Context, _ core.DID, id uuid.UUID, policy *domain.ExpirationPolicy) error {
+	schema, found := s.schemas[id]
+	if !found {
+		return ErrSchemaDoesNotExist
+	}
+	schema.DefaultExpiration = policy
+	s.schemas[id] = schema
+	return nil
+}
+
+func (s *schemaInMemory) GetDefaultExpirationByURL(_ context.Context, _ core.DID, url string) (*domain.ExpirationPolicy, error) {
+	var last *domain.Schema
+	for _, schema := range s.schemas {
+		schema := schema
+		if schema.URL == url && schema.DefaultExpiration != nil && (last == nil || schema.CreatedAt.After(last.CreatedAt)) {
+			last = &schema
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	return last.DefaultExpiration, nil
+}
+
+func (s *schemaInMemory) UpdatePIIAttributes(_ context.Context, _ core.DID, id uuid.UUID, attrs domain.SchemaAttrs) error {
+	schema, found := s.schemas[id]
+	if !found {
+		return ErrSchemaDoesNotExist
+	}
+	schema.PIIAttributes = attrs
+	s.schemas[id] = schema
+	return nil
+}
+
+func (s *schemaInMemory) GetPIIAttributesByURL(_ context.Context, _ core.DID, url string) (domain.SchemaAttrs, error) {
+	var last *domain.Schema
+	for _, schema := range s.schemas {
+		schema := schema
+		if schema.URL == url && schema.PIIAttributes != nil && (last == nil || schema.CreatedAt.After(last.CreatedAt)) {
+			last = &schema
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	return last.PIIAttributes, nil
+}
+
+func (s *schemaInMemory) UpdateMaxActivePerSubject(_ context.Context, _ core.DID, id uuid.UUID, maxActive *int) error {
+	schema, found := s.schemas[id]
+	if !found {
+		return ErrSchemaDoesNotExist
+	}
+	schema.MaxActivePerSubject = maxActive
+	s.schemas[id] = schema
+	return nil
+}
+
+func (s *schemaInMemory) GetMaxActivePerSubjectByURL(_ context.Context, _ core.DID, url string) (*int, error) {
+	var last *domain.Schema
+	for _, schema := range s.schemas {
+		schema := schema
+		if schema.URL == url && schema.MaxActivePerSubject != nil && (last == nil || schema.CreatedAt.After(last.CreatedAt)) {
+			last = &schema
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	return last.MaxActivePerSubject, nil
+}
+
+func (s *schemaInMemory) UpdateRevokeSuperseded(_ context.Context, _ core.DID, id uuid.UUID, revokeSuperseded bool) error {
+	schema, found := s.schemas[id]
+	if !found {
+		return ErrSchemaDoesNotExist
+	}
+	schema.RevokeSuperseded = revokeSuperseded
+	s.schemas[id] = schema
+	return nil
+}
+
+func (s *schemaInMemory) GetRevokeSupersededByURL(_ context.Context, _ core.DID, url string) (bool, error) {
+	for _, schema := range s.schemas {
+		if schema.URL == url && schema.RevokeSuperseded {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *schemaInMemory) UpdatePostIssuanceHooks(_ context.Context, _ core.DID, id uuid.UUID, hooks domain.PostIssuanceHooks) error {
+	schema, found := s.schemas[id]
+	if !found {
+		return ErrSchemaDoesNotExist
+	}
+	schema.PostIssuanceHooks = hooks
+	s.schemas[id] = schema
+	return nil
+}
+
+func (s *schemaInMemory) GetPostIssuanceHooksByURL(_ context.Context, _ core.DID, url string) (domain.PostIssuanceHooks, error) {
+	var last *domain.Schema
+	for _, schema := range s.schemas {
+		schema := schema
+		if schema.URL == url && schema.PostIssuanceHooks != nil && (last == nil || schema.CreatedAt.After(last.CreatedAt)) {
+			last = &schema
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	return last.PostIssuanceHooks, nil
+}
+
+func (s *schemaInMemory) GetUsageByURL(_ context.Context, _ core.DID, _ string, _, _ *time.Time) (*domain.SchemaUsage, error) {
+	return &domain.SchemaUsage{Days: make([]domain.SchemaUsageDay, 0)}, nil
+}
+
+func (s *schemaInMemory) UpdateDisplayStrings(_ context.Context, _ core.DID, id uuid.UUID, display domain.DisplayStrings) error {
+	schema, found := s.schemas[id]
+	if !found {
+		return ErrSchemaDoesNotExist
+	}
+	schema.DisplayStrings = display
+	s.schemas[id] = schema
+	return nil
+}
+
+func (s *schemaInMemory) GetDisplayStringsByURL(_ context.Context, _ core.DID, url string) (domain.DisplayStrings, error) {
+	var last *domain.Schema
+	for _, schema := range s.schemas {
+		schema := schema
+		if schema.URL == url && schema.DisplayStrings != nil && (last == nil || schema.CreatedAt.After(last.CreatedAt)) {
+			last = &schema
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	return last.DisplayStrings, nil
+}
+
+func (s *schemaInMemory) UpdatePrerequisites(_ context.Context, _ core.DID, id uuid.UUID, prerequisites domain.Prerequisites) error {
+	schema, found := s.schemas[id]
+	if !found {
+		return ErrSchemaDoesNotExist
+	}
+	schema.Prerequisites = prerequisites
+	s.schemas[id] = schema
+	return nil
+}
+
+func (s *schemaInMemory) GetVersions(_ context.Context, _ core.DID, sType string) ([]domain.Schema, error) {
+	versions := make([]domain.Schema, 0)
+	for _, schema := range s.schemas {
+		if schema.Type == sType && schema.InRegistry() {
+			versions = append(versions, schema)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return domain.CompareSchemaVersions(versions[i].Version, versions[j].Version) > 0
+	})
+	return versions, nil
+}
+
+func (s *schemaInMemory) UpdateMetadata(_ context.Context, _ core.DID, id uuid.UUID, title, description string) error {
+	schema, found := s.schemas[id]
+	if !found {
+		return ErrSchemaDoesNotExist
+	}
+	schema.Title, schema.Description = title, description
+	s.schemas[id] = schema
+	return nil
+}
+
+func (s *schemaInMemory) UpdateDeprecatedAt(_ context.Context, _ core.DID, id uuid.UUID, deprecatedAt *time.Time) error {
+	schema, found := s.schemas[id]
+	if !found {
+		return ErrSchemaDoesNotExist
+	}
+	schema.DeprecatedAt = deprecatedAt
+	s.schemas[id] = schema
+	return nil
+}
+
+func (s *schemaInMemory) GetDeprecatedAtByURL(_ context.Context, _ core.DID, url string) (*time.Time, error) {
+	var last *domain.Schema
+	for _, schema := range s.schemas {
+		schema := schema
+		if schema.URL == url && (last == nil || schema.CreatedAt.After(last.CreatedAt)) {
+			last = &schema
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	return last.DeprecatedAt, nil
+}
+
+func (s *schemaInMemory) GetDocumentByURL(_ context.Context, url string) ([]byte, error) {
+	for _, schema := range s.schemas {
+		if schema.URL == url && schema.Document != nil {
+			return schema.Document, nil
+		}
+		if schema.ContextURL == url && schema.ContextDocument != nil {
+			return schema.ContextDocument, nil
+		}
+	}
+	return nil, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -25,6 +26,21 @@ type dbSchema struct {
 	Type       string
 	Hash       string
 	Attributes string
+	ProofTypes []string
+	Expiration *string
+	PII        []string
+	MaxActive  *int
+	Supersede  bool
+	Hooks      domain.PostIssuanceHooks
+	Display    domain.DisplayStrings
+	Prereqs    domain.Prerequisites
+	Version    string
+	Title      string
+	Desc       string
+	Deprecated *time.Time
+	Document   []byte
+	ContextDoc []byte
+	ContextURL string
 	CreatedAt  time.Time
 }
 
@@ -39,7 +55,7 @@ func NewSchema(conn db.Storage) *schema {
 
 // Save stores a new entry in schemas table
 func (r *schema) Save(ctx context.Context, s *domain.Schema) error {
-	const insertSchema = `INSERT INTO schemas (id, issuer_id, url, type, attributes, hash, ts_words, created_at) VALUES($1, $2::text, $3::text, $4::text, $5::text, $6::text, to_tsvector($7::text), $8);`
+	const insertSchema = `INSERT INTO schemas (id, issuer_id, url, type, attributes, hash, ts_words, default_proof_types, default_expiration, pii_attributes, max_active_per_subject, revoke_superseded, post_issuance_hooks, display_strings, prerequisites, version, title, description, deprecated_at, json_schema, jsonld_context, context_url, created_at) VALUES($1, $2::text, $3::text, $4::text, $5::text, $6::text, to_tsvector($7::text), $8, $9, $10, $11, $12, $13, $14, $15, NULLIF($16::text, ''), NULLIF($17::text, ''), NULLIF($18::text, ''), $19, $20, $21, NULLIF($22::text, ''), $23);`
 	hash, err := s.Hash.MarshalText()
 	if err != nil {
 		return err
@@ -53,7 +69,22 @@ func (r *schema) Save(ctx context.Context, s *domain.Schema) error {
 		s.Type,
 		s.Attributes.String(),
 		string(hash),
-		r.toFullTextSearchDocument(s.Type, s.Attributes),
+		r.toFullTextSearchDocument(s.Type+" "+s.Title, s.Attributes),
+		s.DefaultProofTypes.Strings(),
+		expirationPolicyString(s.DefaultExpiration),
+		piiAttributesStrings(s.PIIAttributes),
+		s.MaxActivePerSubject,
+		s.RevokeSuperseded,
+		postIssuanceHooksJSON(s.PostIssuanceHooks),
+		displayStringsJSON(s.DisplayStrings),
+		prerequisitesJSON(s.Prerequisites),
+		s.Version,
+		s.Title,
+		s.Description,
+		s.DeprecatedAt,
+		s.Document,
+		s.ContextDocument,
+		s.ContextURL,
 		s.CreatedAt)
 	return err
 }
@@ -71,12 +102,12 @@ func (r *schema) toFullTextSearchDocument(sType string, attrs domain.SchemaAttrs
 // GetAll returns all the schemas that match any of the words that are included in the query string.
 // For each word, it will search for attributes that start with it or include it following postgres full text search tokenization
 func (r *schema) GetAll(ctx context.Context, issuerDID core.DID, query *string) ([]domain.Schema, error) {
-	const all = `SELECT id, issuer_id, url, type, attributes, hash, created_at
+	const all = `SELECT id, issuer_id, url, type, attributes, hash, default_proof_types, default_expiration, pii_attributes, max_active_per_subject, revoke_superseded, post_issuance_hooks, display_strings, prerequisites, COALESCE(version, ''), COALESCE(title, ''), COALESCE(description, ''), deprecated_at, created_at
 	FROM schemas
 	WHERE issuer_id=$1
 	ORDER BY created_at DESC`
 	const allFTS = `
-SELECT id, issuer_id, url, type, attributes, hash, created_at 
+SELECT id, issuer_id, url, type, attributes, hash, default_proof_types, default_expiration, pii_attributes, max_active_per_subject, revoke_superseded, post_issuance_hooks, display_strings, prerequisites, COALESCE(version, ''), COALESCE(title, ''), COALESCE(description, ''), deprecated_at, created_at 
 FROM schemas 
 WHERE issuer_id=$1 AND ts_words @@ to_tsquery($2)
 ORDER BY created_at DESC`
@@ -84,9 +115,9 @@ ORDER BY created_at DESC`
 	var rows pgx.Rows
 
 	if query != nil && *query != "" {
-		rows, err = r.conn.Pgx.Query(ctx, allFTS, issuerDID.String(), fullTextSearchQuery(*query, " | "))
+		rows, err = r.conn.Reader().Query(ctx, allFTS, issuerDID.String(), fullTextSearchQuery(*query, " | "))
 	} else {
-		rows, err = r.conn.Pgx.Query(ctx, all, issuerDID.String())
+		rows, err = r.conn.Reader().Query(ctx, all, issuerDID.String())
 	}
 	if err != nil {
 		return nil, err
@@ -95,7 +126,7 @@ ORDER BY created_at DESC`
 	schemaCol := make([]domain.Schema, 0)
 	s := dbSchema{}
 	for rows.Next() {
-		if err := rows.Scan(&s.ID, &s.IssuerID, &s.URL, &s.Type, &s.Attributes, &s.Hash, &s.CreatedAt); err != nil {
+		if err := rows.Scan(&s.ID, &s.IssuerID, &s.URL, &s.Type, &s.Attributes, &s.Hash, &s.ProofTypes, &s.Expiration, &s.PII, &s.MaxActive, &s.Supersede, &s.Hooks, &s.Display, &s.Prereqs, &s.Version, &s.Title, &s.Desc, &s.Deprecated, &s.CreatedAt); err != nil {
 			return nil, err
 		}
 		item, err := toSchemaDomain(&s)
@@ -109,13 +140,13 @@ ORDER BY created_at DESC`
 
 // GetByID searches and returns an schema by id
 func (r *schema) GetByID(ctx context.Context, issuerDID core.DID, id uuid.UUID) (*domain.Schema, error) {
-	const byID = `SELECT id, issuer_id, url, type, attributes, hash, created_at 
+	const byID = `SELECT id, issuer_id, url, type, attributes, hash, default_proof_types, default_expiration, pii_attributes, max_active_per_subject, revoke_superseded, post_issuance_hooks, display_strings, prerequisites, COALESCE(version, ''), COALESCE(title, ''), COALESCE(description, ''), deprecated_at, json_schema, jsonld_context, COALESCE(context_url, ''), created_at 
 		FROM schemas 
 		WHERE issuer_id = $1 AND id=$2`
 
 	s := dbSchema{}
 	row := r.conn.Pgx.QueryRow(ctx, byID, issuerDID.String(), id)
-	err := row.Scan(&s.ID, &s.IssuerID, &s.URL, &s.Type, &s.Attributes, &s.Hash, &s.CreatedAt)
+	err := row.Scan(&s.ID, &s.IssuerID, &s.URL, &s.Type, &s.Attributes, &s.Hash, &s.ProofTypes, &s.Expiration, &s.PII, &s.MaxActive, &s.Supersede, &s.Hooks, &s.Display, &s.Prereqs, &s.Version, &s.Title, &s.Desc, &s.Deprecated, &s.Document, &s.ContextDoc, &s.ContextURL, &s.CreatedAt)
 	if err == pgx.ErrNoRows {
 		return nil, ErrSchemaDoesNotExist
 	}
@@ -125,6 +156,407 @@ func (r *schema) GetByID(ctx context.Context, issuerDID core.DID, id uuid.UUID)
 	return toSchemaDomain(&s)
 }
 
+// UpdateDefaultProofTypes sets the default proof types of a schema. Nil removes them.
+func (r *schema) UpdateDefaultProofTypes(ctx context.Context, issuerDID core.DID, id uuid.UUID, proofTypes domain.ProofTypes) error {
+	const update = `UPDATE schemas SET default_proof_types = $3 WHERE issuer_id = $1 AND id = $2`
+	res, err := r.conn.Pgx.Exec(ctx, update, issuerDID.String(), id, proofTypes.Strings())
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSchemaDoesNotExist
+	}
+	return nil
+}
+
+// GetDefaultProofTypesByURL returns the default proof types of the last imported schema with the given url that has them.
+// It returns nil if there is none.
+func (r *schema) GetDefaultProofTypesByURL(ctx context.Context, issuerDID core.DID, url string) (domain.ProofTypes, error) {
+	const byURL = `SELECT default_proof_types 
+		FROM schemas 
+		WHERE issuer_id = $1 AND url = $2 AND default_proof_types IS NOT NULL
+		ORDER BY created_at DESC
+		LIMIT 1`
+	var proofTypes []string
+	err := r.conn.Pgx.QueryRow(ctx, byURL, issuerDID.String(), url).Scan(&proofTypes)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return domain.ProofTypesFromStrings(proofTypes), nil
+}
+
+// UpdateDefaultExpiration sets the default expiration policy of a schema. Nil removes it.
+func (r *schema) UpdateDefaultExpiration(ctx context.Context, issuerDID core.DID, id uuid.UUID, policy *domain.ExpirationPolicy) error {
+	const update = `UPDATE schemas SET default_expiration = $3 WHERE issuer_id = $1 AND id = $2`
+	res, err := r.conn.Pgx.Exec(ctx, update, issuerDID.String(), id, expirationPolicyString(policy))
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSchemaDoesNotExist
+	}
+	return nil
+}
+
+// GetDefaultExpirationByURL returns the default expiration policy of the last imported schema with the given url that has one.
+// It returns nil if there is none.
+func (r *schema) GetDefaultExpirationByURL(ctx context.Context, issuerDID core.DID, url string) (*domain.ExpirationPolicy, error) {
+	const byURL = `SELECT default_expiration 
+		FROM schemas 
+		WHERE issuer_id = $1 AND url = $2 AND default_expiration IS NOT NULL
+		ORDER BY created_at DESC
+		LIMIT 1`
+	var policy *string
+	err := r.conn.Pgx.QueryRow(ctx, byURL, issuerDID.String(), url).Scan(&policy)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toExpirationPolicy(policy)
+}
+
+// UpdatePIIAttributes sets the PII attributes of a schema. Nil removes them.
+func (r *schema) UpdatePIIAttributes(ctx context.Context, issuerDID core.DID, id uuid.UUID, attrs domain.SchemaAttrs) error {
+	const update = `UPDATE schemas SET pii_attributes = $3 WHERE issuer_id = $1 AND id = $2`
+	res, err := r.conn.Pgx.Exec(ctx, update, issuerDID.String(), id, piiAttributesStrings(attrs))
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSchemaDoesNotExist
+	}
+	return nil
+}
+
+// GetPIIAttributesByURL returns the PII attributes of the last imported schema with the given url that has them.
+// It returns nil if there is none.
+func (r *schema) GetPIIAttributesByURL(ctx context.Context, issuerDID core.DID, url string) (domain.SchemaAttrs, error) {
+	const byURL = `SELECT pii_attributes 
+		FROM schemas 
+		WHERE issuer_id = $1 AND url = $2 AND pii_attributes IS NOT NULL
+		ORDER BY created_at DESC
+		LIMIT 1`
+	var attrs []string
+	err := r.conn.Pgx.QueryRow(ctx, byURL, issuerDID.String(), url).Scan(&attrs)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// UpdateMaxActivePerSubject sets the maximum number of active credentials of a schema per subject. Nil removes it.
+func (r *schema) UpdateMaxActivePerSubject(ctx context.Context, issuerDID core.DID, id uuid.UUID, maxActive *int) error {
+	const update = `UPDATE schemas SET max_active_per_subject = $3 WHERE issuer_id = $1 AND id = $2`
+	res, err := r.conn.Pgx.Exec(ctx, update, issuerDID.String(), id, maxActive)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSchemaDoesNotExist
+	}
+	return nil
+}
+
+// GetMaxActivePerSubjectByURL returns the maximum number of active credentials per subject of the last imported schema
+// with the given url that has one. It returns nil if there is none.
+func (r *schema) GetMaxActivePerSubjectByURL(ctx context.Context, issuerDID core.DID, url string) (*int, error) {
+	const byURL = `SELECT max_active_per_subject 
+		FROM schemas 
+		WHERE issuer_id = $1 AND url = $2 AND max_active_per_subject IS NOT NULL
+		ORDER BY created_at DESC
+		LIMIT 1`
+	var maxActive int
+	err := r.conn.Pgx.QueryRow(ctx, byURL, issuerDID.String(), url).Scan(&maxActive)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &maxActive, nil
+}
+
+// UpdateRevokeSuperseded sets whether the previous credentials of a schema of a subject are revoked when a new one is issued
+func (r *schema) UpdateRevokeSuperseded(ctx context.Context, issuerDID core.DID, id uuid.UUID, revokeSuperseded bool) error {
+	const update = `UPDATE schemas SET revoke_superseded = $3 WHERE issuer_id = $1 AND id = $2`
+	res, err := r.conn.Pgx.Exec(ctx, update, issuerDID.String(), id, revokeSuperseded)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSchemaDoesNotExist
+	}
+	return nil
+}
+
+// GetRevokeSupersededByURL tells whether any imported schema with the given url revokes the superseded credentials
+func (r *schema) GetRevokeSupersededByURL(ctx context.Context, issuerDID core.DID, url string) (bool, error) {
+	const byURL = `SELECT EXISTS(SELECT 1 FROM schemas WHERE issuer_id = $1 AND url = $2 AND revoke_superseded)`
+	var revokeSuperseded bool
+	err := r.conn.Pgx.QueryRow(ctx, byURL, issuerDID.String(), url).Scan(&revokeSuperseded)
+	return revokeSuperseded, err
+}
+
+// UpdatePostIssuanceHooks sets the post-issuance hooks of a schema. Nil removes them.
+func (r *schema) UpdatePostIssuanceHooks(ctx context.Context, issuerDID core.DID, id uuid.UUID, hooks domain.PostIssuanceHooks) error {
+	const update = `UPDATE schemas SET post_issuance_hooks = $3 WHERE issuer_id = $1 AND id = $2`
+	res, err := r.conn.Pgx.Exec(ctx, update, issuerDID.String(), id, postIssuanceHooksJSON(hooks))
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSchemaDoesNotExist
+	}
+	return nil
+}
+
+// GetPostIssuanceHooksByURL returns the post-issuance hooks of the last imported schema with the given url that has them.
+// It returns nil if there is none.
+func (r *schema) GetPostIssuanceHooksByURL(ctx context.Context, issuerDID core.DID, url string) (domain.PostIssuanceHooks, error) {
+	const byURL = `SELECT post_issuance_hooks 
+		FROM schemas 
+		WHERE issuer_id = $1 AND url = $2 AND post_issuance_hooks IS NOT NULL
+		ORDER BY created_at DESC
+		LIMIT 1`
+	var hooks domain.PostIssuanceHooks
+	err := r.conn.Pgx.QueryRow(ctx, byURL, issuerDID.String(), url).Scan(&hooks)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return hooks, nil
+}
+
+// GetUsageByURL returns the credentials of the schema url issued and revoked between from and to, both included and
+// optional, with the counts of every day in the period
+func (r *schema) GetUsageByURL(ctx context.Context, issuerDID core.DID, url string, from, to *time.Time) (*domain.SchemaUsage, error) {
+	const byURL = `SELECT day, issued, issued_by_link, revoked 
+		FROM schema_usage 
+		WHERE issuer_id = $1 AND schema_url = $2 AND ($3::date IS NULL OR day >= $3) AND ($4::date IS NULL OR day <= $4)
+		ORDER BY day`
+	rows, err := r.conn.Pgx.Query(ctx, byURL, issuerDID.String(), url, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usage := &domain.SchemaUsage{Days: make([]domain.SchemaUsageDay, 0)}
+	for rows.Next() {
+		var day domain.SchemaUsageDay
+		if err := rows.Scan(&day.Day, &day.Issued, &day.IssuedByLink, &day.Revoked); err != nil {
+			return nil, err
+		}
+		usage.Issued += day.Issued
+		usage.IssuedByLink += day.IssuedByLink
+		usage.Revoked += day.Revoked
+		usage.Days = append(usage.Days, day)
+	}
+	return usage, rows.Err()
+}
+
+// UpdateDisplayStrings sets the localized display strings of a schema. Nil removes them.
+func (r *schema) UpdateDisplayStrings(ctx context.Context, issuerDID core.DID, id uuid.UUID, display domain.DisplayStrings) error {
+	const update = `UPDATE schemas SET display_strings = $3 WHERE issuer_id = $1 AND id = $2`
+	res, err := r.conn.Pgx.Exec(ctx, update, issuerDID.String(), id, displayStringsJSON(display))
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSchemaDoesNotExist
+	}
+	return nil
+}
+
+// GetDisplayStringsByURL returns the display strings of the last imported schema with the given url that has them.
+// It returns nil if there is none.
+func (r *schema) GetDisplayStringsByURL(ctx context.Context, issuerDID core.DID, url string) (domain.DisplayStrings, error) {
+	const byURL = `SELECT display_strings 
+		FROM schemas 
+		WHERE issuer_id = $1 AND url = $2 AND display_strings IS NOT NULL
+		ORDER BY created_at DESC
+		LIMIT 1`
+	var display domain.DisplayStrings
+	err := r.conn.Pgx.QueryRow(ctx, byURL, issuerDID.String(), url).Scan(&display)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return display, nil
+}
+
+// UpdatePrerequisites sets the prerequisite credentials of a schema. Nil removes them.
+func (r *schema) UpdatePrerequisites(ctx context.Context, issuerDID core.DID, id uuid.UUID, prerequisites domain.Prerequisites) error {
+	const update = `UPDATE schemas SET prerequisites = $3 WHERE issuer_id = $1 AND id = $2`
+	res, err := r.conn.Pgx.Exec(ctx, update, issuerDID.String(), id, prerequisitesJSON(prerequisites))
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSchemaDoesNotExist
+	}
+	return nil
+}
+
+// GetVersions returns the schemas of the registry of the given type, from the newest version to the oldest
+func (r *schema) GetVersions(ctx context.Context, issuerDID core.DID, sType string) ([]domain.Schema, error) {
+	const byType = `SELECT id, issuer_id, url, type, attributes, hash, default_proof_types, default_expiration, pii_attributes, max_active_per_subject, revoke_superseded, post_issuance_hooks, display_strings, prerequisites, COALESCE(version, ''), COALESCE(title, ''), COALESCE(description, ''), deprecated_at, created_at 
+		FROM schemas 
+		WHERE issuer_id = $1 AND type = $2 AND version IS NOT NULL`
+	rows, err := r.conn.Pgx.Query(ctx, byType, issuerDID.String(), sType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	versions := make([]domain.Schema, 0)
+	s := dbSchema{}
+	for rows.Next() {
+		if err := rows.Scan(&s.ID, &s.IssuerID, &s.URL, &s.Type, &s.Attributes, &s.Hash, &s.ProofTypes, &s.Expiration, &s.PII, &s.MaxActive, &s.Supersede, &s.Hooks, &s.Display, &s.Prereqs, &s.Version, &s.Title, &s.Desc, &s.Deprecated, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		item, err := toSchemaDomain(&s)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, *item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return domain.CompareSchemaVersions(versions[i].Version, versions[j].Version) > 0
+	})
+	return versions, nil
+}
+
+// UpdateMetadata sets the title and the description of a schema
+func (r *schema) UpdateMetadata(ctx context.Context, issuerDID core.DID, id uuid.UUID, title, description string) error {
+	const update = `UPDATE schemas SET title = NULLIF($3::text, ''), description = NULLIF($4::text, ''), ts_words = to_tsvector($5::text) WHERE issuer_id = $1 AND id = $2`
+	s, err := r.GetByID(ctx, issuerDID, id)
+	if err != nil {
+		return err
+	}
+	res, err := r.conn.Pgx.Exec(ctx, update, issuerDID.String(), id, title, description, r.toFullTextSearchDocument(s.Type+" "+title, s.Attributes))
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSchemaDoesNotExist
+	}
+	return nil
+}
+
+// UpdateDeprecatedAt sets when a schema was deprecated. Nil undeprecates it.
+func (r *schema) UpdateDeprecatedAt(ctx context.Context, issuerDID core.DID, id uuid.UUID, deprecatedAt *time.Time) error {
+	const update = `UPDATE schemas SET deprecated_at = $3 WHERE issuer_id = $1 AND id = $2`
+	res, err := r.conn.Pgx.Exec(ctx, update, issuerDID.String(), id, deprecatedAt)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSchemaDoesNotExist
+	}
+	return nil
+}
+
+// GetDeprecatedAtByURL returns when the last imported schema with the given url was deprecated.
+// It returns nil if it is not deprecated or there is none.
+func (r *schema) GetDeprecatedAtByURL(ctx context.Context, issuerDID core.DID, url string) (*time.Time, error) {
+	const byURL = `SELECT deprecated_at 
+		FROM schemas 
+		WHERE issuer_id = $1 AND url = $2
+		ORDER BY created_at DESC
+		LIMIT 1`
+	var deprecatedAt *time.Time
+	err := r.conn.Pgx.QueryRow(ctx, byURL, issuerDID.String(), url).Scan(&deprecatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return deprecatedAt, nil
+}
+
+// GetDocumentByURL returns the json schema or the JSON-LD context of the schema of the registry published in url.
+// It returns nil if there is none.
+func (r *schema) GetDocumentByURL(ctx context.Context, url string) ([]byte, error) {
+	const byURL = `SELECT CASE WHEN url = $1 THEN json_schema ELSE jsonld_context END 
+		FROM schemas 
+		WHERE (url = $1 AND json_schema IS NOT NULL) OR (context_url = $1 AND jsonld_context IS NOT NULL)
+		LIMIT 1`
+	var doc []byte
+	err := r.conn.Pgx.QueryRow(ctx, byURL, url).Scan(&doc)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// postIssuanceHooksJSON returns the hooks to store in the jsonb column, where no hooks are NULL
+func postIssuanceHooksJSON(hooks domain.PostIssuanceHooks) any {
+	if len(hooks) == 0 {
+		return nil
+	}
+	return hooks
+}
+
+// displayStringsJSON returns the display strings to store in a jsonb column, where no display strings are NULL
+func displayStringsJSON(display domain.DisplayStrings) any {
+	if len(display) == 0 {
+		return nil
+	}
+	return display
+}
+
+// prerequisitesJSON returns the prerequisites to store in a jsonb column, where no prerequisites are NULL
+func prerequisitesJSON(prerequisites domain.Prerequisites) any {
+	if len(prerequisites) == 0 {
+		return nil
+	}
+	return prerequisites
+}
+
+func piiAttributesStrings(attrs domain.SchemaAttrs) []string {
+	if attrs == nil {
+		return nil
+	}
+	return attrs
+}
+
+func toExpirationPolicy(value *string) (*domain.ExpirationPolicy, error) {
+	if value == nil {
+		return nil, nil
+	}
+	policy, err := domain.ParseExpirationPolicy(*value)
+	if err != nil {
+		return nil, fmt.Errorf("parsing expiration policy: %w", err)
+	}
+	return policy, nil
+}
+
+func expirationPolicyString(policy *domain.ExpirationPolicy) *string {
+	if policy == nil {
+		return nil
+	}
+	value := policy.String()
+	return &value
+}
+
 func toSchemaDomain(s *dbSchema) (*domain.Schema, error) {
 	issuerDID, err := core.ParseDID(s.IssuerID)
 	if err != nil {
@@ -134,13 +566,32 @@ func toSchemaDomain(s *dbSchema) (*domain.Schema, error) {
 	if err != nil {
 		return nil, fmt.Errorf("parsing hash from schema: %w", err)
 	}
+	expiration, err := toExpirationPolicy(s.Expiration)
+	if err != nil {
+		return nil, fmt.Errorf("parsing schema default expiration: %w", err)
+	}
 	return &domain.Schema{
-		ID:         s.ID,
-		IssuerDID:  *issuerDID,
-		URL:        s.URL,
-		Type:       s.Type,
-		Hash:       schemaHash,
-		Attributes: domain.SchemaAttrsFromString(s.Attributes),
-		CreatedAt:  s.CreatedAt,
+		ID:                  s.ID,
+		IssuerDID:           *issuerDID,
+		URL:                 s.URL,
+		Type:                s.Type,
+		Hash:                schemaHash,
+		Attributes:          domain.SchemaAttrsFromString(s.Attributes),
+		DefaultProofTypes:   domain.ProofTypesFromStrings(s.ProofTypes),
+		DefaultExpiration:   expiration,
+		PIIAttributes:       s.PII,
+		MaxActivePerSubject: s.MaxActive,
+		RevokeSuperseded:    s.Supersede,
+		PostIssuanceHooks:   s.Hooks,
+		DisplayStrings:      s.Display,
+		Prerequisites:       s.Prereqs,
+		Version:             s.Version,
+		Title:               s.Title,
+		Description:         s.Desc,
+		DeprecatedAt:        s.Deprecated,
+		Document:            s.Document,
+		ContextDocument:     s.ContextDoc,
+		ContextURL:          s.ContextURL,
+		CreatedAt:           s.CreatedAt,
 	}, nil
 }
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+type schemaUsage struct{}
+
+// NewSchemaUsage returns a new schema usage repository
+func NewSchemaUsage() ports.SchemaUsageRepository {
+	return &schemaUsage{}
+}
+
+// AddIssued counts a credential of the schema issued at the given time
+func (r *schemaUsage) AddIssued(ctx context.Context, conn db.Querier, issuerDID core.DID, schemaURL string, byLink bool, at time.Time) error {
+	byLinkCount := 0
+	if byLink {
+		byLinkCount = 1
+	}
+	_, err := conn.Exec(ctx,
+		`INSERT INTO schema_usage (issuer_id, schema_url, day, issued, issued_by_link) VALUES ($1, $2, $3, 1, $4)
+		ON CONFLICT (issuer_id, schema_url, day) DO UPDATE 
+		SET issued = schema_usage.issued + 1, issued_by_link = schema_usage.issued_by_link + EXCLUDED.issued_by_link`,
+		issuerDID.String(), schemaURL, at.UTC().Format(time.DateOnly), byLinkCount)
+	return err
+}
+
+// AddRevoked counts a credential of the schema revoked at the given time
+func (r *schemaUsage) AddRevoked(ctx context.Context, conn db.Querier, issuerDID core.DID, schemaURL string, at time.Time) error {
+	_, err := conn.Exec(ctx,
+		`INSERT INTO schema_usage (issuer_id, schema_url, day, revoked) VALUES ($1, $2, $3, 1)
+		ON CONFLICT (issuer_id, schema_url, day) DO UPDATE SET revoked = schema_usage.revoked + 1`,
+		issuerDID.String(), schemaURL, at.UTC().Format(time.DateOnly))
+	return err
+}
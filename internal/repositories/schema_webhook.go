@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// ErrSchemaWebhookDoesNotExist schema webhook does not exist
+var ErrSchemaWebhookDoesNotExist = errors.New("schema webhook does not exist")
+
+type schemaWebhook struct{}
+
+// NewSchemaWebhook returns a new schema webhook repository
+func NewSchemaWebhook() ports.SchemaWebhookRepository {
+	return &schemaWebhook{}
+}
+
+// Save stores a new schema webhook
+func (r *schemaWebhook) Save(ctx context.Context, conn db.Querier, webhook *domain.SchemaWebhook) error {
+	_, err := conn.Exec(ctx,
+		`INSERT INTO schema_webhooks (id, issuer_id, schema_type, url, events, auth_header, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		webhook.ID, webhook.IssuerDID.String(), webhook.SchemaType, webhook.URL, webhook.Events, webhook.Authorization, webhook.CreatedAt)
+	return err
+}
+
+// GetAll returns the schema webhooks of the issuer, the oldest first
+func (r *schemaWebhook) GetAll(ctx context.Context, conn db.Querier, issuerDID core.DID) ([]domain.SchemaWebhook, error) {
+	rows, err := conn.Query(ctx,
+		`SELECT id, schema_type, url, events, auth_header, created_at
+		FROM schema_webhooks
+		WHERE issuer_id = $1
+		ORDER BY created_at`, issuerDID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]domain.SchemaWebhook, 0)
+	for rows.Next() {
+		webhook := domain.SchemaWebhook{IssuerDID: issuerDID}
+		if err := rows.Scan(&webhook.ID, &webhook.SchemaType, &webhook.URL, &webhook.Events, &webhook.Authorization, &webhook.CreatedAt); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// Delete removes a schema webhook of the issuer
+func (r *schemaWebhook) Delete(ctx context.Context, conn db.Querier, issuerDID core.DID, id uuid.UUID) error {
+	res, err := conn.Exec(ctx, `DELETE FROM schema_webhooks WHERE issuer_id = $1 AND id = $2`, issuerDID.String(), id)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSchemaWebhookDoesNotExist
+	}
+	return nil
+}
@@ -2,55 +2,211 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
 	"fmt"
 	"time"
 
 	"github.com/iden3/iden3comm/protocol"
+	"github.com/jackc/pgx/v4"
 
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
 	"github.com/polygonid/sh-id-platform/pkg/cache"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
 	link_state "github.com/polygonid/sh-id-platform/pkg/link"
 )
 
 const (
 	defaultTTL = 5 * time.Minute
+
+	// expiredSessionGrace is how long an expired entry is kept in the cache, so a late lookup is counted as expired
+	// instead of as a miss.
+	expiredSessionGrace = time.Hour
+
+	sessionAuthRequest = "auth_request"
+	sessionLinkState   = "link_state"
+)
+
+var (
+	errAuthRequestNotFound = errors.New("authorization request not found")
+	errLinkStateNotFound   = errors.New("link state not found")
 )
 
+// sessionMetrics counts the session lookups by payload type and result, e.g. link_state.expired.
+// They are published with the rest of the expvar variables.
+var sessionMetrics = expvar.NewMap("session_store")
+
+// SessionTTLs is how long each type of session payload can be read after it is stored
+type SessionTTLs struct {
+	AuthRequest time.Duration // authorization requests of the auth and link qr codes
+	LinkState   time.Duration // state of a link offer, including the credential offer once it is issued
+	Clock       clock.Clock   // time source of the expirations, the system clock when nil
+}
+
+// DefaultSessionTTLs are the TTLs used when none is configured
+var DefaultSessionTTLs = SessionTTLs{AuthRequest: defaultTTL, LinkState: defaultTTL}
+
+func (t SessionTTLs) withDefaults() SessionTTLs {
+	if t.AuthRequest <= 0 {
+		t.AuthRequest = DefaultSessionTTLs.AuthRequest
+	}
+	if t.LinkState <= 0 {
+		t.LinkState = DefaultSessionTTLs.LinkState
+	}
+	t.Clock = clock.OrSystem(t.Clock)
+	return t
+}
+
+// sessionEntry is a session payload with its own expiration, so expired entries can be told apart from missing ones
+type sessionEntry[T any] struct {
+	Value     T         `json:"value"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 type cached struct {
 	cache cache.Cache
+	ttls  SessionTTLs
 }
 
 // NewSessionCached returns a new cached manager
 func NewSessionCached(c cache.Cache) ports.SessionRepository {
-	return &cached{cache: c}
+	return NewSessionCachedWithTTLs(c, DefaultSessionTTLs)
+}
+
+// NewSessionCachedWithTTLs returns a new cached manager that keeps each type of payload for the given TTLs
+func NewSessionCachedWithTTLs(c cache.Cache, ttls SessionTTLs) ports.SessionRepository {
+	return &cached{cache: c, ttls: ttls.withDefaults()}
 }
 
 // Get returns the cached session
 func (c *cached) Get(ctx context.Context, key string) (protocol.AuthorizationRequestMessage, error) {
-	var message protocol.AuthorizationRequestMessage
-	found := c.cache.Get(ctx, key, &message)
+	message, found := getCachedSession[protocol.AuthorizationRequestMessage](ctx, c.cache, c.ttls.Clock, sessionAuthRequest, key)
 	if !found {
-		return message, fmt.Errorf("authorization request not found")
+		return message, errAuthRequestNotFound
 	}
-
 	return message, nil
 }
 
 // Set stores the given session information
 func (c *cached) Set(ctx context.Context, key string, value protocol.AuthorizationRequestMessage) error {
-	return c.cache.Set(ctx, key, value, defaultTTL)
+	return setCachedSession(ctx, c.cache, c.ttls.Clock, key, value, c.ttls.AuthRequest)
 }
 
 // SetLink - stores the given session information
 func (c *cached) SetLink(ctx context.Context, key string, value link_state.State) error {
-	return c.cache.Set(ctx, key, value, defaultTTL)
+	return setCachedSession(ctx, c.cache, c.ttls.Clock, key, value, c.ttls.LinkState)
 }
 
+// GetLink - returns the stored link state
 func (c *cached) GetLink(ctx context.Context, key string) (link_state.State, error) {
-	var message link_state.State
-	found := c.cache.Get(ctx, key, &message)
+	state, found := getCachedSession[link_state.State](ctx, c.cache, c.ttls.Clock, sessionLinkState, key)
 	if !found {
-		return message, fmt.Errorf("link state not found")
+		return state, errLinkStateNotFound
+	}
+	return state, nil
+}
+
+func setCachedSession[T any](ctx context.Context, c cache.Cache, clk clock.Clock, key string, value T, ttl time.Duration) error {
+	return c.Set(ctx, key, sessionEntry[T]{Value: value, ExpiresAt: clk.Now().Add(ttl)}, ttl+expiredSessionGrace)
+}
+
+func getCachedSession[T any](ctx context.Context, c cache.Cache, clk clock.Clock, payloadType string, key string) (T, bool) {
+	var entry sessionEntry[T]
+	if !c.Get(ctx, key, &entry) {
+		countSessionLookup(payloadType, "miss")
+		return entry.Value, false
+	}
+	if clk.Now().After(entry.ExpiresAt) {
+		countSessionLookup(payloadType, "expired")
+		var zero T
+		return zero, false
+	}
+	countSessionLookup(payloadType, "hit")
+	return entry.Value, true
+}
+
+type sessionPostgres struct {
+	conn db.Storage
+	ttls SessionTTLs
+}
+
+// NewSessionPostgres returns a session manager that keeps the sessions in the sessions table, so they survive
+// restarts and cache evictions
+func NewSessionPostgres(conn db.Storage, ttls SessionTTLs) ports.SessionRepository {
+	return &sessionPostgres{conn: conn, ttls: ttls.withDefaults()}
+}
+
+// Get returns the stored session
+func (s *sessionPostgres) Get(ctx context.Context, key string) (protocol.AuthorizationRequestMessage, error) {
+	var message protocol.AuthorizationRequestMessage
+	if err := s.get(ctx, sessionAuthRequest, key, &message); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return message, errAuthRequestNotFound
+		}
+		return message, err
 	}
 	return message, nil
 }
+
+// Set stores the given session information
+func (s *sessionPostgres) Set(ctx context.Context, key string, value protocol.AuthorizationRequestMessage) error {
+	return s.set(ctx, sessionAuthRequest, key, value, s.ttls.AuthRequest)
+}
+
+// SetLink - stores the given session information
+func (s *sessionPostgres) SetLink(ctx context.Context, key string, value link_state.State) error {
+	return s.set(ctx, sessionLinkState, key, value, s.ttls.LinkState)
+}
+
+// GetLink - returns the stored link state
+func (s *sessionPostgres) GetLink(ctx context.Context, key string) (link_state.State, error) {
+	var state link_state.State
+	if err := s.get(ctx, sessionLinkState, key, &state); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return state, errLinkStateNotFound
+		}
+		return state, err
+	}
+	return state, nil
+}
+
+func (s *sessionPostgres) set(ctx context.Context, payloadType string, key string, value any, ttl time.Duration) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	// Entries that are never read again are removed once they are past the grace period of the expired ones
+	if _, err := s.conn.Pgx.Exec(ctx, `DELETE FROM sessions WHERE expires_at < $1`, s.ttls.Clock.Now().Add(-expiredSessionGrace)); err != nil {
+		return err
+	}
+	_, err = s.conn.Pgx.Exec(ctx,
+		`INSERT INTO sessions (key, payload_type, payload, expires_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO UPDATE SET payload_type=$2, payload=$3, expires_at=$4`,
+		key, payloadType, payload, s.ttls.Clock.Now().Add(ttl))
+	return err
+}
+
+func (s *sessionPostgres) get(ctx context.Context, payloadType string, key string, value any) error {
+	var payload []byte
+	var expiresAt time.Time
+	err := s.conn.Pgx.QueryRow(ctx, `SELECT payload, expires_at FROM sessions WHERE key = $1 AND payload_type = $2`, key, payloadType).
+		Scan(&payload, &expiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			countSessionLookup(payloadType, "miss")
+		}
+		return err
+	}
+	if s.ttls.Clock.Now().After(expiresAt) {
+		countSessionLookup(payloadType, "expired")
+		return pgx.ErrNoRows
+	}
+	countSessionLookup(payloadType, "hit")
+	return json.Unmarshal(payload, value)
+}
+
+func countSessionLookup(payloadType string, result string) {
+	sessionMetrics.Add(fmt.Sprintf("%s.%s", payloadType, result), 1)
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/claimarchive"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+type subject struct{}
+
+// NewSubject returns a new subject data repository
+func NewSubject() ports.SubjectRepository {
+	return &subject{}
+}
+
+// Erase moves the credentials of the subject to erased_claims, the archived ones too, keeping only what is needed to
+// revoke them, deletes its connection and stores the erasure record. It must be called inside a transaction.
+func (s *subject) Erase(ctx context.Context, conn db.Querier, erasure *domain.SubjectErasure, subject core.DID) error {
+	_, err := conn.Exec(ctx, `INSERT INTO subject_erasures (id, issuer_id, subject_hash, credentials, connections, created_at)
+		VALUES ($1, $2, $3, 0, 0, $4)`, erasure.ID, erasure.IssuerDID.String(), erasure.SubjectHash, erasure.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error saving erasure record: %w", err)
+	}
+
+	_, err = conn.Exec(ctx, `INSERT INTO erased_claims (id, erasure_id, identifier, schema_hash, rev_nonce, index_hash, revoked)
+		SELECT id, $1, identifier, schema_hash, rev_nonce, index_hash, coalesce(revoked, false)
+		FROM claims WHERE identifier = $2 AND other_identifier = $3`, erasure.ID, erasure.IssuerDID.String(), subject.String())
+	if err != nil {
+		return fmt.Errorf("error keeping revocation data of erased claims: %w", err)
+	}
+
+	if err := db.SetCredentialEvent(ctx, conn, domain.CredentialEventErased); err != nil {
+		return err
+	}
+	erased, err := deleteClaims(ctx, conn, `DELETE FROM claims WHERE identifier = $1 AND other_identifier = $2 RETURNING id`, erasure.IssuerDID.String(), subject.String())
+	if err != nil {
+		return fmt.Errorf("error erasing claims: %w", err)
+	}
+	erasure.Credentials = int64(len(erased))
+
+	if err := claimarchive.FillSubjects(ctx, conn, erasure.IssuerDID.String()); err != nil {
+		return fmt.Errorf("error filling subjects of archived claims: %w", err)
+	}
+	_, err = conn.Exec(ctx, `INSERT INTO erased_claims (id, erasure_id, identifier, schema_hash, rev_nonce, index_hash, revoked)
+		SELECT id, $1, identifier, schema_hash, rev_nonce, index_hash, revoked
+		FROM archived_claims WHERE identifier = $2 AND subject = $3`, erasure.ID, erasure.IssuerDID.String(), subject.String())
+	if err != nil {
+		return fmt.Errorf("error keeping revocation data of erased archived claims: %w", err)
+	}
+
+	erased, err = deleteClaims(ctx, conn, `DELETE FROM archived_claims WHERE identifier = $1 AND subject = $2 RETURNING id`, erasure.IssuerDID.String(), subject.String())
+	if err != nil {
+		return fmt.Errorf("error erasing archived claims: %w", err)
+	}
+	// the archived claims are no longer in the claims table, their erasure is recorded in the credential log directly
+	_, err = conn.Exec(ctx, `SELECT record_credential_event($1, id, $2) FROM unnest($3::uuid[]) AS id`,
+		erasure.IssuerDID.String(), domain.CredentialEventErased, uuidStrings(erased))
+	if err != nil {
+		return fmt.Errorf("error recording the erasure of archived claims: %w", err)
+	}
+	erasure.Credentials += int64(len(erased))
+
+	cmd, err := conn.Exec(ctx, `DELETE FROM connections WHERE issuer_id = $1 AND user_id = $2`, erasure.IssuerDID.String(), subject.String())
+	if err != nil {
+		return fmt.Errorf("error erasing connection: %w", err)
+	}
+	erasure.Connections = cmd.RowsAffected()
+
+	_, err = conn.Exec(ctx, `UPDATE subject_erasures SET credentials = $2, connections = $3 WHERE id = $1`,
+		erasure.ID, erasure.Credentials, erasure.Connections)
+	return err
+}
+
+func (s *subject) GetErasures(ctx context.Context, conn db.Querier, issuerDID core.DID, subjectHash string) ([]*domain.SubjectErasure, error) {
+	rows, err := conn.Query(ctx, `SELECT id, subject_hash, credentials, connections, created_at
+		FROM subject_erasures
+		WHERE issuer_id = $1 AND subject_hash = $2
+		ORDER BY created_at`, issuerDID.String(), subjectHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	erasures := make([]*domain.SubjectErasure, 0)
+	for rows.Next() {
+		erasure := domain.SubjectErasure{IssuerDID: issuerDID}
+		if err := rows.Scan(&erasure.ID, &erasure.SubjectHash, &erasure.Credentials, &erasure.Connections, &erasure.CreatedAt); err != nil {
+			return nil, err
+		}
+		erasures = append(erasures, &erasure)
+	}
+
+	return erasures, rows.Err()
+}
+
+// GetArchivedClaims returns the credentials of the subject moved to the archive by the issuer
+func (s *subject) GetArchivedClaims(ctx context.Context, conn db.Querier, issuerDID core.DID, subject core.DID) ([]*domain.Claim, error) {
+	if err := claimarchive.FillSubjects(ctx, conn, issuerDID.String()); err != nil {
+		return nil, fmt.Errorf("error filling subjects of archived claims: %w", err)
+	}
+	archived, err := claimarchive.SubjectClaims(ctx, conn, issuerDID.String(), subject.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// the archived rows are read back with the columns the claims table has now
+	const query = `SELECT claims.id,
+				   issuer,
+				   schema_hash,
+				   schema_url,
+				   schema_type,
+				   other_identifier,
+				   expiration,
+				   updatable,
+				   claims.version,
+				   rev_nonce,
+				   signature_proof,
+				   mtp_proof,
+				   data,
+				   claims.identifier,
+				   identity_state,
+				   identity_states.status,
+				   credential_status,
+				   core_claim,
+				   coalesce(claims.revoked, false) OR $2,
+				   mtp
+			FROM json_populate_record(NULL::claims, $1::json) AS claims
+			LEFT JOIN identity_states  ON claims.identity_state = identity_states.state`
+
+	claims := make([]*domain.Claim, 0, len(archived))
+	for _, a := range archived {
+		rows, err := conn.Query(ctx, query, string(a.Row), a.Revoked)
+		if err != nil {
+			return nil, err
+		}
+		claim, err := processClaims(rows)
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading archived claim %s: %w", a.ID, err)
+		}
+		claims = append(claims, claim...)
+	}
+	return claims, nil
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestAgentMessage_Record(t *testing.T) {
+	ctx := context.Background()
+	agentMessages := repositories.NewAgentMessage()
+	hash := sha256.Sum256([]byte(uuid.NewString()))
+
+	recorded, err := agentMessages.Record(ctx, storage.Pgx, hash[:], time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, recorded)
+
+	recorded, err = agentMessages.Record(ctx, storage.Pgx, hash[:], time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, recorded, "the same message can't be recorded twice while it is remembered")
+
+	other := sha256.Sum256([]byte(uuid.NewString()))
+	recorded, err = agentMessages.Record(ctx, storage.Pgx, other[:], time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	assert.True(t, recorded)
+
+	recorded, err = agentMessages.Record(ctx, storage.Pgx, other[:], time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, recorded, "an expired message is forgotten")
+}
+
+func TestAgentMessagePruner_Prune(t *testing.T) {
+	ctx := context.Background()
+	agentMessages := repositories.NewAgentMessage()
+	expired := sha256.Sum256([]byte(uuid.NewString()))
+	kept := sha256.Sum256([]byte(uuid.NewString()))
+
+	_, err := agentMessages.Record(ctx, storage.Pgx, expired[:], time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	_, err = agentMessages.Record(ctx, storage.Pgx, kept[:], time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	pruned, err := repositories.NewAgentMessagePruner(storage.Pgx).Prune(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, pruned, int64(1))
+
+	var count int
+	require.NoError(t, storage.Pgx.QueryRow(ctx, `SELECT count(*) FROM agent_messages WHERE hash = $1 OR hash = $2`, expired[:], kept[:]).Scan(&count))
+	assert.Equal(t, 1, count, "only the expired message is pruned")
+}
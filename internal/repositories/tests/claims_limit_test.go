@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db/tests"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestClaimsCountActiveBySubjectAndSchema(t *testing.T) {
+	ctx := context.Background()
+	idStr := "did:polygonid:polygon:mumbai:2qKZg1vCMwJeMzvVayn9ebUHpnD6QCxTgk6T28THxy"
+	fixture := tests.NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	did, err := core.ParseDID(idStr)
+	require.NoError(t, err)
+
+	claimsRepo := repositories.NewClaims()
+	save := func(revoked bool, expiration int64) *domain.Claim {
+		claim := fixture.NewClaim(t, idStr)
+		claim.HIndex = uuid.NewString()
+		claim.Revoked = revoked
+		claim.Expiration = expiration
+		_, err := claimsRepo.Save(ctx, storage.Pgx, claim)
+		require.NoError(t, err)
+		return claim
+	}
+	active := save(false, 0)
+	save(false, time.Now().Add(time.Hour).Unix())
+	save(true, 0)
+	save(false, time.Now().Add(-time.Hour).Unix())
+
+	count, err := claimsRepo.CountActiveBySubjectAndSchema(ctx, storage.Pgx, *did, active.OtherIdentifier, active.SchemaURL)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "the revoked and expired claims are not active")
+
+	count, err = claimsRepo.CountActiveBySubjectAndSchema(ctx, storage.Pgx, *did, "did:polygonid:polygon:mumbai:2qFrLQA6R1bfUTxjRnZEN9st77g6ZN2c7Vw1Dq6Vpp", active.SchemaURL)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	t.Run("should lock the subject schema until the end of the transaction", func(t *testing.T) {
+		require.NoError(t, storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+			return claimsRepo.LockSubjectSchema(ctx, tx, *did, active.OtherIdentifier, active.SchemaURL)
+		}))
+	})
+
+	t.Run("should store the schema max active credentials per subject", func(t *testing.T) {
+		schemaRepo := repositories.NewSchema(*storage)
+		i := &big.Int{}
+		i.SetInt64(rand.Int63())
+		schema := &domain.Schema{
+			ID:         uuid.New(),
+			IssuerDID:  *did,
+			URL:        active.SchemaURL,
+			Type:       active.SchemaType,
+			Hash:       core.NewSchemaHashFromInt(i),
+			Attributes: domain.SchemaAttrs{"birthday", "documentType"},
+			CreatedAt:  time.Now(),
+		}
+		require.NoError(t, schemaRepo.Save(ctx, schema))
+
+		maxActive, err := schemaRepo.GetMaxActivePerSubjectByURL(ctx, *did, active.SchemaURL)
+		require.NoError(t, err)
+		assert.Nil(t, maxActive)
+
+		require.NoError(t, schemaRepo.UpdateMaxActivePerSubject(ctx, *did, schema.ID, common.ToPointer(1)))
+		maxActive, err = schemaRepo.GetMaxActivePerSubjectByURL(ctx, *did, active.SchemaURL)
+		require.NoError(t, err)
+		assert.Equal(t, common.ToPointer(1), maxActive)
+
+		stored, err := schemaRepo.GetByID(ctx, *did, schema.ID)
+		require.NoError(t, err)
+		assert.Equal(t, common.ToPointer(1), stored.MaxActivePerSubject)
+
+		require.NoError(t, schemaRepo.UpdateMaxActivePerSubject(ctx, *did, schema.ID, nil))
+		maxActive, err = schemaRepo.GetMaxActivePerSubjectByURL(ctx, *did, active.SchemaURL)
+		require.NoError(t, err)
+		assert.Nil(t, maxActive)
+		assert.ErrorIs(t, schemaRepo.UpdateMaxActivePerSubject(ctx, *did, uuid.New(), nil), repositories.ErrSchemaDoesNotExist)
+	})
+}
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db/tests"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/blobstore"
+)
+
+func TestClaimsWithPayloadStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := blobstore.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	claimsRepo := repositories.NewClaimsWithPayloadStore(store, 1024)
+
+	idStr := "did:polygonid:polygon:mumbai:2qFBjGm3Ja6sbPL6Wm4SXDCShHkCnNRcV5ebAoCsRp"
+	fixture := tests.NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	did, err := core.ParseDID(idStr)
+	require.NoError(t, err)
+
+	photo := strings.Repeat("iVBORw0KGgo", 200)
+	claim := fixture.NewClaim(t, idStr)
+	var vc verifiable.W3CCredential
+	require.NoError(t, json.Unmarshal(claim.Data.Bytes, &vc))
+	vc.CredentialSubject["photo"] = photo
+	require.NoError(t, claim.Data.Set(vc))
+
+	id, err := claimsRepo.Save(ctx, storage.Pgx, claim)
+	require.NoError(t, err)
+	assert.Contains(t, string(claim.Data.Bytes), photo, "the saved claim keeps its payload")
+
+	t.Run("should keep the large attributes out of the claims table", func(t *testing.T) {
+		var data string
+		require.NoError(t, storage.Pgx.QueryRow(ctx, `SELECT data::text FROM claims WHERE id = $1`, id).Scan(&data))
+		assert.NotContains(t, data, photo)
+		assert.Contains(t, data, "$payloadRef")
+		assert.Contains(t, data, "birthday")
+	})
+
+	t.Run("should return the claims with their payloads", func(t *testing.T) {
+		got, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, did, id)
+		require.NoError(t, err)
+		var credential verifiable.W3CCredential
+		require.NoError(t, json.Unmarshal(got.Data.Bytes, &credential))
+		assert.Equal(t, photo, credential.CredentialSubject["photo"])
+		assert.Equal(t, float64(19960424), credential.CredentialSubject["birthday"])
+
+		all, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, *did, &ports.ClaimsFilter{})
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Contains(t, string(all[0].Data.Bytes), photo)
+
+		byNonce, err := repositories.NewClaims().GetByRevocationNonce(ctx, storage.Pgx, did, claim.RevNonce)
+		require.NoError(t, err)
+		assert.NotContains(t, string(byNonce.Data.Bytes), photo, "the plain repository returns the stored data")
+	})
+
+	t.Run("should fail when the payload is missing", func(t *testing.T) {
+		emptyStore, err := blobstore.NewFileStore(t.TempDir())
+		require.NoError(t, err)
+		_, err = repositories.NewClaimsWithPayloadStore(emptyStore, 1024).GetByIdAndIssuer(ctx, storage.Pgx, did, id)
+		assert.ErrorIs(t, err, blobstore.ErrNotFound)
+	})
+
+	t.Run("should delete the payloads of the deleted claims", func(t *testing.T) {
+		collector := repositories.NewPayloadCollector(storage.Pgx, store)
+		ref := func() string {
+			var key string
+			require.NoError(t, storage.Pgx.QueryRow(ctx, `SELECT payload_key FROM claim_payloads WHERE claim_id = $1`, id).Scan(&key))
+			return key
+		}()
+		expire := func() {
+			_, err := storage.Pgx.Exec(ctx, `UPDATE payloads SET updated_at = $2 WHERE key = $1`, ref, time.Now().Add(-2*repositories.PayloadGracePeriod))
+			require.NoError(t, err)
+		}
+
+		expire()
+		collected, err := collector.Collect(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, collected, "the payload is referenced")
+
+		require.NoError(t, claimsRepo.Delete(ctx, storage.Pgx, *did, id))
+		expire()
+		collected, err = collector.Collect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, collected)
+		_, err = store.Get(ctx, ref)
+		assert.ErrorIs(t, err, blobstore.ErrNotFound)
+	})
+}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db/tests"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestClaimsPIIAttributes(t *testing.T) {
+	ctx := context.Background()
+	idStr := "did:polygonid:polygon:mumbai:2qKZg1vCMwJeN4F5tyGhyjn8HPqHLJHS5eTWmud1Bj"
+	fixture := tests.NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	did, err := core.ParseDID(idStr)
+	require.NoError(t, err)
+
+	claim := fixture.NewClaim(t, idStr)
+	claimsRepo := repositories.NewClaims()
+	id, err := claimsRepo.Save(ctx, storage.Pgx, claim)
+	require.NoError(t, err)
+
+	schemaRepo := repositories.NewSchema(*storage)
+	i := &big.Int{}
+	i.SetInt64(rand.Int63())
+	schema := &domain.Schema{
+		ID:         uuid.New(),
+		IssuerDID:  *did,
+		URL:        claim.SchemaURL,
+		Type:       claim.SchemaType,
+		Hash:       core.NewSchemaHashFromInt(i),
+		Attributes: domain.SchemaAttrs{"birthday", "documentType"},
+		CreatedAt:  time.Now(),
+	}
+	require.NoError(t, schemaRepo.Save(ctx, schema))
+
+	subject := func(filter *ports.ClaimsFilter) map[string]any {
+		all, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, *did, filter)
+		require.NoError(t, err)
+		for _, c := range all {
+			if c.ID == id {
+				var vc verifiable.W3CCredential
+				require.NoError(t, json.Unmarshal(c.Data.Bytes, &vc))
+				return vc.CredentialSubject
+			}
+		}
+		require.Fail(t, "claim not found")
+		return nil
+	}
+
+	t.Run("should not mask the schemas without pii attributes", func(t *testing.T) {
+		attrs, err := schemaRepo.GetPIIAttributesByURL(ctx, *did, claim.SchemaURL)
+		require.NoError(t, err)
+		assert.Nil(t, attrs)
+		assert.Equal(t, float64(19960424), subject(&ports.ClaimsFilter{})["birthday"])
+	})
+
+	require.NoError(t, schemaRepo.UpdatePIIAttributes(ctx, *did, schema.ID, domain.SchemaAttrs{"birthday"}))
+
+	t.Run("should mask the pii attributes by default", func(t *testing.T) {
+		attrs, err := schemaRepo.GetPIIAttributesByURL(ctx, *did, claim.SchemaURL)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SchemaAttrs{"birthday"}, attrs)
+
+		masked := subject(&ports.ClaimsFilter{})
+		assert.Equal(t, domain.MaskedValue, masked["birthday"])
+		assert.Equal(t, float64(2), masked["documentType"])
+		assert.Equal(t, "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ", masked["id"])
+	})
+
+	t.Run("should return the pii attributes when requested", func(t *testing.T) {
+		assert.Equal(t, float64(19960424), subject(&ports.ClaimsFilter{PII: true})["birthday"])
+
+		stored, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, did, id)
+		require.NoError(t, err)
+		assert.Contains(t, string(stored.Data.Bytes), "19960424")
+	})
+
+	t.Run("should not mask after removing the tags", func(t *testing.T) {
+		require.NoError(t, schemaRepo.UpdatePIIAttributes(ctx, *did, schema.ID, nil))
+		assert.Equal(t, float64(19960424), subject(&ports.ClaimsFilter{})["birthday"])
+		assert.ErrorIs(t, schemaRepo.UpdatePIIAttributes(ctx, *did, uuid.New(), nil), repositories.ErrSchemaDoesNotExist)
+	})
+}
@@ -302,6 +302,57 @@ func TestGetAllByIssuerID(t *testing.T) {
 	}
 }
 
+func TestGetAllByIssuerIDPages(t *testing.T) {
+	ctx := context.Background()
+	fixture := tests.NewFixture(storage)
+	issuerDID, err := core.ParseDID("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ")
+	require.NoError(t, err)
+	subject := "did:example:" + uuid.NewString()
+
+	issued := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]uuid.UUID, 3)
+	for i := range ids {
+		c := &domain.Claim{
+			ID:              uuid.New(),
+			Identifier:      common.ToPointer(issuerDID.String()),
+			Issuer:          issuerDID.String(),
+			SchemaHash:      "ca938857241db9451ea329256b9c06e5",
+			SchemaURL:       "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+			SchemaType:      "KYCAgeCredential",
+			OtherIdentifier: subject,
+			HIndex:          fmt.Sprintf("%d", rand.Int()),
+		}
+		issuanceDate := issued.AddDate(0, 0, i)
+		require.NoError(t, c.Data.Set(&verifiable.W3CCredential{ID: uuid.NewString(), IssuanceDate: &issuanceDate}))
+		ids[len(ids)-1-i] = fixture.CreateClaim(t, c)
+	}
+
+	claimsRepo := repositories.NewClaims()
+	filter := &ports.ClaimsFilter{Subject: subject, Limit: 2}
+	page, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, *issuerDID, filter)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, ids[0], page[0].ID, "the newest issued first")
+	assert.Equal(t, ids[1], page[1].ID)
+
+	filter.Offset = 2
+	page, err = claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, *issuerDID, filter)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, ids[2], page[0].ID)
+
+	total, err := claimsRepo.CountByIssuerID(ctx, storage.Pgx, *issuerDID, filter)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+
+	all, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, *issuerDID, &ports.ClaimsFilter{Subject: subject})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	for i := range ids {
+		assert.Equal(t, ids[i], all[i].ID, "the claims without a page are sorted too")
+	}
+}
+
 func TestGetClaimsIssuedForUserID(t *testing.T) {
 	ctx := context.Background()
 	fixture := tests.NewFixture(storage)
@@ -380,3 +431,58 @@ func TestGetClaimsIssuedForUserID(t *testing.T) {
 		})
 	}
 }
+
+func TestClaimsBatch(t *testing.T) {
+	ctx := context.Background()
+	fixture := tests.NewFixture(storage)
+	claimsRepo := repositories.NewClaims()
+	did := core.DID{}
+	require.NoError(t, did.SetString("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ"))
+
+	claims := make([]*domain.Claim, 3)
+	for i := range claims {
+		claims[i] = fixture.NewClaim(t, did.String())
+		claims[i].HIndex = uuid.NewString()
+	}
+	claims[1].ID = uuid.Nil
+
+	ids, err := claimsRepo.SaveBatch(ctx, storage.Pgx, claims)
+	require.NoError(t, err)
+	require.Len(t, ids, len(claims))
+	assert.NotEqual(t, uuid.Nil, ids[1])
+	for i, id := range ids {
+		assert.Equal(t, claims[i].ID, id)
+		saved, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, &did, id)
+		require.NoError(t, err)
+		assert.Equal(t, claims[i].SchemaURL, saved.SchemaURL)
+	}
+
+	_, err = claimsRepo.SaveBatch(ctx, storage.Pgx, claims[:1])
+	assert.ErrorIs(t, err, repositories.ErrClaimDuplication)
+
+	state := "d0f3e2d7e5f8cb0e66a6e1c1c0a8a3b2b0c1d5e9f3a7b6c5d4e3f2a1b0c9d8e7"
+	affected, err := claimsRepo.UpdateStateBatch(ctx, storage.Pgx, &did, state, ids[:2])
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), affected)
+
+	updated := make([]domain.Claim, len(claims))
+	for i, claim := range claims {
+		updated[i] = *claim
+		require.NoError(t, updated[i].MTPProof.Set(map[string]any{"type": "Iden3SparseMerkleTreeProof", "coreClaim": fmt.Sprintf("%d", i)}))
+	}
+	affected, err = claimsRepo.UpdateClaimMTPBatch(ctx, storage.Pgx, &did, updated)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(claims)), affected)
+
+	for i, id := range ids {
+		saved, err := claimsRepo.GetByIdAndIssuer(ctx, storage.Pgx, &did, id)
+		require.NoError(t, err)
+		if i < 2 {
+			require.NotNil(t, saved.IdentityState)
+			assert.Equal(t, state, *saved.IdentityState)
+		} else {
+			assert.Nil(t, saved.IdentityState)
+		}
+		assert.JSONEq(t, string(updated[i].MTPProof.Bytes), string(saved.MTPProof.Bytes))
+	}
+}
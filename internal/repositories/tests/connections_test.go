@@ -81,6 +81,46 @@ func TestUpdatePushToken(t *testing.T) {
 	})
 }
 
+func TestConnectionsSeen(t *testing.T) {
+	connectionsRepo := repositories.NewConnections()
+	issuerDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qMZrfBsXuGFTwSqkqYki78zF3pe1vtXoqH4yRLsfs")
+	require.NoError(t, err)
+	userDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qFDziX3k3h7To2jDJbQiXFtcozbgSNNasMhSAMAhm")
+	require.NoError(t, err)
+	userDoc := json.RawMessage(`{"id": "did:polygonid:polygon:mumbai:2qFDziX3k3h7To2jDJbQiXFtcozbgSNNasMhSAMAhm"}`)
+	ctx := context.Background()
+
+	t.Run("should create the connection if it doesn't exist", func(t *testing.T) {
+		at := time.Now().UTC().Truncate(time.Second)
+		connID, err := connectionsRepo.Seen(ctx, storage.Pgx, *issuerDID, *userDID, at)
+		require.NoError(t, err)
+		connDB, err := connectionsRepo.GetByUserID(ctx, storage.Pgx, *issuerDID, *userDID)
+		require.NoError(t, err)
+		assert.Equal(t, connID, connDB.ID)
+		require.NotNil(t, connDB.LastSeen)
+		assert.True(t, at.Equal(*connDB.LastSeen))
+	})
+
+	t.Run("should update the last seen without modifying the connection", func(t *testing.T) {
+		connDB, err := connectionsRepo.GetByUserID(ctx, storage.Pgx, *issuerDID, *userDID)
+		require.NoError(t, err)
+		connDB.UserDoc = userDoc
+		_, err = connectionsRepo.Save(ctx, storage.Pgx, connDB)
+		require.NoError(t, err)
+
+		at := connDB.LastSeen.Add(time.Hour)
+		connID, err := connectionsRepo.Seen(ctx, storage.Pgx, *issuerDID, *userDID, at)
+		require.NoError(t, err)
+		assert.Equal(t, connDB.ID, connID)
+
+		connDB, err = connectionsRepo.GetByUserID(ctx, storage.Pgx, *issuerDID, *userDID)
+		require.NoError(t, err)
+		require.NotNil(t, connDB.LastSeen)
+		assert.True(t, at.Equal(*connDB.LastSeen))
+		assert.JSONEq(t, string(userDoc), string(connDB.UserDoc))
+	})
+}
+
 func TestDelete(t *testing.T) {
 	connectionsRepo := repositories.NewConnections()
 	fixture := tests.NewFixture(storage)
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db/tests"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestCredentialEvents(t *testing.T) {
+	ctx := context.Background()
+	eventRepo := repositories.NewCredentialEvent()
+	claimsRepo := repositories.NewClaims()
+
+	idStr := "did:polygonid:polygon:mumbai:2qFpPHotk6oyaX1fcrpQFT4BMnmg8YszUwxYtaoGoe"
+	fixture := tests.NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	issuerDID, err := core.ParseDID(idStr)
+	require.NoError(t, err)
+
+	claimID := fixture.CreateClaim(t, fixture.NewClaim(t, idStr))
+	fixture.ExecQuery(t, tests.ExecQueryParams{Query: `UPDATE claims SET revoked = true WHERE id = $1`, Arguments: []interface{}{claimID}})
+	fixture.ExecQuery(t, tests.ExecQueryParams{Query: `UPDATE claims SET revoked = true WHERE id = $1`, Arguments: []interface{}{claimID}})
+	require.NoError(t, claimsRepo.Delete(ctx, storage.Pgx, *issuerDID, claimID))
+
+	t.Run("should chain the events of the claims", func(t *testing.T) {
+		events, err := eventRepo.GetEvents(ctx, storage.Pgx, *issuerDID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 3, "the second revocation changes nothing")
+		var previous *domain.CredentialEvent
+		for i, kind := range []string{domain.CredentialEventIssued, domain.CredentialEventRevoked, domain.CredentialEventDeleted} {
+			assert.Equal(t, kind, events[i].Event)
+			assert.Equal(t, claimID, events[i].ClaimID)
+			assert.True(t, events[i].Follows(previous), "the database and the domain compute the same hashes")
+			previous = &events[i]
+		}
+
+		head, err := eventRepo.GetHead(ctx, storage.Pgx, *issuerDID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), head.Seq)
+	})
+
+	t.Run("should detect a modified event", func(t *testing.T) {
+		fixture.ExecQuery(t, tests.ExecQueryParams{
+			Query:     `UPDATE credential_events SET event = $3 WHERE issuer = $1 AND seq = $2`,
+			Arguments: []interface{}{idStr, 2, domain.CredentialEventIssued},
+		})
+		events, err := eventRepo.GetEvents(ctx, storage.Pgx, *issuerDID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		assert.False(t, events[1].Follows(&events[0]))
+		assert.True(t, events[2].Follows(&events[1]), "only the modified event is wrong")
+	})
+
+	t.Run("should keep the anchors", func(t *testing.T) {
+		_, err := eventRepo.GetLastAnchor(ctx, storage.Pgx, *issuerDID)
+		assert.ErrorIs(t, err, repositories.ErrCredentialEventAnchorDoesNotExist)
+
+		head, err := eventRepo.GetHead(ctx, storage.Pgx, *issuerDID)
+		require.NoError(t, err)
+		require.NoError(t, eventRepo.SaveAnchor(ctx, storage.Pgx, &domain.CredentialEventAnchor{Issuer: idStr, Seq: head.Seq, Hash: head.Hash, State: "state"}))
+		anchor, err := eventRepo.GetLastAnchor(ctx, storage.Pgx, *issuerDID)
+		require.NoError(t, err)
+		assert.Equal(t, head.Hash, anchor.Hash)
+		assert.Equal(t, "state", anchor.State)
+	})
+}
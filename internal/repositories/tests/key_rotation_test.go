@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db/tests"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	idStr := "did:polygonid:polygon:mumbai:2qGZ2ZNAfsXv3nLyxgcRjvQaBKEgT4G4VmWWhRuYdX"
+	fixture := tests.NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	did, err := core.ParseDID(idStr)
+	require.NoError(t, err)
+
+	keyRotationRepo := repositories.NewKeyRotation()
+	newRotation := func() *domain.KeyRotation {
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		return &domain.KeyRotation{
+			ID:             uuid.New(),
+			IssuerDID:      *did,
+			Status:         domain.KeyRotationAdding,
+			OldAuthClaimID: uuid.New(),
+			NewAuthClaimID: uuid.New(),
+			NewKeyID:       "BJJ:" + uuid.NewString(),
+			CreatedAt:      now,
+			ModifiedAt:     now,
+		}
+	}
+
+	rotation := newRotation()
+	require.NoError(t, keyRotationRepo.Save(ctx, storage.Pgx, rotation))
+
+	t.Run("should allow one active rotation per identity", func(t *testing.T) {
+		err := keyRotationRepo.Save(ctx, storage.Pgx, newRotation())
+		assert.ErrorIs(t, err, repositories.ErrKeyRotationActive)
+
+		active, err := keyRotationRepo.GetActiveByIssuer(ctx, storage.Pgx, *did)
+		require.NoError(t, err)
+		assert.Equal(t, rotation.ID, active.ID)
+	})
+
+	t.Run("should update the progress of the rotation", func(t *testing.T) {
+		rotation.Status = domain.KeyRotationRevoking
+		rotation.AddState = common.ToPointer("b25cf54e7e648a263658416194c41ef6ae2dec101c50dfb2febc5e96eaa87110")
+		rotation.Error = common.ToPointer("the state failed to publish")
+		rotation.ModifiedAt = rotation.ModifiedAt.Add(time.Minute)
+		require.NoError(t, keyRotationRepo.Save(ctx, storage.Pgx, rotation))
+
+		got, err := keyRotationRepo.GetByID(ctx, storage.Pgx, *did, rotation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.KeyRotationRevoking, got.Status)
+		assert.Equal(t, rotation.AddState, got.AddState)
+		assert.Nil(t, got.RevokeState)
+		assert.Equal(t, rotation.Error, got.Error)
+		assert.Equal(t, rotation.NewKeyID, got.NewKeyID)
+		assert.Equal(t, rotation.ModifiedAt, got.ModifiedAt.UTC())
+		assert.Equal(t, did.String(), got.IssuerDID.String())
+
+		active, err := keyRotationRepo.GetActive(ctx, storage.Pgx)
+		require.NoError(t, err)
+		ids := make([]uuid.UUID, len(active))
+		for i := range active {
+			ids[i] = active[i].ID
+		}
+		assert.Contains(t, ids, rotation.ID)
+	})
+
+	t.Run("should start a new rotation once the previous one is completed", func(t *testing.T) {
+		rotation.Status = domain.KeyRotationCompleted
+		rotation.Error = nil
+		require.NoError(t, keyRotationRepo.Save(ctx, storage.Pgx, rotation))
+
+		_, err := keyRotationRepo.GetActiveByIssuer(ctx, storage.Pgx, *did)
+		assert.ErrorIs(t, err, repositories.ErrKeyRotationDoesNotExist)
+
+		require.NoError(t, keyRotationRepo.Save(ctx, storage.Pgx, newRotation()))
+	})
+
+	t.Run("should not find the rotation of other identity", func(t *testing.T) {
+		other, err := core.ParseDID("did:polygonid:polygon:mumbai:2qFrLQA6R1bfUTxjRnZEN9st77g6ZN2c7Vw1Dq6Vpp")
+		require.NoError(t, err)
+		_, err = keyRotationRepo.GetByID(ctx, storage.Pgx, *other, rotation.ID)
+		assert.ErrorIs(t, err, repositories.ErrKeyRotationDoesNotExist)
+	})
+}
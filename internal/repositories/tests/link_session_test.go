@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db/tests"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestLinkSession(t *testing.T) {
+	ctx := context.Background()
+	idStr := "did:polygonid:polygon:mumbai:2qE1ZT16aqEWhh9mX9aqM2pe2ZwV995dTkReeKwCaQ"
+	userDID, err := core.ParseDID("did:polygonid:polygon:mumbai:2qFrLQA6R1bfUTxjRnZEN9st77g6ZN2c7Vw1Dq6Vpp")
+	require.NoError(t, err)
+	fixture := tests.NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	did, err := core.ParseDID(idStr)
+	require.NoError(t, err)
+
+	schemaID := insertSchemaForLink(ctx, idStr, repositories.NewSchema(*storage), t)
+	link := domain.NewLink(*did, common.ToPointer(10), nil, schemaID, nil, true, false, domain.CredentialSubject{"birthday": 19790911, "documentType": 1})
+	linkID, err := repositories.NewLink(*storage).Save(ctx, storage.Pgx, link)
+	require.NoError(t, err)
+
+	linkSessionRepo := repositories.NewLinkSession()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	created := domain.NewLinkSession(uuid.New(), *did, *linkID, now)
+	require.NoError(t, linkSessionRepo.Save(ctx, storage.Pgx, created))
+	issued := domain.NewLinkSession(uuid.New(), *did, *linkID, now.Add(time.Second))
+	require.NoError(t, issued.Authenticate(*userDID, now.Add(time.Second)))
+	claimID := uuid.New()
+	require.NoError(t, issued.Issue(claimID, now.Add(2*time.Second)))
+	require.NoError(t, linkSessionRepo.Save(ctx, storage.Pgx, issued))
+
+	t.Run("should get the session with its progress", func(t *testing.T) {
+		got, err := linkSessionRepo.GetByID(ctx, storage.Pgx, *did, *linkID, issued.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.LinkSessionIssued, got.Status)
+		assert.Equal(t, issued.UserDID, got.UserDID)
+		assert.Equal(t, &claimID, got.ClaimID)
+		assert.Nil(t, got.Error)
+		assert.Equal(t, issued.ModifiedAt, got.ModifiedAt.UTC())
+		assert.Equal(t, did.String(), got.IssuerDID.String())
+	})
+
+	t.Run("should list the sessions of the link, the newest first", func(t *testing.T) {
+		sessions, err := linkSessionRepo.GetByLink(ctx, storage.Pgx, *did, *linkID, ports.LinkSessionsFilter{})
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, issued.ID, sessions[0].ID)
+		assert.Equal(t, created.ID, sessions[1].ID)
+
+		status := domain.LinkSessionCreated
+		sessions, err = linkSessionRepo.GetByLink(ctx, storage.Pgx, *did, *linkID, ports.LinkSessionsFilter{Status: &status})
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, created.ID, sessions[0].ID)
+
+		sessions, err = linkSessionRepo.GetByLink(ctx, storage.Pgx, *did, *linkID, ports.LinkSessionsFilter{UserDID: common.ToPointer(userDID.String()), Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, issued.ID, sessions[0].ID)
+	})
+
+	t.Run("should mark the session fetched with its credential", func(t *testing.T) {
+		require.NoError(t, linkSessionRepo.MarkFetched(ctx, storage.Pgx, claimID))
+		got, err := linkSessionRepo.GetByID(ctx, storage.Pgx, *did, *linkID, issued.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.LinkSessionFetched, got.Status)
+	})
+
+	t.Run("should not find the session of other link", func(t *testing.T) {
+		_, err := linkSessionRepo.GetByID(ctx, storage.Pgx, *did, uuid.New(), created.ID)
+		assert.ErrorIs(t, err, repositories.ErrLinkSessionDoesNotExist)
+	})
+}
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/pkg/antiabuse"
+	"github.com/polygonid/sh-id-platform/pkg/cache"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+)
+
+type postgresTestEvent struct {
+	Name string
+}
+
+func (e *postgresTestEvent) Marshal() (pubsub.Message, error) {
+	return json.Marshal(e)
+}
+
+func (e *postgresTestEvent) Unmarshal(msg pubsub.Message) error {
+	return json.Unmarshal(msg, e)
+}
+
+func TestPostgresCache(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewPostgresCache(storage.Pgx)
+	type entry struct {
+		Schema    []byte
+		Extension string
+	}
+
+	key := uuid.New().String()
+	require.NoError(t, c.Set(ctx, key, entry{Schema: []byte(`{"a":1}`), Extension: "json"}, time.Hour))
+	var got entry
+	require.True(t, c.Get(ctx, key, &got))
+	assert.Equal(t, entry{Schema: []byte(`{"a":1}`), Extension: "json"}, got)
+	assert.True(t, c.Exists(ctx, key))
+
+	require.NoError(t, c.Set(ctx, key, entry{Extension: "jsonld"}, cache.ForEver))
+	require.True(t, c.Get(ctx, key, &got))
+	assert.Equal(t, "jsonld", got.Extension)
+
+	require.NoError(t, c.Delete(ctx, key))
+	assert.False(t, c.Get(ctx, key, &got))
+	assert.False(t, c.Exists(ctx, key))
+
+	expired := uuid.New().String()
+	require.NoError(t, c.Set(ctx, expired, entry{}, -time.Second))
+	assert.False(t, c.Get(ctx, expired, &got))
+	assert.False(t, c.Exists(ctx, expired))
+	removed, err := c.Cleanup(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, removed, int64(1))
+}
+
+func TestPostgresThrottle(t *testing.T) {
+	ctx := context.Background()
+	scope := uuid.New().String()
+	throttle := antiabuse.NewPostgresIPThrottle(storage.Pgx, scope, 2)
+	other := antiabuse.NewPostgresIPThrottle(storage.Pgx, scope, 2)
+
+	_, err := throttle.Allow(ctx, "1.2.3.4")
+	require.NoError(t, err)
+	_, err = other.Allow(ctx, "1.2.3.4")
+	require.NoError(t, err, "the servers share the bucket of the ip")
+	wait, err := throttle.Allow(ctx, "1.2.3.4")
+	assert.ErrorIs(t, err, antiabuse.ErrThrottled)
+	assert.Greater(t, wait, time.Duration(0))
+
+	_, err = antiabuse.NewPostgresIPThrottle(storage.Pgx, uuid.New().String(), 2).Allow(ctx, "1.2.3.4")
+	assert.NoError(t, err, "the scopes have their own buckets")
+	_, err = other.Allow(ctx, "5.6.7.8")
+	assert.NoError(t, err)
+
+	removed, err := throttle.Cleanup(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), removed, "the ips seen recently are kept")
+}
+
+func TestPostgresPubSub(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ps := pubsub.NewPostgres(storage.Pgx)
+	topic := "test-" + uuid.New().String()
+
+	received := make(chan string, 1)
+	ps.Subscribe(ctx, topic, func(_ context.Context, msg pubsub.Message) error {
+		var e postgresTestEvent
+		if err := e.Unmarshal(msg); err != nil {
+			return err
+		}
+		received <- e.Name
+		return nil
+	})
+
+	// The subscriber listens in the background, so the event is published until it arrives
+	require.Eventually(t, func() bool {
+		require.NoError(t, ps.Publish(ctx, topic, &postgresTestEvent{Name: "credential"}))
+		select {
+		case name := <-received:
+			return name == "credential"
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestQueryPlans(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewQueryPlans()
+
+	plans, err := repo.Explain(ctx, storage.Pgx)
+	require.NoError(t, err)
+	names := make([]string, len(plans))
+	for i, plan := range plans {
+		names[i] = plan.Name
+		assert.NotEmpty(t, plan.Index)
+		assert.Positive(t, plan.TotalCost)
+	}
+	assert.Equal(t, []string{"claims_by_issuer", "claims_by_subject", "claim_by_revocation_nonce", "active_claims_by_subject_and_schema", "unpublished_claims", "links_by_issuer"}, names)
+
+	tables, err := repo.TableStats(ctx, storage.Pgx)
+	require.NoError(t, err)
+	stats := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		stats[table.Table] = true
+		assert.Positive(t, table.SizeBytes)
+	}
+	assert.True(t, stats["claims"])
+	assert.True(t, stats["links"])
+}
@@ -53,6 +53,99 @@ func TestGetSchema(t *testing.T) {
 	assert.InDelta(t, schema1.CreatedAt.UnixMilli(), schema2.CreatedAt.UnixMilli(), 10)
 }
 
+func TestSchemaDefaultProofTypes(t *testing.T) {
+	ctx := context.Background()
+	store := repositories.NewSchema(*storage)
+	did := core.DID{}
+	require.NoError(t, did.SetString("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ"))
+	url := fmt.Sprintf("https://an.url.org/%s.json", uuid.NewString())
+	i := &big.Int{}
+	i.SetInt64(rand.Int63())
+	newSchema := func(createdAt time.Time, proofTypes domain.ProofTypes) *domain.Schema {
+		s := &domain.Schema{
+			ID:                uuid.New(),
+			IssuerDID:         did,
+			URL:               url,
+			Type:              "schemaType",
+			Hash:              core.NewSchemaHashFromInt(i),
+			Attributes:        domain.SchemaAttrs{"field1"},
+			DefaultProofTypes: proofTypes,
+			CreatedAt:         createdAt,
+		}
+		require.NoError(t, store.Save(ctx, s))
+		return s
+	}
+
+	proofTypes, err := store.GetDefaultProofTypesByURL(ctx, did, url)
+	require.NoError(t, err)
+	assert.Nil(t, proofTypes)
+
+	older := newSchema(time.Now().Add(-time.Hour), domain.NewProofTypes(true, true))
+	newer := newSchema(time.Now(), nil)
+
+	proofTypes, err = store.GetDefaultProofTypesByURL(ctx, did, url)
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewProofTypes(true, true), proofTypes)
+
+	require.NoError(t, store.UpdateDefaultProofTypes(ctx, did, newer.ID, domain.NewProofTypes(false, true)))
+	proofTypes, err = store.GetDefaultProofTypesByURL(ctx, did, url)
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewProofTypes(false, true), proofTypes)
+
+	saved, err := store.GetByID(ctx, did, older.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewProofTypes(true, true), saved.DefaultProofTypes)
+
+	require.NoError(t, store.UpdateDefaultProofTypes(ctx, did, older.ID, nil))
+	saved, err = store.GetByID(ctx, did, older.ID)
+	require.NoError(t, err)
+	assert.Nil(t, saved.DefaultProofTypes)
+
+	assert.ErrorIs(t, store.UpdateDefaultProofTypes(ctx, did, uuid.New(), nil), repositories.ErrSchemaDoesNotExist)
+}
+
+func TestSchemaDefaultExpiration(t *testing.T) {
+	ctx := context.Background()
+	store := repositories.NewSchema(*storage)
+	did := core.DID{}
+	require.NoError(t, did.SetString("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ"))
+	url := fmt.Sprintf("https://an.url.org/%s.json", uuid.NewString())
+	i := &big.Int{}
+	i.SetInt64(rand.Int63())
+	oneYear, err := domain.ParseExpirationPolicy("P1Y")
+	require.NoError(t, err)
+	ninetyDays, err := domain.ParseExpirationPolicy("90d")
+	require.NoError(t, err)
+
+	s := &domain.Schema{
+		ID:                uuid.New(),
+		IssuerDID:         did,
+		URL:               url,
+		Type:              "schemaType",
+		Hash:              core.NewSchemaHashFromInt(i),
+		Attributes:        domain.SchemaAttrs{"field1"},
+		DefaultExpiration: oneYear,
+		CreatedAt:         time.Now(),
+	}
+	require.NoError(t, store.Save(ctx, s))
+
+	policy, err := store.GetDefaultExpirationByURL(ctx, did, url)
+	require.NoError(t, err)
+	assert.Equal(t, oneYear, policy)
+
+	require.NoError(t, store.UpdateDefaultExpiration(ctx, did, s.ID, ninetyDays))
+	saved, err := store.GetByID(ctx, did, s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ninetyDays, saved.DefaultExpiration)
+
+	require.NoError(t, store.UpdateDefaultExpiration(ctx, did, s.ID, nil))
+	policy, err = store.GetDefaultExpirationByURL(ctx, did, url)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	assert.ErrorIs(t, store.UpdateDefaultExpiration(ctx, did, uuid.New(), nil), repositories.ErrSchemaDoesNotExist)
+}
+
 func TestGetAllFullTextSearch(t *testing.T) {
 	rand.NewSource(time.Now().Unix())
 	ctx := context.Background()
@@ -231,3 +324,249 @@ func insertSchemaGetAllData(t *testing.T, ctx context.Context, did core.DID, sto
 		time.Sleep(2 * time.Millisecond)
 	}
 }
+
+func TestSchemaPostIssuanceHooks(t *testing.T) {
+	ctx := context.Background()
+	store := repositories.NewSchema(*storage)
+	did := core.DID{}
+	require.NoError(t, did.SetString("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ"))
+	url := fmt.Sprintf("https://an.url.org/%s.json", uuid.NewString())
+	i := &big.Int{}
+	i.SetInt64(rand.Int63())
+	hooks := domain.PostIssuanceHooks{
+		{Name: "crm", URL: "https://crm.example.com/credentials", Mode: domain.PostIssuanceHookSync},
+		{Name: "archive", URL: "https://archive.example.com/hook", Mode: domain.PostIssuanceHookQueued},
+	}
+
+	s := &domain.Schema{
+		ID:         uuid.New(),
+		IssuerDID:  did,
+		URL:        url,
+		Type:       "schemaType",
+		Hash:       core.NewSchemaHashFromInt(i),
+		Attributes: domain.SchemaAttrs{"field1"},
+		CreatedAt:  time.Now(),
+	}
+	require.NoError(t, store.Save(ctx, s))
+
+	found, err := store.GetPostIssuanceHooksByURL(ctx, did, url)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	require.NoError(t, store.UpdatePostIssuanceHooks(ctx, did, s.ID, hooks))
+	found, err = store.GetPostIssuanceHooksByURL(ctx, did, url)
+	require.NoError(t, err)
+	assert.Equal(t, hooks, found)
+
+	saved, err := store.GetByID(ctx, did, s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, hooks, saved.PostIssuanceHooks)
+
+	require.NoError(t, store.UpdatePostIssuanceHooks(ctx, did, s.ID, nil))
+	saved, err = store.GetByID(ctx, did, s.ID)
+	require.NoError(t, err)
+	assert.Nil(t, saved.PostIssuanceHooks)
+
+	assert.ErrorIs(t, store.UpdatePostIssuanceHooks(ctx, did, uuid.New(), nil), repositories.ErrSchemaDoesNotExist)
+}
+
+func TestSchemaUsage(t *testing.T) {
+	ctx := context.Background()
+	store := repositories.NewSchema(*storage)
+	usageRepo := repositories.NewSchemaUsage()
+	did := core.DID{}
+	require.NoError(t, did.SetString("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ"))
+	url := fmt.Sprintf("https://an.url.org/%s.json", uuid.NewString())
+	day1 := time.Date(2023, 4, 20, 10, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	require.NoError(t, usageRepo.AddIssued(ctx, storage.Pgx, did, url, false, day1))
+	require.NoError(t, usageRepo.AddIssued(ctx, storage.Pgx, did, url, true, day1.Add(time.Hour)))
+	require.NoError(t, usageRepo.AddIssued(ctx, storage.Pgx, did, url, true, day2))
+	require.NoError(t, usageRepo.AddRevoked(ctx, storage.Pgx, did, url, day2))
+
+	usage, err := store.GetUsageByURL(ctx, did, url, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), usage.Issued)
+	assert.Equal(t, int64(2), usage.IssuedByLink)
+	assert.Equal(t, int64(1), usage.Revoked)
+	assert.InDelta(t, 1.0/3, usage.RevocationRate(), 0.001)
+	require.Len(t, usage.Days, 2)
+	assert.Equal(t, "2023-04-20", usage.Days[0].Day.Format("2006-01-02"))
+	assert.Equal(t, int64(2), usage.Days[0].Issued)
+	assert.Equal(t, int64(1), usage.Days[0].IssuedByLink)
+	assert.Equal(t, int64(0), usage.Days[0].Revoked)
+	assert.Equal(t, int64(1), usage.Days[1].Revoked)
+
+	usage, err = store.GetUsageByURL(ctx, did, url, &day2, &day2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), usage.Issued)
+	assert.Equal(t, int64(1), usage.Revoked)
+	require.Len(t, usage.Days, 1)
+
+	usage, err = store.GetUsageByURL(ctx, did, "https://an.url.org/other.json", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), usage.Issued)
+	assert.Empty(t, usage.Days)
+	assert.Zero(t, usage.RevocationRate())
+}
+
+func TestSchemaDisplayStrings(t *testing.T) {
+	ctx := context.Background()
+	store := repositories.NewSchema(*storage)
+	did := core.DID{}
+	require.NoError(t, did.SetString("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ"))
+	url := fmt.Sprintf("https://an.url.org/%s.json", uuid.NewString())
+	i := &big.Int{}
+	i.SetInt64(rand.Int63())
+	display := domain.DisplayStrings{
+		"en":    {Name: "Proof of age"},
+		"pt-BR": {Name: "Prova de idade", Description: "Prova de que o titular é maior de idade"},
+	}
+
+	s := &domain.Schema{
+		ID:         uuid.New(),
+		IssuerDID:  did,
+		URL:        url,
+		Type:       "schemaType",
+		Hash:       core.NewSchemaHashFromInt(i),
+		Attributes: domain.SchemaAttrs{"field1"},
+		CreatedAt:  time.Now(),
+	}
+	require.NoError(t, store.Save(ctx, s))
+
+	found, err := store.GetDisplayStringsByURL(ctx, did, url)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	require.NoError(t, store.UpdateDisplayStrings(ctx, did, s.ID, display))
+	found, err = store.GetDisplayStringsByURL(ctx, did, url)
+	require.NoError(t, err)
+	assert.Equal(t, display, found)
+
+	saved, err := store.GetByID(ctx, did, s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, display, saved.DisplayStrings)
+
+	require.NoError(t, store.UpdateDisplayStrings(ctx, did, s.ID, nil))
+	saved, err = store.GetByID(ctx, did, s.ID)
+	require.NoError(t, err)
+	assert.Nil(t, saved.DisplayStrings)
+
+	assert.ErrorIs(t, store.UpdateDisplayStrings(ctx, did, uuid.New(), nil), repositories.ErrSchemaDoesNotExist)
+}
+
+func TestSchemaPrerequisites(t *testing.T) {
+	ctx := context.Background()
+	store := repositories.NewSchema(*storage)
+	did := core.DID{}
+	require.NoError(t, did.SetString("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ"))
+	i := &big.Int{}
+	i.SetInt64(rand.Int63())
+	prerequisites := domain.Prerequisites{
+		{
+			Context:           "https://example.com/kyc.json-ld",
+			Type:              "KYCCountryOfResidenceCredential",
+			AllowedIssuers:    []string{domain.AnyIssuer},
+			CredentialSubject: map[string]any{"countryCode": map[string]any{"$eq": "ES"}},
+			ProofType:         domain.PrerequisiteSig,
+		},
+		{Context: "https://example.com/member.json-ld", Type: "Membership", AllowedIssuers: []string{did.String()}, ProofType: domain.PrerequisiteMTP},
+	}
+
+	s := &domain.Schema{
+		ID:         uuid.New(),
+		IssuerDID:  did,
+		URL:        fmt.Sprintf("https://an.url.org/%s.json", uuid.NewString()),
+		Type:       "schemaType",
+		Hash:       core.NewSchemaHashFromInt(i),
+		Attributes: domain.SchemaAttrs{"field1"},
+		CreatedAt:  time.Now(),
+	}
+	require.NoError(t, store.Save(ctx, s))
+
+	saved, err := store.GetByID(ctx, did, s.ID)
+	require.NoError(t, err)
+	assert.Nil(t, saved.Prerequisites)
+
+	require.NoError(t, store.UpdatePrerequisites(ctx, did, s.ID, prerequisites))
+	saved, err = store.GetByID(ctx, did, s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, prerequisites, saved.Prerequisites)
+
+	require.NoError(t, store.UpdatePrerequisites(ctx, did, s.ID, nil))
+	saved, err = store.GetByID(ctx, did, s.ID)
+	require.NoError(t, err)
+	assert.Nil(t, saved.Prerequisites)
+
+	assert.ErrorIs(t, store.UpdatePrerequisites(ctx, did, uuid.New(), nil), repositories.ErrSchemaDoesNotExist)
+}
+
+func TestSchemaRegistry(t *testing.T) {
+	ctx := context.Background()
+	store := repositories.NewSchema(*storage)
+	did := core.DID{}
+	require.NoError(t, did.SetString("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ"))
+	sType := fmt.Sprintf("RegistryCredential%d", rand.Int63())
+
+	var saved []*domain.Schema
+	for _, version := range []string{"1.0.0", "1.10.0", "1.2.0"} {
+		i := &big.Int{}
+		i.SetInt64(rand.Int63())
+		id := uuid.New()
+		s := &domain.Schema{
+			ID:              id,
+			IssuerDID:       did,
+			URL:             fmt.Sprintf("https://issuer.example.com/v1/schemas/%s/jsonschema", id),
+			Type:            sType,
+			Hash:            core.NewSchemaHashFromInt(i),
+			Attributes:      domain.SchemaAttrs{"field1"},
+			Version:         version,
+			Title:           "Registry credential",
+			Document:        []byte(`{"type": "object"}`),
+			ContextDocument: []byte(`{"@context": []}`),
+			ContextURL:      fmt.Sprintf("https://issuer.example.com/v1/schemas/%s/context", id),
+			CreatedAt:       time.Now(),
+		}
+		require.NoError(t, store.Save(ctx, s))
+		saved = append(saved, s)
+	}
+
+	versions, err := store.GetVersions(ctx, did, sType)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, []string{"1.10.0", "1.2.0", "1.0.0"}, []string{versions[0].Version, versions[1].Version, versions[2].Version})
+
+	s, err := store.GetByID(ctx, did, saved[0].ID)
+	require.NoError(t, err)
+	assert.True(t, s.InRegistry())
+	assert.Equal(t, "Registry credential", s.Title)
+	assert.Equal(t, saved[0].ContextURL, s.ContextURL)
+
+	doc, err := store.GetDocumentByURL(ctx, saved[0].URL)
+	require.NoError(t, err)
+	assert.Equal(t, saved[0].Document, doc)
+	doc, err = store.GetDocumentByURL(ctx, saved[0].ContextURL)
+	require.NoError(t, err)
+	assert.Equal(t, saved[0].ContextDocument, doc)
+	doc, err = store.GetDocumentByURL(ctx, "https://example.com/unknown.json")
+	require.NoError(t, err)
+	assert.Nil(t, doc)
+
+	require.NoError(t, store.UpdateMetadata(ctx, did, saved[0].ID, "", "Proof of registration"))
+	s, err = store.GetByID(ctx, did, saved[0].ID)
+	require.NoError(t, err)
+	assert.Empty(t, s.Title)
+	assert.Equal(t, "Proof of registration", s.Description)
+
+	deprecatedAt, err := store.GetDeprecatedAtByURL(ctx, did, saved[0].URL)
+	require.NoError(t, err)
+	assert.Nil(t, deprecatedAt)
+	require.NoError(t, store.UpdateDeprecatedAt(ctx, did, saved[0].ID, common.ToPointer(time.Now())))
+	deprecatedAt, err = store.GetDeprecatedAtByURL(ctx, did, saved[0].URL)
+	require.NoError(t, err)
+	assert.NotNil(t, deprecatedAt)
+
+	assert.ErrorIs(t, store.UpdateDeprecatedAt(ctx, did, uuid.New(), nil), repositories.ErrSchemaDoesNotExist)
+	assert.ErrorIs(t, store.UpdateMetadata(ctx, did, uuid.New(), "", ""), repositories.ErrSchemaDoesNotExist)
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"context"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/iden3comm/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/cache"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+	link_state "github.com/polygonid/sh-id-platform/pkg/link"
+)
+
+func TestSessionRepositories(t *testing.T) {
+	ctx := context.Background()
+	now := clock.NewFake(time.Now())
+	ttls := repositories.SessionTTLs{AuthRequest: time.Hour, LinkState: time.Minute, Clock: now}
+
+	for name, sessions := range map[string]ports.SessionRepository{
+		"cached":          repositories.NewSessionCachedWithTTLs(cache.NewMemoryCache(), ttls),
+		"postgres":        repositories.NewSessionPostgres(*storage, ttls),
+		"postgres cached": repositories.NewSessionCachedWithTTLs(cache.NewPostgresCache(storage.Pgx), ttls),
+	} {
+		t.Run(name, func(t *testing.T) {
+			sessionID := uuid.New().String()
+			authRequest := protocol.AuthorizationRequestMessage{ID: sessionID, ThreadID: sessionID, From: "did:iden3:issuer"}
+			require.NoError(t, sessions.Set(ctx, sessionID, authRequest))
+
+			got, err := sessions.Get(ctx, sessionID)
+			require.NoError(t, err)
+			assert.Equal(t, authRequest.ID, got.ID)
+			assert.Equal(t, authRequest.From, got.From)
+
+			_, err = sessions.Get(ctx, uuid.New().String())
+			assert.Error(t, err)
+
+			linkKey := link_state.CredentialStateCacheKey(uuid.New().String(), sessionID)
+			_, err = sessions.GetLink(ctx, linkKey)
+			assert.Error(t, err, "a link state is not returned as an authorization request or the other way around")
+
+			require.NoError(t, sessions.SetLink(ctx, linkKey, *link_state.NewStatePending()))
+			state, err := sessions.GetLink(ctx, linkKey)
+			require.NoError(t, err)
+			assert.Equal(t, link_state.StatusPending, state.Status)
+
+			require.NoError(t, sessions.SetLink(ctx, linkKey, *link_state.NewStatePendingPublish()))
+			state, err = sessions.GetLink(ctx, linkKey)
+			require.NoError(t, err)
+			assert.Equal(t, link_state.StatusPendingPublish, state.Status)
+
+			expired := sessionLookups("link_state.expired")
+			now.Advance(2 * time.Minute)
+			_, err = sessions.GetLink(ctx, linkKey)
+			assert.Error(t, err)
+			assert.Equal(t, expired+1, sessionLookups("link_state.expired"))
+
+			_, err = sessions.Get(ctx, sessionID)
+			assert.NoError(t, err, "the authorization request has its own ttl")
+		})
+	}
+}
+
+func sessionLookups(key string) int64 {
+	metrics, ok := expvar.Get("session_store").(*expvar.Map)
+	if !ok {
+		return 0
+	}
+	counter, ok := metrics.Get(key).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+	return counter.Value()
+}
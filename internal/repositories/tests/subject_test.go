@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/claimarchive"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/db/tests"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestSubjectEraseArchivedClaims(t *testing.T) {
+	ctx := context.Background()
+	subjectRepo := repositories.NewSubject()
+
+	idStr := "did:polygonid:polygon:mumbai:2qGjTUuxZKqKS4Q8UmxHUPw55g15QgEVGnj6Wkq8Vk"
+	fixture := tests.NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	issuerDID, err := core.ParseDID(idStr)
+	require.NoError(t, err)
+
+	expired := func(nonce uint64) *domain.Claim {
+		claim := fixture.NewClaim(t, idStr)
+		claim.SchemaType = "KYCAgeCredential"
+		claim.Expiration = 1
+		claim.RevNonce = domain.RevNonceUint64(nonce)
+		return claim
+	}
+	archivedID := fixture.CreateClaim(t, expired(1001))
+	legacyID := fixture.CreateClaim(t, expired(1002))
+	subject, err := core.ParseDID(fixture.NewClaim(t, idStr).OtherIdentifier)
+	require.NoError(t, err)
+
+	_, err = claimarchive.Archive(ctx, storage, claimarchive.Options{Identifier: idStr})
+	require.NoError(t, err)
+	// the claims archived before the subjects were kept uncompressed
+	fixture.ExecQuery(t, tests.ExecQueryParams{
+		Query:     `UPDATE archived_claims SET subject = NULL, schema_hash = NULL, index_hash = NULL WHERE id = $1`,
+		Arguments: []interface{}{legacyID},
+	})
+
+	t.Run("should export the archived claims of the subject", func(t *testing.T) {
+		claims, err := subjectRepo.GetArchivedClaims(ctx, storage.Pgx, *issuerDID, *subject)
+		require.NoError(t, err)
+		require.Len(t, claims, 2)
+		assert.ElementsMatch(t, []uuid.UUID{archivedID, legacyID}, []uuid.UUID{claims[0].ID, claims[1].ID})
+		assert.Equal(t, subject.String(), claims[0].OtherIdentifier)
+	})
+
+	t.Run("should erase the archived claims of the subject", func(t *testing.T) {
+		erasure := domain.NewSubjectErasure(*issuerDID, *subject)
+		require.NoError(t, storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+			return subjectRepo.Erase(ctx, tx, erasure, *subject)
+		}))
+		assert.Equal(t, int64(2), erasure.Credentials)
+
+		var archived, erased int
+		require.NoError(t, storage.Pgx.QueryRow(ctx, `SELECT count(*) FROM archived_claims WHERE identifier = $1`, idStr).Scan(&archived))
+		assert.Zero(t, archived)
+		require.NoError(t, storage.Pgx.QueryRow(ctx, `SELECT count(*) FROM erased_claims WHERE erasure_id = $1 AND schema_hash <> ''`, erasure.ID).Scan(&erased))
+		assert.Equal(t, 2, erased, "the erased claims can still be revoked")
+
+		claims, err := subjectRepo.GetArchivedClaims(ctx, storage.Pgx, *issuerDID, *subject)
+		require.NoError(t, err)
+		assert.Empty(t, claims)
+	})
+}
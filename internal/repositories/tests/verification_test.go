@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestVerification_GetAll(t *testing.T) {
+	ctx := context.Background()
+	did, err := core.ParseDID("did:polygonid:polygon:mumbai:2qKZg1vCMwJeMzvVayn9ebUHpnD6QCxTgk6T28THxy")
+	require.NoError(t, err)
+	userDID := "did:polygonid:polygon:mumbai:" + uuid.NewString()
+	verificationRepo := repositories.NewVerification()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	verified := &domain.Verification{
+		ID:          uuid.New(),
+		IssuerDID:   *did,
+		SessionID:   uuid.New(),
+		UserDID:     &userDID,
+		Queries:     json.RawMessage(`[{"id": 1, "circuitId": "credentialAtomicQuerySigV2"}]`),
+		ProofHashes: []string{"015abd7f5cc57a2dd94b7590f04ad8084273905ee33ec5cebeae62276a97f862"},
+		Verified:    true,
+		CreatedAt:   now.Add(-time.Hour),
+	}
+	failed := &domain.Verification{
+		ID:        uuid.New(),
+		IssuerDID: *did,
+		SessionID: uuid.New(),
+		UserDID:   &userDID,
+		Error:     common.ToPointer("proof is not valid"),
+		CreatedAt: now,
+	}
+	require.NoError(t, verificationRepo.Save(ctx, storage.Pgx, verified))
+	require.NoError(t, verificationRepo.Save(ctx, storage.Pgx, failed))
+
+	got, err := verificationRepo.GetByID(ctx, storage.Pgx, *did, verified.ID)
+	require.NoError(t, err)
+	assert.Equal(t, verified.ProofHashes, got.ProofHashes)
+	assert.JSONEq(t, string(verified.Queries), string(got.Queries))
+	assert.True(t, got.Verified)
+
+	_, err = verificationRepo.GetByID(ctx, storage.Pgx, *did, uuid.New())
+	assert.ErrorIs(t, err, repositories.ErrVerificationDoesNotExist)
+
+	all, err := verificationRepo.GetAll(ctx, storage.Pgx, *did, domain.VerificationFilter{UserDID: &userDID})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, failed.ID, all[0].ID, "the newest first")
+	assert.Equal(t, "[]", string(all[0].Queries))
+	assert.Empty(t, all[0].ProofHashes)
+
+	all, err = verificationRepo.GetAll(ctx, storage.Pgx, *did, domain.VerificationFilter{UserDID: &userDID, Verified: common.ToPointer(false)})
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, failed.ID, all[0].ID)
+
+	to := now.Add(-time.Minute)
+	all, err = verificationRepo.GetAll(ctx, storage.Pgx, *did, domain.VerificationFilter{UserDID: &userDID, To: &to})
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, verified.ID, all[0].ID)
+
+	all, err = verificationRepo.GetAll(ctx, storage.Pgx, *did, domain.VerificationFilter{UserDID: &userDID, Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
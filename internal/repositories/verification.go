@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+)
+
+// ErrVerificationDoesNotExist verification does not exist
+var ErrVerificationDoesNotExist = errors.New("verification does not exist")
+
+type verification struct{}
+
+// NewVerification returns a new verification repository
+func NewVerification() ports.VerificationRepository {
+	return &verification{}
+}
+
+// Save stores the result of a verification
+func (r *verification) Save(ctx context.Context, conn db.Querier, v *domain.Verification) error {
+	queries := v.Queries
+	if len(queries) == 0 {
+		queries = json.RawMessage("[]")
+	}
+	proofHashes := v.ProofHashes
+	if proofHashes == nil {
+		proofHashes = []string{}
+	}
+	_, err := conn.Exec(ctx,
+		`INSERT INTO verifications (id, issuer_id, session_id, user_id, queries, proof_hashes, verified, error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		v.ID, v.IssuerDID.String(), v.SessionID, v.UserDID, queries, proofHashes, v.Verified, v.Error, v.CreatedAt)
+	return err
+}
+
+// GetByID returns a verification of the issuer
+func (r *verification) GetByID(ctx context.Context, conn db.Querier, issuerDID core.DID, id uuid.UUID) (*domain.Verification, error) {
+	row := conn.QueryRow(ctx,
+		`SELECT id, session_id, user_id, queries, proof_hashes, verified, error, created_at
+		FROM verifications
+		WHERE issuer_id = $1 AND id = $2`, issuerDID.String(), id)
+	v, err := scanVerification(row, issuerDID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrVerificationDoesNotExist
+	}
+	return v, err
+}
+
+// GetAll returns the verifications of the issuer that match the filter, the newest first
+func (r *verification) GetAll(ctx context.Context, conn db.Querier, issuerDID core.DID, filter domain.VerificationFilter) ([]domain.Verification, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = domain.DefaultVerificationsLimit
+	}
+	rows, err := conn.Query(ctx,
+		`SELECT id, session_id, user_id, queries, proof_hashes, verified, error, created_at
+		FROM verifications
+		WHERE issuer_id = $1
+		  AND ($2::timestamptz IS NULL OR created_at >= $2)
+		  AND ($3::timestamptz IS NULL OR created_at < $3)
+		  AND ($4::text IS NULL OR user_id = $4)
+		  AND ($5::bool IS NULL OR verified = $5)
+		ORDER BY created_at DESC
+		LIMIT $6`, issuerDID.String(), filter.From, filter.To, filter.UserDID, filter.Verified, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	verifications := make([]domain.Verification, 0)
+	for rows.Next() {
+		v, err := scanVerification(rows, issuerDID)
+		if err != nil {
+			return nil, err
+		}
+		verifications = append(verifications, *v)
+	}
+	return verifications, rows.Err()
+}
+
+func scanVerification(row pgx.Row, issuerDID core.DID) (*domain.Verification, error) {
+	v := domain.Verification{IssuerDID: issuerDID}
+	if err := row.Scan(&v.ID, &v.SessionID, &v.UserDID, &v.Queries, &v.ProofHashes, &v.Verified, &v.Error, &v.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
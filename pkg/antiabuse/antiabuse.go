@@ -0,0 +1,80 @@
+// Package antiabuse protects the public endpoints of open issuance campaigns with challenges that a client must
+// solve, like a CAPTCHA or a proof of work, and with a throttle of the requests of each IP.
+package antiabuse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// ChallengeCaptcha asks the client to solve a CAPTCHA of a siteverify compatible provider, like hCaptcha,
+	// reCAPTCHA or Turnstile
+	ChallengeCaptcha = "captcha"
+	// ChallengePoW asks the client to solve a proof of work
+	ChallengePoW = "pow"
+)
+
+var (
+	// ErrChallengeFailed is returned when the solution of a challenge is not valid
+	ErrChallengeFailed = errors.New("challenge failed")
+	// ErrThrottled is returned when an IP made too many requests
+	ErrThrottled = errors.New("too many requests")
+)
+
+// Challenge is what the client needs to solve a challenge
+type Challenge struct {
+	Type string
+	// Value is the site key of the CAPTCHA or the challenge of the proof of work
+	Value string
+	// Difficulty is the number of leading zero bits of the proof of work hash
+	Difficulty int
+}
+
+// Challenger issues challenges and verifies their solutions
+type Challenger interface {
+	// New returns the challenge for a client
+	New(ctx context.Context) (*Challenge, error)
+	// Verify returns ErrChallengeFailed if solution doesn't solve a challenge issued to the client
+	Verify(ctx context.Context, solution string, remoteIP string) error
+}
+
+// Throttle limits the requests of each IP
+type Throttle interface {
+	// Allow returns ErrThrottled and how long the IP has to wait when it can't make another request
+	Allow(ctx context.Context, ip string) (time.Duration, error)
+	// Report tells whether the IP solved or failed a challenge, to change its reputation
+	Report(ctx context.Context, ip string, solved bool)
+}
+
+// ChallengeConfig configures the challenger of NewChallenger
+type ChallengeConfig struct {
+	Type string
+	// CaptchaVerifyURL is the siteverify endpoint of the CAPTCHA provider
+	CaptchaVerifyURL string
+	CaptchaSecret    string
+	CaptchaSiteKey   string
+	// CaptchaTransport sends the siteverify requests. Nil sends them with http.DefaultTransport.
+	CaptchaTransport http.RoundTripper
+	// PoWDifficulty is the number of leading zero bits of the proof of work hash
+	PoWDifficulty int
+	// PoWSecret signs the proof of work challenges. Every server of the campaign must use the same one.
+	PoWSecret string
+}
+
+// NewChallenger returns the challenger of the configured type. An empty type means there are no challenges and
+// returns nil.
+func NewChallenger(cfg ChallengeConfig) (Challenger, error) {
+	switch cfg.Type {
+	case "":
+		return nil, nil
+	case ChallengeCaptcha:
+		return NewCaptcha(cfg.CaptchaVerifyURL, cfg.CaptchaSecret, cfg.CaptchaSiteKey, cfg.CaptchaTransport)
+	case ChallengePoW:
+		return NewProofOfWork(cfg.PoWDifficulty, []byte(cfg.PoWSecret))
+	}
+	return nil, fmt.Errorf("unknown challenge type <%s>, it must be %s or %s", cfg.Type, ChallengeCaptcha, ChallengePoW)
+}
@@ -0,0 +1,144 @@
+package antiabuse
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofOfWork(t *testing.T) {
+	ctx := context.Background()
+	challenger, err := NewProofOfWork(8, []byte("secret"))
+	require.NoError(t, err)
+
+	challenge, err := challenger.New(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ChallengePoW, challenge.Type)
+	assert.Equal(t, 8, challenge.Difficulty)
+
+	solution := solve(challenge)
+	assert.ErrorIs(t, challenger.Verify(ctx, challenge.Value+":wrong", ""), ErrChallengeFailed)
+	assert.NoError(t, challenger.Verify(ctx, solution, ""))
+	assert.ErrorIs(t, challenger.Verify(ctx, solution, ""), ErrChallengeFailed, "a challenge can be solved once")
+
+	t.Run("should reject the challenges of another secret", func(t *testing.T) {
+		other, err := NewProofOfWork(8, []byte("other secret"))
+		require.NoError(t, err)
+		challenge, err := other.New(ctx)
+		require.NoError(t, err)
+		assert.ErrorIs(t, challenger.Verify(ctx, solve(challenge), ""), ErrChallengeFailed)
+	})
+
+	t.Run("should reject the expired challenges", func(t *testing.T) {
+		challenge, err := challenger.New(ctx)
+		require.NoError(t, err)
+		challenger.(*proofOfWork).now = func() time.Time { return time.Now().Add(powTTL + time.Minute) }
+		defer func() { challenger.(*proofOfWork).now = time.Now }()
+		assert.ErrorIs(t, challenger.Verify(ctx, solve(challenge), ""), ErrChallengeFailed)
+	})
+
+	_, err = NewProofOfWork(64, nil)
+	assert.Error(t, err)
+}
+
+func TestCaptcha(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "secret", r.Form.Get("secret"))
+		assert.Equal(t, "203.0.113.7", r.Form.Get("remoteip"))
+		_, _ = w.Write([]byte(`{"success":` + strconv.FormatBool(r.Form.Get("response") == "valid-token") + `}`))
+	}))
+	defer server.Close()
+
+	challenger, err := NewCaptcha(server.URL, "secret", "site-key", nil)
+	require.NoError(t, err)
+	challenge, err := challenger.New(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &Challenge{Type: ChallengeCaptcha, Value: "site-key"}, challenge)
+
+	assert.NoError(t, challenger.Verify(ctx, "valid-token", "203.0.113.7"))
+	assert.ErrorIs(t, challenger.Verify(ctx, "invalid-token", "203.0.113.7"), ErrChallengeFailed)
+	assert.ErrorIs(t, challenger.Verify(ctx, "", "203.0.113.7"), ErrChallengeFailed)
+}
+
+func TestIPThrottle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	throttle := NewIPThrottle(6).(*ipThrottle)
+	throttle.now = func() time.Time { return now }
+
+	for i := 0; i < 6; i++ {
+		_, err := throttle.Allow(ctx, "203.0.113.7")
+		require.NoError(t, err)
+	}
+	wait, err := throttle.Allow(ctx, "203.0.113.7")
+	assert.ErrorIs(t, err, ErrThrottled)
+	assert.Equal(t, 10*time.Second, wait)
+	_, err = throttle.Allow(ctx, "198.51.100.1")
+	assert.NoError(t, err, "the other IPs are not throttled")
+
+	now = now.Add(10 * time.Second)
+	_, err = throttle.Allow(ctx, "203.0.113.7")
+	assert.NoError(t, err)
+
+	t.Run("should lower the limit of the IPs that fail the challenges", func(t *testing.T) {
+		throttle.Report(ctx, "198.51.100.1", false)
+		throttle.Report(ctx, "198.51.100.1", false)
+		for i := 0; i < 2; i++ {
+			_, err := throttle.Allow(ctx, "198.51.100.1")
+			require.NoError(t, err)
+		}
+		wait, err := throttle.Allow(ctx, "198.51.100.1")
+		assert.ErrorIs(t, err, ErrThrottled)
+		assert.Equal(t, 30*time.Second, wait)
+
+		now = now.Add(2 * strikeDecay)
+		for i := 0; i < 6; i++ {
+			_, err := throttle.Allow(ctx, "198.51.100.1")
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:4000"
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	assert.Equal(t, "10.0.0.1", ClientIP(r, false))
+	assert.Equal(t, "203.0.113.7", ClientIP(r, true))
+
+	r.Header.Del("X-Forwarded-For")
+	assert.Equal(t, "10.0.0.1", ClientIP(r, true), "without the header")
+	r.RemoteAddr = "10.0.0.1"
+	assert.Equal(t, "10.0.0.1", ClientIP(r, false), "without a port")
+}
+
+func TestNewChallenger(t *testing.T) {
+	challenger, err := NewChallenger(ChallengeConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, challenger)
+
+	_, err = NewChallenger(ChallengeConfig{Type: ChallengeCaptcha, CaptchaVerifyURL: "https://hcaptcha.com/siteverify"})
+	assert.Error(t, err)
+
+	_, err = NewChallenger(ChallengeConfig{Type: "sms"})
+	assert.Error(t, err)
+}
+
+func solve(challenge *Challenge) string {
+	for nonce := 0; ; nonce++ {
+		solution := challenge.Value + ":" + strconv.Itoa(nonce)
+		hash := sha256.Sum256([]byte(solution))
+		if leadingZeroBits(hash[:]) >= challenge.Difficulty {
+			return solution
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package antiabuse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const captchaTimeout = 10 * time.Second
+
+type captcha struct {
+	verifyURL string
+	secret    string
+	siteKey   string
+	client    *http.Client
+}
+
+// NewCaptcha returns a challenger that verifies the CAPTCHA tokens with the siteverify endpoint of the provider.
+// The challenges carry the site key the client uses to render the CAPTCHA. The requests are sent with transport, nil
+// sends them with http.DefaultTransport.
+func NewCaptcha(verifyURL, secret, siteKey string, transport http.RoundTripper) (Challenger, error) {
+	u, err := url.ParseRequestURI(verifyURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid captcha verify url <%s>", verifyURL)
+	}
+	if secret == "" || siteKey == "" {
+		return nil, errors.New("the captcha secret and site key are required")
+	}
+	return &captcha{verifyURL: verifyURL, secret: secret, siteKey: siteKey, client: &http.Client{Timeout: captchaTimeout, Transport: transport}}, nil
+}
+
+// New returns the captcha challenge
+func (c *captcha) New(_ context.Context) (*Challenge, error) {
+	return &Challenge{Type: ChallengeCaptcha, Value: c.siteKey}, nil
+}
+
+// Verify asks the provider whether the token is valid
+func (c *captcha) Verify(ctx context.Context, solution string, remoteIP string) error {
+	if solution == "" {
+		return ErrChallengeFailed
+	}
+	form := url.Values{"secret": {c.secret}, "response": {solution}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("verifying the captcha: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("verifying the captcha: unexpected status %d", resp.StatusCode)
+	}
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("verifying the captcha: %w", err)
+	}
+	if !result.Success {
+		return ErrChallengeFailed
+	}
+	return nil
+}
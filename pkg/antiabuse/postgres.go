@@ -0,0 +1,99 @@
+package antiabuse
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// PostgresThrottle is the throttle of NewIPThrottle kept in the throttles table, so every server of the node shares
+// the requests and the strikes of each IP. The throttles of different endpoints are told apart by their scope.
+type PostgresThrottle struct {
+	conn      *pgxpool.Pool
+	scope     string
+	perMinute int
+	now       func() time.Time
+}
+
+// NewPostgresIPThrottle returns a throttle that allows perMinute requests per minute to each IP, like NewIPThrottle,
+// with the state in the database. Run removes the IPs that have been idle for a while.
+func NewPostgresIPThrottle(conn *pgxpool.Pool, scope string, perMinute int) *PostgresThrottle {
+	return &PostgresThrottle{conn: conn, scope: scope, perMinute: perMinute, now: time.Now}
+}
+
+// Allow takes a request from the IP bucket. The request is allowed when the database fails, so the throttle doesn't
+// take the endpoints down with it.
+func (t *PostgresThrottle) Allow(ctx context.Context, ip string) (time.Duration, error) {
+	var wait time.Duration
+	var throttled error
+	if err := t.update(ctx, ip, func(state *ipState) { wait, throttled = state.take(t.perMinute) }); err != nil {
+		log.Error(ctx, "reading the rate limit of the ip", "err", err, "ip", ip, "scope", t.scope)
+		return 0, nil
+	}
+	return wait, throttled
+}
+
+// Report adds a strike to the IP when it failed a challenge and removes one when it solved it
+func (t *PostgresThrottle) Report(ctx context.Context, ip string, solved bool) {
+	if err := t.update(ctx, ip, func(state *ipState) { state.report(t.perMinute, solved) }); err != nil {
+		log.Error(ctx, "reporting the challenge of the ip", "err", err, "ip", ip, "scope", t.scope)
+	}
+}
+
+// Cleanup removes the IPs without strikes that have been idle for a while, and returns how many were removed
+func (t *PostgresThrottle) Cleanup(ctx context.Context) (int64, error) {
+	now := t.now()
+	tag, err := t.conn.Exec(ctx,
+		`DELETE FROM throttles WHERE scope = $1 AND last_seen < $2 AND last_seen < $3 - make_interval(secs => strikes * $4)`,
+		t.scope, now.Add(-idleTTL), now, strikeDecay.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Run removes the idle IPs every period until the context is done
+func (t *PostgresThrottle) Run(ctx context.Context, period time.Duration) {
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			if _, err := t.Cleanup(ctx); err != nil {
+				log.Error(ctx, "removing the idle rate limits", "err", err, "scope", t.scope)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// update changes the refilled state of the IP, with its row locked until it is saved
+func (t *PostgresThrottle) update(ctx context.Context, ip string, change func(state *ipState)) error {
+	return t.conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		now := t.now()
+		state := newIPState(t.perMinute, now)
+		err := tx.QueryRow(ctx, `SELECT tokens, strikes, last_seen FROM throttles WHERE scope = $1 AND ip = $2 FOR UPDATE`, t.scope, ip).
+			Scan(&state.tokens, &state.strikes, &state.lastSeen)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+		case err != nil:
+			return err
+		default:
+			state.refill(t.perMinute, now)
+		}
+		change(state)
+		_, err = tx.Exec(ctx,
+			`INSERT INTO throttles (scope, ip, tokens, strikes, last_seen) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (scope, ip) DO UPDATE SET tokens = $3, strikes = $4, last_seen = $5`,
+			t.scope, ip, state.tokens, state.strikes, state.lastSeen)
+		return err
+	})
+}
@@ -0,0 +1,114 @@
+package antiabuse
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPoWDifficulty takes around a second in a browser
+	DefaultPoWDifficulty = 20
+	maxPoWDifficulty     = 32
+	// powTTL is how long a proof of work challenge can be solved
+	powTTL = 5 * time.Minute
+)
+
+type proofOfWork struct {
+	difficulty int
+	secret     []byte
+	now        func() time.Time
+
+	mu   sync.Mutex
+	used map[string]time.Time // solved challenges by expiration, to reject their reuse
+}
+
+// NewProofOfWork returns a challenger that asks for a nonce such that the sha256 of "<challenge>:<nonce>" starts with
+// difficulty zero bits. The solution is sent as "<challenge>:<nonce>".
+// The challenges are signed with secret, so they don't need to be stored. Without secret a random one is used, and
+// the challenges can only be solved in the same server.
+func NewProofOfWork(difficulty int, secret []byte) (Challenger, error) {
+	if difficulty <= 0 {
+		difficulty = DefaultPoWDifficulty
+	}
+	if difficulty > maxPoWDifficulty {
+		return nil, fmt.Errorf("the proof of work difficulty can't be greater than %d", maxPoWDifficulty)
+	}
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+	}
+	return &proofOfWork{difficulty: difficulty, secret: secret, now: time.Now, used: make(map[string]time.Time)}, nil
+}
+
+// New returns a signed challenge "<expiration>.<random>.<signature>"
+func (p *proofOfWork) New(_ context.Context) (*Challenge, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	unsigned := strconv.FormatInt(p.now().Add(powTTL).Unix(), 10) + "." + hex.EncodeToString(random)
+	return &Challenge{Type: ChallengePoW, Value: unsigned + "." + p.sign(unsigned), Difficulty: p.difficulty}, nil
+}
+
+// Verify checks the signature and expiration of the challenge and the hash of the solution. Each challenge can be
+// used once.
+func (p *proofOfWork) Verify(_ context.Context, solution string, _ string) error {
+	challenge, _, found := strings.Cut(solution, ":")
+	if !found {
+		return ErrChallengeFailed
+	}
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 3 || !hmac.Equal([]byte(p.sign(parts[0]+"."+parts[1])), []byte(parts[2])) {
+		return ErrChallengeFailed
+	}
+	expiration, err := strconv.ParseInt(parts[0], 10, 64)
+	now := p.now()
+	if err != nil || now.Unix() > expiration {
+		return ErrChallengeFailed
+	}
+	hash := sha256.Sum256([]byte(solution))
+	if leadingZeroBits(hash[:]) < p.difficulty {
+		return ErrChallengeFailed
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for used, expiresAt := range p.used {
+		if now.After(expiresAt) {
+			delete(p.used, used)
+		}
+	}
+	if _, ok := p.used[challenge]; ok {
+		return ErrChallengeFailed
+	}
+	p.used[challenge] = time.Unix(expiration, 0)
+	return nil
+}
+
+func (p *proofOfWork) sign(value string) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func leadingZeroBits(hash []byte) int {
+	zeros := 0
+	for _, b := range hash {
+		if b != 0 {
+			return zeros + bits.LeadingZeros8(b)
+		}
+		zeros += 8
+	}
+	return zeros
+}
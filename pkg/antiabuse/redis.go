@@ -0,0 +1,104 @@
+package antiabuse
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// redisAttempts is how many times an update is retried when another server changed the IP concurrently
+const redisAttempts = 5
+
+// RedisThrottle is the throttle of NewIPThrottle kept in redis, so every server of the node shares the requests and
+// the strikes of each IP. The throttles of different endpoints are told apart by their scope.
+type RedisThrottle struct {
+	client    *redis.Client
+	scope     string
+	perMinute int
+	now       func() time.Time
+}
+
+// NewRedisIPThrottle returns a throttle that allows perMinute requests per minute to each IP, like NewIPThrottle,
+// with the state in redis. The idle IPs expire on their own.
+func NewRedisIPThrottle(client *redis.Client, scope string, perMinute int) *RedisThrottle {
+	return &RedisThrottle{client: client, scope: scope, perMinute: perMinute, now: time.Now}
+}
+
+// Allow takes a request from the IP bucket. The request is allowed when redis fails, so the throttle doesn't take
+// the endpoints down with it.
+func (t *RedisThrottle) Allow(ctx context.Context, ip string) (time.Duration, error) {
+	var wait time.Duration
+	var throttled error
+	if err := t.update(ctx, ip, func(state *ipState) { wait, throttled = state.take(t.perMinute) }); err != nil {
+		log.Error(ctx, "reading the rate limit of the ip", "err", err, "ip", ip, "scope", t.scope)
+		return 0, nil
+	}
+	return wait, throttled
+}
+
+// Report adds a strike to the IP when it failed a challenge and removes one when it solved it
+func (t *RedisThrottle) Report(ctx context.Context, ip string, solved bool) {
+	if err := t.update(ctx, ip, func(state *ipState) { state.report(t.perMinute, solved) }); err != nil {
+		log.Error(ctx, "reporting the challenge of the ip", "err", err, "ip", ip, "scope", t.scope)
+	}
+}
+
+// update changes the refilled state of the IP and saves it unless another server changed it meanwhile, in which case
+// it starts again. The key expires when the IP becomes forgettable.
+func (t *RedisThrottle) update(ctx context.Context, ip string, change func(state *ipState)) error {
+	key := "throttle:" + t.scope + ":" + ip
+	txf := func(tx *redis.Tx) error {
+		now := t.now()
+		state := newIPState(t.perMinute, now)
+		values, err := tx.HGetAll(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+		if len(values) > 0 {
+			if err := parseIPState(values, state); err != nil {
+				return err
+			}
+			state.refill(t.perMinute, now)
+		}
+		change(state)
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, key,
+				"tokens", strconv.FormatFloat(state.tokens, 'g', -1, 64),
+				"strikes", strconv.FormatFloat(state.strikes, 'g', -1, 64),
+				"last_seen", strconv.FormatInt(state.lastSeen.UnixNano(), 10))
+			pipe.PExpire(ctx, key, idleTTL+time.Duration(state.strikes*float64(strikeDecay)))
+			return nil
+		})
+		return err
+	}
+	for i := 0; i < redisAttempts; i++ {
+		err := t.client.Watch(ctx, txf, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return redis.TxFailedErr
+}
+
+// parseIPState reads the state saved by update
+func parseIPState(values map[string]string, state *ipState) error {
+	tokens, err := strconv.ParseFloat(values["tokens"], 64)
+	if err != nil {
+		return err
+	}
+	strikes, err := strconv.ParseFloat(values["strikes"], 64)
+	if err != nil {
+		return err
+	}
+	lastSeen, err := strconv.ParseInt(values["last_seen"], 10, 64)
+	if err != nil {
+		return err
+	}
+	state.tokens, state.strikes, state.lastSeen = tokens, strikes, time.Unix(0, lastSeen)
+	return nil
+}
@@ -0,0 +1,67 @@
+package antiabuse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisThrottle(t *testing.T) {
+	ctx := context.Background()
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer func() { assert.NoError(t, client.Close()) }()
+
+	now := time.Now()
+	throttle := NewRedisIPThrottle(client, "agent", 6)
+	throttle.now = func() time.Time { return now }
+	other := NewRedisIPThrottle(client, "agent", 6)
+	other.now = throttle.now
+
+	for i := 0; i < 3; i++ {
+		_, err := throttle.Allow(ctx, "203.0.113.7")
+		require.NoError(t, err)
+		_, err = other.Allow(ctx, "203.0.113.7")
+		require.NoError(t, err)
+	}
+	wait, err := throttle.Allow(ctx, "203.0.113.7")
+	assert.ErrorIs(t, err, ErrThrottled, "the servers share the bucket")
+	assert.Equal(t, 10*time.Second, wait)
+	_, err = throttle.Allow(ctx, "198.51.100.1")
+	assert.NoError(t, err, "the other IPs are not throttled")
+	_, err = NewRedisIPThrottle(client, "qrcode", 6).Allow(ctx, "203.0.113.7")
+	assert.NoError(t, err, "the other scopes are not throttled")
+
+	now = now.Add(10 * time.Second)
+	_, err = throttle.Allow(ctx, "203.0.113.7")
+	assert.NoError(t, err)
+
+	t.Run("should lower the limit of the IPs that fail the challenges", func(t *testing.T) {
+		throttle.Report(ctx, "192.0.2.1", false)
+		throttle.Report(ctx, "192.0.2.1", false)
+		for i := 0; i < 2; i++ {
+			_, err := throttle.Allow(ctx, "192.0.2.1")
+			require.NoError(t, err)
+		}
+		wait, err := throttle.Allow(ctx, "192.0.2.1")
+		assert.ErrorIs(t, err, ErrThrottled)
+		assert.Equal(t, 30*time.Second, wait)
+	})
+
+	t.Run("should forget the idle IPs", func(t *testing.T) {
+		s.FastForward(idleTTL + time.Second)
+		assert.False(t, s.Exists("throttle:agent:203.0.113.7"))
+		assert.True(t, s.Exists("throttle:agent:192.0.2.1"), "the strikes are kept longer")
+	})
+
+	t.Run("should allow the requests when redis fails", func(t *testing.T) {
+		s.Close()
+		_, err := throttle.Allow(ctx, "203.0.113.7")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,140 @@
+package antiabuse
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// strikeDecay is how long it takes to forget a failed challenge of an IP
+	strikeDecay = 10 * time.Minute
+	// idleTTL is how long an IP without failed challenges is tracked after its last request
+	idleTTL = 10 * time.Minute
+)
+
+type ipState struct {
+	tokens   float64
+	strikes  float64
+	lastSeen time.Time
+}
+
+type ipThrottle struct {
+	perMinute int
+	now       func() time.Time
+
+	mu        sync.Mutex
+	ips       map[string]*ipState
+	lastSweep time.Time
+}
+
+// NewIPThrottle returns a throttle that allows perMinute requests per minute to each IP, in bursts of up to
+// perMinute requests. Each failed challenge divides the limit of the IP, and each solved challenge and every 10
+// minutes without failures restore it. The state is kept in memory, so every server throttles its own requests.
+func NewIPThrottle(perMinute int) Throttle {
+	return &ipThrottle{perMinute: perMinute, now: time.Now, ips: make(map[string]*ipState)}
+}
+
+// Allow takes a request from the IP bucket
+func (t *ipThrottle) Allow(_ context.Context, ip string) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	t.sweep(now)
+	return t.state(ip, now).take(t.perMinute)
+}
+
+// Report adds a strike to the IP when it failed a challenge and removes one when it solved it
+func (t *ipThrottle) Report(_ context.Context, ip string, solved bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.state(ip, t.now()).report(t.perMinute, solved)
+}
+
+// state returns the refilled state of the ip
+func (t *ipThrottle) state(ip string, now time.Time) *ipState {
+	state, ok := t.ips[ip]
+	if !ok {
+		state = newIPState(t.perMinute, now)
+		t.ips[ip] = state
+		return state
+	}
+	state.refill(t.perMinute, now)
+	return state
+}
+
+// sweep forgets the IPs without strikes that have been idle for a while, once a minute
+func (t *ipThrottle) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < time.Minute {
+		return
+	}
+	t.lastSweep = now
+	for ip, state := range t.ips {
+		if state.forgettable(now) {
+			delete(t.ips, ip)
+		}
+	}
+}
+
+func newIPState(perMinute int, now time.Time) *ipState {
+	return &ipState{tokens: float64(perMinute), lastSeen: now}
+}
+
+// refill forgets the strikes and refills the tokens of the time elapsed since the IP was last seen
+func (s *ipState) refill(perMinute int, now time.Time) {
+	elapsed := now.Sub(s.lastSeen)
+	s.strikes = math.Max(0, s.strikes-float64(elapsed)/float64(strikeDecay))
+	limit := s.limit(perMinute)
+	s.tokens = math.Min(limit, s.tokens+elapsed.Seconds()*limit/60)
+	s.lastSeen = now
+}
+
+// take takes a token, or returns ErrThrottled and how long it takes to refill one
+func (s *ipState) take(perMinute int) (time.Duration, error) {
+	if s.tokens >= 1 {
+		s.tokens--
+		return 0, nil
+	}
+	wait := math.Ceil((1-s.tokens)*60/s.limit(perMinute) - 1e-9)
+	return time.Duration(wait) * time.Second, ErrThrottled
+}
+
+// report removes a strike when the IP solved a challenge, and adds one when it failed it
+func (s *ipState) report(perMinute int, solved bool) {
+	if solved {
+		s.strikes = math.Max(0, s.strikes-1)
+		return
+	}
+	s.strikes++
+	s.tokens = math.Min(s.tokens, s.limit(perMinute))
+}
+
+// limit is the number of requests per minute of the IP, that is also the size of its bucket
+func (s *ipState) limit(perMinute int) float64 {
+	return math.Max(1, float64(perMinute)/(1+s.strikes))
+}
+
+// forgettable tells whether the IP has no strikes left and has been idle for a while, so its state can be dropped
+func (s *ipState) forgettable(now time.Time) bool {
+	return now.Sub(s.lastSeen) > idleTTL && now.Sub(s.lastSeen) > time.Duration(s.strikes*float64(strikeDecay))
+}
+
+// ClientIP returns the IP of the client of the request, the key of its throttle, or the first one of the
+// X-Forwarded-For header with trustProxy, behind a load balancer
+func ClientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,47 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// BackendFile keeps the blobs in a local directory, that can be a mounted bucket
+	BackendFile = "file"
+	// BackendHTTP keeps the blobs in an HTTP object store that accepts PUT and GET requests, like a bucket endpoint
+	BackendHTTP = "http"
+)
+
+var (
+	// ErrNotFound is returned by Get when there is no blob for the key
+	ErrNotFound = errors.New("blob not found")
+	// ErrInvalidKey is returned when a key can't be used as the name of a blob
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Store keeps immutable blobs by key
+type Store interface {
+	// Put stores data under key, replacing any previous blob with the same key
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns the blob stored under key or ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the blob stored under key. Deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Open returns the store of the given backend. location is the directory of the file backend and the base url of
+// the http one, authorization is sent as the Authorization header of the http requests and transport sends them,
+// http.DefaultTransport when it is nil. An empty backend means there is no store and returns nil.
+func Open(backend string, location string, authorization string, transport http.RoundTripper) (Store, error) {
+	switch backend {
+	case "":
+		return nil, nil
+	case BackendFile:
+		return NewFileStore(location)
+	case BackendHTTP:
+		return NewHTTPStore(location, authorization, transport)
+	}
+	return nil, fmt.Errorf("unknown blob store backend <%s>, it must be %s or %s", backend, BackendFile, BackendHTTP)
+}
@@ -0,0 +1,99 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	ctx := context.Background()
+
+	fileStore, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	blobs := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+		switch r.Method {
+		case http.MethodPut:
+			blobs[key], _ = io.ReadAll(r.Body)
+		case http.MethodGet:
+			blob, ok := blobs[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(blob)
+		case http.MethodDelete:
+			if _, ok := blobs[key]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(blobs, key)
+		}
+	}))
+	defer server.Close()
+	httpStore, err := NewHTTPStore(server.URL+"/bucket/", "Bearer token", nil)
+	require.NoError(t, err)
+
+	for name, store := range map[string]Store{"file": fileStore, "http": httpStore} {
+		t.Run(name, func(t *testing.T) {
+			_, err := store.Get(ctx, "a1b2c3")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			require.NoError(t, store.Put(ctx, "a1b2c3", []byte(`"payload"`)))
+			blob, err := store.Get(ctx, "a1b2c3")
+			require.NoError(t, err)
+			assert.Equal(t, []byte(`"payload"`), blob)
+
+			require.NoError(t, store.Put(ctx, "a1b2c3", []byte(`"new payload"`)))
+			blob, err = store.Get(ctx, "a1b2c3")
+			require.NoError(t, err)
+			assert.Equal(t, []byte(`"new payload"`), blob)
+
+			require.NoError(t, store.Delete(ctx, "a1b2c3"))
+			_, err = store.Get(ctx, "a1b2c3")
+			assert.ErrorIs(t, err, ErrNotFound)
+			assert.NoError(t, store.Delete(ctx, "a1b2c3"), "the blob is already deleted")
+
+			assert.ErrorIs(t, store.Put(ctx, "../a1b2c3", nil), ErrInvalidKey)
+		})
+	}
+
+	t.Run("http without authorization", func(t *testing.T) {
+		store, err := NewHTTPStore(server.URL+"/bucket", "", nil)
+		require.NoError(t, err)
+		assert.Error(t, store.Put(ctx, "a1b2c3", []byte(`"payload"`)))
+	})
+}
+
+func TestOpen(t *testing.T) {
+	store, err := Open("", "", "", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+
+	store, err = Open(BackendFile, t.TempDir(), "", nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, store)
+
+	_, err = Open(BackendHTTP, "not a url", "", nil)
+	assert.Error(t, err)
+
+	_, err = Open("s3", "bucket", "", nil)
+	assert.Error(t, err)
+}
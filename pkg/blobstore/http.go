@@ -0,0 +1,104 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const httpTimeout = 30 * time.Second
+
+type httpStore struct {
+	baseURL       string
+	authorization string
+	client        *http.Client
+}
+
+// NewHTTPStore returns a store that uploads each blob with a PUT request to baseURL/key and downloads it with a GET.
+// If authorization is not empty it is sent as the Authorization header. The requests are sent with transport, or with
+// http.DefaultTransport when it is nil.
+func NewHTTPStore(baseURL string, authorization string, transport http.RoundTripper) (Store, error) {
+	u, err := url.ParseRequestURI(baseURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid blob store url <%s>", baseURL)
+	}
+	return &httpStore{
+		baseURL:       strings.TrimSuffix(baseURL, "/"),
+		authorization: authorization,
+		client:        &http.Client{Timeout: httpTimeout, Transport: transport},
+	}, nil
+}
+
+// Put uploads the blob
+func (h *httpStore) Put(ctx context.Context, key string, data []byte) error {
+	req, err := h.request(ctx, http.MethodPut, key, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("uploading blob %s: unexpected status %d", key, resp.StatusCode)
+	}
+	return nil
+}
+
+// Get downloads the blob
+func (h *httpStore) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := h.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading blob %s: unexpected status %d", key, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Delete deletes the blob
+func (h *httpStore) Delete(ctx context.Context, key string) error {
+	req, err := h.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusNotFound && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+		return fmt.Errorf("deleting blob %s: unexpected status %d", key, resp.StatusCode)
+	}
+	return nil
+}
+
+func (h *httpStore) request(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	if key == "" || strings.ContainsAny(key, "/?#") {
+		return nil, ErrInvalidKey
+	}
+	req, err := http.NewRequestWithContext(ctx, method, h.baseURL+"/"+url.PathEscape(key), body)
+	if err != nil {
+		return nil, err
+	}
+	if h.authorization != "" {
+		req.Header.Set("Authorization", h.authorization)
+	}
+	return req, nil
+}
@@ -83,6 +83,8 @@ func (c *Client) GetLatestStateByID(ctx context.Context, addr common.Address, id
 		latestState abi.IStateStateInfo
 		err         error
 	)
+	ctx, cancel := context.WithTimeout(ctx, c.Config.RPCResponseTimeout)
+	defer cancel()
 	if err = c.Call(func(c *ethclient.Client) error {
 		stateContact, err := abi.NewState(addr, c)
 		if err != nil {
@@ -119,6 +121,7 @@ func (c *Client) CallAuth(ctx context.Context, gasLimit uint64, privateKey *ecds
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transaction signer: %v", err)
 	}
+	auth.Context = ctx
 	auth.Value = big.NewInt(0) // in wei
 	if gasLimit == 0 {
 		auth.GasLimit = uint64(c.Config.DefaultGasLimit) // in units
@@ -0,0 +1,243 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iden3/contracts-abi/state/go/abi"
+	"github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+var (
+	// ErrReadOnly is returned by the transaction related methods of the contract backend.
+	// State transitions must be published with PublishState.
+	ErrReadOnly = errors.New("sandbox chain only accepts state transitions through PublishState")
+
+	// the revert messages of the state contract are matched by the callers, so they are kept verbatim
+	errStateNotFound = errors.New("execution reverted: State does not exist")
+	errRootNotFound  = errors.New("execution reverted: Root does not exist")
+)
+
+// waitCycleTime is the interval used to poll for blocks and receipts
+const waitCycleTime = 100 * time.Millisecond
+
+// State returns a state contract binding served by the sandbox chain
+func (c *Chain) State(contract common.Address) (*abi.State, error) {
+	return abi.NewState(contract, c)
+}
+
+// CodeAt returns a non empty code for any address, so the bindings accept the simulated contract
+func (c *Chain) CodeAt(_ context.Context, _ common.Address, _ *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+// CallContract executes the read only methods of the state contract used by the issuer
+func (c *Chain) CallContract(ctx context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	stateABI, err := abi.StateMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	if len(call.Data) < 4 {
+		return nil, fmt.Errorf("invalid call data")
+	}
+	method, err := stateABI.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	switch method.Name {
+	case "getStateInfoById":
+		info, err := c.latestState(args[0].(*big.Int))
+		if err != nil {
+			return nil, fmt.Errorf("execution reverted: %w", err)
+		}
+		return method.Outputs.Pack(info)
+	case "getStateInfoByState":
+		info, err := c.stateInfo(args[0].(*big.Int))
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(info)
+	case "getGISTRoot":
+		return method.Outputs.Pack(c.gist.Root().BigInt())
+	case "getGISTRootInfo":
+		idx, ok := c.rootIdx[args[0].(*big.Int).String()]
+		if !ok {
+			return nil, errRootNotFound
+		}
+		return method.Outputs.Pack(c.roots[idx])
+	case "getGISTProof":
+		proof, err := c.gistProof(ctx, args[0].(*big.Int))
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(proof)
+	default:
+		return nil, fmt.Errorf("method %s is not supported by the sandbox chain", method.Name)
+	}
+}
+
+func (c *Chain) stateInfo(state *big.Int) (abi.IStateStateInfo, error) {
+	for _, info := range c.states[c.stateIdx[state.String()]] {
+		if info.State.Cmp(state) == 0 {
+			return info, nil
+		}
+	}
+	return abi.IStateStateInfo{}, errStateNotFound
+}
+
+func (c *Chain) gistProof(ctx context.Context, id *big.Int) (abi.IStateGistProof, error) {
+	key, err := poseidon.Hash([]*big.Int{id})
+	if err != nil {
+		return abi.IStateGistProof{}, err
+	}
+
+	root := c.gist.Root()
+	proof, value, err := c.gist.GenerateProof(ctx, key, root)
+	if err != nil {
+		return abi.IStateGistProof{}, err
+	}
+
+	result := abi.IStateGistProof{
+		Root:         root.BigInt(),
+		Existence:    proof.Existence,
+		Index:        key,
+		Value:        value,
+		AuxExistence: false,
+		AuxIndex:     big.NewInt(0),
+		AuxValue:     big.NewInt(0),
+	}
+	if proof.NodeAux != nil {
+		result.AuxExistence = true
+		result.AuxIndex = proof.NodeAux.Key.BigInt()
+		result.AuxValue = proof.NodeAux.Value.BigInt()
+	}
+
+	siblings := proof.AllSiblings()
+	for i := range result.Siblings {
+		result.Siblings[i] = big.NewInt(0)
+		if i < len(siblings) {
+			result.Siblings[i] = siblings[i].BigInt()
+		}
+	}
+
+	return result, nil
+}
+
+// HeaderByNumber returns the header of the given block, or the latest one if number is nil
+func (c *Chain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	if !number.IsInt64() || number.Sign() < 0 || number.Int64() >= int64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return c.headers[number.Int64()], nil
+}
+
+// BlockByNumber returns an empty block with the header of the given block number
+func (c *Chain) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	header, err := c.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewBlockWithHeader(header), nil
+}
+
+// CurrentBlock returns the number of the latest mined block
+func (c *Chain) CurrentBlock(_ context.Context) (*big.Int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return big.NewInt(int64(len(c.headers) - 1)), nil
+}
+
+// WaitForBlock waits until the given block is mined. Blocks are only mined by state transitions,
+// so it returns as soon as the block exists.
+func (c *Chain) WaitForBlock(ctx context.Context, confirmationBlock *big.Int) error {
+	for {
+		current, _ := c.CurrentBlock(ctx)
+		if current.Cmp(confirmationBlock) >= 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(waitCycleTime):
+		}
+	}
+}
+
+// GetTransactionReceiptByID returns the receipt of a state transition
+func (c *Chain) GetTransactionReceiptByID(_ context.Context, txID string) (*types.Receipt, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	receipt, ok := c.receipts[common.HexToHash(txID)]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+// WaitTransactionReceiptByID returns the receipt of a state transition. Transactions are mined when
+// they are published, so there is nothing to wait for.
+func (c *Chain) WaitTransactionReceiptByID(ctx context.Context, txID string) (*types.Receipt, error) {
+	return c.GetTransactionReceiptByID(ctx, txID)
+}
+
+// PendingCodeAt is part of bind.ContractBackend
+func (c *Chain) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return c.CodeAt(ctx, account, nil)
+}
+
+// PendingNonceAt is part of bind.ContractBackend
+func (c *Chain) PendingNonceAt(_ context.Context, _ common.Address) (uint64, error) {
+	return 0, nil
+}
+
+// SuggestGasPrice is part of bind.ContractBackend. Gas is free in the sandbox.
+func (c *Chain) SuggestGasPrice(_ context.Context) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+// SuggestGasTipCap is part of bind.ContractBackend. Gas is free in the sandbox.
+func (c *Chain) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+// EstimateGas is part of bind.ContractBackend
+func (c *Chain) EstimateGas(_ context.Context, _ ethereum.CallMsg) (uint64, error) {
+	return 0, ErrReadOnly
+}
+
+// SendTransaction is part of bind.ContractBackend
+func (c *Chain) SendTransaction(_ context.Context, _ *types.Transaction) error {
+	return ErrReadOnly
+}
+
+// FilterLogs is part of bind.ContractBackend. The sandbox chain doesn't emit events.
+func (c *Chain) FilterLogs(_ context.Context, _ ethereum.FilterQuery) ([]types.Log, error) {
+	return []types.Log{}, nil
+}
+
+// SubscribeFilterLogs is part of bind.ContractBackend. The sandbox chain doesn't emit events.
+func (c *Chain) SubscribeFilterLogs(_ context.Context, _ ethereum.FilterQuery, _ chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, ErrReadOnly
+}
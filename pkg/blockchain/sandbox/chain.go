@@ -0,0 +1,244 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/contracts-abi/state/go/abi"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-iden3-crypto/poseidon"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-merkletree-sql/v2/db/memory"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/log"
+	"github.com/polygonid/sh-id-platform/pkg/protocol"
+)
+
+// gistDepth is the depth of the global identities state tree used by the state contract
+const gistDepth = 64
+
+var (
+	// ErrStateAlreadyExists is returned when a genesis transition is published for an identity that already has states
+	ErrStateAlreadyExists = errors.New("state already exists")
+	// ErrOldStateMismatch is returned when the old state of a transition is not the latest state of the identity
+	ErrOldStateMismatch = errors.New("old state does not match the latest state")
+)
+
+// Chain is an in-process simulation of a blockchain running the iden3 state contract.
+// It keeps identity states, the global identities state tree (GIST) and transaction receipts in memory,
+// mines one block per state transition and confirms every transaction immediately.
+// Zero knowledge proofs sent with the transitions are not verified.
+type Chain struct {
+	mu       sync.RWMutex
+	headers  []*types.Header
+	states   map[string][]abi.IStateStateInfo
+	stateIdx map[string]string
+	gist     *merkletree.MerkleTree
+	roots    []abi.IStateGistRootInfo
+	rootIdx  map[string]int
+	receipts map[common.Hash]*types.Receipt
+}
+
+// New returns an empty sandbox chain with only the genesis block mined
+func New(ctx context.Context) (*Chain, error) {
+	gist, err := merkletree.NewMerkleTree(ctx, memory.NewMemoryStorage(), gistDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	genesis := &types.Header{Number: big.NewInt(0), Time: uint64(time.Now().Unix())}
+	c := &Chain{
+		headers:  []*types.Header{genesis},
+		states:   make(map[string][]abi.IStateStateInfo),
+		stateIdx: make(map[string]string),
+		gist:     gist,
+		rootIdx:  make(map[string]int),
+		receipts: make(map[common.Hash]*types.Receipt),
+	}
+	c.appendRoot(gist.Root().BigInt(), genesis)
+
+	return c, nil
+}
+
+// Replay applies the already published states stored by the issuer, so a restarted node sees the same
+// chain it had before. States that can't be applied are logged and skipped.
+func (c *Chain) Replay(ctx context.Context, states []domain.IdentityState) error {
+	sort.Slice(states, func(i, j int) bool { return states[i].StateID < states[j].StateID })
+
+	for _, state := range states {
+		if state.PreviousState == nil || state.State == nil || state.TxID == nil {
+			continue
+		}
+
+		did, err := core.ParseDID(state.Identifier)
+		if err != nil {
+			return fmt.Errorf("parsing identifier %s: %w", state.Identifier, err)
+		}
+
+		oldState, err := merkletree.NewHashFromHex(*state.PreviousState)
+		if err != nil {
+			return err
+		}
+
+		newState, err := merkletree.NewHashFromHex(*state.State)
+		if err != nil {
+			return err
+		}
+
+		c.mu.Lock()
+		isOldStateGenesis := len(c.states[did.ID.BigInt().String()]) == 0
+		err = c.transit(ctx, did.ID.BigInt(), oldState.BigInt(), newState.BigInt(), isOldStateGenesis, common.HexToHash(*state.TxID))
+		c.mu.Unlock()
+		if err != nil {
+			log.Warn(ctx, "sandbox: skipping state on replay", "identifier", state.Identifier, "state", *state.State, "err", err)
+		}
+	}
+
+	return nil
+}
+
+// PublishState simulates the transitState call of the state contract. A transition that the contract would
+// reject is still mined, but its receipt is marked as failed.
+func (c *Chain) PublishState(ctx context.Context, identifier *core.DID, latestState, newState *merkletree.Hash, isOldStateGenesis bool, _ *domain.ZKProof) (*string, error) {
+	id := identifier.ID.BigInt()
+	txHash := crypto.Keccak256Hash(id.Bytes(), latestState[:], newState[:])
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.transit(ctx, id, latestState.BigInt(), newState.BigInt(), isOldStateGenesis, txHash); err != nil {
+		log.Warn(ctx, "sandbox: state transition reverted", "identifier", identifier.String(), "err", err)
+		header := c.mine()
+		c.receipts[txHash] = &types.Receipt{Status: types.ReceiptStatusFailed, TxHash: txHash, BlockNumber: header.Number, BlockHash: header.Hash()}
+	}
+
+	txID := txHash.Hex()
+	log.Info(ctx, "sandbox: state published", "identifier", identifier.String(), "tx", txID)
+	return &txID, nil
+}
+
+// GetLatestStateByID returns the latest state of the identity. The contract address is ignored.
+func (c *Chain) GetLatestStateByID(_ context.Context, _ common.Address, id *big.Int) (abi.IStateStateInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.latestState(id)
+}
+
+func (c *Chain) latestState(id *big.Int) (abi.IStateStateInfo, error) {
+	history := c.states[id.String()]
+	if len(history) == 0 {
+		return abi.IStateStateInfo{}, protocol.ErrStateNotFound
+	}
+	return history[len(history)-1], nil
+}
+
+// transit must be called with the write lock held
+func (c *Chain) transit(ctx context.Context, id, oldState, newState *big.Int, isOldStateGenesis bool, txHash common.Hash) error {
+	key := id.String()
+	history := c.states[key]
+
+	if isOldStateGenesis && len(history) > 0 {
+		return ErrStateAlreadyExists
+	}
+	if !isOldStateGenesis {
+		if len(history) == 0 {
+			return protocol.ErrStateNotFound
+		}
+		if history[len(history)-1].State.Cmp(oldState) != 0 {
+			return ErrOldStateMismatch
+		}
+	}
+
+	gistKey, err := poseidon.Hash([]*big.Int{id})
+	if err != nil {
+		return err
+	}
+	if isOldStateGenesis {
+		err = c.gist.Add(ctx, gistKey, newState)
+	} else {
+		_, err = c.gist.Update(ctx, gistKey, newState)
+	}
+	if err != nil {
+		return err
+	}
+
+	header := c.mine()
+	timestamp := new(big.Int).SetUint64(header.Time)
+
+	if isOldStateGenesis {
+		history = append(history, abi.IStateStateInfo{
+			Id:                  id,
+			State:               oldState,
+			ReplacedByState:     big.NewInt(0),
+			CreatedAtTimestamp:  big.NewInt(0),
+			ReplacedAtTimestamp: big.NewInt(0),
+			CreatedAtBlock:      big.NewInt(0),
+			ReplacedAtBlock:     big.NewInt(0),
+		})
+		c.stateIdx[oldState.String()] = key
+	}
+	previous := &history[len(history)-1]
+	previous.ReplacedByState = newState
+	previous.ReplacedAtTimestamp = timestamp
+	previous.ReplacedAtBlock = header.Number
+
+	c.states[key] = append(history, abi.IStateStateInfo{
+		Id:                  id,
+		State:               newState,
+		ReplacedByState:     big.NewInt(0),
+		CreatedAtTimestamp:  timestamp,
+		ReplacedAtTimestamp: big.NewInt(0),
+		CreatedAtBlock:      header.Number,
+		ReplacedAtBlock:     big.NewInt(0),
+	})
+
+	c.stateIdx[newState.String()] = key
+
+	c.appendRoot(c.gist.Root().BigInt(), header)
+	c.receipts[txHash] = &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: txHash, BlockNumber: header.Number, BlockHash: header.Hash()}
+
+	return nil
+}
+
+// appendRoot records a new GIST root, replacing the previous one
+func (c *Chain) appendRoot(root *big.Int, header *types.Header) {
+	timestamp := new(big.Int).SetUint64(header.Time)
+	if len(c.roots) > 0 {
+		previous := &c.roots[len(c.roots)-1]
+		previous.ReplacedByRoot = root
+		previous.ReplacedAtTimestamp = timestamp
+		previous.ReplacedAtBlock = header.Number
+	}
+
+	c.rootIdx[root.String()] = len(c.roots)
+	c.roots = append(c.roots, abi.IStateGistRootInfo{
+		Root:                root,
+		ReplacedByRoot:      big.NewInt(0),
+		CreatedAtTimestamp:  timestamp,
+		ReplacedAtTimestamp: big.NewInt(0),
+		CreatedAtBlock:      header.Number,
+		ReplacedAtBlock:     big.NewInt(0),
+	})
+}
+
+// mine appends a new block to the chain. It must be called with the write lock held
+func (c *Chain) mine() *types.Header {
+	parent := c.headers[len(c.headers)-1]
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     big.NewInt(int64(len(c.headers))),
+		Time:       uint64(time.Now().Unix()),
+	}
+	c.headers = append(c.headers, header)
+	return header
+}
@@ -0,0 +1,130 @@
+package sandbox
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/pkg/protocol"
+)
+
+func TestChain_PublishState(t *testing.T) {
+	ctx := context.Background()
+	did, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+
+	chain, err := New(ctx)
+	require.NoError(t, err)
+
+	_, err = chain.GetLatestStateByID(ctx, ethCommon.Address{}, did.ID.BigInt())
+	assert.ErrorIs(t, err, protocol.ErrStateNotFound)
+
+	genesis := hash(t, 1)
+	first := hash(t, 2)
+	second := hash(t, 3)
+
+	txID, err := chain.PublishState(ctx, did, genesis, first, true, nil)
+	require.NoError(t, err)
+	receipt, err := chain.WaitTransactionReceiptByID(ctx, *txID)
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+
+	txID, err = chain.PublishState(ctx, did, genesis, second, false, nil)
+	require.NoError(t, err)
+	receipt, err = chain.GetTransactionReceiptByID(ctx, *txID)
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+
+	txID, err = chain.PublishState(ctx, did, first, second, false, nil)
+	require.NoError(t, err)
+	receipt, err = chain.GetTransactionReceiptByID(ctx, *txID)
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+
+	current, err := chain.CurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), current.Int64())
+
+	latest, err := chain.GetLatestStateByID(ctx, ethCommon.Address{}, did.ID.BigInt())
+	require.NoError(t, err)
+	assert.Equal(t, second.BigInt(), latest.State)
+	assert.Equal(t, big.NewInt(0), latest.ReplacedByState)
+}
+
+func TestChain_StateContract(t *testing.T) {
+	ctx := context.Background()
+	did, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+
+	chain, err := New(ctx)
+	require.NoError(t, err)
+	_, err = chain.PublishState(ctx, did, hash(t, 1), hash(t, 2), true, nil)
+	require.NoError(t, err)
+
+	contract, err := chain.State(ethCommon.Address{})
+	require.NoError(t, err)
+	opts := &bind.CallOpts{Context: ctx}
+
+	info, err := contract.GetStateInfoById(opts, did.ID.BigInt())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2), info.State)
+
+	root, err := contract.GetGISTRoot(opts)
+	require.NoError(t, err)
+
+	proof, err := contract.GetGISTProof(opts, did.ID.BigInt())
+	require.NoError(t, err)
+	assert.True(t, proof.Existence)
+	assert.Equal(t, root, proof.Root)
+	assert.Equal(t, big.NewInt(2), proof.Value)
+
+	rootInfo, err := contract.GetGISTRootInfo(opts, root)
+	require.NoError(t, err)
+	assert.Equal(t, root, rootInfo.Root)
+	assert.Equal(t, big.NewInt(0), rootInfo.ReplacedByRoot)
+
+	_, err = contract.GetGISTRootInfo(opts, big.NewInt(42))
+	assert.Error(t, err)
+}
+
+func TestChain_Replay(t *testing.T) {
+	ctx := context.Background()
+	identifier := "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+	did, err := core.ParseDID(identifier)
+	require.NoError(t, err)
+
+	chain, err := New(ctx)
+	require.NoError(t, err)
+
+	states := []domain.IdentityState{
+		{StateID: 3, Identifier: identifier, PreviousState: common.ToPointer(hash(t, 2).Hex()), State: common.ToPointer(hash(t, 3).Hex()), TxID: common.ToPointer("0x02")},
+		{StateID: 1, Identifier: identifier, State: common.ToPointer(hash(t, 1).Hex())},
+		{StateID: 2, Identifier: identifier, PreviousState: common.ToPointer(hash(t, 1).Hex()), State: common.ToPointer(hash(t, 2).Hex()), TxID: common.ToPointer("0x01")},
+	}
+	require.NoError(t, chain.Replay(ctx, states))
+
+	latest, err := chain.GetLatestStateByID(ctx, ethCommon.Address{}, did.ID.BigInt())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(3), latest.State)
+
+	receipt, err := chain.GetTransactionReceiptByID(ctx, "0x02")
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+}
+
+func hash(t *testing.T, n int64) *merkletree.Hash {
+	t.Helper()
+	h, err := merkletree.NewHashFromBigInt(big.NewInt(n))
+	require.NoError(t, err)
+	return h
+}
@@ -0,0 +1,38 @@
+package sandbox
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iden3/go-iden3-auth/state"
+)
+
+// Resolver resolves identity states and GIST roots against the sandbox chain.
+// It implements the state resolver interface of the go-iden3-auth verifier.
+type Resolver struct {
+	chain *Chain
+}
+
+// Resolver returns a state resolver backed by the chain
+func (c *Chain) Resolver() Resolver {
+	return Resolver{chain: c}
+}
+
+// Resolve returns the resolved state of the identity
+func (r Resolver) Resolve(ctx context.Context, id, st *big.Int) (*state.ResolvedState, error) {
+	getter, err := state.NewStateCaller(common.Address{}, r.chain)
+	if err != nil {
+		return nil, err
+	}
+	return state.Resolve(ctx, getter, id, st)
+}
+
+// ResolveGlobalRoot returns the resolved GIST root
+func (r Resolver) ResolveGlobalRoot(ctx context.Context, st *big.Int) (*state.ResolvedState, error) {
+	getter, err := state.NewStateCaller(common.Address{}, r.chain)
+	if err != nil {
+		return nil, err
+	}
+	return state.ResolveGlobalRoot(ctx, getter, st)
+}
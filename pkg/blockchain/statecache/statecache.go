@@ -0,0 +1,196 @@
+// Package statecache caches the identity states and the global state roots resolved in the state contracts, so the
+// verifications of a busy node don't ask the RPC endpoint about the same states over and over.
+package statecache
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/iden3/go-iden3-auth/pubsignals"
+	"github.com/iden3/go-iden3-auth/state"
+
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+const (
+	// replacedTTL is how long the answers about replaced states are kept. They never change, so it only bounds the
+	// memory of the cache.
+	replacedTTL = 24 * time.Hour
+	// refreshTimeout bounds the background refresh of a stale answer
+	refreshTimeout = 30 * time.Second
+	// sweepPeriod is how often the expired answers are removed
+	sweepPeriod = time.Minute
+)
+
+// metrics counts the lookups by kind and result, e.g. gist.stale, and the invalidated answers.
+// They are published with the rest of the expvar variables.
+var metrics = expvar.NewMap("state_cache")
+
+// Config sets how long the answers that a state is the latest one are trusted. The answers that a state was replaced
+// are final and are served without asking the chain again.
+type Config struct {
+	// TTL is how long the answer is served without asking the chain
+	TTL time.Duration
+	// StaleTTL is how long after the TTL the answer is still served, while it is refreshed in the background
+	StaleTTL time.Duration
+	// Clock is the time source of the expirations, the system clock when nil
+	Clock clock.Clock
+}
+
+// key of a cached answer. The global state roots don't have an id.
+type key struct {
+	id    string
+	state string
+}
+
+func (k key) kind() string {
+	if k.id == "" {
+		return "gist"
+	}
+	return "identity"
+}
+
+type entry struct {
+	resolved   state.ResolvedState
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+// Resolver is a state resolver that caches the answers of another one with stale-while-revalidate semantics. An
+// answer that a state was replaced is an observed state transition, and drops the answers that the other states of
+// the identity, or the other global state roots, were the latest ones.
+type Resolver struct {
+	resolver pubsignals.StateResolver
+	cfg      Config
+
+	mu        sync.Mutex
+	entries   map[key]*entry
+	lastSweep time.Time
+}
+
+// New returns a resolver that caches the answers of resolver
+func New(resolver pubsignals.StateResolver, cfg Config) *Resolver {
+	cfg.Clock = clock.OrSystem(cfg.Clock)
+	return &Resolver{resolver: resolver, cfg: cfg, entries: make(map[key]*entry)}
+}
+
+// Resolve returns the resolved state of the identity
+func (r *Resolver) Resolve(ctx context.Context, id, st *big.Int) (*state.ResolvedState, error) {
+	return r.lookup(ctx, key{id: id.String(), state: st.String()}, func(ctx context.Context) (*state.ResolvedState, error) {
+		return r.resolver.Resolve(ctx, id, st)
+	})
+}
+
+// ResolveGlobalRoot returns the resolved global state root
+func (r *Resolver) ResolveGlobalRoot(ctx context.Context, st *big.Int) (*state.ResolvedState, error) {
+	return r.lookup(ctx, key{state: st.String()}, func(ctx context.Context) (*state.ResolvedState, error) {
+		return r.resolver.ResolveGlobalRoot(ctx, st)
+	})
+}
+
+// InvalidateIdentity drops the answers that the states of the identity, and the global state roots, are the latest
+// ones. It is called when a state transition of the identity is known before the cache sees it.
+func (r *Resolver) InvalidateIdentity(id *big.Int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.invalidateLatest(id.String())
+	r.invalidateLatest("")
+}
+
+// lookup returns the cached answer: a fresh one as it is, a stale one while it is refreshed in the background, and
+// otherwise the one of the chain. The errors are not cached.
+func (r *Resolver) lookup(ctx context.Context, k key, fetch func(ctx context.Context) (*state.ResolvedState, error)) (*state.ResolvedState, error) {
+	r.mu.Lock()
+	now := r.cfg.Clock.Now()
+	r.sweep(now)
+	if cached, ok := r.entries[k]; ok {
+		age := now.Sub(cached.fetchedAt)
+		resolved := cached.resolved
+		switch {
+		case !resolved.Latest && age < replacedTTL, resolved.Latest && age < r.cfg.TTL:
+			r.mu.Unlock()
+			count(k.kind(), "hit")
+			return &resolved, nil
+		case resolved.Latest && age < r.cfg.TTL+r.cfg.StaleTTL:
+			if !cached.refreshing {
+				cached.refreshing = true
+				go r.refresh(k, fetch)
+			}
+			r.mu.Unlock()
+			count(k.kind(), "stale")
+			return &resolved, nil
+		}
+	}
+	r.mu.Unlock()
+
+	count(k.kind(), "miss")
+	resolved, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.store(k, *resolved)
+	return resolved, nil
+}
+
+// refresh asks the chain again for a stale answer
+func (r *Resolver) refresh(k key, fetch func(ctx context.Context) (*state.ResolvedState, error)) {
+	ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+	defer cancel()
+	resolved, err := fetch(ctx)
+	if err != nil {
+		count(k.kind(), "refresh_error")
+		r.mu.Lock()
+		if cached, ok := r.entries[k]; ok {
+			cached.refreshing = false
+		}
+		r.mu.Unlock()
+		return
+	}
+	r.store(k, *resolved)
+}
+
+// store caches the answer. An answer that the state was replaced drops the ones that the other states were the latest.
+func (r *Resolver) store(k key, resolved state.ResolvedState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !resolved.Latest {
+		r.invalidateLatest(k.id)
+	}
+	r.entries[k] = &entry{resolved: resolved, fetchedAt: r.cfg.Clock.Now()}
+}
+
+// invalidateLatest drops the answers that a state of the identity, or a global state root when id is empty, is the
+// latest one
+func (r *Resolver) invalidateLatest(id string) {
+	for k, cached := range r.entries {
+		if k.id == id && cached.resolved.Latest {
+			delete(r.entries, k)
+			count(k.kind(), "invalidated")
+		}
+	}
+}
+
+// sweep removes the expired answers, once a minute
+func (r *Resolver) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < sweepPeriod {
+		return
+	}
+	r.lastSweep = now
+	for k, cached := range r.entries {
+		ttl := replacedTTL
+		if cached.resolved.Latest {
+			ttl = r.cfg.TTL + r.cfg.StaleTTL
+		}
+		if now.Sub(cached.fetchedAt) >= ttl {
+			delete(r.entries, k)
+		}
+	}
+}
+
+func count(kind string, result string) {
+	metrics.Add(fmt.Sprintf("%s.%s", kind, result), 1)
+}
@@ -0,0 +1,152 @@
+package statecache
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iden3/go-iden3-auth/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+// chain answers with the states it is told, and counts the calls
+type chain struct {
+	mu     sync.Mutex
+	states map[string]state.ResolvedState
+	calls  int
+	err    error
+}
+
+func (c *chain) answer(st *big.Int) (*state.ResolvedState, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	resolved := c.states[st.String()]
+	return &resolved, nil
+}
+
+func (c *chain) Resolve(_ context.Context, _, st *big.Int) (*state.ResolvedState, error) {
+	return c.answer(st)
+}
+
+func (c *chain) ResolveGlobalRoot(_ context.Context, st *big.Int) (*state.ResolvedState, error) {
+	return c.answer(st)
+}
+
+func (c *chain) set(st int64, resolved state.ResolvedState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.states[big.NewInt(st).String()] = resolved
+}
+
+func (c *chain) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+	id := big.NewInt(1)
+	now := clock.NewFake(time.Now())
+	newResolver := func() (*Resolver, *chain) {
+		c := &chain{states: map[string]state.ResolvedState{
+			"10": {State: "10", Latest: true},
+			"20": {State: "20", Latest: false, TransitionTimestamp: 100},
+		}}
+		return New(c, Config{TTL: time.Minute, StaleTTL: time.Minute, Clock: now}), c
+	}
+
+	t.Run("should serve the fresh answers from the cache", func(t *testing.T) {
+		resolver, c := newResolver()
+		for i := 0; i < 3; i++ {
+			resolved, err := resolver.Resolve(ctx, id, big.NewInt(10))
+			require.NoError(t, err)
+			assert.True(t, resolved.Latest)
+		}
+		assert.Equal(t, 1, c.count())
+	})
+
+	t.Run("should serve a stale answer while it is refreshed", func(t *testing.T) {
+		resolver, c := newResolver()
+		_, err := resolver.ResolveGlobalRoot(ctx, big.NewInt(10))
+		require.NoError(t, err)
+		c.set(10, state.ResolvedState{State: "10", Latest: false, TransitionTimestamp: 200})
+
+		now.Advance(90 * time.Second)
+		resolved, err := resolver.ResolveGlobalRoot(ctx, big.NewInt(10))
+		require.NoError(t, err)
+		assert.True(t, resolved.Latest, "the stale answer is served")
+		require.Eventually(t, func() bool {
+			resolved, err := resolver.ResolveGlobalRoot(ctx, big.NewInt(10))
+			return err == nil && !resolved.Latest
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, 2, c.count())
+	})
+
+	t.Run("should ask the chain again after the stale ttl", func(t *testing.T) {
+		resolver, c := newResolver()
+		_, err := resolver.Resolve(ctx, id, big.NewInt(10))
+		require.NoError(t, err)
+		now.Advance(3 * time.Minute)
+		_, err = resolver.Resolve(ctx, id, big.NewInt(10))
+		require.NoError(t, err)
+		assert.Equal(t, 2, c.count())
+	})
+
+	t.Run("should keep the replaced states", func(t *testing.T) {
+		resolver, c := newResolver()
+		_, err := resolver.Resolve(ctx, id, big.NewInt(20))
+		require.NoError(t, err)
+		now.Advance(time.Hour)
+		resolved, err := resolver.Resolve(ctx, id, big.NewInt(20))
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), resolved.TransitionTimestamp)
+		assert.Equal(t, 1, c.count())
+	})
+
+	t.Run("should drop the latest states of the identity on an observed transition", func(t *testing.T) {
+		resolver, c := newResolver()
+		_, err := resolver.Resolve(ctx, id, big.NewInt(10))
+		require.NoError(t, err)
+		_, err = resolver.Resolve(ctx, id, big.NewInt(20))
+		require.NoError(t, err)
+		_, err = resolver.Resolve(ctx, id, big.NewInt(10))
+		require.NoError(t, err)
+		assert.Equal(t, 3, c.count())
+	})
+
+	t.Run("should drop the latest states on invalidation", func(t *testing.T) {
+		resolver, c := newResolver()
+		_, err := resolver.Resolve(ctx, id, big.NewInt(10))
+		require.NoError(t, err)
+		_, err = resolver.ResolveGlobalRoot(ctx, big.NewInt(10))
+		require.NoError(t, err)
+		resolver.InvalidateIdentity(id)
+		_, err = resolver.Resolve(ctx, id, big.NewInt(10))
+		require.NoError(t, err)
+		_, err = resolver.ResolveGlobalRoot(ctx, big.NewInt(10))
+		require.NoError(t, err)
+		assert.Equal(t, 4, c.count())
+	})
+
+	t.Run("should not cache the errors", func(t *testing.T) {
+		resolver, c := newResolver()
+		c.err = errors.New("rpc down")
+		_, err := resolver.Resolve(ctx, id, big.NewInt(10))
+		assert.Error(t, err)
+		c.err = nil
+		_, err = resolver.Resolve(ctx, id, big.NewInt(10))
+		require.NoError(t, err)
+		assert.Equal(t, 2, c.count())
+	})
+}
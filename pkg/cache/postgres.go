@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// PostgresCache is a cache kept in the cache_entries table, for the nodes that run without redis. The values are
+// stored as JSON, and Run removes the expired entries.
+type PostgresCache struct {
+	conn *pgxpool.Pool
+	now  func() time.Time
+}
+
+// NewPostgresCache returns a new cache based on Postgres
+func NewPostgresCache(conn *pgxpool.Pool) *PostgresCache {
+	return &PostgresCache{conn: conn, now: time.Now}
+}
+
+// Set sets a new entry in the cache table
+func (c *PostgresCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var expiresAt *time.Time
+	if ttl != ForEver {
+		expiration := c.now().Add(ttl)
+		expiresAt = &expiration
+	}
+	_, err = c.conn.Exec(ctx,
+		`INSERT INTO cache_entries (key, value, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE SET value = $2, expires_at = $3`,
+		key, data, expiresAt)
+	return err
+}
+
+// Get returns an entry from the cache table and a boolean telling if the key has been found
+// value must be passed as reference as the cached value will be stored there
+func (c *PostgresCache) Get(ctx context.Context, key string, value any) bool {
+	var data []byte
+	err := c.conn.QueryRow(ctx, `SELECT value FROM cache_entries WHERE key = $1 AND (expires_at IS NULL OR expires_at > $2)`, key, c.now()).
+		Scan(&data)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, value) == nil
+}
+
+// Exists returns true if the key exists in the cache table and it is not expired
+func (c *PostgresCache) Exists(ctx context.Context, key string) bool {
+	var exists bool
+	err := c.conn.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM cache_entries WHERE key = $1 AND (expires_at IS NULL OR expires_at > $2))`, key, c.now()).
+		Scan(&exists)
+	return err == nil && exists
+}
+
+// Delete removes an entry from the cache table
+func (c *PostgresCache) Delete(ctx context.Context, key string) error {
+	_, err := c.conn.Exec(ctx, `DELETE FROM cache_entries WHERE key = $1`, key)
+	return err
+}
+
+// Cleanup removes the expired entries and returns how many were removed
+func (c *PostgresCache) Cleanup(ctx context.Context) (int64, error) {
+	tag, err := c.conn.Exec(ctx, `DELETE FROM cache_entries WHERE expires_at <= $1`, c.now())
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Run removes the expired entries every period until the context is done
+func (c *PostgresCache) Run(ctx context.Context, period time.Duration) {
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			if _, err := c.Cleanup(ctx); err != nil {
+				log.Error(ctx, "removing the expired cache entries", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
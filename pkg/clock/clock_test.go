@@ -0,0 +1,27 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPassed(t *testing.T) {
+	now := time.Date(2023, 8, 17, 12, 0, 0, 0, time.UTC)
+	c := NewFake(now)
+
+	assert.False(t, Passed(c, now, 0))
+	assert.True(t, Passed(c, now.Add(-time.Second), 0))
+	assert.False(t, Passed(c, now.Add(-time.Second), 30*time.Second), "within the skew tolerance")
+
+	c.Advance(time.Minute)
+	assert.True(t, Passed(c, now, 30*time.Second))
+	assert.Equal(t, now.Add(time.Minute), c.Now())
+
+	c.Set(now)
+	assert.Equal(t, now, c.Now())
+
+	assert.Equal(t, System, OrSystem(nil))
+	assert.Equal(t, Clock(c), OrSystem(c))
+}
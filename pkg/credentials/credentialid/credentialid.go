@@ -0,0 +1,173 @@
+// Package credentialid builds the ids of the credentials the node issues and of their revocation status.
+//
+// The unique part of an id is a UUID or an ULID. Both are kept as a UUID by the node, the ULID one holds the time it
+// was created in its first 48 bits, so the ids sort by their creation. The ids are shown as an url of the node, a DID
+// url of the issuer, an urn or a custom template.
+package credentialid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+// Generators of the unique part of the ids
+const (
+	// GeneratorUUID generates time based UUIDs (version 1)
+	GeneratorUUID = "uuid"
+	// GeneratorULID generates ULIDs, shown in their base32 form
+	GeneratorULID = "ulid"
+)
+
+// Formats of the credential ids. Any other format is a template.
+const (
+	// FormatURL shows the ids as the url of the credential in the node
+	FormatURL = "url"
+	// FormatDID shows the ids as a DID url of the issuer, {issuer}/credentials/{id}
+	FormatDID = "did"
+	// FormatURN shows the ids as an urn of the generator, urn:uuid:{id} or urn:ulid:{id}
+	FormatURN = "urn"
+)
+
+// Placeholders of the templates
+const (
+	placeholderHost   = "{host}"
+	placeholderIssuer = "{issuer}"
+	placeholderID     = "{id}"
+	placeholderNonce  = "{nonce}"
+)
+
+// crockford is the base32 alphabet of the ULIDs
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ErrInvalidScheme is returned for an unknown generator or a template without its placeholder
+var ErrInvalidScheme = errors.New("invalid credential id scheme")
+
+// Scheme builds the ids of the credentials and of their status. The nil Scheme builds the default ones: UUIDs shown as
+// the urls of the node.
+type Scheme struct {
+	generator      string
+	format         string
+	statusTemplate string
+	clock          clock.Clock
+}
+
+// New returns the scheme of the generator and the format of the ids. The status template is the id of the revocation
+// status with the {host}, {issuer} and {nonce} placeholders, empty for the url of the node. The empty generator and
+// format are the default ones.
+func New(generator, format, statusTemplate string, c clock.Clock) (*Scheme, error) {
+	switch generator {
+	case "":
+		generator = GeneratorUUID
+	case GeneratorUUID, GeneratorULID:
+	default:
+		return nil, fmt.Errorf("%w: unknown generator <%s>, it must be %s or %s", ErrInvalidScheme, generator, GeneratorUUID, GeneratorULID)
+	}
+	switch format {
+	case "":
+		format = FormatURL
+	case FormatURL, FormatDID, FormatURN:
+	default:
+		if !strings.Contains(format, placeholderID) {
+			return nil, fmt.Errorf("%w: the template <%s> has no %s", ErrInvalidScheme, format, placeholderID)
+		}
+	}
+	if statusTemplate != "" && !strings.Contains(statusTemplate, placeholderNonce) {
+		return nil, fmt.Errorf("%w: the status template <%s> has no %s", ErrInvalidScheme, statusTemplate, placeholderNonce)
+	}
+	return &Scheme{generator: generator, format: format, statusTemplate: statusTemplate, clock: clock.OrSystem(c)}, nil
+}
+
+// NewID returns the unique part of a new credential id
+func (s *Scheme) NewID() (uuid.UUID, error) {
+	if s == nil || s.generator == GeneratorUUID {
+		return uuid.NewUUID()
+	}
+	var id uuid.UUID
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(s.clock.Now().UnixMilli()))
+	copy(id[:6], ms[2:])
+	if _, err := rand.Read(id[6:]); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+// Text returns the unique part of the id as it is shown in the credential
+func (s *Scheme) Text(id uuid.UUID) string {
+	if s == nil || s.generator == GeneratorUUID {
+		return id.String()
+	}
+	n := new(big.Int).SetBytes(id[:])
+	mask := big.NewInt(31)
+	text := make([]byte, 26)
+	for i := len(text) - 1; i >= 0; i-- {
+		text[i] = crockford[new(big.Int).And(n, mask).Int64()]
+		n.Rsh(n, 5)
+	}
+	return string(text)
+}
+
+// CredentialID returns the id of the credential of the issuer. The single issuer credentials have the url of the node
+// without the issuer.
+func (s *Scheme) CredentialID(host, issuerDID string, id uuid.UUID, singleIssuer bool) string {
+	host = strings.TrimSuffix(host, "/")
+	format := FormatURL
+	if s != nil {
+		format = s.format
+	}
+	switch format {
+	case FormatURL:
+		if singleIssuer {
+			return fmt.Sprintf("%s/v1/credentials/%s", host, s.Text(id))
+		}
+		return fmt.Sprintf("%s/v1/%s/claims/%s", host, issuerDID, s.Text(id))
+	case FormatDID:
+		return fmt.Sprintf("%s/credentials/%s", issuerDID, s.Text(id))
+	case FormatURN:
+		return fmt.Sprintf("urn:%s:%s", s.generator, s.Text(id))
+	default:
+		return strings.NewReplacer(placeholderHost, host, placeholderIssuer, issuerDID, placeholderID, s.Text(id)).Replace(format)
+	}
+}
+
+// StatusID returns the id of the revocation status of the credential of the issuer with the nonce
+func (s *Scheme) StatusID(host, issuerDID string, nonce uint64, singleIssuer bool) string {
+	if s != nil && s.statusTemplate != "" {
+		return strings.NewReplacer(placeholderHost, strings.TrimSuffix(host, "/"), placeholderIssuer, url.QueryEscape(issuerDID), placeholderNonce, strconv.FormatUint(nonce, 10)).Replace(s.statusTemplate)
+	}
+	if singleIssuer {
+		return fmt.Sprintf("%s/v1/credentials/revocation/status/%d", host, nonce)
+	}
+	return fmt.Sprintf("%s/v1/%s/claims/revocation/status/%d", host, url.QueryEscape(issuerDID), nonce)
+}
+
+// Parse returns the unique part of an id from its text, a UUID or an ULID
+func Parse(text string) (uuid.UUID, error) {
+	if len(text) != 26 {
+		return uuid.Parse(text)
+	}
+	n := new(big.Int)
+	for _, r := range strings.ToUpper(text) {
+		i := strings.IndexRune(crockford, r)
+		if i < 0 {
+			return uuid.Nil, fmt.Errorf("invalid ULID <%s>", text)
+		}
+		n.Lsh(n, 5).Or(n, big.NewInt(int64(i)))
+	}
+	if n.BitLen() > 128 {
+		return uuid.Nil, fmt.Errorf("invalid ULID <%s>", text)
+	}
+	var id uuid.UUID
+	n.FillBytes(id[:])
+	return id, nil
+}
@@ -0,0 +1,84 @@
+package credentialid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+const issuer = "did:polygonid:polygon:amoy:2qQ68JkRcf3xrHPQPWZei3YeVzHPP58wYNxx2mEouR"
+
+func TestScheme_CredentialID(t *testing.T) {
+	id := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	for name, tc := range map[string]struct {
+		scheme       *Scheme
+		singleIssuer bool
+		expected     string
+	}{
+		"default":         {nil, false, "https://issuer.example.com/v1/" + issuer + "/claims/01890a5d-ac96-774b-bcce-b302099a8057"},
+		"single issuer":   {nil, true, "https://issuer.example.com/v1/credentials/01890a5d-ac96-774b-bcce-b302099a8057"},
+		"ulid url":        {mustNew(t, GeneratorULID, FormatURL, ""), false, "https://issuer.example.com/v1/" + issuer + "/claims/01H455VB4PEX5VSKNK084SN02Q"},
+		"did url":         {mustNew(t, GeneratorUUID, FormatDID, ""), false, issuer + "/credentials/01890a5d-ac96-774b-bcce-b302099a8057"},
+		"urn":             {mustNew(t, GeneratorUUID, FormatURN, ""), false, "urn:uuid:01890a5d-ac96-774b-bcce-b302099a8057"},
+		"ulid urn":        {mustNew(t, GeneratorULID, FormatURN, ""), true, "urn:ulid:01H455VB4PEX5VSKNK084SN02Q"},
+		"custom template": {mustNew(t, GeneratorULID, "https://vc.example.com/{id}", ""), false, "https://vc.example.com/01H455VB4PEX5VSKNK084SN02Q"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.scheme.CredentialID("https://issuer.example.com/", issuer, id, tc.singleIssuer))
+		})
+	}
+}
+
+func TestScheme_StatusID(t *testing.T) {
+	var defaults *Scheme
+	assert.Equal(t, "https://issuer.example.com/v1/credentials/revocation/status/7", defaults.StatusID("https://issuer.example.com", issuer, 7, true))
+
+	scheme := mustNew(t, "", "", "https://status.example.com/{issuer}/{nonce}")
+	assert.Equal(t, "https://status.example.com/did%3Apolygonid%3Apolygon%3Aamoy%3A2qQ68JkRcf3xrHPQPWZei3YeVzHPP58wYNxx2mEouR/7", scheme.StatusID("https://issuer.example.com", issuer, 7, false))
+}
+
+func TestScheme_NewID(t *testing.T) {
+	now := clock.NewFake(time.Now())
+	scheme, err := New(GeneratorULID, "", "", now)
+	require.NoError(t, err)
+
+	first, err := scheme.NewID()
+	require.NoError(t, err)
+	now.Advance(time.Millisecond)
+	second, err := scheme.NewID()
+	require.NoError(t, err)
+	assert.Less(t, scheme.Text(first), scheme.Text(second), "the ULIDs sort by their creation")
+
+	parsed, err := Parse(scheme.Text(first))
+	require.NoError(t, err)
+	assert.Equal(t, first, parsed)
+	parsed, err = Parse(first.String())
+	require.NoError(t, err)
+	assert.Equal(t, first, parsed)
+
+	_, err = Parse("8ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	assert.Error(t, err, "it overflows 128 bits")
+}
+
+func TestNew(t *testing.T) {
+	for _, args := range [][3]string{
+		{"snowflake", "", ""},
+		{"", "https://vc.example.com/credentials", ""},
+		{"", "", "https://status.example.com/{issuer}"},
+	} {
+		_, err := New(args[0], args[1], args[2], nil)
+		assert.ErrorIs(t, err, ErrInvalidScheme, args)
+	}
+}
+
+func mustNew(t *testing.T, generator, format, statusTemplate string) *Scheme {
+	t.Helper()
+	scheme, err := New(generator, format, statusTemplate, nil)
+	require.NoError(t, err)
+	return scheme
+}
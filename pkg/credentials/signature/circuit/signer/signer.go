@@ -82,6 +82,34 @@ func (signer *CircuitSigner) getSignatureSuite(signatureType string) (SignatureS
 	return nil, fmt.Errorf("signature type %s not supported", signatureType)
 }
 
+// ErrInvalidSignature is returned by Verify when the signature doesn't match the claim and key
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Verify checks that sigHex is the signature made by Sign for claim with the BJJ key of the issuer auth claim
+func Verify(claim *core.Claim, authClaim *core.Claim, sigHex string) error {
+	hashIndex, hashValue, err := claim.HiHv()
+	if err != nil {
+		return err
+	}
+
+	commonHash, err := poseidon.Hash([]*big.Int{hashIndex, hashValue})
+	if err != nil {
+		return err
+	}
+
+	sig, err := BJJSignatureFromHexString(sigHex)
+	if err != nil {
+		return err
+	}
+
+	authSlots := authClaim.RawSlotsAsInts()
+	publicKey := babyjub.PublicKey{X: authSlots[2], Y: authSlots[3]}
+	if !publicKey.VerifyPoseidon(commonHash, sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
 // BJJSignatureFromHexString converts hex to  babyjub.Signature
 func BJJSignatureFromHexString(sigHex string) (*babyjub.Signature, error) {
 	signatureBytes, err := hex.DecodeString(sigHex)
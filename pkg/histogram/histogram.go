@@ -0,0 +1,87 @@
+package histogram
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultBounds are the upper bounds of the buckets of the latencies of a request, from 5ms to 10s
+var DefaultBounds = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Histogram counts durations in buckets of growing upper bounds. It is an expvar.Var, so it can be published alone or
+// in an expvar.Map.
+type Histogram struct {
+	bounds []time.Duration
+
+	mu     sync.Mutex
+	counts []int64 // one per bound, and the last one for the durations above all of them
+	count  int64
+	sum    time.Duration
+}
+
+// Snapshot is the content of a histogram. The buckets are cumulative, like the ones of prometheus: each one counts the
+// durations up to its bound, in milliseconds, and +Inf counts all of them.
+type Snapshot struct {
+	Count   int64            `json:"count"`
+	SumMs   float64          `json:"sum_ms"`
+	Buckets map[string]int64 `json:"buckets"`
+}
+
+// New returns a histogram with the bounds, that must be sorted. DefaultBounds are used when none is given.
+func New(bounds ...time.Duration) *Histogram {
+	if len(bounds) == 0 {
+		bounds = DefaultBounds
+	}
+	return &Histogram{bounds: bounds, counts: make([]int64, len(bounds)+1)}
+}
+
+// Observe counts a duration
+func (h *Histogram) Observe(d time.Duration) {
+	i := 0
+	for i < len(h.bounds) && d > h.bounds[i] {
+		i++
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[i]++
+	h.count++
+	h.sum += d
+}
+
+// Snapshot returns the content of the histogram
+func (h *Histogram) Snapshot() Snapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := Snapshot{Count: h.count, SumMs: milliseconds(h.sum), Buckets: make(map[string]int64, len(h.counts))}
+	var cumulative int64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		s.Buckets[strconv.FormatFloat(milliseconds(bound), 'f', -1, 64)] = cumulative
+	}
+	s.Buckets["+Inf"] = h.count
+	return s
+}
+
+// String returns the snapshot of the histogram in JSON, as expvar.Var requires
+func (h *Histogram) String() string {
+	out, _ := json.Marshal(h.Snapshot())
+	return string(out)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
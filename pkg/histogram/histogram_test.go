@@ -0,0 +1,31 @@
+package histogram
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	h := New(10*time.Millisecond, 100*time.Millisecond)
+	h.Observe(2 * time.Millisecond)
+	h.Observe(10 * time.Millisecond)
+	h.Observe(50 * time.Millisecond)
+	h.Observe(3 * time.Second)
+
+	s := h.Snapshot()
+	assert.Equal(t, int64(4), s.Count)
+	assert.Equal(t, 3062.0, s.SumMs)
+	assert.Equal(t, map[string]int64{"10": 2, "100": 3, "+Inf": 4}, s.Buckets, "the buckets are cumulative")
+
+	var published Snapshot
+	var _ expvar.Var = h
+	require.NoError(t, json.Unmarshal([]byte(h.String()), &published))
+	assert.Equal(t, s, published)
+
+	assert.Len(t, New().Snapshot().Buckets, len(DefaultBounds)+1)
+}
@@ -14,11 +14,19 @@ import (
 )
 
 // DefaultHTTPClientWithRetry http client with retry behavior.
-var DefaultHTTPClientWithRetry = NewClient(http.Client{
-	Transport: &retryablehttp.RoundTripper{
-		Client: retryablehttp.NewClient(),
-	},
-})
+var DefaultHTTPClientWithRetry = NewClientWithRetry(nil)
+
+// NewClientWithRetry returns a client with retry behavior that sends the requests with transport, or with
+// http.DefaultTransport when it is nil
+func NewClientWithRetry(transport http.RoundTripper) *Client {
+	c := retryablehttp.NewClient()
+	c.HTTPClient = &http.Client{Transport: transport}
+	return NewClient(http.Client{
+		Transport: &retryablehttp.RoundTripper{
+			Client: c,
+		},
+	})
+}
 
 // Client represents default http client that can be used to send requests to third party services
 type Client struct {
@@ -36,7 +44,7 @@ func NewClient(c http.Client) *Client {
 func (c *Client) Post(ctx context.Context, url string, req []byte) ([]byte, error) {
 	reqBody := bytes.NewBuffer(req)
 
-	request, err := http.NewRequest(http.MethodPost, url, reqBody)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,23 @@
+package http
+
+import (
+	"fmt"
+	"net"
+)
+
+// Listen opens a tcp listener on each address, like the ones of config.ListenAddresses. When one of them can't be
+// opened the others are closed, so a server never starts on part of its addresses.
+func Listen(addresses []string) ([]net.Listener, error) {
+	listeners := make([]net.Listener, 0, len(addresses))
+	for _, address := range addresses {
+		l, err := net.Listen("tcp", address)
+		if err != nil {
+			for _, opened := range listeners {
+				_ = opened.Close()
+			}
+			return nil, fmt.Errorf("listening on %s: %w", address, err)
+		}
+		listeners = append(listeners, l)
+	}
+	return listeners, nil
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListen(t *testing.T) {
+	listeners, err := Listen([]string{"127.0.0.1:0"})
+	require.NoError(t, err)
+	require.Len(t, listeners, 1)
+	address := listeners[0].Addr().String()
+
+	_, err = Listen([]string{"127.0.0.1:0", address})
+	assert.ErrorContains(t, err, "listening on "+address)
+
+	require.NoError(t, listeners[0].Close())
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/cors"
+)
+
+// CORSConfig configures the cross-origin requests of a server, like config.CORS. The lists are comma separated.
+type CORSConfig struct {
+	AllowedOrigins   string
+	AllowedMethods   string
+	AllowedHeaders   string
+	ExposedHeaders   string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// SecurityHeadersConfig configures the security headers of the responses of a server, like config.SecurityHeaders
+type SecurityHeadersConfig struct {
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	FrameOptions          string
+	ReferrerPolicy        string
+}
+
+// CORS returns the middleware that answers the preflight requests and adds the CORS headers to the responses of the
+// allowed origins
+func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
+	return cors.Handler(cors.Options{
+		AllowedOrigins:   splitList(cfg.AllowedOrigins),
+		AllowedMethods:   splitList(cfg.AllowedMethods),
+		AllowedHeaders:   splitList(cfg.AllowedHeaders),
+		ExposedHeaders:   splitList(cfg.ExposedHeaders),
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           int(cfg.MaxAge.Seconds()),
+	})
+}
+
+// SecurityHeaders returns the middleware that adds the security headers to every response. Strict-Transport-Security
+// is sent over plain http too, as the node is usually behind a proxy that terminates the TLS, and the browsers ignore it
+// when it doesn't come over https.
+func SecurityHeaders(cfg SecurityHeadersConfig) func(http.Handler) http.Handler {
+	var hsts string
+	if cfg.HSTSMaxAge > 0 {
+		hsts = fmt.Sprintf("max-age=%d", int64(cfg.HSTSMaxAge.Seconds()))
+		if cfg.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+	frameOptions := strings.ToUpper(cfg.FrameOptions)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Set("X-Content-Type-Options", "nosniff")
+			if frameOptions != "" {
+				h.Set("X-Frame-Options", frameOptions)
+			}
+			if cfg.ReferrerPolicy != "" {
+				h.Set("Referrer-Policy", cfg.ReferrerPolicy)
+			}
+			if hsts != "" {
+				h.Set("Strict-Transport-Security", hsts)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
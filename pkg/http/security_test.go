@@ -0,0 +1,67 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	handler := CORS(CORSConfig{
+		AllowedOrigins:   "https://ui.example.com, https://admin.example.com",
+		AllowedMethods:   "GET,POST,DELETE",
+		AllowedHeaders:   "Authorization,Content-Type",
+		ExposedHeaders:   "Retry-After",
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }))
+
+	preflight := httptest.NewRequest(http.MethodOptions, "/v1/identities", http.NoBody)
+	preflight.Header.Set("Origin", "https://ui.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", http.MethodDelete)
+	preflight.Header.Set("Access-Control-Request-Headers", "Authorization")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, preflight)
+	assert.Equal(t, "https://ui.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.MethodDelete, rr.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "true", rr.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "600", rr.Header().Get("Access-Control-Max-Age"))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/identities", http.NoBody)
+	req.Header.Set("Origin", "https://admin.example.com")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "https://admin.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Retry-After", rr.Header().Get("Access-Control-Expose-Headers"))
+
+	req.Header.Set("Origin", "https://evil.example.com")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	rr := httptest.NewRecorder()
+	SecurityHeaders(SecurityHeadersConfig{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		FrameOptions:          "sameorigin",
+		ReferrerPolicy:        "no-referrer",
+	})(next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/status", http.NoBody))
+	assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "SAMEORIGIN", rr.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "no-referrer", rr.Header().Get("Referrer-Policy"))
+	assert.Equal(t, "max-age=31536000; includeSubDomains", rr.Header().Get("Strict-Transport-Security"))
+
+	rr = httptest.NewRecorder()
+	SecurityHeaders(SecurityHeadersConfig{})(next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/status", http.NoBody))
+	assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+	assert.Empty(t, rr.Header().Get("Strict-Transport-Security"))
+	assert.Empty(t, rr.Header().Get("X-Frame-Options"))
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// NewTransport returns the transport of the requests the issuer sends to other services, like the schema loaders, the
+// push gateway, the ethereum rpc over http and the webhooks. It is a copy of http.DefaultTransport, that is not
+// changed, with the proxy and the trusted certificate authorities set.
+// The HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored, and proxyURL and noProxy replace them
+// when set. The certificates of the PEM file caBundle are trusted besides the ones of the system.
+func NewTransport(proxyURL string, noProxy string, caBundle string) (*http.Transport, error) {
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("the default transport is not an *http.Transport")
+	}
+	transport := defaultTransport.Clone()
+
+	proxy := httpproxy.FromEnvironment()
+	if proxyURL != "" {
+		if _, err := url.Parse(proxyURL); err != nil {
+			return nil, fmt.Errorf("invalid proxy url <%s>: %w", proxyURL, err)
+		}
+		proxy.HTTPProxy, proxy.HTTPSProxy = proxyURL, proxyURL
+	}
+	if noProxy != "" {
+		proxy.NoProxy = noProxy
+	}
+	proxyFunc := proxy.ProxyFunc()
+	transport.Proxy = func(r *http.Request) (*url.URL, error) {
+		return proxyFunc(r.URL)
+	}
+
+	if caBundle != "" {
+		roots, err := certPool(caBundle)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	}
+	return transport, nil
+}
+
+// certPool returns the system certificates plus the ones of the PEM file caBundle
+func certPool(caBundle string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("reading the ca bundle: %w", err)
+	}
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("the ca bundle <%s> has no PEM certificates", caBundle)
+	}
+	return roots, nil
+}
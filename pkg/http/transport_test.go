@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransport(t *testing.T) {
+	transport, err := NewTransport("http://proxy.internal:3128", "rhs.internal,10.0.0.0/8", "")
+	require.NoError(t, err)
+	assert.NotSame(t, http.DefaultTransport, transport)
+
+	for _, tc := range []struct {
+		url      string
+		expected string
+	}{
+		{url: "https://schemas.example.com/kyc.json", expected: "http://proxy.internal:3128"},
+		{url: "http://push.example.com/api/v1/notify", expected: "http://proxy.internal:3128"},
+		{url: "https://rhs.internal/node", expected: ""},
+		{url: "http://10.1.2.3:8545", expected: ""},
+	} {
+		req, err := http.NewRequest(http.MethodGet, tc.url, http.NoBody)
+		require.NoError(t, err)
+		proxyURL, err := transport.Proxy(req)
+		require.NoError(t, err)
+		if tc.expected == "" {
+			assert.Nil(t, proxyURL, tc.url)
+			continue
+		}
+		require.NotNil(t, proxyURL, tc.url)
+		assert.Equal(t, tc.expected, proxyURL.String(), tc.url)
+	}
+}
+
+func TestNewTransport_CABundle(t *testing.T) {
+	_, err := NewTransport("", "", filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, err)
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+	_, err = NewTransport("", "", notPEM)
+	assert.ErrorContains(t, err, "has no PEM certificates")
+}
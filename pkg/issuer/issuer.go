@@ -0,0 +1,166 @@
+// Package issuer exposes the issuer node core services as a library, so Go programs can create identities,
+// issue and revoke credentials and manage links and schemas without running the HTTP servers.
+//
+// The services keep their state in the issuer database, which must be migrated with the issuer migrations,
+// and sign with the keys of the given KMS. Publishing identity states on chain is not part of this package.
+package issuer
+
+import (
+	"errors"
+
+	auth "github.com/iden3/go-iden3-auth"
+
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/pkg/blobstore"
+	"github.com/polygonid/sh-id-platform/pkg/cache"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+	"github.com/polygonid/sh-id-platform/pkg/policy"
+	"github.com/polygonid/sh-id-platform/pkg/pubsub"
+	"github.com/polygonid/sh-id-platform/pkg/reverse_hash"
+)
+
+var (
+	// ErrStorageRequired is returned by New when the configuration has no storage
+	ErrStorageRequired = errors.New("issuer: storage is required")
+	// ErrKMSRequired is returned by New when the configuration has no KMS
+	ErrKMSRequired = errors.New("issuer: kms is required")
+	// ErrHostRequired is returned by New when the configuration has no host
+	ErrHostRequired = errors.New("issuer: host is required")
+)
+
+// RHS configures the reverse hash service used in the credential status of the issued credentials
+type RHS struct {
+	Enabled bool
+	URL     string
+}
+
+// Config holds the dependencies of the issuer services. Storage, KMS and Host are required, the rest have defaults.
+type Config struct {
+	// Storage is the issuer database. See OpenStorage.
+	Storage *Storage
+	// KMS holds the identity keys. See OpenVaultKMS and NewKMS.
+	KMS KMS
+	// Host is the public base url of the issuer, used to build credential ids and revocation status urls.
+	Host string
+	// RHS configures the reverse hash service. Disabled by default.
+	RHS RHS
+	// Loader loads the JSON schemas and JSON-LD contexts. Defaults to an HTTP loader.
+	Loader LoaderFactory
+	// Cache stores the authentication and link sessions. Defaults to an in-memory cache.
+	Cache cache.Cache
+	// PubSub receives the issuer events. Defaults to a client that discards them.
+	PubSub pubsub.Client
+	// Verifier validates the authentication responses of the wallets. Authentication is not available without it.
+	Verifier *auth.Verifier
+	// PayloadStore keeps the credentialSubject attributes larger than PayloadThreshold bytes instead of the claims
+	// table. Optional, see blobstore.NewFileStore and blobstore.NewHTTPStore.
+	PayloadStore blobstore.Store
+	// PayloadThreshold defaults to 16KiB.
+	PayloadThreshold int
+	// Clock is the time source of the session and link expirations. Defaults to the system clock.
+	Clock clock.Clock
+	// PolicyHook approves, denies or changes the credentials before they are issued. Optional, see policy.NewHTTPHook.
+	PolicyHook policy.Hook
+}
+
+// Issuer groups the issuer core services
+type Issuer struct {
+	identities  IdentityService
+	claims      ClaimsService
+	links       LinkService
+	schemas     SchemaService
+	connections ConnectionsService
+}
+
+// New wires the issuer services with the given configuration
+func New(cfg Config) (*Issuer, error) {
+	if cfg.Storage == nil {
+		return nil, ErrStorageRequired
+	}
+	if cfg.KMS == nil {
+		return nil, ErrKMSRequired
+	}
+	if cfg.Host == "" {
+		return nil, ErrHostRequired
+	}
+	if cfg.Loader == nil {
+		cfg.Loader = loader.HTTPFactory
+	}
+	if cfg.Cache == nil {
+		cfg.Cache = cache.NewMemoryCache()
+	}
+	if cfg.PubSub == nil {
+		cfg.PubSub = pubsub.NewMock()
+	}
+
+	identityRepository := repositories.NewIdentity()
+	claimsRepository := repositories.NewClaimsWithPayloadStore(cfg.PayloadStore, cfg.PayloadThreshold)
+	mtRepository := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepository := repositories.NewIdentityState()
+	revocationRepository := repositories.NewRevocation()
+	connectionsRepository := repositories.NewConnections()
+	sessionTTLs := repositories.DefaultSessionTTLs
+	sessionTTLs.Clock = cfg.Clock
+	sessionRepository := repositories.NewSessionCachedWithTTLs(cfg.Cache, sessionTTLs)
+	linkRepository := repositories.NewLink(*cfg.Storage)
+	schemaRepository := repositories.NewSchema(*cfg.Storage)
+
+	mtService := services.NewIdentityMerkleTrees(mtRepository)
+	identityService := services.NewIdentity(cfg.KMS, identityRepository, mtRepository, identityStateRepository, mtService, claimsRepository, revocationRepository, connectionsRepository, cfg.Storage, reverse_hash.NewRhsPublisher(nil, false), cfg.Verifier, sessionRepository, cfg.PubSub)
+	claimsService := services.NewClaim(
+		claimsRepository,
+		identityService,
+		mtService,
+		identityStateRepository,
+		cfg.Loader,
+		cfg.Storage,
+		services.ClaimCfg{
+			RHSEnabled: cfg.RHS.Enabled,
+			RHSUrl:     cfg.RHS.URL,
+			Host:       cfg.Host,
+			PolicyHook: cfg.PolicyHook,
+		},
+		cfg.PubSub,
+	)
+
+	return &Issuer{
+		identities:  identityService,
+		claims:      claimsService,
+		links:       services.NewLinkService(cfg.Storage, claimsService, claimsRepository, linkRepository, schemaRepository, cfg.Loader, sessionRepository, cfg.PubSub, nil, cfg.Clock),
+		schemas:     services.NewSchema(schemaRepository, cfg.Loader, nil, nil, false),
+		connections: services.NewConnection(connectionsRepository, cfg.Storage),
+	}, nil
+}
+
+// Identities returns the identity service
+func (i *Issuer) Identities() IdentityService {
+	return i.identities
+}
+
+// Claims returns the credentials service
+func (i *Issuer) Claims() ClaimsService {
+	return i.claims
+}
+
+// Links returns the credential links service
+func (i *Issuer) Links() LinkService {
+	return i.links
+}
+
+// Schemas returns the schemas service
+func (i *Issuer) Schemas() SchemaService {
+	return i.schemas
+}
+
+// Connections returns the connections service
+func (i *Issuer) Connections() ConnectionsService {
+	return i.connections
+}
+
+// OpenStorage connects to the issuer database
+func OpenStorage(databaseURL string) (*Storage, error) {
+	return db.NewStorage(databaseURL)
+}
@@ -0,0 +1,48 @@
+package issuer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	type testConfig struct {
+		name     string
+		cfg      Config
+		expected error
+	}
+	for _, tc := range []testConfig{
+		{
+			name:     "missing storage",
+			cfg:      Config{KMS: NewKMS(), Host: "https://issuer.example.com"},
+			expected: ErrStorageRequired,
+		},
+		{
+			name:     "missing kms",
+			cfg:      Config{Storage: &Storage{}, Host: "https://issuer.example.com"},
+			expected: ErrKMSRequired,
+		},
+		{
+			name:     "missing host",
+			cfg:      Config{Storage: &Storage{}, KMS: NewKMS()},
+			expected: ErrHostRequired,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.cfg)
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		iss, err := New(Config{Storage: &Storage{}, KMS: NewKMS(), Host: "https://issuer.example.com"})
+		require.NoError(t, err)
+		assert.NotNil(t, iss.Identities())
+		assert.NotNil(t, iss.Claims())
+		assert.NotNil(t, iss.Links())
+		assert.NotNil(t, iss.Schemas())
+		assert.NotNil(t, iss.Connections())
+	})
+}
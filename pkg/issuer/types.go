@@ -0,0 +1,194 @@
+package issuer
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/core/services"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/kms"
+	"github.com/polygonid/sh-id-platform/internal/loader"
+	"github.com/polygonid/sh-id-platform/internal/providers"
+)
+
+// The aliases below are the stable names of the issuer types for programs outside this module.
+
+// Storage is the issuer database connection
+type Storage = db.Storage
+
+// Configuration is the configuration of the issuer node, read from the ISSUER_ environment variables
+type Configuration = config.Configuration
+
+// KMS is the key management service used to create and use the identity keys
+type KMS = kms.KMSType
+
+// KeyProvider creates and uses keys of one type for the KMS
+type KeyProvider = kms.KeyProvider
+
+// KeyType is the type of the keys managed by a key provider
+type KeyType = kms.KeyType
+
+// Key types supported by the issuer
+const (
+	KeyTypeBabyJubJub = kms.KeyTypeBabyJubJub
+	KeyTypeEthereum   = kms.KeyTypeEthereum
+)
+
+// LoaderFactory builds the loaders of JSON schemas and JSON-LD contexts from an url
+type LoaderFactory = loader.Factory
+
+// Loader loads a JSON schema or a JSON-LD context
+type Loader = loader.Loader
+
+// LocalOrder is the order in which a local schema bundle and the remote documents are tried
+type LocalOrder = loader.LocalOrder
+
+// Orders of the local schema bundles
+const (
+	LocalFirst  = loader.LocalFirst
+	RemoteFirst = loader.RemoteFirst
+	LocalOnly   = loader.LocalOnly
+)
+
+// DIDMethodDriver creates and resolves the identities of a DID method
+type DIDMethodDriver = ports.DIDMethodDriver
+
+// RegisterDIDMethodDriver makes the identities of the method of a driver available to IdentityService.Create
+var RegisterDIDMethodDriver = services.RegisterDIDMethodDriver
+
+// Services
+type (
+	// IdentityService manages the issuer identities and their states
+	IdentityService = ports.IdentityService
+	// ClaimsService issues, fetches and revokes credentials
+	ClaimsService = ports.ClaimsService
+	// LinkService manages the credential links
+	LinkService = ports.LinkService
+	// SchemaService imports and lists schemas
+	SchemaService = ports.SchemaService
+	// ConnectionsService manages the connections with the credential holders
+	ConnectionsService = ports.ConnectionsService
+)
+
+// Requests and entities
+type (
+	// Identity is an issuer identity
+	Identity = domain.Identity
+	// IdentityState is a state of an issuer identity
+	IdentityState = domain.IdentityState
+	// Claim is an issued credential
+	Claim = domain.Claim
+	// CreateClaimRequest holds the data to issue a credential. See NewCreateClaimRequest.
+	CreateClaimRequest = ports.CreateClaimRequest
+	// ClaimsFilter filters the credentials returned by ClaimsService.GetAll. See NewClaimsFilter.
+	ClaimsFilter = ports.ClaimsFilter
+	// Link is a credential link
+	Link = domain.Link
+	// LinkStatus filters the links returned by LinkService.GetAll
+	LinkStatus = ports.LinkStatus
+	// CredentialSubject holds the attributes of a credential
+	CredentialSubject = domain.CredentialSubject
+	// Schema is an imported schema
+	Schema = domain.Schema
+	// Connection is a connection between the issuer and a holder
+	Connection = domain.Connection
+)
+
+// Link statuses
+const (
+	LinkAll      = ports.LinkAll
+	LinkActive   = ports.LinkActive
+	LinkInactive = ports.LinkInactive
+	LinkExceeded = ports.LinkExceeded
+)
+
+// Errors returned by the services
+var (
+	ErrClaimNotFound  = services.ErrClaimNotFound
+	ErrSchemaNotFound = services.ErrSchemaNotFound
+	ErrLinkNotFound   = services.ErrLinkNotFound
+)
+
+// NewCreateClaimRequest returns a request to issue a credential
+var NewCreateClaimRequest = ports.NewCreateClaimRequest
+
+// NewClaimsFilter returns a filter for ClaimsService.GetAll
+var NewClaimsFilter = ports.NewClaimsFilter
+
+// BundleLoader returns a LoaderFactory that loads the schemas from bundle, like an embed.FS, before, after or instead
+// of fetching them over HTTP. The copy of an url is under its host and path, like example.com/schemas/kyc.json.
+func BundleLoader(bundle fs.FS, order LocalOrder) LoaderFactory {
+	return loader.LocalFactory(loader.HTTPFactory, bundle, order)
+}
+
+// NewKMS returns an empty KMS. Key providers for both key types must be registered before creating identities.
+func NewKMS() KMS {
+	return kms.NewKMS()
+}
+
+// OpenKMS returns the KMS of the key store backend of the node configuration, the same one the issuer node opens
+func OpenKMS(ctx context.Context, cfg *Configuration) (KMS, error) {
+	keyStore, err := kms.NewFromConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return keyStore, nil
+}
+
+// OpenVaultKMS returns a KMS backed by the vault iden3 plugin, the same key store used by the issuer node
+func OpenVaultKMS(address, token, pluginMountPath string) (KMS, error) {
+	vaultCli, err := providers.NewVaultClient(address, token)
+	if err != nil {
+		return nil, err
+	}
+	keyStore, err := kms.Open(pluginMountPath, vaultCli)
+	if err != nil {
+		return nil, err
+	}
+	return keyStore, nil
+}
+
+// OpenAWSKMS returns a KMS with the Ethereum keys in AWS KMS and the BabyJubJub keys in AWS Secrets Manager. The names
+// of the keys start with prefix, "issuer" when it is empty.
+func OpenAWSKMS(region, accessKeyID, secretAccessKey, prefix string) (KMS, error) {
+	keyStore, err := kms.OpenAWS(kms.AWSConfig{Region: region, KeyID: accessKeyID, Key: secretAccessKey, Prefix: prefix}, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	return keyStore, nil
+}
+
+// OpenGCPKMS returns a KMS with the Ethereum keys in the Cloud KMS key ring and the BabyJubJub keys in Secret Manager.
+// credentialsFile is a service account key file, the service account of the instance when it is empty, and the names of
+// the keys start with prefix, "issuer" when it is empty.
+func OpenGCPKMS(project, location, keyRing, credentialsFile, prefix string) (KMS, error) {
+	keyStore, err := kms.OpenGCP(kms.GCPConfig{Project: project, Location: location, KeyRing: keyRing, CredentialsFile: credentialsFile, Prefix: prefix}, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	return keyStore, nil
+}
+
+// OpenAzureKMS returns a KMS with the keys in the Azure Key Vault of vaultURL, with the credentials of a service
+// principal, or with the managed identity of the machine when clientSecret is empty. The key paths start with prefix,
+// "issuer" when it is empty.
+func OpenAzureKMS(vaultURL, tenantID, clientID, clientSecret, prefix string) (KMS, error) {
+	keyStore, err := kms.OpenAzure(kms.AzureConfig{VaultURL: vaultURL, TenantID: tenantID, ClientID: clientID, ClientSecret: clientSecret, Prefix: prefix}, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	return keyStore, nil
+}
+
+// OpenFileKMS returns a KMS with the keys in the file of path, encrypted with passphrase, that is created when it doesn't
+// exist. It is only meant for development and tests.
+func OpenFileKMS(path, passphrase string) (KMS, error) {
+	keyStore, err := kms.OpenFile(kms.FileConfig{Path: path, Passphrase: passphrase})
+	if err != nil {
+		return nil, err
+	}
+	return keyStore, nil
+}
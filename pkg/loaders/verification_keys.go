@@ -0,0 +1,39 @@
+package loaders
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/iden3/go-circuits"
+)
+
+const circuitVerificationKeyFile = "verification_key.json"
+
+// VerificationKeys loads the verification keys of the circuits for the auth verifier. Unlike FSKeyLoader, that only
+// reads the keys of the circuits in one directory, it reads the verification_key.json file of every circuit, so the
+// verifier checks the query proofs too. The auth circuits keep their key in a file named after the circuit.
+type VerificationKeys struct {
+	basePath string
+}
+
+// NewVerificationKeys create loader that returns the circuits verification keys.
+func NewVerificationKeys(basePath string) *VerificationKeys {
+	return &VerificationKeys{basePath: basePath}
+}
+
+// Load verification key by circuit ID.
+func (l *VerificationKeys) Load(circuitID circuits.CircuitID) ([]byte, error) {
+	path := filepath.Join(l.basePath, string(circuitID), circuitVerificationKeyFile)
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		path = filepath.Join(l.basePath, string(circuitID), string(circuitID)+".json")
+		data, err = os.ReadFile(filepath.Clean(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed read verification key of circuit '%s' by path '%s': %v", circuitID, path, err)
+	}
+	return data, nil
+}
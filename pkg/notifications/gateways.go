@@ -0,0 +1,192 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// Gateways is a group of push gateways that can deliver the pushes of each other, like the replicas of the push gateway
+// of a wallet, that share the key the devices are encrypted with. A push to the endpoint of a DID document in the group
+// can be sent to any of them: each connection sticks to one gateway while it is up, and fails over to the next one
+// when it is down.
+type Gateways struct {
+	urls []string
+	mu   sync.RWMutex
+	down map[string]bool
+}
+
+// HealthCheck tells whether a gateway is up
+type HealthCheck func(ctx context.Context, url string) error
+
+// ParseGateways returns the group of the comma separated gateway urls. It is nil when there are none.
+func ParseGateways(urls string) (*Gateways, error) {
+	var parsed []string
+	for _, u := range strings.Split(urls, ",") {
+		u = normalizeGatewayURL(u)
+		if u == "" {
+			continue
+		}
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			return nil, fmt.Errorf("push gateways must be http or https urls <%s>", u)
+		}
+		parsed = append(parsed, u)
+	}
+	if len(parsed) == 0 {
+		return nil, nil
+	}
+	return NewGateways(parsed...), nil
+}
+
+// NewGateways returns a group of gateways, all of them up
+func NewGateways(urls ...string) *Gateways {
+	g := &Gateways{down: make(map[string]bool)}
+	for _, u := range urls {
+		g.urls = append(g.urls, normalizeGatewayURL(u))
+	}
+	return g
+}
+
+// For returns the gateways to try, in order, for a push to the endpoint of the DID document of a connection. The key
+// identifies the connection, so its pushes go to the same gateway while it is up. The gateways that are down go last,
+// so they are still tried when all of them are. An endpoint out of the group is returned alone.
+func (g *Gateways) For(endpoint string, key string) []string {
+	if g == nil || !g.contains(normalizeGatewayURL(endpoint)) {
+		return []string{endpoint}
+	}
+
+	ordered := append([]string(nil), g.urls...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return affinity(key, ordered[i]) > affinity(key, ordered[j])
+	})
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return !g.down[ordered[i]] && g.down[ordered[j]]
+	})
+	return ordered
+}
+
+// MarkUp records that a gateway delivered a push or passed a health check. Like MarkDown, it ignores the urls out of
+// the group and does nothing on a nil group.
+func (g *Gateways) MarkUp(ctx context.Context, url string) {
+	g.mark(ctx, url, false)
+}
+
+// MarkDown records that a gateway failed a push or a health check
+func (g *Gateways) MarkDown(ctx context.Context, url string) {
+	g.mark(ctx, url, true)
+}
+
+// Down returns the gateways that are down
+func (g *Gateways) Down() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	down := make([]string, 0)
+	for _, u := range g.urls {
+		if g.down[u] {
+			down = append(down, u)
+		}
+	}
+	return down
+}
+
+// Check runs the health check on every gateway of the group
+func (g *Gateways) Check(ctx context.Context, check HealthCheck) {
+	for _, u := range g.urls {
+		if err := check(ctx, u); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Debug(ctx, "push gateway health check failed", "url", u, "err", err)
+			g.MarkDown(ctx, u)
+			continue
+		}
+		g.MarkUp(ctx, u)
+	}
+}
+
+// Run checks the gateways every period until the context is done
+func (g *Gateways) Run(ctx context.Context, period time.Duration, check HealthCheck) {
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			g.Check(ctx, check)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// HTTPHealthCheck returns a health check that considers a gateway up when it answers a GET to its url without a server
+// error, in less than timeout. The requests are sent with transport, or with http.DefaultTransport when it is nil.
+func HTTPHealthCheck(timeout time.Duration, transport http.RoundTripper) HealthCheck {
+	client := &http.Client{Transport: transport}
+	return func(ctx context.Context, url string) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("push gateway answered with status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+func (g *Gateways) contains(url string) bool {
+	for _, u := range g.urls {
+		if u == url {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Gateways) mark(ctx context.Context, url string, down bool) {
+	url = normalizeGatewayURL(url)
+	if g == nil || !g.contains(url) {
+		return
+	}
+	g.mu.Lock()
+	changed := g.down[url] != down
+	g.down[url] = down
+	g.mu.Unlock()
+	if changed && down {
+		log.Warn(ctx, "push gateway is down", "url", url)
+	} else if changed {
+		log.Info(ctx, "push gateway is up again", "url", url)
+	}
+}
+
+// affinity is the rendezvous hash of the connection and the gateway: each connection prefers the gateway with the
+// highest one, and only the connections of a gateway that goes down move to another one
+func affinity(key string, url string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(url))
+	return h.Sum64()
+}
+
+func normalizeGatewayURL(url string) string {
+	return strings.TrimRight(strings.TrimSpace(url), "/")
+}
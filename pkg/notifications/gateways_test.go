@@ -0,0 +1,97 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGateways(t *testing.T) {
+	gateways, err := ParseGateways("")
+	require.NoError(t, err)
+	assert.Nil(t, gateways)
+
+	gateways, err = ParseGateways(" https://push-1.example.com/api/v1/, https://push-2.example.com/api/v1 ,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://push-1.example.com/api/v1", "https://push-2.example.com/api/v1"}, gateways.urls)
+
+	_, err = ParseGateways("push.example.com")
+	assert.Error(t, err)
+}
+
+func TestGateways_For(t *testing.T) {
+	ctx := context.Background()
+	const (
+		push1 = "https://push-1.example.com/api/v1"
+		push2 = "https://push-2.example.com/api/v1"
+		push3 = "https://push-3.example.com/api/v1"
+		other = "https://push.other-wallet.com/api/v1"
+	)
+	gateways := NewGateways(push1, push2, push3)
+
+	assert.Equal(t, []string{other}, gateways.For(other, "did:polygonid:polygon:mumbai:2qFXmNqGWPrLqDowKz37Gq2FETk4yQwVUVUqeBLmf9"))
+	assert.Equal(t, []string{push1}, (*Gateways)(nil).For(push1, "did:polygonid:polygon:mumbai:2qFXmNqGWPrLqDowKz37Gq2FETk4yQwVUVUqeBLmf9"))
+
+	// every connection sticks to a gateway, and the connections are spread over all of them
+	preferred := map[string]string{}
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"} {
+		ordered := gateways.For(push1+"/", key)
+		require.ElementsMatch(t, []string{push1, push2, push3}, ordered)
+		assert.Equal(t, ordered, gateways.For(push2, key))
+		preferred[key] = ordered[0]
+	}
+	assert.Len(t, uniqueValues(preferred), 3)
+
+	// the connections of a gateway that is down fail over, and the others stay
+	gateways.MarkDown(ctx, push1)
+	assert.Equal(t, []string{push1}, gateways.Down())
+	for key, gateway := range preferred {
+		ordered := gateways.For(push1, key)
+		assert.Equal(t, push1, ordered[2], "the gateways that are down go last")
+		if gateway != push1 {
+			assert.Equal(t, gateway, ordered[0])
+		}
+	}
+
+	gateways.MarkUp(ctx, push1)
+	assert.Empty(t, gateways.Down())
+	for key, gateway := range preferred {
+		assert.Equal(t, gateway, gateways.For(push1, key)[0])
+	}
+}
+
+func TestGateways_Check(t *testing.T) {
+	ctx := context.Background()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer up.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	gateways := NewGateways(up.URL, failing.URL)
+	gateways.Check(ctx, HTTPHealthCheck(time.Second, nil))
+	assert.Equal(t, []string{failing.URL}, gateways.Down())
+
+	gateways.Check(ctx, func(context.Context, string) error { return nil })
+	assert.Empty(t, gateways.Down())
+
+	gateways.Check(ctx, func(context.Context, string) error { return errors.New("unreachable") })
+	assert.Equal(t, []string{up.URL, failing.URL}, gateways.Down())
+}
+
+func uniqueValues(m map[string]string) map[string]bool {
+	unique := map[string]bool{}
+	for _, v := range m {
+		unique[v] = true
+	}
+	return unique
+}
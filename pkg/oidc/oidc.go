@@ -0,0 +1,322 @@
+// Package oidc verifies the JWT access tokens issued by an OpenID Connect provider with the keys it publishes in its
+// JWKS, so the APIs of the issuer can be protected by the identity provider of the organization.
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+const (
+	// keysTTL is how long the keys of the JWKS are used before they are fetched again
+	keysTTL = time.Hour
+	// keysMinRefresh is how often at most the JWKS is fetched again for a token signed with an unknown key
+	keysMinRefresh = time.Minute
+	// leeway is the tolerated difference between the clocks of the node and of the identity provider
+	leeway = time.Minute
+	// minRSAKeyBits is the size of the smallest RSA key that signs the tokens
+	minRSAKeyBits = 2048
+)
+
+// rsaAlgorithms are the algorithms of the tokens signed with RSA keys
+var rsaAlgorithms = map[jose.SignatureAlgorithm]bool{
+	jose.RS256: true, jose.RS384: true, jose.RS512: true,
+	jose.PS256: true, jose.PS384: true, jose.PS512: true,
+}
+
+// ecAlgorithms are the algorithms of the tokens signed with ECDSA keys, with the curve of their keys
+var ecAlgorithms = map[jose.SignatureAlgorithm]elliptic.Curve{
+	jose.ES256: elliptic.P256(),
+	jose.ES384: elliptic.P384(),
+	jose.ES512: elliptic.P521(),
+}
+
+// ErrInvalidToken means the token is not a valid JWT of the identity provider for the node
+var ErrInvalidToken = errors.New("invalid token")
+
+// Config of the identity provider
+type Config struct {
+	// Issuer is the iss claim of the tokens. Its discovery document gives the JWKS url when JWKSURL is empty.
+	Issuer string
+	// JWKSURL is the url of the keys of the identity provider
+	JWKSURL string
+	// Audience must be one of the aud claims of the tokens
+	Audience string
+	// RequiredClaims must be in the tokens, see Claims.Match
+	RequiredClaims map[string]string
+	// Client fetches the discovery document and the keys
+	Client *http.Client
+	// Clock is the time source of the expirations, the system clock when nil
+	Clock clock.Clock
+}
+
+// Claims of a token
+type Claims map[string]any
+
+// Subject returns the sub claim
+func (c Claims) Subject() string {
+	sub, _ := c["sub"].(string)
+	return sub
+}
+
+// Match tells whether the claims have the values of required. A claim matches a value when it is that value, when it
+// is a list with that value, like the groups claim, or when it is a space separated list with that value, like the
+// scope claim.
+func (c Claims) Match(required map[string]string) bool {
+	for name, value := range required {
+		if !claimHas(c[name], value) {
+			return false
+		}
+	}
+	return true
+}
+
+func claimHas(claim any, value string) bool {
+	switch v := claim.(type) {
+	case string:
+		for _, item := range strings.Fields(v) {
+			if item == value {
+				return true
+			}
+		}
+		return v == value
+	case bool:
+		return strconv.FormatBool(v) == value
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64) == value
+	case []any:
+		for _, item := range v {
+			if claimHas(item, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseClaims parses comma separated name=value pairs, like groups=issuer-admins,email_verified=true
+func ParseClaims(s string) (map[string]string, error) {
+	claims := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, value, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid claim <%s>, it must be name=value", pair)
+		}
+		claims[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return claims, nil
+}
+
+// Verifier verifies the tokens of an identity provider
+type Verifier struct {
+	cfg Config
+
+	mu          sync.Mutex
+	jwksURL     string
+	keys        map[string]jose.JSONWebKey
+	fetchedAt   time.Time
+	lastRefresh time.Time
+}
+
+// NewVerifier returns the verifier of the tokens of the identity provider. The keys are fetched with the first token.
+func NewVerifier(cfg Config) *Verifier {
+	cfg.Clock = clock.OrSystem(cfg.Clock)
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	return &Verifier{cfg: cfg, jwksURL: cfg.JWKSURL}
+}
+
+// Verify checks the signature, the issuer, the audience, the validity period and the required claims of the token
+// and returns its claims. Only the RS, PS and ES algorithms are accepted, with a key of their type and curve. The
+// errors of the token wrap ErrInvalidToken, the other ones mean the keys of the identity provider can't be read.
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	if strings.Count(token, ".") != 2 {
+		return nil, fmt.Errorf("%w: it is not a signed JWT", ErrInvalidToken)
+	}
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+	if len(parsed.Headers) != 1 {
+		return nil, fmt.Errorf("%w: it must have one signature", ErrInvalidToken)
+	}
+	alg := jose.SignatureAlgorithm(parsed.Headers[0].Algorithm)
+	if _, ec := ecAlgorithms[alg]; !ec && !rsaAlgorithms[alg] {
+		return nil, fmt.Errorf("%w: unsupported algorithm %s", ErrInvalidToken, alg)
+	}
+	key, err := v.key(ctx, parsed.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKey(alg, key); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	var claims Claims
+	if err := parsed.Claims(key.Key, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+	if err := v.validate(claims); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+// checkKey checks that the key can sign the tokens of the algorithm: an RSA key of at least minRSAKeyBits for the RS
+// and PS algorithms, and an ECDSA key of the curve of the ES ones. A key that sets its algorithm only signs with it.
+func checkKey(alg jose.SignatureAlgorithm, key jose.JSONWebKey) error {
+	if key.Algorithm != "" && key.Algorithm != string(alg) {
+		return fmt.Errorf("the key <%s> signs with %s, not %s", key.KeyID, key.Algorithm, alg)
+	}
+	switch pub := key.Key.(type) {
+	case *rsa.PublicKey:
+		if !rsaAlgorithms[alg] {
+			break
+		}
+		if pub.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("the RSA key <%s> has less than %d bits", key.KeyID, minRSAKeyBits)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if curve, ok := ecAlgorithms[alg]; ok && pub.Curve == curve {
+			return nil
+		}
+	}
+	return fmt.Errorf("the algorithm %s doesn't match the key <%s>", alg, key.KeyID)
+}
+
+func (v *Verifier) validate(claims Claims) error {
+	if iss, _ := claims["iss"].(string); iss != v.cfg.Issuer {
+		return fmt.Errorf("unexpected issuer <%s>", iss)
+	}
+	if v.cfg.Audience != "" && !claimHas(claims["aud"], v.cfg.Audience) {
+		return errors.New("unexpected audience")
+	}
+	now := v.cfg.Clock.Now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return errors.New("no expiration")
+	}
+	if now.Add(-leeway).After(time.Unix(int64(exp), 0)) {
+		return errors.New("expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(leeway).Before(time.Unix(int64(nbf), 0)) {
+		return errors.New("not valid yet")
+	}
+	if !claims.Match(v.cfg.RequiredClaims) {
+		return errors.New("the required claims are missing")
+	}
+	return nil
+}
+
+// key returns the key of the kid, fetching the keys again when they are old or when the kid is unknown. An empty kid
+// is the only key of the JWKS.
+func (v *Verifier) key(ctx context.Context, kid string) (jose.JSONWebKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.cfg.Clock.Now()
+	key, found := v.lookup(kid)
+	fresh := now.Sub(v.fetchedAt) < keysTTL
+	if found && fresh {
+		return key, nil
+	}
+	if !found && fresh && now.Sub(v.lastRefresh) < keysMinRefresh {
+		return jose.JSONWebKey{}, fmt.Errorf("%w: unknown key <%s>", ErrInvalidToken, kid)
+	}
+
+	v.lastRefresh = now
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		if found {
+			// the identity provider is down, the known keys are still good
+			return key, nil
+		}
+		return jose.JSONWebKey{}, fmt.Errorf("reading the keys of the identity provider: %w", err)
+	}
+	v.keys, v.fetchedAt = keys, now
+	if key, found = v.lookup(kid); !found {
+		return jose.JSONWebKey{}, fmt.Errorf("%w: unknown key <%s>", ErrInvalidToken, kid)
+	}
+	return key, nil
+}
+
+func (v *Verifier) lookup(kid string) (jose.JSONWebKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, found := v.keys[kid]
+	return key, found
+}
+
+func (v *Verifier) fetchKeys(ctx context.Context) (map[string]jose.JSONWebKey, error) {
+	if v.jwksURL == "" {
+		var discovery struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := v.get(ctx, strings.TrimSuffix(v.cfg.Issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+			return nil, fmt.Errorf("discovery: %w", err)
+		}
+		if discovery.JWKSURI == "" {
+			return nil, errors.New("the discovery document has no jwks_uri")
+		}
+		v.jwksURL = discovery.JWKSURI
+	}
+
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := v.get(ctx, v.jwksURL, &jwks); err != nil {
+		return nil, fmt.Errorf("jwks: %w", err)
+	}
+	keys := make(map[string]jose.JSONWebKey)
+	for _, raw := range jwks.Keys {
+		// the keys of unsupported types are skipped, the tokens signed with them are rejected as unknown keys
+		var key jose.JSONWebKey
+		if err := key.UnmarshalJSON(raw); err != nil || !key.IsPublic() {
+			continue
+		}
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		keys[key.KeyID] = key
+	}
+	return keys, nil
+}
+
+func (v *Verifier) get(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := v.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,176 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/pkg/clock"
+)
+
+// provider is an identity provider that publishes an RSA and an EC key, and an RSA key too small to be trusted
+type provider struct {
+	server    *httptest.Server
+	rsaKey    *rsa.PrivateKey
+	weakKey   *rsa.PrivateKey
+	ecKey     *ecdsa.PrivateKey
+	jwksCalls int
+}
+
+func newProvider(t *testing.T) *provider {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p := &provider{rsaKey: rsaKey, weakKey: weakKey, ecKey: ecKey}
+
+	encode := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": p.server.URL, "jwks_uri": p.server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		p.jwksCalls++
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa", "use": "sig", "n": encode(rsaKey.N), "e": encode(big.NewInt(int64(rsaKey.E)))},
+			{"kty": "RSA", "kid": "weak", "use": "sig", "n": encode(weakKey.N), "e": encode(big.NewInt(int64(weakKey.E)))},
+			{"kty": "EC", "kid": "ec", "crv": "P-256", "x": encode(ecKey.X), "y": encode(ecKey.Y)},
+		}})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// token signs the claims with the key of kid
+func (p *provider) token(t *testing.T, kid string, claims map[string]any) string {
+	t.Helper()
+	return p.tokenWithAlg(t, map[string]string{"rsa": "RS256", "weak": "RS256", "other": "RS256", "ec": "ES256"}[kid], kid, claims)
+}
+
+// tokenWithAlg signs the claims with the key of kid, with alg in the header
+func (p *provider) tokenWithAlg(t *testing.T, alg string, kid string, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	if kid == "ec" {
+		r, s, err := ecdsa.Sign(rand.Reader, p.ecKey, digest[:])
+		require.NoError(t, err)
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	} else {
+		key := p.rsaKey
+		if kid == "weak" {
+			key = p.weakKey
+		}
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+	p := newProvider(t)
+	now := clock.NewFake(time.Now())
+	verifier := NewVerifier(Config{
+		Issuer:         p.server.URL,
+		Audience:       "issuer-node",
+		RequiredClaims: map[string]string{"groups": "issuer-admins"},
+		Clock:          now,
+	})
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"iss":    p.server.URL,
+			"sub":    "alice",
+			"aud":    []string{"issuer-node", "other"},
+			"exp":    now.Now().Add(time.Hour).Unix(),
+			"groups": []string{"staff", "issuer-admins"},
+		}
+		for name, value := range overrides {
+			c[name] = value
+		}
+		return c
+	}
+
+	t.Run("should accept the tokens signed with the keys of the provider", func(t *testing.T) {
+		for _, kid := range []string{"rsa", "ec"} {
+			verified, err := verifier.Verify(ctx, p.token(t, kid, claims(nil)))
+			require.NoError(t, err, kid)
+			assert.Equal(t, "alice", verified.Subject())
+		}
+		assert.Equal(t, 1, p.jwksCalls, "the keys are cached")
+	})
+
+	t.Run("should reject the invalid tokens", func(t *testing.T) {
+		for name, token := range map[string]string{
+			"other issuer":         p.token(t, "rsa", claims(map[string]any{"iss": "https://idp.example.com"})),
+			"other audience":       p.token(t, "rsa", claims(map[string]any{"aud": "other"})),
+			"expired":              p.token(t, "rsa", claims(map[string]any{"exp": now.Now().Add(-time.Hour).Unix()})),
+			"not valid yet":        p.token(t, "rsa", claims(map[string]any{"nbf": now.Now().Add(time.Hour).Unix()})),
+			"missing claim":        p.token(t, "rsa", claims(map[string]any{"groups": "staff"})),
+			"unknown key":          p.token(t, "other", claims(nil)),
+			"not a jwt":            "token",
+			"tampered claims":      tamper(p.token(t, "rsa", claims(nil)), p.token(t, "rsa", claims(map[string]any{"sub": "mallory"}))),
+			"unsigned":             base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + base64.RawURLEncoding.EncodeToString([]byte(`{}`)) + ".",
+			"no expiration claim":  p.token(t, "rsa", claims(map[string]any{"exp": nil})),
+			"small RSA key":        p.token(t, "weak", claims(nil)),
+			"curve of another alg": p.tokenWithAlg(t, "ES384", "ec", claims(nil)),
+			"RSA alg with EC key":  p.tokenWithAlg(t, "RS256", "ec", claims(nil)),
+			"HMAC":                 p.tokenWithAlg(t, "HS256", "rsa", claims(nil)),
+		} {
+			_, err := verifier.Verify(ctx, token)
+			assert.ErrorIs(t, err, ErrInvalidToken, name)
+		}
+	})
+
+	t.Run("should fetch the keys again after their ttl", func(t *testing.T) {
+		calls := p.jwksCalls
+		now.Advance(2 * time.Hour)
+		_, err := verifier.Verify(ctx, p.token(t, "rsa", claims(nil)))
+		require.NoError(t, err)
+		assert.Equal(t, calls+1, p.jwksCalls)
+	})
+}
+
+// tamper returns the token with the claims of other
+func tamper(token, other string) string {
+	parts, otherParts := strings.Split(token, "."), strings.Split(other, ".")
+	return parts[0] + "." + otherParts[1] + "." + parts[2]
+}
+
+func TestParseClaims(t *testing.T) {
+	claims, err := ParseClaims("groups=issuer-admins, email_verified=true,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"groups": "issuer-admins", "email_verified": "true"}, claims)
+	assert.True(t, Claims{"groups": []any{"issuer-admins"}, "email_verified": true}.Match(claims))
+	assert.True(t, Claims{"scope": "openid issuer:admin"}.Match(map[string]string{"scope": "issuer:admin"}))
+
+	_, err = ParseClaims("groups")
+	assert.Error(t, err)
+}
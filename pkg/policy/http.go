@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type httpHook struct {
+	url           string
+	authorization string
+	client        *http.Client
+}
+
+// NewHTTPHook returns a hook that POSTs the request as JSON to hookURL with transport, or with http.DefaultTransport
+// when it is nil, and reads the decision from the response body. If authorization is not empty it is sent as the
+// Authorization header.
+func NewHTTPHook(hookURL string, authorization string, timeout time.Duration, transport http.RoundTripper) (Hook, error) {
+	u, err := url.ParseRequestURI(hookURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid policy hook url <%s>", hookURL)
+	}
+	return &httpHook{
+		url:           hookURL,
+		authorization: authorization,
+		client:        &http.Client{Timeout: timeout, Transport: transport},
+	}, nil
+}
+
+// Evaluate asks the hook for a decision
+func (h *httpHook) Evaluate(ctx context.Context, req Request) (*Decision, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if h.authorization != "" {
+		httpReq.Header.Set("Authorization", h.authorization)
+	}
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("policy hook: unexpected status %d", resp.StatusCode)
+	}
+
+	decision := &Decision{}
+	if err := json.NewDecoder(resp.Body).Decode(decision); err != nil {
+		return nil, fmt.Errorf("policy hook: malformed decision: %w", err)
+	}
+	if err := decision.Validate(); err != nil {
+		return nil, fmt.Errorf("policy hook: %w <%s>", err, decision.Decision)
+	}
+	return decision, nil
+}
@@ -0,0 +1,71 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHook(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch req.Type {
+		case "KYCAgeCredential":
+			_, _ = w.Write([]byte(`{"decision": "approve", "credentialSubject": {"kycLevel": 2}}`))
+		case "KYCCountryOfResidenceCredential":
+			_, _ = w.Write([]byte(`{"decision": "deny", "reason": "the subject didn't pass the KYC"}`))
+		case "Unknown":
+			_, _ = w.Write([]byte(`{"decision": "maybe"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	hook, err := NewHTTPHook(server.URL, "Bearer token", time.Second, nil)
+	require.NoError(t, err)
+
+	request := func(credentialType string) Request {
+		return Request{
+			Issuer:            "did:polygonid:polygon:mumbai:2qKZg1vCMwJeMzvVayn9ebUHpnD6QCxTgk6T28THxy",
+			Subject:           "did:polygonid:polygon:mumbai:2qFrLQA6R1bfUTxjRnZEN9st77g6ZN2c7Vw1Dq6Vpp",
+			Schema:            "https://example.com/schema.json",
+			Type:              credentialType,
+			CredentialSubject: map[string]any{"birthday": 19960424},
+		}
+	}
+
+	decision, err := hook.Evaluate(ctx, request("KYCAgeCredential"))
+	require.NoError(t, err)
+	assert.Equal(t, Approve, decision.Decision)
+	assert.Equal(t, map[string]any{"kycLevel": float64(2)}, decision.CredentialSubject)
+
+	decision, err = hook.Evaluate(ctx, request("KYCCountryOfResidenceCredential"))
+	require.NoError(t, err)
+	assert.Equal(t, Deny, decision.Decision)
+	assert.Equal(t, "the subject didn't pass the KYC", decision.Reason)
+
+	_, err = hook.Evaluate(ctx, request("Unknown"))
+	assert.ErrorIs(t, err, ErrInvalidDecision)
+
+	_, err = hook.Evaluate(ctx, request("Other"))
+	assert.Error(t, err)
+
+	_, err = NewHTTPHook("hook.example.com", "", time.Second, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Decisions of a policy hook
+const (
+	Approve = "approve" // Approve the credential is issued, with the changes of the decision if any
+	Deny    = "deny"    // Deny the credential is not issued
+)
+
+// ErrInvalidDecision is returned when the hook answers something other than approve or deny
+var ErrInvalidDecision = errors.New("invalid policy decision")
+
+// Request is the issuance a hook evaluates
+type Request struct {
+	Issuer            string         `json:"issuer"`
+	Subject           string         `json:"subject,omitempty"`
+	Schema            string         `json:"schema"`
+	Type              string         `json:"type"`
+	CredentialSubject map[string]any `json:"credentialSubject"`
+	Expiration        *time.Time     `json:"expiration,omitempty"`
+	LinkID            string         `json:"linkId,omitempty"`
+}
+
+// Decision is the answer of a hook. An approval can change the credential: the CredentialSubject attributes are
+// set in the credential subject, a null value removes the attribute, and Expiration replaces the expiration.
+type Decision struct {
+	Decision          string         `json:"decision"`
+	Reason            string         `json:"reason,omitempty"`
+	CredentialSubject map[string]any `json:"credentialSubject,omitempty"`
+	Expiration        *time.Time     `json:"expiration,omitempty"`
+}
+
+// Validate checks that the decision is approve or deny
+func (d *Decision) Validate() error {
+	if d.Decision != Approve && d.Decision != Deny {
+		return ErrInvalidDecision
+	}
+	return nil
+}
+
+// Hook decides whether a credential can be issued, e.g. after checking the KYC or the entitlements of the subject
+type Hook interface {
+	Evaluate(ctx context.Context, req Request) (*Decision, error)
+}
+
+// HookFunc adapts a function to the Hook interface, e.g. to embed a policy in a program that uses the issuer as a library
+type HookFunc func(ctx context.Context, req Request) (*Decision, error)
+
+// Evaluate calls f
+func (f HookFunc) Evaluate(ctx context.Context, req Request) (*Decision, error) {
+	return f(ctx, req)
+}
@@ -2,54 +2,141 @@ package protocol
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
-	"github.com/iden3/contracts-abi/state/go/abi"
 	"github.com/iden3/go-circuits"
+	"github.com/iden3/go-iden3-auth/pubsignals"
 	core "github.com/iden3/go-iden3-core"
 	"github.com/iden3/go-jwz"
 	"github.com/iden3/iden3comm"
 	"github.com/iden3/iden3comm/packers"
 
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
 	"github.com/polygonid/sh-id-platform/pkg/loaders"
 )
 
-// InitPackageManager initializes the iden3comm package manager
-func InitPackageManager(ctx context.Context, stateContract *abi.State, zkProofService ports.ProofService, circuitsPath string) (*iden3comm.PackageManager, error) {
-	circuitsLoaderService := loaders.NewCircuits(circuitsPath)
+var (
+	// ErrUnsupportedCircuit is returned when the configuration allows a circuit the node can't verify
+	ErrUnsupportedCircuit = errors.New("unsupported circuit")
+	// ErrUnsupportedMediaType is returned when the configuration allows a packer the node doesn't have
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	// ErrNoCircuits is returned when zero knowledge packed messages are allowed without any circuit
+	ErrNoCircuits = errors.New("zero knowledge packed messages need at least one circuit")
+)
+
+// supportedCircuits are the authentication circuits the node can verify, with their proving method
+var supportedCircuits = map[circuits.CircuitID]jwz.ProvingMethodAlg{
+	circuits.AuthV2CircuitID: jwz.AuthV2Groth16Alg,
+}
+
+// Config selects the circuits and packers accepted in the incoming iden3comm messages
+type Config struct {
+	Circuits   []circuits.CircuitID
+	MediaTypes []iden3comm.MediaType
+	// Clock is the time source of the proof state checks, the system clock when nil
+	Clock clock.Clock
+	// ClockSkew is tolerated in the age of the global state a proof was generated with
+	ClockSkew time.Duration
+	// Resolvers resolve the global state roots of the proofs by network, keyed by blockchain:network, and can cache
+	// them. The networks without one read them from their state contract.
+	Resolvers map[string]pubsignals.StateResolver
+}
+
+// DefaultConfig accepts every supported circuit and packer. The signed messages are opt-in, as they are only
+// verified for the identities with secp256k1 signing keys.
+var DefaultConfig = Config{
+	Circuits:   []circuits.CircuitID{circuits.AuthV2CircuitID},
+	MediaTypes: []iden3comm.MediaType{packers.MediaTypeZKPMessage, packers.MediaTypePlainMessage},
+}
 
-	authV2Set, err := circuitsLoaderService.Load(circuits.AuthV2CircuitID)
-	if err != nil {
-		return nil, fmt.Errorf("failed upload circuits files: %w", err)
+// NewConfig returns the configuration for the given circuit ids and media types. Empty lists take the defaults.
+func NewConfig(circuitIDs []string, mediaTypes []string) (Config, error) {
+	cfg := Config{Circuits: DefaultConfig.Circuits, MediaTypes: DefaultConfig.MediaTypes}
+	if len(circuitIDs) > 0 {
+		cfg.Circuits = make([]circuits.CircuitID, len(circuitIDs))
+		for i, id := range circuitIDs {
+			cfg.Circuits[i] = circuits.CircuitID(id)
+		}
+	}
+	if len(mediaTypes) > 0 {
+		cfg.MediaTypes = make([]iden3comm.MediaType, len(mediaTypes))
+		for i, mediaType := range mediaTypes {
+			cfg.MediaTypes[i] = iden3comm.MediaType(mediaType)
+		}
 	}
+	return cfg, cfg.Validate()
+}
 
-	provers := make(map[jwz.ProvingMethodAlg]packers.ProvingParams)
-	pParams := packers.ProvingParams{
-		DataPreparer: prepareAuthInputs(ctx, zkProofService),
-		ProvingKey:   authV2Set.ProofKey,
-		Wasm:         authV2Set.Wasm,
+// Validate checks that the node supports every allowed circuit and packer
+func (c Config) Validate() error {
+	for _, id := range c.Circuits {
+		if _, ok := supportedCircuits[id]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnsupportedCircuit, id)
+		}
+	}
+	for _, mediaType := range c.MediaTypes {
+		if mediaType != packers.MediaTypeZKPMessage && mediaType != packers.MediaTypePlainMessage && mediaType != MediaTypeSignedMessage {
+			return fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mediaType)
+		}
 	}
-	provers[jwz.AuthV2Groth16Alg] = pParams
+	if c.Accepts(packers.MediaTypeZKPMessage) && len(c.Circuits) == 0 {
+		return ErrNoCircuits
+	}
+	return nil
+}
 
-	verifications := make(map[jwz.ProvingMethodAlg]packers.VerificationParams)
-	verifications[jwz.AuthV2Groth16Alg] = packers.NewVerificationParams(authV2Set.VerificationKey,
-		stateVerificationHandler(stateContract))
+// Accepts tells whether messages packed with the given media type are allowed
+func (c Config) Accepts(mediaType iden3comm.MediaType) bool {
+	for _, allowed := range c.MediaTypes {
+		if allowed == mediaType {
+			return true
+		}
+	}
+	return false
+}
 
-	zkpPackerV2 := packers.NewZKPPacker(
-		provers,
-		verifications,
-	)
+// InitPackageManager initializes the iden3comm package manager with the packers and circuits allowed by cfg. The
+// signed messages have no packer, they are unpacked by SignedMessages.
+func InitPackageManager(ctx context.Context, stateContracts ports.StateContracts, zkProofService ports.ProofService, circuitsPath string, cfg Config) (*iden3comm.PackageManager, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 
-	packageManager := iden3comm.NewPackageManager()
+	var allowedPackers []iden3comm.Packer
+	if cfg.Accepts(packers.MediaTypeZKPMessage) {
+		circuitsLoaderService := loaders.NewCircuits(circuitsPath)
+		provers := make(map[jwz.ProvingMethodAlg]packers.ProvingParams)
+		verifications := make(map[jwz.ProvingMethodAlg]packers.VerificationParams)
+		for _, circuitID := range cfg.Circuits {
+			circuitSet, err := circuitsLoaderService.Load(circuitID)
+			if err != nil {
+				return nil, fmt.Errorf("failed upload circuits files: %w", err)
+			}
+
+			alg := supportedCircuits[circuitID]
+			provers[alg] = packers.ProvingParams{
+				DataPreparer: prepareAuthInputs(ctx, zkProofService),
+				ProvingKey:   circuitSet.ProofKey,
+				Wasm:         circuitSet.Wasm,
+			}
+			verifications[alg] = packers.NewVerificationParams(circuitSet.VerificationKey, stateVerificationHandler(ctx, stateContracts, cfg.Resolvers, clock.OrSystem(cfg.Clock), cfg.ClockSkew))
+		}
+		allowedPackers = append(allowedPackers, packers.NewZKPPacker(provers, verifications))
+	}
+	if cfg.Accepts(packers.MediaTypePlainMessage) {
+		allowedPackers = append(allowedPackers, &packers.PlainMessagePacker{})
+	}
 
-	err = packageManager.RegisterPackers(zkpPackerV2, &packers.PlainMessagePacker{})
-	if err != nil {
+	packageManager := iden3comm.NewPackageManager()
+	if err := packageManager.RegisterPackers(allowedPackers...); err != nil {
 		return nil, err
 	}
 
-	return packageManager, err
+	return packageManager, nil
 }
 
 func prepareAuthInputs(ctx context.Context, proofService ports.ProofService) packers.DataPreparerHandlerFunc {
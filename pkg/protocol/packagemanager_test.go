@@ -0,0 +1,40 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/iden3/go-circuits"
+	"github.com/iden3/iden3comm"
+	"github.com/iden3/iden3comm/packers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	cfg, err := NewConfig(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConfig, cfg)
+
+	cfg, err = NewConfig([]string{"authV2"}, []string{string(packers.MediaTypeZKPMessage)})
+	require.NoError(t, err)
+	assert.Equal(t, []circuits.CircuitID{circuits.AuthV2CircuitID}, cfg.Circuits)
+	assert.True(t, cfg.Accepts(packers.MediaTypeZKPMessage))
+	assert.False(t, cfg.Accepts(packers.MediaTypePlainMessage))
+
+	_, err = NewConfig([]string{"auth"}, nil)
+	assert.ErrorIs(t, err, ErrUnsupportedCircuit)
+
+	_, err = NewConfig(nil, []string{"application/iden3comm-encrypted-json"})
+	assert.ErrorIs(t, err, ErrUnsupportedMediaType)
+
+	err = Config{MediaTypes: []iden3comm.MediaType{packers.MediaTypeZKPMessage}}.Validate()
+	assert.ErrorIs(t, err, ErrNoCircuits)
+
+	err = Config{MediaTypes: []iden3comm.MediaType{packers.MediaTypePlainMessage}}.Validate()
+	assert.NoError(t, err)
+
+	cfg, err = NewConfig(nil, []string{string(packers.MediaTypeZKPMessage), string(MediaTypeSignedMessage)})
+	require.NoError(t, err)
+	assert.True(t, cfg.Accepts(MediaTypeSignedMessage))
+	assert.False(t, DefaultConfig.Accepts(MediaTypeSignedMessage))
+}
@@ -0,0 +1,130 @@
+package protocol
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/iden3comm"
+)
+
+const (
+	// MediaTypeSignedMessage is the media type of the iden3comm messages signed in a JWS
+	MediaTypeSignedMessage iden3comm.MediaType = "application/iden3comm-signed-json"
+	// ES256KR is the algorithm of the signed messages: a secp256k1 signature of the SHA-256 hash of the signing input,
+	// in the [R || S || V] format, that recovers the key of the sender
+	ES256KR = "ES256K-R"
+)
+
+// ErrInvalidSignedMessage is returned when a signed message is malformed, or not signed by a key of its sender
+var ErrInvalidSignedMessage = errors.New("invalid signed message")
+
+// SignersFunc returns the Ethereum addresses of the secp256k1 keys that sign the messages of the identity
+type SignersFunc func(ctx context.Context, did core.DID) ([]ethcommon.Address, error)
+
+// SignedMessages unpacks the messages signed with the secp256k1 keys of their senders. The iden3comm package manager
+// only has the zero knowledge and plain packers, so the signed messages are verified here. A sender is only known by
+// the signers of its identity, that is, the identities of the node with secp256k1 signing keys.
+type SignedMessages struct {
+	signers SignersFunc
+}
+
+// NewSignedMessages returns the unpacker of the signed messages, whose senders sign with the keys of signers
+func NewSignedMessages(signers SignersFunc) *SignedMessages {
+	return &SignedMessages{signers: signers}
+}
+
+type signedMessageHeader struct {
+	Alg string              `json:"alg"`
+	Kid string              `json:"kid"`
+	Typ iden3comm.MediaType `json:"typ"`
+}
+
+// IsSignedMessage tells whether the envelope is a JWS with the media type of the signed messages
+func IsSignedMessage(envelope []byte) bool {
+	parts := strings.Split(string(envelope), ".")
+	if len(parts) != 3 {
+		return false
+	}
+	header, err := decodeSignedMessageHeader(parts[0])
+	return err == nil && header.Typ == MediaTypeSignedMessage
+}
+
+// Unpack verifies the signature of the message in the compact JWS envelope, and returns the message. The kid of the
+// header is a key of the from DID of the message, and the key that signed it has to be one of the signers of the from
+// DID.
+func (m *SignedMessages) Unpack(ctx context.Context, envelope []byte) (*iden3comm.BasicMessage, error) {
+	parts := strings.Split(string(envelope), ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a compact JWS", ErrInvalidSignedMessage)
+	}
+	header, err := decodeSignedMessageHeader(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	if header.Typ != MediaTypeSignedMessage {
+		return nil, fmt.Errorf("%w: unexpected media type %s", ErrInvalidSignedMessage, header.Typ)
+	}
+	if header.Alg != ES256KR {
+		return nil, fmt.Errorf("%w: unsupported algorithm %s", ErrInvalidSignedMessage, header.Alg)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSignedMessage, err)
+	}
+	var msg iden3comm.BasicMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSignedMessage, err)
+	}
+	if kidDID, _, _ := strings.Cut(header.Kid, "#"); kidDID != msg.From {
+		return nil, fmt.Errorf("%w: the key %s is not of the sender", ErrInvalidSignedMessage, header.Kid)
+	}
+	from, err := core.ParseDID(msg.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid sender: %s", ErrInvalidSignedMessage, err)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidSignedMessage)
+	}
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	pubKey, err := crypto.SigToPub(digest[:], sig)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSignedMessage, err)
+	}
+	signer := crypto.PubkeyToAddress(*pubKey)
+
+	signers, err := m.signers(ctx, *from)
+	if err != nil {
+		return nil, err
+	}
+	for _, address := range signers {
+		if address == signer {
+			return &msg, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s is not a signer of %s", ErrInvalidSignedMessage, signer.Hex(), msg.From)
+}
+
+func decodeSignedMessageHeader(encoded string) (signedMessageHeader, error) {
+	var header signedMessageHeader
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return header, fmt.Errorf("%w: %s", ErrInvalidSignedMessage, err)
+	}
+	if err := json.Unmarshal(b, &header); err != nil {
+		return header, fmt.Errorf("%w: %s", ErrInvalidSignedMessage, err)
+	}
+	return header, nil
+}
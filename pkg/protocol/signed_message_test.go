@@ -0,0 +1,96 @@
+package protocol
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const signedMessageSender = "did:polygonid:polygon:mumbai:2qFpPHotk6oyaX1fcrpQFT4BMnmg8YszUwxYtaoGoe"
+
+func signMessage(t *testing.T, header map[string]string, msg map[string]any, recoveryOffset byte) []byte {
+	t.Helper()
+	key, err := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	require.NoError(t, err)
+	h, err := json.Marshal(header)
+	require.NoError(t, err)
+	p, err := json.Marshal(msg)
+	require.NoError(t, err)
+	input := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(p)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := crypto.Sign(digest[:], key)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += recoveryOffset
+	return []byte(input + "." + base64.RawURLEncoding.EncodeToString(sig))
+}
+
+func TestSignedMessages_Unpack(t *testing.T) {
+	key, err := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	signedMessages := NewSignedMessages(func(_ context.Context, did core.DID) ([]ethcommon.Address, error) {
+		if did.String() != signedMessageSender {
+			return nil, nil
+		}
+		return []ethcommon.Address{signer}, nil
+	})
+
+	header := map[string]string{"alg": ES256KR, "kid": signedMessageSender + "#secp256k1", "typ": string(MediaTypeSignedMessage)}
+	msg := map[string]any{
+		"id":   "b6d7f9b4-2ff2-4c1c-a2ce-f1e5d8d4b0a3",
+		"type": "https://iden3-communication.io/credentials/1.0/fetch-request",
+		"from": signedMessageSender,
+		"to":   "did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5",
+	}
+
+	t.Run("signed by a signer of the sender", func(t *testing.T) {
+		for _, offset := range []byte{0, 27} {
+			envelope := signMessage(t, header, msg, offset)
+			assert.True(t, IsSignedMessage(envelope))
+			unpacked, err := signedMessages.Unpack(context.Background(), envelope)
+			require.NoError(t, err)
+			assert.Equal(t, signedMessageSender, unpacked.From)
+		}
+	})
+
+	t.Run("not a signed message", func(t *testing.T) {
+		assert.False(t, IsSignedMessage([]byte(`{"id":"1"}`)))
+		_, err := signedMessages.Unpack(context.Background(), []byte(`{"id":"1"}`))
+		assert.ErrorIs(t, err, ErrInvalidSignedMessage)
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		envelope := signMessage(t, map[string]string{"alg": "ES256K", "kid": header["kid"], "typ": header["typ"]}, msg, 0)
+		_, err := signedMessages.Unpack(context.Background(), envelope)
+		assert.ErrorIs(t, err, ErrInvalidSignedMessage)
+	})
+
+	t.Run("key of another identity", func(t *testing.T) {
+		envelope := signMessage(t, map[string]string{"alg": ES256KR, "kid": msg["to"].(string) + "#secp256k1", "typ": header["typ"]}, msg, 0)
+		_, err := signedMessages.Unpack(context.Background(), envelope)
+		assert.ErrorIs(t, err, ErrInvalidSignedMessage)
+	})
+
+	t.Run("not a signer of the sender", func(t *testing.T) {
+		other := map[string]any{"id": msg["id"], "type": msg["type"], "from": msg["to"], "to": msg["from"]}
+		envelope := signMessage(t, map[string]string{"alg": ES256KR, "kid": msg["to"].(string) + "#secp256k1", "typ": header["typ"]}, other, 0)
+		_, err := signedMessages.Unpack(context.Background(), envelope)
+		assert.ErrorIs(t, err, ErrInvalidSignedMessage)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		envelope := signMessage(t, header, msg, 0)
+		tampered := signMessage(t, header, map[string]any{"id": msg["id"], "type": msg["type"], "from": msg["from"], "to": msg["from"]}, 0)
+		envelope = append(tampered[:len(tampered)-88], envelope[len(envelope)-88:]...)
+		_, err := signedMessages.Unpack(context.Background(), envelope)
+		assert.ErrorIs(t, err, ErrInvalidSignedMessage)
+	})
+}
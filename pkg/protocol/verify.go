@@ -1,35 +1,48 @@
 package protocol
 
 import (
+	"context"
 	"encoding/json"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/iden3/contracts-abi/state/go/abi"
 	"github.com/iden3/go-circuits"
+	"github.com/iden3/go-iden3-auth/pubsignals"
+	core "github.com/iden3/go-iden3-core"
 	"github.com/iden3/iden3comm/packers"
 	"github.com/pkg/errors"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/pkg/clock"
 )
 
+// gistRootMaxAge is how long after being replaced a global state root is still accepted in a proof
+const gistRootMaxAge = 15 * time.Minute
+
 // ErrStateNotFound issuer state is genesis state.
 var (
 	ErrStateNotFound = errors.New("Identity does not exist")
 )
 
-func stateVerificationHandler(ethStateContract *abi.State) packers.VerificationHandlerFunc {
+// stateVerificationHandler checks the states of the proofs of the messages. The packers don't pass a context to the
+// handler, so the calls to the resolvers and the state contracts are made with ctx, like the inputs of the proofs.
+func stateVerificationHandler(ctx context.Context, stateContracts ports.StateContracts, resolvers map[string]pubsignals.StateResolver, clk clock.Clock, skew time.Duration) packers.VerificationHandlerFunc {
 	return func(id circuits.CircuitID, pubsignals []string) error {
 		switch id {
 		case circuits.AuthV2CircuitID:
-			return authV2CircuitStateVerification(ethStateContract, pubsignals)
+			return authV2CircuitStateVerification(ctx, stateContracts, resolvers, pubsignals, clk, skew)
 		default:
 			return errors.Errorf("'%s' unknow circuit ID", id)
 		}
 	}
 }
 
-// authV2CircuitStateVerification `authV2` circuit state verification
-func authV2CircuitStateVerification(contract *abi.State, pubsignals []string) error {
+// authV2CircuitStateVerification `authV2` circuit state verification. The global state the wallet generated the
+// proof with can have been replaced up to gistRootMaxAge plus the clock skew ago. It is checked in the state contract of
+// the network of the wallet, through its resolver when there is one.
+func authV2CircuitStateVerification(ctx context.Context, stateContracts ports.StateContracts, resolvers map[string]pubsignals.StateResolver, pubsignals []string, clk clock.Clock, skew time.Duration) error {
 	bytePubsig, err := json.Marshal(pubsignals)
 	if err != nil {
 		return err
@@ -41,8 +54,28 @@ func authV2CircuitStateVerification(contract *abi.State, pubsignals []string) er
 		return err
 	}
 
+	userDID, err := core.ParseDIDFromID(*authPubSignals.UserID)
+	if err != nil {
+		return err
+	}
 	globalState := authPubSignals.GISTRoot.BigInt()
-	globalStateInfo, err := contract.GetGISTRootInfo(&bind.CallOpts{}, globalState)
+	if resolver, ok := resolvers[common.DIDNetwork(userDID)]; ok {
+		resolved, err := resolver.ResolveGlobalRoot(ctx, globalState)
+		if err != nil {
+			return err
+		}
+		replacedAt := time.Unix(resolved.TransitionTimestamp, 0)
+		if !resolved.Latest && clock.Passed(clk, replacedAt.Add(gistRootMaxAge), skew) {
+			return errors.Errorf("global state is too old, replaced timestamp is %v", resolved.TransitionTimestamp)
+		}
+		return nil
+	}
+
+	contract, err := stateContracts.StateContract(userDID)
+	if err != nil {
+		return err
+	}
+	globalStateInfo, err := contract.GetGISTRootInfo(&bind.CallOpts{Context: ctx}, globalState)
 	if err != nil {
 		return err
 	}
@@ -53,7 +86,8 @@ func authV2CircuitStateVerification(contract *abi.State, pubsignals []string) er
 		return errors.Errorf("invalid global state info in the smart contract, expected root %s, got %s", globalState.String(), globalStateInfo.Root.String())
 	}
 
-	if (big.NewInt(0)).Cmp(globalStateInfo.ReplacedByRoot) != 0 && time.Since(time.Unix(globalStateInfo.ReplacedAtTimestamp.Int64(), 0)) > time.Minute*15 {
+	replacedAt := time.Unix(globalStateInfo.ReplacedAtTimestamp.Int64(), 0)
+	if (big.NewInt(0)).Cmp(globalStateInfo.ReplacedByRoot) != 0 && clock.Passed(clk, replacedAt.Add(gistRootMaxAge), skew) {
 		return errors.Errorf("global state is too old, replaced timestamp is %v", globalStateInfo.ReplacedAtTimestamp.Int64())
 	}
 
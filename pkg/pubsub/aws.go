@@ -0,0 +1,123 @@
+package pubsub
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+const (
+	sqsAPIVersion = "2012-11-05"
+	snsAPIVersion = "2010-03-31"
+)
+
+// awsSink sends the events with the AWS query API, signing the requests with the signature version 4 signer of the
+// AWS SDK
+type awsSink struct {
+	endpoint    string
+	service     string
+	region      string
+	credentials aws.Credentials
+	signer      *v4.Signer
+	params      func(topic string, msg Message) url.Values
+	client      *http.Client
+	now         func() time.Time
+}
+
+// NewSQSSink returns a sink that sends each event as a message of the SQS queue, with the topic in the topic
+// message attribute. The requests are sent with transport, or with http.DefaultTransport when it is nil.
+func NewSQSSink(queueURL, region, keyID, key string, transport http.RoundTripper) (Sink, error) {
+	u, err := url.ParseRequestURI(queueURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid sqs queue url <%s>", queueURL)
+	}
+	if err := guardAWSCredentials(region, keyID, key); err != nil {
+		return nil, err
+	}
+	return &awsSink{
+		endpoint:    queueURL,
+		service:     "sqs",
+		region:      region,
+		credentials: aws.Credentials{AccessKeyID: keyID, SecretAccessKey: key},
+		signer:      v4.NewSigner(),
+		params: func(topic string, msg Message) url.Values {
+			return url.Values{
+				"Action":                               {"SendMessage"},
+				"Version":                              {sqsAPIVersion},
+				"MessageBody":                          {string(msg)},
+				"MessageAttribute.1.Name":              {topicAttribute},
+				"MessageAttribute.1.Value.DataType":    {"String"},
+				"MessageAttribute.1.Value.StringValue": {topic},
+			}
+		},
+		client: &http.Client{Timeout: sinkTimeout, Transport: transport},
+		now:    time.Now,
+	}, nil
+}
+
+// NewSNSSink returns a sink that publishes each event in the SNS topic, with the issuer topic in the topic message
+// attribute. The requests are sent with transport, or with http.DefaultTransport when it is nil.
+func NewSNSSink(topicARN, region, keyID, key string, transport http.RoundTripper) (Sink, error) {
+	if !strings.HasPrefix(topicARN, "arn:") {
+		return nil, fmt.Errorf("invalid sns topic arn <%s>", topicARN)
+	}
+	if err := guardAWSCredentials(region, keyID, key); err != nil {
+		return nil, err
+	}
+	return &awsSink{
+		endpoint:    fmt.Sprintf("https://sns.%s.amazonaws.com/", region),
+		service:     "sns",
+		region:      region,
+		credentials: aws.Credentials{AccessKeyID: keyID, SecretAccessKey: key},
+		signer:      v4.NewSigner(),
+		params: func(topic string, msg Message) url.Values {
+			return url.Values{
+				"Action":                         {"Publish"},
+				"Version":                        {snsAPIVersion},
+				"TopicArn":                       {topicARN},
+				"Message":                        {string(msg)},
+				"MessageAttributes.entry.1.Name": {topicAttribute},
+				"MessageAttributes.entry.1.Value.DataType":    {"String"},
+				"MessageAttributes.entry.1.Value.StringValue": {topic},
+			}
+		},
+		client: &http.Client{Timeout: sinkTimeout, Transport: transport},
+		now:    time.Now,
+	}, nil
+}
+
+func guardAWSCredentials(region, keyID, key string) error {
+	if region == "" || keyID == "" || key == "" {
+		return errors.New("the aws region, access key id and secret access key are required")
+	}
+	return nil
+}
+
+// Send sends the event
+func (s *awsSink) Send(ctx context.Context, topic string, msg Message) error {
+	body := []byte(s.params(topic, msg).Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	payloadHash := sha256.Sum256(body)
+	err = s.signer.SignHTTP(ctx, s.credentials, req, hex.EncodeToString(payloadHash[:]), s.service, s.region, s.now())
+	if err != nil {
+		return err
+	}
+	if err := doSinkRequest(s.client, req); err != nil {
+		return fmt.Errorf("sending the event to %s: %w", s.service, err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package pubsub
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sasTokenValidity is the validity of the shared access signature of each Service Bus request
+const sasTokenValidity = time.Hour
+
+// serviceBusSink sends the events with the Service Bus REST API, authenticated with a shared access signature
+type serviceBusSink struct {
+	entityURL string
+	keyName   string
+	key       string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewServiceBusSink returns a sink that sends each event to the Service Bus queue or topic, with the issuer topic as
+// the message label and the topic custom property. entityURL is https://<namespace>.servicebus.windows.net/<entity>
+// and keyName and key are a shared access policy of the namespace or of the entity with the Send right. The requests
+// are sent with transport, or with http.DefaultTransport when it is nil.
+func NewServiceBusSink(entityURL, keyName, key string, transport http.RoundTripper) (Sink, error) {
+	u, err := url.ParseRequestURI(entityURL)
+	if err != nil || u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return nil, fmt.Errorf("invalid service bus queue or topic url <%s>", entityURL)
+	}
+	if keyName == "" || key == "" {
+		return nil, errors.New("the service bus shared access key name and key are required")
+	}
+	return &serviceBusSink{
+		entityURL: strings.TrimSuffix(entityURL, "/"),
+		keyName:   keyName,
+		key:       key,
+		client:    &http.Client{Timeout: sinkTimeout, Transport: transport},
+		now:       time.Now,
+	}, nil
+}
+
+// Send sends the event
+func (s *serviceBusSink) Send(ctx context.Context, topic string, msg Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.entityURL+"/messages", bytes.NewReader(msg))
+	if err != nil {
+		return err
+	}
+	brokerProperties, err := json.Marshal(map[string]string{"Label": topic})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", s.sasToken())
+	req.Header.Set("BrokerProperties", string(brokerProperties))
+	req.Header.Set(topicAttribute, strconv.Quote(topic))
+	if err := doSinkRequest(s.client, req); err != nil {
+		return fmt.Errorf("sending the event to service bus: %w", err)
+	}
+	return nil
+}
+
+// sasToken returns the shared access signature of the entity
+func (s *serviceBusSink) sasToken() string {
+	resource := url.QueryEscape(strings.ToLower(s.entityURL))
+	expiry := strconv.FormatInt(s.now().Add(sasTokenValidity).Unix(), 10)
+	signature := base64.StdEncoding.EncodeToString(hmacSHA256([]byte(s.key), resource+"\n"+expiry))
+	return fmt.Sprintf("SharedAccessSignature sr=%s&sig=%s&se=%s&skn=%s", resource, url.QueryEscape(signature), expiry, s.keyName)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
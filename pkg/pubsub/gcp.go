@@ -0,0 +1,81 @@
+package pubsub
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	pubSubEndpoint = "https://pubsub.googleapis.com/v1/"
+	pubSubScope    = "https://www.googleapis.com/auth/pubsub"
+)
+
+var pubSubTopicRegex = regexp.MustCompile(`^projects/[^/]+/topics/[^/]+$`)
+
+// pubSubSink publishes the events with the Pub/Sub REST API. It authenticates with access tokens of a service account.
+type pubSubSink struct {
+	publishURL string
+	tokens     oauth2.TokenSource
+	client     *http.Client
+}
+
+// NewPubSubSink returns a sink that publishes each event in the Pub/Sub topic, with the issuer topic in the topic
+// message attribute. The topic is projects/<project>/topics/<topic> and credentialsFile is a service account key file.
+// The requests, and the ones for the access tokens, are sent with transport, or with http.DefaultTransport when it is
+// nil.
+func NewPubSubSink(topic string, credentialsFile string, transport http.RoundTripper) (Sink, error) {
+	if !pubSubTopicRegex.MatchString(topic) {
+		return nil, fmt.Errorf("invalid pubsub topic <%s>, it must be projects/<project>/topics/<topic>", topic)
+	}
+	content, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading the pubsub credentials file: %w", err)
+	}
+	client := &http.Client{Timeout: sinkTimeout, Transport: transport}
+	credentials, err := google.CredentialsFromJSON(context.WithValue(context.Background(), oauth2.HTTPClient, client), content, pubSubScope)
+	if err != nil {
+		return nil, fmt.Errorf("pubsub credentials file: %w", err)
+	}
+	return &pubSubSink{
+		publishURL: pubSubEndpoint + topic + ":publish",
+		tokens:     credentials.TokenSource,
+		client:     client,
+	}, nil
+}
+
+// Send publishes the event
+func (s *pubSubSink) Send(ctx context.Context, topic string, msg Message) error {
+	token, err := s.tokens.Token()
+	if err != nil {
+		return fmt.Errorf("getting the pubsub access token: %w", err)
+	}
+	type pubSubMessage struct {
+		Data       string            `json:"data"`
+		Attributes map[string]string `json:"attributes"`
+	}
+	body, err := json.Marshal(map[string][]pubSubMessage{
+		"messages": {{Data: base64.StdEncoding.EncodeToString(msg), Attributes: map[string]string{topicAttribute: topic}}},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.publishURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token.SetAuthHeader(req)
+	if err := doSinkRequest(s.client, req); err != nil {
+		return fmt.Errorf("publishing the event in pubsub: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// postgresReconnectDelay is how long a subscriber waits to listen again after its connection failed
+const postgresReconnectDelay = 5 * time.Second
+
+// PostgresClient is a pubsub on the LISTEN/NOTIFY of Postgres, for the nodes that run without redis. Like the redis
+// pubsub the events are not stored, so a subscriber only receives the ones published while it listens. Each
+// subscription opens its own connection, outside of the pool, and the payload of an event must be shorter than 8000
+// bytes.
+type PostgresClient struct {
+	conn *pgxpool.Pool
+	log  logger
+}
+
+// NewPostgres returns a postgres pubsub client
+func NewPostgres(conn *pgxpool.Pool) *PostgresClient {
+	return &PostgresClient{conn: conn, log: func(ctx context.Context, msg string, args ...any) {}}
+}
+
+// WithLogger inject a function log that will be used from now on to log errors.
+func (c *PostgresClient) WithLogger(logFn logger) {
+	c.log = logFn
+}
+
+// Publish notifies the listeners of the topic
+func (c *PostgresClient) Publish(ctx context.Context, topic string, event Event) error {
+	msg, err := event.Marshal()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(payload{
+		ID:   uuid.New(),
+		Time: time.Now(),
+		Msg:  []byte(msg),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.conn.Exec(ctx, `SELECT pg_notify($1, $2)`, topic, string(data))
+	return err
+}
+
+// Subscribe listens to the topic until the context is done, and listens again when the connection fails
+func (c *PostgresClient) Subscribe(ctx context.Context, topic string, callback EventHandler) {
+	go func() {
+		for {
+			if err := c.listen(ctx, topic, callback); err != nil && ctx.Err() == nil {
+				c.log(ctx, "postgres pubsub: listening to the topic", "err", err, "topic", topic)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(postgresReconnectDelay):
+			}
+		}
+	}()
+}
+
+// listen opens a connection with the settings of the pool that listens to the topic until it fails or the context is
+// done
+func (c *PostgresClient) listen(ctx context.Context, topic string, callback EventHandler) error {
+	conn, err := pgx.ConnectConfig(ctx, c.conn.Config().ConnConfig.Copy())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{topic}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var payload payload
+		if err := json.Unmarshal([]byte(notification.Payload), &payload); err != nil {
+			c.log(ctx, "postgres pubsub: unmarshalling payload event")
+			continue
+		}
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					c.log(ctx, "panic in event handler", "r", r, "topic", topic)
+				}
+			}()
+			if err := callback(ctx, payload.Msg); err != nil {
+				c.log(ctx, "executing callback function", "err", err, "topic", topic)
+			}
+		}()
+	}
+}
@@ -0,0 +1,88 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// SinkSQS sends the events to an AWS SQS queue
+	SinkSQS = "sqs"
+	// SinkSNS publishes the events in an AWS SNS topic
+	SinkSNS = "sns"
+	// SinkPubSub publishes the events in a GCP Pub/Sub topic
+	SinkPubSub = "pubsub"
+	// SinkServiceBus sends the events to an Azure Service Bus queue or topic
+	SinkServiceBus = "servicebus"
+
+	sinkTimeout = 30 * time.Second
+	// topicAttribute is the message attribute with the topic of the event
+	topicAttribute = "topic"
+)
+
+// Sink delivers the events to an external messaging service
+type Sink interface {
+	Send(ctx context.Context, topic string, msg Message) error
+}
+
+// SinkConfig configures the sink of NewSink
+type SinkConfig struct {
+	Backend string
+	// Destination is the SQS queue url, the SNS topic ARN, the Pub/Sub topic as projects/<project>/topics/<topic> or
+	// the Service Bus queue or topic url
+	Destination string
+	// Region is the AWS region of the SQS queue or SNS topic
+	Region string
+	// KeyID is the AWS access key id or the Service Bus shared access key name
+	KeyID string
+	// Key is the AWS secret access key or the Service Bus shared access key
+	Key string
+	// CredentialsFile is the Google service account key file used to publish in Pub/Sub
+	CredentialsFile string
+	// Transport sends the requests to the messaging service. Nil sends them with http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// NewSink returns the sink of the configured backend. An empty backend means there is no sink and returns nil.
+func NewSink(cfg SinkConfig) (Sink, error) {
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case SinkSQS:
+		return NewSQSSink(cfg.Destination, cfg.Region, cfg.KeyID, cfg.Key, cfg.Transport)
+	case SinkSNS:
+		return NewSNSSink(cfg.Destination, cfg.Region, cfg.KeyID, cfg.Key, cfg.Transport)
+	case SinkPubSub:
+		return NewPubSubSink(cfg.Destination, cfg.CredentialsFile, cfg.Transport)
+	case SinkServiceBus:
+		return NewServiceBusSink(cfg.Destination, cfg.KeyID, cfg.Key, cfg.Transport)
+	}
+	return nil, fmt.Errorf("unknown event sink backend <%s>, it must be %s, %s, %s or %s", cfg.Backend, SinkSQS, SinkSNS, SinkPubSub, SinkServiceBus)
+}
+
+// Forward returns a handler that sends the messages of topic to sink. It is meant to be subscribed to the topic.
+func Forward(sink Sink, topic string) EventHandler {
+	return func(ctx context.Context, msg Message) error {
+		if err := sink.Send(ctx, topic, msg); err != nil {
+			return fmt.Errorf("forwarding the %s event: %w", topic, err)
+		}
+		return nil
+	}
+}
+
+// doSinkRequest sends the request and fails on a status other than 2xx
+func doSinkRequest(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package pubsub
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is an httptest server that keeps the last request
+type recorder struct {
+	*httptest.Server
+	req  *http.Request
+	body []byte
+}
+
+func newRecorder(t *testing.T, status int) *recorder {
+	r := &recorder{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.req = req
+		r.body, _ = io.ReadAll(req.Body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func TestSinks(t *testing.T) {
+	ctx := context.Background()
+	msg := Message(`{"credentialsID":["1"],"issuerID":"did:polygonid:polygon:mumbai:2qFBjGm3Ja6sbPL6Wm4SXDCShHkCnNRcV5ebAoCsRp"}`)
+
+	t.Run("sqs", func(t *testing.T) {
+		server := newRecorder(t, http.StatusOK)
+		sink, err := NewSQSSink(server.URL+"/123456789012/issuer-events", "eu-west-1", "AKIDEXAMPLE", "secret", nil)
+		require.NoError(t, err)
+		require.NoError(t, Forward(sink, "createCredentialEvent")(ctx, msg))
+
+		assert.Equal(t, "/123456789012/issuer-events", server.req.URL.Path)
+		form, err := url.ParseQuery(string(server.body))
+		require.NoError(t, err)
+		assert.Equal(t, "SendMessage", form.Get("Action"))
+		assert.Equal(t, string(msg), form.Get("MessageBody"))
+		assert.Equal(t, "createCredentialEvent", form.Get("MessageAttribute.1.Value.StringValue"))
+		assert.Regexp(t, `^AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/\d{8}/eu-west-1/sqs/aws4_request, SignedHeaders=[a-z0-9;-]*content-type;host;x-amz-date, Signature=[0-9a-f]{64}$`, server.req.Header.Get("Authorization"))
+	})
+
+	t.Run("sns", func(t *testing.T) {
+		server := newRecorder(t, http.StatusOK)
+		sink, err := NewSNSSink("arn:aws:sns:eu-west-1:123456789012:issuer-events", "eu-west-1", "AKIDEXAMPLE", "secret", nil)
+		require.NoError(t, err)
+		sink.(*awsSink).endpoint = server.URL
+		require.NoError(t, sink.Send(ctx, "createConnectionEvent", msg))
+
+		form, err := url.ParseQuery(string(server.body))
+		require.NoError(t, err)
+		assert.Equal(t, "Publish", form.Get("Action"))
+		assert.Equal(t, "arn:aws:sns:eu-west-1:123456789012:issuer-events", form.Get("TopicArn"))
+		assert.Equal(t, string(msg), form.Get("Message"))
+		assert.Equal(t, "createConnectionEvent", form.Get("MessageAttributes.entry.1.Value.StringValue"))
+		assert.Contains(t, server.req.Header.Get("Authorization"), "/eu-west-1/sns/aws4_request")
+	})
+
+	t.Run("pubsub", func(t *testing.T) {
+		tokens := 0
+		server := newRecorder(t, http.StatusOK)
+		tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokens++
+			require.NoError(t, r.ParseForm())
+			assert.Len(t, strings.Split(r.Form.Get("assertion"), "."), 3)
+			_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+		}))
+		defer tokenServer.Close()
+
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: mustPKCS8(t, key)})
+		credentials, err := json.Marshal(map[string]string{"type": "service_account", "client_email": "issuer@project.iam.gserviceaccount.com", "private_key": string(keyPEM), "token_uri": tokenServer.URL})
+		require.NoError(t, err)
+		credentialsFile := filepath.Join(t.TempDir(), "credentials.json")
+		require.NoError(t, os.WriteFile(credentialsFile, credentials, 0o600))
+
+		sink, err := NewPubSubSink("projects/project/topics/issuer-events", credentialsFile, nil)
+		require.NoError(t, err)
+		sink.(*pubSubSink).publishURL = server.URL + "/v1/projects/project/topics/issuer-events:publish"
+		require.NoError(t, sink.Send(ctx, "createCredentialEvent", msg))
+		require.NoError(t, sink.Send(ctx, "createCredentialEvent", msg))
+
+		assert.Equal(t, 1, tokens, "the access token is reused")
+		assert.Equal(t, "Bearer token", server.req.Header.Get("Authorization"))
+		var body struct {
+			Messages []struct {
+				Data       string
+				Attributes map[string]string
+			}
+		}
+		require.NoError(t, json.Unmarshal(server.body, &body))
+		require.Len(t, body.Messages, 1)
+		data, err := base64.StdEncoding.DecodeString(body.Messages[0].Data)
+		require.NoError(t, err)
+		assert.Equal(t, msg, Message(data))
+		assert.Equal(t, "createCredentialEvent", body.Messages[0].Attributes["topic"])
+
+		_, err = NewPubSubSink("issuer-events", credentialsFile, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("servicebus", func(t *testing.T) {
+		server := newRecorder(t, http.StatusCreated)
+		sink, err := NewServiceBusSink(server.URL+"/issuer-events", "send", "c2VjcmV0", nil)
+		require.NoError(t, err)
+		require.NoError(t, sink.Send(ctx, "createCredentialEvent", msg))
+
+		assert.Equal(t, "/issuer-events/messages", server.req.URL.Path)
+		assert.Equal(t, msg, Message(server.body))
+		assert.JSONEq(t, `{"Label":"createCredentialEvent"}`, server.req.Header.Get("BrokerProperties"))
+		assert.Regexp(t, `^SharedAccessSignature sr=.+&sig=.+&se=\d+&skn=send$`, server.req.Header.Get("Authorization"))
+	})
+
+	t.Run("failed delivery", func(t *testing.T) {
+		server := newRecorder(t, http.StatusForbidden)
+		sink, err := NewServiceBusSink(server.URL+"/issuer-events", "send", "c2VjcmV0", nil)
+		require.NoError(t, err)
+		assert.Error(t, Forward(sink, "createCredentialEvent")(ctx, msg))
+	})
+}
+
+func TestNewSink(t *testing.T) {
+	sink, err := NewSink(SinkConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, sink)
+
+	_, err = NewSink(SinkConfig{Backend: SinkSQS, Destination: "https://sqs.eu-west-1.amazonaws.com/123456789012/issuer-events"})
+	assert.Error(t, err, "the credentials are required")
+
+	_, err = NewSink(SinkConfig{Backend: SinkServiceBus, Destination: "https://issuer.servicebus.windows.net", KeyID: "send", Key: "key"})
+	assert.Error(t, err, "the queue or topic is required")
+
+	_, err = NewSink(SinkConfig{Backend: "kafka"})
+	assert.Error(t, err)
+}
+
+func mustPKCS8(t *testing.T, key *rsa.PrivateKey) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return der
+}
@@ -2,9 +2,11 @@ package schema
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	core "github.com/iden3/go-iden3-core"
 	jsonSuite "github.com/iden3/go-schema-processor/json"
@@ -16,22 +18,63 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/loader"
 )
 
+// maxParsedSchemas is the number of parsed schemas kept in memory before the cache is reset
+const maxParsedSchemas = 1000
+
 var (
 	ErrLoadSchema   = errors.New("cannot load schema")          // ErrLoadSchema Cannot process schema
 	ErrValidateData = errors.New("error validating claim data") // ErrValidateData Cannot process schema
 	ErrParseClaim   = errors.New("error parsing claim")         // ErrParseClaim Cannot process schema
 )
 
+// W3CCredential is a verifiable credential with its display method. verifiable.W3CCredential has no displayMethod
+// section, so it is kept beside the embedded credential and serialized with it.
+type W3CCredential struct {
+	verifiable.W3CCredential
+	DisplayMethod *domain.DisplayMethod `json:"displayMethod,omitempty"`
+}
+
+var (
+	// The json suite validator and parser are stateless, so the same instances are shared by every issuance.
+	validator processor.Validator = jsonSuite.Validator{}
+	parser    processor.Parser    = jsonSuite.Parser{}
+
+	parsedSchemasMu sync.RWMutex
+	parsedSchemas   = make(map[[sha256.Size]byte]jsonSuite.Schema)
+)
+
 // LoadSchema loads schema from url
 func LoadSchema(ctx context.Context, loader loader.Loader) (jsonSuite.Schema, error) {
-	var schema jsonSuite.Schema
 	schemaBytes, _, err := loader.Load(ctx)
 	if err != nil {
-		return schema, err
+		return jsonSuite.Schema{}, err
+	}
+	return ParseSchema(schemaBytes)
+}
+
+// ParseSchema parses the content of a json schema. Parsed schemas are kept in memory by content, so issuing
+// many credentials of the same type only parses its schema once. The returned schema is shared and must not be modified.
+func ParseSchema(schemaBytes []byte) (jsonSuite.Schema, error) {
+	key := sha256.Sum256(schemaBytes)
+	parsedSchemasMu.RLock()
+	schema, found := parsedSchemas[key]
+	parsedSchemasMu.RUnlock()
+	if found {
+		return schema, nil
+	}
+
+	if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+		return jsonSuite.Schema{}, err
+	}
+
+	parsedSchemasMu.Lock()
+	if len(parsedSchemas) >= maxParsedSchemas {
+		parsedSchemas = make(map[[sha256.Size]byte]jsonSuite.Schema)
 	}
-	err = json.Unmarshal(schemaBytes, &schema)
+	parsedSchemas[key] = schema
+	parsedSchemasMu.Unlock()
 
-	return schema, err
+	return schema, nil
 }
 
 // FromClaimModelToW3CCredential JSON-LD response base on claim
@@ -48,9 +91,8 @@ func FromClaimModelToW3CCredential(claim domain.Claim) (*verifiable.W3CCredentia
 
 	proofs := make(verifiable.CredentialProofs, 0)
 
-	var signatureProof *verifiable.BJJSignatureProof2021
 	if claim.SignatureProof.Status != pgtype.Null {
-		err = claim.SignatureProof.AssignTo(&signatureProof)
+		signatureProof, err := claim.GetSignatureProof()
 		if err != nil {
 			return nil, err
 		}
@@ -72,6 +114,19 @@ func FromClaimModelToW3CCredential(claim domain.Claim) (*verifiable.W3CCredentia
 	return &cred, nil
 }
 
+// FromClaimModelToW3CCredentialWithDisplay is FromClaimModelToW3CCredential including the display method of the credential
+func FromClaimModelToW3CCredentialWithDisplay(claim domain.Claim) (*W3CCredential, error) {
+	cred, err := FromClaimModelToW3CCredential(claim)
+	if err != nil {
+		return nil, err
+	}
+	displayMethod, err := claim.GetDisplayMethod()
+	if err != nil {
+		return nil, err
+	}
+	return &W3CCredential{W3CCredential: *cred, DisplayMethod: displayMethod}, nil
+}
+
 // FromClaimsModelToW3CCredential JSON-LD response base on claim
 func FromClaimsModelToW3CCredential(credentials domain.Credentials) ([]*verifiable.W3CCredential, error) {
 	w3Credentials := make([]*verifiable.W3CCredential, len(credentials))
@@ -87,21 +142,12 @@ func FromClaimsModelToW3CCredential(credentials domain.Credentials) ([]*verifiab
 	return w3Credentials, nil
 }
 
-// Process data and schema and create Index and Value slots
-func Process(ctx context.Context, ld loader.Loader, credentialType string, credential verifiable.W3CCredential, options *processor.CoreClaimOptions) (*core.Claim, error) {
-	var parser processor.Parser
-	var validator processor.Validator
-	pr := &processor.Processor{}
-
-	validator = jsonSuite.Validator{}
-	parser = jsonSuite.Parser{}
-
-	pr = processor.InitProcessorOptions(pr, processor.WithValidator(validator), processor.WithParser(parser), processor.WithSchemaLoader(ld))
-
-	schema, _, err := pr.Load(ctx)
-	if err != nil {
+// Process data and schema and create Index and Value slots. schema is the content of the already loaded json schema
+func Process(ctx context.Context, schema []byte, credentialType string, credential verifiable.W3CCredential, options *processor.CoreClaimOptions) (*core.Claim, error) {
+	if len(schema) == 0 {
 		return nil, ErrLoadSchema
 	}
+	pr := processor.InitProcessorOptions(&processor.Processor{}, processor.WithValidator(validator), processor.WithParser(parser))
 
 	jsonCredential, err := json.Marshal(credential)
 	if err != nil {
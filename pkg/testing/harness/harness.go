@@ -0,0 +1,65 @@
+// Package harness provides the external dependencies of the issuer node for end to end tests without docker:
+// an in-process state contract simulator, a fake reverse hash service and a server with seeded schemas.
+//
+// The state contract is simulated by the sandbox chain instead of a go-ethereum simulated backend because
+// the state contract bytecode, together with its verifier and poseidon libraries, is not distributed with the abi bindings.
+package harness
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iden3/contracts-abi/state/go/abi"
+
+	"github.com/polygonid/sh-id-platform/pkg/blockchain/sandbox"
+)
+
+// ContractAddress is the address the simulated state contract is reachable at. The chain accepts any address,
+// this one is only provided so tests don't need to make one up.
+var ContractAddress = common.HexToAddress("0x134B1BE34911E39A8397ec6289782989729807a4")
+
+// Harness groups the simulated dependencies of the issuer node
+type Harness struct {
+	Chain   *sandbox.Chain
+	RHS     *RHS
+	Schemas *Schemas
+}
+
+// New starts all the simulated dependencies. Close must be called to release them.
+func New(ctx context.Context) (*Harness, error) {
+	chain, err := sandbox.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Harness{
+		Chain:   chain,
+		RHS:     NewRHS(),
+		Schemas: NewSchemas(),
+	}, nil
+}
+
+// Start is like New but fails the test on error and closes the harness when the test finishes
+func Start(t testing.TB) *Harness {
+	t.Helper()
+
+	h, err := New(context.Background())
+	if err != nil {
+		t.Fatalf("starting test harness: %v", err)
+	}
+	t.Cleanup(h.Close)
+
+	return h
+}
+
+// StateContract returns a state contract binding backed by the simulated chain
+func (h *Harness) StateContract() (*abi.State, error) {
+	return h.Chain.State(ContractAddress)
+}
+
+// Close stops the servers started by the harness
+func (h *Harness) Close() {
+	h.RHS.Close()
+	h.Schemas.Close()
+}
@@ -0,0 +1,74 @@
+package harness
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/iden3/go-merkletree-sql/v2"
+	proof "github.com/iden3/merkletree-proof"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/loader"
+)
+
+func TestHarness_Schemas(t *testing.T) {
+	h := Start(t)
+
+	content, _, err := loader.HTTPFactory(h.Schemas.SchemaURL("exampleString")).Load(context.Background())
+	require.NoError(t, err)
+
+	var schema struct {
+		Metadata struct {
+			Uris map[string]string `json:"uris"`
+		} `json:"$metadata"`
+	}
+	require.NoError(t, json.Unmarshal(content, &schema))
+	assert.Equal(t, h.Schemas.ContextURL("exampleString"), schema.Metadata.Uris["jsonLdContext"])
+
+	content, _, err = loader.HTTPFactory(h.Schemas.ContextURL("exampleString")).Load(context.Background())
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(content), "RoleCredential"))
+
+	_, _, err = loader.HTTPFactory(h.Schemas.SchemaURL("missing")).Load(context.Background())
+	assert.Error(t, err)
+}
+
+func TestHarness_RHS(t *testing.T) {
+	ctx := context.Background()
+	h := Start(t)
+
+	hash := func(n int64) *merkletree.Hash {
+		res, err := merkletree.NewHashFromBigInt(big.NewInt(n))
+		require.NoError(t, err)
+		return res
+	}
+
+	cli := proof.HTTPReverseHashCli{URL: h.RHS.URL(), HTTPTimeout: 5 * time.Second}
+	node := proof.Node{Hash: hash(1), Children: []*merkletree.Hash{hash(2), hash(3), hash(4)}}
+	require.NoError(t, cli.SaveNodes(ctx, []proof.Node{node}))
+	assert.Equal(t, 1, h.RHS.Len())
+
+	got, err := cli.GetNode(ctx, hash(1))
+	require.NoError(t, err)
+	assert.Equal(t, node.Children, got.Children)
+
+	_, err = cli.GetNode(ctx, hash(5))
+	assert.Error(t, err)
+}
+
+func TestHarness_StateContract(t *testing.T) {
+	h := Start(t)
+
+	contract, err := h.StateContract()
+	require.NoError(t, err)
+
+	root, err := contract.GetGISTRoot(&bind.CallOpts{Context: context.Background()})
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(0), root)
+}
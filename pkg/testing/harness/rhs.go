@@ -0,0 +1,103 @@
+package harness
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/iden3/go-merkletree-sql/v2"
+	proof "github.com/iden3/merkletree-proof"
+)
+
+// RHS is an in-memory reverse hash service speaking the same HTTP protocol as the real one
+type RHS struct {
+	server *httptest.Server
+	mu     sync.RWMutex
+	nodes  map[merkletree.Hash]proof.Node
+}
+
+// NewRHS starts a fake reverse hash service listening on a random local port
+func NewRHS() *RHS {
+	rhs := &RHS{nodes: make(map[merkletree.Hash]proof.Node)}
+
+	mux := chi.NewRouter()
+	mux.Post("/node", rhs.saveNodes)
+	mux.Get("/node/{hash}", rhs.getNode)
+	rhs.server = httptest.NewServer(mux)
+
+	return rhs
+}
+
+// URL returns the base url of the service, as expected by the ISSUER_REVERSE_HASH_SERVICE_URL setting
+func (r *RHS) URL() string {
+	return r.server.URL
+}
+
+// SaveNodes stores the nodes as if they had been pushed through the HTTP API
+func (r *RHS) SaveNodes(nodes ...proof.Node) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, node := range nodes {
+		r.nodes[*node.Hash] = node
+	}
+}
+
+// Len returns the number of stored nodes
+func (r *RHS) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.nodes)
+}
+
+// Close stops the service
+func (r *RHS) Close() {
+	r.server.Close()
+}
+
+func (r *RHS) saveNodes(w http.ResponseWriter, req *http.Request) {
+	var nodes []proof.Node
+	if err := json.NewDecoder(req.Body).Decode(&nodes); err != nil {
+		writeRHSResponse(w, http.StatusBadRequest, map[string]string{"status": "error", "error": err.Error()})
+		return
+	}
+	for _, node := range nodes {
+		if node.Hash == nil {
+			writeRHSResponse(w, http.StatusBadRequest, map[string]string{"status": "error", "error": "node without hash"})
+			return
+		}
+	}
+
+	r.SaveNodes(nodes...)
+	writeRHSResponse(w, http.StatusOK, map[string]string{"status": "OK"})
+}
+
+func (r *RHS) getNode(w http.ResponseWriter, req *http.Request) {
+	hash, err := merkletree.NewHashFromHex(chi.URLParam(req, "hash"))
+	if err != nil {
+		writeRHSResponse(w, http.StatusBadRequest, map[string]string{"status": "error", "error": err.Error()})
+		return
+	}
+
+	r.mu.RLock()
+	node, ok := r.nodes[*hash]
+	r.mu.RUnlock()
+	if !ok {
+		writeRHSResponse(w, http.StatusNotFound, map[string]string{"status": "not found"})
+		return
+	}
+
+	writeRHSResponse(w, http.StatusOK, struct {
+		Status string     `json:"status"`
+		Node   proof.Node `json:"node"`
+	}{Status: "OK", Node: node})
+}
+
+func writeRHSResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
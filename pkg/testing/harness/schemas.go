@@ -0,0 +1,64 @@
+package harness
+
+import (
+	"bytes"
+	"embed"
+	"net/http"
+	"net/http/httptest"
+	"path"
+)
+
+// fixturesBaseURL is the location the seeded schemas point to in their metadata. It is rewritten to the
+// fixtures server address, so loading a schema never leaves the test process.
+const fixturesBaseURL = "https://raw.githubusercontent.com/0xPolygonID/issuer-node/main/docs/examples/schemas"
+
+//go:embed fixtures/schemas
+var fixtures embed.FS
+
+// Schemas serves the seeded JSON schemas and JSON-LD contexts over HTTP.
+// Schema names are the file names without extension, e.g. exampleString or exampleEmployee.
+type Schemas struct {
+	server *httptest.Server
+}
+
+// NewSchemas starts the fixtures server listening on a random local port
+func NewSchemas() *Schemas {
+	s := &Schemas{}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// URL returns the base url of the fixtures server
+func (s *Schemas) URL() string {
+	return s.server.URL
+}
+
+// SchemaURL returns the url of the JSON schema with the given name
+func (s *Schemas) SchemaURL(name string) string {
+	return s.server.URL + "/json/" + name + ".json"
+}
+
+// ContextURL returns the url of the JSON-LD context with the given name
+func (s *Schemas) ContextURL(name string) string {
+	return s.server.URL + "/json-ld/" + name + ".json-ld"
+}
+
+// Close stops the server
+func (s *Schemas) Close() {
+	s.server.Close()
+}
+
+func (s *Schemas) serve(w http.ResponseWriter, r *http.Request) {
+	content, err := fixtures.ReadFile(path.Join("fixtures/schemas", path.Clean(r.URL.Path)))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if path.Ext(r.URL.Path) == ".json-ld" {
+		w.Header().Set("Content-Type", "application/ld+json")
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	_, _ = w.Write(bytes.ReplaceAll(content, []byte(fixturesBaseURL), []byte(s.server.URL)))
+}
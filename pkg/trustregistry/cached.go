@@ -0,0 +1,39 @@
+package trustregistry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/pkg/cache"
+)
+
+type cachedRegistry struct {
+	registry Registry
+	cache    cache.Cache
+	ttl      time.Duration
+}
+
+// NewCached returns a registry that keeps the answers of registry in c for ttl. The errors are not cached.
+func NewCached(registry Registry, c cache.Cache, ttl time.Duration) Registry {
+	return &cachedRegistry{registry: registry, cache: c, ttl: ttl}
+}
+
+// Accreditation returns the cached answer or asks the registry. CheckedAt is the time of the registry answer.
+func (c *cachedRegistry) Accreditation(ctx context.Context, issuerDID string, credentialType string) (*Accreditation, error) {
+	key := c.key(issuerDID, credentialType)
+	var accreditation Accreditation
+	if c.cache.Get(ctx, key, &accreditation) {
+		return &accreditation, nil
+	}
+	fetched, err := c.registry.Accreditation(ctx, issuerDID, credentialType)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.cache.Set(ctx, key, *fetched, c.ttl)
+	return fetched, nil
+}
+
+func (c *cachedRegistry) key(issuerDID string, credentialType string) string {
+	return fmt.Sprintf("trust-registry-%s-%s", issuerDID, credentialType)
+}
@@ -0,0 +1,118 @@
+package trustregistry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ebsiRevoked is the issuerType of an attribute whose accreditation was revoked by the accreditation organisation
+const ebsiRevoked = "Revoked"
+
+type ebsiIssuer struct {
+	Attributes []ebsiAttribute `json:"attributes"`
+}
+
+type ebsiAttribute struct {
+	Body       string `json:"body"`
+	IssuerType string `json:"issuerType"`
+}
+
+type ebsiAccreditationJWT struct {
+	VC ebsiAccreditationCredential `json:"vc"`
+}
+
+type ebsiAccreditationCredential struct {
+	CredentialSubject struct {
+		ID            string `json:"id"`
+		AccreditedFor []struct {
+			Types []string `json:"types"`
+		} `json:"accreditedFor"`
+	} `json:"credentialSubject"`
+	ValidUntil     *time.Time `json:"validUntil"`
+	ExpirationDate *time.Time `json:"expirationDate"`
+}
+
+type ebsiRegistry struct {
+	client *client
+}
+
+// NewEBSIRegistry returns a registry that reads the issuer from an EBSI trusted issuers registry api, like
+// https://api-pilot.ebsi.eu/trusted-issuers-registry/v4. The issuer is accredited for a type when one of its
+// attributes, not revoked nor expired, is an accreditation for that type.
+// The accreditations are trusted as returned by the registry, their signatures are not verified.
+func NewEBSIRegistry(baseURL string, authorization string, transport http.RoundTripper) (Registry, error) {
+	c, err := newClient(baseURL, authorization, transport)
+	if err != nil {
+		return nil, err
+	}
+	return &ebsiRegistry{client: c}, nil
+}
+
+// Accreditation reads the attributes of the issuer and looks for an accreditation of the credential type
+func (e *ebsiRegistry) Accreditation(ctx context.Context, issuerDID string, credentialType string) (*Accreditation, error) {
+	accreditation := &Accreditation{CheckedAt: time.Now().UTC()}
+	issuer := ebsiIssuer{}
+	found, err := e.client.getJSON(ctx, "/issuers/"+url.PathEscape(issuerDID), &issuer)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return accreditation, nil
+	}
+
+	for _, attribute := range issuer.Attributes {
+		if attribute.IssuerType == ebsiRevoked {
+			continue
+		}
+		vc, err := decodeEBSIAccreditation(attribute.Body)
+		if err != nil {
+			continue
+		}
+		if vc.CredentialSubject.ID != "" && vc.CredentialSubject.ID != issuerDID {
+			continue
+		}
+		if expiration := vc.expiration(); expiration != nil && !expiration.After(accreditation.CheckedAt) {
+			continue
+		}
+		for _, accreditedFor := range vc.CredentialSubject.AccreditedFor {
+			for _, t := range accreditedFor.Types {
+				if t == credentialType {
+					accreditation.Accredited = true
+					return accreditation, nil
+				}
+			}
+		}
+	}
+	return accreditation, nil
+}
+
+func (vc *ebsiAccreditationCredential) expiration() *time.Time {
+	if vc.ValidUntil != nil {
+		return vc.ValidUntil
+	}
+	return vc.ExpirationDate
+}
+
+// decodeEBSIAccreditation reads the verifiable accreditation of a registry attribute, a JWT with the credential
+// in the vc claim
+func decodeEBSIAccreditation(jwt string) (*ebsiAccreditationCredential, error) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("the attribute body is not a jwt")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	token := ebsiAccreditationJWT{}
+	if err := json.Unmarshal(payload, &token); err != nil {
+		return nil, err
+	}
+	return &token.VC, nil
+}
@@ -0,0 +1,90 @@
+package trustregistry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const httpTimeout = 10 * time.Second
+
+type httpRegistry struct {
+	client *client
+}
+
+type httpAccreditation struct {
+	Accredited bool `json:"accredited"`
+}
+
+// NewHTTPRegistry returns a registry that asks GET baseURL/issuers/{did}/accreditations/{type}. The registry answers
+// {"accredited": true|false}, or 404 when it doesn't know the issuer.
+// If authorization is not empty it is sent as the Authorization header. The requests are sent with transport, or with
+// http.DefaultTransport when it is nil.
+func NewHTTPRegistry(baseURL string, authorization string, transport http.RoundTripper) (Registry, error) {
+	c, err := newClient(baseURL, authorization, transport)
+	if err != nil {
+		return nil, err
+	}
+	return &httpRegistry{client: c}, nil
+}
+
+// Accreditation asks the registry about the issuer and the credential type
+func (h *httpRegistry) Accreditation(ctx context.Context, issuerDID string, credentialType string) (*Accreditation, error) {
+	accreditation := &Accreditation{CheckedAt: time.Now().UTC()}
+	body := httpAccreditation{}
+	found, err := h.client.getJSON(ctx, "/issuers/"+url.PathEscape(issuerDID)+"/accreditations/"+url.PathEscape(credentialType), &body)
+	if err != nil {
+		return nil, err
+	}
+	accreditation.Accredited = found && body.Accredited
+	return accreditation, nil
+}
+
+type client struct {
+	baseURL       string
+	authorization string
+	http          *http.Client
+}
+
+func newClient(baseURL string, authorization string, transport http.RoundTripper) (*client, error) {
+	u, err := url.ParseRequestURI(baseURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid trust registry url <%s>", baseURL)
+	}
+	return &client{
+		baseURL:       strings.TrimSuffix(baseURL, "/"),
+		authorization: authorization,
+		http:          &http.Client{Timeout: httpTimeout, Transport: transport},
+	}, nil
+}
+
+// getJSON decodes the response of a GET request to the path in value. It returns false when the registry answers 404.
+func (c *client) getJSON(ctx context.Context, path string, value any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("trust registry: unexpected status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(value); err != nil {
+		return false, fmt.Errorf("trust registry: malformed response: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,42 @@
+package trustregistry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// BackendHTTP asks a registry that answers whether an issuer is accredited for a credential type
+	BackendHTTP = "http"
+	// BackendEBSI reads the accreditations of the issuer from an EBSI trusted issuers registry
+	BackendEBSI = "ebsi"
+)
+
+// Accreditation is the answer of a trust registry about an issuer and a credential type
+type Accreditation struct {
+	Accredited bool
+	CheckedAt  time.Time
+}
+
+// Registry tells whether an issuer is accredited to issue a credential type.
+// An issuer unknown to the registry is not accredited, it is not an error.
+type Registry interface {
+	Accreditation(ctx context.Context, issuerDID string, credentialType string) (*Accreditation, error)
+}
+
+// Open returns the registry of the given backend. url is the base url of the registry api, authorization is sent
+// as the Authorization header of the requests and transport sends them, http.DefaultTransport when it is nil.
+// An empty backend means there is no registry and returns nil.
+func Open(backend string, url string, authorization string, transport http.RoundTripper) (Registry, error) {
+	switch backend {
+	case "":
+		return nil, nil
+	case BackendHTTP:
+		return NewHTTPRegistry(url, authorization, transport)
+	case BackendEBSI:
+		return NewEBSIRegistry(url, authorization, transport)
+	}
+	return nil, fmt.Errorf("unknown trust registry backend <%s>, it must be %s or %s", backend, BackendHTTP, BackendEBSI)
+}
@@ -0,0 +1,142 @@
+package trustregistry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/pkg/cache"
+)
+
+const (
+	accreditedDID = "did:polygonid:polygon:mumbai:2qKZg1vCMwJeMzvVayn9ebUHpnD6QCxTgk6T28THxy"
+	unknownDID    = "did:polygonid:polygon:mumbai:2qFrLQA6R1bfUTxjRnZEN9st77g6ZN2c7Vw1Dq6Vpp"
+)
+
+func TestRegistries(t *testing.T) {
+	ctx := context.Background()
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/issuers/" + accreditedDID + "/accreditations/KYCAgeCredential":
+			_, _ = w.Write([]byte(`{"accredited": true}`))
+		case "/issuers/" + accreditedDID + "/accreditations/KYCCountryOfResidenceCredential":
+			_, _ = w.Write([]byte(`{"accredited": false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer httpServer.Close()
+	httpRegistry, err := Open(BackendHTTP, httpServer.URL, "Bearer token", nil)
+	require.NoError(t, err)
+
+	expired := time.Now().Add(-time.Hour)
+	ebsiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/trusted-issuers-registry/v4/issuers/"+accreditedDID {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		issuer := map[string]any{"did": accreditedDID, "attributes": []map[string]any{
+			{"issuerType": "TI", "body": ebsiAccreditation(t, accreditedDID, "KYCAgeCredential", nil)},
+			{"issuerType": "Revoked", "body": ebsiAccreditation(t, accreditedDID, "KYCCountryOfResidenceCredential", nil)},
+			{"issuerType": "TI", "body": ebsiAccreditation(t, accreditedDID, "ProofOfDegree", &expired)},
+			{"issuerType": "TI", "body": "not a jwt"},
+		}}
+		_ = json.NewEncoder(w).Encode(issuer)
+	}))
+	defer ebsiServer.Close()
+	ebsiRegistry, err := Open(BackendEBSI, ebsiServer.URL+"/trusted-issuers-registry/v4/", "", nil)
+	require.NoError(t, err)
+
+	for name, registry := range map[string]Registry{"http": httpRegistry, "ebsi": ebsiRegistry} {
+		t.Run(name, func(t *testing.T) {
+			accreditation, err := registry.Accreditation(ctx, accreditedDID, "KYCAgeCredential")
+			require.NoError(t, err)
+			assert.True(t, accreditation.Accredited)
+			assert.WithinDuration(t, time.Now(), accreditation.CheckedAt, time.Minute)
+
+			accreditation, err = registry.Accreditation(ctx, accreditedDID, "KYCCountryOfResidenceCredential")
+			require.NoError(t, err)
+			assert.False(t, accreditation.Accredited)
+
+			accreditation, err = registry.Accreditation(ctx, unknownDID, "KYCAgeCredential")
+			require.NoError(t, err)
+			assert.False(t, accreditation.Accredited, "an unknown issuer is not accredited")
+		})
+	}
+
+	t.Run("ebsi expired accreditation", func(t *testing.T) {
+		accreditation, err := ebsiRegistry.Accreditation(ctx, accreditedDID, "ProofOfDegree")
+		require.NoError(t, err)
+		assert.False(t, accreditation.Accredited)
+	})
+
+	t.Run("open", func(t *testing.T) {
+		registry, err := Open("", "", "", nil)
+		assert.NoError(t, err)
+		assert.Nil(t, registry)
+		_, err = Open("ldap", "https://registry.example.com", "", nil)
+		assert.Error(t, err)
+		_, err = Open(BackendHTTP, "registry.example.com", "", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestCached(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	var fail atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"accredited": true}`))
+	}))
+	defer server.Close()
+	httpRegistry, err := NewHTTPRegistry(server.URL, "", nil)
+	require.NoError(t, err)
+	registry := NewCached(httpRegistry, cache.NewMemoryCache(), time.Minute)
+
+	first, err := registry.Accreditation(ctx, accreditedDID, "KYCAgeCredential")
+	require.NoError(t, err)
+	second, err := registry.Accreditation(ctx, accreditedDID, "KYCAgeCredential")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, int32(1), calls.Load())
+
+	fail.Store(true)
+	_, err = registry.Accreditation(ctx, accreditedDID, "ProofOfDegree")
+	assert.Error(t, err)
+	_, err = registry.Accreditation(ctx, accreditedDID, "ProofOfDegree")
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), calls.Load(), "the errors are not cached")
+}
+
+func ebsiAccreditation(t *testing.T, did string, credentialType string, validUntil *time.Time) string {
+	t.Helper()
+	payload, err := json.Marshal(map[string]any{"vc": map[string]any{
+		"type":       []string{"VerifiableCredential", "VerifiableAttestation", "VerifiableAccreditationToAttest"},
+		"validUntil": validUntil,
+		"credentialSubject": map[string]any{
+			"id":            did,
+			"accreditedFor": []map[string]any{{"types": []string{"VerifiableCredential", "VerifiableAttestation", credentialType}}},
+		},
+	}})
+	require.NoError(t, err)
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256","typ":"JWT"}`))
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + ".c2lnbmF0dXJl"
+}